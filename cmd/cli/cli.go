@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+)
+
+// channelID is the fake channel all hot-seat games are played in
+const channelID = "cli"
+
+const helpText = `Commands:
+  new <name>            create a new game with <name> as the creator
+  join <name>           join the current game
+  start                 start the game (as the creator)
+  roll <name>           roll the dice for <name>
+  assign <from> <to>    assign a drink after a critical hit
+  pay <name>            pay one of <name>'s drinks
+  status                show the current game
+  leaderboard           show the session leaderboard
+  newsession            start a new drinking session
+  abandon               abandon the current game
+  help                  show this help
+  quit                  exit`
+
+// cli drives the game service from commands read from in, writing what happens to out
+type cli struct {
+	gameService game.Service
+	in          io.Reader
+	out         io.Writer
+}
+
+// newCLI creates a CLI reading commands from in and writing to out
+func newCLI(gameService game.Service, in io.Reader, out io.Writer) *cli {
+	return &cli{
+		gameService: gameService,
+		in:          in,
+		out:         out,
+	}
+}
+
+// run reads commands until the input ends or quit
+func (c *cli) run(ctx context.Context) error {
+	fmt.Fprintln(c.out, "Ronnied hot-seat mode. Type 'help' for commands.")
+
+	scanner := bufio.NewScanner(c.in)
+	for {
+		fmt.Fprint(c.out, "> ")
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 {
+			continue
+		}
+
+		if args[0] == "quit" || args[0] == "exit" {
+			return nil
+		}
+
+		if err := c.execute(ctx, args[0], args[1:]); err != nil {
+			fmt.Fprintf(c.out, "error: %v\n", err)
+		}
+	}
+}
+
+// execute runs a single command
+func (c *cli) execute(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "help":
+		fmt.Fprintln(c.out, helpText)
+		return nil
+	case "new":
+		if len(args) != 1 {
+			return errors.New("usage: new <name>")
+		}
+		return c.newGame(ctx, args[0])
+	case "join":
+		if len(args) != 1 {
+			return errors.New("usage: join <name>")
+		}
+		return c.join(ctx, args[0])
+	case "start":
+		return c.start(ctx)
+	case "roll":
+		if len(args) != 1 {
+			return errors.New("usage: roll <name>")
+		}
+		return c.roll(ctx, args[0])
+	case "assign":
+		if len(args) != 2 {
+			return errors.New("usage: assign <from> <to>")
+		}
+		return c.assign(ctx, args[0], args[1])
+	case "pay":
+		if len(args) != 1 {
+			return errors.New("usage: pay <name>")
+		}
+		return c.pay(ctx, args[0])
+	case "status":
+		return c.status(ctx)
+	case "leaderboard":
+		return c.leaderboard(ctx)
+	case "newsession":
+		_, err := c.gameService.StartNewSession(ctx, &game.StartNewSessionInput{
+			ChannelID: channelID,
+			CreatorID: "cli",
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, "New session started.")
+		return nil
+	case "abandon":
+		current, err := c.currentGame(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := c.gameService.AbandonGame(ctx, &game.AbandonGameInput{GameID: current.ID}); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.out, "Game abandoned.")
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q (try 'help')", command)
+	}
+}
+
+// newGame creates a game and joins the creator
+func (c *cli) newGame(ctx context.Context, name string) error {
+	output, err := c.gameService.CreateGame(ctx, &game.CreateGameInput{
+		ChannelID:   channelID,
+		CreatorID:   playerID(name),
+		CreatorName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     output.GameID,
+		PlayerID:   playerID(name),
+		PlayerName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%s created a game. Others can 'join <name>', then 'start'.\n", name)
+	return nil
+}
+
+// join adds a player to the current game
+func (c *cli) join(ctx context.Context, name string) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     current.ID,
+		PlayerID:   playerID(name),
+		PlayerName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.AlreadyJoined {
+		fmt.Fprintf(c.out, "%s is already in the game.\n", name)
+		return nil
+	}
+
+	fmt.Fprintf(c.out, "%s joined the game.\n", name)
+	return nil
+}
+
+// start starts the current game as its creator
+func (c *cli) start(ctx context.Context) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.gameService.StartGame(ctx, &game.StartGameInput{
+		GameID:   current.ID,
+		PlayerID: current.CreatorID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !output.Success {
+		return errors.New("game could not be started")
+	}
+
+	fmt.Fprintln(c.out, "Game started! Everyone 'roll <name>'.")
+	return nil
+}
+
+// roll rolls the dice for a player
+func (c *cli) roll(ctx context.Context, name string) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.gameService.RollDice(ctx, &game.RollDiceInput{
+		GameID:   current.ID,
+		PlayerID: playerID(name),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%s rolled a %d. %s\n", name, output.RollValue, output.Result)
+	if output.Details != "" {
+		fmt.Fprintln(c.out, output.Details)
+	}
+
+	if output.IsCriticalHit && len(output.EligiblePlayers) > 0 {
+		names := make([]string, 0, len(output.EligiblePlayers))
+		for _, p := range output.EligiblePlayers {
+			names = append(names, p.PlayerName)
+		}
+		fmt.Fprintf(c.out, "Critical hit! 'assign %s <name>' with one of: %s\n", name, strings.Join(names, ", "))
+	}
+
+	if output.NeedsRollOff {
+		fmt.Fprintln(c.out, "Roll-off needed! Tied players roll again.")
+	}
+
+	return c.status(ctx)
+}
+
+// assign assigns a drink from one player to another
+func (c *cli) assign(ctx context.Context, from, to string) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
+		GameID:       current.ID,
+		FromPlayerID: playerID(from),
+		ToPlayerID:   playerID(to),
+		Reason:       game.DrinkReasonCriticalHit,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%s assigned a drink to %s.\n", from, to)
+	return nil
+}
+
+// pay pays one drink for a player
+func (c *cli) pay(ctx context.Context, name string) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.gameService.PayDrink(ctx, &game.PayDrinkInput{
+		GameID:   current.ID,
+		PlayerID: playerID(name),
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.Credited {
+		fmt.Fprintf(c.out, "%s didn't owe a drink, banked a credit (%d banked).\n", name, output.CreditBalance)
+		return nil
+	}
+
+	fmt.Fprintf(c.out, "%s paid a drink.\n", name)
+	return nil
+}
+
+// status prints the current game and its drinks
+func (c *cli) status(ctx context.Context) error {
+	current, err := c.currentGame(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%s\n", current.Status.DisplayTitle())
+	for _, p := range current.Participants {
+		roll := "-"
+		if p.RollValue > 0 {
+			roll = fmt.Sprintf("%d", p.RollValue)
+		}
+		fmt.Fprintf(c.out, "  %-12s %s\n", p.PlayerName, roll)
+	}
+
+	records, err := c.gameService.GetDrinkRecords(ctx, &game.GetDrinkRecordsInput{
+		GameID: current.ID,
+	})
+	if err != nil || len(records.Records) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(c.out, "Drinks:")
+	for _, record := range records.Records {
+		paid := ""
+		if record.Paid {
+			paid = " (paid)"
+		}
+		fmt.Fprintf(c.out, "  %s -> %s: %s%s\n", record.FromPlayerID, record.ToPlayerID, record.Reason, paid)
+	}
+
+	return nil
+}
+
+// leaderboard prints the session leaderboard
+func (c *cli) leaderboard(ctx context.Context) error {
+	output, err := c.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(output.Entries) == 0 {
+		fmt.Fprintln(c.out, "No drinks yet this session.")
+		return nil
+	}
+
+	for i, entry := range output.Entries {
+		fmt.Fprintf(c.out, "%d. %-12s %d drinks (%d paid)\n", i+1, entry.PlayerName, entry.DrinkCount, entry.PaidCount)
+	}
+
+	return nil
+}
+
+// currentGame returns the game in the CLI channel
+func (c *cli) currentGame(ctx context.Context) (*models.Game, error) {
+	output, err := c.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return nil, errors.New("no game yet, create one with 'new <name>'")
+		}
+		return nil, err
+	}
+
+	return output.Game, nil
+}
+
+// playerID derives a stable player ID from a hot-seat name
+func playerID(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CLITestSuite struct {
+	suite.Suite
+	cleanup        func()
+	mockCtrl       *gomock.Controller
+	mockDiceRoller *diceMocks.MockRoller
+	gameService    game.Service
+	ctx            context.Context
+}
+
+func (s *CLITestSuite) SetupTest() {
+	redisClient, cleanup, err := newRedisClient("memory", "")
+	s.Require().NoError(err)
+	s.cleanup = cleanup
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: redisClient})
+	s.Require().NoError(err)
+
+	players, err := player.NewRedis(&player.Config{RedisClient: redisClient})
+	s.Require().NoError(err)
+
+	ledger, err := drink_ledger.NewRedis(&drink_ledger.Config{RedisClient: redisClient})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockDiceRoller = diceMocks.NewMockRoller(s.mockCtrl)
+
+	s.gameService, err = game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+	s.Require().NoError(err)
+
+	s.ctx = context.Background()
+}
+
+func (s *CLITestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.cleanup()
+}
+
+func TestCLITestSuite(t *testing.T) {
+	suite.Run(t, new(CLITestSuite))
+}
+
+// play runs the commands, one a line, and returns everything written
+func (s *CLITestSuite) play(commands ...string) string {
+	var out bytes.Buffer
+	err := newCLI(s.gameService, strings.NewReader(strings.Join(commands, "\n")), &out).run(s.ctx)
+	s.Require().NoError(err)
+	return out.String()
+}
+
+func (s *CLITestSuite) TestFullGame() {
+	gomock.InOrder(
+		s.mockDiceRoller.EXPECT().Roll(6).Return(6),
+		s.mockDiceRoller.EXPECT().Roll(6).Return(3),
+	)
+
+	out := s.play(
+		"new Alice",
+		"join Bob",
+		"start",
+		"roll Alice",
+		"roll Bob",
+		"assign Alice Bob",
+		"pay Bob",
+		"status",
+		"leaderboard",
+	)
+
+	s.Contains(out, "Ronnied hot-seat mode.")
+	s.Contains(out, "Alice created a game.")
+	s.Contains(out, "Bob joined the game.")
+	s.Contains(out, "Game started!")
+	s.Contains(out, "Alice rolled a 6.")
+	s.Contains(out, "Critical hit! 'assign Alice <name>' with one of: Bob")
+	s.Contains(out, "Bob rolled a 3.")
+	s.Contains(out, "Alice assigned a drink to Bob.")
+	s.Contains(out, "Bob paid a drink.")
+
+	// Bob drinks for his low roll and Alice's critical hit, and paid one of them
+	s.Contains(out, "-> bob: lowest_roll")
+	s.Contains(out, "alice -> bob: critical_hit")
+	s.Equal(1, strings.Count(out, "(paid)"))
+	s.Contains(out, "2 drinks (1 paid)")
+	s.NotContains(out, "error:")
+}
+
+func (s *CLITestSuite) TestErrorsKeepTheLoopGoing() {
+	out := s.play(
+		"roll Alice",
+		"dance",
+		"new",
+		"new Alice",
+	)
+
+	s.Contains(out, "error: no game yet, create one with 'new <name>'")
+	s.Contains(out, `error: unknown command "dance" (try 'help')`)
+	s.Contains(out, "error: usage: new <name>")
+	s.Contains(out, "Alice created a game.")
+}
+
+func (s *CLITestSuite) TestQuit() {
+	out := s.play(
+		"quit",
+		"new Alice",
+	)
+
+	s.NotContains(out, "Alice created a game.")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	backend := flag.String("backend", "memory", "storage backend: memory or redis")
+	redisAddr := flag.String("redis-addr", "localhost:6379", "Redis address when using the redis backend")
+	seed := flag.Int64("seed", 0, "dice seed for reproducible games (0 for random)")
+	flag.Parse()
+
+	redisClient, cleanup, err := newRedisClient(*backend, *redisAddr)
+	if err != nil {
+		log.Fatalf("Failed to set up %s backend: %v", *backend, err)
+	}
+	defer cleanup()
+
+	gameSvc, err := newGameService(redisClient, *seed)
+	if err != nil {
+		log.Fatalf("Failed to create game service: %v", err)
+	}
+
+	if err := newCLI(gameSvc, os.Stdin, os.Stdout).run(context.Background()); err != nil {
+		log.Fatalf("Failed to read commands: %v", err)
+	}
+}
+
+// newRedisClient connects to Redis or starts an in-memory server
+func newRedisClient(backend, redisAddr string) (*redis.Client, func(), error) {
+	switch backend {
+	case "memory":
+		server, err := miniredis.Run()
+		if err != nil {
+			return nil, nil, err
+		}
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		return client, func() {
+			client.Close()
+			server.Close()
+		}, nil
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: redisAddr})
+		if err := client.Ping(context.Background()).Err(); err != nil {
+			return nil, nil, err
+		}
+		return client, func() { client.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown backend %q", backend)
+	}
+}
+
+// newGameService wires the repositories and game service on top of Redis
+func newGameService(redisClient *redis.Client, seed int64) (game.Service, error) {
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := player.NewRedis(&player.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := drink_ledger.NewRedis(&drink_ledger.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	return game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      dice.New(&dice.Config{Seed: seed}),
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// maxSteps guards against a game that never completes
+const maxSteps = 100
+
+// RuleSet holds the rule parameters being evaluated
+type RuleSet struct {
+	DiceSides          int
+	CriticalHitValues  []int
+	CriticalFailValues []int
+}
+
+// String returns a short description of the rule set
+func (r RuleSet) String() string {
+	return fmt.Sprintf("d%d, hit=%s, fail=%s", r.DiceSides, joinInts(r.CriticalHitValues), joinInts(r.CriticalFailValues))
+}
+
+// Stats holds the aggregated results for one player count
+type Stats struct {
+	Players        int
+	Games          int
+	Incomplete     int
+	RollOffGames   int
+	TotalDrinks    int
+	DrinksBySeat   []int
+	DrinksPerGame  map[int]int
+	DrinksByReason map[models.DrinkReason]int
+}
+
+// simulator plays games against a real game service backed by in-memory Redis
+type simulator struct {
+	gameService game.Service
+	random      *rand.Rand
+}
+
+func main() {
+	games := flag.Int("games", 1000, "number of games to simulate per player count")
+	playerCounts := flag.String("players", "2,4,6,8", "comma separated player counts to simulate")
+	diceSides := flag.Int("sides", 6, "number of sides on the dice")
+	criticalHit := flag.String("crit-hit", "6", "comma separated roll values that let a player assign a drink")
+	criticalFail := flag.String("crit-fail", "1", "comma separated roll values that make a player drink")
+	seed := flag.Int64("seed", 0, "seed for dice and drink assignment (0 for random)")
+	verbose := flag.Bool("verbose", false, "show game service logs")
+	flag.Parse()
+
+	// The game service logs every step, keep the report readable by default
+	logger := log.New(os.Stderr, "", 0)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+		logger.SetOutput(io.Discard)
+	}
+
+	counts, err := parsePlayerCounts(*playerCounts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -players: %v\n", err)
+		os.Exit(2)
+	}
+
+	criticalHitValues, err := models.ParseRollValues(*criticalHit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -crit-hit: %v\n", err)
+		os.Exit(2)
+	}
+
+	criticalFailValues, err := models.ParseRollValues(*criticalFail)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -crit-fail: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	rules := RuleSet{
+		DiceSides:          *diceSides,
+		CriticalHitValues:  criticalHitValues,
+		CriticalFailValues: criticalFailValues,
+	}
+
+	fmt.Printf("Simulating %d games per player count with rules %s (seed %d)\n\n", *games, rules, *seed)
+
+	for _, count := range counts {
+		sim, cleanup, err := newSimulator(rules, *seed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create simulator: %v\n", err)
+			os.Exit(1)
+		}
+
+		stats := sim.run(context.Background(), logger, count, *games)
+		cleanup()
+
+		printStats(stats)
+	}
+}
+
+// newSimulator wires a game service on top of an in-memory Redis server
+func newSimulator(rules RuleSet, seed int64) (*simulator, func(), error) {
+	server, err := miniredis.Run()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	cleanup := func() {
+		redisClient.Close()
+		server.Close()
+	}
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: redisClient})
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	players, err := player.NewRedis(&player.Config{RedisClient: redisClient})
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	ledger, err := drink_ledger.NewRedis(&drink_ledger.Config{RedisClient: redisClient})
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:           games,
+		PlayerRepo:         players,
+		DrinkLedgerRepo:    ledger,
+		DiceRoller:         dice.New(&dice.Config{Seed: seed}),
+		UUIDGenerator:      uuid.New(),
+		Clock:              clock.New(),
+		DiceSides:          rules.DiceSides,
+		CriticalHitValues:  rules.CriticalHitValues,
+		CriticalFailValues: rules.CriticalFailValues,
+	})
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return &simulator{
+		gameService: gameSvc,
+		random:      rand.New(rand.NewSource(seed)),
+	}, cleanup, nil
+}
+
+// run simulates a number of games with a fixed player count
+func (s *simulator) run(ctx context.Context, logger *log.Logger, playerCount, games int) *Stats {
+	stats := &Stats{
+		Players:        playerCount,
+		DrinksBySeat:   make([]int, playerCount),
+		DrinksPerGame:  make(map[int]int),
+		DrinksByReason: make(map[models.DrinkReason]int),
+	}
+
+	for i := 0; i < games; i++ {
+		if err := s.playGame(ctx, fmt.Sprintf("sim-%d-%d", playerCount, i), playerCount, stats); err != nil {
+			logger.Printf("Game %d failed: %v", i, err)
+			stats.Incomplete++
+			continue
+		}
+		stats.Games++
+	}
+
+	return stats
+}
+
+// playGame plays a single game to completion and records its drinks
+func (s *simulator) playGame(ctx context.Context, channelID string, playerCount int, stats *Stats) error {
+	createOutput, err := s.gameService.CreateGame(ctx, &game.CreateGameInput{
+		ChannelID:   channelID,
+		CreatorID:   seatID(0),
+		CreatorName: seatID(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	for seat := 1; seat < playerCount; seat++ {
+		_, err := s.gameService.JoinGame(ctx, &game.JoinGameInput{
+			GameID:     createOutput.GameID,
+			PlayerID:   seatID(seat),
+			PlayerName: seatID(seat),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.gameService.StartGame(ctx, &game.StartGameInput{
+		GameID:   createOutput.GameID,
+		PlayerID: seatID(0),
+	})
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[string]bool)
+	if err := s.playRound(ctx, createOutput.GameID, visited); err != nil {
+		return err
+	}
+
+	if len(visited) > 1 {
+		stats.RollOffGames++
+	}
+
+	drinks := 0
+	for gameID := range visited {
+		records, err := s.gameService.GetDrinkRecords(ctx, &game.GetDrinkRecordsInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records.Records {
+			seat, err := seatIndex(record.ToPlayerID)
+			if err != nil {
+				return err
+			}
+			stats.DrinksBySeat[seat]++
+			stats.DrinksByReason[record.Reason]++
+			drinks++
+		}
+	}
+
+	stats.TotalDrinks += drinks
+	stats.DrinksPerGame[drinks]++
+
+	return nil
+}
+
+// playRound rolls for everyone in a game, assigns drinks and follows roll-offs
+func (s *simulator) playRound(ctx context.Context, gameID string, visited map[string]bool) error {
+	visited[gameID] = true
+
+	for step := 0; step < maxSteps; step++ {
+		gameOutput, err := s.gameService.GetGame(ctx, &game.GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			return err
+		}
+
+		current := gameOutput.Game
+		if current.Status.IsCompleted() {
+			return nil
+		}
+
+		progressed := false
+		for _, p := range current.Participants {
+			if p.RollTime == nil {
+				if _, err := s.gameService.RollDice(ctx, &game.RollDiceInput{
+					GameID:   gameID,
+					PlayerID: p.PlayerID,
+				}); err != nil {
+					return err
+				}
+				progressed = true
+			}
+		}
+
+		// Refresh to pick up assignment status and roll-offs
+		gameOutput, err = s.gameService.GetGame(ctx, &game.GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			return err
+		}
+		current = gameOutput.Game
+
+		for _, p := range current.Participants {
+			if p.Status != models.ParticipantStatusNeedsToAssign {
+				continue
+			}
+
+			if _, err := s.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
+				GameID:       gameID,
+				FromPlayerID: p.PlayerID,
+				ToPlayerID:   s.randomOpponent(current, p.PlayerID),
+				Reason:       game.DrinkReasonCriticalHit,
+			}); err != nil {
+				return err
+			}
+			progressed = true
+		}
+
+		for _, rollOffID := range []string{current.HighestRollOffGameID, current.LowestRollOffGameID, current.RollOffGameID} {
+			if rollOffID == "" || visited[rollOffID] {
+				continue
+			}
+			if err := s.playRound(ctx, rollOffID, visited); err != nil {
+				return err
+			}
+			progressed = true
+		}
+
+		if !progressed {
+			return fmt.Errorf("game %s stalled in status %s", gameID, current.Status)
+		}
+	}
+
+	return fmt.Errorf("game %s did not complete after %d steps", gameID, maxSteps)
+}
+
+// randomOpponent picks a random participant other than the given player
+func (s *simulator) randomOpponent(current *models.Game, playerID string) string {
+	var opponents []string
+	for _, p := range current.Participants {
+		if p.PlayerID != playerID {
+			opponents = append(opponents, p.PlayerID)
+		}
+	}
+
+	if len(opponents) == 0 {
+		return playerID
+	}
+
+	return opponents[s.random.Intn(len(opponents))]
+}
+
+// printStats prints the drink distribution for one player count
+func printStats(stats *Stats) {
+	fmt.Printf("=== %d players: %d games", stats.Players, stats.Games)
+	if stats.Incomplete > 0 {
+		fmt.Printf(" (%d did not complete, run with -verbose for details)", stats.Incomplete)
+	}
+	fmt.Println(" ===")
+
+	if stats.Games == 0 {
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("Drinks per game: %.2f   Games with roll-offs: %.1f%%\n",
+		float64(stats.TotalDrinks)/float64(stats.Games),
+		100*float64(stats.RollOffGames)/float64(stats.Games))
+
+	var reasons []string
+	for reason, count := range stats.DrinksByReason {
+		reasons = append(reasons, fmt.Sprintf("%s=%d", reason, count))
+	}
+	sort.Strings(reasons)
+	fmt.Printf("Drinks by reason: %s\n\n", strings.Join(reasons, " "))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Seat\tDrinks\tShare\tPer game\t")
+
+	expectedShare := 1 / float64(stats.Players)
+	var variance float64
+	for seat, drinks := range stats.DrinksBySeat {
+		share := 0.0
+		if stats.TotalDrinks > 0 {
+			share = float64(drinks) / float64(stats.TotalDrinks)
+		}
+		variance += (share - expectedShare) * (share - expectedShare)
+
+		fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%.2f\t\n", seatID(seat), drinks, 100*share, float64(drinks)/float64(stats.Games))
+	}
+	w.Flush()
+
+	fmt.Printf("\nShare deviation from even split: %.2f%% (lower is fairer)\n", 100*math.Sqrt(variance/float64(stats.Players)))
+
+	fmt.Println("Drinks per game histogram:")
+	var totals []int
+	for total := range stats.DrinksPerGame {
+		totals = append(totals, total)
+	}
+	sort.Ints(totals)
+	for _, total := range totals {
+		count := stats.DrinksPerGame[total]
+		fmt.Printf("  %2d: %6d %s\n", total, count, strings.Repeat("#", int(math.Ceil(50*float64(count)/float64(stats.Games)))))
+	}
+	fmt.Println()
+}
+
+// parsePlayerCounts parses a comma separated list of player counts
+func parsePlayerCounts(value string) ([]int, error) {
+	var counts []int
+	for _, part := range strings.Split(value, ",") {
+		count, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if count < 2 {
+			return nil, fmt.Errorf("player count must be at least 2, got %d", count)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+// joinInts formats values as a comma separated list
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// seatID returns the player ID used for a seat
+func seatID(seat int) string {
+	return fmt.Sprintf("P%d", seat+1)
+}
+
+// seatIndex returns the seat for a player ID created by seatID
+func seatIndex(playerID string) (int, error) {
+	seat, err := strconv.Atoi(strings.TrimPrefix(playerID, "P"))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected player ID %q", playerID)
+	}
+	return seat - 1, nil
+}
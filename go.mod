@@ -7,11 +7,14 @@ toolchain go1.24.0
 require (
 	github.com/alicebob/miniredis/v2 v2.31.0
 	github.com/bwmarrin/discordgo v0.27.1
+	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
 	github.com/google/uuid v1.3.1
 	github.com/joho/godotenv v1.5.1
 	github.com/redis/go-redis/v9 v9.2.1
+	github.com/slack-go/slack v0.12.3
 	github.com/stretchr/testify v1.9.0
 	go.uber.org/mock v0.5.0
+	golang.org/x/image v0.18.0
 )
 
 require (
@@ -0,0 +1,77 @@
+// Package analytics writes anonymized rows for the games played to a sink, so play patterns can be analysed
+// offline without scraping logs.
+package analytics
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Row types
+const (
+	RowTypeGameCreated   = "game_created"
+	RowTypeRoll          = "roll"
+	RowTypeDrinkAssigned = "drink_assigned"
+)
+
+// Row is one anonymized event. IDs are replaced by keyed hashes so rows can be joined without telling who
+// played, and names are left out.
+type Row struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Guild   string `json:"guild,omitempty"`
+	Game    string `json:"game,omitempty"`
+	Session string `json:"session,omitempty"`
+
+	// Player is the game's creator, the roller or the drink's recipient
+	Player string `json:"player,omitempty"`
+
+	// FromPlayer is who gave the drink, empty for drinks the game gave
+	FromPlayer string `json:"from_player,omitempty"`
+
+	// Roll rows
+	RollValue    int  `json:"roll_value,omitempty"`
+	CriticalHit  bool `json:"critical_hit,omitempty"`
+	CriticalFail bool `json:"critical_fail,omitempty"`
+	RollOff      bool `json:"roll_off,omitempty"`
+	Reroll       bool `json:"reroll,omitempty"`
+
+	// Drink rows
+	Reason string `json:"reason,omitempty"`
+	Social bool   `json:"social,omitempty"`
+
+	// Game rows
+	Countdown bool     `json:"countdown,omitempty"`
+	Private   bool     `json:"private,omitempty"`
+	LastCall  bool     `json:"last_call,omitempty"`
+	Events    []string `json:"events,omitempty"`
+}
+
+// Sink is where rows are written
+type Sink interface {
+	// Write writes a single row
+	Write(ctx context.Context, row *Row) error
+
+	// Close releases anything the sink holds open
+	Close() error
+}
+
+// anonymizer replaces IDs with keyed hashes
+type anonymizer struct {
+	salt []byte
+}
+
+// hash returns the ID's keyed hash, empty for an empty ID
+func (a *anonymizer) hash(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,63 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileSinkConfig holds configuration for the file sink
+type FileSinkConfig struct {
+	// Path of the file rows are appended to, it's created if it doesn't exist
+	Path string
+}
+
+// fileSink appends rows to a file as JSON lines
+type fileSink struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewFileSink creates a sink that appends rows to a file, one JSON object per line
+func NewFileSink(cfg *FileSinkConfig) (Sink, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.Path == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+
+	file, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics file: %w", err)
+	}
+
+	return &fileSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// Write appends the row to the file
+func (s *fileSink) Write(ctx context.Context, row *Row) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.encoder.Encode(row); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultHTTPTimeout is how long the HTTP sink waits for the endpoint by default
+const defaultHTTPTimeout = 5 * time.Second
+
+// HTTPSinkConfig holds configuration for the HTTP sink
+type HTTPSinkConfig struct {
+	// URL rows are POSTed to
+	URL string
+
+	// Token is sent as a bearer token if set (optional)
+	Token string
+
+	// HTTPClient used to send rows (optional, defaults to a client with a 5 second timeout)
+	HTTPClient *http.Client
+}
+
+// httpSink POSTs each row to an endpoint as JSON
+type httpSink struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPSink creates a sink that POSTs each row to an external endpoint
+func NewHTTPSink(cfg *HTTPSinkConfig) (Sink, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("url must be an http(s) URL")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	return &httpSink{
+		url:        cfg.URL,
+		token:      cfg.Token,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Write POSTs the row to the endpoint
+func (s *httpSink) Write(ctx context.Context, row *Row) error {
+	body, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("failed to marshal row: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ronnied-analytics")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Close does nothing, rows aren't buffered
+func (s *httpSink) Close() error {
+	return nil
+}
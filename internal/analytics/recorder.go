@@ -0,0 +1,165 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+)
+
+// defaultQueueSize is how many rows can wait to be written by default
+const defaultQueueSize = 1000
+
+// RecorderConfig holds configuration for the recorder
+type RecorderConfig struct {
+	// Sink the rows are written to
+	Sink Sink
+
+	// Salt keys the hashes that replace IDs, rows can only be joined across restarts while it stays the same
+	Salt string
+
+	// QueueSize is how many rows can wait to be written before new ones are dropped (optional, defaults to 1000)
+	QueueSize int
+}
+
+// Recorder turns domain events into anonymized rows and writes them to a sink in the background, so a slow
+// sink never holds up a game
+type Recorder struct {
+	sink       Sink
+	anonymizer *anonymizer
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Row
+	done   chan struct{}
+}
+
+// NewRecorder creates a recorder and starts writing rows to its sink
+func NewRecorder(cfg *RecorderConfig) (*Recorder, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.Sink == nil {
+		return nil, errors.New("sink cannot be nil")
+	}
+
+	if cfg.Salt == "" {
+		return nil, errors.New("salt cannot be empty")
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	r := &Recorder{
+		sink:       cfg.Sink,
+		anonymizer: &anonymizer{salt: []byte(cfg.Salt)},
+		queue:      make(chan *Row, queueSize),
+		done:       make(chan struct{}),
+	}
+	go r.run()
+
+	return r, nil
+}
+
+// Subscribe records the bus's game created, roll and drink assigned events
+func (r *Recorder) Subscribe(bus events.Bus) {
+	bus.Subscribe(events.TypeGameCreated, r.Record)
+	bus.Subscribe(events.TypeDiceRolled, r.Record)
+	bus.Subscribe(events.TypeDrinkAssigned, r.Record)
+}
+
+// Record queues the event's row to be written, events that don't make a row are ignored
+func (r *Recorder) Record(ctx context.Context, event *events.Event) {
+	row := r.row(event)
+	if row == nil {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	select {
+	case r.queue <- row:
+	default:
+		log.Printf("Analytics queue is full, dropping %s row", row.Type)
+	}
+}
+
+// Close writes the rows still queued and closes the sink
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.mu.Unlock()
+
+	<-r.done
+	return r.sink.Close()
+}
+
+// run writes queued rows until the recorder is closed
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	for row := range r.queue {
+		if err := r.sink.Write(context.Background(), row); err != nil {
+			log.Printf("Error writing %s analytics row: %v", row.Type, err)
+		}
+	}
+}
+
+// row turns an event into an anonymized row, nil for events that aren't recorded
+func (r *Recorder) row(event *events.Event) *Row {
+	if event == nil {
+		return nil
+	}
+
+	row := &Row{
+		Timestamp: event.Timestamp,
+		Guild:     r.anonymizer.hash(event.GuildID),
+		Game:      r.anonymizer.hash(event.GameID),
+		Session:   r.anonymizer.hash(event.SessionID),
+	}
+
+	switch payload := event.Payload.(type) {
+	case *events.GameCreatedPayload:
+		row.Type = RowTypeGameCreated
+		row.Player = r.anonymizer.hash(payload.CreatorID)
+		row.Countdown = payload.Countdown
+		row.Private = payload.Private
+		row.LastCall = payload.LastCall
+		row.Events = payload.Events
+	case *events.DiceRolledPayload:
+		row.Type = RowTypeRoll
+		// Roll-offs count toward the game they were started from
+		row.Game = r.anonymizer.hash(payload.RootGameID)
+		row.Player = r.anonymizer.hash(payload.PlayerID)
+		row.RollValue = payload.RollValue
+		row.CriticalHit = payload.CriticalHit
+		row.CriticalFail = payload.CriticalFail
+		row.RollOff = payload.RollOff
+		row.Reroll = payload.Reroll
+	case *events.DrinkAssignedPayload:
+		row.Type = RowTypeDrinkAssigned
+		row.Player = r.anonymizer.hash(payload.ToPlayerID)
+		row.FromPlayer = r.anonymizer.hash(payload.FromPlayerID)
+		row.Reason = payload.Reason
+		row.Social = payload.Social
+	default:
+		return nil
+	}
+
+	return row
+}
@@ -0,0 +1,140 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/stretchr/testify/suite"
+)
+
+// memorySink keeps the rows written to it
+type memorySink struct {
+	mu     sync.Mutex
+	rows   []*Row
+	closed bool
+}
+
+func (s *memorySink) Write(ctx context.Context, row *Row) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.closed = true
+	return nil
+}
+
+type RecorderTestSuite struct {
+	suite.Suite
+	sink     *memorySink
+	bus      events.Bus
+	recorder *Recorder
+	ctx      context.Context
+	now      time.Time
+}
+
+func (s *RecorderTestSuite) SetupTest() {
+	s.sink = &memorySink{}
+	s.bus = events.NewBus()
+	s.ctx = context.Background()
+	s.now = time.Date(2025, 4, 18, 21, 0, 0, 0, time.UTC)
+
+	recorder, err := NewRecorder(&RecorderConfig{
+		Sink: s.sink,
+		Salt: "pepper",
+	})
+	s.Require().NoError(err)
+	s.recorder = recorder
+	s.recorder.Subscribe(s.bus)
+}
+
+func TestRecorderTestSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+func (s *RecorderTestSuite) TestRecordsAnonymizedRows() {
+	s.bus.Publish(s.ctx, &events.Event{
+		Type:      events.TypeGameCreated,
+		GuildID:   "guild1",
+		GameID:    "game1",
+		Timestamp: s.now,
+		Payload:   &events.GameCreatedPayload{CreatorID: "alice", CreatorName: "Alice", Countdown: true},
+	})
+	s.bus.Publish(s.ctx, &events.Event{
+		Type:      events.TypeDiceRolled,
+		GuildID:   "guild1",
+		GameID:    "rolloff1",
+		Timestamp: s.now,
+		Payload:   &events.DiceRolledPayload{PlayerID: "alice", PlayerName: "Alice", RollValue: 6, CriticalHit: true, RollOff: true, RootGameID: "game1"},
+	})
+	s.bus.Publish(s.ctx, &events.Event{
+		Type:      events.TypeDrinkAssigned,
+		GuildID:   "guild1",
+		GameID:    "game1",
+		SessionID: "session1",
+		Timestamp: s.now,
+		Payload:   &events.DrinkAssignedPayload{FromPlayerID: "alice", ToPlayerID: "bob", Reason: "critical_hit"},
+	})
+
+	// Events that aren't analysed are ignored
+	s.bus.Publish(s.ctx, &events.Event{Type: events.TypeMercyRule, Payload: &events.MercyRulePayload{PlayerID: "bob"}})
+
+	s.Require().NoError(s.recorder.Close())
+	s.True(s.sink.closed)
+	s.Require().Len(s.sink.rows, 3)
+
+	created, roll, drink := s.sink.rows[0], s.sink.rows[1], s.sink.rows[2]
+	s.Equal(RowTypeGameCreated, created.Type)
+	s.True(created.Countdown)
+	s.Equal(s.now, created.Timestamp)
+
+	s.Equal(RowTypeRoll, roll.Type)
+	s.Equal(6, roll.RollValue)
+	s.True(roll.CriticalHit)
+	s.Equal(created.Game, roll.Game, "roll-offs count toward the game they were started from")
+	s.Equal(created.Player, roll.Player)
+
+	s.Equal(RowTypeDrinkAssigned, drink.Type)
+	s.Equal("critical_hit", drink.Reason)
+	s.Equal(roll.Player, drink.FromPlayer)
+	s.NotEqual(drink.FromPlayer, drink.Player)
+	s.NotEmpty(drink.Session)
+
+	// Nothing says who played
+	for _, row := range s.sink.rows {
+		data, err := json.Marshal(row)
+		s.Require().NoError(err)
+		for _, secret := range []string{"alice", "Alice", "bob", "guild1", "game1", "session1"} {
+			s.NotContains(string(data), `"`+secret+`"`)
+		}
+	}
+}
+
+func (s *RecorderTestSuite) TestHashesDependOnTheSalt() {
+	other := &anonymizer{salt: []byte("salt")}
+	s.NotEqual(s.recorder.anonymizer.hash("alice"), other.hash("alice"))
+	s.Equal(s.recorder.anonymizer.hash("alice"), s.recorder.anonymizer.hash("alice"))
+	s.Empty(other.hash(""))
+}
+
+func (s *RecorderTestSuite) TestRecordAfterClose() {
+	s.Require().NoError(s.recorder.Close())
+
+	s.bus.Publish(s.ctx, &events.Event{
+		Type:    events.TypeGameCreated,
+		Payload: &events.GameCreatedPayload{CreatorID: "alice"},
+	})
+	s.Empty(s.sink.rows)
+	s.NoError(s.recorder.Close())
+}
+
+func (s *RecorderTestSuite) TestNewRecorderRequiresASalt() {
+	_, err := NewRecorder(&RecorderConfig{Sink: s.sink})
+	s.Error(err)
+}
@@ -0,0 +1,90 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Defaults for the Redis stream sink
+const (
+	defaultStream       = "analytics"
+	defaultStreamMaxLen = 100000
+)
+
+// RedisStreamSinkConfig holds configuration for the Redis stream sink
+type RedisStreamSinkConfig struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+
+	// Stream is the stream rows are added to (optional, defaults to "analytics")
+	Stream string
+
+	// MaxLen is roughly how many rows the stream keeps, older ones are trimmed (optional, defaults to 100000)
+	MaxLen int64
+}
+
+// redisStreamSink adds rows to a Redis stream, each entry has the row's type and the row as JSON
+type redisStreamSink struct {
+	client redis.UniversalClient
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamSink creates a sink that adds rows to a Redis stream
+func NewRedisStreamSink(cfg *RedisStreamSinkConfig) (Sink, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	stream := cfg.Stream
+	if stream == "" {
+		stream = defaultStream
+	}
+
+	maxLen := cfg.MaxLen
+	if maxLen <= 0 {
+		maxLen = defaultStreamMaxLen
+	}
+
+	return &redisStreamSink{
+		client: cfg.RedisClient,
+		stream: stream,
+		maxLen: maxLen,
+	}, nil
+}
+
+// Write adds the row to the stream
+func (s *redisStreamSink) Write(ctx context.Context, row *Row) error {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("failed to marshal row: %w", err)
+	}
+
+	err = s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type": row.Type,
+			"row":  data,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add row to stream: %w", err)
+	}
+
+	return nil
+}
+
+// Close does nothing, the Redis client is shared with the rest of the bot
+func (s *redisStreamSink) Close() error {
+	return nil
+}
@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type SinkTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	row *Row
+}
+
+func (s *SinkTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.row = &Row{Type: RowTypeRoll, Player: "abc123", RollValue: 4}
+}
+
+func TestSinkTestSuite(t *testing.T) {
+	suite.Run(t, new(SinkTestSuite))
+}
+
+func (s *SinkTestSuite) TestRedisStreamSink() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	sink, err := NewRedisStreamSink(&RedisStreamSinkConfig{RedisClient: client})
+	s.Require().NoError(err)
+	s.Require().NoError(sink.Write(s.ctx, s.row))
+	s.Require().NoError(sink.Close())
+
+	entries, err := client.XRange(s.ctx, defaultStream, "-", "+").Result()
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Equal(RowTypeRoll, entries[0].Values["type"])
+
+	var row Row
+	s.Require().NoError(json.Unmarshal([]byte(entries[0].Values["row"].(string)), &row))
+	s.Equal(*s.row, row)
+}
+
+func (s *SinkTestSuite) TestFileSink() {
+	path := filepath.Join(s.T().TempDir(), "analytics.jsonl")
+
+	sink, err := NewFileSink(&FileSinkConfig{Path: path})
+	s.Require().NoError(err)
+	s.Require().NoError(sink.Write(s.ctx, s.row))
+	s.Require().NoError(sink.Write(s.ctx, &Row{Type: RowTypeDrinkAssigned, Reason: "lowest_roll"}))
+	s.Require().NoError(sink.Close())
+
+	// Reopening appends
+	sink, err = NewFileSink(&FileSinkConfig{Path: path})
+	s.Require().NoError(err)
+	s.Require().NoError(sink.Write(s.ctx, s.row))
+	s.Require().NoError(sink.Close())
+
+	file, err := os.Open(path)
+	s.Require().NoError(err)
+	defer file.Close()
+
+	var types []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var row Row
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &row))
+		types = append(types, row.Type)
+	}
+	s.Equal([]string{RowTypeRoll, RowTypeDrinkAssigned, RowTypeRoll}, types)
+}
+
+func (s *SinkTestSuite) TestHTTPSink() {
+	var received Row
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink, err := NewHTTPSink(&HTTPSinkConfig{URL: server.URL, Token: "secret"})
+	s.Require().NoError(err)
+	s.Require().NoError(sink.Write(s.ctx, s.row))
+	s.Equal(*s.row, received)
+	s.Equal("Bearer secret", auth)
+}
+
+func (s *SinkTestSuite) TestHTTPSink_ErrorStatus() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink, err := NewHTTPSink(&HTTPSinkConfig{URL: server.URL})
+	s.Require().NoError(err)
+	s.Error(sink.Write(s.ctx, s.row))
+}
+
+func (s *SinkTestSuite) TestNewHTTPSink_InvalidURL() {
+	_, err := NewHTTPSink(&HTTPSinkConfig{URL: "ftp://example.com"})
+	s.Error(err)
+}
@@ -0,0 +1,498 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/analytics"
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/handlers/dashboard"
+	"github.com/KirkDiggler/ronnied/internal/repositories/audit"
+	"github.com/KirkDiggler/ronnied/internal/repositories/bot_config"
+	"github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/KirkDiggler/ronnied/internal/repositories/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/game_view"
+	"github.com/KirkDiggler/ronnied/internal/repositories/guild_config"
+	"github.com/KirkDiggler/ronnied/internal/repositories/job"
+	"github.com/KirkDiggler/ronnied/internal/repositories/migration"
+	"github.com/KirkDiggler/ronnied/internal/repositories/moment"
+	"github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/repositories/preset"
+	"github.com/KirkDiggler/ronnied/internal/repositories/roll_log"
+	"github.com/KirkDiggler/ronnied/internal/repositories/seasonal"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/KirkDiggler/ronnied/internal/repositories/webhook"
+	botConfigService "github.com/KirkDiggler/ronnied/internal/services/bot_config"
+	featureFlagService "github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
+	guildConfigService "github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	messagingService "github.com/KirkDiggler/ronnied/internal/services/messaging"
+	presetService "github.com/KirkDiggler/ronnied/internal/services/preset"
+	seasonalService "github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	webhookService "github.com/KirkDiggler/ronnied/internal/services/webhook"
+	"github.com/redis/go-redis/v9"
+)
+
+// Config holds configuration for the bot
+type Config struct {
+	// Redis the bot saves its games to
+	Redis *Redis
+}
+
+// App is the bot, wired up from environment configuration for the chat platform picked by PLATFORM
+type App struct {
+	platform string
+	clock    clock.Clock
+
+	analyticsRecorder *analytics.Recorder
+	jobRepo           job.Repository
+
+	gameSvc      gameService.Service
+	msgSvc       messagingService.Service
+	botConfigSvc botConfigService.Service
+
+	archiveRetention   time.Duration
+	maxGameDuration    time.Duration
+	drinkReminderAfter time.Duration
+	sessionRotation    *gameService.SessionRotationConfig
+
+	bot       chatBot
+	dashboard *dashboard.Server
+
+	// stopWorkers stops the background loops, and jobsDone is closed once the job being run has finished
+	stopWorkers context.CancelFunc
+	jobsDone    chan struct{}
+}
+
+// New wires up the bot's repositories, services and chat platform from environment configuration
+func New(cfg *Config) (*App, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.Redis == nil {
+		return nil, errors.New("redis cannot be nil")
+	}
+
+	redisClient := cfg.Redis.Client
+	replicaClient := cfg.Redis.Replica
+
+	// Select which chat platform to run the game on
+	platform := getEnv("PLATFORM", platformDiscord)
+	if platform != platformDiscord && platform != platformTelegram && platform != platformSlack {
+		return nil, fmt.Errorf("unknown PLATFORM %q, expected %q, %q or %q", platform, platformDiscord, platformTelegram, platformSlack)
+	}
+
+	// Bring the data saved by older versions up to date before anything reads it
+	if err := applyMigrations(redisClient); err != nil {
+		return nil, err
+	}
+
+	// Initialize common dependencies
+	uuidGen := uuid.New()
+	clockSvc := clock.New()
+	eventBus := events.NewBus()
+
+	// Write anonymized game, roll and drink rows for offline analysis, if a sink is configured
+	analyticsRecorder := analyticsRecorderFromEnv(redisClient)
+	if analyticsRecorder != nil {
+		analyticsRecorder.Subscribe(eventBus)
+	}
+
+	// Initialize repositories
+	fmt.Println("Initializing repositories...")
+	gameRepo, err := game.NewRedis(&game.Config{
+		RedisClient: redisClient,
+		ReadClient:  replicaClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game repository: %w", err)
+	}
+
+	playerRepo, err := player.NewRedis(&player.Config{
+		RedisClient: redisClient,
+		ReadClient:  replicaClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create player repository: %w", err)
+	}
+
+	drinkLedgerRepo, err := drink_ledger.NewRedis(&drink_ledger.Config{
+		RedisClient: redisClient,
+		ReadClient:  replicaClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create drink ledger repository: %w", err)
+	}
+
+	webhookRepo, err := webhook.NewRedis(&webhook.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook repository: %w", err)
+	}
+
+	presetRepo, err := preset.NewRedis(&preset.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preset repository: %w", err)
+	}
+
+	gameViewRepo, err := game_view.NewRedis(&game_view.Config{
+		RedisClient: redisClient,
+		ReadClient:  replicaClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game view repository: %w", err)
+	}
+
+	auditRepo, err := audit.NewRedis(&audit.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit repository: %w", err)
+	}
+
+	rollLogRepo, err := roll_log.NewRedis(&roll_log.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create roll log repository: %w", err)
+	}
+
+	momentRepo, err := moment.NewRedis(&moment.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create moment repository: %w", err)
+	}
+
+	// Flows writing to several repositories save all or nothing
+	transactions, err := transaction.NewRedis(&transaction.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction runner: %w", err)
+	}
+
+	// Finish off completed games in the background so the roll that ended them returns quickly, each platform
+	// runs the jobs queued for its own games
+	var jobRepo job.Repository
+	if getEnv("POST_GAME_JOBS", "true") == "true" {
+		jobRepo, err = job.NewRedis(&job.Config{
+			RedisClient: redisClient,
+			Queue:       platform,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job repository: %w", err)
+		}
+	}
+
+	seasonalRepo, err := seasonal.NewRedis(&seasonal.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seasonal repository: %w", err)
+	}
+
+	guildConfigRepo, err := guild_config.NewRedis(&guild_config.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guild config repository: %w", err)
+	}
+
+	botConfigRepo, err := bot_config.NewRedis(&bot_config.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bot config repository: %w", err)
+	}
+
+	featureFlagRepo, err := feature_flag.NewRedis(&feature_flag.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feature flag repository: %w", err)
+	}
+
+	// Initialize dice roller
+	diceRoller := dice.New(&dice.Config{})
+
+	// Get game configuration from environment
+	maxPlayers := getEnvAsInt("MAX_PLAYERS", 10)
+	diceSides := getEnvAsInt("DICE_SIDES", 6)
+	criticalHitValues := getEnvAsRollValues("CRITICAL_HIT_VALUE", []int{6})
+	criticalFailValues := getEnvAsRollValues("CRITICAL_FAIL_VALUE", []int{1})
+
+	// Initialize seasonal service, events follow the session time zone
+	fmt.Println("Initializing seasonal service...")
+	seasonalSvc, err := seasonalService.New(&seasonalService.Config{
+		Location:     sessionLocationFromEnv(),
+		SeasonalRepo: seasonalRepo,
+		Clock:        clockSvc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create seasonal service: %w", err)
+	}
+
+	// Initialize feature flag service
+	fmt.Println("Initializing feature flag service...")
+	featureFlagSvc, err := featureFlagService.New(&featureFlagService.Config{
+		FeatureFlagRepo: featureFlagRepo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feature flag service: %w", err)
+	}
+
+	// Initialize guild config service
+	fmt.Println("Initializing guild config service...")
+	guildConfigSvc, err := guildConfigService.New(&guildConfigService.Config{
+		GuildConfigRepo: guildConfigRepo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guild config service: %w", err)
+	}
+
+	// Initialize game service
+	fmt.Println("Initializing game service...")
+	maxGameDuration := maxGameDurationFromEnv()
+	drinkReminderAfter := drinkReminderAfterFromEnv()
+	sessionRotation := sessionRotationFromEnv()
+	gameSvc, err := gameService.New(&gameService.Config{
+		GameRepo:           gameRepo,
+		PlayerRepo:         playerRepo,
+		DrinkLedgerRepo:    drinkLedgerRepo,
+		AuditRepo:          auditRepo,
+		GameViewRepo:       gameViewRepo,
+		RollLogRepo:        rollLogRepo,
+		MomentRepo:         momentRepo,
+		Transactions:       transactions,
+		JobRepo:            jobRepo,
+		DiceRoller:         diceRoller,
+		UUIDGenerator:      uuidGen,
+		Clock:              clockSvc,
+		MaxPlayers:         maxPlayers,
+		DiceSides:          diceSides,
+		CriticalHitValues:  criticalHitValues,
+		CriticalFailValues: criticalFailValues,
+		SocialValues:       getEnvAsRollValues("SOCIAL_VALUES", nil),
+		EventBus:           eventBus,
+		SessionRotation:    sessionRotation,
+		DrinkCap:           getEnvAsInt("DRINK_CAP", 0),
+		Pacing:             pacingFromEnv(),
+		SeasonalService:    seasonalSvc,
+		FeatureFlagService: featureFlagSvc,
+		GuildConfigService: guildConfigSvc,
+		LastCallMultiplier: getEnvAsFloat("LAST_CALL_MULTIPLIER", 0),
+		MaxGameDuration:    maxGameDuration,
+		LeaderHandicap:     getEnv("LEADER_HANDICAP", "false") == "true",
+		GuestDrinks:        getEnv("GUEST_DRINKS", "false") == "true",
+		Karma:              getEnv("KARMA", "false") == "true",
+		Jackpot:            getEnv("JACKPOT", "false") == "true",
+		RonnieRollDelay:    ronnieRollDelayFromEnv(),
+		HappyHour:          happyHourFromEnv(),
+		BirthdayLocation:   sessionLocationFromEnv(),
+		DrinkReminderAfter: drinkReminderAfter,
+		VoteThreshold:      getEnvAsFloat("VOTE_THRESHOLD", 0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create game service: %w", err)
+	}
+
+	// Initialize webhook service
+	fmt.Println("Initializing webhook service...")
+	webhookSvc, err := webhookService.New(&webhookService.Config{
+		WebhookRepo:   webhookRepo,
+		Clock:         clockSvc,
+		UUIDGenerator: uuidGen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook service: %w", err)
+	}
+
+	// Initialize preset service
+	fmt.Println("Initializing preset service...")
+	presetSvc, err := presetService.New(&presetService.Config{
+		PresetRepo: presetRepo,
+		Clock:      clockSvc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preset service: %w", err)
+	}
+
+	// Initialize messaging service
+	fmt.Println("Initializing messaging service...")
+	msgSvc, err := messagingService.NewService(&messagingService.ServiceConfig{
+		// We'll add repository configuration here later when we implement message storage
+		Generator:            banterGeneratorFromEnv(),
+		GenerationsPerMinute: getEnvAsInt("BANTER_PER_MINUTE", 10),
+		Clock:                clockSvc,
+		CriticalHitValues:    criticalHitValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create messaging service: %w", err)
+	}
+
+	// Initialize bot config service, settings changed while the bot is running are applied over the environment's
+	fmt.Println("Initializing bot config service...")
+	botConfigSvc, err := botConfigService.New(&botConfigService.Config{
+		BotConfigRepo: botConfigRepo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bot config service: %w", err)
+	}
+	reloadBotConfig(context.Background(), botConfigSvc, gameSvc, msgSvc)
+
+	var bot chatBot
+	var dashboardServer *dashboard.Server
+	switch platform {
+	case platformDiscord:
+		bot, err = newDiscordBot(gameSvc, msgSvc, webhookSvc, presetSvc, seasonalSvc, guildConfigSvc, featureFlagSvc, eventBus)
+
+		// The admin dashboard manages Discord servers
+		dashboardServer = dashboardFromEnv(gameSvc, guildConfigSvc, featureFlagSvc)
+	case platformTelegram:
+		bot, err = newTelegramBot(gameSvc, msgSvc)
+	case platformSlack:
+		bot, err = newSlackBot(gameSvc, msgSvc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{
+		platform:           platform,
+		clock:              clockSvc,
+		analyticsRecorder:  analyticsRecorder,
+		jobRepo:            jobRepo,
+		gameSvc:            gameSvc,
+		msgSvc:             msgSvc,
+		botConfigSvc:       botConfigSvc,
+		archiveRetention:   archiveRetentionFromEnv(),
+		maxGameDuration:    maxGameDuration,
+		drinkReminderAfter: drinkReminderAfter,
+		sessionRotation:    sessionRotation,
+		bot:                bot,
+		dashboard:          dashboardServer,
+		jobsDone:           make(chan struct{}),
+	}, nil
+}
+
+// Start starts the background work, the bot and the admin dashboard
+func (a *App) Start() error {
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	a.stopWorkers = stopWorkers
+
+	// Delete abandoned games once they're past the retention period
+	if a.archiveRetention > 0 {
+		go runArchiveRetention(workersCtx, a.gameSvc, a.clock, a.archiveRetention)
+	}
+
+	// Warn and end games running past the max game duration
+	if a.maxGameDuration > 0 {
+		go runGameDurationChecks(workersCtx, a.gameSvc)
+	}
+
+	// Remind players of drinks they've owed too long
+	if a.drinkReminderAfter > 0 {
+		go runDrinkReminders(workersCtx, a.gameSvc)
+	}
+
+	// Rotate sessions that have run their course, even in channels nobody is playing in
+	if a.sessionRotation != nil {
+		go runSessionRotation(workersCtx, a.gameSvc)
+	}
+
+	// Run the jobs queued in the background, stopped before Redis is closed on shutdown. The jobs left unfinished
+	// by workers that stopped without completing them are queued again first.
+	if a.jobRepo != nil {
+		if output, err := a.jobRepo.RequeueStale(workersCtx, &job.RequeueStaleInput{}); err != nil {
+			log.Printf("Error queueing unfinished jobs again: %v", err)
+		} else if output.Requeued > 0 {
+			log.Printf("Queued %d unfinished jobs again", output.Requeued)
+		}
+		go runJobWorker(workersCtx, a.gameSvc, a.jobsDone)
+	} else {
+		close(a.jobsDone)
+	}
+
+	// Pick up changed settings on SIGHUP, and by checking for them regularly
+	go runBotConfigReload(workersCtx, a.botConfigSvc, a.gameSvc, a.msgSvc, botConfigReloadIntervalFromEnv())
+
+	// Start the bot
+	fmt.Printf("Starting %s bot...\n", a.platform)
+	if err := a.bot.Start(); err != nil {
+		return fmt.Errorf("failed to start %s bot: %w", a.platform, err)
+	}
+
+	// Start the admin dashboard
+	if a.dashboard != nil {
+		if err := a.dashboard.Start(); err != nil {
+			return fmt.Errorf("failed to start the dashboard: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Stop lets the job being run finish, drains and stops the bot and the dashboard, and writes the analytics rows
+// still queued. The Redis connections are left for their owner to close.
+func (a *App) Stop() {
+	// Stop taking jobs and let the job being run finish, so the bot can still show what it did. The job runs on a
+	// context of its own that stopping the workers doesn't cancel. Jobs queued from now on are run when the bot
+	// next starts.
+	if a.stopWorkers != nil {
+		a.stopWorkers()
+		<-a.jobsDone
+	}
+
+	// Stop the bot once the interactions it's handling are done
+	if err := a.bot.Stop(); err != nil {
+		log.Printf("Error stopping bot: %v", err)
+	}
+
+	// Stop the admin dashboard
+	if a.dashboard != nil {
+		if err := a.dashboard.Stop(); err != nil {
+			log.Printf("Error stopping the dashboard: %v", err)
+		}
+	}
+
+	// Write the analytics rows still queued
+	if a.analyticsRecorder != nil {
+		if err := a.analyticsRecorder.Close(); err != nil {
+			log.Printf("Error closing analytics: %v", err)
+		}
+	}
+}
+
+// applyMigrations brings the data saved by older versions of the bot up to date. Each migration can safely be run
+// again, so bots starting together can both apply them.
+func applyMigrations(redisClient redis.UniversalClient) error {
+	migrationRepo, err := migration.NewRedis(&migration.Config{
+		RedisClient: redisClient,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create migration repository: %w", err)
+	}
+
+	output, err := migrationRepo.Migrate(context.Background(), &migration.MigrateInput{})
+	if err != nil {
+		return fmt.Errorf("failed to migrate saved data: %w", err)
+	}
+
+	for _, applied := range output.Applied {
+		fmt.Printf("Applied migration %d (%s), %d records changed\n", applied.Migration.Version, applied.Migration.Name, applied.Changed)
+	}
+
+	return nil
+}
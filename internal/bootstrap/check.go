@@ -0,0 +1,353 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/handlers/discord"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/redis/go-redis/v9"
+)
+
+// envSetting is an environment setting checked by RunChecks, with how its value is parsed
+type envSetting struct {
+	key   string
+	parse func(value string) error
+}
+
+// envSettings are the settings RunChecks parses, the ones a bad value would otherwise stop the bot part way through
+// starting or quietly fall back to a default
+var envSettings = []envSetting{
+	{"PLATFORM", parseOneOf(platformDiscord, platformTelegram, platformSlack)},
+	{"REDIS_MODE", parseOneOf(redisModeStandalone, redisModeCluster, redisModeSentinel)},
+	{"REDIS_DB", parseInt},
+	{"REDIS_TLS", parseBool},
+	{"MAX_PLAYERS", parseInt},
+	{"DICE_SIDES", parseInt},
+	{"CRITICAL_HIT_VALUE", parseRollValues},
+	{"CRITICAL_FAIL_VALUE", parseRollValues},
+	{"SOCIAL_VALUES", parseRollValues},
+	{"DRINK_CAP", parseInt},
+	{"LAST_CALL_MULTIPLIER", parseFloat},
+	{"VOTE_THRESHOLD", parseFloat},
+	{"BANTER_PER_MINUTE", parseInt},
+	{"PACING_MAX_DRINKS", parseInt},
+	{"PACING_WINDOW", parsePositiveDuration},
+	{"PACING_COOLDOWN", parsePositiveDuration},
+	{"SESSION_ROTATE_AT", parseTimeOfDay},
+	{"SESSION_INACTIVITY", parsePositiveDuration},
+	{"SESSION_TIMEZONE", parseTimeZone},
+	{"HAPPY_HOURS", parseHappyHoursSetting},
+	{"ARCHIVE_RETENTION", parsePositiveDuration},
+	{"MAX_GAME_DURATION", parsePositiveDuration},
+	{"DRINK_REMINDER_AFTER", parsePositiveDuration},
+	{"RONNIE_ROLL_DELAY", parsePositiveDuration},
+	{"CONFIG_RELOAD_INTERVAL", parseDuration},
+	{"DRAIN_TIMEOUT", parsePositiveDuration},
+	{"LEADER_HANDICAP", parseBool},
+	{"GUEST_DRINKS", parseBool},
+	{"KARMA", parseBool},
+	{"JACKPOT", parseBool},
+	{"POST_GAME_JOBS", parseBool},
+	{"LEADERBOARD_IMAGES", parseBool},
+	{"MEMBER_LEAVES", parseBool},
+	{"NICKNAME_SYNC", parseBool},
+	{"SOUNDS_DIR", parseDir},
+	{"ANALYTICS_SINK", parseOneOf("redis", "file", "http")},
+	{"ANALYTICS_STREAM_MAXLEN", parseInt},
+}
+
+// envRequirement is a setting another setting needs to work
+type envRequirement struct {
+	key      string
+	needs    string
+	required func() bool
+}
+
+// envRequirements are the settings that have to be set for the ones set alongside them to work
+var envRequirements = []envRequirement{
+	{"ANALYTICS_SALT", "ANALYTICS_SINK", func() bool { return getEnv("ANALYTICS_SINK", "") != "" }},
+	{"ANALYTICS_URL", "ANALYTICS_SINK=http", func() bool { return getEnv("ANALYTICS_SINK", "") == "http" }},
+	{"APPLICATION_ID", "DASHBOARD_ADDR", func() bool { return getEnv("DASHBOARD_ADDR", "") != "" }},
+	{"DASHBOARD_CLIENT_SECRET", "DASHBOARD_ADDR", func() bool { return getEnv("DASHBOARD_ADDR", "") != "" }},
+	{"DASHBOARD_REDIRECT_URL", "DASHBOARD_ADDR", func() bool { return getEnv("DASHBOARD_ADDR", "") != "" }},
+	{"DASHBOARD_SESSION_SECRET", "DASHBOARD_ADDR", func() bool { return getEnv("DASHBOARD_ADDR", "") != "" }},
+}
+
+// RunChecks checks the bot's environment configuration, Redis and the chat platform's setup without starting the
+// bot, printing what it finds and how to fix each problem. It returns whether every check passed.
+func RunChecks() bool {
+	// Secrets are read first, the other checks need them
+	results := []discord.Diagnostic{checkSecrets()}
+	results = append(results, checkEnv()...)
+	results = append(results, checkRules())
+	results = append(results, checkRedis()...)
+	results = append(results, checkPlatform()...)
+
+	failed := 0
+	for _, result := range results {
+		if result.Failed() {
+			failed++
+			fmt.Printf("✗ %s: %s\n", result.Name, result.Problem)
+		} else {
+			fmt.Printf("✓ %s: %s\n", result.Name, result.Detail)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d checks failed\n", failed, len(results))
+		return false
+	}
+	fmt.Printf("\nAll %d checks passed\n", len(results))
+	return true
+}
+
+// checkSecrets reads the secret settings kept in files or secret managers
+func checkSecrets() discord.Diagnostic {
+	result := discord.Diagnostic{Name: "Secrets"}
+
+	loaded, err := LoadSecrets()
+	if err != nil {
+		result.Problem = strings.ReplaceAll(err.Error(), "\n", ", ")
+		return result
+	}
+
+	switch loaded {
+	case 0:
+		result.Detail = "All secrets are set in the environment"
+	case 1:
+		result.Detail = "1 secret read from a file or secret manager"
+	default:
+		result.Detail = fmt.Sprintf("%d secrets read from files or secret managers", loaded)
+	}
+	return result
+}
+
+// checkEnv parses the environment settings that are set and checks the ones they need are too
+func checkEnv() []discord.Diagnostic {
+	var results []discord.Diagnostic
+	set := 0
+	for _, setting := range envSettings {
+		value := os.Getenv(setting.key)
+		if value == "" {
+			continue
+		}
+		set++
+
+		if err := setting.parse(value); err != nil {
+			results = append(results, discord.Diagnostic{
+				Name:    setting.key,
+				Problem: fmt.Sprintf("%q is invalid, %v", value, err),
+			})
+		}
+	}
+
+	for _, requirement := range envRequirements {
+		if requirement.required() && os.Getenv(requirement.key) == "" {
+			results = append(results, discord.Diagnostic{
+				Name:    requirement.key,
+				Problem: fmt.Sprintf("%s is required with %s", requirement.key, requirement.needs),
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, discord.Diagnostic{
+			Name:   "Environment",
+			Detail: fmt.Sprintf("%d settings set, all valid", set),
+		})
+	}
+	return results
+}
+
+// checkRules checks that the default game rules make a playable game
+func checkRules() discord.Diagnostic {
+	result := discord.Diagnostic{Name: "Game rules"}
+
+	rules := models.GameRules{
+		DiceSides:          getEnvAsInt("DICE_SIDES", 6),
+		MaxPlayers:         getEnvAsInt("MAX_PLAYERS", 10),
+		CriticalHitValues:  getEnvAsRollValues("CRITICAL_HIT_VALUE", []int{6}),
+		CriticalFailValues: getEnvAsRollValues("CRITICAL_FAIL_VALUE", []int{1}),
+		SocialValues:       getEnvAsRollValues("SOCIAL_VALUES", nil),
+	}
+	if err := gameService.ValidateRules(rules); err != nil {
+		result.Problem = fmt.Sprintf("%v, check DICE_SIDES, MAX_PLAYERS, CRITICAL_HIT_VALUE, CRITICAL_FAIL_VALUE and SOCIAL_VALUES", err)
+		return result
+	}
+
+	result.Detail = fmt.Sprintf("%d-sided dice for up to %d players", rules.DiceSides, rules.MaxPlayers)
+	return result
+}
+
+// checkRedis checks that Redis, and its replica when there is one, can be reached
+func checkRedis() []discord.Diagnostic {
+	mode := getEnv("REDIS_MODE", redisModeStandalone)
+	options, err := redisOptionsFromEnv(mode)
+	if err != nil {
+		return []discord.Diagnostic{{
+			Name:    "Redis",
+			Problem: err.Error(),
+		}}
+	}
+
+	addrs := strings.Join(options.Addrs, ", ")
+	results := []discord.Diagnostic{pingRedis("Redis", "REDIS_ADDR", fmt.Sprintf("%s (%s)", addrs, mode), newRedisClient(mode, options))}
+	if replicaClient := redisReplicaFromEnv(options); replicaClient != nil {
+		results = append(results, pingRedis("Redis replica", "REDIS_REPLICA_ADDR", getEnv("REDIS_REPLICA_ADDR", ""), replicaClient))
+	}
+	return results
+}
+
+// pingRedis checks that the Redis at the address answers, closing the client afterwards
+func pingRedis(name, key, addr string, client redis.UniversalClient) discord.Diagnostic {
+	result := discord.Diagnostic{Name: name}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		result.Problem = fmt.Sprintf("Couldn't connect to %s, check %s and that Redis is running: %v", addr, key, err)
+		return result
+	}
+
+	result.Detail = fmt.Sprintf("Connected to %s", addr)
+	return result
+}
+
+// checkPlatform checks the chat platform's side of the bot's setup
+func checkPlatform() []discord.Diagnostic {
+	switch getEnv("PLATFORM", platformDiscord) {
+	case platformDiscord:
+		return discord.CheckSetup(&discord.Config{
+			Token:             getEnv("DISCORD_TOKEN", ""),
+			ApplicationID:     getEnv("APPLICATION_ID", ""),
+			GuildID:           getEnv("GUILD_ID", ""),
+			LeaderboardImages: getEnv("LEADERBOARD_IMAGES", "true") != "false",
+			MemberLeaves:      getEnv("MEMBER_LEAVES", "false") == "true",
+			NicknameSync:      getEnv("NICKNAME_SYNC", "false") == "true",
+		})
+	case platformTelegram:
+		// The Telegram token is only checked when the bot connects
+		result := discord.Diagnostic{
+			Name:   "Telegram token",
+			Detail: "TELEGRAM_TOKEN is set, Telegram checks it when the bot starts",
+		}
+		if getEnv("TELEGRAM_TOKEN", "") == "" {
+			result.Problem = "TELEGRAM_TOKEN is required, get one from @BotFather"
+		}
+		return []discord.Diagnostic{result}
+	case platformSlack:
+		// Slack only checks the token and signing secret once it sends a request
+		result := discord.Diagnostic{
+			Name:   "Slack credentials",
+			Detail: "SLACK_BOT_TOKEN and SLACK_SIGNING_SECRET are set, Slack checks them once the bot is running",
+		}
+		if getEnv("SLACK_BOT_TOKEN", "") == "" || getEnv("SLACK_SIGNING_SECRET", "") == "" {
+			result.Problem = "SLACK_BOT_TOKEN and SLACK_SIGNING_SECRET are required, find them in the Slack app's settings"
+		}
+		return []discord.Diagnostic{result}
+	default:
+		return nil
+	}
+}
+
+// parseOneOf returns a parser accepting only the given values
+func parseOneOf(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, option := range allowed {
+			if value == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %v", allowed)
+	}
+}
+
+// parseInt accepts whole numbers
+func parseInt(value string) error {
+	if _, err := strconv.Atoi(value); err != nil {
+		return errors.New("expected a whole number")
+	}
+	return nil
+}
+
+// parseFloat accepts numbers
+func parseFloat(value string) error {
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return errors.New("expected a number")
+	}
+	return nil
+}
+
+// parseBool accepts true or false, anything else is read as the setting's default
+func parseBool(value string) error {
+	if value != "true" && value != "false" {
+		return errors.New("expected true or false")
+	}
+	return nil
+}
+
+// parseRollValues accepts die faces such as "5,6"
+func parseRollValues(value string) error {
+	if _, err := models.ParseRollValues(value); err != nil {
+		return errors.New("expected die faces such as 5,6")
+	}
+	return nil
+}
+
+// parseDuration accepts durations such as "90s" or "2h"
+func parseDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return errors.New("expected a duration such as 90s or 2h")
+	}
+	return nil
+}
+
+// parsePositiveDuration accepts durations longer than zero
+func parsePositiveDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return errors.New("expected a positive duration such as 90s or 2h")
+	}
+	return nil
+}
+
+// parseTimeOfDay accepts times of day such as "04:00"
+func parseTimeOfDay(value string) error {
+	if _, err := time.Parse("15:04", value); err != nil {
+		return errors.New("expected a time such as 04:00")
+	}
+	return nil
+}
+
+// parseTimeZone accepts time zones such as "America/Chicago"
+func parseTimeZone(value string) error {
+	if _, err := time.LoadLocation(value); err != nil {
+		return errors.New("expected a time zone such as America/Chicago")
+	}
+	return nil
+}
+
+// parseHappyHoursSetting accepts happy hour windows such as "17:00-19:00x2"
+func parseHappyHoursSetting(value string) error {
+	_, err := parseHappyHours(value)
+	return err
+}
+
+// parseDir accepts directories that exist
+func parseDir(value string) error {
+	info, err := os.Stat(value)
+	if err != nil || !info.IsDir() {
+		return errors.New("expected a directory that exists")
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+// Package bootstrap reads the bot's configuration from the environment and wires up its repositories, services and
+// chat platform, so every command the bot runs is set up the same way
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/analytics"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
+	messagingService "github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+)
+
+// LoadEnv loads the .env file into the environment, when there is one
+func LoadEnv() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: No .env file found. Using environment variables.")
+	}
+}
+
+// sessionRotationFromEnv builds the session rotation config from environment configuration.
+// SESSION_ROTATE_AT rotates daily at a local time such as "04:00", SESSION_TIMEZONE picks its time zone,
+// and SESSION_INACTIVITY rotates after a quiet period such as "8h". Returns nil when neither is set.
+func sessionRotationFromEnv() *gameService.SessionRotationConfig {
+	rotateAt := getEnv("SESSION_ROTATE_AT", "")
+	inactivity := getEnv("SESSION_INACTIVITY", "")
+	if rotateAt == "" && inactivity == "" {
+		return nil
+	}
+
+	rotation := &gameService.SessionRotationConfig{}
+
+	if rotateAt != "" {
+		at, err := time.Parse("15:04", rotateAt)
+		if err != nil {
+			log.Fatalf("Invalid SESSION_ROTATE_AT %q, expected HH:MM: %v", rotateAt, err)
+		}
+		rotation.Daily = true
+		rotation.DailyAt = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+	}
+
+	rotation.Location = sessionLocationFromEnv()
+
+	if inactivity != "" {
+		duration, err := time.ParseDuration(inactivity)
+		if err != nil {
+			log.Fatalf("Invalid SESSION_INACTIVITY %q: %v", inactivity, err)
+		}
+		rotation.Inactivity = duration
+	}
+
+	return rotation
+}
+
+// sessionLocationFromEnv loads the SESSION_TIMEZONE time zone, returning nil when it isn't set
+func sessionLocationFromEnv() *time.Location {
+	timezone := getEnv("SESSION_TIMEZONE", "")
+	if timezone == "" {
+		return nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Fatalf("Invalid SESSION_TIMEZONE %q: %v", timezone, err)
+	}
+	return location
+}
+
+// happyHourFromEnv builds the happy hour config from HAPPY_HOURS, a comma separated list of windows such as
+// "17:00-19:00x2,23:30-01:00x0.5" in SESSION_TIMEZONE. Returns nil when HAPPY_HOURS isn't set.
+func happyHourFromEnv() *gameService.HappyHourConfig {
+	value := getEnv("HAPPY_HOURS", "")
+	if value == "" {
+		return nil
+	}
+
+	windows, err := parseHappyHours(value)
+	if err != nil {
+		log.Fatalf("Invalid HAPPY_HOURS: %v", err)
+	}
+
+	return &gameService.HappyHourConfig{
+		Location: sessionLocationFromEnv(),
+		Windows:  windows,
+	}
+}
+
+// parseHappyHours parses happy hour windows such as "17:00-19:00x2,23:30-01:00x0.5"
+func parseHappyHours(value string) ([]gameService.HappyHourWindow, error) {
+	var windows []gameService.HappyHourWindow
+	for _, window := range strings.Split(value, ",") {
+		times, multiplier, found := strings.Cut(strings.TrimSpace(window), "x")
+		start, end, foundEnd := strings.Cut(times, "-")
+		if !found || !foundEnd {
+			return nil, fmt.Errorf("window %q, expected HH:MM-HH:MMxMULTIPLIER", window)
+		}
+
+		startAt, err := time.Parse("15:04", start)
+		if err != nil {
+			return nil, fmt.Errorf("start %q: %w", start, err)
+		}
+		endAt, err := time.Parse("15:04", end)
+		if err != nil {
+			return nil, fmt.Errorf("end %q: %w", end, err)
+		}
+		factor, err := strconv.ParseFloat(multiplier, 64)
+		if err != nil {
+			return nil, fmt.Errorf("multiplier %q: %w", multiplier, err)
+		}
+
+		windows = append(windows, gameService.HappyHourWindow{
+			Start:      time.Duration(startAt.Hour())*time.Hour + time.Duration(startAt.Minute())*time.Minute,
+			End:        time.Duration(endAt.Hour())*time.Hour + time.Duration(endAt.Minute())*time.Minute,
+			Multiplier: factor,
+		})
+	}
+
+	return windows, nil
+}
+
+// pacingFromEnv builds the drink pacing config from environment configuration.
+// PACING_MAX_DRINKS drinks paid within PACING_WINDOW (e.g. "30m") trigger a warning, and PACING_COOLDOWN
+// optionally blocks paying drinks for a while afterwards. Returns nil when PACING_MAX_DRINKS isn't set.
+func pacingFromEnv() *gameService.PacingConfig {
+	maxDrinks := getEnvAsInt("PACING_MAX_DRINKS", 0)
+	if maxDrinks <= 0 {
+		return nil
+	}
+
+	window, err := time.ParseDuration(getEnv("PACING_WINDOW", "30m"))
+	if err != nil {
+		log.Fatalf("Invalid PACING_WINDOW: %v", err)
+	}
+
+	var cooldown time.Duration
+	if value := getEnv("PACING_COOLDOWN", ""); value != "" {
+		cooldown, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid PACING_COOLDOWN %q: %v", value, err)
+		}
+	}
+
+	return &gameService.PacingConfig{
+		MaxDrinks: maxDrinks,
+		Window:    window,
+		Cooldown:  cooldown,
+	}
+}
+
+// archiveRetentionFromEnv reads how long abandoned games are kept from ARCHIVE_RETENTION (e.g. "720h"),
+// returning 0 to keep them forever when it isn't set
+func archiveRetentionFromEnv() time.Duration {
+	value := getEnv("ARCHIVE_RETENTION", "")
+	if value == "" {
+		return 0
+	}
+
+	retention, err := time.ParseDuration(value)
+	if err != nil || retention <= 0 {
+		log.Fatalf("Invalid ARCHIVE_RETENTION %q, expected a positive duration", value)
+	}
+	return retention
+}
+
+// maxGameDurationFromEnv reads how long a game can be played from MAX_GAME_DURATION (e.g. "20m"),
+// returning 0 for no limit when it isn't set
+func maxGameDurationFromEnv() time.Duration {
+	value := getEnv("MAX_GAME_DURATION", "")
+	if value == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid MAX_GAME_DURATION %q, expected a positive duration", value)
+	}
+	return duration
+}
+
+// drinkReminderAfterFromEnv reads how long drinks are owed before their player is reminded from
+// DRINK_REMINDER_AFTER (e.g. "2h"), returning 0 for no reminders when it isn't set
+func drinkReminderAfterFromEnv() time.Duration {
+	value := getEnv("DRINK_REMINDER_AFTER", "")
+	if value == "" {
+		return 0
+	}
+
+	after, err := time.ParseDuration(value)
+	if err != nil || after <= 0 {
+		log.Fatalf("Invalid DRINK_REMINDER_AFTER %q, expected a positive duration", value)
+	}
+	return after
+}
+
+// ronnieRollDelayFromEnv reads how long Ronnie waits before rolling, 0 leaves it to the game service's default
+func ronnieRollDelayFromEnv() time.Duration {
+	value := getEnv("RONNIE_ROLL_DELAY", "")
+	if value == "" {
+		return 0
+	}
+
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay <= 0 {
+		log.Fatalf("Invalid RONNIE_ROLL_DELAY %q, expected a positive duration", value)
+	}
+	return delay
+}
+
+// drainTimeoutFromEnv reads how long shutdown waits for the interactions being handled from DRAIN_TIMEOUT
+// (e.g. "20s"), 0 leaves it to the bot's default
+func drainTimeoutFromEnv() time.Duration {
+	value := getEnv("DRAIN_TIMEOUT", "")
+	if value == "" {
+		return 0
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		log.Fatalf("Invalid DRAIN_TIMEOUT %q, expected a positive duration", value)
+	}
+	return timeout
+}
+
+// botConfigReloadIntervalFromEnv reads how often to check for changed settings from CONFIG_RELOAD_INTERVAL
+// (e.g. "1m"), defaulting to 30 seconds, 0 only reloads on SIGHUP
+func botConfigReloadIntervalFromEnv() time.Duration {
+	value := getEnv("CONFIG_RELOAD_INTERVAL", "")
+	if value == "" {
+		return 30 * time.Second
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		log.Fatalf("Invalid CONFIG_RELOAD_INTERVAL %q, expected a duration", value)
+	}
+	return interval
+}
+
+// banterGeneratorFromEnv returns the LLM that writes fresh roll comments, nil when BANTER_LLM_URL isn't set
+func banterGeneratorFromEnv() messagingService.Generator {
+	endpoint := getEnv("BANTER_LLM_URL", "")
+	if endpoint == "" {
+		return nil
+	}
+
+	generator, err := messagingService.NewLLMGenerator(&messagingService.LLMGeneratorConfig{
+		Endpoint: endpoint,
+		APIKey:   getEnv("BANTER_LLM_API_KEY", ""),
+		Model:    getEnv("BANTER_LLM_MODEL", ""),
+	})
+	if err != nil {
+		log.Printf("Warning: Could not set up the banter LLM, using the usual lines: %v", err)
+		return nil
+	}
+
+	return generator
+}
+
+// analyticsRecorderFromEnv sets up the analytics sink picked by ANALYTICS_SINK ("redis", "file" or "http"),
+// nil when analytics are off or can't be set up
+func analyticsRecorderFromEnv(redisClient redis.UniversalClient) *analytics.Recorder {
+	sinkType := getEnv("ANALYTICS_SINK", "")
+	if sinkType == "" {
+		return nil
+	}
+
+	salt := getEnv("ANALYTICS_SALT", "")
+	if salt == "" {
+		log.Printf("Warning: ANALYTICS_SALT is required for analytics, analytics are off")
+		return nil
+	}
+
+	var sink analytics.Sink
+	var err error
+	switch sinkType {
+	case "redis":
+		sink, err = analytics.NewRedisStreamSink(&analytics.RedisStreamSinkConfig{
+			RedisClient: redisClient,
+			Stream:      getEnv("ANALYTICS_STREAM", ""),
+			MaxLen:      int64(getEnvAsInt("ANALYTICS_STREAM_MAXLEN", 0)),
+		})
+	case "file":
+		sink, err = analytics.NewFileSink(&analytics.FileSinkConfig{
+			Path: getEnv("ANALYTICS_FILE", "analytics.jsonl"),
+		})
+	case "http":
+		sink, err = analytics.NewHTTPSink(&analytics.HTTPSinkConfig{
+			URL:   getEnv("ANALYTICS_URL", ""),
+			Token: getEnv("ANALYTICS_TOKEN", ""),
+		})
+	default:
+		err = fmt.Errorf("unknown ANALYTICS_SINK %q, expected redis, file or http", sinkType)
+	}
+	if err != nil {
+		log.Printf("Warning: Could not set up analytics, analytics are off: %v", err)
+		return nil
+	}
+
+	recorder, err := analytics.NewRecorder(&analytics.RecorderConfig{
+		Sink: sink,
+		Salt: salt,
+	})
+	if err != nil {
+		log.Printf("Warning: Could not set up analytics, analytics are off: %v", err)
+		return nil
+	}
+
+	return recorder
+}
+
+// getEnv gets an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsInt gets an environment variable as an integer or returns a default value
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Printf("Warning: Could not parse %s as integer, using default: %d", key, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getEnvAsRollValues gets an environment variable as a list of die faces such as "5,6" or returns a default value
+func getEnvAsRollValues(key string, defaultValue []int) []int {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	values, err := models.ParseRollValues(valueStr)
+	if err != nil {
+		log.Printf("Warning: Could not parse %s as die faces, using default: %v", key, defaultValue)
+		return defaultValue
+	}
+
+	return values
+}
+
+// getEnvAsFloat gets an environment variable as a float or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Warning: Could not parse %s as a number, using default: %g", key, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,146 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/handlers/dashboard"
+	"github.com/KirkDiggler/ronnied/internal/handlers/discord"
+	"github.com/KirkDiggler/ronnied/internal/handlers/slack"
+	"github.com/KirkDiggler/ronnied/internal/handlers/telegram"
+	featureFlagService "github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
+	guildConfigService "github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	messagingService "github.com/KirkDiggler/ronnied/internal/services/messaging"
+	presetService "github.com/KirkDiggler/ronnied/internal/services/preset"
+	seasonalService "github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	webhookService "github.com/KirkDiggler/ronnied/internal/services/webhook"
+)
+
+// Supported chat platforms
+const (
+	platformDiscord  = "discord"
+	platformTelegram = "telegram"
+	platformSlack    = "slack"
+)
+
+// chatBot is implemented by each platform handler
+type chatBot interface {
+	Start() error
+	Stop() error
+}
+
+// newDiscordBot creates the Discord bot from environment configuration
+func newDiscordBot(gameSvc gameService.Service, msgSvc messagingService.Service, webhookSvc webhookService.Service, presetSvc presetService.Service, seasonalSvc seasonalService.Service, guildConfigSvc guildConfigService.Service, featureFlagSvc featureFlagService.Service, eventBus events.Bus) (chatBot, error) {
+	// Get Discord token from environment
+	discordToken := getEnv("DISCORD_TOKEN", "")
+	if discordToken == "" {
+		return nil, errors.New("DISCORD_TOKEN environment variable is required")
+	}
+
+	// Get application ID for the bot
+	applicationID := getEnv("APPLICATION_ID", "")
+
+	// Get optional guild ID for development
+	guildID := getEnv("GUILD_ID", "")
+
+	fmt.Println("Initializing Discord bot...")
+	bot, err := discord.New(&discord.Config{
+		Token:              discordToken,
+		ApplicationID:      applicationID,
+		GuildID:            guildID,
+		GameService:        gameSvc,
+		MessagingService:   msgSvc,
+		WebhookService:     webhookSvc,
+		PresetService:      presetSvc,
+		SeasonalService:    seasonalSvc,
+		GuildConfigService: guildConfigSvc,
+		FeatureFlagService: featureFlagSvc,
+		EventBus:           eventBus,
+		LeaderboardImages:  getEnv("LEADERBOARD_IMAGES", "true") != "false",
+		MemberLeaves:       getEnv("MEMBER_LEAVES", "false") == "true",
+		NicknameSync:       getEnv("NICKNAME_SYNC", "false") == "true",
+		SoundsDir:          getEnv("SOUNDS_DIR", ""),
+		DrainTimeout:       drainTimeoutFromEnv(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Discord bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// newTelegramBot creates the Telegram bot from environment configuration
+func newTelegramBot(gameSvc gameService.Service, msgSvc messagingService.Service) (chatBot, error) {
+	telegramToken := getEnv("TELEGRAM_TOKEN", "")
+	if telegramToken == "" {
+		return nil, errors.New("TELEGRAM_TOKEN environment variable is required")
+	}
+
+	fmt.Println("Initializing Telegram bot...")
+	bot, err := telegram.New(&telegram.Config{
+		Token:            telegramToken,
+		GameService:      gameSvc,
+		MessagingService: msgSvc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Telegram bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// newSlackBot creates the Slack bot from environment configuration
+func newSlackBot(gameSvc gameService.Service, msgSvc messagingService.Service) (chatBot, error) {
+	slackBotToken := getEnv("SLACK_BOT_TOKEN", "")
+	if slackBotToken == "" {
+		return nil, errors.New("SLACK_BOT_TOKEN environment variable is required")
+	}
+
+	slackSigningSecret := getEnv("SLACK_SIGNING_SECRET", "")
+	if slackSigningSecret == "" {
+		return nil, errors.New("SLACK_SIGNING_SECRET environment variable is required")
+	}
+
+	fmt.Println("Initializing Slack bot...")
+	bot, err := slack.New(&slack.Config{
+		BotToken:         slackBotToken,
+		SigningSecret:    slackSigningSecret,
+		ListenAddr:       getEnv("SLACK_LISTEN_ADDR", ":3000"),
+		GameService:      gameSvc,
+		MessagingService: msgSvc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Slack bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// dashboardFromEnv sets up the admin dashboard when DASHBOARD_ADDR is set, nil when it's off or can't be set up.
+// Admins log in with the bot's Discord application, so APPLICATION_ID is its OAuth2 client ID.
+func dashboardFromEnv(gameSvc gameService.Service, guildConfigSvc guildConfigService.Service, featureFlagSvc featureFlagService.Service) *dashboard.Server {
+	addr := getEnv("DASHBOARD_ADDR", "")
+	if addr == "" {
+		return nil
+	}
+
+	server, err := dashboard.New(&dashboard.Config{
+		ListenAddr:         addr,
+		ClientID:           getEnv("APPLICATION_ID", ""),
+		ClientSecret:       getEnv("DASHBOARD_CLIENT_SECRET", ""),
+		RedirectURL:        getEnv("DASHBOARD_REDIRECT_URL", ""),
+		SessionSecret:      getEnv("DASHBOARD_SESSION_SECRET", ""),
+		GameService:        gameSvc,
+		GuildConfigService: guildConfigSvc,
+		FeatureFlagService: featureFlagSvc,
+	})
+	if err != nil {
+		log.Printf("Warning: Could not set up the dashboard, the dashboard is off: %v", err)
+		return nil
+	}
+
+	return server
+}
@@ -0,0 +1,192 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Ways of connecting to Redis, picked by REDIS_MODE
+const (
+	redisModeStandalone = "standalone"
+	redisModeCluster    = "cluster"
+	redisModeSentinel   = "sentinel"
+)
+
+// redisOptionsFromEnv reads how to connect to Redis in the given mode. REDIS_ADDR is the Redis node when standalone,
+// the comma separated cluster nodes to discover the rest from in cluster mode, or the comma separated sentinels
+// watching the primary named by REDIS_SENTINEL_MASTER in sentinel mode.
+func redisOptionsFromEnv(mode string) (*redis.UniversalOptions, error) {
+	var addrs []string
+	for _, addr := range strings.Split(getEnv("REDIS_ADDR", "localhost:6379"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("REDIS_ADDR has no addresses")
+	}
+
+	db, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	if err != nil || db < 0 {
+		return nil, fmt.Errorf("REDIS_DB %q isn't a database number", getEnv("REDIS_DB", ""))
+	}
+
+	tlsConfig, err := redisTLSFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	options := &redis.UniversalOptions{
+		Addrs:            addrs,
+		Username:         getEnv("REDIS_USERNAME", ""),
+		Password:         getEnv("REDIS_PASSWORD", ""),
+		DB:               db,
+		TLSConfig:        tlsConfig,
+		MasterName:       getEnv("REDIS_SENTINEL_MASTER", ""),
+		SentinelUsername: getEnv("REDIS_SENTINEL_USERNAME", ""),
+		SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+	}
+
+	switch mode {
+	case redisModeStandalone:
+		if len(addrs) > 1 {
+			return nil, errors.New("REDIS_ADDR lists more than one address, set REDIS_MODE to cluster or sentinel")
+		}
+	case redisModeCluster:
+		// Redis Cluster only has the one database
+		if db != 0 {
+			return nil, errors.New("REDIS_DB can't be used with REDIS_MODE=cluster")
+		}
+	case redisModeSentinel:
+		if options.MasterName == "" {
+			return nil, errors.New("REDIS_SENTINEL_MASTER is required with REDIS_MODE=sentinel")
+		}
+	default:
+		return nil, fmt.Errorf("unknown REDIS_MODE %q, expected %s, %s or %s", mode, redisModeStandalone, redisModeCluster, redisModeSentinel)
+	}
+
+	return options, nil
+}
+
+// redisTLSFromEnv sets up TLS for the connections to Redis when REDIS_TLS is true or REDIS_TLS_CA_FILE is set,
+// trusting the certificate authority in that file as well as the system's. It returns nil when TLS is off.
+func redisTLSFromEnv() (*tls.Config, error) {
+	caFile := getEnv("REDIS_TLS_CA_FILE", "")
+	if getEnv("REDIS_TLS", "false") != "true" && caFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: getEnv("REDIS_TLS_SERVER_NAME", ""),
+	}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read REDIS_TLS_CA_FILE: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("REDIS_TLS_CA_FILE %s has no PEM certificates", caFile)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
+// newRedisClient connects to Redis in the given mode
+func newRedisClient(mode string, options *redis.UniversalOptions) redis.UniversalClient {
+	switch mode {
+	case redisModeCluster:
+		return redis.NewClusterClient(options.Cluster())
+	case redisModeSentinel:
+		return redis.NewFailoverClient(options.Failover())
+	default:
+		return redis.NewClient(options.Simple())
+	}
+}
+
+// redisReplicaFromEnv connects to the read replica at REDIS_REPLICA_ADDR, nil when there isn't one.
+// The replica uses the primary's username, database and TLS, and its password unless REDIS_REPLICA_PASSWORD is set.
+func redisReplicaFromEnv(primary *redis.UniversalOptions) redis.UniversalClient {
+	replicaAddr := getEnv("REDIS_REPLICA_ADDR", "")
+	if replicaAddr == "" {
+		return nil
+	}
+
+	fmt.Printf("Reading from the Redis replica at %s\n", replicaAddr)
+	return redis.NewClient(&redis.Options{
+		Addr:      replicaAddr,
+		Username:  primary.Username,
+		Password:  getEnv("REDIS_REPLICA_PASSWORD", primary.Password),
+		DB:        primary.DB,
+		TLSConfig: primary.TLSConfig,
+	})
+}
+
+// Redis holds the connections to Redis and its read replica
+type Redis struct {
+	// Client is connected to the primary
+	Client redis.UniversalClient
+
+	// Replica is connected to the read replica, nil when there isn't one
+	Replica redis.UniversalClient
+}
+
+// ConnectRedis connects to the Redis configured by REDIS_MODE and the settings it reads, and to the replica at
+// REDIS_REPLICA_ADDR when there is one, checking the primary answers
+func ConnectRedis() (*Redis, error) {
+	mode := getEnv("REDIS_MODE", redisModeStandalone)
+	options, err := redisOptionsFromEnv(mode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis configuration: %w", err)
+	}
+
+	fmt.Printf("Connecting to Redis (%s) at %s...\n", mode, strings.Join(options.Addrs, ", "))
+	client := newRedisClient(mode, options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	fmt.Println("Connected to Redis successfully")
+
+	return &Redis{
+		Client: client,
+
+		// Leaderboards, tabs and game messages are read from a replica when one is configured
+		Replica: redisReplicaFromEnv(options),
+	}, nil
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	var errs []error
+	if err := r.Client.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close Redis connection: %w", err))
+	}
+	if r.Replica != nil {
+		if err := r.Replica.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close Redis replica connection: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisTestSuite struct {
+	suite.Suite
+}
+
+func TestRedisTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisTestSuite))
+}
+
+func (s *RedisTestSuite) TestOptions() {
+	s.T().Setenv("REDIS_ADDR", "redis-1:6379, redis-2:6379,")
+	s.T().Setenv("REDIS_PASSWORD", "secret")
+
+	options, err := redisOptionsFromEnv(redisModeCluster)
+	s.Require().NoError(err)
+	s.Equal([]string{"redis-1:6379", "redis-2:6379"}, options.Addrs)
+	s.Equal("secret", options.Password)
+	s.Nil(options.TLSConfig)
+
+	_, err = redisOptionsFromEnv(redisModeStandalone)
+	s.ErrorContains(err, "more than one address")
+
+	_, err = redisOptionsFromEnv(redisModeSentinel)
+	s.ErrorContains(err, "REDIS_SENTINEL_MASTER")
+
+	_, err = redisOptionsFromEnv("primary")
+	s.ErrorContains(err, "unknown REDIS_MODE")
+
+	s.T().Setenv("REDIS_DB", "2")
+	_, err = redisOptionsFromEnv(redisModeCluster)
+	s.ErrorContains(err, "REDIS_DB")
+}
+
+func (s *RedisTestSuite) TestOptionsTLS() {
+	s.T().Setenv("REDIS_TLS", "true")
+	s.T().Setenv("REDIS_TLS_SERVER_NAME", "redis.internal")
+
+	options, err := redisOptionsFromEnv(redisModeStandalone)
+	s.Require().NoError(err)
+	s.Require().NotNil(options.TLSConfig)
+	s.Equal("redis.internal", options.TLSConfig.ServerName)
+
+	s.T().Setenv("REDIS_TLS_CA_FILE", "missing.pem")
+	_, err = redisOptionsFromEnv(redisModeStandalone)
+	s.ErrorContains(err, "REDIS_TLS_CA_FILE")
+}
+
+func (s *RedisTestSuite) TestConnectRedis() {
+	mr := miniredis.RunT(s.T())
+	s.T().Setenv("REDIS_ADDR", mr.Addr())
+
+	conn, err := ConnectRedis()
+	s.Require().NoError(err)
+	s.Nil(conn.Replica)
+	s.NoError(conn.Client.Set(context.Background(), "key", "value", 0).Err())
+	s.NoError(conn.Close())
+
+	mr.Close()
+	_, err = ConnectRedis()
+	s.ErrorContains(err, "failed to connect to Redis")
+}
@@ -0,0 +1,61 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/config/secrets"
+)
+
+// secretSettings are the settings that can be read from the file their _FILE setting names, or from a cloud
+// secret manager
+var secretSettings = []string{
+	"DISCORD_TOKEN",
+	"TELEGRAM_TOKEN",
+	"SLACK_BOT_TOKEN",
+	"SLACK_SIGNING_SECRET",
+	"REDIS_PASSWORD",
+	"REDIS_REPLICA_PASSWORD",
+	"REDIS_SENTINEL_PASSWORD",
+	"DASHBOARD_CLIENT_SECRET",
+	"DASHBOARD_SESSION_SECRET",
+	"ANALYTICS_SALT",
+	"ANALYTICS_TOKEN",
+	"BANTER_LLM_API_KEY",
+}
+
+// LoadSecrets reads the secret settings kept in files or secret managers into the environment, so they're read like
+// any other setting from then on. It returns how many were read from somewhere else.
+func LoadSecrets() (int, error) {
+	loader, err := secrets.New(&secrets.Config{})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	loaded := 0
+	var errs []error
+	for _, key := range secretSettings {
+		value, err := loader.Get(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if value == os.Getenv(key) {
+			continue
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set %s: %w", key, err))
+			continue
+		}
+		loaded++
+	}
+
+	return loaded, errors.Join(errs...)
+}
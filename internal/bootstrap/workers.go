@@ -0,0 +1,183 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	botConfigService "github.com/KirkDiggler/ronnied/internal/services/bot_config"
+	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
+	messagingService "github.com/KirkDiggler/ronnied/internal/services/messaging"
+)
+
+// runArchiveRetention deletes games abandoned longer ago than the retention period, checking hourly until ctx is done
+func runArchiveRetention(ctx context.Context, gameSvc gameService.Service, clockSvc clock.Clock, retention time.Duration) {
+	ticker := clockSvc.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		output, err := gameSvc.PurgeArchivedGames(ctx, &gameService.PurgeArchivedGamesInput{
+			ArchivedBefore: clockSvc.Now().Add(-retention),
+		})
+		if err != nil {
+			log.Printf("Error purging archived games: %v", err)
+		} else if output.DeletedCount > 0 {
+			log.Printf("Purged %d archived games", output.DeletedCount)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// runGameDurationChecks warns the slow players of games running out of time and ends the games out of time,
+// checking every half minute until ctx is done
+func runGameDurationChecks(ctx context.Context, gameSvc gameService.Service) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		output, err := gameSvc.CheckGameDurations(ctx, &gameService.CheckGameDurationsInput{})
+		if err != nil {
+			log.Printf("Error checking game durations: %v", err)
+		} else if len(output.EndedGameIDs) > 0 {
+			log.Printf("Ended %d games that ran out of time", len(output.EndedGameIDs))
+		}
+	}
+}
+
+// runDrinkReminders reminds players of drinks they've owed too long, checking every minute until ctx is done
+func runDrinkReminders(ctx context.Context, gameSvc gameService.Service) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		output, err := gameSvc.CheckDrinkReminders(ctx, &gameService.CheckDrinkRemindersInput{})
+		if err != nil {
+			log.Printf("Error checking drink reminders: %v", err)
+		} else if len(output.RemindedPlayerIDs) > 0 {
+			log.Printf("Reminded %d players of drinks they owe", len(output.RemindedPlayerIDs))
+		}
+	}
+}
+
+// runSessionRotation rotates the sessions that have run their course, checking every minute until ctx is done
+func runSessionRotation(ctx context.Context, gameSvc gameService.Service) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		output, err := gameSvc.RotateDueSessions(ctx, &gameService.RotateDueSessionsInput{})
+		if err != nil {
+			log.Printf("Error rotating sessions: %v", err)
+		} else if len(output.RotatedSessionIDs) > 0 {
+			log.Printf("Rotated %d sessions", len(output.RotatedSessionIDs))
+		}
+	}
+}
+
+// runJobWorker runs the jobs queued in the background one at a time until the context is done, then closes done
+func runJobWorker(ctx context.Context, gameSvc gameService.Service, done chan<- struct{}) {
+	defer close(done)
+
+	for ctx.Err() == nil {
+		output, err := gameSvc.ProcessNextJob(ctx, &gameService.ProcessNextJobInput{
+			Timeout: 5 * time.Second,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Error processing job: %v", err)
+
+			// Don't spin while Redis is unreachable
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		if output.Job != nil {
+			log.Printf("Ran %s job %s for game %s", output.Job.Type, output.Job.ID, output.Job.GameID)
+		}
+	}
+}
+
+// runBotConfigReload reloads the bot's runtime settings on SIGHUP and every interval until ctx is done
+func runBotConfigReload(ctx context.Context, botConfigSvc botConfigService.Service, gameSvc gameService.Service, msgSvc messagingService.Service, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Println("Reloading bot config on SIGHUP")
+		case <-tick:
+		}
+
+		reloadBotConfig(ctx, botConfigSvc, gameSvc, msgSvc)
+	}
+}
+
+// reloadBotConfig reads the bot's runtime settings and applies them if they changed,
+// keeping the current settings when they can't be read or don't make a playable game
+func reloadBotConfig(ctx context.Context, botConfigSvc botConfigService.Service, gameSvc gameService.Service, msgSvc messagingService.Service) {
+	output, err := botConfigSvc.Reload(ctx, &botConfigService.ReloadInput{})
+	if err != nil {
+		log.Printf("Error reloading bot config, keeping the current settings: %v", err)
+		return
+	}
+	if !output.Changed {
+		return
+	}
+
+	if _, err := gameSvc.ApplyBotConfig(ctx, &gameService.ApplyBotConfigInput{
+		Config: output.Config,
+	}); err != nil {
+		log.Printf("Error applying bot config to games, keeping the current rules: %v", err)
+	}
+
+	if _, err := msgSvc.SetDefaultContentRating(ctx, &messagingService.SetDefaultContentRatingInput{
+		Rating: output.Config.ContentRating,
+	}); err != nil {
+		log.Printf("Error applying bot config to messages, keeping the current rating: %v", err)
+	}
+
+	log.Println("Applied bot config")
+}
@@ -5,6 +5,39 @@ import "time"
 //go:generate mockgen -package=mocks -destination=mocks/mock_clock.go github.com/KirkDiggler/ronnied/internal/common/clock Clock
 type Clock interface {
 	Now() time.Time
+
+	// After waits for the duration to pass and then sends the time on the returned channel
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep pauses the calling goroutine for the duration
+	Sleep(d time.Duration)
+
+	// NewTicker returns a ticker that sends the time every period
+	NewTicker(d time.Duration) Ticker
+
+	// AfterFunc calls f once the duration has passed
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker sends the time on its channel every period until it's stopped
+type Ticker interface {
+	// C returns the channel the ticks are sent on
+	C() <-chan time.Time
+
+	// Stop turns the ticker off, no more ticks are sent
+	Stop()
+
+	// Reset stops the ticker and starts it again with the new period
+	Reset(d time.Duration)
+}
+
+// Timer is a call waiting for its time to come
+type Timer interface {
+	// Stop cancels the call, false when it had already been made or stopped
+	Stop() bool
+
+	// Reset makes the call once the duration has passed from now, false when it had already been made or stopped
+	Reset(d time.Duration) bool
 }
 
 // DefaultClock implements the Clock interface using the system clock
@@ -19,3 +52,43 @@ func New() *DefaultClock {
 func (c *DefaultClock) Now() time.Time {
 	return time.Now()
 }
+
+// After waits for the duration to pass and then sends the time on the returned channel
+func (c *DefaultClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Sleep pauses the calling goroutine for the duration
+func (c *DefaultClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// NewTicker returns a ticker that sends the time every period
+func (c *DefaultClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{ticker: time.NewTicker(d)}
+}
+
+// AfterFunc calls f in its own goroutine once the duration has passed
+func (c *DefaultClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// systemTicker is a Ticker backed by the system clock
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+// C returns the channel the ticks are sent on
+func (t *systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+// Stop turns the ticker off, no more ticks are sent
+func (t *systemTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// Reset stops the ticker and starts it again with the new period
+func (t *systemTicker) Reset(d time.Duration) {
+	t.ticker.Reset(d)
+}
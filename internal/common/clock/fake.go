@@ -0,0 +1,225 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when a test advances it. Everything waiting on it, timers, tickers,
+// sleeps and AfterFunc calls, is released in order as Advance passes their time, so scheduled behavior runs
+// deterministically without real sleeps.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+	nextID  int
+}
+
+// fakeWaiter is something waiting for the fake clock to reach its time
+type fakeWaiter struct {
+	id     int
+	at     time.Time
+	period time.Duration
+	fire   func(now time.Time)
+}
+
+// NewFake returns a fake clock set to the given time
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the fake clock's time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// After sends the time on the returned channel once the clock has been advanced past the duration
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.Now()
+		return ch
+	}
+
+	f.schedule(d, 0, func(now time.Time) {
+		ch <- now
+	})
+	return ch
+}
+
+// Sleep blocks until the clock has been advanced past the duration
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// NewTicker returns a ticker that sends the time each time the clock is advanced past another period.
+// Like the system ticker, ticks nobody has received yet are dropped.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	t := &fakeTicker{clock: f, ch: make(chan time.Time, 1)}
+	t.id = f.schedule(d, d, t.tick)
+	return t
+}
+
+// AfterFunc calls f once the clock has been advanced past the duration, at the next Advance when the duration
+// has already passed. The call is made by the goroutine advancing the clock, so it has finished by the time
+// Advance returns.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{clock: f, fn: fn}
+	t.id = f.schedule(d, 0, t.fire)
+	return t
+}
+
+// Advance moves the clock forward, releasing everything waiting on the times it passes in order
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	for {
+		waiter := f.next(target)
+		if waiter == nil {
+			break
+		}
+		at := waiter.at
+		f.now = at
+		if waiter.period > 0 {
+			waiter.at = at.Add(waiter.period)
+			f.add(waiter)
+		}
+
+		f.mu.Unlock()
+		waiter.fire(at)
+		f.mu.Lock()
+	}
+	if target.After(f.now) {
+		f.now = target
+	}
+	f.mu.Unlock()
+}
+
+// BlockUntil waits until at least n timers, tickers, sleeps or AfterFunc calls are waiting on the clock, so a
+// test can advance it knowing a goroutine has started waiting
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// Waiters returns how many timers, tickers, sleeps and AfterFunc calls are waiting on the clock
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.waiters)
+}
+
+// schedule adds a waiter for the duration from now
+func (f *Fake) schedule(d, period time.Duration, fire func(now time.Time)) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	f.add(&fakeWaiter{id: f.nextID, at: f.now.Add(d), period: period, fire: fire})
+	return f.nextID
+}
+
+// add keeps the waiters in the order they're due, earliest added first for the same time
+func (f *Fake) add(waiter *fakeWaiter) {
+	f.waiters = append(f.waiters, waiter)
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].at.Before(f.waiters[j].at)
+	})
+	f.cond.Broadcast()
+}
+
+// next removes and returns the earliest waiter due by the target, nil when none are
+func (f *Fake) next(target time.Time) *fakeWaiter {
+	if len(f.waiters) == 0 || f.waiters[0].at.After(target) {
+		return nil
+	}
+
+	waiter := f.waiters[0]
+	f.waiters = f.waiters[1:]
+	return waiter
+}
+
+// remove cancels a waiter, false when it isn't waiting
+func (f *Fake) remove(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, waiter := range f.waiters {
+		if waiter.id == id {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTicker is a Ticker driven by a fake clock
+type fakeTicker struct {
+	clock *Fake
+	id    int
+	ch    chan time.Time
+}
+
+// C returns the channel the ticks are sent on
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop turns the ticker off, no more ticks are sent
+func (t *fakeTicker) Stop() {
+	t.clock.remove(t.id)
+}
+
+// Reset stops the ticker and starts it again with the new period
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.remove(t.id)
+	t.id = t.clock.schedule(d, d, t.tick)
+}
+
+// tick sends the time unless the last tick is still waiting to be received
+func (t *fakeTicker) tick(now time.Time) {
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+// fakeTimer is a Timer driven by a fake clock
+type fakeTimer struct {
+	clock *Fake
+	id    int
+	fn    func()
+}
+
+// Stop cancels the call, false when it had already been made or stopped
+func (t *fakeTimer) Stop() bool {
+	return t.clock.remove(t.id)
+}
+
+// Reset makes the call once the duration has passed from now, false when it had already been made or stopped
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	pending := t.clock.remove(t.id)
+	t.id = t.clock.schedule(d, 0, t.fire)
+	return pending
+}
+
+// fire makes the call
+func (t *fakeTimer) fire(time.Time) {
+	t.fn()
+}
@@ -0,0 +1,109 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FakeTestSuite struct {
+	suite.Suite
+	start time.Time
+	clock *Fake
+}
+
+func (s *FakeTestSuite) SetupTest() {
+	s.start = time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	s.clock = NewFake(s.start)
+}
+
+func TestFakeTestSuite(t *testing.T) {
+	suite.Run(t, new(FakeTestSuite))
+}
+
+func (s *FakeTestSuite) TestAdvance() {
+	s.Equal(s.start, s.clock.Now())
+
+	s.clock.Advance(90 * time.Second)
+	s.Equal(s.start.Add(90*time.Second), s.clock.Now())
+}
+
+func (s *FakeTestSuite) TestAfter() {
+	ch := s.clock.After(time.Minute)
+
+	s.clock.Advance(59 * time.Second)
+	s.Empty(ch)
+
+	s.clock.Advance(time.Second)
+	s.Equal(s.start.Add(time.Minute), <-ch)
+	s.Zero(s.clock.Waiters())
+
+	s.Len(s.clock.After(0), 1, "a duration that has already passed sends straight away")
+}
+
+func (s *FakeTestSuite) TestSleep() {
+	done := make(chan struct{})
+	go func() {
+		s.clock.Sleep(time.Second)
+		close(done)
+	}()
+
+	s.clock.BlockUntil(1)
+	s.clock.Advance(time.Second)
+	<-done
+}
+
+func (s *FakeTestSuite) TestAfterFunc_FiresInOrder() {
+	var fired []string
+	s.clock.AfterFunc(2*time.Second, func() { fired = append(fired, "second") })
+	s.clock.AfterFunc(time.Second, func() {
+		fired = append(fired, "first")
+		s.Equal(s.start.Add(time.Second), s.clock.Now(), "the clock reads the time the call was due")
+	})
+	stopped := s.clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	s.True(stopped.Stop())
+	s.False(stopped.Stop())
+
+	s.clock.Advance(5 * time.Second)
+	s.Equal([]string{"first", "second"}, fired)
+}
+
+func (s *FakeTestSuite) TestAfterFunc_Reset() {
+	calls := 0
+	timer := s.clock.AfterFunc(time.Second, func() { calls++ })
+
+	s.clock.Advance(500 * time.Millisecond)
+	s.True(timer.Reset(time.Second))
+
+	s.clock.Advance(500 * time.Millisecond)
+	s.Zero(calls)
+
+	s.clock.Advance(500 * time.Millisecond)
+	s.Equal(1, calls)
+
+	s.False(timer.Reset(time.Second), "the call was already made")
+	s.clock.Advance(time.Second)
+	s.Equal(2, calls)
+}
+
+func (s *FakeTestSuite) TestTicker() {
+	ticker := s.clock.NewTicker(time.Minute)
+
+	s.clock.Advance(time.Minute)
+	s.Equal(s.start.Add(time.Minute), <-ticker.C())
+
+	// Ticks nobody received are dropped
+	s.clock.Advance(3 * time.Minute)
+	s.Equal(s.start.Add(2*time.Minute), <-ticker.C())
+	s.Empty(ticker.C())
+
+	ticker.Reset(10 * time.Minute)
+	s.clock.Advance(time.Minute)
+	s.Empty(ticker.C())
+
+	ticker.Stop()
+	s.clock.Advance(time.Hour)
+	s.Empty(ticker.C())
+	s.Zero(s.clock.Waiters())
+}
@@ -12,6 +12,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	clock "github.com/KirkDiggler/ronnied/internal/common/clock"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -38,6 +39,48 @@ func (m *MockClock) EXPECT() *MockClockMockRecorder {
 	return m.recorder
 }
 
+// After mocks base method.
+func (m *MockClock) After(d time.Duration) <-chan time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "After", d)
+	ret0, _ := ret[0].(<-chan time.Time)
+	return ret0
+}
+
+// After indicates an expected call of After.
+func (mr *MockClockMockRecorder) After(d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "After", reflect.TypeOf((*MockClock)(nil).After), d)
+}
+
+// AfterFunc mocks base method.
+func (m *MockClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AfterFunc", d, f)
+	ret0, _ := ret[0].(clock.Timer)
+	return ret0
+}
+
+// AfterFunc indicates an expected call of AfterFunc.
+func (mr *MockClockMockRecorder) AfterFunc(d, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterFunc", reflect.TypeOf((*MockClock)(nil).AfterFunc), d, f)
+}
+
+// NewTicker mocks base method.
+func (m *MockClock) NewTicker(d time.Duration) clock.Ticker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTicker", d)
+	ret0, _ := ret[0].(clock.Ticker)
+	return ret0
+}
+
+// NewTicker indicates an expected call of NewTicker.
+func (mr *MockClockMockRecorder) NewTicker(d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTicker", reflect.TypeOf((*MockClock)(nil).NewTicker), d)
+}
+
 // Now mocks base method.
 func (m *MockClock) Now() time.Time {
 	m.ctrl.T.Helper()
@@ -51,3 +94,15 @@ func (mr *MockClockMockRecorder) Now() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClock)(nil).Now))
 }
+
+// Sleep mocks base method.
+func (m *MockClock) Sleep(d time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sleep", d)
+}
+
+// Sleep indicates an expected call of Sleep.
+func (mr *MockClockMockRecorder) Sleep(d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sleep", reflect.TypeOf((*MockClock)(nil).Sleep), d)
+}
@@ -0,0 +1,383 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// awsService is the name AWS signs Secrets Manager requests for
+const awsService = "secretsmanager"
+
+// awsContainerCredentialsHost serves the credentials of the task role to ECS and Fargate containers
+const awsContainerCredentialsHost = "http://169.254.170.2"
+
+// defaultAWSMetadataURL is the EC2 instance metadata service, which serves the credentials of the instance profile
+const defaultAWSMetadataURL = "http://169.254.169.254"
+
+// awsMetadataTimeout is how long the instance metadata service is waited on, it isn't there when not on EC2
+const awsMetadataTimeout = 2 * time.Second
+
+// awsMetadataTokenTTL is how many seconds the instance metadata session tokens asked for last
+const awsMetadataTokenTTL = "300"
+
+// defaultAWSRoleSessionName names the session a service account role is assumed in when AWS_ROLE_SESSION_NAME isn't set
+const defaultAWSRoleSessionName = "ronnied"
+
+// awsCredentials sign requests to AWS
+type awsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"Token"`
+}
+
+// getAWSSecret fetches the secret string of the secret, named by its name or ARN, from AWS Secrets Manager
+func (l *Loader) getAWSSecret(ctx context.Context, secretID string) (string, error) {
+	if secretID == "" {
+		return "", errors.New("secret name is missing")
+	}
+
+	region := awsRegion(secretID, l.getenv)
+	if region == "" {
+		return "", errors.New("AWS region is unknown, set AWS_REGION or refer to the secret by its ARN")
+	}
+
+	credentials, err := l.awsCredentials(ctx, region)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := l.awsEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsService, region)
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signAWSRequest(req, body, credentials, region, awsService, l.clock.Now())
+
+	var response struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := l.getJSON(req, &response); err != nil {
+		return "", err
+	}
+	if response.SecretString == nil {
+		return "", errors.New("secret is binary, store it as a string")
+	}
+
+	return *response.SecretString, nil
+}
+
+// awsRegion returns the region of the secret's ARN, or the region the environment picks for secrets referred to by
+// name
+func awsRegion(secretID string, getenv func(string) string) string {
+	// arn:aws:secretsmanager:<region>:<account>:secret:<name>
+	if parts := strings.Split(secretID, ":"); len(parts) > 3 && parts[0] == "arn" {
+		return parts[3]
+	}
+	if region := getenv("AWS_REGION"); region != "" {
+		return region
+	}
+	return getenv("AWS_DEFAULT_REGION")
+}
+
+// awsCredentials returns the credentials in the environment, or otherwise those of the EKS service account role,
+// the ECS and Fargate task role or the EC2 instance profile the bot runs with
+func (l *Loader) awsCredentials(ctx context.Context, region string) (*awsCredentials, error) {
+	if accessKeyID := l.getenv("AWS_ACCESS_KEY_ID"); accessKeyID != "" {
+		secretAccessKey := l.getenv("AWS_SECRET_ACCESS_KEY")
+		if secretAccessKey == "" {
+			return nil, errors.New("AWS_SECRET_ACCESS_KEY is required with AWS_ACCESS_KEY_ID")
+		}
+		return &awsCredentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    l.getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	if tokenFile := l.getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); tokenFile != "" {
+		return l.awsWebIdentityCredentials(ctx, region, tokenFile)
+	}
+
+	credentialsURL := l.getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if relativeURI := l.getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relativeURI != "" {
+		credentialsURL = awsContainerCredentialsHost + relativeURI
+	}
+	if credentialsURL != "" {
+		return l.awsContainerCredentials(ctx, credentialsURL)
+	}
+
+	if strings.EqualFold(l.getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		return nil, errors.New("no AWS credentials, set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or run with an " +
+			"EKS service account role, ECS task role or EC2 instance profile")
+	}
+
+	credentials, err := l.awsInstanceCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no AWS credentials, set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or run with an "+
+			"EKS service account role, ECS task role or EC2 instance profile: %w", err)
+	}
+	return credentials, nil
+}
+
+// awsContainerCredentials returns the credentials of the ECS or Fargate task role
+func (l *Loader) awsContainerCredentials(ctx context.Context, credentialsURL string) (*awsCredentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, credentialsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials request: %w", err)
+	}
+	if token := l.getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"); token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	var credentials awsCredentials
+	if err := l.getJSON(req, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to get the container's AWS credentials: %w", err)
+	}
+
+	return &credentials, nil
+}
+
+// awsWebIdentityCredentials assumes AWS_ROLE_ARN with the web identity token in the file, which is how EKS hands a
+// pod the role of its service account
+func (l *Loader) awsWebIdentityCredentials(ctx context.Context, region, tokenFile string) (*awsCredentials, error) {
+	roleARN := l.getenv("AWS_ROLE_ARN")
+	if roleARN == "" {
+		return nil, errors.New("AWS_ROLE_ARN is required with AWS_WEB_IDENTITY_TOKEN_FILE")
+	}
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AWS_WEB_IDENTITY_TOKEN_FILE: %w", err)
+	}
+
+	sessionName := l.getenv("AWS_ROLE_SESSION_NAME")
+	if sessionName == "" {
+		sessionName = defaultAWSRoleSessionName
+	}
+
+	endpoint := l.awsSTSEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com", region)
+	}
+
+	// AssumeRoleWithWebIdentity is authorized by the token rather than signed
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {sessionName},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create STS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	// STS answers in XML
+	var response struct {
+		Credentials struct {
+			AccessKeyID     string `xml:"AccessKeyId"`
+			SecretAccessKey string `xml:"SecretAccessKey"`
+			SessionToken    string `xml:"SessionToken"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+		Message string `xml:"Error>Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&response); err != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", req.URL.Host, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if response.Message == "" {
+			return nil, fmt.Errorf("failed to assume AWS_ROLE_ARN: %s returned status %d", req.URL.Host, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to assume AWS_ROLE_ARN: %s returned status %d: %s", req.URL.Host, resp.StatusCode,
+			response.Message)
+	}
+
+	return &awsCredentials{
+		AccessKeyID:     response.Credentials.AccessKeyID,
+		SecretAccessKey: response.Credentials.SecretAccessKey,
+		SessionToken:    response.Credentials.SessionToken,
+	}, nil
+}
+
+// awsInstanceCredentials returns the credentials of the EC2 instance profile from the instance metadata service,
+// using a session token as IMDSv2 requires
+func (l *Loader) awsInstanceCredentials(ctx context.Context) (*awsCredentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, awsMetadataTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, l.awsMetadataURL+"/latest/api/token", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata token request: %w", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", awsMetadataTokenTTL)
+	token, err := l.getText(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an instance metadata token: %w", err)
+	}
+
+	credentialsURL := l.awsMetadataURL + "/latest/meta-data/iam/security-credentials/"
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, credentialsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance profile request: %w", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+	roles, err := l.getText(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the instance profile: %w", err)
+	}
+	role := strings.TrimSpace(strings.SplitN(roles, "\n", 2)[0])
+	if role == "" {
+		return nil, errors.New("the instance has no instance profile")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, credentialsURL+url.PathEscape(role), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials request: %w", err)
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", token)
+
+	var credentials awsCredentials
+	if err := l.getJSON(req, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to get the instance profile's credentials: %w", err)
+	}
+
+	return &credentials, nil
+}
+
+// getText sends the request and returns the plain text it's answered with
+func (l *Loader) getText(req *http.Request) (string, error) {
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response from %s: %w", req.URL.Host, err)
+	}
+
+	return string(body), nil
+}
+
+// signAWSRequest signs the request with AWS Signature Version 4, adding its date, session token and authorization
+// headers. Every header already on the request is signed.
+func signAWSRequest(req *http.Request, body []byte, credentials *awsCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes the query string the way AWS signs it, sorted with spaces as %20
+func canonicalQuery(query url.Values) string {
+	var params []string
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, awsEscape(name)+"="+awsEscape(value))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// awsEscape percent-encodes everything but unreserved characters
+func awsEscape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
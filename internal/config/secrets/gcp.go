@@ -0,0 +1,82 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// defaultGCPEndpoint is Secret Manager's API
+	defaultGCPEndpoint = "https://secretmanager.googleapis.com/v1/"
+
+	// defaultGCPTokenURL hands out access tokens for the service account of the machine, Cloud Run service or GKE
+	// workload the bot runs as
+	defaultGCPTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// getGCPSecret fetches the secret version from GCP Secret Manager. The reference is the secret's resource name,
+// such as "projects/my-project/secrets/discord", with the latest version read unless it names one.
+func (l *Loader) getGCPSecret(ctx context.Context, name string) (string, error) {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "secrets":
+		name += "/versions/latest"
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+	default:
+		return "", errors.New("expected a secret such as projects/<project>/secrets/<secret>")
+	}
+
+	token, err := l.gcpAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(l.gcpEndpoint, "/")+"/"+name+":access", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var response struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := l.getJSON(req, &response); err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(response.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// gcpAccessToken returns the access token in GOOGLE_OAUTH_ACCESS_TOKEN, such as from
+// "gcloud auth print-access-token" when running locally, or otherwise one from the metadata server
+func (l *Loader) gcpAccessToken(ctx context.Context) (string, error) {
+	if token := l.getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.gcpTokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := l.getJSON(req, &response); err != nil {
+		return "", fmt.Errorf("failed to get a GCP access token, set GOOGLE_OAUTH_ACCESS_TOKEN when not running on GCP: %w", err)
+	}
+
+	return response.AccessToken, nil
+}
@@ -0,0 +1,209 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+)
+
+// Prefixes of settings that refer to a secret kept in a cloud secret manager
+const (
+	awsPrefix = "aws-secretsmanager://"
+	gcpPrefix = "gcp-secretmanager://"
+)
+
+// fileSuffix is added to a setting's name for the setting naming a file its value is read from
+const fileSuffix = "_FILE"
+
+// defaultHTTPTimeout is how long the secret managers are waited on by default
+const defaultHTTPTimeout = 10 * time.Second
+
+// Config holds configuration for the secrets loader
+type Config struct {
+	// Getenv reads environment variables (optional, defaults to os.Getenv)
+	Getenv func(key string) string
+
+	// HTTPClient used to call the secret managers (optional, defaults to a client with a 10 second timeout)
+	HTTPClient *http.Client
+
+	// Clock dates the requests signed for AWS (optional, defaults to the real clock)
+	Clock clock.Clock
+
+	// AWSEndpoint replaces the regional Secrets Manager endpoint, such as for a VPC endpoint (optional)
+	AWSEndpoint string
+
+	// AWSSTSEndpoint replaces the regional STS endpoint EKS service account roles are assumed through (optional)
+	AWSSTSEndpoint string
+
+	// AWSMetadataURL replaces the EC2 instance metadata address instance profile credentials are fetched from
+	// (optional)
+	AWSMetadataURL string
+
+	// GCPEndpoint replaces the Secret Manager endpoint (optional)
+	GCPEndpoint string
+
+	// GCPTokenURL replaces the metadata server address GCP access tokens are fetched from (optional)
+	GCPTokenURL string
+}
+
+// Loader reads secret settings from the environment, mounted files and cloud secret managers
+type Loader struct {
+	getenv         func(key string) string
+	httpClient     *http.Client
+	clock          clock.Clock
+	awsEndpoint    string
+	awsSTSEndpoint string
+	awsMetadataURL string
+	gcpEndpoint    string
+	gcpTokenURL    string
+}
+
+// New creates a secrets loader
+func New(cfg *Config) (*Loader, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	loader := &Loader{
+		getenv:         cfg.Getenv,
+		httpClient:     cfg.HTTPClient,
+		clock:          cfg.Clock,
+		awsEndpoint:    cfg.AWSEndpoint,
+		awsSTSEndpoint: cfg.AWSSTSEndpoint,
+		awsMetadataURL: cfg.AWSMetadataURL,
+		gcpEndpoint:    cfg.GCPEndpoint,
+		gcpTokenURL:    cfg.GCPTokenURL,
+	}
+	if loader.getenv == nil {
+		loader.getenv = os.Getenv
+	}
+	if loader.httpClient == nil {
+		loader.httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	if loader.clock == nil {
+		loader.clock = clock.New()
+	}
+	if loader.awsMetadataURL == "" {
+		loader.awsMetadataURL = defaultAWSMetadataURL
+	}
+	if loader.gcpEndpoint == "" {
+		loader.gcpEndpoint = defaultGCPEndpoint
+	}
+	if loader.gcpTokenURL == "" {
+		loader.gcpTokenURL = defaultGCPTokenURL
+	}
+
+	return loader, nil
+}
+
+// Get returns the secret setting key. It's read from the file named by key's _FILE setting, such as
+// DISCORD_TOKEN_FILE, when that's set. When the setting refers to a secret manager, such as
+// "aws-secretsmanager://ronnied/discord" or "gcp-secretmanager://projects/my-project/secrets/discord", the secret
+// is fetched from it, and a reference ending in "#field" picks that field out of a secret stored as JSON. Otherwise
+// the setting is returned as it is, empty when it isn't set.
+func (l *Loader) Get(ctx context.Context, key string) (string, error) {
+	value := l.getenv(key)
+	path := l.getenv(key + fileSuffix)
+	if path != "" {
+		if value != "" {
+			return "", fmt.Errorf("%s and %s are both set, set one of them", key, key+fileSuffix)
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", key+fileSuffix, err)
+		}
+		// Files written by editors and secret mounts usually end with a newline that isn't part of the secret
+		return strings.TrimSpace(string(contents)), nil
+	}
+
+	var secret string
+	var err error
+	switch {
+	case strings.HasPrefix(value, awsPrefix):
+		ref, field := splitField(strings.TrimPrefix(value, awsPrefix))
+		secret, err = l.getAWSSecret(ctx, ref)
+		if err == nil {
+			secret, err = jsonField(secret, field)
+		}
+	case strings.HasPrefix(value, gcpPrefix):
+		ref, field := splitField(strings.TrimPrefix(value, gcpPrefix))
+		secret, err = l.getGCPSecret(ctx, ref)
+		if err == nil {
+			secret, err = jsonField(secret, field)
+		}
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s from %s: %w", key, value, err)
+	}
+
+	return secret, nil
+}
+
+// splitField splits a reference to a secret from the JSON field after its "#", if there is one
+func splitField(ref string) (string, string) {
+	ref, field, _ := strings.Cut(ref, "#")
+	return ref, field
+}
+
+// jsonField picks the string field out of a secret stored as a JSON object, returning the secret itself when no
+// field is asked for
+func jsonField(secret, field string) (string, error) {
+	if field == "" {
+		return secret, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret isn't a JSON object, so has no field %q", field)
+	}
+
+	value, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("secret has no string field %q", field)
+	}
+
+	return value, nil
+}
+
+// getJSON calls a secret manager and decodes its JSON response
+func (l *Loader) getJSON(req *http.Request, response interface{}) error {
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var body struct {
+			Message string `json:"message"`
+			Error   struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		message := body.Message
+		if message == "" {
+			message = body.Error.Message
+		}
+		if message == "" {
+			return fmt.Errorf("%s returned status %d", req.URL.Host, resp.StatusCode)
+		}
+		return fmt.Errorf("%s returned status %d: %s", req.URL.Host, resp.StatusCode, message)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", req.URL.Host, err)
+	}
+
+	return nil
+}
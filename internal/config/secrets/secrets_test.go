@@ -0,0 +1,294 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecretsTestSuite struct {
+	suite.Suite
+	env    map[string]string
+	clock  *clock.Fake
+	server *httptest.Server
+	loader *Loader
+	ctx    context.Context
+
+	// handle answers the requests made to the test server
+	handle http.HandlerFunc
+}
+
+func (s *SecretsTestSuite) SetupTest() {
+	s.env = map[string]string{}
+	s.clock = clock.NewFake(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handle(w, r)
+	}))
+
+	var err error
+	s.loader, err = New(&Config{
+		Getenv:         func(key string) string { return s.env[key] },
+		Clock:          s.clock,
+		AWSEndpoint:    s.server.URL,
+		AWSSTSEndpoint: s.server.URL + "/sts",
+		AWSMetadataURL: s.server.URL + "/imds",
+		GCPEndpoint:    s.server.URL,
+		GCPTokenURL:    s.server.URL + "/token",
+	})
+	s.Require().NoError(err)
+	s.ctx = context.Background()
+}
+
+func (s *SecretsTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func TestSecretsTestSuite(t *testing.T) {
+	suite.Run(t, new(SecretsTestSuite))
+}
+
+func (s *SecretsTestSuite) TestPlainSetting() {
+	s.env["DISCORD_TOKEN"] = "token"
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+
+	value, err = s.loader.Get(s.ctx, "REDIS_PASSWORD")
+	s.Require().NoError(err)
+	s.Empty(value)
+}
+
+func (s *SecretsTestSuite) TestFile() {
+	path := filepath.Join(s.T().TempDir(), "discord_token")
+	s.Require().NoError(os.WriteFile(path, []byte("token\n"), 0o600))
+	s.env["DISCORD_TOKEN_FILE"] = path
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+
+	// Setting both is a mistake worth hearing about
+	s.env["DISCORD_TOKEN"] = "other"
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "set one of them")
+
+	delete(s.env, "DISCORD_TOKEN")
+	s.env["DISCORD_TOKEN_FILE"] = filepath.Join(s.T().TempDir(), "missing")
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "DISCORD_TOKEN_FILE")
+}
+
+func (s *SecretsTestSuite) TestAWS() {
+	s.env["REDIS_PASSWORD"] = "aws-secretsmanager://ronnied/redis#password"
+	s.env["AWS_REGION"] = "us-east-2"
+	s.env["AWS_ACCESS_KEY_ID"] = "AKID"
+	s.env["AWS_SECRET_ACCESS_KEY"] = "secret"
+	s.env["AWS_SESSION_TOKEN"] = "session"
+
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		s.Equal("20261016T120000Z", r.Header.Get("X-Amz-Date"))
+		s.Equal("session", r.Header.Get("X-Amz-Security-Token"))
+		s.True(strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20261016/us-east-2/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature="))
+
+		var body map[string]string
+		s.Require().NoError(json.NewDecoder(r.Body).Decode(&body))
+		s.Equal("ronnied/redis", body["SecretId"])
+
+		s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"password":"hunter2"}`}))
+	}
+
+	value, err := s.loader.Get(s.ctx, "REDIS_PASSWORD")
+	s.Require().NoError(err)
+	s.Equal("hunter2", value)
+}
+
+func (s *SecretsTestSuite) TestAWSContainerCredentials() {
+	s.env["DISCORD_TOKEN"] = "aws-secretsmanager://arn:aws:secretsmanager:eu-west-1:123456789012:secret:discord"
+	s.env["AWS_CONTAINER_CREDENTIALS_FULL_URI"] = s.server.URL + "/credentials"
+	s.env["AWS_CONTAINER_AUTHORIZATION_TOKEN"] = "container-token"
+
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/credentials" {
+			s.Equal("container-token", r.Header.Get("Authorization"))
+			s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"AccessKeyId": "ROLE", "SecretAccessKey": "secret", "Token": "role-session"}))
+			return
+		}
+
+		// The region comes from the ARN
+		s.Contains(r.Header.Get("Authorization"), "Credential=ROLE/20261016/eu-west-1/secretsmanager/aws4_request")
+		s.Equal("role-session", r.Header.Get("X-Amz-Security-Token"))
+		s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"SecretString": "token"}))
+	}
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+}
+
+func (s *SecretsTestSuite) TestAWSWebIdentityCredentials() {
+	tokenFile := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenFile, []byte("jwt\n"), 0o600))
+	s.env["DISCORD_TOKEN"] = "aws-secretsmanager://discord"
+	s.env["AWS_REGION"] = "us-west-2"
+	s.env["AWS_WEB_IDENTITY_TOKEN_FILE"] = tokenFile
+	s.env["AWS_ROLE_ARN"] = "arn:aws:iam::123456789012:role/ronnied"
+
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sts/" {
+			s.Require().NoError(r.ParseForm())
+			s.Equal("AssumeRoleWithWebIdentity", r.PostForm.Get("Action"))
+			s.Equal("arn:aws:iam::123456789012:role/ronnied", r.PostForm.Get("RoleArn"))
+			s.Equal("ronnied", r.PostForm.Get("RoleSessionName"))
+			s.Equal("jwt", r.PostForm.Get("WebIdentityToken"))
+			_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>` +
+				`<AccessKeyId>POD</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>pod-session</SessionToken>` +
+				`</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`))
+			return
+		}
+
+		s.Contains(r.Header.Get("Authorization"), "Credential=POD/20261016/us-west-2/secretsmanager/aws4_request")
+		s.Equal("pod-session", r.Header.Get("X-Amz-Security-Token"))
+		s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"SecretString": "token"}))
+	}
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+}
+
+func (s *SecretsTestSuite) TestAWSInstanceCredentials() {
+	s.env["DISCORD_TOKEN"] = "aws-secretsmanager://discord"
+	s.env["AWS_REGION"] = "us-east-1"
+
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/imds/latest/api/token":
+			s.Equal(http.MethodPut, r.Method)
+			s.NotEmpty(r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+			_, _ = w.Write([]byte("imds-token"))
+		case "/imds/latest/meta-data/iam/security-credentials/":
+			s.Equal("imds-token", r.Header.Get("X-aws-ec2-metadata-token"))
+			_, _ = w.Write([]byte("ronnied-instance"))
+		case "/imds/latest/meta-data/iam/security-credentials/ronnied-instance":
+			s.Equal("imds-token", r.Header.Get("X-aws-ec2-metadata-token"))
+			s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"AccessKeyId": "INSTANCE", "SecretAccessKey": "secret", "Token": "instance-session"}))
+		default:
+			s.Contains(r.Header.Get("Authorization"), "Credential=INSTANCE/20261016/us-east-1/secretsmanager/aws4_request")
+			s.Equal("instance-session", r.Header.Get("X-Amz-Security-Token"))
+			s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"SecretString": "token"}))
+		}
+	}
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+}
+
+func (s *SecretsTestSuite) TestAWSErrors() {
+	s.env["DISCORD_TOKEN"] = "aws-secretsmanager://discord"
+
+	_, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "AWS_REGION")
+
+	// Not on EC2, so there's no instance metadata service to ask
+	s.env["AWS_REGION"] = "us-east-1"
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "no AWS credentials")
+
+	s.env["AWS_EC2_METADATA_DISABLED"] = "true"
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "no AWS credentials")
+
+	s.env["AWS_WEB_IDENTITY_TOKEN_FILE"] = filepath.Join(s.T().TempDir(), "token")
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "AWS_ROLE_ARN is required")
+
+	s.env["AWS_ROLE_ARN"] = "arn:aws:iam::123456789012:role/ronnied"
+	s.Require().NoError(os.WriteFile(s.env["AWS_WEB_IDENTITY_TOKEN_FILE"], []byte("jwt"), 0o600))
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>InvalidIdentityToken</Code><Message>Incorrect token audience</Message></Error></ErrorResponse>`))
+	}
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "Incorrect token audience")
+	delete(s.env, "AWS_WEB_IDENTITY_TOKEN_FILE")
+
+	s.env["AWS_ACCESS_KEY_ID"] = "AKID"
+	s.env["AWS_SECRET_ACCESS_KEY"] = "secret"
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+	}
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "can't find the specified secret")
+}
+
+func (s *SecretsTestSuite) TestGCP() {
+	s.env["DISCORD_TOKEN"] = "gcp-secretmanager://projects/ronnied/secrets/discord"
+
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			s.Equal("Google", r.Header.Get("Metadata-Flavor"))
+			s.Require().NoError(json.NewEncoder(w).Encode(map[string]string{"access_token": "access"}))
+			return
+		}
+
+		s.Equal("/projects/ronnied/secrets/discord/versions/latest:access", r.URL.Path)
+		s.Equal("Bearer access", r.Header.Get("Authorization"))
+		s.Require().NoError(json.NewEncoder(w).Encode(map[string]interface{}{
+			"payload": map[string]string{"data": base64.StdEncoding.EncodeToString([]byte("token"))},
+		}))
+	}
+
+	value, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token", value)
+}
+
+func (s *SecretsTestSuite) TestGCPErrors() {
+	s.env["DISCORD_TOKEN"] = "gcp-secretmanager://discord"
+	_, err := s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "projects/<project>/secrets/<secret>")
+
+	s.env["DISCORD_TOKEN"] = "gcp-secretmanager://projects/ronnied/secrets/discord/versions/3#token"
+	s.env["GOOGLE_OAUTH_ACCESS_TOKEN"] = "local"
+	s.handle = func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/projects/ronnied/secrets/discord/versions/3:access", r.URL.Path)
+		s.Equal("Bearer local", r.Header.Get("Authorization"))
+		s.Require().NoError(json.NewEncoder(w).Encode(map[string]interface{}{
+			"payload": map[string]string{"data": base64.StdEncoding.EncodeToString([]byte("not json"))},
+		}))
+	}
+	_, err = s.loader.Get(s.ctx, "DISCORD_TOKEN")
+	s.ErrorContains(err, "isn't a JSON object")
+}
+
+func (s *SecretsTestSuite) TestSignAWSRequest() {
+	// The get-vanilla case from AWS' Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	s.Require().NoError(err)
+
+	signAWSRequest(req, nil, &awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	s.Equal("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
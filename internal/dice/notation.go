@@ -0,0 +1,250 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits keep ad-hoc rolls small enough to show in a message
+const (
+	maxTerms    = 10
+	maxDice     = 100
+	maxSides    = 1000
+	maxModifier = 1000
+	maxNotation = 64
+)
+
+// ErrInvalidNotation is returned when dice notation can't be parsed
+var ErrInvalidNotation = errors.New("invalid dice notation")
+
+// diceTermPattern matches a group of dice such as d20, 3d6 or 4d6kh3
+var diceTermPattern = regexp.MustCompile(`^(\d*)d(\d+)(?:kh?(\d+))?$`)
+
+// Term is one part of a dice expression, either a group of dice or a flat modifier
+type Term struct {
+	// Negative subtracts the term instead of adding it
+	Negative bool
+
+	// Count and Sides are the dice rolled, both zero for a flat modifier
+	Count int
+	Sides int
+
+	// KeepHighest keeps only that many of the highest dice, zero keeps them all
+	KeepHighest int
+
+	// Modifier is the flat amount added by a modifier term
+	Modifier int
+}
+
+// IsDice checks whether the term rolls dice rather than adding a flat amount
+func (t Term) IsDice() bool {
+	return t.Count > 0
+}
+
+// String returns the term in dice notation, without its sign
+func (t Term) String() string {
+	if !t.IsDice() {
+		return strconv.Itoa(t.Modifier)
+	}
+
+	notation := fmt.Sprintf("%dd%d", t.Count, t.Sides)
+	if t.KeepHighest > 0 {
+		notation += fmt.Sprintf("kh%d", t.KeepHighest)
+	}
+	return notation
+}
+
+// Expression is parsed dice notation such as 3d6+2, 4d6kh3 or d20+d4-1
+type Expression struct {
+	Terms []Term
+}
+
+// String returns the expression in normalized dice notation
+func (e *Expression) String() string {
+	var sb strings.Builder
+	for i, term := range e.Terms {
+		switch {
+		case term.Negative:
+			sb.WriteString("-")
+		case i > 0:
+			sb.WriteString("+")
+		}
+		sb.WriteString(term.String())
+	}
+	return sb.String()
+}
+
+// Parse reads dice notation: dice terms written NdM, with an optional khK to keep the K highest dice,
+// and flat modifiers, added or subtracted, e.g. 3d6+2, 4d6kh3 or d20+d4-1. Spaces and case are ignored.
+func Parse(notation string) (*Expression, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(notation), ""))
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: nothing to roll", ErrInvalidNotation)
+	}
+	if len(normalized) > maxNotation {
+		return nil, fmt.Errorf("%w: too long", ErrInvalidNotation)
+	}
+
+	var terms []Term
+	totalDice := 0
+	for _, part := range splitTerms(normalized) {
+		term, err := parseTerm(part)
+		if err != nil {
+			return nil, err
+		}
+
+		totalDice += term.Count
+		if totalDice > maxDice {
+			return nil, fmt.Errorf("%w: at most %d dice", ErrInvalidNotation, maxDice)
+		}
+
+		terms = append(terms, term)
+	}
+
+	if len(terms) > maxTerms {
+		return nil, fmt.Errorf("%w: at most %d terms", ErrInvalidNotation, maxTerms)
+	}
+	if totalDice == 0 {
+		return nil, fmt.Errorf("%w: there are no dice to roll", ErrInvalidNotation)
+	}
+
+	return &Expression{Terms: terms}, nil
+}
+
+// splitTerms splits notation before each + or -, keeping the sign with its term
+func splitTerms(notation string) []string {
+	var parts []string
+	start := 0
+	for i := 1; i < len(notation); i++ {
+		if notation[i] == '+' || notation[i] == '-' {
+			parts = append(parts, notation[start:i])
+			start = i
+		}
+	}
+	return append(parts, notation[start:])
+}
+
+// parseTerm parses a single signed term such as +2, -1 or 3d6
+func parseTerm(part string) (Term, error) {
+	var term Term
+	switch {
+	case strings.HasPrefix(part, "-"):
+		term.Negative = true
+		part = part[1:]
+	case strings.HasPrefix(part, "+"):
+		part = part[1:]
+	}
+
+	if part == "" {
+		return term, fmt.Errorf("%w: a sign has nothing after it", ErrInvalidNotation)
+	}
+
+	// A flat modifier
+	if modifier, err := strconv.Atoi(part); err == nil {
+		if modifier > maxModifier {
+			return term, fmt.Errorf("%w: modifiers can be at most %d", ErrInvalidNotation, maxModifier)
+		}
+		term.Modifier = modifier
+		return term, nil
+	}
+
+	match := diceTermPattern.FindStringSubmatch(part)
+	if match == nil {
+		return term, fmt.Errorf("%w: can't read %q", ErrInvalidNotation, part)
+	}
+
+	term.Count = 1
+	if match[1] != "" {
+		term.Count, _ = strconv.Atoi(match[1])
+	}
+	term.Sides, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		term.KeepHighest, _ = strconv.Atoi(match[3])
+	}
+
+	if term.Count < 1 || term.Count > maxDice {
+		return term, fmt.Errorf("%w: roll between 1 and %d dice", ErrInvalidNotation, maxDice)
+	}
+	if term.Sides < 2 || term.Sides > maxSides {
+		return term, fmt.Errorf("%w: dice need between 2 and %d sides", ErrInvalidNotation, maxSides)
+	}
+	if match[3] != "" && (term.KeepHighest < 1 || term.KeepHighest > term.Count) {
+		return term, fmt.Errorf("%w: can only keep between 1 and %d of %d dice", ErrInvalidNotation, term.Count, term.Count)
+	}
+
+	return term, nil
+}
+
+// TermResult is how one term of an expression rolled
+type TermResult struct {
+	Term Term
+
+	// Rolls are the dice rolled in order, empty for a modifier
+	Rolls []int
+
+	// Kept marks which of the rolls count towards the total
+	Kept []bool
+
+	// Total is what the term adds to the result, negative when it's subtracted
+	Total int
+}
+
+// Result is how an expression rolled
+type Result struct {
+	Expression *Expression
+	Terms      []TermResult
+	Total      int
+}
+
+// Roll rolls every die in the expression and adds up the terms
+func (e *Expression) Roll(roller Roller) *Result {
+	result := &Result{Expression: e}
+	for _, term := range e.Terms {
+		termResult := rollTerm(term, roller)
+		result.Terms = append(result.Terms, termResult)
+		result.Total += termResult.Total
+	}
+	return result
+}
+
+// rollTerm rolls a term's dice, keeping the highest if the term asks for it
+func rollTerm(term Term, roller Roller) TermResult {
+	termResult := TermResult{Term: term}
+
+	if !term.IsDice() {
+		termResult.Total = term.Modifier
+	} else {
+		termResult.Rolls = make([]int, term.Count)
+		termResult.Kept = make([]bool, term.Count)
+		for i := range termResult.Rolls {
+			termResult.Rolls[i] = roller.Roll(term.Sides)
+		}
+
+		// Keep the highest dice, the earliest roll winning ties
+		order := make([]int, term.Count)
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return termResult.Rolls[order[a]] > termResult.Rolls[order[b]]
+		})
+
+		keep := term.Count
+		if term.KeepHighest > 0 {
+			keep = term.KeepHighest
+		}
+		for _, i := range order[:keep] {
+			termResult.Kept[i] = true
+			termResult.Total += termResult.Rolls[i]
+		}
+	}
+
+	if term.Negative {
+		termResult.Total = -termResult.Total
+	}
+	return termResult
+}
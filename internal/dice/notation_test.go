@@ -0,0 +1,158 @@
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type NotationTestSuite struct {
+	suite.Suite
+	mockCtrl   *gomock.Controller
+	mockRoller *mocks.MockRoller
+}
+
+func (s *NotationTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockRoller = mocks.NewMockRoller(s.mockCtrl)
+}
+
+func (s *NotationTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestNotationTestSuite(t *testing.T) {
+	suite.Run(t, new(NotationTestSuite))
+}
+
+func (s *NotationTestSuite) TestParse() {
+	testCases := []struct {
+		notation string
+		expected []dice.Term
+		str      string
+	}{
+		{
+			notation: "3d6+2",
+			expected: []dice.Term{{Count: 3, Sides: 6}, {Modifier: 2}},
+			str:      "3d6+2",
+		},
+		{
+			notation: "d20",
+			expected: []dice.Term{{Count: 1, Sides: 20}},
+			str:      "1d20",
+		},
+		{
+			notation: " 4D6 KH3 ",
+			expected: []dice.Term{{Count: 4, Sides: 6, KeepHighest: 3}},
+			str:      "4d6kh3",
+		},
+		{
+			notation: "2d20k1",
+			expected: []dice.Term{{Count: 2, Sides: 20, KeepHighest: 1}},
+			str:      "2d20kh1",
+		},
+		{
+			notation: "2d8+d6-1",
+			expected: []dice.Term{{Count: 2, Sides: 8}, {Count: 1, Sides: 6}, {Negative: true, Modifier: 1}},
+			str:      "2d8+1d6-1",
+		},
+		{
+			notation: "5-d4",
+			expected: []dice.Term{{Modifier: 5}, {Negative: true, Count: 1, Sides: 4}},
+			str:      "5-1d4",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.notation, func() {
+			expression, err := dice.Parse(tc.notation)
+			s.Require().NoError(err)
+			s.Equal(tc.expected, expression.Terms)
+			s.Equal(tc.str, expression.String())
+		})
+	}
+}
+
+func (s *NotationTestSuite) TestParse_Invalid() {
+	testCases := []string{
+		"",
+		"banana",
+		"3d",
+		"d1",
+		"d1001",
+		"0d6",
+		"101d6",
+		"60d6+60d6",
+		"4d6kh5",
+		"4d6kh0",
+		"3d6+",
+		"3d6++2",
+		"5",
+		"3d6*2",
+		"d6+d6+d6+d6+d6+d6+d6+d6+d6+d6+d6",
+	}
+
+	for _, notation := range testCases {
+		s.Run(notation, func() {
+			_, err := dice.Parse(notation)
+			s.ErrorIs(err, dice.ErrInvalidNotation)
+		})
+	}
+}
+
+func (s *NotationTestSuite) TestRoll() {
+	expression, err := dice.Parse("3d6+2")
+	s.Require().NoError(err)
+
+	gomock.InOrder(
+		s.mockRoller.EXPECT().Roll(6).Return(4),
+		s.mockRoller.EXPECT().Roll(6).Return(1),
+		s.mockRoller.EXPECT().Roll(6).Return(6),
+	)
+
+	result := expression.Roll(s.mockRoller)
+
+	s.Equal(13, result.Total)
+	s.Require().Len(result.Terms, 2)
+	s.Equal([]int{4, 1, 6}, result.Terms[0].Rolls)
+	s.Equal([]bool{true, true, true}, result.Terms[0].Kept)
+	s.Equal(11, result.Terms[0].Total)
+	s.Equal(2, result.Terms[1].Total)
+}
+
+func (s *NotationTestSuite) TestRoll_KeepHighest() {
+	expression, err := dice.Parse("4d6kh3")
+	s.Require().NoError(err)
+
+	gomock.InOrder(
+		s.mockRoller.EXPECT().Roll(6).Return(3),
+		s.mockRoller.EXPECT().Roll(6).Return(5),
+		s.mockRoller.EXPECT().Roll(6).Return(3),
+		s.mockRoller.EXPECT().Roll(6).Return(2),
+	)
+
+	result := expression.Roll(s.mockRoller)
+
+	// Only one of the tied threes is dropped along with the two
+	s.Equal(11, result.Total)
+	s.Equal([]bool{true, true, true, false}, result.Terms[0].Kept)
+}
+
+func (s *NotationTestSuite) TestRoll_Subtracted() {
+	expression, err := dice.Parse("d20-d4-1")
+	s.Require().NoError(err)
+
+	gomock.InOrder(
+		s.mockRoller.EXPECT().Roll(20).Return(12),
+		s.mockRoller.EXPECT().Roll(4).Return(3),
+	)
+
+	result := expression.Roll(s.mockRoller)
+
+	s.Equal(8, result.Total)
+	s.Equal(-3, result.Terms[1].Total)
+	s.Equal(-1, result.Terms[2].Total)
+}
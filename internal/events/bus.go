@@ -0,0 +1,57 @@
+package events
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_bus.go github.com/KirkDiggler/ronnied/internal/events Bus
+
+import (
+	"context"
+	"sync"
+)
+
+// Bus delivers published events to subscribed handlers
+type Bus interface {
+	// Publish delivers an event to every handler subscribed to its type
+	Publish(ctx context.Context, event *Event)
+
+	// Subscribe registers a handler for an event type
+	Subscribe(eventType Type, handler Handler)
+}
+
+// bus is an in-memory, synchronous Bus implementation
+type bus struct {
+	mu       sync.RWMutex
+	handlers map[Type][]Handler
+}
+
+// NewBus creates a new in-memory event bus
+func NewBus() Bus {
+	return &bus{
+		handlers: make(map[Type][]Handler),
+	}
+}
+
+// Publish delivers an event to every handler subscribed to its type
+func (b *bus) Publish(ctx context.Context, event *Event) {
+	if event == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := append([]Handler(nil), b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
+
+// Subscribe registers a handler for an event type
+func (b *bus) Subscribe(eventType Type, handler Handler) {
+	if handler == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
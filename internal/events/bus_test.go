@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BusTestSuite struct {
+	suite.Suite
+	bus Bus
+	ctx context.Context
+}
+
+func (s *BusTestSuite) SetupTest() {
+	s.bus = NewBus()
+	s.ctx = context.Background()
+}
+
+func TestBusTestSuite(t *testing.T) {
+	suite.Run(t, new(BusTestSuite))
+}
+
+func (s *BusTestSuite) TestPublishDeliversToSubscribers() {
+	var received []*Event
+	s.bus.Subscribe(TypeGameCompleted, func(ctx context.Context, event *Event) {
+		received = append(received, event)
+	})
+	s.bus.Subscribe(TypeGameCompleted, func(ctx context.Context, event *Event) {
+		received = append(received, event)
+	})
+
+	event := &Event{Type: TypeGameCompleted, GameID: "game-1"}
+	s.bus.Publish(s.ctx, event)
+
+	s.Len(received, 2)
+	s.Equal("game-1", received[0].GameID)
+}
+
+func (s *BusTestSuite) TestPublishIgnoresOtherTypes() {
+	called := false
+	s.bus.Subscribe(TypeSessionLeaderboard, func(ctx context.Context, event *Event) {
+		called = true
+	})
+
+	s.bus.Publish(s.ctx, &Event{Type: TypeGameCompleted})
+
+	s.False(called)
+}
+
+func (s *BusTestSuite) TestPublishNilEvent() {
+	s.bus.Subscribe(TypeGameCompleted, func(ctx context.Context, event *Event) {
+		s.Fail("handler should not be called")
+	})
+
+	s.bus.Publish(s.ctx, nil)
+}
@@ -0,0 +1,299 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Type identifies the kind of domain event being published
+type Type string
+
+const (
+	// TypeGameCompleted is published when a game (not a roll-off) finishes
+	TypeGameCompleted Type = "game_completed"
+
+	// TypeSessionLeaderboard is published with the updated session standings after a game finishes
+	TypeSessionLeaderboard Type = "session_leaderboard"
+
+	// TypeSessionRotated is published with the closing leaderboard when a session is rotated automatically
+	TypeSessionRotated Type = "session_rotated"
+
+	// TypeMercyRule is published when a player hits the session drink cap and a drink is made social
+	TypeMercyRule Type = "mercy_rule"
+
+	// TypePacingWarning is published when a player pays drinks faster than the pacing config allows
+	TypePacingWarning Type = "pacing_warning"
+
+	// TypeDiceRolled is published for every roll, including roll-offs and re-rolls
+	TypeDiceRolled Type = "dice_rolled"
+
+	// TypeGameDurationWarning is published when a game is running out of time and players still haven't rolled
+	TypeGameDurationWarning Type = "game_duration_warning"
+
+	// TypeGameTimedOut is published when a game ran out of time and was ended without the players who hadn't rolled
+	TypeGameTimedOut Type = "game_timed_out"
+
+	// TypeSideBetsSettled is published when a player rolled and the side bets on their roll were settled
+	TypeSideBetsSettled Type = "side_bets_settled"
+
+	// TypeGameCreated is published when a game (not a roll-off) is created
+	TypeGameCreated Type = "game_created"
+
+	// TypeDrinkAssigned is published for every drink recorded in a game, whoever or whatever gave it
+	TypeDrinkAssigned Type = "drink_assigned"
+
+	// TypeLedgerChanged is published whenever the drinks in a session change: recorded, paid, forgiven,
+	// transferred or voided, a designated driver starting or stopping, or a new session starting
+	TypeLedgerChanged Type = "ledger_changed"
+
+	// TypeDrinkReminder is published when a player is reminded of drinks they've owed longer than the reminder delay
+	TypeDrinkReminder Type = "drink_reminder"
+
+	// TypeVoteClosed is published when the players' vote on a game passes or fails
+	TypeVoteClosed Type = "vote_closed"
+
+	// TypeWaitlistPromoted is published when a waitlisted player is given a spot that opened up in a full game
+	TypeWaitlistPromoted Type = "waitlist_promoted"
+
+	// TypePostGameFinished is published when the work left once a game completed was done in the background, so
+	// the game's message can be redrawn with the results
+	TypePostGameFinished Type = "post_game_finished"
+
+	// TypeSocialRound is published when a social round gave every player in a game a drink, from a social roll
+	// or called by an admin
+	TypeSocialRound Type = "social_round"
+
+	// TypeSessionGoalCompleted is published when a player completed one of the challenges the session is played with
+	TypeSessionGoalCompleted Type = "session_goal_completed"
+
+	// TypeJackpot is published when a drink went in the session's jackpot, or a critical fail took the lot
+	TypeJackpot Type = "jackpot"
+
+	// TypeRonnieRolled is published when Ronnie, the bot's own player, made a roll in a game he's playing
+	TypeRonnieRolled Type = "ronnie_rolled"
+)
+
+// Event is a domain event published by the services
+type Event struct {
+	// Type is the kind of event
+	Type Type
+
+	// ChannelID is the channel the event originated in
+	ChannelID string
+
+	// GuildID is the Discord server the event originated in, empty outside a server
+	GuildID string
+
+	// GameID is the game the event relates to, if any
+	GameID string
+
+	// SessionID is the session the event relates to, if any
+	SessionID string
+
+	// Timestamp is when the event happened
+	Timestamp time.Time
+
+	// Payload carries the event specific data (one of the *Payload types)
+	Payload interface{}
+}
+
+// PlayerResult is a single player's result in a completed game
+type PlayerResult struct {
+	PlayerID       string `json:"player_id"`
+	PlayerName     string `json:"player_name"`
+	RollValue      int    `json:"roll_value"`
+	DrinksAssigned int    `json:"drinks_assigned"`
+	DrinksReceived int    `json:"drinks_received"`
+}
+
+// GameCompletedPayload is the payload for TypeGameCompleted events
+type GameCompletedPayload struct {
+	Results []*PlayerResult `json:"results"`
+}
+
+// SessionStanding is a single row of the session leaderboard
+type SessionStanding struct {
+	PlayerID         string `json:"player_id"`
+	PlayerName       string `json:"player_name"`
+	DrinkCount       int    `json:"drink_count"`
+	PaidCount        int    `json:"paid_count"`
+	SocialCount      int    `json:"social_count,omitempty"`
+	ForgivenCount    int    `json:"forgiven_count,omitempty"`
+	DesignatedDriver bool   `json:"designated_driver,omitempty"`
+
+	// DrinksPerHour and LongestDryStreak are the player's pace over the session, zero until it has run a while
+	DrinksPerHour    float64       `json:"drinks_per_hour,omitempty"`
+	LongestDryStreak time.Duration `json:"longest_dry_streak,omitempty"`
+}
+
+// SessionLeaderboardPayload is the payload for TypeSessionLeaderboard events
+type SessionLeaderboardPayload struct {
+	Standings []*SessionStanding `json:"standings"`
+}
+
+// SessionRotatedPayload is the payload for TypeSessionRotated events.
+// The event's SessionID is the new session, the closing standings belong to the previous one.
+type SessionRotatedPayload struct {
+	PreviousSessionID string             `json:"previous_session_id"`
+	Reason            string             `json:"reason"`
+	Standings         []*SessionStanding `json:"standings"`
+
+	// StartedAt and EndedAt are when the previous session began and ended
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// MercyRulePayload is the payload for TypeMercyRule events
+type MercyRulePayload struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Reason     string `json:"reason"`
+	DrinkCap   int    `json:"drink_cap"`
+}
+
+// PacingWarningPayload is the payload for TypePacingWarning events
+type PacingWarningPayload struct {
+	PlayerID        string `json:"player_id"`
+	PlayerName      string `json:"player_name"`
+	DrinksPaid      int    `json:"drinks_paid"`
+	WindowMinutes   int    `json:"window_minutes"`
+	CooldownMinutes int    `json:"cooldown_minutes,omitempty"`
+}
+
+// DiceRolledPayload is the payload for TypeDiceRolled events.
+// The event's GameID is the game rolled in, RootGameID is the game a roll-off was started from.
+type DiceRolledPayload struct {
+	PlayerID     string `json:"player_id"`
+	PlayerName   string `json:"player_name"`
+	RollValue    int    `json:"roll_value"`
+	CriticalHit  bool   `json:"critical_hit,omitempty"`
+	CriticalFail bool   `json:"critical_fail,omitempty"`
+	RollOff      bool   `json:"roll_off,omitempty"`
+	Reroll       bool   `json:"reroll,omitempty"`
+	RootGameID   string `json:"root_game_id"`
+}
+
+// GameDurationWarningPayload is the payload for TypeGameDurationWarning events.
+// The event's GameID is the game running out of time, RootGameID is the game a roll-off was started from.
+type GameDurationWarningPayload struct {
+	PlayerIDs  []string  `json:"player_ids"`
+	EndsAt     time.Time `json:"ends_at"`
+	RootGameID string    `json:"root_game_id"`
+}
+
+// GameTimedOutPayload is the payload for TypeGameTimedOut events.
+// Abandoned is set when nobody had rolled, so the game was abandoned rather than ended. EndedBy is the host who
+// wrapped the game up, empty when it ran out of time.
+type GameTimedOutPayload struct {
+	SkippedPlayerIDs []string `json:"skipped_player_ids"`
+	Abandoned        bool     `json:"abandoned,omitempty"`
+	RootGameID       string   `json:"root_game_id"`
+	EndedBy          string   `json:"ended_by,omitempty"`
+}
+
+// SideBetsSettledPayload is the payload for TypeSideBetsSettled events
+type SideBetsSettledPayload struct {
+	PlayerID   string            `json:"player_id"`
+	PlayerName string            `json:"player_name"`
+	RollValue  int               `json:"roll_value"`
+	Bets       []*SettledSideBet `json:"bets"`
+}
+
+// SettledSideBet is a side bet settled by a roll, the loser of the bet takes a drink from the winner
+type SettledSideBet struct {
+	BettorID   string `json:"bettor_id"`
+	BettorName string `json:"bettor_name"`
+	Label      string `json:"label"`
+	Won        bool   `json:"won"`
+}
+
+// GameCreatedPayload is the payload for TypeGameCreated events
+type GameCreatedPayload struct {
+	CreatorID   string   `json:"creator_id"`
+	CreatorName string   `json:"creator_name"`
+	Countdown   bool     `json:"countdown,omitempty"`
+	Private     bool     `json:"private,omitempty"`
+	LastCall    bool     `json:"last_call,omitempty"`
+	Events      []string `json:"events,omitempty"`
+}
+
+// DrinkAssignedPayload is the payload for TypeDrinkAssigned events.
+// The event's GameID is the game the drink was given in, FromPlayerID is empty for drinks the game gave.
+type DrinkAssignedPayload struct {
+	FromPlayerID string `json:"from_player_id,omitempty"`
+	ToPlayerID   string `json:"to_player_id"`
+	Reason       string `json:"reason"`
+	Social       bool   `json:"social,omitempty"`
+}
+
+// LedgerChangedPayload is the payload for TypeLedgerChanged events
+type LedgerChangedPayload struct {
+	Change string `json:"change"`
+}
+
+// DrinkReminderPayload is the payload for TypeDrinkReminder events. Reminder counts up each time the player is
+// reminded about the same drinks, starting at 1, and Delivery is "channel" or "dm".
+type DrinkReminderPayload struct {
+	PlayerID      string    `json:"player_id"`
+	PlayerName    string    `json:"player_name"`
+	DrinkCount    int       `json:"drink_count"`
+	OldestDrinkAt time.Time `json:"oldest_drink_at"`
+	Reminder      int       `json:"reminder"`
+	Delivery      string    `json:"delivery"`
+}
+
+// VoteClosedPayload is the payload for TypeVoteClosed events. Action is "start" or "abandon", and a vote that
+// passed has had its action applied to the game.
+type VoteClosedPayload struct {
+	Action    string   `json:"action"`
+	CalledBy  string   `json:"called_by"`
+	Passed    bool     `json:"passed"`
+	Approvals []string `json:"approvals"`
+}
+
+// WaitlistPromotedPayload is the payload for TypeWaitlistPromoted events, LeftPlayerID is the player whose spot
+// was given away
+type WaitlistPromotedPayload struct {
+	PlayerID     string `json:"player_id"`
+	PlayerName   string `json:"player_name"`
+	LeftPlayerID string `json:"left_player_id"`
+}
+
+// SocialRoundPayload is the payload for TypeSocialRound events. RollValue is the roll that called the round,
+// zero when an admin called it.
+type SocialRoundPayload struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	RollValue  int    `json:"roll_value,omitempty"`
+	DrinkCount int    `json:"drink_count"`
+	RootGameID string `json:"root_game_id"`
+}
+
+// SessionGoalCompletedPayload is the payload for TypeSessionGoalCompleted events, Goal is the challenge's name
+type SessionGoalCompletedPayload struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Goal       string `json:"goal"`
+}
+
+// JackpotPayload is the payload for TypeJackpot events. When Won is set the player rolled the critical fail that took
+// the jackpot's Drinks, otherwise a drink went in for a tie, or for the player rolling doubles, making it Drinks.
+type JackpotPayload struct {
+	PlayerID   string `json:"player_id,omitempty"`
+	PlayerName string `json:"player_name,omitempty"`
+	Drinks     int    `json:"drinks"`
+	Won        bool   `json:"won,omitempty"`
+}
+
+// RonnieRolledPayload is the payload for TypeRonnieRolled events. The event's GameID is the game Ronnie rolled in,
+// GameIDs are the games whose messages his roll changed. DrinkToName is who he gave a critical hit's drink to.
+type RonnieRolledPayload struct {
+	RollValue    int      `json:"roll_value"`
+	CriticalHit  bool     `json:"critical_hit,omitempty"`
+	CriticalFail bool     `json:"critical_fail,omitempty"`
+	DrinkToName  string   `json:"drink_to_name,omitempty"`
+	GameIDs      []string `json:"game_ids"`
+}
+
+// Handler is called for every published event it is subscribed to
+type Handler func(ctx context.Context, event *Event)
@@ -0,0 +1,169 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord OAuth2 endpoints
+const (
+	discordAuthorizeURL = "https://discord.com/oauth2/authorize"
+	discordAPIURL       = "https://discord.com/api/v10"
+)
+
+// DiscordUser is the logged in Discord user
+type DiscordUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// DiscordGuild is a server the logged in user is a member of
+type DiscordGuild struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Owner       bool   `json:"owner"`
+	Permissions string `json:"permissions"`
+}
+
+// IsAdmin returns true if the user owns the server or can manage it, the same admins the bot's commands allow
+func (g *DiscordGuild) IsAdmin() bool {
+	if g.Owner {
+		return true
+	}
+
+	permissions, err := strconv.ParseInt(g.Permissions, 10, 64)
+	if err != nil {
+		return false
+	}
+	return permissions&(discordgo.PermissionManageServer|discordgo.PermissionAdministrator) != 0
+}
+
+// DiscordAPI is the part of Discord's API admins are logged in with
+type DiscordAPI interface {
+	// AuthorizeURL returns where to send the user to log in, state comes back with them
+	AuthorizeURL(state string) string
+
+	// Exchange trades the code the user came back with for an access token
+	Exchange(ctx context.Context, code string) (string, error)
+
+	// GetUser returns the user the access token belongs to
+	GetUser(ctx context.Context, accessToken string) (*DiscordUser, error)
+
+	// GetGuilds returns the servers the user is a member of
+	GetGuilds(ctx context.Context, accessToken string) ([]*DiscordGuild, error)
+}
+
+// discordOAuth implements DiscordAPI against Discord's OAuth2 endpoints
+type discordOAuth struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+}
+
+// newDiscordOAuth creates a client for Discord's OAuth2 endpoints
+func newDiscordOAuth(clientID, clientSecret, redirectURL string) *discordOAuth {
+	return &discordOAuth{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthorizeURL returns where to send the user to log in, state comes back with them
+func (d *discordOAuth) AuthorizeURL(state string) string {
+	query := url.Values{
+		"client_id":     {d.clientID},
+		"redirect_uri":  {d.redirectURL},
+		"response_type": {"code"},
+		"scope":         {"identify guilds"},
+		"state":         {state},
+		"prompt":        {"none"},
+	}
+	return discordAuthorizeURL + "?" + query.Encode()
+}
+
+// Exchange trades the code the user came back with for an access token
+func (d *discordOAuth) Exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"client_id":     {d.clientID},
+		"client_secret": {d.clientSecret},
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {d.redirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discordAPIURL+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := d.do(req, &token); err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("no access token returned")
+	}
+
+	return token.AccessToken, nil
+}
+
+// GetUser returns the user the access token belongs to
+func (d *discordOAuth) GetUser(ctx context.Context, accessToken string) (*DiscordUser, error) {
+	var user DiscordUser
+	if err := d.get(ctx, accessToken, "/users/@me", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetGuilds returns the servers the user is a member of
+func (d *discordOAuth) GetGuilds(ctx context.Context, accessToken string) ([]*DiscordGuild, error) {
+	var guilds []*DiscordGuild
+	if err := d.get(ctx, accessToken, "/users/@me/guilds", &guilds); err != nil {
+		return nil, err
+	}
+	return guilds, nil
+}
+
+// get reads an API endpoint as the user
+func (d *discordOAuth) get(ctx context.Context, accessToken, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discordAPIURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	return d.do(req, result)
+}
+
+// do sends the request and decodes the JSON response
+func (d *discordOAuth) do(req *http.Request, result interface{}) error {
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("discord returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
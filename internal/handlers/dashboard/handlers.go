@@ -0,0 +1,208 @@
+package dashboard
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+)
+
+// indexPage lists the servers the logged in user admins
+type indexPage struct {
+	Username string
+	Guilds   []*sessionGuild
+}
+
+// guildPage shows what's going on in a server
+type guildPage struct {
+	Username    string
+	Guild       *sessionGuild
+	Games       []*models.Game
+	Session     *models.Session
+	Leaderboard []game.LeaderboardEntry
+	Rules       models.GameRules
+
+	// Config and Flags are nil when the dashboard doesn't have their service
+	Config *models.GuildConfig
+	Flags  []*models.FeatureFlagState
+}
+
+// errorPage explains why a page couldn't be shown
+type errorPage struct {
+	Username string
+	Status   int
+	Message  string
+}
+
+// handleIndex lists the servers the logged in user admins, or asks them to log in
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	sess := s.currentSession(r)
+	if sess == nil {
+		s.render(w, http.StatusOK, "login.html", &indexPage{})
+		return
+	}
+
+	s.render(w, http.StatusOK, "index.html", &indexPage{
+		Username: sess.Username,
+		Guilds:   sess.Guilds,
+	})
+}
+
+// handleLogin sends the user to Discord to log in
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	state := make([]byte, 16)
+	if _, err := rand.Read(state); err != nil {
+		log.Printf("Error creating login state: %v", err)
+		s.renderError(w, http.StatusInternalServerError, "Couldn't start logging in.")
+		return
+	}
+
+	s.setCookie(w, stateCookie, hex.EncodeToString(state), stateDuration)
+	http.Redirect(w, r, s.discord.AuthorizeURL(hex.EncodeToString(state)), http.StatusFound)
+}
+
+// handleCallback logs in the user Discord sent back, remembering the servers they admin
+func (s *Server) handleCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	state, err := r.Cookie(stateCookie)
+	if err != nil || state.Value == "" || r.URL.Query().Get("state") != state.Value {
+		s.renderError(w, http.StatusBadRequest, "That login link has expired, try logging in again.")
+		return
+	}
+	s.setCookie(w, stateCookie, "", 0)
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		s.renderError(w, http.StatusUnauthorized, "Discord didn't log you in.")
+		return
+	}
+
+	accessToken, err := s.discord.Exchange(ctx, code)
+	if err != nil {
+		log.Printf("Error exchanging login code: %v", err)
+		s.renderError(w, http.StatusBadGateway, "Discord didn't log you in.")
+		return
+	}
+
+	user, err := s.discord.GetUser(ctx, accessToken)
+	if err != nil {
+		log.Printf("Error getting Discord user: %v", err)
+		s.renderError(w, http.StatusBadGateway, "Couldn't get your Discord account.")
+		return
+	}
+
+	guilds, err := s.discord.GetGuilds(ctx, accessToken)
+	if err != nil {
+		log.Printf("Error getting Discord guilds: %v", err)
+		s.renderError(w, http.StatusBadGateway, "Couldn't get your Discord servers.")
+		return
+	}
+
+	sess := &session{
+		UserID:    user.ID,
+		Username:  user.Username,
+		ExpiresAt: s.clock.Now().Add(sessionDuration),
+	}
+	for _, guild := range guilds {
+		if guild.IsAdmin() {
+			sess.Guilds = append(sess.Guilds, &sessionGuild{ID: guild.ID, Name: guild.Name})
+		}
+	}
+
+	if len(sess.Guilds) == 0 {
+		s.renderError(w, http.StatusForbidden, "The dashboard is for server admins, and you don't admin any servers.")
+		return
+	}
+
+	value, err := s.sign(sess)
+	if err != nil {
+		log.Printf("Error signing session: %v", err)
+		s.renderError(w, http.StatusInternalServerError, "Couldn't log you in.")
+		return
+	}
+
+	s.setCookie(w, sessionCookie, value, sessionDuration)
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// handleLogout logs the user out
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	s.setCookie(w, sessionCookie, "", 0)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// handleGuild shows a server's games, session, leaderboard and settings to its admins
+func (s *Server) handleGuild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sess := s.currentSession(r)
+	if sess == nil {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	guild := sess.guild(r.PathValue("guildID"))
+	if guild == nil {
+		s.renderError(w, http.StatusForbidden, "Only that server's admins can see its dashboard.")
+		return
+	}
+
+	overview, err := s.gameService.GetGuildOverview(ctx, &game.GetGuildOverviewInput{
+		GuildID: guild.ID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild overview: %v", err)
+		s.renderError(w, http.StatusInternalServerError, "Couldn't get the server's games.")
+		return
+	}
+
+	rules, err := s.gameService.GetGameRules(ctx, &game.GetGameRulesInput{
+		GuildID: guild.ID,
+	})
+	if err != nil {
+		log.Printf("Error getting game rules: %v", err)
+		s.renderError(w, http.StatusInternalServerError, "Couldn't get the game rules.")
+		return
+	}
+
+	page := &guildPage{
+		Username:    sess.Username,
+		Guild:       guild,
+		Games:       overview.ActiveGames,
+		Session:     overview.Session,
+		Leaderboard: overview.Leaderboard,
+		Rules:       rules.Rules,
+	}
+
+	if s.guildConfigService != nil {
+		config, err := s.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: guild.ID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			s.renderError(w, http.StatusInternalServerError, "Couldn't get the server's settings.")
+			return
+		}
+		page.Config = config.Config
+	}
+
+	if s.featureFlagService != nil {
+		flags, err := s.featureFlagService.GetFeatureFlags(ctx, &feature_flag.GetFeatureFlagsInput{
+			GuildID: guild.ID,
+		})
+		if err != nil {
+			log.Printf("Error getting feature flags: %v", err)
+			s.renderError(w, http.StatusInternalServerError, "Couldn't get the server's feature flags.")
+			return
+		}
+		page.Flags = flags.Flags
+	}
+
+	s.render(w, http.StatusOK, "guild.html", page)
+}
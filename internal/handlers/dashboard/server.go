@@ -0,0 +1,194 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// Server serves the admin dashboard
+type Server struct {
+	server             *http.Server
+	handler            http.Handler
+	gameService        game.Service
+	guildConfigService guild_config.Service
+	featureFlagService feature_flag.Service
+	discord            DiscordAPI
+	clock              clock.Clock
+	templates          *template.Template
+	config             *Config
+}
+
+// Config holds the configuration for the admin dashboard
+type Config struct {
+	// Address the HTTP server listens on (defaults to :8080)
+	ListenAddr string
+
+	// Discord application credentials admins log in with
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the dashboard's /callback address registered with the Discord application
+	RedirectURL string
+
+	// SessionSecret signs the login cookie
+	SessionSecret string
+
+	// Game service
+	GameService game.Service
+
+	// Guild config service, the guild's settings aren't shown without it
+	GuildConfigService guild_config.Service
+
+	// Feature flag service, the guild's feature flags aren't shown without it
+	FeatureFlagService feature_flag.Service
+
+	// Discord logs admins in (defaults to Discord's OAuth2 endpoints)
+	Discord DiscordAPI
+
+	// Clock (defaults to the system clock)
+	Clock clock.Clock
+}
+
+// New creates a new admin dashboard
+func New(cfg *Config) (*Server, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client ID cannot be empty")
+	}
+
+	if cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("client secret cannot be empty")
+	}
+
+	if cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("redirect URL cannot be empty")
+	}
+
+	if cfg.SessionSecret == "" {
+		return nil, fmt.Errorf("session secret cannot be empty")
+	}
+
+	if cfg.GameService == nil {
+		return nil, fmt.Errorf("game service cannot be nil")
+	}
+
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = ":8080"
+	}
+
+	if cfg.Discord == nil {
+		cfg.Discord = newDiscordOAuth(cfg.ClientID, cfg.ClientSecret, cfg.RedirectURL)
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+
+	templates, err := template.New("").Funcs(templateFuncs).ParseFS(templateFiles, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	s := &Server{
+		gameService:        cfg.GameService,
+		guildConfigService: cfg.GuildConfigService,
+		featureFlagService: cfg.FeatureFlagService,
+		discord:            cfg.Discord,
+		clock:              cfg.Clock,
+		templates:          templates,
+		config:             cfg,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /login", s.handleLogin)
+	mux.HandleFunc("GET /callback", s.handleCallback)
+	mux.HandleFunc("POST /logout", s.handleLogout)
+	mux.HandleFunc("GET /guilds/{guildID}", s.handleGuild)
+	s.handler = mux
+
+	s.server = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return s, nil
+}
+
+// Start begins serving the dashboard
+func (s *Server) Start() error {
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Dashboard HTTP server stopped: %v", err)
+		}
+	}()
+
+	log.Printf("Dashboard listening on %s", s.config.ListenAddr)
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(ctx)
+}
+
+// ServeHTTP serves the dashboard's pages
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// templateFuncs are the helpers the page templates use
+var templateFuncs = template.FuncMap{
+	"short": func(id string) string {
+		if len(id) > 8 {
+			return id[:8]
+		}
+		return id
+	},
+	"when": func(t time.Time) string {
+		return t.UTC().Format("Jan 2 15:04 MST")
+	},
+}
+
+// render writes a page, nothing is written when the template fails
+func (s *Server) render(w http.ResponseWriter, status int, name string, data interface{}) {
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		log.Printf("Error rendering %s: %v", name, err)
+		http.Error(w, "Something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+// renderError writes an error page
+func (s *Server) renderError(w http.ResponseWriter, status int, message string) {
+	s.render(w, status, "error.html", &errorPage{
+		Status:  status,
+		Message: message,
+	})
+}
@@ -0,0 +1,313 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	guildConfigMocks "github.com/KirkDiggler/ronnied/internal/services/guild_config/mocks"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// ServerTestSuite tests the dashboard against a mocked Discord API and the real game service
+type ServerTestSuite struct {
+	suite.Suite
+	mr              *miniredis.Miniredis
+	client          *redis.Client
+	mockCtrl        *gomock.Controller
+	discord         *fakeDiscord
+	mockGuildConfig *guildConfigMocks.MockService
+	gameService     game.Service
+	server          *Server
+	ctx             context.Context
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.discord = &fakeDiscord{}
+	s.mockGuildConfig = guildConfigMocks.NewMockService(s.mockCtrl)
+	s.ctx = context.Background()
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:           games,
+		PlayerRepo:         players,
+		DrinkLedgerRepo:    ledger,
+		GuildConfigService: s.mockGuildConfig,
+		DiceRoller:         diceMocks.NewMockRoller(s.mockCtrl),
+		UUIDGenerator:      uuid.New(),
+		Clock:              clock.New(),
+	})
+	s.Require().NoError(err)
+	s.gameService = gameSvc
+
+	s.server, err = New(&Config{
+		ClientID:           "client-id",
+		ClientSecret:       "client-secret",
+		RedirectURL:        "https://dashboard.example.com/callback",
+		SessionSecret:      "session-secret",
+		GameService:        gameSvc,
+		GuildConfigService: s.mockGuildConfig,
+		Discord:            s.discord,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+// fakeDiscord logs in kirk, an admin of the servers it's given
+type fakeDiscord struct {
+	guilds []*DiscordGuild
+}
+
+func (f *fakeDiscord) AuthorizeURL(state string) string {
+	return "https://discord.example.com/authorize?state=" + state
+}
+
+func (f *fakeDiscord) Exchange(ctx context.Context, code string) (string, error) {
+	if code != "the-code" {
+		return "", errors.New("invalid code")
+	}
+	return "access-token", nil
+}
+
+func (f *fakeDiscord) GetUser(ctx context.Context, accessToken string) (*DiscordUser, error) {
+	return &DiscordUser{ID: "user-1", Username: "kirk"}, nil
+}
+
+func (f *fakeDiscord) GetGuilds(ctx context.Context, accessToken string) ([]*DiscordGuild, error) {
+	return f.guilds, nil
+}
+
+// serve sends a request to the dashboard with the given cookies
+func (s *ServerTestSuite) serve(method, target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	s.server.ServeHTTP(rec, req)
+	return rec
+}
+
+// loggedIn returns the session cookie of an admin of the given servers
+func (s *ServerTestSuite) loggedIn(expiresAt time.Time, guilds ...*sessionGuild) *http.Cookie {
+	value, err := s.server.sign(&session{
+		UserID:    "user-1",
+		Username:  "kirk",
+		Guilds:    guilds,
+		ExpiresAt: expiresAt,
+	})
+	s.Require().NoError(err)
+	return &http.Cookie{Name: sessionCookie, Value: value}
+}
+
+// cookie returns the cookie the response set
+func (s *ServerTestSuite) cookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func (s *ServerTestSuite) TestNew_Validation() {
+	_, err := New(nil)
+	s.Error(err)
+
+	_, err = New(&Config{ClientID: "client-id", ClientSecret: "client-secret", RedirectURL: "https://example.com/callback"})
+	s.Error(err, "a session secret is required")
+
+	server, err := New(&Config{
+		ClientID:      "client-id",
+		ClientSecret:  "client-secret",
+		RedirectURL:   "https://example.com/callback",
+		SessionSecret: "secret",
+		GameService:   s.gameService,
+	})
+	s.Require().NoError(err)
+	s.Equal(":8080", server.config.ListenAddr)
+}
+
+func (s *ServerTestSuite) TestIndex_LoggedOut() {
+	rec := s.serve(http.MethodGet, "/")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `href="/login"`)
+}
+
+func (s *ServerTestSuite) TestLogin() {
+	rec := s.serve(http.MethodGet, "/login")
+	s.Equal(http.StatusFound, rec.Code)
+
+	state := s.cookie(rec, stateCookie)
+	s.Require().NotNil(state)
+	s.NotEmpty(state.Value)
+	s.True(state.HttpOnly)
+	s.True(state.Secure, "cookies are secure when the dashboard is served over https")
+	s.Equal("https://discord.example.com/authorize?state="+state.Value, rec.Header().Get("Location"))
+}
+
+func (s *ServerTestSuite) TestCallback() {
+	s.discord.guilds = []*DiscordGuild{
+		{ID: "guild-1", Name: "Owned", Owner: true, Permissions: "0"},
+		{ID: "guild-2", Name: "Managed", Permissions: "32"},
+		{ID: "guild-3", Name: "Member", Permissions: "1024"},
+	}
+
+	rec := s.serve(http.MethodGet, "/callback?"+url.Values{"code": {"the-code"}, "state": {"the-state"}}.Encode(),
+		&http.Cookie{Name: stateCookie, Value: "the-state"})
+	s.Equal(http.StatusFound, rec.Code)
+	s.Equal("/", rec.Header().Get("Location"))
+
+	sessionValue := s.cookie(rec, sessionCookie)
+	s.Require().NotNil(sessionValue)
+
+	sess, err := s.server.verify(sessionValue.Value)
+	s.Require().NoError(err)
+	s.Equal("kirk", sess.Username)
+	s.Equal([]*sessionGuild{{ID: "guild-1", Name: "Owned"}, {ID: "guild-2", Name: "Managed"}}, sess.Guilds)
+
+	rec = s.serve(http.MethodGet, "/", sessionValue)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), `href="/guilds/guild-1"`)
+	s.Contains(rec.Body.String(), `href="/guilds/guild-2"`)
+	s.NotContains(rec.Body.String(), "guild-3", "servers the user doesn't admin aren't listed")
+}
+
+func (s *ServerTestSuite) TestCallback_StateMismatch() {
+	rec := s.serve(http.MethodGet, "/callback?code=the-code&state=forged", &http.Cookie{Name: stateCookie, Value: "the-state"})
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Nil(s.cookie(rec, sessionCookie))
+
+	rec = s.serve(http.MethodGet, "/callback?code=the-code&state=the-state")
+	s.Equal(http.StatusBadRequest, rec.Code, "a login has to start at /login")
+}
+
+func (s *ServerTestSuite) TestCallback_NotAnAdmin() {
+	s.discord.guilds = []*DiscordGuild{
+		{ID: "guild-3", Name: "Member", Permissions: "1024"},
+	}
+
+	rec := s.serve(http.MethodGet, "/callback?code=the-code&state=the-state", &http.Cookie{Name: stateCookie, Value: "the-state"})
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Nil(s.cookie(rec, sessionCookie))
+}
+
+func (s *ServerTestSuite) TestSession_Rejected() {
+	guild := &sessionGuild{ID: "guild-1", Name: "Owned"}
+
+	expired := s.loggedIn(time.Now().Add(-time.Minute), guild)
+	rec := s.serve(http.MethodGet, "/", expired)
+	s.Contains(rec.Body.String(), `href="/login"`, "expired logins are logged out")
+
+	tampered := s.loggedIn(time.Now().Add(time.Hour), guild)
+	tampered.Value = tampered.Value + "x"
+	rec = s.serve(http.MethodGet, "/", tampered)
+	s.Contains(rec.Body.String(), `href="/login"`, "tampered logins are logged out")
+}
+
+func (s *ServerTestSuite) TestLogout() {
+	rec := s.serve(http.MethodPost, "/logout", s.loggedIn(time.Now().Add(time.Hour)))
+	s.Equal(http.StatusSeeOther, rec.Code)
+
+	cleared := s.cookie(rec, sessionCookie)
+	s.Require().NotNil(cleared)
+	s.Empty(cleared.Value)
+	s.Less(cleared.MaxAge, 0)
+
+	rec = s.serve(http.MethodGet, "/logout")
+	s.Equal(http.StatusMethodNotAllowed, rec.Code)
+}
+
+func (s *ServerTestSuite) TestGuild() {
+	created, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   "channel-1",
+		GuildID:     "guild-1",
+		CreatorID:   "player-1",
+		CreatorName: "Dotty",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.StartGame(s.ctx, &game.StartGameInput{GameID: created.GameID, PlayerID: "player-1"})
+	s.Require().NoError(err)
+
+	// The settings are shown, and the rules reflect the server's overrides
+	s.mockGuildConfig.EXPECT().GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "guild-1"}).
+		Return(&guild_config.GetGuildConfigOutput{Config: &models.GuildConfig{
+			GuildID:           "guild-1",
+			DiceSides:         20,
+			CriticalHitValues: []int{5, 6},
+			Rating:            models.ContentRatingPG13,
+		}}, nil).Times(2)
+
+	rec := s.serve(http.MethodGet, "/guilds/guild-1", s.loggedIn(time.Now().Add(time.Hour), &sessionGuild{ID: "guild-1", Name: "Owned"}))
+	s.Require().Equal(http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	s.Contains(body, "Owned")
+	s.Contains(body, "Dotty")
+	s.Contains(body, "channel-1")
+	s.Contains(body, "No session is running")
+	s.Contains(body, "d20")
+	s.Contains(body, "[5 6] (server)")
+	s.Contains(body, string(models.ContentRatingPG13))
+}
+
+func (s *ServerTestSuite) TestGuild_NotAnAdmin() {
+	rec := s.serve(http.MethodGet, "/guilds/guild-2", s.loggedIn(time.Now().Add(time.Hour), &sessionGuild{ID: "guild-1", Name: "Owned"}))
+	s.Equal(http.StatusForbidden, rec.Code)
+
+	rec = s.serve(http.MethodGet, "/guilds/guild-1")
+	s.Equal(http.StatusFound, rec.Code)
+	s.Equal("/login", rec.Header().Get("Location"))
+}
+
+func (s *ServerTestSuite) TestDiscordGuild_IsAdmin() {
+	s.True((&DiscordGuild{Owner: true, Permissions: "0"}).IsAdmin())
+	s.True((&DiscordGuild{Permissions: "8"}).IsAdmin(), "administrator")
+	s.True((&DiscordGuild{Permissions: "32"}).IsAdmin(), "manage server")
+	s.False((&DiscordGuild{Permissions: "1024"}).IsAdmin())
+	s.False((&DiscordGuild{Permissions: "not-a-number"}).IsAdmin())
+}
@@ -0,0 +1,124 @@
+package dashboard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cookies set by the dashboard
+const (
+	sessionCookie = "ronnied_session"
+	stateCookie   = "ronnied_oauth_state"
+)
+
+// How long logins and login attempts last
+const (
+	sessionDuration = 12 * time.Hour
+	stateDuration   = 10 * time.Minute
+)
+
+// errInvalidSession is returned for a session cookie that's been tampered with or has expired
+var errInvalidSession = errors.New("invalid session")
+
+// session is a logged in admin, kept in a signed cookie. The servers they admin are checked when they log in.
+type session struct {
+	UserID    string          `json:"user_id"`
+	Username  string          `json:"username"`
+	Guilds    []*sessionGuild `json:"guilds"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// sessionGuild is a server the logged in user admins
+type sessionGuild struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// guild returns the server if the user admins it, nil otherwise
+func (s *session) guild(guildID string) *sessionGuild {
+	for _, guild := range s.Guilds {
+		if guild.ID == guildID {
+			return guild
+		}
+	}
+	return nil
+}
+
+// sign encodes the session with its signature
+func (s *Server) sign(sess *session) (string, error) {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + s.signature(payload), nil
+}
+
+// verify decodes a signed session, rejecting ones that have been tampered with or have expired
+func (s *Server) verify(value string) (*session, error) {
+	payload, signature, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.signature(payload))) {
+		return nil, errInvalidSession
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, errInvalidSession
+	}
+
+	var sess session
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, errInvalidSession
+	}
+
+	if !s.clock.Now().Before(sess.ExpiresAt) {
+		return nil, errInvalidSession
+	}
+	return &sess, nil
+}
+
+// signature returns the payload's HMAC keyed with the session secret
+func (s *Server) signature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.SessionSecret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// currentSession returns the logged in admin, nil when nobody is
+func (s *Server) currentSession(r *http.Request) *session {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return nil
+	}
+
+	sess, err := s.verify(cookie.Value)
+	if err != nil {
+		return nil
+	}
+	return sess
+}
+
+// setCookie sets one of the dashboard's cookies, a zero duration clears it
+func (s *Server) setCookie(w http.ResponseWriter, name, value string, duration time.Duration) {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.config.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if duration > 0 {
+		cookie.Expires = s.clock.Now().Add(duration)
+	} else {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
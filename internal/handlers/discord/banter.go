@@ -0,0 +1,77 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// banterCommand returns the subcommand for turning freshly written roll comments on or off
+func banterCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "banter",
+		Description: "See or change whether roll comments are freshly written",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "enabled",
+				Description: "Whether roll comments are freshly written",
+			},
+		},
+	}
+}
+
+// handleBanter shows whether the server's roll comments are freshly written, or turns it on or off
+func (c *RonniedCommand) handleBanter(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Banter settings are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Banter can only be set up in a server.")
+	}
+
+	var enabled *bool
+	for _, opt := range subcommand.Options {
+		if opt.Name == "enabled" {
+			value := opt.BoolValue()
+			enabled = &value
+		}
+	}
+
+	if enabled == nil {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the banter setting: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "**Banter**\n"+banterDescription(output.Config.Banter))
+	}
+
+	output, err := c.guildConfigService.SetBanter(ctx, &guild_config.SetBanterInput{
+		GuildID: i.GuildID,
+		Enabled: *enabled,
+	})
+	if err != nil {
+		log.Printf("Error setting banter: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the banter: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Banter updated**\n"+banterDescription(output.Config.Banter))
+}
+
+// banterDescription describes whether roll comments are freshly written
+func banterDescription(enabled bool) string {
+	if enabled {
+		return "On: roll comments are freshly written when the bot has an LLM set up, with the usual lines filling in when it doesn't or gets too busy."
+	}
+	return "Off: roll comments come from Ronnie's usual lines."
+}
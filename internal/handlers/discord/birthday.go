@@ -0,0 +1,138 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// birthdayCommand returns the subcommand for sharing your birthday
+func birthdayCommand() *discordgo.ApplicationCommandOption {
+	months := make([]*discordgo.ApplicationCommandOptionChoice, 0, 12)
+	for month := time.January; month <= time.December; month++ {
+		months = append(months, &discordgo.ApplicationCommandOptionChoice{Name: month.String(), Value: int(month)})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "birthday",
+		Description: "See, set or clear your birthday, critical hits go to you on the day",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "month",
+				Description: "The month of your birthday",
+				Choices:     months,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "day",
+				Description: "The day of the month of your birthday",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "clear",
+				Description: "Forget your birthday",
+			},
+		},
+	}
+}
+
+// handleBirthday shows the player their birthday, or saves or clears it
+func (c *RonniedCommand) handleBirthday(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, userID, username string) error {
+	ctx := context.Background()
+
+	var month, day int
+	var forget bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "month":
+			month = int(opt.IntValue())
+		case "day":
+			day = int(opt.IntValue())
+		case "clear":
+			forget = opt.BoolValue()
+		}
+	}
+
+	if forget {
+		if _, err := c.gameService.SetBirthday(ctx, &game.SetBirthdayInput{
+			PlayerID:   userID,
+			PlayerName: username,
+		}); err != nil {
+			log.Printf("Error clearing birthday: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't clear your birthday: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "Your birthday has been forgotten.")
+	}
+
+	if month == 0 && day == 0 {
+		output, err := c.gameService.GetBirthday(ctx, &game.GetBirthdayInput{
+			PlayerID: userID,
+		})
+		if err != nil {
+			log.Printf("Error getting birthday: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get your birthday: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, birthdayDescription(output.Birthday, output.IsToday))
+	}
+
+	if month == 0 || day == 0 {
+		return RespondWithEphemeralMessage(s, i, "Give both the month and the day of your birthday.")
+	}
+
+	output, err := c.gameService.SetBirthday(ctx, &game.SetBirthdayInput{
+		PlayerID:   userID,
+		PlayerName: username,
+		Birthday:   &models.Birthday{Month: time.Month(month), Day: day},
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrInvalidBirthday) {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("%s doesn't have a day %d.", time.Month(month), day))
+		}
+		log.Printf("Error setting birthday: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't save your birthday: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Got it, your birthday is %s. Every critical hit in your games that day goes to you! 🎂", output.Birthday))
+}
+
+// birthdayDescription tells a player what birthday they've shared
+func birthdayDescription(birthday *models.Birthday, isToday bool) string {
+	switch {
+	case birthday == nil:
+		return "You haven't shared your birthday. Use `/ronnied birthday month:<month> day:<day>` and critical hits go to you on the day."
+	case isToday:
+		return "🎉 Happy birthday! Every critical hit in your games today goes to you."
+	}
+	return fmt.Sprintf("Your birthday is %s.", birthday)
+}
+
+// birthdayRollLine celebrates a critical hit whose drink has to go to the birthday player, empty when nobody's celebrating
+func (b *Bot) birthdayRollLine(ctx context.Context, rollOutput *game.RollDiceOutput) string {
+	if len(rollOutput.BirthdayPlayers) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(rollOutput.BirthdayPlayers))
+	for _, player := range rollOutput.BirthdayPlayers {
+		names = append(names, player.PlayerName)
+	}
+
+	output, err := b.messagingService.GetBirthdayMessage(ctx, &messaging.GetBirthdayMessageInput{
+		PlayerName:          rollOutput.PlayerName,
+		BirthdayPlayerNames: names,
+	})
+	if err != nil {
+		log.Printf("Error getting birthday message: %v", err)
+		return ""
+	}
+	return output.Message
+}
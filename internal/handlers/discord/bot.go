@@ -5,21 +5,45 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
 	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
 	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/KirkDiggler/ronnied/internal/services/preset"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	"github.com/KirkDiggler/ronnied/internal/services/webhook"
 	"github.com/bwmarrin/discordgo"
 )
 
 // Bot represents the Discord bot instance
 type Bot struct {
-	session          *discordgo.Session
-	gameService      game.Service
-	messagingService messaging.Service
-	commands         map[string]CommandHandler
-	commandIDs       map[string]string // Maps command name to command ID
-	config           *Config
+	session            *discordgo.Session
+	api                DiscordSession
+	gameService        game.Service
+	messagingService   messaging.Service
+	webhookService     webhook.Service
+	presetService      preset.Service
+	seasonalService    seasonal.Service
+	guildConfigService guild_config.Service
+	featureFlagService feature_flag.Service
+	commands           map[string]CommandHandler
+	commandIDs         map[string]string // Maps command name to command ID
+	updates            *messageUpdateQueue
+	pins               *messageUpdateQueue
+	interactions       *interactionTracker
+	renders            *renderCache
+	rollLogs           *rollLogs
+	locales            *guildLocales
+	soundboard         *soundboard
+	feed               *gameFeed
+	clock              clock.Clock
+	config             *Config
 }
 
 // Config holds the configuration for the bot
@@ -38,6 +62,53 @@ type Config struct {
 
 	// Messaging service
 	MessagingService messaging.Service
+
+	// Webhook service for outbound integrations (optional)
+	WebhookService webhook.Service
+
+	// Preset service for named game presets (optional)
+	PresetService preset.Service
+
+	// Seasonal service for the seasonal event opt-out (optional)
+	SeasonalService seasonal.Service
+
+	// Guild config service for per-server settings such as the roll feed (optional)
+	GuildConfigService guild_config.Service
+
+	// Feature flag service for turning features on or off per server (optional, everything is on when nil)
+	FeatureFlagService feature_flag.Service
+
+	// EventBus the game service publishes to (optional, required for webhooks)
+	EventBus events.Bus
+
+	// Session used for Discord API calls (optional, defaults to the bot's own session)
+	Session DiscordSession
+
+	// How long updates to a channel's game message are coalesced into one edit (optional, defaults to one second)
+	MessageUpdateWindow time.Duration
+
+	// Whether closing leaderboards are posted as an image with avatars and drink bars (optional, text embeds when false)
+	LeaderboardImages bool
+
+	// Whether players who leave a server are taken out of their games (optional). It needs the privileged Server
+	// Members intent turned on for the bot.
+	MemberLeaves bool
+
+	// Whether players' saved names follow them changing their server nickname (optional). It needs the privileged
+	// Server Members intent turned on for the bot.
+	NicknameSync bool
+
+	// Directory of DCA sound effects played in servers' voice channels (optional, the soundboard is off without it)
+	SoundsDir string
+
+	// How long after one sound effect starts before a server can play the next (optional, defaults to 15 seconds)
+	SoundboardCooldown time.Duration
+
+	// How long shutdown waits for the interactions being handled to finish (optional, defaults to 10 seconds)
+	DrainTimeout time.Duration
+
+	// Clock the countdowns and message updates are timed with (optional, defaults to the system clock)
+	Clock clock.Clock
 }
 
 // New creates a new Discord bot
@@ -64,17 +135,114 @@ func New(cfg *Config) (*Bot, error) {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
+	api := cfg.Session
+	if api == nil {
+		api = session
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+
 	bot := &Bot{
-		session:          session,
-		gameService:      cfg.GameService,
-		messagingService: cfg.MessagingService,
-		commands:         make(map[string]CommandHandler),
-		commandIDs:       make(map[string]string),
-		config:           cfg,
+		session:            session,
+		api:                api,
+		gameService:        cfg.GameService,
+		messagingService:   cfg.MessagingService,
+		webhookService:     cfg.WebhookService,
+		presetService:      cfg.PresetService,
+		seasonalService:    cfg.SeasonalService,
+		guildConfigService: cfg.GuildConfigService,
+		featureFlagService: cfg.FeatureFlagService,
+		commands:           make(map[string]CommandHandler),
+		commandIDs:         make(map[string]string),
+		interactions:       newInteractionTracker(),
+		renders:            newRenderCache(),
+		rollLogs:           newRollLogs(),
+		locales:            newGuildLocales(),
+		feed:               newGameFeed(cfg.GuildConfigService, cfg.Clock),
+		clock:              cfg.Clock,
+		config:             cfg,
+	}
+	if cfg.SoundsDir != "" {
+		bot.soundboard, err = loadSoundboard(cfg.SoundsDir, cfg.Clock, cfg.SoundboardCooldown)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load soundboard: %w", err)
+		}
 	}
+	bot.updates = newMessageUpdateQueue(cfg.Clock, cfg.MessageUpdateWindow, bot.editGameMessage)
+	bot.pins = newMessageUpdateQueue(cfg.Clock, cfg.MessageUpdateWindow, bot.editPinnedLeaderboard)
 
 	// Register the interaction handler
-	session.AddHandler(bot.handleInteraction)
+	session.AddHandler(func(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+		bot.handleInteraction(bot.api, i)
+	})
+
+	// Take players who leave a server out of their games
+	if cfg.MemberLeaves {
+		session.Identify.Intents |= discordgo.IntentsGuildMembers
+		session.AddHandler(func(_ *discordgo.Session, m *discordgo.GuildMemberRemove) {
+			bot.handleGuildMemberRemove(m)
+		})
+	}
+
+	// Learn the preferred locale of each server the bot is in, and post the setup message in servers it's added to
+	session.AddHandler(func(_ *discordgo.Session, g *discordgo.GuildCreate) {
+		if g.Guild != nil {
+			bot.locales.remember(g.ID, discordgo.Locale(g.PreferredLocale))
+		}
+		if cfg.GuildConfigService != nil {
+			bot.handleGuildCreate(g)
+		}
+	})
+
+	// Keep players' names in step with their nicknames
+	if cfg.NicknameSync {
+		session.Identify.Intents |= discordgo.IntentsGuildMembers
+		session.AddHandler(func(_ *discordgo.Session, m *discordgo.GuildMemberUpdate) {
+			bot.handleGuildMemberUpdate(m)
+		})
+	}
+
+	// Forward game events to registered webhooks
+	if cfg.EventBus != nil && cfg.WebhookService != nil {
+		cfg.EventBus.Subscribe(events.TypeGameCompleted, bot.handleWebhookEvent)
+		cfg.EventBus.Subscribe(events.TypeSessionLeaderboard, bot.handleWebhookEvent)
+		cfg.EventBus.Subscribe(events.TypeSessionRotated, bot.handleWebhookEvent)
+	}
+
+	// Announce session rotations, the mercy rule and pacing checks, games running out of time and settled side bets
+	// in the channel, remind players of drinks they've owed a long time, redraw games players voted on or that were
+	// finished off in the background, ping players who got a spot off a waitlist, celebrate completed challenges and
+	// follow the jackpot and Ronnie's rolls
+	if cfg.EventBus != nil {
+		cfg.EventBus.Subscribe(events.TypeSessionRotated, bot.handleSessionRotated)
+		cfg.EventBus.Subscribe(events.TypeMercyRule, bot.handleMercyRule)
+		cfg.EventBus.Subscribe(events.TypeSocialRound, bot.handleSocialRound)
+		cfg.EventBus.Subscribe(events.TypePacingWarning, bot.handlePacingWarning)
+		cfg.EventBus.Subscribe(events.TypeGameDurationWarning, bot.handleGameDurationWarning)
+		cfg.EventBus.Subscribe(events.TypeGameTimedOut, bot.handleGameTimedOut)
+		cfg.EventBus.Subscribe(events.TypeSideBetsSettled, bot.handleSideBetsSettled)
+		cfg.EventBus.Subscribe(events.TypeDrinkReminder, bot.handleDrinkReminder)
+		cfg.EventBus.Subscribe(events.TypeVoteClosed, bot.handleVoteClosed)
+		cfg.EventBus.Subscribe(events.TypeWaitlistPromoted, bot.handleWaitlistPromoted)
+		cfg.EventBus.Subscribe(events.TypePostGameFinished, bot.handlePostGameFinished)
+		cfg.EventBus.Subscribe(events.TypeSessionGoalCompleted, bot.handleSessionGoalCompleted)
+		cfg.EventBus.Subscribe(events.TypeJackpot, bot.handleJackpot)
+		cfg.EventBus.Subscribe(events.TypeRonnieRolled, bot.handleRonnieRolled)
+	}
+
+	// Post rolls publicly in servers that have turned on the roll feed and keep pinned leaderboards up to date
+	if cfg.EventBus != nil && cfg.GuildConfigService != nil {
+		cfg.EventBus.Subscribe(events.TypeDiceRolled, bot.handleDiceRolled)
+		cfg.EventBus.Subscribe(events.TypeLedgerChanged, bot.handleLedgerChanged)
+	}
+
+	// Play sound effects in the voice channel of servers that have turned on the soundboard
+	if cfg.EventBus != nil && cfg.GuildConfigService != nil && bot.soundboard != nil {
+		cfg.EventBus.Subscribe(events.TypeDiceRolled, bot.handleSoundboardRoll)
+		cfg.EventBus.Subscribe(events.TypeGameCompleted, bot.handleSoundboardGameCompleted)
+	}
 
 	return bot, nil
 }
@@ -86,18 +254,44 @@ func (b *Bot) Start() error {
 		return fmt.Errorf("failed to open Discord connection: %w", err)
 	}
 
-	// Register the ronnied command
-	ronniedCmd := NewRonniedCommand(b.gameService)
-	if err := b.RegisterCommand(ronniedCmd); err != nil {
-		return fmt.Errorf("failed to register ronnied command: %w", err)
+	// Register the bot's commands
+	ronniedCmd := NewRonniedCommand(b.gameService, b.webhookService, b.presetService, b.seasonalService, b.guildConfigService, b.featureFlagService)
+	ronniedCmd.feed = b.feed
+	ronniedCmd.botUserID = b.session.State.User.ID
+	commands := commandRegistry(ronniedCmd)
+	for _, cmd := range commands {
+		if err := b.RegisterCommand(cmd); err != nil {
+			return fmt.Errorf("failed to register %s command: %w", cmd.GetName(), err)
+		}
 	}
+	ronniedCmd.diagnostics = newDiagnostics(b.api, b.config, commands, true)
+
+	// Pick up the games that were being played when the bot last stopped
+	b.resumeGames()
 
 	log.Println("Bot is now running. Press CTRL-C to exit.")
 	return nil
 }
 
-// Stop gracefully shuts down the Discord connection
+// Stop gracefully shuts down the Discord connection. New interactions are turned away while the ones being
+// handled are given until the drain timeout to finish, then the game message edits still waiting are sent before
+// the connection is closed.
 func (b *Bot) Stop() error {
+	timeout := b.config.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	deadline := b.clock.Now().Add(timeout)
+
+	log.Println("Draining interactions...")
+	if remaining := b.interactions.drain(b.clock, timeout); remaining > 0 {
+		log.Printf("Gave up waiting on %d interactions after %s", remaining, timeout)
+	}
+
+	// Show the games as the last interactions left them, for as long as the drain timeout allows
+	b.updates.drain(deadline)
+	b.pins.drain(deadline)
+
 	// Remove all commands
 	appID := b.config.ApplicationID
 	if appID == "" {
@@ -110,7 +304,7 @@ func (b *Bot) Stop() error {
 	}
 
 	for cmdName, cmdID := range b.commandIDs {
-		if err := b.session.ApplicationCommandDelete(appID, guildID, cmdID); err != nil {
+		if err := b.api.ApplicationCommandDelete(appID, guildID, cmdID); err != nil {
 			log.Printf("Failed to delete command %s (ID: %s): %v", cmdName, cmdID, err)
 		} else {
 			log.Printf("Successfully deleted command %s (ID: %s)", cmdName, cmdID)
@@ -139,7 +333,7 @@ func (b *Bot) RegisterCommand(cmd CommandHandler) error {
 		log.Printf("Registering command %s globally", cmd.GetName())
 	}
 
-	createdCmd, err := b.session.ApplicationCommandCreate(appID, guildID, cmd.GetCommand())
+	createdCmd, err := b.api.ApplicationCommandCreate(appID, guildID, cmd.GetCommand())
 	if err != nil {
 		return fmt.Errorf("failed to create command %s: %w", cmd.GetName(), err)
 	}
@@ -153,22 +347,113 @@ func (b *Bot) RegisterCommand(cmd CommandHandler) error {
 }
 
 // ButtonHandler defines a function type for handling button interactions
-type ButtonHandler func(s *discordgo.Session, i *discordgo.InteractionCreate) error
+type ButtonHandler func(s DiscordSession, i *discordgo.InteractionCreate) error
 
 // Button IDs
 const (
-	ButtonJoinGame     = "join_game"
-	ButtonBeginGame    = "begin_game"
-	ButtonRollDice     = "roll_dice"
-	ButtonStartNewGame = "start_new_game"
-	ButtonPayDrink     = "pay_drink"
+	ButtonJoinGame      = "join_game"
+	ButtonBeginGame     = "begin_game"
+	ButtonRollDice      = "roll_dice"
+	ButtonStartNewGame  = "start_new_game"
+	ButtonPayDrink      = "pay_drink"
+	ButtonUseReroll     = "use_reroll"
+	ButtonForgetMe      = "forget_me"
+	ButtonForgetCancel  = "forget_cancel"
+	ButtonForgiveCancel = "forgive_cancel"
+	ButtonTransferDrink = "transfer_drink"
+	ButtonSideBet       = "side_bet"
+	ButtonInvitePlayers = "invite_players"
+	ButtonLeaveGame     = "leave_game"
+	ButtonHotSeat       = "hot_seat"
+	ButtonRollHotSeat   = "roll_hot_seat"
+	ButtonAddRonnie     = "add_ronnie"
+	ButtonCleanupGames  = "cleanup_games"
+	ButtonCleanupCancel = "cleanup_cancel"
+
+	// ButtonPurgeUserPrefix starts the purge confirmation button's ID, followed by the player's ID
+	ButtonPurgeUserPrefix = "purge_user:"
+
+	// ButtonForgiveDrinkPrefix and ButtonForgiveTabPrefix start the forgive confirmation buttons' IDs,
+	// followed by the player's ID
+	ButtonForgiveDrinkPrefix = "forgive_drink:"
+	ButtonForgiveTabPrefix   = "forgive_tab:"
+
+	// ButtonAcceptTransferPrefix and ButtonDeclineTransferPrefix start the drink transfer offer buttons' IDs,
+	// followed by the transfer's ID
+	ButtonAcceptTransferPrefix  = "transfer_accept:"
+	ButtonDeclineTransferPrefix = "transfer_decline:"
+
+	// ButtonAcceptGuestDrinkPrefix and ButtonDeclineGuestDrinkPrefix start the guest drink offer buttons' IDs,
+	// followed by the game's ID and the ID of the player offering the drink
+	ButtonAcceptGuestDrinkPrefix  = "guest_accept:"
+	ButtonDeclineGuestDrinkPrefix = "guest_decline:"
+
+	// ButtonAcceptChallengePrefix and ButtonDeclineChallengePrefix start the challenge buttons' IDs,
+	// followed by the challenge's ID
+	ButtonAcceptChallengePrefix  = "challenge_accept:"
+	ButtonDeclineChallengePrefix = "challenge_decline:"
+
+	// ButtonVoteYesPrefix and ButtonVoteNoPrefix start the vote buttons' IDs, followed by the game's ID
+	ButtonVoteYesPrefix = "vote_yes:"
+	ButtonVoteNoPrefix  = "vote_no:"
+
+	// ButtonPlaceSideBetPrefix starts the side bet outcome buttons' IDs, followed by the game's ID, the player
+	// bet on and the outcome
+	ButtonPlaceSideBetPrefix = "side_bet:"
+
+	// ButtonShowMorePrefix starts the ID of the buttons showing game message sections that didn't fit,
+	// followed by the game's ID and the section's field index
+	ButtonShowMorePrefix = "show_more:"
+
+	// ButtonSetupPrefix starts the ID of the setup message's buttons, followed by the setting and the value picked
+	ButtonSetupPrefix = "setup:"
+
+	// ButtonTabPagePrefix starts the ID of the tab view's page buttons, followed by the game's ID, the page and the filters
+	ButtonTabPagePrefix = "tab_page:"
 
 	// Select menu custom IDs
-	SelectAssignDrink = "assign_drink"
+	SelectAssignDrink   = "assign_drink"
+	SelectTransferDrink = "transfer_drink_to"
+	SelectInvitePlayers = "invite_players_to"
+
+	// SelectSideBetTargetPrefix starts the ID of the menu picking whose roll to bet on, followed by the game's ID
+	SelectSideBetTargetPrefix = "side_bet_target:"
+
+	// SelectGuestDrinkPrefix starts the ID of the menu offering a critical hit's drink to someone watching,
+	// followed by the game's ID
+	SelectGuestDrinkPrefix = "guest_drink:"
+
+	// SelectAssignDrinkForPrefix starts the ID of the menu a hot seat player's critical hit drink is assigned from,
+	// followed by the hot seat player's ID
+	SelectAssignDrinkForPrefix = "assign_drink_for:"
+
+	// ModalHotSeatPrefix starts the ID of the modal naming a player's hot seat players, followed by the game's ID
+	ModalHotSeatPrefix = "hot_seat_modal:"
 )
 
 // handleInteraction handles Discord interactions
-func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func (b *Bot) handleInteraction(s DiscordSession, i *discordgo.InteractionCreate) {
+	// Turn away interactions that come in while shutting down, and let shutdown wait for the rest
+	if !b.interactions.begin() {
+		b.turnAway(s, i)
+		return
+	}
+	defer b.interactions.end()
+
+	// Defer the interaction if its handler is slow to answer, and answer it with the call that fits once deferred.
+	// Autocomplete can't be deferred.
+	if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		r := newResponder(s, i, b.clock)
+		defer r.done()
+		s = r
+	}
+
+	// Keep a panicking handler from killing the goroutine and leaving the interaction hanging
+	defer b.recoverInteraction(s, i)
+
+	// Messages posted later for the server are written for its locale too
+	b.locales.remember(i.GuildID, interactionLocale(i))
+
 	// Handle different interaction types
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
@@ -183,11 +468,28 @@ func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCr
 		if err := b.handleComponentInteraction(s, i); err != nil {
 			log.Printf("Error handling component interaction: %v", err)
 		}
+	case discordgo.InteractionModalSubmit:
+		// Handle forms sent back from a modal
+		if err := b.handleModalSubmit(s, i); err != nil {
+			log.Printf("Error handling modal submit: %v", err)
+		}
+	}
+}
+
+// handleModalSubmit handles forms sent back from a modal
+func (b *Bot) handleModalSubmit(s DiscordSession, i *discordgo.InteractionCreate) error {
+	customID := i.ModalSubmitData().CustomID
+
+	// The hot seat modal carries the game the players sit down at
+	if gameID, ok := strings.CutPrefix(customID, ModalHotSeatPrefix); ok {
+		return b.handleHotSeatModal(s, i, i.ChannelID, i.Member.User.ID, gameID)
 	}
+
+	return nil
 }
 
 // handleComponentInteraction handles button clicks and other component interactions
-func (b *Bot) handleComponentInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) handleComponentInteraction(s DiscordSession, i *discordgo.InteractionCreate) error {
 	// Get the custom ID of the component
 	customID := i.MessageComponentData().CustomID
 
@@ -199,6 +501,82 @@ func (b *Bot) handleComponentInteraction(s *discordgo.Session, i *discordgo.Inte
 		username = i.Member.Nick
 	}
 
+	// The purge confirmation carries the player to purge in its ID
+	if playerID, ok := strings.CutPrefix(customID, ButtonPurgeUserPrefix); ok {
+		return b.handlePurgeUserButton(s, i, userID, playerID)
+	}
+
+	// So do the forgive confirmations
+	if playerID, ok := strings.CutPrefix(customID, ButtonForgiveDrinkPrefix); ok {
+		return b.handleForgiveButton(s, i, userID, playerID, false)
+	}
+	if playerID, ok := strings.CutPrefix(customID, ButtonForgiveTabPrefix); ok {
+		return b.handleForgiveButton(s, i, userID, playerID, true)
+	}
+
+	// And the drink transfer offers carry the transfer
+	if transferID, ok := strings.CutPrefix(customID, ButtonAcceptTransferPrefix); ok {
+		return b.handleAcceptTransferButton(s, i, userID, transferID)
+	}
+	if transferID, ok := strings.CutPrefix(customID, ButtonDeclineTransferPrefix); ok {
+		return b.handleDeclineTransferButton(s, i, userID, transferID)
+	}
+
+	// The guest drink menu carries the game, and its offer buttons the player offering the drink too
+	if gameID, ok := strings.CutPrefix(customID, SelectGuestDrinkPrefix); ok {
+		return b.handleGuestDrinkSelect(s, i, channelID, userID, gameID)
+	}
+	if answer, ok := strings.CutPrefix(customID, ButtonAcceptGuestDrinkPrefix); ok {
+		return b.handleAcceptGuestDrinkButton(s, i, userID, answer)
+	}
+	if answer, ok := strings.CutPrefix(customID, ButtonDeclineGuestDrinkPrefix); ok {
+		return b.handleDeclineGuestDrinkButton(s, i, userID, answer)
+	}
+
+	// The challenge buttons carry the challenge
+	if challengeID, ok := strings.CutPrefix(customID, ButtonAcceptChallengePrefix); ok {
+		return b.handleAcceptChallengeButton(s, i, userID, challengeID)
+	}
+	if challengeID, ok := strings.CutPrefix(customID, ButtonDeclineChallengePrefix); ok {
+		return b.handleDeclineChallengeButton(s, i, userID, challengeID)
+	}
+
+	// The vote buttons carry the game voted on
+	if gameID, ok := strings.CutPrefix(customID, ButtonVoteYesPrefix); ok {
+		return b.handleVoteButton(s, i, userID, gameID, true)
+	}
+	if gameID, ok := strings.CutPrefix(customID, ButtonVoteNoPrefix); ok {
+		return b.handleVoteButton(s, i, userID, gameID, false)
+	}
+
+	// The side bet menu and buttons carry the game, and the buttons the bet
+	if gameID, ok := strings.CutPrefix(customID, SelectSideBetTargetPrefix); ok {
+		return b.handleSideBetTargetSelect(s, i, gameID)
+	}
+	if bet, ok := strings.CutPrefix(customID, ButtonPlaceSideBetPrefix); ok {
+		return b.handlePlaceSideBetButton(s, i, channelID, userID, username, bet)
+	}
+
+	// The show more buttons carry the game and the section to show
+	if section, ok := strings.CutPrefix(customID, ButtonShowMorePrefix); ok {
+		return b.handleShowMoreButton(s, i, section)
+	}
+
+	// And the tab view's page buttons carry the page and its filters
+	if page, ok := strings.CutPrefix(customID, ButtonTabPagePrefix); ok {
+		return b.handleTabPageButton(s, i, userID, page)
+	}
+
+	// A hot seat player's drink menu carries the hot seat player
+	if playerID, ok := strings.CutPrefix(customID, SelectAssignDrinkForPrefix); ok {
+		return b.handleAssignDrinkSelect(s, i, channelID, userID, playerID)
+	}
+
+	// The setup buttons carry the setting and the value picked
+	if choice, ok := strings.CutPrefix(customID, ButtonSetupPrefix); ok {
+		return b.handleSetupButton(s, i, choice)
+	}
+
 	// Handle different button actions
 	switch customID {
 	case ButtonJoinGame:
@@ -210,32 +588,78 @@ func (b *Bot) handleComponentInteraction(s *discordgo.Session, i *discordgo.Inte
 	case ButtonRollDice:
 		// Handle roll dice button
 		return b.handleRollDiceButton(s, i, channelID, userID)
+	case ButtonHotSeat:
+		// Handle hot seat button
+		return b.handleHotSeatButton(s, i, channelID)
+	case ButtonRollHotSeat:
+		// Handle roll for the table button
+		return b.handleRollHotSeatButton(s, i, channelID, userID)
+	case ButtonAddRonnie:
+		// Handle add Ronnie button
+		return b.handleAddRonnieButton(s, i, channelID, userID)
 	case SelectAssignDrink:
 		// Handle assign drink dropdown
-		return b.handleAssignDrinkSelect(s, i, channelID, userID)
+		return b.handleAssignDrinkSelect(s, i, channelID, userID, userID)
 	case ButtonStartNewGame:
 		// Handle start new game button
 		return b.handleStartNewGameButton(s, i, channelID, userID, username)
 	case ButtonPayDrink:
 		// Handle pay drink button
 		return b.handlePayDrinkButton(s, i)
+	case ButtonUseReroll:
+		// Handle use re-roll button
+		return b.handleUseRerollButton(s, i, channelID, userID)
+	case ButtonForgetMe:
+		// Handle forget me confirmation button
+		return b.handleForgetMeButton(s, i, userID)
+	case ButtonForgetCancel:
+		// Handle forget me or purge cancel button
+		return b.handleForgetCancelButton(s, i)
+	case ButtonForgiveCancel:
+		// Handle forgive cancel button
+		return b.handleForgiveCancelButton(s, i)
+	case ButtonCleanupGames:
+		// Handle cleanup confirmation button
+		return b.handleCleanupButton(s, i)
+	case ButtonCleanupCancel:
+		// Handle cleanup cancel button
+		return b.handleCleanupCancelButton(s, i)
+	case ButtonTransferDrink:
+		// Handle transfer drink button
+		return b.handleTransferDrinkButton(s, i)
+	case SelectTransferDrink:
+		// Handle transfer drink volunteer selection
+		return b.handleTransferDrinkSelect(s, i, channelID, userID)
+	case ButtonSideBet:
+		// Handle side bet button
+		return b.handleSideBetButton(s, i, channelID, userID)
+	case ButtonInvitePlayers:
+		// Handle invite players button
+		return b.handleInvitePlayersButton(s, i)
+	case SelectInvitePlayers:
+		// Handle private game invite selection
+		return b.handleInvitePlayersSelect(s, i, channelID, userID)
+	case ButtonLeaveGame:
+		// Handle leave game button
+		return b.handleLeaveGameButton(s, i, channelID, userID)
 	default:
 		return RespondWithError(s, i, fmt.Sprintf("Unknown button: %s", customID))
 	}
 }
 
 // handleJoinGameButton handles the join game button click
-func (b *Bot) handleJoinGameButton(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID, username string) error {
+func (b *Bot) handleJoinGameButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, username string) error {
 	ctx := context.Background()
+	ctx = b.messagingContext(ctx, i.GuildID)
 
 	// Get the game in this channel
-	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
 	if err != nil {
 		log.Printf("Error getting game: %v", err)
-		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Error: %v", err))
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Error"))
 	}
 
 	// Join the game
@@ -245,35 +669,12 @@ func (b *Bot) handleJoinGameButton(s *discordgo.Session, i *discordgo.Interactio
 		PlayerName: username,
 	})
 	if err != nil {
-		log.Printf("Error joining game: %v", err)
-
-		// Map the error to an error type for the messaging service
-		var errorType string
-		switch err {
-		case game.ErrGameActive:
-			errorType = "game_active"
-		case game.ErrGameRollOff:
-			errorType = "game_roll_off"
-		case game.ErrGameCompleted:
-			errorType = "game_completed"
-		case game.ErrGameFull:
-			errorType = "game_full"
-		case game.ErrInvalidGameState:
-			errorType = "invalid_game_state"
-		default:
-			// For any other error, just return the error message
-			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Failed to join game: %v", err))
-		}
-
-		// Get a friendly error message from the messaging service
-		errorMsgOutput, msgErr := b.messagingService.GetErrorMessage(ctx, &messaging.GetErrorMessageInput{
-			ErrorType: errorType,
-		})
-		if msgErr != nil {
-			// If messaging service fails, use a generic message
-			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Failed to join game: %v", err))
+		// A full game puts the player on its waitlist instead
+		if errors.Is(err, game.ErrGameFull) {
+			return b.joinWaitlist(ctx, s, i, channelID, existingGame.Game.ID, userID, username)
 		}
-		return RespondWithEphemeralMessage(s, i, errorMsgOutput.Message)
+		log.Printf("Error joining game: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to join game"))
 	}
 
 	// Update the game message
@@ -323,45 +724,42 @@ func (b *Bot) handleJoinGameButton(s *discordgo.Session, i *discordgo.Interactio
 }
 
 // handleBeginGameButton handles the begin game button click
-func (b *Bot) handleBeginGameButton(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID string) error {
+func (b *Bot) handleBeginGameButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
 	ctx := context.Background()
+	ctx = b.messagingContext(ctx, i.GuildID)
 
 	// Get the game in this channel
-	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
 	if err != nil {
 		log.Printf("Error getting game: %v", err)
-		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Error: %v", err))
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Error"))
 	}
 
-	// Start the game
+	// Start the game, players who don't host it call a vote to start it instead
 	startOutput, err := b.gameService.StartGame(ctx, &game.StartGameInput{
-		GameID:     existingGame.Game.ID,
-		PlayerID:   userID,
-		ForceStart: true, // Always try to force start, service layer will decide if it's allowed
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
 	})
+	if errors.Is(err, game.ErrNotCreator) {
+		return callVote(ctx, b.gameService, s, i, existingGame.Game.ID, userID, models.VoteActionStart)
+	}
 	if err != nil {
 		log.Printf("Error starting game: %v", err)
-		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Failed to start game: %v", err))
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to start game"))
 	}
 
 	if !startOutput.Success {
 		return RespondWithEphemeralMessage(s, i, "Failed to start the game. Make sure you are the creator of the game.")
 	}
 
-	// If the game was force-started, add a metadata field to the game
-	if startOutput.ForceStarted && startOutput.CreatorName != "" {
-		// Create a special message for the shared game message
-		forceStartMsg := fmt.Sprintf("⚠️ Game force-started by %s! %s took too long to start the game and has been assigned a drink.",
-			s.State.User.Username, startOutput.CreatorName)
+	b.updateGameMessage(s, channelID, existingGame.Game.ID)
 
-		// Update the game message with the force-start information
-		b.updateGameMessageWithForceStart(s, channelID, existingGame.Game.ID, forceStartMsg)
-	} else {
-		// Update the game message normally
-		b.updateGameMessage(s, channelID, existingGame.Game.ID)
+	// Countdown games count down in the channel so everyone rolls at once
+	if startOutput.RollWindow != nil {
+		go b.runCountdown(i.GuildID, channelID, startOutput.RollWindow)
 	}
 
 	// Create roll button
@@ -383,14 +781,14 @@ func (b *Bot) handleBeginGameButton(s *discordgo.Session, i *discordgo.Interacti
 	// Default message if the messaging service fails
 	gameStartedMessage := "Game Started! Click the button below to roll your dice."
 
-	// If the game was force-started, add information about the original creator
-	if startOutput.ForceStarted && startOutput.CreatorName != "" {
-		gameStartedMessage = fmt.Sprintf("Game force-started! %s took too long to start the game and has been assigned a drink. Click the button below to roll your dice.", startOutput.CreatorName)
-	} else if err == nil {
+	if err == nil {
 		gameStartedMessage = startMsgOutput.Message
 	} else {
 		log.Printf("Error getting game started message: %v", err)
 	}
+	if startOutput.RollWindow != nil {
+		gameStartedMessage += "\n\n⏱️ Wait for the countdown in the channel and roll together, rolling early or late costs a drink!"
+	}
 
 	// Send an ephemeral message to the user who started the game
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -408,7 +806,7 @@ func (b *Bot) handleBeginGameButton(s *discordgo.Session, i *discordgo.Interacti
 }
 
 // handleRollDiceButton handles the roll dice button click
-func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID string) error {
+func (b *Bot) handleRollDiceButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
 	ctx := context.Background()
 
 	// First, acknowledge the interaction with a deferred update
@@ -421,7 +819,7 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 	}
 
 	// Get the game in this channel
-	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
@@ -450,56 +848,13 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 
 	// Handle errors
 	if err != nil {
-		// Map the error to an error type for the messaging service
-		var errorType string
-		switch err {
-		case game.ErrGameActive:
-			errorType = "game_active"
-		case game.ErrGameRollOff:
-			errorType = "game_roll_off"
-		case game.ErrGameCompleted:
-			errorType = "game_completed"
-		case game.ErrInvalidGameState:
-			errorType = "invalid_game_state"
-		case game.ErrPlayerNotInGame:
-			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: "You are not part of this game.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
-			return err
-		case game.ErrPlayerInRollOff:
-			// The player needs to roll in a roll-off game
-			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: "You need to roll in a roll-off game! Use the Roll button on the game message to continue.",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
-
+		if errors.Is(err, game.ErrPlayerInRollOff) {
 			// Update the game message to make the roll-off more visible
 			b.updateGameMessage(s, channelID, existingGame.Game.ID)
-			return err
-		default:
-			// For any other error, just return the error message
-			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: fmt.Sprintf("Failed to roll dice: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
-			return err
 		}
 
-		// Get a friendly error message from the messaging service
-		errorMsgOutput, msgErr := b.messagingService.GetErrorMessage(ctx, &messaging.GetErrorMessageInput{
-			ErrorType: errorType,
-		})
-		if msgErr != nil {
-			// If messaging service fails, use a generic message
-			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-				Content: fmt.Sprintf("Failed to roll dice: %v", err),
-				Flags:   discordgo.MessageFlagsEphemeral,
-			})
-			return err
-		}
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: errorMsgOutput.Message,
+			Content: b.friendlyError(ctx, err, "Failed to roll dice"),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return err
@@ -517,16 +872,25 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 		return err
 	}
 
+	return b.showRollResult(ctx, s, i, channelID, existingGame.Game, rollOutput)
+}
+
+// showRollResult updates the player's roll response with the result of their roll
+func (b *Bot) showRollResult(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, channelID string, existingGame *models.Game, rollOutput *game.RollDiceOutput) error {
 	// Update all game messages that need updating
 	for _, gameID := range rollOutput.GameIDsToUpdate {
 		b.updateGameMessage(s, channelID, gameID)
 	}
 
+	// Keep the roll messages to the server's content rating, freshly written if it has banter on
+	ctx = b.messagingContext(ctx, existingGame.GuildID)
+
 	// Get fun roll result message from messaging service
 	rollResultOutput, err := b.messagingService.GetRollResultMessage(ctx, &messaging.GetRollResultMessageInput{
 		RollValue:      rollOutput.RollValue,
 		IsCriticalHit:  rollOutput.IsCriticalHit,
 		IsCriticalFail: rollOutput.IsCriticalFail,
+		DiceSides:      rollOutput.DiceSides,
 		PlayerName:     rollOutput.PlayerName,
 	})
 	if err != nil {
@@ -539,6 +903,7 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 		RollValue:      rollOutput.RollValue,
 		IsCriticalHit:  rollOutput.IsCriticalHit,
 		IsCriticalFail: rollOutput.IsCriticalFail,
+		DiceSides:      rollOutput.DiceSides,
 		PlayerName:     rollOutput.PlayerName,
 	})
 	if whisperErr != nil {
@@ -549,6 +914,12 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 	// Create embeds for the response
 	var embeds []*discordgo.MessageEmbed
 	contentText := rollResultOutput.Title
+	if seasonalLines := b.seasonalRollLines(ctx, rollOutput); seasonalLines != "" {
+		contentText += "\n" + seasonalLines
+	}
+	if birthdayLine := b.birthdayRollLine(ctx, rollOutput); birthdayLine != "" {
+		contentText += "\n" + birthdayLine
+	}
 
 	// Add the whisper message as an embed if available
 	if whisperErr == nil {
@@ -564,6 +935,38 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 		embeds = append(embeds, whisperEmbed)
 	}
 
+	if modifiersEmbed := rollModifiersEmbed(rollOutput.RollModifiers); modifiersEmbed != nil {
+		embeds = append(embeds, modifiersEmbed)
+	}
+
+	if rollOutput.LeaderHandicap {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       "👑 Leader Handicap",
+			Description: "You've had the most drinks this session, so you rolled twice and kept the worse roll.",
+			Color:       0xF1C40F,
+		})
+	}
+
+	if timingEmbed := rollTimingEmbed(rollOutput.RollTiming); timingEmbed != nil {
+		embeds = append(embeds, timingEmbed)
+	}
+
+	if rollOutput.JackpotDrinks > 0 {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       "💰 Jackpot",
+			Description: fmt.Sprintf("Your critical fail takes the whole jackpot too, that's %d more drinks!", rollOutput.JackpotDrinks),
+			Color:       0xE74C3C,
+		})
+	}
+
+	if rollOutput.EarnedRerollToken {
+		embeds = append(embeds, &discordgo.MessageEmbed{
+			Title:       "🏅 Hard Luck",
+			Description: "That's a lot of critical fails. Here's a re-roll token for your troubles!",
+			Color:       0xF1C40F,
+		})
+	}
+
 	// Create roll again button for non-critical hits
 	rollButton := discordgo.Button{
 		Label:    "Roll Again",
@@ -584,9 +987,19 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 		},
 	}
 
+	// A hot seat player's roll is shown to the table with who rolled it, and moves on to the host's next player
+	hotSeatHostID := models.HotSeatHost(rollOutput.PlayerID)
+	if hotSeatHostID != "" {
+		contentText = fmt.Sprintf("🪑 **%s** (rolled by <@%s>)\n%s", isolateName(rollOutput.PlayerName), hotSeatHostID, contentText)
+	}
+
 	// Create action row for components if we have any
 	var messageComponents []discordgo.MessageComponent
-	if len(embeds) > 0 || rollOutput.IsCriticalHit {
+	if hotSeatHostID != "" && !rollOutput.IsCriticalHit {
+		messageComponents = append(messageComponents, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{rollHotSeatButton()},
+		})
+	} else if len(embeds) > 0 || rollOutput.IsCriticalHit {
 		if rollOutput.IsCriticalHit {
 			// Create player selection dropdown for critical hits
 			if len(rollOutput.EligiblePlayers) > 0 {
@@ -603,18 +1016,32 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 					})
 				}
 
+				placeholder := "Select a player to drink"
+				if len(rollOutput.BirthdayPlayers) > 0 {
+					placeholder = "🎂 Select the birthday player to drink"
+				}
+
 				playerSelect := discordgo.SelectMenu{
-					CustomID:    SelectAssignDrink,
-					Placeholder: "Select a player to drink",
+					CustomID:    assignDrinkSelectID(rollOutput.PlayerID),
+					Placeholder: placeholder,
 					Options:     playerOptions,
 				}
 
 				messageComponents = append(messageComponents, playerSelect)
 			}
+
+			// Games played with guest drinks let it go to someone watching instead
+			if rollOutput.GuestDrinks && hotSeatHostID == "" {
+				messageComponents = append(messageComponents, guestDrinkSelect(rollOutput.Game.ID))
+			}
 		} else {
-			// Add both buttons
+			// Add both buttons, plus a re-roll if the player has a token to spend
+			buttons := []discordgo.MessageComponent{rollButton, payDrinkButton}
+			if rerollButton := b.rerollButton(ctx, rollOutput); rerollButton != nil {
+				buttons = append(buttons, *rerollButton)
+			}
 			messageComponents = append(messageComponents, discordgo.ActionsRow{
-				Components: []discordgo.MessageComponent{rollButton, payDrinkButton},
+				Components: buttons,
 			})
 		}
 	}
@@ -633,17 +1060,18 @@ func (b *Bot) handleRollDiceButton(s *discordgo.Session, i *discordgo.Interactio
 
 	// Update the game message in the channel
 	// This is a separate update to the shared message that everyone can see
-	if existingGame.Game.MessageID != "" {
-		b.updateGameMessage(s, channelID, existingGame.Game.ID)
+	if existingGame.MessageID != "" {
+		b.updateGameMessage(s, channelID, existingGame.ID)
 	} else {
-		log.Printf("No message ID found for game %s, skipping update", existingGame.Game.ID)
+		log.Printf("No message ID found for game %s, skipping update", existingGame.ID)
 	}
 
 	return nil
 }
 
-// handleAssignDrinkSelect handles the assign drink dropdown selection
-func (b *Bot) handleAssignDrinkSelect(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID string) error {
+// handleAssignDrinkSelect handles the assign drink dropdown selection, for the player's own critical hit or for one of
+// their hot seat players'
+func (b *Bot) handleAssignDrinkSelect(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, fromPlayerID string) error {
 	ctx := context.Background()
 
 	// Get the selected player ID from the interaction data
@@ -657,7 +1085,7 @@ func (b *Bot) handleAssignDrinkSelect(s *discordgo.Session, i *discordgo.Interac
 	}
 
 	// Get the game in this channel
-	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
@@ -680,15 +1108,16 @@ func (b *Bot) handleAssignDrinkSelect(s *discordgo.Session, i *discordgo.Interac
 	}
 
 	// Assign the drink
-	_, err = b.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
+	assignOutput, err := b.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
 		GameID:       existingGame.Game.ID,
-		FromPlayerID: userID,
+		FromPlayerID: fromPlayerID,
 		ToPlayerID:   targetPlayerID,
 		Reason:       game.DrinkReasonCriticalHit,
+		HostID:       userID,
 	})
 	if err != nil {
 		log.Printf("Error assigning drink: %v", err)
-		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Failed to assign drink: %v", err))
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to assign drink"))
 	}
 
 	// Update the game message in the channel to show the drink assignment
@@ -714,14 +1143,31 @@ func (b *Bot) handleAssignDrinkSelect(s *discordgo.Session, i *discordgo.Interac
 		},
 	}
 
+	confirmation := fmt.Sprintf("You assigned a drink to %s! 🍻", targetPlayerName)
+	if note := messaging.HappyHourNote(assignOutput.HappyHourMultiplier, assignOutput.OnTheHouse); note != "" {
+		confirmation += " " + note
+	}
+	if assignOutput.RonnieDrinkTo != "" {
+		confirmation += fmt.Sprintf("\nRonnie can't drink, so %s drinks it for him! 🤖", assignOutput.RonnieDrinkTo)
+	}
+	if assignOutput.KarmaDrink {
+		confirmation += fmt.Sprintf("\nThat's a lot of drinks for %s, karma says you drink one too! 😈", targetPlayerName)
+	}
+
+	// Hot seat players move on to the host's next player instead
+	buttons := []discordgo.MessageComponent{rollButton, payDrinkButton}
+	if models.IsHotSeatPlayer(fromPlayerID) {
+		buttons = []discordgo.MessageComponent{rollHotSeatButton()}
+	}
+
 	// Update the current message with a confirmation and a roll button
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseUpdateMessage,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("You assigned a drink to %s! 🍻", targetPlayerName),
+			Content: confirmation,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{rollButton, payDrinkButton},
+					Components: buttons,
 				},
 			},
 		},
@@ -729,30 +1175,34 @@ func (b *Bot) handleAssignDrinkSelect(s *discordgo.Session, i *discordgo.Interac
 }
 
 // handleStartNewGameButton handles the start new game button click
-func (b *Bot) handleStartNewGameButton(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID, username string) error {
+func (b *Bot) handleStartNewGameButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, username string) error {
 	ctx := context.Background()
 
+	// Servers can keep games to some channels
+	if redirect := gameChannelRedirect(ctx, s, b.guildConfigService, i.GuildID, channelID); redirect != "" {
+		return RespondWithEphemeralMessage(s, i, redirect)
+	}
+
 	// Check if there's an existing game in this channel
-	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	_, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
-	// Only allow creating a new game if no game exists or the existing game is completed
-	if err == nil && existingGame != nil && existingGame.Game != nil {
-		if existingGame.Game.Status != models.GameStatusCompleted {
-			return RespondWithEphemeralMessage(s, i, "There's already an active game in this channel. Use `/ronnied abandon` if you want to abandon the current game.")
-		}
+	// Only allow creating a new game if every game in the channel is completed
+	if err == nil {
+		return RespondWithEphemeralMessage(s, i, "There's already an active game in this channel. Use `/ronnied abandon` if you want to abandon the current game.")
 	}
 
 	// Create a new game
 	createOutput, err := b.gameService.CreateGame(ctx, &game.CreateGameInput{
 		ChannelID:   channelID,
+		GuildID:     i.GuildID,
 		CreatorID:   userID,
 		CreatorName: username,
 	})
 	if err != nil {
 		log.Printf("Error creating game: %v", err)
-		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Failed to create game: %v", err))
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to create game"))
 	}
 
 	// Join the creator to the game
@@ -812,8 +1262,8 @@ func (b *Bot) handleStartNewGameButton(s *discordgo.Session, i *discordgo.Intera
 		},
 	}
 
-	// Send the message to the channel
-	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+	// Send the message to the channel, under the server's persona when it has one
+	msg, err := b.feed.send(ctx, s, i.GuildID, channelID, &discordgo.MessageSend{
 		Embeds: embeds,
 		Components: []discordgo.MessageComponent{
 			discordgo.ActionsRow{
@@ -843,11 +1293,12 @@ func (b *Bot) handleStartNewGameButton(s *discordgo.Session, i *discordgo.Intera
 }
 
 // handlePayDrinkButton handles the pay drink button click
-func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *Bot) handlePayDrinkButton(s DiscordSession, i *discordgo.InteractionCreate) error {
 	// Get the user ID and channel ID
 	userID := i.Member.User.ID
 	channelID := i.ChannelID
 	ctx := context.Background()
+	ctx = b.messagingContext(ctx, i.GuildID)
 
 	// First, acknowledge the interaction with a deferred update
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -861,6 +1312,7 @@ func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.Interactio
 	// Get the game in this channel
 	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
 		ChannelID: channelID,
+		GuildID:   i.GuildID,
 	})
 
 	// Handle errors or missing game
@@ -890,14 +1342,23 @@ func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.Interactio
 	}
 
 	// Pay the drink
-	_, err = b.gameService.PayDrink(ctx, &game.PayDrinkInput{
+	payOutput, err := b.gameService.PayDrink(ctx, &game.PayDrinkInput{
 		GameID:   existingGame.Game.ID,
 		PlayerID: userID,
 	})
 	if err != nil {
 		log.Printf("Error paying drink: %v", err)
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("Failed to pay drink: %v", err),
+			Content: b.friendlyError(ctx, err, "Failed to pay drink"),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return err
+	}
+
+	// A drink paid when none was owed is banked for later
+	if payOutput.Credited {
+		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: creditBankedMessage(payOutput.CreditBalance),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return err
@@ -909,6 +1370,7 @@ func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.Interactio
 	// Get the session ID from the game's channel
 	sessionOutput, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
 		ChannelID: channelID,
+		GuildID:   i.GuildID,
 	})
 
 	// Calculate remaining drinks for the player
@@ -944,6 +1406,9 @@ func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.Interactio
 
 					drinkStats = fmt.Sprintf("**Drink Stats** %s\nTotal: %d | Paid: %d | Remaining: %d",
 						statusEmoji, entry.DrinkCount, entry.PaidCount, remainingDrinks)
+					if karma := formatKarma(entry.Karma); karma != "" {
+						drinkStats += " | " + karma
+					}
 
 					// Create a visual progress bar
 					progressBar = createProgressBar(entry.PaidCount, entry.DrinkCount)
@@ -1062,189 +1527,109 @@ func (b *Bot) handlePayDrinkButton(s *discordgo.Session, i *discordgo.Interactio
 	return err
 }
 
-// updateGameMessage updates the main game message in the channel
-func (b *Bot) updateGameMessage(s *discordgo.Session, channelID string, gameID string) {
-	ctx := context.Background()
-
-	// Get the game
-	gameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
-		GameID: gameID,
+// updateGameMessage queues an update of the main game message in the channel
+func (b *Bot) updateGameMessage(s DiscordSession, channelID string, gameID string) {
+	b.updates.enqueue(s, channelID, &messageUpdate{
+		gameID: gameID,
 	})
-	if err != nil {
-		log.Printf("Error getting game for message update: %v", err)
-		return
-	}
-
-	if gameOutput.Game.MessageID == "" {
-		log.Printf("Game has no message ID, cannot update")
-		return
-	}
+}
 
-	// Get related data needed for rendering
-	var rollOffGame, parentGame *models.Game
-	var drinkRecords []*models.DrinkLedger
-	var leaderboardEntries, sessionLeaderboardEntries []game.LeaderboardEntry
+// handlePostGameFinished redraws a game's message once the work left when it completed was done in the background
+func (b *Bot) handlePostGameFinished(ctx context.Context, event *events.Event) {
+	b.updateGameMessage(b.api, event.ChannelID, event.GameID)
+}
 
-	// Check if this is a roll-off game
-	if gameOutput.Game.Status.IsRollOff() && gameOutput.Game.ParentGameID != "" {
-		// Get the parent game
-		parentGameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
-			GameID: gameOutput.Game.ParentGameID,
-		})
-		if err == nil {
-			parentGame = parentGameOutput.Game
-		}
-	}
+// editGameMessage renders the game and edits the main game message in the channel
+func (b *Bot) editGameMessage(s DiscordSession, channelID string, update *messageUpdate) error {
+	ctx := context.Background()
 
-	// Check if this game has a roll-off in progress
-	if gameOutput.Game.RollOffGameID != "" {
-		// Get the roll-off game
-		rollOffGameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
-			GameID: gameOutput.Game.RollOffGameID,
-		})
-		if err == nil {
-			rollOffGame = rollOffGameOutput.Game
-		}
+	// Get the game along with everything needed to render it, and render it
+	messageEdit, renderedGame, err := b.renderGameView(ctx, update.gameID)
+	if err != nil {
+		log.Printf("Error rendering game message: %v", err)
+		return nil
 	}
 
-	// Get drink records
-	drinkRecordsOutput, err := b.gameService.GetDrinkRecords(ctx, &game.GetDrinkRecordsInput{
-		GameID: gameID,
-	})
-	if err == nil && drinkRecordsOutput != nil {
-		drinkRecords = drinkRecordsOutput.Records
+	if messageEdit.ID == "" {
+		log.Printf("Game has no message ID, cannot update")
+		return nil
 	}
 
-	// Get leaderboard for completed games
-	if gameOutput.Game.Status.IsCompleted() {
-		leaderboardOutput, err := b.gameService.GetLeaderboard(ctx, &game.GetLeaderboardInput{
-			GameID: gameID,
-		})
-		if err == nil && leaderboardOutput != nil {
-			leaderboardEntries = leaderboardOutput.Entries
-		}
+	// Keep the message within Discord's embed limits, with buttons to show whatever didn't fit
+	fitEmbedLimits(messageEdit, update.gameID)
 
-		// Get session leaderboard for completed games
-		sessionOutput, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
-			ChannelID: channelID,
-		})
-		if err == nil && sessionOutput != nil {
-			sessionLeaderboardEntries = sessionOutput.Entries
-		}
+	// Skip the edit if the message already shows exactly this
+	if b.renders.unchanged(messageEdit) {
+		messageEditsSkipped.Add(1)
+		return nil
 	}
 
-	// Render the game message
-	messageEdit, err := b.renderGameMessage(gameOutput.Game, drinkRecords, leaderboardEntries, sessionLeaderboardEntries, rollOffGame, parentGame)
-	if err != nil {
-		log.Printf("Error rendering game message: %v", err)
-		return
-	}
-
-	// Send the message edit
-	_, err = s.ChannelMessageEditComplex(messageEdit)
+	// Send the message edit, leaving rate limits to the update queue rather than blocking on a retry
+	_, err = b.feed.edit(s, messageEdit, discordgo.WithRetryOnRatelimit(false))
 	if err != nil {
+		// Someone deleted the message or its thread was archived, so post it again
+		if isMissingMessage(err) {
+			return b.repostGameMessage(ctx, s, renderedGame, messageEdit)
+		}
 		log.Printf("Error updating game message: %v", err)
+		return err
 	}
-}
 
-// updateGameMessageWithForceStart updates the main game message in the channel with force-start information
-func (b *Bot) updateGameMessageWithForceStart(s *discordgo.Session, channelID string, gameID string, forceStartMsg string) {
-	ctx := context.Background()
+	b.renders.remember(messageEdit)
+	return nil
+}
 
-	// Get the game
-	gameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
+// renderGameView loads a game with everything needed to render it and renders its message, returning the game
+// it rendered along with it
+func (b *Bot) renderGameView(ctx context.Context, gameID string) (*discordgo.MessageEdit, *models.Game, error) {
+	view, err := b.gameService.GetGameView(ctx, &game.GetGameViewInput{
 		GameID: gameID,
 	})
 	if err != nil {
-		log.Printf("Error getting game for message update: %v", err)
-		return
-	}
-
-	if gameOutput.Game.MessageID == "" {
-		log.Printf("Game has no message ID, cannot update")
-		return
+		return nil, nil, fmt.Errorf("failed to get game: %w", err)
 	}
 
-	// Get related data needed for rendering
-	var rollOffGame, parentGame *models.Game
-	var drinkRecords []*models.DrinkLedger
-	var leaderboardEntries, sessionLeaderboardEntries []game.LeaderboardEntry
-
-	// Check if this is a roll-off game
-	if gameOutput.Game.Status.IsRollOff() && gameOutput.Game.ParentGameID != "" {
-		// Get the parent game
-		parentGameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
-			GameID: gameOutput.Game.ParentGameID,
-		})
-		if err == nil {
-			parentGame = parentGameOutput.Game
-		}
+	density := b.displayDensity(ctx, view.Game.GuildID)
+	ctx = b.messagingContext(ctx, view.Game.GuildID)
+	edit, err := b.renderGameMessage(ctx, view, density)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Check if this game has a roll-off in progress
-	if gameOutput.Game.RollOffGameID != "" {
-		// Get the roll-off game
-		rollOffGameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
-			GameID: gameOutput.Game.RollOffGameID,
-		})
-		if err == nil {
-			rollOffGame = rollOffGameOutput.Game
-		}
+	// Servers that don't drink play for points
+	if b.scoringMode(ctx, view.Game.GuildID) == models.ScoringPoints {
+		pointsWording(edit)
 	}
 
-	// Get drink records
-	drinkRecordsOutput, err := b.gameService.GetDrinkRecords(ctx, &game.GetDrinkRecordsInput{
-		GameID: gameID,
-	})
-	if err == nil && drinkRecordsOutput != nil {
-		drinkRecords = drinkRecordsOutput.Records
+	// And servers can show their own emoji
+	if replacer := b.guildEmojis(ctx, view.Game.GuildID); replacer != nil {
+		serverEmojis(edit, replacer)
 	}
 
-	// Get leaderboard for completed games
-	if gameOutput.Game.Status.IsCompleted() {
-		leaderboardOutput, err := b.gameService.GetLeaderboard(ctx, &game.GetLeaderboardInput{
-			GameID: gameID,
-		})
-		if err == nil && leaderboardOutput != nil {
-			leaderboardEntries = leaderboardOutput.Entries
-		}
+	// The game the roll-offs started from shows how each of them is going
+	addRollOffSummary(edit, view.Game, view.RollOffs)
 
-		// Get session leaderboard for completed games
-		sessionOutput, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
-			ChannelID: channelID,
-		})
-		if err == nil && sessionOutput != nil {
-			sessionLeaderboardEntries = sessionOutput.Entries
-		}
-	}
+	return edit, view.Game, nil
+}
 
-	// Render the game message
-	messageEdit, err := b.renderGameMessage(gameOutput.Game, drinkRecords, leaderboardEntries, sessionLeaderboardEntries, rollOffGame, parentGame)
-	if err != nil {
-		log.Printf("Error rendering game message: %v", err)
-		return
-	}
+// Helper function to create a string pointer
+func stringPtr(s string) *string {
+	return &s
+}
 
-	// Add the force-start message to the game message
-	if messageEdit.Embeds != nil && len(messageEdit.Embeds) > 0 {
-		messageEdit.Embeds[0].Description = forceStartMsg + "\n\n" + messageEdit.Embeds[0].Description
-	} else if messageEdit.Content != nil {
-		// Create a new content string with the force-start message
-		newContent := forceStartMsg + "\n\n" + *messageEdit.Content
-		messageEdit.Content = &newContent
-	} else {
-		// If there's no content, create a new one
-		messageEdit.Content = &forceStartMsg
+// friendlyError maps known game errors to a message from the messaging service
+func (b *Bot) friendlyError(ctx context.Context, err error, fallback string) string {
+	errorType := messaging.ErrorTypeFor(err)
+	if errorType == messaging.ErrorTypeUnknown {
+		return fmt.Sprintf("%s: %v", fallback, err)
 	}
 
-	// Send the message edit
-	_, err = s.ChannelMessageEditComplex(messageEdit)
-	if err != nil {
-		log.Printf("Error updating game message: %v", err)
+	errorMsgOutput, msgErr := b.messagingService.GetErrorMessage(ctx, &messaging.GetErrorMessageInput{
+		ErrorType: errorType,
+	})
+	if msgErr != nil {
+		return fmt.Sprintf("%s: %v", fallback, err)
 	}
-}
 
-// Helper function to create a string pointer
-func stringPtr(s string) *string {
-	return &s
+	return errorMsgOutput.Message
 }
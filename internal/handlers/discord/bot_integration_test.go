@@ -0,0 +1,259 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/bwmarrin/discordgo"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeRequest is a request the bot sent to the Discord API
+type fakeRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// fakeDiscord stands in for the Discord REST API and records every request
+type fakeDiscord struct {
+	mu       sync.Mutex
+	requests []fakeRequest
+}
+
+// RoundTrip records the request and answers with a minimal message object
+func (f *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := fakeRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal(body, &recorded.Body)
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, recorded)
+	f.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"id":"fake-message-id","channel_id":"fake-channel-id"}`)),
+		Request:    req,
+	}, nil
+}
+
+// find returns the recorded requests matching the method whose path contains the fragment
+func (f *fakeDiscord) find(method, pathFragment string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matches []fakeRequest
+	for _, req := range f.requests {
+		if req.Method == method && strings.Contains(req.Path, pathFragment) {
+			matches = append(matches, req)
+		}
+	}
+	return matches
+}
+
+// DiscordIntegrationTestSuite drives bot handlers end-to-end against real services and a fake Discord API
+type DiscordIntegrationTestSuite struct {
+	suite.Suite
+	mr             *miniredis.Miniredis
+	client         *redis.Client
+	mockCtrl       *gomock.Controller
+	mockDiceRoller *diceMocks.MockRoller
+	discord        *fakeDiscord
+	gameService    game.Service
+	bot            *Bot
+	ctx            context.Context
+
+	testChannelID string
+	testMessageID string
+}
+
+func (s *DiscordIntegrationTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockDiceRoller = diceMocks.NewMockRoller(s.mockCtrl)
+	s.ctx = context.Background()
+	s.testChannelID = "test-channel-id"
+	s.testMessageID = "test-message-id"
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+	s.Require().NoError(err)
+	s.gameService = gameSvc
+
+	msgSvc, err := messaging.NewService(&messaging.ServiceConfig{})
+	s.Require().NoError(err)
+
+	bot, err := New(&Config{
+		Token:               "test-token",
+		GameService:         gameSvc,
+		MessagingService:    msgSvc,
+		MessageUpdateWindow: 10 * time.Millisecond,
+	})
+	s.Require().NoError(err)
+
+	// Send every REST call to the fake Discord API
+	s.discord = &fakeDiscord{}
+	bot.session.Client = &http.Client{Transport: s.discord}
+	s.bot = bot
+}
+
+func (s *DiscordIntegrationTestSuite) TearDownTest() {
+	s.bot.updates.stop()
+	s.mockCtrl.Finish()
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestDiscordIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(DiscordIntegrationTestSuite))
+}
+
+// createGame creates a game in the test channel with a posted game message
+func (s *DiscordIntegrationTestSuite) createGame(creatorID string) string {
+	output, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   creatorID,
+		CreatorName: creatorID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    output.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	return output.GameID
+}
+
+// click simulates a user pressing a message component
+func (s *DiscordIntegrationTestSuite) click(customID, userID string) {
+	s.bot.handleInteraction(s.bot.session, &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			ID:        "interaction-" + userID,
+			AppID:     "test-app-id",
+			Token:     "token-" + userID,
+			Type:      discordgo.InteractionMessageComponent,
+			ChannelID: s.testChannelID,
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: userID, Username: userID},
+			},
+			Data: discordgo.MessageComponentInteractionData{
+				CustomID: customID,
+			},
+		},
+	})
+}
+
+func (s *DiscordIntegrationTestSuite) TestJoinButton() {
+	gameID := s.createGame("alice")
+
+	s.click(ButtonJoinGame, "bob")
+
+	// Bob is in the game
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.NotNil(gameOutput.Game.GetParticipant("bob"))
+
+	// The interaction was answered with an ephemeral message
+	callbacks := s.discord.find(http.MethodPost, "/interactions/interaction-bob/token-bob/callback")
+	s.Require().Len(callbacks, 1)
+	data, ok := callbacks[0].Body["data"].(map[string]interface{})
+	s.Require().True(ok)
+	s.EqualValues(discordgo.MessageFlagsEphemeral, data["flags"])
+
+	// The shared game message was edited
+	s.Len(s.discord.find(http.MethodPatch, "/channels/"+s.testChannelID+"/messages/"+s.testMessageID), 1)
+}
+
+func (s *DiscordIntegrationTestSuite) TestRollButton_CompletesGame() {
+	gameID := s.createGame("alice")
+	s.click(ButtonJoinGame, "bob")
+	s.click(ButtonBeginGame, "alice")
+
+	gomock.InOrder(
+		s.mockDiceRoller.EXPECT().Roll(6).Return(4),
+		s.mockDiceRoller.EXPECT().Roll(6).Return(2),
+	)
+
+	s.click(ButtonRollDice, "alice")
+	s.click(ButtonRollDice, "bob")
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, gameOutput.Game.Status)
+
+	// Each roll was deferred and the deferred response edited with the result
+	s.Len(s.discord.find(http.MethodPost, "/interactions/interaction-alice/token-alice/callback"), 2)
+	s.NotEmpty(s.discord.find(http.MethodPatch, "/webhooks/test-app-id/token-alice/messages/@original"))
+	s.NotEmpty(s.discord.find(http.MethodPatch, "/webhooks/test-app-id/token-bob/messages/@original"))
+
+	// Bob drinks for the lowest roll
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &game.GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Len(records.Records, 1)
+	s.Equal("bob", records.Records[0].ToPlayerID)
+	s.Equal(models.DrinkReasonLowestRoll, records.Records[0].Reason)
+
+	// The final edit shows the completed game once the coalesced updates are sent
+	s.Eventually(func() bool {
+		edits := s.discord.find(http.MethodPatch, "/channels/"+s.testChannelID+"/messages/"+s.testMessageID)
+		if len(edits) == 0 {
+			return false
+		}
+		embeds, ok := edits[len(edits)-1].Body["embeds"].([]interface{})
+		return ok && len(embeds) > 0 && embeds[0].(map[string]interface{})["title"] == getGameTitle(gameOutput.Game)
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,2167 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/handlers/discord/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	guildConfigMocks "github.com/KirkDiggler/ronnied/internal/services/guild_config/mocks"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/KirkDiggler/ronnied/internal/services/preset"
+	presetMocks "github.com/KirkDiggler/ronnied/internal/services/preset/mocks"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/bwmarrin/discordgo"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// BotTestSuite tests the bot handlers against a mocked Discord session
+type BotTestSuite struct {
+	suite.Suite
+	mr          *miniredis.Miniredis
+	client      *redis.Client
+	mockCtrl    *gomock.Controller
+	mockSession *mocks.MockDiscordSession
+	gameService game.Service
+	bot         *Bot
+	ctx         context.Context
+
+	testChannelID string
+	testMessageID string
+}
+
+func (s *BotTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockSession = mocks.NewMockDiscordSession(s.mockCtrl)
+	s.ctx = context.Background()
+	s.testChannelID = "test-channel-id"
+	s.testMessageID = "test-message-id"
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      diceMocks.NewMockRoller(s.mockCtrl),
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+	s.Require().NoError(err)
+	s.gameService = gameSvc
+
+	msgSvc, err := messaging.NewService(&messaging.ServiceConfig{})
+	s.Require().NoError(err)
+
+	s.bot, err = New(&Config{
+		Token:            "test-token",
+		ApplicationID:    "test-app-id",
+		GameService:      gameSvc,
+		MessagingService: msgSvc,
+		Session:          s.mockSession,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *BotTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestBotTestSuite(t *testing.T) {
+	suite.Run(t, new(BotTestSuite))
+}
+
+// componentInteraction builds a message component interaction from the given user
+func (s *BotTestSuite) componentInteraction(customID, userID string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionMessageComponent,
+			ChannelID: s.testChannelID,
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: userID, Username: userID},
+			},
+			Data: discordgo.MessageComponentInteractionData{
+				CustomID: customID,
+			},
+		},
+	}
+}
+
+func (s *BotTestSuite) TestRegisterCommand() {
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil)
+
+	s.mockSession.EXPECT().
+		ApplicationCommandCreate("test-app-id", "", cmd.GetCommand()).
+		Return(&discordgo.ApplicationCommand{ID: "command-id"}, nil)
+
+	err := s.bot.RegisterCommand(cmd)
+	s.Require().NoError(err)
+	s.Equal("command-id", s.bot.commandIDs[cmd.GetName()])
+}
+
+func (s *BotTestSuite) TestRegisterCommand_Error() {
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil)
+
+	s.mockSession.EXPECT().
+		ApplicationCommandCreate("test-app-id", "", cmd.GetCommand()).
+		Return(nil, errors.New("discord unavailable"))
+
+	err := s.bot.RegisterCommand(cmd)
+	s.Error(err)
+	s.NotContains(s.bot.commands, cmd.GetName())
+}
+
+func (s *BotTestSuite) TestUnknownButton() {
+	i := s.componentInteraction("mystery_button", "alice")
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Require().Len(resp.Data.Embeds, 1)
+			s.Equal("Unknown button: mystery_button", resp.Data.Embeds[0].Description)
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+}
+
+func (s *BotTestSuite) TestJoinGameButton_NoGame() {
+	i := s.componentInteraction(ButtonJoinGame, "bob")
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+}
+
+func (s *BotTestSuite) TestJoinGameButton() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	i := s.componentInteraction(ButtonJoinGame, "bob")
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal(s.testChannelID, edit.Channel)
+			s.Equal(s.testMessageID, edit.ID)
+			return &discordgo.Message{ID: s.testMessageID}, nil
+		})
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseChannelMessageWithSource, resp.Type)
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			s.NotEmpty(resp.Data.Components)
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: createOutput.GameID})
+	s.Require().NoError(err)
+	s.NotNil(gameOutput.Game.GetParticipant("bob"))
+}
+
+func (s *BotTestSuite) TestGuildMemberUpdate_RedrawsGame() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal(s.testMessageID, edit.ID)
+			return &discordgo.Message{ID: s.testMessageID}, nil
+		})
+
+	s.bot.handleGuildMemberUpdate(&discordgo.GuildMemberUpdate{
+		Member: &discordgo.Member{
+			GuildID: "test-guild",
+			Nick:    "Big Al",
+			User:    &discordgo.User{ID: "alice", Username: "alice"},
+		},
+	})
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: createOutput.GameID})
+	s.Require().NoError(err)
+	s.Equal("Big Al", gameOutput.Game.GetParticipant("alice").PlayerName)
+
+	// Nothing to redraw when the name didn't change
+	s.bot.handleGuildMemberUpdate(&discordgo.GuildMemberUpdate{
+		Member: &discordgo.Member{
+			GuildID: "test-guild",
+			Nick:    "Big Al",
+			User:    &discordgo.User{ID: "alice", Username: "alice"},
+		},
+	})
+}
+
+// createPrivateGame creates a private game started by alice with its message, inviting the given players
+func (s *BotTestSuite) createPrivateGame(invited ...string) string {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:        s.testChannelID,
+		CreatorID:        "alice",
+		CreatorName:      "alice",
+		Private:          true,
+		InvitedPlayerIDs: invited,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	return createOutput.GameID
+}
+
+func (s *BotTestSuite) TestJoinGameButton_NotInvited() {
+	gameID := s.createPrivateGame("bob")
+
+	i := s.componentInteraction(ButtonJoinGame, "carol")
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			s.NotContains(resp.Data.Content, string(game.ErrNotInvited))
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Nil(gameOutput.Game.GetParticipant("carol"))
+}
+
+func (s *BotTestSuite) TestInvitePlayersSelect() {
+	gameID := s.createPrivateGame("bob")
+
+	i := s.componentInteraction(SelectInvitePlayers, "alice")
+	i.Data = discordgo.MessageComponentInteractionData{
+		CustomID: SelectInvitePlayers,
+		Values:   []string{"carol", "dave"},
+	}
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Contains(fieldNames(edit.Embeds[0]), "🔒 Private Table")
+			return &discordgo.Message{ID: s.testMessageID}, nil
+		})
+
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Contains(msg.Content, "<@carol>, <@dave>")
+			s.Equal([]string{"carol", "dave"}, msg.AllowedMentions.Users)
+			return &discordgo.Message{ID: "invite-message-id"}, nil
+		})
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal([]string{"bob", "carol", "dave"}, gameOutput.Game.InvitedPlayerIDs)
+}
+
+func (s *BotTestSuite) TestInvitePlayersSelect_NotCreator() {
+	gameID := s.createPrivateGame("bob")
+
+	i := s.componentInteraction(SelectInvitePlayers, "bob")
+	i.Data = discordgo.MessageComponentInteractionData{
+		CustomID: SelectInvitePlayers,
+		Values:   []string{"carol"},
+	}
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Contains(resp.Data.Content, "only whoever started the game")
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal([]string{"bob"}, gameOutput.Game.InvitedPlayerIDs)
+}
+
+// gameView returns the view a game is rendered from, with the rules it's played with
+func (s *BotTestSuite) gameView(g *models.Game) *game.GetGameViewOutput {
+	output, err := s.gameService.GetGameRules(s.ctx, &game.GetGameRulesInput{Game: g})
+	s.Require().NoError(err)
+	return &game.GetGameViewOutput{Game: g, Rules: output.Rules}
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_Private() {
+	g := &models.Game{
+		ID:               "game-1",
+		ChannelID:        s.testChannelID,
+		MessageID:        s.testMessageID,
+		CreatorID:        "alice",
+		Status:           models.GameStatusWaiting,
+		Private:          true,
+		InvitedPlayerIDs: []string{"bob", "carol"},
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice"},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+
+	embed := edit.Embeds[0]
+	s.Equal([]string{"📊 Status", "👥 Players", "🔒 Private Table", "👥 Participants (1)"}, fieldNames(embed))
+	s.Equal("Invite only: <@bob>, <@carol>", embed.Fields[2].Value)
+
+	row := edit.Components[0].(discordgo.ActionsRow)
+	s.Len(row.Components, 5)
+	s.Equal(ButtonInvitePlayers, row.Components[3].(discordgo.Button).CustomID)
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_Waitlist() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		CreatorID: "alice",
+		Status:    models.GameStatusWaiting,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice"},
+		},
+		Waitlist: []*models.WaitlistEntry{
+			{PlayerID: "bob", PlayerName: "bob"},
+			{PlayerID: "carol", PlayerName: "carol"},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+
+	embed := edit.Embeds[0]
+	s.Equal([]string{"📊 Status", "👥 Players", "⏳ Waitlist (2)", "👥 Participants (1)"}, fieldNames(embed))
+	s.Equal("1. <@bob>\n2. <@carol>", embed.Fields[2].Value)
+
+	row := edit.Components[0].(discordgo.ActionsRow)
+	s.Equal(ButtonLeaveGame, row.Components[2].(discordgo.Button).CustomID)
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_Departures() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		CreatorID: "alice",
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice"},
+		},
+		Departures: []*models.Departure{
+			{PlayerID: "bob", PlayerName: "bob"},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+
+	embed := edit.Embeds[0]
+	s.Contains(fieldNames(embed), "🚪 Left the Server")
+	for _, field := range embed.Fields {
+		s.NotContains(field.Value, "<@bob>", "a mention of someone who left shows up broken")
+	}
+}
+
+func (s *BotTestSuite) TestMentionedUserIDs() {
+	s.Equal([]string{"123", "456"}, mentionedUserIDs("<@123> and <@!456>, not @789"))
+	s.Empty(mentionedUserIDs("@bob @carol"))
+}
+
+// panickingCommand is a command whose handler always panics
+type panickingCommand struct {
+	BaseCommand
+}
+
+// Handle panics
+func (c *panickingCommand) Handle(s DiscordSession, i *discordgo.InteractionCreate) error {
+	panic("boom")
+}
+
+// commandInteraction builds a slash command interaction for the named command
+func (s *BotTestSuite) commandInteraction(name string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: name,
+			},
+		},
+	}
+}
+
+func (s *BotTestSuite) TestHandlerPanic_RespondsWithError() {
+	s.bot.commands["explode"] = &panickingCommand{BaseCommand{Name: "explode"}}
+	i := s.commandInteraction("explode")
+	panicsBefore := handlerPanics.Value()
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(panicMessage, resp.Data.Content)
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+
+	s.NotPanics(func() {
+		s.bot.handleInteraction(s.mockSession, i)
+	})
+	s.Equal(panicsBefore+1, handlerPanics.Value())
+}
+
+func (s *BotTestSuite) TestHandlerPanic_AlreadyAcknowledged() {
+	s.bot.commands["explode"] = &panickingCommand{BaseCommand{Name: "explode"}}
+	i := s.commandInteraction("explode")
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		Return(errors.New("interaction has already been acknowledged"))
+
+	s.mockSession.EXPECT().
+		FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: panicMessage,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		}).
+		Return(&discordgo.Message{}, nil)
+
+	s.NotPanics(func() {
+		s.bot.handleInteraction(s.mockSession, i)
+	})
+}
+
+func (s *BotTestSuite) TestInteractionWhileDraining_IsTurnedAway() {
+	s.bot.commands["explode"] = &panickingCommand{BaseCommand{Name: "explode"}}
+	i := s.commandInteraction("explode")
+	s.Equal(0, s.bot.interactions.drain(s.bot.clock, time.Second))
+	turnedAwayBefore := interactionsTurnedAway.Value()
+
+	// The command isn't run, so doesn't panic
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(drainingMessage, resp.Data.Content)
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+
+	s.bot.handleInteraction(s.mockSession, i)
+	s.Equal(turnedAwayBefore+1, interactionsTurnedAway.Value())
+}
+
+func (s *BotTestSuite) TestSessionRotated_PostsClosingLeaderboard() {
+	event := &events.Event{
+		Type:      events.TypeSessionRotated,
+		ChannelID: s.testChannelID,
+		SessionID: "new-session",
+		Payload: &events.SessionRotatedPayload{
+			PreviousSessionID: "old-session",
+			Reason:            "daily",
+			Standings: []*events.SessionStanding{
+				{PlayerID: "bob", PlayerName: "bob", DrinkCount: 3, PaidCount: 2},
+			},
+		},
+	}
+
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Require().Len(msg.Embeds, 1)
+			s.Contains(msg.Embeds[0].Fields[0].Value, "**bob**: 3 drinks (2 paid)")
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleSessionRotated(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestSessionRotated_ShowsPace() {
+	startedAt := time.Date(2025, 4, 19, 20, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(3 * time.Hour)
+	event := &events.Event{
+		Type:      events.TypeSessionRotated,
+		ChannelID: s.testChannelID,
+		SessionID: "new-session",
+		Payload: &events.SessionRotatedPayload{
+			PreviousSessionID: "old-session",
+			Reason:            "inactivity",
+			StartedAt:         startedAt,
+			EndedAt:           endedAt,
+			Standings: []*events.SessionStanding{
+				{PlayerID: "bob", PlayerName: "bob", DrinkCount: 3, DrinksPerHour: 1, LongestDryStreak: 65 * time.Minute},
+			},
+		},
+	}
+
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Require().Len(msg.Embeds, 1)
+			fields := msg.Embeds[0].Fields
+			s.Require().Len(fields, 3)
+			s.Contains(fields[1].Value, fmt.Sprintf("<t:%d:t> to <t:%d:t>", startedAt.Unix(), endedAt.Unix()))
+			s.Equal("Pace", fields[2].Name)
+			s.Contains(fields[2].Value, "**bob**: ⏱️ 1.0/hr, 🏜️ longest dry streak 1h 05m")
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleSessionRotated(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestRenderFairnessReport() {
+	embed := renderFairnessReport(&game.GetFairnessReportOutput{
+		Players: []*game.FairnessPlayer{
+			{PlayerID: "alice", PlayerName: "alice", Given: 3, Targets: map[string]int{"carol": 3}},
+			{PlayerID: "bob", PlayerName: "bob", Given: 3, Targets: map[string]int{"carol": 3}},
+			{PlayerID: "carol", PlayerName: "carol", Received: 6, Targets: map[string]int{}},
+		},
+		Pairs: []*game.FairnessPair{
+			{FirstPlayerID: "alice", SecondPlayerID: "bob", Pattern: game.FairnessPatternAlliance},
+			{FirstPlayerID: "alice", SecondPlayerID: "carol", FirstToSecond: 3},
+			{FirstPlayerID: "bob", SecondPlayerID: "carol", FirstToSecond: 3},
+		},
+	})
+
+	s.Require().Len(embed.Fields, 3)
+	s.Contains(embed.Fields[0].Value, "alice        -      0      3")
+	s.NotContains(embed.Fields[0].Value, "\ncarol ", "players who gave nothing get no row")
+	s.Contains(embed.Fields[1].Value, "**alice** → **carol** 3, **carol** → **alice** 0 (0% even)")
+	s.Equal("**alice** and **bob** hand out drinks but never to each other\n", embed.Fields[2].Value)
+}
+
+func (s *BotTestSuite) TestSessionRotated_WrittenForTheServersLocale() {
+	s.bot.locales.remember("test-guild-id", discordgo.German)
+
+	event := &events.Event{
+		Type:      events.TypeSessionRotated,
+		ChannelID: s.testChannelID,
+		GuildID:   "test-guild-id",
+		Payload: &events.SessionRotatedPayload{
+			PreviousSessionID: "old-session",
+			Standings: []*events.SessionStanding{
+				{PlayerID: "bob", PlayerName: "bob", DrinkCount: 3, DrinksPerHour: 1.5, LongestDryStreak: 45 * time.Minute},
+				{PlayerID: "dana", PlayerName: "דנה", DrinkCount: 1},
+			},
+		},
+	}
+
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			fields := msg.Embeds[0].Fields
+			s.Require().Len(fields, 3)
+			s.Contains(fields[0].Value, "**\u2068דנה\u2069**: 1 drinks", "right to left names are isolated")
+			s.Contains(fields[2].Value, "**bob**: ⏱️ 1,5/hr")
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleSessionRotated(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestFormatDecimal() {
+	s.Equal("1.5", formatDecimal(discordgo.EnglishUS, 1.5, 1))
+	s.Equal("1,5", formatDecimal(discordgo.French, 1.5, 1))
+	s.Equal("2.25", formatDecimal(discordgo.Unknown, 2.25, 2))
+	s.Equal("alice", isolateName("alice"))
+}
+
+func (s *BotTestSuite) TestRenderSessionGoals() {
+	s.Empty(renderSessionGoals(nil))
+	s.Equal("🎯 **First to 3 crits** ✅ <@alice>\n🎯 **Survive 5 games without a fail**\n", renderSessionGoals([]*models.SessionGoal{
+		{Type: models.SessionGoalFirstToCrits, Target: 3, CompletedBy: []string{"alice"}},
+		{Type: models.SessionGoalSurviveGames, Target: 5},
+	}))
+	s.Equal([]*models.SessionGoal{{Type: models.SessionGoalSurviveGames, Target: 4}}, sessionGoalsFromOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "survive-games", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(4)},
+	}))
+}
+
+func (s *BotTestSuite) TestGameTimeLine() {
+	createdAt := time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	startedAt := createdAt.Add(2 * time.Minute)
+	g := &models.Game{Status: models.GameStatusWaiting, CreatedAt: createdAt}
+	s.Equal(fmt.Sprintf("🕒 Created <t:%d:R>", createdAt.Unix()), gameTimeLine(g))
+
+	g.Status, g.StartedAt = models.GameStatusActive, &startedAt
+	s.Equal(fmt.Sprintf("🕒 Started <t:%d:R>", startedAt.Unix()), gameTimeLine(g))
+
+	g.StartedAt = nil
+	s.Empty(gameTimeLine(g), "games from before starts were saved say nothing")
+}
+
+func (s *BotTestSuite) TestGameDurationWarning_PingsSlowPlayers() {
+	endsAt := time.Date(2025, 4, 19, 21, 20, 0, 0, time.UTC)
+	event := &events.Event{
+		Type:      events.TypeGameDurationWarning,
+		ChannelID: s.testChannelID,
+		GameID:    "game-1",
+		Payload: &events.GameDurationWarningPayload{
+			PlayerIDs:  []string{"bob", "carol"},
+			EndsAt:     endsAt,
+			RootGameID: "game-1",
+		},
+	}
+
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("<@bob> <@carol>", msg.Content)
+			s.Equal([]string{"bob", "carol"}, msg.AllowedMentions.Users)
+			s.Require().Len(msg.Embeds, 1)
+			s.Contains(msg.Embeds[0].Description, fmt.Sprintf("<t:%d:R>", endsAt.Unix()))
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleGameDurationWarning(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestDrinkReminder_ByDM() {
+	event := &events.Event{
+		Type:      events.TypeDrinkReminder,
+		ChannelID: s.testChannelID,
+		Payload: &events.DrinkReminderPayload{
+			PlayerID:   "bob",
+			PlayerName: "Bob",
+			DrinkCount: 2,
+			Reminder:   1,
+			Delivery:   string(models.ReminderDeliveryDM),
+		},
+	}
+
+	s.mockSession.EXPECT().UserChannelCreate("bob").Return(&discordgo.Channel{ID: "dm-channel"}, nil)
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex("dm-channel", gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Empty(msg.Content)
+			s.Require().Len(msg.Embeds, 1)
+			s.Contains(msg.Embeds[0].Description, "Bob")
+			s.Contains(msg.Embeds[0].Description, "2 drinks")
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleDrinkReminder(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestDrinkReminder_ClosedDMsMentionInChannel() {
+	event := &events.Event{
+		Type:      events.TypeDrinkReminder,
+		ChannelID: s.testChannelID,
+		Payload: &events.DrinkReminderPayload{
+			PlayerID:   "bob",
+			PlayerName: "Bob",
+			DrinkCount: 1,
+			Reminder:   3,
+			Delivery:   string(models.ReminderDeliveryDM),
+		},
+	}
+
+	s.mockSession.EXPECT().UserChannelCreate("bob").Return(nil, errors.New("cannot send messages to this user"))
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("<@bob>", msg.Content)
+			s.Equal([]string{"bob"}, msg.AllowedMentions.Users)
+			s.Require().Len(msg.Embeds, 1)
+			s.Contains(msg.Embeds[0].Description, "1 drink")
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleDrinkReminder(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestSessionRotated_LeaderboardImage() {
+	s.bot.config.LeaderboardImages = true
+
+	event := &events.Event{
+		Type:      events.TypeSessionRotated,
+		ChannelID: s.testChannelID,
+		SessionID: "new-session",
+		Payload: &events.SessionRotatedPayload{
+			PreviousSessionID: "old-session",
+			Reason:            "daily",
+			Standings: []*events.SessionStanding{
+				{PlayerID: "bob", PlayerName: "bob", DrinkCount: 3, PaidCount: 2},
+				{PlayerID: "carol", PlayerName: "carol", DrinkCount: 1, DesignatedDriver: true},
+			},
+		},
+	}
+
+	// Players whose avatars can't be looked up get a placeholder
+	s.mockSession.EXPECT().User(gomock.Any()).Return(nil, errors.New("unknown user")).Times(2)
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Require().Len(msg.Files, 1)
+			s.Equal(leaderboardImageName, msg.Files[0].Name)
+			s.Equal("image/png", msg.Files[0].ContentType)
+
+			img, err := png.Decode(msg.Files[0].Reader)
+			s.Require().NoError(err)
+			s.Equal(leaderboardImageWidth, img.Bounds().Dx())
+			s.Equal(leaderboardHeader+2*leaderboardRowHeight+leaderboardPadding, img.Bounds().Dy())
+
+			s.Require().Len(msg.Embeds, 1)
+			s.Require().NotNil(msg.Embeds[0].Image)
+			s.Equal("attachment://"+leaderboardImageName, msg.Embeds[0].Image.URL)
+
+			// The image replaces the text standings
+			s.Require().Len(msg.Embeds[0].Fields, 1)
+			s.Equal("Totals", msg.Embeds[0].Fields[0].Name)
+			return &discordgo.Message{}, nil
+		})
+
+	s.bot.handleSessionRotated(s.ctx, event)
+}
+
+func (s *BotTestSuite) TestRenderLeaderboardImage_WithAvatar() {
+	avatar := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	draw.Draw(avatar, avatar.Bounds(), image.NewUniform(color.RGBA{R: 0xFF, A: 0xFF}), image.Point{}, draw.Src)
+
+	standings := []*events.SessionStanding{
+		{PlayerID: "bob", PlayerName: "bob", DrinkCount: 2, PaidCount: 1},
+	}
+
+	data, err := renderLeaderboardImage(standings, map[string]image.Image{"bob": avatar})
+	s.Require().NoError(err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	s.Require().NoError(err)
+
+	// The middle of bob's avatar is the avatar itself, the corner of it is clipped to a circle
+	at := image.Pt(leaderboardNameX-leaderboardAvatarSize-12, leaderboardHeader+leaderboardRowHeight/2-leaderboardAvatarSize/2)
+	r, g, b, _ := img.At(at.X+leaderboardAvatarSize/2, at.Y+leaderboardAvatarSize/2).RGBA()
+	s.Equal([]uint32{0xFFFF, 0, 0}, []uint32{r, g, b})
+
+	r, _, _, _ = img.At(at.X, at.Y).RGBA()
+	s.NotEqual(uint32(0xFFFF), r)
+}
+
+func (s *BotTestSuite) TestDiceRolled_RollLog() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", RollFeed: models.RollFeedLog},
+		}, nil).
+		Times(3)
+	s.bot.guildConfigService = mockGuildConfig
+
+	rolled := func(gameID, playerID string, value int) *events.Event {
+		return &events.Event{
+			Type:      events.TypeDiceRolled,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			GameID:    gameID,
+			Payload: &events.DiceRolledPayload{
+				PlayerID:   playerID,
+				PlayerName: playerID,
+				RollValue:  value,
+				RootGameID: gameID,
+			},
+		}
+	}
+
+	// The game's first roll posts the log, later rolls edit it
+	gomock.InOrder(
+		s.mockSession.EXPECT().
+			ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+			DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+				s.Equal("🎲 **alice** rolled a **4**", msg.Embeds[0].Description)
+				return &discordgo.Message{ID: "log-message-id"}, nil
+			}),
+		s.mockSession.EXPECT().
+			ChannelMessageEditComplex(gomock.Any()).
+			DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+				s.Equal("log-message-id", edit.ID)
+				s.Equal("🎲 **alice** rolled a **4**\n🎲 **bob** rolled a **2**", edit.Embeds[0].Description)
+				return &discordgo.Message{ID: "log-message-id"}, nil
+			}),
+		// A new game starts a new log
+		s.mockSession.EXPECT().
+			ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+			DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+				s.Equal("🎲 **bob** rolled a **5**", msg.Embeds[0].Description)
+				return &discordgo.Message{ID: "next-log-message-id"}, nil
+			}),
+	)
+
+	s.bot.handleDiceRolled(s.ctx, rolled("game-1", "alice", 4))
+	s.bot.handleDiceRolled(s.ctx, rolled("game-1", "bob", 2))
+	s.bot.handleDiceRolled(s.ctx, rolled("game-2", "bob", 5))
+}
+
+func (s *BotTestSuite) TestStartWithUnknownPreset() {
+	mockPresets := presetMocks.NewMockService(s.mockCtrl)
+	cmd := NewRonniedCommand(s.gameService, nil, mockPresets, nil, nil, nil)
+
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: "alice", Username: "alice"},
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "start",
+						Type: discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{Name: "preset", Type: discordgo.ApplicationCommandOptionString, Value: "quickies"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mockPresets.EXPECT().
+		GetPreset(gomock.Any(), &preset.GetPresetInput{GuildID: "test-guild-id", Name: "quickies"}).
+		Return(nil, preset.ErrPresetNotFound)
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			s.Contains(resp.Data.Content, "quickies")
+			return nil
+		})
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// No game was created
+	_, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{ChannelID: s.testChannelID, GuildID: "test-guild-id"})
+	s.ErrorIs(err, game.ErrGameNotFound)
+}
+
+// fieldNames returns the names of an embed's fields
+func fieldNames(embed *discordgo.MessageEmbed) []string {
+	names := make([]string, 0, len(embed.Fields))
+	for _, field := range embed.Fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_Compact() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice", RollValue: 6},
+			{PlayerID: "bob", PlayerName: "bob", RollValue: 3},
+			{PlayerID: "carol", PlayerName: "carol"},
+		},
+	}
+	drinks := []*models.DrinkLedger{
+		{FromPlayerID: "alice", ToPlayerID: "bob", Reason: models.DrinkReasonCriticalHit},
+	}
+	leaderboard := []game.LeaderboardEntry{
+		{PlayerID: "bob", PlayerName: "bob", DrinkCount: 2, PaidCount: 1},
+	}
+
+	view := s.gameView(g)
+	view.DrinkRecords = drinks
+	view.SessionLeaderboard = leaderboard
+	edit, err := s.bot.renderGameMessage(context.Background(), view, models.DisplayCompact)
+	s.Require().NoError(err)
+
+	embed := edit.Embeds[0]
+	s.Equal([]string{"📊 Status", "👥 Players", "👥 Participants (3)", "🍻 Recent Drinks (1)", "🏆 Session Leaderboard"}, fieldNames(embed))
+	s.Equal("• **alice** 🔥 6\n• **bob** 3\n• carol ⏳\n", embed.Fields[2].Value)
+	s.Equal("🔥 **bob** from alice\n", embed.Fields[3].Value)
+	s.Equal("1. **bob** 1/2 paid\n**Total**: 1/2 paid", embed.Fields[4].Value)
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_AboutToLose() {
+	rolledAt := time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice", RollValue: 4, RollTime: &rolledAt},
+			{PlayerID: "bob", PlayerName: "bob", RollValue: 3, RollTime: &rolledAt},
+			{PlayerID: "carol", PlayerName: "carol"},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+	s.Contains(edit.Embeds[0].Description, "📉 About to lose: **bob** with a **3**")
+
+	// carol ties bob
+	g.Participants[2].RollValue, g.Participants[2].RollTime = 3, &rolledAt
+	edit, err = s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+	s.Contains(edit.Embeds[0].Description, "📉 About to lose: **bob** = **carol** tied on **3**")
+
+	// Nobody loses a highest roll-off
+	view := s.gameView(g)
+	view.ParentGame = &models.Game{ID: "parent", HighestRollOffGameID: g.ID}
+	edit, err = s.bot.renderGameMessage(context.Background(), view, models.DisplayCompact)
+	s.Require().NoError(err)
+	s.NotContains(edit.Embeds[0].Description, "About to lose")
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_ServerEmojis() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice", RollValue: 6},
+			{PlayerID: "bob", PlayerName: "bob", RollValue: 1},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+	s.Nil(emojiReplacer(&models.GuildConfig{}), "servers that haven't picked any keep the bot's")
+
+	serverEmojis(edit, emojiReplacer(&models.GuildConfig{Emojis: map[models.EmojiSlot]string{
+		models.EmojiSlotCriticalHit: "<:crit:123456789012345678>",
+	}}))
+	s.Equal("• **alice** <:crit:123456789012345678> 6\n• **bob** 💀 1\n", edit.Embeds[0].Fields[2].Value)
+	s.Equal("🎲 Ronnied Drinking Game - Roll the Dice!", edit.Embeds[0].Title)
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_MultipleCrits() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Rules:     &models.GameRules{CriticalHitValues: []int{5, 6}, CriticalFailValues: []int{1, 2}},
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice", RollValue: 5},
+			{PlayerID: "bob", PlayerName: "bob", RollValue: 2},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayCompact)
+	s.Require().NoError(err)
+
+	embed := edit.Embeds[0]
+	s.Contains(embed.Description, "Roll a 5 or 6 to assign a drink, roll a 1 or 2 and you drink!")
+	s.Equal("• **alice** 🔥 5\n• **bob** 💀 2\n", embed.Fields[2].Value)
+}
+
+func (s *BotTestSuite) TestStartWithGuildCrits() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, mockGuildConfig, nil)
+
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: "alice", Username: "alice"},
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "start", Type: discordgo.ApplicationCommandOptionSubCommand},
+				},
+			},
+		},
+	}
+
+	// The config is read for where games can be started and for the game's rules
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", CriticalHitValues: []int{5, 6}},
+		}, nil).
+		Times(2)
+	s.mockSession.EXPECT().InteractionRespond(i.Interaction, gomock.Any()).Return(nil)
+	s.mockSession.EXPECT().ChannelMessages(s.testChannelID, 5, "", "", "").Return(nil, nil)
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// The server's crits are played with, the defaults filling in the rest
+	output, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{ChannelID: s.testChannelID, GuildID: "test-guild-id"})
+	s.Require().NoError(err)
+	s.Equal(&models.GameRules{CriticalHitValues: []int{5, 6}}, output.Game.Rules)
+}
+
+func (s *BotTestSuite) TestStartWithGuildDice() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, mockGuildConfig, nil)
+
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: "alice", Username: "alice"},
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "start", Type: discordgo.ApplicationCommandOptionSubCommand},
+				},
+			},
+		},
+	}
+
+	// The config is read for where games can be started and for the game's rules
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", DiceSides: 20},
+		}, nil).
+		Times(2)
+	s.mockSession.EXPECT().InteractionRespond(i.Interaction, gomock.Any()).Return(nil)
+	s.mockSession.EXPECT().ChannelMessages(s.testChannelID, 5, "", "", "").Return(nil, nil)
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// The d20's critical values come with it
+	output, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{ChannelID: s.testChannelID, GuildID: "test-guild-id"})
+	s.Require().NoError(err)
+	s.Equal(&models.GameRules{DiceSides: 20, CriticalHitValues: []int{20}, CriticalFailValues: []int{1}}, output.Game.Rules)
+}
+
+func (s *BotTestSuite) TestStartKeepsTheBotsMessage() {
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil)
+	cmd.botUserID = "bot-user"
+
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			AppID:     "app-id",
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: "alice", Username: "alice"},
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "start", Type: discordgo.ApplicationCommandOptionSubCommand},
+				},
+			},
+		},
+	}
+
+	// The application ID isn't the bot user's ID, only the bot's own message is the game's
+	s.mockSession.EXPECT().InteractionRespond(i.Interaction, gomock.Any()).Return(nil)
+	s.mockSession.EXPECT().ChannelMessages(s.testChannelID, 5, "", "", "").Return([]*discordgo.Message{
+		{ID: "someone-elses-message", Author: &discordgo.User{ID: "app-id"}},
+		{ID: "game-message", Author: &discordgo.User{ID: "bot-user"}},
+	}, nil)
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	output, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{ChannelID: s.testChannelID, GuildID: "test-guild-id"})
+	s.Require().NoError(err)
+	s.Equal("game-message", output.Game.MessageID)
+}
+
+func (s *BotTestSuite) TestGuildCreate_PostsSetup() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	s.bot.guildConfigService = mockGuildConfig
+
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: "test-guild-id"}}, nil)
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex("system-channel-id", gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Require().Len(msg.Components, len(setupSettings))
+			s.Equal("😂 Funny", msg.Embeds[0].Fields[0].Value)
+			s.Equal("🎲 d6", msg.Embeds[0].Fields[2].Value, "the bot's default dice are picked")
+			return &discordgo.Message{ID: "setup-message-id"}, nil
+		})
+
+	guild := &discordgo.Guild{ID: "test-guild-id", SystemChannelID: "system-channel-id", JoinedAt: time.Now()}
+	s.bot.handleGuildCreate(&discordgo.GuildCreate{Guild: guild})
+
+	// Servers the bot was already in are announced when it connects, and don't get it again
+	s.bot.handleGuildCreate(&discordgo.GuildCreate{Guild: &discordgo.Guild{
+		ID:              "test-guild-id",
+		SystemChannelID: "system-channel-id",
+		JoinedAt:        time.Now().Add(-24 * time.Hour),
+	}})
+}
+
+func (s *BotTestSuite) TestSetupButton() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	s.bot.guildConfigService = mockGuildConfig
+
+	i := s.componentInteraction(ButtonSetupPrefix+"dice:20", "alice")
+	i.GuildID = "test-guild-id"
+
+	// Only admins can change it
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+
+	i.Member.Permissions = discordgo.PermissionManageServer
+	mockGuildConfig.EXPECT().
+		SetDefaultDice(gomock.Any(), &guild_config.SetDefaultDiceInput{GuildID: "test-guild-id", Sides: 20}).
+		Return(&guild_config.SetDefaultDiceOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", DiceSides: 20},
+		}, nil)
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			s.Equal("🎲 d20", resp.Data.Embeds[0].Fields[2].Value)
+
+			dice := resp.Data.Components[2].(discordgo.ActionsRow).Components
+			s.Equal(discordgo.SecondaryButton, dice[0].(discordgo.Button).Style)
+			s.Equal(discordgo.PrimaryButton, dice[1].(discordgo.Button).Style)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_Points() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice"},
+		},
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayDetailed)
+	s.Require().NoError(err)
+	pointsWording(edit)
+
+	embed := edit.Embeds[0]
+	s.Equal("🎲 Ronnied Dice Game - Roll the Dice!", embed.Title)
+	s.Contains(embed.Description, "to assign a point, roll a 1 and you take a point!")
+	s.NotContains(strings.ToLower(embed.Fields[len(embed.Fields)-1].Value), "drink")
+
+	buttons := edit.Components[0].(discordgo.ActionsRow).Components
+	s.Equal("Pay Point", buttons[1].(discordgo.Button).Label)
+}
+
+func (s *BotTestSuite) TestSocialRound_PostsOneMessage() {
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Require().Len(msg.Embeds, 1)
+			s.Contains(msg.Embeds[0].Description, "**bob** rolled a 3")
+			return &discordgo.Message{ID: "social-message-id"}, nil
+		})
+
+	s.bot.handleSocialRound(context.Background(), &events.Event{
+		Type:      events.TypeSocialRound,
+		ChannelID: s.testChannelID,
+		GameID:    "game-1",
+		Payload: &events.SocialRoundPayload{
+			PlayerID:   "bob",
+			PlayerName: "bob",
+			RollValue:  3,
+			DrinkCount: 3,
+			RootGameID: "game-1",
+		},
+	})
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_SocialRoundShownOnce() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{PlayerID: "alice", PlayerName: "alice"},
+			{PlayerID: "bob", PlayerName: "bob"},
+			{PlayerID: "carol", PlayerName: "carol"},
+		},
+	}
+
+	now := time.Now()
+	view := s.gameView(g)
+	for _, p := range g.Participants {
+		view.DrinkRecords = append(view.DrinkRecords, &models.DrinkLedger{
+			GameID:       g.ID,
+			FromPlayerID: "bob",
+			ToPlayerID:   p.PlayerID,
+			Reason:       models.DrinkReasonSocialRound,
+			Timestamp:    now,
+		})
+	}
+
+	for _, density := range []models.DisplayDensity{models.DisplayDetailed, models.DisplayCompact} {
+		edit, err := s.bot.renderGameMessage(context.Background(), view, density)
+		s.Require().NoError(err)
+
+		var drinks string
+		for _, field := range edit.Embeds[0].Fields {
+			if strings.Contains(field.Name, "Recent Drink") {
+				drinks = field.Value
+			}
+		}
+		s.Equal(1, strings.Count(drinks, "Social!"), density)
+	}
+}
+
+func (s *BotTestSuite) TestRenderGameMessage_BigGroupsAreCompact() {
+	g := &models.Game{
+		ID:        "game-1",
+		ChannelID: s.testChannelID,
+		MessageID: s.testMessageID,
+		Status:    models.GameStatusWaiting,
+	}
+	for n := 0; n < compactParticipantThreshold; n++ {
+		id := fmt.Sprintf("player-%d", n)
+		g.Participants = append(g.Participants, &models.Participant{PlayerID: id, PlayerName: id})
+	}
+
+	edit, err := s.bot.renderGameMessage(context.Background(), s.gameView(g), models.DisplayDetailed)
+	s.Require().NoError(err)
+
+	// Detailed servers keep the rules, but the players are listed compactly
+	embed := edit.Embeds[0]
+	s.Equal([]string{"📊 Status", "👥 Players", "👥 Participants (20)", "📜 Game Rules"}, fieldNames(embed))
+	s.NotContains(embed.Fields[2].Value, "\n\n")
+}
+
+func (s *BotTestSuite) TestShowMoreButton() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	// The waiting game's sections are its status, player count, participants and rules
+	i := s.componentInteraction(fmt.Sprintf("%s%s:2", ButtonShowMorePrefix, createOutput.GameID), "bob")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			s.Require().Len(resp.Data.Embeds, 1)
+			s.Equal("👥 Participants & Rolls", resp.Data.Embeds[0].Title)
+			s.Require().Len(resp.Data.Embeds[0].Fields, 1)
+			s.Contains(resp.Data.Embeds[0].Fields[0].Value, "**alice**")
+			return nil
+		})
+
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestShowMoreButton_SectionGone() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	i := s.componentInteraction(fmt.Sprintf("%s%s:9", ButtonShowMorePrefix, createOutput.GameID), "bob")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Contains(resp.Data.Content, "changed")
+			return nil
+		})
+
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestTabPageButton() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	filter := tabFilter{UnpaidOnly: true, Reason: models.DrinkReasonCriticalHit, AssignedBy: "bob"}
+	i := s.componentInteraction(tabPageID(createOutput.GameID, 3, filter), "alice")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			s.Require().Len(resp.Data.Embeds, 1)
+			embed := resp.Data.Embeds[0]
+			s.Equal("🍺 alice's Tab", embed.Title)
+			s.Contains(embed.Description, "Showing unpaid only · 🔥 Critical hit · from <@bob>")
+			s.Equal("Nothing here", embed.Fields[0].Value)
+
+			// A tab that fits on one page has no page buttons
+			s.Equal("Page 1 of 1", embed.Footer.Text)
+			s.Empty(resp.Data.Components)
+			return nil
+		})
+
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestDeclineChallengeButton() {
+	output, err := s.gameService.CreateChallenge(s.ctx, &game.CreateChallengeInput{
+		ChannelID:      s.testChannelID,
+		ChallengerID:   "alice",
+		ChallengerName: "alice",
+		OpponentID:     "bob",
+		OpponentName:   "bob",
+	})
+	s.Require().NoError(err)
+
+	// Nobody else can turn it down
+	i := s.componentInteraction(ButtonDeclineChallengePrefix+output.Challenge.ID, "carol")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+
+	i = s.componentInteraction(ButtonDeclineChallengePrefix+output.Challenge.ID, "bob")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			s.Contains(resp.Data.Content, "<@bob> chickened out of <@alice>'s challenge")
+			s.Empty(resp.Data.Components)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestVoteButton() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.GameID
+	for _, playerID := range []string{"bob", "carol"} {
+		_, err := s.gameService.JoinGame(s.ctx, &game.JoinGameInput{GameID: gameID, PlayerID: playerID, PlayerName: playerID})
+		s.Require().NoError(err)
+	}
+
+	// Bob can't start alice's game, so pressing begin calls a vote to start it
+	i := s.componentInteraction(ButtonBeginGame, "bob")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Require().Len(resp.Data.Embeds, 1)
+			s.Equal("🗳️ Vote to Start the Game", resp.Data.Embeds[0].Title)
+			s.Len(resp.Data.Components, 1)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+
+	i = s.componentInteraction(ButtonVoteYesPrefix+gameID, "carol")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			s.Equal("The vote passed, the game was started.", resp.Data.Embeds[0].Description)
+			s.Empty(resp.Data.Components)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+
+	output, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, output.Game.Status)
+
+	// Once it's over the vote buttons just clear themselves
+	i = s.componentInteraction(ButtonVoteNoPrefix+gameID, "alice")
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal("This vote is over.", resp.Data.Content)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestChallengeResultEmbed() {
+	embed := challengeResultEmbed(&models.ChallengeGame{
+		ChallengerName: "alice",
+		OpponentName:   "bob",
+		Call:           models.ChallengeCallOdds,
+		ChallengerRoll: 2,
+		OpponentRoll:   4,
+	})
+	s.Contains(embed.Description, "that's 6 (even)")
+	s.Contains(embed.Description, "**bob** wins, 🍺 **alice** drinks!")
+}
+
+// rollInteraction builds a /ronnied roll interaction from alice
+func (s *BotTestSuite) rollInteraction(notation string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			Member: &discordgo.Member{
+				User: &discordgo.User{ID: "alice", Username: "alice"},
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "roll",
+						Type: discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{Name: "dice", Type: discordgo.ApplicationCommandOptionString, Value: notation},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *BotTestSuite) TestRollCommand() {
+	mockRoller := diceMocks.NewMockRoller(s.mockCtrl)
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil)
+	cmd.diceRoller = mockRoller
+
+	i := s.rollInteraction("4d6kh3+2")
+
+	gomock.InOrder(
+		mockRoller.EXPECT().Roll(6).Return(5),
+		mockRoller.EXPECT().Roll(6).Return(1),
+		mockRoller.EXPECT().Roll(6).Return(4),
+		mockRoller.EXPECT().Roll(6).Return(6),
+	)
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseChannelMessageWithSource, resp.Type)
+			s.Require().Len(resp.Data.Embeds, 1)
+			embed := resp.Data.Embeds[0]
+			s.Equal("🎲 alice rolled 4d6kh3+2", embed.Title)
+			s.Equal("+ 4d6kh3: [5, ~~1~~, 4, 6] = 15\n+ 2\n\nTotal: **17**", embed.Description)
+			return nil
+		})
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// Rolling dice doesn't start a game
+	_, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{ChannelID: s.testChannelID})
+	s.ErrorIs(err, game.ErrGameNotFound)
+}
+
+func (s *BotTestSuite) TestRollCommand_InvalidNotation() {
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil)
+	i := s.rollInteraction("3d6*2")
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			s.Contains(resp.Data.Content, "3d6+2")
+			return nil
+		})
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestCountdownFrames() {
+	opensAt := time.Date(2025, 4, 19, 21, 0, 3, 0, time.UTC)
+	frames := countdownFrames(&models.RollWindow{OpensAt: opensAt, ClosesAt: opensAt.Add(10 * time.Second)})
+
+	s.Require().Len(frames, 5)
+	s.Equal(opensAt.Add(-3*time.Second), frames[0].At)
+	s.Contains(frames[0].Text, "**3**")
+	s.Contains(frames[2].Text, "**1**")
+	s.Equal(opensAt, frames[3].At)
+	s.Contains(frames[3].Text, "ROLL NOW")
+	s.Equal(opensAt.Add(10*time.Second), frames[4].At)
+
+	s.Nil(rollTimingEmbed(""))
+	s.Contains(rollTimingEmbed(game.RollTimingEarly).Description, "jumped the gun")
+}
+
+func (s *BotTestSuite) TestRunCountdown() {
+	fakeClock := clock.NewFake(time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC))
+	s.bot.clock = fakeClock
+	opensAt := fakeClock.Now().Add(5 * time.Second)
+
+	var shown []string
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		DoAndReturn(func(_ string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			shown = append(shown, data.Content)
+			return &discordgo.Message{ID: "countdown-message"}, nil
+		})
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("countdown-message", edit.ID)
+			shown = append(shown, *edit.Content)
+			return &discordgo.Message{ID: edit.ID}, nil
+		}).
+		Times(4)
+
+	done := make(chan struct{})
+	go func() {
+		s.bot.runCountdown("", s.testChannelID, &models.RollWindow{OpensAt: opensAt, ClosesAt: opensAt.Add(10 * time.Second)})
+		close(done)
+	}()
+
+	// Each frame is shown once the clock reaches it
+	for i, step := range []time.Duration{2 * time.Second, time.Second, time.Second, time.Second, 10 * time.Second} {
+		fakeClock.BlockUntil(1)
+		s.Len(shown, i)
+		fakeClock.Advance(step)
+	}
+	<-done
+
+	s.Require().Len(shown, 5)
+	s.Contains(shown[0], "**3**")
+	s.Contains(shown[3], "ROLL NOW")
+	s.Contains(shown[4], "Time's up")
+}
+
+func (s *BotTestSuite) TestSideBetTargetSelect() {
+	gameID := "5cd42ee2-e49e-4622-9c1e-c417a965d9e3"
+	i := s.componentInteraction(SelectSideBetTargetPrefix+gameID, "carol")
+	i.Data = discordgo.MessageComponentInteractionData{
+		CustomID: SelectSideBetTargetPrefix + gameID,
+		Values:   []string{"123456789012345678"},
+	}
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseUpdateMessage, resp.Type)
+			row := resp.Data.Components[0].(discordgo.ActionsRow)
+			s.Len(row.Components, len(sideBetOutcomes))
+
+			// Each outcome button carries the whole bet within Discord's custom ID limit
+			customID := row.Components[2].(discordgo.Button).CustomID
+			s.LessOrEqual(len(customID), 100)
+			s.Equal(ButtonPlaceSideBetPrefix+gameID+":123456789012345678:over", customID)
+			return nil
+		})
+	s.Require().NoError(s.bot.handleComponentInteraction(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestSideBetsSettledEmbed() {
+	embed := sideBetsSettledEmbed(&events.SideBetsSettledPayload{
+		PlayerName: "bob",
+		RollValue:  5,
+		Bets: []*events.SettledSideBet{
+			{BettorName: "carol", Label: "over 3", Won: true},
+			{BettorName: "alice", Label: "a critical hit"},
+		},
+	})
+	s.Equal("🎰 Side Bets on bob's 5", embed.Title)
+	s.Contains(embed.Description, "✅ **carol** called over 3, 🍺 **bob** drinks")
+	s.Contains(embed.Description, "❌ **alice** called a critical hit, 🍺 **alice** drinks")
+}
+
+func (s *BotTestSuite) TestTabPageID() {
+	filter := tabFilter{UnpaidOnly: true, Reason: models.DrinkReasonLowestRoll, AssignedBy: "123456789012345678"}
+	id := tabPageID("5cd42ee2-e49e-4622-9c1e-c417a965d9e3", 2, filter)
+	s.LessOrEqual(len(id), 100)
+
+	gameID, page, parsed, err := parseTabPageID(strings.TrimPrefix(id, ButtonTabPagePrefix))
+	s.Require().NoError(err)
+	s.Equal("5cd42ee2-e49e-4622-9c1e-c417a965d9e3", gameID)
+	s.Equal(2, page)
+	s.Equal(filter, parsed)
+
+	_, _, _, err = parseTabPageID("game:-1:0::")
+	s.Error(err)
+}
+
+func (s *BotTestSuite) TestEditGameMessage_RepostsMissingMessage() {
+	for name, code := range map[string]int{
+		"deleted":  discordgo.ErrCodeUnknownMessage,
+		"archived": discordgo.ErrCodePerformedOperationOnArchivedThread,
+	} {
+		s.Run(name, func() {
+			createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+				ChannelID:   name + "-channel",
+				CreatorID:   "alice",
+				CreatorName: "alice",
+			})
+			s.Require().NoError(err)
+
+			_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+				GameID:    createOutput.GameID,
+				MessageID: "old-message-id",
+			})
+			s.Require().NoError(err)
+
+			s.mockSession.EXPECT().
+				ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+				Return(nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: code}})
+
+			s.mockSession.EXPECT().
+				ChannelMessageSendComplex(name+"-channel", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ string, msg *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+					s.NotEmpty(msg.Embeds)
+					s.NotEmpty(msg.Components)
+					return &discordgo.Message{ID: "new-message-id"}, nil
+				})
+
+			s.Require().NoError(s.bot.editGameMessage(s.mockSession, name+"-channel", &messageUpdate{gameID: createOutput.GameID}))
+
+			gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: createOutput.GameID})
+			s.Require().NoError(err)
+			s.Equal("new-message-id", gameOutput.Game.MessageID)
+		})
+	}
+}
+
+func (s *BotTestSuite) TestEditGameMessage_OtherErrorsAreNotReposted() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		Return(nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeMissingPermissions}})
+
+	s.Error(s.bot.editGameMessage(s.mockSession, s.testChannelID, &messageUpdate{gameID: createOutput.GameID}))
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: createOutput.GameID})
+	s.Require().NoError(err)
+	s.Equal(s.testMessageID, gameOutput.Game.MessageID)
+}
+
+func (s *BotTestSuite) TestResumeGames_RedrawsGameMessages() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.JoinGame(s.ctx, &game.JoinGameInput{GameID: createOutput.GameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.StartGame(s.ctx, &game.StartGameInput{GameID: createOutput.GameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.UpdateGameMessage(s.ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: s.testMessageID,
+	})
+	s.Require().NoError(err)
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal(s.testChannelID, edit.Channel)
+			s.Equal(s.testMessageID, edit.ID)
+			return &discordgo.Message{ID: s.testMessageID}, nil
+		})
+
+	s.bot.resumeGames()
+}
+
+func (s *BotTestSuite) TestLedgerChanged_RefreshesPinnedLeaderboard() {
+	pin := &models.PinnedLeaderboard{ChannelID: s.testChannelID, MessageID: "pinned-message-id"}
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", PinnedLeaderboard: pin},
+		}, nil).
+		AnyTimes()
+	s.bot.guildConfigService = mockGuildConfig
+
+	fakeClock := clock.NewFake(time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC))
+	s.bot.clock = fakeClock
+	s.bot.pins = newMessageUpdateQueue(fakeClock, time.Second, s.bot.editPinnedLeaderboard)
+
+	changed := &events.Event{
+		Type:      events.TypeLedgerChanged,
+		ChannelID: s.testChannelID,
+		GuildID:   "test-guild-id",
+		Payload:   &events.LedgerChangedPayload{Change: "drink_recorded"},
+	}
+
+	// The first change edits the pin right away, the rest are folded into one edit when the window closes
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(edit *discordgo.MessageEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("pinned-message-id", edit.ID)
+			s.Equal(s.testChannelID, edit.Channel)
+			s.Require().Len(edit.Embeds, 1)
+			s.Equal("📌 Session Leaderboard", edit.Embeds[0].Title)
+			return &discordgo.Message{ID: edit.ID}, nil
+		}).
+		Times(2)
+
+	s.bot.handleLedgerChanged(s.ctx, changed)
+	s.bot.handleLedgerChanged(s.ctx, changed)
+	s.bot.handleLedgerChanged(s.ctx, changed)
+	fakeClock.Advance(time.Second)
+	fakeClock.Advance(time.Second)
+}
+
+func (s *BotTestSuite) TestEditPinnedLeaderboard_ForgetsDeletedPin() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{
+				GuildID:           "test-guild-id",
+				PinnedLeaderboard: &models.PinnedLeaderboard{ChannelID: s.testChannelID, MessageID: "pinned-message-id"},
+			},
+		}, nil)
+	mockGuildConfig.EXPECT().
+		SetPinnedLeaderboard(gomock.Any(), &guild_config.SetPinnedLeaderboardInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.SetPinnedLeaderboardOutput{}, nil)
+	s.bot.guildConfigService = mockGuildConfig
+
+	s.mockSession.EXPECT().
+		ChannelMessageEditComplex(gomock.Any(), gomock.Any()).
+		Return(nil, &discordgo.RESTError{Message: &discordgo.APIErrorMessage{Code: discordgo.ErrCodeUnknownMessage}})
+
+	s.NoError(s.bot.editPinnedLeaderboard(s.mockSession, s.testChannelID, &messageUpdate{guildID: "test-guild-id"}))
+}
+
+func (s *BotTestSuite) TestRenderPinnedLeaderboard() {
+	embed := renderPinnedLeaderboard(&game.GetSessionLeaderboardOutput{
+		Session: &models.Session{CreatedAt: time.Date(2025, 4, 19, 20, 0, 0, 0, time.UTC)},
+		Entries: []game.LeaderboardEntry{
+			{PlayerName: "alice", DrinkCount: 1},
+			{PlayerName: "bob", DrinkCount: 3, PaidCount: 2, DesignatedDriver: true},
+		},
+	}, time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC))
+
+	s.Equal("Session started <t:1745092800:f>\n\n🥇 **bob**: 3 drinks (2 paid) 🚗\n🥈 **alice**: 1 drinks (0 paid)\n", embed.Description)
+
+	empty := renderPinnedLeaderboard(&game.GetSessionLeaderboardOutput{}, time.Now())
+	s.Contains(empty.Description, "No drinks yet")
+}
+
+func (s *BotTestSuite) TestRollOffSummaryField() {
+	rolledAt := time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	main := &models.Game{ID: "main", Status: models.GameStatusRollOff, LowestRollOffGameID: "lowest"}
+	lowest := &models.Game{
+		ID:                  "lowest",
+		ParentGameID:        "main",
+		Status:              models.GameStatusRollOff,
+		LowestRollOffGameID: "nested",
+		Participants: []*models.Participant{
+			{PlayerName: "alice", RollValue: 3, RollTime: &rolledAt},
+			{PlayerName: "bob", RollValue: 3, RollTime: &rolledAt},
+		},
+	}
+	nested := &models.Game{
+		ID:           "nested",
+		ParentGameID: "lowest",
+		Status:       models.GameStatusRollOff,
+		Participants: []*models.Participant{
+			{PlayerName: "alice", RollValue: 5, RollTime: &rolledAt},
+			{PlayerName: "bob"},
+		},
+	}
+
+	field := rollOffSummaryField(main, []*models.Game{lowest, nested})
+	s.Require().NotNil(field)
+	s.Equal("**🔻 Lowest roll-off** (2/2 rolled)\n"+
+		"• alice 🎲 **3**\n"+
+		"• bob 🎲 **3**\n"+
+		"  **↳ 🔻 Lowest roll-off, round 2** (1/2 rolled)\n"+
+		"  • alice 🎲 **5**\n"+
+		"  • bob ⏳ waiting to roll", field.Value)
+
+	s.Nil(rollOffSummaryField(main, nil))
+}
+
+func (s *BotTestSuite) TestReadDCA() {
+	var dca bytes.Buffer
+	for _, frame := range [][]byte{{1, 2, 3}, {4, 5}} {
+		s.Require().NoError(binary.Write(&dca, binary.LittleEndian, int16(len(frame))))
+		dca.Write(frame)
+	}
+
+	frames, err := readDCA(&dca)
+	s.Require().NoError(err)
+	s.Equal([][]byte{{1, 2, 3}, {4, 5}}, frames)
+
+	_, err = readDCA(bytes.NewReader([]byte{3, 0, 1}))
+	s.Error(err, "a frame cut short")
+}
+
+func (s *BotTestSuite) TestSoundboardCooldown() {
+	fakeClock := clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	sb, err := loadSoundboard(s.T().TempDir(), fakeClock, 10*time.Second)
+	s.Require().NoError(err)
+	s.Empty(sb.sounds, "effects without a file are left silent")
+
+	s.True(sb.claim("guild-1"))
+	s.False(sb.claim("guild-1"), "a sound is still playing")
+	s.True(sb.claim("guild-2"), "each server has its own turn")
+
+	sb.release("guild-1")
+	s.False(sb.claim("guild-1"), "the cooldown hasn't passed")
+
+	fakeClock.Advance(10 * time.Second)
+	s.True(sb.claim("guild-1"))
+}
+
+func (s *BotTestSuite) TestGameFeed_Persona() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{
+				GuildID: "test-guild-id",
+				Persona: &models.GamePersona{Name: "Ronnie the Bartender", AvatarURL: "https://example.com/ronnie.png"},
+			},
+		}, nil)
+	feed := newGameFeed(mockGuildConfig, clock.New())
+
+	webhook := &discordgo.Webhook{ID: "webhook-id", Token: "webhook-token", Name: gameFeedWebhookName}
+	s.mockSession.EXPECT().ChannelWebhooks(s.testChannelID).Return([]*discordgo.Webhook{{ID: "other", Name: "Announcements"}}, nil)
+	s.mockSession.EXPECT().WebhookCreate(s.testChannelID, gameFeedWebhookName, "").Return(webhook, nil)
+	s.mockSession.EXPECT().
+		WebhookExecute("webhook-id", "webhook-token", true, gomock.Any()).
+		DoAndReturn(func(_, _ string, _ bool, params *discordgo.WebhookParams, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("Ronnie the Bartender", params.Username)
+			s.Equal("https://example.com/ronnie.png", params.AvatarURL)
+			return &discordgo.Message{ID: s.testMessageID}, nil
+		})
+
+	msg, err := feed.send(s.ctx, s.mockSession, "test-guild-id", s.testChannelID, &discordgo.MessageSend{Content: "game"})
+	s.Require().NoError(err)
+	s.Equal(s.testMessageID, msg.ID)
+
+	// Messages posted under the persona are edited through the webhook
+	s.mockSession.EXPECT().
+		WebhookMessageEdit("webhook-id", "webhook-token", s.testMessageID, gomock.Any()).
+		Return(&discordgo.Message{ID: s.testMessageID}, nil)
+
+	_, err = feed.edit(s.mockSession, discordgo.NewMessageEdit(s.testChannelID, s.testMessageID))
+	s.Require().NoError(err)
+}
+
+func (s *BotTestSuite) TestGameFeed_WebhooksNotAllowed() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: "test-guild-id", Persona: &models.GamePersona{Name: "Ronnie the Bartender"}},
+		}, nil).
+		Times(2)
+	feed := newGameFeed(mockGuildConfig, clock.New())
+
+	// The bot can't manage webhooks, so the game is posted as the bot and the channel isn't tried again for a while
+	s.mockSession.EXPECT().ChannelWebhooks(s.testChannelID).Return(nil, errors.New("missing permissions"))
+	s.mockSession.EXPECT().
+		ChannelMessageSendComplex(s.testChannelID, gomock.Any()).
+		Return(&discordgo.Message{ID: s.testMessageID}, nil).
+		Times(2)
+
+	for range 2 {
+		msg, err := feed.send(s.ctx, s.mockSession, "test-guild-id", s.testChannelID, &discordgo.MessageSend{Content: "game"})
+		s.Require().NoError(err)
+		s.Equal(s.testMessageID, msg.ID)
+	}
+}
+
+func (s *BotTestSuite) TestCommandRegistry_Permissions() {
+	commands := commandRegistry(NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil))
+	s.Require().Len(commands, 2)
+
+	ronnied, admin := commands[0].GetCommand(), commands[1].GetCommand()
+	s.Nil(ronnied.DefaultMemberPermissions, "everyone can play")
+	s.Require().NotNil(admin.DefaultMemberPermissions)
+	s.Equal(int64(discordgo.PermissionManageServer), *admin.DefaultMemberPermissions)
+	for _, cmd := range []*discordgo.ApplicationCommand{ronnied, admin} {
+		s.Require().NotNil(cmd.DMPermission)
+		s.False(*cmd.DMPermission, "%s only works in servers", cmd.Name)
+	}
+
+	// Every subcommand is on exactly one of the commands, groups can be split between them. Anyone can start a new
+	// session, but only admins pick the challenges it's played with.
+	seen := make(map[string]string)
+	for _, cmd := range []*discordgo.ApplicationCommand{ronnied, admin} {
+		for _, opt := range cmd.Options {
+			if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup {
+				if opt.Name != "newsession" {
+					s.Empty(seen[opt.Name], "%s is on both commands", opt.Name)
+				}
+				seen[opt.Name] = cmd.Name
+				continue
+			}
+			for _, sub := range opt.Options {
+				name := opt.Name + " " + sub.Name
+				s.Empty(seen[name], "%s is on both commands", name)
+				seen[name] = cmd.Name
+			}
+		}
+	}
+	s.Equal("ronnied-admin", seen["forgive"])
+	s.Equal("ronnied", seen["start"])
+	for _, name := range []string{"rollfeed", "display", "emoji", "persona", "soundboard", "rating", "banter", "crits",
+		"flags", "preset save", "preset remove", "reroll grant", "seasonal on", "seasonal off"} {
+		s.Equal("ronnied-admin", seen[name], "%s is for admins", name)
+	}
+	for _, name := range []string{"preset list", "reroll tokens", "seasonal status"} {
+		s.Equal("ronnied", seen[name], "%s is for everyone", name)
+	}
+	for _, opt := range ronnied.Options {
+		if opt.Name == "newsession" {
+			s.Empty(opt.Options, "only admins pick challenges")
+		}
+	}
+}
+
+func (s *BotTestSuite) TestRonniedAdminCommand_NotAdmin() {
+	cmd := NewRonniedAdminCommand(NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil))
+
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User:        &discordgo.User{ID: "alice", Username: "alice"},
+				Permissions: discordgo.PermissionSendMessages,
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied-admin",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "social", Type: discordgo.ApplicationCommandOptionSubCommand},
+				},
+			},
+		},
+	}
+
+	// A role the server handed the command to without Manage Server is still turned away
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Contains(resp.Data.Content, "Only server admins")
+			return nil
+		})
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+}
+
+func (s *BotTestSuite) TestRonniedAdminMerge_OnlyThisServer() {
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	for _, playerID := range []string{"alice", "alt"} {
+		s.Require().NoError(players.SavePlayer(s.ctx, &playerRepo.SavePlayerInput{
+			Player: &models.Player{ID: playerID, Name: playerID},
+		}))
+	}
+	for _, guildID := range []string{"test-guild-id", "other-guild-id"} {
+		_, err := ledger.CreateDrinkRecord(s.ctx, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:     "game-" + guildID,
+			ToPlayerID: "alt",
+			Reason:     models.DrinkReasonLowestRoll,
+			Timestamp:  time.Now(),
+			GuildID:    guildID,
+		})
+		s.Require().NoError(err)
+	}
+
+	cmd := NewRonniedAdminCommand(NewRonniedCommand(s.gameService, nil, nil, nil, nil, nil))
+	i := &discordgo.InteractionCreate{
+		Interaction: &discordgo.Interaction{
+			Type:      discordgo.InteractionApplicationCommand,
+			ChannelID: s.testChannelID,
+			GuildID:   "test-guild-id",
+			Member: &discordgo.Member{
+				User:        &discordgo.User{ID: "admin", Username: "admin"},
+				Permissions: discordgo.PermissionManageServer,
+			},
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "ronnied-admin",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "merge",
+						Type: discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{Name: "alt", Type: discordgo.ApplicationCommandOptionUser, Value: "alt"},
+							{Name: "into", Type: discordgo.ApplicationCommandOptionUser, Value: "alice"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Contains(resp.Data.Content, "1 drink records from this server moved")
+			return nil
+		})
+
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// The drink from the other server stays with the alt
+	altRecords, err := ledger.GetDrinkRecordsForPlayer(s.ctx, &ledgerRepo.GetDrinkRecordsForPlayerInput{PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Require().Len(altRecords.Records, 1)
+	s.Equal("other-guild-id", altRecords.Records[0].GuildID)
+}
+
+func (s *BotTestSuite) TestStartOutsideGameChannels() {
+	mockGuildConfig := guildConfigMocks.NewMockService(s.mockCtrl)
+	cmd := NewRonniedCommand(s.gameService, nil, nil, nil, mockGuildConfig, nil)
+
+	start := func(channelID string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{
+			Interaction: &discordgo.Interaction{
+				Type:      discordgo.InteractionApplicationCommand,
+				ChannelID: channelID,
+				GuildID:   "test-guild-id",
+				Member: &discordgo.Member{
+					User: &discordgo.User{ID: "alice", Username: "alice"},
+				},
+				Data: discordgo.ApplicationCommandInteractionData{
+					Name: "ronnied",
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "start", Type: discordgo.ApplicationCommandOptionSubCommand},
+					},
+				},
+			},
+		}
+	}
+
+	mockGuildConfig.EXPECT().
+		GetGuildConfig(gomock.Any(), &guild_config.GetGuildConfigInput{GuildID: "test-guild-id"}).
+		Return(&guild_config.GetGuildConfigOutput{
+			Config: &models.GuildConfig{
+				GuildID:         "test-guild-id",
+				GameChannelMode: models.GameChannelsAllow,
+				GameChannelIDs:  []string{"games-channel"},
+			},
+		}, nil).
+		Times(3)
+
+	// Games started elsewhere are pointed to the game channel
+	i := start("general-channel")
+	s.mockSession.EXPECT().Channel("general-channel").Return(&discordgo.Channel{ID: "general-channel", Type: discordgo.ChannelTypeGuildText}, nil)
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal("🍻 This server plays its games in <#games-channel>. Head over there to start one!", resp.Data.Content)
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+
+	// Threads in the game channel can have games too
+	i = start("games-thread")
+	s.mockSession.EXPECT().Channel("games-thread").Return(&discordgo.Channel{ID: "games-thread", ParentID: "games-channel", Type: discordgo.ChannelTypeGuildPublicThread}, nil)
+	s.mockSession.EXPECT().InteractionRespond(i.Interaction, gomock.Any()).Return(nil)
+	s.mockSession.EXPECT().ChannelMessages("games-thread", 5, "", "", "").Return(nil, nil)
+	s.Require().NoError(cmd.Handle(s.mockSession, i))
+}
@@ -0,0 +1,205 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// challengeCommand returns the subcommand for challenging another player to odds and evens
+func challengeCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "challenge",
+		Description: "Challenge a player to quickfire odds and evens, the loser drinks",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "opponent",
+				Description: "The player to challenge",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "call",
+				Description: "What you bet the two dice add up to (odds by default)",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Odds", Value: string(models.ChallengeCallOdds)},
+					{Name: "Evens", Value: string(models.ChallengeCallEvens)},
+				},
+			},
+		},
+	}
+}
+
+// handleChallenge posts a challenge for the opponent to accept or turn down
+func (c *RonniedCommand) handleChallenge(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID, username string) error {
+	ctx := context.Background()
+
+	var opponent *discordgo.User
+	var call models.ChallengeCall
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "opponent":
+			opponent = opt.UserValue(nil)
+		case "call":
+			call = models.ChallengeCall(opt.StringValue())
+		}
+	}
+
+	if opponent == nil {
+		return errors.New("missing opponent option")
+	}
+
+	output, err := c.gameService.CreateChallenge(ctx, &game.CreateChallengeInput{
+		ChannelID:      channelID,
+		GuildID:        i.GuildID,
+		ChallengerID:   userID,
+		ChallengerName: username,
+		OpponentID:     opponent.ID,
+		OpponentName:   resolvedUserName(i, opponent.ID),
+		Call:           call,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrCannotChallengeSelf) {
+			return RespondWithEphemeralMessage(s, i, "You can't challenge yourself, just have a drink.")
+		}
+		log.Printf("Error creating challenge: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't make the challenge: %v", err))
+	}
+	challenge := output.Challenge
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s>", challenge.OpponentID),
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title: "⚔️ Challenge!",
+					Description: fmt.Sprintf("**%s** challenges **%s** to odds and evens and calls **%s**. Both roll at once, the loser drinks. Expires %s.",
+						challenge.ChallengerName, challenge.OpponentName, challenge.Call, discordTimestamp(challenge.ExpiresAt, timestampRelative)),
+					Color: 0xE67E22,
+				},
+			},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							Label:    "Bring it on",
+							Style:    discordgo.SuccessButton,
+							CustomID: ButtonAcceptChallengePrefix + challenge.ID,
+						},
+						discordgo.Button{
+							Label:    "Chicken out",
+							Style:    discordgo.SecondaryButton,
+							CustomID: ButtonDeclineChallengePrefix + challenge.ID,
+						},
+					},
+				},
+			},
+			AllowedMentions: &discordgo.MessageAllowedMentions{
+				Users: []string{challenge.OpponentID},
+			},
+		},
+	})
+}
+
+// handleAcceptChallengeButton plays the challenge once the opponent accepts and shows how it went
+func (b *Bot) handleAcceptChallengeButton(s DiscordSession, i *discordgo.InteractionCreate, userID, challengeID string) error {
+	ctx := context.Background()
+
+	output, err := b.gameService.AcceptChallenge(ctx, &game.AcceptChallengeInput{
+		ChallengeID: challengeID,
+		PlayerID:    userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotChallengeTarget):
+			return RespondWithEphemeralMessage(s, i, "Only the player who was challenged can accept.")
+		case errors.Is(err, game.ErrChallengeNotFound):
+			return updateForgetMessage(s, i, "This challenge has expired.")
+		}
+		log.Printf("Error accepting challenge: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't play the challenge"))
+	}
+
+	// The loser's drink shows on the channel's game message
+	b.refreshGameMessage(ctx, s, i.GuildID, i.ChannelID)
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "",
+			Embeds:     []*discordgo.MessageEmbed{challengeResultEmbed(output.Challenge)},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// handleDeclineChallengeButton turns down the challenge, or calls it off when the challenger presses it
+func (b *Bot) handleDeclineChallengeButton(s DiscordSession, i *discordgo.InteractionCreate, userID, challengeID string) error {
+	ctx := context.Background()
+
+	output, err := b.gameService.DeclineChallenge(ctx, &game.DeclineChallengeInput{
+		ChallengeID: challengeID,
+		PlayerID:    userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotChallengeTarget):
+			return RespondWithEphemeralMessage(s, i, "This challenge isn't yours to turn down.")
+		case errors.Is(err, game.ErrChallengeNotFound):
+			return updateForgetMessage(s, i, "This challenge has expired.")
+		}
+		log.Printf("Error declining challenge: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't turn down the challenge"))
+	}
+
+	if userID == output.Challenge.ChallengerID {
+		return updateForgetMessage(s, i, fmt.Sprintf("<@%s> called off the challenge.", userID))
+	}
+	return updateForgetMessage(s, i, fmt.Sprintf("🐔 <@%s> chickened out of <@%s>'s challenge.", userID, output.Challenge.ChallengerID))
+}
+
+// challengeResultEmbed shows both rolls, what they added up to and who drinks
+func challengeResultEmbed(challenge *models.ChallengeGame) *discordgo.MessageEmbed {
+	parity := "odd"
+	if challenge.Total()%2 == 0 {
+		parity = "even"
+	}
+
+	loserName, winnerName := challenge.ChallengerName, challenge.OpponentName
+	if challenge.ChallengerWon() {
+		loserName, winnerName = challenge.OpponentName, challenge.ChallengerName
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: "⚔️ Challenge Settled",
+		Description: fmt.Sprintf("**%s** called **%s**.\n🎲 **%s** rolled %d, **%s** rolled %d, that's %d (%s).\n\n🏆 **%s** wins, 🍺 **%s** drinks!",
+			challenge.ChallengerName, challenge.Call,
+			challenge.ChallengerName, challenge.ChallengerRoll, challenge.OpponentName, challenge.OpponentRoll,
+			challenge.Total(), parity, winnerName, loserName),
+		Color: 0x2ECC71,
+	}
+}
+
+// resolvedUserName returns the server nickname or username of a user picked in a command option, or a mention
+// when the interaction didn't include them
+func resolvedUserName(i *discordgo.InteractionCreate, userID string) string {
+	resolved := i.ApplicationCommandData().Resolved
+	if resolved == nil {
+		return "<@" + userID + ">"
+	}
+	if member, ok := resolved.Members[userID]; ok && member.Nick != "" {
+		return member.Nick
+	}
+	if user, ok := resolved.Users[userID]; ok {
+		return user.Username
+	}
+	return "<@" + userID + ">"
+}
@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// cleanupCommand returns the admin subcommand for resolving the server's stuck games
+func cleanupCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "cleanup",
+		Description: "Fix or abandon every game left stuck in the server, such as after the bot was down",
+	}
+}
+
+// handleCleanup asks a server admin to confirm cleaning up the server's stuck games
+func (c *RonniedCommand) handleCleanup(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return RespondWithEphemeralEmbedAndButtons(s, i, "Clean up games?",
+		"This finishes games that were stuck waiting on something that will never happen, and abandons lobbies and "+
+			"games nobody has touched in the last few minutes, along with roll-offs whose game is over. Games being "+
+			"played, or paused, are left alone. Drinks already given stay on the leaderboards.", nil,
+		[]discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    "Clean up",
+				Style:    discordgo.DangerButton,
+				CustomID: ButtonCleanupGames,
+			},
+			discordgo.Button{
+				Label:    "Cancel",
+				Style:    discordgo.SecondaryButton,
+				CustomID: ButtonCleanupCancel,
+			},
+		})
+}
+
+// handleCleanupButton cleans up the server's stuck games once a server admin has confirmed, redrawing their messages
+func (b *Bot) handleCleanupButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	ctx := context.Background()
+
+	// The confirmation is only shown to admins, but check again in case their role changed since
+	if i.GuildID == "" || !isGuildAdmin(i) {
+		return updateForgetMessage(s, i, "Only server admins can clean up games.")
+	}
+
+	output, err := b.gameService.CleanupGuildGames(ctx, &game.CleanupGuildGamesInput{
+		GuildID: i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error cleaning up games in guild %s: %v", i.GuildID, err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't clean up the server's games"))
+	}
+
+	// Abandoned games show they're over, and games moved along show where they're up to
+	for _, g := range output.Games {
+		if g.MessageID == "" {
+			continue
+		}
+		b.updateGameMessage(s, g.ChannelID, g.ID)
+	}
+
+	log.Printf("Cleaned up guild %s: %d repaired, %d abandoned, %d left in play", i.GuildID, output.Repaired, output.Abandoned, output.InPlay)
+	return updateForgetMessage(s, i, describeCleanup(output))
+}
+
+// handleCleanupCancelButton leaves the server's games alone
+func (b *Bot) handleCleanupCancelButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return updateForgetMessage(s, i, "Nothing was cleaned up.")
+}
+
+// describeCleanup reports what cleaning up the server's games fixed
+func describeCleanup(output *game.CleanupGuildGamesOutput) string {
+	var report strings.Builder
+	if output.Repaired == 0 && output.Abandoned == 0 {
+		report.WriteString("🧹 Nothing needed cleaning up, no games were stuck.")
+	} else {
+		fmt.Fprintf(&report, "🧹 Cleaned up the server's games: %s finished and %s abandoned.",
+			pluralGames(output.Repaired), pluralGames(output.Abandoned))
+	}
+
+	switch {
+	case output.InPlay == 1:
+		report.WriteString(" 1 game being played was left alone.")
+	case output.InPlay > 1:
+		fmt.Fprintf(&report, " %d games being played were left alone.", output.InPlay)
+	}
+	return report.String()
+}
+
+// pluralGames counts games, such as "1 game" or "3 games"
+func pluralGames(count int) string {
+	if count == 1 {
+		return "1 game"
+	}
+	return fmt.Sprintf("%d games", count)
+}
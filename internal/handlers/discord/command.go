@@ -13,7 +13,7 @@ type CommandHandler interface {
 	GetCommand() *discordgo.ApplicationCommand
 	
 	// Handle processes a Discord interaction
-	Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error
+	Handle(s DiscordSession, i *discordgo.InteractionCreate) error
 }
 
 // BaseCommand provides common functionality for all commands
@@ -21,6 +21,13 @@ type BaseCommand struct {
 	Name        string
 	Description string
 	Options     []*discordgo.ApplicationCommandOption
+
+	// Permissions are what members need to see and use the command, nil lets everyone. Server admins can change
+	// who can use it in their server's Integrations settings.
+	Permissions *int64
+
+	// GuildOnly keeps the command out of direct messages
+	GuildOnly bool
 }
 
 // GetName returns the command name
@@ -30,15 +37,29 @@ func (c *BaseCommand) GetName() string {
 
 // GetCommand returns the application command definition
 func (c *BaseCommand) GetCommand() *discordgo.ApplicationCommand {
-	return &discordgo.ApplicationCommand{
-		Name:        c.Name,
-		Description: c.Description,
-		Options:     c.Options,
+	cmd := &discordgo.ApplicationCommand{
+		Name:                     c.Name,
+		Description:              c.Description,
+		Options:                  c.Options,
+		DefaultMemberPermissions: c.Permissions,
+	}
+	if c.GuildOnly {
+		dmPermission := false
+		cmd.DMPermission = &dmPermission
+	}
+	return cmd
+}
+
+// Allowed reports whether the member using the command has the permissions it needs
+func (c *BaseCommand) Allowed(i *discordgo.InteractionCreate) bool {
+	if c.Permissions == nil {
+		return true
 	}
+	return hasPermissions(i, *c.Permissions)
 }
 
 // RespondWithMessage sends a simple text message response to an interaction
-func RespondWithMessage(s *discordgo.Session, i *discordgo.InteractionCreate, message string) error {
+func RespondWithMessage(s DiscordSession, i *discordgo.InteractionCreate, message string) error {
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
@@ -48,7 +69,7 @@ func RespondWithMessage(s *discordgo.Session, i *discordgo.InteractionCreate, me
 }
 
 // RespondWithEmbed sends an embed response to an interaction
-func RespondWithEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField) error {
+func RespondWithEmbed(s DiscordSession, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField) error {
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
 		Description: description,
@@ -65,7 +86,7 @@ func RespondWithEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, titl
 }
 
 // RespondWithError sends an error response to an interaction
-func RespondWithError(s *discordgo.Session, i *discordgo.InteractionCreate, errorMessage string) error {
+func RespondWithError(s DiscordSession, i *discordgo.InteractionCreate, errorMessage string) error {
 	embed := &discordgo.MessageEmbed{
 		Title:       "Error",
 		Description: errorMessage,
@@ -81,7 +102,7 @@ func RespondWithError(s *discordgo.Session, i *discordgo.InteractionCreate, erro
 }
 
 // RespondWithEmbedAndButtons sends an embed response with buttons to an interaction
-func RespondWithEmbedAndButtons(s *discordgo.Session, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField, buttons []discordgo.MessageComponent) error {
+func RespondWithEmbedAndButtons(s DiscordSession, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField, buttons []discordgo.MessageComponent) error {
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
 		Description: description,
@@ -104,7 +125,7 @@ func RespondWithEmbedAndButtons(s *discordgo.Session, i *discordgo.InteractionCr
 }
 
 // RespondWithEphemeralEmbedAndButtons sends an ephemeral embed response with buttons to an interaction
-func RespondWithEphemeralEmbedAndButtons(s *discordgo.Session, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField, buttons []discordgo.MessageComponent) error {
+func RespondWithEphemeralEmbedAndButtons(s DiscordSession, i *discordgo.InteractionCreate, title, description string, fields []*discordgo.MessageEmbedField, buttons []discordgo.MessageComponent) error {
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
 		Description: description,
@@ -128,7 +149,7 @@ func RespondWithEphemeralEmbedAndButtons(s *discordgo.Session, i *discordgo.Inte
 }
 
 // RespondWithEphemeralMessage sends an ephemeral message response to an interaction
-func RespondWithEphemeralMessage(s *discordgo.Session, i *discordgo.InteractionCreate, message string) error {
+func RespondWithEphemeralMessage(s DiscordSession, i *discordgo.InteractionCreate, message string) error {
 	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
@@ -0,0 +1,123 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// contentRatings describes each content rating for the rating command
+var contentRatings = map[models.ContentRating]string{
+	models.ContentRatingPG:   "PG: no innuendo or swearing",
+	models.ContentRatingPG13: "PG-13: innuendo but no swearing",
+	models.ContentRatingR:    "R: all of Ronnie's humor",
+}
+
+// ratingCommand returns the subcommand for the content rating setting
+func ratingCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "rating",
+		Description: "See or change how tame the bot's messages are",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "level",
+				Description: "The content rating for the server",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "PG", Value: string(models.ContentRatingPG)},
+					{Name: "PG-13", Value: string(models.ContentRatingPG13)},
+					{Name: "R", Value: string(models.ContentRatingR)},
+				},
+			},
+		},
+	}
+}
+
+// handleRating shows the server's content rating, or changes it
+func (c *RonniedCommand) handleRating(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Content ratings are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "The content rating can only be set up in a server.")
+	}
+
+	var rating models.ContentRating
+	for _, opt := range subcommand.Options {
+		if opt.Name == "level" {
+			rating = models.ContentRating(opt.StringValue())
+		}
+	}
+
+	if rating == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the content rating: %v", err))
+		}
+		if output.Config.Rating == "" {
+			return RespondWithEphemeralMessage(s, i, "**Content Rating**\nNot picked, the bot's default is used")
+		}
+		return RespondWithEphemeralMessage(s, i, "**Content Rating**\n"+contentRatings[output.Config.Rating])
+	}
+
+	output, err := c.guildConfigService.SetContentRating(ctx, &guild_config.SetContentRatingInput{
+		GuildID: i.GuildID,
+		Rating:  rating,
+	})
+	if err != nil {
+		log.Printf("Error setting content rating: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the content rating: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Content Rating updated**\n"+contentRatings[output.Config.ContentRating()])
+}
+
+// messagingContext keeps the messages written with the returned context to the guild's content rating, tone and critical hits,
+// and lets roll comments be freshly written if the guild has banter on and the llm_messages flag isn't off
+func (b *Bot) messagingContext(ctx context.Context, guildID string) context.Context {
+	if b.guildConfigService == nil || guildID == "" {
+		return ctx
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for messaging: %v", err)
+		return ctx
+	}
+
+	// Guilds that haven't picked a rating get the bot's default
+	if output.Config.Rating != "" {
+		ctx = messaging.WithContentRating(ctx, output.Config.Rating)
+	}
+	if output.Config.Tone != "" {
+		ctx = messaging.WithTone(ctx, output.Config.Tone)
+	}
+	// Messages name the server's critical hits, or those of its dice's preset
+	criticalHits := output.Config.CriticalHitValues
+	if len(criticalHits) == 0 && output.Config.DiceSides > 0 {
+		if preset := models.BuiltinPreset(fmt.Sprintf("d%d", output.Config.DiceSides)); preset != nil {
+			criticalHits = preset.Rules.CriticalHitValues
+		}
+	}
+	if len(criticalHits) > 0 {
+		ctx = messaging.WithCriticalHitValues(ctx, criticalHits)
+	}
+	if output.Config.Banter && b.featureEnabled(ctx, guildID, models.FeatureFlagLLMMessages) {
+		ctx = messaging.WithGeneration(ctx, guildID)
+	}
+	return ctx
+}
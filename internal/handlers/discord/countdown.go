@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// countdownSeconds is how many seconds are counted down before the rolling window opens, the game service
+// opens it this long after the game starts
+const countdownSeconds = 3
+
+// countdownFrame is what the countdown message shows from a point in time
+type countdownFrame struct {
+	At   time.Time
+	Text string
+}
+
+// countdownFrames returns the countdown message's frames for a rolling window, in order
+func countdownFrames(window *models.RollWindow) []countdownFrame {
+	frames := make([]countdownFrame, 0, countdownSeconds+2)
+	for n := countdownSeconds; n >= 1; n-- {
+		frames = append(frames, countdownFrame{
+			At:   window.OpensAt.Add(-time.Duration(n) * time.Second),
+			Text: fmt.Sprintf("⏱️ Everyone roll together in... **%d**", n),
+		})
+	}
+
+	return append(frames,
+		countdownFrame{
+			At:   window.OpensAt,
+			Text: "🎲 **ROLL NOW!**",
+		},
+		countdownFrame{
+			At:   window.ClosesAt,
+			Text: "⏹️ Time's up! Anyone rolling now drinks for being late.",
+		},
+	)
+}
+
+// runCountdown posts the countdown in the channel and edits it in step with the rolling window, guilds with
+// animations turned off only see the window open and close
+func (b *Bot) runCountdown(guildID, channelID string, window *models.RollWindow) {
+	frames := countdownFrames(window)
+	if !b.featureEnabled(context.Background(), guildID, models.FeatureFlagAnimations) {
+		frames = frames[countdownSeconds:]
+	}
+
+	b.clock.Sleep(frames[0].At.Sub(b.clock.Now()))
+	message, err := b.api.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: frames[0].Text,
+	})
+	if err != nil {
+		log.Printf("Error posting countdown in channel %s: %v", channelID, err)
+		return
+	}
+
+	for _, frame := range frames[1:] {
+		b.clock.Sleep(frame.At.Sub(b.clock.Now()))
+		_, err = b.api.ChannelMessageEditComplex(discordgo.NewMessageEdit(channelID, message.ID).SetContent(frame.Text))
+		if err != nil {
+			log.Printf("Error updating countdown in channel %s: %v", channelID, err)
+			return
+		}
+	}
+}
+
+// rollTimingEmbed explains the penalty drink for a countdown game's roll outside the rolling window, nil when the
+// roll was on time
+func rollTimingEmbed(timing game.RollTiming) *discordgo.MessageEmbed {
+	var description string
+	switch timing {
+	case game.RollTimingEarly:
+		description = "You jumped the gun! Rolling before the countdown ends costs a drink."
+	case game.RollTimingLate:
+		description = "Too slow! Rolling after the window closes costs a drink."
+	default:
+		return nil
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "⏱️ Out of Sync",
+		Description: description,
+		Color:       0xE74C3C,
+	}
+}
@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// critsCommand returns the subcommand for the server's critical hit and fail values
+func critsCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "crits",
+		Description: "See or change which rolls are critical hits and fails",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "hits",
+				Description: "Rolls that assign a drink, e.g. 5,6",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "fails",
+				Description: "Rolls that make you drink, e.g. 1,2",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "reset",
+				Description: "Go back to the bot's defaults",
+			},
+		},
+	}
+}
+
+// handleCrits shows the server's critical values, or changes them
+func (c *RonniedCommand) handleCrits(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Server critical values are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Critical values can only be set up in a server.")
+	}
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subcommand.Options {
+		options[opt.Name] = opt
+	}
+
+	configOutput, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get the critical values: %v", err))
+	}
+
+	if len(options) == 0 {
+		return RespondWithEphemeralMessage(s, i, "**Critical values**\n"+c.describeCrits(ctx, configOutput.Config))
+	}
+
+	// Values that aren't given keep their current setting
+	hitValues, failValues := configOutput.Config.CriticalHitValues, configOutput.Config.CriticalFailValues
+	if reset, ok := options["reset"]; ok && reset.BoolValue() {
+		hitValues, failValues = nil, nil
+	}
+	if options["hits"] != nil {
+		if hitValues, err = rollValuesOption(options, "hits"); err != nil {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't read hits: %v", err))
+		}
+	}
+	if options["fails"] != nil {
+		if failValues, err = rollValuesOption(options, "fails"); err != nil {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't read fails: %v", err))
+		}
+	}
+
+	output, err := c.guildConfigService.SetCriticalValues(ctx, &guild_config.SetCriticalValuesInput{
+		GuildID:    i.GuildID,
+		HitValues:  hitValues,
+		FailValues: failValues,
+	})
+	if err != nil {
+		if errors.Is(err, guild_config.ErrInvalidCritValues) {
+			return RespondWithEphemeralMessage(s, i, "Critical values must be die faces, and a roll can't be both a hit and a fail.")
+		}
+		log.Printf("Error setting critical values: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the critical values: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Critical values updated**\n"+c.describeCrits(ctx, output.Config))
+}
+
+// describeCrits lists the rolls that are critical hits and fails in a server's games
+func (c *RonniedCommand) describeCrits(ctx context.Context, config *models.GuildConfig) string {
+	rules := c.guildRules(ctx, config)
+	return fmt.Sprintf("🔥 Critical hits: **%s**\n💀 Critical fails: **%s**",
+		models.FormatRollValues(rules.CriticalHitValues), models.FormatRollValues(rules.CriticalFailValues))
+}
+
+// guildRules returns the bot's default rules with a server's critical values applied
+func (c *RonniedCommand) guildRules(ctx context.Context, config *models.GuildConfig) models.GameRules {
+	var rules models.GameRules
+	output, err := c.gameService.GetGameRules(ctx, &game.GetGameRulesInput{})
+	if err != nil {
+		log.Printf("Error getting the default game rules: %v", err)
+	} else {
+		rules = output.Rules
+	}
+
+	if len(config.CriticalHitValues) > 0 {
+		rules.CriticalHitValues = config.CriticalHitValues
+	}
+	if len(config.CriticalFailValues) > 0 {
+		rules.CriticalFailValues = config.CriticalFailValues
+	}
+	return rules
+}
+
+// withGuildRules applies a server's dice, critical values, tie strategy and Ronnie drink rule to the rules a game is started with,
+// unless the rules already set their own
+func (c *RonniedCommand) withGuildRules(ctx context.Context, guildID string, rules *models.GameRules) *models.GameRules {
+	if c.guildConfigService == nil || guildID == "" {
+		return rules
+	}
+
+	output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for game rules: %v", err)
+		return rules
+	}
+
+	return output.Config.ApplyGameRules(rules)
+}
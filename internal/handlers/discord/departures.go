@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleGuildMemberRemove takes a member who left the server out of the game they were playing and redraws it
+func (b *Bot) handleGuildMemberRemove(m *discordgo.GuildMemberRemove) {
+	if m.Member == nil || m.User == nil {
+		return
+	}
+
+	output, err := b.gameService.RemoveDepartedPlayer(context.Background(), &game.RemoveDepartedPlayerInput{
+		GuildID:  m.GuildID,
+		PlayerID: m.User.ID,
+	})
+	if err != nil {
+		log.Printf("Error removing %s, who left guild %s: %v", m.User.ID, m.GuildID, err)
+		return
+	}
+
+	if output.GameID != "" {
+		b.updateGameMessage(b.api, output.ChannelID, output.RootGameID)
+	}
+}
+
+// departuresField notes the players who left the server during the game, nil when nobody did. They're named
+// rather than mentioned, as a mention of someone who left shows up broken.
+func departuresField(g *models.Game) *discordgo.MessageEmbedField {
+	if len(g.Departures) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(g.Departures))
+	for i, departure := range g.Departures {
+		names[i] = departure.PlayerName
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "🚪 Left the Server",
+		Value: fmt.Sprintf("%s left mid-game, so the game carried on without them.", strings.Join(names, ", ")),
+	}
+}
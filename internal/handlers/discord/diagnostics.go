@@ -0,0 +1,360 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Application flags Discord sets when the privileged Server Members intent is turned on for a bot, the limited
+// one for bots in fewer than 100 servers that haven't been verified
+const (
+	applicationFlagGatewayGuildMembers        = 1 << 14
+	applicationFlagGatewayGuildMembersLimited = 1 << 15
+)
+
+// Diagnostic is the outcome of one of the checks of the bot's setup
+type Diagnostic struct {
+	// Name is what was checked
+	Name string
+
+	// Detail is what was found
+	Detail string
+
+	// Problem says what's wrong and how to fix it, empty when the check passed
+	Problem string
+}
+
+// Failed checks whether the check found a problem
+func (d Diagnostic) Failed() bool {
+	return d.Problem != ""
+}
+
+// diagnostics checks the bot's setup on Discord's side
+type diagnostics struct {
+	api DiscordSession
+
+	// applicationID is the application the bot's commands are registered for, empty for the token's own
+	applicationID string
+
+	// guildID is the server the commands are registered in, empty when they're registered globally
+	guildID string
+
+	// membersIntent is whether the bot's config needs the privileged Server Members intent
+	membersIntent bool
+
+	// leaderboardImages is whether the bot posts leaderboards as images, which needs it to attach files
+	leaderboardImages bool
+
+	// commands are the commands the bot handles
+	commands []CommandHandler
+
+	// running is whether the bot has registered its commands, which it removes again when it stops
+	running bool
+}
+
+// newDiagnostics creates the checks of the bot's setup from its config
+func newDiagnostics(api DiscordSession, cfg *Config, commands []CommandHandler, running bool) *diagnostics {
+	return &diagnostics{
+		api:               api,
+		applicationID:     cfg.ApplicationID,
+		guildID:           cfg.GuildID,
+		membersIntent:     cfg.MemberLeaves || cfg.NicknameSync,
+		leaderboardImages: cfg.LeaderboardImages,
+		commands:          commands,
+		running:           running,
+	}
+}
+
+// CheckSetup checks the Discord side of the bot's config without connecting to Discord's gateway: that the token
+// is accepted, that it belongs to the application with the intents the config needs, and the commands Discord has
+// registered for it
+func CheckSetup(cfg *Config) []Diagnostic {
+	if cfg == nil || cfg.Token == "" {
+		return []Diagnostic{{
+			Name:    "Discord token",
+			Problem: "DISCORD_TOKEN is required, copy it from the Bot page of your application in the Discord developer portal",
+		}}
+	}
+
+	session, err := discordgo.New("Bot " + cfg.Token)
+	if err != nil {
+		return []Diagnostic{{
+			Name:    "Discord token",
+			Problem: fmt.Sprintf("Couldn't create a Discord session: %v", err),
+		}}
+	}
+
+	commands := commandRegistry(NewRonniedCommand(nil, nil, nil, nil, nil, nil))
+	return newDiagnostics(session, cfg, commands, false).run("")
+}
+
+// run checks the bot's login, application and commands, and its permissions in the channel when there is one
+func (d *diagnostics) run(channelID string) []Diagnostic {
+	login, botUser := d.checkLogin()
+	if botUser == nil {
+		// Nothing else can be checked without a working token
+		return []Diagnostic{login}
+	}
+
+	appID := d.applicationID
+	if appID == "" {
+		appID = botUser.ID
+	}
+
+	results := []Diagnostic{login, d.checkApplication(appID), d.checkCommands(appID)}
+	if channelID != "" {
+		results = append(results, d.checkChannel(botUser.ID, channelID))
+	}
+	return results
+}
+
+// checkLogin checks that Discord accepts the bot's token, returning the bot's user when it does
+func (d *diagnostics) checkLogin() (Diagnostic, *discordgo.User) {
+	result := Diagnostic{Name: "Discord token"}
+
+	botUser, err := d.api.User("@me")
+	if err != nil {
+		if restStatus(err) == http.StatusUnauthorized {
+			result.Problem = "Discord rejected DISCORD_TOKEN, reset the token on the Bot page of your application in the Discord developer portal and copy the new one"
+		} else {
+			result.Problem = fmt.Sprintf("Couldn't reach Discord to check the token: %v", err)
+		}
+		return result, nil
+	}
+
+	result.Detail = fmt.Sprintf("Logged in as %s", botUser.Username)
+	return result, botUser
+}
+
+// checkApplication checks that the token belongs to the application and that the intents the config needs are on
+func (d *diagnostics) checkApplication(appID string) Diagnostic {
+	result := Diagnostic{Name: "Application"}
+
+	application, err := d.api.Application("@me")
+	if err != nil {
+		result.Problem = fmt.Sprintf("Couldn't get the bot's application: %v", err)
+		return result
+	}
+
+	if application.ID != appID {
+		result.Problem = fmt.Sprintf("APPLICATION_ID is %s but the token belongs to application %s (%s), set APPLICATION_ID to %s or leave it empty",
+			appID, application.ID, application.Name, application.ID)
+		return result
+	}
+
+	if d.membersIntent && application.Flags&(applicationFlagGatewayGuildMembers|applicationFlagGatewayGuildMembersLimited) == 0 {
+		result.Problem = "MEMBER_LEAVES or NICKNAME_SYNC needs the Server Members Intent, turn it on under Privileged Gateway Intents on the Bot page of your application, or Discord will refuse the connection"
+		return result
+	}
+
+	result.Detail = application.Name
+	return result
+}
+
+// checkCommands compares the commands Discord has registered for the application with the ones the bot handles
+func (d *diagnostics) checkCommands(appID string) Diagnostic {
+	result := Diagnostic{Name: "Commands"}
+
+	where := "globally"
+	if d.guildID != "" {
+		where = "in GUILD_ID " + d.guildID
+	}
+
+	registered, err := d.api.ApplicationCommands(appID, d.guildID)
+	if err != nil {
+		if d.guildID != "" && restStatus(err) == http.StatusForbidden {
+			result.Problem = fmt.Sprintf("The bot can't register commands in GUILD_ID %s, invite it to that server with the bot and applications.commands scopes", d.guildID)
+		} else {
+			result.Problem = fmt.Sprintf("Couldn't get the commands registered %s: %v", where, err)
+		}
+		return result
+	}
+
+	handled := make(map[string]bool)
+	for _, cmd := range d.commands {
+		handled[cmd.GetName()] = true
+	}
+	found := make(map[string]bool)
+	var stale []string
+	for _, cmd := range registered {
+		found[cmd.Name] = true
+		if !handled[cmd.Name] {
+			stale = append(stale, "/"+cmd.Name)
+		}
+	}
+	var missing []string
+	for _, cmd := range d.commands {
+		if !found[cmd.GetName()] {
+			missing = append(missing, "/"+cmd.GetName())
+		}
+	}
+	sort.Strings(stale)
+
+	var problems []string
+	if len(stale) > 0 {
+		problems = append(problems, fmt.Sprintf("%s are still registered %s by an older version of the bot and do nothing, delete them through Discord's API",
+			strings.Join(stale, ", "), where))
+	}
+	if d.running && len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("%s aren't registered %s, restart the bot to register them again", strings.Join(missing, ", "), where))
+	}
+
+	// Commands registered both ways show up twice in the server
+	if d.guildID != "" {
+		global, err := d.api.ApplicationCommands(appID, "")
+		if err == nil {
+			var doubled []string
+			for _, cmd := range global {
+				if found[cmd.Name] {
+					doubled = append(doubled, "/"+cmd.Name)
+				}
+			}
+			if len(doubled) > 0 {
+				problems = append(problems, fmt.Sprintf("%s are registered globally and in GUILD_ID %s so they show up twice there, delete the global ones or unset GUILD_ID",
+					strings.Join(doubled, ", "), d.guildID))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		result.Problem = strings.Join(problems, ". ")
+		return result
+	}
+
+	switch {
+	case len(missing) == 0:
+		result.Detail = fmt.Sprintf("All %d commands are registered %s", len(d.commands), where)
+	case len(missing) == len(d.commands):
+		result.Detail = fmt.Sprintf("No commands are registered %s yet, the bot registers them when it starts", where)
+	default:
+		result.Detail = fmt.Sprintf("%s will be registered %s when the bot starts", strings.Join(missing, ", "), where)
+	}
+	return result
+}
+
+// channelPermission is a permission the bot needs in a game channel
+type channelPermission struct {
+	permission int64
+	name       string
+}
+
+// checkChannel checks that the bot has the permissions it needs to run games in the channel
+func (d *diagnostics) checkChannel(botUserID, channelID string) Diagnostic {
+	result := Diagnostic{Name: "Channel permissions"}
+
+	permissions, err := d.api.UserChannelPermissions(botUserID, channelID)
+	if err != nil {
+		result.Problem = fmt.Sprintf("Couldn't get the bot's permissions in this channel: %v", err)
+		return result
+	}
+
+	needed := []channelPermission{
+		{discordgo.PermissionViewChannel, "View Channel"},
+		{discordgo.PermissionSendMessages, "Send Messages"},
+		{discordgo.PermissionEmbedLinks, "Embed Links"},
+		{discordgo.PermissionReadMessageHistory, "Read Message History"},
+	}
+	if d.leaderboardImages {
+		needed = append(needed, channelPermission{discordgo.PermissionAttachFiles, "Attach Files"})
+	}
+
+	var missing []string
+	for _, p := range needed {
+		if permissions&p.permission == 0 {
+			missing = append(missing, p.name)
+		}
+	}
+	if len(missing) > 0 {
+		result.Problem = fmt.Sprintf("The bot is missing %s in this channel, give its role them in the channel's settings", strings.Join(missing, ", "))
+		return result
+	}
+
+	result.Detail = "The bot can post and update games here"
+	if permissions&discordgo.PermissionManageWebhooks == 0 {
+		result.Detail += ", but without Manage Webhooks games are posted as the bot rather than the server's persona"
+	}
+	return result
+}
+
+// restStatus returns the HTTP status of a failed Discord API call, 0 when the call didn't get a response
+func restStatus(err error) int {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Response == nil {
+		return 0
+	}
+	return restErr.Response.StatusCode
+}
+
+// diagCommand returns the admin subcommand for checking the bot's setup in the server
+func diagCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "diag",
+		Description: "Check the bot's connection, commands and permissions in this channel",
+	}
+}
+
+// handleDiag checks the bot's storage, Discord setup and permissions in the channel and shows what it found
+func (c *RonniedCommand) handleDiag(s DiscordSession, i *discordgo.InteractionCreate) error {
+	if c.diagnostics == nil {
+		return RespondWithEphemeralMessage(s, i, "Diagnostics aren't available on this bot.")
+	}
+
+	results := []Diagnostic{c.checkStorage(i.GuildID)}
+	results = append(results, c.diagnostics.run(i.ChannelID)...)
+
+	var lines []string
+	failed := 0
+	for _, result := range results {
+		if result.Failed() {
+			failed++
+			lines = append(lines, fmt.Sprintf("❌ **%s**: %s", result.Name, result.Problem))
+		} else {
+			lines = append(lines, fmt.Sprintf("✅ **%s**: %s", result.Name, result.Detail))
+		}
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Everything checks out",
+		Description: strings.Join(lines, "\n"),
+		Color:       0x00ff00,
+	}
+	if failed > 0 {
+		embed.Title = fmt.Sprintf("%d of %d checks found a problem", failed, len(results))
+		embed.Color = 0xff0000
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// checkStorage checks that the server's games and session can be read
+func (c *RonniedCommand) checkStorage(guildID string) Diagnostic {
+	result := Diagnostic{Name: "Storage"}
+
+	output, err := c.gameService.GetGuildOverview(context.Background(), &game.GetGuildOverviewInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		result.Problem = fmt.Sprintf("Couldn't read the server's games, check that Redis is up and REDIS_ADDR points at it: %v", err)
+		return result
+	}
+
+	result.Detail = "The server's games and session can be read"
+	if output.Session == nil {
+		result.Detail = "The server's games can be read, it hasn't started a session yet"
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package discord
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/handlers/discord/mocks"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// DiagnosticsTestSuite tests the checks of the bot's Discord setup against a mocked Discord session
+type DiagnosticsTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockSession *mocks.MockDiscordSession
+	diagnostics *diagnostics
+}
+
+func (s *DiagnosticsTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockSession = mocks.NewMockDiscordSession(s.mockCtrl)
+	s.diagnostics = newDiagnostics(s.mockSession, &Config{GuildID: "guild"},
+		commandRegistry(NewRonniedCommand(nil, nil, nil, nil, nil, nil)), true)
+	s.mockSession.EXPECT().User("@me").Return(&discordgo.User{ID: "app", Username: "Ronnied"}, nil).AnyTimes()
+}
+
+func (s *DiagnosticsTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestDiagnosticsTestSuite(t *testing.T) {
+	suite.Run(t, new(DiagnosticsTestSuite))
+}
+
+// registered returns the application commands for the command names
+func registered(names ...string) []*discordgo.ApplicationCommand {
+	var commands []*discordgo.ApplicationCommand
+	for _, name := range names {
+		commands = append(commands, &discordgo.ApplicationCommand{Name: name})
+	}
+	return commands
+}
+
+// handledNames returns the names of the commands the bot handles
+func (s *DiagnosticsTestSuite) handledNames() []string {
+	var names []string
+	for _, cmd := range s.diagnostics.commands {
+		names = append(names, cmd.GetName())
+	}
+	return names
+}
+
+func (s *DiagnosticsTestSuite) TestBadToken() {
+	mockSession := mocks.NewMockDiscordSession(s.mockCtrl)
+	mockSession.EXPECT().User("@me").Return(nil, &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusUnauthorized},
+	})
+	s.diagnostics.api = mockSession
+
+	results := s.diagnostics.run("channel")
+	s.Require().Len(results, 1)
+	s.True(results[0].Failed())
+	s.Contains(results[0].Problem, "rejected DISCORD_TOKEN")
+}
+
+func (s *DiagnosticsTestSuite) TestEverythingChecksOut() {
+	s.mockSession.EXPECT().Application("@me").Return(&discordgo.Application{ID: "app", Name: "Ronnied"}, nil)
+	s.mockSession.EXPECT().ApplicationCommands("app", "guild").Return(registered(s.handledNames()...), nil)
+	s.mockSession.EXPECT().ApplicationCommands("app", "").Return(nil, nil)
+	s.mockSession.EXPECT().UserChannelPermissions("app", "channel").Return(int64(
+		discordgo.PermissionViewChannel|discordgo.PermissionSendMessages|discordgo.PermissionEmbedLinks|
+			discordgo.PermissionReadMessageHistory|discordgo.PermissionManageWebhooks), nil)
+
+	results := s.diagnostics.run("channel")
+	s.Require().Len(results, 4)
+	for _, result := range results {
+		s.False(result.Failed(), "%s: %s", result.Name, result.Problem)
+	}
+}
+
+func (s *DiagnosticsTestSuite) TestApplicationMismatch() {
+	s.diagnostics.applicationID = "other"
+	s.mockSession.EXPECT().Application("@me").Return(&discordgo.Application{ID: "app", Name: "Ronnied"}, nil)
+
+	result := s.diagnostics.checkApplication("other")
+	s.True(result.Failed())
+	s.Contains(result.Problem, "set APPLICATION_ID to app")
+}
+
+func (s *DiagnosticsTestSuite) TestMembersIntentMissing() {
+	s.diagnostics.membersIntent = true
+	s.mockSession.EXPECT().Application("@me").Return(&discordgo.Application{ID: "app", Name: "Ronnied"}, nil)
+
+	result := s.diagnostics.checkApplication("app")
+	s.True(result.Failed())
+	s.Contains(result.Problem, "Server Members Intent")
+
+	s.mockSession.EXPECT().Application("@me").Return(&discordgo.Application{
+		ID:    "app",
+		Name:  "Ronnied",
+		Flags: applicationFlagGatewayGuildMembersLimited,
+	}, nil)
+	s.False(s.diagnostics.checkApplication("app").Failed())
+}
+
+func (s *DiagnosticsTestSuite) TestStaleMissingAndDoubledCommands() {
+	names := s.handledNames()
+	s.mockSession.EXPECT().ApplicationCommands("app", "guild").Return(registered(append(names[1:], "oldcommand")...), nil)
+	s.mockSession.EXPECT().ApplicationCommands("app", "").Return(registered(names[1]), nil)
+
+	result := s.diagnostics.checkCommands("app")
+	s.True(result.Failed())
+	s.Contains(result.Problem, "/oldcommand are still registered")
+	s.Contains(result.Problem, "/"+names[0]+" aren't registered")
+	s.Contains(result.Problem, "/"+names[1]+" are registered globally and in GUILD_ID guild")
+}
+
+func (s *DiagnosticsTestSuite) TestCommandsNotRegisteredBeforeStarting() {
+	s.diagnostics.running = false
+	s.mockSession.EXPECT().ApplicationCommands("app", "guild").Return(nil, nil)
+	s.mockSession.EXPECT().ApplicationCommands("app", "").Return(nil, nil)
+
+	result := s.diagnostics.checkCommands("app")
+	s.False(result.Failed())
+	s.Contains(result.Detail, "the bot registers them when it starts")
+}
+
+func (s *DiagnosticsTestSuite) TestGuildMissingScope() {
+	s.mockSession.EXPECT().ApplicationCommands("app", "guild").Return(nil, &discordgo.RESTError{
+		Response: &http.Response{StatusCode: http.StatusForbidden},
+	})
+
+	result := s.diagnostics.checkCommands("app")
+	s.True(result.Failed())
+	s.Contains(result.Problem, "applications.commands")
+}
+
+func (s *DiagnosticsTestSuite) TestMissingChannelPermissions() {
+	s.diagnostics.leaderboardImages = true
+	s.mockSession.EXPECT().UserChannelPermissions("app", "channel").Return(int64(
+		discordgo.PermissionViewChannel|discordgo.PermissionSendMessages|discordgo.PermissionReadMessageHistory), nil)
+
+	result := s.diagnostics.checkChannel("app", "channel")
+	s.True(result.Failed())
+	s.Contains(result.Problem, "Embed Links, Attach Files")
+}
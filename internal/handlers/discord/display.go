@@ -0,0 +1,217 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// compactParticipantThreshold is how many players switch a game message to the compact display,
+// whatever the server's setting, so big groups don't overflow the embed
+const compactParticipantThreshold = 20
+
+// maxCompactDrinks is how many recent drinks the compact display lists
+const maxCompactDrinks = 5
+
+// displayDensities describes each display density for the display command
+var displayDensities = map[models.DisplayDensity]string{
+	models.DisplayDetailed: "Detailed: roll comments, drink messages and progress bars",
+	models.DisplayCompact:  "Compact: one short line per player, for big groups",
+}
+
+// compactDrinkReasons labels each drink reason in the compact display
+var compactDrinkReasons = map[models.DrinkReason]string{
+	models.DrinkReasonCriticalHit:  "🔥",
+	models.DrinkReasonCriticalFail: "💀",
+	models.DrinkReasonLowestRoll:   "👇",
+	models.DrinkReasonDelayedStart: "⏰",
+	models.DrinkReasonChallenge:    "⚔️",
+	models.DrinkReasonOutOfSync:    "⏱️",
+	models.DrinkReasonSideBet:      "🎰",
+	models.DrinkReasonSocialRound:  "🍻",
+	models.DrinkReasonKarma:        "😈",
+	models.DrinkReasonRoundsLoser:  "🔁",
+	models.DrinkReasonSuddenDeath:  "☠️",
+	models.DrinkReasonJackpot:      "💰",
+}
+
+// displayCommand returns the subcommand for the display density setting
+func displayCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "display",
+		Description: "See or change how much detail game messages show",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "density",
+				Description: "How much detail to show",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Detailed", Value: string(models.DisplayDetailed)},
+					{Name: "Compact", Value: string(models.DisplayCompact)},
+				},
+			},
+		},
+	}
+}
+
+// handleDisplay shows the server's display density, or changes it
+func (c *RonniedCommand) handleDisplay(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Display settings are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "The display can only be set up in a server.")
+	}
+
+	var density models.DisplayDensity
+	for _, opt := range subcommand.Options {
+		if opt.Name == "density" {
+			density = models.DisplayDensity(opt.StringValue())
+		}
+	}
+
+	if density == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the display setting: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "**Display**\n"+displayDensities[output.Config.DisplayDensity()])
+	}
+
+	output, err := c.guildConfigService.SetDisplayDensity(ctx, &guild_config.SetDisplayDensityInput{
+		GuildID: i.GuildID,
+		Density: density,
+	})
+	if err != nil {
+		log.Printf("Error setting display density: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the display: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Display updated**\n"+displayDensities[output.Config.DisplayDensity()])
+}
+
+// displayDensity returns how much detail a guild's game messages show, detailed when it can't be looked up
+func (b *Bot) displayDensity(ctx context.Context, guildID string) models.DisplayDensity {
+	if b.guildConfigService == nil || guildID == "" {
+		return models.DisplayDetailed
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for display density: %v", err)
+		return models.DisplayDetailed
+	}
+
+	return output.Config.DisplayDensity()
+}
+
+// compactGameFields renders the participants, recent drinks and leaderboard with one short line each
+func compactGameFields(g *models.Game, rules models.GameRules, drinkRecords []*models.DrinkLedger, leaderboardEntries []game.LeaderboardEntry, sessionLeaderboardEntries []game.LeaderboardEntry) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+
+	names := make(map[string]string, len(g.Participants))
+	var participants strings.Builder
+	for _, p := range g.Participants {
+		names[p.PlayerID] = p.PlayerName
+
+		switch {
+		case p.RollValue == 0:
+			fmt.Fprintf(&participants, "• %s ⏳\n", p.PlayerName)
+		case rules.IsCriticalHit(p.RollValue):
+			fmt.Fprintf(&participants, "• **%s** 🔥 %d\n", p.PlayerName, p.RollValue)
+		case rules.IsCriticalFail(p.RollValue):
+			fmt.Fprintf(&participants, "• **%s** 💀 %d\n", p.PlayerName, p.RollValue)
+		default:
+			fmt.Fprintf(&participants, "• **%s** %d\n", p.PlayerName, p.RollValue)
+		}
+	}
+
+	if participants.Len() > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("👥 Participants (%d)", len(g.Participants)),
+			Value: participants.String(),
+		})
+	}
+
+	if len(drinkRecords) > 0 {
+		sort.Slice(drinkRecords, func(i, j int) bool {
+			return drinkRecords[i].Timestamp.After(drinkRecords[j].Timestamp)
+		})
+
+		var drinks strings.Builder
+		recentDrinks := collapseSocialRounds(drinkRecords)
+		for _, record := range recentDrinks[:min(len(recentDrinks), maxCompactDrinks)] {
+			if record.Reason == models.DrinkReasonSocialRound {
+				fmt.Fprintf(&drinks, "%s **Social!** everyone drinks\n", compactDrinkReasons[record.Reason])
+				continue
+			}
+
+			toName, ok := names[record.ToPlayerID]
+			if !ok && record.IsGuest() {
+				toName, ok = record.GuestName, true
+			}
+			if !ok {
+				continue
+			}
+
+			fmt.Fprintf(&drinks, "%s **%s**", compactDrinkReasons[record.Reason], toName)
+			if fromName, ok := names[record.FromPlayerID]; ok && record.FromPlayerID != record.ToPlayerID {
+				fmt.Fprintf(&drinks, " from %s", fromName)
+			}
+			drinks.WriteString("\n")
+		}
+
+		if drinks.Len() > 0 {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  fmt.Sprintf("🍻 Recent Drinks (%d)", len(drinkRecords)),
+				Value: drinks.String(),
+			})
+		}
+	}
+
+	// The session leaderboard takes the place of the game's when there is one
+	title := "🏆 Session Leaderboard"
+	entries := sessionLeaderboardEntries
+	if len(entries) == 0 {
+		title = "🏆 Game Leaderboard"
+		entries = leaderboardEntries
+	}
+
+	if len(entries) > 0 {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].PaidCount > entries[j].PaidCount
+		})
+
+		var leaderboard strings.Builder
+		var totalDrinks, totalPaid int
+		for i, entry := range entries {
+			totalDrinks += entry.DrinkCount
+			totalPaid += entry.PaidCount
+			fmt.Fprintf(&leaderboard, "%d. **%s** %d/%d paid\n", i+1, entry.PlayerName, entry.PaidCount, entry.DrinkCount)
+		}
+		fmt.Fprintf(&leaderboard, "**Total**: %d/%d paid", totalPaid, totalDrinks)
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  title,
+			Value: leaderboard.String(),
+		})
+	}
+
+	return fields
+}
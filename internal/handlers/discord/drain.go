@@ -0,0 +1,98 @@
+package discord
+
+import (
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultDrainTimeout is how long shutdown waits for the interactions being handled by default
+const defaultDrainTimeout = 10 * time.Second
+
+// drainingMessage is shown to users whose interaction comes in while the bot is shutting down
+const drainingMessage = "🔄 Ronnie's restarting. Try that again in a moment."
+
+// interactionsTurnedAway counts the interactions turned away while the bot was shutting down
+var interactionsTurnedAway = expvar.NewInt("discord_interactions_turned_away")
+
+// interactionTracker counts the interactions being handled, so shutdown can stop taking new ones and wait for the
+// rest to finish
+type interactionTracker struct {
+	mu       sync.Mutex
+	inFlight int
+	draining bool
+
+	// idle is closed once the last interaction being handled while draining finishes
+	idle chan struct{}
+}
+
+// newInteractionTracker creates a tracker with no interactions being handled
+func newInteractionTracker() *interactionTracker {
+	return &interactionTracker{}
+}
+
+// begin counts an interaction starting, returning false once draining has begun and no new ones are taken
+func (t *interactionTracker) begin() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.draining {
+		return false
+	}
+	t.inFlight++
+	return true
+}
+
+// end counts an interaction finishing
+func (t *interactionTracker) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+	if t.draining && t.inFlight == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// drain stops new interactions being taken and waits up to the timeout for the ones being handled to finish,
+// returning how many were still running when it gave up
+func (t *interactionTracker) drain(clk clock.Clock, timeout time.Duration) int {
+	t.mu.Lock()
+	t.draining = true
+	if t.inFlight == 0 {
+		t.mu.Unlock()
+		return 0
+	}
+	idle := make(chan struct{})
+	t.idle = idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-clk.After(timeout):
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.inFlight
+}
+
+// turnAway tells the user the bot is shutting down instead of handling their interaction
+func (b *Bot) turnAway(s DiscordSession, i *discordgo.InteractionCreate) {
+	interactionsTurnedAway.Add(1)
+
+	// Autocomplete requests can't be answered with a message
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+
+	if err := RespondWithEphemeralMessage(s, i, drainingMessage); err != nil {
+		log.Printf("Error turning away interaction %s while shutting down: %v", i.ID, err)
+	}
+}
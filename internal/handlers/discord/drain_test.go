@@ -0,0 +1,72 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InteractionTrackerTestSuite struct {
+	suite.Suite
+	clock   *clock.Fake
+	tracker *interactionTracker
+}
+
+func (s *InteractionTrackerTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Now())
+	s.tracker = newInteractionTracker()
+}
+
+func TestInteractionTrackerTestSuite(t *testing.T) {
+	suite.Run(t, new(InteractionTrackerTestSuite))
+}
+
+// drainAsync drains the tracker in the background, sending how many interactions were left running
+func (s *InteractionTrackerTestSuite) drainAsync(timeout time.Duration) <-chan int {
+	remaining := make(chan int, 1)
+	go func() {
+		remaining <- s.tracker.drain(s.clock, timeout)
+	}()
+	return remaining
+}
+
+func (s *InteractionTrackerTestSuite) TestDrainWithNothingRunning() {
+	s.Equal(0, s.tracker.drain(s.clock, time.Second))
+	s.False(s.tracker.begin())
+}
+
+func (s *InteractionTrackerTestSuite) TestDrainWaitsForRunningInteractions() {
+	s.Require().True(s.tracker.begin())
+	s.Require().True(s.tracker.begin())
+
+	remaining := s.drainAsync(time.Second)
+	s.clock.BlockUntil(1)
+
+	// Nothing new is taken while draining
+	s.False(s.tracker.begin())
+
+	s.tracker.end()
+	select {
+	case <-remaining:
+		s.Fail("drain finished with an interaction still running")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.tracker.end()
+	s.Equal(0, <-remaining)
+}
+
+func (s *InteractionTrackerTestSuite) TestDrainGivesUp() {
+	s.Require().True(s.tracker.begin())
+
+	remaining := s.drainAsync(time.Second)
+	s.clock.BlockUntil(1)
+	s.clock.Advance(time.Second)
+
+	s.Equal(1, <-remaining)
+
+	// Interactions finishing afterwards don't trip anything up
+	s.tracker.end()
+}
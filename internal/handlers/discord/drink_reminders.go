@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// remindersCommand returns the subcommand for choosing how you're reminded of drinks you've owed a long time
+func remindersCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "reminders",
+		Description: "See or change how you're reminded of drinks you've owed a long time",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "delivery",
+				Description: "Where your reminders go",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "In the channel", Value: string(models.ReminderDeliveryChannel)},
+					{Name: "By DM", Value: string(models.ReminderDeliveryDM)},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "mute",
+				Description: "Stop your reminders, or start them again",
+			},
+		},
+	}
+}
+
+// handleReminders shows the player how they're reminded of drinks they owe, or changes it
+func (c *RonniedCommand) handleReminders(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, userID, username string) error {
+	ctx := context.Background()
+
+	input := &game.SetDrinkRemindersInput{
+		PlayerID:   userID,
+		PlayerName: username,
+	}
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "delivery":
+			input.Delivery = models.ReminderDelivery(opt.StringValue())
+		case "mute":
+			muted := opt.BoolValue()
+			input.Muted = &muted
+		}
+	}
+
+	current, err := c.gameService.GetDrinkReminders(ctx, &game.GetDrinkRemindersInput{
+		PlayerID: userID,
+	})
+	if err != nil {
+		log.Printf("Error getting drink reminders: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get your reminders: %v", err))
+	}
+
+	if input.Delivery == "" && input.Muted == nil {
+		return RespondWithEphemeralMessage(s, i, drinkRemindersDescription(current.Reminders, current.After))
+	}
+
+	output, err := c.gameService.SetDrinkReminders(ctx, input)
+	if err != nil {
+		if errors.Is(err, game.ErrInvalidReminderDelivery) {
+			return RespondWithEphemeralMessage(s, i, "Reminders can go to the channel or to your DMs.")
+		}
+		log.Printf("Error setting drink reminders: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change your reminders: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Reminders updated**\n"+drinkRemindersDescription(output.Reminders, current.After))
+}
+
+// drinkRemindersDescription tells a player how they're reminded of drinks they owe
+func drinkRemindersDescription(reminders *models.DrinkReminders, after time.Duration) string {
+	var description strings.Builder
+	switch {
+	case reminders.Muted:
+		description.WriteString("🔕 Your drink reminders are muted.")
+	case reminders.DeliveryOrDefault() == models.ReminderDeliveryDM:
+		description.WriteString("🔔 You're reminded of drinks you owe by DM.")
+	default:
+		description.WriteString("🔔 You're reminded of drinks you owe with a mention in the channel.")
+	}
+
+	if after == 0 {
+		description.WriteString("\nReminders are turned off on this bot.")
+	} else {
+		fmt.Fprintf(&description, "\nReminders go out once a drink has been owed for %s.", after)
+	}
+	return description.String()
+}
+
+// handleDrinkReminder reminds a player of the drinks they've owed a long time, by DM when they asked for it and
+// with a mention in the channel otherwise, or when their DMs are closed
+func (b *Bot) handleDrinkReminder(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.DrinkReminderPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	output, err := b.messagingService.GetDrinkReminderMessage(b.messagingContext(ctx, event.GuildID), &messaging.GetDrinkReminderMessageInput{
+		PlayerName: payload.PlayerName,
+		DrinkCount: payload.DrinkCount,
+		Reminder:   payload.Reminder,
+	})
+	if err != nil {
+		log.Printf("Error getting drink reminder message: %v", err)
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       output.Title,
+		Description: output.Message,
+		Color:       0xE67E22,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "Mute these with /ronnied reminders mute:true",
+		},
+	}
+
+	if models.ReminderDelivery(payload.Delivery) == models.ReminderDeliveryDM {
+		err := b.sendDirectMessage(payload.PlayerID, embed)
+		if err == nil {
+			return
+		}
+		log.Printf("Error sending drink reminder to %s by DM, mentioning them instead: %v", payload.PlayerID, err)
+	}
+
+	_, err = b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Content: mentions([]string{payload.PlayerID}),
+		Embeds:  []*discordgo.MessageEmbed{embed},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: []string{payload.PlayerID},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting drink reminder for %s: %v", payload.PlayerID, err)
+	}
+}
+
+// sendDirectMessage sends an embed to a user's DMs
+func (b *Bot) sendDirectMessage(userID string, embed *discordgo.MessageEmbed) error {
+	channel, err := b.api.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.api.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	return err
+}
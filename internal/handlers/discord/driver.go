@@ -0,0 +1,128 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// driverCommandGroup returns the subcommand group for the designated driver role
+func driverCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "driver",
+		Description: "Sit out drinks as the designated driver",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "on",
+				Description: "Become the designated driver, your drinks are owed later",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "off",
+				Description: "Stop being the designated driver and start drinking again",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "cover",
+				Description: "Volunteer to take a designated driver's drinks",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "player",
+						Description: "The designated driver to drink for",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// handleDriver handles the designated driver subcommand group
+func (c *RonniedCommand) handleDriver(s DiscordSession, i *discordgo.InteractionCreate, group *discordgo.ApplicationCommandInteractionDataOption, channelID, userID, username string) error {
+	ctx := context.Background()
+
+	if len(group.Options) == 0 {
+		return errors.New("missing driver subcommand")
+	}
+
+	subcommand := group.Options[0]
+	switch subcommand.Name {
+	case "on":
+		_, err := c.gameService.SetDesignatedDriver(ctx, &game.SetDesignatedDriverInput{
+			ChannelID:  channelID,
+			GuildID:    i.GuildID,
+			PlayerID:   userID,
+			PlayerName: username,
+		})
+		if err != nil {
+			return respondDriverError(s, i, err)
+		}
+
+		return RespondWithMessage(s, i, fmt.Sprintf("🚗 **%s** is the designated driver this session. Their drinks are owed later, unless someone volunteers with `/ronnied driver cover`.", username))
+
+	case "off":
+		output, err := c.gameService.ClearDesignatedDriver(ctx, &game.ClearDesignatedDriverInput{
+			ChannelID: channelID,
+			GuildID:   i.GuildID,
+			PlayerID:  userID,
+		})
+		if err != nil {
+			return respondDriverError(s, i, err)
+		}
+
+		if output.DrinksOwed == 0 {
+			return RespondWithMessage(s, i, fmt.Sprintf("🍺 **%s** is off driving duty and back in the game.", username))
+		}
+		return RespondWithMessage(s, i, fmt.Sprintf("🍺 **%s** is off driving duty and has %d drinks to catch up on!", username, output.DrinksOwed))
+
+	case "cover":
+		if len(subcommand.Options) == 0 {
+			return errors.New("missing driver to cover")
+		}
+
+		driverID := subcommand.Options[0].UserValue(nil).ID
+		if driverID == userID {
+			return RespondWithEphemeralMessage(s, i, "You can't cover your own drinks, that's just drinking.")
+		}
+
+		output, err := c.gameService.CoverDesignatedDriver(ctx, &game.CoverDesignatedDriverInput{
+			ChannelID:      channelID,
+			GuildID:        i.GuildID,
+			DriverID:       driverID,
+			SubstituteID:   userID,
+			SubstituteName: username,
+		})
+		if err != nil {
+			return respondDriverError(s, i, err)
+		}
+
+		return RespondWithMessage(s, i, fmt.Sprintf("🦸 **%s** will take **%s**'s drinks while they drive!", username, output.Driver.PlayerName))
+
+	default:
+		return fmt.Errorf("unknown driver subcommand: %s", subcommand.Name)
+	}
+}
+
+// respondDriverError explains why a designated driver command was refused
+func respondDriverError(s DiscordSession, i *discordgo.InteractionCreate, err error) error {
+	switch {
+	case errors.Is(err, game.ErrAlreadyDesignatedDriver):
+		return RespondWithEphemeralMessage(s, i, "You're already the designated driver this session.")
+	case errors.Is(err, game.ErrNotDesignatedDriver):
+		return RespondWithEphemeralMessage(s, i, "That player isn't a designated driver this session.")
+	case errors.Is(err, game.ErrCoveringForDriver):
+		return RespondWithEphemeralMessage(s, i, "You're covering for a designated driver, so you can't stop drinking now!")
+	case errors.Is(err, game.ErrInvalidSubstitute):
+		return RespondWithEphemeralMessage(s, i, "Designated drivers can't cover anyone's drinks.")
+	}
+
+	log.Printf("Error updating designated driver: %v", err)
+	return RespondWithError(s, i, fmt.Sprintf("Couldn't update the designated driver: %v", err))
+}
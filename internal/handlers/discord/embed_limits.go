@@ -0,0 +1,216 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord's embed limits, counted in characters
+const (
+	maxEmbedDescription = 4096
+	maxEmbedFieldValue  = 1024
+	maxEmbedFields      = 25
+	maxEmbedTotal       = 6000
+
+	// maxMessageComponentRows and maxButtonsPerRow limit a message's buttons
+	maxMessageComponentRows = 5
+	maxButtonsPerRow        = 5
+
+	// maxFieldsPerShowMore is how many pages of a section go in each show more message
+	maxFieldsPerShowMore = 5
+)
+
+// truncatedFieldNote ends a section that was cut short
+const truncatedFieldNote = "\n*…and more, press Show more to see it all*"
+
+// fitEmbedLimits trims a game message's embed to Discord's limits so the edit isn't rejected.
+// Sections that are cut short or left out get a "Show more" button that shows them in full.
+func fitEmbedLimits(edit *discordgo.MessageEdit, gameID string) {
+	if len(edit.Embeds) == 0 {
+		return
+	}
+	embed := edit.Embeds[0]
+
+	if utf8.RuneCountInString(embed.Description) > maxEmbedDescription {
+		embed.Description = truncateRunes(embed.Description, maxEmbedDescription-1) + "…"
+	}
+
+	names := make([]string, len(embed.Fields))
+	overflow := make(map[int]bool)
+	for index, field := range embed.Fields {
+		names[index] = field.Name
+		if utf8.RuneCountInString(field.Value) <= maxEmbedFieldValue {
+			continue
+		}
+
+		// Keep the first page of lines, leaving room for the note
+		pages := splitFieldValue(field.Value, maxEmbedFieldValue-utf8.RuneCountInString(truncatedFieldNote))
+		field.Value = strings.TrimRight(pages[0], "\n") + truncatedFieldNote
+		overflow[index] = true
+	}
+
+	// Leave out whole sections from the end until the rest fits
+	kept := len(embed.Fields)
+	for kept > 0 && (kept > maxEmbedFields || embedLength(embed, kept) > maxEmbedTotal) {
+		kept--
+		overflow[kept] = true
+	}
+	embed.Fields = embed.Fields[:kept]
+
+	if len(overflow) == 0 || len(edit.Components) >= maxMessageComponentRows {
+		return
+	}
+
+	indexes := make([]int, 0, len(overflow))
+	for index := range overflow {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var buttons []discordgo.MessageComponent
+	for _, index := range indexes {
+		if len(buttons) == maxButtonsPerRow {
+			break
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    truncateRunes("Show more: "+names[index], 80),
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("%s%s:%d", ButtonShowMorePrefix, gameID, index),
+		})
+	}
+
+	edit.Components = append(edit.Components, discordgo.ActionsRow{Components: buttons})
+}
+
+// handleShowMoreButton shows a section of the game message in full, in as many private messages as it takes
+func (b *Bot) handleShowMoreButton(s DiscordSession, i *discordgo.InteractionCreate, section string) error {
+	ctx := context.Background()
+
+	gameID, indexStr, _ := strings.Cut(section, ":")
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, "That section can't be found.")
+	}
+
+	// Render the game again without trimming it to the limits
+	messageEdit, _, err := b.renderGameView(ctx, gameID)
+	if err != nil {
+		log.Printf("Error rendering game for show more: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Error"))
+	}
+
+	if len(messageEdit.Embeds) == 0 || index < 0 || index >= len(messageEdit.Embeds[0].Fields) {
+		return RespondWithEphemeralMessage(s, i, "That section has changed since, take another look at the game message.")
+	}
+	field := messageEdit.Embeds[0].Fields[index]
+
+	pages := splitFieldValue(field.Value, maxEmbedFieldValue)
+	embeds := make([]*discordgo.MessageEmbed, 0, (len(pages)+maxFieldsPerShowMore-1)/maxFieldsPerShowMore)
+	for start := 0; start < len(pages); start += maxFieldsPerShowMore {
+		embed := &discordgo.MessageEmbed{
+			Title: field.Name,
+			Color: messageEdit.Embeds[0].Color,
+		}
+		for page := start; page < min(start+maxFieldsPerShowMore, len(pages)); page++ {
+			name := "\u200b" // Fields need a name, so a single page gets an invisible one
+			if len(pages) > 1 {
+				name = fmt.Sprintf("Page %d of %d", page+1, len(pages))
+			}
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  name,
+				Value: pages[page],
+			})
+		}
+		embeds = append(embeds, embed)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: embeds[:1],
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Each message has room for a few pages, the rest follow
+	for _, embed := range embeds[1:] {
+		if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitFieldValue splits a field's text into pages of at most limit characters, breaking between lines where it can
+func splitFieldValue(value string, limit int) []string {
+	var pages []string
+	var page strings.Builder
+	pageLen := 0
+
+	for _, line := range strings.SplitAfter(value, "\n") {
+		lineLen := utf8.RuneCountInString(line)
+
+		// A single line longer than a page is cut wherever it has to be
+		for lineLen > limit {
+			if pageLen > 0 {
+				pages = append(pages, page.String())
+				page.Reset()
+				pageLen = 0
+			}
+			pages = append(pages, truncateRunes(line, limit))
+			line = string([]rune(line)[limit:])
+			lineLen -= limit
+		}
+
+		if pageLen+lineLen > limit {
+			pages = append(pages, page.String())
+			page.Reset()
+			pageLen = 0
+		}
+		page.WriteString(line)
+		pageLen += lineLen
+	}
+
+	if pageLen > 0 || len(pages) == 0 {
+		pages = append(pages, page.String())
+	}
+	return pages
+}
+
+// embedLength counts the characters of an embed that count towards Discord's total, using its first fields only
+func embedLength(embed *discordgo.MessageEmbed, fields int) int {
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	if embed.Footer != nil {
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	if embed.Author != nil {
+		length += utf8.RuneCountInString(embed.Author.Name)
+	}
+	for _, field := range embed.Fields[:fields] {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	return length
+}
+
+// truncateRunes cuts text down to at most limit characters
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit])
+}
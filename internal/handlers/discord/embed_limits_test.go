@@ -0,0 +1,127 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmbedLimitsTestSuite struct {
+	suite.Suite
+}
+
+func TestEmbedLimitsTestSuite(t *testing.T) {
+	suite.Run(t, new(EmbedLimitsTestSuite))
+}
+
+// lines builds a field value of count numbered lines
+func lines(count int) string {
+	var value strings.Builder
+	for n := 1; n <= count; n++ {
+		fmt.Fprintf(&value, "• **player-%02d** rolled a 4 and is feeling fine\n", n)
+	}
+	return value.String()
+}
+
+// gameMessage builds a game message edit with the given fields
+func gameMessage(fields ...*discordgo.MessageEmbedField) *discordgo.MessageEdit {
+	return &discordgo.MessageEdit{
+		Channel: "channel-1",
+		ID:      "message-1",
+		Embeds: []*discordgo.MessageEmbed{
+			{Title: "Game", Fields: fields},
+		},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{discordgo.Button{CustomID: ButtonRollDice}}},
+		},
+	}
+}
+
+// showMoreIDs returns the custom IDs of a message's show more buttons
+func showMoreIDs(edit *discordgo.MessageEdit) []string {
+	var ids []string
+	for _, component := range edit.Components {
+		for _, button := range component.(discordgo.ActionsRow).Components {
+			if id := button.(discordgo.Button).CustomID; strings.HasPrefix(id, ButtonShowMorePrefix) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (s *EmbedLimitsTestSuite) TestSmallEmbedIsUntouched() {
+	edit := gameMessage(&discordgo.MessageEmbedField{Name: "Players", Value: lines(3)})
+
+	fitEmbedLimits(edit, "game-1")
+
+	s.Equal(lines(3), edit.Embeds[0].Fields[0].Value)
+	s.Len(edit.Components, 1)
+}
+
+func (s *EmbedLimitsTestSuite) TestLongSectionIsCutShort() {
+	edit := gameMessage(
+		&discordgo.MessageEmbedField{Name: "Status", Value: "Active"},
+		&discordgo.MessageEmbedField{Name: "Players", Value: lines(40)},
+	)
+
+	fitEmbedLimits(edit, "game-1")
+
+	value := edit.Embeds[0].Fields[1].Value
+	s.LessOrEqual(utf8.RuneCountInString(value), maxEmbedFieldValue)
+	s.True(strings.HasPrefix(value, "• **player-01**"))
+	s.True(strings.HasSuffix(value, truncatedFieldNote))
+	s.Equal([]string{ButtonShowMorePrefix + "game-1:1"}, showMoreIDs(edit))
+}
+
+func (s *EmbedLimitsTestSuite) TestSectionsThatDontFitAreLeftOut() {
+	var fields []*discordgo.MessageEmbedField
+	for n := 0; n < 8; n++ {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: fmt.Sprintf("Section %d", n), Value: lines(20)})
+	}
+	edit := gameMessage(fields...)
+
+	fitEmbedLimits(edit, "game-1")
+
+	embed := edit.Embeds[0]
+	s.LessOrEqual(embedLength(embed, len(embed.Fields)), maxEmbedTotal)
+	s.Len(embed.Fields, 6)
+
+	// Every section that was left out gets a button
+	s.Equal([]string{ButtonShowMorePrefix + "game-1:6", ButtonShowMorePrefix + "game-1:7"}, showMoreIDs(edit))
+}
+
+func (s *EmbedLimitsTestSuite) TestShowMoreButtonsFillOneRow() {
+	var fields []*discordgo.MessageEmbedField
+	for n := 0; n < 8; n++ {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: fmt.Sprintf("Section %d", n), Value: lines(25)})
+	}
+	edit := gameMessage(fields...)
+
+	fitEmbedLimits(edit, "game-1")
+
+	ids := showMoreIDs(edit)
+	s.Len(ids, maxButtonsPerRow)
+	s.Equal(ButtonShowMorePrefix+"game-1:0", ids[0])
+}
+
+func (s *EmbedLimitsTestSuite) TestSplitFieldValue() {
+	pages := splitFieldValue(lines(40), maxEmbedFieldValue)
+
+	s.Greater(len(pages), 1)
+	s.Equal(lines(40), strings.Join(pages, ""))
+	for _, page := range pages {
+		s.LessOrEqual(utf8.RuneCountInString(page), maxEmbedFieldValue)
+		s.True(strings.HasSuffix(page, "\n"))
+	}
+
+	// Lines longer than a page are cut
+	long := strings.Repeat("🍺", 30)
+	pages = splitFieldValue(long, 10)
+	s.Len(pages, 3)
+	s.Equal(long, strings.Join(pages, ""))
+}
@@ -0,0 +1,170 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// emojiSlots are the bot's emoji a server can swap for its own, in the order they're listed
+var emojiSlots = []models.EmojiSlot{
+	models.EmojiSlotDice,
+	models.EmojiSlotDrink,
+	models.EmojiSlotCriticalHit,
+	models.EmojiSlotCriticalFail,
+}
+
+// defaultEmojis are the bot's own emoji for each slot
+var defaultEmojis = map[models.EmojiSlot]string{
+	models.EmojiSlotDice:         defaultDiceEmoji,
+	models.EmojiSlotDrink:        "🍺",
+	models.EmojiSlotCriticalHit:  "🔥",
+	models.EmojiSlotCriticalFail: "💀",
+}
+
+// emojiSlotNames names each slot for the emoji command
+var emojiSlotNames = map[models.EmojiSlot]string{
+	models.EmojiSlotDice:         "Dice",
+	models.EmojiSlotDrink:        "Drinks",
+	models.EmojiSlotCriticalHit:  "Critical hits",
+	models.EmojiSlotCriticalFail: "Critical fails",
+}
+
+// emojiCommand returns the subcommand for swapping the bot's emoji for the server's own
+func emojiCommand() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(emojiSlots))
+	for _, slot := range emojiSlots {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: emojiSlotNames[slot], Value: string(slot)})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "emoji",
+		Description: "See or change the server emoji shown for dice, drinks and crits",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "for",
+				Description: "What the emoji is shown for",
+				Choices:     choices,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "emoji",
+				Description: "One of this server's emoji, leave it out to go back to the bot's",
+			},
+		},
+	}
+}
+
+// handleEmoji lists the server's emoji, or changes one of them
+func (c *RonniedCommand) handleEmoji(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Server emoji are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Emoji can only be set up in a server.")
+	}
+
+	var slot models.EmojiSlot
+	var emoji string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "for":
+			slot = models.EmojiSlot(opt.StringValue())
+		case "emoji":
+			emoji = strings.TrimSpace(opt.StringValue())
+		}
+	}
+
+	if slot == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the server's emoji: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "**Emoji**\n"+describeEmojis(output.Config))
+	}
+
+	output, err := c.guildConfigService.SetEmoji(ctx, &guild_config.SetEmojiInput{
+		GuildID: i.GuildID,
+		Slot:    slot,
+		Emoji:   emoji,
+	})
+	if err != nil {
+		if errors.Is(err, guild_config.ErrInvalidEmoji) {
+			return RespondWithEphemeralMessage(s, i, "That isn't one of the server's emoji. Pick one from the emoji picker, it looks like `<:name:123456789012345678>` once sent.")
+		}
+		log.Printf("Error setting emoji: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the emoji: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Emoji updated**\n"+describeEmojis(output.Config))
+}
+
+// describeEmojis lists the emoji shown for each slot, noting those still the bot's own
+func describeEmojis(config *models.GuildConfig) string {
+	var lines strings.Builder
+	for _, slot := range emojiSlots {
+		emoji := config.Emoji(slot, defaultEmojis[slot])
+		fmt.Fprintf(&lines, "%s: %s", emojiSlotNames[slot], emoji)
+		if emoji == defaultEmojis[slot] {
+			lines.WriteString(" (default)")
+		}
+		lines.WriteString("\n")
+	}
+	return lines.String()
+}
+
+// emojiReplacer swaps the bot's emoji for the ones the server picked, nil when it hasn't picked any
+func emojiReplacer(config *models.GuildConfig) *strings.Replacer {
+	var pairs []string
+	for _, slot := range emojiSlots {
+		if emoji := config.Emoji(slot, ""); emoji != "" {
+			pairs = append(pairs, defaultEmojis[slot], emoji)
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// guildEmojis returns the replacer for a guild's own emoji, nil when it hasn't picked any or they can't be looked up
+func (b *Bot) guildEmojis(ctx context.Context, guildID string) *strings.Replacer {
+	if b.guildConfigService == nil || guildID == "" {
+		return nil
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for emoji: %v", err)
+		return nil
+	}
+
+	return emojiReplacer(output.Config)
+}
+
+// serverEmojis shows a server's own emoji in a game message's text and sections. Titles and section names keep
+// the bot's, Discord only draws server emoji in the text under them.
+func serverEmojis(edit *discordgo.MessageEdit, replacer *strings.Replacer) {
+	for _, embed := range edit.Embeds {
+		embed.Description = replacer.Replace(embed.Description)
+		for _, field := range embed.Fields {
+			field.Value = replacer.Replace(field.Value)
+		}
+	}
+}
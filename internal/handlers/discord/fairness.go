@@ -0,0 +1,152 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// fairnessMatrixSize is how many players the fairness matrix fits, the ones who gave the most drinks
+	fairnessMatrixSize = 8
+
+	// fairnessNameWidth is how many characters of a player's name the fairness matrix shows
+	fairnessNameWidth = 6
+
+	// fairnessExchanges is how many of the biggest exchanges of drinks the fairness report lists
+	fairnessExchanges = 5
+)
+
+// fairnessCommand returns the subcommand for the session's fairness report
+func fairnessCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "fairness",
+		Description: "See who has been giving drinks to whom this session, and who never gives them to each other",
+	}
+}
+
+// handleFairness posts the fairness report of the channel's session for everyone to see
+func (c *RonniedCommand) handleFairness(s DiscordSession, i *discordgo.InteractionCreate, channelID string) error {
+	output, err := c.gameService.GetFairnessReport(context.Background(), &game.GetFairnessReportInput{
+		ChannelID: channelID,
+		GuildID:   i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting fairness report: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't work out the fairness report: %v", err))
+	}
+
+	if output.Session == nil || len(output.Players) == 0 {
+		return RespondWithEphemeralMessage(s, i, "Nobody has given out a drink this session yet, so everyone's playing fair. 😇")
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{renderFairnessReport(output)},
+		},
+	})
+}
+
+// renderFairnessReport shows who gave drinks to whom as a matrix, with the alliances, feuds and biggest exchanges
+// of drinks picked out underneath
+func renderFairnessReport(output *game.GetFairnessReportOutput) *discordgo.MessageEmbed {
+	names := make(map[string]string, len(output.Players))
+	for _, player := range output.Players {
+		names[player.PlayerID] = isolateName(player.PlayerName)
+	}
+
+	var alliances, feuds strings.Builder
+	for _, pair := range output.Pairs {
+		first, second := names[pair.FirstPlayerID], names[pair.SecondPlayerID]
+		switch pair.Pattern {
+		case game.FairnessPatternAlliance:
+			fmt.Fprintf(&alliances, "**%s** and **%s** hand out drinks but never to each other\n", first, second)
+		case game.FairnessPatternFeud:
+			fmt.Fprintf(&feuds, "**%s** ⇄ **%s**: %d and %d drinks\n", first, second, pair.FirstToSecond, pair.SecondToFirst)
+		}
+	}
+
+	exchanges := make([]*game.FairnessPair, 0, len(output.Pairs))
+	for _, pair := range output.Pairs {
+		if pair.FirstToSecond+pair.SecondToFirst > 0 {
+			exchanges = append(exchanges, pair)
+		}
+	}
+	sort.SliceStable(exchanges, func(i, j int) bool {
+		return exchanges[i].FirstToSecond+exchanges[i].SecondToFirst > exchanges[j].FirstToSecond+exchanges[j].SecondToFirst
+	})
+	var trades strings.Builder
+	for _, pair := range exchanges[:min(len(exchanges), fairnessExchanges)] {
+		fmt.Fprintf(&trades, "**%s** → **%s** %d, **%s** → **%s** %d (%.0f%% even)\n",
+			names[pair.FirstPlayerID], names[pair.SecondPlayerID], pair.FirstToSecond,
+			names[pair.SecondPlayerID], names[pair.FirstPlayerID], pair.SecondToFirst,
+			pair.Reciprocity()*100)
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:  "Who Gave Whom",
+			Value: renderFairnessMatrix(output.Players),
+		},
+	}
+	if trades.Len() > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Biggest Exchanges", Value: trades.String()})
+	}
+	if alliances.Len() > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "🤝 Alliances?", Value: alliances.String()})
+	}
+	if feuds.Len() > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "⚔️ Feuds", Value: feuds.String()})
+	}
+
+	description := "Who has been giving the drinks they got to choose to whom this session."
+	if alliances.Len() == 0 && feuds.Len() == 0 {
+		description += " No alliances or feuds, everyone's playing fair. 😇"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "⚖️ Fairness Report",
+		Description: description,
+		Color:       0x3498DB,
+		Fields:      fields,
+	}
+}
+
+// renderFairnessMatrix lays out the drinks each player gave, one row for each giver and one column for each player
+// they could have given them to
+func renderFairnessMatrix(players []*game.FairnessPlayer) string {
+	players = players[:min(len(players), fairnessMatrixSize)]
+
+	var matrix strings.Builder
+	matrix.WriteString("```\n")
+	fmt.Fprintf(&matrix, "%-*s", fairnessNameWidth+1, "from")
+	for _, to := range players {
+		fmt.Fprintf(&matrix, " %*s", fairnessNameWidth, truncateRunes(to.PlayerName, fairnessNameWidth))
+	}
+	matrix.WriteString("\n")
+
+	for _, from := range players {
+		if from.Given == 0 {
+			continue
+		}
+		fmt.Fprintf(&matrix, "%-*s", fairnessNameWidth+1, truncateRunes(from.PlayerName, fairnessNameWidth))
+		for _, to := range players {
+			cell := "-"
+			if to.PlayerID != from.PlayerID {
+				cell = fmt.Sprint(from.Targets[to.PlayerID])
+			}
+			fmt.Fprintf(&matrix, " %*s", fairnessNameWidth, cell)
+		}
+		matrix.WriteString("\n")
+	}
+	matrix.WriteString("```")
+
+	return matrix.String()
+}
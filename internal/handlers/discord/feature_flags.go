@@ -0,0 +1,157 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/bwmarrin/discordgo"
+)
+
+// featureFlagSources explains where a flag's value comes from
+var featureFlagSources = map[models.FeatureFlagSource]string{
+	models.FeatureFlagSourceDefault: "default",
+	models.FeatureFlagSourceBot:     "set for the bot",
+	models.FeatureFlagSourceGuild:   "set for this server",
+}
+
+// flagsCommand returns the subcommand for seeing and toggling the server's feature flags
+func flagsCommand() *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(models.FeatureFlags))
+	for _, flag := range models.FeatureFlags {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: string(flag), Value: string(flag)})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "flags",
+		Description: "See or toggle the bot's features for the server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "flag",
+				Description: "The feature to toggle",
+				Choices:     choices,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "enabled",
+				Description: "Whether the feature is on for the server",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "reset",
+				Description: "Go back to the bot's setting for the feature",
+			},
+		},
+	}
+}
+
+// handleFlags lists the server's feature flags, or turns one on or off
+func (c *RonniedCommand) handleFlags(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.featureFlagService == nil {
+		return RespondWithEphemeralMessage(s, i, "Feature flags are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Feature flags can only be set up in a server.")
+	}
+
+	var flag models.FeatureFlag
+	var enabled *bool
+	var reset bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "flag":
+			flag = models.FeatureFlag(opt.StringValue())
+		case "enabled":
+			value := opt.BoolValue()
+			enabled = &value
+		case "reset":
+			reset = opt.BoolValue()
+		}
+	}
+
+	if enabled == nil && !reset {
+		output, err := c.featureFlagService.GetFeatureFlags(ctx, &feature_flag.GetFeatureFlagsInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting feature flags: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the feature flags: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "**Feature Flags**\n"+featureFlagsDescription(output.Flags))
+	}
+
+	if flag == "" {
+		return RespondWithEphemeralMessage(s, i, "Pick the flag to change.")
+	}
+
+	var state *models.FeatureFlagState
+	var err error
+	if reset {
+		var output *feature_flag.ClearFeatureFlagOutput
+		output, err = c.featureFlagService.ClearFeatureFlag(ctx, &feature_flag.ClearFeatureFlagInput{
+			GuildID: i.GuildID,
+			Flag:    flag,
+		})
+		if output != nil {
+			state = output.State
+		}
+	} else {
+		var output *feature_flag.SetFeatureFlagOutput
+		output, err = c.featureFlagService.SetFeatureFlag(ctx, &feature_flag.SetFeatureFlagInput{
+			GuildID: i.GuildID,
+			Flag:    flag,
+			Enabled: *enabled,
+		})
+		if output != nil {
+			state = output.State
+		}
+	}
+	if err != nil {
+		if errors.Is(err, feature_flag.ErrUnknownFlag) {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("There's no feature flag called %s.", flag))
+		}
+		log.Printf("Error changing feature flag: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the feature flag: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Feature Flag updated**\n"+featureFlagsDescription([]*models.FeatureFlagState{state}))
+}
+
+// featureFlagsDescription lists feature flags, one line each
+func featureFlagsDescription(flags []*models.FeatureFlagState) string {
+	lines := make([]string, 0, len(flags))
+	for _, state := range flags {
+		status := "❌ off"
+		if state.Enabled {
+			status = "✅ on"
+		}
+		lines = append(lines, fmt.Sprintf("`%s` %s (%s)\n%s", state.Flag, status, featureFlagSources[state.Source], state.Flag.Description()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// featureEnabled returns whether a flag is on for the guild, its default when flags can't be read
+func (b *Bot) featureEnabled(ctx context.Context, guildID string, flag models.FeatureFlag) bool {
+	if b.featureFlagService == nil {
+		return flag.Default()
+	}
+
+	output, err := b.featureFlagService.IsEnabled(ctx, &feature_flag.IsEnabledInput{
+		GuildID: guildID,
+		Flag:    flag,
+	})
+	if err != nil {
+		log.Printf("Error checking feature flag %s: %v", flag, err)
+		return flag.Default()
+	}
+	return output.Enabled
+}
@@ -0,0 +1,147 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// forgetMeCommand returns the subcommand for players to delete their own data
+func forgetMeCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "forgetme",
+		Description: "Delete everything the bot knows about you",
+	}
+}
+
+// purgeUserCommand returns the admin subcommand for deleting a player's data
+func purgeUserCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "purge-user",
+		Description: "Delete everything the bot knows about a player from this server (admins only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "The player to delete",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// forgetWarning explains what deleting a player's data does
+const forgetWarning = "Their profile, re-roll tokens and roll modifiers are deleted. Drinks they gave or took stay on " +
+	"the leaderboards as \"Deleted Player\" so everyone else's tallies still add up. This can't be undone."
+
+// purgeWarning explains what purging a player from a server does
+const purgeWarning = "Their drinks, rolls and moments from this server stay on the leaderboards as \"Deleted Player\" " +
+	"so everyone else's tallies still add up. Their profile and what they did in other servers are left alone, " +
+	"only they can delete those. This can't be undone."
+
+// handleForgetMe asks the player to confirm deleting their data
+func (c *RonniedCommand) handleForgetMe(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return RespondWithEphemeralEmbedAndButtons(s, i, "Forget me?",
+		"This deletes everything the bot knows about you. "+forgetWarning, nil,
+		forgetButtons(ButtonForgetMe, "Forget me"))
+}
+
+// handlePurgeUser asks a server admin to confirm deleting a player's data
+func (c *RonniedCommand) handlePurgeUser(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	var player *discordgo.User
+	for _, opt := range subcommand.Options {
+		if opt.Name == "player" {
+			player = opt.UserValue(nil)
+		}
+	}
+
+	if player == nil {
+		return errors.New("missing player option")
+	}
+
+	return RespondWithEphemeralEmbedAndButtons(s, i, "Purge player?",
+		fmt.Sprintf("This deletes everything the bot knows about <@%s> from this server. %s", player.ID, purgeWarning), nil,
+		forgetButtons(ButtonPurgeUserPrefix+player.ID, "Purge player"))
+}
+
+// forgetButtons returns the confirm and cancel buttons for deleting a player's data
+func forgetButtons(confirmID, confirmLabel string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.Button{
+			Label:    confirmLabel,
+			Style:    discordgo.DangerButton,
+			CustomID: confirmID,
+		},
+		discordgo.Button{
+			Label:    "Cancel",
+			Style:    discordgo.SecondaryButton,
+			CustomID: ButtonForgetCancel,
+		},
+	}
+}
+
+// handleForgetMeButton deletes the player's data once they've confirmed
+func (b *Bot) handleForgetMeButton(s DiscordSession, i *discordgo.InteractionCreate, userID string) error {
+	return b.forgetPlayer(s, i, &game.ForgetPlayerInput{
+		PlayerID:    userID,
+		RequestedBy: userID,
+		GuildID:     i.GuildID,
+	}, "Done, the bot has forgotten you. 👋")
+}
+
+// handlePurgeUserButton deletes a player's data from the server once a server admin has confirmed
+func (b *Bot) handlePurgeUserButton(s DiscordSession, i *discordgo.InteractionCreate, userID, playerID string) error {
+	// The confirmation is only shown to admins, but check again in case their role changed since
+	if i.GuildID == "" || !isGuildAdmin(i) {
+		return updateForgetMessage(s, i, "Only server admins can purge players.")
+	}
+
+	return b.forgetPlayer(s, i, &game.ForgetPlayerInput{
+		PlayerID:    playerID,
+		RequestedBy: userID,
+		GuildID:     i.GuildID,
+	}, fmt.Sprintf("Done, everything about <@%s> from this server has been deleted.", playerID))
+}
+
+// handleForgetCancelButton leaves the player's data alone
+func (b *Bot) handleForgetCancelButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return updateForgetMessage(s, i, "Nothing was deleted.")
+}
+
+// forgetPlayer deletes a player's data and replaces the confirmation with the result
+func (b *Bot) forgetPlayer(s DiscordSession, i *discordgo.InteractionCreate, input *game.ForgetPlayerInput, doneMessage string) error {
+	ctx := context.Background()
+
+	output, err := b.gameService.ForgetPlayer(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrPlayerNotFound):
+			return updateForgetMessage(s, i, "There's nothing stored to delete.")
+		case errors.Is(err, game.ErrForgetPlayerInGame):
+			return updateForgetMessage(s, i, "That player is in a game. Try again once the game is over.")
+		}
+		log.Printf("Error forgetting player: %v", err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't delete the player's data"))
+	}
+
+	log.Printf("Forgot player, %d drink records anonymized as %s", output.RecordsAnonymized, output.AnonymousID)
+	return updateForgetMessage(s, i, doneMessage)
+}
+
+// updateForgetMessage replaces the confirmation message, and its buttons, with a result
+func updateForgetMessage(s DiscordSession, i *discordgo.InteractionCreate, message string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    message,
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// forgiveReasonField names the confirmation's embed field holding the reason, the buttons read it back from there
+const forgiveReasonField = "Reason"
+
+// forgiveCommand returns the admin subcommand for writing off a player's drinks
+func forgiveCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "forgive",
+		Description: "Write off drinks a player owes this session (admins only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "The player whose drinks are forgiven",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reason",
+				Description: "Why the drinks are forgiven",
+				Required:    true,
+				MaxLength:   200,
+			},
+		},
+	}
+}
+
+// handleForgive asks a server admin whether to forgive one of the player's drinks or their whole tab
+func (c *RonniedCommand) handleForgive(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	var player *discordgo.User
+	var reason string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "player":
+			player = opt.UserValue(nil)
+		case "reason":
+			reason = opt.StringValue()
+		}
+	}
+
+	if player == nil {
+		return errors.New("missing player option")
+	}
+
+	return RespondWithEphemeralEmbedAndButtons(s, i, "Forgive drinks?",
+		fmt.Sprintf("Write off drinks <@%s> owes this session. Forgiven drinks stay on the leaderboard but are no longer owed.", player.ID),
+		[]*discordgo.MessageEmbedField{
+			{Name: forgiveReasonField, Value: reason},
+		},
+		[]discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    "Forgive one drink",
+				Style:    discordgo.PrimaryButton,
+				CustomID: ButtonForgiveDrinkPrefix + player.ID,
+			},
+			discordgo.Button{
+				Label:    "Forgive whole tab",
+				Style:    discordgo.DangerButton,
+				CustomID: ButtonForgiveTabPrefix + player.ID,
+			},
+			discordgo.Button{
+				Label:    "Cancel",
+				Style:    discordgo.SecondaryButton,
+				CustomID: ButtonForgiveCancel,
+			},
+		})
+}
+
+// handleForgiveButton forgives one of the player's drinks, or their whole tab, once a server admin has confirmed
+func (b *Bot) handleForgiveButton(s DiscordSession, i *discordgo.InteractionCreate, userID, playerID string, wholeTab bool) error {
+	ctx := context.Background()
+
+	// The confirmation is only shown to admins, but check again in case their role changed since
+	if i.GuildID == "" || !isGuildAdmin(i) {
+		return updateForgetMessage(s, i, "Only server admins can forgive drinks.")
+	}
+
+	output, err := b.gameService.ForgiveDrinks(ctx, &game.ForgiveDrinksInput{
+		ChannelID:  i.ChannelID,
+		GuildID:    i.GuildID,
+		PlayerID:   playerID,
+		WholeTab:   wholeTab,
+		ForgivenBy: userID,
+		Reason:     forgiveReason(i),
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrNoDrinksToForgive) {
+			return updateForgetMessage(s, i, fmt.Sprintf("<@%s> doesn't owe any drinks.", playerID))
+		}
+		log.Printf("Error forgiving drinks: %v", err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't forgive the drinks"))
+	}
+
+	// Show the forgiven drinks on the channel's game message
+	b.refreshGameMessage(ctx, s, i.GuildID, i.ChannelID)
+
+	return updateForgetMessage(s, i, fmt.Sprintf("Forgave %d of <@%s>'s drinks. 🙏", len(output.Records), playerID))
+}
+
+// handleForgiveCancelButton leaves the player's drinks alone
+func (b *Bot) handleForgiveCancelButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return updateForgetMessage(s, i, "No drinks were forgiven.")
+}
+
+// forgiveReason reads the reason back from the confirmation the button was pressed on
+func forgiveReason(i *discordgo.InteractionCreate) string {
+	if i.Message != nil {
+		for _, embed := range i.Message.Embeds {
+			for _, field := range embed.Fields {
+				if field.Name == forgiveReasonField && field.Value != "" {
+					return field.Value
+				}
+			}
+		}
+	}
+	return "Forgiven by a server admin"
+}
@@ -0,0 +1,167 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// gameChannelsAnywhere is the channels command's choice for letting games be created in any channel
+const gameChannelsAnywhere = "anywhere"
+
+// gameChannelsCommand returns the subcommand for picking which channels games can be created in
+func gameChannelsCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "channels",
+		Description: "See or change which channels games can be started in",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "mode",
+				Description: "Whether the listed channels are the only ones games are played in, or the ones they aren't",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Only in the listed channels", Value: string(models.GameChannelsAllow)},
+					{Name: "Anywhere but the listed channels", Value: string(models.GameChannelsDeny)},
+					{Name: "Anywhere", Value: gameChannelsAnywhere},
+				},
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionChannel,
+				Name:         "add",
+				Description:  "A channel to add to the list",
+				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionChannel,
+				Name:         "remove",
+				Description:  "A channel to take off the list",
+				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+			},
+		},
+	}
+}
+
+// handleGameChannels lists the channels games can be created in, or changes them
+func (c *RonniedCommand) handleGameChannels(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Game channels are not enabled on this bot.")
+	}
+
+	output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get the game channels: %v", err))
+	}
+
+	config := output.Config
+	mode := config.GameChannelMode
+	channelIDs := slices.Clone(config.GameChannelIDs)
+	var modePicked bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "mode":
+			modePicked = true
+			mode = models.GameChannelMode(opt.StringValue())
+			if mode == gameChannelsAnywhere {
+				mode = ""
+			}
+		case "add":
+			channelIDs = append(channelIDs, opt.ChannelValue(nil).ID)
+		case "remove":
+			removed := opt.ChannelValue(nil).ID
+			channelIDs = slices.DeleteFunc(channelIDs, func(channelID string) bool { return channelID == removed })
+		}
+	}
+
+	if len(subcommand.Options) == 0 {
+		return RespondWithEphemeralMessage(s, i, describeGameChannels(config))
+	}
+
+	// Listing a channel without picking a mode keeps games out of it
+	if mode == "" && !modePicked && len(channelIDs) > 0 {
+		mode = models.GameChannelsDeny
+	}
+
+	setOutput, err := c.guildConfigService.SetGameChannels(ctx, &guild_config.SetGameChannelsInput{
+		GuildID:    i.GuildID,
+		Mode:       mode,
+		ChannelIDs: channelIDs,
+	})
+	if err != nil {
+		if errors.Is(err, guild_config.ErrNoAllowedChannels) {
+			return RespondWithEphemeralMessage(s, i, "Add at least one channel for games to be played in, e.g. `/ronnied-admin channels mode:allow add:#games`.")
+		}
+		log.Printf("Error setting game channels: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the game channels: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, describeGameChannels(setOutput.Config))
+}
+
+// describeGameChannels says where the server's games can be created
+func describeGameChannels(config *models.GuildConfig) string {
+	switch config.GameChannelMode {
+	case models.GameChannelsAllow:
+		return "🎲 Games can only be started in " + channelMentions(config.GameChannelIDs) + "."
+	case models.GameChannelsDeny:
+		return "🎲 Games can be started anywhere but " + channelMentions(config.GameChannelIDs) + "."
+	default:
+		return "🎲 Games can be started in any channel."
+	}
+}
+
+// channelMentions lists channels the way Discord links them
+func channelMentions(channelIDs []string) string {
+	mentions := make([]string, len(channelIDs))
+	for i, channelID := range channelIDs {
+		mentions[i] = fmt.Sprintf("<#%s>", channelID)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// gameChannelRedirect returns a message pointing the player to where games are played when the server doesn't
+// allow them in the channel, empty when they can start one there
+func gameChannelRedirect(ctx context.Context, s DiscordSession, guildConfigService guild_config.Service, guildID, channelID string) string {
+	if guildConfigService == nil || guildID == "" {
+		return ""
+	}
+
+	output, err := guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for game channels: %v", err)
+		return ""
+	}
+
+	config := output.Config
+	if !config.GameChannelMode.IsValid() {
+		return ""
+	}
+
+	// Threads follow the channel they're in
+	var parentID string
+	if channel, err := s.Channel(channelID); err == nil && channel.IsThread() {
+		parentID = channel.ParentID
+	}
+	if config.AllowsGamesIn(channelID, parentID) {
+		return ""
+	}
+
+	if config.GameChannelMode == models.GameChannelsAllow {
+		return fmt.Sprintf("🍻 This server plays its games in %s. Head over there to start one!", channelMentions(config.GameChannelIDs))
+	}
+	return "🍻 This server doesn't play games in this channel. Pick another one to start a game!"
+}
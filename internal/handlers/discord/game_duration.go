@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleGameDurationWarning pings the players who haven't rolled when the game is running out of time
+func (b *Bot) handleGameDurationWarning(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.GameDurationWarningPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Content: mentions(payload.PlayerIDs),
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "⏳ Time's Nearly Up",
+				Description: fmt.Sprintf("The game ends %s. Roll before then or you'll be skipped!", discordTimestamp(payload.EndsAt, timestampRelative)),
+				Color:       0xE67E22,
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: payload.PlayerIDs,
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting game duration warning for game %s: %v", event.GameID, err)
+	}
+}
+
+// handleGameTimedOut lets the channel know a game ran out of time and who was skipped, then redraws the game
+func (b *Bot) handleGameTimedOut(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.GameTimedOutPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	title := "⌛ Time's Up"
+	description := "Nobody rolled in time, so the game was abandoned."
+	if payload.EndedBy != "" {
+		title = "🏁 Game Wrapped Up"
+		description = fmt.Sprintf("<@%s> wrapped up the game, but nobody had rolled, so it was abandoned.", payload.EndedBy)
+	}
+	if !payload.Abandoned {
+		description = "The game was ended with the rolls made so far."
+		if payload.EndedBy != "" {
+			description = fmt.Sprintf("<@%s> wrapped up the game with the rolls made so far.", payload.EndedBy)
+		}
+		if len(payload.SkippedPlayerIDs) > 0 {
+			description += "\nSkipped: " + mentions(payload.SkippedPlayerIDs)
+		}
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       title,
+				Description: description,
+				Color:       0xE74C3C,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting game timed out message for game %s: %v", event.GameID, err)
+	}
+
+	b.updateGameMessage(b.api, event.ChannelID, payload.RootGameID)
+}
+
+// mentions formats players as Discord mentions separated by spaces
+func mentions(playerIDs []string) string {
+	formatted := make([]string, len(playerIDs))
+	for i, playerID := range playerIDs {
+		formatted[i] = "<@" + playerID + ">"
+	}
+	return strings.Join(formatted, " ")
+}
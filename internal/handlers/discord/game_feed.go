@@ -0,0 +1,288 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// gameFeedWebhookName is the name of the webhook the bot makes in a channel to post game messages under a persona
+const gameFeedWebhookName = "Ronnied Game Feed"
+
+// gameFeedRetryAfter is how long the bot waits before trying again to make a webhook in a channel it couldn't
+const gameFeedRetryAfter = time.Hour
+
+// gameFeed posts game messages through a channel webhook for servers that picked a persona, so they show its name
+// and avatar. Channels the bot can't make a webhook in get normal bot messages.
+type gameFeed struct {
+	guildConfigService guild_config.Service
+	clock              clock.Clock
+
+	mu sync.Mutex
+
+	// webhooks are the webhook the bot posts through in each channel
+	webhooks map[string]*discordgo.Webhook
+
+	// unavailable are the channels the bot couldn't make a webhook in, by when it last tried
+	unavailable map[string]time.Time
+
+	// messages are the webhook each game message was posted through, by message ID
+	messages map[string]*discordgo.Webhook
+}
+
+// newGameFeed creates the game feed, posting every game message as the bot when there's no guild config service
+func newGameFeed(guildConfigService guild_config.Service, clk clock.Clock) *gameFeed {
+	return &gameFeed{
+		guildConfigService: guildConfigService,
+		clock:              clk,
+		webhooks:           make(map[string]*discordgo.Webhook),
+		unavailable:        make(map[string]time.Time),
+		messages:           make(map[string]*discordgo.Webhook),
+	}
+}
+
+// send posts a game message under the server's persona, or as the bot when it has none or the channel can't have
+// a webhook
+func (f *gameFeed) send(ctx context.Context, s DiscordSession, guildID, channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	msg, err := f.post(ctx, s, guildID, channelID, data, options...)
+	if err != nil || msg != nil {
+		return msg, err
+	}
+	return s.ChannelMessageSendComplex(channelID, data, options...)
+}
+
+// post posts a game message under the server's persona, nil when it has none or the channel can't have a webhook
+func (f *gameFeed) post(ctx context.Context, s DiscordSession, guildID, channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	persona := f.persona(ctx, guildID)
+	if persona == nil {
+		return nil, nil
+	}
+
+	webhook := f.channelWebhook(s, channelID)
+	if webhook == nil {
+		return nil, nil
+	}
+
+	msg, err := s.WebhookExecute(webhook.ID, webhook.Token, true, &discordgo.WebhookParams{
+		Content:    data.Content,
+		Username:   persona.Name,
+		AvatarURL:  persona.AvatarURL,
+		Components: data.Components,
+		Embeds:     data.Embeds,
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.messages[msg.ID] = webhook
+	f.mu.Unlock()
+	return msg, nil
+}
+
+// edit edits a game message, through the webhook it was posted with when it went out under a persona
+func (f *gameFeed) edit(s DiscordSession, edit *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if f == nil {
+		return s.ChannelMessageEditComplex(edit, options...)
+	}
+
+	f.mu.Lock()
+	webhook := f.messages[edit.ID]
+	f.mu.Unlock()
+
+	if webhook == nil {
+		msg, err := s.ChannelMessageEditComplex(edit, options...)
+		if !isWebhookMessage(err) {
+			return msg, err
+		}
+
+		// The message was posted under a persona before the bot last started
+		webhook = f.channelWebhook(s, edit.Channel)
+		if webhook == nil {
+			return msg, err
+		}
+		f.mu.Lock()
+		f.messages[edit.ID] = webhook
+		f.mu.Unlock()
+	}
+
+	return s.WebhookMessageEdit(webhook.ID, webhook.Token, edit.ID, &discordgo.WebhookEdit{
+		Content:    edit.Content,
+		Components: &edit.Components,
+		Embeds:     &edit.Embeds,
+	}, options...)
+}
+
+// persona returns the name and avatar the server's game messages are posted under, nil when it hasn't picked one
+func (f *gameFeed) persona(ctx context.Context, guildID string) *models.GamePersona {
+	if f.guildConfigService == nil || guildID == "" {
+		return nil
+	}
+
+	output, err := f.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for the game feed: %v", err)
+		return nil
+	}
+
+	return output.Config.Persona
+}
+
+// channelWebhook returns the webhook the bot posts game messages through in a channel, making it the first time.
+// It's nil when the bot isn't allowed to make one there, such as without the Manage Webhooks permission or in a
+// thread.
+func (f *gameFeed) channelWebhook(s DiscordSession, channelID string) *discordgo.Webhook {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if webhook := f.webhooks[channelID]; webhook != nil {
+		return webhook
+	}
+	if triedAt, ok := f.unavailable[channelID]; ok && f.clock.Now().Sub(triedAt) < gameFeedRetryAfter {
+		return nil
+	}
+
+	webhook, err := findOrCreateGameFeedWebhook(s, channelID)
+	if err != nil {
+		log.Printf("Can't post game messages through a webhook in channel %s, posting them as the bot: %v", channelID, err)
+		f.unavailable[channelID] = f.clock.Now()
+		return nil
+	}
+
+	delete(f.unavailable, channelID)
+	f.webhooks[channelID] = webhook
+	return webhook
+}
+
+// findOrCreateGameFeedWebhook finds the webhook the bot made in a channel, or makes it
+func findOrCreateGameFeedWebhook(s DiscordSession, channelID string) (*discordgo.Webhook, error) {
+	webhooks, err := s.ChannelWebhooks(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Name == gameFeedWebhookName && webhook.Token != "" {
+			return webhook, nil
+		}
+	}
+
+	webhook, err := s.WebhookCreate(channelID, gameFeedWebhookName, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// isWebhookMessage reports whether Discord turned down an edit because someone else posted the message, as it does
+// for messages posted through a webhook
+func isWebhookMessage(err error) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeCannotEditFromAnotherUser
+}
+
+// personaCommand returns the subcommand for picking the name and avatar game messages are posted under
+func personaCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "persona",
+		Description: "See or change the name and avatar game messages are posted under",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "name",
+				Description: "The name to post under, e.g. Ronnie the Bartender",
+				MaxLength:   80,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "avatar",
+				Description: "A link to the picture to post with",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "reset",
+				Description: "Go back to posting as the bot",
+			},
+		},
+	}
+}
+
+// handlePersona shows the name and avatar game messages are posted under, or changes them
+func (c *RonniedCommand) handlePersona(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Personas are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "A persona can only be set up in a server.")
+	}
+
+	var persona models.GamePersona
+	var reset bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "name":
+			persona.Name = strings.TrimSpace(opt.StringValue())
+		case "avatar":
+			persona.AvatarURL = strings.TrimSpace(opt.StringValue())
+		case "reset":
+			reset = opt.BoolValue()
+		}
+	}
+
+	if persona.Name == "" && persona.AvatarURL == "" && !reset {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the persona: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, describePersona(output.Config.Persona))
+	}
+
+	input := &guild_config.SetPersonaInput{GuildID: i.GuildID}
+	if !reset {
+		if persona.Name == "" {
+			return RespondWithEphemeralMessage(s, i, "Give the persona a `name` to post under.")
+		}
+		input.Persona = &persona
+	}
+
+	output, err := c.guildConfigService.SetPersona(ctx, input)
+	if err != nil {
+		if errors.Is(err, guild_config.ErrInvalidPersona) {
+			return RespondWithEphemeralMessage(s, i, "Discord won't post under that. Names can't mention Discord or Clyde, and the avatar has to be an http(s) link.")
+		}
+		log.Printf("Error setting persona: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the persona: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, describePersona(output.Config.Persona))
+}
+
+// describePersona says who the server's game messages are posted as
+func describePersona(persona *models.GamePersona) string {
+	if persona == nil {
+		return "🤖 Game messages are posted as the bot."
+	}
+	return fmt.Sprintf("🎭 Game messages are posted as **%s**. Channels where the bot can't manage webhooks get them from the bot instead.", persona.Name)
+}
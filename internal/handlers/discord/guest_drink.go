@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// guestDrinkSelect returns the menu a player with a critical hit uses to offer their drink to someone watching
+func guestDrinkSelect(gameID string) discordgo.MessageComponent {
+	return discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				MenuType:    discordgo.UserSelectMenu,
+				CustomID:    SelectGuestDrinkPrefix + gameID,
+				Placeholder: "Or offer it to someone watching",
+			},
+		},
+	}
+}
+
+// guestRule is the game rules line for games played with guest drinks, empty for games without
+func guestRule(rules models.GameRules) string {
+	if !rules.GuestDrinks {
+		return ""
+	}
+	return "\n• Critical hits can go to someone watching, if they'll take it! 👀"
+}
+
+// handleGuestDrinkSelect offers the player's drink to the channel member they picked, who answers in the channel
+func (b *Bot) handleGuestDrinkSelect(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, gameID string) error {
+	ctx := context.Background()
+
+	data := i.MessageComponentData()
+	if len(data.Values) == 0 {
+		return RespondWithEphemeralMessage(s, i, "No one selected")
+	}
+	guestID := data.Values[0]
+
+	output, err := b.gameService.OfferGuestDrink(ctx, &game.OfferGuestDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: userID,
+		GuestID:      guestID,
+		GuestName:    resolvedComponentUserName(data, guestID),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrGuestIsPlaying):
+			return RespondWithEphemeralMessage(s, i, "They're playing, pick them from the players instead.")
+		case errors.Is(err, game.ErrNotEligibleToAssign):
+			return RespondWithEphemeralMessage(s, i, "You've already given out your drink.")
+		}
+		log.Printf("Error offering guest drink: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't offer the drink"))
+	}
+
+	answer := gameID + ":" + userID
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("<@%s>, <@%s> rolled a critical hit and wants you to have the drink, even though you're not playing. 🍺 Will you take it?",
+			guestID, userID),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Take it",
+						Style:    discordgo.SuccessButton,
+						CustomID: ButtonAcceptGuestDrinkPrefix + answer,
+					},
+					discordgo.Button{
+						Label:    "No thanks",
+						Style:    discordgo.SecondaryButton,
+						CustomID: ButtonDeclineGuestDrinkPrefix + answer,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error sending guest drink offer: %v", err)
+		return RespondWithEphemeralMessage(s, i, "Couldn't post the offer, try again.")
+	}
+
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Asked %s to take your drink. Until they answer you can still give it to a player.",
+		output.Offer.GuestName))
+}
+
+// handleAcceptGuestDrinkButton puts the drink on the guest's tab once they agree to take it
+func (b *Bot) handleAcceptGuestDrinkButton(s DiscordSession, i *discordgo.InteractionCreate, userID, answer string) error {
+	ctx := context.Background()
+
+	gameID, fromPlayerID, ok := strings.Cut(answer, ":")
+	if !ok {
+		return RespondWithError(s, i, "Invalid guest drink")
+	}
+
+	output, err := b.gameService.AcceptGuestDrink(ctx, &game.AcceptGuestDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: fromPlayerID,
+		GuestID:      userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotGuest):
+			return RespondWithEphemeralMessage(s, i, "Only the person who was asked can take this drink.")
+		case errors.Is(err, game.ErrGuestDrinkNotFound), errors.Is(err, game.ErrInvalidGameState):
+			return updateForgetMessage(s, i, "This drink has already gone to someone else.")
+		}
+		log.Printf("Error accepting guest drink: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't take the drink"))
+	}
+
+	b.refreshGameMessage(ctx, s, i.GuildID, i.ChannelID)
+
+	return updateForgetMessage(s, i, fmt.Sprintf("🍺 <@%s> took <@%s>'s drink from the sidelines.", output.Offer.GuestID, fromPlayerID))
+}
+
+// handleDeclineGuestDrinkButton turns down the drink, or calls it off when the player who offered it presses it
+func (b *Bot) handleDeclineGuestDrinkButton(s DiscordSession, i *discordgo.InteractionCreate, userID, answer string) error {
+	ctx := context.Background()
+
+	gameID, fromPlayerID, ok := strings.Cut(answer, ":")
+	if !ok {
+		return RespondWithError(s, i, "Invalid guest drink")
+	}
+
+	output, err := b.gameService.DeclineGuestDrink(ctx, &game.DeclineGuestDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: fromPlayerID,
+		PlayerID:     userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotGuest):
+			return RespondWithEphemeralMessage(s, i, "This drink isn't yours to turn down.")
+		case errors.Is(err, game.ErrGuestDrinkNotFound), errors.Is(err, game.ErrInvalidGameState):
+			return updateForgetMessage(s, i, "This drink has already gone to someone else.")
+		}
+		log.Printf("Error declining guest drink: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't turn down the drink"))
+	}
+
+	if userID == fromPlayerID {
+		return updateForgetMessage(s, i, fmt.Sprintf("<@%s> called off the drink offer.", userID))
+	}
+	return updateForgetMessage(s, i, fmt.Sprintf("<@%s> passed on <@%s>'s drink, it goes to a player instead.", output.Offer.GuestID, fromPlayerID))
+}
+
+// resolvedComponentUserName returns the server nickname or username of a user picked in a select menu, or their ID
+// when the interaction didn't include them
+func resolvedComponentUserName(data discordgo.MessageComponentInteractionData, userID string) string {
+	if member, ok := data.Resolved.Members[userID]; ok && member.Nick != "" {
+		return member.Nick
+	}
+	if user, ok := data.Resolved.Users[userID]; ok {
+		return user.Username
+	}
+	return userID
+}
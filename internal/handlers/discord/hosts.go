@@ -0,0 +1,188 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// hostCommand returns the subcommand the game's hosts run it with
+func hostCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "host",
+		Description: "Run the game in this channel, only its creator and co-hosts can",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "action",
+				Description: "What to do",
+				Required:    true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Make a player a co-host", Value: "cohost"},
+					{Name: "Take a co-host's powers away", Value: "uncohost"},
+					{Name: "Pause rolling", Value: "pause"},
+					{Name: "Resume rolling", Value: "resume"},
+					{Name: "Wrap up a stuck game", Value: "wrapup"},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "player",
+				Description: "The co-host, when adding or removing one",
+			},
+		},
+	}
+}
+
+// handleHost runs one of the host actions on the game in the channel
+func (c *RonniedCommand) handleHost(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID string) error {
+	ctx := context.Background()
+
+	var action string
+	var player *discordgo.User
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "action":
+			action = opt.StringValue()
+		case "player":
+			player = opt.UserValue(nil)
+		}
+	}
+
+	existingGame, err := c.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return RespondWithEphemeralMessage(s, i, "No game found in this channel. Use `/ronnied start` to create a new game.")
+		}
+		log.Printf("Error getting game: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Error getting game: %v", err))
+	}
+	gameID := existingGame.Game.ID
+
+	switch action {
+	case "cohost", "uncohost":
+		if player == nil {
+			return RespondWithEphemeralMessage(s, i, "Pick the player with the `player` option.")
+		}
+		return c.handleCoHost(ctx, s, i, gameID, userID, player.ID, action == "cohost")
+
+	case "pause":
+		output, err := c.gameService.PauseGame(ctx, &game.PauseGameInput{
+			GameID:   gameID,
+			PlayerID: userID,
+		})
+		if err != nil {
+			return respondWithHostError(s, i, err, "Couldn't pause the game")
+		}
+		return RespondWithEmbed(s, i, "⏸️ Game Paused",
+			fmt.Sprintf("<@%s> paused the game %s. Nobody can roll until a host resumes it.", userID, discordTimestamp(output.PausedAt, timestampRelative)),
+			nil)
+
+	case "resume":
+		output, err := c.gameService.ResumeGame(ctx, &game.ResumeGameInput{
+			GameID:   gameID,
+			PlayerID: userID,
+		})
+		if err != nil {
+			return respondWithHostError(s, i, err, "Couldn't resume the game")
+		}
+		return RespondWithEmbed(s, i, "▶️ Game Resumed",
+			fmt.Sprintf("<@%s> resumed the game after %s. Roll away!", userID, output.PausedFor.Round(time.Second)),
+			nil)
+
+	case "wrapup":
+		// The timed out game's message lets the channel know who was skipped
+		if _, err := c.gameService.WrapUpGame(ctx, &game.WrapUpGameInput{
+			GameID:   gameID,
+			PlayerID: userID,
+		}); err != nil {
+			return respondWithHostError(s, i, err, "Couldn't wrap up the game")
+		}
+		return RespondWithEphemeralMessage(s, i, "Game wrapped up with the rolls made so far.")
+	}
+
+	return errors.New("unknown host action")
+}
+
+// handleCoHost adds or removes one of the game's co-hosts
+func (c *RonniedCommand) handleCoHost(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, gameID, userID, coHostID string, add bool) error {
+	var coHostIDs []string
+	if add {
+		output, err := c.gameService.AddCoHost(ctx, &game.AddCoHostInput{
+			GameID:   gameID,
+			PlayerID: userID,
+			CoHostID: coHostID,
+		})
+		if err != nil {
+			if errors.Is(err, game.ErrPlayerNotInGame) {
+				return RespondWithEphemeralMessage(s, i, "Co-hosts have to be playing in the game.")
+			}
+			return respondWithHostError(s, i, err, "Couldn't add the co-host")
+		}
+		coHostIDs = output.CoHostIDs
+	} else {
+		output, err := c.gameService.RemoveCoHost(ctx, &game.RemoveCoHostInput{
+			GameID:   gameID,
+			PlayerID: userID,
+			CoHostID: coHostID,
+		})
+		if err != nil {
+			return respondWithHostError(s, i, err, "Couldn't remove the co-host")
+		}
+		coHostIDs = output.CoHostIDs
+	}
+
+	description := "The game has no co-hosts."
+	if len(coHostIDs) > 0 {
+		description = "Co-hosts: " + mentionList(coHostIDs)
+	}
+	return RespondWithEmbed(s, i, "🎩 Co-Hosts", description, nil)
+}
+
+// respondWithHostError explains why a host action failed
+func respondWithHostError(s DiscordSession, i *discordgo.InteractionCreate, err error, fallback string) error {
+	switch {
+	case errors.Is(err, game.ErrNotCreator):
+		return RespondWithEphemeralMessage(s, i, "Only the game's creator can choose co-hosts, and only its hosts can pause, resume or wrap it up.")
+	case errors.Is(err, game.ErrGamePaused):
+		return RespondWithEphemeralMessage(s, i, "The game is already paused.")
+	case errors.Is(err, game.ErrGameNotPaused):
+		return RespondWithEphemeralMessage(s, i, "The game isn't paused.")
+	case errors.Is(err, game.ErrGameNotStarted):
+		return RespondWithEphemeralMessage(s, i, "The game hasn't started yet.")
+	case errors.Is(err, game.ErrInvalidGameState):
+		return RespondWithEphemeralMessage(s, i, "The game is already over.")
+	}
+
+	log.Printf("%s: %v", fallback, err)
+	return RespondWithError(s, i, fmt.Sprintf("%s: %v", fallback, err))
+}
+
+// hostsField returns the game message field for a game's co-hosts and pause, or nil when it has neither
+func hostsField(g *models.Game) *discordgo.MessageEmbedField {
+	if len(g.CoHostIDs) == 0 && !g.IsPaused() {
+		return nil
+	}
+
+	value := "Co-hosts: " + mentionList(g.CoHostIDs)
+	if g.IsPaused() {
+		value = fmt.Sprintf("⏸️ Paused by <@%s> %s, nobody can roll until a host resumes the game.", g.PausedBy, discordTimestamp(*g.PausedAt, timestampRelative))
+		if len(g.CoHostIDs) > 0 {
+			value += "\nCo-hosts: " + mentionList(g.CoHostIDs)
+		}
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "🎩 Hosts",
+		Value: value,
+	}
+}
@@ -0,0 +1,187 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// hotSeatNamesInput is the ID of the hot seat modal's text input
+const hotSeatNamesInput = "names"
+
+// hotSeatButton returns the button for sitting players without the bot down at a game waiting to start
+func hotSeatButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Hot Seat",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonHotSeat,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🪑",
+		},
+	}
+}
+
+// rollHotSeatButton returns the button for rolling for the next of the player's hot seat players
+func rollHotSeatButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Roll for the Table",
+		Style:    discordgo.PrimaryButton,
+		CustomID: ButtonRollHotSeat,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🪑",
+		},
+	}
+}
+
+// assignDrinkSelectID returns the ID of the menu a player picks who drinks their critical hit's drink from, which
+// carries the hot seat player it's for
+func assignDrinkSelectID(playerID string) string {
+	if models.IsHotSeatPlayer(playerID) {
+		return SelectAssignDrinkForPrefix + playerID
+	}
+	return SelectAssignDrink
+}
+
+// hotSeatField lists who rolls for the game's hot seat players, nil when there aren't any
+func hotSeatField(g *models.Game) *discordgo.MessageEmbedField {
+	tables := make(map[string][]string)
+	var hostIDs []string
+	for _, participant := range g.Participants {
+		hostID := models.HotSeatHost(participant.PlayerID)
+		if hostID == "" {
+			continue
+		}
+		if tables[hostID] == nil {
+			hostIDs = append(hostIDs, hostID)
+		}
+		tables[hostID] = append(tables[hostID], isolateName(participant.PlayerName))
+	}
+	if len(hostIDs) == 0 {
+		return nil
+	}
+
+	var lines strings.Builder
+	for _, hostID := range hostIDs {
+		fmt.Fprintf(&lines, "<@%s> rolls for %s\n", hostID, strings.Join(tables[hostID], ", "))
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  "🪑 Hot Seat",
+		Value: lines.String(),
+	}
+}
+
+// handleHotSeatButton asks a player in a game waiting to start for the names of the people at their table
+func (b *Bot) handleHotSeatButton(s DiscordSession, i *discordgo.InteractionCreate, channelID string) error {
+	existingGame, err := b.gameService.GetActiveGameByChannel(context.Background(), &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, "No game found in this channel.")
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: ModalHotSeatPrefix + existingGame.Game.ID,
+			Title:    "Hot Seat",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    hotSeatNamesInput,
+							Label:       "Who's at your table? One name per line",
+							Style:       discordgo.TextInputParagraph,
+							Placeholder: "Dan\nErin",
+							Required:    true,
+							MaxLength:   500,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleHotSeatModal sits the people named in the hot seat modal down at the game, for the player who sent it to
+// roll for
+func (b *Bot) handleHotSeatModal(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, gameID string) error {
+	ctx := context.Background()
+
+	var names []string
+	for _, row := range i.ModalSubmitData().Components {
+		actionsRow, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, component := range actionsRow.Components {
+			if input, ok := component.(*discordgo.TextInput); ok && input.CustomID == hotSeatNamesInput {
+				for _, name := range strings.Split(input.Value, "\n") {
+					if name = strings.TrimSpace(name); name != "" {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+	}
+
+	output, err := b.gameService.AddHotSeatPlayers(ctx, &game.AddHotSeatPlayersInput{
+		GameID:      gameID,
+		HostID:      userID,
+		PlayerNames: names,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrPlayerNotInGame) {
+			return RespondWithEphemeralMessage(s, i, "Join the game first, then sit your table down with you.")
+		}
+		log.Printf("Error adding hot seat players: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't sit your table down"))
+	}
+
+	b.updateGameMessage(s, channelID, gameID)
+
+	if len(output.Added) == 0 {
+		return RespondWithEphemeralMessage(s, i, "Everyone you named is already at the table.")
+	}
+	added := make([]string, len(output.Added))
+	for n, participant := range output.Added {
+		added[n] = isolateName(participant.PlayerName)
+	}
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf("🪑 %s sat down. Once the game starts, press **Roll for the Table** to roll for each of them in turn.",
+		strings.Join(added, ", ")))
+}
+
+// handleRollHotSeatButton rolls for the next of the player's hot seat players, showing the result to the whole table
+func (b *Bot) handleRollHotSeatButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, "No active game found in this channel.")
+	}
+
+	rollOutput, err := b.gameService.RollHotSeat(ctx, &game.RollHotSeatInput{
+		GameID: existingGame.Game.ID,
+		HostID: userID,
+	})
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to roll for your table"))
+	}
+
+	// The result is posted for everyone, not just the player rolling
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		log.Printf("Error acknowledging interaction: %v", err)
+		return err
+	}
+
+	return b.showRollResult(ctx, s, i, channelID, existingGame.Game, rollOutput)
+}
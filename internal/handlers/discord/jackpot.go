@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// jackpotRule is the game rules line for games played for the jackpot, empty for games without
+func jackpotRule(rules models.GameRules) string {
+	if !rules.Jackpot {
+		return ""
+	}
+	return "\n• Ties and doubles put a drink in the jackpot, the next critical fail drinks the lot! 💰"
+}
+
+// jackpotLine shows what's in the session's jackpot, empty while it's empty
+func jackpotLine(session *models.Session) string {
+	if session == nil || session.Jackpot == 0 {
+		return ""
+	}
+	return fmt.Sprintf("💰 **Jackpot:** %d for the next critical fail\n", session.Jackpot)
+}
+
+// handleJackpot announces a drink going in the session's jackpot, or a critical fail taking all of it
+func (b *Bot) handleJackpot(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.JackpotPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "💰 The Jackpot Grows",
+		Description: fmt.Sprintf("A tie! A drink goes in the jackpot, that's **%d** waiting for the next critical fail.", payload.Drinks),
+		Color:       0xF1C40F,
+	}
+	switch {
+	case payload.Won:
+		embed.Title = "💰💀 JACKPOT! 💀💰"
+		embed.Description = fmt.Sprintf("**%s** rolled a critical fail and drinks the whole jackpot: **%d** drinks! The pot starts again from nothing.",
+			isolateName(payload.PlayerName), payload.Drinks)
+		embed.Color = 0xE74C3C
+	case payload.PlayerID != "":
+		embed.Description = fmt.Sprintf("**%s** rolled doubles! A drink goes in the jackpot, that's **%d** waiting for the next critical fail.",
+			isolateName(payload.PlayerName), payload.Drinks)
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	if err != nil {
+		log.Printf("Error announcing the jackpot in channel %s: %v", event.ChannelID, err)
+	}
+}
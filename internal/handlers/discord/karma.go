@@ -0,0 +1,26 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// karmaRule is the game rules line for games played with karma, empty for games without
+func karmaRule(rules models.GameRules) string {
+	if !rules.Karma {
+		return ""
+	}
+	return "\n• Keep giving your drinks to the same player and karma makes you drink too! 😈"
+}
+
+// formatKarma shows a player's karma, empty while they haven't spread their drinks either way
+func formatKarma(score int) string {
+	switch {
+	case score > 0:
+		return fmt.Sprintf("😇 +%d karma", score)
+	case score < 0:
+		return fmt.Sprintf("😈 %d karma", score)
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// lastCallCommand returns the subcommand for calling last call on the session
+func lastCallCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "lastcall",
+		Description: "One final game with drinks counting extra, then the session closes",
+	}
+}
+
+// handleLastCall puts the session into last call and announces it to the channel
+func (c *RonniedCommand) handleLastCall(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	output, err := c.gameService.LastCall(ctx, &game.LastCallInput{
+		ChannelID: channelID,
+		GuildID:   i.GuildID,
+		PlayerID:  userID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrLastCallAlreadyCalled) {
+			return RespondWithEphemeralMessage(s, i, "Last call has already been called, finish your drinks!")
+		}
+		log.Printf("Error calling last call: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Failed to call last call: %v", err))
+	}
+
+	finalGame := "The next game is the last one of the session."
+	if output.GameID != "" {
+		finalGame = "The game in progress is the last one of the session."
+	}
+
+	return RespondWithEmbed(s, i, "🔔 Last Call!",
+		fmt.Sprintf("<@%s> called last call. %s Drinks count x%s, and the session closes with its final standings when the game ends.",
+			userID, finalGame, strconv.FormatFloat(output.Multiplier, 'f', -1, 64)),
+		nil)
+}
+
+// lastCallField returns the game message field for a session's final game, or nil for other games
+func lastCallField(lastCall bool) *discordgo.MessageEmbedField {
+	if !lastCall {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "🔔 Last Call",
+		Value: "Final game of the session: drinks count extra, and the session closes when it ends.",
+	}
+}
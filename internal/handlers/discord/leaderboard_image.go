@@ -0,0 +1,281 @@
+package discord
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/bwmarrin/discordgo"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// leaderboardImageName is the file name the leaderboard image is attached under
+	leaderboardImageName = "leaderboard.png"
+
+	// maxLeaderboardImageRows is how many players the leaderboard image has room for
+	maxLeaderboardImageRows = 10
+
+	leaderboardImageWidth = 640
+	leaderboardPadding    = 20
+	leaderboardHeader     = 56
+	leaderboardRowHeight  = 52
+	leaderboardAvatarSize = 40
+	leaderboardNameX      = 112
+	leaderboardBarX       = 300
+	leaderboardBarWidth   = 220
+	leaderboardBarHeight  = 20
+	maxLeaderboardNameLen = 22
+)
+
+var (
+	leaderboardBackground = color.RGBA{0x2F, 0x31, 0x36, 0xFF}
+	leaderboardRowShade   = color.RGBA{0x36, 0x39, 0x3F, 0xFF}
+	leaderboardText       = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	leaderboardSubtleText = color.RGBA{0xB9, 0xBB, 0xBE, 0xFF}
+	leaderboardDrinkBar   = color.RGBA{0x9B, 0x59, 0xB6, 0xFF}
+	leaderboardPaidBar    = color.RGBA{0x2E, 0xCC, 0x71, 0xFF}
+	leaderboardBarTrack   = color.RGBA{0x20, 0x22, 0x25, 0xFF}
+	leaderboardRankColors = []color.RGBA{
+		{0xF1, 0xC4, 0x0F, 0xFF}, // gold
+		{0xBD, 0xC3, 0xC7, 0xFF}, // silver
+		{0xCD, 0x7F, 0x32, 0xFF}, // bronze
+	}
+)
+
+// avatarClient downloads player avatars for the leaderboard image
+var avatarClient = &http.Client{Timeout: 5 * time.Second}
+
+// leaderboardImageFile renders a session's standings as a PNG ready to attach to a message
+func (b *Bot) leaderboardImageFile(standings []*events.SessionStanding) (*discordgo.File, error) {
+	if len(standings) > maxLeaderboardImageRows {
+		standings = standings[:maxLeaderboardImageRows]
+	}
+
+	data, err := renderLeaderboardImage(standings, b.fetchAvatars(standings))
+	if err != nil {
+		return nil, err
+	}
+
+	return &discordgo.File{
+		Name:        leaderboardImageName,
+		ContentType: "image/png",
+		Reader:      bytes.NewReader(data),
+	}, nil
+}
+
+// fetchAvatars downloads the avatars of the given players, skipping any that can't be fetched
+func (b *Bot) fetchAvatars(standings []*events.SessionStanding) map[string]image.Image {
+	avatars := make(map[string]image.Image, len(standings))
+	for _, standing := range standings {
+		user, err := b.api.User(standing.PlayerID)
+		if err != nil {
+			continue
+		}
+
+		avatar, err := downloadAvatar(user.AvatarURL("64"))
+		if err != nil {
+			log.Printf("Error downloading avatar for player %s: %v", standing.PlayerID, err)
+			continue
+		}
+		avatars[standing.PlayerID] = avatar
+	}
+	return avatars
+}
+
+// downloadAvatar fetches and decodes an avatar image
+func downloadAvatar(url string) (image.Image, error) {
+	resp, err := avatarClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	avatar, _, err := image.Decode(resp.Body)
+	return avatar, err
+}
+
+// renderLeaderboardImage draws the standings as a PNG with an avatar and a drinks bar per player.
+// Players without an avatar get a colored circle with their initial.
+func renderLeaderboardImage(standings []*events.SessionStanding, avatars map[string]image.Image) ([]byte, error) {
+	height := leaderboardHeader + len(standings)*leaderboardRowHeight + leaderboardPadding
+	img := image.NewRGBA(image.Rect(0, 0, leaderboardImageWidth, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(leaderboardBackground), image.Point{}, draw.Src)
+
+	drawText(img, leaderboardPadding, 38, "Final Standings", leaderboardText, 2)
+
+	maxDrinks := 0
+	for _, standing := range standings {
+		if standing.DrinkCount > maxDrinks {
+			maxDrinks = standing.DrinkCount
+		}
+	}
+
+	for i, standing := range standings {
+		top := leaderboardHeader + i*leaderboardRowHeight
+		if i%2 == 0 {
+			row := image.Rect(0, top, leaderboardImageWidth, top+leaderboardRowHeight)
+			draw.Draw(img, row, image.NewUniform(leaderboardRowShade), image.Point{}, draw.Src)
+		}
+		middle := top + leaderboardRowHeight/2
+
+		rankColor := leaderboardSubtleText
+		if i < len(leaderboardRankColors) {
+			rankColor = leaderboardRankColors[i]
+		}
+		drawText(img, leaderboardPadding, middle+8, fmt.Sprintf("#%d", i+1), rankColor, 2)
+
+		avatarAt := image.Pt(leaderboardNameX-leaderboardAvatarSize-12, middle-leaderboardAvatarSize/2)
+		drawAvatar(img, avatarAt, avatars[standing.PlayerID], standing)
+
+		name := standing.PlayerName
+		if standing.DesignatedDriver {
+			name += " (driver)"
+		}
+		drawText(img, leaderboardNameX, middle+4, truncateName(name), leaderboardText, 1)
+
+		drawDrinkBar(img, middle, standing, maxDrinks)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode leaderboard image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawDrinkBar draws a player's drinks as a bar scaled to the most drinks taken, with the paid part in green
+func drawDrinkBar(img *image.RGBA, middle int, standing *events.SessionStanding, maxDrinks int) {
+	top := middle - leaderboardBarHeight/2
+	track := image.Rect(leaderboardBarX, top, leaderboardBarX+leaderboardBarWidth, top+leaderboardBarHeight)
+	draw.Draw(img, track, image.NewUniform(leaderboardBarTrack), image.Point{}, draw.Src)
+
+	if maxDrinks > 0 && standing.DrinkCount > 0 {
+		width := leaderboardBarWidth * standing.DrinkCount / maxDrinks
+		bar := image.Rect(leaderboardBarX, top, leaderboardBarX+width, top+leaderboardBarHeight)
+		draw.Draw(img, bar, image.NewUniform(leaderboardDrinkBar), image.Point{}, draw.Src)
+
+		paid := min(standing.PaidCount, standing.DrinkCount)
+		if paid > 0 {
+			paidBar := image.Rect(leaderboardBarX, top, leaderboardBarX+width*paid/standing.DrinkCount, top+leaderboardBarHeight)
+			draw.Draw(img, paidBar, image.NewUniform(leaderboardPaidBar), image.Point{}, draw.Src)
+		}
+	}
+
+	label := fmt.Sprintf("%d (%d paid)", standing.DrinkCount, standing.PaidCount)
+	drawText(img, leaderboardBarX+leaderboardBarWidth+10, middle+4, label, leaderboardSubtleText, 1)
+}
+
+// drawAvatar draws a player's avatar clipped to a circle, or their initial when there's no avatar
+func drawAvatar(img *image.RGBA, at image.Point, avatar image.Image, standing *events.SessionStanding) {
+	tile := image.NewRGBA(image.Rect(0, 0, leaderboardAvatarSize, leaderboardAvatarSize))
+	if avatar != nil {
+		xdraw.CatmullRom.Scale(tile, tile.Bounds(), avatar, avatar.Bounds(), xdraw.Src, nil)
+	} else {
+		draw.Draw(tile, tile.Bounds(), image.NewUniform(placeholderColor(standing.PlayerID)), image.Point{}, draw.Src)
+		drawText(tile, leaderboardAvatarSize/2-7, leaderboardAvatarSize/2+9, initial(standing.PlayerName), leaderboardText, 2)
+	}
+
+	dest := image.Rectangle{Min: at, Max: at.Add(tile.Bounds().Size())}
+	draw.DrawMask(img, dest, tile, image.Point{}, &circleMask{radius: leaderboardAvatarSize / 2}, image.Point{}, draw.Over)
+}
+
+// drawText draws text with its baseline at y, scaling the built-in bitmap font up for larger text
+func drawText(img *image.RGBA, x, y int, text string, c color.Color, scale int) {
+	face := basicfont.Face7x13
+	if scale <= 1 {
+		drawer := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+		drawer.DrawString(text)
+		return
+	}
+
+	width := font.MeasureString(face, text).Ceil()
+	height := face.Metrics().Height.Ceil()
+	ascent := face.Metrics().Ascent.Ceil()
+
+	small := image.NewRGBA(image.Rect(0, 0, width, height))
+	drawer := &font.Drawer{Dst: small, Src: image.NewUniform(c), Face: face, Dot: fixed.P(0, ascent)}
+	drawer.DrawString(text)
+
+	top := y - ascent*scale
+	dest := image.Rect(x, top, x+width*scale, top+height*scale)
+	xdraw.NearestNeighbor.Scale(img, dest, small, small.Bounds(), xdraw.Over, nil)
+}
+
+// truncateName shortens a name to fit next to the drinks bar
+func truncateName(name string) string {
+	runes := []rune(name)
+	if len(runes) <= maxLeaderboardNameLen {
+		return name
+	}
+	return string(runes[:maxLeaderboardNameLen-3]) + "..."
+}
+
+// initial returns the upper-cased first letter or digit of a name for its avatar placeholder
+func initial(name string) string {
+	for _, r := range name {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return strings.ToUpper(string(r))
+		}
+	}
+	return "?"
+}
+
+// placeholderColor picks a stable color for a player's avatar placeholder
+func placeholderColor(playerID string) color.RGBA {
+	palette := []color.RGBA{
+		{0x58, 0x65, 0xF2, 0xFF},
+		{0xE6, 0x7E, 0x22, 0xFF},
+		{0x1A, 0xBC, 0x9C, 0xFF},
+		{0xE9, 0x1E, 0x63, 0xFF},
+		{0x34, 0x98, 0xDB, 0xFF},
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(playerID))
+	return palette[hash.Sum32()%uint32(len(palette))]
+}
+
+// circleMask is an image mask that's opaque inside a circle
+type circleMask struct {
+	radius int
+}
+
+// ColorModel implements image.Image
+func (m *circleMask) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+// Bounds implements image.Image
+func (m *circleMask) Bounds() image.Rectangle {
+	return image.Rect(0, 0, 2*m.radius, 2*m.radius)
+}
+
+// At implements image.Image
+func (m *circleMask) At(x, y int) color.Color {
+	dx := float64(x-m.radius) + 0.5
+	dy := float64(y-m.radius) + 0.5
+	if dx*dx+dy*dy <= float64(m.radius*m.radius) {
+		return color.Alpha{A: 0xFF}
+	}
+	return color.Alpha{}
+}
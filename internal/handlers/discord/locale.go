@@ -0,0 +1,99 @@
+package discord
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// commaDecimalLocales are the Discord locales whose numbers are written with a decimal comma, e.g. 1,5 rather than 1.5
+var commaDecimalLocales = map[discordgo.Locale]bool{
+	discordgo.Bulgarian:    true,
+	discordgo.Croatian:     true,
+	discordgo.Czech:        true,
+	discordgo.Danish:       true,
+	discordgo.Dutch:        true,
+	discordgo.Finnish:      true,
+	discordgo.French:       true,
+	discordgo.German:       true,
+	discordgo.Greek:        true,
+	discordgo.Hungarian:    true,
+	discordgo.Italian:      true,
+	discordgo.Lithuanian:   true,
+	discordgo.Norwegian:    true,
+	discordgo.Polish:       true,
+	discordgo.PortugueseBR: true,
+	discordgo.Romanian:     true,
+	discordgo.Russian:      true,
+	discordgo.SpanishES:    true,
+	discordgo.Swedish:      true,
+	discordgo.Turkish:      true,
+	discordgo.Ukrainian:    true,
+	discordgo.Vietnamese:   true,
+}
+
+// rightToLeftScripts are the scripts written right to left that can turn up in player names
+var rightToLeftScripts = []*unicode.RangeTable{unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko}
+
+// guildLocales remembers each server's preferred locale, so messages posted without an interaction to go on,
+// such as closing leaderboards, are written for it too
+type guildLocales struct {
+	mu      sync.Mutex
+	locales map[string]discordgo.Locale
+}
+
+// newGuildLocales creates an empty set of server locales
+func newGuildLocales() *guildLocales {
+	return &guildLocales{
+		locales: make(map[string]discordgo.Locale),
+	}
+}
+
+// remember keeps a server's preferred locale
+func (l *guildLocales) remember(guildID string, locale discordgo.Locale) {
+	if guildID == "" || locale == discordgo.Unknown {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.locales[guildID] = locale
+}
+
+// get returns a server's preferred locale, unknown until the bot has heard it
+func (l *guildLocales) get(guildID string) discordgo.Locale {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.locales[guildID]
+}
+
+// interactionLocale returns the preferred locale of the server an interaction came from, unknown outside servers
+func interactionLocale(i *discordgo.InteractionCreate) discordgo.Locale {
+	if i.GuildLocale == nil {
+		return discordgo.Unknown
+	}
+	return *i.GuildLocale
+}
+
+// formatDecimal writes a number with the given number of decimal places the way the locale writes them
+func formatDecimal(locale discordgo.Locale, value float64, places int) string {
+	formatted := strconv.FormatFloat(value, 'f', places, 64)
+	if commaDecimalLocales[locale] {
+		return strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
+
+// isolateName keeps a name written right to left from reordering the text around it, names written left to right
+// are left as they are
+func isolateName(name string) string {
+	for _, r := range name {
+		if unicode.In(r, rightToLeftScripts...) {
+			return "\u2068" + name + "\u2069"
+		}
+	}
+	return name
+}
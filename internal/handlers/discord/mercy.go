@@ -0,0 +1,41 @@
+package discord
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleMercyRule lets the channel know a player's drink was made social by the mercy rule
+func (b *Bot) handleMercyRule(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.MercyRulePayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	output, err := b.messagingService.GetMercyRuleMessage(ctx, &messaging.GetMercyRuleMessageInput{
+		PlayerName: payload.PlayerName,
+		DrinkCap:   payload.DrinkCap,
+	})
+	if err != nil {
+		log.Printf("Error getting mercy rule message: %v", err)
+		return
+	}
+
+	_, err = b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       output.Title,
+				Description: output.Message,
+				Color:       0x3498DB,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting mercy rule message for %s: %v", payload.PlayerID, err)
+	}
+}
@@ -0,0 +1,67 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// mergeCommand returns the admin subcommand for merging an alt account into a player
+func mergeCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "merge",
+		Description: "Merge an alt account's drinks in this server into a player (admins only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "alt",
+				Description: "The alt account to merge",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "into",
+				Description: "The player who keeps the drinks",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// handleMerge handles the merge subcommand
+func (c *RonniedCommand) handleMerge(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	options := make(map[string]string)
+	for _, opt := range subcommand.Options {
+		options[opt.Name] = opt.UserValue(nil).ID
+	}
+
+	output, err := c.gameService.MergePlayers(ctx, &game.MergePlayersInput{
+		CanonicalPlayerID: options["into"],
+		AliasPlayerID:     options["alt"],
+		GuildID:           i.GuildID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrCannotMergeSelf):
+			return RespondWithEphemeralMessage(s, i, "Pick two different accounts to merge.")
+		case errors.Is(err, game.ErrPlayerNotFound):
+			return RespondWithEphemeralMessage(s, i, "Both accounts need to have played before they can be merged.")
+		case errors.Is(err, game.ErrPlayerAlreadyMerged):
+			return RespondWithEphemeralMessage(s, i, "One of those accounts has already been merged.")
+		case errors.Is(err, game.ErrMergePlayerInGame):
+			return RespondWithEphemeralMessage(s, i, "The alt account is in a game. Merge it once the game is over.")
+		}
+		log.Printf("Error merging players: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't merge players: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Merged **%s** into **%s**, %d drink records from this server moved.",
+		output.Alias.Name, output.Canonical.Name, output.RecordsMoved))
+}
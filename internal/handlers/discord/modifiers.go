@@ -0,0 +1,30 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// rollModifiersEmbed returns an embed listing the modifiers applied to a roll, or nil if there were none
+func rollModifiersEmbed(modifiers []*models.RollModifier) *discordgo.MessageEmbed {
+	if len(modifiers) == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "✨ Modifiers applied",
+		Description: modifierLabels(modifiers, "\n"),
+		Color:       0x9B59B6, // Purple for buffs and debuffs
+	}
+}
+
+// modifierLabels joins the labels of the given modifiers
+func modifierLabels(modifiers []*models.RollModifier, sep string) string {
+	labels := make([]string, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		labels = append(labels, modifier.Label())
+	}
+	return strings.Join(labels, sep)
+}
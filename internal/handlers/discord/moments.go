@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// momentsPageSize is how many moments each page of the moments command shows
+const momentsPageSize = 10
+
+// clipCommand returns the subcommand for keeping the latest roll as a moment
+func clipCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "clip",
+		Description: "Keep the latest roll in this channel as a moment to look back on",
+	}
+}
+
+// momentsCommand returns the subcommand for browsing clipped moments
+func momentsCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "moments",
+		Description: "Look back on the moments clipped in this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "page",
+				Description: "The page of moments to show, newest first",
+			},
+		},
+	}
+}
+
+// handleClip clips the latest roll in the channel and shows it off
+func (c *RonniedCommand) handleClip(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, username string) error {
+	ctx := context.Background()
+
+	output, err := c.gameService.ClipMoment(ctx, &game.ClipMomentInput{
+		ChannelID:  channelID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrMomentsDisabled):
+			return RespondWithEphemeralMessage(s, i, "Moments are not enabled on this bot.")
+		case errors.Is(err, game.ErrNothingToClip):
+			return RespondWithEphemeralMessage(s, i, "Nobody has rolled here yet, there's nothing to clip.")
+		case errors.Is(err, game.ErrAlreadyClipped):
+			return RespondWithEphemeralMessage(s, i, "Someone beat you to it, that roll is already clipped.")
+		}
+		log.Printf("Error clipping moment: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't clip that: %v", err))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "🎬 Moment clipped",
+					Description: formatMoment(output.Moment),
+					Color:       0x9B59B6,
+				},
+			},
+		},
+	})
+}
+
+// handleMoments shows a page of the server's clipped moments
+func (c *RonniedCommand) handleMoments(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID string) error {
+	ctx := context.Background()
+
+	page := 1
+	for _, opt := range subcommand.Options {
+		if opt.Name == "page" {
+			page = int(opt.IntValue())
+		}
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	output, err := c.gameService.GetMoments(ctx, &game.GetMomentsInput{
+		GuildID:   i.GuildID,
+		ChannelID: channelID,
+		Offset:    (page - 1) * momentsPageSize,
+		Limit:     momentsPageSize,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrMomentsDisabled) {
+			return RespondWithEphemeralMessage(s, i, "Moments are not enabled on this bot.")
+		}
+		log.Printf("Error getting moments: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get the moments: %v", err))
+	}
+
+	if output.Total == 0 {
+		return RespondWithEphemeralMessage(s, i, "No moments yet. Use `/ronnied clip` right after a roll worth remembering.")
+	}
+
+	pageCount := (output.Total + momentsPageSize - 1) / momentsPageSize
+	if len(output.Moments) == 0 {
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("There are only %d pages of moments.", pageCount))
+	}
+
+	lines := make([]string, 0, len(output.Moments))
+	for _, moment := range output.Moments {
+		lines = append(lines, formatMoment(moment))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "🎬 Moments",
+					Description: strings.Join(lines, "\n\n"),
+					Color:       0x9B59B6,
+					Footer: &discordgo.MessageEmbedFooter{
+						Text: fmt.Sprintf("Page %d of %d · %d moments", page, pageCount, output.Total),
+					},
+				},
+			},
+		},
+	})
+}
+
+// formatMoment describes a moment, what was rolled and who thought it was worth keeping
+func formatMoment(moment *models.Moment) string {
+	var line string
+	switch {
+	case moment.IsCriticalHit:
+		line = fmt.Sprintf("🔥 **%s** rolled a critical %d", moment.PlayerName, moment.RollValue)
+	case moment.IsCriticalFail:
+		line = fmt.Sprintf("💀 **%s** rolled a tragic %d", moment.PlayerName, moment.RollValue)
+	default:
+		line = fmt.Sprintf("🎲 **%s** rolled a %d", moment.PlayerName, moment.RollValue)
+	}
+	if moment.IsRollOff {
+		line += " in a roll-off"
+	}
+
+	switch moment.DrinkCount {
+	case 0:
+		line += ", still dry"
+	case 1:
+		line += ", their first drink of the game"
+	default:
+		line += fmt.Sprintf(", %d drinks deep", moment.DrinkCount)
+	}
+
+	line += fmt.Sprintf("\n%s · clipped by %s", discordTimestamp(moment.RolledAt, timestampRelative), moment.ClippedByName)
+	return line
+}
@@ -0,0 +1,36 @@
+package discord
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleGuildMemberUpdate updates a member's saved name when they change their nickname, redrawing the game
+// they're playing so it shows the new one
+func (b *Bot) handleGuildMemberUpdate(m *discordgo.GuildMemberUpdate) {
+	if m.Member == nil || m.User == nil {
+		return
+	}
+
+	name := m.User.Username
+	if m.Nick != "" {
+		name = m.Nick
+	}
+
+	output, err := b.gameService.RenamePlayer(context.Background(), &game.RenamePlayerInput{
+		GuildID:    m.GuildID,
+		PlayerID:   m.User.ID,
+		PlayerName: name,
+	})
+	if err != nil {
+		log.Printf("Error renaming %s in guild %s: %v", m.User.ID, m.GuildID, err)
+		return
+	}
+
+	if output.GameID != "" {
+		b.updateGameMessage(b.api, output.ChannelID, output.GameID)
+	}
+}
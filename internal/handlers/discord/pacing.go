@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// handlePacingWarning posts a gentle reminder when a player is paying drinks too quickly
+func (b *Bot) handlePacingWarning(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.PacingWarningPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	output, err := b.messagingService.GetPacingMessage(ctx, &messaging.GetPacingMessageInput{
+		PlayerName:      payload.PlayerName,
+		DrinksPaid:      payload.DrinksPaid,
+		WindowMinutes:   payload.WindowMinutes,
+		CooldownMinutes: payload.CooldownMinutes,
+	})
+	if err != nil {
+		log.Printf("Error getting pacing message: %v", err)
+		return
+	}
+
+	_, err = b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       output.Title,
+				Description: output.Message,
+				Color:       0x1ABC9C,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting pacing message for %s: %v", payload.PlayerID, err)
+	}
+}
@@ -0,0 +1,234 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// pinboardCommand returns the subcommand for keeping a pinned session leaderboard in the channel
+func pinboardCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "pinboard",
+		Description: "Keep a pinned session leaderboard in this channel, updated as drinks change (admins only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "enabled",
+				Description: "Pin the leaderboard here, or stop updating it",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// handlePinboard pins a session leaderboard the bot keeps up to date in the channel, or stops keeping it.
+// A server has one pinned leaderboard, pinning it in another channel moves it.
+func (c *RonniedCommand) handlePinboard(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Pinned leaderboards are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Pinned leaderboards can only be set up in a server.")
+	}
+
+	var enabled bool
+	for _, opt := range subcommand.Options {
+		if opt.Name == "enabled" {
+			enabled = opt.BoolValue()
+		}
+	}
+
+	config, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get the pinned leaderboard: %v", err))
+	}
+	previous := config.Config.PinnedLeaderboard
+
+	if !enabled {
+		if previous == nil {
+			return RespondWithEphemeralMessage(s, i, "There's no pinned leaderboard to stop updating.")
+		}
+
+		if _, err := c.guildConfigService.SetPinnedLeaderboard(ctx, &guild_config.SetPinnedLeaderboardInput{
+			GuildID: i.GuildID,
+		}); err != nil {
+			log.Printf("Error clearing pinned leaderboard: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't stop updating the pinned leaderboard: %v", err))
+		}
+		unpinLeaderboard(s, previous)
+
+		return RespondWithEphemeralMessage(s, i, "The pinned leaderboard won't be updated anymore.")
+	}
+
+	leaderboard, err := c.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
+		ChannelID: i.ChannelID,
+		GuildID:   i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting session leaderboard: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Failed to get session leaderboard: %v", err))
+	}
+
+	message, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{renderPinnedLeaderboard(leaderboard, time.Now())},
+	})
+	if err != nil {
+		log.Printf("Error posting pinned leaderboard: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't post the leaderboard: %v", err))
+	}
+
+	reply := "📌 The session leaderboard is pinned in this channel and updates as drinks change."
+	if err := s.ChannelMessagePin(i.ChannelID, message.ID); err != nil {
+		log.Printf("Error pinning leaderboard: %v", err)
+		reply = "The session leaderboard is posted and updates as drinks change, but I couldn't pin it. Check I can manage messages here."
+	}
+
+	if _, err := c.guildConfigService.SetPinnedLeaderboard(ctx, &guild_config.SetPinnedLeaderboardInput{
+		GuildID: i.GuildID,
+		Pin:     &models.PinnedLeaderboard{ChannelID: i.ChannelID, MessageID: message.ID},
+	}); err != nil {
+		log.Printf("Error saving pinned leaderboard: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't save the pinned leaderboard: %v", err))
+	}
+
+	if previous != nil {
+		unpinLeaderboard(s, previous)
+	}
+
+	return RespondWithEphemeralMessage(s, i, reply)
+}
+
+// unpinLeaderboard unpins a leaderboard the bot no longer updates, leaving the message where it is
+func unpinLeaderboard(s DiscordSession, pin *models.PinnedLeaderboard) {
+	if err := s.ChannelMessageUnpin(pin.ChannelID, pin.MessageID); err != nil && !isMissingMessage(err) {
+		log.Printf("Error unpinning leaderboard in channel %s: %v", pin.ChannelID, err)
+	}
+}
+
+// handleLedgerChanged queues a refresh of the guild's pinned leaderboard, changes coming in quick succession are
+// folded into a single edit
+func (b *Bot) handleLedgerChanged(ctx context.Context, event *events.Event) {
+	if event.GuildID == "" {
+		return
+	}
+
+	pin := b.pinnedLeaderboard(ctx, event.GuildID)
+	if pin == nil {
+		return
+	}
+
+	b.pins.enqueue(b.api, pin.ChannelID, &messageUpdate{guildID: event.GuildID})
+}
+
+// pinnedLeaderboard returns the guild's pinned leaderboard, nil when it doesn't keep one
+func (b *Bot) pinnedLeaderboard(ctx context.Context, guildID string) *models.PinnedLeaderboard {
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config: %v", err)
+		return nil
+	}
+	return output.Config.PinnedLeaderboard
+}
+
+// editPinnedLeaderboard shows the current session leaderboard on a guild's pinned leaderboard. A pin that was
+// deleted is forgotten, so a server gets rid of it by deleting the message.
+func (b *Bot) editPinnedLeaderboard(s DiscordSession, channelID string, update *messageUpdate) error {
+	ctx := context.Background()
+
+	pin := b.pinnedLeaderboard(ctx, update.guildID)
+	if pin == nil || pin.ChannelID != channelID {
+		return nil
+	}
+
+	leaderboard, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
+		ChannelID: channelID,
+		GuildID:   update.guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting session leaderboard: %v", err)
+		return nil
+	}
+
+	edit := discordgo.NewMessageEdit(channelID, pin.MessageID).
+		SetEmbeds([]*discordgo.MessageEmbed{renderPinnedLeaderboard(leaderboard, b.clock.Now())})
+
+	_, err = s.ChannelMessageEditComplex(edit, discordgo.WithRetryOnRatelimit(false))
+	if err != nil && isMissingMessage(err) {
+		log.Printf("Pinned leaderboard in channel %s is gone, no longer updating it", channelID)
+		if _, err := b.guildConfigService.SetPinnedLeaderboard(ctx, &guild_config.SetPinnedLeaderboardInput{
+			GuildID: update.guildID,
+		}); err != nil {
+			log.Printf("Error clearing pinned leaderboard: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error updating pinned leaderboard: %v", err)
+	}
+	return err
+}
+
+// renderPinnedLeaderboard builds the pinned session leaderboard embed
+func renderPinnedLeaderboard(leaderboard *game.GetSessionLeaderboardOutput, updatedAt time.Time) *discordgo.MessageEmbed {
+	entries := append([]game.LeaderboardEntry(nil), leaderboard.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DrinkCount > entries[j].DrinkCount
+	})
+
+	var description strings.Builder
+	if leaderboard.Session != nil {
+		fmt.Fprintf(&description, "Session started %s\n\n", discordTimestamp(leaderboard.Session.CreatedAt, timestampDateTime))
+	}
+	if len(entries) == 0 {
+		description.WriteString("🏜️ No drinks yet this session.")
+	}
+
+	rankEmojis := []string{"🥇", "🥈", "🥉"}
+	for i, entry := range entries {
+		rank := "🍺"
+		if i < len(rankEmojis) {
+			rank = rankEmojis[i]
+		}
+
+		fmt.Fprintf(&description, "%s **%s**: %d drinks (%d paid)", rank, isolateName(entry.PlayerName), entry.DrinkCount, entry.PaidCount)
+		if entry.SocialCount > 0 {
+			fmt.Fprintf(&description, " 🤝 %d social", entry.SocialCount)
+		}
+		if entry.ForgivenCount > 0 {
+			fmt.Fprintf(&description, " 🙏 %d forgiven", entry.ForgivenCount)
+		}
+		if entry.DesignatedDriver {
+			description.WriteString(" 🚗")
+		}
+		description.WriteString("\n")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📌 Session Leaderboard",
+		Description: description.String(),
+		Color:       0xF1C40F,
+		Timestamp:   updatedAt.Format(time.RFC3339),
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Updated as drinks change"},
+	}
+
+	return embed
+}
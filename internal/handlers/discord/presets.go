@@ -0,0 +1,309 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/preset"
+	"github.com/bwmarrin/discordgo"
+)
+
+// presetCommandGroup returns the subcommand group for seeing the game presets
+func presetCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "preset",
+		Description: "Named game presets",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "List the presets saved for this server",
+			},
+		},
+	}
+}
+
+// presetAdminCommandGroup returns the admin subcommand group for managing game presets
+func presetAdminCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "preset",
+		Description: "Manage named game presets",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "save",
+				Description: "Save a preset games can be started with",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "A short name, e.g. quickies",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "dice_sides",
+						Description: "Number of sides on the dice",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "max_players",
+						Description: "Maximum number of players",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "crit_hit",
+						Description: "Rolls that assign a drink, e.g. 5,6 (default: highest side)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "crit_fail",
+						Description: "Rolls that make you drink, e.g. 1,2 (default: 1)",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "social",
+						Description: "Rolls that make everyone drink, e.g. 3",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "leader_handicap",
+						Description: "The player with the most drinks this session rolls twice and keeps the worse roll",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "guests",
+						Description: "Critical hits can give drinks to people in the channel who aren't playing, if they agree",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "karma",
+						Description: "Players who keep giving their drinks to the same player drink one too",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "jackpot",
+						Description: "Ties and doubles put a drink in the session's jackpot, the next critical fail drinks it",
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "roll_mode",
+						Description: "Every roll is made twice, keeping the better or the worse one",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "advantage", Value: string(models.RollModifierAdvantage)},
+							{Name: "disadvantage", Value: string(models.RollModifierDisadvantage)},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove",
+				Description: "Remove a saved preset",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "The preset name (see /ronnied preset list)",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// handlePreset handles the preset subcommand groups, saving and removing presets is only on the admin command
+func (c *RonniedCommand) handlePreset(s DiscordSession, i *discordgo.InteractionCreate, group *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.presetService == nil || i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Presets are not enabled here.")
+	}
+
+	if len(group.Options) == 0 {
+		return errors.New("missing preset subcommand")
+	}
+
+	subcommand := group.Options[0]
+
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, opt := range subcommand.Options {
+		options[opt.Name] = opt
+	}
+
+	switch subcommand.Name {
+	case "save":
+		criticalHitValues, err := rollValuesOption(options, "crit_hit")
+		if err != nil {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't read crit_hit: %v", err))
+		}
+
+		criticalFailValues, err := rollValuesOption(options, "crit_fail")
+		if err != nil {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't read crit_fail: %v", err))
+		}
+
+		socialValues, err := rollValuesOption(options, "social")
+		if err != nil {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't read social: %v", err))
+		}
+
+		output, err := c.presetService.SavePreset(ctx, &preset.SavePresetInput{
+			GuildID: i.GuildID,
+			Name:    options["name"].StringValue(),
+			Rules: models.GameRules{
+				DiceSides:          intOption(options, "dice_sides"),
+				MaxPlayers:         intOption(options, "max_players"),
+				CriticalHitValues:  criticalHitValues,
+				CriticalFailValues: criticalFailValues,
+				SocialValues:       socialValues,
+				LeaderHandicap:     options["leader_handicap"] != nil && options["leader_handicap"].BoolValue(),
+				GuestDrinks:        options["guests"] != nil && options["guests"].BoolValue(),
+				Karma:              options["karma"] != nil && options["karma"].BoolValue(),
+				Jackpot:            options["jackpot"] != nil && options["jackpot"].BoolValue(),
+				RollMode:           models.RollModifierType(stringOption(options, "roll_mode")),
+			},
+			CreatedBy: i.Member.User.ID,
+		})
+		if err != nil {
+			log.Printf("Error saving preset: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't save preset: %v", err))
+		}
+
+		verb := "saved"
+		if output.Replaced {
+			verb = "updated"
+		}
+
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Preset `%s` %s: %s. Start it with `/ronnied start preset:%s`.",
+			output.Preset.Name, verb, describeRules(output.Preset.Rules), output.Preset.Name))
+
+	case "list":
+		output, err := c.presetService.ListPresets(ctx, &preset.ListPresetsInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error listing presets: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't list presets: %v", err))
+		}
+
+		if len(output.Presets) == 0 {
+			return RespondWithEphemeralMessage(s, i, "No presets saved. Admins can add one with `/ronnied-admin preset save`.")
+		}
+
+		var sb strings.Builder
+		sb.WriteString("**Game presets**\n")
+		for _, p := range output.Presets {
+			builtin := ""
+			if p.CreatedBy == "" {
+				builtin = " (built in)"
+			}
+			sb.WriteString(fmt.Sprintf("`%s`%s - %s\n", p.Name, builtin, describeRules(p.Rules)))
+		}
+
+		return RespondWithEphemeralMessage(s, i, sb.String())
+
+	case "remove":
+		_, err := c.presetService.RemovePreset(ctx, &preset.RemovePresetInput{
+			GuildID: i.GuildID,
+			Name:    options["name"].StringValue(),
+		})
+		if err != nil {
+			if errors.Is(err, preset.ErrPresetNotFound) {
+				return RespondWithEphemeralMessage(s, i, "No preset with that name is saved for this server.")
+			}
+			log.Printf("Error removing preset: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't remove preset: %v", err))
+		}
+
+		return RespondWithEphemeralMessage(s, i, "Preset removed.")
+
+	default:
+		return fmt.Errorf("unknown preset subcommand: %s", subcommand.Name)
+	}
+}
+
+// intOption returns the named integer option, or 0 if it wasn't given
+func intOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption, name string) int {
+	opt, ok := options[name]
+	if !ok {
+		return 0
+	}
+	return int(opt.IntValue())
+}
+
+// stringOption returns the named string option, or empty if it wasn't given
+func stringOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption, name string) string {
+	opt, ok := options[name]
+	if !ok {
+		return ""
+	}
+	return opt.StringValue()
+}
+
+// rollValuesOption gets a list of die faces from an optional string option, nil when it wasn't given
+func rollValuesOption(options map[string]*discordgo.ApplicationCommandInteractionDataOption, name string) ([]int, error) {
+	opt, ok := options[name]
+	if !ok {
+		return nil, nil
+	}
+	return models.ParseRollValues(opt.StringValue())
+}
+
+// rollModeRule is the game rules line for games whose every roll is made with advantage or disadvantage
+func rollModeRule(rules models.GameRules) string {
+	switch rules.RollMode {
+	case models.RollModifierAdvantage:
+		return "\n• Every roll is made with advantage, roll twice and keep the better! 🍀"
+	case models.RollModifierDisadvantage:
+		return "\n• Every roll is made with disadvantage, roll twice and keep the worse! 🥀"
+	default:
+		return ""
+	}
+}
+
+// describeRules summarizes the rules a preset changes
+func describeRules(rules models.GameRules) string {
+	var parts []string
+	if rules.DiceSides > 0 {
+		parts = append(parts, fmt.Sprintf("d%d", rules.DiceSides))
+	}
+	if rules.MaxPlayers > 0 {
+		parts = append(parts, fmt.Sprintf("up to %d players", rules.MaxPlayers))
+	}
+	if len(rules.CriticalHitValues) > 0 {
+		parts = append(parts, "crit hit on "+models.FormatRollValues(rules.CriticalHitValues))
+	}
+	if len(rules.CriticalFailValues) > 0 {
+		parts = append(parts, "crit fail on "+models.FormatRollValues(rules.CriticalFailValues))
+	}
+	if len(rules.SocialValues) > 0 {
+		parts = append(parts, "social on "+models.FormatRollValues(rules.SocialValues))
+	}
+	if rules.LeaderHandicap {
+		parts = append(parts, "session leader rolls with disadvantage")
+	}
+	if rules.GuestDrinks {
+		parts = append(parts, "drinks for guests")
+	}
+	if rules.Karma {
+		parts = append(parts, "karma for picking on one player")
+	}
+	if rules.Jackpot {
+		parts = append(parts, "played for the jackpot")
+	}
+	switch rules.RollMode {
+	case models.RollModifierAdvantage:
+		parts = append(parts, "every roll with advantage")
+	case models.RollModifierDisadvantage:
+		parts = append(parts, "every roll with disadvantage")
+	}
+	return strings.Join(parts, ", ")
+}
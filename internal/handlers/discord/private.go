@@ -0,0 +1,140 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxInvitesPerPick is how many players can be picked at once in the invite menu
+const maxInvitesPerPick = 10
+
+// userMentionPattern matches a user mention such as <@123> or <@!123>
+var userMentionPattern = regexp.MustCompile(`<@!?(\d+)>`)
+
+// mentionedUserIDs returns the IDs of the users mentioned in text, in order
+func mentionedUserIDs(text string) []string {
+	var userIDs []string
+	for _, match := range userMentionPattern.FindAllStringSubmatch(text, -1) {
+		userIDs = append(userIDs, match[1])
+	}
+	return userIDs
+}
+
+// mentionList mentions each of the users, e.g. <@1>, <@2>
+func mentionList(userIDs []string) string {
+	mentions := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		mentions[i] = "<@" + userID + ">"
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// invitePlayersButton returns the button a private game's creator presses to invite more players
+func invitePlayersButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Invite Players",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonInvitePlayers,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "✉️",
+		},
+	}
+}
+
+// privateTableField lists who's invited to a private game, nil for games anyone can join
+func privateTableField(g *models.Game) *discordgo.MessageEmbedField {
+	if !g.Private {
+		return nil
+	}
+
+	value := "Invite only, nobody else has been invited yet."
+	if len(g.InvitedPlayerIDs) > 0 {
+		value = "Invite only: " + mentionList(g.InvitedPlayerIDs)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "🔒 Private Table",
+		Value: value,
+	}
+}
+
+// handleInvitePlayersButton asks the creator of a private game who to invite
+func (b *Bot) handleInvitePlayersButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "Who's getting a seat at your table?",
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.SelectMenu{
+							MenuType:    discordgo.UserSelectMenu,
+							CustomID:    SelectInvitePlayers,
+							Placeholder: "Pick the players to invite",
+							MaxValues:   maxInvitesPerPick,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleInvitePlayersSelect invites the picked players to the channel's private game and lets them know
+func (b *Bot) handleInvitePlayersSelect(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	playerIDs := i.MessageComponentData().Values
+	if len(playerIDs) == 0 {
+		return RespondWithEphemeralMessage(s, i, "No players selected")
+	}
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't find the game"))
+	}
+
+	output, err := b.gameService.InvitePlayers(ctx, &game.InvitePlayersInput{
+		GameID:    existingGame.Game.ID,
+		InviterID: userID,
+		PlayerIDs: playerIDs,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotCreator):
+			return updateForgetMessage(s, i, "It's not your table, only whoever started the game can send invites.")
+		case errors.Is(err, game.ErrGameNotPrivate):
+			return updateForgetMessage(s, i, "This game is open to everyone, no invites needed.")
+		case errors.Is(err, game.ErrInvalidGameState):
+			return updateForgetMessage(s, i, "The game has already started, it's too late to invite anyone.")
+		}
+		log.Printf("Error inviting players: %v", err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't send the invites"))
+	}
+
+	b.updateGameMessage(s, channelID, output.Game.ID)
+
+	// Let the invited players know they can join
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("✉️ %s, <@%s> saved you a seat at their private table. Hit Join Game to sit down!", mentionList(playerIDs), userID),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: playerIDs,
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting invites: %v", err)
+	}
+
+	return updateForgetMessage(s, i, fmt.Sprintf("Invited %s.", mentionList(playerIDs)))
+}
@@ -0,0 +1,38 @@
+package discord
+
+import (
+	"expvar"
+	"log"
+	"runtime/debug"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// panicMessage is shown to the user when a handler panics
+const panicMessage = "😵 Something went wrong handling that. Please try again."
+
+// handlerPanics counts the panics recovered from interaction handlers
+var handlerPanics = expvar.NewInt("discord_handler_panics")
+
+// recoverInteraction recovers a panicking interaction handler, logs the stack and tells the user.
+// It must be deferred directly so recover can stop the panic.
+func (b *Bot) recoverInteraction(s DiscordSession, i *discordgo.InteractionCreate) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	handlerPanics.Add(1)
+	log.Printf("Recovered from panic handling interaction %s: %v\n%s", i.ID, r, debug.Stack())
+
+	// The handler may have already acknowledged the interaction, in which case only a follow-up works
+	if err := RespondWithEphemeralMessage(s, i, panicMessage); err != nil {
+		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: panicMessage,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		if err != nil {
+			log.Printf("Error notifying user of recovered panic: %v", err)
+		}
+	}
+}
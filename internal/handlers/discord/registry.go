@@ -0,0 +1,18 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// adminPermissions are what members need to use the admin command, the same as the bot's other admin checks
+var adminPermissions int64 = discordgo.PermissionManageServer
+
+// commandRegistry returns every slash command the bot registers. Each command's definition says who Discord lets
+// use it, so the subcommands only server admins use live on their own command that Discord hides from everyone
+// else.
+func commandRegistry(ronnied *RonniedCommand) []CommandHandler {
+	return []CommandHandler{
+		ronnied,
+		NewRonniedAdminCommand(ronnied),
+	}
+}
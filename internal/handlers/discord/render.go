@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
 	"github.com/KirkDiggler/ronnied/internal/services/game"
@@ -13,7 +15,7 @@ import (
 )
 
 // renderRollDiceResponse renders the response for a roll dice action
-func renderRollDiceResponse(s *discordgo.Session, i *discordgo.InteractionCreate, output *game.RollDiceOutput, messagingService messaging.Service) error {
+func renderRollDiceResponse(s DiscordSession, i *discordgo.InteractionCreate, output *game.RollDiceOutput, messagingService messaging.Service) error {
 	var components []discordgo.MessageComponent
 
 	// Build components based on the roll result
@@ -68,7 +70,7 @@ func renderRollDiceResponse(s *discordgo.Session, i *discordgo.InteractionCreate
 	var embedColor int
 	if output.IsCriticalHit {
 		embedColor = 0x2ecc71 // Green for critical hits
-	} else if output.RollValue == 1 {
+	} else if output.IsCriticalFail {
 		embedColor = 0xe74c3c // Red for critical fails
 	} else {
 		embedColor = 0x3498db // Blue for normal rolls
@@ -79,8 +81,9 @@ func renderRollDiceResponse(s *discordgo.Session, i *discordgo.InteractionCreate
 		PlayerName:        output.PlayerName,
 		RollValue:         output.RollValue,
 		IsCriticalHit:     output.IsCriticalHit,
-		IsCriticalFail:    output.RollValue == 1, // Assuming 1 is critical fail
-		IsPersonalMessage: true,                  // This is an ephemeral message to the player
+		IsCriticalFail:    output.IsCriticalFail,
+		DiceSides:         output.DiceSides,
+		IsPersonalMessage: true, // This is an ephemeral message to the player
 	})
 
 	// Get a supportive whisper message from Ronnie
@@ -88,7 +91,8 @@ func renderRollDiceResponse(s *discordgo.Session, i *discordgo.InteractionCreate
 		PlayerName:     output.PlayerName,
 		RollValue:      output.RollValue,
 		IsCriticalHit:  output.IsCriticalHit,
-		IsCriticalFail: output.RollValue == 1, // Assuming 1 is critical fail
+		IsCriticalFail: output.IsCriticalFail,
+		DiceSides:      output.DiceSides,
 	})
 
 	// Create embeds - either with messaging service output or fallback to static content
@@ -157,7 +161,7 @@ func renderRollDiceResponse(s *discordgo.Session, i *discordgo.InteractionCreate
 }
 
 // renderRollDiceResponseEdit renders the response for a roll dice action by editing the deferred message
-func renderRollDiceResponseEdit(s *discordgo.Session, i *discordgo.InteractionCreate, output *game.RollDiceOutput, messagingService messaging.Service) error {
+func renderRollDiceResponseEdit(s DiscordSession, i *discordgo.InteractionCreate, output *game.RollDiceOutput, messagingService messaging.Service) error {
 	var components []discordgo.MessageComponent
 
 	// Build components based on the roll result
@@ -223,7 +227,7 @@ func renderRollDiceResponseEdit(s *discordgo.Session, i *discordgo.InteractionCr
 	var embedColor int
 	if output.IsCriticalHit {
 		embedColor = 0x2ecc71 // Green for critical hits
-	} else if output.RollValue == 1 {
+	} else if output.IsCriticalFail {
 		embedColor = 0xe74c3c // Red for critical fails
 	} else {
 		embedColor = 0x3498db // Blue for normal rolls
@@ -234,8 +238,9 @@ func renderRollDiceResponseEdit(s *discordgo.Session, i *discordgo.InteractionCr
 		PlayerName:        output.PlayerName,
 		RollValue:         output.RollValue,
 		IsCriticalHit:     output.IsCriticalHit,
-		IsCriticalFail:    output.RollValue == 1, // Assuming 1 is critical fail
-		IsPersonalMessage: true,                  // This is an ephemeral message to the player
+		IsCriticalFail:    output.IsCriticalFail,
+		DiceSides:         output.DiceSides,
+		IsPersonalMessage: true, // This is an ephemeral message to the player
 	})
 
 	// Get a supportive whisper message from Ronnie
@@ -243,7 +248,8 @@ func renderRollDiceResponseEdit(s *discordgo.Session, i *discordgo.InteractionCr
 		PlayerName:     output.PlayerName,
 		RollValue:      output.RollValue,
 		IsCriticalHit:  output.IsCriticalHit,
-		IsCriticalFail: output.RollValue == 1, // Assuming 1 is critical fail
+		IsCriticalFail: output.IsCriticalFail,
+		DiceSides:      output.DiceSides,
 	})
 
 	// Create embeds - either with messaging service output or fallback to static content
@@ -296,7 +302,7 @@ func renderRollDiceResponseEdit(s *discordgo.Session, i *discordgo.InteractionCr
 }
 
 // renderGameMessage renders the game message with the current state
-func renderGameMessage(s *discordgo.Session, game *models.Game, leaderboard *game.GetSessionLeaderboardOutput) error {
+func renderGameMessage(s DiscordSession, game *models.Game, leaderboard *game.GetSessionLeaderboardOutput) error {
 	var embeds []*discordgo.MessageEmbed
 	var components []discordgo.MessageComponent
 
@@ -448,6 +454,7 @@ func renderGameMessage(s *discordgo.Session, game *models.Game, leaderboard *gam
 		components = append(components, discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				startNewGameButton,
+				transferDrinkButton(),
 			},
 		})
 	}
@@ -458,6 +465,9 @@ func renderGameMessage(s *discordgo.Session, game *models.Game, leaderboard *gam
 		var rollInfo string
 		if p.RollValue > 0 {
 			rollInfo = fmt.Sprintf(" (Rolled: %d)", p.RollValue)
+			if len(p.RollModifiers) > 0 {
+				rollInfo += fmt.Sprintf(" ✨ %s", modifierLabels(p.RollModifiers, ", "))
+			}
 		} else {
 			rollInfo = " (Not rolled yet)"
 		}
@@ -552,7 +562,11 @@ func renderGameMessage(s *discordgo.Session, game *models.Game, leaderboard *gam
 	return err
 }
 
-func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkLedger, leaderboardEntries []game.LeaderboardEntry, sessionLeaderboardEntries []game.LeaderboardEntry, rollOffGame *models.Game, parentGame *models.Game) (*discordgo.MessageEdit, error) {
+func (b *Bot) renderGameMessage(ctx context.Context, view *game.GetGameViewOutput, density models.DisplayDensity) (*discordgo.MessageEdit, error) {
+	game, rules := view.Game, view.Rules
+	drinkRecords, leaderboardEntries, sessionLeaderboardEntries := view.DrinkRecords, view.Leaderboard, view.SessionLeaderboard
+	parentGame := view.ParentGame
+
 	// Create the embed with a more dynamic title based on game status
 	embed := &discordgo.MessageEmbed{
 		Title: getGameTitle(game),
@@ -577,7 +591,8 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 		}
 
 	case models.GameStatusActive:
-		embed.Description = "🎲 **Game in progress!** Each player should roll their dice.\n*Roll a 6 to assign a drink, roll a 1 and you drink!*"
+		embed.Description = fmt.Sprintf("🎲 **Game in progress!** Each player should roll their dice.\n*Roll a %s to assign a drink, roll a %s and you drink!*",
+			models.FormatRollValues(rules.CriticalHitValues), models.FormatRollValues(rules.CriticalFailValues))
 		embed.Fields = []*discordgo.MessageEmbedField{
 			{
 				Name:   "📊 Status",
@@ -608,6 +623,11 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 			},
 		}
 
+		// Sudden death is rolled in turns, for a growing pot
+		if suddenDeath := suddenDeathField(game); suddenDeath != nil {
+			embed.Fields = append(embed.Fields, suddenDeath)
+		}
+
 		// If this is a roll-off game, add info about the parent game
 		if parentGame != nil {
 			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -647,8 +667,245 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 				Inline: true,
 			},
 		}
+
+	case models.GameStatusAbandoned:
+		embed.Description = "🚫 **Game abandoned.**\n*Start a new game whenever you're ready!*"
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{
+				Name:   "📊 Status",
+				Value:  "🚫 Abandoned",
+				Inline: true,
+			},
+			{
+				Name:   "👥 Players",
+				Value:  fmt.Sprintf("%d", len(game.Participants)),
+				Inline: true,
+			},
+		}
+	}
+
+	// Show who would drink if nobody still to roll goes lower, highest roll-offs are won rather than lost
+	isHighestRollOff := parentGame != nil && parentGame.HighestRollOffGameID == game.ID
+	if lowest := lowestRollLine(game); lowest != "" && game.Status == models.GameStatusActive && !isHighestRollOff {
+		embed.Description += "\n" + lowest
+	}
+
+	// Say when the game was created, started or finished
+	if timeLine := gameTimeLine(game); timeLine != "" {
+		embed.Description += "\n" + timeLine
+	}
+
+	// Show any seasonal events the game is played with
+	if eventsField := seasonalEventsField(game.Events); eventsField != nil {
+		embed.Fields = append(embed.Fields, eventsField)
+	}
+
+	if lastCall := lastCallField(game.LastCall); lastCall != nil {
+		embed.Fields = append(embed.Fields, lastCall)
+	}
+
+	// Show which round a rounds game is on and everyone's totals
+	if rounds := roundsField(game); rounds != nil && game.Status != models.GameStatusWaiting {
+		embed.Fields = append(embed.Fields, rounds)
+	}
+
+	// Show who's helping run a game still being played, and whether it's paused
+	if hosts := hostsField(game); hosts != nil && !game.Status.IsFinished() {
+		embed.Fields = append(embed.Fields, hosts)
+	}
+
+	// Show who's invited while a private game waits for players
+	if privateTable := privateTableField(game); privateTable != nil && game.Status == models.GameStatusWaiting {
+		embed.Fields = append(embed.Fields, privateTable)
+	}
+
+	// And who's waiting for a spot in a full one
+	if waitlist := waitlistField(game); waitlist != nil && game.Status == models.GameStatusWaiting {
+		embed.Fields = append(embed.Fields, waitlist)
+	}
+
+	// Show who rolls for the hot seat players in a game still being played
+	if hotSeat := hotSeatField(game); hotSeat != nil && !game.Status.IsFinished() {
+		embed.Fields = append(embed.Fields, hotSeat)
+	}
+
+	// And note anyone who left the server mid-game
+	if departures := departuresField(game); departures != nil {
+		embed.Fields = append(embed.Fields, departures)
+	}
+
+	// Add the participants, recent drinks and leaderboard, one short line each for compact displays and big groups
+	if density == models.DisplayCompact || len(game.Participants) >= compactParticipantThreshold {
+		embed.Fields = append(embed.Fields, compactGameFields(game, rules, drinkRecords, leaderboardEntries, sessionLeaderboardEntries)...)
+	} else {
+		embed.Fields = append(embed.Fields, b.detailedGameFields(ctx, game, rules, drinkRecords, leaderboardEntries, sessionLeaderboardEntries)...)
+	}
+
+	// Add game rules as a field for reference
+	if density != models.DisplayCompact && (game.Status == models.GameStatusWaiting || game.Status == models.GameStatusActive) {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: "📜 Game Rules",
+			Value: fmt.Sprintf("• Roll a **%s** = Assign a drink to someone else! 🔥\n", models.FormatRollValues(rules.CriticalHitValues)) +
+				fmt.Sprintf("• Roll a **%s** = Take a drink yourself! 💀\n", models.FormatRollValues(rules.CriticalFailValues)) +
+				"• Lowest roll in a round = Take a drink! 👇\n" +
+				"• Ties result in a roll-off! ⚔️" +
+				rollModeRule(rules) +
+				socialRule(rules) +
+				guestRule(rules) +
+				karmaRule(rules) +
+				jackpotRule(rules) +
+				ronnieRule(game, rules),
+		})
+	}
+
+	// Create embeds array
+	embeds := []*discordgo.MessageEmbed{embed}
+
+	// Create components based on game status
+	var components []discordgo.MessageComponent
+
+	switch game.Status {
+	case models.GameStatusWaiting:
+		// Add join and begin buttons
+		joinButton := discordgo.Button{
+			Label:    "Join Game",
+			Style:    discordgo.SuccessButton,
+			CustomID: ButtonJoinGame,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "🎮",
+			},
+		}
+
+		beginButton := discordgo.Button{
+			Label:    "Begin Game",
+			Style:    discordgo.PrimaryButton,
+			CustomID: ButtonBeginGame,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "▶️",
+			},
+		}
+
+		buttons := []discordgo.MessageComponent{
+			joinButton,
+			beginButton,
+			leaveGameButton(),
+		}
+		if game.Private {
+			buttons = append(buttons, invitePlayersButton())
+		}
+		buttons = append(buttons, hotSeatButton())
+
+		components = append(components, discordgo.ActionsRow{
+			Components: buttons,
+		})
+
+		// Anyone short of players can have Ronnie sit in
+		if !game.HasRonnie() {
+			components = append(components, discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{addRonnieButton()},
+			})
+		}
+
+	case models.GameStatusActive:
+		// Add roll dice button for active games
+		rollButton := discordgo.Button{
+			Label:    "Roll Dice",
+			Style:    discordgo.PrimaryButton,
+			CustomID: ButtonRollDice,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "🎲",
+			},
+		}
+		
+		// Add Pay Drink button
+		payDrinkButton := discordgo.Button{
+			Label:    "Pay Drink",
+			Style:    discordgo.SuccessButton,
+			CustomID: ButtonPayDrink,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "💸",
+			},
+		}
+		
+		buttons := []discordgo.MessageComponent{
+			rollButton,
+			payDrinkButton,
+			transferDrinkButton(),
+			sideBetButton(),
+		}
+		if game.HasHotSeatPlayers() {
+			buttons = append(buttons, rollHotSeatButton())
+		}
+
+		components = append(components, discordgo.ActionsRow{
+			Components: buttons,
+		})
+
+	case models.GameStatusRollOff:
+		rollButton := discordgo.Button{
+			Label:    "Roll Dice",
+			Style:    discordgo.DangerButton, // Red to make it stand out
+			CustomID: ButtonRollDice,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "🎲",
+			},
+		}
+		buttons := []discordgo.MessageComponent{
+			rollButton,
+		}
+		if game.HasHotSeatPlayers() {
+			buttons = append(buttons, rollHotSeatButton())
+		}
+
+		components = append(components, discordgo.ActionsRow{
+			Components: buttons,
+		})
+
+	case models.GameStatusCompleted, models.GameStatusAbandoned:
+		// Add start new game button
+		startNewGameButton := discordgo.Button{
+			Label:    "Start New Game",
+			Style:    discordgo.SuccessButton,
+			CustomID: ButtonStartNewGame,
+			Emoji: discordgo.ComponentEmoji{
+				Name: "🎮",
+			},
+		}
+
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				startNewGameButton,
+				transferDrinkButton(),
+			},
+		})
+	}
+
+	// Create the message edit
+	messageEdit := &discordgo.MessageEdit{
+		Channel: game.ChannelID,
+		ID:      game.MessageID,
+		Embeds:  embeds,
+	}
+
+	// Only set Components if we have any
+	if len(components) > 0 {
+		log.Printf("Setting %d components for game %s", len(components), game.ID)
+		messageEdit.Components = components
+	} else {
+		log.Printf("No components to set for message edit for game %s (status: %s)", game.ID, game.Status)
+		// Explicitly set to nil to remove any existing components
+		var emptyComponents []discordgo.MessageComponent
+		messageEdit.Components = emptyComponents
+		log.Printf("Set empty components array for game %s to clear buttons", game.ID)
 	}
 
+	return messageEdit, nil
+}
+
+// detailedGameFields renders the participants with their roll comments, the recent drinks and the leaderboard with progress bars
+func (b *Bot) detailedGameFields(ctx context.Context, game *models.Game, rules models.GameRules, drinkRecords []*models.DrinkLedger, leaderboardEntries []game.LeaderboardEntry, sessionLeaderboardEntries []game.LeaderboardEntry) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+
 	// Add participant list with enhanced information
 	var participantList string
 	
@@ -660,19 +917,25 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 		
 		if p.RollValue > 0 {
 			// Select emoji based on roll value
-			switch p.RollValue {
-			case 6:
+			switch {
+			case rules.IsCriticalHit(p.RollValue):
 				rollEmoji = "🔥" // Critical hit
-			case 1:
+			case rules.IsCriticalFail(p.RollValue):
 				rollEmoji = "💀" // Critical fail
-			case 5:
+			case p.RollValue == 5:
 				rollEmoji = "⭐" // High roll
-			case 4:
+			case p.RollValue == 4:
 				rollEmoji = "✨" // Good roll
 			default:
-				rollEmoji = "🎲" // Normal roll
+				rollEmoji = diceEmoji(game.Events) // Normal roll
 			}
 			rollInfo = fmt.Sprintf(" (%s **%d**)", rollEmoji, p.RollValue)
+			if len(p.RollModifiers) > 0 {
+				rollInfo += fmt.Sprintf(" ✨ _%s_", modifierLabels(p.RollModifiers, ", "))
+			}
+			if p.RollTime != nil {
+				rollInfo += " " + discordTimestamp(*p.RollTime, timestampRelative)
+			}
 		} else {
 			rollInfo = " (🎲 Not rolled yet)"
 		}
@@ -681,11 +944,11 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 		var rollComment string
 		if p.RollValue > 0 {
 			// Get the comment from the messaging service
-			rollCommentOutput, err := b.messagingService.GetRollComment(context.Background(), &messaging.GetRollCommentInput{
+			rollCommentOutput, err := b.messagingService.GetRollComment(ctx, &messaging.GetRollCommentInput{
 				PlayerName:     p.PlayerName,
 				RollValue:      p.RollValue,
-				IsCriticalHit:  p.RollValue == 6,
-				IsCriticalFail: p.RollValue == 1,
+				IsCriticalHit:  rules.IsCriticalHit(p.RollValue),
+				IsCriticalFail: rules.IsCriticalFail(p.RollValue),
 			})
 			
 			if err == nil && rollCommentOutput != nil {
@@ -694,11 +957,11 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 		}
 		
 		// Add spacing between participants
-		participantList += fmt.Sprintf("• **%s**%s%s\n\n", p.PlayerName, rollInfo, rollComment)
+		participantList += fmt.Sprintf("• **%s**%s%s\n\n", isolateName(p.PlayerName), rollInfo, rollComment)
 	}
 	
 	if participantList != "" {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		fields = append(fields, &discordgo.MessageEmbedField{
 			Name:  "👥 Participants & Rolls",
 			Value: participantList,
 		})
@@ -713,15 +976,18 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 			return drinkRecords[i].Timestamp.After(drinkRecords[j].Timestamp)
 		})
 		
+		// A social round shows up once rather than once for every player who drinks
+		recentDrinks := collapseSocialRounds(drinkRecords)
+
 		// Take only the 5 most recent drink assignments
 		recentCount := 5
-		if len(drinkRecords) < recentCount {
-			recentCount = len(drinkRecords)
+		if len(recentDrinks) < recentCount {
+			recentCount = len(recentDrinks)
 		}
 		
 		// Build the drink assignments text with messages from the service
 		for i := 0; i < recentCount; i++ {
-			record := drinkRecords[i]
+			record := recentDrinks[i]
 			
 			// Find player names
 			var fromPlayerName, toPlayerName string
@@ -734,16 +1000,22 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 				}
 			}
 			
-			// Skip if we couldn't find the player names
-			if fromPlayerName == "" || (toPlayerName == "" && record.Reason == models.DrinkReasonCriticalHit) {
+			// Someone watching the game isn't one of its participants
+			if toPlayerName == "" && record.IsGuest() {
+				toPlayerName = record.GuestName
+			}
+
+			// Skip if we couldn't find the player names, a social round can be called by an admin who isn't playing
+			if (fromPlayerName == "" && record.Reason != models.DrinkReasonSocialRound) || (toPlayerName == "" && record.Reason == models.DrinkReasonCriticalHit) {
 				continue
 			}
 			
 			// Get the message from the messaging service
-			assignmentOutput, err := b.messagingService.GetDrinkAssignmentMessage(context.Background(), &messaging.GetDrinkAssignmentMessageInput{
-				FromPlayerName: fromPlayerName,
-				ToPlayerName:   toPlayerName,
-				Reason:         record.Reason,
+			assignmentOutput, err := b.messagingService.GetDrinkAssignmentMessage(ctx, &messaging.GetDrinkAssignmentMessageInput{
+				FromPlayerName:      fromPlayerName,
+				ToPlayerName:        toPlayerName,
+				Reason:              record.Reason,
+				HappyHourMultiplier: record.HappyHourMultiplier,
 			})
 			
 			if err == nil && assignmentOutput != nil {
@@ -752,7 +1024,7 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 		}
 		
 		if drinkAssignments != "" {
-			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			fields = append(fields, &discordgo.MessageEmbedField{
 				Name:  "🍻 Recent Drink Assignments",
 				Value: drinkAssignments,
 			})
@@ -824,7 +1096,7 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 			leaderboardText += fmt.Sprintf("\n**Session Progress**: %s", sessionProgress)
 		}
 
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		fields = append(fields, &discordgo.MessageEmbedField{
 			Name:  "🏆 Drink Leaderboard (By Drinks Paid)",
 			Value: leaderboardText,
 		})
@@ -851,138 +1123,13 @@ func (b *Bot) renderGameMessage(game *models.Game, drinkRecords []*models.DrinkL
 			leaderboardText += fmt.Sprintf("%s**%s**: %d drinks paid\n", rankEmoji, entry.PlayerName, entry.PaidCount)
 		}
 
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		fields = append(fields, &discordgo.MessageEmbedField{
 			Name:  "🏆 Drink Leaderboard",
 			Value: leaderboardText,
 		})
 	}
 
-	// Add game rules as a field for reference
-	if game.Status == models.GameStatusWaiting || game.Status == models.GameStatusActive {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: "📜 Game Rules",
-			Value: "• Roll a **6** = Assign a drink to someone else! 🔥\n" +
-				"• Roll a **1** = Take a drink yourself! 💀\n" +
-				"• Lowest roll in a round = Take a drink! 👇\n" +
-				"• Ties result in a roll-off! ⚔️",
-		})
-	}
-
-	// Create embeds array
-	embeds := []*discordgo.MessageEmbed{embed}
-
-	// Create components based on game status
-	var components []discordgo.MessageComponent
-
-	switch game.Status {
-	case models.GameStatusWaiting:
-		// Add join and begin buttons
-		joinButton := discordgo.Button{
-			Label:    "Join Game",
-			Style:    discordgo.SuccessButton,
-			CustomID: ButtonJoinGame,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "🎮",
-			},
-		}
-
-		beginButton := discordgo.Button{
-			Label:    "Begin Game",
-			Style:    discordgo.PrimaryButton,
-			CustomID: ButtonBeginGame,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "▶️",
-			},
-		}
-
-		components = append(components, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				joinButton,
-				beginButton,
-			},
-		})
-
-	case models.GameStatusActive:
-		// Add roll dice button for active games
-		rollButton := discordgo.Button{
-			Label:    "Roll Dice",
-			Style:    discordgo.PrimaryButton,
-			CustomID: ButtonRollDice,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "🎲",
-			},
-		}
-		
-		// Add Pay Drink button
-		payDrinkButton := discordgo.Button{
-			Label:    "Pay Drink",
-			Style:    discordgo.SuccessButton,
-			CustomID: ButtonPayDrink,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "💸",
-			},
-		}
-		
-		components = append(components, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				rollButton,
-				payDrinkButton,
-			},
-		})
-
-	case models.GameStatusRollOff:
-		rollButton := discordgo.Button{
-			Label:    "Roll Dice",
-			Style:    discordgo.DangerButton, // Red to make it stand out
-			CustomID: ButtonRollDice,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "🎲",
-			},
-		}
-		components = append(components, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				rollButton,
-			},
-		})
-
-	case models.GameStatusCompleted:
-		// Add start new game button
-		startNewGameButton := discordgo.Button{
-			Label:    "Start New Game",
-			Style:    discordgo.SuccessButton,
-			CustomID: ButtonStartNewGame,
-			Emoji: discordgo.ComponentEmoji{
-				Name: "🎮",
-			},
-		}
-
-		components = append(components, discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				startNewGameButton,
-			},
-		})
-	}
-
-	// Create the message edit
-	messageEdit := &discordgo.MessageEdit{
-		Channel: game.ChannelID,
-		ID:      game.MessageID,
-		Embeds:  embeds,
-	}
-
-	// Only set Components if we have any
-	if len(components) > 0 {
-		log.Printf("Setting %d components for game %s", len(components), game.ID)
-		messageEdit.Components = components
-	} else {
-		log.Printf("No components to set for message edit for game %s (status: %s)", game.ID, game.Status)
-		// Explicitly set to nil to remove any existing components
-		var emptyComponents []discordgo.MessageComponent
-		messageEdit.Components = emptyComponents
-		log.Printf("Set empty components array for game %s to clear buttons", game.ID)
-	}
-
-	return messageEdit, nil
+	return fields
 }
 
 // createDrinkProgressBar creates a visual progress bar for drink payments
@@ -1121,6 +1268,8 @@ func getGameTitle(game *models.Game) string {
 		return "⚔️ Ronnied Drinking Game - Roll-Off in Progress"
 	case models.GameStatusCompleted:
 		return "🏆 Ronnied Drinking Game - Game Complete"
+	case models.GameStatusAbandoned:
+		return "🚫 Ronnied Drinking Game - Abandoned"
 	default:
 		return "🎲 Ronnied Drinking Game"
 	}
@@ -1137,7 +1286,65 @@ func getGameStatusColor(status models.GameStatus) int {
 		return 0xff9900 // Orange color
 	case models.GameStatusCompleted:
 		return 0x9b59b6 // Purple color
+	case models.GameStatusAbandoned:
+		return 0x95a5a6 // Grey color
 	default:
 		return 0x3498db // Default blue
 	}
 }
+
+// lowestRollLine names who holds the lowest roll so far, with everyone tied on it, empty before anyone has rolled
+func lowestRollLine(game *models.Game) string {
+	lowest := game.LowestRollers()
+	if len(lowest) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(lowest))
+	for i, p := range lowest {
+		names[i] = "**" + isolateName(p.PlayerName) + "**"
+	}
+	if len(lowest) == 1 {
+		return fmt.Sprintf("📉 About to lose: %s with a **%d**", names[0], lowest[0].RollValue)
+	}
+	return fmt.Sprintf("📉 About to lose: %s tied on **%d**", strings.Join(names, " = "), lowest[0].RollValue)
+}
+
+// gameTimeLine says when a game was created, started or finished, written by Discord as how long ago it was
+func gameTimeLine(game *models.Game) string {
+	switch game.Status {
+	case models.GameStatusWaiting:
+		if game.CreatedAt.IsZero() {
+			return ""
+		}
+		return "🕒 Created " + discordTimestamp(game.CreatedAt, timestampRelative)
+	case models.GameStatusActive, models.GameStatusRollOff:
+		if game.StartedAt == nil {
+			return ""
+		}
+		return "🕒 Started " + discordTimestamp(*game.StartedAt, timestampRelative)
+	case models.GameStatusCompleted, models.GameStatusAbandoned:
+		if game.UpdatedAt.IsZero() {
+			return ""
+		}
+		return "🏁 Finished " + discordTimestamp(game.UpdatedAt, timestampRelative)
+	}
+	return ""
+}
+
+// The styles Discord can show a timestamp in
+const (
+	// timestampShortTime is the time of day, e.g. 9:41 PM
+	timestampShortTime = "t"
+
+	// timestampDateTime is the date and time, e.g. 20 April 2021 9:41 PM
+	timestampDateTime = "f"
+
+	// timestampRelative is how long ago or until the time, e.g. 2 minutes ago
+	timestampRelative = "R"
+)
+
+// discordTimestamp shows a time in the style given, which Discord writes in each reader's own language and time zone
+func discordTimestamp(t time.Time, style string) string {
+	return fmt.Sprintf("<t:%d:%s>", t.Unix(), style)
+}
@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"expvar"
+	"log"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxRenderCacheEntries is how many game messages the render cache remembers before starting over
+const maxRenderCacheEntries = 1000
+
+// messageEditsSkipped counts game message edits skipped because nothing on the message changed
+var messageEditsSkipped = expvar.NewInt("discord_message_edits_skipped")
+
+// renderCache remembers a hash of the last edit sent to each game message so identical edits can be skipped
+type renderCache struct {
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// newRenderCache creates an empty render cache
+func newRenderCache() *renderCache {
+	return &renderCache{
+		hashes: make(map[string]string),
+	}
+}
+
+// unchanged reports whether the edit would leave its message exactly as the last edit sent to it did
+func (c *renderCache) unchanged(edit *discordgo.MessageEdit) bool {
+	hash := renderHash(edit)
+	if hash == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hashes[renderCacheKey(edit)] == hash
+}
+
+// remember records the edit as the last one sent to its message
+func (c *renderCache) remember(edit *discordgo.MessageEdit) {
+	hash := renderHash(edit)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hash == "" {
+		delete(c.hashes, renderCacheKey(edit))
+		return
+	}
+
+	// Old games' messages are rarely edited again, so forgetting them all is cheaper than tracking age
+	if len(c.hashes) >= maxRenderCacheEntries {
+		c.hashes = make(map[string]string)
+	}
+
+	c.hashes[renderCacheKey(edit)] = hash
+}
+
+// renderCacheKey identifies the message an edit is for
+func renderCacheKey(edit *discordgo.MessageEdit) string {
+	return edit.Channel + "/" + edit.ID
+}
+
+// renderHash hashes the edit as it would be sent to Discord
+func renderHash(edit *discordgo.MessageEdit) string {
+	data, err := json.Marshal(edit)
+	if err != nil {
+		log.Printf("Error hashing game message edit: %v", err)
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
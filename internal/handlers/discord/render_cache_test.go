@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/suite"
+)
+
+type RenderCacheTestSuite struct {
+	suite.Suite
+	cache *renderCache
+}
+
+func (s *RenderCacheTestSuite) SetupTest() {
+	s.cache = newRenderCache()
+}
+
+func TestRenderCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderCacheTestSuite))
+}
+
+// gameEdit builds an edit of a game message with a single embed
+func gameEdit(messageID, title string) *discordgo.MessageEdit {
+	return &discordgo.MessageEdit{
+		Channel: "channel-1",
+		ID:      messageID,
+		Embeds: []*discordgo.MessageEmbed{
+			{Title: title},
+		},
+	}
+}
+
+func (s *RenderCacheTestSuite) TestUnknownMessageIsChanged() {
+	s.False(s.cache.unchanged(gameEdit("message-1", "Waiting for Players")))
+}
+
+func (s *RenderCacheTestSuite) TestIdenticalEditIsUnchanged() {
+	s.cache.remember(gameEdit("message-1", "Waiting for Players"))
+
+	s.True(s.cache.unchanged(gameEdit("message-1", "Waiting for Players")))
+}
+
+func (s *RenderCacheTestSuite) TestDifferentEmbedIsChanged() {
+	s.cache.remember(gameEdit("message-1", "Waiting for Players"))
+
+	s.False(s.cache.unchanged(gameEdit("message-1", "Game Complete")))
+}
+
+func (s *RenderCacheTestSuite) TestMessagesAreTrackedSeparately() {
+	s.cache.remember(gameEdit("message-1", "Waiting for Players"))
+
+	s.False(s.cache.unchanged(gameEdit("message-2", "Waiting for Players")))
+}
+
+func (s *RenderCacheTestSuite) TestCacheStartsOverWhenFull() {
+	for i := 0; i < maxRenderCacheEntries; i++ {
+		s.cache.remember(gameEdit(fmt.Sprintf("old-message-%d", i), "Waiting for Players"))
+	}
+	s.cache.remember(gameEdit("message-1", "Waiting for Players"))
+
+	s.Len(s.cache.hashes, 1)
+	s.True(s.cache.unchanged(gameEdit("message-1", "Waiting for Players")))
+}
@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// gameMessagesReposted counts game messages posted again because the old one was deleted or its thread archived
+var gameMessagesReposted = expvar.NewInt("discord_game_messages_reposted")
+
+// isMissingMessage reports whether Discord turned down an edit because the message was deleted or its
+// thread was archived
+func isMissingMessage(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) || restErr.Message == nil {
+		return false
+	}
+
+	switch restErr.Message.Code {
+	case discordgo.ErrCodeUnknownMessage, discordgo.ErrCodePerformedOperationOnArchivedThread:
+		return true
+	}
+	return false
+}
+
+// repostGameMessage posts a game message again when the old one can't be edited, and keeps the new
+// message's ID on the game so later updates edit it. Posting in an archived thread also unarchives it.
+func (b *Bot) repostGameMessage(ctx context.Context, s DiscordSession, existingGame *models.Game, edit *discordgo.MessageEdit) error {
+	gameID := existingGame.ID
+	send := &discordgo.MessageSend{
+		Embeds:     edit.Embeds,
+		Components: edit.Components,
+	}
+	if edit.Content != nil {
+		send.Content = *edit.Content
+	}
+
+	msg, err := b.feed.send(ctx, s, existingGame.GuildID, edit.Channel, send, discordgo.WithRetryOnRatelimit(false))
+	if err != nil {
+		log.Printf("Error reposting game message for game %s: %v", gameID, err)
+		return fmt.Errorf("failed to repost game message: %w", err)
+	}
+	gameMessagesReposted.Add(1)
+	log.Printf("Reposted game message for game %s in channel %s, message %s was gone", gameID, edit.Channel, edit.ID)
+
+	_, err = b.gameService.UpdateGameMessage(ctx, &game.UpdateGameMessageInput{
+		GameID:    gameID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		log.Printf("Error saving reposted game message ID: %v", err)
+		return fmt.Errorf("failed to save reposted game message: %w", err)
+	}
+
+	edit.ID = msg.ID
+	b.renders.remember(edit)
+	return nil
+}
@@ -0,0 +1,188 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// rerollCommandGroup returns the subcommand group for re-roll tokens
+func rerollCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "reroll",
+		Description: "Re-roll tokens",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "tokens",
+				Description: "See how many re-roll tokens you have",
+			},
+		},
+	}
+}
+
+// rerollAdminCommandGroup returns the admin subcommand group for handing out re-roll tokens
+func rerollAdminCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "reroll",
+		Description: "Hand out re-roll tokens",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "grant",
+				Description: "Give a player re-roll tokens",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "player",
+						Description: "The player to give tokens to",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "count",
+						Description: "How many tokens to give (default: 1)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// handleReroll handles the reroll subcommand groups, granting tokens is only on the admin command
+func (c *RonniedCommand) handleReroll(s DiscordSession, i *discordgo.InteractionCreate, group *discordgo.ApplicationCommandInteractionDataOption, userID string) error {
+	ctx := context.Background()
+
+	if len(group.Options) == 0 {
+		return errors.New("missing reroll subcommand")
+	}
+
+	subcommand := group.Options[0]
+	switch subcommand.Name {
+	case "tokens":
+		output, err := c.gameService.GetRerollTokens(ctx, &game.GetRerollTokensInput{
+			PlayerID: userID,
+		})
+		if err != nil {
+			log.Printf("Error getting re-roll tokens: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get your re-roll tokens: %v", err))
+		}
+
+		if output.Tokens == 0 {
+			return RespondWithEphemeralMessage(s, i, "You don't have any re-roll tokens. Roll enough critical fails in a session and you'll earn one!")
+		}
+
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("You have %d re-roll token(s). Use one with the Use Re-roll button after a bad roll.", output.Tokens))
+
+	case "grant":
+		var player *discordgo.User
+		count := 1
+		for _, opt := range subcommand.Options {
+			switch opt.Name {
+			case "player":
+				player = opt.UserValue(nil)
+			case "count":
+				count = int(opt.IntValue())
+			}
+		}
+
+		if player == nil {
+			return errors.New("missing player option")
+		}
+
+		output, err := c.gameService.GrantRerollTokens(ctx, &game.GrantRerollTokensInput{
+			PlayerID:   player.ID,
+			PlayerName: player.Username,
+			Count:      count,
+		})
+		if err != nil {
+			log.Printf("Error granting re-roll tokens: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't grant re-roll tokens: %v", err))
+		}
+
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Gave <@%s> %d re-roll token(s), they now have %d.", player.ID, count, output.Tokens))
+
+	default:
+		return fmt.Errorf("unknown reroll subcommand: %s", subcommand.Name)
+	}
+}
+
+// rerollButton returns a Use Re-roll button if the roll can still be re-rolled and the player has a token
+func (b *Bot) rerollButton(ctx context.Context, rollOutput *game.RollDiceOutput) *discordgo.Button {
+	if rollOutput.IsCriticalHit || rollOutput.IsRollOffRoll || rollOutput.Game == nil || rollOutput.Game.Status != models.GameStatusActive {
+		return nil
+	}
+
+	participant := rollOutput.Game.GetParticipant(rollOutput.PlayerID)
+	if participant == nil || len(participant.VoidedRolls) > 0 {
+		return nil
+	}
+
+	tokensOutput, err := b.gameService.GetRerollTokens(ctx, &game.GetRerollTokensInput{
+		PlayerID: rollOutput.PlayerID,
+	})
+	if err != nil {
+		log.Printf("Error getting re-roll tokens: %v", err)
+		return nil
+	}
+
+	if tokensOutput.Tokens == 0 {
+		return nil
+	}
+
+	return &discordgo.Button{
+		Label:    fmt.Sprintf("Use Re-roll (%d)", tokensOutput.Tokens),
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonUseReroll,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🔄",
+		},
+	}
+}
+
+// handleUseRerollButton handles the use re-roll button click
+func (b *Bot) handleUseRerollButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	// Acknowledge the interaction so the roll result can replace the old one
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		log.Printf("Error acknowledging interaction: %v", err)
+		return err
+	}
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		log.Printf("Error getting game: %v", err)
+		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: b.friendlyError(ctx, err, "No active game found in this channel."),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return err
+	}
+
+	rollOutput, err := b.gameService.RerollDice(ctx, &game.RerollDiceInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: b.friendlyError(ctx, err, "Failed to re-roll"),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		return err
+	}
+
+	return b.showRollResult(ctx, s, i, channelID, existingGame.Game, rollOutput)
+}
@@ -0,0 +1,325 @@
+package discord
+
+import (
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/bwmarrin/discordgo"
+)
+
+// autoDeferAfter is how long an interaction can go unanswered before it's deferred. Discord fails interactions that
+// aren't answered within three seconds, so this leaves time for the deferral to get there.
+const autoDeferAfter = 2 * time.Second
+
+// interactionsAutoDeferred counts the interactions deferred because their handler was slow to answer them
+var interactionsAutoDeferred = expvar.NewInt("discord_interactions_auto_deferred")
+
+// responseState is how far an interaction has been answered
+type responseState int
+
+const (
+	// responseNone means nothing has been sent for the interaction yet
+	responseNone responseState = iota
+
+	// responseDeferredMessage means a "thinking" message stands in for the reply still to come
+	responseDeferredMessage
+
+	// responseDeferredUpdate means the component's message was acknowledged and is left as it is for now
+	responseDeferredUpdate
+
+	// responseSent means the reply, the component's updated message or a modal was sent
+	responseSent
+)
+
+// responder answers one interaction, picking the Discord call that fits how far it has been answered. It defers
+// the interaction when its handler hasn't answered within autoDeferAfter, so a slow handler doesn't leave the user
+// looking at "This interaction failed". It's a DiscordSession too, so handlers answering with InteractionRespond
+// are switched to the right call once the interaction has been deferred.
+type responder struct {
+	DiscordSession
+	interaction *discordgo.InteractionCreate
+
+	mu    sync.Mutex
+	state responseState
+
+	// ephemeralDeferral is whether the deferred "thinking" message is only shown to the user
+	ephemeralDeferral bool
+
+	timer clock.Timer
+}
+
+// newResponder creates a responder for the interaction, which defers it once autoDeferAfter passes unanswered
+func newResponder(s DiscordSession, i *discordgo.InteractionCreate, clk clock.Clock) *responder {
+	r := &responder{
+		DiscordSession: s,
+		interaction:    i,
+	}
+	r.timer = clk.AfterFunc(autoDeferAfter, r.autoDefer)
+	return r
+}
+
+// done stops the interaction being deferred, once its handler has returned
+func (r *responder) done() {
+	r.timer.Stop()
+}
+
+// autoDefer defers the interaction if nothing has been sent for it yet
+func (r *responder) autoDefer() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state != responseNone {
+		return
+	}
+
+	if err := r.deferLocked(); err != nil {
+		log.Printf("Error deferring slow interaction %s: %v", r.interaction.ID, err)
+		return
+	}
+	interactionsAutoDeferred.Add(1)
+}
+
+// Defer acknowledges the interaction, if it hasn't been already. A component's message is left as it is, anything
+// else shows a "thinking" message only the user sees until the reply is sent.
+func (r *responder) Defer() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state != responseNone {
+		return nil
+	}
+	return r.deferLocked()
+}
+
+// deferLocked acknowledges the interaction without answering it yet
+func (r *responder) deferLocked() error {
+	resp := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}
+	if r.updatesMessage() {
+		resp = &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		}
+	}
+
+	if err := r.DiscordSession.InteractionRespond(r.interaction.Interaction, resp); err != nil {
+		return err
+	}
+	r.sent(resp)
+	return nil
+}
+
+// Respond sends the reply to the interaction: as its response when nothing has been sent yet, in place of the
+// deferred "thinking" message, or as a follow-up otherwise
+func (r *responder) Respond(data *discordgo.InteractionResponseData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.respondLocked(data)
+}
+
+// respondLocked sends the reply to the interaction
+func (r *responder) respondLocked(data *discordgo.InteractionResponseData) error {
+	switch r.state {
+	case responseNone:
+		resp := &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: data,
+		}
+		if err := r.DiscordSession.InteractionRespond(r.interaction.Interaction, resp); err != nil {
+			return err
+		}
+		r.sent(resp)
+		return nil
+	case responseDeferredMessage:
+		// The reply takes the place of the "thinking" message when they're shown to the same people
+		if isEphemeral(data) == r.ephemeralDeferral {
+			if _, err := r.DiscordSession.InteractionResponseEdit(r.interaction.Interaction, webhookEdit(data)); err != nil {
+				return err
+			}
+			r.state = responseSent
+			return nil
+		}
+
+		// Otherwise it's posted alongside, and the "thinking" message removed
+		if _, err := r.DiscordSession.FollowupMessageCreate(r.interaction.Interaction, true, webhookParams(data)); err != nil {
+			return err
+		}
+		r.state = responseSent
+		if err := r.DiscordSession.InteractionResponseDelete(r.interaction.Interaction); err != nil {
+			log.Printf("Error removing the deferred response to interaction %s: %v", r.interaction.ID, err)
+		}
+		return nil
+	default:
+		_, err := r.DiscordSession.FollowupMessageCreate(r.interaction.Interaction, true, webhookParams(data))
+		return err
+	}
+}
+
+// Edit changes what the interaction shows: the message of the component that was used, or the reply to anything
+// else. It's sent as the reply when nothing has been sent for a command yet.
+func (r *responder) Edit(data *discordgo.InteractionResponseData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.editLocked(data)
+}
+
+// editLocked changes what the interaction shows
+func (r *responder) editLocked(data *discordgo.InteractionResponseData) error {
+	if r.state == responseNone {
+		if !r.updatesMessage() {
+			return r.respondLocked(data)
+		}
+
+		resp := &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: data,
+		}
+		if err := r.DiscordSession.InteractionRespond(r.interaction.Interaction, resp); err != nil {
+			return err
+		}
+		r.sent(resp)
+		return nil
+	}
+
+	if _, err := r.DiscordSession.InteractionResponseEdit(r.interaction.Interaction, webhookEdit(data)); err != nil {
+		return err
+	}
+	r.state = responseSent
+	return nil
+}
+
+// Followup sends another message for the interaction, or its reply when nothing has replied to it yet
+func (r *responder) Followup(data *discordgo.InteractionResponseData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == responseNone || r.state == responseDeferredMessage {
+		return r.respondLocked(data)
+	}
+
+	_, err := r.DiscordSession.FollowupMessageCreate(r.interaction.Interaction, true, webhookParams(data))
+	return err
+}
+
+// InteractionRespond responds to the responder's interaction the way its handler asked when nothing has been sent
+// for it yet, and otherwise with the call that has the same effect. Other interactions are responded to as asked.
+func (r *responder) InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	if interaction != r.interaction.Interaction {
+		return r.DiscordSession.InteractionRespond(interaction, resp, options...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == responseNone {
+		if err := r.DiscordSession.InteractionRespond(interaction, resp, options...); err != nil {
+			return err
+		}
+		r.sent(resp)
+		return nil
+	}
+
+	// The interaction was already acknowledged, most likely deferred while its handler was busy
+	switch resp.Type {
+	case discordgo.InteractionResponseDeferredChannelMessageWithSource, discordgo.InteractionResponseDeferredMessageUpdate:
+		return nil
+	case discordgo.InteractionResponseChannelMessageWithSource:
+		return r.respondLocked(resp.Data)
+	case discordgo.InteractionResponseUpdateMessage:
+		return r.editLocked(resp.Data)
+	default:
+		// Modals can only be the first response, so leave Discord to turn this down
+		return r.DiscordSession.InteractionRespond(interaction, resp, options...)
+	}
+}
+
+// InteractionResponseEdit edits the original response to an interaction, noting the responder's interaction has
+// been replied to
+func (r *responder) InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	if interaction != r.interaction.Interaction {
+		return r.DiscordSession.InteractionResponseEdit(interaction, newresp, options...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg, err := r.DiscordSession.InteractionResponseEdit(interaction, newresp, options...)
+	if err == nil && r.state != responseNone {
+		r.state = responseSent
+	}
+	return msg, err
+}
+
+// sent notes the response sent for the interaction
+func (r *responder) sent(resp *discordgo.InteractionResponse) {
+	switch resp.Type {
+	case discordgo.InteractionResponseDeferredChannelMessageWithSource:
+		r.state = responseDeferredMessage
+		r.ephemeralDeferral = isEphemeral(resp.Data)
+	case discordgo.InteractionResponseDeferredMessageUpdate:
+		r.state = responseDeferredUpdate
+	default:
+		r.state = responseSent
+	}
+}
+
+// updatesMessage reports whether the interaction came from a message's component, so can be answered by updating
+// that message
+func (r *responder) updatesMessage() bool {
+	return r.interaction.Type == discordgo.InteractionMessageComponent ||
+		(r.interaction.Type == discordgo.InteractionModalSubmit && r.interaction.Message != nil)
+}
+
+// isEphemeral reports whether the response data is only shown to the user
+func isEphemeral(data *discordgo.InteractionResponseData) bool {
+	return data != nil && data.Flags&discordgo.MessageFlagsEphemeral != 0
+}
+
+// webhookEdit turns response data into an edit of the original response
+func webhookEdit(data *discordgo.InteractionResponseData) *discordgo.WebhookEdit {
+	if data == nil {
+		data = &discordgo.InteractionResponseData{}
+	}
+
+	content := data.Content
+	embeds := data.Embeds
+	if embeds == nil {
+		embeds = []*discordgo.MessageEmbed{}
+	}
+	components := data.Components
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+
+	return &discordgo.WebhookEdit{
+		Content:         &content,
+		Embeds:          &embeds,
+		Components:      &components,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+	}
+}
+
+// webhookParams turns response data into a follow-up message
+func webhookParams(data *discordgo.InteractionResponseData) *discordgo.WebhookParams {
+	if data == nil {
+		data = &discordgo.InteractionResponseData{}
+	}
+
+	return &discordgo.WebhookParams{
+		Content:         data.Content,
+		Embeds:          data.Embeds,
+		Components:      data.Components,
+		Files:           data.Files,
+		AllowedMentions: data.AllowedMentions,
+		Flags:           data.Flags,
+	}
+}
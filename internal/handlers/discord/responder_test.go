@@ -0,0 +1,199 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/handlers/discord/mocks"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ResponderTestSuite struct {
+	suite.Suite
+	mockCtrl    *gomock.Controller
+	mockSession *mocks.MockDiscordSession
+	clock       *clock.Fake
+}
+
+func (s *ResponderTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockSession = mocks.NewMockDiscordSession(s.mockCtrl)
+	s.clock = clock.NewFake(time.Now())
+}
+
+func TestResponderTestSuite(t *testing.T) {
+	suite.Run(t, new(ResponderTestSuite))
+}
+
+// command builds a slash command interaction
+func command() *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:   "interaction-1",
+		Type: discordgo.InteractionApplicationCommand,
+	}}
+}
+
+// component builds a button press on a message
+func component() *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		ID:      "interaction-1",
+		Type:    discordgo.InteractionMessageComponent,
+		Message: &discordgo.Message{ID: "message-1"},
+	}}
+}
+
+// expectRespond expects the interaction to be responded to with the response type
+func (s *ResponderTestSuite) expectRespond(i *discordgo.InteractionCreate, responseType discordgo.InteractionResponseType) *gomock.Call {
+	return s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(responseType, resp.Type)
+			return nil
+		})
+}
+
+func (s *ResponderTestSuite) TestRespondBeforeDeferral() {
+	i := command()
+	r := newResponder(s.mockSession, i, s.clock)
+
+	s.expectRespond(i, discordgo.InteractionResponseChannelMessageWithSource)
+	s.Require().NoError(r.Respond(&discordgo.InteractionResponseData{Content: "🎲"}))
+
+	// Answered in time, so nothing is deferred
+	r.done()
+	s.clock.Advance(autoDeferAfter)
+	s.Equal(responseSent, r.state)
+}
+
+func (s *ResponderTestSuite) TestDoneStopsDeferral() {
+	r := newResponder(s.mockSession, command(), s.clock)
+	r.done()
+
+	s.clock.Advance(autoDeferAfter)
+	s.Equal(responseNone, r.state)
+}
+
+func (s *ResponderTestSuite) TestSlowCommandEditsDeferredResponse() {
+	i := command()
+	r := newResponder(s.mockSession, i, s.clock)
+	defer r.done()
+
+	s.mockSession.EXPECT().
+		InteractionRespond(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, resp *discordgo.InteractionResponse, _ ...discordgo.RequestOption) error {
+			s.Equal(discordgo.InteractionResponseDeferredChannelMessageWithSource, resp.Type)
+			s.Equal(discordgo.MessageFlagsEphemeral, resp.Data.Flags)
+			return nil
+		})
+	s.clock.Advance(autoDeferAfter)
+	s.Equal(responseDeferredMessage, r.state)
+
+	// The handler's own deferral is already done
+	s.Require().NoError(r.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}))
+
+	// And its reply takes the place of the "thinking" message
+	s.mockSession.EXPECT().
+		InteractionResponseEdit(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, edit *discordgo.WebhookEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("Only you can see this", *edit.Content)
+			return &discordgo.Message{}, nil
+		})
+	s.Require().NoError(r.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "Only you can see this",
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	}))
+	s.Equal(responseSent, r.state)
+
+	// Anything more is a follow-up
+	s.mockSession.EXPECT().FollowupMessageCreate(i.Interaction, true, gomock.Any()).Return(&discordgo.Message{}, nil)
+	s.Require().NoError(r.Followup(&discordgo.InteractionResponseData{Content: "And another thing"}))
+}
+
+func (s *ResponderTestSuite) TestPublicReplyAfterPrivateDeferral() {
+	i := command()
+	r := newResponder(s.mockSession, i, s.clock)
+	defer r.done()
+
+	s.expectRespond(i, discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	s.clock.Advance(autoDeferAfter)
+
+	// Editing the "thinking" message would leave the reply hidden from the channel, so it's posted instead
+	gomock.InOrder(
+		s.mockSession.EXPECT().
+			FollowupMessageCreate(i.Interaction, true, gomock.Any()).
+			DoAndReturn(func(_ *discordgo.Interaction, _ bool, params *discordgo.WebhookParams, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+				s.Equal("Everyone can see this", params.Content)
+				s.Zero(params.Flags)
+				return &discordgo.Message{}, nil
+			}),
+		s.mockSession.EXPECT().InteractionResponseDelete(i.Interaction).Return(nil),
+	)
+	s.Require().NoError(r.Respond(&discordgo.InteractionResponseData{Content: "Everyone can see this"}))
+	s.Equal(responseSent, r.state)
+}
+
+func (s *ResponderTestSuite) TestEditComponentMessage() {
+	i := component()
+	r := newResponder(s.mockSession, i, s.clock)
+	defer r.done()
+
+	s.expectRespond(i, discordgo.InteractionResponseUpdateMessage)
+	s.Require().NoError(r.Edit(&discordgo.InteractionResponseData{Content: "Rolled"}))
+	s.Equal(responseSent, r.state)
+}
+
+func (s *ResponderTestSuite) TestSlowComponent() {
+	i := component()
+	r := newResponder(s.mockSession, i, s.clock)
+	defer r.done()
+
+	// The button's message is left as it is until the handler is ready
+	s.expectRespond(i, discordgo.InteractionResponseDeferredMessageUpdate)
+	s.clock.Advance(autoDeferAfter)
+	s.Equal(responseDeferredUpdate, r.state)
+
+	// Updating the message edits it instead
+	s.mockSession.EXPECT().
+		InteractionResponseEdit(i.Interaction, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, edit *discordgo.WebhookEdit, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal("Rolled", *edit.Content)
+			return &discordgo.Message{}, nil
+		})
+	s.Require().NoError(r.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{Content: "Rolled"},
+	}))
+
+	// And a reply is a follow-up
+	s.mockSession.EXPECT().
+		FollowupMessageCreate(i.Interaction, true, gomock.Any()).
+		DoAndReturn(func(_ *discordgo.Interaction, _ bool, params *discordgo.WebhookParams, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+			s.Equal(discordgo.MessageFlagsEphemeral, params.Flags)
+			return &discordgo.Message{}, nil
+		})
+	s.Require().NoError(r.Respond(&discordgo.InteractionResponseData{
+		Content: "You rolled a 6",
+		Flags:   discordgo.MessageFlagsEphemeral,
+	}))
+}
+
+func (s *ResponderTestSuite) TestOtherInteractionsPassThrough() {
+	i := command()
+	r := newResponder(s.mockSession, i, s.clock)
+	defer r.done()
+
+	other := &discordgo.Interaction{ID: "interaction-2"}
+	s.mockSession.EXPECT().InteractionRespond(other, gomock.Any()).Return(nil)
+	s.Require().NoError(r.InteractionRespond(other, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+	}))
+	s.Equal(responseNone, r.state)
+}
@@ -0,0 +1,29 @@
+package discord
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+)
+
+// resumeGames repairs games left stuck by a restart and redraws their messages, so games being played when
+// the bot went down pick up where they left off
+func (b *Bot) resumeGames() {
+	ctx := context.Background()
+
+	output, err := b.gameService.RecoverGames(ctx, &game.RecoverGamesInput{})
+	if err != nil {
+		log.Printf("Error recovering games: %v", err)
+		return
+	}
+
+	for _, g := range output.Games {
+		if g.MessageID == "" {
+			continue
+		}
+		b.updateGameMessage(b.api, g.ChannelID, g.ID)
+	}
+
+	log.Printf("Resumed %d games with %d repairs", len(output.Games), output.Repaired)
+}
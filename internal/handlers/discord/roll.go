@@ -0,0 +1,98 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/bwmarrin/discordgo"
+)
+
+// rollHelp explains the dice notation the roll command understands
+const rollHelp = "Try something like `3d6+2`, `d20-1`, `4d6kh3` (keep the highest 3) or `2d8+d6+3`."
+
+// rollCommand returns the subcommand for rolling dice outside of a game
+func rollCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "roll",
+		Description: "Roll any dice, just for fun, e.g. 3d6+2 or 4d6kh3 (doesn't count in a game)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "dice",
+				Description: "The dice to roll, e.g. 3d6+2, d20-1 or 4d6kh3",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// handleRoll rolls dice notation and shows every die and the total
+func (c *RonniedCommand) handleRoll(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, username string) error {
+	var notation string
+	for _, opt := range subcommand.Options {
+		if opt.Name == "dice" {
+			notation = opt.StringValue()
+		}
+	}
+
+	expression, err := dice.Parse(notation)
+	if err != nil {
+		if errors.Is(err, dice.ErrInvalidNotation) {
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't roll that, %v. %s", err, rollHelp))
+		}
+		return err
+	}
+
+	result := expression.Roll(c.diceRoller)
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{rollResultEmbed(username, result)},
+		},
+	})
+}
+
+// rollResultEmbed shows how each term of a roll came up, dropped dice struck through, and the total
+func rollResultEmbed(username string, result *dice.Result) *discordgo.MessageEmbed {
+	var lines []string
+	for _, term := range result.Terms {
+		lines = append(lines, formatTermResult(term))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🎲 %s rolled %s", username, result.Expression),
+		Description: fmt.Sprintf("%s\n\nTotal: **%d**", strings.Join(lines, "\n"), result.Total),
+		Color:       0x3498DB,
+	}
+}
+
+// formatTermResult lists a term's dice, or its modifier, and what it added
+func formatTermResult(term dice.TermResult) string {
+	sign := "+"
+	if term.Term.Negative {
+		sign = "-"
+	}
+
+	if !term.Term.IsDice() {
+		return fmt.Sprintf("%s %d", sign, term.Term.Modifier)
+	}
+
+	rolls := make([]string, len(term.Rolls))
+	for i, roll := range term.Rolls {
+		rolls[i] = strconv.Itoa(roll)
+		if !term.Kept[i] {
+			rolls[i] = "~~" + rolls[i] + "~~"
+		}
+	}
+
+	total := term.Total
+	if total < 0 {
+		total = -total
+	}
+	return fmt.Sprintf("%s %s: [%s] = %d", sign, term.Term, strings.Join(rolls, ", "), total)
+}
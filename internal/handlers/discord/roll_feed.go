@@ -0,0 +1,217 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxRollLogLines is how many rolls a roll log shows, older rolls scroll off the top
+const maxRollLogLines = 40
+
+// rollFeedModes describes each roll feed mode for the rollfeed command
+var rollFeedModes = map[models.RollFeedMode]string{
+	models.RollFeedOff:      "Off: only the player sees their roll",
+	models.RollFeedMessages: "Compact messages: every roll is posted in the channel",
+	models.RollFeedLog:      "Roll log: one message per game, updated as players roll",
+}
+
+// rollFeedCommand returns the subcommand for the public roll feed setting
+func rollFeedCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "rollfeed",
+		Description: "See or change how rolls are posted in the channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "mode",
+				Description: "How rolls are posted",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Off", Value: string(models.RollFeedOff)},
+					{Name: "Compact messages", Value: string(models.RollFeedMessages)},
+					{Name: "Roll log", Value: string(models.RollFeedLog)},
+				},
+			},
+		},
+	}
+}
+
+// handleRollFeed shows the server's roll feed mode, or changes it
+func (c *RonniedCommand) handleRollFeed(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "The roll feed is not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "The roll feed can only be set up in a server.")
+	}
+
+	var mode models.RollFeedMode
+	for _, opt := range subcommand.Options {
+		if opt.Name == "mode" {
+			mode = models.RollFeedMode(opt.StringValue())
+		}
+	}
+
+	if mode == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the roll feed: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, "**Roll feed**\n"+rollFeedModes[output.Config.RollFeedMode()])
+	}
+
+	output, err := c.guildConfigService.SetRollFeed(ctx, &guild_config.SetRollFeedInput{
+		GuildID: i.GuildID,
+		Mode:    mode,
+	})
+	if err != nil {
+		log.Printf("Error setting roll feed: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the roll feed: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, "**Roll feed updated**\n"+rollFeedModes[output.Config.RollFeedMode()])
+}
+
+// rollLog is the roll log message of a channel's current game
+type rollLog struct {
+	gameID    string
+	messageID string
+	lines     []string
+}
+
+// rollLogs keeps each channel's roll log so it can be edited as players roll.
+// Only the latest game per channel is kept, a new game starts a new log message.
+type rollLogs struct {
+	mu       sync.Mutex
+	channels map[string]*rollLog
+}
+
+// newRollLogs creates an empty set of roll logs
+func newRollLogs() *rollLogs {
+	return &rollLogs{
+		channels: make(map[string]*rollLog),
+	}
+}
+
+// handleDiceRolled posts a roll to the channel if its server has the roll feed turned on
+func (b *Bot) handleDiceRolled(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.DiceRolledPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	// The roll feed is set per server
+	if event.GuildID == "" {
+		return
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: event.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for roll feed: %v", err)
+		return
+	}
+
+	line := renderRollFeedLine(payload)
+	if replacer := emojiReplacer(output.Config); replacer != nil {
+		line = replacer.Replace(line)
+	}
+	switch output.Config.RollFeedMode() {
+	case models.RollFeedMessages:
+		_, err = b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+			Content: line,
+		})
+	case models.RollFeedLog:
+		err = b.appendRollLog(event.ChannelID, payload.RootGameID, line)
+	}
+	if err != nil {
+		log.Printf("Error posting roll to channel %s: %v", event.ChannelID, err)
+	}
+}
+
+// appendRollLog adds a roll to the channel's roll log, posting the log on the game's first roll and editing it after
+func (b *Bot) appendRollLog(channelID, gameID, line string) error {
+	// Held across the Discord call so a game's first rolls can't each post a log of their own
+	b.rollLogs.mu.Lock()
+	defer b.rollLogs.mu.Unlock()
+
+	entry := b.rollLogs.channels[channelID]
+	if entry == nil || entry.gameID != gameID {
+		entry = &rollLog{gameID: gameID}
+	}
+
+	lines := append(entry.lines, line)
+	if len(lines) > maxRollLogLines {
+		lines = lines[len(lines)-maxRollLogLines:]
+	}
+	embed := renderRollLog(lines)
+
+	if entry.messageID == "" {
+		message, err := b.api.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{embed},
+		})
+		if err != nil {
+			return err
+		}
+		entry.messageID = message.ID
+	} else {
+		if _, err := b.api.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:      entry.messageID,
+			Channel: channelID,
+			Embeds:  []*discordgo.MessageEmbed{embed},
+		}); err != nil {
+			return err
+		}
+	}
+
+	entry.lines = lines
+	b.rollLogs.channels[channelID] = entry
+	return nil
+}
+
+// renderRollFeedLine describes a roll in one line for the roll feed
+func renderRollFeedLine(payload *events.DiceRolledPayload) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s **%s** rolled a **%d**", defaultDiceEmoji, payload.PlayerName, payload.RollValue)
+
+	switch {
+	case payload.CriticalHit:
+		sb.WriteString(" 🔥 Critical hit!")
+	case payload.CriticalFail:
+		sb.WriteString(" 💀 Critical fail!")
+	}
+
+	if payload.RollOff {
+		sb.WriteString(" (roll-off)")
+	}
+	if payload.Reroll {
+		sb.WriteString(" (re-roll)")
+	}
+
+	return sb.String()
+}
+
+// renderRollLog builds the roll log embed from its lines, oldest first
+func renderRollLog(lines []string) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       "📜 Roll Log",
+		Description: strings.Join(lines, "\n"),
+		Color:       0x3498DB,
+	}
+}
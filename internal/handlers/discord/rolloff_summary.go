@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// addRollOffSummary shows how a game's roll-offs are going on its message, right below its status, so the
+// players who aren't in them can follow along
+func addRollOffSummary(edit *discordgo.MessageEdit, g *models.Game, rollOffs []*models.Game) {
+	if len(edit.Embeds) == 0 {
+		return
+	}
+
+	field := rollOffSummaryField(g, rollOffs)
+	if field == nil {
+		return
+	}
+
+	embed := edit.Embeds[0]
+	at := min(2, len(embed.Fields))
+	embed.Fields = append(embed.Fields[:at], append([]*discordgo.MessageEmbedField{field}, embed.Fields[at:]...)...)
+}
+
+// rollOffSummaryField lists every roll-off of a game with who has rolled and what, nil when there are none
+func rollOffSummaryField(g *models.Game, rollOffs []*models.Game) *discordgo.MessageEmbedField {
+	games := map[string]*models.Game{g.ID: g}
+	for _, rollOff := range rollOffs {
+		games[rollOff.ID] = rollOff
+	}
+
+	var value strings.Builder
+	for _, rollOff := range rollOffs {
+		if rollOff.Status == models.GameStatusAbandoned {
+			continue
+		}
+
+		// Roll-offs of roll-offs are indented under the one they settle
+		depth := 0
+		parent := games[rollOff.ParentGameID]
+		for p := parent; p != nil && p.ID != g.ID; p = games[p.ParentGameID] {
+			depth++
+		}
+
+		rolled := 0
+		for _, p := range rollOff.Participants {
+			if p.RollTime != nil {
+				rolled++
+			}
+		}
+
+		status := fmt.Sprintf("%d/%d rolled", rolled, len(rollOff.Participants))
+		if rollOff.Status == models.GameStatusCompleted {
+			status = "✅ settled"
+		}
+
+		indent := strings.Repeat("  ", depth)
+		fmt.Fprintf(&value, "%s**%s** (%s)\n", indent, rollOffLabel(rollOff, parent, depth), status)
+		for _, p := range rollOff.Participants {
+			if p.RollTime != nil {
+				fmt.Fprintf(&value, "%s• %s 🎲 **%d**\n", indent, p.PlayerName, p.RollValue)
+			} else {
+				fmt.Fprintf(&value, "%s• %s ⏳ waiting to roll\n", indent, p.PlayerName)
+			}
+		}
+	}
+
+	if value.Len() == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "⚔️ Roll-Offs",
+		Value: strings.TrimSuffix(value.String(), "\n"),
+	}
+}
+
+// rollOffLabel names a roll-off after the tie it settles
+func rollOffLabel(rollOff, parent *models.Game, depth int) string {
+	label := "Roll-off"
+	if parent != nil {
+		switch rollOff.ID {
+		case parent.HighestRollOffGameID:
+			label = "🔺 Highest roll-off"
+		case parent.LowestRollOffGameID:
+			label = "🔻 Lowest roll-off"
+		}
+	}
+
+	if depth > 0 {
+		label = fmt.Sprintf("↳ %s, round %d", label, depth+1)
+	}
+	return label
+}
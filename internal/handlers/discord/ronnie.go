@@ -0,0 +1,164 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// addRonnieButton returns the button for sitting Ronnie down at a game waiting for players
+func addRonnieButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Add Ronnie",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonAddRonnie,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🤖",
+		},
+	}
+}
+
+// ronnieCommand returns the subcommand for picking who drinks the drinks given to Ronnie in the server's games
+func ronnieCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "ronnie",
+		Description: "See or change who drinks the drinks given to Ronnie when he plays",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "drinks",
+				Description: "Who drinks in Ronnie's place",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Bounce: back to whoever gave it to him", Value: string(models.RonnieDrinksBounce)},
+					{Name: "Random: a random player in the game", Value: string(models.RonnieDrinksRandom)},
+				},
+			},
+		},
+	}
+}
+
+// handleRonnie shows who drinks the drinks given to Ronnie in the server's games, or changes it
+func (c *RonniedCommand) handleRonnie(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Ronnie's settings are not enabled on this bot.")
+	}
+
+	var rule models.RonnieDrinkRule
+	for _, opt := range subcommand.Options {
+		if opt.Name == "drinks" {
+			rule = models.RonnieDrinkRule(opt.StringValue())
+		}
+	}
+
+	if rule == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get Ronnie's drink rule: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, describeRonnieDrinks(output.Config.RonnieDrinks))
+	}
+
+	output, err := c.guildConfigService.SetRonnieDrinks(ctx, &guild_config.SetRonnieDrinksInput{
+		GuildID: i.GuildID,
+		Rule:    rule,
+	})
+	if err != nil {
+		log.Printf("Error setting Ronnie's drink rule: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change Ronnie's drink rule: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, describeRonnieDrinks(output.Config.RonnieDrinks)+" New games will play it.")
+}
+
+// describeRonnieDrinks says who drinks the drinks given to Ronnie
+func describeRonnieDrinks(rule models.RonnieDrinkRule) string {
+	if rule == models.RonnieDrinksRandom {
+		return "🤖 Ronnie can't drink, so drinks given to him go to a random player in the game."
+	}
+	return "🤖 Ronnie can't drink, so drinks given to him bounce back to whoever gave them, and his own go to a random player."
+}
+
+// ronnieRule is the game rules line for games Ronnie is playing in, empty for games without him
+func ronnieRule(g *models.Game, rules models.GameRules) string {
+	if !g.HasRonnie() {
+		return ""
+	}
+	if rules.RonnieDrinks == models.RonnieDrinksRandom {
+		return "\n• Ronnie's playing! His drinks go to a random player 🤖"
+	}
+	return "\n• Ronnie's playing! Drinks given to him bounce back 🤖"
+}
+
+// handleAddRonnieButton sits Ronnie down at the game waiting for players in the channel
+func (b *Bot) handleAddRonnieButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, "No game found in this channel.")
+	}
+
+	_, err = b.gameService.AddRonnie(ctx, &game.AddRonnieInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrPlayerNotInGame):
+			return RespondWithEphemeralMessage(s, i, "Join the game first, then Ronnie will pull up a stool.")
+		case errors.Is(err, game.ErrPlayerAlreadyInGame):
+			return RespondWithEphemeralMessage(s, i, "Ronnie's already at the table.")
+		}
+		log.Printf("Error adding Ronnie: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Ronnie couldn't make it"))
+	}
+
+	b.updateGameMessage(s, channelID, existingGame.Game.ID)
+
+	return RespondWithEphemeralMessage(s, i, "🤖 Ronnie pulls up a stool. He'll roll on his own once the game starts.")
+}
+
+// handleRonnieRolled shows Ronnie's roll in the channel and redraws the games it changed
+func (b *Bot) handleRonnieRolled(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.RonnieRolledPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	for _, gameID := range payload.GameIDs {
+		b.updateGameMessage(b.api, event.ChannelID, gameID)
+	}
+
+	message := fmt.Sprintf("🤖 Ronnie rolled a **%d**.", payload.RollValue)
+	switch {
+	case payload.CriticalHit && payload.DrinkToName != "":
+		message = fmt.Sprintf("🤖 Ronnie rolled a **%d**, critical hit! He gives his drink to **%s**. 🍺", payload.RollValue, isolateName(payload.DrinkToName))
+	case payload.CriticalHit:
+		message = fmt.Sprintf("🤖 Ronnie rolled a **%d**, critical hit!", payload.RollValue)
+	case payload.CriticalFail:
+		message = fmt.Sprintf("🤖 Ronnie rolled a **%d**, critical fail! He can't drink it, so someone else will. 💀", payload.RollValue)
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Content: message,
+	})
+	if err != nil {
+		log.Printf("Error showing Ronnie's roll in channel %s: %v", event.ChannelID, err)
+	}
+}
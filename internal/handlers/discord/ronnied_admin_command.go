@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"errors"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// RonniedAdminCommand handles the ronnied-admin command, the subcommands only server admins use
+type RonniedAdminCommand struct {
+	BaseCommand
+
+	// ronnied handles the subcommands, they share its services
+	ronnied *RonniedCommand
+}
+
+// NewRonniedAdminCommand creates the ronnied-admin command handler, handing its subcommands to the ronnied command
+func NewRonniedAdminCommand(ronnied *RonniedCommand) *RonniedAdminCommand {
+	permissions := adminPermissions
+	return &RonniedAdminCommand{
+		BaseCommand: BaseCommand{
+			Name:        "ronnied-admin",
+			Description: "Dice rolling drinking game commands for server admins",
+			Options: []*discordgo.ApplicationCommandOption{
+				setupCommand(),
+				forgiveCommand(),
+				socialCommand(),
+				pinboardCommand(),
+				gameChannelsCommand(),
+				tiesCommand(),
+				ronnieCommand(),
+				mergeCommand(),
+				purgeUserCommand(),
+				cleanupCommand(),
+				diagCommand(),
+				webhookCommandGroup(),
+				newSessionAdminCommand(),
+				presetAdminCommandGroup(),
+				rerollAdminCommandGroup(),
+				seasonalAdminCommandGroup(),
+				rollFeedCommand(),
+				displayCommand(),
+				emojiCommand(),
+				personaCommand(),
+				soundboardCommand(),
+				ratingCommand(),
+				banterCommand(),
+				critsCommand(),
+				flagsCommand(),
+			},
+			Permissions: &permissions,
+			GuildOnly:   true,
+		},
+		ronnied: ronnied,
+	}
+}
+
+// Handle processes a Discord interaction for the ronnied-admin command
+func (c *RonniedAdminCommand) Handle(s DiscordSession, i *discordgo.InteractionCreate) error {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return nil
+	}
+
+	data := i.ApplicationCommandData()
+	if data.Name != c.Name {
+		return nil
+	}
+
+	// Discord only shows the command to admins, but a server can hand it to other roles in its Integrations
+	// settings and the subcommands change everyone's drinks
+	if !c.Allowed(i) {
+		return RespondWithEphemeralMessage(s, i, "Only server admins can use `/ronnied-admin`.")
+	}
+
+	channelID := i.ChannelID
+	userID := i.Member.User.ID
+	username := i.Member.User.Username
+	if i.Member.Nick != "" {
+		username = i.Member.Nick
+	}
+
+	var err error
+	switch data.Options[0].Name {
+	case "setup":
+		err = c.ronnied.handleSetup(s, i)
+	case "forgive":
+		err = c.ronnied.handleForgive(s, i, data.Options[0])
+	case "social":
+		err = c.ronnied.handleSocial(s, i, channelID, userID, username)
+	case "pinboard":
+		err = c.ronnied.handlePinboard(s, i, data.Options[0])
+	case "channels":
+		err = c.ronnied.handleGameChannels(s, i, data.Options[0])
+	case "ties":
+		err = c.ronnied.handleTies(s, i, data.Options[0])
+	case "ronnie":
+		err = c.ronnied.handleRonnie(s, i, data.Options[0])
+	case "merge":
+		err = c.ronnied.handleMerge(s, i, data.Options[0])
+	case "purge-user":
+		err = c.ronnied.handlePurgeUser(s, i, data.Options[0])
+	case "cleanup":
+		err = c.ronnied.handleCleanup(s, i)
+	case "diag":
+		err = c.ronnied.handleDiag(s, i)
+	case "webhook":
+		err = c.ronnied.handleWebhook(s, i, data.Options[0])
+	case "newsession":
+		err = c.ronnied.handleNewSession(s, i, data.Options[0], channelID, userID)
+	case "preset":
+		err = c.ronnied.handlePreset(s, i, data.Options[0])
+	case "reroll":
+		err = c.ronnied.handleReroll(s, i, data.Options[0], userID)
+	case "seasonal":
+		err = c.ronnied.handleSeasonal(s, i, data.Options[0])
+	case "rollfeed":
+		err = c.ronnied.handleRollFeed(s, i, data.Options[0])
+	case "display":
+		err = c.ronnied.handleDisplay(s, i, data.Options[0])
+	case "emoji":
+		err = c.ronnied.handleEmoji(s, i, data.Options[0])
+	case "persona":
+		err = c.ronnied.handlePersona(s, i, data.Options[0])
+	case "soundboard":
+		err = c.ronnied.handleSoundboard(s, i, data.Options[0])
+	case "rating":
+		err = c.ronnied.handleRating(s, i, data.Options[0])
+	case "banter":
+		err = c.ronnied.handleBanter(s, i, data.Options[0])
+	case "crits":
+		err = c.ronnied.handleCrits(s, i, data.Options[0])
+	case "flags":
+		err = c.ronnied.handleFlags(s, i, data.Options[0])
+	default:
+		err = errors.New("unknown subcommand")
+	}
+
+	return err
+}
@@ -9,28 +9,76 @@ import (
 	"strings"
 	"time"
 
+	"github.com/KirkDiggler/ronnied/internal/dice"
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
 	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/KirkDiggler/ronnied/internal/services/preset"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	"github.com/KirkDiggler/ronnied/internal/services/webhook"
 	"github.com/bwmarrin/discordgo"
 )
 
 // RonniedCommand handles the /ronnied command
 type RonniedCommand struct {
 	BaseCommand
-	gameService game.Service
+	gameService        game.Service
+	webhookService     webhook.Service
+	presetService      preset.Service
+	seasonalService    seasonal.Service
+	guildConfigService guild_config.Service
+	featureFlagService feature_flag.Service
+
+	// diceRoller rolls dice for the roll command, outside of any game
+	diceRoller dice.Roller
+
+	// feed posts new games under the server's persona, nil posts them as the bot
+	feed *gameFeed
+
+	// diagnostics checks the bot's setup for the diag command, nil until the bot has registered its commands
+	diagnostics *diagnostics
+
+	// botUserID is the bot's own user ID, which authors the game messages it posts
+	botUserID string
 }
 
-// NewRonniedCommand creates a new ronnied command handler
-func NewRonniedCommand(gameService game.Service) *RonniedCommand {
+// NewRonniedCommand creates a new ronnied command handler, the webhook, preset, seasonal, guild config and feature flag
+// services are optional
+func NewRonniedCommand(gameService game.Service, webhookService webhook.Service, presetService preset.Service, seasonalService seasonal.Service, guildConfigService guild_config.Service, featureFlagService feature_flag.Service) *RonniedCommand {
 	return &RonniedCommand{
 		BaseCommand: BaseCommand{
 			Name:        "ronnied",
 			Description: "Dice rolling drinking game commands",
+			GuildOnly:   true,
 			Options: []*discordgo.ApplicationCommandOption{
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "start",
 					Description: "Create a new game",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "preset",
+							Description: "Play with a saved preset (see /ronnied preset list)",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "countdown",
+							Description: "Everyone rolls at once after a 3-2-1 countdown, rolling early or late costs a drink",
+						},
+						roundsOption(),
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "private",
+							Description: "Only players you invite can join",
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "invite",
+							Description: "Players to invite to a private game, e.g. @bob @carol",
+						},
+					},
 				},
 				{
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
@@ -47,14 +95,37 @@ func NewRonniedCommand(gameService game.Service) *RonniedCommand {
 					Name:        "abandon",
 					Description: "Abandon the current game",
 				},
+				presetCommandGroup(),
+				driverCommandGroup(),
+				rerollCommandGroup(),
+				seasonalCommandGroup(),
+				forgetMeCommand(),
+				lastCallCommand(),
+				breakCommand(),
+				tabCommand(),
+				challengeCommand(),
+				rollCommand(),
+				clipCommand(),
+				momentsCommand(),
+				birthdayCommand(),
+				remindersCommand(),
+				hostCommand(),
+				voteCommand(),
+				fairnessCommand(),
 			},
 		},
-		gameService: gameService,
+		gameService:        gameService,
+		webhookService:     webhookService,
+		presetService:      presetService,
+		seasonalService:    seasonalService,
+		guildConfigService: guildConfigService,
+		featureFlagService: featureFlagService,
+		diceRoller:         dice.New(&dice.Config{}),
 	}
 }
 
 // Handle processes a Discord interaction for the ronnied command
-func (c *RonniedCommand) Handle(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (c *RonniedCommand) Handle(s DiscordSession, i *discordgo.InteractionCreate) error {
 	if i.Type != discordgo.InteractionApplicationCommand {
 		return nil
 	}
@@ -76,13 +147,47 @@ func (c *RonniedCommand) Handle(s *discordgo.Session, i *discordgo.InteractionCr
 	var err error
 	switch data.Options[0].Name {
 	case "start":
-		err = c.handleStart(s, i, channelID, userID, username)
+		err = c.handleStart(s, i, data.Options[0], channelID, userID, username)
 	case "leaderboard":
 		err = c.handleSessionboard(s, i, channelID)
 	case "newsession":
-		err = c.handleNewSession(s, i, channelID)
+		err = c.handleNewSession(s, i, data.Options[0], channelID, userID)
 	case "abandon":
 		err = c.handleAbandon(s, i, channelID, userID)
+	case "preset":
+		err = c.handlePreset(s, i, data.Options[0])
+	case "driver":
+		err = c.handleDriver(s, i, data.Options[0], channelID, userID, username)
+	case "reroll":
+		err = c.handleReroll(s, i, data.Options[0], userID)
+	case "seasonal":
+		err = c.handleSeasonal(s, i, data.Options[0])
+	case "forgetme":
+		err = c.handleForgetMe(s, i)
+	case "lastcall":
+		err = c.handleLastCall(s, i, channelID, userID)
+	case "break":
+		err = c.handleBreak(s, i, data.Options[0], channelID, userID)
+	case "tab":
+		err = c.handleTab(s, i, data.Options[0], channelID, userID)
+	case "challenge":
+		err = c.handleChallenge(s, i, data.Options[0], channelID, userID, username)
+	case "roll":
+		err = c.handleRoll(s, i, data.Options[0], username)
+	case "clip":
+		err = c.handleClip(s, i, channelID, userID, username)
+	case "moments":
+		err = c.handleMoments(s, i, data.Options[0], channelID)
+	case "birthday":
+		err = c.handleBirthday(s, i, data.Options[0], userID, username)
+	case "reminders":
+		err = c.handleReminders(s, i, data.Options[0], userID, username)
+	case "host":
+		err = c.handleHost(s, i, data.Options[0], channelID, userID)
+	case "vote":
+		err = c.handleVote(s, i, data.Options[0], channelID, userID)
+	case "fairness":
+		err = c.handleFairness(s, i, channelID)
 	default:
 		err = errors.New("unknown subcommand")
 	}
@@ -91,11 +196,62 @@ func (c *RonniedCommand) Handle(s *discordgo.Session, i *discordgo.InteractionCr
 }
 
 // handleStart handles the start subcommand
-func (c *RonniedCommand) handleStart(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID, username string) error {
+func (c *RonniedCommand) handleStart(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID, username string) error {
 	ctx := context.Background()
 
+	// Servers can keep games to some channels
+	if redirect := gameChannelRedirect(ctx, s, c.guildConfigService, i.GuildID, channelID); redirect != "" {
+		return RespondWithEphemeralMessage(s, i, redirect)
+	}
+
+	// Look up the preset to play with, if one was picked
+	var gamePreset *models.Preset
+	countdown := false
+	rounds := 0
+	private := false
+	var invited []string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "countdown":
+			countdown = opt.BoolValue()
+			continue
+		case "rounds":
+			rounds = int(opt.IntValue())
+			continue
+		case "private":
+			private = opt.BoolValue()
+			continue
+		case "invite":
+			invited = mentionedUserIDs(opt.StringValue())
+			if len(invited) == 0 {
+				return RespondWithEphemeralMessage(s, i, "Mention the players to invite, e.g. @bob @carol.")
+			}
+			continue
+		}
+		if opt.Name != "preset" {
+			continue
+		}
+
+		if c.presetService == nil || i.GuildID == "" {
+			return RespondWithEphemeralMessage(s, i, "Presets are not enabled here.")
+		}
+
+		presetOutput, err := c.presetService.GetPreset(ctx, &preset.GetPresetInput{
+			GuildID: i.GuildID,
+			Name:    opt.StringValue(),
+		})
+		if err != nil {
+			if errors.Is(err, preset.ErrPresetNotFound) {
+				return RespondWithEphemeralMessage(s, i, fmt.Sprintf("There's no preset called `%s`. See `/ronnied preset list`.", opt.StringValue()))
+			}
+			log.Printf("Error getting preset: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Error getting preset: %v", err))
+		}
+		gamePreset = presetOutput.Preset
+	}
+
 	// Check if there's already a game in this channel
-	existingGame, err := c.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	_, err := c.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
@@ -109,21 +265,38 @@ func (c *RonniedCommand) handleStart(s *discordgo.Session, i *discordgo.Interact
 			log.Printf("Error checking for existing game: %v", err)
 			return RespondWithError(s, i, fmt.Sprintf("Error checking for existing game: %v", err))
 		}
-	} else if existingGame != nil && existingGame.Game != nil {
-		// There's an existing game, check if it's active or waiting
-		if existingGame.Game.Status == models.GameStatusActive || existingGame.Game.Status == models.GameStatusWaiting || existingGame.Game.Status == models.GameStatusRollOff {
-			return RespondWithError(s, i, "There's already a game in progress in this channel. Use `/ronnied abandon` to clear it if needed.")
-		}
-		// If the game exists but is completed, we can proceed to create a new game
+	} else {
+		// There's a game still being played
+		return RespondWithError(s, i, "There's already a game in progress in this channel. Use `/ronnied abandon` to clear it if needed.")
 	}
 
 	// Create a new game
-	createOutput, err := c.gameService.CreateGame(ctx, &game.CreateGameInput{
-		ChannelID:   channelID,
-		CreatorID:   userID,
-		CreatorName: username,
-	})
+	createInput := &game.CreateGameInput{
+		ChannelID:        channelID,
+		GuildID:          i.GuildID,
+		CreatorID:        userID,
+		CreatorName:      username,
+		Countdown:        countdown,
+		Rounds:           rounds,
+		Private:          private,
+		InvitedPlayerIDs: invited,
+	}
+	if gamePreset != nil {
+		createInput.Rules = &gamePreset.Rules
+	}
+	createInput.Rules = c.withGuildRules(ctx, i.GuildID, createInput.Rules)
+
+	createOutput, err := c.gameService.CreateGame(ctx, createInput)
 	if err != nil {
+		if errors.Is(err, game.ErrLastCallGameStarted) {
+			return RespondWithEphemeralMessage(s, i, "It's last call and the final game has already started. The session closes when it ends.")
+		}
+		if errors.Is(err, game.ErrSessionPaused) {
+			return RespondWithEphemeralMessage(s, i, "The session is on a break. End it with `/ronnied break action:end` to start a game.")
+		}
+		if errors.Is(err, game.ErrCountdownRounds) {
+			return RespondWithEphemeralMessage(s, i, "Countdown games are a single round, pick either `countdown` or `rounds`.")
+		}
 		log.Printf("Error creating game: %v", err)
 		return RespondWithError(s, i, fmt.Sprintf("Failed to create game: %v", err))
 	}
@@ -171,24 +344,72 @@ func (c *RonniedCommand) handleStart(s *discordgo.Session, i *discordgo.Interact
 			Inline: true,
 		},
 	}
+	if gamePreset != nil {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Preset",
+			Value:  fmt.Sprintf("%s (%s)", gamePreset.Name, describeRules(gamePreset.Rules)),
+			Inline: false,
+		})
+	}
+	if countdown {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Mode",
+			Value:  "⏱️ Countdown: everyone rolls at once, rolling early or late costs a drink",
+			Inline: false,
+		})
+	}
+	if rounds > 0 {
+		fields = append(fields, roundsModeField(rounds))
+	}
+
+	// Private games let their creator invite more players from the start
+	buttons := []discordgo.MessageComponent{joinButton, startButton}
+	if private || len(invited) > 0 {
+		table := &models.Game{CreatorID: userID, Private: true}
+		table.Invite(invited...)
+		fields = append(fields, privateTableField(table))
+		buttons = append(buttons, invitePlayersButton())
+	}
+
+	gameEmbeds := []*discordgo.MessageEmbed{
+		{
+			Title:       "New Game Started!",
+			Description: "Click the Join button to join the game. Once everyone has joined, the creator can click Begin to start the game.",
+			Color:       0x00ff00, // Green color
+			Fields:      fields,
+		},
+	}
+	gameComponents := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: buttons,
+		},
+	}
+
+	// Servers with a persona get the game posted under it, and the creator is told it's up
+	msg, err := c.feed.post(ctx, s, i.GuildID, channelID, &discordgo.MessageSend{
+		Embeds:     gameEmbeds,
+		Components: gameComponents,
+	})
+	if err != nil {
+		log.Printf("Error posting game message under the persona, posting it as the bot: %v", err)
+	}
+	if msg != nil {
+		_, err = c.gameService.UpdateGameMessage(ctx, &game.UpdateGameMessageInput{
+			GameID:    createOutput.GameID,
+			MessageID: msg.ID,
+		})
+		if err != nil {
+			log.Printf("Error updating game message ID: %v", err)
+		}
+		return RespondWithEphemeralMessage(s, i, "🎲 Your game is up, get everyone to join!")
+	}
 
 	// Send the response message
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title:       "New Game Started!",
-					Description: "Click the Join button to join the game. Once everyone has joined, the creator can click Begin to start the game.",
-					Color:       0x00ff00, // Green color
-					Fields:      fields,
-				},
-			},
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{joinButton, startButton},
-				},
-			},
+			Embeds:     gameEmbeds,
+			Components: gameComponents,
 		},
 	})
 	if err != nil {
@@ -208,7 +429,7 @@ func (c *RonniedCommand) handleStart(s *discordgo.Session, i *discordgo.Interact
 	} else {
 		// Find our message (should be the most recent one)
 		for _, msg := range messages {
-			if msg.Author.ID == s.State.User.ID {
+			if msg.Author != nil && msg.Author.ID == c.botUserID {
 				// Update the game with the message ID
 				_, err = c.gameService.UpdateGameMessage(ctx, &game.UpdateGameMessageInput{
 					GameID:    createOutput.GameID,
@@ -227,20 +448,22 @@ func (c *RonniedCommand) handleStart(s *discordgo.Session, i *discordgo.Interact
 }
 
 // handleSessionboard handles the sessionboard subcommand
-func (c *RonniedCommand) handleSessionboard(s *discordgo.Session, i *discordgo.InteractionCreate, channelID string) error {
+func (c *RonniedCommand) handleSessionboard(s DiscordSession, i *discordgo.InteractionCreate, channelID string) error {
 	ctx := context.Background()
 
 	// Get the session leaderboard
 	sessionboard, err := c.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
 		ChannelID: channelID,
+		GuildID:   i.GuildID,
 	})
 	if err != nil {
 		log.Printf("Error getting session leaderboard: %v", err)
 		return RespondWithError(s, i, fmt.Sprintf("Failed to get session leaderboard: %v", err))
 	}
 
-	// Build the session leaderboard description
+	// Build the session leaderboard description, its numbers written for the server's locale
 	var description strings.Builder
+	locale := interactionLocale(i)
 	
 	// Session info header
 	if sessionboard.Session != nil {
@@ -254,39 +477,23 @@ func (c *RonniedCommand) handleSessionboard(s *discordgo.Session, i *discordgo.I
 			sessionCreatedAt, 
 			now)
 		
-		// Always show session creation time for reference
-			description.WriteString(fmt.Sprintf("🍻 **Session Started:** %s\n", 
-				sessionCreatedAt.Format("Jan 2 at 3:04 PM")))
-			
-			// Calculate and format the age
-			sessionAge := now.Sub(sessionCreatedAt)
-			
-			// Only show age if it's a reasonable value (positive and less than a week)
-			if sessionAge > 0 && sessionAge < 7*24*time.Hour {
-				// Format the duration in a human-readable way
-				var formattedAge string
-				hours := int(sessionAge.Hours())
-				minutes := int(sessionAge.Minutes()) % 60
-				
-				if hours > 0 {
-					if hours == 1 {
-						formattedAge = "1 hour"
-					} else {
-						formattedAge = fmt.Sprintf("%d hours", hours)
-					}
-					
-					if minutes > 0 {
-						formattedAge += fmt.Sprintf(" %d min", minutes)
-					}
-				} else if minutes > 0 {
-					formattedAge = fmt.Sprintf("%d minutes", minutes)
-				} else {
-					formattedAge = "just started"
-				}
-				
-				description.WriteString(fmt.Sprintf(" (%s ago)\n\n", formattedAge))
-			} else {
-				description.WriteString("\n\n")
+		// Discord writes the time in each reader's own language and time zone
+			description.WriteString(fmt.Sprintf("🍻 **Session Started:** %s (%s)\n\n", 
+				discordTimestamp(sessionCreatedAt, timestampDateTime), discordTimestamp(sessionCreatedAt, timestampRelative)))
+
+			// Breaks don't count towards the drink rates
+			if pause := sessionboard.Session.CurrentPause(); pause != nil {
+				description.WriteString(fmt.Sprintf("⏸️ **On a break** since %s\n\n", discordTimestamp(pause.Start, timestampShortTime)))
+			}
+
+			// And show the challenges the session is played with
+			if challenges := renderSessionGoals(sessionboard.Session.Goals); challenges != "" {
+				description.WriteString(challenges + "\n")
+			}
+
+			// And what's waiting in the jackpot
+			if jackpot := jackpotLine(sessionboard.Session); jackpot != "" {
+				description.WriteString(jackpot + "\n")
 			}
 		}
 	
@@ -351,10 +558,35 @@ func (c *RonniedCommand) handleSessionboard(s *discordgo.Session, i *discordgo.I
 				}
 			}
 			
+			// Drinks waived by the mercy rule
+			if entry.SocialCount > 0 {
+				paymentStatus += fmt.Sprintf(" 🤝 %d social", entry.SocialCount)
+			}
+			
+			// Drinks written off by an admin
+			if entry.ForgivenCount > 0 {
+				paymentStatus += fmt.Sprintf(" 🙏 %d forgiven", entry.ForgivenCount)
+			}
+			
+			// Players sitting out drinks this session
+			if entry.DesignatedDriver {
+				paymentStatus += " 🚗 designated driver"
+			}
+			
+			// How fast they're drinking, once the session has run a while
+			if entry.DrinksPerHour > 0 {
+				paymentStatus += fmt.Sprintf(" ⏱️ %s/hr", formatDecimal(locale, entry.DrinksPerHour, 1))
+			}
+			
+			// How evenly they've handed out the drinks they chose to give
+			if karma := formatKarma(entry.Karma); karma != "" {
+				paymentStatus += " " + karma
+			}
+			
 			// Add the entry with all components
 			description.WriteString(fmt.Sprintf("%s **%s**: %d drinks%s\n%s\n\n", 
 				rankEmoji, 
-				entry.PlayerName, 
+				isolateName(entry.PlayerName), 
 				entry.DrinkCount,
 				paymentStatus,
 				progressBar))
@@ -391,13 +623,19 @@ func (c *RonniedCommand) handleSessionboard(s *discordgo.Session, i *discordgo.I
 	return RespondWithEmbed(s, i, "🍻 Session Leaderboard 🍻", description.String(), fields)
 }
 
-// handleNewSession handles the newsession subcommand
-func (c *RonniedCommand) handleNewSession(s *discordgo.Session, i *discordgo.InteractionCreate, channelID string) error {
+// handleNewSession handles the newsession subcommand, the challenges the session is played with are only picked on
+// the admin command
+func (c *RonniedCommand) handleNewSession(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID string) error {
 	ctx := context.Background()
 
+	goals := sessionGoalsFromOptions(subcommand.Options)
+
 	// Start a new session
-	_, err := c.gameService.StartNewSession(ctx, &game.StartNewSessionInput{
+	output, err := c.gameService.StartNewSession(ctx, &game.StartNewSessionInput{
 		ChannelID: channelID,
+		GuildID:   i.GuildID,
+		CreatorID: userID,
+		Goals:     goals,
 	})
 	if err != nil {
 		log.Printf("Error starting new session: %v", err)
@@ -405,15 +643,18 @@ func (c *RonniedCommand) handleNewSession(s *discordgo.Session, i *discordgo.Int
 	}
 
 	// Respond with success message
+	if challenges := renderSessionGoals(output.Session.Goals); challenges != "" {
+		return RespondWithMessage(s, i, "New session started successfully. This session's challenges:\n"+challenges)
+	}
 	return RespondWithMessage(s, i, "New session started successfully.")
 }
 
 // handleAbandon handles the abandon subcommand
-func (c *RonniedCommand) handleAbandon(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID string) error {
+func (c *RonniedCommand) handleAbandon(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
 	ctx := context.Background()
 
 	// Get the game in this channel
-	existingGame, err := c.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+	existingGame, err := c.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
 		ChannelID: channelID,
 	})
 
@@ -428,9 +669,13 @@ func (c *RonniedCommand) handleAbandon(s *discordgo.Session, i *discordgo.Intera
 
 	// Abandon the game
 	_, err = c.gameService.AbandonGame(ctx, &game.AbandonGameInput{
-		GameID: existingGame.Game.ID,
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
 	})
 	if err != nil {
+		if errors.Is(err, game.ErrNotCreator) {
+			return RespondWithEphemeralMessage(s, i, "Only the game's hosts can abandon it while it's being played. Call a vote with `/ronnied vote action:abandon`, or abandon it yourself once it's been left idle for a few minutes.")
+		}
 		log.Printf("Error abandoning game: %v", err)
 		return RespondWithError(s, i, fmt.Sprintf("Failed to abandon game: %v", err))
 	}
@@ -440,12 +685,13 @@ func (c *RonniedCommand) handleAbandon(s *discordgo.Session, i *discordgo.Intera
 }
 
 // handlePay handles the pay button interaction
-func (c *RonniedCommand) handlePay(s *discordgo.Session, i *discordgo.InteractionCreate, channelID, userID string, count int) error {
+func (c *RonniedCommand) handlePay(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string, count int) error {
 	ctx := context.Background()
 
 	// Get the game in this channel
 	existingGame, err := c.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
 		ChannelID: channelID,
+		GuildID:   i.GuildID,
 	})
 
 	// Handle errors or missing game
@@ -457,12 +703,14 @@ func (c *RonniedCommand) handlePay(s *discordgo.Session, i *discordgo.Interactio
 		return RespondWithError(s, i, fmt.Sprintf("Error getting game: %v", err))
 	}
 
-	// Track how many drinks were successfully paid
+	// Track how many drinks were successfully paid, and how many were banked as credits
 	paidCount := 0
+	creditBalance := 0
+	creditedCount := 0
 	
 	// Pay one drink at a time
 	for j := 0; j < count; j++ {
-		_, err := c.gameService.PayDrink(ctx, &game.PayDrinkInput{
+		payOutput, err := c.gameService.PayDrink(ctx, &game.PayDrinkInput{
 			GameID:   existingGame.Game.ID,
 			PlayerID: userID,
 		})
@@ -482,9 +730,22 @@ func (c *RonniedCommand) handlePay(s *discordgo.Session, i *discordgo.Interactio
 			return RespondWithError(s, i, fmt.Sprintf("Failed to pay drinks: %v", err))
 		}
 		
+		if payOutput.Credited {
+			creditedCount++
+			creditBalance = payOutput.CreditBalance
+			continue
+		}
 		paidCount++
 	}
 
+	// Drinks paid beyond what was owed were banked as credits
+	if creditedCount > 0 {
+		if paidCount == 0 {
+			return RespondWithMessage(s, i, creditBankedMessage(creditBalance))
+		}
+		return RespondWithMessage(s, i, fmt.Sprintf("You've paid %d drinks. Cheers! 🍻 %s", paidCount, creditBankedMessage(creditBalance)))
+	}
+
 	// Respond with success message
 	if paidCount == 1 {
 		return RespondWithMessage(s, i, "You've paid 1 drink. Cheers! 🍻")
@@ -0,0 +1,146 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/bwmarrin/discordgo"
+)
+
+// rotationReasons describes each session rotation reason for the closing message
+var rotationReasons = map[string]string{
+	"daily":      "Last call! The night is over",
+	"inactivity": "Things went quiet for a while",
+	"last_call":  "Last call has been answered",
+}
+
+// handleSessionRotated posts the closing leaderboard of a rotated session to its channel
+func (b *Bot) handleSessionRotated(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.SessionRotatedPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	embed := renderSessionRotated(payload, b.locales.get(event.GuildID))
+	msg := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	}
+
+	if b.config.LeaderboardImages && len(payload.Standings) > 0 {
+		file, err := b.leaderboardImageFile(payload.Standings)
+		if err != nil {
+			log.Printf("Error rendering leaderboard image for session %s, posting text instead: %v", payload.PreviousSessionID, err)
+		} else {
+			msg.Files = []*discordgo.File{file}
+			embed.Image = &discordgo.MessageEmbedImage{URL: "attachment://" + leaderboardImageName}
+
+			// The image replaces the text standings when everyone fits on it
+			if len(payload.Standings) <= maxLeaderboardImageRows {
+				embed.Fields = embed.Fields[1:]
+			}
+		}
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, msg)
+	if err != nil {
+		log.Printf("Error posting closing leaderboard for session %s: %v", payload.PreviousSessionID, err)
+	}
+}
+
+// renderSessionRotated builds the closing leaderboard embed for a rotated session, its numbers written for the
+// server's locale
+func renderSessionRotated(payload *events.SessionRotatedPayload, locale discordgo.Locale) *discordgo.MessageEmbed {
+	reason, ok := rotationReasons[payload.Reason]
+	if !ok {
+		reason = "Time for a fresh start"
+	}
+
+	var standings strings.Builder
+	var totalDrinks, totalPaid int
+	for i, standing := range payload.Standings {
+		totalDrinks += standing.DrinkCount
+		totalPaid += standing.PaidCount
+
+		rank := "•"
+		switch i {
+		case 0:
+			rank = "🥇"
+		case 1:
+			rank = "🥈"
+		case 2:
+			rank = "🥉"
+		}
+
+		fmt.Fprintf(&standings, "%s **%s**: %d drinks (%d paid)", rank, isolateName(standing.PlayerName), standing.DrinkCount, standing.PaidCount)
+		if standing.SocialCount > 0 {
+			fmt.Fprintf(&standings, " 🤝 %d social", standing.SocialCount)
+		}
+		if standing.ForgivenCount > 0 {
+			fmt.Fprintf(&standings, " 🙏 %d forgiven", standing.ForgivenCount)
+		}
+		if standing.DesignatedDriver {
+			standings.WriteString(" 🚗 designated driver")
+		}
+		standings.WriteString("\n")
+	}
+
+	totals := fmt.Sprintf("%d drinks, %d paid", totalDrinks, totalPaid)
+	if !payload.StartedAt.IsZero() && !payload.EndedAt.IsZero() {
+		totals += fmt.Sprintf("\nRan from %s to %s", discordTimestamp(payload.StartedAt, timestampShortTime), discordTimestamp(payload.EndedAt, timestampShortTime))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🌅 Session Closed",
+		Description: reason + " - here's how the last session ended. A new one has started, tallies are back to zero.",
+		Color:       0x9B59B6,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Final Standings",
+				Value: standings.String(),
+			},
+			{
+				Name:  "Totals",
+				Value: totals,
+			},
+		},
+	}
+
+	if pace := renderSessionPace(payload.Standings, locale); pace != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Pace",
+			Value: pace,
+		})
+	}
+
+	return embed
+}
+
+// renderSessionPace lists each player's drinks per hour and longest dry streak, empty when the session was too short
+// for them to be worked out
+func renderSessionPace(standings []*events.SessionStanding, locale discordgo.Locale) string {
+	var pace strings.Builder
+	for _, standing := range standings {
+		if standing.DrinksPerHour == 0 && standing.LongestDryStreak == 0 {
+			continue
+		}
+		fmt.Fprintf(&pace, "**%s**: ⏱️ %s/hr, 🏜️ longest dry streak %s\n",
+			isolateName(standing.PlayerName), formatDecimal(locale, standing.DrinksPerHour, 1), formatStreak(standing.LongestDryStreak))
+	}
+	return pace.String()
+}
+
+// formatStreak formats a duration in hours and minutes, e.g. "1h 05m" or "45m"
+func formatStreak(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", hours, minutes)
+}
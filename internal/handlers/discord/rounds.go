@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// roundsOption returns the start option for playing a game over several rounds
+func roundsOption() *discordgo.ApplicationCommandOption {
+	minRounds := float64(models.MinGameRounds)
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionInteger,
+		Name:        "rounds",
+		Description: "Play this many rounds in a row, the lowest total over all of them takes a bonus drink",
+		MinValue:    &minRounds,
+		MaxValue:    models.MaxGameRounds,
+	}
+}
+
+// roundsModeField describes a rounds game while it waits for players
+func roundsModeField(rounds int) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{
+		Name:  "Mode",
+		Value: fmt.Sprintf("🔁 Best of %d rounds: everyone rolls each round, the lowest total at the end takes a bonus drink", rounds),
+	}
+}
+
+// roundsField shows which round a rounds game is on and everyone's totals so far, nil for single round games
+func roundsField(game *models.Game) *discordgo.MessageEmbedField {
+	rounds := game.Rounds
+	if rounds == nil {
+		return nil
+	}
+
+	name := fmt.Sprintf("🔁 Round %d/%d", rounds.Current, rounds.Total)
+	if game.Status.IsFinished() {
+		name = fmt.Sprintf("🔁 Final totals after %d rounds", rounds.Current)
+	}
+	if len(rounds.Totals) == 0 {
+		return &discordgo.MessageEmbedField{
+			Name:  name,
+			Value: "No rounds finished yet.",
+		}
+	}
+
+	// Highest total first, the player at the bottom is the one in trouble
+	participants := slices.Clone(game.Participants)
+	slices.SortStableFunc(participants, func(a, b *models.Participant) int {
+		return rounds.Totals[b.PlayerID] - rounds.Totals[a.PlayerID]
+	})
+
+	var lines strings.Builder
+	for _, participant := range participants {
+		fmt.Fprintf(&lines, "%s: **%d**\n", isolateName(participant.PlayerName), rounds.Totals[participant.PlayerID])
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  name,
+		Value: lines.String(),
+	}
+}
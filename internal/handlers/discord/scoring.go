@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// pointsReplacer rewords drinks as points, the longer phrases listed first so they win
+var pointsReplacer = strings.NewReplacer(
+	"Drinking Game", "Dice Game",
+	"drinking game", "dice game",
+	"and you drink", "and you take a point",
+	"Drinks", "Points",
+	"drinks", "points",
+	"Drink", "Point",
+	"drink", "point",
+)
+
+// pointsFields are the game message sections whose text is reworded for points. The others carry the bot's
+// jokes, which are left alone.
+var pointsFields = []string{"Leaderboard", "Game Rules", "Recent Drinks"}
+
+// scoringMode returns what a guild's games are played for, drinks when it can't be looked up
+func (b *Bot) scoringMode(ctx context.Context, guildID string) models.ScoringMode {
+	if b.guildConfigService == nil || guildID == "" {
+		return models.ScoringDrinks
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for scoring: %v", err)
+		return models.ScoringDrinks
+	}
+
+	return output.Config.ScoringMode()
+}
+
+// pointsWording rewords a game message for a server playing for points, in its titles, buttons and the sections
+// that count drinks
+func pointsWording(edit *discordgo.MessageEdit) {
+	for _, embed := range edit.Embeds {
+		embed.Title = pointsReplacer.Replace(embed.Title)
+		embed.Description = pointsReplacer.Replace(embed.Description)
+		for _, field := range embed.Fields {
+			for _, name := range pointsFields {
+				if strings.Contains(field.Name, name) {
+					field.Value = pointsReplacer.Replace(field.Value)
+					break
+				}
+			}
+			field.Name = pointsReplacer.Replace(field.Name)
+		}
+	}
+
+	for _, component := range edit.Components {
+		row, ok := component.(discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for i, rowComponent := range row.Components {
+			if button, ok := rowComponent.(discordgo.Button); ok {
+				button.Label = pointsReplacer.Replace(button.Label)
+				row.Components[i] = button
+			}
+		}
+	}
+}
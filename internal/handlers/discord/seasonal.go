@@ -0,0 +1,193 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultDiceEmoji is shown for ordinary rolls when no seasonal event replaces it
+const defaultDiceEmoji = "🎲"
+
+// seasonalCommandGroup returns the subcommand group for seasonal events
+func seasonalCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "seasonal",
+		Description: "Seasonal and holiday events",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "status",
+				Description: "See which seasonal events are running",
+			},
+		},
+	}
+}
+
+// seasonalAdminCommandGroup returns the admin subcommand group for opting the server in or out of seasonal events
+func seasonalAdminCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "seasonal",
+		Description: "Turn seasonal and holiday events on or off",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "on",
+				Description: "Play new games with seasonal events",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "off",
+				Description: "Opt this server out of seasonal events",
+			},
+		},
+	}
+}
+
+// handleSeasonal handles the seasonal subcommand groups, turning events on or off is only on the admin command
+func (c *RonniedCommand) handleSeasonal(s DiscordSession, i *discordgo.InteractionCreate, group *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.seasonalService == nil {
+		return RespondWithEphemeralMessage(s, i, "Seasonal events are not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Seasonal events can only be managed in a server.")
+	}
+
+	if len(group.Options) == 0 {
+		return errors.New("missing seasonal subcommand")
+	}
+
+	subcommand := group.Options[0]
+	switch subcommand.Name {
+	case "status":
+		output, err := c.seasonalService.GetActiveEvents(ctx, &seasonal.GetActiveEventsInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting seasonal events: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get seasonal events: %v", err))
+		}
+
+		if output.OptedOut {
+			return RespondWithEphemeralMessage(s, i, "This server has opted out of seasonal events. An admin can turn them back on with `/ronnied-admin seasonal on`.")
+		}
+
+		if len(output.Events) == 0 {
+			return RespondWithEphemeralMessage(s, i, "No seasonal events are running today.")
+		}
+
+		return RespondWithEphemeralMessage(s, i, "**Running today**\n"+describeEvents(output.Events))
+
+	case "on", "off":
+		optOut := subcommand.Name == "off"
+		_, err := c.seasonalService.SetOptOut(ctx, &seasonal.SetOptOutInput{
+			GuildID: i.GuildID,
+			OptOut:  optOut,
+		})
+		if err != nil {
+			log.Printf("Error setting seasonal opt-out: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't change seasonal events: %v", err))
+		}
+
+		if optOut {
+			return RespondWithEphemeralMessage(s, i, "Seasonal events are off. New games will be played with the normal rules.")
+		}
+		return RespondWithEphemeralMessage(s, i, "Seasonal events are on. New games will join in whatever's being celebrated.")
+
+	default:
+		return fmt.Errorf("unknown seasonal subcommand: %s", subcommand.Name)
+	}
+}
+
+// describeEvents lists events along with how they change the game, one per line
+func describeEvents(events []*seasonal.Event) string {
+	var sb strings.Builder
+	for _, event := range events {
+		sb.WriteString(fmt.Sprintf("%s %s", event.Emoji, event.Name))
+
+		var effects []string
+		if event.DrinkMultiplier > 1 {
+			effects = append(effects, fmt.Sprintf("drinks count x%d", event.DrinkMultiplier))
+		}
+		if event.RollModifier != nil {
+			effects = append(effects, event.RollModifier.Label())
+		}
+		if len(effects) > 0 {
+			sb.WriteString(" - " + strings.Join(effects, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// gameEvents looks up the seasonal events a game is played with, skipping any the bot no longer knows
+func gameEvents(eventIDs []string) []*seasonal.Event {
+	var events []*seasonal.Event
+	for _, id := range eventIDs {
+		if event := seasonal.Lookup(id); event != nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// seasonalEventsField returns an embed field listing a game's seasonal events, or nil if it has none
+func seasonalEventsField(eventIDs []string) *discordgo.MessageEmbedField {
+	events := gameEvents(eventIDs)
+	if len(events) == 0 {
+		return nil
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "🎉 Seasonal Events",
+		Value: describeEvents(events),
+	}
+}
+
+// diceEmoji returns the emoji shown for ordinary rolls in a game with the given seasonal events
+func diceEmoji(eventIDs []string) string {
+	for _, event := range gameEvents(eventIDs) {
+		if event.DiceEmoji != "" {
+			return event.DiceEmoji
+		}
+	}
+	return defaultDiceEmoji
+}
+
+// seasonalRollLines returns the seasonal events' extra lines for a roll, one per line
+func (b *Bot) seasonalRollLines(ctx context.Context, rollOutput *game.RollDiceOutput) string {
+	if rollOutput.Game == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, eventID := range rollOutput.Game.Events {
+		output, err := b.messagingService.GetSeasonalMessage(ctx, &messaging.GetSeasonalMessageInput{
+			EventID:        eventID,
+			PlayerName:     rollOutput.PlayerName,
+			IsCriticalHit:  rollOutput.IsCriticalHit,
+			IsCriticalFail: rollOutput.IsCriticalFail,
+		})
+		if err != nil {
+			log.Printf("Error getting seasonal message: %v", err)
+			continue
+		}
+
+		if output.Message != "" {
+			lines = append(lines, output.Message)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
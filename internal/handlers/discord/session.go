@@ -0,0 +1,79 @@
+package discord
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_session.go github.com/KirkDiggler/ronnied/internal/handlers/discord DiscordSession
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordSession is the subset of the Discord REST API the bot uses
+type DiscordSession interface {
+	// InteractionRespond responds to an interaction
+	InteractionRespond(interaction *discordgo.Interaction, resp *discordgo.InteractionResponse, options ...discordgo.RequestOption) error
+
+	// InteractionResponseEdit edits the original response to an interaction
+	InteractionResponseEdit(interaction *discordgo.Interaction, newresp *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// InteractionResponseDelete deletes the original response to an interaction
+	InteractionResponseDelete(interaction *discordgo.Interaction, options ...discordgo.RequestOption) error
+
+	// FollowupMessageCreate sends a follow-up message to an interaction
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// ChannelMessageSendComplex sends a message to a channel
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// ChannelMessageEditComplex edits an existing message
+	ChannelMessageEditComplex(m *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// ChannelMessagePin pins a message in its channel
+	ChannelMessagePin(channelID, messageID string, options ...discordgo.RequestOption) error
+
+	// ChannelMessageUnpin unpins a message in its channel
+	ChannelMessageUnpin(channelID, messageID string, options ...discordgo.RequestOption) error
+
+	// ChannelMessages returns recent messages in a channel
+	ChannelMessages(channelID string, limit int, beforeID, afterID, aroundID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error)
+
+	// ChannelVoiceJoin joins a voice channel
+	ChannelVoiceJoin(gID, cID string, mute, deaf bool) (*discordgo.VoiceConnection, error)
+
+	// ChannelWebhooks returns a channel's webhooks
+	ChannelWebhooks(channelID string, options ...discordgo.RequestOption) ([]*discordgo.Webhook, error)
+
+	// WebhookCreate creates a webhook in a channel
+	WebhookCreate(channelID, name, avatar string, options ...discordgo.RequestOption) (*discordgo.Webhook, error)
+
+	// WebhookExecute posts a message through a webhook
+	WebhookExecute(webhookID, token string, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// WebhookMessageEdit edits a message posted through a webhook
+	WebhookMessageEdit(webhookID, token, messageID string, data *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+
+	// Channel returns a channel by ID
+	Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	// UserChannelCreate opens a direct message channel with a user
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	// User returns a user by ID
+	User(userID string, options ...discordgo.RequestOption) (*discordgo.User, error)
+
+	// ApplicationCommandCreate registers an application command
+	ApplicationCommandCreate(appID string, guildID string, cmd *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+
+	// ApplicationCommandDelete removes an application command
+	ApplicationCommandDelete(appID, guildID, cmdID string, options ...discordgo.RequestOption) error
+
+	// ApplicationCommands returns the commands registered for an application, globally or in a guild
+	ApplicationCommands(appID, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+
+	// Application returns an application, "@me" for the bot's own
+	Application(appID string) (*discordgo.Application, error)
+
+	// UserChannelPermissions returns a user's permissions in a channel
+	UserChannelPermissions(userID, channelID string, fetchOptions ...discordgo.RequestOption) (int64, error)
+}
+
+// Ensure the discordgo session satisfies DiscordSession
+var _ DiscordSession = (*discordgo.Session)(nil)
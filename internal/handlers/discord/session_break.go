@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// breakCommand returns the subcommand for pausing the session for a break and resuming it
+func breakCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "break",
+		Description: "Pause the session for a break, no new games or drink reminders until it's over",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "action",
+				Description: "Start or end the break",
+				Required:    true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Start a break", Value: "start"},
+					{Name: "End the break", Value: "end"},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reason",
+				Description: "What the break is for, e.g. food",
+			},
+		},
+	}
+}
+
+// handleBreak pauses or resumes the channel's session and announces it
+func (c *RonniedCommand) handleBreak(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID string) error {
+	ctx := context.Background()
+
+	var action, reason string
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "action":
+			action = opt.StringValue()
+		case "reason":
+			reason = opt.StringValue()
+		}
+	}
+
+	if action == "end" {
+		output, err := c.gameService.ResumeSession(ctx, &game.ResumeSessionInput{
+			ChannelID: channelID,
+			GuildID:   i.GuildID,
+			PlayerID:  userID,
+		})
+		if err != nil {
+			if errors.Is(err, game.ErrSessionNotPaused) || errors.Is(err, game.ErrSessionNotFound) {
+				return RespondWithEphemeralMessage(s, i, "The session isn't on a break.")
+			}
+			log.Printf("Error resuming session: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Failed to end the break: %v", err))
+		}
+
+		return RespondWithEmbed(s, i, "▶️ Break's Over",
+			fmt.Sprintf("<@%s> ended the break after %s. Start a game with `/ronnied start`!",
+				userID, output.PausedFor.Round(time.Minute)),
+			nil)
+	}
+
+	_, err := c.gameService.PauseSession(ctx, &game.PauseSessionInput{
+		ChannelID: channelID,
+		GuildID:   i.GuildID,
+		PlayerID:  userID,
+		Reason:    reason,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrSessionPaused) {
+			return RespondWithEphemeralMessage(s, i, "The session is already on a break.")
+		}
+		log.Printf("Error pausing session: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Failed to start a break: %v", err))
+	}
+
+	description := fmt.Sprintf("<@%s> called a break.", userID)
+	if reason != "" {
+		description = fmt.Sprintf("<@%s> called a break for %s.", userID, reason)
+	}
+	description += " No new games or drink reminders until it's over, and it doesn't count against anyone's drinks per hour. End it with `/ronnied break action:end`."
+
+	return RespondWithEmbed(s, i, "⏸️ Session on a Break", description, nil)
+}
@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// sessionGoalOptionTypes maps the admin newsession options challenges are picked with to the challenges
+var sessionGoalOptionTypes = map[string]models.SessionGoalType{
+	"first-to-crits": models.SessionGoalFirstToCrits,
+	"survive-games":  models.SessionGoalSurviveGames,
+}
+
+// newSessionAdminCommand returns the admin subcommand for starting a new session played with challenges
+func newSessionAdminCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "newsession",
+		Description: "Start a new drinking session played with challenges",
+		Options:     sessionGoalOptions(),
+	}
+}
+
+// sessionGoalOptions returns the newsession options for picking the session's challenges
+func sessionGoalOptions() []*discordgo.ApplicationCommandOption {
+	minTarget := float64(1)
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "first-to-crits",
+			Description: "Challenge: the first player to roll this many critical hits wins",
+			MinValue:    &minTarget,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "survive-games",
+			Description: "Challenge: play this many games in a row without a critical fail",
+			MinValue:    &minTarget,
+		},
+	}
+}
+
+// sessionGoalsFromOptions returns the challenges picked with the newsession options
+func sessionGoalsFromOptions(options []*discordgo.ApplicationCommandInteractionDataOption) []*models.SessionGoal {
+	var goals []*models.SessionGoal
+	for _, opt := range options {
+		if goalType, ok := sessionGoalOptionTypes[opt.Name]; ok {
+			goals = append(goals, &models.SessionGoal{Type: goalType, Target: int(opt.IntValue())})
+		}
+	}
+	return goals
+}
+
+// renderSessionGoals lists the session's challenges with who completed them, empty for sessions without any
+func renderSessionGoals(goals []*models.SessionGoal) string {
+	var lines strings.Builder
+	for _, goal := range goals {
+		fmt.Fprintf(&lines, "🎯 **%s**", goal.Name())
+		if len(goal.CompletedBy) > 0 {
+			winners := make([]string, len(goal.CompletedBy))
+			for i, playerID := range goal.CompletedBy {
+				winners[i] = fmt.Sprintf("<@%s>", playerID)
+			}
+			fmt.Fprintf(&lines, " ✅ %s", strings.Join(winners, ", "))
+		}
+		lines.WriteString("\n")
+	}
+	return lines.String()
+}
+
+// handleSessionGoalCompleted congratulates a player on completing one of the session's challenges
+func (b *Bot) handleSessionGoalCompleted(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.SessionGoalCompletedPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       "🏅 Challenge Complete!",
+				Description: fmt.Sprintf("**%s** completed the session challenge **%s**!", isolateName(payload.PlayerName), payload.Goal),
+				Color:       0xF1C40F,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error announcing completed challenge in channel %s: %v", event.ChannelID, err)
+	}
+}
@@ -0,0 +1,291 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// newGuildWindow is how recently the bot has to have joined a server for it to post the setup message there, as
+// the servers it was already in are announced the same way when it connects
+const newGuildWindow = 5 * time.Minute
+
+// setupSetting is a row of buttons on the setup message picking one of the server's settings
+type setupSetting struct {
+	// name is the setting in the buttons' IDs
+	name string
+
+	// title names the setting in the message
+	title string
+
+	// choices are the buttons
+	choices []setupChoice
+}
+
+// setupChoice is a button on the setup message
+type setupChoice struct {
+	label string
+	value string
+}
+
+// setupSettings are the settings the setup message picks, a row of buttons each
+var setupSettings = []setupSetting{
+	{
+		name:  "tone",
+		title: "Tone",
+		choices: []setupChoice{
+			{"😂 Funny", string(models.ToneFunny)},
+			{"🙄 Sarcastic", string(models.ToneSarcastic)},
+			{"🤗 Encouraging", string(models.ToneEncouraging)},
+			{"😐 Neutral", string(models.ToneNeutral)},
+		},
+	},
+	{
+		name:  "rating",
+		title: "Content Rating",
+		choices: []setupChoice{
+			{"PG", string(models.ContentRatingPG)},
+			{"PG-13", string(models.ContentRatingPG13)},
+			{"R", string(models.ContentRatingR)},
+		},
+	},
+	{
+		name:  "dice",
+		title: "Dice",
+		choices: []setupChoice{
+			{"🎲 d6", "6"},
+			{"🎲 d20", "20"},
+		},
+	},
+	{
+		name:  "scoring",
+		title: "Playing For",
+		choices: []setupChoice{
+			{"🍺 Drinks", string(models.ScoringDrinks)},
+			{"🏆 Points", string(models.ScoringPoints)},
+		},
+	},
+}
+
+// setupCommand returns the subcommand bringing back the setup message
+func setupCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "setup",
+		Description: "Pick the bot's tone, content rating, dice and what games are played for (admins only)",
+	}
+}
+
+// handleSetup posts the setup message for server admins to pick the server's settings again
+func (c *RonniedCommand) handleSetup(s DiscordSession, i *discordgo.InteractionCreate) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Server setup is not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Setup can only be run in a server.")
+	}
+
+	output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: i.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get the server's settings: %v", err))
+	}
+
+	embed, components := setupMessage(output.Config, defaultDiceSides(ctx, c.gameService))
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+}
+
+// handleGuildCreate posts the setup message in the system channel of a server the bot was just added to
+func (b *Bot) handleGuildCreate(g *discordgo.GuildCreate) {
+	if g.Guild == nil || g.Unavailable || g.SystemChannelID == "" {
+		return
+	}
+
+	if b.clock.Now().Sub(g.JoinedAt) > newGuildWindow {
+		return
+	}
+
+	ctx := context.Background()
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: g.ID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for the setup of guild %s: %v", g.ID, err)
+		return
+	}
+
+	embed, components := setupMessage(output.Config, defaultDiceSides(ctx, b.gameService))
+	if _, err := b.api.ChannelMessageSendComplex(g.SystemChannelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	}); err != nil {
+		log.Printf("Error posting the setup message in guild %s: %v", g.ID, err)
+	}
+}
+
+// handleSetupButton changes the setting a setup button picks for server admins and redraws the setup message
+func (b *Bot) handleSetupButton(s DiscordSession, i *discordgo.InteractionCreate, choice string) error {
+	ctx := context.Background()
+
+	if b.guildConfigService == nil || i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "Server setup is not enabled on this bot.")
+	}
+
+	if !isGuildAdmin(i) {
+		return RespondWithEphemeralMessage(s, i, "Only server admins can change the setup.")
+	}
+
+	setting, value, _ := strings.Cut(choice, ":")
+	config, err := b.applySetupChoice(ctx, i.GuildID, setting, value)
+	if err != nil {
+		log.Printf("Error changing the %s setup: %v", setting, err)
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't change the setup: %v", err))
+	}
+
+	embed, components := setupMessage(config, defaultDiceSides(ctx, b.gameService))
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+}
+
+// applySetupChoice saves the value a setup button picks for its setting, returning the server's settings
+func (b *Bot) applySetupChoice(ctx context.Context, guildID, setting, value string) (*models.GuildConfig, error) {
+	switch setting {
+	case "tone":
+		output, err := b.guildConfigService.SetTone(ctx, &guild_config.SetToneInput{
+			GuildID: guildID,
+			Tone:    models.MessageTone(value),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Config, nil
+	case "rating":
+		output, err := b.guildConfigService.SetContentRating(ctx, &guild_config.SetContentRatingInput{
+			GuildID: guildID,
+			Rating:  models.ContentRating(value),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Config, nil
+	case "dice":
+		sides, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, guild_config.ErrInvalidDice
+		}
+		output, err := b.guildConfigService.SetDefaultDice(ctx, &guild_config.SetDefaultDiceInput{
+			GuildID: guildID,
+			Sides:   sides,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Config, nil
+	case "scoring":
+		output, err := b.guildConfigService.SetScoring(ctx, &guild_config.SetScoringInput{
+			GuildID: guildID,
+			Mode:    models.ScoringMode(value),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Config, nil
+	default:
+		return nil, fmt.Errorf("unknown setting %q", setting)
+	}
+}
+
+// setupMessage renders the setup message, showing the server's settings with a row of buttons to change each and
+// the current picks highlighted
+func setupMessage(config *models.GuildConfig, defaultSides int) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	embed := &discordgo.MessageEmbed{
+		Title:       "🎲 Set up Ronnie",
+		Description: "Pick how Ronnie runs games in this server. Only server admins can change these, and `/ronnied-admin setup` brings this message back any time.",
+		Color:       0x00ff00,
+	}
+
+	var components []discordgo.MessageComponent
+	for _, setting := range setupSettings {
+		current := setupSelection(config, setting.name, defaultSides)
+
+		picked := "Not picked"
+		var buttons []discordgo.MessageComponent
+		for _, choice := range setting.choices {
+			style := discordgo.SecondaryButton
+			if choice.value == current {
+				style = discordgo.PrimaryButton
+				picked = choice.label
+			}
+			buttons = append(buttons, discordgo.Button{
+				Label:    choice.label,
+				Style:    style,
+				CustomID: ButtonSetupPrefix + setting.name + ":" + choice.value,
+			})
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   setting.title,
+			Value:  picked,
+			Inline: true,
+		})
+		components = append(components, discordgo.ActionsRow{
+			Components: buttons,
+		})
+	}
+
+	return embed, components
+}
+
+// setupSelection returns the value the server has for a setting on the setup message, the default when it hasn't
+// picked one
+func setupSelection(config *models.GuildConfig, setting string, defaultSides int) string {
+	switch setting {
+	case "tone":
+		return string(config.MessageTone())
+	case "rating":
+		return string(config.ContentRating())
+	case "dice":
+		if config.DiceSides > 0 {
+			return strconv.Itoa(config.DiceSides)
+		}
+		return strconv.Itoa(defaultSides)
+	case "scoring":
+		return string(config.ScoringMode())
+	default:
+		return ""
+	}
+}
+
+// defaultDiceSides returns the dice games are played with when nothing picks them, zero when it can't be looked up
+func defaultDiceSides(ctx context.Context, gameService game.Service) int {
+	output, err := gameService.GetGameRules(ctx, &game.GetGameRulesInput{})
+	if err != nil {
+		log.Printf("Error getting the default game rules: %v", err)
+		return 0
+	}
+	return output.Rules.DiceSides
+}
@@ -0,0 +1,195 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// sideBetOutcomes are the outcomes offered as buttons once a player to bet on is picked, in order
+var sideBetOutcomes = []struct {
+	Outcome models.SideBetOutcome
+	Label   string
+	Emoji   string
+}{
+	{models.SideBetCriticalHit, "Crit", "🔥"},
+	{models.SideBetCriticalFail, "Fail", "💀"},
+	{models.SideBetOver, "Over", "⬆️"},
+	{models.SideBetUnder, "Under", "⬇️"},
+}
+
+// sideBetButton returns the button anyone presses to bet a drink on a player's roll
+func sideBetButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Side Bet",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonSideBet,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🎰",
+		},
+	}
+}
+
+// handleSideBetButton asks whose roll the player wants to bet on, out of the players still to roll
+func (b *Bot) handleSideBetButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't find the game"))
+	}
+
+	var options []discordgo.SelectMenuOption
+	for _, participant := range existingGame.Game.Participants {
+		if participant.RollTime != nil || participant.PlayerID == userID {
+			continue
+		}
+		options = append(options, discordgo.SelectMenuOption{
+			Label: participant.PlayerName,
+			Value: participant.PlayerID,
+		})
+	}
+
+	if len(options) == 0 {
+		return RespondWithEphemeralMessage(s, i, "There's nobody left to bet on, everyone else has rolled.")
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "Whose roll are you betting a drink on?",
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.SelectMenu{
+							CustomID:    SelectSideBetTargetPrefix + existingGame.Game.ID,
+							Placeholder: "Pick a player",
+							Options:     options,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleSideBetTargetSelect asks what the picked player's roll is going to be
+func (b *Bot) handleSideBetTargetSelect(s DiscordSession, i *discordgo.InteractionCreate, gameID string) error {
+	var targetPlayerID string
+	if values := i.MessageComponentData().Values; len(values) > 0 {
+		targetPlayerID = values[0]
+	}
+
+	if targetPlayerID == "" {
+		return RespondWithEphemeralMessage(s, i, "No player selected")
+	}
+
+	buttons := make([]discordgo.MessageComponent, 0, len(sideBetOutcomes))
+	for _, outcome := range sideBetOutcomes {
+		buttons = append(buttons, discordgo.Button{
+			Label:    outcome.Label,
+			Style:    discordgo.PrimaryButton,
+			CustomID: fmt.Sprintf("%s%s:%s:%s", ButtonPlaceSideBetPrefix, gameID, targetPlayerID, outcome.Outcome),
+			Emoji: discordgo.ComponentEmoji{
+				Name: outcome.Emoji,
+			},
+		})
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("What's <@%s> going to roll? Over and under are measured against the middle of the die. "+
+				"Win and they drink, lose and you do.", targetPlayerID),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: buttons,
+				},
+			},
+		},
+	})
+}
+
+// handlePlaceSideBetButton places the bet and lets the channel know about it
+func (b *Bot) handlePlaceSideBetButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, username, data string) error {
+	ctx := context.Background()
+
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 {
+		return RespondWithEphemeralMessage(s, i, "That bet doesn't make sense, try again.")
+	}
+
+	output, err := b.gameService.PlaceSideBet(ctx, &game.PlaceSideBetInput{
+		GameID:         parts[0],
+		BettorID:       userID,
+		BettorName:     username,
+		TargetPlayerID: parts[1],
+		Outcome:        models.SideBetOutcome(parts[2]),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrCannotBetOnSelf):
+			return updateForgetMessage(s, i, "You can't bet on your own roll.")
+		case errors.Is(err, game.ErrPlayerAlreadyRolled):
+			return updateForgetMessage(s, i, "Too late, they've already rolled.")
+		case errors.Is(err, game.ErrSideBetAlreadyPlaced):
+			return updateForgetMessage(s, i, "You've already got a bet on their roll.")
+		}
+		log.Printf("Error placing side bet: %v", err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't place the bet"))
+	}
+	bet := output.Bet
+
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("🎰 **%s** bets a drink that **%s** rolls %s!", bet.BettorName, bet.TargetPlayerName, bet.Label()),
+	})
+	if err != nil {
+		log.Printf("Error announcing side bet: %v", err)
+	}
+
+	return updateForgetMessage(s, i, fmt.Sprintf("You're on: **%s** rolls %s.", bet.TargetPlayerName, bet.Label()))
+}
+
+// handleSideBetsSettled posts how the side bets on a roll went
+func (b *Bot) handleSideBetsSettled(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.SideBetsSettledPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{sideBetsSettledEmbed(payload)},
+	})
+	if err != nil {
+		log.Printf("Error posting side bets for game %s: %v", event.GameID, err)
+	}
+}
+
+// sideBetsSettledEmbed shows who won and lost the bets on a roll and who drinks for each
+func sideBetsSettledEmbed(payload *events.SideBetsSettledPayload) *discordgo.MessageEmbed {
+	lines := make([]string, 0, len(payload.Bets))
+	for _, bet := range payload.Bets {
+		if bet.Won {
+			lines = append(lines, fmt.Sprintf("✅ **%s** called %s, 🍺 **%s** drinks", bet.BettorName, bet.Label, payload.PlayerName))
+		} else {
+			lines = append(lines, fmt.Sprintf("❌ **%s** called %s, 🍺 **%s** drinks", bet.BettorName, bet.Label, bet.BettorName))
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🎰 Side Bets on %s's %d", payload.PlayerName, payload.RollValue),
+		Description: strings.Join(lines, "\n"),
+		Color:       0x9B59B6,
+	}
+}
@@ -0,0 +1,114 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// socialCommand returns the admin subcommand for giving everyone in the channel's game a drink
+func socialCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "social",
+		Description: "Everyone in the game drinks (admins only)",
+	}
+}
+
+// handleSocial calls a social round on the channel's game. The channel hears about it from the social round
+// event, so the admin just gets a confirmation.
+func (c *RonniedCommand) handleSocial(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID, username string) error {
+	output, err := c.gameService.CallSocialRound(context.Background(), &game.CallSocialRoundInput{
+		ChannelID:  channelID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrGameNotFound):
+			return RespondWithEphemeralMessage(s, i, "No game found in this channel. Use `/ronnied start` to create a new game.")
+		case errors.Is(err, game.ErrGameNotStarted):
+			return RespondWithEphemeralMessage(s, i, "The game hasn't started yet.")
+		}
+		log.Printf("Error calling a social round: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Failed to call a social: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Social called, %d drinks poured.", len(output.Records)))
+}
+
+// handleSocialRound celebrates a social round with a single message to the channel, rather than one for each of
+// the drinks it gave. A called round isn't part of a roll, so the game's message is redrawn to show it.
+func (b *Bot) handleSocialRound(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.SocialRoundPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	output, err := b.messagingService.GetSocialRoundMessage(b.messagingContext(ctx, event.GuildID), &messaging.GetSocialRoundMessageInput{
+		PlayerName: payload.PlayerName,
+		RollValue:  payload.RollValue,
+		DrinkCount: payload.DrinkCount,
+	})
+	if err != nil {
+		log.Printf("Error getting social round message: %v", err)
+		return
+	}
+
+	_, err = b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       output.Title,
+				Description: output.Message,
+				Color:       0xF1C40F,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error posting social round message for game %s: %v", event.GameID, err)
+	}
+
+	if payload.RollValue == 0 && payload.RootGameID != "" {
+		b.updateGameMessage(b.api, event.ChannelID, payload.RootGameID)
+	}
+}
+
+// socialRule is the game rules line for games with social values, empty for games without
+func socialRule(rules models.GameRules) string {
+	if len(rules.SocialValues) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n• Roll a **%s** = Social, everyone drinks! 🍻", models.FormatRollValues(rules.SocialValues))
+}
+
+// collapseSocialRounds keeps one of the drinks each social round gave, so a round shows up once in a list of
+// drinks rather than once for every player
+func collapseSocialRounds(records []*models.DrinkLedger) []*models.DrinkLedger {
+	type round struct {
+		fromPlayerID string
+		unixNano     int64
+	}
+
+	seen := make(map[round]bool)
+	collapsed := make([]*models.DrinkLedger, 0, len(records))
+	for _, record := range records {
+		if record.Reason == models.DrinkReasonSocialRound {
+			key := round{fromPlayerID: record.FromPlayerID, unixNano: record.Timestamp.UnixNano()}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		collapsed = append(collapsed, record)
+	}
+
+	return collapsed
+}
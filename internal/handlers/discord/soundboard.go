@@ -0,0 +1,294 @@
+package discord
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// soundEffect is a sound the soundboard plays, named after the file it's loaded from
+type soundEffect string
+
+const (
+	soundCriticalHit  soundEffect = "critical_hit"
+	soundCriticalFail soundEffect = "critical_fail"
+	soundGameEnd      soundEffect = "game_end"
+)
+
+// soundEffects are the sounds the soundboard looks for in its directory
+var soundEffects = []soundEffect{soundCriticalHit, soundCriticalFail, soundGameEnd}
+
+// defaultSoundboardCooldown is how long after one sound starts before the next can play in a server
+const defaultSoundboardCooldown = 15 * time.Second
+
+// voiceSettleTime is the pause the bot gives a voice connection before and after speaking, so the start and end of
+// a sound aren't cut off
+const voiceSettleTime = 250 * time.Millisecond
+
+// soundboard plays short sound effects in a server's voice channel, at most one at a time and one per cooldown
+type soundboard struct {
+	clock    clock.Clock
+	cooldown time.Duration
+
+	// sounds are the opus frames of each effect
+	sounds map[soundEffect][][]byte
+
+	mu         sync.Mutex
+	playing    map[string]bool
+	lastPlayed map[string]time.Time
+}
+
+// loadSoundboard loads the sound effects in a directory, each a DCA file named after the effect, e.g.
+// critical_hit.dca. Effects without a file are left silent.
+func loadSoundboard(dir string, clk clock.Clock, cooldown time.Duration) (*soundboard, error) {
+	if cooldown <= 0 {
+		cooldown = defaultSoundboardCooldown
+	}
+
+	sb := &soundboard{
+		clock:      clk,
+		cooldown:   cooldown,
+		sounds:     make(map[soundEffect][][]byte),
+		playing:    make(map[string]bool),
+		lastPlayed: make(map[string]time.Time),
+	}
+
+	for _, effect := range soundEffects {
+		frames, err := readDCAFile(filepath.Join(dir, string(effect)+".dca"))
+		if errors.Is(err, os.ErrNotExist) {
+			log.Printf("No sound for %s in %s, it won't be played", effect, dir)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the %s sound: %w", effect, err)
+		}
+		sb.sounds[effect] = frames
+	}
+
+	return sb, nil
+}
+
+// readDCAFile reads the opus frames of a DCA file
+func readDCAFile(path string) ([][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readDCA(bufio.NewReader(file))
+}
+
+// readDCA reads opus frames written the DCA way, each one after its length as a little endian int16
+func readDCA(r io.Reader) ([][]byte, error) {
+	var frames [][]byte
+	for {
+		var length int16
+		err := binary.Read(r, binary.LittleEndian, &length)
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if length <= 0 {
+			return nil, fmt.Errorf("invalid frame length %d", length)
+		}
+
+		frame := make([]byte, length)
+		if _, err := io.ReadFull(r, frame); err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// claim takes the server's turn to play a sound, false while one is playing or its cooldown hasn't passed
+func (sb *soundboard) claim(guildID string) bool {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	now := sb.clock.Now()
+	if sb.playing[guildID] || now.Sub(sb.lastPlayed[guildID]) < sb.cooldown {
+		return false
+	}
+
+	sb.playing[guildID] = true
+	sb.lastPlayed[guildID] = now
+	return true
+}
+
+// release lets the server play sounds again once its cooldown has passed
+func (sb *soundboard) release(guildID string) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	delete(sb.playing, guildID)
+}
+
+// play joins the voice channel, plays the sound and leaves again
+func (sb *soundboard) play(api DiscordSession, guildID, channelID string, frames [][]byte) {
+	defer sb.release(guildID)
+
+	vc, err := api.ChannelVoiceJoin(guildID, channelID, false, true)
+	if err != nil {
+		log.Printf("Error joining voice channel %s to play a sound: %v", channelID, err)
+		return
+	}
+	defer func() {
+		if err := vc.Disconnect(); err != nil {
+			log.Printf("Error leaving voice channel %s: %v", channelID, err)
+		}
+	}()
+
+	sb.clock.Sleep(voiceSettleTime)
+	if err := vc.Speaking(true); err != nil {
+		log.Printf("Error speaking in voice channel %s: %v", channelID, err)
+		return
+	}
+	for _, frame := range frames {
+		vc.OpusSend <- frame
+	}
+	if err := vc.Speaking(false); err != nil {
+		log.Printf("Error stopping speaking in voice channel %s: %v", channelID, err)
+	}
+	sb.clock.Sleep(voiceSettleTime)
+}
+
+// soundboardCommand returns the subcommand for picking the voice channel sound effects play in
+func soundboardCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "soundboard",
+		Description: "Play sound effects for crits, fails and game ends in a voice channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionChannel,
+				Name:         "channel",
+				Description:  "The voice channel to play them in",
+				ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "off",
+				Description: "Stop playing sound effects",
+			},
+		},
+	}
+}
+
+// handleSoundboard shows the voice channel sound effects play in, or changes it
+func (c *RonniedCommand) handleSoundboard(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "The soundboard is not enabled on this bot.")
+	}
+
+	if i.GuildID == "" {
+		return RespondWithEphemeralMessage(s, i, "The soundboard can only be set up in a server.")
+	}
+
+	var channelID string
+	var off bool
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "channel":
+			channelID = opt.ChannelValue(nil).ID
+		case "off":
+			off = opt.BoolValue()
+		}
+	}
+
+	if channelID == "" && !off {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the soundboard: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, describeSoundboard(output.Config.SoundboardChannelID))
+	}
+
+	if off {
+		channelID = ""
+	}
+
+	output, err := c.guildConfigService.SetSoundboard(ctx, &guild_config.SetSoundboardInput{
+		GuildID:   i.GuildID,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		log.Printf("Error setting soundboard: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the soundboard: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, describeSoundboard(output.Config.SoundboardChannelID))
+}
+
+// describeSoundboard says where the server's sound effects play
+func describeSoundboard(channelID string) string {
+	if channelID == "" {
+		return "🔇 The soundboard is off."
+	}
+	return fmt.Sprintf("🔊 Sound effects for crits, fails and game ends play in <#%s>.", channelID)
+}
+
+// handleSoundboardRoll plays the crit or fail sound for a roll
+func (b *Bot) handleSoundboardRoll(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.DiceRolledPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	switch {
+	case payload.CriticalHit:
+		b.playSound(ctx, event.GuildID, soundCriticalHit)
+	case payload.CriticalFail:
+		b.playSound(ctx, event.GuildID, soundCriticalFail)
+	}
+}
+
+// handleSoundboardGameCompleted plays the game end sound
+func (b *Bot) handleSoundboardGameCompleted(ctx context.Context, event *events.Event) {
+	b.playSound(ctx, event.GuildID, soundGameEnd)
+}
+
+// playSound plays a sound effect in the server's soundboard channel, skipping it when the server has the soundboard
+// off or played a sound too recently
+func (b *Bot) playSound(ctx context.Context, guildID string, effect soundEffect) {
+	frames := b.soundboard.sounds[effect]
+	if guildID == "" || len(frames) == 0 {
+		return
+	}
+
+	output, err := b.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for the soundboard: %v", err)
+		return
+	}
+
+	channelID := output.Config.SoundboardChannelID
+	if channelID == "" || !b.soundboard.claim(guildID) {
+		return
+	}
+
+	go b.soundboard.play(b.api, guildID, channelID, frames)
+}
@@ -0,0 +1,301 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// tabPageSize is how many drinks each list of the tab view shows per page
+const tabPageSize = 8
+
+// tabReasons labels each drink reason in the tab view
+var tabReasons = map[models.DrinkReason]string{
+	models.DrinkReasonCriticalHit:  "🔥 Critical hit",
+	models.DrinkReasonCriticalFail: "💀 Critical fail",
+	models.DrinkReasonLowestRoll:   "👇 Lowest roll",
+	models.DrinkReasonDelayedStart: "⏰ Late start",
+	models.DrinkReasonChallenge:    "⚔️ Lost a challenge",
+	models.DrinkReasonOutOfSync:    "⏱️ Rolled out of sync",
+	models.DrinkReasonSideBet:      "🎰 Lost a side bet",
+	models.DrinkReasonSocialRound:  "🍻 Social round",
+	models.DrinkReasonKarma:        "😈 Karma",
+	models.DrinkReasonRoundsLoser:  "🔁 Lowest over the rounds",
+	models.DrinkReasonSuddenDeath:  "☠️ Sudden death pot",
+	models.DrinkReasonJackpot:      "💰 Jackpot",
+}
+
+// tabFilter holds a tab view's filters, its page buttons carry them in their IDs
+type tabFilter struct {
+	UnpaidOnly bool
+	Reason     models.DrinkReason
+	AssignedBy string
+}
+
+// tabCommand returns the subcommand for looking through your tab
+func tabCommand() *discordgo.ApplicationCommandOption {
+	reasons := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(tabReasons))
+	for _, reason := range []models.DrinkReason{
+		models.DrinkReasonCriticalHit,
+		models.DrinkReasonCriticalFail,
+		models.DrinkReasonLowestRoll,
+		models.DrinkReasonDelayedStart,
+		models.DrinkReasonChallenge,
+		models.DrinkReasonOutOfSync,
+		models.DrinkReasonSideBet,
+		models.DrinkReasonSocialRound,
+		models.DrinkReasonKarma,
+		models.DrinkReasonRoundsLoser,
+		models.DrinkReasonSuddenDeath,
+		models.DrinkReasonJackpot,
+	} {
+		reasons = append(reasons, &discordgo.ApplicationCommandOptionChoice{Name: tabReasons[reason], Value: string(reason)})
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "tab",
+		Description: "Look through the drinks you owe and handed out in this channel's game",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "unpaid",
+				Description: "Only show drinks you still owe",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "reason",
+				Description: "Only show drinks given for this",
+				Choices:     reasons,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "from",
+				Description: "Only show drinks this player gave",
+			},
+		},
+	}
+}
+
+// handleTab shows the player the first page of their tab for the channel's game
+func (c *RonniedCommand) handleTab(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID string) error {
+	ctx := context.Background()
+
+	var filter tabFilter
+	for _, opt := range subcommand.Options {
+		switch opt.Name {
+		case "unpaid":
+			filter.UnpaidOnly = opt.BoolValue()
+		case "reason":
+			filter.Reason = models.DrinkReason(opt.StringValue())
+		case "from":
+			if user := opt.UserValue(nil); user != nil {
+				filter.AssignedBy = user.ID
+			}
+		}
+	}
+
+	existingGame, err := c.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID,
+		GuildID:   i.GuildID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return RespondWithEphemeralMessage(s, i, "No game found in this channel. Use `/ronnied start` to create a new game.")
+		}
+		log.Printf("Error getting game: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Error getting game: %v", err))
+	}
+
+	if existingGame.Game.GetParticipant(userID) == nil {
+		return RespondWithEphemeralMessage(s, i, "You're not in this channel's game, so you don't have a tab yet.")
+	}
+
+	view, err := tabView(ctx, c.gameService, existingGame.Game.ID, userID, 0, filter)
+	if err != nil {
+		log.Printf("Error getting player tab: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't get your tab: %v", err))
+	}
+	view.Flags = discordgo.MessageFlagsEphemeral
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: view,
+	})
+}
+
+// handleTabPageButton turns the tab view to another page with the same filters
+func (b *Bot) handleTabPageButton(s DiscordSession, i *discordgo.InteractionCreate, userID, data string) error {
+	ctx := context.Background()
+
+	gameID, page, filter, err := parseTabPageID(data)
+	if err != nil {
+		return RespondWithEphemeralMessage(s, i, "That page can't be found.")
+	}
+
+	view, err := tabView(ctx, b.gameService, gameID, userID, page, filter)
+	if err != nil {
+		log.Printf("Error getting player tab: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't get your tab"))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: view,
+	})
+}
+
+// tabView renders a page of the player's tab with buttons to the pages either side
+func tabView(ctx context.Context, gameService game.Service, gameID, playerID string, page int, filter tabFilter) (*discordgo.InteractionResponseData, error) {
+	output, err := gameService.GetPlayerTab(ctx, &game.GetPlayerTabInput{
+		GameID:     gameID,
+		PlayerID:   playerID,
+		UnpaidOnly: filter.UnpaidOnly,
+		Reason:     filter.Reason,
+		AssignedBy: filter.AssignedBy,
+		Page:       page,
+		PageSize:   tabPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tab := output.Tab
+
+	description := fmt.Sprintf("**Owes** %d · **Handed out** %d · **Net** %+d", tab.TotalOwed, tab.TotalAssigned, tab.NetDrinks)
+	if tab.Credits > 0 {
+		description += fmt.Sprintf(" · 💳 **Credits** %d", tab.Credits)
+	}
+	var filters []string
+	if filter.UnpaidOnly {
+		filters = append(filters, "unpaid only")
+	}
+	if filter.Reason != "" {
+		filters = append(filters, tabReasons[filter.Reason])
+	}
+	if filter.AssignedBy != "" {
+		filters = append(filters, fmt.Sprintf("from <@%s>", filter.AssignedBy))
+	}
+	if len(filters) > 0 {
+		description += "\nShowing " + strings.Join(filters, " · ")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🍺 %s's Tab", tab.PlayerName),
+		Description: description,
+		Color:       0x00ff00,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Drinks Owed", Value: tabEntryLines(tab.DrinksOwed, playerID, true)},
+			{Name: "Drinks Handed Out", Value: tabEntryLines(tab.DrinksAssigned, playerID, false)},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d of %d", output.Page+1, output.PageCount),
+		},
+	}
+
+	components := []discordgo.MessageComponent{}
+	if output.PageCount > 1 {
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "◀ Prev",
+					Style:    discordgo.SecondaryButton,
+					CustomID: tabPageID(gameID, output.Page-1, filter),
+					Disabled: output.Page == 0,
+				},
+				discordgo.Button{
+					Label:    "Next ▶",
+					Style:    discordgo.SecondaryButton,
+					CustomID: tabPageID(gameID, output.Page+1, filter),
+					Disabled: output.Page == output.PageCount-1,
+				},
+			},
+		})
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
+	}, nil
+}
+
+// tabEntryLines lists a page of tab entries, one line each
+func tabEntryLines(entries []*game.PlayerTabEntry, playerID string, owed bool) string {
+	if len(entries) == 0 {
+		return "Nothing here"
+	}
+
+	var lines strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&lines, "• %s", tabReasons[entry.Reason])
+		switch {
+		case owed && entry.FromPlayerID != playerID:
+			fmt.Fprintf(&lines, " from **%s**", entry.FromPlayerName)
+		case !owed && entry.ToPlayerID != playerID:
+			fmt.Fprintf(&lines, " to **%s**", entry.ToPlayerName)
+		}
+		fmt.Fprintf(&lines, " %s — %s\n", discordTimestamp(entry.Timestamp, timestampRelative), tabEntryStatus(entry))
+	}
+	return lines.String()
+}
+
+// tabEntryStatus describes whether a drink on the tab is still owed
+func tabEntryStatus(entry *game.PlayerTabEntry) string {
+	switch {
+	case entry.Forgiven:
+		return "🙏 forgiven"
+	case entry.Social:
+		return "🫂 social"
+	case entry.PaidWithCredit:
+		return "💳 paid with credit"
+	case entry.Paid:
+		return "✅ paid"
+	case entry.OwedLater:
+		return "🚗 owed later"
+	default:
+		return "⏳ owed"
+	}
+}
+
+// tabPageID builds the ID of a tab view page button, the game's ID, the page and the filters after the prefix
+func tabPageID(gameID string, page int, filter tabFilter) string {
+	unpaid := "0"
+	if filter.UnpaidOnly {
+		unpaid = "1"
+	}
+	return fmt.Sprintf("%s%s:%d:%s:%s:%s", ButtonTabPagePrefix, gameID, page, unpaid, filter.Reason, filter.AssignedBy)
+}
+
+// parseTabPageID reads back what tabPageID put after the prefix
+func parseTabPageID(data string) (string, int, tabFilter, error) {
+	parts := strings.Split(data, ":")
+	if len(parts) != 5 {
+		return "", 0, tabFilter{}, fmt.Errorf("malformed tab page ID %q", data)
+	}
+
+	page, err := strconv.Atoi(parts[1])
+	if err != nil || page < 0 {
+		return "", 0, tabFilter{}, fmt.Errorf("malformed tab page %q", parts[1])
+	}
+
+	return parts[0], page, tabFilter{
+		UnpaidOnly: parts[2] == "1",
+		Reason:     models.DrinkReason(parts[3]),
+		AssignedBy: parts[4],
+	}, nil
+}
+
+// creditBankedMessage tells a player who paid a drink they didn't owe that it was banked as a credit
+func creditBankedMessage(balance int) string {
+	credits := "1 credit"
+	if balance != 1 {
+		credits = fmt.Sprintf("%d credits", balance)
+	}
+	return fmt.Sprintf("You didn't owe a drink, so that one's banked. 💳 You have %s to cover the next drinks you're given.", credits)
+}
@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/bwmarrin/discordgo"
+)
+
+// tiesCommand returns the subcommand for picking how the server's games settle ties
+func tiesCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "ties",
+		Description: "See or change how games settle ties for the highest or lowest roll",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "strategy",
+				Description: "How tied players are split up",
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Roll-offs: tied players roll again together", Value: string(models.TieRollOff)},
+					{Name: "Sudden death: tied players take turns, every tie adds to the pot", Value: string(models.TieSuddenDeath)},
+				},
+			},
+		},
+	}
+}
+
+// handleTies shows how the server's games settle ties, or changes it
+func (c *RonniedCommand) handleTies(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.guildConfigService == nil {
+		return RespondWithEphemeralMessage(s, i, "Tie strategies are not enabled on this bot.")
+	}
+
+	var strategy models.TieStrategy
+	for _, opt := range subcommand.Options {
+		if opt.Name == "strategy" {
+			strategy = models.TieStrategy(opt.StringValue())
+		}
+	}
+
+	if strategy == "" {
+		output, err := c.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error getting guild config: %v", err)
+			return RespondWithError(s, i, fmt.Sprintf("Couldn't get the tie strategy: %v", err))
+		}
+		return RespondWithEphemeralMessage(s, i, describeTieStrategy(output.Config.TieStrategy))
+	}
+
+	output, err := c.guildConfigService.SetTieStrategy(ctx, &guild_config.SetTieStrategyInput{
+		GuildID:  i.GuildID,
+		Strategy: strategy,
+	})
+	if err != nil {
+		log.Printf("Error setting tie strategy: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't change the tie strategy: %v", err))
+	}
+
+	return RespondWithEphemeralMessage(s, i, describeTieStrategy(output.Config.TieStrategy)+" New games will play it.")
+}
+
+// describeTieStrategy says how the server's games settle ties
+func describeTieStrategy(strategy models.TieStrategy) string {
+	if strategy == models.TieSuddenDeath {
+		return "☠️ Ties are settled with sudden death: the tied players take turns rolling until one of them is out on their own, and every tie along the way adds a drink to the pot the loser takes."
+	}
+	return "⚔️ Ties are settled with roll-offs: the tied players roll again together until someone's out on their own."
+}
+
+// suddenDeathField shows whose turn it is in a sudden death and what's in the pot, nil for other games
+func suddenDeathField(game *models.Game) *discordgo.MessageEmbedField {
+	if game.SuddenDeath == nil || game.Status != models.GameStatusRollOff {
+		return nil
+	}
+
+	value := "Players take turns rolling, every tie adds a drink to the pot."
+	if next := game.NextRoller(); next != nil {
+		value = fmt.Sprintf("**%s** to roll. %s", isolateName(next.PlayerName), value)
+	}
+	if game.SuddenDeath.Pot > 0 {
+		value += fmt.Sprintf("\n🍺 Pot: the loser takes **%d** extra", game.SuddenDeath.Pot)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  "☠️ Sudden Death",
+		Value: value,
+	}
+}
@@ -0,0 +1,167 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// transferDrinkButton returns the button players press to hand one of their drinks to someone else
+func transferDrinkButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Transfer Drink",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonTransferDrink,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🤝",
+		},
+	}
+}
+
+// handleTransferDrinkButton asks the player who they want to hand a drink to
+func (b *Bot) handleTransferDrinkButton(s DiscordSession, i *discordgo.InteractionCreate) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "Who's taking one of your drinks? They'll have to accept it.",
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.SelectMenu{
+							MenuType:    discordgo.UserSelectMenu,
+							CustomID:    SelectTransferDrink,
+							Placeholder: "Pick a volunteer",
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleTransferDrinkSelect offers one of the player's drinks to the volunteer they picked
+func (b *Bot) handleTransferDrinkSelect(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := context.Background()
+
+	var targetPlayerID string
+	if values := i.MessageComponentData().Values; len(values) > 0 {
+		targetPlayerID = values[0]
+	}
+
+	if targetPlayerID == "" {
+		return RespondWithEphemeralMessage(s, i, "No player selected")
+	}
+
+	output, err := b.gameService.RequestDrinkTransfer(ctx, &game.RequestDrinkTransferInput{
+		ChannelID:    channelID,
+		GuildID:      i.GuildID,
+		FromPlayerID: userID,
+		ToPlayerID:   targetPlayerID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNoDrinksToTransfer):
+			return updateForgetMessage(s, i, "You don't owe any drinks to hand over.")
+		case errors.Is(err, game.ErrCannotTransferToSelf):
+			return updateForgetMessage(s, i, "You can't hand a drink to yourself.")
+		case errors.Is(err, game.ErrInvalidSubstitute):
+			return updateForgetMessage(s, i, "Designated drivers can't be handed drinks.")
+		}
+		log.Printf("Error requesting drink transfer: %v", err)
+		return updateForgetMessage(s, i, b.friendlyError(ctx, err, "Couldn't offer the drink"))
+	}
+
+	// The volunteer answers in the channel
+	_, err = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("<@%s>, <@%s> wants to hand you one of their drinks. 🍺 Will you take it?", targetPlayerID, userID),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Take it",
+						Style:    discordgo.SuccessButton,
+						CustomID: ButtonAcceptTransferPrefix + output.Transfer.ID,
+					},
+					discordgo.Button{
+						Label:    "No thanks",
+						Style:    discordgo.SecondaryButton,
+						CustomID: ButtonDeclineTransferPrefix + output.Transfer.ID,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error sending drink transfer offer: %v", err)
+		return updateForgetMessage(s, i, "Couldn't post the offer, try again.")
+	}
+
+	return updateForgetMessage(s, i, fmt.Sprintf("Asked <@%s> to take one of your drinks.", targetPlayerID))
+}
+
+// handleAcceptTransferButton moves the offered drink to the volunteer once they accept
+func (b *Bot) handleAcceptTransferButton(s DiscordSession, i *discordgo.InteractionCreate, userID, transferID string) error {
+	ctx := context.Background()
+
+	output, err := b.gameService.AcceptDrinkTransfer(ctx, &game.AcceptDrinkTransferInput{
+		TransferID: transferID,
+		PlayerID:   userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotTransferTarget):
+			return RespondWithEphemeralMessage(s, i, "Only the player who was asked can take this drink.")
+		case errors.Is(err, game.ErrDrinkTransferNotFound):
+			return updateForgetMessage(s, i, "This drink offer has expired.")
+		}
+		log.Printf("Error accepting drink transfer: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't take the drink"))
+	}
+
+	b.refreshGameMessage(ctx, s, i.GuildID, i.ChannelID)
+
+	return updateForgetMessage(s, i, fmt.Sprintf("🤝 <@%s> took a drink off <@%s>'s hands.",
+		output.Transfer.ToPlayerID, output.Transfer.FromPlayerID))
+}
+
+// handleDeclineTransferButton turns down the offer, or calls it off when the player who made it presses it
+func (b *Bot) handleDeclineTransferButton(s DiscordSession, i *discordgo.InteractionCreate, userID, transferID string) error {
+	ctx := context.Background()
+
+	output, err := b.gameService.DeclineDrinkTransfer(ctx, &game.DeclineDrinkTransferInput{
+		TransferID: transferID,
+		PlayerID:   userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNotTransferTarget):
+			return RespondWithEphemeralMessage(s, i, "This drink offer isn't yours to turn down.")
+		case errors.Is(err, game.ErrDrinkTransferNotFound):
+			return updateForgetMessage(s, i, "This drink offer has expired.")
+		}
+		log.Printf("Error declining drink transfer: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't turn down the drink"))
+	}
+
+	if userID == output.Transfer.FromPlayerID {
+		return updateForgetMessage(s, i, fmt.Sprintf("<@%s> called off the drink offer.", userID))
+	}
+	return updateForgetMessage(s, i, fmt.Sprintf("<@%s> passed on <@%s>'s drink.", userID, output.Transfer.FromPlayerID))
+}
+
+// refreshGameMessage redraws the channel's game message after its drinks have changed
+func (b *Bot) refreshGameMessage(ctx context.Context, s DiscordSession, guildID, channelID string) {
+	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID,
+		GuildID:   guildID,
+	})
+	if err != nil {
+		return
+	}
+	b.updateGameMessage(s, channelID, existingGame.Game.ID)
+}
@@ -0,0 +1,214 @@
+package discord
+
+import (
+	"errors"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultMessageUpdateWindow is how long updates to a channel's game message are coalesced for
+const defaultMessageUpdateWindow = time.Second
+
+// maxMessageUpdateRetries is how many times a rate limited edit is retried before it's dropped
+const maxMessageUpdateRetries = 3
+
+var (
+	// messageEditsCoalesced counts game message updates folded into an edit that was already waiting
+	messageEditsCoalesced = expvar.NewInt("discord_message_edits_coalesced")
+
+	// messageEditsDropped counts game message edits given up on, after too many rate limits or at shutdown
+	messageEditsDropped = expvar.NewInt("discord_message_edits_dropped")
+
+	// messageEditsRateLimited counts game message edits Discord turned away with a rate limit
+	messageEditsRateLimited = expvar.NewInt("discord_message_edits_rate_limited")
+)
+
+// messageUpdate is a requested edit of a channel's game message
+type messageUpdate struct {
+	gameID  string
+	retries int
+
+	// guildID is set instead of gameID when a guild's pinned leaderboard is refreshed
+	guildID string
+}
+
+// channelUpdates tracks the edits of one channel's game message
+type channelUpdates struct {
+	session DiscordSession
+	pending *messageUpdate
+	timer   clock.Timer
+}
+
+// messageUpdateQueue debounces game message edits per channel.
+// The first update in a quiet channel is sent straight away; any updates in the window after it are
+// coalesced into a single edit sent when the window closes. Edits render the latest game state, so the
+// newest update always covers the ones it replaced.
+type messageUpdateQueue struct {
+	clock  clock.Clock
+	window time.Duration
+	send   func(s DiscordSession, channelID string, update *messageUpdate) error
+
+	mu       sync.Mutex
+	channels map[string]*channelUpdates
+	stopped  bool
+}
+
+// newMessageUpdateQueue creates a queue that sends edits with the given function
+func newMessageUpdateQueue(clk clock.Clock, window time.Duration, send func(s DiscordSession, channelID string, update *messageUpdate) error) *messageUpdateQueue {
+	if window <= 0 {
+		window = defaultMessageUpdateWindow
+	}
+
+	return &messageUpdateQueue{
+		clock:    clk,
+		window:   window,
+		send:     send,
+		channels: make(map[string]*channelUpdates),
+	}
+}
+
+// enqueue asks for the channel's game message to be edited
+func (q *messageUpdateQueue) enqueue(s DiscordSession, channelID string, update *messageUpdate) {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		messageEditsDropped.Add(1)
+		return
+	}
+
+	if channel, ok := q.channels[channelID]; ok {
+		if channel.pending != nil {
+			messageEditsCoalesced.Add(1)
+		}
+		channel.session = s
+		channel.pending = update
+		q.mu.Unlock()
+		return
+	}
+
+	q.channels[channelID] = &channelUpdates{
+		session: s,
+		timer: q.clock.AfterFunc(q.window, func() {
+			q.flush(channelID)
+		}),
+	}
+	q.mu.Unlock()
+
+	q.deliver(s, channelID, update)
+}
+
+// flush sends the channel's waiting edit when its window closes, or forgets the channel if nothing is waiting
+func (q *messageUpdateQueue) flush(channelID string) {
+	q.mu.Lock()
+	channel, ok := q.channels[channelID]
+	if !ok {
+		q.mu.Unlock()
+		return
+	}
+
+	if channel.pending == nil {
+		delete(q.channels, channelID)
+		q.mu.Unlock()
+		return
+	}
+
+	update := channel.pending
+	channel.pending = nil
+	channel.timer.Reset(q.window)
+	s := channel.session
+	q.mu.Unlock()
+
+	q.deliver(s, channelID, update)
+}
+
+// deliver sends an edit, holding it back until Discord is ready for it if it was rate limited
+func (q *messageUpdateQueue) deliver(s DiscordSession, channelID string, update *messageUpdate) {
+	err := q.send(s, channelID, update)
+
+	var rateLimitErr *discordgo.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return
+	}
+	messageEditsRateLimited.Add(1)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	channel, ok := q.channels[channelID]
+	if !ok || q.stopped {
+		messageEditsDropped.Add(1)
+		return
+	}
+
+	// A newer update is already waiting and will show everything this one would have
+	if channel.pending != nil {
+		messageEditsCoalesced.Add(1)
+	} else if update.retries >= maxMessageUpdateRetries {
+		log.Printf("Dropping game message edit in channel %s after %d rate limits", channelID, update.retries+1)
+		messageEditsDropped.Add(1)
+	} else {
+		update.retries++
+		channel.pending = update
+	}
+
+	retryAfter := q.window
+	if rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil && rateLimitErr.RetryAfter > retryAfter {
+		retryAfter = rateLimitErr.RetryAfter
+	}
+	channel.timer.Reset(retryAfter)
+}
+
+// drain stops taking new edits and sends every waiting edit straight away, without retrying the rate limited ones.
+// Edits still waiting once the deadline passes are dropped.
+func (q *messageUpdateQueue) drain(deadline time.Time) {
+	type waitingEdit struct {
+		session   DiscordSession
+		channelID string
+		update    *messageUpdate
+	}
+
+	q.mu.Lock()
+	q.stopped = true
+	var waiting []waitingEdit
+	for channelID, channel := range q.channels {
+		channel.timer.Stop()
+		if channel.pending != nil {
+			waiting = append(waiting, waitingEdit{session: channel.session, channelID: channelID, update: channel.pending})
+		}
+		delete(q.channels, channelID)
+	}
+	q.mu.Unlock()
+
+	for n, edit := range waiting {
+		if !q.clock.Now().Before(deadline) {
+			log.Printf("Dropping %d game message edits waiting at shutdown", len(waiting)-n)
+			messageEditsDropped.Add(int64(len(waiting) - n))
+			return
+		}
+
+		if err := q.send(edit.session, edit.channelID, edit.update); err != nil {
+			log.Printf("Error sending game message edit in channel %s at shutdown: %v", edit.channelID, err)
+			messageEditsDropped.Add(1)
+		}
+	}
+}
+
+// stop cancels every waiting edit
+func (q *messageUpdateQueue) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.stopped = true
+	for channelID, channel := range q.channels {
+		channel.timer.Stop()
+		if channel.pending != nil {
+			messageEditsDropped.Add(1)
+		}
+		delete(q.channels, channelID)
+	}
+}
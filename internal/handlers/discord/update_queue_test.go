@@ -0,0 +1,215 @@
+package discord
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/suite"
+)
+
+// sentEdit is an edit the queue handed to its send function
+type sentEdit struct {
+	channelID string
+	gameID    string
+}
+
+type MessageUpdateQueueTestSuite struct {
+	suite.Suite
+	clock *clock.Fake
+	queue *messageUpdateQueue
+
+	mu    sync.Mutex
+	sent  []sentEdit
+	errs  []error
+	calls int
+}
+
+func (s *MessageUpdateQueueTestSuite) SetupTest() {
+	s.sent = nil
+	s.errs = nil
+	s.calls = 0
+	s.clock = clock.NewFake(time.Now())
+	s.queue = newMessageUpdateQueue(s.clock, 20*time.Millisecond, s.send)
+}
+
+func (s *MessageUpdateQueueTestSuite) TearDownTest() {
+	s.queue.stop()
+}
+
+func TestMessageUpdateQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageUpdateQueueTestSuite))
+}
+
+// send records the edit, failing it with the next queued error if there is one
+func (s *MessageUpdateQueueTestSuite) send(_ DiscordSession, channelID string, update *messageUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+
+	s.sent = append(s.sent, sentEdit{channelID: channelID, gameID: update.gameID})
+	return nil
+}
+
+// sentEdits returns a copy of the edits sent so far
+func (s *MessageUpdateQueueTestSuite) sentEdits() []sentEdit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]sentEdit(nil), s.sent...)
+}
+
+// rateLimited builds the error Discord returns for a rate limited request
+func rateLimited(retryAfter time.Duration) error {
+	return &discordgo.RateLimitError{RateLimit: &discordgo.RateLimit{
+		TooManyRequests: &discordgo.TooManyRequests{RetryAfter: retryAfter},
+	}}
+}
+
+func (s *MessageUpdateQueueTestSuite) TestFirstUpdateIsSentImmediately() {
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+
+	s.Equal([]sentEdit{{channelID: "channel-1", gameID: "game-1"}}, s.sentEdits())
+}
+
+func (s *MessageUpdateQueueTestSuite) TestUpdatesInWindowAreCoalesced() {
+	coalescedBefore := messageEditsCoalesced.Value()
+
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-2"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-3"})
+
+	s.clock.Advance(19 * time.Millisecond)
+	s.Len(s.sentEdits(), 1, "nothing more is sent until the window closes")
+
+	// Only the newest waiting update is sent when the window closes
+	s.clock.Advance(time.Millisecond)
+	s.Require().Len(s.sentEdits(), 2)
+	s.Equal("game-3", s.sentEdits()[1].gameID)
+	s.Equal(coalescedBefore+1, messageEditsCoalesced.Value())
+
+	s.clock.Advance(50 * time.Millisecond)
+	s.Len(s.sentEdits(), 2)
+}
+
+func (s *MessageUpdateQueueTestSuite) TestChannelsAreDebouncedSeparately() {
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-2", &messageUpdate{gameID: "game-2"})
+
+	s.Equal([]sentEdit{
+		{channelID: "channel-1", gameID: "game-1"},
+		{channelID: "channel-2", gameID: "game-2"},
+	}, s.sentEdits())
+}
+
+func (s *MessageUpdateQueueTestSuite) TestRateLimitedEditIsRetried() {
+	rateLimitedBefore := messageEditsRateLimited.Value()
+	s.errs = []error{rateLimited(30 * time.Millisecond)}
+
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.Empty(s.sentEdits())
+	s.Equal(rateLimitedBefore+1, messageEditsRateLimited.Value())
+
+	// The retry waits for as long as Discord asked
+	s.clock.Advance(20 * time.Millisecond)
+	s.Empty(s.sentEdits())
+
+	s.clock.Advance(10 * time.Millisecond)
+	s.Require().Len(s.sentEdits(), 1)
+	s.Equal("game-1", s.sentEdits()[0].gameID)
+}
+
+func (s *MessageUpdateQueueTestSuite) TestRateLimitedEditIsDroppedAfterRetries() {
+	droppedBefore := messageEditsDropped.Value()
+	for i := 0; i <= maxMessageUpdateRetries; i++ {
+		s.errs = append(s.errs, rateLimited(time.Millisecond))
+	}
+
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+
+	s.clock.Advance(time.Second)
+	s.Equal(droppedBefore+1, messageEditsDropped.Value())
+	s.Empty(s.sentEdits())
+
+	s.mu.Lock()
+	s.Equal(maxMessageUpdateRetries+1, s.calls)
+	s.mu.Unlock()
+}
+
+func (s *MessageUpdateQueueTestSuite) TestOtherErrorsAreNotRetried() {
+	s.errs = []error{errors.New("unknown message")}
+
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.clock.Advance(50 * time.Millisecond)
+
+	s.mu.Lock()
+	s.Equal(1, s.calls)
+	s.mu.Unlock()
+}
+
+func (s *MessageUpdateQueueTestSuite) TestStopDropsWaitingEdits() {
+	droppedBefore := messageEditsDropped.Value()
+
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-2"})
+	s.queue.stop()
+
+	s.clock.Advance(50 * time.Millisecond)
+	s.Len(s.sentEdits(), 1)
+	s.Equal(droppedBefore+1, messageEditsDropped.Value())
+}
+
+func (s *MessageUpdateQueueTestSuite) TestDrainSendsWaitingEdits() {
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-2"})
+	s.queue.enqueue(nil, "channel-2", &messageUpdate{gameID: "game-3"})
+
+	s.queue.drain(s.clock.Now().Add(time.Second))
+
+	s.ElementsMatch([]sentEdit{
+		{channelID: "channel-1", gameID: "game-1"},
+		{channelID: "channel-1", gameID: "game-2"},
+		{channelID: "channel-2", gameID: "game-3"},
+	}, s.sentEdits())
+
+	// Edits asked for afterwards are dropped
+	droppedBefore := messageEditsDropped.Value()
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-4"})
+	s.clock.Advance(50 * time.Millisecond)
+	s.Len(s.sentEdits(), 3)
+	s.Equal(droppedBefore+1, messageEditsDropped.Value())
+}
+
+func (s *MessageUpdateQueueTestSuite) TestDrainDoesNotRetryRateLimitedEdits() {
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-2"})
+	s.errs = []error{rateLimited(time.Second)}
+	droppedBefore := messageEditsDropped.Value()
+
+	s.queue.drain(s.clock.Now().Add(time.Second))
+	s.clock.Advance(2 * time.Second)
+
+	s.Equal(2, s.calls)
+	s.Len(s.sentEdits(), 1)
+	s.Equal(droppedBefore+1, messageEditsDropped.Value())
+}
+
+func (s *MessageUpdateQueueTestSuite) TestDrainDropsEditsPastTheDeadline() {
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-1"})
+	s.queue.enqueue(nil, "channel-1", &messageUpdate{gameID: "game-2"})
+	droppedBefore := messageEditsDropped.Value()
+
+	s.queue.drain(s.clock.Now())
+
+	s.Len(s.sentEdits(), 1)
+	s.Equal(droppedBefore+1, messageEditsDropped.Value())
+}
@@ -0,0 +1,228 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// voteCommand returns the subcommand for calling a vote on the game in the channel
+func voteCommand() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommand,
+		Name:        "vote",
+		Description: "Call a vote to start or abandon the game in this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "action",
+				Description: "What to vote on",
+				Required:    true,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Start the game", Value: string(models.VoteActionStart)},
+					{Name: "Abandon the game", Value: string(models.VoteActionAbandon)},
+				},
+			},
+		},
+	}
+}
+
+// handleVote calls a vote on the game in the channel
+func (c *RonniedCommand) handleVote(s DiscordSession, i *discordgo.InteractionCreate, subcommand *discordgo.ApplicationCommandInteractionDataOption, channelID, userID string) error {
+	ctx := context.Background()
+
+	var action models.VoteAction
+	for _, opt := range subcommand.Options {
+		if opt.Name == "action" {
+			action = models.VoteAction(opt.StringValue())
+		}
+	}
+
+	existingGame, err := c.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return RespondWithEphemeralMessage(s, i, "No game found in this channel. Use `/ronnied start` to create a new game.")
+		}
+		log.Printf("Error getting game: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Error getting game: %v", err))
+	}
+
+	return callVote(ctx, c.gameService, s, i, existingGame.Game.ID, userID, action)
+}
+
+// callVote calls a vote on the game and posts it in the channel with buttons to vote
+func callVote(ctx context.Context, gameService game.Service, s DiscordSession, i *discordgo.InteractionCreate, gameID, userID string, action models.VoteAction) error {
+	output, err := gameService.CallVote(ctx, &game.CallVoteInput{
+		GameID:   gameID,
+		PlayerID: userID,
+		Action:   action,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrPlayerNotInGame):
+			return RespondWithEphemeralMessage(s, i, "Only players in the game can call a vote, join it first.")
+		case errors.Is(err, game.ErrVoteInProgress):
+			return RespondWithEphemeralMessage(s, i, "There's already a vote going, have your say on that one.")
+		case errors.Is(err, game.ErrInvalidGameState):
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("The game can't be %s now.", voteActionDone(action)))
+		}
+		log.Printf("Error calling vote: %v", err)
+		return RespondWithError(s, i, fmt.Sprintf("Couldn't call the vote: %v", err))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{voteEmbed(output.Vote, output.Closed, output.Passed)},
+			Components: voteButtons(gameID, output.Closed),
+		},
+	})
+}
+
+// handleVoteButton records a player's vote and shows how the vote stands
+func (b *Bot) handleVoteButton(s DiscordSession, i *discordgo.InteractionCreate, userID, gameID string, approve bool) error {
+	ctx := b.messagingContext(context.Background(), i.GuildID)
+
+	output, err := b.gameService.CastVote(ctx, &game.CastVoteInput{
+		GameID:   gameID,
+		PlayerID: userID,
+		Approve:  approve,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrNoVote):
+			return updateForgetMessage(s, i, "This vote is over.")
+		case errors.Is(err, game.ErrPlayerNotInGame):
+			return RespondWithEphemeralMessage(s, i, "Only players in the game get a vote.")
+		case errors.Is(err, game.ErrAlreadyVoted):
+			return RespondWithEphemeralMessage(s, i, "You've already voted.")
+		}
+		log.Printf("Error casting vote: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Couldn't count your vote"))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{voteEmbed(output.Vote, output.Closed, output.Passed)},
+			Components: voteButtons(gameID, output.Closed),
+		},
+	})
+}
+
+// handleVoteClosed redraws the game once a vote on it passes, and counts down a countdown game started by one
+func (b *Bot) handleVoteClosed(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.VoteClosedPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+	if !payload.Passed {
+		return
+	}
+
+	b.updateGameMessage(b.api, event.ChannelID, event.GameID)
+
+	if models.VoteAction(payload.Action) != models.VoteActionStart {
+		return
+	}
+	output, err := b.gameService.GetGame(ctx, &game.GetGameInput{
+		GameID:  event.GameID,
+		GuildID: event.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting game %s started by vote: %v", event.GameID, err)
+		return
+	}
+	if output.Game.RollWindow != nil {
+		go b.runCountdown(event.GuildID, event.ChannelID, output.Game.RollWindow)
+	}
+}
+
+// voteEmbed shows who called a vote, who's voted which way and how it ended
+func voteEmbed(vote *models.GameVote, closed, passed bool) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🗳️ Vote to %s the Game", voteActionTitle(vote.Action)),
+		Description: fmt.Sprintf("<@%s> called a vote to %s the game. It needs **%d** approvals and closes %s.",
+			vote.CalledBy, vote.Action, vote.Required, discordTimestamp(vote.ExpiresAt, timestampRelative)),
+		Color: 0x3498DB,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   fmt.Sprintf("✅ For (%d/%d)", len(vote.Approvals), vote.Required),
+				Value:  mentionsOrNobody(vote.Approvals),
+				Inline: true,
+			},
+			{
+				Name:   fmt.Sprintf("❌ Against (%d)", len(vote.Rejections)),
+				Value:  mentionsOrNobody(vote.Rejections),
+				Inline: true,
+			},
+		},
+	}
+
+	switch {
+	case passed:
+		embed.Description = fmt.Sprintf("The vote passed, the game was %s.", voteActionDone(vote.Action))
+		embed.Color = 0x2ECC71
+	case closed:
+		embed.Description = fmt.Sprintf("The vote failed, too many players turned it down for the game to be %s.", voteActionDone(vote.Action))
+		embed.Color = 0xE74C3C
+	}
+	return embed
+}
+
+// voteButtons returns the buttons to vote with, none once the vote is closed
+func voteButtons(gameID string, closed bool) []discordgo.MessageComponent {
+	if closed {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Yes",
+					Style:    discordgo.SuccessButton,
+					CustomID: ButtonVoteYesPrefix + gameID,
+				},
+				discordgo.Button{
+					Label:    "No",
+					Style:    discordgo.DangerButton,
+					CustomID: ButtonVoteNoPrefix + gameID,
+				},
+			},
+		},
+	}
+}
+
+// voteActionTitle returns the action for a vote's title
+func voteActionTitle(action models.VoteAction) string {
+	if action == models.VoteActionAbandon {
+		return "Abandon"
+	}
+	return "Start"
+}
+
+// voteActionDone returns the action as it's been done to a game, e.g. started
+func voteActionDone(action models.VoteAction) string {
+	if action == models.VoteActionAbandon {
+		return "abandoned"
+	}
+	return "started"
+}
+
+// mentionsOrNobody mentions the players, or says nobody when there aren't any
+func mentionsOrNobody(playerIDs []string) string {
+	if len(playerIDs) == 0 {
+		return "Nobody yet"
+	}
+	return mentions(playerIDs)
+}
@@ -0,0 +1,114 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/bwmarrin/discordgo"
+)
+
+// leaveGameButton returns the button players press to leave a game before it starts, or its waitlist
+func leaveGameButton() discordgo.Button {
+	return discordgo.Button{
+		Label:    "Leave",
+		Style:    discordgo.SecondaryButton,
+		CustomID: ButtonLeaveGame,
+		Emoji: discordgo.ComponentEmoji{
+			Name: "🚪",
+		},
+	}
+}
+
+// waitlistField lists who's waiting for a spot in a full game, nil when nobody is
+func waitlistField(g *models.Game) *discordgo.MessageEmbedField {
+	if len(g.Waitlist) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(g.Waitlist))
+	for i, entry := range g.Waitlist {
+		lines[i] = fmt.Sprintf("%d. <@%s>", i+1, entry.PlayerID)
+	}
+
+	return &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("⏳ Waitlist (%d)", len(g.Waitlist)),
+		Value: strings.Join(lines, "\n"),
+	}
+}
+
+// joinWaitlist puts a player who tried to join a full game on its waitlist and tells them their place
+func (b *Bot) joinWaitlist(ctx context.Context, s DiscordSession, i *discordgo.InteractionCreate, channelID, gameID, userID, username string) error {
+	output, err := b.gameService.JoinWaitlist(ctx, &game.JoinWaitlistInput{
+		GameID:     gameID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		log.Printf("Error joining waitlist: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to join the waitlist"))
+	}
+
+	b.updateGameMessage(s, channelID, gameID)
+
+	return RespondWithEphemeralMessage(s, i, fmt.Sprintf(
+		"The game is full, you're #%d on the waitlist. You'll be pinged if a spot opens up before it starts.", output.Position))
+}
+
+// handleLeaveGameButton takes a player out of the game waiting in the channel, or off its waitlist
+func (b *Bot) handleLeaveGameButton(s DiscordSession, i *discordgo.InteractionCreate, channelID, userID string) error {
+	ctx := b.messagingContext(context.Background(), i.GuildID)
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		log.Printf("Error getting game: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Error"))
+	}
+
+	output, err := b.gameService.LeaveGame(ctx, &game.LeaveGameInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, game.ErrCreatorCannotLeave):
+			return RespondWithEphemeralMessage(s, i, "You created this game, abandon it instead if you're not playing.")
+		case errors.Is(err, game.ErrPlayerNotInGame):
+			return RespondWithEphemeralMessage(s, i, "You're not in this game or on its waitlist.")
+		case errors.Is(err, game.ErrInvalidGameState):
+			return RespondWithEphemeralMessage(s, i, "The game has already started, you're in it now.")
+		}
+		log.Printf("Error leaving game: %v", err)
+		return RespondWithEphemeralMessage(s, i, b.friendlyError(ctx, err, "Failed to leave the game"))
+	}
+
+	b.updateGameMessage(s, channelID, existingGame.Game.ID)
+
+	if output.Waitlisted {
+		return RespondWithEphemeralMessage(s, i, "You've left the waitlist.")
+	}
+	return RespondWithEphemeralMessage(s, i, "You've left the game.")
+}
+
+// handleWaitlistPromoted pings the player who got a spot in a game from its waitlist
+func (b *Bot) handleWaitlistPromoted(ctx context.Context, event *events.Event) {
+	payload, ok := event.Payload.(*events.WaitlistPromotedPayload)
+	if !ok {
+		log.Printf("Unexpected payload for %s event: %T", event.Type, event.Payload)
+		return
+	}
+
+	_, err := b.api.ChannelMessageSendComplex(event.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("🎟️ <@%s>, a spot opened up and you're in the game!", payload.PlayerID),
+	})
+	if err != nil {
+		log.Printf("Error pinging %s about their waitlist spot: %v", payload.PlayerID, err)
+	}
+}
@@ -0,0 +1,218 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/services/webhook"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Webhook subcommand event choices
+const (
+	webhookEventsAll = "all"
+)
+
+// webhookCommandGroup returns the admin subcommand group for managing webhooks
+func webhookCommandGroup() *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:        "webhook",
+		Description: "Manage outbound webhooks (admins only)",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "add",
+				Description: "Register a URL that receives game events as JSON",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "url",
+						Description: "The http(s) URL to POST events to",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "events",
+						Description: "Which events to send (default: all)",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "All events", Value: webhookEventsAll},
+							{Name: "Game completed", Value: string(events.TypeGameCompleted)},
+							{Name: "Session leaderboard", Value: string(events.TypeSessionLeaderboard)},
+							{Name: "Session rotated", Value: string(events.TypeSessionRotated)},
+						},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "list",
+				Description: "List the webhooks registered for this server",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "remove",
+				Description: "Remove a registered webhook",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "id",
+						Description: "The webhook ID (see /ronnied-admin webhook list)",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// isGuildAdmin returns true if the interaction member can manage the server
+func isGuildAdmin(i *discordgo.InteractionCreate) bool {
+	return hasPermissions(i, discordgo.PermissionManageServer)
+}
+
+// hasPermissions reports whether the member using an interaction has all of the permissions, administrators have
+// every permission
+func hasPermissions(i *discordgo.InteractionCreate, permissions int64) bool {
+	if i.Member == nil {
+		return false
+	}
+
+	return i.Member.Permissions&discordgo.PermissionAdministrator != 0 || i.Member.Permissions&permissions == permissions
+}
+
+// handleWebhook handles the webhook subcommand group
+func (c *RonniedCommand) handleWebhook(s DiscordSession, i *discordgo.InteractionCreate, group *discordgo.ApplicationCommandInteractionDataOption) error {
+	ctx := context.Background()
+
+	if c.webhookService == nil {
+		return RespondWithEphemeralMessage(s, i, "Webhooks are not enabled on this bot.")
+	}
+
+	if len(group.Options) == 0 {
+		return errors.New("missing webhook subcommand")
+	}
+
+	subcommand := group.Options[0]
+	options := make(map[string]string)
+	for _, opt := range subcommand.Options {
+		options[opt.Name] = opt.StringValue()
+	}
+
+	switch subcommand.Name {
+	case "add":
+		var eventTypes []events.Type
+		if selected := options["events"]; selected != "" && selected != webhookEventsAll {
+			eventTypes = append(eventTypes, events.Type(selected))
+		}
+
+		output, err := c.webhookService.RegisterWebhook(ctx, &webhook.RegisterWebhookInput{
+			GuildID:   i.GuildID,
+			URL:       options["url"],
+			Events:    eventTypes,
+			CreatedBy: i.Member.User.ID,
+		})
+		if err != nil {
+			log.Printf("Error registering webhook: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't register webhook: %v", err))
+		}
+
+		return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Webhook registered with ID `%s`.\n"+
+			"Deliveries are signed with the secret `%s` in the `%s` header. Keep it somewhere safe, it won't be shown again.",
+			output.Webhook.ID, output.Webhook.Secret, webhook.SignatureHeader))
+
+	case "list":
+		output, err := c.webhookService.ListWebhooks(ctx, &webhook.ListWebhooksInput{
+			GuildID: i.GuildID,
+		})
+		if err != nil {
+			log.Printf("Error listing webhooks: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't list webhooks: %v", err))
+		}
+
+		if len(output.Webhooks) == 0 {
+			return RespondWithEphemeralMessage(s, i, "No webhooks registered. Add one with `/ronnied-admin webhook add`.")
+		}
+
+		var sb strings.Builder
+		sb.WriteString("**Registered webhooks**\n")
+		for _, hook := range output.Webhooks {
+			eventList := webhookEventsAll
+			if len(hook.Events) > 0 {
+				eventList = strings.Join(hook.Events, ", ")
+			}
+			sb.WriteString(fmt.Sprintf("`%s` - %s (%s)\n", hook.ID, hook.URL, eventList))
+		}
+
+		return RespondWithEphemeralMessage(s, i, sb.String())
+
+	case "remove":
+		_, err := c.webhookService.RemoveWebhook(ctx, &webhook.RemoveWebhookInput{
+			GuildID:   i.GuildID,
+			WebhookID: options["id"],
+		})
+		if err != nil {
+			if errors.Is(err, webhook.ErrWebhookNotFound) {
+				return RespondWithEphemeralMessage(s, i, "No webhook with that ID is registered for this server.")
+			}
+			log.Printf("Error removing webhook: %v", err)
+			return RespondWithEphemeralMessage(s, i, fmt.Sprintf("Couldn't remove webhook: %v", err))
+		}
+
+		return RespondWithEphemeralMessage(s, i, "Webhook removed.")
+
+	default:
+		return fmt.Errorf("unknown webhook subcommand: %s", subcommand.Name)
+	}
+}
+
+// handleWebhookEvent forwards a game event to the guild's registered webhooks
+func (b *Bot) handleWebhookEvent(ctx context.Context, event *events.Event) {
+	guildID := event.GuildID
+	if guildID == "" {
+		var err error
+		guildID, err = b.guildIDForChannel(event.ChannelID)
+		if err != nil {
+			log.Printf("Error resolving guild for webhook event %s: %v", event.Type, err)
+			return
+		}
+	}
+
+	// Deliver in the background so slow endpoints don't hold up the game
+	go func() {
+		output, err := b.webhookService.Dispatch(context.Background(), &webhook.DispatchInput{
+			GuildID: guildID,
+			Event:   event,
+		})
+		if err != nil {
+			log.Printf("Error dispatching webhook event %s: %v", event.Type, err)
+			return
+		}
+
+		if output.Failed > 0 {
+			log.Printf("Webhook event %s: %d delivered, %d failed", event.Type, output.Delivered, output.Failed)
+		}
+	}()
+}
+
+// guildIDForChannel looks up the guild a channel belongs to
+func (b *Bot) guildIDForChannel(channelID string) (string, error) {
+	if channel, err := b.session.State.Channel(channelID); err == nil {
+		return channel.GuildID, nil
+	}
+
+	channel, err := b.api.Channel(channelID)
+	if err != nil {
+		return "", err
+	}
+
+	if channel.GuildID == "" {
+		return "", fmt.Errorf("channel %s is not in a guild", channelID)
+	}
+
+	return channel.GuildID, nil
+}
@@ -0,0 +1,410 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	slackapi "github.com/slack-go/slack"
+)
+
+// handleStart creates a new game in the channel and posts the game message
+func (b *Bot) handleStart(ctx context.Context, channelID, userID, username string) string {
+	// Check if there's already a game in this channel
+	_, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil && !errors.Is(err, game.ErrGameNotFound) {
+		log.Printf("Error checking for existing game: %v", err)
+		return fmt.Sprintf("Error checking for existing game: %v", err)
+	}
+
+	if err == nil {
+		return "There's already a game in progress in this channel. Use `/ronnied abandon` to clear it if needed."
+	}
+
+	if err := b.createGame(ctx, channelID, userID, username); err != nil {
+		return fmt.Sprintf("Failed to create game: %v", err)
+	}
+
+	return "Game created! Everyone can join with the button on the game message."
+}
+
+// createGame creates a game, joins the creator and posts the shared game message
+func (b *Bot) createGame(ctx context.Context, channelID, userID, username string) error {
+	createOutput, err := b.gameService.CreateGame(ctx, &game.CreateGameInput{
+		ChannelID:   channelID,
+		CreatorID:   userID,
+		CreatorName: username,
+	})
+	if err != nil {
+		log.Printf("Error creating game: %v", err)
+		return err
+	}
+
+	// Join the creator to the game
+	_, err = b.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     createOutput.GameID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		log.Printf("Error joining game: %v", err)
+		// Not critical, continue
+	}
+
+	gameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
+		GameID: createOutput.GameID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Post the shared game message and remember its timestamp
+	_, timestamp, err := b.client.PostMessage(channelID, slackapi.MsgOptionBlocks(b.renderGameBlocks(ctx, gameOutput.Game, nil, nil)...))
+	if err != nil {
+		log.Printf("Error posting game message: %v", err)
+		return err
+	}
+
+	_, err = b.gameService.UpdateGameMessage(ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: timestamp,
+	})
+	if err != nil {
+		log.Printf("Error updating game message: %v", err)
+		// Not critical, continue
+	}
+
+	return nil
+}
+
+// handleLeaderboard renders the session leaderboard as text
+func (b *Bot) handleLeaderboard(ctx context.Context, channelID string) string {
+	sessionboard, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		log.Printf("Error getting session leaderboard: %v", err)
+		return fmt.Sprintf("Failed to get session leaderboard: %v", err)
+	}
+
+	return renderLeaderboardText(sessionboard.Entries)
+}
+
+// handleNewSession starts a new drinking session
+func (b *Bot) handleNewSession(ctx context.Context, channelID, userID string) string {
+	_, err := b.gameService.StartNewSession(ctx, &game.StartNewSessionInput{
+		ChannelID: channelID,
+		CreatorID: userID,
+	})
+	if err != nil {
+		log.Printf("Error starting new session: %v", err)
+		return fmt.Sprintf("Failed to start new session: %v", err)
+	}
+
+	return "New session started successfully."
+}
+
+// handleAbandon abandons the game in the channel
+func (b *Bot) handleAbandon(ctx context.Context, channelID, userID string) string {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return "No game found in this channel to abandon."
+		}
+		log.Printf("Error getting game: %v", err)
+		return fmt.Sprintf("Error getting game: %v", err)
+	}
+
+	_, err = b.gameService.AbandonGame(ctx, &game.AbandonGameInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrNotCreator) {
+			return "Only the game's hosts can abandon it while it's being played. Anyone can once it's been left idle for a few minutes."
+		}
+		log.Printf("Error abandoning game: %v", err)
+		return fmt.Sprintf("Failed to abandon game: %v", err)
+	}
+
+	return "Game abandoned successfully. You can start a new game with `/ronnied start`."
+}
+
+// handleJoinGame handles the join game button
+func (b *Bot) handleJoinGame(ctx context.Context, channelID, userID, username string) error {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Error: %v", err), false))
+	}
+
+	joinOutput, err := b.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     existingGame.Game.ID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		log.Printf("Error joining game: %v", err)
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(b.friendlyError(ctx, err, "Failed to join game"), false))
+	}
+
+	b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+
+	joinMsgOutput, err := b.messagingService.GetJoinGameMessage(ctx, &messaging.GetJoinGameMessageInput{
+		PlayerName:    username,
+		GameStatus:    existingGame.Game.Status,
+		AlreadyJoined: joinOutput.AlreadyJoined,
+	})
+	if err != nil {
+		log.Printf("Error getting join game message: %v", err)
+		joinMsgOutput = &messaging.GetJoinGameMessageOutput{
+			Message: "You've joined the game!",
+		}
+	}
+
+	return b.whisper(channelID, userID, slackapi.MsgOptionBlocks(
+		slackapi.NewSectionBlock(markdown(joinMsgOutput.Message), nil, nil),
+	))
+}
+
+// handleBeginGame handles the begin game button
+func (b *Bot) handleBeginGame(ctx context.Context, channelID, userID string) error {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Error: %v", err), false))
+	}
+
+	startOutput, err := b.gameService.StartGame(ctx, &game.StartGameInput{
+		GameID:     existingGame.Game.ID,
+		PlayerID:   userID,
+		ForceStart: true, // Service layer decides if a force start is allowed
+	})
+	if err != nil {
+		log.Printf("Error starting game: %v", err)
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Failed to start game: %v", err), false))
+	}
+
+	if !startOutput.Success {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText("Failed to start the game. Make sure you are the creator of the game.", false))
+	}
+
+	b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+
+	gameStartedMessage := "Game Started! Click Roll Dice on the game message to roll."
+	if startOutput.ForceStarted && startOutput.CreatorName != "" {
+		gameStartedMessage = fmt.Sprintf("Game force-started! %s took too long to start the game and has been assigned a drink.", startOutput.CreatorName)
+	} else if startMsgOutput, err := b.messagingService.GetGameStartedMessage(ctx, &messaging.GetGameStartedMessageInput{
+		CreatorName: existingGame.Game.GetCreatorName(),
+		PlayerCount: len(existingGame.Game.Participants),
+	}); err == nil {
+		gameStartedMessage = startMsgOutput.Message
+	}
+
+	return b.whisper(channelID, userID, slackapi.MsgOptionText(gameStartedMessage, false))
+}
+
+// handleRollDice handles the roll dice button
+func (b *Bot) handleRollDice(ctx context.Context, channelID, userID string) error {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return b.whisper(channelID, userID, slackapi.MsgOptionText("No active game found in this channel.", false))
+		}
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Error getting game: %v", err), false))
+	}
+
+	rollOutput, err := b.gameService.RollDice(ctx, &game.RollDiceInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrPlayerNotInGame) {
+			return b.whisper(channelID, userID, slackapi.MsgOptionText("You are not part of this game.", false))
+		}
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(b.friendlyError(ctx, err, "Failed to roll dice"), false))
+	}
+
+	if rollOutput.NeedsToRollInRollOff {
+		b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+		return b.whisper(channelID, userID, slackapi.MsgOptionText("You need to roll in a roll-off game! Use the Roll button on the game message to continue.", false))
+	}
+
+	// Update every game message affected by the roll
+	for _, gameID := range rollOutput.GameIDsToUpdate {
+		b.updateGameMessage(ctx, channelID, gameID)
+	}
+	b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+
+	rollResultOutput, err := b.messagingService.GetRollResultMessage(ctx, &messaging.GetRollResultMessageInput{
+		RollValue:         rollOutput.RollValue,
+		IsCriticalHit:     rollOutput.IsCriticalHit,
+		IsCriticalFail:    rollOutput.IsCriticalFail,
+		DiceSides:         rollOutput.DiceSides,
+		PlayerName:        rollOutput.PlayerName,
+		IsPersonalMessage: true,
+	})
+	if err != nil {
+		log.Printf("Error getting roll result message: %v", err)
+		rollResultOutput = &messaging.GetRollResultMessageOutput{
+			Title:   fmt.Sprintf("You rolled a %d", rollOutput.RollValue),
+			Message: "",
+		}
+	}
+
+	return b.whisper(channelID, userID, slackapi.MsgOptionBlocks(renderRollResultBlocks(rollResultOutput, rollOutput)...))
+}
+
+// handleAssignDrink handles the assign drink select menu
+func (b *Bot) handleAssignDrink(ctx context.Context, channelID, userID, targetPlayerID string) error {
+	if targetPlayerID == "" {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText("No player selected", false))
+	}
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return b.whisper(channelID, userID, slackapi.MsgOptionText("No active game found in this channel.", false))
+		}
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Error getting game: %v", err), false))
+	}
+
+	targetPlayerName := targetPlayerID
+	if participant := existingGame.Game.GetParticipant(targetPlayerID); participant != nil {
+		targetPlayerName = participant.PlayerName
+	}
+
+	assignOutput, err := b.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
+		GameID:       existingGame.Game.ID,
+		FromPlayerID: userID,
+		ToPlayerID:   targetPlayerID,
+		Reason:       game.DrinkReasonCriticalHit,
+	})
+	if err != nil {
+		log.Printf("Error assigning drink: %v", err)
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Failed to assign drink: %v", err), false))
+	}
+
+	b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+
+	confirmation := fmt.Sprintf("You assigned a drink to %s! :beers:", targetPlayerName)
+	if note := messaging.HappyHourNote(assignOutput.HappyHourMultiplier, assignOutput.OnTheHouse); note != "" {
+		confirmation += " " + note
+	}
+
+	return b.whisper(channelID, userID, slackapi.MsgOptionText(confirmation, false))
+}
+
+// handleStartNewGame handles the start new game button on a completed game
+func (b *Bot) handleStartNewGame(ctx context.Context, channelID, userID, username string) error {
+	_, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err == nil {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText("There's already an active game in this channel. Use `/ronnied abandon` if you want to abandon the current game.", false))
+	}
+
+	if err := b.createGame(ctx, channelID, userID, username); err != nil {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Failed to create game: %v", err), false))
+	}
+
+	return nil
+}
+
+// handlePayDrink handles the pay drink button
+func (b *Bot) handlePayDrink(ctx context.Context, channelID, userID string) error {
+	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return b.whisper(channelID, userID, slackapi.MsgOptionText("No active game found in this channel.", false))
+		}
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Error getting game: %v", err), false))
+	}
+
+	payOutput, err := b.gameService.PayDrink(ctx, &game.PayDrinkInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		log.Printf("Error paying drink: %v", err)
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("Failed to pay drink: %v", err), false))
+	}
+
+	if payOutput.Credited {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText(fmt.Sprintf("You didn't owe a drink, so it's banked as a credit (%d banked) for the next drink you're given.", payOutput.CreditBalance), false))
+	}
+
+	b.updateGameMessage(ctx, channelID, existingGame.Game.ID)
+
+	playerName := userID
+	if participant := existingGame.Game.GetParticipant(userID); participant != nil {
+		playerName = participant.PlayerName
+	}
+
+	payMsgOutput, err := b.messagingService.GetPayDrinkMessage(ctx, &messaging.GetPayDrinkMessageInput{
+		PlayerName: playerName,
+		DrinkCount: 1,
+	})
+	if err != nil {
+		return b.whisper(channelID, userID, slackapi.MsgOptionText("You paid your drink!", false))
+	}
+
+	return b.whisper(channelID, userID, slackapi.MsgOptionBlocks(
+		slackapi.NewSectionBlock(markdown(fmt.Sprintf("*%s*\n%s", payMsgOutput.Title, payMsgOutput.Message)), nil, nil),
+	))
+}
+
+// updateGameMessage re-renders the shared game message in the channel
+func (b *Bot) updateGameMessage(ctx context.Context, channelID, gameID string) {
+	view, err := b.gameService.GetGameView(ctx, &game.GetGameViewInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		log.Printf("Error getting game for message update: %v", err)
+		return
+	}
+
+	if view.Game.MessageID == "" {
+		log.Printf("Game has no message ID, cannot update")
+		return
+	}
+
+	blocks := b.renderGameBlocks(ctx, view.Game, view.DrinkRecords, view.SessionLeaderboard)
+
+	_, _, _, err = b.client.UpdateMessage(channelID, view.Game.MessageID, slackapi.MsgOptionBlocks(blocks...))
+	if err != nil {
+		log.Printf("Error updating game message: %v", err)
+	}
+}
+
+// friendlyError maps known game errors to a message from the messaging service
+func (b *Bot) friendlyError(ctx context.Context, err error, fallback string) string {
+	errorType := messaging.ErrorTypeFor(err)
+	if errorType == messaging.ErrorTypeUnknown {
+		return fmt.Sprintf("%s: %v", fallback, err)
+	}
+
+	errorMsgOutput, msgErr := b.messagingService.GetErrorMessage(ctx, &messaging.GetErrorMessageInput{
+		ErrorType: errorType,
+	})
+	if msgErr != nil {
+		return fmt.Sprintf("%s: %v", fallback, err)
+	}
+
+	return errorMsgOutput.Message
+}
@@ -0,0 +1,251 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	slackapi "github.com/slack-go/slack"
+)
+
+// Action IDs used by block kit buttons and selects
+const (
+	ActionJoinGame     = "join_game"
+	ActionBeginGame    = "begin_game"
+	ActionRollDice     = "roll_dice"
+	ActionStartNewGame = "start_new_game"
+	ActionPayDrink     = "pay_drink"
+	ActionAssignDrink  = "assign_drink"
+)
+
+// Bot represents the Slack bot instance
+type Bot struct {
+	client           *slackapi.Client
+	server           *http.Server
+	gameService      game.Service
+	messagingService messaging.Service
+	config           *Config
+}
+
+// Config holds the configuration for the Slack bot
+type Config struct {
+	// Bot user OAuth token (xoxb-...)
+	BotToken string
+
+	// Signing secret used to verify requests from Slack
+	SigningSecret string
+
+	// Address the HTTP server listens on for slash commands and interactions
+	ListenAddr string
+
+	// Slash command name (defaults to /ronnied)
+	CommandName string
+
+	// Game service
+	GameService game.Service
+
+	// Messaging service
+	MessagingService messaging.Service
+}
+
+// New creates a new Slack bot
+func New(cfg *Config) (*Bot, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	if cfg.BotToken == "" {
+		return nil, fmt.Errorf("bot token cannot be empty")
+	}
+
+	if cfg.SigningSecret == "" {
+		return nil, fmt.Errorf("signing secret cannot be empty")
+	}
+
+	if cfg.GameService == nil {
+		return nil, fmt.Errorf("game service cannot be nil")
+	}
+
+	if cfg.MessagingService == nil {
+		return nil, fmt.Errorf("messaging service cannot be nil")
+	}
+
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = ":3000"
+	}
+
+	if cfg.CommandName == "" {
+		cfg.CommandName = "/ronnied"
+	}
+
+	bot := &Bot{
+		client:           slackapi.New(cfg.BotToken),
+		gameService:      cfg.GameService,
+		messagingService: cfg.MessagingService,
+		config:           cfg,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slack/commands", bot.verified(bot.handleCommand))
+	mux.HandleFunc("/slack/interactions", bot.verified(bot.handleInteraction))
+
+	bot.server = &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return bot, nil
+}
+
+// Start begins serving slash commands and interactions
+func (b *Bot) Start() error {
+	go func() {
+		if err := b.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Slack HTTP server stopped: %v", err)
+		}
+	}()
+
+	log.Printf("Slack bot listening on %s", b.config.ListenAddr)
+	return nil
+}
+
+// Stop gracefully shuts down the HTTP server
+func (b *Bot) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return b.server.Shutdown(ctx)
+}
+
+// verified wraps a handler with Slack request signature verification
+func (b *Bot) verified(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		verifier, err := slackapi.NewSecretsVerifier(r.Header, b.config.SigningSecret)
+		if err != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := verifier.Write(body); err != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		if err := verifier.Ensure(); err != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		// Restore the body for the wrapped handler
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next(w, r)
+	}
+}
+
+// handleCommand handles the /ronnied slash command
+func (b *Bot) handleCommand(w http.ResponseWriter, r *http.Request) {
+	cmd, err := slackapi.SlashCommandParse(r)
+	if err != nil {
+		http.Error(w, "invalid command", http.StatusBadRequest)
+		return
+	}
+
+	if cmd.Command != b.config.CommandName {
+		respondEphemeral(w, fmt.Sprintf("Unknown command: %s", cmd.Command))
+		return
+	}
+
+	ctx := r.Context()
+
+	var reply string
+	switch cmd.Text {
+	case "", "start":
+		reply = b.handleStart(ctx, cmd.ChannelID, cmd.UserID, cmd.UserName)
+	case "leaderboard":
+		reply = b.handleLeaderboard(ctx, cmd.ChannelID)
+	case "newsession":
+		reply = b.handleNewSession(ctx, cmd.ChannelID, cmd.UserID)
+	case "abandon":
+		reply = b.handleAbandon(ctx, cmd.ChannelID, cmd.UserID)
+	default:
+		reply = fmt.Sprintf("Unknown subcommand `%s`. Try `start`, `leaderboard`, `newsession` or `abandon`.", cmd.Text)
+	}
+
+	respondEphemeral(w, reply)
+}
+
+// handleInteraction handles block kit button clicks and select menus
+func (b *Bot) handleInteraction(w http.ResponseWriter, r *http.Request) {
+	var callback slackapi.InteractionCallback
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &callback); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// Acknowledge right away, Slack expects a response within 3 seconds
+	w.WriteHeader(http.StatusOK)
+
+	if callback.Type != slackapi.InteractionTypeBlockActions || len(callback.ActionCallback.BlockActions) == 0 {
+		return
+	}
+
+	action := callback.ActionCallback.BlockActions[0]
+	channelID := callback.Channel.ID
+	userID := callback.User.ID
+	username := callback.User.Name
+
+	go func() {
+		ctx := context.Background()
+
+		var err error
+		switch action.ActionID {
+		case ActionJoinGame:
+			err = b.handleJoinGame(ctx, channelID, userID, username)
+		case ActionBeginGame:
+			err = b.handleBeginGame(ctx, channelID, userID)
+		case ActionRollDice:
+			err = b.handleRollDice(ctx, channelID, userID)
+		case ActionAssignDrink:
+			err = b.handleAssignDrink(ctx, channelID, userID, action.SelectedOption.Value)
+		case ActionStartNewGame:
+			err = b.handleStartNewGame(ctx, channelID, userID, username)
+		case ActionPayDrink:
+			err = b.handlePayDrink(ctx, channelID, userID)
+		default:
+			err = fmt.Errorf("unknown action: %s", action.ActionID)
+		}
+
+		if err != nil {
+			log.Printf("Error handling Slack action %s: %v", action.ActionID, err)
+		}
+	}()
+}
+
+// respondEphemeral writes an ephemeral slash command response
+func respondEphemeral(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&slackapi.Msg{
+		ResponseType: slackapi.ResponseTypeEphemeral,
+		Text:         text,
+	})
+}
+
+// whisper sends an ephemeral message only the user can see
+func (b *Bot) whisper(channelID, userID string, options ...slackapi.MsgOption) error {
+	_, err := b.client.PostEphemeral(channelID, userID, options...)
+	return err
+}
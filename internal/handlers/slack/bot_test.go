@@ -0,0 +1,245 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	slackapi "github.com/slack-go/slack"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const testSigningSecret = "test-signing-secret"
+
+// BotTestSuite tests the Slack HTTP handlers against the real game service
+type BotTestSuite struct {
+	suite.Suite
+	mr          *miniredis.Miniredis
+	client      *redis.Client
+	mockCtrl    *gomock.Controller
+	gameService game.Service
+	bot         *Bot
+	ctx         context.Context
+
+	testChannelID string
+}
+
+func (s *BotTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.ctx = context.Background()
+	s.testChannelID = "test-channel-id"
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      diceMocks.NewMockRoller(s.mockCtrl),
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+	s.Require().NoError(err)
+	s.gameService = gameSvc
+
+	msgSvc, err := messaging.NewService(&messaging.ServiceConfig{})
+	s.Require().NoError(err)
+
+	s.bot, err = New(&Config{
+		BotToken:         "xoxb-test",
+		SigningSecret:    testSigningSecret,
+		GameService:      gameSvc,
+		MessagingService: msgSvc,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *BotTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestBotTestSuite(t *testing.T) {
+	suite.Run(t, new(BotTestSuite))
+}
+
+// signedRequest builds a request to the path signed with the secret at the time, the way Slack signs them
+func (s *BotTestSuite) signedRequest(path, body, secret string, at time.Time) *http.Request {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+// command sends the slash command from alice and returns the ephemeral reply
+func (s *BotTestSuite) command(name, text string) string {
+	body := url.Values{
+		"command":    {name},
+		"text":       {text},
+		"channel_id": {s.testChannelID},
+		"user_id":    {"alice"},
+		"user_name":  {"alice"},
+	}.Encode()
+
+	recorder := httptest.NewRecorder()
+	s.bot.server.Handler.ServeHTTP(recorder, s.signedRequest("/slack/commands", body, testSigningSecret, time.Now()))
+	s.Require().Equal(http.StatusOK, recorder.Code)
+
+	var reply slackapi.Msg
+	s.Require().NoError(json.NewDecoder(recorder.Body).Decode(&reply))
+	s.Equal(slackapi.ResponseTypeEphemeral, reply.ResponseType)
+	return reply.Text
+}
+
+func (s *BotTestSuite) TestSignatureVerification() {
+	body := url.Values{"command": {"/ronnied"}, "text": {"leaderboard"}, "channel_id": {s.testChannelID}}.Encode()
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		status  int
+	}{
+		{
+			name: "good signature",
+			request: func() *http.Request {
+				return s.signedRequest("/slack/commands", body, testSigningSecret, time.Now())
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "signed with another secret",
+			request: func() *http.Request {
+				return s.signedRequest("/slack/commands", body, "someone-elses-secret", time.Now())
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "body changed after signing",
+			request: func() *http.Request {
+				req := s.signedRequest("/slack/commands", body, testSigningSecret, time.Now())
+				req.Body = http.NoBody
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "stale timestamp",
+			request: func() *http.Request {
+				return s.signedRequest("/slack/commands", body, testSigningSecret, time.Now().Add(-10*time.Minute))
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "unsigned",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(body))
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "unsigned interaction",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader("payload={}"))
+			},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := httptest.NewRecorder()
+			s.bot.server.Handler.ServeHTTP(recorder, tt.request())
+			s.Equal(tt.status, recorder.Code)
+		})
+	}
+}
+
+func (s *BotTestSuite) TestCommandDispatch() {
+	tests := []struct {
+		name     string
+		command  string
+		text     string
+		expected string
+	}{
+		{name: "unknown command", command: "/other", text: "start", expected: "Unknown command: /other"},
+		{name: "unknown subcommand", command: "/ronnied", text: "dance", expected: "Unknown subcommand `dance`"},
+		{name: "abandon without a game", command: "/ronnied", text: "abandon", expected: "No game found in this channel to abandon."},
+		{name: "new session", command: "/ronnied", text: "newsession", expected: "New session started successfully."},
+		{name: "leaderboard", command: "/ronnied", text: "leaderboard", expected: "No drinks have been assigned in this session yet."},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Contains(s.command(tt.command, tt.text), tt.expected)
+		})
+	}
+}
+
+func (s *BotTestSuite) TestStartWithAGameInTheChannel() {
+	_, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "bob",
+		CreatorName: "bob",
+	})
+	s.Require().NoError(err)
+
+	s.Contains(s.command("/ronnied", "start"), "There's already a game in progress in this channel.")
+	s.Contains(s.command("/ronnied", ""), "There's already a game in progress in this channel.")
+}
+
+func (s *BotTestSuite) TestAbandon() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &game.CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	s.Contains(s.command("/ronnied", "abandon"), "Game abandoned successfully.")
+
+	// Slack games have no guild
+	output, err := s.gameService.GetGame(s.ctx, &game.GetGameInput{GameID: createOutput.GameID})
+	s.Require().NoError(err)
+	s.True(output.Game.Status.IsAbandoned())
+}
@@ -0,0 +1,246 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	slackapi "github.com/slack-go/slack"
+)
+
+// markdown creates a mrkdwn text object
+func markdown(text string) *slackapi.TextBlockObject {
+	return slackapi.NewTextBlockObject(slackapi.MarkdownType, text, false, false)
+}
+
+// button creates a block kit button for an action
+func button(actionID, label string, style slackapi.Style) *slackapi.ButtonBlockElement {
+	btn := slackapi.NewButtonBlockElement(actionID, actionID, slackapi.NewTextBlockObject(slackapi.PlainTextType, label, true, false))
+	btn.Style = style
+	return btn
+}
+
+// gameRules returns the rules a game is played with, the classic rules on a d6 when they can't be looked up
+func (b *Bot) gameRules(ctx context.Context, g *models.Game) models.GameRules {
+	output, err := b.gameService.GetGameRules(ctx, &game.GetGameRulesInput{
+		Game: g,
+	})
+	if err != nil {
+		log.Printf("Error getting rules for game %s: %v", g.ID, err)
+		return models.GameRules{DiceSides: 6, CriticalHitValues: []int{6}, CriticalFailValues: []int{1}}
+	}
+	return output.Rules
+}
+
+// rollEmoji returns the emoji used for a roll value played with the given rules
+func rollEmoji(rollValue int, rules models.GameRules) string {
+	switch {
+	case rules.IsCriticalHit(rollValue):
+		return ":fire:" // Critical hit
+	case rules.IsCriticalFail(rollValue):
+		return ":skull:" // Critical fail
+	case rollValue == 5:
+		return ":star:" // High roll
+	case rollValue == 4:
+		return ":sparkles:" // Good roll
+	default:
+		return ":game_die:" // Normal roll
+	}
+}
+
+// renderGameBlocks renders the shared game message as block kit blocks
+func (b *Bot) renderGameBlocks(ctx context.Context, game *models.Game, drinkRecords []*models.DrinkLedger, sessionLeaderboardEntries []game.LeaderboardEntry) []slackapi.Block {
+	rules := b.gameRules(ctx, game)
+
+	blocks := []slackapi.Block{
+		slackapi.NewHeaderBlock(slackapi.NewTextBlockObject(slackapi.PlainTextType, game.Status.DisplayTitle(), true, false)),
+	}
+
+	var description string
+	switch game.Status {
+	case models.GameStatusWaiting:
+		description = ":video_game: *Waiting for players to join the drinking game!*\n_Click Join Game below to participate._"
+	case models.GameStatusActive:
+		description = fmt.Sprintf(":game_die: *Game in progress!* Each player should roll their dice.\n_Roll a %s to assign a drink, roll a %s and you drink!_",
+			models.FormatRollValues(rules.CriticalHitValues), models.FormatRollValues(rules.CriticalFailValues))
+	case models.GameStatusRollOff:
+		description = ":crossed_swords: *ROLL-OFF IN PROGRESS!* Players in the roll-off need to roll again to break the tie."
+	case models.GameStatusCompleted:
+		description = ":trophy: *Game completed!* Here are the final results.\n_Start a new game to continue the fun!_"
+	default:
+		description = game.Status.Description()
+	}
+	blocks = append(blocks, slackapi.NewSectionBlock(markdown(description), nil, nil))
+
+	// Participant list with roll info
+	var participantList strings.Builder
+	for _, p := range game.Participants {
+		if p.RollValue == 0 {
+			participantList.WriteString(fmt.Sprintf("• *%s* (:game_die: Not rolled yet)\n", p.PlayerName))
+			continue
+		}
+
+		var rollComment string
+		rollCommentOutput, err := b.messagingService.GetRollComment(ctx, &messaging.GetRollCommentInput{
+			PlayerName:     p.PlayerName,
+			RollValue:      p.RollValue,
+			IsCriticalHit:  rules.IsCriticalHit(p.RollValue),
+			IsCriticalFail: rules.IsCriticalFail(p.RollValue),
+		})
+		if err == nil && rollCommentOutput != nil {
+			rollComment = rollCommentOutput.Comment
+		}
+
+		participantList.WriteString(fmt.Sprintf("• *%s* (%s *%d*)%s\n", p.PlayerName, rollEmoji(p.RollValue, rules), p.RollValue, rollComment))
+	}
+
+	if participantList.Len() > 0 {
+		blocks = append(blocks,
+			slackapi.NewDividerBlock(),
+			slackapi.NewSectionBlock(markdown(fmt.Sprintf("*:busts_in_silhouette: Players (%d)*\n%s", len(game.Participants), participantList.String())), nil, nil),
+		)
+	}
+
+	// Recent drinks assigned in this game
+	if len(drinkRecords) > 0 {
+		names := make(map[string]string, len(game.Participants))
+		for _, p := range game.Participants {
+			names[p.PlayerID] = p.PlayerName
+		}
+
+		var drinkList strings.Builder
+		for _, record := range drinkRecords {
+			status := ""
+			if record.Paid {
+				status = " :white_check_mark:"
+			} else if record.Social {
+				status = " (social)"
+			} else if record.Forgiven {
+				status = " (forgiven)"
+			} else if record.OwedLater {
+				status = " (owed later)"
+			}
+			drinkList.WriteString(fmt.Sprintf("• %s → %s (%s)%s\n", nameOrID(names, record.FromPlayerID), nameOrID(names, record.ToPlayerID), record.Reason, status))
+		}
+
+		blocks = append(blocks, slackapi.NewSectionBlock(markdown("*:beer: Drinks*\n"+drinkList.String()), nil, nil))
+	}
+
+	if len(sessionLeaderboardEntries) > 0 {
+		blocks = append(blocks,
+			slackapi.NewDividerBlock(),
+			slackapi.NewSectionBlock(markdown(renderLeaderboardText(sessionLeaderboardEntries)), nil, nil),
+		)
+	}
+
+	// Buttons depend on the game status
+	var buttons []slackapi.BlockElement
+	switch game.Status {
+	case models.GameStatusWaiting:
+		buttons = append(buttons,
+			button(ActionJoinGame, "Join Game", slackapi.StylePrimary),
+			button(ActionBeginGame, "Begin Game", slackapi.StyleDefault),
+		)
+	case models.GameStatusActive, models.GameStatusRollOff:
+		buttons = append(buttons,
+			button(ActionRollDice, "Roll Dice", slackapi.StylePrimary),
+			button(ActionPayDrink, "Pay Drink", slackapi.StyleDefault),
+		)
+	case models.GameStatusCompleted:
+		buttons = append(buttons,
+			button(ActionStartNewGame, "Start New Game", slackapi.StylePrimary),
+			button(ActionPayDrink, "Pay Drink", slackapi.StyleDefault),
+		)
+	case models.GameStatusAbandoned:
+		buttons = append(buttons,
+			button(ActionStartNewGame, "Start New Game", slackapi.StylePrimary),
+		)
+	}
+
+	if len(buttons) > 0 {
+		blocks = append(blocks, slackapi.NewActionBlock("game_actions", buttons...))
+	}
+
+	return blocks
+}
+
+// renderRollResultBlocks renders the ephemeral roll result for the player who rolled
+func renderRollResultBlocks(result *messaging.GetRollResultMessageOutput, output *game.RollDiceOutput) []slackapi.Block {
+	text := fmt.Sprintf("*%s*", result.Title)
+	if result.Message != "" {
+		text += "\n" + result.Message
+	}
+	if output.Details != "" {
+		text += "\n_" + output.Details + "_"
+	}
+
+	blocks := []slackapi.Block{
+		slackapi.NewSectionBlock(markdown(text), nil, nil),
+	}
+
+	// Critical hits let the player pick who drinks
+	if output.IsCriticalHit && len(output.EligiblePlayers) > 0 {
+		options := make([]*slackapi.OptionBlockObject, 0, len(output.EligiblePlayers))
+		for _, player := range output.EligiblePlayers {
+			options = append(options, slackapi.NewOptionBlockObject(
+				player.PlayerID,
+				slackapi.NewTextBlockObject(slackapi.PlainTextType, player.PlayerName, false, false),
+				nil,
+			))
+		}
+
+		selectMenu := slackapi.NewOptionsSelectBlockElement(
+			slackapi.OptTypeStatic,
+			slackapi.NewTextBlockObject(slackapi.PlainTextType, "Choose who drinks", false, false),
+			ActionAssignDrink,
+			options...,
+		)
+
+		blocks = append(blocks, slackapi.NewActionBlock("assign_drink_actions", selectMenu))
+		return blocks
+	}
+
+	blocks = append(blocks, slackapi.NewActionBlock("roll_actions",
+		button(ActionRollDice, "Roll Again", slackapi.StylePrimary),
+		button(ActionPayDrink, "Pay Drink", slackapi.StyleDefault),
+	))
+
+	return blocks
+}
+
+// renderLeaderboardText renders session leaderboard entries as mrkdwn
+func renderLeaderboardText(entries []game.LeaderboardEntry) string {
+	if len(entries) == 0 {
+		return "No drinks have been assigned in this session yet."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*:bar_chart: Session Leaderboard*\n")
+	for i, entry := range entries {
+		sb.WriteString(fmt.Sprintf("%d. *%s* - %d drinks (%d paid)", i+1, entry.PlayerName, entry.DrinkCount, entry.PaidCount))
+		if entry.SocialCount > 0 {
+			sb.WriteString(fmt.Sprintf(", %d social", entry.SocialCount))
+		}
+		if entry.ForgivenCount > 0 {
+			sb.WriteString(fmt.Sprintf(", %d forgiven", entry.ForgivenCount))
+		}
+		if entry.DesignatedDriver {
+			sb.WriteString(" :car: designated driver")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// nameOrID returns the player name for an ID, falling back to the ID itself
+func nameOrID(names map[string]string, playerID string) string {
+	if name, ok := names[playerID]; ok {
+		return name
+	}
+	return playerID
+}
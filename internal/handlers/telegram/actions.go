@@ -0,0 +1,415 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleNewGame creates a new game in the chat and posts the game message
+func (b *Bot) handleNewGame(ctx context.Context, chatID int64, userID, username string) string {
+	// Check if there's already a game in this chat
+	_, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil && !errors.Is(err, game.ErrGameNotFound) {
+		log.Printf("Error checking for existing game: %v", err)
+		return fmt.Sprintf("Error checking for existing game: %v", html.EscapeString(err.Error()))
+	}
+
+	if err == nil {
+		return "There's already a game in progress in this chat. Use /abandon to clear it if needed."
+	}
+
+	if err := b.createGame(ctx, chatID, userID, username); err != nil {
+		return fmt.Sprintf("Failed to create game: %v", html.EscapeString(err.Error()))
+	}
+
+	return ""
+}
+
+// createGame creates a game, joins the creator and posts the shared game message
+func (b *Bot) createGame(ctx context.Context, chatID int64, userID, username string) error {
+	createOutput, err := b.gameService.CreateGame(ctx, &game.CreateGameInput{
+		ChannelID:   channelID(chatID),
+		CreatorID:   userID,
+		CreatorName: username,
+	})
+	if err != nil {
+		log.Printf("Error creating game: %v", err)
+		return err
+	}
+
+	// Join the creator to the game
+	_, err = b.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     createOutput.GameID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		log.Printf("Error joining game: %v", err)
+		// Not critical, continue
+	}
+
+	gameOutput, err := b.gameService.GetGame(ctx, &game.GetGameInput{
+		GameID: createOutput.GameID,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, b.renderGameText(ctx, gameOutput.Game, nil, nil))
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = renderGameKeyboard(gameOutput.Game)
+
+	sent, err := b.api.Send(msg)
+	if err != nil {
+		log.Printf("Error sending game message: %v", err)
+		return err
+	}
+
+	_, err = b.gameService.UpdateGameMessage(ctx, &game.UpdateGameMessageInput{
+		GameID:    createOutput.GameID,
+		MessageID: strconv.Itoa(sent.MessageID),
+	})
+	if err != nil {
+		log.Printf("Error updating game message: %v", err)
+		// Not critical, continue
+	}
+
+	return nil
+}
+
+// handleLeaderboard renders the session leaderboard
+func (b *Bot) handleLeaderboard(ctx context.Context, chatID int64) string {
+	sessionboard, err := b.gameService.GetSessionLeaderboard(ctx, &game.GetSessionLeaderboardInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		log.Printf("Error getting session leaderboard: %v", err)
+		return fmt.Sprintf("Failed to get session leaderboard: %v", html.EscapeString(err.Error()))
+	}
+
+	return renderLeaderboardText(sessionboard.Entries)
+}
+
+// handleNewSession starts a new drinking session
+func (b *Bot) handleNewSession(ctx context.Context, chatID int64, userID string) string {
+	_, err := b.gameService.StartNewSession(ctx, &game.StartNewSessionInput{
+		ChannelID: channelID(chatID),
+		CreatorID: userID,
+	})
+	if err != nil {
+		log.Printf("Error starting new session: %v", err)
+		return fmt.Sprintf("Failed to start new session: %v", html.EscapeString(err.Error()))
+	}
+
+	return "New session started successfully."
+}
+
+// handleAbandon abandons the game in the chat
+func (b *Bot) handleAbandon(ctx context.Context, chatID int64, userID string) string {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return "No game found in this chat to abandon."
+		}
+		log.Printf("Error getting game: %v", err)
+		return fmt.Sprintf("Error getting game: %v", html.EscapeString(err.Error()))
+	}
+
+	_, err = b.gameService.AbandonGame(ctx, &game.AbandonGameInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrNotCreator) {
+			return "Only the game's hosts can abandon it while it's being played. Anyone can once it's been left idle for a few minutes."
+		}
+		log.Printf("Error abandoning game: %v", err)
+		return fmt.Sprintf("Failed to abandon game: %v", html.EscapeString(err.Error()))
+	}
+
+	return "Game abandoned successfully. You can start a new game with /newgame."
+}
+
+// handleJoinGame handles the join game button
+func (b *Bot) handleJoinGame(ctx context.Context, chatID int64, userID, username string) string {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	joinOutput, err := b.gameService.JoinGame(ctx, &game.JoinGameInput{
+		GameID:     existingGame.Game.ID,
+		PlayerID:   userID,
+		PlayerName: username,
+	})
+	if err != nil {
+		log.Printf("Error joining game: %v", err)
+		return b.friendlyError(ctx, err, "Failed to join game")
+	}
+
+	b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+
+	joinMsgOutput, err := b.messagingService.GetJoinGameMessage(ctx, &messaging.GetJoinGameMessageInput{
+		PlayerName:    username,
+		GameStatus:    existingGame.Game.Status,
+		AlreadyJoined: joinOutput.AlreadyJoined,
+	})
+	if err != nil {
+		log.Printf("Error getting join game message: %v", err)
+		return "You've joined the game!"
+	}
+
+	return joinMsgOutput.Message
+}
+
+// handleBeginGame handles the begin game button
+func (b *Bot) handleBeginGame(ctx context.Context, chatID int64, userID string) string {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	startOutput, err := b.gameService.StartGame(ctx, &game.StartGameInput{
+		GameID:     existingGame.Game.ID,
+		PlayerID:   userID,
+		ForceStart: true, // Service layer decides if a force start is allowed
+	})
+	if err != nil {
+		log.Printf("Error starting game: %v", err)
+		return fmt.Sprintf("Failed to start game: %v", err)
+	}
+
+	if !startOutput.Success {
+		return "Failed to start the game. Make sure you are the creator of the game."
+	}
+
+	b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+
+	if startOutput.ForceStarted && startOutput.CreatorName != "" {
+		return fmt.Sprintf("Game force-started! %s took too long to start the game and has been assigned a drink.", startOutput.CreatorName)
+	}
+
+	return "Game started! Roll your dice."
+}
+
+// handleRollDice handles the roll dice button
+func (b *Bot) handleRollDice(ctx context.Context, chatID int64, userID string) string {
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return "No active game found in this chat."
+		}
+		return fmt.Sprintf("Error getting game: %v", err)
+	}
+
+	rollOutput, err := b.gameService.RollDice(ctx, &game.RollDiceInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrPlayerNotInGame) {
+			return "You are not part of this game."
+		}
+		return b.friendlyError(ctx, err, "Failed to roll dice")
+	}
+
+	if rollOutput.NeedsToRollInRollOff {
+		b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+		return "You need to roll in a roll-off game! Press Roll Dice again to continue."
+	}
+
+	// Update every game message affected by the roll
+	for _, gameID := range rollOutput.GameIDsToUpdate {
+		b.updateGameMessage(ctx, chatID, gameID)
+	}
+	b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+
+	// Critical hits let the roller pick who drinks from a keyboard in the chat
+	if rollOutput.IsCriticalHit && len(rollOutput.EligiblePlayers) > 0 {
+		msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("🔥 <b>%s</b> rolled a %d! Choose who drinks:", html.EscapeString(rollOutput.PlayerName), rollOutput.RollValue))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = renderAssignKeyboard(rollOutput.EligiblePlayers)
+		if _, err := b.api.Send(msg); err != nil {
+			log.Printf("Error sending assign drink keyboard: %v", err)
+		}
+	}
+
+	rollResultOutput, err := b.messagingService.GetRollResultMessage(ctx, &messaging.GetRollResultMessageInput{
+		RollValue:         rollOutput.RollValue,
+		IsCriticalHit:     rollOutput.IsCriticalHit,
+		IsCriticalFail:    rollOutput.IsCriticalFail,
+		DiceSides:         rollOutput.DiceSides,
+		PlayerName:        rollOutput.PlayerName,
+		IsPersonalMessage: true,
+	})
+	if err != nil {
+		log.Printf("Error getting roll result message: %v", err)
+		return fmt.Sprintf("You rolled a %d", rollOutput.RollValue)
+	}
+
+	return rollResultOutput.Title
+}
+
+// handleAssignDrink handles a button on the assign drink keyboard
+func (b *Bot) handleAssignDrink(ctx context.Context, chatID int64, userID, targetPlayerID string) string {
+	if targetPlayerID == "" {
+		return "No player selected"
+	}
+
+	existingGame, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return "No active game found in this chat."
+		}
+		return fmt.Sprintf("Error getting game: %v", err)
+	}
+
+	targetPlayerName := targetPlayerID
+	if participant := existingGame.Game.GetParticipant(targetPlayerID); participant != nil {
+		targetPlayerName = participant.PlayerName
+	}
+
+	assignOutput, err := b.gameService.AssignDrink(ctx, &game.AssignDrinkInput{
+		GameID:       existingGame.Game.ID,
+		FromPlayerID: userID,
+		ToPlayerID:   targetPlayerID,
+		Reason:       game.DrinkReasonCriticalHit,
+	})
+	if err != nil {
+		log.Printf("Error assigning drink: %v", err)
+		return fmt.Sprintf("Failed to assign drink: %v", err)
+	}
+
+	b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+
+	confirmation := fmt.Sprintf("You assigned a drink to %s! 🍻", targetPlayerName)
+	if note := messaging.HappyHourNote(assignOutput.HappyHourMultiplier, assignOutput.OnTheHouse); note != "" {
+		confirmation += " " + note
+	}
+	return confirmation
+}
+
+// handleStartNewGame handles the start new game button on a completed game
+func (b *Bot) handleStartNewGame(ctx context.Context, chatID int64, userID, username string) string {
+	_, err := b.gameService.GetActiveGameByChannel(ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err == nil {
+		return "There's already an active game in this chat. Use /abandon if you want to abandon the current game."
+	}
+
+	if err := b.createGame(ctx, chatID, userID, username); err != nil {
+		return fmt.Sprintf("Failed to create game: %v", err)
+	}
+
+	return "New game created!"
+}
+
+// handlePayDrink handles the pay drink button
+func (b *Bot) handlePayDrink(ctx context.Context, chatID int64, userID string) string {
+	existingGame, err := b.gameService.GetGameByChannel(ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID(chatID),
+	})
+	if err != nil {
+		if errors.Is(err, game.ErrGameNotFound) {
+			return "No active game found in this chat."
+		}
+		return fmt.Sprintf("Error getting game: %v", err)
+	}
+
+	payOutput, err := b.gameService.PayDrink(ctx, &game.PayDrinkInput{
+		GameID:   existingGame.Game.ID,
+		PlayerID: userID,
+	})
+	if err != nil {
+		log.Printf("Error paying drink: %v", err)
+		return fmt.Sprintf("Failed to pay drink: %v", err)
+	}
+
+	if payOutput.Credited {
+		return fmt.Sprintf("You didn't owe a drink, so it's banked as a credit (%d banked) for the next drink you're given.", payOutput.CreditBalance)
+	}
+
+	b.updateGameMessage(ctx, chatID, existingGame.Game.ID)
+
+	playerName := userID
+	if participant := existingGame.Game.GetParticipant(userID); participant != nil {
+		playerName = participant.PlayerName
+	}
+
+	payMsgOutput, err := b.messagingService.GetPayDrinkMessage(ctx, &messaging.GetPayDrinkMessageInput{
+		PlayerName: playerName,
+		DrinkCount: 1,
+	})
+	if err != nil {
+		return "You paid your drink!"
+	}
+
+	return payMsgOutput.Title
+}
+
+// updateGameMessage re-renders the shared game message in the chat
+func (b *Bot) updateGameMessage(ctx context.Context, chatID int64, gameID string) {
+	view, err := b.gameService.GetGameView(ctx, &game.GetGameViewInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		log.Printf("Error getting game for message update: %v", err)
+		return
+	}
+
+	messageID, err := strconv.Atoi(view.Game.MessageID)
+	if err != nil {
+		log.Printf("Game has no valid message ID, cannot update")
+		return
+	}
+
+	edit := tgbotapi.NewEditMessageTextAndMarkup(
+		chatID,
+		messageID,
+		b.renderGameText(ctx, view.Game, view.DrinkRecords, view.SessionLeaderboard),
+		renderGameKeyboard(view.Game),
+	)
+	edit.ParseMode = tgbotapi.ModeHTML
+
+	if _, err := b.api.Send(edit); err != nil {
+		log.Printf("Error updating game message: %v", err)
+	}
+}
+
+// friendlyError maps known game errors to a message from the messaging service
+func (b *Bot) friendlyError(ctx context.Context, err error, fallback string) string {
+	errorType := messaging.ErrorTypeFor(err)
+	if errorType == messaging.ErrorTypeUnknown {
+		return fmt.Sprintf("%s: %v", fallback, err)
+	}
+
+	errorMsgOutput, msgErr := b.messagingService.GetErrorMessage(ctx, &messaging.GetErrorMessageInput{
+		ErrorType: errorType,
+	})
+	if msgErr != nil {
+		return fmt.Sprintf("%s: %v", fallback, err)
+	}
+
+	return errorMsgOutput.Message
+}
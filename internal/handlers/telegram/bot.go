@@ -0,0 +1,226 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Callback data used by inline keyboard buttons
+const (
+	CallbackJoinGame     = "join"
+	CallbackBeginGame    = "begin"
+	CallbackRollDice     = "roll"
+	CallbackStartNewGame = "new"
+	CallbackPayDrink     = "pay"
+
+	// CallbackAssignDrinkPrefix is followed by the target player ID
+	CallbackAssignDrinkPrefix = "assign:"
+)
+
+// Bot represents the Telegram bot instance
+type Bot struct {
+	api              *tgbotapi.BotAPI
+	gameService      game.Service
+	messagingService messaging.Service
+	config           *Config
+}
+
+// Config holds the configuration for the Telegram bot
+type Config struct {
+	// Bot token from @BotFather
+	Token string
+
+	// Long polling timeout in seconds
+	PollTimeout int
+
+	// Enable verbose logging from the Telegram client
+	Debug bool
+
+	// Bot API endpoint, such as a local Bot API server (optional, defaults to api.telegram.org)
+	APIEndpoint string
+
+	// Game service
+	GameService game.Service
+
+	// Messaging service
+	MessagingService messaging.Service
+}
+
+// New creates a new Telegram bot
+func New(cfg *Config) (*Bot, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("token cannot be empty")
+	}
+
+	if cfg.GameService == nil {
+		return nil, fmt.Errorf("game service cannot be nil")
+	}
+
+	if cfg.MessagingService == nil {
+		return nil, fmt.Errorf("messaging service cannot be nil")
+	}
+
+	if cfg.PollTimeout <= 0 {
+		cfg.PollTimeout = 60
+	}
+
+	if cfg.APIEndpoint == "" {
+		cfg.APIEndpoint = tgbotapi.APIEndpoint
+	}
+
+	api, err := tgbotapi.NewBotAPIWithAPIEndpoint(cfg.Token, cfg.APIEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Telegram client: %w", err)
+	}
+	api.Debug = cfg.Debug
+
+	return &Bot{
+		api:              api,
+		gameService:      cfg.GameService,
+		messagingService: cfg.MessagingService,
+		config:           cfg,
+	}, nil
+}
+
+// Start registers the bot commands and begins polling for updates
+func (b *Bot) Start() error {
+	_, err := b.api.Request(tgbotapi.NewSetMyCommands(
+		tgbotapi.BotCommand{Command: "newgame", Description: "Start a new game in this chat"},
+		tgbotapi.BotCommand{Command: "leaderboard", Description: "Show the session leaderboard"},
+		tgbotapi.BotCommand{Command: "newsession", Description: "Start a new drinking session"},
+		tgbotapi.BotCommand{Command: "abandon", Description: "Abandon the current game"},
+	))
+	if err != nil {
+		log.Printf("Error registering Telegram commands: %v", err)
+		// Not critical, commands still work when typed
+	}
+
+	updateConfig := tgbotapi.NewUpdate(0)
+	updateConfig.Timeout = b.config.PollTimeout
+
+	updates := b.api.GetUpdatesChan(updateConfig)
+	go func() {
+		for update := range updates {
+			b.handleUpdate(update)
+		}
+	}()
+
+	log.Printf("Telegram bot authorized as @%s", b.api.Self.UserName)
+	return nil
+}
+
+// Stop stops polling for updates
+func (b *Bot) Stop() error {
+	b.api.StopReceivingUpdates()
+	return nil
+}
+
+// handleUpdate routes an update to the command or callback handler
+func (b *Bot) handleUpdate(update tgbotapi.Update) {
+	ctx := context.Background()
+
+	switch {
+	case update.CallbackQuery != nil:
+		if err := b.handleCallback(ctx, update.CallbackQuery); err != nil {
+			log.Printf("Error handling Telegram callback %s: %v", update.CallbackQuery.Data, err)
+		}
+	case update.Message != nil && update.Message.IsCommand():
+		if err := b.handleCommand(ctx, update.Message); err != nil {
+			log.Printf("Error handling Telegram command %s: %v", update.Message.Command(), err)
+		}
+	}
+}
+
+// handleCommand handles slash commands sent to the group chat
+func (b *Bot) handleCommand(ctx context.Context, message *tgbotapi.Message) error {
+	chatID := message.Chat.ID
+	userID := userIDString(message.From)
+	username := message.From.String()
+
+	var reply string
+	switch message.Command() {
+	case "newgame":
+		reply = b.handleNewGame(ctx, chatID, userID, username)
+	case "leaderboard":
+		reply = b.handleLeaderboard(ctx, chatID)
+	case "newsession":
+		reply = b.handleNewSession(ctx, chatID, userID)
+	case "abandon":
+		reply = b.handleAbandon(ctx, chatID, userID)
+	default:
+		return nil
+	}
+
+	if reply == "" {
+		return nil
+	}
+
+	return b.sendHTML(chatID, reply)
+}
+
+// handleCallback handles inline keyboard button presses
+func (b *Bot) handleCallback(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+	if query.Message == nil {
+		return b.answer(query, "This button is no longer available.")
+	}
+
+	chatID := query.Message.Chat.ID
+	userID := userIDString(query.From)
+	username := query.From.String()
+
+	switch {
+	case query.Data == CallbackJoinGame:
+		return b.answer(query, b.handleJoinGame(ctx, chatID, userID, username))
+	case query.Data == CallbackBeginGame:
+		return b.answer(query, b.handleBeginGame(ctx, chatID, userID))
+	case query.Data == CallbackRollDice:
+		return b.answer(query, b.handleRollDice(ctx, chatID, userID))
+	case query.Data == CallbackStartNewGame:
+		return b.answer(query, b.handleStartNewGame(ctx, chatID, userID, username))
+	case query.Data == CallbackPayDrink:
+		return b.answer(query, b.handlePayDrink(ctx, chatID, userID))
+	case strings.HasPrefix(query.Data, CallbackAssignDrinkPrefix):
+		targetID := strings.TrimPrefix(query.Data, CallbackAssignDrinkPrefix)
+		return b.answer(query, b.handleAssignDrink(ctx, chatID, userID, targetID))
+	default:
+		return fmt.Errorf("unknown callback: %s", query.Data)
+	}
+}
+
+// answer acknowledges a callback with a short notification for the user who pressed it
+func (b *Bot) answer(query *tgbotapi.CallbackQuery, text string) error {
+	_, err := b.api.Request(tgbotapi.NewCallback(query.ID, text))
+	return err
+}
+
+// sendHTML sends an HTML formatted message to the chat
+func (b *Bot) sendHTML(chatID int64, text string) error {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = tgbotapi.ModeHTML
+	_, err := b.api.Send(msg)
+	return err
+}
+
+// channelID converts a Telegram chat ID to the channel ID used by the game service
+func channelID(chatID int64) string {
+	return strconv.FormatInt(chatID, 10)
+}
+
+// userIDString converts a Telegram user to the player ID used by the game service
+func userIDString(user *tgbotapi.User) string {
+	if user == nil {
+		return ""
+	}
+	return strconv.FormatInt(user.ID, 10)
+}
@@ -0,0 +1,303 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/alicebob/miniredis/v2"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// apiCall is a request the bot made to the Bot API
+type apiCall struct {
+	method string
+	params url.Values
+}
+
+// BotTestSuite tests the bot handlers against a fake Bot API
+type BotTestSuite struct {
+	suite.Suite
+	mr             *miniredis.Miniredis
+	client         *redis.Client
+	mockCtrl       *gomock.Controller
+	mockDiceRoller *diceMocks.MockRoller
+	api            *httptest.Server
+	gameService    game.Service
+	bot            *Bot
+	ctx            context.Context
+
+	callsMu       sync.Mutex
+	calls         []apiCall
+	lastMessageID int
+
+	testChatID int64
+	alice      *tgbotapi.User
+	bob        *tgbotapi.User
+}
+
+func (s *BotTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockDiceRoller = diceMocks.NewMockRoller(s.mockCtrl)
+	s.ctx = context.Background()
+	s.testChatID = -100
+	s.alice = &tgbotapi.User{ID: 1, UserName: "alice"}
+	s.bob = &tgbotapi.User{ID: 2, UserName: "bob"}
+	s.calls = nil
+	s.lastMessageID = 0
+	s.api = httptest.NewServer(http.HandlerFunc(s.serveAPI))
+
+	gameSvc, err := game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+	s.Require().NoError(err)
+	s.gameService = gameSvc
+
+	msgSvc, err := messaging.NewService(&messaging.ServiceConfig{})
+	s.Require().NoError(err)
+
+	s.bot, err = New(&Config{
+		Token:            "test-token",
+		APIEndpoint:      s.api.URL + "/bot%s/%s",
+		GameService:      gameSvc,
+		MessagingService: msgSvc,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *BotTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.api.Close()
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestBotTestSuite(t *testing.T) {
+	suite.Run(t, new(BotTestSuite))
+}
+
+// serveAPI records the Bot API call and answers it the way Telegram would
+func (s *BotTestSuite) serveAPI(w http.ResponseWriter, r *http.Request) {
+	s.NoError(r.ParseForm())
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	s.callsMu.Lock()
+	s.calls = append(s.calls, apiCall{method: method, params: r.PostForm})
+	s.lastMessageID++
+	messageID := s.lastMessageID
+	s.callsMu.Unlock()
+
+	var result interface{}
+	switch method {
+	case "getMe":
+		result = map[string]interface{}{"id": 99, "is_bot": true, "username": "ronnied_bot"}
+	case "sendMessage", "editMessageText":
+		result = map[string]interface{}{"message_id": messageID, "chat": map[string]interface{}{"id": s.testChatID}, "date": 0}
+	default:
+		result = true
+	}
+	s.NoError(json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result}))
+}
+
+// callsTo returns the calls made to the Bot API method
+func (s *BotTestSuite) callsTo(method string) []apiCall {
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+
+	var calls []apiCall
+	for _, call := range s.calls {
+		if call.method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// command sends the command to the chat from the user
+func (s *BotTestSuite) command(from *tgbotapi.User, command string) {
+	text := "/" + command
+	s.Require().NoError(s.bot.handleCommand(s.ctx, &tgbotapi.Message{
+		Text:     text,
+		Chat:     &tgbotapi.Chat{ID: s.testChatID},
+		From:     from,
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(text)}},
+	}))
+}
+
+// press presses the inline keyboard button as the user and returns the notification they're shown
+func (s *BotTestSuite) press(from *tgbotapi.User, data string) string {
+	before := len(s.callsTo("answerCallbackQuery"))
+
+	s.Require().NoError(s.bot.handleCallback(s.ctx, &tgbotapi.CallbackQuery{
+		ID:      fmt.Sprintf("query-%s-%s", from.UserName, data),
+		From:    from,
+		Message: &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: s.testChatID}},
+		Data:    data,
+	}))
+
+	answers := s.callsTo("answerCallbackQuery")
+	s.Require().Len(answers, before+1)
+	return answers[before].params.Get("text")
+}
+
+// activeGame returns the game being played in the chat
+func (s *BotTestSuite) activeGame() *models.Game {
+	output, err := s.gameService.GetActiveGameByChannel(s.ctx, &game.GetActiveGameByChannelInput{
+		ChannelID: channelID(s.testChatID),
+	})
+	s.Require().NoError(err)
+	return output.Game
+}
+
+// activeGameOrLatest returns the game in the chat, even once it's finished
+func (s *BotTestSuite) activeGameOrLatest() *models.Game {
+	output, err := s.gameService.GetGameByChannel(s.ctx, &game.GetGameByChannelInput{
+		ChannelID: channelID(s.testChatID),
+	})
+	s.Require().NoError(err)
+	return output.Game
+}
+
+// drinksTo returns the records of drinks given to the player
+func (s *BotTestSuite) drinksTo(records []*models.DrinkLedger, playerID string) []*models.DrinkLedger {
+	var drinks []*models.DrinkLedger
+	for _, record := range records {
+		if record.ToPlayerID == playerID {
+			drinks = append(drinks, record)
+		}
+	}
+	return drinks
+}
+
+func (s *BotTestSuite) TestNewGameCommand() {
+	s.command(s.alice, "newgame")
+
+	// The game message is posted with the join button
+	sent := s.callsTo("sendMessage")
+	s.Require().Len(sent, 1)
+	s.Equal(channelID(s.testChatID), sent[0].params.Get("chat_id"))
+	s.Contains(sent[0].params.Get("reply_markup"), CallbackJoinGame)
+
+	created := s.activeGame()
+	s.Equal(models.GameStatusWaiting, created.Status)
+	s.NotNil(created.GetParticipant("1"))
+	s.NotEmpty(created.MessageID)
+
+	// A second game isn't started over the first
+	s.command(s.bob, "newgame")
+	sent = s.callsTo("sendMessage")
+	s.Require().Len(sent, 2)
+	s.Contains(sent[1].params.Get("text"), "already a game in progress")
+}
+
+func (s *BotTestSuite) TestJoinButton() {
+	s.command(s.alice, "newgame")
+
+	s.NotEmpty(s.press(s.bob, CallbackJoinGame))
+
+	s.NotNil(s.activeGame().GetParticipant("2"))
+	s.NotEmpty(s.callsTo("editMessageText"), "the game message shows who joined")
+}
+
+func (s *BotTestSuite) TestJoinButton_NoGame() {
+	s.Contains(s.press(s.bob, CallbackJoinGame), "Error")
+}
+
+func (s *BotTestSuite) TestRollAssignAndPay() {
+	s.command(s.alice, "newgame")
+	s.press(s.bob, CallbackJoinGame)
+	s.Equal("Game started! Roll your dice.", s.press(s.alice, CallbackBeginGame))
+
+	// Alice's critical hit gets her a keyboard to pick who drinks
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	s.NotEmpty(s.press(s.alice, CallbackRollDice))
+
+	sent := s.callsTo("sendMessage")
+	s.Require().Len(sent, 2)
+	s.Contains(sent[1].params.Get("text"), "rolled a 6")
+	s.Contains(sent[1].params.Get("reply_markup"), CallbackAssignDrinkPrefix+"2")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	s.NotEmpty(s.press(s.bob, CallbackRollDice))
+
+	s.Equal("You assigned a drink to bob! 🍻", s.press(s.alice, CallbackAssignDrinkPrefix+"2"))
+
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &game.GetDrinkRecordsInput{GameID: s.activeGameOrLatest().ID})
+	s.Require().NoError(err)
+	assigned := s.drinksTo(records.Records, "2")
+	s.Require().NotEmpty(assigned)
+
+	// Bob pays his drink off
+	s.NotEmpty(s.press(s.bob, CallbackPayDrink))
+
+	records, err = s.gameService.GetDrinkRecords(s.ctx, &game.GetDrinkRecordsInput{GameID: s.activeGameOrLatest().ID})
+	s.Require().NoError(err)
+	paid := 0
+	for _, record := range s.drinksTo(records.Records, "2") {
+		if record.Paid {
+			paid++
+		}
+	}
+	s.Equal(1, paid)
+}
+
+func (s *BotTestSuite) TestAssignWithoutACriticalHit() {
+	s.command(s.alice, "newgame")
+	s.press(s.bob, CallbackJoinGame)
+	s.press(s.alice, CallbackBeginGame)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	s.press(s.alice, CallbackRollDice)
+
+	s.Contains(s.press(s.alice, CallbackAssignDrinkPrefix+"2"), "Failed to assign drink")
+}
+
+func (s *BotTestSuite) TestUnknownButton() {
+	err := s.bot.handleCallback(s.ctx, &tgbotapi.CallbackQuery{
+		ID:      "query",
+		From:    s.alice,
+		Message: &tgbotapi.Message{MessageID: 1, Chat: &tgbotapi.Chat{ID: s.testChatID}},
+		Data:    "dance",
+	})
+	s.Error(err)
+}
@@ -0,0 +1,189 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// gameRules returns the rules a game is played with, the classic rules on a d6 when they can't be looked up
+func (b *Bot) gameRules(ctx context.Context, g *models.Game) models.GameRules {
+	output, err := b.gameService.GetGameRules(ctx, &game.GetGameRulesInput{
+		Game: g,
+	})
+	if err != nil {
+		log.Printf("Error getting rules for game %s: %v", g.ID, err)
+		return models.GameRules{DiceSides: 6, CriticalHitValues: []int{6}, CriticalFailValues: []int{1}}
+	}
+	return output.Rules
+}
+
+// rollEmoji returns the emoji used for a roll value played with the given rules
+func rollEmoji(rollValue int, rules models.GameRules) string {
+	switch {
+	case rules.IsCriticalHit(rollValue):
+		return "🔥" // Critical hit
+	case rules.IsCriticalFail(rollValue):
+		return "💀" // Critical fail
+	case rollValue == 5:
+		return "⭐" // High roll
+	case rollValue == 4:
+		return "✨" // Good roll
+	default:
+		return "🎲" // Normal roll
+	}
+}
+
+// renderGameText renders the shared game message as HTML
+func (b *Bot) renderGameText(ctx context.Context, game *models.Game, drinkRecords []*models.DrinkLedger, sessionLeaderboardEntries []game.LeaderboardEntry) string {
+	rules := b.gameRules(ctx, game)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>%s</b>\n\n", html.EscapeString(game.Status.DisplayTitle())))
+
+	switch game.Status {
+	case models.GameStatusWaiting:
+		sb.WriteString("🎮 <b>Waiting for players to join the drinking game!</b>\n<i>Press Join Game below to participate.</i>\n")
+	case models.GameStatusActive:
+		sb.WriteString(fmt.Sprintf("🎲 <b>Game in progress!</b> Each player should roll their dice.\n<i>Roll a %s to assign a drink, roll a %s and you drink!</i>\n",
+			models.FormatRollValues(rules.CriticalHitValues), models.FormatRollValues(rules.CriticalFailValues)))
+	case models.GameStatusRollOff:
+		sb.WriteString("⚔️ <b>ROLL-OFF IN PROGRESS!</b> Players in the roll-off need to roll again to break the tie.\n")
+	case models.GameStatusCompleted:
+		sb.WriteString("🏆 <b>Game completed!</b> Here are the final results.\n<i>Start a new game to continue the fun!</i>\n")
+	default:
+		sb.WriteString(html.EscapeString(game.Status.Description()) + "\n")
+	}
+
+	// Participant list with roll info
+	if len(game.Participants) > 0 {
+		sb.WriteString(fmt.Sprintf("\n👥 <b>Players (%d)</b>\n", len(game.Participants)))
+	}
+	for _, p := range game.Participants {
+		name := html.EscapeString(p.PlayerName)
+		if p.RollValue == 0 {
+			sb.WriteString(fmt.Sprintf("• <b>%s</b> (🎲 Not rolled yet)\n", name))
+			continue
+		}
+
+		var rollComment string
+		rollCommentOutput, err := b.messagingService.GetRollComment(ctx, &messaging.GetRollCommentInput{
+			PlayerName:     p.PlayerName,
+			RollValue:      p.RollValue,
+			IsCriticalHit:  rules.IsCriticalHit(p.RollValue),
+			IsCriticalFail: rules.IsCriticalFail(p.RollValue),
+		})
+		if err == nil && rollCommentOutput != nil {
+			rollComment = html.EscapeString(rollCommentOutput.Comment)
+		}
+
+		sb.WriteString(fmt.Sprintf("• <b>%s</b> (%s <b>%d</b>)%s\n", name, rollEmoji(p.RollValue, rules), p.RollValue, rollComment))
+	}
+
+	// Drinks assigned in this game
+	if len(drinkRecords) > 0 {
+		names := make(map[string]string, len(game.Participants))
+		for _, p := range game.Participants {
+			names[p.PlayerID] = p.PlayerName
+		}
+
+		sb.WriteString("\n🍺 <b>Drinks</b>\n")
+		for _, record := range drinkRecords {
+			status := ""
+			if record.Paid {
+				status = " ✅"
+			} else if record.Social {
+				status = " (social)"
+			} else if record.Forgiven {
+				status = " (forgiven)"
+			} else if record.OwedLater {
+				status = " (owed later)"
+			}
+			sb.WriteString(fmt.Sprintf("• %s → %s (%s)%s\n",
+				html.EscapeString(nameOrID(names, record.FromPlayerID)),
+				html.EscapeString(nameOrID(names, record.ToPlayerID)),
+				record.Reason,
+				status,
+			))
+		}
+	}
+
+	if len(sessionLeaderboardEntries) > 0 {
+		sb.WriteString("\n" + renderLeaderboardText(sessionLeaderboardEntries))
+	}
+
+	return sb.String()
+}
+
+// renderGameKeyboard returns the inline keyboard for the game status
+func renderGameKeyboard(game *models.Game) tgbotapi.InlineKeyboardMarkup {
+	switch game.Status {
+	case models.GameStatusWaiting:
+		return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Join Game", CallbackJoinGame),
+			tgbotapi.NewInlineKeyboardButtonData("Begin Game", CallbackBeginGame),
+		))
+	case models.GameStatusActive, models.GameStatusRollOff:
+		return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🎲 Roll Dice", CallbackRollDice),
+			tgbotapi.NewInlineKeyboardButtonData("🍺 Pay Drink", CallbackPayDrink),
+		))
+	default:
+		return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Start New Game", CallbackStartNewGame),
+			tgbotapi.NewInlineKeyboardButtonData("🍺 Pay Drink", CallbackPayDrink),
+		))
+	}
+}
+
+// renderAssignKeyboard returns one button per player that can be assigned a drink
+func renderAssignKeyboard(players []game.PlayerOption) tgbotapi.InlineKeyboardMarkup {
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(players))
+	for _, player := range players {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(player.PlayerName, CallbackAssignDrinkPrefix+player.PlayerID),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// renderLeaderboardText renders session leaderboard entries as HTML
+func renderLeaderboardText(entries []game.LeaderboardEntry) string {
+	if len(entries) == 0 {
+		return "No drinks have been assigned in this session yet."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📊 <b>Session Leaderboard</b>\n")
+	for i, entry := range entries {
+		sb.WriteString(fmt.Sprintf("%d. <b>%s</b> - %d drinks (%d paid)", i+1, html.EscapeString(entry.PlayerName), entry.DrinkCount, entry.PaidCount))
+		if entry.SocialCount > 0 {
+			sb.WriteString(fmt.Sprintf(", %d social", entry.SocialCount))
+		}
+		if entry.ForgivenCount > 0 {
+			sb.WriteString(fmt.Sprintf(", %d forgiven", entry.ForgivenCount))
+		}
+		if entry.DesignatedDriver {
+			sb.WriteString(" 🚗 designated driver")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// nameOrID returns the player name for an ID, falling back to the ID itself
+func nameOrID(names map[string]string, playerID string) string {
+	if name, ok := names[playerID]; ok {
+		return name
+	}
+	return playerID
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditAction identifies what an audit entry records
+type AuditAction string
+
+const (
+	// AuditActionForgetPlayer records a player's data being deleted at their own or an admin's request
+	AuditActionForgetPlayer AuditAction = "forget_player"
+
+	// AuditActionForgiveDrinks records an admin writing off a player's outstanding drinks
+	AuditActionForgiveDrinks AuditAction = "forgive_drinks"
+)
+
+// AuditEntry records an administrative action taken on the bot's data
+type AuditEntry struct {
+	// ID is the unique identifier for this entry
+	ID string `json:"id"`
+
+	// GuildID is the Discord server the action was taken from, empty outside a server
+	GuildID string `json:"guild_id,omitempty"`
+
+	// Action is what was done
+	Action AuditAction `json:"action"`
+
+	// ActorID is the ID of the admin who took the action, empty when players acted on their own data
+	ActorID string `json:"actor_id,omitempty"`
+
+	// SubjectID is the ID the action was recorded under, for deletions the anonymous ID that replaced the player
+	SubjectID string `json:"subject_id"`
+
+	// Details describes the action
+	Details string `json:"details,omitempty"`
+
+	// CreatedAt is when the action was taken
+	CreatedAt time.Time `json:"created_at"`
+}
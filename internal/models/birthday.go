@@ -0,0 +1,45 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Birthday is the day of the year a player celebrates their birthday
+type Birthday struct {
+	Month time.Month `json:"month"`
+	Day   int        `json:"day"`
+}
+
+// IsValid returns true if the birthday is a real day of the year, February 29 included
+func (b Birthday) IsValid() bool {
+	if b.Month < time.January || b.Month > time.December || b.Day < 1 {
+		return false
+	}
+
+	// Check against a leap year so February 29 counts
+	return b.Day <= daysIn(b.Month, 2024)
+}
+
+// IsOn returns true if the birthday falls on t's day, February 29 birthdays are celebrated on the 28th in other years
+func (b Birthday) IsOn(t time.Time) bool {
+	if t.Month() != b.Month {
+		return false
+	}
+
+	day := b.Day
+	if lastDay := daysIn(b.Month, t.Year()); day > lastDay {
+		day = lastDay
+	}
+	return t.Day() == day
+}
+
+// String returns the birthday as it's shown to players, e.g. March 14
+func (b Birthday) String() string {
+	return fmt.Sprintf("%s %d", b.Month, b.Day)
+}
+
+// daysIn returns how many days a month has in a year
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
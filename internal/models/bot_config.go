@@ -0,0 +1,24 @@
+package models
+
+// BotConfig holds the settings that can be changed while the bot is running, anything left unset keeps the
+// value the bot was started with
+type BotConfig struct {
+	// DiceSides is how many sides the dice of games without their own rules have
+	DiceSides int
+
+	// MaxPlayers is how many players can join games without their own rules
+	MaxPlayers int
+
+	// CriticalHitValues and CriticalFailValues are the critical rolls of games without their own rules
+	CriticalHitValues  []int
+	CriticalFailValues []int
+
+	// DrinkCap is the mercy rule's drinks per player per session, 0 turns the mercy rule off
+	DrinkCap *int
+
+	// LeaderHandicap turns the leader handicap on or off for every game
+	LeaderHandicap *bool
+
+	// ContentRating is the message tone of servers that haven't picked a content rating
+	ContentRating ContentRating
+}
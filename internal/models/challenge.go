@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+// ChallengeCall is what the challenger bets the two dice add up to
+type ChallengeCall string
+
+const (
+	// ChallengeCallOdds bets the dice add up to an odd number
+	ChallengeCallOdds ChallengeCall = "odds"
+
+	// ChallengeCallEvens bets the dice add up to an even number
+	ChallengeCallEvens ChallengeCall = "evens"
+)
+
+// ChallengeGame is a quickfire game of odds and evens between two players, pending until the opponent accepts.
+// Once accepted both players roll at once, the challenger wins if the total matches their call and the loser drinks.
+type ChallengeGame struct {
+	// ID is the unique identifier for this challenge
+	ID string `json:"id"`
+
+	// ChannelID is the channel the challenge was made in
+	ChannelID string `json:"channel_id"`
+
+	// GuildID is the Discord server the challenge was made in, empty outside a server
+	GuildID string `json:"guild_id,omitempty"`
+
+	// ChallengerID is the ID of the player who made the challenge
+	ChallengerID string `json:"challenger_id"`
+
+	// ChallengerName is the display name of the player who made the challenge
+	ChallengerName string `json:"challenger_name"`
+
+	// OpponentID is the ID of the player challenged
+	OpponentID string `json:"opponent_id"`
+
+	// OpponentName is the display name of the player challenged
+	OpponentName string `json:"opponent_name"`
+
+	// Call is what the challenger bets the dice add up to
+	Call ChallengeCall `json:"call"`
+
+	// ChallengerRoll and OpponentRoll are the players' rolls, zero until the challenge is played
+	ChallengerRoll int `json:"challenger_roll,omitempty"`
+	OpponentRoll   int `json:"opponent_roll,omitempty"`
+
+	// CreatedAt is when the challenge was made
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is when the challenge lapses if it hasn't been accepted
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Total returns the two rolls added up
+func (c *ChallengeGame) Total() int {
+	return c.ChallengerRoll + c.OpponentRoll
+}
+
+// ChallengerWon checks whether the rolls add up to what the challenger called
+func (c *ChallengeGame) ChallengerWon() bool {
+	even := c.Total()%2 == 0
+	return even == (c.Call == ChallengeCallEvens)
+}
+
+// LoserID returns the ID of the player who drinks once the challenge is played
+func (c *ChallengeGame) LoserID() string {
+	if c.ChallengerWon() {
+		return c.OpponentID
+	}
+	return c.ChallengerID
+}
+
+// WinnerID returns the ID of the player who hands out the drink once the challenge is played
+func (c *ChallengeGame) WinnerID() string {
+	if c.ChallengerWon() {
+		return c.ChallengerID
+	}
+	return c.OpponentID
+}
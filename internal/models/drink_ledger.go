@@ -19,6 +19,30 @@ const (
 	
 	// DrinkReasonDelayedStart indicates a drink assigned to the creator for delaying game start
 	DrinkReasonDelayedStart DrinkReason = "delayed_start"
+	
+	// DrinkReasonChallenge indicates a drink taken for losing a quickfire challenge
+	DrinkReasonChallenge DrinkReason = "challenge"
+	
+	// DrinkReasonOutOfSync indicates a drink taken for rolling outside a countdown game's rolling window
+	DrinkReasonOutOfSync DrinkReason = "out_of_sync"
+	
+	// DrinkReasonSideBet indicates a drink taken for losing a side bet on a roll
+	DrinkReasonSideBet DrinkReason = "side_bet"
+
+	// DrinkReasonSocialRound indicates a drink every player in the game took together in a social round
+	DrinkReasonSocialRound DrinkReason = "social_round"
+
+	// DrinkReasonKarma indicates a drink taken for giving too many drinks to the same player, in games played with karma
+	DrinkReasonKarma DrinkReason = "karma"
+
+	// DrinkReasonRoundsLoser indicates the bonus drink taken for the lowest total over every round of a rounds game
+	DrinkReasonRoundsLoser DrinkReason = "rounds_loser"
+
+	// DrinkReasonSuddenDeath indicates a drink from the pot the loser of a sudden death takes, one for every tie in it
+	DrinkReasonSuddenDeath DrinkReason = "sudden_death"
+
+	// DrinkReasonJackpot indicates a drink from the session's jackpot, taken by the next player to roll a critical fail
+	DrinkReasonJackpot DrinkReason = "jackpot"
 )
 
 // DrinkLedger records a drink assignment between players
@@ -55,4 +79,51 @@ type DrinkLedger struct {
 	
 	// SessionID is the ID of the drinking session this record belongs to
 	SessionID string
+
+	// GuildID is the Discord server the drink was assigned in, empty for drinks outside a server
+	GuildID string `json:",omitempty"`
+	
+	// Social marks a drink waived by the mercy rule, it counts as a point rather than a drink owed
+	Social bool
+
+	// OwedLater marks a drink given to a designated driver, it can't be paid until they stop driving
+	OwedLater bool
+
+	// CoveredFor is the ID of the designated driver a substitute took this drink for
+	CoveredFor string
+
+	// Forgiven marks a drink written off by an admin, it's no longer owed but doesn't count as paid
+	Forgiven bool
+
+	// ForgivenTimestamp is when the drink was forgiven
+	ForgivenTimestamp time.Time
+
+	// ForgivenBy is the ID of the admin who forgave the drink
+	ForgivenBy string `json:",omitempty"`
+
+	// ForgiveReason is why the drink was forgiven
+	ForgiveReason string `json:",omitempty"`
+
+	// PaidWithCredit marks a drink offset by a credit the player banked paying a drink they didn't owe
+	PaidWithCredit bool `json:",omitempty"`
+
+	// HappyHourMultiplier is how many times the drink counted for being given during happy hour, 0 outside it
+	HappyHourMultiplier float64 `json:",omitempty"`
+
+	// TransferredFrom lists the players who handed this drink on, oldest first, ending with the player before ToPlayerID
+	TransferredFrom []string `json:",omitempty"`
+
+	// GuestName is the display name of a channel member given the drink while watching rather than playing,
+	// empty for drinks given to players
+	GuestName string `json:",omitempty"`
+}
+
+// IsGuest returns true if the drink was given to someone watching the game rather than playing it
+func (d *DrinkLedger) IsGuest() bool {
+	return d.GuestName != ""
+}
+
+// IsOutstanding returns true if the drink is still owed: not paid, forgiven or waived by the mercy rule
+func (d *DrinkLedger) IsOutstanding() bool {
+	return !d.Paid && !d.Social && !d.Forgiven
 }
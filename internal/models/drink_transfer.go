@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// DrinkTransfer is a player's offer to hand one of their drinks to a volunteer, pending until the volunteer accepts
+type DrinkTransfer struct {
+	// ID is the unique identifier for this transfer
+	ID string `json:"id"`
+
+	// DrinkID is the ID of the drink being handed over
+	DrinkID string `json:"drink_id"`
+
+	// FromPlayerID is the ID of the player giving the drink away
+	FromPlayerID string `json:"from_player_id"`
+
+	// ToPlayerID is the ID of the player asked to take the drink
+	ToPlayerID string `json:"to_player_id"`
+
+	// ChannelID is the channel the transfer was offered in
+	ChannelID string `json:"channel_id"`
+
+	// GuildID is the Discord server the transfer was offered in, empty outside a server
+	GuildID string `json:"guild_id,omitempty"`
+
+	// CreatedAt is when the transfer was offered
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is when the offer lapses if it hasn't been accepted
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "errors"
+
+// ErrorCode classifies service errors so callers can react to a kind of error rather than each error value
+type ErrorCode string
+
+// Error codes
+const (
+	ErrorCodeUnknown          ErrorCode = "unknown"
+	ErrorCodeConfig           ErrorCode = "config"
+	ErrorCodeInvalidInput     ErrorCode = "invalid_input"
+	ErrorCodeGameNotFound     ErrorCode = "game_not_found"
+	ErrorCodePlayerNotFound   ErrorCode = "player_not_found"
+	ErrorCodeGameExists       ErrorCode = "game_exists"
+	ErrorCodeAlreadyJoined    ErrorCode = "already_joined"
+	ErrorCodeNotInGame        ErrorCode = "not_in_game"
+	ErrorCodeGameFull         ErrorCode = "game_full"
+	ErrorCodeInvalidGameState ErrorCode = "invalid_game_state"
+	ErrorCodeGameActive       ErrorCode = "game_active"
+	ErrorCodeGameRollOff      ErrorCode = "game_roll_off"
+	ErrorCodeGameCompleted    ErrorCode = "game_completed"
+	ErrorCodeGameNotStarted   ErrorCode = "game_not_started"
+	ErrorCodeAlreadyRolled    ErrorCode = "already_rolled"
+	ErrorCodeNotEnoughPlayers ErrorCode = "not_enough_players"
+	ErrorCodeNotCreator       ErrorCode = "not_creator"
+	ErrorCodeInRollOff        ErrorCode = "in_roll_off"
+	ErrorCodeNotEligible      ErrorCode = "not_eligible"
+	ErrorCodeRoundInProgress  ErrorCode = "round_in_progress"
+	ErrorCodeCoolingDown      ErrorCode = "cooling_down"
+	ErrorCodeNoRerollTokens   ErrorCode = "no_reroll_tokens"
+	ErrorCodeRerollNotAllowed ErrorCode = "reroll_not_allowed"
+	ErrorCodeNotInvited       ErrorCode = "not_invited"
+	ErrorCodeGamePaused       ErrorCode = "game_paused"
+	ErrorCodeSessionPaused    ErrorCode = "session_paused"
+	ErrorCodeNotYourTurn      ErrorCode = "not_your_turn"
+)
+
+// codedError is implemented by errors that carry an error code, such as the game service's
+type codedError interface {
+	error
+	Code() ErrorCode
+}
+
+// ErrorCodeOf returns the code of the first coded error in err's chain, or ErrorCodeUnknown
+func ErrorCodeOf(err error) ErrorCode {
+	var coded codedError
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	return ErrorCodeUnknown
+}
@@ -0,0 +1,73 @@
+package models
+
+// FeatureFlag names a part of the bot that can be turned off while it's running
+type FeatureFlag string
+
+const (
+	// FeatureFlagRollOffs settles tied highest and lowest rolls with a roll-off, when off every tied lowest
+	// roller drinks instead
+	FeatureFlagRollOffs FeatureFlag = "rolloffs_enabled"
+
+	// FeatureFlagAnimations counts down to a countdown game's rolling window, when off only the window is announced
+	FeatureFlagAnimations FeatureFlag = "animations_enabled"
+
+	// FeatureFlagLLMMessages lets servers with banter on have their roll comments freshly written
+	FeatureFlagLLMMessages FeatureFlag = "llm_messages"
+)
+
+// FeatureFlags lists the known feature flags in the order they're shown
+var FeatureFlags = []FeatureFlag{
+	FeatureFlagRollOffs,
+	FeatureFlagAnimations,
+	FeatureFlagLLMMessages,
+}
+
+// IsValid returns true if the flag is one of the known feature flags
+func (f FeatureFlag) IsValid() bool {
+	for _, flag := range FeatureFlags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Default returns whether the flag is on when neither the bot nor the server has set it, every flag starts on
+func (f FeatureFlag) Default() bool {
+	return true
+}
+
+// Description explains what the flag turns on
+func (f FeatureFlag) Description() string {
+	switch f {
+	case FeatureFlagRollOffs:
+		return "Ties are settled with a roll-off, when off every tied lowest roller drinks"
+	case FeatureFlagAnimations:
+		return "Countdown games count down 3-2-1, when off only the rolling window is announced"
+	case FeatureFlagLLMMessages:
+		return "Servers with banter on get freshly written roll comments"
+	default:
+		return ""
+	}
+}
+
+// FeatureFlagSource is where a feature flag's value comes from
+type FeatureFlagSource string
+
+const (
+	// FeatureFlagSourceDefault means nobody has set the flag
+	FeatureFlagSourceDefault FeatureFlagSource = "default"
+
+	// FeatureFlagSourceBot means the flag is set for every server
+	FeatureFlagSourceBot FeatureFlagSource = "bot"
+
+	// FeatureFlagSourceGuild means the server has overridden the flag
+	FeatureFlagSourceGuild FeatureFlagSource = "guild"
+)
+
+// FeatureFlagState is whether a feature flag is on for a server and why
+type FeatureFlagState struct {
+	Flag    FeatureFlag
+	Enabled bool
+	Source  FeatureFlagSource
+}
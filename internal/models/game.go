@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 )
 
@@ -19,6 +20,9 @@ const (
 
 	// GameStatusCompleted indicates a game has been completed
 	GameStatusCompleted GameStatus = "completed"
+
+	// GameStatusAbandoned indicates a game was abandoned before it finished and is kept as history
+	GameStatusAbandoned GameStatus = "abandoned"
 )
 
 // DisplayTitle returns a user-friendly title for the game status
@@ -32,6 +36,8 @@ func (s GameStatus) DisplayTitle() string {
 		return "Ronnied Game - Roll-Off in Progress"
 	case GameStatusCompleted:
 		return "Ronnied Game - Completed"
+	case GameStatusAbandoned:
+		return "Ronnied Game - Abandoned"
 	default:
 		return "Ronnied Game"
 	}
@@ -48,6 +54,8 @@ func (s GameStatus) Description() string {
 		return "A roll-off is in progress to determine who drinks!"
 	case GameStatusCompleted:
 		return "Game completed. Check the leaderboard to see who owes drinks!"
+	case GameStatusAbandoned:
+		return "Game abandoned. Start a new game whenever you're ready!"
 	default:
 		return "Unknown game status."
 	}
@@ -73,6 +81,16 @@ func (s GameStatus) IsCompleted() bool {
 	return s == GameStatusCompleted
 }
 
+// IsAbandoned returns true if the game status is abandoned
+func (s GameStatus) IsAbandoned() bool {
+	return s == GameStatusAbandoned
+}
+
+// IsFinished returns true if the game is over, whether it was completed or abandoned
+func (s GameStatus) IsFinished() bool {
+	return s == GameStatusCompleted || s == GameStatusAbandoned
+}
+
 // Game represents a dice rolling game session
 type Game struct {
 	// ID is the unique identifier for the game
@@ -81,6 +99,9 @@ type Game struct {
 	// ChannelID is the Discord channel where the game is being played
 	ChannelID string
 
+	// GuildID is the Discord server the game is played in, empty for games outside a server
+	GuildID string `json:",omitempty"`
+
 	// CreatorID is the ID of the user who initiated the game
 	CreatorID string
 
@@ -105,11 +126,73 @@ type Game struct {
 	// MessageID is the Discord message ID for the game
 	MessageID string
 
+	// Rules override the default game rules, roll-offs inherit them from their parent (nil uses the defaults)
+	Rules *GameRules
+
+	// Events are the IDs of the seasonal events running when the game was created, roll-offs inherit them
+	Events []string `json:",omitempty"`
+
+	// LastCall marks the final game of a session that's closing, roll-offs inherit it
+	LastCall bool `json:",omitempty"`
+
+	// Countdown makes everyone roll at once: the bot counts down when the game starts and rolls outside the
+	// rolling window that follows cost a drink
+	Countdown bool `json:",omitempty"`
+
+	// SuddenDeath marks a roll-off played as sudden death, nil for normal roll-offs and games
+	SuddenDeath *SuddenDeath `json:",omitempty"`
+
+	// Rounds plays the game over several rounds with the same players, nil for a single round
+	Rounds *GameRounds `json:",omitempty"`
+
+	// Private makes the game invite-only: only the creator and the invited players can join
+	Private bool `json:",omitempty"`
+
+	// InvitedPlayerIDs are the players invited to a private game
+	InvitedPlayerIDs []string `json:",omitempty"`
+
+	// RollWindow is when rolls count in a countdown game, set when it starts
+	RollWindow *RollWindow `json:",omitempty"`
+
+	// StartedAt is when the game started being played, nil for roll-offs and games started before it was kept
+	StartedAt *time.Time `json:",omitempty"`
+
+	// DurationWarned marks that the players who hadn't rolled were warned the game is running out of time
+	DurationWarned bool `json:",omitempty"`
+
+	// CoHostIDs are the players the creator deputized to start, pause, abandon and wrap up the game
+	CoHostIDs []string `json:",omitempty"`
+
+	// PausedAt is when the game was paused, nil while it's being played
+	PausedAt *time.Time `json:",omitempty"`
+
+	// PausedBy is the ID of the host who paused the game
+	PausedBy string `json:",omitempty"`
+
+	// PausedFor is how long the game has spent paused, which doesn't count against its time
+	PausedFor time.Duration `json:",omitempty"`
+
+	// Vote is the vote the players are holding on starting or abandoning the game, nil when there isn't one
+	Vote *GameVote `json:",omitempty"`
+
+	// Waitlist holds the players waiting for a spot in a full game, first in line first
+	Waitlist []*WaitlistEntry `json:",omitempty"`
+
+	// Departures are the players who left the server while the game or its roll-offs were being played
+	Departures []*Departure `json:",omitempty"`
+
 	// CreatedAt is when the game was created
 	CreatedAt time.Time
 
 	// UpdatedAt is when the game was last updated
 	UpdatedAt time.Time
+
+	// Version counts the saves of the game, so a save made from a copy read before the last one is refused
+	// rather than overwriting it
+	Version int64 `json:",omitempty"`
+
+	// participantIndex maps player IDs to their participants, built by IndexParticipants and never stored
+	participantIndex map[string]*Participant
 }
 
 func (g *Game) GetCreatorName() string {
@@ -123,8 +206,140 @@ func (g *Game) GetCreatorName() string {
 	return "Unknown Player"
 }
 
+// CanJoin checks whether a player is allowed to join the game, anyone can unless the game is private
+func (g *Game) CanJoin(playerID string) bool {
+	if !g.Private || playerID == g.CreatorID {
+		return true
+	}
+	return slices.Contains(g.InvitedPlayerIDs, playerID)
+}
+
+// Invite adds players to a private game's invite list, skipping anyone already on it
+func (g *Game) Invite(playerIDs ...string) {
+	for _, playerID := range playerIDs {
+		if playerID != "" && playerID != g.CreatorID && !slices.Contains(g.InvitedPlayerIDs, playerID) {
+			g.InvitedPlayerIDs = append(g.InvitedPlayerIDs, playerID)
+		}
+	}
+}
+
+// IsHost checks whether a player runs the game, as its creator or one of its co-hosts
+func (g *Game) IsHost(playerID string) bool {
+	return playerID != "" && (playerID == g.CreatorID || slices.Contains(g.CoHostIDs, playerID))
+}
+
+// WaitlistPosition returns where a player is on the game's waitlist counting from 1, 0 when they aren't on it
+func (g *Game) WaitlistPosition(playerID string) int {
+	for i, entry := range g.Waitlist {
+		if entry.PlayerID == playerID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// InvolvesPlayer checks whether the game refers to a player anywhere, as a participant, host, invitee, voter,
+// on the waitlist or among those who left
+func (g *Game) InvolvesPlayer(playerID string) bool {
+	involved := false
+	g.playerRefs(func(id, _ *string) {
+		if playerID != "" && *id == playerID {
+			involved = true
+		}
+	})
+	return involved
+}
+
+// ReplacePlayer replaces every reference to a player with another player and name, reporting whether the game
+// referred to them at all
+func (g *Game) ReplacePlayer(playerID, newPlayerID, newName string) bool {
+	replaced := false
+	g.playerRefs(func(id, name *string) {
+		if playerID == "" || *id != playerID {
+			return
+		}
+		*id = newPlayerID
+		if name != nil {
+			*name = newName
+		}
+		replaced = true
+	})
+
+	if replaced {
+		g.IndexParticipants()
+	}
+	return replaced
+}
+
+// playerRefs calls fn with every player ID the game keeps, and the name kept alongside it if there is one
+func (g *Game) playerRefs(fn func(id, name *string)) {
+	fn(&g.CreatorID, nil)
+	fn(&g.PausedBy, nil)
+
+	for _, participant := range g.Participants {
+		fn(&participant.PlayerID, &participant.PlayerName)
+		if participant.GuestOffer != nil {
+			fn(&participant.GuestOffer.GuestID, &participant.GuestOffer.GuestName)
+		}
+	}
+	for i := range g.CoHostIDs {
+		fn(&g.CoHostIDs[i], nil)
+	}
+	for i := range g.InvitedPlayerIDs {
+		fn(&g.InvitedPlayerIDs[i], nil)
+	}
+	if g.Vote != nil {
+		fn(&g.Vote.CalledBy, nil)
+		for i := range g.Vote.Approvals {
+			fn(&g.Vote.Approvals[i], nil)
+		}
+		for i := range g.Vote.Rejections {
+			fn(&g.Vote.Rejections[i], nil)
+		}
+	}
+	for _, entry := range g.Waitlist {
+		fn(&entry.PlayerID, &entry.PlayerName)
+	}
+	for _, departure := range g.Departures {
+		fn(&departure.PlayerID, &departure.PlayerName)
+	}
+}
+
+// IsPaused returns true if a host has paused the game
+func (g *Game) IsPaused() bool {
+	return g.PausedAt != nil
+}
+
+// IndexParticipants indexes the participants by player ID so they can be looked up without scanning them.
+// It must be called again after participants are changed other than through AddParticipant.
+func (g *Game) IndexParticipants() {
+	g.participantIndex = make(map[string]*Participant, len(g.Participants))
+	for _, participant := range g.Participants {
+		g.participantIndex[participant.PlayerID] = participant
+	}
+}
+
+// AddParticipant adds a participant to the game, keeping the participant index up to date
+func (g *Game) AddParticipant(participant *Participant) {
+	g.Participants = append(g.Participants, participant)
+	if g.participantIndex != nil {
+		g.participantIndex[participant.PlayerID] = participant
+	}
+}
+
 // GetParticipant returns the participant with the given player ID or nil if they do not exist
 func (g *Game) GetParticipant(playerID string) *Participant {
+	// Trust the index while it still covers every participant and the participant found hasn't changed player
+	if g.participantIndex != nil && len(g.participantIndex) == len(g.Participants) {
+		participant, ok := g.participantIndex[playerID]
+		if !ok {
+			return nil
+		}
+		if participant.PlayerID == playerID {
+			return participant
+		}
+	}
+
 	for _, participant := range g.Participants {
 		if participant.PlayerID == playerID {
 			return participant
@@ -134,6 +349,28 @@ func (g *Game) GetParticipant(playerID string) *Participant {
 	return nil
 }
 
+// HasParticipant returns true if the player is participating in the game
+func (g *Game) HasParticipant(playerID string) bool {
+	return g.GetParticipant(playerID) != nil
+}
+
+// LowestRollers returns the players holding the lowest roll so far, who drink unless someone still to roll goes
+// lower, more than one when they're tied and nil before anyone has rolled
+func (g *Game) LowestRollers() []*Participant {
+	var lowest []*Participant
+	for _, participant := range g.Participants {
+		if participant.RollTime == nil {
+			continue
+		}
+		if len(lowest) == 0 || participant.RollValue < lowest[0].RollValue {
+			lowest = []*Participant{participant}
+		} else if participant.RollValue == lowest[0].RollValue {
+			lowest = append(lowest, participant)
+		}
+	}
+	return lowest
+}
+
 // IsReadyToComplete checks if all players have completed their actions
 // and the game is ready to be completed
 func (g *Game) IsReadyToComplete() bool {
@@ -158,3 +395,21 @@ func (g *Game) IsReadyToComplete() bool {
 	// All checks passed, the game is ready to complete
 	return true
 }
+
+// WaitlistEntry is a player waiting for a spot in a full game
+type WaitlistEntry struct {
+	PlayerID   string
+	PlayerName string
+
+	// AddedAt is when the player joined the waitlist
+	AddedAt time.Time
+}
+
+// Departure is a player who left the server in the middle of a game
+type Departure struct {
+	PlayerID   string
+	PlayerName string
+
+	// LeftAt is when the player left
+	LeftAt time.Time
+}
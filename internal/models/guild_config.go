@@ -0,0 +1,387 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RollFeedMode is how a guild's rolls are posted publicly in the channel
+type RollFeedMode string
+
+const (
+	// RollFeedOff keeps roll feedback to the rolling player
+	RollFeedOff RollFeedMode = "off"
+
+	// RollFeedMessages posts a compact message in the channel for every roll
+	RollFeedMessages RollFeedMode = "messages"
+
+	// RollFeedLog keeps a single roll log message per game, edited as players roll
+	RollFeedLog RollFeedMode = "log"
+)
+
+// IsValid returns true if the mode is one of the known roll feed modes
+func (m RollFeedMode) IsValid() bool {
+	switch m {
+	case RollFeedOff, RollFeedMessages, RollFeedLog:
+		return true
+	default:
+		return false
+	}
+}
+
+// DisplayDensity is how much detail a guild's game messages show
+type DisplayDensity string
+
+const (
+	// DisplayDetailed shows roll comments, drink messages and progress bars
+	DisplayDetailed DisplayDensity = "detailed"
+
+	// DisplayCompact shows one short line per player so big groups fit in the message
+	DisplayCompact DisplayDensity = "compact"
+)
+
+// IsValid returns true if the density is one of the known display densities
+func (d DisplayDensity) IsValid() bool {
+	switch d {
+	case DisplayDetailed, DisplayCompact:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContentRating is how tame a guild's messages are kept
+type ContentRating string
+
+const (
+	// ContentRatingPG keeps messages free of innuendo and swearing
+	ContentRatingPG ContentRating = "pg"
+
+	// ContentRatingPG13 allows innuendo but no swearing
+	ContentRatingPG13 ContentRating = "pg-13"
+
+	// ContentRatingR allows all of the bot's humor
+	ContentRatingR ContentRating = "r"
+)
+
+// IsValid returns true if the rating is one of the known content ratings
+func (r ContentRating) IsValid() bool {
+	switch r {
+	case ContentRatingPG, ContentRatingPG13, ContentRatingR:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allows returns true if content with the other rating can be shown under this rating
+func (r ContentRating) Allows(other ContentRating) bool {
+	return r.level() >= other.level()
+}
+
+// level orders the ratings from tamest to wildest, unknown ratings are treated as R
+func (r ContentRating) level() int {
+	switch r {
+	case ContentRatingPG:
+		return 0
+	case ContentRatingPG13:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// MessageTone is the voice a guild's messages are written in
+type MessageTone string
+
+const (
+	// ToneFunny jokes around, the bot's usual voice
+	ToneFunny MessageTone = "funny"
+
+	// ToneSarcastic is dry and mocking
+	ToneSarcastic MessageTone = "sarcastic"
+
+	// ToneEncouraging cheers players on
+	ToneEncouraging MessageTone = "encouraging"
+
+	// ToneNeutral sticks to what happened, leaving out the comments on rolls
+	ToneNeutral MessageTone = "neutral"
+)
+
+// IsValid returns true if the tone is one of the known message tones
+func (t MessageTone) IsValid() bool {
+	switch t {
+	case ToneFunny, ToneSarcastic, ToneEncouraging, ToneNeutral:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScoringMode is what a guild's games are played for
+type ScoringMode string
+
+const (
+	// ScoringDrinks plays for drinks
+	ScoringDrinks ScoringMode = "drinks"
+
+	// ScoringPoints plays for points, for servers that don't drink
+	ScoringPoints ScoringMode = "points"
+)
+
+// IsValid returns true if the mode is one of the known scoring modes
+func (m ScoringMode) IsValid() bool {
+	switch m {
+	case ScoringDrinks, ScoringPoints:
+		return true
+	default:
+		return false
+	}
+}
+
+// EmojiSlot is a part of the bot's messages a guild can show its own emoji in
+type EmojiSlot string
+
+const (
+	// EmojiSlotDice is the dice shown with rolls
+	EmojiSlotDice EmojiSlot = "dice"
+
+	// EmojiSlotDrink is the drink shown with drinks owed and paid
+	EmojiSlotDrink EmojiSlot = "drink"
+
+	// EmojiSlotCriticalHit marks critical hits
+	EmojiSlotCriticalHit EmojiSlot = "critical_hit"
+
+	// EmojiSlotCriticalFail marks critical fails
+	EmojiSlotCriticalFail EmojiSlot = "critical_fail"
+)
+
+// IsValid returns true if the slot is one of the known emoji slots
+func (s EmojiSlot) IsValid() bool {
+	switch s {
+	case EmojiSlotDice, EmojiSlotDrink, EmojiSlotCriticalHit, EmojiSlotCriticalFail:
+		return true
+	default:
+		return false
+	}
+}
+
+// GameChannelMode is how a guild's channel list limits where games can be created
+type GameChannelMode string
+
+const (
+	// GameChannelsAllow only lets games be created in the listed channels
+	GameChannelsAllow GameChannelMode = "allow"
+
+	// GameChannelsDeny lets games be created anywhere but the listed channels
+	GameChannelsDeny GameChannelMode = "deny"
+)
+
+// IsValid returns true if the mode is one of the known game channel modes
+func (m GameChannelMode) IsValid() bool {
+	switch m {
+	case GameChannelsAllow, GameChannelsDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// TieStrategy is how a game settles players tied for the highest or lowest roll
+type TieStrategy string
+
+const (
+	// TieRollOff has the tied players roll again together in a roll-off, another one for any still tied
+	TieRollOff TieStrategy = "roll_off"
+
+	// TieSuddenDeath has the tied players take turns rolling until one of them is out on their own, every tie
+	// along the way adding a drink to the pot the loser takes
+	TieSuddenDeath TieStrategy = "sudden_death"
+)
+
+// IsValid returns true if the strategy is one of the known tie strategies
+func (t TieStrategy) IsValid() bool {
+	switch t {
+	case TieRollOff, TieSuddenDeath:
+		return true
+	default:
+		return false
+	}
+}
+
+// GuildConfig holds a guild's settings, zero values fall back to the defaults
+type GuildConfig struct {
+	// GuildID is the Discord server the settings belong to
+	GuildID string `json:"guild_id"`
+
+	// RollFeed is how rolls are posted in the channel, empty means off
+	RollFeed RollFeedMode `json:"roll_feed,omitempty"`
+
+	// Display is how much detail game messages show, empty means detailed
+	Display DisplayDensity `json:"display,omitempty"`
+
+	// CriticalHitValues are the rolls that assign a drink in the guild's games, empty means the bot's defaults
+	CriticalHitValues []int `json:"critical_hit_values,omitempty"`
+
+	// CriticalFailValues are the rolls that make a player drink in the guild's games, empty means the bot's defaults
+	CriticalFailValues []int `json:"critical_fail_values,omitempty"`
+
+	// Rating is how tame the guild's messages are kept, empty means R
+	Rating ContentRating `json:"content_rating,omitempty"`
+
+	// Tone is the voice the guild's messages are written in, empty means funny
+	Tone MessageTone `json:"tone,omitempty"`
+
+	// DiceSides is the dice the guild's games are played with unless a preset picks them, zero means the bot's default
+	DiceSides int `json:"dice_sides,omitempty"`
+
+	// Scoring is what the guild's games are played for, empty means drinks
+	Scoring ScoringMode `json:"scoring,omitempty"`
+
+	// Banter has roll comments freshly written by the bot's LLM, when it has one, instead of the usual lines
+	Banter bool `json:"banter,omitempty"`
+
+	// PinnedLeaderboard is the pinned session leaderboard the bot keeps up to date, nil when there isn't one
+	PinnedLeaderboard *PinnedLeaderboard `json:"pinned_leaderboard,omitempty"`
+
+	// Emojis are the server's own emoji shown in place of the bot's, by slot
+	Emojis map[EmojiSlot]string `json:"emojis,omitempty"`
+
+	// SoundboardChannelID is the voice channel the bot plays sound effects in, empty means the soundboard is off
+	SoundboardChannelID string `json:"soundboard_channel_id,omitempty"`
+
+	// Persona is the name and avatar game messages are posted under, nil posts them as the bot
+	Persona *GamePersona `json:"persona,omitempty"`
+
+	// GameChannelMode is how GameChannelIDs limit where games can be created, empty means anywhere
+	GameChannelMode GameChannelMode `json:"game_channel_mode,omitempty"`
+
+	// GameChannelIDs are the channels games are allowed or denied in
+	GameChannelIDs []string `json:"game_channel_ids,omitempty"`
+
+	// TieStrategy is how the guild's games settle ties, empty means roll-offs
+	TieStrategy TieStrategy `json:"tie_strategy,omitempty"`
+
+	// RonnieDrinks is who drinks the drinks given to Ronnie in the guild's games, empty means they bounce back
+	RonnieDrinks RonnieDrinkRule `json:"ronnie_drinks,omitempty"`
+}
+
+// GamePersona is a themed name and avatar a guild's game messages are posted under
+type GamePersona struct {
+	// Name is shown as the messages' author, e.g. "Ronnie the Bartender"
+	Name string `json:"name"`
+
+	// AvatarURL is the author's picture, empty keeps the bot's
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// PinnedLeaderboard is a message pinned in a channel showing the guild's session leaderboard
+type PinnedLeaderboard struct {
+	// ChannelID is the channel the leaderboard is pinned in
+	ChannelID string `json:"channel_id"`
+
+	// MessageID is the pinned message
+	MessageID string `json:"message_id"`
+}
+
+// RollFeedMode returns the guild's roll feed mode, off when it hasn't been set
+func (c *GuildConfig) RollFeedMode() RollFeedMode {
+	if c == nil || c.RollFeed == "" {
+		return RollFeedOff
+	}
+	return c.RollFeed
+}
+
+// DisplayDensity returns the guild's display density, detailed when it hasn't been set
+func (c *GuildConfig) DisplayDensity() DisplayDensity {
+	if c == nil || c.Display == "" {
+		return DisplayDetailed
+	}
+	return c.Display
+}
+
+// ContentRating returns the guild's content rating, R when it hasn't been set
+func (c *GuildConfig) ContentRating() ContentRating {
+	if c == nil || c.Rating == "" {
+		return ContentRatingR
+	}
+	return c.Rating
+}
+
+// MessageTone returns the guild's message tone, funny when it hasn't been set
+func (c *GuildConfig) MessageTone() MessageTone {
+	if c == nil || c.Tone == "" {
+		return ToneFunny
+	}
+	return c.Tone
+}
+
+// ScoringMode returns what the guild's games are played for, drinks when it hasn't been set
+func (c *GuildConfig) ScoringMode() ScoringMode {
+	if c == nil || c.Scoring == "" {
+		return ScoringDrinks
+	}
+	return c.Scoring
+}
+
+// Emoji returns the server's own emoji for a slot, or the fallback when it hasn't set one
+func (c *GuildConfig) Emoji(slot EmojiSlot, fallback string) string {
+	if c == nil || c.Emojis[slot] == "" {
+		return fallback
+	}
+	return c.Emojis[slot]
+}
+
+// AllowsGamesIn returns true if games can be created in the channel. Threads follow the channel they're in, so
+// parentID is the thread's channel and empty for other channels.
+func (c *GuildConfig) AllowsGamesIn(channelID, parentID string) bool {
+	if c == nil || !c.GameChannelMode.IsValid() {
+		return true
+	}
+
+	listed := slices.Contains(c.GameChannelIDs, channelID) || (parentID != "" && slices.Contains(c.GameChannelIDs, parentID))
+	if c.GameChannelMode == GameChannelsAllow {
+		return listed
+	}
+	return !listed
+}
+
+// ApplyGameRules applies the server's dice, critical values, tie strategy and Ronnie drink rule to the rules a game
+// is started with, unless the rules already set their own. A server playing with dice that have a built-in preset
+// gets the preset's critical values when it hasn't set its own. The rules given aren't changed.
+func (c *GuildConfig) ApplyGameRules(rules *GameRules) *GameRules {
+	if c == nil || (c.DiceSides == 0 && len(c.CriticalHitValues) == 0 && len(c.CriticalFailValues) == 0 &&
+		c.TieStrategy == "" && c.RonnieDrinks == "") {
+		return rules
+	}
+
+	// Copy the rules so the preset they came from isn't changed
+	merged := GameRules{}
+	if rules != nil {
+		merged = *rules
+	}
+	if len(merged.CriticalHitValues) == 0 {
+		merged.CriticalHitValues = c.CriticalHitValues
+	}
+	if len(merged.CriticalFailValues) == 0 {
+		merged.CriticalFailValues = c.CriticalFailValues
+	}
+	if merged.TieStrategy == "" {
+		merged.TieStrategy = c.TieStrategy
+	}
+	if merged.RonnieDrinks == "" {
+		merged.RonnieDrinks = c.RonnieDrinks
+	}
+	if merged.DiceSides == 0 && c.DiceSides > 0 {
+		merged.DiceSides = c.DiceSides
+		if preset := BuiltinPreset(fmt.Sprintf("d%d", c.DiceSides)); preset != nil {
+			if len(merged.CriticalHitValues) == 0 {
+				merged.CriticalHitValues = preset.Rules.CriticalHitValues
+			}
+			if len(merged.CriticalFailValues) == 0 {
+				merged.CriticalFailValues = preset.Rules.CriticalFailValues
+			}
+		}
+	}
+	return &merged
+}
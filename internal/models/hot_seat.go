@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strings"
+)
+
+// hotSeatPrefix starts the player IDs of hot seat players
+const hotSeatPrefix = "hotseat:"
+
+// MaxHotSeatNameLength is the longest name a hot seat player can be given
+const MaxHotSeatNameLength = 32
+
+// HotSeatPlayerID returns the player ID of a hot seat player, someone at the table without the bot who a Discord
+// user rolls for from their own device. The same name run by the same user is the same player in every game, so
+// their drinks add up on the ledger like anyone else's.
+func HotSeatPlayerID(hostID, name string) string {
+	return hotSeatPrefix + hostID + ":" + strings.ToLower(strings.TrimSpace(name))
+}
+
+// HotSeatHost returns the Discord user who rolls for a hot seat player, empty for players on their own device
+func HotSeatHost(playerID string) string {
+	rest, ok := strings.CutPrefix(playerID, hotSeatPrefix)
+	if !ok {
+		return ""
+	}
+	hostID, _, _ := strings.Cut(rest, ":")
+	return hostID
+}
+
+// IsHotSeatPlayer checks whether a player is played from someone else's device
+func IsHotSeatPlayer(playerID string) bool {
+	return strings.HasPrefix(playerID, hotSeatPrefix)
+}
+
+// HotSeatPlayers returns the game's players the given Discord user rolls for
+func (g *Game) HotSeatPlayers(hostID string) []*Participant {
+	var players []*Participant
+	for _, participant := range g.Participants {
+		if HotSeatHost(participant.PlayerID) == hostID {
+			players = append(players, participant)
+		}
+	}
+	return players
+}
+
+// HasHotSeatPlayers checks whether anyone in the game is played from someone else's device
+func (g *Game) HasHotSeatPlayers() bool {
+	for _, participant := range g.Participants {
+		if IsHotSeatPlayer(participant.PlayerID) {
+			return true
+		}
+	}
+	return false
+}
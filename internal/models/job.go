@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// JobType identifies the work a background job does
+type JobType string
+
+const (
+	// JobTypePostGame finishes off a completed game: completing the games above a roll-off, totting up the
+	// session leaderboard, letting subscribers know and closing a last call session
+	JobTypePostGame JobType = "post_game"
+)
+
+// Job is work queued to run in the background, outside the interaction that caused it
+type Job struct {
+	// ID identifies the job
+	ID string `json:"id"`
+
+	// Type is the work the job does
+	Type JobType `json:"type"`
+
+	// GameID is the game the job is for
+	GameID string `json:"game_id"`
+
+	// SessionID is the session the game was played in
+	SessionID string `json:"session_id,omitempty"`
+
+	// Attempts is how many times the job has been tried and failed
+	Attempts int `json:"attempts,omitempty"`
+
+	// EnqueuedAt is when the job was first queued
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
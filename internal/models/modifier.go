@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+)
+
+// RollModifierType represents the kind of effect a roll modifier has
+type RollModifierType string
+
+const (
+	// RollModifierBonus adds its amount to the roll, a negative amount is a penalty
+	RollModifierBonus RollModifierType = "bonus"
+
+	// RollModifierAdvantage rolls twice and keeps the better roll
+	RollModifierAdvantage RollModifierType = "advantage"
+
+	// RollModifierDisadvantage rolls twice and keeps the worse roll
+	RollModifierDisadvantage RollModifierType = "disadvantage"
+)
+
+// RollModifier is a temporary buff or debuff applied to a player's next rolls
+type RollModifier struct {
+	// Type is the kind of effect the modifier has
+	Type RollModifierType `json:"type"`
+
+	// Amount is added to the roll by bonus modifiers
+	Amount int `json:"amount,omitempty"`
+
+	// Rolls is how many more rolls the modifier applies to
+	Rolls int `json:"rolls"`
+
+	// Source describes what attached the modifier, e.g. an event name
+	Source string `json:"source,omitempty"`
+}
+
+// Label returns a short description of the modifier's effect
+func (m *RollModifier) Label() string {
+	var label string
+	switch m.Type {
+	case RollModifierBonus:
+		label = fmt.Sprintf("%+d to roll", m.Amount)
+	case RollModifierAdvantage:
+		label = "rolls twice, takes better"
+	case RollModifierDisadvantage:
+		label = "rolls twice, takes worse"
+	default:
+		label = string(m.Type)
+	}
+
+	if m.Source != "" {
+		label = fmt.Sprintf("%s (%s)", label, m.Source)
+	}
+
+	return label
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Moment is a memorable roll a player clipped to look back on later
+type Moment struct {
+	// ID identifies the roll that was clipped, so the same roll can't be clipped twice
+	ID string `json:"id"`
+
+	// GuildID and ChannelID are where the roll was made, GuildID is empty outside a server
+	GuildID   string `json:"guild_id,omitempty"`
+	ChannelID string `json:"channel_id"`
+
+	// GameID is the game the roll was made in
+	GameID string `json:"game_id"`
+
+	// PlayerID and PlayerName are who made the roll
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+
+	// RollValue is what they rolled
+	RollValue int `json:"roll_value"`
+
+	// IsCriticalHit and IsCriticalFail are whether the roll was a critical
+	IsCriticalHit  bool `json:"is_critical_hit,omitempty"`
+	IsCriticalFail bool `json:"is_critical_fail,omitempty"`
+
+	// IsRollOff marks a roll made in a roll-off
+	IsRollOff bool `json:"is_roll_off,omitempty"`
+
+	// DrinkCount is how many drinks the player had been handed in the game when it was clipped
+	DrinkCount int `json:"drink_count"`
+
+	// RolledAt is when the roll was made
+	RolledAt time.Time `json:"rolled_at"`
+
+	// ClippedByID and ClippedByName are who clipped the moment
+	ClippedByID   string `json:"clipped_by_id"`
+	ClippedByName string `json:"clipped_by_name"`
+
+	// ClippedAt is when the moment was clipped
+	ClippedAt time.Time `json:"clipped_at"`
+}
+
+// MomentScope returns the guild a moment is kept under, or its channel outside a guild
+func (m *Moment) MomentScope() string {
+	if m.GuildID != "" {
+		return m.GuildID
+	}
+	return m.ChannelID
+}
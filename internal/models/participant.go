@@ -40,4 +40,41 @@ type Participant struct {
 
 	// RollTime is when the player rolled in this game
 	RollTime *time.Time
+
+	// RollModifiers are the modifiers that were applied to the player's roll in this game
+	RollModifiers []*RollModifier `json:",omitempty"`
+
+	// VoidedRolls are the rolls the player threw away with a re-roll token
+	VoidedRolls []*VoidedRoll `json:",omitempty"`
+
+	// GuestOffer is the drink the player offered someone watching the game, waiting on them to take it
+	GuestOffer *GuestOffer `json:",omitempty"`
+}
+
+// GuestOffer is a critical hit's drink offered to someone in the channel who isn't playing. It only goes on
+// their tab once they agree to take it.
+type GuestOffer struct {
+	// GuestID is the ID of the channel member offered the drink
+	GuestID string
+
+	// GuestName is the display name of the channel member offered the drink
+	GuestName string
+
+	// OfferedAt is when the drink was offered
+	OfferedAt time.Time
+}
+
+// VoidedRoll records a roll that was replaced by a re-roll
+type VoidedRoll struct {
+	// RollValue is the value of the voided roll
+	RollValue int
+
+	// RollTime is when the voided roll was made
+	RollTime time.Time
+
+	// VoidedAt is when the roll was voided
+	VoidedAt time.Time
+
+	// DrinkIDs are the IDs of the critical fail drinks that were voided with the roll, if any
+	DrinkIDs []string
 }
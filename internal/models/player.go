@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// DeletedPlayerName is shown in place of a player who asked for their data to be deleted
+const DeletedPlayerName = "Deleted Player"
+
 // Player represents a participant in a game
 type Player struct {
 	// ID is the Discord user ID of the player
@@ -20,4 +23,22 @@ type Player struct {
 	
 	// LastRollTime is when the player last rolled
 	LastRollTime time.Time
+	
+	// RerollTokens is how many re-rolls the player has banked
+	RerollTokens int
+	
+	// RollModifiers are the buffs and debuffs waiting to be applied to the player's next rolls
+	RollModifiers []*RollModifier `json:",omitempty"`
+	
+	// MergedInto is the ID of the canonical player this account was merged into, empty if it wasn't
+	MergedInto string
+	
+	// Birthday is the day the player celebrates their birthday, nil if they haven't shared it
+	Birthday *Birthday `json:",omitempty"`
+	
+	// DrinkReminders are the player's settings for reminders about drinks they've owed a long time, nil for the defaults
+	DrinkReminders *DrinkReminders `json:",omitempty"`
+	
+	// Achievements are what the player has earned, such as completing a session's challenges, oldest first
+	Achievements []*Achievement `json:",omitempty"`
 }
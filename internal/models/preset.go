@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GameRules are the settings a game is played with.
+// Zero values fall back to the game service defaults.
+type GameRules struct {
+	// DiceSides is the number of sides on the dice
+	DiceSides int `json:"dice_sides,omitempty"`
+
+	// MaxPlayers is the maximum number of players that can join
+	MaxPlayers int `json:"max_players,omitempty"`
+
+	// CriticalHitValues are the rolls that let a player assign a drink
+	CriticalHitValues []int `json:"critical_hit_values,omitempty"`
+
+	// CriticalFailValues are the rolls that make a player drink
+	CriticalFailValues []int `json:"critical_fail_values,omitempty"`
+
+	// SocialValues are the rolls that call a social round, giving every player in the game a drink
+	SocialValues []int `json:"social_values,omitempty"`
+
+	// LeaderHandicap makes the session leader, the player with the most drinks, roll with disadvantage
+	LeaderHandicap bool `json:"leader_handicap,omitempty"`
+
+	// RollMode makes every roll in the game, roll-offs included, with advantage or disadvantage
+	RollMode RollModifierType `json:"roll_mode,omitempty"`
+
+	// GuestDrinks lets a critical hit's drink go to someone in the channel who isn't playing, if they agree to it
+	GuestDrinks bool `json:"guest_drinks,omitempty"`
+
+	// Karma makes a player who keeps giving their drinks to the same player drink one too
+	Karma bool `json:"karma,omitempty"`
+
+	// TieStrategy is how ties are settled, empty means roll-offs
+	TieStrategy TieStrategy `json:"tie_strategy,omitempty"`
+
+	// Jackpot makes ties and doubles put a drink in the session's jackpot, which the next critical fail drinks
+	Jackpot bool `json:"jackpot,omitempty"`
+
+	// RonnieDrinks is who drinks the drinks given to Ronnie when he's playing, empty means they bounce back
+	RonnieDrinks RonnieDrinkRule `json:"ronnie_drinks,omitempty"`
+}
+
+// IsCriticalHit checks whether a roll is one of the critical hit values
+func (r GameRules) IsCriticalHit(rollValue int) bool {
+	return slices.Contains(r.CriticalHitValues, rollValue)
+}
+
+// IsCriticalFail checks whether a roll is one of the critical fail values
+func (r GameRules) IsCriticalFail(rollValue int) bool {
+	return slices.Contains(r.CriticalFailValues, rollValue)
+}
+
+// IsSocial checks whether a roll is one of the social round values
+func (r GameRules) IsSocial(rollValue int) bool {
+	return slices.Contains(r.SocialValues, rollValue)
+}
+
+// IsZero checks whether the rules leave everything to the defaults
+func (r GameRules) IsZero() bool {
+	return r.DiceSides == 0 && r.MaxPlayers == 0 && len(r.CriticalHitValues) == 0 && len(r.CriticalFailValues) == 0 &&
+		len(r.SocialValues) == 0 && !r.LeaderHandicap && r.RollMode == "" && !r.GuestDrinks && !r.Karma &&
+		r.TieStrategy == "" && !r.Jackpot && r.RonnieDrinks == ""
+}
+
+// ValidRollMode checks whether a roll mode is one the game can be played with, empty being normal rolls
+func ValidRollMode(mode RollModifierType) bool {
+	return mode == "" || mode == RollModifierAdvantage || mode == RollModifierDisadvantage
+}
+
+// UnmarshalJSON reads rules saved before critical values became lists, when each was a single roll
+func (r *GameRules) UnmarshalJSON(data []byte) error {
+	type rules GameRules
+	var saved struct {
+		rules
+		CriticalHitValue  int `json:"critical_hit_value"`
+		CriticalFailValue int `json:"critical_fail_value"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	*r = GameRules(saved.rules)
+	if len(r.CriticalHitValues) == 0 && saved.CriticalHitValue > 0 {
+		r.CriticalHitValues = []int{saved.CriticalHitValue}
+	}
+	if len(r.CriticalFailValues) == 0 && saved.CriticalFailValue > 0 {
+		r.CriticalFailValues = []int{saved.CriticalFailValue}
+	}
+	return nil
+}
+
+// Preset is a named set of game rules saved by a guild
+type Preset struct {
+	// Name is how the preset is picked when starting a game, unique within the guild
+	Name string `json:"name"`
+
+	// GuildID is the Discord server the preset belongs to
+	GuildID string `json:"guild_id"`
+
+	// Rules are the game rules the preset applies
+	Rules GameRules `json:"rules"`
+
+	// CreatedBy is the user ID of the admin that saved the preset
+	CreatedBy string `json:"created_by"`
+
+	// CreatedAt is when the preset was saved
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BuiltinPresets are the presets every guild can start games with without saving them.
+// A guild that saves a preset with the same name plays its own instead.
+var BuiltinPresets = []*Preset{
+	{
+		Name: "d20",
+		Rules: GameRules{
+			DiceSides:          20,
+			CriticalHitValues:  []int{20},
+			CriticalFailValues: []int{1},
+		},
+	},
+}
+
+// BuiltinPreset returns the built-in preset with the name, nil when there isn't one
+func BuiltinPreset(name string) *Preset {
+	for _, preset := range BuiltinPresets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset
+		}
+	}
+	return nil
+}
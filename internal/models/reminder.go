@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ReminderDelivery is how a player is reminded of drinks they've owed a long time
+type ReminderDelivery string
+
+const (
+	// ReminderDeliveryChannel mentions the player in the channel the drinks were given in
+	ReminderDeliveryChannel ReminderDelivery = "channel"
+
+	// ReminderDeliveryDM sends the player a direct message
+	ReminderDeliveryDM ReminderDelivery = "dm"
+)
+
+// IsValid returns true if the delivery is one of the known ways to remind a player
+func (d ReminderDelivery) IsValid() bool {
+	return d == ReminderDeliveryChannel || d == ReminderDeliveryDM
+}
+
+// DrinkReminders are a player's settings for reminders about drinks they've owed a long time
+type DrinkReminders struct {
+	// Delivery is how the player is reminded, empty for the channel
+	Delivery ReminderDelivery `json:",omitempty"`
+
+	// Muted stops the player's reminders
+	Muted bool `json:",omitempty"`
+
+	// LastRemindedAt is when the player was last reminded, zero if they never were
+	LastRemindedAt time.Time
+}
+
+// DeliveryOrDefault returns how the player is reminded, the channel when they haven't chosen
+func (r *DrinkReminders) DeliveryOrDefault() ReminderDelivery {
+	if r == nil || r.Delivery == "" {
+		return ReminderDeliveryChannel
+	}
+	return r.Delivery
+}
@@ -15,9 +15,19 @@ type Roll struct {
 	// PlayerID is the ID of the player who made the roll
 	PlayerID string
 	
+	// PlayerName is the name the player rolled under
+	PlayerName string
+	
 	// GameID is the ID of the game the roll belongs to
 	GameID string
 	
+	// ParentGameID is the game a roll-off roll was made for
+	ParentGameID string `json:",omitempty"`
+	
+	// GuildID and ChannelID are where the roll was made
+	GuildID   string
+	ChannelID string
+	
 	// Timestamp is when the roll was made
 	Timestamp time.Time
 	
@@ -29,4 +39,15 @@ type Roll struct {
 	
 	// IsLowestRoll indicates if the roll was the lowest in the game
 	IsLowestRoll bool
+	
+	// IsReroll indicates the roll replaced a voided one
+	IsReroll bool `json:",omitempty"`
+	
+	// DiceSides, CriticalHitValues and CriticalFailValues are the dice the roll was made with
+	DiceSides          int
+	CriticalHitValues  []int
+	CriticalFailValues []int
+	
+	// Modifiers are the adjustments applied to the natural roll
+	Modifiers []*RollModifier `json:",omitempty"`
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseRollValues reads a list of die faces such as "5, 6" or "5 6", returned sorted without duplicates
+func ParseRollValues(s string) ([]int, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no values in %q", s)
+	}
+
+	values := make([]int, 0, len(fields))
+	for _, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil || value < 1 {
+			return nil, fmt.Errorf("%q is not a die face", field)
+		}
+		values = append(values, value)
+	}
+
+	slices.Sort(values)
+	return slices.Compact(values), nil
+}
+
+// FormatRollValues lists die faces for players, e.g. "6", "5 or 6" or "1, 2 or 3"
+func FormatRollValues(values []int) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return strconv.Itoa(values[0])
+	}
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = strconv.Itoa(value)
+	}
+	return strings.Join(formatted[:len(formatted)-1], ", ") + " or " + formatted[len(formatted)-1]
+}
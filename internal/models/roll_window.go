@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// RollWindow is the short window a countdown game's players have to roll in once the countdown ends
+type RollWindow struct {
+	// OpensAt is when the countdown ends and rolling starts
+	OpensAt time.Time `json:"opens_at"`
+
+	// ClosesAt is when rolling stops counting as on time
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+// IsEarly checks whether a roll at the given time jumped the gun
+func (w *RollWindow) IsEarly(t time.Time) bool {
+	return t.Before(w.OpensAt)
+}
+
+// IsLate checks whether a roll at the given time missed the window
+func (w *RollWindow) IsLate(t time.Time) bool {
+	return t.After(w.ClosesAt)
+}
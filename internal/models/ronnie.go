@@ -0,0 +1,41 @@
+package models
+
+const (
+	// RonniePlayerID is the player ID of Ronnie, the bot's own player, who can sit in on a game short of players
+	RonniePlayerID = "ronnie"
+
+	// RonniePlayerName is the name Ronnie plays under
+	RonniePlayerName = "Ronnie"
+)
+
+// IsRonnie checks whether a player is Ronnie rather than someone playing
+func IsRonnie(playerID string) bool {
+	return playerID == RonniePlayerID
+}
+
+// HasRonnie checks whether Ronnie is playing in the game
+func (g *Game) HasRonnie() bool {
+	return g.HasParticipant(RonniePlayerID)
+}
+
+// RonnieDrinkRule is who drinks the drinks given to Ronnie, who can't drink them himself
+type RonnieDrinkRule string
+
+const (
+	// RonnieDrinksBounce sends a drink back to the player who gave it to Ronnie, and Ronnie's own drinks to a
+	// random player
+	RonnieDrinksBounce RonnieDrinkRule = "bounce"
+
+	// RonnieDrinksRandom sends every drink given to Ronnie to a random player in the game
+	RonnieDrinksRandom RonnieDrinkRule = "random"
+)
+
+// IsValid returns true if the rule is one of the known Ronnie drink rules
+func (r RonnieDrinkRule) IsValid() bool {
+	switch r {
+	case RonnieDrinksBounce, RonnieDrinksRandom:
+		return true
+	default:
+		return false
+	}
+}
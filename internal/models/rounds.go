@@ -0,0 +1,57 @@
+package models
+
+// The number of rounds a rounds game can be played over
+const (
+	MinGameRounds = 2
+	MaxGameRounds = 10
+)
+
+// GameRounds tracks a game played over several rounds by the same players. Every round the lowest roller drinks as
+// usual, and once the last round is done the lowest total over all of them takes a bonus drink.
+type GameRounds struct {
+	// Total is how many rounds the game is played over
+	Total int `json:"total"`
+
+	// Current is the round being played, starting at 1
+	Current int `json:"current"`
+
+	// Totals are each player's rolls added up over the rounds finished so far, by player ID
+	Totals map[string]int `json:"totals,omitempty"`
+}
+
+// NewGameRounds creates the rounds for a game played over the given number of them, starting on the first
+func NewGameRounds(total int) *GameRounds {
+	return &GameRounds{
+		Total:   total,
+		Current: 1,
+		Totals:  make(map[string]int),
+	}
+}
+
+// IsLastRound checks whether the round being played is the final one
+func (r *GameRounds) IsLastRound() bool {
+	return r.Current >= r.Total
+}
+
+// Record adds each participant's roll in the round just finished to their total
+func (r *GameRounds) Record(participants []*Participant) {
+	if r.Totals == nil {
+		r.Totals = make(map[string]int)
+	}
+	for _, participant := range participants {
+		r.Totals[participant.PlayerID] += participant.RollValue
+	}
+}
+
+// LowestTotals returns the participants with the lowest total, everyone tied on it when there's a tie
+func (r *GameRounds) LowestTotals(participants []*Participant) []*Participant {
+	var lowest []*Participant
+	for _, participant := range participants {
+		if len(lowest) == 0 || r.Totals[participant.PlayerID] < r.Totals[lowest[0].PlayerID] {
+			lowest = []*Participant{participant}
+		} else if r.Totals[participant.PlayerID] == r.Totals[lowest[0].PlayerID] {
+			lowest = append(lowest, participant)
+		}
+	}
+	return lowest
+}
@@ -20,4 +20,148 @@ type Session struct {
 
 	// Active indicates if this is the current active session
 	Active bool `json:"active"`
+
+	// DesignatedDrivers are the players sitting out drinks this session
+	DesignatedDrivers []*DesignatedDriver `json:"designated_drivers,omitempty"`
+
+	// LastCall indicates the session closes once its final game ends
+	LastCall bool `json:"last_call,omitempty"`
+
+	// LastCallBy is the user ID who called last call
+	LastCallBy string `json:"last_call_by,omitempty"`
+
+	// LastCallAt is when last call was called
+	LastCallAt *time.Time `json:"last_call_at,omitempty"`
+
+	// LastCallGameID is the final game played after last call, empty until it's created
+	LastCallGameID string `json:"last_call_game_id,omitempty"`
+
+	// EndedAt is when the session was replaced by a new one, nil while it's still running
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+
+	// Pauses are the breaks the session was paused for, oldest first. The last one is still going if it hasn't ended.
+	Pauses []*SessionPause `json:"pauses,omitempty"`
+
+	// Goals are the challenges the session is played with, picked when it started
+	Goals []*SessionGoal `json:"goals,omitempty"`
+
+	// Jackpot is the drinks waiting in the session's pot for the next critical fail in a game played for it
+	Jackpot int `json:"jackpot,omitempty"`
+}
+
+// SessionPause is a break a session was paused for. No games are started and nobody is reminded of drinks while
+// it lasts, and it doesn't count towards the session's time-based stats.
+type SessionPause struct {
+	// PausedBy is the user ID who paused the session
+	PausedBy string `json:"paused_by"`
+
+	// Reason is why the session was paused, e.g. "food break"
+	Reason string `json:"reason,omitempty"`
+
+	// Start is when the session was paused
+	Start time.Time `json:"start"`
+
+	// End is when the session was resumed, nil while it's still paused
+	End *time.Time `json:"end,omitempty"`
+}
+
+// DesignatedDriver is a player who isn't drinking this session.
+// Drinks given to them are owed later, or taken by a substitute who volunteers to cover them.
+type DesignatedDriver struct {
+	// PlayerID is the ID of the designated driver
+	PlayerID string `json:"player_id"`
+
+	// PlayerName is the display name of the designated driver
+	PlayerName string `json:"player_name"`
+
+	// SubstituteID is the ID of the player covering the driver's drinks, empty if nobody is
+	SubstituteID string `json:"substitute_id,omitempty"`
+
+	// SubstituteName is the display name of the substitute
+	SubstituteName string `json:"substitute_name,omitempty"`
+
+	// Since is when the player became the designated driver
+	Since time.Time `json:"since"`
+}
+
+// GetDesignatedDriver returns the designated driver entry for a player, or nil if they're drinking
+func (s *Session) GetDesignatedDriver(playerID string) *DesignatedDriver {
+	for _, driver := range s.DesignatedDrivers {
+		if driver.PlayerID == playerID {
+			return driver
+		}
+	}
+	return nil
+}
+
+// CurrentPause returns the break the session is paused for, or nil if it's running
+func (s *Session) CurrentPause() *SessionPause {
+	if len(s.Pauses) == 0 {
+		return nil
+	}
+
+	last := s.Pauses[len(s.Pauses)-1]
+	if last.End != nil {
+		return nil
+	}
+	return last
+}
+
+// IsPaused reports whether the session is paused for a break
+func (s *Session) IsPaused() bool {
+	return s.CurrentPause() != nil
+}
+
+// PausedDuration returns how long the session has been paused for in total up to now, counting a break still going
+func (s *Session) PausedDuration(now time.Time) time.Duration {
+	var paused time.Duration
+	for _, pause := range s.Pauses {
+		end := now
+		if pause.End != nil {
+			end = *pause.End
+		}
+		if end.After(pause.Start) {
+			paused += end.Sub(pause.Start)
+		}
+	}
+	return paused
+}
+
+// EndTime returns when the session ended, or now if it's still running
+func (s *Session) EndTime(now time.Time) time.Time {
+	if s.EndedAt != nil && s.EndedAt.Before(now) {
+		return *s.EndedAt
+	}
+	return now
+}
+
+// ActiveDuration returns how long the session has been running up to now, or until it ended, leaving out the breaks
+// it was paused for
+func (s *Session) ActiveDuration(now time.Time) time.Duration {
+	return s.ActiveBetween(s.CreatedAt, s.EndTime(now))
+}
+
+// ActiveBetween returns how much of the time from one moment to another the session was running rather than paused
+func (s *Session) ActiveBetween(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+
+	active := to.Sub(from)
+	for _, pause := range s.Pauses {
+		start := pause.Start
+		if start.Before(from) {
+			start = from
+		}
+
+		end := to
+		if pause.End != nil && pause.End.Before(to) {
+			end = *pause.End
+		}
+
+		if end.After(start) {
+			active -= end.Sub(start)
+		}
+	}
+	return active
 }
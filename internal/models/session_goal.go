@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SessionGoalType is a kind of challenge a session can be played with
+type SessionGoalType string
+
+const (
+	// SessionGoalFirstToCrits is won by the first player to roll a number of critical hits in the session
+	SessionGoalFirstToCrits SessionGoalType = "first_to_crits"
+
+	// SessionGoalSurviveGames is completed by every player who plays a number of games in a row without a
+	// critical fail
+	SessionGoalSurviveGames SessionGoalType = "survive_games"
+)
+
+// SessionGoal is a challenge the players of a session try to complete, picked when the session starts
+type SessionGoal struct {
+	// Type is the kind of challenge
+	Type SessionGoalType `json:"type"`
+
+	// Target is how many critical hits or games the challenge takes
+	Target int `json:"target"`
+
+	// Progress counts each player's way towards the target
+	Progress map[string]int `json:"progress,omitempty"`
+
+	// CompletedBy are the players who completed the challenge, first first
+	CompletedBy []string `json:"completed_by,omitempty"`
+}
+
+// Name describes the challenge, e.g. "First to 3 crits"
+func (g *SessionGoal) Name() string {
+	switch g.Type {
+	case SessionGoalFirstToCrits:
+		return fmt.Sprintf("First to %d crits", g.Target)
+	case SessionGoalSurviveGames:
+		return fmt.Sprintf("Survive %d games without a fail", g.Target)
+	default:
+		return string(g.Type)
+	}
+}
+
+// IsOver reports whether nobody else can complete the challenge, once a race has been won
+func (g *SessionGoal) IsOver() bool {
+	return g.Type == SessionGoalFirstToCrits && len(g.CompletedBy) > 0
+}
+
+// HasCompleted reports whether the player has completed the challenge
+func (g *SessionGoal) HasCompleted(playerID string) bool {
+	return slices.Contains(g.CompletedBy, playerID)
+}
+
+// Achievement is something a player earned, such as completing a session's challenge
+type Achievement struct {
+	// Name is what the player earned, e.g. "First to 3 crits"
+	Name string `json:"name"`
+
+	// SessionID is the session the achievement was earned in
+	SessionID string `json:"session_id,omitempty"`
+
+	// EarnedAt is when the player earned it
+	EarnedAt time.Time `json:"earned_at"`
+}
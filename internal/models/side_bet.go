@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SideBetOutcome is what a side bet backs a player's next roll to do
+type SideBetOutcome string
+
+const (
+	// SideBetCriticalHit backs the roll to be a critical hit
+	SideBetCriticalHit SideBetOutcome = "critical_hit"
+
+	// SideBetCriticalFail backs the roll to be a critical fail
+	SideBetCriticalFail SideBetOutcome = "critical_fail"
+
+	// SideBetOver backs the roll to be over the line
+	SideBetOver SideBetOutcome = "over"
+
+	// SideBetUnder backs the roll to be the line or under
+	SideBetUnder SideBetOutcome = "under"
+)
+
+// SideBet is a drink bet on the outcome of another player's roll, settled when they roll.
+// The bettor hands a drink to the player if the bet wins and takes one from them if it loses.
+type SideBet struct {
+	// ID is the unique identifier for this bet
+	ID string `json:"id"`
+
+	// GameID is the game the roll is made in
+	GameID string `json:"game_id"`
+
+	// ChannelID is the channel the game is played in
+	ChannelID string `json:"channel_id"`
+
+	// BettorID is the ID of the player who made the bet
+	BettorID string `json:"bettor_id"`
+
+	// BettorName is the display name of the player who made the bet
+	BettorName string `json:"bettor_name"`
+
+	// TargetPlayerID is the ID of the player whose roll the bet is on
+	TargetPlayerID string `json:"target_player_id"`
+
+	// TargetPlayerName is the display name of the player whose roll the bet is on
+	TargetPlayerName string `json:"target_player_name"`
+
+	// Outcome is what the bet backs the roll to do
+	Outcome SideBetOutcome `json:"outcome"`
+
+	// Line is the roll over and under bets are measured against, over wins above it and under wins on it or below
+	Line int `json:"line,omitempty"`
+
+	// CreatedAt is when the bet was made
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExpiresAt is when the bet lapses if the player hasn't rolled
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Wins checks whether a roll played with the given rules wins the bet
+func (b *SideBet) Wins(rollValue int, rules GameRules) bool {
+	switch b.Outcome {
+	case SideBetCriticalHit:
+		return rules.IsCriticalHit(rollValue)
+	case SideBetCriticalFail:
+		return rules.IsCriticalFail(rollValue)
+	case SideBetOver:
+		return rollValue > b.Line
+	case SideBetUnder:
+		return rollValue <= b.Line
+	default:
+		return false
+	}
+}
+
+// Label returns a short description of what the bet backs the roll to do
+func (b *SideBet) Label() string {
+	switch b.Outcome {
+	case SideBetCriticalHit:
+		return "a critical hit"
+	case SideBetCriticalFail:
+		return "a critical fail"
+	case SideBetOver:
+		return fmt.Sprintf("over %d", b.Line)
+	case SideBetUnder:
+		return fmt.Sprintf("%d or under", b.Line)
+	default:
+		return string(b.Outcome)
+	}
+}
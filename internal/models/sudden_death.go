@@ -0,0 +1,31 @@
+package models
+
+// SuddenDeath is a roll-off the tied players play in turns. Whenever they all tie again a drink goes in the pot
+// and they go again, until one of them is out on their own. The loser takes the pot on top of their usual drink.
+type SuddenDeath struct {
+	// Pot is how many drinks the loser takes on top of their usual one
+	Pot int `json:"pot"`
+}
+
+// NextRoller returns the participant whose turn it is to roll, the first one who hasn't yet, nil once everyone has
+func (g *Game) NextRoller() *Participant {
+	for _, participant := range g.Participants {
+		if participant.RollTime == nil {
+			return participant
+		}
+	}
+	return nil
+}
+
+// IsDeadHeat checks whether every player rolled the same, with more than one of them playing
+func (g *Game) IsDeadHeat() bool {
+	if len(g.Participants) < 2 {
+		return false
+	}
+	for _, participant := range g.Participants {
+		if participant.RollTime == nil || participant.RollValue != g.Participants[0].RollValue {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// VoteAction is what the players of a game vote to do with it
+type VoteAction string
+
+const (
+	// VoteActionStart starts a game waiting for its creator
+	VoteActionStart VoteAction = "start"
+
+	// VoteActionAbandon abandons a game, whatever state it's in
+	VoteActionAbandon VoteAction = "abandon"
+)
+
+// IsValid checks whether the action is one players can vote on
+func (a VoteAction) IsValid() bool {
+	return a == VoteActionStart || a == VoteActionAbandon
+}
+
+// GameVote is a vote the players of a game are holding on what to do with it. It passes once enough players
+// approve and fails once too many turn it down for it to pass, or when it expires.
+type GameVote struct {
+	// Action is what the vote is on
+	Action VoteAction `json:"action"`
+
+	// CalledBy is the ID of the player who called the vote, who approves it by calling it
+	CalledBy string `json:"called_by"`
+
+	// Required is how many players have to approve the vote, fixed when it's called
+	Required int `json:"required"`
+
+	// Approvals and Rejections are the IDs of the players who voted for and against
+	Approvals  []string `json:"approvals,omitempty"`
+	Rejections []string `json:"rejections,omitempty"`
+
+	// CalledAt is when the vote was called
+	CalledAt time.Time `json:"called_at"`
+
+	// ExpiresAt is when the vote fails if it hasn't passed
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// HasVoted checks whether a player has voted either way
+func (v *GameVote) HasVoted(playerID string) bool {
+	return slices.Contains(v.Approvals, playerID) || slices.Contains(v.Rejections, playerID)
+}
+
+// IsExpired checks whether the vote ran out of time
+func (v *GameVote) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+// Passed checks whether enough players approved the vote
+func (v *GameVote) Passed() bool {
+	return len(v.Approvals) >= v.Required
+}
+
+// CanPass checks whether enough of the players yet to vote are left for the vote to pass
+func (v *GameVote) CanPass(players int) bool {
+	return players-len(v.Rejections) >= v.Required
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Webhook represents an outbound webhook registered by a guild admin
+type Webhook struct {
+	// ID is the unique identifier for the webhook
+	ID string `json:"id"`
+
+	// GuildID is the Discord server the webhook belongs to
+	GuildID string `json:"guild_id"`
+
+	// URL is the endpoint that receives the JSON payloads
+	URL string `json:"url"`
+
+	// Events is the list of event types delivered to this webhook (empty means all)
+	Events []string `json:"events,omitempty"`
+
+	// Secret is the key deliveries are signed with, so receivers can tell they came from the bot
+	Secret string `json:"secret,omitempty"`
+
+	// CreatedBy is the user ID of the admin that registered the webhook
+	CreatedBy string `json:"created_by"`
+
+	// CreatedAt is when the webhook was registered
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WantsEvent returns true if the webhook should receive the given event type
+func (w *Webhook) WantsEvent(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package audit
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/audit Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for persisting the audit log
+type Repository interface {
+	// AddEntry appends an entry to the audit log
+	AddEntry(ctx context.Context, input *AddEntryInput) error
+
+	// ListEntries returns a guild's audit entries, newest first
+	ListEntries(ctx context.Context, input *ListEntriesInput) (*ListEntriesOutput, error)
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis
+	auditKeyPrefix = "audit:"
+
+	// globalAuditKey holds the entries for actions taken outside a guild
+	globalAuditKey = auditKeyPrefix + "global"
+)
+
+// Config holds configuration for the Redis audit repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed audit repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// AddEntry appends an entry to its guild's audit log
+func (r *redisRepository) AddEntry(ctx context.Context, input *AddEntryInput) error {
+	if input == nil || input.Entry == nil {
+		return errors.New("entry cannot be nil")
+	}
+
+	if input.Entry.ID == "" {
+		return errors.New("entry ID cannot be empty")
+	}
+
+	entryJSON, err := json.Marshal(input.Entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	if err := r.client.LPush(ctx, auditKey(input.Entry.GuildID), entryJSON).Err(); err != nil {
+		return fmt.Errorf("failed to save audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListEntries returns a guild's audit entries, newest first
+func (r *redisRepository) ListEntries(ctx context.Context, input *ListEntriesInput) (*ListEntriesOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	entriesJSON, err := r.client.LRange(ctx, auditKey(input.GuildID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	entries := make([]*models.AuditEntry, 0, len(entriesJSON))
+	for _, entryJSON := range entriesJSON {
+		var entry models.AuditEntry
+		if err := json.Unmarshal([]byte(entryJSON), &entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return &ListEntriesOutput{
+		Entries: entries,
+	}, nil
+}
+
+// auditKey returns the key of a guild's audit log
+func auditKey(guildID string) string {
+	if guildID == "" {
+		return globalAuditKey
+	}
+	return auditKeyPrefix + guildID
+}
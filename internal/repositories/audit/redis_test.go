@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestAddAndListEntries() {
+	ctx := context.Background()
+
+	for _, id := range []string{"entry-1", "entry-2"} {
+		err := s.repo.AddEntry(ctx, &AddEntryInput{
+			Entry: &models.AuditEntry{
+				ID:        id,
+				GuildID:   "guild-1",
+				Action:    models.AuditActionForgetPlayer,
+				ActorID:   "admin-1",
+				SubjectID: "deleted-" + id,
+				CreatedAt: s.testNow,
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.ListEntries(ctx, &ListEntriesInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Entries, 2)
+	s.Equal("entry-2", output.Entries[0].ID)
+	s.Equal("entry-1", output.Entries[1].ID)
+	s.Equal(models.AuditActionForgetPlayer, output.Entries[0].Action)
+	s.Equal("admin-1", output.Entries[0].ActorID)
+	s.True(s.testNow.Equal(output.Entries[0].CreatedAt))
+}
+
+func (s *RedisRepositoryTestSuite) TestEntriesAreKeptPerGuild() {
+	ctx := context.Background()
+
+	err := s.repo.AddEntry(ctx, &AddEntryInput{
+		Entry: &models.AuditEntry{ID: "entry-1", GuildID: "guild-1", Action: models.AuditActionForgetPlayer},
+	})
+	s.Require().NoError(err)
+
+	err = s.repo.AddEntry(ctx, &AddEntryInput{
+		Entry: &models.AuditEntry{ID: "entry-2", Action: models.AuditActionForgetPlayer},
+	})
+	s.Require().NoError(err)
+
+	output, err := s.repo.ListEntries(ctx, &ListEntriesInput{GuildID: "guild-2"})
+	s.Require().NoError(err)
+	s.Empty(output.Entries)
+
+	output, err = s.repo.ListEntries(ctx, &ListEntriesInput{})
+	s.Require().NoError(err)
+	s.Require().Len(output.Entries, 1)
+	s.Equal("entry-2", output.Entries[0].ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestAddEntryRequiresID() {
+	err := s.repo.AddEntry(context.Background(), &AddEntryInput{
+		Entry: &models.AuditEntry{GuildID: "guild-1"},
+	})
+	s.Error(err)
+}
@@ -0,0 +1,18 @@
+package audit
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// AddEntryInput contains parameters for adding an audit entry
+type AddEntryInput struct {
+	Entry *models.AuditEntry
+}
+
+// ListEntriesInput contains parameters for listing audit entries
+type ListEntriesInput struct {
+	GuildID string
+}
+
+// ListEntriesOutput contains the result of listing audit entries
+type ListEntriesOutput struct {
+	Entries []*models.AuditEntry
+}
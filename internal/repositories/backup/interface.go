@@ -0,0 +1,16 @@
+package backup
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/backup Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for copying all of the bot's saved data out and back in
+type Repository interface {
+	// Backup writes every saved key to the writer
+	Backup(ctx context.Context, input *BackupInput) (*BackupOutput, error)
+
+	// Restore saves the keys read from a backup, replacing any keys of the same name
+	Restore(ctx context.Context, input *RestoreInput) (*RestoreOutput, error)
+}
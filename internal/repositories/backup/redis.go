@@ -0,0 +1,240 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/redis/go-redis/v9"
+)
+
+// maxRecordSize is the largest key a backup can hold, as a line of JSON
+const maxRecordSize = 64 * 1024 * 1024
+
+// Config holds configuration for the Redis backup repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed backup repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// Backup writes every key in the database to the writer as a line of JSON, along with how long it has left to live.
+// Keys are read one at a time while the bot may still be changing them, so stop the bot first for a backup that's
+// consistent across keys. A stream's entries are kept but its consumer groups aren't.
+func (r *redisRepository) Backup(ctx context.Context, input *BackupInput) (*BackupOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.Writer == nil {
+		return nil, errors.New("writer cannot be empty")
+	}
+
+	keys, err := scan.Keys(ctx, r.client, "*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	writer := bufio.NewWriter(input.Writer)
+	encoder := json.NewEncoder(writer)
+
+	output := &BackupOutput{}
+	for _, key := range keys {
+		rec, err := r.readKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		// The key expired or was deleted since it was listed
+		if rec == nil {
+			continue
+		}
+
+		if err := encoder.Encode(rec); err != nil {
+			return nil, fmt.Errorf("failed to write key %s: %w", key, err)
+		}
+		output.Keys++
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return output, nil
+}
+
+// readKey reads the key's type, value and time to live, returning nil when the key no longer exists
+func (r *redisRepository) readKey(ctx context.Context, key string) (*record, error) {
+	keyType, err := r.client.Type(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get type of key %s: %w", key, err)
+	}
+
+	rec := &record{Key: key, Type: keyType}
+	switch keyType {
+	case "none":
+		return nil, nil
+	case keyTypeString:
+		value, err := r.client.Get(ctx, key).Result()
+		if err == redis.Nil {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key %s: %w", key, err)
+		}
+		rec.String = &value
+	case keyTypeHash:
+		rec.Hash, err = r.client.HGetAll(ctx, key).Result()
+	case keyTypeList:
+		rec.List, err = r.client.LRange(ctx, key, 0, -1).Result()
+	case keyTypeSet:
+		var members []string
+		members, err = r.client.SMembers(ctx, key).Result()
+		for _, m := range members {
+			rec.Members = append(rec.Members, &member{Member: m})
+		}
+	case keyTypeZSet:
+		var members []redis.Z
+		members, err = r.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+		for _, m := range members {
+			rec.Members = append(rec.Members, &member{Member: fmt.Sprint(m.Member), Score: m.Score})
+		}
+	case keyTypeStream:
+		var messages []redis.XMessage
+		messages, err = r.client.XRange(ctx, key, "-", "+").Result()
+		for _, m := range messages {
+			rec.Entries = append(rec.Entries, &entry{ID: m.ID, Values: m.Values})
+		}
+	default:
+		return nil, fmt.Errorf("key %s has type %s, which can't be backed up", key, keyType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s: %w", key, err)
+	}
+
+	ttl, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to live of key %s: %w", key, err)
+	}
+	if ttl > 0 {
+		rec.TTLMillis = ttl.Milliseconds()
+	}
+
+	return rec, nil
+}
+
+// Restore saves each key read from the backup, replacing the key if it's already saved. Keys that had a time to
+// live are given what they had left when they were backed up.
+func (r *redisRepository) Restore(ctx context.Context, input *RestoreInput) (*RestoreOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.Reader == nil {
+		return nil, errors.New("reader cannot be empty")
+	}
+
+	scanner := bufio.NewScanner(input.Reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	output := &RestoreOutput{}
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return output, fmt.Errorf("line %d isn't a backed up key: %w", line, err)
+		}
+
+		if err := r.writeKey(ctx, &rec); err != nil {
+			return output, fmt.Errorf("line %d: %w", line, err)
+		}
+		output.Keys++
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return output, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	return output, nil
+}
+
+// writeKey replaces the key with the backed up one in a single transaction
+func (r *redisRepository) writeKey(ctx context.Context, rec *record) error {
+	if rec.Key == "" {
+		return errors.New("key is missing its name")
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, rec.Key)
+
+		switch rec.Type {
+		case keyTypeString:
+			if rec.String == nil {
+				return fmt.Errorf("string key %s has no value", rec.Key)
+			}
+			pipe.Set(ctx, rec.Key, *rec.String, 0)
+		case keyTypeHash:
+			pipe.HSet(ctx, rec.Key, rec.Hash)
+		case keyTypeList:
+			values := make([]interface{}, len(rec.List))
+			for i, value := range rec.List {
+				values[i] = value
+			}
+			pipe.RPush(ctx, rec.Key, values...)
+		case keyTypeSet:
+			members := make([]interface{}, len(rec.Members))
+			for i, m := range rec.Members {
+				members[i] = m.Member
+			}
+			pipe.SAdd(ctx, rec.Key, members...)
+		case keyTypeZSet:
+			members := make([]redis.Z, len(rec.Members))
+			for i, m := range rec.Members {
+				members[i] = redis.Z{Score: m.Score, Member: m.Member}
+			}
+			pipe.ZAdd(ctx, rec.Key, members...)
+		case keyTypeStream:
+			for _, e := range rec.Entries {
+				pipe.XAdd(ctx, &redis.XAddArgs{Stream: rec.Key, ID: e.ID, Values: e.Values})
+			}
+		default:
+			return fmt.Errorf("key %s has unknown type %q", rec.Key, rec.Type)
+		}
+
+		if rec.TTLMillis > 0 {
+			pipe.PExpire(ctx, rec.Key, time.Duration(rec.TTLMillis)*time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore key %s: %w", rec.Key, err)
+	}
+
+	return nil
+}
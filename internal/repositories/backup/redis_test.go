@@ -0,0 +1,150 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	repo   Repository
+	ctx    context.Context
+
+	// restored is a second database backups are restored into
+	restoredMr     *miniredis.Miniredis
+	restoredClient *redis.Client
+	restoredRepo   Repository
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.mr, s.client, s.repo = s.newDatabase()
+	s.restoredMr, s.restoredClient, s.restoredRepo = s.newDatabase()
+}
+
+func (s *RedisRepositoryTestSuite) newDatabase() (*miniredis.Miniredis, *redis.Client, Repository) {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	repo, err := NewRedis(&Config{
+		RedisClient: client,
+	})
+	s.Require().NoError(err)
+
+	return mr, client, repo
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+	s.restoredClient.Close()
+	s.restoredMr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestBackupAndRestore() {
+	s.Require().NoError(s.client.Set(s.ctx, "game:1", `{"id":"1"}`, 0).Err())
+	s.Require().NoError(s.client.Set(s.ctx, "cooldown:alice", "1", time.Hour).Err())
+	s.Require().NoError(s.client.HSet(s.ctx, "guild_presets:guild-1", "big", `{"name":"Big"}`, "small", `{"name":"Small"}`).Err())
+	s.Require().NoError(s.client.RPush(s.ctx, "history", "first", "second", "third").Err())
+	s.Require().NoError(s.client.SAdd(s.ctx, "game_participants:1", "alice", "bob").Err())
+	s.Require().NoError(s.client.ZAdd(s.ctx, "channel_active:channel-1", redis.Z{Score: 1.5, Member: "1"}, redis.Z{Score: 3, Member: "2"}).Err())
+	s.Require().NoError(s.client.XAdd(s.ctx, &redis.XAddArgs{Stream: "events", ID: "1-1", Values: map[string]interface{}{"type": "roll"}}).Err())
+	s.Require().NoError(s.client.XAdd(s.ctx, &redis.XAddArgs{Stream: "events", ID: "2-1", Values: map[string]interface{}{"type": "drink"}}).Err())
+
+	var buf bytes.Buffer
+	backupOutput, err := s.repo.Backup(s.ctx, &BackupInput{Writer: &buf})
+	s.Require().NoError(err)
+	s.Equal(7, backupOutput.Keys)
+	s.Len(strings.Split(strings.TrimSpace(buf.String()), "\n"), 7)
+
+	restoreOutput, err := s.restoredRepo.Restore(s.ctx, &RestoreInput{Reader: &buf})
+	s.Require().NoError(err)
+	s.Equal(7, restoreOutput.Keys)
+
+	s.Equal(`{"id":"1"}`, s.restoredClient.Get(s.ctx, "game:1").Val())
+	s.Equal(time.Duration(0), s.restoredMr.TTL("game:1"))
+	s.Equal("1", s.restoredClient.Get(s.ctx, "cooldown:alice").Val())
+	s.Equal(time.Hour, s.restoredMr.TTL("cooldown:alice"))
+	s.Equal(map[string]string{"big": `{"name":"Big"}`, "small": `{"name":"Small"}`}, s.restoredClient.HGetAll(s.ctx, "guild_presets:guild-1").Val())
+	s.Equal([]string{"first", "second", "third"}, s.restoredClient.LRange(s.ctx, "history", 0, -1).Val())
+	s.ElementsMatch([]string{"alice", "bob"}, s.restoredClient.SMembers(s.ctx, "game_participants:1").Val())
+	s.Equal([]redis.Z{{Score: 1.5, Member: "1"}, {Score: 3, Member: "2"}}, s.restoredClient.ZRangeWithScores(s.ctx, "channel_active:channel-1", 0, -1).Val())
+
+	messages := s.restoredClient.XRange(s.ctx, "events", "-", "+").Val()
+	s.Require().Len(messages, 2)
+	s.Equal("1-1", messages[0].ID)
+	s.Equal(map[string]interface{}{"type": "roll"}, messages[0].Values)
+	s.Equal("2-1", messages[1].ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestRestoreReplacesKeys() {
+	s.Require().NoError(s.client.SAdd(s.ctx, "game_participants:1", "alice").Err())
+
+	var buf bytes.Buffer
+	_, err := s.repo.Backup(s.ctx, &BackupInput{Writer: &buf})
+	s.Require().NoError(err)
+
+	// The restored set replaces the one saved since, rather than being merged into it
+	s.Require().NoError(s.restoredClient.SAdd(s.ctx, "game_participants:1", "bob", "carol").Err())
+	s.Require().NoError(s.restoredClient.Set(s.ctx, "game:2", "kept", 0).Err())
+
+	_, err = s.restoredRepo.Restore(s.ctx, &RestoreInput{Reader: &buf})
+	s.Require().NoError(err)
+
+	s.Equal([]string{"alice"}, s.restoredClient.SMembers(s.ctx, "game_participants:1").Val())
+	s.Equal("kept", s.restoredClient.Get(s.ctx, "game:2").Val())
+}
+
+func (s *RedisRepositoryTestSuite) TestBackupEmptyDatabase() {
+	var buf bytes.Buffer
+	output, err := s.repo.Backup(s.ctx, &BackupInput{Writer: &buf})
+	s.Require().NoError(err)
+	s.Equal(0, output.Keys)
+	s.Empty(buf.String())
+}
+
+func (s *RedisRepositoryTestSuite) TestRestoreInvalidBackup() {
+	input := strings.Join([]string{
+		`{"key":"game:1","type":"string","string":"one"}`,
+		`not a backup`,
+	}, "\n")
+
+	output, err := s.restoredRepo.Restore(s.ctx, &RestoreInput{Reader: strings.NewReader(input)})
+	s.ErrorContains(err, "line 2")
+	s.Equal(1, output.Keys)
+
+	_, err = s.restoredRepo.Restore(s.ctx, &RestoreInput{Reader: strings.NewReader(`{"key":"game:1","type":"module"}`)})
+	s.ErrorContains(err, `unknown type "module"`)
+
+	_, err = s.restoredRepo.Restore(s.ctx, &RestoreInput{Reader: strings.NewReader(`{"key":"game:1","type":"string"}`)})
+	s.ErrorContains(err, "has no value")
+	s.Equal("one", s.restoredClient.Get(s.ctx, "game:1").Val())
+}
+
+func (s *RedisRepositoryTestSuite) TestInvalidInput() {
+	_, err := s.repo.Backup(s.ctx, nil)
+	s.Error(err)
+
+	_, err = s.repo.Backup(s.ctx, &BackupInput{})
+	s.Error(err)
+
+	_, err = s.repo.Restore(s.ctx, &RestoreInput{})
+	s.Error(err)
+}
@@ -0,0 +1,67 @@
+package backup
+
+import (
+	"io"
+)
+
+// Types of Redis keys a backup holds
+const (
+	keyTypeString = "string"
+	keyTypeHash   = "hash"
+	keyTypeList   = "list"
+	keyTypeSet    = "set"
+	keyTypeZSet   = "zset"
+	keyTypeStream = "stream"
+)
+
+// BackupInput contains parameters for backing up the saved data
+type BackupInput struct {
+	// Writer the backup is written to
+	Writer io.Writer
+}
+
+// BackupOutput contains the result of a backup
+type BackupOutput struct {
+	// Keys is how many keys were backed up
+	Keys int
+}
+
+// RestoreInput contains parameters for restoring a backup
+type RestoreInput struct {
+	// Reader the backup is read from
+	Reader io.Reader
+}
+
+// RestoreOutput contains the result of a restore
+type RestoreOutput struct {
+	// Keys is how many keys were restored
+	Keys int
+}
+
+// record is a key as it's written to a backup, one JSON object per line
+type record struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+
+	// TTLMillis is how long the key had left to live, 0 when it doesn't expire
+	TTLMillis int64 `json:"ttl_ms,omitempty"`
+
+	// One of these holds the key's value, depending on its type
+	String  *string           `json:"string,omitempty"`
+	Hash    map[string]string `json:"hash,omitempty"`
+	List    []string          `json:"list,omitempty"`
+	Members []*member         `json:"members,omitempty"`
+	Entries []*entry          `json:"entries,omitempty"`
+}
+
+// member is a member of a set or sorted set, with its score in a sorted set
+type member struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score,omitempty"`
+}
+
+// entry is an entry of a stream
+type entry struct {
+	ID     string                 `json:"id"`
+	Values map[string]interface{} `json:"values"`
+}
@@ -0,0 +1,13 @@
+package bot_config
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/bot_config Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for reading the settings that can be changed while the bot is running
+type Repository interface {
+	// GetBotConfig reads the bot's runtime settings, an empty config when none have been set
+	GetBotConfig(ctx context.Context, input *GetBotConfigInput) (*GetBotConfigOutput, error)
+}
@@ -0,0 +1,111 @@
+package bot_config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// botConfigKey is the hash holding the bot's runtime settings, one field per setting
+	botConfigKey = "bot_config"
+)
+
+// Fields of the bot config hash
+const (
+	FieldDiceSides          = "dice_sides"
+	FieldMaxPlayers         = "max_players"
+	FieldCriticalHitValues  = "critical_hit_values"
+	FieldCriticalFailValues = "critical_fail_values"
+	FieldDrinkCap           = "drink_cap"
+	FieldLeaderHandicap     = "leader_handicap"
+	FieldContentRating      = "content_rating"
+)
+
+// ErrInvalidField is returned when a field of the bot config hash can't be read
+var ErrInvalidField = errors.New("invalid bot config field")
+
+// Config holds configuration for the Redis bot config repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed bot config repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// GetBotConfig reads the bot's runtime settings, an empty config when none have been set.
+// Unknown fields are ignored, a field that can't be read fails the whole config so it isn't half applied.
+func (r *redisRepository) GetBotConfig(ctx context.Context, input *GetBotConfigInput) (*GetBotConfigOutput, error) {
+	fields, err := r.client.HGetAll(ctx, botConfigKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	config := &models.BotConfig{}
+	for field, value := range fields {
+		if err := setField(config, field, value); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidField, field, err)
+		}
+	}
+
+	return &GetBotConfigOutput{
+		Config: config,
+	}, nil
+}
+
+// setField reads a hash field into the config
+func setField(config *models.BotConfig, field, value string) error {
+	var err error
+	switch field {
+	case FieldDiceSides:
+		config.DiceSides, err = strconv.Atoi(value)
+	case FieldMaxPlayers:
+		config.MaxPlayers, err = strconv.Atoi(value)
+	case FieldCriticalHitValues:
+		config.CriticalHitValues, err = models.ParseRollValues(value)
+	case FieldCriticalFailValues:
+		config.CriticalFailValues, err = models.ParseRollValues(value)
+	case FieldDrinkCap:
+		var drinkCap int
+		drinkCap, err = strconv.Atoi(value)
+		config.DrinkCap = &drinkCap
+	case FieldLeaderHandicap:
+		var handicap bool
+		handicap, err = strconv.ParseBool(value)
+		config.LeaderHandicap = &handicap
+	case FieldContentRating:
+		config.ContentRating = models.ContentRating(value)
+		if !config.ContentRating.IsValid() {
+			err = fmt.Errorf("%q is not a content rating", value)
+		}
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package bot_config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	repo   Repository
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetBotConfig() {
+	ctx := context.Background()
+
+	// Nothing set yet
+	output, err := s.repo.GetBotConfig(ctx, &GetBotConfigInput{})
+	s.Require().NoError(err)
+	s.Equal(&models.BotConfig{}, output.Config)
+
+	s.mr.HSet(botConfigKey,
+		FieldDiceSides, "20",
+		FieldMaxPlayers, "8",
+		FieldCriticalHitValues, "19, 20",
+		FieldCriticalFailValues, "1",
+		FieldDrinkCap, "0",
+		FieldLeaderHandicap, "true",
+		FieldContentRating, "pg-13",
+		"not_a_setting", "ignored",
+	)
+
+	drinkCap := 0
+	handicap := true
+	output, err = s.repo.GetBotConfig(ctx, &GetBotConfigInput{})
+	s.Require().NoError(err)
+	s.Equal(&models.BotConfig{
+		DiceSides:          20,
+		MaxPlayers:         8,
+		CriticalHitValues:  []int{19, 20},
+		CriticalFailValues: []int{1},
+		DrinkCap:           &drinkCap,
+		LeaderHandicap:     &handicap,
+		ContentRating:      models.ContentRatingPG13,
+	}, output.Config)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetBotConfig_InvalidField() {
+	ctx := context.Background()
+
+	for field, value := range map[string]string{
+		FieldDiceSides:         "twenty",
+		FieldCriticalHitValues: "0",
+		FieldLeaderHandicap:    "sometimes",
+		FieldContentRating:     "nc-17",
+	} {
+		s.mr.Del(botConfigKey)
+		s.mr.HSet(botConfigKey, field, value)
+
+		_, err := s.repo.GetBotConfig(ctx, &GetBotConfigInput{})
+		s.ErrorIs(err, ErrInvalidField, field)
+	}
+}
@@ -0,0 +1,12 @@
+package bot_config
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// GetBotConfigInput contains parameters for reading the bot's runtime settings
+type GetBotConfigInput struct {
+}
+
+// GetBotConfigOutput contains the bot's runtime settings
+type GetBotConfigOutput struct {
+	Config *models.BotConfig
+}
@@ -0,0 +1,46 @@
+package drink_ledger
+
+// AddCreditInput contains parameters for banking a drink credit
+type AddCreditInput struct {
+	// SessionID is the session the credit is banked in, credits don't carry over to the next session
+	SessionID string
+
+	// PlayerID is the player banking the credit
+	PlayerID string
+}
+
+// AddCreditOutput contains the result of banking a drink credit
+type AddCreditOutput struct {
+	// Balance is the player's credits after banking this one
+	Balance int
+}
+
+// ApplyCreditInput contains parameters for spending a drink credit
+type ApplyCreditInput struct {
+	// SessionID is the session the credit was banked in
+	SessionID string
+
+	// PlayerID is the player spending the credit
+	PlayerID string
+}
+
+// ApplyCreditOutput contains the result of spending a drink credit
+type ApplyCreditOutput struct {
+	// Applied is true when the player had a credit to spend
+	Applied bool
+
+	// Balance is the player's credits left
+	Balance int
+}
+
+// GetCreditsInput contains parameters for retrieving a session's drink credits
+type GetCreditsInput struct {
+	// SessionID is the session to get the credits for
+	SessionID string
+}
+
+// GetCreditsOutput contains the drink credits banked in a session
+type GetCreditsOutput struct {
+	// Balances maps player IDs to their credits, players without any are left out
+	Balances map[string]int
+}
@@ -20,6 +20,9 @@ type Repository interface {
 	// MarkDrinkPaid marks a drink as paid
 	MarkDrinkPaid(ctx context.Context, input *MarkDrinkPaidInput) error
 	
+	// ForgiveDrink writes off a drink so it's no longer owed
+	ForgiveDrink(ctx context.Context, input *ForgiveDrinkInput) error
+	
 	// CreateDrinkRecord creates a new drink record with a generated UUID
 	CreateDrinkRecord(ctx context.Context, input *CreateDrinkRecordInput) (*CreateDrinkRecordOutput, error)
 	
@@ -29,12 +32,51 @@ type Repository interface {
 	// DeleteDrinkRecords deletes all drink records for a game
 	DeleteDrinkRecords(ctx context.Context, input *DeleteDrinkRecordsInput) error
 	
+	// DeleteDrinkRecord deletes a single drink record and removes it from every index
+	DeleteDrinkRecord(ctx context.Context, input *DeleteDrinkRecordInput) error
+	
+	// TransferDrink hands a drink the player still owes to another player, adding them to the drink's transfer chain
+	TransferDrink(ctx context.Context, input *TransferDrinkInput) (*TransferDrinkOutput, error)
+	
+	// CreateDrinkTransfer saves a pending drink transfer until it expires
+	CreateDrinkTransfer(ctx context.Context, input *CreateDrinkTransferInput) error
+	
+	// GetDrinkTransfer retrieves a pending drink transfer
+	GetDrinkTransfer(ctx context.Context, input *GetDrinkTransferInput) (*GetDrinkTransferOutput, error)
+	
+	// DeleteDrinkTransfer removes a pending drink transfer, failing if it was already removed
+	DeleteDrinkTransfer(ctx context.Context, input *DeleteDrinkTransferInput) error
+	
+	// AddCredit banks a drink credit for a player, to offset the next drink they're given
+	AddCredit(ctx context.Context, input *AddCreditInput) (*AddCreditOutput, error)
+	
+	// ApplyCredit atomically spends one of a player's drink credits, if they have one
+	ApplyCredit(ctx context.Context, input *ApplyCreditInput) (*ApplyCreditOutput, error)
+	
+	// GetCredits retrieves every player's drink credits in a session
+	GetCredits(ctx context.Context, input *GetCreditsInput) (*GetCreditsOutput, error)
+	
+	// ReassignPlayerRecords rewrites a player's drink records, stats and drink credits under another player
+	ReassignPlayerRecords(ctx context.Context, input *ReassignPlayerRecordsInput) (*ReassignPlayerRecordsOutput, error)
+	
 	// CreateSession creates a new drinking session
 	CreateSession(ctx context.Context, input *CreateSessionInput) (*CreateSessionOutput, error)
 	
 	// GetCurrentSession retrieves the current active session for a channel
 	GetCurrentSession(ctx context.Context, input *GetCurrentSessionInput) (*GetCurrentSessionOutput, error)
+
+	// GetCurrentSessions retrieves every guild's current session
+	GetCurrentSessions(ctx context.Context, input *GetCurrentSessionsInput) (*GetCurrentSessionsOutput, error)
+	
+	// UpdateSession saves changes to an existing session
+	UpdateSession(ctx context.Context, input *UpdateSessionInput) error
 	
 	// GetDrinkRecordsForSession retrieves all drink records for a session
 	GetDrinkRecordsForSession(ctx context.Context, input *GetDrinkRecordsForSessionInput) (*GetDrinkRecordsForSessionOutput, error)
+	
+	// GetSession retrieves a session by ID, whether or not it's still active
+	GetSession(ctx context.Context, input *GetSessionInput) (*GetSessionOutput, error)
+	
+	// ListDrinkRecords retrieves every drink record in the ledger, for maintenance checks
+	ListDrinkRecords(ctx context.Context, input *ListDrinkRecordsInput) (*ListDrinkRecordsOutput, error)
 }
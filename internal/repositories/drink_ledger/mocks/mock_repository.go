@@ -39,6 +39,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddCredit mocks base method.
+func (m *MockRepository) AddCredit(arg0 context.Context, arg1 *drink_ledger.AddCreditInput) (*drink_ledger.AddCreditOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCredit", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.AddCreditOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCredit indicates an expected call of AddCredit.
+func (mr *MockRepositoryMockRecorder) AddCredit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCredit", reflect.TypeOf((*MockRepository)(nil).AddCredit), arg0, arg1)
+}
+
 // AddDrinkRecord mocks base method.
 func (m *MockRepository) AddDrinkRecord(arg0 context.Context, arg1 *drink_ledger.AddDrinkRecordInput) error {
 	m.ctrl.T.Helper()
@@ -53,6 +68,21 @@ func (mr *MockRepositoryMockRecorder) AddDrinkRecord(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDrinkRecord", reflect.TypeOf((*MockRepository)(nil).AddDrinkRecord), arg0, arg1)
 }
 
+// ApplyCredit mocks base method.
+func (m *MockRepository) ApplyCredit(arg0 context.Context, arg1 *drink_ledger.ApplyCreditInput) (*drink_ledger.ApplyCreditOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCredit", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.ApplyCreditOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCredit indicates an expected call of ApplyCredit.
+func (mr *MockRepositoryMockRecorder) ApplyCredit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCredit", reflect.TypeOf((*MockRepository)(nil).ApplyCredit), arg0, arg1)
+}
+
 // ArchiveDrinkRecords mocks base method.
 func (m *MockRepository) ArchiveDrinkRecords(arg0 context.Context, arg1 *drink_ledger.ArchiveDrinkRecordsInput) error {
 	m.ctrl.T.Helper()
@@ -82,6 +112,20 @@ func (mr *MockRepositoryMockRecorder) CreateDrinkRecord(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrinkRecord", reflect.TypeOf((*MockRepository)(nil).CreateDrinkRecord), arg0, arg1)
 }
 
+// CreateDrinkTransfer mocks base method.
+func (m *MockRepository) CreateDrinkTransfer(arg0 context.Context, arg1 *drink_ledger.CreateDrinkTransferInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDrinkTransfer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDrinkTransfer indicates an expected call of CreateDrinkTransfer.
+func (mr *MockRepositoryMockRecorder) CreateDrinkTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDrinkTransfer", reflect.TypeOf((*MockRepository)(nil).CreateDrinkTransfer), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockRepository) CreateSession(arg0 context.Context, arg1 *drink_ledger.CreateSessionInput) (*drink_ledger.CreateSessionOutput, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +141,20 @@ func (mr *MockRepositoryMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRepository)(nil).CreateSession), arg0, arg1)
 }
 
+// DeleteDrinkRecord mocks base method.
+func (m *MockRepository) DeleteDrinkRecord(arg0 context.Context, arg1 *drink_ledger.DeleteDrinkRecordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDrinkRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDrinkRecord indicates an expected call of DeleteDrinkRecord.
+func (mr *MockRepositoryMockRecorder) DeleteDrinkRecord(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrinkRecord", reflect.TypeOf((*MockRepository)(nil).DeleteDrinkRecord), arg0, arg1)
+}
+
 // DeleteDrinkRecords mocks base method.
 func (m *MockRepository) DeleteDrinkRecords(arg0 context.Context, arg1 *drink_ledger.DeleteDrinkRecordsInput) error {
 	m.ctrl.T.Helper()
@@ -111,6 +169,49 @@ func (mr *MockRepositoryMockRecorder) DeleteDrinkRecords(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrinkRecords", reflect.TypeOf((*MockRepository)(nil).DeleteDrinkRecords), arg0, arg1)
 }
 
+// DeleteDrinkTransfer mocks base method.
+func (m *MockRepository) DeleteDrinkTransfer(arg0 context.Context, arg1 *drink_ledger.DeleteDrinkTransferInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDrinkTransfer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDrinkTransfer indicates an expected call of DeleteDrinkTransfer.
+func (mr *MockRepositoryMockRecorder) DeleteDrinkTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDrinkTransfer", reflect.TypeOf((*MockRepository)(nil).DeleteDrinkTransfer), arg0, arg1)
+}
+
+// ForgiveDrink mocks base method.
+func (m *MockRepository) ForgiveDrink(arg0 context.Context, arg1 *drink_ledger.ForgiveDrinkInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgiveDrink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgiveDrink indicates an expected call of ForgiveDrink.
+func (mr *MockRepositoryMockRecorder) ForgiveDrink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgiveDrink", reflect.TypeOf((*MockRepository)(nil).ForgiveDrink), arg0, arg1)
+}
+
+// GetCredits mocks base method.
+func (m *MockRepository) GetCredits(arg0 context.Context, arg1 *drink_ledger.GetCreditsInput) (*drink_ledger.GetCreditsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredits", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.GetCreditsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredits indicates an expected call of GetCredits.
+func (mr *MockRepositoryMockRecorder) GetCredits(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredits", reflect.TypeOf((*MockRepository)(nil).GetCredits), arg0, arg1)
+}
+
 // GetCurrentSession mocks base method.
 func (m *MockRepository) GetCurrentSession(arg0 context.Context, arg1 *drink_ledger.GetCurrentSessionInput) (*drink_ledger.GetCurrentSessionOutput, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +227,21 @@ func (mr *MockRepositoryMockRecorder) GetCurrentSession(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSession", reflect.TypeOf((*MockRepository)(nil).GetCurrentSession), arg0, arg1)
 }
 
+// GetCurrentSessions mocks base method.
+func (m *MockRepository) GetCurrentSessions(arg0 context.Context, arg1 *drink_ledger.GetCurrentSessionsInput) (*drink_ledger.GetCurrentSessionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSessions", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.GetCurrentSessionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSessions indicates an expected call of GetCurrentSessions.
+func (mr *MockRepositoryMockRecorder) GetCurrentSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSessions", reflect.TypeOf((*MockRepository)(nil).GetCurrentSessions), arg0, arg1)
+}
+
 // GetDrinkRecordsForGame mocks base method.
 func (m *MockRepository) GetDrinkRecordsForGame(arg0 context.Context, arg1 *drink_ledger.GetDrinkRecordsForGameInput) (*drink_ledger.GetDrinkRecordsForGameOutput, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +287,51 @@ func (mr *MockRepositoryMockRecorder) GetDrinkRecordsForSession(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrinkRecordsForSession", reflect.TypeOf((*MockRepository)(nil).GetDrinkRecordsForSession), arg0, arg1)
 }
 
+// GetDrinkTransfer mocks base method.
+func (m *MockRepository) GetDrinkTransfer(arg0 context.Context, arg1 *drink_ledger.GetDrinkTransferInput) (*drink_ledger.GetDrinkTransferOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrinkTransfer", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.GetDrinkTransferOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrinkTransfer indicates an expected call of GetDrinkTransfer.
+func (mr *MockRepositoryMockRecorder) GetDrinkTransfer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrinkTransfer", reflect.TypeOf((*MockRepository)(nil).GetDrinkTransfer), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockRepository) GetSession(arg0 context.Context, arg1 *drink_ledger.GetSessionInput) (*drink_ledger.GetSessionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.GetSessionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockRepositoryMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockRepository)(nil).GetSession), arg0, arg1)
+}
+
+// ListDrinkRecords mocks base method.
+func (m *MockRepository) ListDrinkRecords(arg0 context.Context, arg1 *drink_ledger.ListDrinkRecordsInput) (*drink_ledger.ListDrinkRecordsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDrinkRecords", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.ListDrinkRecordsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDrinkRecords indicates an expected call of ListDrinkRecords.
+func (mr *MockRepositoryMockRecorder) ListDrinkRecords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDrinkRecords", reflect.TypeOf((*MockRepository)(nil).ListDrinkRecords), arg0, arg1)
+}
+
 // MarkDrinkPaid mocks base method.
 func (m *MockRepository) MarkDrinkPaid(arg0 context.Context, arg1 *drink_ledger.MarkDrinkPaidInput) error {
 	m.ctrl.T.Helper()
@@ -184,3 +345,47 @@ func (mr *MockRepositoryMockRecorder) MarkDrinkPaid(arg0, arg1 any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDrinkPaid", reflect.TypeOf((*MockRepository)(nil).MarkDrinkPaid), arg0, arg1)
 }
+
+// ReassignPlayerRecords mocks base method.
+func (m *MockRepository) ReassignPlayerRecords(arg0 context.Context, arg1 *drink_ledger.ReassignPlayerRecordsInput) (*drink_ledger.ReassignPlayerRecordsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignPlayerRecords", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.ReassignPlayerRecordsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignPlayerRecords indicates an expected call of ReassignPlayerRecords.
+func (mr *MockRepositoryMockRecorder) ReassignPlayerRecords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignPlayerRecords", reflect.TypeOf((*MockRepository)(nil).ReassignPlayerRecords), arg0, arg1)
+}
+
+// TransferDrink mocks base method.
+func (m *MockRepository) TransferDrink(arg0 context.Context, arg1 *drink_ledger.TransferDrinkInput) (*drink_ledger.TransferDrinkOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferDrink", arg0, arg1)
+	ret0, _ := ret[0].(*drink_ledger.TransferDrinkOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferDrink indicates an expected call of TransferDrink.
+func (mr *MockRepositoryMockRecorder) TransferDrink(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferDrink", reflect.TypeOf((*MockRepository)(nil).TransferDrink), arg0, arg1)
+}
+
+// UpdateSession mocks base method.
+func (m *MockRepository) UpdateSession(arg0 context.Context, arg1 *drink_ledger.UpdateSessionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSession indicates an expected call of UpdateSession.
+func (mr *MockRepositoryMockRecorder) UpdateSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSession", reflect.TypeOf((*MockRepository)(nil).UpdateSession), arg0, arg1)
+}
@@ -5,11 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -22,20 +27,37 @@ const (
 	sessionKeyPrefix      = "session:"
 	guildSessionPrefix    = "guild_session:"
 	sessionDrinksPrefix   = "session_drinks:"
+
+	// Pending drink transfers, and the transfer each drink is claimed by
+	drinkTransferKeyPrefix   = "drink_transfer:"
+	drinkTransferDrinkPrefix = "drink_transfer_drink:"
 )
 
 // ErrDrinkNotFound is returned when a drink record is not found
 var ErrDrinkNotFound = errors.New("drink record not found")
 
+// ErrSessionNotFound is returned when a session is not found
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionNotInGuild is returned when a session is asked for by a guild it doesn't belong to
+var ErrSessionNotInGuild = errors.New("session belongs to another guild")
+
+// ErrSessionReplaced is returned when the session to be replaced isn't the guild's current session anymore
+var ErrSessionReplaced = errors.New("session was already replaced")
+
 // Config holds configuration for the Redis drink ledger repository
 type Config struct {
 	// Redis client
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
+
+	// ReadClient is an optional read replica for the reads callers allow to lag behind
+	ReadClient redis.UniversalClient
 }
 
 // redisRepository implements the Repository interface using Redis
 type redisRepository struct {
-	client *redis.Client
+	client  redis.UniversalClient
+	replica redis.UniversalClient
 }
 
 // NewRedis creates a new Redis-backed drink ledger repository
@@ -54,8 +76,15 @@ func NewRedis(cfg *Config) (*redisRepository, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	if cfg.ReadClient != nil {
+		if err := cfg.ReadClient.Ping(context.Background()).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis replica: %w", err)
+		}
+	}
+
 	return &redisRepository{
-		client: cfg.RedisClient,
+		client:  cfg.RedisClient,
+		replica: cfg.ReadClient,
 	}, nil
 }
 
@@ -82,43 +111,47 @@ func (r *redisRepository) AddDrinkRecord(ctx context.Context, input *AddDrinkRec
 		return fmt.Errorf("failed to marshal drink record: %w", err)
 	}
 
-	// Create a Redis transaction
-	pipe := r.client.Pipeline()
-
-	// Store the drink record
-	drinkKey := fmt.Sprintf("%s%s", drinkKeyPrefix, record.ID)
-	pipe.Set(ctx, drinkKey, recordJSON, 0) // No expiration for now
-
-	// Add to the game's drink records sorted set
-	gameKey := fmt.Sprintf("%s%s", gameDrinksKeyPrefix, record.GameID)
-	pipe.ZAdd(ctx, gameKey, redis.Z{
-		Score:  float64(record.Timestamp.Unix()),
-		Member: record.ID,
-	})
-
-	// Add to the "from player" drink records sorted set
-	fromPlayerKey := fmt.Sprintf("%s%s:from", playerDrinksKeyPrefix, record.FromPlayerID)
-	pipe.ZAdd(ctx, fromPlayerKey, redis.Z{
-		Score:  float64(record.Timestamp.Unix()),
-		Member: record.ID,
-	})
+	// Store the record and its indexes together, as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		// Store the drink record
+		drinkKey := fmt.Sprintf("%s%s", drinkKeyPrefix, record.ID)
+		pipe.Set(ctx, drinkKey, recordJSON, 0) // No expiration for now
+
+		// Add to the game's drink records sorted set
+		gameKey := fmt.Sprintf("%s%s", gameDrinksKeyPrefix, record.GameID)
+		pipe.ZAdd(ctx, gameKey, redis.Z{
+			Score:  float64(record.Timestamp.Unix()),
+			Member: record.ID,
+		})
+
+		// Add to the "from player" drink records sorted set
+		fromPlayerKey := fmt.Sprintf("%s%s:from", playerDrinksKeyPrefix, record.FromPlayerID)
+		pipe.ZAdd(ctx, fromPlayerKey, redis.Z{
+			Score:  float64(record.Timestamp.Unix()),
+			Member: record.ID,
+		})
+
+		// Add to the "to player" drink records sorted set
+		toPlayerKey := fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, record.ToPlayerID)
+		pipe.ZAdd(ctx, toPlayerKey, redis.Z{
+			Score:  float64(record.Timestamp.Unix()),
+			Member: record.ID,
+		})
+
+		// Update player stats
+		fromPlayerStatsKey := fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.FromPlayerID)
+		pipe.HIncrBy(ctx, fromPlayerStatsKey, "assigned", 1)
+
+		toPlayerStatsKey := fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.ToPlayerID)
+		pipe.HIncrBy(ctx, toPlayerStatsKey, "received", 1)
+
+		// Add to the session's drink set
+		if record.SessionID != "" {
+			pipe.SAdd(ctx, sessionDrinksPrefix+record.SessionID, record.ID)
+		}
 
-	// Add to the "to player" drink records sorted set
-	toPlayerKey := fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, record.ToPlayerID)
-	pipe.ZAdd(ctx, toPlayerKey, redis.Z{
-		Score:  float64(record.Timestamp.Unix()),
-		Member: record.ID,
+		return nil
 	})
-
-	// Update player stats
-	fromPlayerStatsKey := fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.FromPlayerID)
-	pipe.HIncrBy(ctx, fromPlayerStatsKey, "assigned", 1)
-
-	toPlayerStatsKey := fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.ToPlayerID)
-	pipe.HIncrBy(ctx, toPlayerStatsKey, "received", 1)
-
-	// Execute the transaction
-	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to add drink record: %w", err)
 	}
@@ -132,9 +165,24 @@ func (r *redisRepository) GetDrinkRecordsForGame(ctx context.Context, input *Get
 		return nil, errors.New("input and game ID cannot be empty")
 	}
 
+	var output *GetDrinkRecordsForGameOutput
+	err := replica.Read(ctx, r.client, r.replica, func(client redis.UniversalClient) error {
+		var err error
+		output, err = drinkRecordsForGame(ctx, client, input.GameID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// drinkRecordsForGame reads a game's drink records from the primary or a replica
+func drinkRecordsForGame(ctx context.Context, client redis.UniversalClient, gameID string) (*GetDrinkRecordsForGameOutput, error) {
 	// Get all drink IDs for the game
-	gameKey := fmt.Sprintf("%s%s", gameDrinksKeyPrefix, input.GameID)
-	drinkIDs, err := r.client.ZRange(ctx, gameKey, 0, -1).Result()
+	gameKey := fmt.Sprintf("%s%s", gameDrinksKeyPrefix, gameID)
+	drinkIDs, err := client.ZRange(ctx, gameKey, 0, -1).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get drink IDs for game: %w", err)
 	}
@@ -147,7 +195,7 @@ func (r *redisRepository) GetDrinkRecordsForGame(ctx context.Context, input *Get
 	}
 
 	// Get all drink records in parallel using a pipeline
-	pipe := r.client.Pipeline()
+	pipe := client.Pipeline()
 	drinkCommands := make(map[string]*redis.StringCmd)
 
 	for _, drinkID := range drinkIDs {
@@ -308,24 +356,26 @@ func (r *redisRepository) CreateDrinkRecord(ctx context.Context, input *CreateDr
 		Timestamp:    input.Timestamp,
 		Paid:         false,
 		SessionID:    sessionID,
+		GuildID:      input.GuildID,
+		Social:       input.Social,
+		OwedLater:    input.OwedLater,
+		CoveredFor:   input.CoveredFor,
+
+		HappyHourMultiplier: input.HappyHourMultiplier,
+		GuestName:           input.GuestName,
+	}
+	if input.PaidWithCredit {
+		record.Paid = true
+		record.PaidTimestamp = input.Timestamp
+		record.PaidWithCredit = true
 	}
 
-	// Save the drink record
+	// Save the drink record, along with its place in the session
 	err := r.AddDrinkRecord(ctx, &AddDrinkRecordInput{Record: record})
 	if err != nil {
 		return nil, fmt.Errorf("failed to save drink record: %w", err)
 	}
 
-	// If we have a session ID, add this drink to the session's drink set
-	if sessionID != "" {
-		sessionDrinksKey := sessionDrinksPrefix + sessionID
-		err = r.client.SAdd(ctx, sessionDrinksKey, drinkID).Err()
-		if err != nil {
-			// Log the error but don't fail the operation
-			fmt.Printf("failed to add drink to session: %v\n", err)
-		}
-	}
-
 	return &CreateDrinkRecordOutput{
 		Record: record,
 	}, nil
@@ -355,7 +405,10 @@ func (r *redisRepository) MarkDrinkPaid(ctx context.Context, input *MarkDrinkPai
 
 	// Update the record
 	record.Paid = true
-	record.PaidTimestamp = time.Now()
+	record.PaidTimestamp = input.PaidAt
+	if record.PaidTimestamp.IsZero() {
+		record.PaidTimestamp = time.Now()
+	}
 
 	// Marshal the updated record
 	updatedRecordJSON, err := json.Marshal(record)
@@ -371,6 +424,47 @@ func (r *redisRepository) MarkDrinkPaid(ctx context.Context, input *MarkDrinkPai
 	return nil
 }
 
+// ForgiveDrink writes off a drink so it's no longer owed
+func (r *redisRepository) ForgiveDrink(ctx context.Context, input *ForgiveDrinkInput) error {
+	if input == nil || input.DrinkID == "" {
+		return errors.New("input and drink ID cannot be empty")
+	}
+
+	// Get the drink record
+	drinkKey := fmt.Sprintf("%s%s", drinkKeyPrefix, input.DrinkID)
+	recordJSON, err := r.client.Get(ctx, drinkKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return ErrDrinkNotFound
+		}
+		return fmt.Errorf("failed to get drink record: %w", err)
+	}
+
+	var record models.DrinkLedger
+	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal drink record: %w", err)
+	}
+
+	record.Forgiven = true
+	record.ForgivenBy = input.ForgivenBy
+	record.ForgiveReason = input.Reason
+	record.ForgivenTimestamp = input.ForgivenAt
+	if record.ForgivenTimestamp.IsZero() {
+		record.ForgivenTimestamp = time.Now()
+	}
+
+	updatedRecordJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal updated drink record: %w", err)
+	}
+
+	if err := r.client.Set(ctx, drinkKey, updatedRecordJSON, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save updated drink record: %w", err)
+	}
+
+	return nil
+}
+
 // ArchiveDrinkRecords marks all drink records for a game as archived
 func (r *redisRepository) ArchiveDrinkRecords(ctx context.Context, input *ArchiveDrinkRecordsInput) error {
 	if input == nil || input.GameID == "" {
@@ -460,3 +554,280 @@ func (r *redisRepository) DeleteDrinkRecords(ctx context.Context, input *DeleteD
 
 	return nil
 }
+
+// DeleteDrinkRecord deletes a single drink record and removes it from every index
+func (r *redisRepository) DeleteDrinkRecord(ctx context.Context, input *DeleteDrinkRecordInput) error {
+	if input == nil || input.DrinkID == "" {
+		return errors.New("drink ID is required")
+	}
+
+	drinkKey := fmt.Sprintf("%s%s", drinkKeyPrefix, input.DrinkID)
+	recordJSON, err := r.client.Get(ctx, drinkKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return ErrDrinkNotFound
+		}
+		return fmt.Errorf("failed to get drink record: %w", err)
+	}
+
+	var record models.DrinkLedger
+	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal drink record: %w", err)
+	}
+
+	// Delete as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, drinkKey)
+		pipe.ZRem(ctx, fmt.Sprintf("%s%s", gameDrinksKeyPrefix, record.GameID), record.ID)
+		pipe.ZRem(ctx, fmt.Sprintf("%s%s:from", playerDrinksKeyPrefix, record.FromPlayerID), record.ID)
+		pipe.ZRem(ctx, fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, record.ToPlayerID), record.ID)
+		for _, playerID := range record.TransferredFrom {
+			pipe.ZRem(ctx, fmt.Sprintf("%s%s:transferred", playerDrinksKeyPrefix, playerID), record.ID)
+		}
+		pipe.HIncrBy(ctx, fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.FromPlayerID), "assigned", -1)
+		pipe.HIncrBy(ctx, fmt.Sprintf("%s%s", playerStatsKeyPrefix, record.ToPlayerID), "received", -1)
+		if record.SessionID != "" {
+			pipe.SRem(ctx, sessionDrinksPrefix+record.SessionID, record.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete drink record: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignPlayerRecords rewrites a player's drink records, stats and drink credits under another player, or only
+// those from one guild when a GuildID is given. All writes happen in one transaction so a failed migration leaves
+// both players untouched.
+func (r *redisRepository) ReassignPlayerRecords(ctx context.Context, input *ReassignPlayerRecordsInput) (*ReassignPlayerRecordsOutput, error) {
+	if input == nil || input.FromPlayerID == "" || input.ToPlayerID == "" {
+		return nil, errors.New("from and to player IDs are required")
+	}
+
+	if input.FromPlayerID == input.ToPlayerID {
+		return nil, errors.New("cannot reassign a player's records to themselves")
+	}
+
+	fromKeys := map[string]string{
+		"from":        fmt.Sprintf("%s%s:from", playerDrinksKeyPrefix, input.FromPlayerID),
+		"to":          fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, input.FromPlayerID),
+		"transferred": fmt.Sprintf("%s%s:transferred", playerDrinksKeyPrefix, input.FromPlayerID),
+	}
+
+	// Read the player's drink indexes, keeping the scores so the records stay in order
+	indexes := make(map[string][]redis.Z)
+	for direction, key := range fromKeys {
+		members, err := r.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s drink IDs for player: %w", direction, err)
+		}
+		indexes[direction] = members
+	}
+
+	stats, err := r.client.HGetAll(ctx, playerStatsKeyPrefix+input.FromPlayerID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player stats: %w", err)
+	}
+
+	creditKeys, err := r.creditKeysToMove(ctx, input.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rewrite every record that mentions the player, counting the stats that go with the ones moved
+	records := make(map[string]*models.DrinkLedger)
+	skipped := make(map[string]bool)
+	movedStats := make(map[string]int64)
+	for _, members := range indexes {
+		for _, member := range members {
+			drinkID := member.Member.(string)
+			if _, ok := records[drinkID]; ok || skipped[drinkID] {
+				continue
+			}
+
+			recordJSON, err := r.client.Get(ctx, drinkKeyPrefix+drinkID).Result()
+			if err != nil {
+				if err == redis.Nil {
+					// Drink record was deleted, the index entry is dropped with the rest
+					continue
+				}
+				return nil, fmt.Errorf("failed to get drink record %s: %w", drinkID, err)
+			}
+
+			var record models.DrinkLedger
+			if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal drink record %s: %w", drinkID, err)
+			}
+
+			if input.GuildID != "" && record.GuildID != input.GuildID {
+				skipped[drinkID] = true
+				continue
+			}
+
+			if record.FromPlayerID == input.FromPlayerID {
+				record.FromPlayerID = input.ToPlayerID
+				movedStats["assigned"]++
+			}
+			if record.ToPlayerID == input.FromPlayerID {
+				record.ToPlayerID = input.ToPlayerID
+				movedStats["received"]++
+			}
+			if record.CoveredFor == input.FromPlayerID {
+				record.CoveredFor = input.ToPlayerID
+			}
+			for i, playerID := range record.TransferredFrom {
+				if playerID == input.FromPlayerID {
+					record.TransferredFrom[i] = input.ToPlayerID
+				}
+			}
+			records[drinkID] = &record
+		}
+	}
+
+	pipe := r.client.TxPipeline()
+
+	for drinkID, record := range records {
+		recordJSON, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal drink record %s: %w", drinkID, err)
+		}
+		pipe.Set(ctx, drinkKeyPrefix+drinkID, recordJSON, 0)
+	}
+
+	toStatsKey := playerStatsKeyPrefix + input.ToPlayerID
+	if input.GuildID == "" {
+		for direction, members := range indexes {
+			if len(members) > 0 {
+				pipe.ZAdd(ctx, fmt.Sprintf("%s%s:%s", playerDrinksKeyPrefix, input.ToPlayerID, direction), members...)
+			}
+			pipe.Del(ctx, fromKeys[direction])
+		}
+
+		for field, value := range stats {
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid player stat %s: %w", field, err)
+			}
+			pipe.HIncrBy(ctx, toStatsKey, field, count)
+		}
+		pipe.Del(ctx, playerStatsKeyPrefix+input.FromPlayerID)
+	} else {
+		// Only the guild's drinks move, the player keeps the rest of their indexes and stats
+		for direction, members := range indexes {
+			moved := make([]redis.Z, 0, len(members))
+			movedIDs := make([]interface{}, 0, len(members))
+			for _, member := range members {
+				if _, ok := records[member.Member.(string)]; ok {
+					moved = append(moved, member)
+					movedIDs = append(movedIDs, member.Member)
+				}
+			}
+			if len(moved) == 0 {
+				continue
+			}
+			pipe.ZAdd(ctx, fmt.Sprintf("%s%s:%s", playerDrinksKeyPrefix, input.ToPlayerID, direction), moved...)
+			pipe.ZRem(ctx, fromKeys[direction], movedIDs...)
+		}
+
+		for field, count := range movedStats {
+			pipe.HIncrBy(ctx, playerStatsKeyPrefix+input.FromPlayerID, field, -count)
+			pipe.HIncrBy(ctx, toStatsKey, field, count)
+		}
+	}
+
+	// Unspent credits go with the drinks, moved by a script so none spent or banked meanwhile are lost
+	creditCmds := make([]*redis.Cmd, 0, len(creditKeys))
+	for _, key := range creditKeys {
+		creditCmds = append(creditCmds, moveCreditsScript.Eval(ctx, pipe, []string{key}, input.FromPlayerID, input.ToPlayerID))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reassign drink records: %w", err)
+	}
+
+	creditsMoved := 0
+	for _, cmd := range creditCmds {
+		moved, err := cmd.Int()
+		if err != nil {
+			return nil, fmt.Errorf("failed to move drink credits: %w", err)
+		}
+		creditsMoved += moved
+	}
+
+	return &ReassignPlayerRecordsOutput{
+		RecordsMoved: len(records),
+		CreditsMoved: creditsMoved,
+	}, nil
+}
+
+// creditKeysToMove returns the drink credit keys of every session, or only of the guild's sessions when a guild
+// is given. Credits of sessions that are gone can't be told apart by guild, so they're left where they are.
+func (r *redisRepository) creditKeysToMove(ctx context.Context, guildID string) ([]string, error) {
+	creditKeys, err := scan.Keys(ctx, r.client, sessionCreditsPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan drink credits: %w", err)
+	}
+
+	if guildID == "" {
+		return creditKeys, nil
+	}
+
+	guildKeys := make([]string, 0, len(creditKeys))
+	for _, key := range creditKeys {
+		sessionJSON, err := r.client.Get(ctx, sessionKeyPrefix+strings.TrimPrefix(key, sessionCreditsPrefix)).Result()
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+
+		var session models.Session
+		if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+		}
+
+		if session.GuildID == guildID {
+			guildKeys = append(guildKeys, key)
+		}
+	}
+
+	return guildKeys, nil
+}
+
+// ListDrinkRecords retrieves every drink record in the ledger by scanning the drink keys, for maintenance checks
+func (r *redisRepository) ListDrinkRecords(ctx context.Context, input *ListDrinkRecordsInput) (*ListDrinkRecordsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	drinkKeys, err := scan.Keys(ctx, r.client, drinkKeyPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan drink records: %w", err)
+	}
+
+	records := []*models.DrinkLedger{}
+	for _, drinkKey := range drinkKeys {
+		recordJSON, err := r.client.Get(ctx, drinkKey).Result()
+		if err != nil {
+			if err == redis.Nil {
+				// Deleted since the scan found it
+				continue
+			}
+			return nil, fmt.Errorf("failed to get drink record %s: %w", drinkKey, err)
+		}
+
+		var record models.DrinkLedger
+		if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal drink record %s: %w", drinkKey, err)
+		}
+
+		records = append(records, &record)
+	}
+
+	return &ListDrinkRecordsOutput{
+		Records: records,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package drink_ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/redis/go-redis/v9"
+)
+
+// sessionCreditsPrefix keys the hash of each session's drink credits by player
+const sessionCreditsPrefix = "session_credits:"
+
+// applyCreditScript spends one of a player's credits if they have any, returning the balance left or -1 when there
+// was nothing to spend, so two drinks can't both use the last credit
+var applyCreditScript = redis.NewScript(`
+local balance = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+if balance <= 0 then
+	return -1
+end
+if balance == 1 then
+	redis.call("HDEL", KEYS[1], ARGV[1])
+else
+	redis.call("HINCRBY", KEYS[1], ARGV[1], -1)
+end
+return balance - 1
+`)
+
+// moveCreditsScript moves a player's drink credits in a session to another player, returning how many were moved
+var moveCreditsScript = redis.NewScript(`
+local balance = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+redis.call("HDEL", KEYS[1], ARGV[1])
+if balance > 0 then
+	redis.call("HINCRBY", KEYS[1], ARGV[2], balance)
+end
+return balance
+`)
+
+// AddCredit banks a drink credit for a player
+func (r *redisRepository) AddCredit(ctx context.Context, input *AddCreditInput) (*AddCreditOutput, error) {
+	if input == nil || input.SessionID == "" || input.PlayerID == "" {
+		return nil, errors.New("input, session ID and player ID cannot be empty")
+	}
+
+	balance, err := r.client.HIncrBy(ctx, sessionCreditsPrefix+input.SessionID, input.PlayerID, 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add credit: %w", err)
+	}
+
+	return &AddCreditOutput{
+		Balance: int(balance),
+	}, nil
+}
+
+// ApplyCredit atomically spends one of a player's drink credits, if they have one
+func (r *redisRepository) ApplyCredit(ctx context.Context, input *ApplyCreditInput) (*ApplyCreditOutput, error) {
+	if input == nil || input.SessionID == "" || input.PlayerID == "" {
+		return nil, errors.New("input, session ID and player ID cannot be empty")
+	}
+
+	balance, err := applyCreditScript.Run(ctx, r.client, []string{sessionCreditsPrefix + input.SessionID}, input.PlayerID).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply credit: %w", err)
+	}
+
+	if balance < 0 {
+		return &ApplyCreditOutput{}, nil
+	}
+
+	// The credit is spent straight away so two drinks can't both use it, and given back if the drink it paid for
+	// isn't saved
+	transaction.OnDiscard(ctx, func(ctx context.Context) {
+		if err := r.client.HIncrBy(ctx, sessionCreditsPrefix+input.SessionID, input.PlayerID, 1).Err(); err != nil {
+			log.Printf("Error giving back drink credit for player %s in session %s: %v", input.PlayerID, input.SessionID, err)
+		}
+	})
+
+	return &ApplyCreditOutput{
+		Applied: true,
+		Balance: balance,
+	}, nil
+}
+
+// GetCredits retrieves every player's drink credits in a session
+func (r *redisRepository) GetCredits(ctx context.Context, input *GetCreditsInput) (*GetCreditsOutput, error) {
+	if input == nil || input.SessionID == "" {
+		return nil, errors.New("input and session ID cannot be empty")
+	}
+
+	values, err := r.client.HGetAll(ctx, sessionCreditsPrefix+input.SessionID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credits: %w", err)
+	}
+
+	balances := make(map[string]int, len(values))
+	for playerID, value := range values {
+		balance, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credits for player %s: %w", playerID, err)
+		}
+		if balance > 0 {
+			balances[playerID] = balance
+		}
+	}
+
+	return &GetCreditsOutput{
+		Balances: balances,
+	}, nil
+}
@@ -5,13 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// createSessionAttempts caps how often CreateSession retries when the guild's session changes underneath it
+const createSessionAttempts = 10
+
 // CreateSession creates a new drinking session
 func (r *redisRepository) CreateSession(ctx context.Context, input *CreateSessionInput) (*CreateSessionOutput, error) {
 	if input == nil {
@@ -46,41 +53,101 @@ func (r *redisRepository) CreateSession(ctx context.Context, input *CreateSessio
 	// Log the serialized JSON for debugging
 	log.Printf("Serialized session JSON: %s", string(sessionJSON))
 
-	// Store the session
-	sessionKey := sessionKeyPrefix + sessionID
-	err = r.client.Set(ctx, sessionKey, sessionJSON, 0).Err()
-	if err != nil {
-		return nil, fmt.Errorf("failed to store session: %w", err)
+	// Swap the session in under a watch on the guild's current session, so two callers replacing the same session
+	// can't both succeed
+	guildSessionKey := guildSessionPrefix + input.GuildID
+	for attempt := 0; attempt < createSessionAttempts; attempt++ {
+		err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+			return replaceSession(ctx, tx, guildSessionKey, session, sessionJSON, input.PreviousSessionID)
+		}, guildSessionKey)
+		if err == redis.TxFailedErr {
+			if input.PreviousSessionID != "" {
+				// Someone else replaced it first
+				return nil, ErrSessionReplaced
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &CreateSessionOutput{
+			Session: session,
+		}, nil
 	}
 
-	// Get the current active session for this guild
-	guildSessionKey := guildSessionPrefix + input.GuildID
-	oldSessionID, err := r.client.Get(ctx, guildSessionKey).Result()
+	return nil, fmt.Errorf("failed to set current session: %w", err)
+}
+
+// replaceSession makes the session the guild's current one and closes the session it replaces
+func replaceSession(ctx context.Context, tx *redis.Tx, guildSessionKey string, session *models.Session, sessionJSON []byte, previousSessionID string) error {
+	oldSessionID, err := tx.Get(ctx, guildSessionKey).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to get current session ID: %w", err)
+	}
+
+	if previousSessionID != "" && oldSessionID != previousSessionID {
+		return ErrSessionReplaced
+	}
 
 	// If there's an existing session, mark it as inactive
-	if err == nil && oldSessionID != "" {
-		oldSessionKey := sessionKeyPrefix + oldSessionID
-		oldSessionJSON, err := r.client.Get(ctx, oldSessionKey).Result()
+	var oldSessionJSON []byte
+	if oldSessionID != "" {
+		storedJSON, err := tx.Get(ctx, sessionKeyPrefix+oldSessionID).Result()
 		if err == nil {
 			var oldSession models.Session
-			if err := json.Unmarshal([]byte(oldSessionJSON), &oldSession); err == nil {
+			if err := json.Unmarshal([]byte(storedJSON), &oldSession); err == nil {
 				oldSession.Active = false
-				updatedJSON, err := json.Marshal(oldSession)
-				if err == nil {
-					r.client.Set(ctx, oldSessionKey, updatedJSON, 0)
+				if oldSession.EndedAt == nil {
+					endedAt := session.CreatedAt
+					oldSession.EndedAt = &endedAt
 				}
+				oldSessionJSON, _ = json.Marshal(oldSession)
 			}
 		}
 	}
 
-	// Set this as the current active session for the guild
-	err = r.client.Set(ctx, guildSessionKey, sessionID, 0).Err()
+	_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKeyPrefix+session.ID, sessionJSON, 0)
+		if oldSessionJSON != nil {
+			pipe.Set(ctx, sessionKeyPrefix+oldSessionID, oldSessionJSON, 0)
+		}
+		pipe.Set(ctx, guildSessionKey, session.ID, 0)
+		return nil
+	})
+	if err != nil && err != redis.TxFailedErr {
+		return fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return err
+}
+
+// GetCurrentSessions retrieves every guild's current session
+func (r *redisRepository) GetCurrentSessions(ctx context.Context, input *GetCurrentSessionsInput) (*GetCurrentSessionsOutput, error) {
+	if input == nil {
+		return nil, fmt.Errorf("input cannot be nil")
+	}
+
+	guildSessionKeys, err := scan.Keys(ctx, r.client, guildSessionPrefix+"*")
 	if err != nil {
-		return nil, fmt.Errorf("failed to set current session: %w", err)
+		return nil, fmt.Errorf("failed to list current sessions: %w", err)
+	}
+
+	sessions := make([]*models.Session, 0, len(guildSessionKeys))
+	for _, guildSessionKey := range guildSessionKeys {
+		output, err := r.GetCurrentSession(ctx, &GetCurrentSessionInput{
+			GuildID: strings.TrimPrefix(guildSessionKey, guildSessionPrefix),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if output.Session != nil {
+			sessions = append(sessions, output.Session)
+		}
 	}
 
-	return &CreateSessionOutput{
-		Session: session,
+	return &GetCurrentSessionsOutput{
+		Sessions: sessions,
 	}, nil
 }
 
@@ -129,6 +196,11 @@ func (r *redisRepository) GetCurrentSession(ctx context.Context, input *GetCurre
 	if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
 	}
+
+	// Never hand a guild another guild's session
+	if session.GuildID != input.GuildID {
+		return nil, ErrSessionNotInGuild
+	}
 	
 	// Check if the CreatedAt time is zero and fix it if needed
 	if session.CreatedAt.IsZero() {
@@ -147,6 +219,42 @@ func (r *redisRepository) GetCurrentSession(ctx context.Context, input *GetCurre
 	}, nil
 }
 
+// UpdateSession saves changes to an existing session
+func (r *redisRepository) UpdateSession(ctx context.Context, input *UpdateSessionInput) error {
+	if input == nil || input.Session == nil {
+		return fmt.Errorf("session cannot be nil")
+	}
+
+	if input.Session.ID == "" {
+		return fmt.Errorf("session ID is required")
+	}
+
+	sessionKey := sessionKeyPrefix + input.Session.ID
+	exists, err := r.client.Exists(ctx, sessionKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if exists == 0 {
+		return fmt.Errorf("session %s not found", input.Session.ID)
+	}
+
+	sessionJSON, err := json.Marshal(input.Session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	// Store the session as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey, sessionJSON, 0)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store session: %w", err)
+	}
+
+	return nil
+}
+
 // GetDrinkRecordsForSession retrieves all drink records for a session
 func (r *redisRepository) GetDrinkRecordsForSession(ctx context.Context, input *GetDrinkRecordsForSessionInput) (*GetDrinkRecordsForSessionOutput, error) {
 	if input == nil {
@@ -157,9 +265,33 @@ func (r *redisRepository) GetDrinkRecordsForSession(ctx context.Context, input *
 		return nil, fmt.Errorf("session ID is required")
 	}
 
+	if input.GuildID == "" {
+		return nil, fmt.Errorf("guild ID is required")
+	}
+
+	if err := r.checkSessionGuild(ctx, input.SessionID, input.GuildID); err != nil {
+		return nil, err
+	}
+
+	var output *GetDrinkRecordsForSessionOutput
+	err := replica.Read(ctx, r.client, r.replica, func(client redis.UniversalClient) error {
+		var err error
+		output, err = drinkRecordsForSession(ctx, client, input.SessionID, input.GuildID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// drinkRecordsForSession reads a session's drink records from the primary or a replica, leaving out any from
+// another guild
+func drinkRecordsForSession(ctx context.Context, client redis.UniversalClient, sessionID, guildID string) (*GetDrinkRecordsForSessionOutput, error) {
 	// Get all drink IDs for this session
-	sessionDrinksKey := sessionDrinksPrefix + input.SessionID
-	drinkIDs, err := r.client.SMembers(ctx, sessionDrinksKey).Result()
+	sessionDrinksKey := sessionDrinksPrefix + sessionID
+	drinkIDs, err := client.SMembers(ctx, sessionDrinksKey).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get drink IDs for session: %w", err)
 	}
@@ -175,7 +307,7 @@ func (r *redisRepository) GetDrinkRecordsForSession(ctx context.Context, input *
 	var records []*models.DrinkLedger
 	for _, drinkID := range drinkIDs {
 		drinkKey := drinkKeyPrefix + drinkID
-		drinkJSON, err := r.client.Get(ctx, drinkKey).Result()
+		drinkJSON, err := client.Get(ctx, drinkKey).Result()
 		if err != nil {
 			if err == redis.Nil {
 				// Drink doesn't exist anymore, skip it
@@ -190,6 +322,11 @@ func (r *redisRepository) GetDrinkRecordsForSession(ctx context.Context, input *
 			return nil, fmt.Errorf("failed to unmarshal drink record: %w", err)
 		}
 
+		// Skip anything recorded in another guild that found its way into the session
+		if record.GuildID != "" && record.GuildID != guildID {
+			continue
+		}
+
 		records = append(records, &record)
 	}
 
@@ -197,3 +334,50 @@ func (r *redisRepository) GetDrinkRecordsForSession(ctx context.Context, input *
 		Records: records,
 	}, nil
 }
+
+// checkSessionGuild returns ErrSessionNotInGuild unless the session belongs to the guild
+func (r *redisRepository) checkSessionGuild(ctx context.Context, sessionID, guildID string) error {
+	sessionJSON, err := r.client.Get(ctx, sessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			// Records can outlive their session, there's nothing left to leak
+			return nil
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
+		return fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	if session.GuildID != guildID {
+		return ErrSessionNotInGuild
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by ID, whether or not it's still active
+func (r *redisRepository) GetSession(ctx context.Context, input *GetSessionInput) (*GetSessionOutput, error) {
+	if input == nil || input.SessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+
+	sessionJSON, err := r.client.Get(ctx, sessionKeyPrefix+input.SessionID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	return &GetSessionOutput{
+		Session: &session,
+	}, nil
+}
@@ -183,6 +183,124 @@ func (s *RedisRepositoryTestSuite) TestMarkDrinkPaid() {
 	s.NotZero(gameOutput.Records[0].PaidTimestamp)
 }
 
+func (s *RedisRepositoryTestSuite) TestForgiveDrink() {
+	err := s.repo.AddDrinkRecord(context.Background(), &AddDrinkRecordInput{
+		Record: &models.DrinkLedger{
+			ID:         "test-drink-id",
+			ToPlayerID: "to-player-id",
+			GameID:     "test-game-id",
+			Reason:     models.DrinkReasonLowestRoll,
+			Timestamp:  s.testNow,
+		},
+	})
+	s.Require().NoError(err)
+
+	err = s.repo.ForgiveDrink(context.Background(), &ForgiveDrinkInput{
+		DrinkID:    "test-drink-id",
+		ForgivenBy: "admin-id",
+		Reason:     "birthday",
+		ForgivenAt: s.testNow,
+	})
+	s.Require().NoError(err)
+
+	gameOutput, err := s.repo.GetDrinkRecordsForGame(context.Background(), &GetDrinkRecordsForGameInput{
+		GameID: "test-game-id",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(gameOutput.Records, 1)
+
+	// Forgiven, not paid
+	record := gameOutput.Records[0]
+	s.True(record.Forgiven)
+	s.False(record.Paid)
+	s.False(record.IsOutstanding())
+	s.Equal("admin-id", record.ForgivenBy)
+	s.Equal("birthday", record.ForgiveReason)
+	s.True(record.ForgivenTimestamp.Equal(s.testNow))
+
+	err = s.repo.ForgiveDrink(context.Background(), &ForgiveDrinkInput{DrinkID: "missing"})
+	s.ErrorIs(err, ErrDrinkNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestTransferDrink() {
+	ctx := context.Background()
+
+	err := s.repo.AddDrinkRecord(ctx, &AddDrinkRecordInput{
+		Record: &models.DrinkLedger{
+			ID:         "test-drink-id",
+			ToPlayerID: "alice",
+			GameID:     "test-game-id",
+			Reason:     models.DrinkReasonLowestRoll,
+			Timestamp:  s.testNow,
+		},
+	})
+	s.Require().NoError(err)
+
+	output, err := s.repo.TransferDrink(ctx, &TransferDrinkInput{DrinkID: "test-drink-id", FromPlayerID: "alice", ToPlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal("bob", output.Record.ToPlayerID)
+	s.Equal([]string{"alice"}, output.Record.TransferredFrom)
+
+	// The drink moved between the players' indexes
+	aliceOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Empty(aliceOutput.Records)
+
+	bobOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().Len(bobOutput.Records, 1)
+	s.Equal("bob", bobOutput.Records[0].ToPlayerID)
+
+	// Passing it on again extends the chain
+	output, err = s.repo.TransferDrink(ctx, &TransferDrinkInput{DrinkID: "test-drink-id", FromPlayerID: "bob", ToPlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Equal([]string{"alice", "bob"}, output.Record.TransferredFrom)
+
+	// alice no longer owes it
+	_, err = s.repo.TransferDrink(ctx, &TransferDrinkInput{DrinkID: "test-drink-id", FromPlayerID: "alice", ToPlayerID: "dave"})
+	s.ErrorIs(err, ErrDrinkNotTransferable)
+}
+
+func (s *RedisRepositoryTestSuite) TestDrinkTransfers() {
+	ctx := context.Background()
+
+	transfer := &models.DrinkTransfer{
+		ID:           "test-transfer-id",
+		DrinkID:      "test-drink-id",
+		FromPlayerID: "alice",
+		ToPlayerID:   "bob",
+		CreatedAt:    s.testNow,
+		ExpiresAt:    s.testNow.Add(10 * time.Minute),
+	}
+	s.Require().NoError(s.repo.CreateDrinkTransfer(ctx, &CreateDrinkTransferInput{Transfer: transfer}))
+
+	// A drink can only be offered to one player at a time
+	err := s.repo.CreateDrinkTransfer(ctx, &CreateDrinkTransferInput{Transfer: &models.DrinkTransfer{
+		ID:        "other-transfer-id",
+		DrinkID:   "test-drink-id",
+		CreatedAt: s.testNow,
+		ExpiresAt: s.testNow.Add(10 * time.Minute),
+	}})
+	s.ErrorIs(err, ErrDrinkTransferPending)
+
+	output, err := s.repo.GetDrinkTransfer(ctx, &GetDrinkTransferInput{TransferID: "test-transfer-id"})
+	s.Require().NoError(err)
+	s.Equal("bob", output.Transfer.ToPlayerID)
+
+	// It can only be answered once
+	s.Require().NoError(s.repo.DeleteDrinkTransfer(ctx, &DeleteDrinkTransferInput{TransferID: "test-transfer-id"}))
+	err = s.repo.DeleteDrinkTransfer(ctx, &DeleteDrinkTransferInput{TransferID: "test-transfer-id"})
+	s.ErrorIs(err, ErrDrinkTransferNotFound)
+
+	// Once answered the drink can be offered again, until the offer expires
+	s.Require().NoError(s.repo.CreateDrinkTransfer(ctx, &CreateDrinkTransferInput{Transfer: transfer}))
+	s.mr.FastForward(11 * time.Minute)
+
+	_, err = s.repo.GetDrinkTransfer(ctx, &GetDrinkTransferInput{TransferID: "test-transfer-id"})
+	s.ErrorIs(err, ErrDrinkTransferNotFound)
+	s.Require().NoError(s.repo.CreateDrinkTransfer(ctx, &CreateDrinkTransferInput{Transfer: transfer}))
+}
+
 func (s *RedisRepositoryTestSuite) TestGetEmptyResults() {
 	// Get drinks for a game with no records
 	gameOutput, err := s.repo.GetDrinkRecordsForGame(context.Background(), &GetDrinkRecordsForGameInput{
@@ -207,3 +325,491 @@ func (s *RedisRepositoryTestSuite) TestMarkNonExistentDrink() {
 	s.Require().Error(err)
 	s.Equal(ErrDrinkNotFound, err)
 }
+
+func (s *RedisRepositoryTestSuite) TestUpdateSession() {
+	ctx := context.Background()
+
+	created, err := s.repo.CreateSession(ctx, &CreateSessionInput{
+		GuildID:   "test-guild",
+		CreatedBy: "test-user",
+	})
+	s.Require().NoError(err)
+
+	session := created.Session
+	session.DesignatedDrivers = []*models.DesignatedDriver{
+		{PlayerID: "driver-id", PlayerName: "Driver", Since: s.testNow},
+	}
+	err = s.repo.UpdateSession(ctx, &UpdateSessionInput{Session: session})
+	s.Require().NoError(err)
+
+	current, err := s.repo.GetCurrentSession(ctx, &GetCurrentSessionInput{GuildID: "test-guild"})
+	s.Require().NoError(err)
+	s.Require().NotNil(current.Session)
+	s.Equal(session.ID, current.Session.ID)
+	s.Require().NotNil(current.Session.GetDesignatedDriver("driver-id"))
+	s.Equal("Driver", current.Session.GetDesignatedDriver("driver-id").PlayerName)
+	s.Nil(current.Session.GetDesignatedDriver("other-id"))
+}
+
+func (s *RedisRepositoryTestSuite) TestUpdateNonExistentSession() {
+	err := s.repo.UpdateSession(context.Background(), &UpdateSessionInput{
+		Session: &models.Session{ID: "missing-session"},
+	})
+	s.Error(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRecords() {
+	ctx := context.Background()
+
+	records := []*models.DrinkLedger{
+		{
+			ID:           "drink-1",
+			FromPlayerID: "alt",
+			ToPlayerID:   "player-2",
+			GameID:       "game-1",
+			Reason:       models.DrinkReasonCriticalHit,
+			Timestamp:    s.testNow,
+		},
+		{
+			ID:           "drink-2",
+			FromPlayerID: "player-2",
+			ToPlayerID:   "alt",
+			GameID:       "game-1",
+			Reason:       models.DrinkReasonCriticalHit,
+			Timestamp:    s.testNow.Add(time.Minute),
+		},
+		{
+			ID:           "drink-3",
+			FromPlayerID: "main",
+			ToPlayerID:   "main",
+			GameID:       "game-2",
+			Reason:       models.DrinkReasonCriticalFail,
+			Timestamp:    s.testNow.Add(2 * time.Minute),
+		},
+	}
+	for _, record := range records {
+		err := s.repo.AddDrinkRecord(ctx, &AddDrinkRecordInput{Record: record})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.ReassignPlayerRecords(ctx, &ReassignPlayerRecordsInput{
+		FromPlayerID: "alt",
+		ToPlayerID:   "main",
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.RecordsMoved)
+
+	// The alt account has nothing left
+	altOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Empty(altOutput.Records)
+	s.False(s.mr.Exists(playerStatsKeyPrefix + "alt"))
+
+	// The main account has its own record plus both of the alt's, rewritten
+	mainOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "main"})
+	s.Require().NoError(err)
+	s.Require().Len(mainOutput.Records, 3)
+
+	recordMap := make(map[string]*models.DrinkLedger)
+	for _, record := range mainOutput.Records {
+		recordMap[record.ID] = record
+	}
+	s.Equal("main", recordMap["drink-1"].FromPlayerID)
+	s.Equal("player-2", recordMap["drink-1"].ToPlayerID)
+	s.Equal("main", recordMap["drink-2"].ToPlayerID)
+
+	// Stats are added to the main account's
+	stats, err := s.client.HGetAll(ctx, playerStatsKeyPrefix+"main").Result()
+	s.Require().NoError(err)
+	s.Equal("2", stats["assigned"])
+	s.Equal("2", stats["received"])
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRecordsRewritesTransfers() {
+	ctx := context.Background()
+
+	err := s.repo.AddDrinkRecord(ctx, &AddDrinkRecordInput{
+		Record: &models.DrinkLedger{
+			ID:         "test-drink-id",
+			ToPlayerID: "alt",
+			GameID:     "test-game-id",
+			Reason:     models.DrinkReasonLowestRoll,
+			Timestamp:  s.testNow,
+		},
+	})
+	s.Require().NoError(err)
+
+	// The alt hands the drink on, so it's no longer theirs but still names them
+	_, err = s.repo.TransferDrink(ctx, &TransferDrinkInput{DrinkID: "test-drink-id", FromPlayerID: "alt", ToPlayerID: "bob"})
+	s.Require().NoError(err)
+	_, err = s.repo.TransferDrink(ctx, &TransferDrinkInput{DrinkID: "test-drink-id", FromPlayerID: "bob", ToPlayerID: "carol"})
+	s.Require().NoError(err)
+
+	output, err := s.repo.ReassignPlayerRecords(ctx, &ReassignPlayerRecordsInput{
+		FromPlayerID: "alt",
+		ToPlayerID:   "main",
+	})
+	s.Require().NoError(err)
+	s.Equal(1, output.RecordsMoved)
+
+	gameOutput, err := s.repo.GetDrinkRecordsForGame(ctx, &GetDrinkRecordsForGameInput{GameID: "test-game-id"})
+	s.Require().NoError(err)
+	s.Require().Len(gameOutput.Records, 1)
+	s.Equal([]string{"main", "bob"}, gameOutput.Records[0].TransferredFrom)
+	s.Equal("carol", gameOutput.Records[0].ToPlayerID)
+	s.False(s.mr.Exists(playerDrinksKeyPrefix + "alt:transferred"))
+
+	// And it goes with main if they're reassigned in turn
+	_, err = s.repo.ReassignPlayerRecords(ctx, &ReassignPlayerRecordsInput{
+		FromPlayerID: "main",
+		ToPlayerID:   "other",
+	})
+	s.Require().NoError(err)
+
+	gameOutput, err = s.repo.GetDrinkRecordsForGame(ctx, &GetDrinkRecordsForGameInput{GameID: "test-game-id"})
+	s.Require().NoError(err)
+	s.Require().Len(gameOutput.Records, 1)
+	s.Equal([]string{"other", "bob"}, gameOutput.Records[0].TransferredFrom)
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRecordsMovesCredits() {
+	ctx := context.Background()
+
+	for _, credit := range []struct {
+		sessionID string
+		playerID  string
+	}{{"session-1", "alt"}, {"session-1", "alt"}, {"session-1", "main"}, {"session-2", "alt"}, {"session-2", "bob"}} {
+		_, err := s.repo.AddCredit(ctx, &AddCreditInput{SessionID: credit.sessionID, PlayerID: credit.playerID})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.ReassignPlayerRecords(ctx, &ReassignPlayerRecordsInput{
+		FromPlayerID: "alt",
+		ToPlayerID:   "main",
+	})
+	s.Require().NoError(err)
+	s.Equal(3, output.CreditsMoved)
+
+	first, err := s.repo.GetCredits(ctx, &GetCreditsInput{SessionID: "session-1"})
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"main": 3}, first.Balances)
+
+	second, err := s.repo.GetCredits(ctx, &GetCreditsInput{SessionID: "session-2"})
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"main": 1, "bob": 1}, second.Balances)
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRecordsInGuild() {
+	ctx := context.Background()
+
+	for i, guildID := range []string{"guild-1", "guild-2"} {
+		created, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: guildID, CreatedBy: "alt"})
+		s.Require().NoError(err)
+
+		_, err = s.repo.CreateDrinkRecord(ctx, &CreateDrinkRecordInput{
+			GameID:       "game-" + guildID,
+			FromPlayerID: "player-2",
+			ToPlayerID:   "alt",
+			Reason:       models.DrinkReasonLowestRoll,
+			Timestamp:    s.testNow.Add(time.Duration(i) * time.Minute),
+			SessionID:    created.Session.ID,
+			GuildID:      guildID,
+		})
+		s.Require().NoError(err)
+
+		_, err = s.repo.AddCredit(ctx, &AddCreditInput{SessionID: created.Session.ID, PlayerID: "alt"})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.ReassignPlayerRecords(ctx, &ReassignPlayerRecordsInput{
+		FromPlayerID: "alt",
+		ToPlayerID:   "main",
+		GuildID:      "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Equal(1, output.RecordsMoved)
+	s.Equal(1, output.CreditsMoved)
+
+	// The drink from the other guild stays with the alt, and the stats follow the drinks
+	altOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Require().Len(altOutput.Records, 1)
+	s.Equal("guild-2", altOutput.Records[0].GuildID)
+
+	mainOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "main"})
+	s.Require().NoError(err)
+	s.Require().Len(mainOutput.Records, 1)
+	s.Equal("guild-1", mainOutput.Records[0].GuildID)
+	s.Equal("main", mainOutput.Records[0].ToPlayerID)
+
+	s.Equal("1", s.mr.HGet(playerStatsKeyPrefix+"alt", "received"))
+	s.Equal("1", s.mr.HGet(playerStatsKeyPrefix+"main", "received"))
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRecordsToSelf() {
+	_, err := s.repo.ReassignPlayerRecords(context.Background(), &ReassignPlayerRecordsInput{
+		FromPlayerID: "main",
+		ToPlayerID:   "main",
+	})
+	s.Error(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteDrinkRecord() {
+	ctx := context.Background()
+
+	output, err := s.repo.CreateDrinkRecord(ctx, &CreateDrinkRecordInput{
+		GameID:       "game-1",
+		FromPlayerID: "player-1",
+		ToPlayerID:   "player-1",
+		Reason:       models.DrinkReasonCriticalFail,
+		Timestamp:    s.testNow,
+		SessionID:    "session-1",
+	})
+	s.Require().NoError(err)
+
+	err = s.repo.DeleteDrinkRecord(ctx, &DeleteDrinkRecordInput{DrinkID: output.Record.ID})
+	s.Require().NoError(err)
+
+	gameOutput, err := s.repo.GetDrinkRecordsForGame(ctx, &GetDrinkRecordsForGameInput{GameID: "game-1"})
+	s.Require().NoError(err)
+	s.Empty(gameOutput.Records)
+
+	playerOutput, err := s.repo.GetDrinkRecordsForPlayer(ctx, &GetDrinkRecordsForPlayerInput{PlayerID: "player-1"})
+	s.Require().NoError(err)
+	s.Empty(playerOutput.Records)
+
+	sessionOutput, err := s.repo.GetDrinkRecordsForSession(ctx, &GetDrinkRecordsForSessionInput{SessionID: "session-1", GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Empty(sessionOutput.Records)
+
+	stats, err := s.client.HGetAll(ctx, playerStatsKeyPrefix+"player-1").Result()
+	s.Require().NoError(err)
+	s.Equal("0", stats["assigned"])
+	s.Equal("0", stats["received"])
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteNonExistentDrink() {
+	err := s.repo.DeleteDrinkRecord(context.Background(), &DeleteDrinkRecordInput{DrinkID: "missing"})
+	s.ErrorIs(err, ErrDrinkNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetDrinkRecordsForSessionScopedToGuild() {
+	ctx := context.Background()
+
+	created, err := s.repo.CreateSession(ctx, &CreateSessionInput{
+		GuildID:   "guild-1",
+		CreatedBy: "player-1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.repo.CreateDrinkRecord(ctx, &CreateDrinkRecordInput{
+		GameID:     "game-1",
+		ToPlayerID: "player-1",
+		Reason:     models.DrinkReasonCriticalFail,
+		Timestamp:  s.testNow,
+		SessionID:  created.Session.ID,
+		GuildID:    "guild-1",
+	})
+	s.Require().NoError(err)
+
+	output, err := s.repo.GetDrinkRecordsForSession(ctx, &GetDrinkRecordsForSessionInput{
+		SessionID: created.Session.ID,
+		GuildID:   "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(output.Records, 1)
+	s.Equal("guild-1", output.Records[0].GuildID)
+
+	_, err = s.repo.GetDrinkRecordsForSession(ctx, &GetDrinkRecordsForSessionInput{
+		SessionID: created.Session.ID,
+		GuildID:   "guild-2",
+	})
+	s.ErrorIs(err, ErrSessionNotInGuild)
+
+	// Nobody reads a session without saying which guild they're reading from
+	_, err = s.repo.GetDrinkRecordsForSession(ctx, &GetDrinkRecordsForSessionInput{
+		SessionID: created.Session.ID,
+	})
+	s.Error(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetDrinkRecordsForSessionSkipsOtherGuilds() {
+	ctx := context.Background()
+
+	created, err := s.repo.CreateSession(ctx, &CreateSessionInput{
+		GuildID:   "guild-1",
+		CreatedBy: "player-1",
+	})
+	s.Require().NoError(err)
+
+	for _, guildID := range []string{"guild-1", "guild-2"} {
+		_, err = s.repo.CreateDrinkRecord(ctx, &CreateDrinkRecordInput{
+			GameID:     "game-" + guildID,
+			ToPlayerID: "player-1",
+			Reason:     models.DrinkReasonCriticalFail,
+			Timestamp:  s.testNow,
+			SessionID:  created.Session.ID,
+			GuildID:    guildID,
+		})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.GetDrinkRecordsForSession(ctx, &GetDrinkRecordsForSessionInput{
+		SessionID: created.Session.ID,
+		GuildID:   "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(output.Records, 1)
+	s.Equal("game-guild-1", output.Records[0].GameID)
+}
+
+func (s *RedisRepositoryTestSuite) TestDrinkCredits() {
+	ctx := context.Background()
+
+	// Nothing to spend yet
+	applyOutput, err := s.repo.ApplyCredit(ctx, &ApplyCreditInput{SessionID: "test-session-id", PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.False(applyOutput.Applied)
+
+	for expected := 1; expected <= 2; expected++ {
+		addOutput, err := s.repo.AddCredit(ctx, &AddCreditInput{SessionID: "test-session-id", PlayerID: "alice"})
+		s.Require().NoError(err)
+		s.Equal(expected, addOutput.Balance)
+	}
+
+	// Credits are kept per session
+	_, err = s.repo.AddCredit(ctx, &AddCreditInput{SessionID: "other-session-id", PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	getOutput, err := s.repo.GetCredits(ctx, &GetCreditsInput{SessionID: "test-session-id"})
+	s.Require().NoError(err)
+	s.Equal(map[string]int{"alice": 2}, getOutput.Balances)
+
+	// Spend both, then there's nothing left
+	for _, expected := range []int{1, 0} {
+		applyOutput, err := s.repo.ApplyCredit(ctx, &ApplyCreditInput{SessionID: "test-session-id", PlayerID: "alice"})
+		s.Require().NoError(err)
+		s.True(applyOutput.Applied)
+		s.Equal(expected, applyOutput.Balance)
+	}
+
+	applyOutput, err = s.repo.ApplyCredit(ctx, &ApplyCreditInput{SessionID: "test-session-id", PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.False(applyOutput.Applied)
+
+	getOutput, err = s.repo.GetCredits(ctx, &GetCreditsInput{SessionID: "test-session-id"})
+	s.Require().NoError(err)
+	s.Empty(getOutput.Balances)
+}
+
+func (s *RedisRepositoryTestSuite) TestCreateDrinkRecordPaidWithCredit() {
+	ctx := context.Background()
+
+	output, err := s.repo.CreateDrinkRecord(ctx, &CreateDrinkRecordInput{
+		GameID:         "test-game-id",
+		ToPlayerID:     "alice",
+		Reason:         models.DrinkReasonLowestRoll,
+		Timestamp:      s.testNow,
+		SessionID:      "test-session-id",
+		PaidWithCredit: true,
+	})
+	s.Require().NoError(err)
+	s.True(output.Record.Paid)
+	s.True(output.Record.PaidWithCredit)
+	s.Equal(s.testNow, output.Record.PaidTimestamp)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetSession() {
+	ctx := context.Background()
+
+	first, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: "test-guild", CreatedBy: "test-user"})
+	s.Require().NoError(err)
+
+	_, err = s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: "test-guild", CreatedBy: "test-user"})
+	s.Require().NoError(err)
+
+	// Replaced sessions can still be looked up
+	output, err := s.repo.GetSession(ctx, &GetSessionInput{SessionID: first.Session.ID})
+	s.Require().NoError(err)
+	s.Equal(first.Session.ID, output.Session.ID)
+	s.False(output.Session.Active)
+	s.Require().NotNil(output.Session.EndedAt)
+	s.False(output.Session.EndedAt.Before(output.Session.CreatedAt))
+
+	_, err = s.repo.GetSession(ctx, &GetSessionInput{SessionID: "missing-session"})
+	s.ErrorIs(err, ErrSessionNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestListDrinkRecords() {
+	ctx := context.Background()
+
+	for _, id := range []string{"drink-1", "drink-2", "drink-3"} {
+		s.Require().NoError(s.repo.AddDrinkRecord(ctx, &AddDrinkRecordInput{
+			Record: &models.DrinkLedger{
+				ID:         id,
+				ToPlayerID: "alice",
+				GameID:     "test-game-id",
+				Reason:     models.DrinkReasonLowestRoll,
+				Timestamp:  s.testNow,
+			},
+		}))
+	}
+
+	// Pending transfers share the prefix but aren't drinks
+	s.Require().NoError(s.repo.CreateDrinkTransfer(ctx, &CreateDrinkTransferInput{Transfer: &models.DrinkTransfer{
+		ID:        "test-transfer-id",
+		DrinkID:   "drink-1",
+		CreatedAt: s.testNow,
+		ExpiresAt: s.testNow.Add(10 * time.Minute),
+	}}))
+
+	output, err := s.repo.ListDrinkRecords(ctx, &ListDrinkRecordsInput{})
+	s.Require().NoError(err)
+
+	var ids []string
+	for _, record := range output.Records {
+		ids = append(ids, record.ID)
+	}
+	s.ElementsMatch([]string{"drink-1", "drink-2", "drink-3"}, ids)
+}
+
+func (s *RedisRepositoryTestSuite) TestCreateSessionReplacesOnlyOnce() {
+	ctx := context.Background()
+
+	first, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: "test-guild", CreatedBy: "test-user"})
+	s.Require().NoError(err)
+
+	second, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: "test-guild", PreviousSessionID: first.Session.ID})
+	s.Require().NoError(err)
+
+	// The first session is already gone, so replacing it again must not replace its successor
+	_, err = s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: "test-guild", PreviousSessionID: first.Session.ID})
+	s.ErrorIs(err, ErrSessionReplaced)
+
+	current, err := s.repo.GetCurrentSession(ctx, &GetCurrentSessionInput{GuildID: "test-guild"})
+	s.Require().NoError(err)
+	s.Equal(second.Session.ID, current.Session.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetCurrentSessions() {
+	ctx := context.Background()
+
+	var expected []string
+	for _, guildID := range []string{"guild-1", "guild-2"} {
+		_, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: guildID})
+		s.Require().NoError(err)
+
+		current, err := s.repo.CreateSession(ctx, &CreateSessionInput{GuildID: guildID})
+		s.Require().NoError(err)
+		expected = append(expected, current.Session.ID)
+	}
+
+	output, err := s.repo.GetCurrentSessions(ctx, &GetCurrentSessionsInput{})
+	s.Require().NoError(err)
+
+	var ids []string
+	for _, session := range output.Sessions {
+		ids = append(ids, session.ID)
+	}
+	s.ElementsMatch(expected, ids)
+}
@@ -0,0 +1,168 @@
+package drink_ledger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrDrinkTransferNotFound is returned when a transfer doesn't exist, has expired or was already answered
+var ErrDrinkTransferNotFound = errors.New("drink transfer not found")
+
+// ErrDrinkTransferPending is returned when the drink is already being offered to someone
+var ErrDrinkTransferPending = errors.New("drink already has a pending transfer")
+
+// ErrDrinkNotTransferable is returned when the drink is no longer owed by the player handing it over
+var ErrDrinkNotTransferable = errors.New("drink is no longer owed by the player")
+
+// CreateDrinkTransfer saves a pending drink transfer until it expires, a drink can only be offered to one player at a time
+func (r *redisRepository) CreateDrinkTransfer(ctx context.Context, input *CreateDrinkTransferInput) error {
+	if input == nil || input.Transfer == nil {
+		return errors.New("input and transfer cannot be nil")
+	}
+
+	transfer := input.Transfer
+	if transfer.ID == "" || transfer.DrinkID == "" {
+		return errors.New("transfer and drink IDs cannot be empty")
+	}
+
+	ttl := transfer.ExpiresAt.Sub(transfer.CreatedAt)
+	if ttl <= 0 {
+		return errors.New("transfer must expire after it's created")
+	}
+
+	// Claim the drink first so two offers can't race each other
+	claimed, err := r.client.SetNX(ctx, drinkTransferDrinkPrefix+transfer.DrinkID, transfer.ID, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to claim drink for transfer: %w", err)
+	}
+	if !claimed {
+		return ErrDrinkTransferPending
+	}
+
+	transferJSON, err := json.Marshal(transfer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal drink transfer: %w", err)
+	}
+
+	if err := r.client.Set(ctx, drinkTransferKeyPrefix+transfer.ID, transferJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save drink transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetDrinkTransfer retrieves a pending drink transfer
+func (r *redisRepository) GetDrinkTransfer(ctx context.Context, input *GetDrinkTransferInput) (*GetDrinkTransferOutput, error) {
+	if input == nil || input.TransferID == "" {
+		return nil, errors.New("input and transfer ID cannot be empty")
+	}
+
+	transferJSON, err := r.client.Get(ctx, drinkTransferKeyPrefix+input.TransferID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrDrinkTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get drink transfer: %w", err)
+	}
+
+	var transfer models.DrinkTransfer
+	if err := json.Unmarshal([]byte(transferJSON), &transfer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal drink transfer: %w", err)
+	}
+
+	return &GetDrinkTransferOutput{
+		Transfer: &transfer,
+	}, nil
+}
+
+// DeleteDrinkTransfer removes a pending drink transfer, only one caller gets to remove it
+// so an answered transfer can't be answered again
+func (r *redisRepository) DeleteDrinkTransfer(ctx context.Context, input *DeleteDrinkTransferInput) error {
+	if input == nil || input.TransferID == "" {
+		return errors.New("input and transfer ID cannot be empty")
+	}
+
+	output, err := r.GetDrinkTransfer(ctx, &GetDrinkTransferInput{
+		TransferID: input.TransferID,
+	})
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.client.Del(ctx, drinkTransferKeyPrefix+input.TransferID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete drink transfer: %w", err)
+	}
+	if deleted == 0 {
+		return ErrDrinkTransferNotFound
+	}
+
+	// Free the drink for another offer, unless it has already been claimed again
+	drinkKey := drinkTransferDrinkPrefix + output.Transfer.DrinkID
+	if claimedBy, err := r.client.Get(ctx, drinkKey).Result(); err == nil && claimedBy == input.TransferID {
+		r.client.Del(ctx, drinkKey)
+	}
+
+	return nil
+}
+
+// TransferDrink hands a drink the player still owes to another player, adding them to the drink's transfer chain
+func (r *redisRepository) TransferDrink(ctx context.Context, input *TransferDrinkInput) (*TransferDrinkOutput, error) {
+	if input == nil || input.DrinkID == "" || input.FromPlayerID == "" || input.ToPlayerID == "" {
+		return nil, errors.New("drink, from and to player IDs are required")
+	}
+
+	drinkKey := drinkKeyPrefix + input.DrinkID
+	recordJSON, err := r.client.Get(ctx, drinkKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrDrinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get drink record: %w", err)
+	}
+
+	var record models.DrinkLedger
+	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal drink record: %w", err)
+	}
+
+	if record.ToPlayerID != input.FromPlayerID || !record.IsOutstanding() {
+		return nil, ErrDrinkNotTransferable
+	}
+
+	record.TransferredFrom = append(record.TransferredFrom, record.ToPlayerID)
+	record.ToPlayerID = input.ToPlayerID
+
+	updatedRecordJSON, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updated drink record: %w", err)
+	}
+
+	// Move the drink between the players' indexes and stats along with the record
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, drinkKey, updatedRecordJSON, 0)
+	pipe.ZRem(ctx, fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, input.FromPlayerID), record.ID)
+	pipe.ZAdd(ctx, fmt.Sprintf("%s%s:to", playerDrinksKeyPrefix, input.ToPlayerID), redis.Z{
+		Score:  float64(record.Timestamp.Unix()),
+		Member: record.ID,
+	})
+	// Remember who handed it on, so the drink can be found when they're merged or forgotten
+	pipe.ZAdd(ctx, fmt.Sprintf("%s%s:transferred", playerDrinksKeyPrefix, input.FromPlayerID), redis.Z{
+		Score:  float64(record.Timestamp.Unix()),
+		Member: record.ID,
+	})
+	pipe.HIncrBy(ctx, playerStatsKeyPrefix+input.FromPlayerID, "received", -1)
+	pipe.HIncrBy(ctx, playerStatsKeyPrefix+input.ToPlayerID, "received", 1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to transfer drink: %w", err)
+	}
+
+	return &TransferDrinkOutput{
+		Record: &record,
+	}, nil
+}
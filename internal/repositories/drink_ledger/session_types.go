@@ -11,6 +11,10 @@ type CreateSessionInput struct {
 
 	// CreatedBy is the user ID who created the session
 	CreatedBy string
+
+	// PreviousSessionID is the session the new one replaces (optional). When set, the session is only created if
+	// that's still the guild's current session, failing with ErrSessionReplaced otherwise.
+	PreviousSessionID string
 }
 
 // CreateSessionOutput contains the result of creating a new session
@@ -31,10 +35,22 @@ type GetCurrentSessionOutput struct {
 	Session *models.Session
 }
 
+// GetCurrentSessionsInput contains parameters for retrieving every guild's current session
+type GetCurrentSessionsInput struct{}
+
+// GetCurrentSessionsOutput contains every guild's current session
+type GetCurrentSessionsOutput struct {
+	Sessions []*models.Session
+}
+
 // GetDrinkRecordsForSessionInput contains parameters for retrieving drink records for a session
 type GetDrinkRecordsForSessionInput struct {
 	// SessionID is the ID of the session to get drink records for
 	SessionID string
+
+	// GuildID is the scope asking for the records: the Discord server, or the channel outside a server.
+	// A session belonging to another scope is refused with ErrSessionNotInGuild.
+	GuildID string
 }
 
 // GetDrinkRecordsForSessionOutput contains the result of retrieving drink records for a session
@@ -42,3 +58,21 @@ type GetDrinkRecordsForSessionOutput struct {
 	// Records is the list of drink records for the session
 	Records []*models.DrinkLedger
 }
+
+// UpdateSessionInput contains parameters for updating a session
+type UpdateSessionInput struct {
+	// Session is the session to save
+	Session *models.Session
+}
+
+// GetSessionInput contains parameters for retrieving a session
+type GetSessionInput struct {
+	// SessionID is the ID of the session to get
+	SessionID string
+}
+
+// GetSessionOutput contains the result of retrieving a session
+type GetSessionOutput struct {
+	// Session is the session, active or not
+	Session *models.Session
+}
@@ -0,0 +1,47 @@
+package drink_ledger
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// CreateDrinkTransferInput contains parameters for saving a pending drink transfer
+type CreateDrinkTransferInput struct {
+	// Transfer is the transfer to save, it's dropped once it expires
+	Transfer *models.DrinkTransfer
+}
+
+// GetDrinkTransferInput contains parameters for retrieving a pending drink transfer
+type GetDrinkTransferInput struct {
+	// TransferID is the ID of the transfer
+	TransferID string
+}
+
+// GetDrinkTransferOutput contains the result of retrieving a pending drink transfer
+type GetDrinkTransferOutput struct {
+	// Transfer is the pending transfer
+	Transfer *models.DrinkTransfer
+}
+
+// DeleteDrinkTransferInput contains parameters for removing a pending drink transfer
+type DeleteDrinkTransferInput struct {
+	// TransferID is the ID of the transfer
+	TransferID string
+}
+
+// TransferDrinkInput contains parameters for handing a drink to another player
+type TransferDrinkInput struct {
+	// DrinkID is the ID of the drink to hand over
+	DrinkID string
+
+	// FromPlayerID is the ID of the player who must still owe the drink
+	FromPlayerID string
+
+	// ToPlayerID is the ID of the player taking the drink
+	ToPlayerID string
+}
+
+// TransferDrinkOutput contains the result of handing a drink to another player
+type TransferDrinkOutput struct {
+	// Record is the drink record under its new owner
+	Record *models.DrinkLedger
+}
@@ -33,16 +33,32 @@ type GetDrinkRecordsForPlayerOutput struct {
 // MarkDrinkPaidInput contains parameters for marking a drink as paid
 type MarkDrinkPaidInput struct {
 	DrinkID string
+	PaidAt  time.Time // When the drink was paid, defaults to now
+}
+
+// ForgiveDrinkInput contains parameters for writing off a drink
+type ForgiveDrinkInput struct {
+	DrinkID    string
+	ForgivenBy string    // ID of the admin forgiving the drink
+	Reason     string    // Why the drink was forgiven
+	ForgivenAt time.Time // When the drink was forgiven, defaults to now
 }
 
 // CreateDrinkRecordInput contains parameters for creating a new drink record
 type CreateDrinkRecordInput struct {
-	GameID       string
-	FromPlayerID string // Empty for system-assigned drinks
-	ToPlayerID   string
-	Reason       models.DrinkReason
-	Timestamp    time.Time
-	SessionID    string // ID of the session this drink belongs to
+	GameID              string
+	FromPlayerID        string // Empty for system-assigned drinks
+	ToPlayerID          string
+	Reason              models.DrinkReason
+	Timestamp           time.Time
+	SessionID           string  // ID of the session this drink belongs to
+	GuildID             string  // Discord server the drink was assigned in
+	Social              bool    // Waived by the mercy rule, recorded but not owed
+	OwedLater           bool    // Given to a designated driver, owed once they stop driving
+	CoveredFor          string  // ID of the designated driver a substitute is drinking for
+	PaidWithCredit      bool    // Offset by a credit the recipient banked, recorded as already paid
+	HappyHourMultiplier float64 // How many times the drink counted for being given during happy hour
+	GuestName           string  // Display name of a channel member given the drink while watching the game
 }
 
 // CreateDrinkRecordOutput contains the result of creating a new drink record
@@ -61,3 +77,41 @@ type DeleteDrinkRecordsInput struct {
 	// GameID is the ID of the game to delete drink records for
 	GameID string
 }
+
+// DeleteDrinkRecordInput contains parameters for deleting a single drink record
+type DeleteDrinkRecordInput struct {
+	// DrinkID is the ID of the drink record to delete
+	DrinkID string
+}
+
+// ReassignPlayerRecordsInput contains parameters for moving a player's drink records to another player
+type ReassignPlayerRecordsInput struct {
+	// FromPlayerID is the ID of the player whose records are moved
+	FromPlayerID string
+
+	// ToPlayerID is the ID of the player who takes over the records
+	ToPlayerID string
+
+	// GuildID limits the reassignment to the drinks given in the server and its sessions' credits (optional),
+	// everything is moved when it's empty
+	GuildID string
+}
+
+// ReassignPlayerRecordsOutput contains the result of moving a player's drink records
+type ReassignPlayerRecordsOutput struct {
+	// RecordsMoved is the number of drink records rewritten
+	RecordsMoved int
+
+	// CreditsMoved is the number of unspent drink credits moved, across every session reassigned
+	CreditsMoved int
+}
+
+// ListDrinkRecordsInput contains parameters for listing every drink record
+type ListDrinkRecordsInput struct {
+}
+
+// ListDrinkRecordsOutput contains every drink record in the ledger
+type ListDrinkRecordsOutput struct {
+	// Records is every drink record, in no particular order
+	Records []*models.DrinkLedger
+}
@@ -0,0 +1,19 @@
+package feature_flag
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/feature_flag Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for persisting feature flags, set for the whole bot or overridden per guild
+type Repository interface {
+	// GetFeatureFlags reads the flags set for the whole bot and the ones overridden by a guild
+	GetFeatureFlags(ctx context.Context, input *GetFeatureFlagsInput) (*GetFeatureFlagsOutput, error)
+
+	// SetFeatureFlag turns a flag on or off for a guild, or for the whole bot when no guild is given
+	SetFeatureFlag(ctx context.Context, input *SetFeatureFlagInput) error
+
+	// ClearFeatureFlag removes a guild's override of a flag, or the bot's setting when no guild is given
+	ClearFeatureFlag(ctx context.Context, input *ClearFeatureFlagInput) error
+}
@@ -0,0 +1,145 @@
+package feature_flag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// botFeatureFlagsKey is the hash holding the flags set for the whole bot, one field per flag
+	botFeatureFlagsKey = "feature_flags"
+
+	// guildFeatureFlagsKeyPrefix is the prefix for the hashes holding a guild's overrides
+	guildFeatureFlagsKeyPrefix = "feature_flags:guild:"
+)
+
+// ErrInvalidValue is returned when a flag in Redis isn't set to true or false
+var ErrInvalidValue = errors.New("invalid feature flag value")
+
+// Config holds configuration for the Redis feature flag repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed feature flag repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// GetFeatureFlags reads the flags set for the whole bot and the ones overridden by a guild
+func (r *redisRepository) GetFeatureFlags(ctx context.Context, input *GetFeatureFlagsInput) (*GetFeatureFlagsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	botFlags, err := r.getFlags(ctx, botFeatureFlagsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	guildFlags := map[models.FeatureFlag]bool{}
+	if input.GuildID != "" {
+		guildFlags, err = r.getFlags(ctx, guildFeatureFlagsKeyPrefix+input.GuildID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &GetFeatureFlagsOutput{
+		BotFlags:   botFlags,
+		GuildFlags: guildFlags,
+	}, nil
+}
+
+// SetFeatureFlag turns a flag on or off for a guild, or for the whole bot when no guild is given
+func (r *redisRepository) SetFeatureFlag(ctx context.Context, input *SetFeatureFlagInput) error {
+	if input == nil {
+		return errors.New("input cannot be nil")
+	}
+
+	if input.Flag == "" {
+		return errors.New("flag cannot be empty")
+	}
+
+	if err := r.client.HSet(ctx, flagsKey(input.GuildID), string(input.Flag), strconv.FormatBool(input.Enabled)).Err(); err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
+	return nil
+}
+
+// ClearFeatureFlag removes a guild's override of a flag, or the bot's setting when no guild is given
+func (r *redisRepository) ClearFeatureFlag(ctx context.Context, input *ClearFeatureFlagInput) error {
+	if input == nil {
+		return errors.New("input cannot be nil")
+	}
+
+	if input.Flag == "" {
+		return errors.New("flag cannot be empty")
+	}
+
+	if err := r.client.HDel(ctx, flagsKey(input.GuildID), string(input.Flag)).Err(); err != nil {
+		return fmt.Errorf("failed to clear feature flag: %w", err)
+	}
+
+	return nil
+}
+
+// getFlags reads a flags hash, fields that aren't known flags are ignored
+func (r *redisRepository) getFlags(ctx context.Context, key string) (map[models.FeatureFlag]bool, error) {
+	fields, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
+
+	flags := make(map[models.FeatureFlag]bool, len(fields))
+	for field, value := range fields {
+		flag := models.FeatureFlag(field)
+		if !flag.IsValid() {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s is %q", ErrInvalidValue, field, value)
+		}
+		flags[flag] = enabled
+	}
+
+	return flags, nil
+}
+
+// flagsKey returns the hash holding a guild's overrides, or the bot's flags when no guild is given
+func flagsKey(guildID string) string {
+	if guildID == "" {
+		return botFeatureFlagsKey
+	}
+	return guildFeatureFlagsKeyPrefix + guildID
+}
@@ -0,0 +1,104 @@
+package feature_flag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	repo   Repository
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestSetAndGetFeatureFlags() {
+	ctx := context.Background()
+
+	// Nothing set yet
+	output, err := s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{GuildID: "guild1"})
+	s.Require().NoError(err)
+	s.Empty(output.BotFlags)
+	s.Empty(output.GuildFlags)
+
+	s.Require().NoError(s.repo.SetFeatureFlag(ctx, &SetFeatureFlagInput{
+		Flag:    models.FeatureFlagLLMMessages,
+		Enabled: false,
+	}))
+	s.Require().NoError(s.repo.SetFeatureFlag(ctx, &SetFeatureFlagInput{
+		GuildID: "guild1",
+		Flag:    models.FeatureFlagRollOffs,
+		Enabled: false,
+	}))
+
+	output, err = s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{GuildID: "guild1"})
+	s.Require().NoError(err)
+	s.Equal(map[models.FeatureFlag]bool{models.FeatureFlagLLMMessages: false}, output.BotFlags)
+	s.Equal(map[models.FeatureFlag]bool{models.FeatureFlagRollOffs: false}, output.GuildFlags)
+
+	// Other guilds only see the bot's flags
+	output, err = s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{GuildID: "guild2"})
+	s.Require().NoError(err)
+	s.Len(output.BotFlags, 1)
+	s.Empty(output.GuildFlags)
+
+	s.Require().NoError(s.repo.ClearFeatureFlag(ctx, &ClearFeatureFlagInput{
+		GuildID: "guild1",
+		Flag:    models.FeatureFlagRollOffs,
+	}))
+
+	output, err = s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{GuildID: "guild1"})
+	s.Require().NoError(err)
+	s.Empty(output.GuildFlags)
+	s.Len(output.BotFlags, 1, "clearing a guild's override leaves the bot's flags alone")
+}
+
+func (s *RedisRepositoryTestSuite) TestGetFeatureFlags_SetByHand() {
+	ctx := context.Background()
+
+	s.mr.HSet(botFeatureFlagsKey,
+		string(models.FeatureFlagAnimations), "0",
+		"not_a_flag", "ignored",
+	)
+
+	output, err := s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{})
+	s.Require().NoError(err)
+	s.Equal(map[models.FeatureFlag]bool{models.FeatureFlagAnimations: false}, output.BotFlags)
+
+	s.mr.HSet(botFeatureFlagsKey, string(models.FeatureFlagAnimations), "sometimes")
+	_, err = s.repo.GetFeatureFlags(ctx, &GetFeatureFlagsInput{})
+	s.ErrorIs(err, ErrInvalidValue)
+}
@@ -0,0 +1,30 @@
+package feature_flag
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// GetFeatureFlagsInput contains parameters for reading feature flags
+type GetFeatureFlagsInput struct {
+	// GuildID is optional, without it only the bot's flags are read
+	GuildID string
+}
+
+// GetFeatureFlagsOutput contains the flags that have been set, flags nobody set are missing
+type GetFeatureFlagsOutput struct {
+	BotFlags   map[models.FeatureFlag]bool
+	GuildFlags map[models.FeatureFlag]bool
+}
+
+// SetFeatureFlagInput contains parameters for turning a flag on or off
+type SetFeatureFlagInput struct {
+	// GuildID is empty to set the flag for the whole bot
+	GuildID string
+	Flag    models.FeatureFlag
+	Enabled bool
+}
+
+// ClearFeatureFlagInput contains parameters for removing a flag's setting
+type ClearFeatureFlagInput struct {
+	// GuildID is empty to clear the bot's setting
+	GuildID string
+	Flag    models.FeatureFlag
+}
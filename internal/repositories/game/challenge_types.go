@@ -0,0 +1,29 @@
+package game
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// CreateChallengeInput contains parameters for saving a pending challenge
+type CreateChallengeInput struct {
+	// Challenge is the challenge to save, it's dropped once it expires
+	Challenge *models.ChallengeGame
+}
+
+// GetChallengeInput contains parameters for retrieving a pending challenge
+type GetChallengeInput struct {
+	// ChallengeID is the ID of the challenge
+	ChallengeID string
+}
+
+// GetChallengeOutput contains the result of retrieving a pending challenge
+type GetChallengeOutput struct {
+	// Challenge is the pending challenge
+	Challenge *models.ChallengeGame
+}
+
+// DeleteChallengeInput contains parameters for removing a pending challenge
+type DeleteChallengeInput struct {
+	// ChallengeID is the ID of the challenge
+	ChallengeID string
+}
@@ -13,18 +13,28 @@ type Repository interface {
 	// SaveGame persists a game
 	SaveGame(ctx context.Context, input *SaveGameInput) error
 	
-	// GetGame retrieves a game by ID
+	// GetGame retrieves a game by ID, only from the input's guild when CheckGuild is set
 	GetGame(ctx context.Context, input *GetGameInput) (*models.Game, error)
 	
-	// GetGameByChannel retrieves a game by channel ID
+	// GetGameByChannel retrieves the game most recently saved in a channel, whatever its status, as long as it
+	// belongs to the input's guild
 	GetGameByChannel(ctx context.Context, input *GetGameByChannelInput) (*models.Game, error)
 	
-	// DeleteGame removes a game
+	// GetActiveGameByChannel retrieves the newest waiting, active or roll-off game in a channel
+	GetActiveGameByChannel(ctx context.Context, input *GetActiveGameByChannelInput) (*models.Game, error)
+	
+	// GetLatestCompletedGameByChannel retrieves the most recently completed game in a channel
+	GetLatestCompletedGameByChannel(ctx context.Context, input *GetLatestCompletedGameByChannelInput) (*models.Game, error)
+	
+	// DeleteGame removes a game for good, which is left to the retention policy
 	DeleteGame(ctx context.Context, input *DeleteGameInput) error
 	
 	// GetActiveGames retrieves all active games
 	GetActiveGames(ctx context.Context, input *GetActiveGamesInput) (*GetActiveGamesOutput, error)
 	
+	// GetUnfinishedGames retrieves the top-level games not yet over in every channel, including those waiting to start
+	GetUnfinishedGames(ctx context.Context, input *GetUnfinishedGamesInput) (*GetUnfinishedGamesOutput, error)
+	
 	// GetGamesByParent retrieves all games with a specific parent game ID
 	GetGamesByParent(ctx context.Context, input *GetGamesByParentInput) ([]*models.Game, error)
 	
@@ -36,4 +46,34 @@ type Repository interface {
 	
 	// CreateParticipant creates a new participant with a generated UUID
 	CreateParticipant(ctx context.Context, input *CreateParticipantInput) (*CreateParticipantOutput, error)
+	
+	// HasParticipant checks whether a player is participating in a game
+	HasParticipant(ctx context.Context, input *HasParticipantInput) (*HasParticipantOutput, error)
+	
+	// RecordRoll atomically records a participant's roll, failing with ErrRollAlreadyRecorded if they've already rolled
+	RecordRoll(ctx context.Context, input *RecordRollInput) (*RecordRollOutput, error)
+	
+	// GetGamesByPlayer retrieves every game that refers to a player, however long ago it was played
+	GetGamesByPlayer(ctx context.Context, input *GetGamesByPlayerInput) (*GetGamesByPlayerOutput, error)
+	
+	// GetArchivedGames retrieves the IDs of the games abandoned before a time
+	GetArchivedGames(ctx context.Context, input *GetArchivedGamesInput) (*GetArchivedGamesOutput, error)
+	
+	// CreateChallenge saves a pending challenge until it expires
+	CreateChallenge(ctx context.Context, input *CreateChallengeInput) error
+	
+	// GetChallenge retrieves a pending challenge
+	GetChallenge(ctx context.Context, input *GetChallengeInput) (*GetChallengeOutput, error)
+	
+	// DeleteChallenge removes a pending challenge, failing if it was already removed
+	DeleteChallenge(ctx context.Context, input *DeleteChallengeInput) error
+	
+	// CreateSideBet saves a side bet until the player rolls or it expires, failing if the bettor already has one on the roll
+	CreateSideBet(ctx context.Context, input *CreateSideBetInput) error
+	
+	// TakeSideBets removes and returns the side bets on a player's roll
+	TakeSideBets(ctx context.Context, input *TakeSideBetsInput) (*TakeSideBetsOutput, error)
+
+	// DeleteSideBetsByPlayer removes the side bets a player made and the ones on their roll
+	DeleteSideBetsByPlayer(ctx context.Context, input *DeleteSideBetsByPlayerInput) (*DeleteSideBetsByPlayerOutput, error)
 }
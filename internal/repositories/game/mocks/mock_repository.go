@@ -40,6 +40,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateChallenge mocks base method.
+func (m *MockRepository) CreateChallenge(arg0 context.Context, arg1 *game.CreateChallengeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockRepositoryMockRecorder) CreateChallenge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockRepository)(nil).CreateChallenge), arg0, arg1)
+}
+
 // CreateGame mocks base method.
 func (m *MockRepository) CreateGame(arg0 context.Context, arg1 *game.CreateGameInput) (*game.CreateGameOutput, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +99,34 @@ func (mr *MockRepositoryMockRecorder) CreateRollOffGame(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRollOffGame", reflect.TypeOf((*MockRepository)(nil).CreateRollOffGame), arg0, arg1)
 }
 
+// CreateSideBet mocks base method.
+func (m *MockRepository) CreateSideBet(arg0 context.Context, arg1 *game.CreateSideBetInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSideBet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSideBet indicates an expected call of CreateSideBet.
+func (mr *MockRepositoryMockRecorder) CreateSideBet(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSideBet", reflect.TypeOf((*MockRepository)(nil).CreateSideBet), arg0, arg1)
+}
+
+// DeleteChallenge mocks base method.
+func (m *MockRepository) DeleteChallenge(arg0 context.Context, arg1 *game.DeleteChallengeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChallenge indicates an expected call of DeleteChallenge.
+func (mr *MockRepositoryMockRecorder) DeleteChallenge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChallenge", reflect.TypeOf((*MockRepository)(nil).DeleteChallenge), arg0, arg1)
+}
+
 // DeleteGame mocks base method.
 func (m *MockRepository) DeleteGame(arg0 context.Context, arg1 *game.DeleteGameInput) error {
 	m.ctrl.T.Helper()
@@ -99,6 +141,36 @@ func (mr *MockRepositoryMockRecorder) DeleteGame(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGame", reflect.TypeOf((*MockRepository)(nil).DeleteGame), arg0, arg1)
 }
 
+// DeleteSideBetsByPlayer mocks base method.
+func (m *MockRepository) DeleteSideBetsByPlayer(arg0 context.Context, arg1 *game.DeleteSideBetsByPlayerInput) (*game.DeleteSideBetsByPlayerOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSideBetsByPlayer", arg0, arg1)
+	ret0, _ := ret[0].(*game.DeleteSideBetsByPlayerOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSideBetsByPlayer indicates an expected call of DeleteSideBetsByPlayer.
+func (mr *MockRepositoryMockRecorder) DeleteSideBetsByPlayer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSideBetsByPlayer", reflect.TypeOf((*MockRepository)(nil).DeleteSideBetsByPlayer), arg0, arg1)
+}
+
+// GetActiveGameByChannel mocks base method.
+func (m *MockRepository) GetActiveGameByChannel(arg0 context.Context, arg1 *game.GetActiveGameByChannelInput) (*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveGameByChannel", arg0, arg1)
+	ret0, _ := ret[0].(*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveGameByChannel indicates an expected call of GetActiveGameByChannel.
+func (mr *MockRepositoryMockRecorder) GetActiveGameByChannel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveGameByChannel", reflect.TypeOf((*MockRepository)(nil).GetActiveGameByChannel), arg0, arg1)
+}
+
 // GetActiveGames mocks base method.
 func (m *MockRepository) GetActiveGames(arg0 context.Context, arg1 *game.GetActiveGamesInput) (*game.GetActiveGamesOutput, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +186,36 @@ func (mr *MockRepositoryMockRecorder) GetActiveGames(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveGames", reflect.TypeOf((*MockRepository)(nil).GetActiveGames), arg0, arg1)
 }
 
+// GetArchivedGames mocks base method.
+func (m *MockRepository) GetArchivedGames(arg0 context.Context, arg1 *game.GetArchivedGamesInput) (*game.GetArchivedGamesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedGames", arg0, arg1)
+	ret0, _ := ret[0].(*game.GetArchivedGamesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedGames indicates an expected call of GetArchivedGames.
+func (mr *MockRepositoryMockRecorder) GetArchivedGames(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedGames", reflect.TypeOf((*MockRepository)(nil).GetArchivedGames), arg0, arg1)
+}
+
+// GetChallenge mocks base method.
+func (m *MockRepository) GetChallenge(arg0 context.Context, arg1 *game.GetChallengeInput) (*game.GetChallengeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", arg0, arg1)
+	ret0, _ := ret[0].(*game.GetChallengeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockRepositoryMockRecorder) GetChallenge(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockRepository)(nil).GetChallenge), arg0, arg1)
+}
+
 // GetGame mocks base method.
 func (m *MockRepository) GetGame(arg0 context.Context, arg1 *game.GetGameInput) (*models.Game, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +261,81 @@ func (mr *MockRepositoryMockRecorder) GetGamesByParent(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGamesByParent", reflect.TypeOf((*MockRepository)(nil).GetGamesByParent), arg0, arg1)
 }
 
+// GetGamesByPlayer mocks base method.
+func (m *MockRepository) GetGamesByPlayer(arg0 context.Context, arg1 *game.GetGamesByPlayerInput) (*game.GetGamesByPlayerOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGamesByPlayer", arg0, arg1)
+	ret0, _ := ret[0].(*game.GetGamesByPlayerOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGamesByPlayer indicates an expected call of GetGamesByPlayer.
+func (mr *MockRepositoryMockRecorder) GetGamesByPlayer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGamesByPlayer", reflect.TypeOf((*MockRepository)(nil).GetGamesByPlayer), arg0, arg1)
+}
+
+// GetLatestCompletedGameByChannel mocks base method.
+func (m *MockRepository) GetLatestCompletedGameByChannel(arg0 context.Context, arg1 *game.GetLatestCompletedGameByChannelInput) (*models.Game, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestCompletedGameByChannel", arg0, arg1)
+	ret0, _ := ret[0].(*models.Game)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestCompletedGameByChannel indicates an expected call of GetLatestCompletedGameByChannel.
+func (mr *MockRepositoryMockRecorder) GetLatestCompletedGameByChannel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestCompletedGameByChannel", reflect.TypeOf((*MockRepository)(nil).GetLatestCompletedGameByChannel), arg0, arg1)
+}
+
+// GetUnfinishedGames mocks base method.
+func (m *MockRepository) GetUnfinishedGames(arg0 context.Context, arg1 *game.GetUnfinishedGamesInput) (*game.GetUnfinishedGamesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnfinishedGames", arg0, arg1)
+	ret0, _ := ret[0].(*game.GetUnfinishedGamesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnfinishedGames indicates an expected call of GetUnfinishedGames.
+func (mr *MockRepositoryMockRecorder) GetUnfinishedGames(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinishedGames", reflect.TypeOf((*MockRepository)(nil).GetUnfinishedGames), arg0, arg1)
+}
+
+// HasParticipant mocks base method.
+func (m *MockRepository) HasParticipant(arg0 context.Context, arg1 *game.HasParticipantInput) (*game.HasParticipantOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasParticipant", arg0, arg1)
+	ret0, _ := ret[0].(*game.HasParticipantOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasParticipant indicates an expected call of HasParticipant.
+func (mr *MockRepositoryMockRecorder) HasParticipant(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasParticipant", reflect.TypeOf((*MockRepository)(nil).HasParticipant), arg0, arg1)
+}
+
+// RecordRoll mocks base method.
+func (m *MockRepository) RecordRoll(arg0 context.Context, arg1 *game.RecordRollInput) (*game.RecordRollOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRoll", arg0, arg1)
+	ret0, _ := ret[0].(*game.RecordRollOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordRoll indicates an expected call of RecordRoll.
+func (mr *MockRepositoryMockRecorder) RecordRoll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRoll", reflect.TypeOf((*MockRepository)(nil).RecordRoll), arg0, arg1)
+}
+
 // SaveGame mocks base method.
 func (m *MockRepository) SaveGame(arg0 context.Context, arg1 *game.SaveGameInput) error {
 	m.ctrl.T.Helper()
@@ -172,3 +349,18 @@ func (mr *MockRepositoryMockRecorder) SaveGame(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGame", reflect.TypeOf((*MockRepository)(nil).SaveGame), arg0, arg1)
 }
+
+// TakeSideBets mocks base method.
+func (m *MockRepository) TakeSideBets(arg0 context.Context, arg1 *game.TakeSideBetsInput) (*game.TakeSideBetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSideBets", arg0, arg1)
+	ret0, _ := ret[0].(*game.TakeSideBetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSideBets indicates an expected call of TakeSideBets.
+func (mr *MockRepositoryMockRecorder) TakeSideBets(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSideBets", reflect.TypeOf((*MockRepository)(nil).TakeSideBets), arg0, arg1)
+}
@@ -5,33 +5,50 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
 const (
 	// Key prefixes for Redis
-	gameKeyPrefix    = "game:"
-	channelKeyPrefix = "channel:"
-	activeGamesKey   = "active_games"
-	parentChildIndex = "parent:child:index:" // Index for parent-child relationships
+	gameKeyPrefix          = "game:"
+	channelKeyPrefix       = "channel:"
+	channelActivePrefix    = "channel_active:"    // Sorted set of a channel's unfinished games by creation time
+	channelCompletedPrefix = "channel_completed:" // The channel's most recently completed game
+	activeGamesKey         = "active_games"
+	archivedGamesKey       = "archived_games"      // Sorted set of abandoned games by when they were abandoned
+	parentChildIndex       = "parent:child:index:" // Index for parent-child relationships
+	participantsKey        = "game_participants:"  // Set of the player IDs participating in a game
+	challengeKeyPrefix     = "challenge:"          // Pending challenges, dropped once they expire
+	sideBetsKeyPrefix      = "side_bets:"          // Hash of the side bets on a player's roll by bettor
 )
 
 // ErrGameNotFound is returned when a game is not found
 var ErrGameNotFound = errors.New("game not found")
 
+// ErrGameChanged is returned when a game is saved from a copy read before its last save
+var ErrGameChanged = errors.New("game changed since it was read")
+
 // Config holds configuration for the Redis game repository
 type Config struct {
 	// Redis client
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
+
+	// ReadClient is an optional read replica for the reads callers allow to lag behind
+	ReadClient redis.UniversalClient
 }
 
 // redisRepository implements the Repository interface using Redis
 type redisRepository struct {
-	client *redis.Client
+	client  redis.UniversalClient
+	replica redis.UniversalClient
 }
 
 // NewRedis creates a new Redis-backed game repository
@@ -50,60 +67,163 @@ func NewRedis(cfg *Config) (*redisRepository, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	if cfg.ReadClient != nil {
+		if err := cfg.ReadClient.Ping(context.Background()).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis replica: %w", err)
+		}
+	}
+
 	return &redisRepository{
-		client: cfg.RedisClient,
+		client:  cfg.RedisClient,
+		replica: cfg.ReadClient,
 	}, nil
 }
 
-// SaveGame persists a game to Redis
+// saveGameScript sets the game only if the stored game is still the version the caller read, so a save can't
+// overwrite a change made after the game was read, such as a roll, even one that gets in just before it
+var saveGameScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local version = cjson.decode(current).Version or 0
+	if version ~= tonumber(ARGV[2]) then
+		return redis.error_reply('game changed since it was read')
+	end
+end
+return redis.call('SET', KEYS[1], ARGV[1])
+`)
+
+// SaveGame persists a game to Redis. The game is only saved if it's still the version that was read, otherwise
+// ErrGameChanged is returned and the caller should read it again; the game's version goes up with every save.
 func (r *redisRepository) SaveGame(ctx context.Context, input *SaveGameInput) error {
 	if input == nil || input.Game == nil {
 		return errors.New("input and game cannot be nil")
 	}
 
+	// Keep the caller's participant index up to date with whatever they changed
+	input.Game.IndexParticipants()
+
+	gameKey := fmt.Sprintf("%s%s", gameKeyPrefix, input.Game.ID)
+	readVersion := input.Game.Version
+
+	// Refuse a stale copy before queuing anything. A unit of work's own earlier saves of the game aren't stored
+	// until it's applied, so in one only a newer stored version is stale.
+	storedVersion, err := r.storedVersion(ctx, gameKey)
+	if err != nil {
+		return err
+	}
+	if storedVersion > readVersion || (!transaction.InProgress(ctx) && storedVersion != readVersion) {
+		return ErrGameChanged
+	}
+
 	// Marshal the game to JSON
+	input.Game.Version = readVersion + 1
 	gameJSON, err := json.Marshal(input.Game)
 	if err != nil {
+		input.Game.Version = readVersion
 		return fmt.Errorf("failed to marshal game: %w", err)
 	}
 
-	// Create a Redis transaction
-	pipe := r.client.Pipeline()
+	// Save the game and its indexes together, as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		saveGameScript.Eval(ctx, pipe, []string{gameKey}, gameJSON, readVersion) // No expiration for now
+		r.queueGameIndexes(ctx, pipe, input.Game)
+		return nil
+	})
+	if err != nil {
+		input.Game.Version = readVersion
+		if strings.Contains(err.Error(), ErrGameChanged.Error()) {
+			return ErrGameChanged
+		}
+		return fmt.Errorf("failed to save game: %w", err)
+	}
 
-	// Save the game
-	gameKey := fmt.Sprintf("%s%s", gameKeyPrefix, input.Game.ID)
-	pipe.Set(ctx, gameKey, gameJSON, 0) // No expiration for now
+	// A unit of work that's dropped doesn't save the game, so the copy in hand is still the version read
+	transaction.OnDiscard(ctx, func(ctx context.Context) {
+		input.Game.Version = readVersion
+	})
+
+	return nil
+}
+
+// storedVersion returns the version of the stored game, 0 when it hasn't been saved yet
+func (r *redisRepository) storedVersion(ctx context.Context, gameKey string) (int64, error) {
+	gameJSON, err := r.client.Get(ctx, gameKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	var stored struct {
+		Version int64
+	}
+	if err := json.Unmarshal([]byte(gameJSON), &stored); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+
+	return stored.Version, nil
+}
+
+// queueGameIndexes queues the writes keeping the indexes of the game up to date
+func (r *redisRepository) queueGameIndexes(ctx context.Context, pipe redis.Pipeliner, game *models.Game) {
+	// Replace the set of participating players
+	participantsSetKey := fmt.Sprintf("%s%s", participantsKey, game.ID)
+	pipe.Del(ctx, participantsSetKey)
+	if len(game.Participants) > 0 {
+		playerIDs := make([]interface{}, 0, len(game.Participants))
+		for _, participant := range game.Participants {
+			playerIDs = append(playerIDs, participant.PlayerID)
+		}
+		pipe.SAdd(ctx, participantsSetKey, playerIDs...)
+	}
+
+	// Track the channel's unfinished and completed games, leaving roll-offs to be found through their parents
+	if game.ChannelID != "" && game.ParentGameID == "" {
+		channelActiveKey := fmt.Sprintf("%s%s", channelActivePrefix, game.ChannelID)
+		if game.Status.IsFinished() {
+			pipe.ZRem(ctx, channelActiveKey, game.ID)
+		} else {
+			pipe.ZAdd(ctx, channelActiveKey, redis.Z{
+				Score:  float64(game.CreatedAt.UnixNano()),
+				Member: game.ID,
+			})
+		}
+		if game.Status.IsCompleted() {
+			pipe.Set(ctx, fmt.Sprintf("%s%s", channelCompletedPrefix, game.ChannelID), game.ID, 0)
+		}
+	}
+
+	// Archive abandoned games until the retention policy deletes them
+	if game.Status.IsAbandoned() {
+		pipe.ZAdd(ctx, archivedGamesKey, redis.Z{
+			Score:  float64(game.UpdatedAt.UnixNano()),
+			Member: game.ID,
+		})
+	}
 
 	// If the game has a channel ID, update the channel-to-game mapping
-	if input.Game.ChannelID != "" {
-		channelKey := fmt.Sprintf("%s%s", channelKeyPrefix, input.Game.ChannelID)
-		pipe.Set(ctx, channelKey, input.Game.ID, 0)
+	if game.ChannelID != "" {
+		channelKey := fmt.Sprintf("%s%s", channelKeyPrefix, game.ChannelID)
+		pipe.Set(ctx, channelKey, game.ID, 0)
 	}
 
 	// If the game is active, add it to the active games set
-	if input.Game.Status == models.GameStatusActive || input.Game.Status == models.GameStatusRollOff {
-		pipe.SAdd(ctx, activeGamesKey, input.Game.ID)
+	if game.Status == models.GameStatusActive || game.Status == models.GameStatusRollOff {
+		pipe.SAdd(ctx, activeGamesKey, game.ID)
 	} else {
 		// If the game is not active, remove it from the active games set
-		pipe.SRem(ctx, activeGamesKey, input.Game.ID)
+		pipe.SRem(ctx, activeGamesKey, game.ID)
 	}
 
 	// Add the game to the parent-child index
-	if input.Game.ParentGameID != "" {
-		parentChildIndexKey := fmt.Sprintf("%s%s", parentChildIndex, input.Game.ParentGameID)
+	if game.ParentGameID != "" {
+		parentChildIndexKey := fmt.Sprintf("%s%s", parentChildIndex, game.ParentGameID)
 		pipe.ZAdd(ctx, parentChildIndexKey, redis.Z{
-			Score:  float64(input.Game.CreatedAt.UnixNano()),
-			Member: input.Game.ID,
+			Score:  float64(game.CreatedAt.UnixNano()),
+			Member: game.ID,
 		})
 	}
-
-	// Execute the transaction
-	_, err = pipe.Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to save game: %w", err)
-	}
-
-	return nil
 }
 
 // GetGame retrieves a game by ID from Redis
@@ -112,9 +232,14 @@ func (r *redisRepository) GetGame(ctx context.Context, input *GetGameInput) (*mo
 		return nil, errors.New("input and game ID cannot be empty")
 	}
 
-	// Get the game from Redis
+	// Get the game from Redis, or its replica when the caller allows it
 	gameKey := fmt.Sprintf("%s%s", gameKeyPrefix, input.GameID)
-	gameJSON, err := r.client.Get(ctx, gameKey).Result()
+	var gameJSON string
+	err := replica.Read(ctx, r.client, r.replica, func(client redis.UniversalClient) error {
+		var err error
+		gameJSON, err = client.Get(ctx, gameKey).Result()
+		return err
+	})
 	if err != nil {
 		if err == redis.Nil {
 			return nil, ErrGameNotFound
@@ -127,6 +252,12 @@ func (r *redisRepository) GetGame(ctx context.Context, input *GetGameInput) (*mo
 	if err := json.Unmarshal([]byte(gameJSON), &game); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
 	}
+	game.IndexParticipants()
+
+	// Another guild's game is no more visible than one that doesn't exist
+	if input.CheckGuild && game.GuildID != input.GuildID {
+		return nil, ErrGameNotFound
+	}
 
 	return &game, nil
 }
@@ -148,6 +279,88 @@ func (r *redisRepository) GetGameByChannel(ctx context.Context, input *GetGameBy
 	}
 
 	// Get the game using the game ID
+	return r.GetGame(ctx, &GetGameInput{
+		GameID:     gameID,
+		GuildID:    input.GuildID,
+		CheckGuild: true,
+	})
+}
+
+// GetActiveGameByChannel retrieves the newest unfinished game in a channel from Redis
+func (r *redisRepository) GetActiveGameByChannel(ctx context.Context, input *GetActiveGameByChannelInput) (*models.Game, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("input and channel ID cannot be empty")
+	}
+
+	channelActiveKey := fmt.Sprintf("%s%s", channelActivePrefix, input.ChannelID)
+	gameIDs, err := r.client.ZRevRange(ctx, channelActiveKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active games for channel: %w", err)
+	}
+
+	for _, gameID := range gameIDs {
+		game, err := r.GetGame(ctx, &GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrGameNotFound) {
+				// The game was removed without going through DeleteGame
+				r.client.ZRem(ctx, channelActiveKey, gameID)
+				continue
+			}
+			return nil, err
+		}
+
+		// Skip games finished without going through SaveGame
+		if game.Status.IsFinished() {
+			continue
+		}
+
+		return game, nil
+	}
+
+	// Channels last played before unfinished games were tracked only have the channel mapping
+	if len(gameIDs) == 0 {
+		game, err := r.GetGameByChannel(ctx, &GetGameByChannelInput{
+			ChannelID: input.ChannelID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if game.ParentGameID == "" && !game.Status.IsFinished() {
+			return game, nil
+		}
+	}
+
+	return nil, ErrGameNotFound
+}
+
+// GetLatestCompletedGameByChannel retrieves the most recently completed game in a channel from Redis
+func (r *redisRepository) GetLatestCompletedGameByChannel(ctx context.Context, input *GetLatestCompletedGameByChannelInput) (*models.Game, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("input and channel ID cannot be empty")
+	}
+
+	channelCompletedKey := fmt.Sprintf("%s%s", channelCompletedPrefix, input.ChannelID)
+	gameID, err := r.client.Get(ctx, channelCompletedKey).Result()
+	if err != nil {
+		if err != redis.Nil {
+			return nil, fmt.Errorf("failed to get completed game for channel: %w", err)
+		}
+
+		// Channels last played before completed games were tracked only have the channel mapping
+		game, err := r.GetGameByChannel(ctx, &GetGameByChannelInput{
+			ChannelID: input.ChannelID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if game.ParentGameID == "" && game.Status.IsCompleted() {
+			return game, nil
+		}
+		return nil, ErrGameNotFound
+	}
+
 	return r.GetGame(ctx, &GetGameInput{
 		GameID: gameID,
 	})
@@ -174,14 +387,25 @@ func (r *redisRepository) DeleteGame(ctx context.Context, input *DeleteGameInput
 	gameKey := fmt.Sprintf("%s%s", gameKeyPrefix, input.GameID)
 	pipe.Del(ctx, gameKey)
 
-	// If the game has a channel ID, delete the channel-to-game mapping
+	// If the game has a channel ID, delete the channel-to-game mappings that still point at it
 	if game.ChannelID != "" {
-		channelKey := fmt.Sprintf("%s%s", channelKeyPrefix, game.ChannelID)
-		pipe.Del(ctx, channelKey)
+		for _, channelKey := range []string{
+			fmt.Sprintf("%s%s", channelKeyPrefix, game.ChannelID),
+			fmt.Sprintf("%s%s", channelCompletedPrefix, game.ChannelID),
+		} {
+			if mappedID, err := r.client.Get(ctx, channelKey).Result(); err == nil && mappedID == input.GameID {
+				pipe.Del(ctx, channelKey)
+			}
+		}
+		pipe.ZRem(ctx, fmt.Sprintf("%s%s", channelActivePrefix, game.ChannelID), input.GameID)
 	}
 
-	// Remove the game from the active games set
+	// Delete the set of participating players
+	pipe.Del(ctx, fmt.Sprintf("%s%s", participantsKey, input.GameID))
+
+	// Remove the game from the active and archived games sets
 	pipe.SRem(ctx, activeGamesKey, input.GameID)
+	pipe.ZRem(ctx, archivedGamesKey, input.GameID)
 
 	// Remove the game from the parent-child index
 	if game.ParentGameID != "" {
@@ -244,6 +468,7 @@ func (r *redisRepository) GetActiveGames(ctx context.Context, input *GetActiveGa
 		if err := json.Unmarshal([]byte(gameJSON), &game); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal game %s: %w", gameID, err)
 		}
+		game.IndexParticipants()
 
 		games = append(games, &game)
 	}
@@ -253,6 +478,45 @@ func (r *redisRepository) GetActiveGames(ctx context.Context, input *GetActiveGa
 	}, nil
 }
 
+// GetUnfinishedGames retrieves the top-level games not yet over from every channel's unfinished games in Redis
+func (r *redisRepository) GetUnfinishedGames(ctx context.Context, input *GetUnfinishedGamesInput) (*GetUnfinishedGamesOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	channelKeys, err := scan.Keys(ctx, r.client, channelActivePrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan unfinished games: %w", err)
+	}
+
+	games := []*models.Game{}
+	for _, channelKey := range channelKeys {
+		gameIDs, err := r.client.ZRange(ctx, channelKey, 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get unfinished games in %s: %w", channelKey, err)
+		}
+
+		for _, gameID := range gameIDs {
+			game, err := r.GetGame(ctx, &GetGameInput{GameID: gameID})
+			if err != nil {
+				if errors.Is(err, ErrGameNotFound) {
+					// Deleted since the scan found it
+					continue
+				}
+				return nil, err
+			}
+			if game.Status.IsFinished() || (input.GuildID != "" && game.GuildID != input.GuildID) {
+				continue
+			}
+			games = append(games, game)
+		}
+	}
+
+	return &GetUnfinishedGamesOutput{
+		Games: games,
+	}, nil
+}
+
 // GetGamesByParent retrieves all games with a specific parent game ID from Redis
 func (r *redisRepository) GetGamesByParent(ctx context.Context, input *GetGamesByParentInput) ([]*models.Game, error) {
 	// Get the list of child game IDs for this parent
@@ -304,13 +568,32 @@ func (r *redisRepository) CreateGame(ctx context.Context, input *CreateGameInput
 	// Create the game
 	now := time.Now()
 	game := &models.Game{
-		ID:           gameID,
-		ChannelID:    input.ChannelID,
-		CreatorID:    input.CreatorID,
-		Status:       input.Status,
-		Participants: []*models.Participant{},
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:               gameID,
+		ChannelID:        input.ChannelID,
+		GuildID:          input.GuildID,
+		CreatorID:        input.CreatorID,
+		Status:           input.Status,
+		Participants:     []*models.Participant{},
+		Rules:            input.Rules,
+		Events:           input.Events,
+		LastCall:         input.LastCall,
+		Countdown:        input.Countdown,
+		Rounds:           input.Rounds,
+		Private:          input.Private,
+		InvitedPlayerIDs: input.InvitedPlayerIDs,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// Join the creator in the same write so the game never exists without them
+	if input.JoinCreator {
+		game.AddParticipant(&models.Participant{
+			ID:         uuid.New().String(),
+			GameID:     gameID,
+			PlayerID:   input.CreatorID,
+			PlayerName: input.CreatorName,
+			Status:     models.ParticipantStatusWaitingToRoll,
+		})
 	}
 
 	// Save the game
@@ -353,13 +636,19 @@ func (r *redisRepository) CreateRollOffGame(ctx context.Context, input *CreateRo
 	game := &models.Game{
 		ID:           gameID,
 		ChannelID:    input.ChannelID,
+		GuildID:      input.GuildID,
 		CreatorID:    input.CreatorID,
 		Status:       models.GameStatusRollOff,
 		ParentGameID: input.ParentGameID,
 		Participants: []*models.Participant{},
+		Rules:        input.Rules,
+		Events:       input.Events,
+		LastCall:     input.LastCall,
+		SuddenDeath:  input.SuddenDeath,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
+	game.IndexParticipants()
 
 	// Create participants for each player
 	for _, playerID := range input.PlayerIDs {
@@ -381,7 +670,7 @@ func (r *redisRepository) CreateRollOffGame(ctx context.Context, input *CreateRo
 			Status:     models.ParticipantStatusWaitingToRoll,
 		}
 
-		game.Participants = append(game.Participants, participant)
+		game.AddParticipant(participant)
 	}
 
 	// Save the game
@@ -415,10 +704,8 @@ func (r *redisRepository) CreateParticipant(ctx context.Context, input *CreatePa
 	}
 
 	// Check if the player is already a participant
-	for _, p := range game.Participants {
-		if p.PlayerID == input.PlayerID {
-			return nil, errors.New("player is already a participant")
-		}
+	if game.HasParticipant(input.PlayerID) {
+		return nil, errors.New("player is already a participant")
 	}
 
 	// Generate a new UUID for the participant
@@ -434,7 +721,7 @@ func (r *redisRepository) CreateParticipant(ctx context.Context, input *CreatePa
 	}
 
 	// Add the participant to the game
-	game.Participants = append(game.Participants, participant)
+	game.AddParticipant(participant)
 	game.UpdatedAt = time.Now()
 
 	// Save the updated game
@@ -445,3 +732,82 @@ func (r *redisRepository) CreateParticipant(ctx context.Context, input *CreatePa
 
 	return &CreateParticipantOutput{Participant: participant}, nil
 }
+
+// HasParticipant checks whether a player is participating in a game without loading the game
+func (r *redisRepository) HasParticipant(ctx context.Context, input *HasParticipantInput) (*HasParticipantOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("input, game ID and player ID cannot be empty")
+	}
+
+	participantsSetKey := fmt.Sprintf("%s%s", participantsKey, input.GameID)
+
+	pipe := r.client.Pipeline()
+	isMemberCmd := pipe.SIsMember(ctx, participantsSetKey, input.PlayerID)
+	existsCmd := pipe.Exists(ctx, participantsSetKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to check participant: %w", err)
+	}
+
+	if isMemberCmd.Val() {
+		return &HasParticipantOutput{IsParticipant: true}, nil
+	}
+	if existsCmd.Val() > 0 {
+		return &HasParticipantOutput{IsParticipant: false}, nil
+	}
+
+	// Games without participants, or saved before the set was kept, are checked the slow way
+	game, err := r.GetGame(ctx, &GetGameInput{GameID: input.GameID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HasParticipantOutput{IsParticipant: game.HasParticipant(input.PlayerID)}, nil
+}
+
+// GetGamesByPlayer retrieves every game that refers to a player by scanning all the games in Redis
+func (r *redisRepository) GetGamesByPlayer(ctx context.Context, input *GetGamesByPlayerInput) (*GetGamesByPlayerOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("input and player ID cannot be empty")
+	}
+
+	gameKeys, err := scan.Keys(ctx, r.client, gameKeyPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan games: %w", err)
+	}
+
+	games := []*models.Game{}
+	for _, gameKey := range gameKeys {
+		game, err := r.GetGame(ctx, &GetGameInput{GameID: strings.TrimPrefix(gameKey, gameKeyPrefix)})
+		if err != nil {
+			if errors.Is(err, ErrGameNotFound) {
+				// Deleted since the scan found it
+				continue
+			}
+			return nil, err
+		}
+		if game.InvolvesPlayer(input.PlayerID) {
+			games = append(games, game)
+		}
+	}
+
+	return &GetGamesByPlayerOutput{
+		Games: games,
+	}, nil
+}
+
+// GetArchivedGames retrieves the IDs of the games abandoned before a time
+func (r *redisRepository) GetArchivedGames(ctx context.Context, input *GetArchivedGamesInput) (*GetArchivedGamesOutput, error) {
+	if input == nil || input.ArchivedBefore.IsZero() {
+		return nil, errors.New("input and archived before time cannot be empty")
+	}
+
+	gameIDs, err := r.client.ZRangeByScore(ctx, archivedGamesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("(%d", input.ArchivedBefore.UnixNano()),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived games: %w", err)
+	}
+
+	return &GetArchivedGamesOutput{GameIDs: gameIDs}, nil
+}
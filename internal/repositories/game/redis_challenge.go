@@ -0,0 +1,84 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrChallengeNotFound is returned when a challenge doesn't exist, has expired or was already answered
+var ErrChallengeNotFound = errors.New("challenge not found")
+
+// CreateChallenge saves a pending challenge until it expires
+func (r *redisRepository) CreateChallenge(ctx context.Context, input *CreateChallengeInput) error {
+	if input == nil || input.Challenge == nil {
+		return errors.New("input and challenge cannot be nil")
+	}
+
+	challenge := input.Challenge
+	if challenge.ID == "" {
+		return errors.New("challenge ID cannot be empty")
+	}
+
+	ttl := challenge.ExpiresAt.Sub(challenge.CreatedAt)
+	if ttl <= 0 {
+		return errors.New("challenge must expire after it's created")
+	}
+
+	challengeJSON, err := json.Marshal(challenge)
+	if err != nil {
+		return fmt.Errorf("failed to marshal challenge: %w", err)
+	}
+
+	if err := r.client.Set(ctx, challengeKeyPrefix+challenge.ID, challengeJSON, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetChallenge retrieves a pending challenge
+func (r *redisRepository) GetChallenge(ctx context.Context, input *GetChallengeInput) (*GetChallengeOutput, error) {
+	if input == nil || input.ChallengeID == "" {
+		return nil, errors.New("input and challenge ID cannot be empty")
+	}
+
+	challengeJSON, err := r.client.Get(ctx, challengeKeyPrefix+input.ChallengeID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	var challenge models.ChallengeGame
+	if err := json.Unmarshal([]byte(challengeJSON), &challenge); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal challenge: %w", err)
+	}
+
+	return &GetChallengeOutput{
+		Challenge: &challenge,
+	}, nil
+}
+
+// DeleteChallenge removes a pending challenge, only one caller gets to remove it
+// so an answered challenge can't be answered again
+func (r *redisRepository) DeleteChallenge(ctx context.Context, input *DeleteChallengeInput) error {
+	if input == nil || input.ChallengeID == "" {
+		return errors.New("input and challenge ID cannot be empty")
+	}
+
+	deleted, err := r.client.Del(ctx, challengeKeyPrefix+input.ChallengeID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+	if deleted == 0 {
+		return ErrChallengeNotFound
+	}
+
+	return nil
+}
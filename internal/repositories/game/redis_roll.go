@@ -0,0 +1,101 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrRollAlreadyRecorded is returned when a player has already rolled in a game
+var ErrRollAlreadyRecorded = errors.New("roll already recorded")
+
+// ErrParticipantNotFound is returned when a player isn't participating in a game
+var ErrParticipantNotFound = errors.New("participant not found")
+
+// recordRollAttempts is how many times recording a roll is tried when other writes to the game keep getting in first
+const recordRollAttempts = 10
+
+// RecordRoll records a participant's roll if they haven't rolled yet, so two rolls can't both get past the check.
+// The game is read and written back under WATCH, so a roll recorded for another player in the meantime is kept
+// rather than overwritten, and its version goes up so a copy of the game read before the roll can't be saved.
+func (r *redisRepository) RecordRoll(ctx context.Context, input *RecordRollInput) (*RecordRollOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("input, game ID and player ID cannot be empty")
+	}
+
+	if input.RollTime.IsZero() || input.Status == "" {
+		return nil, errors.New("roll time and status cannot be empty")
+	}
+
+	gameKey := fmt.Sprintf("%s%s", gameKeyPrefix, input.GameID)
+	for attempt := 0; attempt < recordRollAttempts; attempt++ {
+		output, err := r.recordRoll(ctx, gameKey, input)
+		if errors.Is(err, redis.TxFailedErr) {
+			// The game changed after it was read, so read it again
+			continue
+		}
+		return output, err
+	}
+
+	return nil, fmt.Errorf("failed to record roll: game %s kept changing", input.GameID)
+}
+
+// recordRoll makes one attempt at recording a roll, failing with redis.TxFailedErr if the game changed meanwhile
+func (r *redisRepository) recordRoll(ctx context.Context, gameKey string, input *RecordRollInput) (*RecordRollOutput, error) {
+	var output *RecordRollOutput
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		gameJSON, err := tx.Get(ctx, gameKey).Result()
+		if err != nil {
+			if err == redis.Nil {
+				return ErrGameNotFound
+			}
+			return fmt.Errorf("failed to get game: %w", err)
+		}
+
+		var game models.Game
+		if err := json.Unmarshal([]byte(gameJSON), &game); err != nil {
+			return fmt.Errorf("failed to unmarshal game: %w", err)
+		}
+		game.IndexParticipants()
+
+		participant := game.GetParticipant(input.PlayerID)
+		if participant == nil {
+			return ErrParticipantNotFound
+		}
+		if participant.RollTime != nil {
+			return ErrRollAlreadyRecorded
+		}
+
+		rollTime := input.RollTime
+		participant.RollValue = input.RollValue
+		participant.RollTime = &rollTime
+		participant.RollModifiers = input.RollModifiers
+		participant.Status = input.Status
+		game.UpdatedAt = rollTime
+		game.Version++
+
+		updatedJSON, err := json.Marshal(&game)
+		if err != nil {
+			return fmt.Errorf("failed to marshal game: %w", err)
+		}
+
+		if _, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, gameKey, updatedJSON, 0)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+
+		output = &RecordRollOutput{Game: &game}
+		return nil
+	}, gameKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
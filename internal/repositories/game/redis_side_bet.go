@@ -0,0 +1,144 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrSideBetAlreadyPlaced is returned when a player already has a bet on another player's roll
+var ErrSideBetAlreadyPlaced = errors.New("side bet already placed")
+
+// sideBetsKey returns the key of the hash holding the side bets on a player's roll by bettor
+func sideBetsKey(gameID, targetPlayerID string) string {
+	return fmt.Sprintf("%s%s:%s", sideBetsKeyPrefix, gameID, targetPlayerID)
+}
+
+// CreateSideBet saves a side bet until the player rolls or it expires, one per bettor on each roll
+func (r *redisRepository) CreateSideBet(ctx context.Context, input *CreateSideBetInput) error {
+	if input == nil || input.Bet == nil {
+		return errors.New("input and bet cannot be nil")
+	}
+
+	bet := input.Bet
+	if bet.GameID == "" || bet.TargetPlayerID == "" || bet.BettorID == "" {
+		return errors.New("game, target player and bettor IDs cannot be empty")
+	}
+
+	ttl := bet.ExpiresAt.Sub(bet.CreatedAt)
+	if ttl <= 0 {
+		return errors.New("bet must expire after it's made")
+	}
+
+	betJSON, err := json.Marshal(bet)
+	if err != nil {
+		return fmt.Errorf("failed to marshal side bet: %w", err)
+	}
+
+	key := sideBetsKey(bet.GameID, bet.TargetPlayerID)
+	created, err := r.client.HSetNX(ctx, key, bet.BettorID, betJSON).Result()
+	if err != nil {
+		return fmt.Errorf("failed to save side bet: %w", err)
+	}
+	if !created {
+		return ErrSideBetAlreadyPlaced
+	}
+
+	// The bets go with the last of them to expire, earlier ones are checked against their own expiry
+	if err := r.client.Expire(ctx, key, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set side bet expiry: %w", err)
+	}
+
+	return nil
+}
+
+// TakeSideBets removes and returns the side bets on a player's roll so they're only settled once
+func (r *redisRepository) TakeSideBets(ctx context.Context, input *TakeSideBetsInput) (*TakeSideBetsOutput, error) {
+	if input == nil || input.GameID == "" || input.TargetPlayerID == "" {
+		return nil, errors.New("input, game ID and target player ID cannot be empty")
+	}
+
+	key := sideBetsKey(input.GameID, input.TargetPlayerID)
+
+	var betsCmd *redis.MapStringStringCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		betsCmd = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take side bets: %w", err)
+	}
+
+	bets := make([]*models.SideBet, 0, len(betsCmd.Val()))
+	for _, betJSON := range betsCmd.Val() {
+		var bet models.SideBet
+		if err := json.Unmarshal([]byte(betJSON), &bet); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal side bet: %w", err)
+		}
+		bets = append(bets, &bet)
+	}
+
+	return &TakeSideBetsOutput{
+		Bets: bets,
+	}, nil
+}
+
+// DeleteSideBetsByPlayer removes the side bets a player made and the ones on their roll, wherever they were made
+func (r *redisRepository) DeleteSideBetsByPlayer(ctx context.Context, input *DeleteSideBetsByPlayerInput) (*DeleteSideBetsByPlayerOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("input and player ID cannot be empty")
+	}
+
+	keys, err := scan.Keys(ctx, r.client, sideBetsKeyPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan side bets: %w", err)
+	}
+
+	deleted := 0
+	inGuild := make(map[string]bool)
+	for _, key := range keys {
+		if input.GuildID != "" {
+			gameID, _, _ := strings.Cut(strings.TrimPrefix(key, sideBetsKeyPrefix), ":")
+			if _, ok := inGuild[gameID]; !ok {
+				_, err := r.GetGame(ctx, &GetGameInput{GameID: gameID, GuildID: input.GuildID, CheckGuild: true})
+				if err != nil && !errors.Is(err, ErrGameNotFound) {
+					return nil, fmt.Errorf("failed to get side bet's game: %w", err)
+				}
+				inGuild[gameID] = err == nil
+			}
+			if !inGuild[gameID] {
+				continue
+			}
+		}
+
+		// The bets on the player's own roll all go
+		if strings.HasSuffix(key, ":"+input.PlayerID) {
+			count, err := r.client.HLen(ctx, key).Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to count side bets: %w", err)
+			}
+			if err := r.client.Del(ctx, key).Err(); err != nil {
+				return nil, fmt.Errorf("failed to delete side bets: %w", err)
+			}
+			deleted += int(count)
+			continue
+		}
+
+		count, err := r.client.HDel(ctx, key, input.PlayerID).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete side bet: %w", err)
+		}
+		deleted += int(count)
+	}
+
+	return &DeleteSideBetsByPlayerOutput{
+		DeletedCount: deleted,
+	}, nil
+}
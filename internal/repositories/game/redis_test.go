@@ -2,10 +2,15 @@ package game
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
@@ -123,6 +128,46 @@ func (s *RedisRepositoryTestSuite) TestGetGameByChannel() {
 	s.Equal("test-channel-id", retrievedGame.ChannelID)
 }
 
+func (s *RedisRepositoryTestSuite) TestGuildScopedReads() {
+	ctx := context.Background()
+
+	for _, game := range []*models.Game{
+		{ID: "guild-game", ChannelID: "guild-channel", GuildID: "guild-1", Status: models.GameStatusWaiting, CreatedAt: s.testNow},
+		{ID: "dm-game", ChannelID: "dm-channel", Status: models.GameStatusWaiting, CreatedAt: s.testNow},
+	} {
+		s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+	}
+
+	// Only the game's own guild sees it by ID
+	game, err := s.repo.GetGame(ctx, &GetGameInput{GameID: "guild-game", GuildID: "guild-1", CheckGuild: true})
+	s.Require().NoError(err)
+	s.Equal("guild-game", game.ID)
+
+	_, err = s.repo.GetGame(ctx, &GetGameInput{GameID: "guild-game", GuildID: "guild-2", CheckGuild: true})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.repo.GetGame(ctx, &GetGameInput{GameID: "guild-game", CheckGuild: true})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.repo.GetGame(ctx, &GetGameInput{GameID: "dm-game", GuildID: "guild-1", CheckGuild: true})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	// And by channel
+	game, err = s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "guild-channel", GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal("guild-game", game.ID)
+
+	_, err = s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "guild-channel", GuildID: "guild-2"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "guild-channel"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	game, err = s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "dm-channel"})
+	s.Require().NoError(err)
+	s.Equal("dm-game", game.ID)
+}
+
 func (s *RedisRepositoryTestSuite) TestGetActiveGames() {
 	// Create test games with different statuses
 	activeGame := &models.Game{
@@ -194,6 +239,32 @@ func (s *RedisRepositoryTestSuite) TestGetActiveGames() {
 	s.False(ok)
 }
 
+func (s *RedisRepositoryTestSuite) TestGetUnfinishedGames() {
+	games := []*models.Game{
+		{ID: "waiting-game-id", ChannelID: "channel-1", GuildID: "guild-1", Status: models.GameStatusWaiting, CreatedAt: s.testNow, UpdatedAt: s.testNow},
+		{ID: "active-game-id", ChannelID: "channel-2", GuildID: "guild-1", Status: models.GameStatusActive, CreatedAt: s.testNow, UpdatedAt: s.testNow},
+		{ID: "rolloff-game-id", ChannelID: "channel-2", GuildID: "guild-1", ParentGameID: "active-game-id", Status: models.GameStatusRollOff, CreatedAt: s.testNow, UpdatedAt: s.testNow},
+		{ID: "completed-game-id", ChannelID: "channel-3", GuildID: "guild-1", Status: models.GameStatusCompleted, CreatedAt: s.testNow, UpdatedAt: s.testNow},
+		{ID: "other-guild-game-id", ChannelID: "channel-4", GuildID: "guild-2", Status: models.GameStatusWaiting, CreatedAt: s.testNow, UpdatedAt: s.testNow},
+	}
+	for _, game := range games {
+		s.Require().NoError(s.repo.SaveGame(context.Background(), &SaveGameInput{Game: game}))
+	}
+
+	// Roll-offs are left to be found through their parents
+	result, err := s.repo.GetUnfinishedGames(context.Background(), &GetUnfinishedGamesInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	var gameIDs []string
+	for _, game := range result.Games {
+		gameIDs = append(gameIDs, game.ID)
+	}
+	s.ElementsMatch([]string{"waiting-game-id", "active-game-id"}, gameIDs)
+
+	result, err = s.repo.GetUnfinishedGames(context.Background(), &GetUnfinishedGamesInput{})
+	s.Require().NoError(err)
+	s.Len(result.Games, 3)
+}
+
 func (s *RedisRepositoryTestSuite) TestDeleteGame() {
 	// Create a test game
 	game := &models.Game{
@@ -429,3 +500,632 @@ func (s *RedisRepositoryTestSuite) TestGetGamesByParent() {
 	s.Require().Len(updatedChildGames, 1)
 	s.Equal("child-game-2", updatedChildGames[0].ID)
 }
+
+func (s *RedisRepositoryTestSuite) TestHasParticipant() {
+	ctx := context.Background()
+
+	createOutput, err := s.repo.CreateGame(ctx, &CreateGameInput{
+		ChannelID: "test-channel-id",
+		CreatorID: "test-creator-id",
+		Status:    models.GameStatusWaiting,
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.Game.ID
+
+	// A game without participants is checked against the game itself
+	output, err := s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: gameID, PlayerID: "test-player-id"})
+	s.Require().NoError(err)
+	s.False(output.IsParticipant)
+
+	_, err = s.repo.CreateParticipant(ctx, &CreateParticipantInput{
+		GameID:   gameID,
+		PlayerID: "test-player-id",
+		Status:   models.ParticipantStatusWaitingToRoll,
+	})
+	s.Require().NoError(err)
+
+	output, err = s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: gameID, PlayerID: "test-player-id"})
+	s.Require().NoError(err)
+	s.True(output.IsParticipant)
+
+	output, err = s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: gameID, PlayerID: "other-player-id"})
+	s.Require().NoError(err)
+	s.False(output.IsParticipant)
+
+	// Joining twice is still refused
+	_, err = s.repo.CreateParticipant(ctx, &CreateParticipantInput{
+		GameID:   gameID,
+		PlayerID: "test-player-id",
+	})
+	s.Error(err)
+
+	_, err = s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: "missing-game-id", PlayerID: "test-player-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestHasParticipant_GameSavedWithoutSet() {
+	ctx := context.Background()
+
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusWaiting,
+		Participants: []*models.Participant{
+			{ID: "test-participant-id", GameID: "test-game-id", PlayerID: "test-player-id"},
+		},
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	// Games saved before the participant set was kept still answer from the game
+	s.Require().NoError(s.client.Del(ctx, participantsKey+"test-game-id").Err())
+
+	output, err := s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: "test-game-id", PlayerID: "test-player-id"})
+	s.Require().NoError(err)
+	s.True(output.IsParticipant)
+}
+
+func (s *RedisRepositoryTestSuite) TestParticipantIndex() {
+	ctx := context.Background()
+
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusWaiting,
+		Participants: []*models.Participant{
+			{ID: "participant-1", GameID: "test-game-id", PlayerID: "player-1"},
+			{ID: "participant-2", GameID: "test-game-id", PlayerID: "player-2"},
+		},
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	retrievedGame, err := s.repo.GetGame(ctx, &GetGameInput{GameID: "test-game-id"})
+	s.Require().NoError(err)
+	s.Require().NotNil(retrievedGame.GetParticipant("player-2"))
+	s.Equal("participant-2", retrievedGame.GetParticipant("player-2").ID)
+	s.Nil(retrievedGame.GetParticipant("player-3"))
+
+	// Changing a participant's player is picked up once the game is saved
+	retrievedGame.GetParticipant("player-2").PlayerID = "player-3"
+	s.Nil(retrievedGame.GetParticipant("player-2"))
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: retrievedGame}))
+	s.Require().NotNil(retrievedGame.GetParticipant("player-3"))
+
+	output, err := s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: "test-game-id", PlayerID: "player-2"})
+	s.Require().NoError(err)
+	s.False(output.IsParticipant)
+
+	output, err = s.repo.HasParticipant(ctx, &HasParticipantInput{GameID: "test-game-id", PlayerID: "player-3"})
+	s.Require().NoError(err)
+	s.True(output.IsParticipant)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetActiveGameByChannel() {
+	ctx := context.Background()
+
+	_, err := s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusActive,
+		CreatedAt: s.testNow,
+		UpdatedAt: s.testNow,
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	// Roll-offs are saved last but the channel's game is the one they came from
+	rollOff := &models.Game{
+		ID:           "test-roll-off-id",
+		ChannelID:    "test-channel-id",
+		ParentGameID: "test-game-id",
+		Status:       models.GameStatusRollOff,
+		CreatedAt:    s.testNow.Add(time.Minute),
+		UpdatedAt:    s.testNow.Add(time.Minute),
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: rollOff}))
+
+	activeGame, err := s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("test-game-id", activeGame.ID)
+
+	_, err = s.repo.GetLatestCompletedGameByChannel(ctx, &GetLatestCompletedGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	// Completing the game moves it over
+	game.Status = models.GameStatusCompleted
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	_, err = s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	completedGame, err := s.repo.GetLatestCompletedGameByChannel(ctx, &GetLatestCompletedGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("test-game-id", completedGame.ID)
+
+	// The channel mapping still follows whatever was saved last
+	latestGame, err := s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("test-game-id", latestGame.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetActiveGameByChannel_NewestFirst() {
+	ctx := context.Background()
+
+	for i, gameID := range []string{"older-game-id", "newer-game-id"} {
+		s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: &models.Game{
+			ID:        gameID,
+			ChannelID: "test-channel-id",
+			Status:    models.GameStatusWaiting,
+			CreatedAt: s.testNow.Add(time.Duration(i) * time.Minute),
+		}}))
+	}
+
+	activeGame, err := s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("newer-game-id", activeGame.ID)
+
+	// Deleting the newer game leaves the older one
+	s.Require().NoError(s.repo.DeleteGame(ctx, &DeleteGameInput{GameID: "newer-game-id"}))
+
+	activeGame, err = s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("older-game-id", activeGame.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveGame_Abandoned() {
+	ctx := context.Background()
+
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusWaiting,
+		CreatedAt: s.testNow,
+		UpdatedAt: s.testNow,
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	game.Status = models.GameStatusAbandoned
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	// An abandoned game is neither the channel's game nor its latest completed one
+	_, err := s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.repo.GetLatestCompletedGameByChannel(ctx, &GetLatestCompletedGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	// It's archived until it's past the retention period
+	archived, err := s.repo.GetArchivedGames(ctx, &GetArchivedGamesInput{ArchivedBefore: s.testNow})
+	s.Require().NoError(err)
+	s.Empty(archived.GameIDs)
+
+	archived, err = s.repo.GetArchivedGames(ctx, &GetArchivedGamesInput{ArchivedBefore: s.testNow.Add(time.Second)})
+	s.Require().NoError(err)
+	s.Equal([]string{"test-game-id"}, archived.GameIDs)
+
+	s.Require().NoError(s.repo.DeleteGame(ctx, &DeleteGameInput{GameID: "test-game-id"}))
+
+	archived, err = s.repo.GetArchivedGames(ctx, &GetArchivedGamesInput{ArchivedBefore: s.testNow.Add(time.Second)})
+	s.Require().NoError(err)
+	s.Empty(archived.GameIDs)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteGame_KeepsNewerChannelGame() {
+	ctx := context.Background()
+
+	for i, gameID := range []string{"older-game-id", "newer-game-id"} {
+		s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: &models.Game{
+			ID:        gameID,
+			ChannelID: "test-channel-id",
+			Status:    models.GameStatusWaiting,
+			CreatedAt: s.testNow.Add(time.Duration(i) * time.Minute),
+		}}))
+	}
+
+	// Purging an old game leaves the channel pointing at the newer one
+	s.Require().NoError(s.repo.DeleteGame(ctx, &DeleteGameInput{GameID: "older-game-id"}))
+
+	game, err := s.repo.GetGameByChannel(ctx, &GetGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("newer-game-id", game.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetActiveGameByChannel_ChannelMappingOnly() {
+	ctx := context.Background()
+
+	// Games saved before the channel's games were tracked only have the channel mapping
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusWaiting,
+		CreatedAt: s.testNow,
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+	s.Require().NoError(s.client.Del(ctx, channelActivePrefix+"test-channel-id").Err())
+
+	activeGame, err := s.repo.GetActiveGameByChannel(ctx, &GetActiveGameByChannelInput{ChannelID: "test-channel-id"})
+	s.Require().NoError(err)
+	s.Equal("test-game-id", activeGame.ID)
+
+	_, err = s.repo.GetLatestCompletedGameByChannel(ctx, &GetLatestCompletedGameByChannelInput{ChannelID: "test-channel-id"})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestChallenges() {
+	ctx := context.Background()
+
+	challenge := &models.ChallengeGame{
+		ID:           "test-challenge-id",
+		ChannelID:    "test-channel-id",
+		ChallengerID: "alice",
+		OpponentID:   "bob",
+		Call:         models.ChallengeCallOdds,
+		CreatedAt:    s.testNow,
+		ExpiresAt:    s.testNow.Add(5 * time.Minute),
+	}
+	s.Require().NoError(s.repo.CreateChallenge(ctx, &CreateChallengeInput{Challenge: challenge}))
+
+	output, err := s.repo.GetChallenge(ctx, &GetChallengeInput{ChallengeID: challenge.ID})
+	s.Require().NoError(err)
+	s.Equal("bob", output.Challenge.OpponentID)
+	s.Equal(models.ChallengeCallOdds, output.Challenge.Call)
+
+	// Only the first caller gets to remove it
+	s.Require().NoError(s.repo.DeleteChallenge(ctx, &DeleteChallengeInput{ChallengeID: challenge.ID}))
+	s.ErrorIs(s.repo.DeleteChallenge(ctx, &DeleteChallengeInput{ChallengeID: challenge.ID}), ErrChallengeNotFound)
+
+	_, err = s.repo.GetChallenge(ctx, &GetChallengeInput{ChallengeID: challenge.ID})
+	s.ErrorIs(err, ErrChallengeNotFound)
+
+	// Challenges lapse once they expire
+	s.Require().NoError(s.repo.CreateChallenge(ctx, &CreateChallengeInput{Challenge: challenge}))
+	s.mr.FastForward(6 * time.Minute)
+	_, err = s.repo.GetChallenge(ctx, &GetChallengeInput{ChallengeID: challenge.ID})
+	s.ErrorIs(err, ErrChallengeNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestSideBets() {
+	ctx := context.Background()
+
+	bet := &models.SideBet{
+		ID:             "test-bet-id",
+		GameID:         "test-game-id",
+		BettorID:       "alice",
+		TargetPlayerID: "bob",
+		Outcome:        models.SideBetOver,
+		Line:           3,
+		CreatedAt:      s.testNow,
+		ExpiresAt:      s.testNow.Add(10 * time.Minute),
+	}
+	s.Require().NoError(s.repo.CreateSideBet(ctx, &CreateSideBetInput{Bet: bet}))
+
+	// One bet per bettor on each roll
+	s.ErrorIs(s.repo.CreateSideBet(ctx, &CreateSideBetInput{Bet: bet}), ErrSideBetAlreadyPlaced)
+
+	output, err := s.repo.TakeSideBets(ctx, &TakeSideBetsInput{GameID: bet.GameID, TargetPlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Bets, 1)
+	s.Equal("alice", output.Bets[0].BettorID)
+	s.Equal(models.SideBetOver, output.Bets[0].Outcome)
+
+	// Bets are only taken once
+	output, err = s.repo.TakeSideBets(ctx, &TakeSideBetsInput{GameID: bet.GameID, TargetPlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Empty(output.Bets)
+
+	// And lapse once they expire
+	s.Require().NoError(s.repo.CreateSideBet(ctx, &CreateSideBetInput{Bet: bet}))
+	s.mr.FastForward(11 * time.Minute)
+	output, err = s.repo.TakeSideBets(ctx, &TakeSideBetsInput{GameID: bet.GameID, TargetPlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Empty(output.Bets)
+}
+
+func (s *RedisRepositoryTestSuite) TestRecordRoll() {
+	ctx := context.Background()
+	rollTime := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	game := &models.Game{
+		ID:        "test-game-id",
+		ChannelID: "test-channel-id",
+		Status:    models.GameStatusActive,
+		Participants: []*models.Participant{
+			{
+				ID:         "rolled-participant-id",
+				GameID:     "test-game-id",
+				PlayerID:   "rolled-player-id",
+				PlayerName: `Sneaky","RollTime":null`,
+				Status:     models.ParticipantStatusWaitingToRoll,
+				VoidedRolls: []*models.VoidedRoll{
+					{RollValue: 1, RollTime: rollTime, VoidedAt: rollTime, DrinkIDs: []string{}},
+				},
+			},
+			{
+				ID:         "test-participant-id",
+				GameID:     "test-game-id",
+				PlayerID:   "test-player-id",
+				PlayerName: "Test Player",
+				Status:     models.ParticipantStatusWaitingToRoll,
+			},
+		},
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	output, err := s.repo.RecordRoll(ctx, &RecordRollInput{
+		GameID:        "test-game-id",
+		PlayerID:      "test-player-id",
+		RollValue:     6,
+		RollTime:      rollTime,
+		Status:        models.ParticipantStatusNeedsToAssign,
+		RollModifiers: []*models.RollModifier{{Type: models.RollModifierBonus, Amount: 1, Rolls: 1, Source: "Lucky"}},
+	})
+	s.Require().NoError(err)
+
+	saved, err := s.repo.GetGame(ctx, &GetGameInput{GameID: "test-game-id"})
+	s.Require().NoError(err)
+	s.Equal(saved, output.Game)
+	s.True(rollTime.Equal(saved.UpdatedAt))
+	s.Equal([]*models.RollModifier{{Type: models.RollModifierBonus, Amount: 1, Rolls: 1, Source: "Lucky"}}, saved.GetParticipant("test-player-id").RollModifiers)
+
+	participant := saved.GetParticipant("test-player-id")
+	s.Equal(6, participant.RollValue)
+	s.Require().NotNil(participant.RollTime)
+	s.True(rollTime.Equal(*participant.RollTime))
+	s.Equal(models.ParticipantStatusNeedsToAssign, participant.Status)
+
+	// The rest of the game is left as it was saved
+	s.Equal(game.Participants[0], saved.Participants[0])
+	s.Equal([]string{}, saved.Participants[0].VoidedRolls[0].DrinkIDs)
+
+	// A second roll can't get past the first
+	_, err = s.repo.RecordRoll(ctx, &RecordRollInput{
+		GameID:    "test-game-id",
+		PlayerID:  "test-player-id",
+		RollValue: 1,
+		RollTime:  rollTime,
+		Status:    models.ParticipantStatusActive,
+	})
+	s.ErrorIs(err, ErrRollAlreadyRecorded)
+
+	_, err = s.repo.RecordRoll(ctx, &RecordRollInput{
+		GameID:   "test-game-id",
+		PlayerID: "other-player-id",
+		RollTime: rollTime,
+		Status:   models.ParticipantStatusActive,
+	})
+	s.ErrorIs(err, ErrParticipantNotFound)
+
+	_, err = s.repo.RecordRoll(ctx, &RecordRollInput{
+		GameID:   "missing-game-id",
+		PlayerID: "test-player-id",
+		RollTime: rollTime,
+		Status:   models.ParticipantStatusActive,
+	})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestRecordRoll_Concurrent() {
+	ctx := context.Background()
+	rollTime := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	game := &models.Game{ID: "test-game-id", ChannelID: "test-channel-id", Status: models.GameStatusActive}
+	for i := 0; i < 5; i++ {
+		game.AddParticipant(&models.Participant{
+			ID:       fmt.Sprintf("participant-%d", i),
+			GameID:   game.ID,
+			PlayerID: fmt.Sprintf("player-%d", i),
+			Status:   models.ParticipantStatusWaitingToRoll,
+		})
+	}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	// Everyone rolls at once, twice over
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+			_, err := s.repo.RecordRoll(ctx, &RecordRollInput{
+				GameID:    game.ID,
+				PlayerID:  playerID,
+				RollValue: 3,
+				RollTime:  rollTime,
+				Status:    models.ParticipantStatusActive,
+			})
+			errs <- err
+		}(fmt.Sprintf("player-%d", i%5))
+	}
+	wg.Wait()
+	close(errs)
+
+	recorded, alreadyRecorded := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			recorded++
+		case errors.Is(err, ErrRollAlreadyRecorded):
+			alreadyRecorded++
+		default:
+			s.Fail("unexpected error", err.Error())
+		}
+	}
+	s.Equal(5, recorded)
+	s.Equal(5, alreadyRecorded)
+
+	// None of the rolls overwrote another
+	saved, err := s.repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	for _, participant := range saved.Participants {
+		s.NotNil(participant.RollTime, participant.PlayerID)
+	}
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveGame_Stale() {
+	ctx := context.Background()
+
+	game := &models.Game{ID: "test-game-id", ChannelID: "test-channel-id", Status: models.GameStatusActive}
+	game.AddParticipant(&models.Participant{
+		ID:       "test-participant-id",
+		GameID:   game.ID,
+		PlayerID: "test-player-id",
+		Status:   models.ParticipantStatusWaitingToRoll,
+	})
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+	s.Equal(int64(1), game.Version)
+
+	stale, err := s.repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+
+	// A roll is recorded after the stale copy was read
+	_, err = s.repo.RecordRoll(ctx, &RecordRollInput{
+		GameID:    game.ID,
+		PlayerID:  "test-player-id",
+		RollValue: 4,
+		RollTime:  s.testNow,
+		Status:    models.ParticipantStatusActive,
+	})
+	s.Require().NoError(err)
+
+	// Saving the stale copy is refused rather than erasing the roll
+	stale.Vote = &models.GameVote{Action: models.VoteActionAbandon}
+	s.ErrorIs(s.repo.SaveGame(ctx, &SaveGameInput{Game: stale}), ErrGameChanged)
+	s.Equal(int64(1), stale.Version)
+
+	saved, err := s.repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Equal(4, saved.GetParticipant("test-player-id").RollValue)
+	s.Nil(saved.Vote)
+	s.Equal(int64(2), saved.Version)
+
+	// The game as it is now saves
+	saved.Vote = &models.GameVote{Action: models.VoteActionAbandon}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: saved}))
+	s.Equal(int64(3), saved.Version)
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveGame_StaleInUnitOfWork() {
+	ctx := context.Background()
+
+	game := &models.Game{ID: "test-game-id", ChannelID: "test-channel-id", Status: models.GameStatusWaiting}
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	stale, err := s.repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	game.MessageID = "test-message-id"
+	s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	transactions, err := transaction.NewRedis(&transaction.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	// A unit of work can save the game more than once, but not from a copy older than the stored game
+	err = transactions.Run(ctx, func(ctx context.Context) error {
+		game.Status = models.GameStatusActive
+		if err := s.repo.SaveGame(ctx, &SaveGameInput{Game: game}); err != nil {
+			return err
+		}
+		return s.repo.SaveGame(ctx, &SaveGameInput{Game: game})
+	})
+	s.Require().NoError(err)
+
+	err = transactions.Run(ctx, func(ctx context.Context) error {
+		stale.Status = models.GameStatusAbandoned
+		return s.repo.SaveGame(ctx, &SaveGameInput{Game: stale})
+	})
+	s.ErrorIs(err, ErrGameChanged)
+
+	saved, err := s.repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, saved.Status)
+	s.Equal("test-message-id", saved.MessageID)
+	s.Equal(game.Version, saved.Version)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetGameFromReplica() {
+	ctx := context.Background()
+
+	replicaServer, err := miniredis.Run()
+	s.Require().NoError(err)
+	defer replicaServer.Close()
+
+	replicaClient := redis.NewClient(&redis.Options{Addr: replicaServer.Addr()})
+	defer replicaClient.Close()
+
+	repo, err := NewRedis(&Config{RedisClient: s.client, ReadClient: replicaClient})
+	s.Require().NoError(err)
+
+	game := &models.Game{ID: "test-game-id", ChannelID: "test-channel-id", Status: models.GameStatusActive}
+	s.Require().NoError(repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+
+	// The replica has an older copy of the game
+	s.Require().NoError(replicaServer.Set(gameKeyPrefix+game.ID, `{"ID":"test-game-id","Status":"waiting"}`))
+
+	saved, err := repo.GetGame(ctx, &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, saved.Status, "reads go to the primary unless the caller allows otherwise")
+
+	saved, err = repo.GetGame(replica.WithReplicaReads(ctx), &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusWaiting, saved.Status)
+
+	// A game the replica hasn't caught up with is read from the primary
+	replicaServer.Del(gameKeyPrefix + game.ID)
+	saved, err = repo.GetGame(replica.WithReplicaReads(ctx), &GetGameInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, saved.Status)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetGamesByPlayer() {
+	ctx := context.Background()
+
+	for _, game := range []*models.Game{
+		{ID: "played-game-id", ChannelID: "test-channel-id", Status: models.GameStatusCompleted, CreatorID: "bob",
+			Participants: []*models.Participant{{PlayerID: "alice"}, {PlayerID: "bob"}}},
+		{ID: "waitlisted-game-id", ChannelID: "test-channel-id", Status: models.GameStatusWaiting, CreatorID: "bob",
+			Waitlist: []*models.WaitlistEntry{{PlayerID: "alice"}}},
+		{ID: "other-game-id", ChannelID: "test-channel-id", Status: models.GameStatusCompleted, CreatorID: "bob",
+			Participants: []*models.Participant{{PlayerID: "bob"}}},
+	} {
+		s.Require().NoError(s.repo.SaveGame(ctx, &SaveGameInput{Game: game}))
+	}
+
+	output, err := s.repo.GetGamesByPlayer(ctx, &GetGamesByPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	var ids []string
+	for _, game := range output.Games {
+		ids = append(ids, game.ID)
+	}
+	s.ElementsMatch([]string{"played-game-id", "waitlisted-game-id"}, ids)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteSideBetsByPlayer() {
+	ctx := context.Background()
+
+	for _, bet := range []*models.SideBet{
+		{ID: "alice-on-bob", BettorID: "alice", TargetPlayerID: "bob"},
+		{ID: "carol-on-bob", BettorID: "carol", TargetPlayerID: "bob"},
+		{ID: "carol-on-alice", BettorID: "carol", TargetPlayerID: "alice"},
+	} {
+		bet.GameID = "test-game-id"
+		bet.Outcome = models.SideBetCriticalHit
+		bet.CreatedAt = s.testNow
+		bet.ExpiresAt = s.testNow.Add(10 * time.Minute)
+		s.Require().NoError(s.repo.CreateSideBet(ctx, &CreateSideBetInput{Bet: bet}))
+	}
+
+	output, err := s.repo.DeleteSideBetsByPlayer(ctx, &DeleteSideBetsByPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(2, output.DeletedCount)
+
+	// Only carol's bet on bob is left
+	bets, err := s.repo.TakeSideBets(ctx, &TakeSideBetsInput{GameID: "test-game-id", TargetPlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().Len(bets.Bets, 1)
+	s.Equal("carol", bets.Bets[0].BettorID)
+
+	bets, err = s.repo.TakeSideBets(ctx, &TakeSideBetsInput{GameID: "test-game-id", TargetPlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Empty(bets.Bets)
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// CreateSideBetInput contains parameters for saving a side bet
+type CreateSideBetInput struct {
+	// Bet is the side bet to save, it's dropped once it expires
+	Bet *models.SideBet
+}
+
+// TakeSideBetsInput contains parameters for taking the side bets on a player's roll
+type TakeSideBetsInput struct {
+	// GameID is the game the roll is made in
+	GameID string
+
+	// TargetPlayerID is the ID of the player rolling
+	TargetPlayerID string
+}
+
+// TakeSideBetsOutput contains the side bets taken
+type TakeSideBetsOutput struct {
+	// Bets are the side bets on the roll, including any that have expired
+	Bets []*models.SideBet
+}
+
+// DeleteSideBetsByPlayerInput contains parameters for deleting the side bets a player made or is the target of
+type DeleteSideBetsByPlayerInput struct {
+	PlayerID string
+
+	// GuildID limits the deletion to the bets on the server's games, all are deleted when it's empty
+	GuildID string
+}
+
+// DeleteSideBetsByPlayerOutput contains the number of side bets deleted
+type DeleteSideBetsByPlayerOutput struct {
+	DeletedCount int
+}
@@ -1,6 +1,10 @@
 package game
 
-import "github.com/KirkDiggler/ronnied/internal/models"
+import (
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
 
 type SaveGameInput struct {
 	Game *models.Game
@@ -8,10 +12,31 @@ type SaveGameInput struct {
 
 type GetGameInput struct {
 	GameID string
+
+	// GuildID is the Discord server reading the game, empty outside a server. It's only checked with CheckGuild.
+	GuildID string
+
+	// CheckGuild makes the read guild-scoped: a game stored under any other guild than GuildID is reported as
+	// ErrGameNotFound. Reads of a game the caller was already handed, such as its buttons, leave it unset.
+	CheckGuild bool
 }
 
 type GetGameByChannelInput struct {
 	ChannelID string
+
+	// GuildID is the Discord server the channel is in, empty outside a server.
+	// A game stored under any other guild is reported as ErrGameNotFound.
+	GuildID string
+}
+
+// GetActiveGameByChannelInput contains parameters for finding a channel's unfinished game
+type GetActiveGameByChannelInput struct {
+	ChannelID string
+}
+
+// GetLatestCompletedGameByChannelInput contains parameters for finding a channel's last completed game
+type GetLatestCompletedGameByChannelInput struct {
+	ChannelID string
 }
 
 type DeleteGameInput struct {
@@ -25,15 +50,38 @@ type GetActiveGamesOutput struct {
 	Games []*models.Game
 }
 
+// GetUnfinishedGamesInput contains parameters for finding the games not yet over
+type GetUnfinishedGamesInput struct {
+	// GuildID limits the games to a server's, empty for every server
+	GuildID string
+}
+
+// GetUnfinishedGamesOutput contains the games not yet over
+type GetUnfinishedGamesOutput struct {
+	Games []*models.Game
+}
+
 type GetGamesByParentInput struct {
 	ParentGameID string
 }
 
 // CreateGameInput contains parameters for creating a new game
 type CreateGameInput struct {
-	ChannelID string
-	CreatorID string
-	Status    models.GameStatus
+	ChannelID        string
+	GuildID          string
+	CreatorID        string
+	Status           models.GameStatus
+	Rules            *models.GameRules
+	Events           []string
+	LastCall         bool
+	Countdown        bool
+	Rounds           *models.GameRounds
+	Private          bool
+	InvitedPlayerIDs []string
+
+	// JoinCreator saves the creator, named CreatorName, with the game as its first participant waiting to roll
+	JoinCreator bool
+	CreatorName string
 }
 
 // CreateGameOutput contains the result of creating a new game
@@ -44,10 +92,15 @@ type CreateGameOutput struct {
 // CreateRollOffGameInput contains parameters for creating a new roll-off game
 type CreateRollOffGameInput struct {
 	ChannelID    string
+	GuildID      string
 	CreatorID    string
 	ParentGameID string
 	PlayerIDs    []string
 	PlayerNames  map[string]string // Map of player ID to player name
+	Rules        *models.GameRules
+	Events       []string
+	LastCall     bool
+	SuddenDeath  *models.SuddenDeath
 }
 
 // CreateRollOffGameOutput contains the result of creating a new roll-off game
@@ -67,3 +120,52 @@ type CreateParticipantInput struct {
 type CreateParticipantOutput struct {
 	Participant *models.Participant
 }
+
+// HasParticipantInput contains parameters for checking whether a player is in a game
+type HasParticipantInput struct {
+	GameID   string
+	PlayerID string
+}
+
+// HasParticipantOutput contains the result of checking whether a player is in a game
+type HasParticipantOutput struct {
+	IsParticipant bool
+}
+
+// RecordRollInput contains parameters for recording a participant's roll
+type RecordRollInput struct {
+	GameID    string
+	PlayerID  string
+	RollValue int
+	RollTime  time.Time
+	Status    models.ParticipantStatus
+
+	// RollModifiers are the modifiers applied to the roll
+	RollModifiers []*models.RollModifier
+}
+
+// RecordRollOutput contains the game with the roll recorded
+type RecordRollOutput struct {
+	// Game is the game as saved with the roll, including the rolls others recorded before it
+	Game *models.Game
+}
+
+// GetArchivedGamesInput contains parameters for finding games archived a while ago
+type GetArchivedGamesInput struct {
+	ArchivedBefore time.Time
+}
+
+// GetArchivedGamesOutput contains the IDs of the archived games found
+type GetArchivedGamesOutput struct {
+	GameIDs []string
+}
+
+// GetGamesByPlayerInput contains parameters for finding the games a player is involved in
+type GetGamesByPlayerInput struct {
+	PlayerID string
+}
+
+// GetGamesByPlayerOutput contains the games found, in no particular order
+type GetGamesByPlayerOutput struct {
+	Games []*models.Game
+}
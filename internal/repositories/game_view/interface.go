@@ -0,0 +1,14 @@
+package game_view
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/game_view Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for loading everything needed to render a game
+type Repository interface {
+	// LoadGameView loads a game along with its parent and roll-off games, its drink records and, once the game
+	// is completed, its guild's current session and that session's drink records, pipelining the reads
+	LoadGameView(ctx context.Context, input *LoadGameViewInput) (*LoadGameViewOutput, error)
+}
@@ -0,0 +1,299 @@
+package game_view
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis, shared with the game, drink ledger and player repositories
+	gameKeyPrefix       = "game:"
+	gameDrinksKeyPrefix = "game_drinks:"
+	drinkKeyPrefix      = "drink:"
+	sessionKeyPrefix    = "session:"
+	guildSessionPrefix  = "guild_session:"
+	sessionDrinksPrefix = "session_drinks:"
+	playerKeyPrefix     = "player:"
+)
+
+// ErrGameNotFound is returned when the game being loaded doesn't exist
+var ErrGameNotFound = errors.New("game not found")
+
+// Config holds configuration for the Redis game view repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+
+	// ReadClient is an optional read replica for the reads callers allow to lag behind
+	ReadClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client  redis.UniversalClient
+	replica redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed game view repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	if cfg.ReadClient != nil {
+		if err := cfg.ReadClient.Ping(context.Background()).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis replica: %w", err)
+		}
+	}
+
+	return &redisRepository{
+		client:  cfg.RedisClient,
+		replica: cfg.ReadClient,
+	}, nil
+}
+
+// LoadGameView loads everything needed to render a game.
+// Each step pipelines every read it can make with what the steps before it found, so a view takes
+// at most five round trips however many games, drinks and players it covers.
+func (r *redisRepository) LoadGameView(ctx context.Context, input *LoadGameViewInput) (*LoadGameViewOutput, error) {
+	if input == nil || input.GameID == "" {
+		return nil, errors.New("input and game ID cannot be empty")
+	}
+
+	// Rendering reads from a replica when the caller allows it
+	var output *LoadGameViewOutput
+	err := replica.Read(ctx, r.client, r.replica, func(client redis.UniversalClient) error {
+		var err error
+		output, err = loadGameView(ctx, client, input.GameID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// loadGameView loads a game view from the primary or a replica
+func loadGameView(ctx context.Context, client redis.UniversalClient, gameID string) (*LoadGameViewOutput, error) {
+	// The game and the IDs of its drinks
+	pipe := client.Pipeline()
+	gameCmd := pipe.Get(ctx, gameKeyPrefix+gameID)
+	drinkIDsCmd := pipe.ZRange(ctx, gameDrinksKeyPrefix+gameID, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	game, err := decodeGame(gameCmd)
+	if err != nil {
+		return nil, err
+	}
+	if game == nil {
+		return nil, ErrGameNotFound
+	}
+
+	output := &LoadGameViewOutput{
+		Game:        game,
+		PlayerNames: make(map[string]string),
+	}
+
+	// The related games, the drinks and the guild's current session
+	pipe = client.Pipeline()
+	var parentCmd, rollOffCmd, sessionIDCmd *redis.StringCmd
+	if game.ParentGameID != "" {
+		parentCmd = pipe.Get(ctx, gameKeyPrefix+game.ParentGameID)
+	}
+	if game.RollOffGameID != "" {
+		rollOffCmd = pipe.Get(ctx, gameKeyPrefix+game.RollOffGameID)
+	}
+	drinkCmds := queueDrinks(ctx, pipe, drinkIDsCmd.Val())
+
+	scope := sessionScope(game)
+	if game.Status.IsCompleted() && scope != "" {
+		sessionIDCmd = pipe.Get(ctx, guildSessionPrefix+scope)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to load game details: %w", err)
+	}
+
+	if output.ParentGame, err = decodeGame(parentCmd); err != nil {
+		return nil, err
+	}
+	if output.RollOffGame, err = decodeGame(rollOffCmd); err != nil {
+		return nil, err
+	}
+	if output.DrinkRecords, err = decodeDrinks(drinkCmds, ""); err != nil {
+		return nil, err
+	}
+
+	// The session and the IDs of its drinks
+	if sessionIDCmd != nil && sessionIDCmd.Err() == nil {
+		sessionID := sessionIDCmd.Val()
+
+		pipe = client.Pipeline()
+		sessionCmd := pipe.Get(ctx, sessionKeyPrefix+sessionID)
+		sessionDrinkIDsCmd := pipe.SMembers(ctx, sessionDrinksPrefix+sessionID)
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to load session: %w", err)
+		}
+
+		session, err := decodeSession(sessionCmd)
+		if err != nil {
+			return nil, err
+		}
+
+		// Never show a guild another guild's session
+		if session != nil && session.GuildID == scope {
+			output.Session = session
+
+			// The session's drinks
+			pipe = client.Pipeline()
+			sessionDrinkCmds := queueDrinks(ctx, pipe, sessionDrinkIDsCmd.Val())
+			if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+				return nil, fmt.Errorf("failed to load session drinks: %w", err)
+			}
+
+			if output.SessionRecords, err = decodeDrinks(sessionDrinkCmds, game.GuildID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The names of everyone who was given a drink
+	playerIDs := make(map[string]bool)
+	for _, records := range [][]*models.DrinkLedger{output.DrinkRecords, output.SessionRecords} {
+		for _, record := range records {
+			playerIDs[record.ToPlayerID] = true
+		}
+	}
+
+	if len(playerIDs) > 0 {
+		pipe = client.Pipeline()
+		playerCmds := make(map[string]*redis.StringCmd, len(playerIDs))
+		for playerID := range playerIDs {
+			playerCmds[playerID] = pipe.Get(ctx, playerKeyPrefix+playerID)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to load players: %w", err)
+		}
+
+		for playerID, cmd := range playerCmds {
+			playerJSON, err := cmd.Result()
+			if err != nil {
+				// Players without a profile are named by whoever renders them
+				continue
+			}
+
+			var player models.Player
+			if err := json.Unmarshal([]byte(playerJSON), &player); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal player %s: %w", playerID, err)
+			}
+			output.PlayerNames[playerID] = player.Name
+		}
+	}
+
+	return output, nil
+}
+
+// sessionScope returns the key the game's session is kept under, matching the game service:
+// the guild, or the channel itself for channels outside a Discord server
+func sessionScope(game *models.Game) string {
+	if game.GuildID != "" {
+		return game.GuildID
+	}
+	return game.ChannelID
+}
+
+// queueDrinks adds a read of each drink record to the pipeline
+func queueDrinks(ctx context.Context, pipe redis.Pipeliner, drinkIDs []string) []*redis.StringCmd {
+	cmds := make([]*redis.StringCmd, 0, len(drinkIDs))
+	for _, drinkID := range drinkIDs {
+		cmds = append(cmds, pipe.Get(ctx, drinkKeyPrefix+drinkID))
+	}
+	return cmds
+}
+
+// decodeGame decodes a game read, returning nil if nothing was read or the game doesn't exist
+func decodeGame(cmd *redis.StringCmd) (*models.Game, error) {
+	if cmd == nil {
+		return nil, nil
+	}
+
+	gameJSON, err := cmd.Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	var game models.Game
+	if err := json.Unmarshal([]byte(gameJSON), &game); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+	game.IndexParticipants()
+
+	return &game, nil
+}
+
+// decodeSession decodes a session read, returning nil if the session doesn't exist
+func decodeSession(cmd *redis.StringCmd) (*models.Session, error) {
+	sessionJSON, err := cmd.Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var session models.Session
+	if err := json.Unmarshal([]byte(sessionJSON), &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// decodeDrinks decodes drink record reads, skipping records deleted since their IDs were read and,
+// when a guild is given, records from other guilds
+func decodeDrinks(cmds []*redis.StringCmd, guildID string) ([]*models.DrinkLedger, error) {
+	records := make([]*models.DrinkLedger, 0, len(cmds))
+	for _, cmd := range cmds {
+		recordJSON, err := cmd.Result()
+		if err != nil {
+			if err == redis.Nil {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get drink record: %w", err)
+		}
+
+		var record models.DrinkLedger
+		if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal drink record: %w", err)
+		}
+
+		if guildID != "" && record.GuildID != "" && record.GuildID != guildID {
+			continue
+		}
+
+		records = append(records, &record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,183 @@
+package game_view
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+// RedisRepositoryTestSuite loads views of data written by the game, drink ledger and player repositories
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	games   gameRepo.Repository
+	ledger  ledgerRepo.Repository
+	players playerRepo.Repository
+	ctx     context.Context
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	repo, err := NewRedis(&Config{RedisClient: s.client})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+	s.games = games
+
+	ledger, err := ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+	s.ledger = ledger
+
+	players, err := playerRepo.NewRedis(&playerRepo.Config{RedisClient: s.client})
+	s.Require().NoError(err)
+	s.players = players
+
+	s.ctx = context.Background()
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+// createGame creates a game in guild-1 with the given status
+func (s *RedisRepositoryTestSuite) createGame(status models.GameStatus) *models.Game {
+	output, err := s.games.CreateGame(s.ctx, &gameRepo.CreateGameInput{
+		ChannelID: "channel-1",
+		GuildID:   "guild-1",
+		CreatorID: "player-1",
+		Status:    status,
+	})
+	s.Require().NoError(err)
+	return output.Game
+}
+
+// giveDrink records a drink for the player in the game and session
+func (s *RedisRepositoryTestSuite) giveDrink(gameID, sessionID, toPlayerID string) {
+	_, err := s.ledger.CreateDrinkRecord(s.ctx, &ledgerRepo.CreateDrinkRecordInput{
+		GameID:     gameID,
+		ToPlayerID: toPlayerID,
+		Reason:     models.DrinkReasonLowestRoll,
+		Timestamp:  s.testNow,
+		SessionID:  sessionID,
+		GuildID:    "guild-1",
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestLoadGameView_NotFound() {
+	_, err := s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: "missing"})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestLoadGameView_ActiveGame() {
+	game := s.createGame(models.GameStatusActive)
+	s.giveDrink(game.ID, "", "player-2")
+
+	output, err := s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: game.ID})
+	s.Require().NoError(err)
+
+	s.Equal(game.ID, output.Game.ID)
+	s.Nil(output.ParentGame)
+	s.Nil(output.RollOffGame)
+	s.Len(output.DrinkRecords, 1)
+
+	// The session is only loaded once the game is completed
+	s.Nil(output.Session)
+	s.Empty(output.SessionRecords)
+}
+
+func (s *RedisRepositoryTestSuite) TestLoadGameView_RollOff() {
+	parent := s.createGame(models.GameStatusRollOff)
+	rollOff, err := s.games.CreateRollOffGame(s.ctx, &gameRepo.CreateRollOffGameInput{
+		ChannelID:    "channel-1",
+		GuildID:      "guild-1",
+		CreatorID:    "player-1",
+		ParentGameID: parent.ID,
+		PlayerIDs:    []string{"player-1", "player-2"},
+	})
+	s.Require().NoError(err)
+
+	parent.RollOffGameID = rollOff.Game.ID
+	s.Require().NoError(s.games.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: parent}))
+
+	output, err := s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: rollOff.Game.ID})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.ParentGame)
+	s.Equal(parent.ID, output.ParentGame.ID)
+
+	output, err = s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: parent.ID})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.RollOffGame)
+	s.Equal(rollOff.Game.ID, output.RollOffGame.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestLoadGameView_CompletedGame() {
+	session, err := s.ledger.CreateSession(s.ctx, &ledgerRepo.CreateSessionInput{
+		GuildID:   "guild-1",
+		CreatedBy: "system",
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.players.SavePlayer(s.ctx, &playerRepo.SavePlayerInput{
+		Player: &models.Player{ID: "player-2", Name: "Bob"},
+	}))
+
+	earlier := s.createGame(models.GameStatusCompleted)
+	s.giveDrink(earlier.ID, session.Session.ID, "player-3")
+
+	game := s.createGame(models.GameStatusCompleted)
+	s.giveDrink(game.ID, session.Session.ID, "player-2")
+
+	output, err := s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: game.ID})
+	s.Require().NoError(err)
+
+	s.Len(output.DrinkRecords, 1)
+	s.Require().NotNil(output.Session)
+	s.Equal(session.Session.ID, output.Session.ID)
+	s.Len(output.SessionRecords, 2)
+
+	// Players without a profile are left for the caller to name
+	s.Equal(map[string]string{"player-2": "Bob"}, output.PlayerNames)
+}
+
+func (s *RedisRepositoryTestSuite) TestLoadGameView_SkipsOtherGuildsSession() {
+	game := s.createGame(models.GameStatusCompleted)
+
+	// A session for another guild stored under this guild's key is never shown
+	session, err := s.ledger.CreateSession(s.ctx, &ledgerRepo.CreateSessionInput{
+		GuildID:   "guild-2",
+		CreatedBy: "system",
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.client.Set(s.ctx, guildSessionPrefix+"guild-1", session.Session.ID, 0).Err())
+
+	output, err := s.repo.LoadGameView(s.ctx, &LoadGameViewInput{GameID: game.ID})
+	s.Require().NoError(err)
+	s.Nil(output.Session)
+}
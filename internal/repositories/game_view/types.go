@@ -0,0 +1,27 @@
+package game_view
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// LoadGameViewInput contains parameters for loading a game view
+type LoadGameViewInput struct {
+	GameID string
+}
+
+// LoadGameViewOutput contains everything needed to render a game
+type LoadGameViewOutput struct {
+	Game        *models.Game
+	ParentGame  *models.Game
+	RollOffGame *models.Game
+
+	// DrinkRecords are the game's drink records
+	DrinkRecords []*models.DrinkLedger
+
+	// Session is the current session for the game's guild, only loaded for completed games
+	Session *models.Session
+
+	// SessionRecords are the session's drink records, only loaded for completed games
+	SessionRecords []*models.DrinkLedger
+
+	// PlayerNames maps the players named in the drink records to their saved names
+	PlayerNames map[string]string
+}
@@ -0,0 +1,16 @@
+package guild_config
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/guild_config Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for persisting per-guild settings
+type Repository interface {
+	// GetGuildConfig retrieves a guild's settings, a guild that never saved any gets an empty config
+	GetGuildConfig(ctx context.Context, input *GetGuildConfigInput) (*GetGuildConfigOutput, error)
+
+	// SaveGuildConfig saves a guild's settings
+	SaveGuildConfig(ctx context.Context, input *SaveGuildConfigInput) error
+}
@@ -0,0 +1,92 @@
+package guild_config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// guildConfigKeyPrefix is the prefix for guild settings keys
+	guildConfigKeyPrefix = "guild_config:"
+)
+
+// Config holds configuration for the Redis guild config repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed guild config repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// GetGuildConfig retrieves a guild's settings, a guild that never saved any gets an empty config
+func (r *redisRepository) GetGuildConfig(ctx context.Context, input *GetGuildConfigInput) (*GetGuildConfigOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, errors.New("guild ID is required")
+	}
+
+	data, err := r.client.Get(ctx, guildConfigKeyPrefix+input.GuildID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return &GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: input.GuildID},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild config: %w", err)
+	}
+
+	var config models.GuildConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal guild config: %w", err)
+	}
+
+	return &GetGuildConfigOutput{
+		Config: &config,
+	}, nil
+}
+
+// SaveGuildConfig saves a guild's settings
+func (r *redisRepository) SaveGuildConfig(ctx context.Context, input *SaveGuildConfigInput) error {
+	if input == nil || input.Config == nil || input.Config.GuildID == "" {
+		return errors.New("guild ID is required")
+	}
+
+	data, err := json.Marshal(input.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal guild config: %w", err)
+	}
+
+	if err := r.client.Set(ctx, guildConfigKeyPrefix+input.Config.GuildID, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save guild config: %w", err)
+	}
+
+	return nil
+}
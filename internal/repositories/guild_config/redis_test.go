@@ -0,0 +1,80 @@
+package guild_config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	repo   Repository
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveAndGetGuildConfig() {
+	ctx := context.Background()
+
+	// A guild that never saved settings gets the defaults
+	output, err := s.repo.GetGuildConfig(ctx, &GetGuildConfigInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal("guild-1", output.Config.GuildID)
+	s.Equal(models.RollFeedOff, output.Config.RollFeedMode())
+
+	err = s.repo.SaveGuildConfig(ctx, &SaveGuildConfigInput{
+		Config: &models.GuildConfig{GuildID: "guild-1", RollFeed: models.RollFeedLog},
+	})
+	s.Require().NoError(err)
+
+	output, err = s.repo.GetGuildConfig(ctx, &GetGuildConfigInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal(models.RollFeedLog, output.Config.RollFeedMode())
+
+	// Other guilds keep their own settings
+	output, err = s.repo.GetGuildConfig(ctx, &GetGuildConfigInput{GuildID: "guild-2"})
+	s.Require().NoError(err)
+	s.Equal(models.RollFeedOff, output.Config.RollFeedMode())
+}
+
+func (s *RedisRepositoryTestSuite) TestGuildIDRequired() {
+	ctx := context.Background()
+
+	_, err := s.repo.GetGuildConfig(ctx, &GetGuildConfigInput{})
+	s.Error(err)
+
+	err = s.repo.SaveGuildConfig(ctx, &SaveGuildConfigInput{Config: &models.GuildConfig{}})
+	s.Error(err)
+}
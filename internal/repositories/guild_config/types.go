@@ -0,0 +1,18 @@
+package guild_config
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// GetGuildConfigInput contains parameters for retrieving a guild's settings
+type GetGuildConfigInput struct {
+	GuildID string
+}
+
+// GetGuildConfigOutput contains the guild's settings
+type GetGuildConfigOutput struct {
+	Config *models.GuildConfig
+}
+
+// SaveGuildConfigInput contains parameters for saving a guild's settings
+type SaveGuildConfigInput struct {
+	Config *models.GuildConfig
+}
@@ -0,0 +1,24 @@
+package job
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/job Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for the queue of background jobs
+type Repository interface {
+	// Enqueue adds a job to the back of the queue
+	Enqueue(ctx context.Context, input *EnqueueInput) error
+
+	// Dequeue takes the job at the front of the queue onto the worker's list, waiting for one to be queued up to the input's timeout.
+	// The output's job is nil when none was queued in time.
+	Dequeue(ctx context.Context, input *DequeueInput) (*DequeueOutput, error)
+
+	// Complete takes a job off the worker's list once it's been run, or queued again
+	Complete(ctx context.Context, input *CompleteInput) error
+
+	// RequeueStale puts the jobs taken by workers that stopped before completing them back on the queue, for a
+	// worker starting up
+	RequeueStale(ctx context.Context, input *RequeueStaleInput) (*RequeueStaleOutput, error)
+}
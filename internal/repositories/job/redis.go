@@ -0,0 +1,253 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefix for the Redis lists jobs are queued on
+	queueKeyPrefix = "jobs:queue:"
+
+	// defaultQueue is the queue used when the config doesn't name one
+	defaultQueue = "default"
+
+	// workerLeaseTTL is how long a worker is taken to be running after it last took a job, or waited for one.
+	// The jobs a worker was running are only taken back once it's been this long.
+	workerLeaseTTL = 5 * time.Minute
+)
+
+// Config holds configuration for the Redis job repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+
+	// Queue names the list jobs are kept on, so bots that don't share an event bus don't run each other's jobs
+	// (optional, defaults to "default")
+	Queue string
+
+	// Worker names the worker taking jobs off the queue, whose jobs are kept on a list of its own while they're
+	// run (optional, defaults to the host name and process ID)
+	Worker string
+}
+
+// redisRepository implements the Repository interface with a Redis list, and a list per worker of the jobs it
+// took and hasn't completed
+type redisRepository struct {
+	client   redis.UniversalClient
+	queueKey string
+	worker   string
+}
+
+// NewRedis creates a new Redis-backed job repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	queue := cfg.Queue
+	if queue == "" {
+		queue = defaultQueue
+	}
+
+	worker := cfg.Worker
+	if worker == "" {
+		hostname, _ := os.Hostname()
+		worker = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	return &redisRepository{
+		client:   cfg.RedisClient,
+		queueKey: queueKeyPrefix + queue,
+		worker:   worker,
+	}, nil
+}
+
+// The keys a queue's workers use are hash tagged with the queue's key, so in a cluster they're kept on the same
+// node as the queue and jobs can be moved between them
+
+// processingKey returns the key of the list of jobs a worker took and hasn't completed
+func (r *redisRepository) processingKey(worker string) string {
+	return fmt.Sprintf("{%s}:processing:%s", r.queueKey, worker)
+}
+
+// workersKey returns the key of the set of workers that have taken jobs off the queue
+func (r *redisRepository) workersKey() string {
+	return fmt.Sprintf("{%s}:workers", r.queueKey)
+}
+
+// leaseKey returns the key that's kept while a worker is running
+func (r *redisRepository) leaseKey(worker string) string {
+	return fmt.Sprintf("{%s}:lease:%s", r.queueKey, worker)
+}
+
+// Enqueue pushes a job onto the back of the queue, along with the unit of work the context carries if there is one
+func (r *redisRepository) Enqueue(ctx context.Context, input *EnqueueInput) error {
+	if input == nil || input.Job == nil {
+		return errors.New("job cannot be nil")
+	}
+
+	if input.Job.ID == "" || input.Job.Type == "" {
+		return errors.New("job ID and type cannot be empty")
+	}
+
+	jobJSON, err := json.Marshal(input.Job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, r.queueKey, jobJSON)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return nil
+}
+
+// Dequeue moves the job at the front of the queue onto the worker's list, blocking until one is pushed or the
+// timeout passes. The job stays on the worker's list until it's completed, so it isn't lost if the worker stops
+// while running it.
+func (r *redisRepository) Dequeue(ctx context.Context, input *DequeueInput) (*DequeueOutput, error) {
+	if input == nil || input.Timeout < 0 {
+		return nil, errors.New("input cannot be nil and timeout cannot be negative")
+	}
+
+	if err := r.renewLease(ctx); err != nil {
+		return nil, err
+	}
+
+	jobJSON, err := r.client.BLMove(ctx, r.queueKey, r.processingKey(r.worker), "LEFT", "RIGHT", input.Timeout).Result()
+	if errors.Is(err, redis.Nil) {
+		return &DequeueOutput{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to dequeue job: %w", err)
+	}
+
+	// Waiting for the job may have taken longer than the lease
+	if err := r.renewLease(ctx); err != nil {
+		return nil, err
+	}
+
+	var job models.Job
+	if err := json.Unmarshal([]byte(jobJSON), &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+
+	return &DequeueOutput{
+		Job: &job,
+	}, nil
+}
+
+// renewLease records that the worker is running, so the jobs it's running aren't taken back
+func (r *redisRepository) renewLease(ctx context.Context) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, r.workersKey(), r.worker)
+		pipe.Set(ctx, r.leaseKey(r.worker), 1, workerLeaseTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to renew worker lease: %w", err)
+	}
+	return nil
+}
+
+// Complete takes a job the worker took off its list, along with the unit of work the context carries if there
+// is one
+func (r *redisRepository) Complete(ctx context.Context, input *CompleteInput) error {
+	if input == nil || input.JobID == "" {
+		return errors.New("job ID cannot be empty")
+	}
+
+	processingKey := r.processingKey(r.worker)
+	jobJSONs, err := r.client.LRange(ctx, processingKey, 0, -1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get the worker's jobs: %w", err)
+	}
+
+	for _, jobJSON := range jobJSONs {
+		var job models.Job
+		if err := json.Unmarshal([]byte(jobJSON), &job); err != nil || job.ID != input.JobID {
+			continue
+		}
+
+		err := transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+			pipe.LRem(ctx, processingKey, 1, jobJSON)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to complete job: %w", err)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// RequeueStale puts the jobs this worker, or workers no longer running, took and never completed back on the front
+// of the queue in the order they were taken
+func (r *redisRepository) RequeueStale(ctx context.Context, input *RequeueStaleInput) (*RequeueStaleOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	workers, err := r.client.SMembers(ctx, r.workersKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workers: %w", err)
+	}
+
+	output := &RequeueStaleOutput{}
+	for _, worker := range workers {
+		// A worker's lease lapses once it stops, this worker's own jobs are stale as it's only starting
+		if worker != r.worker {
+			running, err := r.client.Exists(ctx, r.leaseKey(worker)).Result()
+			if err != nil {
+				return nil, fmt.Errorf("failed to check worker %s: %w", worker, err)
+			}
+			if running > 0 {
+				continue
+			}
+		}
+
+		// The job taken last goes back first, leaving the one taken first at the front
+		for {
+			err := r.client.LMove(ctx, r.processingKey(worker), r.queueKey, "RIGHT", "LEFT").Err()
+			if errors.Is(err, redis.Nil) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to requeue the jobs of worker %s: %w", worker, err)
+			}
+			output.Requeued++
+		}
+
+		if worker != r.worker {
+			if err := r.client.SRem(ctx, r.workersKey(), worker).Err(); err != nil {
+				return nil, fmt.Errorf("failed to remove worker %s: %w", worker, err)
+			}
+		}
+	}
+
+	return output, nil
+}
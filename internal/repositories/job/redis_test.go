@@ -0,0 +1,200 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	ctx     context.Context
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.ctx = context.Background()
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) enqueue(id, gameID string) {
+	err := s.repo.Enqueue(s.ctx, &EnqueueInput{
+		Job: &models.Job{
+			ID:         id,
+			Type:       models.JobTypePostGame,
+			GameID:     gameID,
+			SessionID:  "session-1",
+			EnqueuedAt: s.testNow,
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestEnqueue_Validation() {
+	s.Error(s.repo.Enqueue(s.ctx, nil))
+	s.Error(s.repo.Enqueue(s.ctx, &EnqueueInput{}))
+	s.Error(s.repo.Enqueue(s.ctx, &EnqueueInput{Job: &models.Job{Type: models.JobTypePostGame}}))
+	s.Error(s.repo.Enqueue(s.ctx, &EnqueueInput{Job: &models.Job{ID: "job-1"}}))
+}
+
+func (s *RedisRepositoryTestSuite) TestDequeueInOrder() {
+	s.enqueue("job-1", "game-1")
+	s.enqueue("job-2", "game-2")
+
+	output, err := s.repo.Dequeue(s.ctx, &DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+	s.Equal(&models.Job{
+		ID:         "job-1",
+		Type:       models.JobTypePostGame,
+		GameID:     "game-1",
+		SessionID:  "session-1",
+		EnqueuedAt: s.testNow,
+	}, output.Job)
+
+	output, err = s.repo.Dequeue(s.ctx, &DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+	s.Equal("job-2", output.Job.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestDequeueTimesOut() {
+	output, err := s.repo.Dequeue(s.ctx, &DequeueInput{Timeout: 10 * time.Millisecond})
+	s.Require().NoError(err)
+	s.Nil(output.Job)
+}
+
+func (s *RedisRepositoryTestSuite) TestDequeueWaitsForJob() {
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		s.enqueue("job-1", "game-1")
+	}()
+
+	output, err := s.repo.Dequeue(s.ctx, &DequeueInput{Timeout: 5 * time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+	s.Equal("job-1", output.Job.ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestQueuesAreSeparate() {
+	slackRepo, err := NewRedis(&Config{
+		RedisClient: s.client,
+		Queue:       "slack",
+	})
+	s.Require().NoError(err)
+
+	s.enqueue("job-1", "game-1")
+
+	output, err := slackRepo.Dequeue(s.ctx, &DequeueInput{Timeout: 10 * time.Millisecond})
+	s.Require().NoError(err)
+	s.Nil(output.Job, "jobs queued on the default queue aren't taken off another")
+	s.True(s.mr.Exists("jobs:queue:default"))
+}
+
+func (s *RedisRepositoryTestSuite) TestDequeue_Validation() {
+	_, err := s.repo.Dequeue(s.ctx, nil)
+	s.Error(err)
+
+	_, err = s.repo.Dequeue(s.ctx, &DequeueInput{Timeout: -time.Second})
+	s.Error(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestDequeueKeepsJobUntilCompleted() {
+	repo, err := NewRedis(&Config{RedisClient: s.client, Worker: "worker-1"})
+	s.Require().NoError(err)
+
+	s.enqueue("job-1", "game-1")
+	s.enqueue("job-2", "game-2")
+
+	output, err := repo.Dequeue(s.ctx, &DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+
+	processing, err := s.mr.List("{jobs:queue:default}:processing:worker-1")
+	s.Require().NoError(err)
+	s.Len(processing, 1)
+
+	s.Require().NoError(repo.Complete(s.ctx, &CompleteInput{JobID: "job-2"}))
+	s.True(s.mr.Exists("{jobs:queue:default}:processing:worker-1"), "only the job taken is completed")
+
+	s.Require().NoError(repo.Complete(s.ctx, &CompleteInput{JobID: "job-1"}))
+	s.False(s.mr.Exists("{jobs:queue:default}:processing:worker-1"))
+
+	s.Error(repo.Complete(s.ctx, &CompleteInput{}))
+}
+
+func (s *RedisRepositoryTestSuite) TestRequeueStale() {
+	stopped, err := NewRedis(&Config{RedisClient: s.client, Worker: "stopped"})
+	s.Require().NoError(err)
+	running, err := NewRedis(&Config{RedisClient: s.client, Worker: "running"})
+	s.Require().NoError(err)
+
+	s.enqueue("job-1", "game-1")
+	s.enqueue("job-2", "game-2")
+	s.enqueue("job-3", "game-3")
+	s.enqueue("job-4", "game-4")
+	for _, repo := range []Repository{stopped, stopped, running} {
+		_, err := repo.Dequeue(s.ctx, &DequeueInput{Timeout: time.Second})
+		s.Require().NoError(err)
+	}
+
+	// The stopped worker's lease runs out while the running one keeps taking jobs
+	s.mr.FastForward(workerLeaseTTL)
+	_, err = running.Dequeue(s.ctx, &DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+
+	starting, err := NewRedis(&Config{RedisClient: s.client, Worker: "starting"})
+	s.Require().NoError(err)
+	output, err := starting.RequeueStale(s.ctx, &RequeueStaleInput{})
+	s.Require().NoError(err)
+	s.Equal(2, output.Requeued)
+
+	// The stopped worker's jobs are at the front in the order it took them, the running worker's are left alone
+	queue, err := s.mr.List("jobs:queue:default")
+	s.Require().NoError(err)
+	s.Require().Len(queue, 2)
+	s.Contains(queue[0], "job-1")
+	s.Contains(queue[1], "job-2")
+
+	processing, err := s.mr.List("{jobs:queue:default}:processing:running")
+	s.Require().NoError(err)
+	s.Len(processing, 2)
+
+	members, err := s.mr.Members("{jobs:queue:default}:workers")
+	s.Require().NoError(err)
+	s.Equal([]string{"running"}, members)
+}
@@ -0,0 +1,36 @@
+package job
+
+import (
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// EnqueueInput contains parameters for queueing a job
+type EnqueueInput struct {
+	Job *models.Job
+}
+
+// DequeueInput contains parameters for taking a job off the queue
+type DequeueInput struct {
+	// Timeout is how long to wait for a job to be queued, 0 waits until one is or the context is done
+	Timeout time.Duration
+}
+
+// DequeueOutput contains the job taken off the queue, nil if none was queued in time
+type DequeueOutput struct {
+	Job *models.Job
+}
+
+// CompleteInput contains parameters for completing a job taken off the queue
+type CompleteInput struct {
+	JobID string
+}
+
+// RequeueStaleInput contains parameters for putting the jobs of stopped workers back on the queue
+type RequeueStaleInput struct{}
+
+// RequeueStaleOutput contains how many jobs were put back on the queue
+type RequeueStaleOutput struct {
+	Requeued int
+}
@@ -0,0 +1,16 @@
+package migration
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/migration Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for bringing the data saved by older versions of the bot up to date
+type Repository interface {
+	// GetStatus reports the version of the saved data and the migrations it's missing
+	GetStatus(ctx context.Context, input *GetStatusInput) (*GetStatusOutput, error)
+
+	// Migrate applies the migrations the saved data is missing, in order
+	Migrate(ctx context.Context, input *MigrateInput) (*MigrateOutput, error)
+}
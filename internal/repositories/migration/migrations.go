@@ -0,0 +1,209 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/scan"
+	"github.com/redis/go-redis/v9"
+)
+
+// The keys the migrations rewrite, as the repositories saved them when each migration was written
+const (
+	gameKeyPrefix         = "game:"
+	participantsKeyPrefix = "game_participants:"
+	channelActivePrefix   = "channel_active:"
+	guildPresetsKeyPrefix = "guild_presets:"
+	drinkKeyPrefix        = "drink:"
+	playerDrinksKeyPrefix = "player_drinks:"
+)
+
+// migration is a change to how the bot's data is saved, run returning how many records it rewrote
+type migration struct {
+	version int
+	name    string
+	run     func(ctx context.Context, client redis.UniversalClient) (int, error)
+}
+
+// migrations are every migration, in the order they're applied. New ones go on the end with the next version, and
+// have to be safe to run again as a failed migration is run again from the start.
+var migrations = []*migration{
+	{version: 1, name: "index the players in each game", run: indexGameParticipants},
+	{version: 2, name: "track each channel's unfinished games", run: trackChannelGames},
+	{version: 3, name: "save preset critical values as lists", run: listPresetCriticalValues},
+	{version: 4, name: "index the drinks each player handed on", run: indexTransferredDrinks},
+}
+
+// forEachGame calls fn with every saved game
+func forEachGame(ctx context.Context, client redis.UniversalClient, fn func(game *models.Game) (bool, error)) (int, error) {
+	keys, err := scan.Keys(ctx, client, gameKeyPrefix+"*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan games: %w", err)
+	}
+
+	changed := 0
+	for _, key := range keys {
+		gameJSON, err := client.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				// Deleted since the scan found it
+				continue
+			}
+			return changed, fmt.Errorf("failed to get %s: %w", key, err)
+		}
+
+		var game models.Game
+		if err := json.Unmarshal([]byte(gameJSON), &game); err != nil {
+			return changed, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+
+		updated, err := fn(&game)
+		if err != nil {
+			return changed, err
+		}
+		if updated {
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// indexGameParticipants adds the set of players in each game to games saved before the set was kept, so checking
+// whether a player is in a game doesn't load the game
+func indexGameParticipants(ctx context.Context, client redis.UniversalClient) (int, error) {
+	return forEachGame(ctx, client, func(game *models.Game) (bool, error) {
+		if len(game.Participants) == 0 {
+			return false, nil
+		}
+
+		participantsKey := participantsKeyPrefix + game.ID
+		exists, err := client.Exists(ctx, participantsKey).Result()
+		if err != nil {
+			return false, fmt.Errorf("failed to check the players of game %s: %w", game.ID, err)
+		}
+		if exists > 0 {
+			return false, nil
+		}
+
+		playerIDs := make([]interface{}, 0, len(game.Participants))
+		for _, participant := range game.Participants {
+			playerIDs = append(playerIDs, participant.PlayerID)
+		}
+		if err := client.SAdd(ctx, participantsKey, playerIDs...).Err(); err != nil {
+			return false, fmt.Errorf("failed to index the players of game %s: %w", game.ID, err)
+		}
+		return true, nil
+	})
+}
+
+// trackChannelGames adds the unfinished games saved before channels kept track of them to their channel's, so
+// lobbies nobody started can be found and cleaned up
+func trackChannelGames(ctx context.Context, client redis.UniversalClient) (int, error) {
+	return forEachGame(ctx, client, func(game *models.Game) (bool, error) {
+		// Roll-offs are found through their game
+		if game.ChannelID == "" || game.ParentGameID != "" || game.Status.IsFinished() {
+			return false, nil
+		}
+
+		added, err := client.ZAddNX(ctx, channelActivePrefix+game.ChannelID, redis.Z{
+			Score:  float64(game.CreatedAt.UnixNano()),
+			Member: game.ID,
+		}).Result()
+		if err != nil {
+			return false, fmt.Errorf("failed to track game %s in its channel: %w", game.ID, err)
+		}
+		return added > 0, nil
+	})
+}
+
+// listPresetCriticalValues saves again the presets saved before critical values became lists, when each was a
+// single roll. They're read either way, but saving them again drops the old fields.
+func listPresetCriticalValues(ctx context.Context, client redis.UniversalClient) (int, error) {
+	keys, err := scan.Keys(ctx, client, guildPresetsKeyPrefix+"*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan presets: %w", err)
+	}
+
+	changed := 0
+	for _, key := range keys {
+		presets, err := client.HGetAll(ctx, key).Result()
+		if err != nil {
+			return changed, fmt.Errorf("failed to get %s: %w", key, err)
+		}
+
+		for field, presetJSON := range presets {
+			var saved struct {
+				Rules map[string]json.RawMessage `json:"rules"`
+			}
+			if err := json.Unmarshal([]byte(presetJSON), &saved); err != nil {
+				return changed, fmt.Errorf("failed to unmarshal preset %s in %s: %w", field, key, err)
+			}
+			_, hasHit := saved.Rules["critical_hit_value"]
+			_, hasFail := saved.Rules["critical_fail_value"]
+			if !hasHit && !hasFail {
+				continue
+			}
+
+			var preset models.Preset
+			if err := json.Unmarshal([]byte(presetJSON), &preset); err != nil {
+				return changed, fmt.Errorf("failed to unmarshal preset %s in %s: %w", field, key, err)
+			}
+			updatedJSON, err := json.Marshal(&preset)
+			if err != nil {
+				return changed, fmt.Errorf("failed to marshal preset %s in %s: %w", field, key, err)
+			}
+			if err := client.HSet(ctx, key, field, updatedJSON).Err(); err != nil {
+				return changed, fmt.Errorf("failed to save preset %s in %s: %w", field, key, err)
+			}
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// indexTransferredDrinks adds the drinks handed on before players kept track of them to the index of each player
+// who handed them on, so merging or forgetting that player rewrites the drink's transfer chain too
+func indexTransferredDrinks(ctx context.Context, client redis.UniversalClient) (int, error) {
+	keys, err := scan.Keys(ctx, client, drinkKeyPrefix+"*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan drinks: %w", err)
+	}
+
+	changed := 0
+	for _, key := range keys {
+		recordJSON, err := client.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				// Deleted since the scan found it
+				continue
+			}
+			return changed, fmt.Errorf("failed to get %s: %w", key, err)
+		}
+
+		var record models.DrinkLedger
+		if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+			return changed, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+
+		indexed := false
+		for _, playerID := range record.TransferredFrom {
+			added, err := client.ZAddNX(ctx, playerDrinksKeyPrefix+playerID+":transferred", redis.Z{
+				Score:  float64(record.Timestamp.Unix()),
+				Member: record.ID,
+			}).Result()
+			if err != nil {
+				return changed, fmt.Errorf("failed to index drink %s: %w", record.ID, err)
+			}
+			indexed = indexed || added > 0
+		}
+		if indexed {
+			changed++
+		}
+	}
+
+	return changed, nil
+}
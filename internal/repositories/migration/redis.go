@@ -0,0 +1,128 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// versionKey holds the version of the saved data, the last migration applied to it
+const versionKey = "schema_version"
+
+// Config holds configuration for the Redis migration repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client     redis.UniversalClient
+	migrations []*migration
+}
+
+// NewRedis creates a new Redis-backed migration repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	return &redisRepository{
+		client:     cfg.RedisClient,
+		migrations: migrations,
+	}, nil
+}
+
+// GetStatus reports the version of the saved data and the migrations it's missing
+func (r *redisRepository) GetStatus(ctx context.Context, input *GetStatusInput) (*GetStatusOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	version, err := r.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetStatusOutput{
+		Version: version,
+		Pending: []*Migration{},
+	}
+	for _, m := range r.pending(version) {
+		output.Pending = append(output.Pending, &Migration{Version: m.version, Name: m.name})
+	}
+
+	return output, nil
+}
+
+// Migrate applies the migrations the saved data is missing in order, recording the version after each one so a
+// failed migration is picked up again from where it stopped. Each migration can safely be run again.
+func (r *redisRepository) Migrate(ctx context.Context, input *MigrateInput) (*MigrateOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	version, err := r.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &MigrateOutput{
+		Applied: []*AppliedMigration{},
+		Version: version,
+	}
+	for _, m := range r.pending(version) {
+		changed, err := m.run(ctx, r.client)
+		if err != nil {
+			return output, fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.name, err)
+		}
+
+		if err := r.client.Set(ctx, versionKey, m.version, 0).Err(); err != nil {
+			return output, fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+
+		output.Version = m.version
+		output.Applied = append(output.Applied, &AppliedMigration{
+			Migration: &Migration{Version: m.version, Name: m.name},
+			Changed:   changed,
+		})
+	}
+
+	return output, nil
+}
+
+// version returns the version of the saved data, 0 when no migration has been applied
+func (r *redisRepository) version(ctx context.Context) (int, error) {
+	value, err := r.client.Get(ctx, versionKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("schema version %q isn't a number: %w", value, err)
+	}
+
+	return version, nil
+}
+
+// pending returns the migrations newer than the version, in order
+func (r *redisRepository) pending(version int) []*migration {
+	var pending []*migration
+	for _, m := range r.migrations {
+		if m.version > version {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
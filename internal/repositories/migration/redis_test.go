@@ -0,0 +1,170 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	ctx     context.Context
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.ctx = context.Background()
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+// saveOldGame saves a game the way older versions of the bot did, without any of its indexes
+func (s *RedisRepositoryTestSuite) saveOldGame(game *models.Game) {
+	gameJSON, err := json.Marshal(game)
+	s.Require().NoError(err)
+	s.Require().NoError(s.mr.Set(gameKeyPrefix+game.ID, string(gameJSON)))
+}
+
+func (s *RedisRepositoryTestSuite) TestStatusBeforeMigrating() {
+	output, err := s.repo.GetStatus(s.ctx, &GetStatusInput{})
+	s.Require().NoError(err)
+	s.Equal(0, output.Version)
+	s.Require().Len(output.Pending, len(migrations))
+	s.Equal(1, output.Pending[0].Version)
+}
+
+func (s *RedisRepositoryTestSuite) TestMigrate() {
+	s.saveOldGame(&models.Game{
+		ID:        "lobby",
+		ChannelID: "channel-1",
+		Status:    models.GameStatusWaiting,
+		CreatedAt: s.testNow,
+		Participants: []*models.Participant{
+			{ID: "p1", GameID: "lobby", PlayerID: "alice"},
+			{ID: "p2", GameID: "lobby", PlayerID: "bob"},
+		},
+	})
+	s.saveOldGame(&models.Game{
+		ID:           "roll-off",
+		ChannelID:    "channel-1",
+		ParentGameID: "lobby",
+		Status:       models.GameStatusRollOff,
+		Participants: []*models.Participant{{ID: "p3", GameID: "roll-off", PlayerID: "alice"}},
+	})
+	s.saveOldGame(&models.Game{
+		ID:        "done",
+		ChannelID: "channel-2",
+		Status:    models.GameStatusCompleted,
+	})
+	s.mr.HSet(guildPresetsKeyPrefix+"guild-1",
+		"big", `{"name":"Big","guild_id":"guild-1","rules":{"dice_sides":20,"critical_hit_value":20,"critical_fail_value":1}}`,
+		"new", `{"name":"New","guild_id":"guild-1","rules":{"dice_sides":6,"critical_hit_values":[5,6]}}`)
+	s.Require().NoError(s.mr.Set(drinkKeyPrefix+"drink-1", `{"ID":"drink-1","ToPlayerID":"carol","TransferredFrom":["alice","bob"]}`))
+	s.Require().NoError(s.mr.Set(drinkKeyPrefix+"drink-2", `{"ID":"drink-2","ToPlayerID":"alice"}`))
+
+	output, err := s.repo.Migrate(s.ctx, &MigrateInput{})
+	s.Require().NoError(err)
+	s.Equal(len(migrations), output.Version)
+	s.Require().Len(output.Applied, len(migrations))
+	s.Equal(2, output.Applied[0].Changed)
+	s.Equal(1, output.Applied[1].Changed)
+	s.Equal(1, output.Applied[2].Changed)
+	s.Equal(1, output.Applied[3].Changed)
+
+	members, err := s.mr.Members(participantsKeyPrefix + "lobby")
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"alice", "bob"}, members)
+
+	// Only the unfinished top-level game is tracked by its channel
+	lobbies, err := s.mr.ZMembers(channelActivePrefix + "channel-1")
+	s.Require().NoError(err)
+	s.Equal([]string{"lobby"}, lobbies)
+	s.False(s.mr.Exists(channelActivePrefix + "channel-2"))
+
+	var preset models.Preset
+	s.Require().NoError(json.Unmarshal([]byte(s.mr.HGet(guildPresetsKeyPrefix+"guild-1", "big")), &preset))
+	s.Equal([]int{20}, preset.Rules.CriticalHitValues)
+	s.Equal([]int{1}, preset.Rules.CriticalFailValues)
+	s.NotContains(s.mr.HGet(guildPresetsKeyPrefix+"guild-1", "big"), "critical_hit_value\"")
+
+	// Each player who handed a drink on can find it
+	for _, playerID := range []string{"alice", "bob"} {
+		handedOn, err := s.mr.ZMembers(playerDrinksKeyPrefix + playerID + ":transferred")
+		s.Require().NoError(err)
+		s.Equal([]string{"drink-1"}, handedOn)
+	}
+
+	// Everything is up to date now
+	status, err := s.repo.GetStatus(s.ctx, &GetStatusInput{})
+	s.Require().NoError(err)
+	s.Equal(len(migrations), status.Version)
+	s.Empty(status.Pending)
+
+	output, err = s.repo.Migrate(s.ctx, &MigrateInput{})
+	s.Require().NoError(err)
+	s.Empty(output.Applied)
+}
+
+func (s *RedisRepositoryTestSuite) TestMigrationsCanRunAgain() {
+	s.saveOldGame(&models.Game{
+		ID:           "game",
+		ChannelID:    "channel-1",
+		Status:       models.GameStatusActive,
+		Participants: []*models.Participant{{ID: "p1", GameID: "game", PlayerID: "alice"}},
+	})
+	s.Require().NoError(s.mr.Set(drinkKeyPrefix+"drink-1", `{"ID":"drink-1","ToPlayerID":"bob","TransferredFrom":["alice"]}`))
+
+	for _, m := range migrations {
+		_, err := m.run(s.ctx, s.client)
+		s.Require().NoError(err)
+
+		changed, err := m.run(s.ctx, s.client)
+		s.Require().NoError(err)
+		s.Equal(0, changed, m.name)
+	}
+}
+
+func (s *RedisRepositoryTestSuite) TestPicksUpFromTheSavedVersion() {
+	s.Require().NoError(s.mr.Set(versionKey, "2"))
+
+	output, err := s.repo.Migrate(s.ctx, &MigrateInput{})
+	s.Require().NoError(err)
+	s.Require().Len(output.Applied, 2)
+	s.Equal(3, output.Applied[0].Migration.Version)
+	s.Equal(4, output.Applied[1].Migration.Version)
+
+	s.Require().NoError(s.mr.Set(versionKey, "soon"))
+	_, err = s.repo.GetStatus(s.ctx, &GetStatusInput{})
+	s.Error(err)
+}
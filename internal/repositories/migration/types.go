@@ -0,0 +1,42 @@
+package migration
+
+// Migration describes a change to how the bot's data is saved
+type Migration struct {
+	// Version is the data's version once the migration has been applied
+	Version int
+
+	// Name says what the migration does
+	Name string
+}
+
+// GetStatusInput contains parameters for checking the saved data's version
+type GetStatusInput struct{}
+
+// GetStatusOutput contains the saved data's version and the migrations it's missing
+type GetStatusOutput struct {
+	// Version is the version of the saved data, 0 before any migration has been applied
+	Version int
+
+	// Pending are the migrations still to apply, in order
+	Pending []*Migration
+}
+
+// MigrateInput contains parameters for migrating the saved data
+type MigrateInput struct{}
+
+// AppliedMigration is a migration that was applied and how much it changed
+type AppliedMigration struct {
+	Migration *Migration
+
+	// Changed is how many records the migration rewrote
+	Changed int
+}
+
+// MigrateOutput contains the migrations that were applied
+type MigrateOutput struct {
+	// Applied are the migrations applied, in order
+	Applied []*AppliedMigration
+
+	// Version is the version of the saved data afterwards
+	Version int
+}
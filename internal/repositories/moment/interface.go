@@ -0,0 +1,19 @@
+package moment
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/moment Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for keeping clipped moments
+type Repository interface {
+	// AddMoment saves a moment, ErrMomentExists if it was already clipped
+	AddMoment(ctx context.Context, input *AddMomentInput) error
+
+	// GetMoments returns a page of a scope's moments, newest first
+	GetMoments(ctx context.Context, input *GetMomentsInput) (*GetMomentsOutput, error)
+
+	// ReassignPlayerMoments moves a player's moments, the ones they rolled or clipped, to another player
+	ReassignPlayerMoments(ctx context.Context, input *ReassignPlayerMomentsInput) (*ReassignPlayerMomentsOutput, error)
+}
@@ -0,0 +1,251 @@
+package moment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis
+	momentKeyPrefix        = "moment:"
+	scopeMomentsKeyPrefix  = "moments:scope:"
+	playerMomentsKeyPrefix = "moments:player:"
+)
+
+// ErrMomentExists is returned when a roll has already been clipped
+var ErrMomentExists = errors.New("moment already exists")
+
+// Config holds configuration for the Redis moment repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed moment repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// AddMoment saves a moment and indexes it by scope and by the players it involves
+func (r *redisRepository) AddMoment(ctx context.Context, input *AddMomentInput) error {
+	if input == nil || input.Moment == nil {
+		return errors.New("moment cannot be nil")
+	}
+
+	moment := input.Moment
+	if moment.ID == "" {
+		return errors.New("moment ID cannot be empty")
+	}
+
+	momentJSON, err := json.Marshal(moment)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moment: %w", err)
+	}
+
+	added, err := r.client.SetNX(ctx, momentKeyPrefix+moment.ID, momentJSON, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to save moment: %w", err)
+	}
+	if !added {
+		return ErrMomentExists
+	}
+
+	member := redis.Z{
+		Score:  float64(moment.ClippedAt.UnixNano()),
+		Member: moment.ID,
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, scopeMomentsKeyPrefix+moment.MomentScope(), member)
+	pipe.ZAdd(ctx, playerMomentsKeyPrefix+moment.PlayerID, member)
+	if moment.ClippedByID != "" && moment.ClippedByID != moment.PlayerID {
+		pipe.ZAdd(ctx, playerMomentsKeyPrefix+moment.ClippedByID, member)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index moment: %w", err)
+	}
+
+	return nil
+}
+
+// GetMoments returns a page of a scope's moments, newest first
+func (r *redisRepository) GetMoments(ctx context.Context, input *GetMomentsInput) (*GetMomentsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.Scope == "" {
+		return nil, errors.New("scope cannot be empty")
+	}
+
+	key := scopeMomentsKeyPrefix + input.Scope
+	total, err := r.client.ZCard(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count moments: %w", err)
+	}
+
+	stop := int64(-1)
+	if input.Limit > 0 {
+		stop = int64(input.Offset + input.Limit - 1)
+	}
+
+	momentIDs, err := r.client.ZRevRange(ctx, key, int64(input.Offset), stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moment IDs: %w", err)
+	}
+
+	moments, err := r.getMoments(ctx, momentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMomentsOutput{
+		Moments: moments,
+		Total:   int(total),
+	}, nil
+}
+
+// ReassignPlayerMoments moves a player's moments, the ones they rolled or clipped, to another player, or only
+// those from one guild
+func (r *redisRepository) ReassignPlayerMoments(ctx context.Context, input *ReassignPlayerMomentsInput) (*ReassignPlayerMomentsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.FromPlayerID == "" || input.ToPlayerID == "" {
+		return nil, errors.New("player IDs cannot be empty")
+	}
+
+	fromKey := playerMomentsKeyPrefix + input.FromPlayerID
+	members, err := r.client.ZRangeWithScores(ctx, fromKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player's moment IDs: %w", err)
+	}
+
+	if len(members) == 0 {
+		return &ReassignPlayerMomentsOutput{}, nil
+	}
+
+	momentIDs := make([]string, 0, len(members))
+	scores := make(map[string]float64, len(members))
+	for _, member := range members {
+		momentID := member.Member.(string)
+		momentIDs = append(momentIDs, momentID)
+		scores[momentID] = member.Score
+	}
+
+	moments, err := r.getMoments(ctx, momentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.GuildID != "" {
+		moments = slices.DeleteFunc(moments, func(moment *models.Moment) bool {
+			return moment.GuildID != input.GuildID
+		})
+	}
+
+	pipe := r.client.TxPipeline()
+	for _, moment := range moments {
+		if moment.PlayerID == input.FromPlayerID {
+			moment.PlayerID = input.ToPlayerID
+			moment.PlayerName = input.ToPlayerName
+		}
+		if moment.ClippedByID == input.FromPlayerID {
+			moment.ClippedByID = input.ToPlayerID
+			moment.ClippedByName = input.ToPlayerName
+		}
+
+		momentJSON, err := json.Marshal(moment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal moment: %w", err)
+		}
+		pipe.Set(ctx, momentKeyPrefix+moment.ID, momentJSON, 0)
+	}
+	if input.GuildID == "" {
+		pipe.ZUnionStore(ctx, playerMomentsKeyPrefix+input.ToPlayerID, &redis.ZStore{
+			Keys:      []string{playerMomentsKeyPrefix + input.ToPlayerID, fromKey},
+			Aggregate: "MAX",
+		})
+		pipe.Del(ctx, fromKey)
+	} else if len(moments) > 0 {
+		// The player keeps their moments from other guilds
+		moved := make([]redis.Z, 0, len(moments))
+		movedIDs := make([]interface{}, 0, len(moments))
+		for _, moment := range moments {
+			moved = append(moved, redis.Z{Score: scores[moment.ID], Member: moment.ID})
+			movedIDs = append(movedIDs, moment.ID)
+		}
+		pipe.ZAdd(ctx, playerMomentsKeyPrefix+input.ToPlayerID, moved...)
+		pipe.ZRem(ctx, fromKey, movedIDs...)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reassign moments: %w", err)
+	}
+
+	return &ReassignPlayerMomentsOutput{
+		MomentsMoved: len(moments),
+	}, nil
+}
+
+// getMoments loads moments by ID in one call, in the same order, skipping any that have gone missing
+func (r *redisRepository) getMoments(ctx context.Context, momentIDs []string) ([]*models.Moment, error) {
+	if len(momentIDs) == 0 {
+		return []*models.Moment{}, nil
+	}
+
+	keys := make([]string, 0, len(momentIDs))
+	for _, momentID := range momentIDs {
+		keys = append(keys, momentKeyPrefix+momentID)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moments: %w", err)
+	}
+
+	moments := make([]*models.Moment, 0, len(values))
+	for _, value := range values {
+		momentJSON, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var moment models.Moment
+		if err := json.Unmarshal([]byte(momentJSON), &moment); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal moment: %w", err)
+		}
+		moments = append(moments, &moment)
+	}
+
+	return moments, nil
+}
@@ -0,0 +1,135 @@
+package moment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	ctx     context.Context
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.ctx = context.Background()
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) addMoment(id, guildID, playerID, clippedByID string, clippedAt time.Time) {
+	err := s.repo.AddMoment(s.ctx, &AddMomentInput{
+		Moment: &models.Moment{
+			ID:            id,
+			GuildID:       guildID,
+			ChannelID:     "channel-1",
+			PlayerID:      playerID,
+			PlayerName:    "Player " + playerID,
+			RollValue:     6,
+			ClippedByID:   clippedByID,
+			ClippedByName: "Player " + clippedByID,
+			ClippedAt:     clippedAt,
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetMoments_NewestFirst() {
+	s.addMoment("m1", "guild-1", "alice", "bob", s.testNow)
+	s.addMoment("m2", "guild-1", "bob", "bob", s.testNow.Add(time.Minute))
+	s.addMoment("m3", "guild-1", "carol", "alice", s.testNow.Add(2*time.Minute))
+	s.addMoment("m4", "guild-2", "alice", "alice", s.testNow.Add(3*time.Minute))
+
+	output, err := s.repo.GetMoments(s.ctx, &GetMomentsInput{Scope: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal(3, output.Total)
+	s.Require().Len(output.Moments, 3)
+	s.Equal("m3", output.Moments[0].ID)
+	s.Equal("m1", output.Moments[2].ID)
+
+	page, err := s.repo.GetMoments(s.ctx, &GetMomentsInput{Scope: "guild-1", Offset: 1, Limit: 1})
+	s.Require().NoError(err)
+	s.Equal(3, page.Total)
+	s.Require().Len(page.Moments, 1)
+	s.Equal("m2", page.Moments[0].ID)
+}
+
+func (s *RedisRepositoryTestSuite) TestAddMoment_AlreadyClipped() {
+	s.addMoment("m1", "guild-1", "alice", "bob", s.testNow)
+
+	err := s.repo.AddMoment(s.ctx, &AddMomentInput{
+		Moment: &models.Moment{ID: "m1", GuildID: "guild-1", PlayerID: "alice", ClippedByID: "carol", ClippedAt: s.testNow},
+	})
+	s.ErrorIs(err, ErrMomentExists)
+
+	output, err := s.repo.GetMoments(s.ctx, &GetMomentsInput{Scope: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Moments, 1)
+	s.Equal("bob", output.Moments[0].ClippedByID)
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerMoments() {
+	s.addMoment("m1", "guild-1", "alice", "bob", s.testNow)
+	s.addMoment("m2", "guild-1", "bob", "alice", s.testNow.Add(time.Minute))
+	s.addMoment("m3", "guild-1", "carol", "carol", s.testNow.Add(2*time.Minute))
+
+	output, err := s.repo.ReassignPlayerMoments(s.ctx, &ReassignPlayerMomentsInput{
+		FromPlayerID: "alice",
+		ToPlayerID:   "anon-1",
+		ToPlayerName: models.DeletedPlayerName,
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.MomentsMoved)
+
+	moments, err := s.repo.GetMoments(s.ctx, &GetMomentsInput{Scope: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(moments.Moments, 3)
+	s.Equal("carol", moments.Moments[0].PlayerID)
+	s.Equal("anon-1", moments.Moments[1].ClippedByID)
+	s.Equal(models.DeletedPlayerName, moments.Moments[1].ClippedByName)
+	s.Equal("bob", moments.Moments[1].PlayerID)
+	s.Equal("anon-1", moments.Moments[2].PlayerID)
+	s.Equal("bob", moments.Moments[2].ClippedByID)
+
+	// Nothing is left under the forgotten player
+	again, err := s.repo.ReassignPlayerMoments(s.ctx, &ReassignPlayerMomentsInput{
+		FromPlayerID: "alice",
+		ToPlayerID:   "anon-1",
+	})
+	s.Require().NoError(err)
+	s.Zero(again.MomentsMoved)
+}
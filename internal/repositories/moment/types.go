@@ -0,0 +1,43 @@
+package moment
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// AddMomentInput contains parameters for saving a moment
+type AddMomentInput struct {
+	Moment *models.Moment
+}
+
+// GetMomentsInput contains parameters for listing moments
+type GetMomentsInput struct {
+	// Scope is the guild, or the channel outside a guild, the moments are kept under
+	Scope string
+
+	// Offset skips the newest moments and Limit caps how many are returned, 0 returns them all
+	Offset int
+	Limit  int
+}
+
+// GetMomentsOutput contains a page of moments
+type GetMomentsOutput struct {
+	Moments []*models.Moment
+
+	// Total is how many moments the scope has
+	Total int
+}
+
+// ReassignPlayerMomentsInput contains parameters for moving a player's moments
+type ReassignPlayerMomentsInput struct {
+	FromPlayerID string
+	ToPlayerID   string
+	ToPlayerName string
+
+	// GuildID limits the reassignment to the moments from the server, all are moved when it's empty
+	GuildID string
+}
+
+// ReassignPlayerMomentsOutput contains the result of moving a player's moments
+type ReassignPlayerMomentsOutput struct {
+	MomentsMoved int
+}
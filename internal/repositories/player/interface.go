@@ -21,4 +21,7 @@ type Repository interface {
 	
 	// UpdatePlayerGame updates a player's current game
 	UpdatePlayerGame(ctx context.Context, input *UpdatePlayerGameInput) error
+	
+	// DeletePlayer removes a player's profile
+	DeletePlayer(ctx context.Context, input *DeletePlayerInput) error
 }
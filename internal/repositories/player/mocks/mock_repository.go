@@ -40,6 +40,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeletePlayer mocks base method.
+func (m *MockRepository) DeletePlayer(arg0 context.Context, arg1 *player.DeletePlayerInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayer indicates an expected call of DeletePlayer.
+func (mr *MockRepositoryMockRecorder) DeletePlayer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayer", reflect.TypeOf((*MockRepository)(nil).DeletePlayer), arg0, arg1)
+}
+
 // GetPlayer mocks base method.
 func (m *MockRepository) GetPlayer(arg0 context.Context, arg1 *player.GetPlayerInput) (*models.Player, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -22,12 +24,16 @@ var ErrPlayerNotFound = errors.New("player not found")
 // Config holds configuration for the Redis player repository
 type Config struct {
 	// Redis client
-	RedisClient *redis.Client
+	RedisClient redis.UniversalClient
+
+	// ReadClient is an optional read replica for the reads callers allow to lag behind
+	ReadClient redis.UniversalClient
 }
 
 // redisRepository implements the Repository interface using Redis
 type redisRepository struct {
-	client *redis.Client
+	client  redis.UniversalClient
+	replica redis.UniversalClient
 }
 
 // NewRedis creates a new Redis-backed player repository
@@ -46,8 +52,15 @@ func NewRedis(cfg *Config) (*redisRepository, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
 
+	if cfg.ReadClient != nil {
+		if err := cfg.ReadClient.Ping(context.Background()).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis replica: %w", err)
+		}
+	}
+
 	return &redisRepository{
-		client: cfg.RedisClient,
+		client:  cfg.RedisClient,
+		replica: cfg.ReadClient,
 	}, nil
 }
 
@@ -70,21 +83,20 @@ func (r *redisRepository) SavePlayer(ctx context.Context, input *SavePlayerInput
 		return fmt.Errorf("failed to marshal player: %w", err)
 	}
 
-	// Create a Redis transaction
-	pipe := r.client.Pipeline()
-
-	// Save the player
-	playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, player.ID)
-	pipe.Set(ctx, playerKey, playerJSON, 0) // No expiration for now
+	// Save the player and their game membership together, as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		// Save the player
+		playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, player.ID)
+		pipe.Set(ctx, playerKey, playerJSON, 0) // No expiration for now
 
-	// If the player is in a game, add them to the game's player set
-	if player.CurrentGameID != "" {
-		gamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, player.CurrentGameID)
-		pipe.SAdd(ctx, gamePlayersKey, player.ID)
-	}
+		// If the player is in a game, add them to the game's player set
+		if player.CurrentGameID != "" {
+			gamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, player.CurrentGameID)
+			pipe.SAdd(ctx, gamePlayersKey, player.ID)
+		}
 
-	// Execute the transaction
-	_, err = pipe.Exec(ctx)
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to save player: %w", err)
 	}
@@ -98,9 +110,14 @@ func (r *redisRepository) GetPlayer(ctx context.Context, input *GetPlayerInput)
 		return nil, errors.New("input and player ID cannot be empty")
 	}
 
-	// Get the player from Redis
+	// Get the player from Redis, or its replica when the caller allows it
 	playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, input.PlayerID)
-	playerJSON, err := r.client.Get(ctx, playerKey).Result()
+	var playerJSON string
+	err := replica.Read(ctx, r.client, r.replica, func(client redis.UniversalClient) error {
+		var err error
+		playerJSON, err = client.Get(ctx, playerKey).Result()
+		return err
+	})
 	if err != nil {
 		if err == redis.Nil {
 			return nil, ErrPlayerNotFound
@@ -191,16 +208,8 @@ func (r *redisRepository) UpdatePlayerGame(ctx context.Context, input *UpdatePla
 		return err
 	}
 
-	// Create a Redis transaction
-	pipe := r.client.Pipeline()
-
-	// If the player is currently in a game, remove them from that game's player set
-	if player.CurrentGameID != "" && player.CurrentGameID != input.GameID {
-		oldGamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, player.CurrentGameID)
-		pipe.SRem(ctx, oldGamePlayersKey, player.ID)
-	}
-
-	// Update the player's current game
+	// Update the player's current game, remembering the one they're leaving
+	previousGameID := player.CurrentGameID
 	player.CurrentGameID = input.GameID
 
 	// Marshal the updated player
@@ -209,21 +218,59 @@ func (r *redisRepository) UpdatePlayerGame(ctx context.Context, input *UpdatePla
 		return fmt.Errorf("failed to marshal player: %w", err)
 	}
 
-	// Save the updated player
-	playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, player.ID)
-	pipe.Set(ctx, playerKey, playerJSON, 0)
+	// Move the player between games together, as part of the caller's unit of work if there is one
+	err = transaction.Pipelined(ctx, r.client, func(pipe redis.Pipeliner) error {
+		// If the player is currently in a game, remove them from that game's player set
+		if previousGameID != "" && previousGameID != input.GameID {
+			oldGamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, previousGameID)
+			pipe.SRem(ctx, oldGamePlayersKey, player.ID)
+		}
 
-	// If the player is joining a new game, add them to that game's player set
-	if input.GameID != "" {
-		newGamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, input.GameID)
-		pipe.SAdd(ctx, newGamePlayersKey, player.ID)
-	}
+		// Save the updated player
+		playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, player.ID)
+		pipe.Set(ctx, playerKey, playerJSON, 0)
 
-	// Execute the transaction
-	_, err = pipe.Exec(ctx)
+		// If the player is joining a new game, add them to that game's player set
+		if input.GameID != "" {
+			newGamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, input.GameID)
+			pipe.SAdd(ctx, newGamePlayersKey, player.ID)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update player game: %w", err)
 	}
 
 	return nil
 }
+
+// DeletePlayer removes a player and their game membership from Redis
+func (r *redisRepository) DeletePlayer(ctx context.Context, input *DeletePlayerInput) error {
+	if input == nil || input.PlayerID == "" {
+		return errors.New("input and player ID cannot be empty")
+	}
+
+	player, err := r.GetPlayer(ctx, &GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+
+	if player.CurrentGameID != "" {
+		gamePlayersKey := fmt.Sprintf("%s%s", gamePlayersKeyPrefix, player.CurrentGameID)
+		pipe.SRem(ctx, gamePlayersKey, player.ID)
+	}
+
+	playerKey := fmt.Sprintf("%s%s", playerKeyPrefix, player.ID)
+	pipe.Del(ctx, playerKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete player: %w", err)
+	}
+
+	return nil
+}
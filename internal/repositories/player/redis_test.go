@@ -251,3 +251,43 @@ func (s *RedisRepositoryTestSuite) TestGetNonExistentPlayer() {
 	s.Require().Error(err)
 	s.Equal(ErrPlayerNotFound, err)
 }
+
+func (s *RedisRepositoryTestSuite) TestDeletePlayer() {
+	// Create a test player in a game
+	player := &models.Player{
+		ID:            "test-player-id",
+		Name:          "Test Player",
+		CurrentGameID: "game-id",
+	}
+
+	err := s.repo.SavePlayer(context.Background(), &SavePlayerInput{
+		Player: player,
+	})
+	s.Require().NoError(err)
+
+	// Delete the player
+	err = s.repo.DeletePlayer(context.Background(), &DeletePlayerInput{
+		PlayerID: "test-player-id",
+	})
+	s.Require().NoError(err)
+
+	// Verify the player is gone
+	_, err = s.repo.GetPlayer(context.Background(), &GetPlayerInput{
+		PlayerID: "test-player-id",
+	})
+	s.Equal(ErrPlayerNotFound, err)
+
+	// Verify the player is no longer in the game
+	gameOutput, err := s.repo.GetPlayersInGame(context.Background(), &GetPlayersInGameInput{
+		GameID: "game-id",
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(gameOutput.Players)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteNonExistentPlayer() {
+	err := s.repo.DeletePlayer(context.Background(), &DeletePlayerInput{
+		PlayerID: "non-existent-player",
+	})
+	s.Equal(ErrPlayerNotFound, err)
+}
@@ -27,3 +27,8 @@ type UpdatePlayerGameInput struct {
 	PlayerID string
 	GameID   string
 }
+
+// DeletePlayerInput contains parameters for deleting a player
+type DeletePlayerInput struct {
+	PlayerID string
+}
@@ -0,0 +1,24 @@
+package preset
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/preset Repository
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// Repository defines the interface for game preset persistence
+type Repository interface {
+	// SavePreset persists a preset, replacing any preset of the same name in the guild
+	SavePreset(ctx context.Context, input *SavePresetInput) error
+
+	// GetPreset retrieves a guild's preset by name
+	GetPreset(ctx context.Context, input *GetPresetInput) (*models.Preset, error)
+
+	// GetPresetsForGuild retrieves all presets saved for a guild
+	GetPresetsForGuild(ctx context.Context, input *GetPresetsForGuildInput) (*GetPresetsForGuildOutput, error)
+
+	// DeletePreset removes a guild's preset
+	DeletePreset(ctx context.Context, input *DeletePresetInput) error
+}
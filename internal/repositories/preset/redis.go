@@ -0,0 +1,170 @@
+package preset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis
+	guildPresetsKeyPrefix = "guild_presets:"
+)
+
+// ErrPresetNotFound is returned when a preset is not found
+var ErrPresetNotFound = errors.New("preset not found")
+
+// Config holds configuration for the Redis preset repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed preset repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// presetField returns the hash field a preset is stored under, names are case insensitive
+func presetField(name string) string {
+	return strings.ToLower(name)
+}
+
+// SavePreset persists a preset in its guild's preset hash
+func (r *redisRepository) SavePreset(ctx context.Context, input *SavePresetInput) error {
+	if input == nil || input.Preset == nil {
+		return errors.New("input and preset cannot be nil")
+	}
+
+	preset := input.Preset
+
+	if preset.Name == "" {
+		return errors.New("preset name cannot be empty")
+	}
+
+	if preset.GuildID == "" {
+		return errors.New("guild ID cannot be empty")
+	}
+
+	// Marshal the preset to JSON
+	presetJSON, err := json.Marshal(preset)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preset: %w", err)
+	}
+
+	err = r.client.HSet(ctx, guildPresetsKeyPrefix+preset.GuildID, presetField(preset.Name), presetJSON).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save preset: %w", err)
+	}
+
+	return nil
+}
+
+// GetPreset retrieves a guild's preset by name
+func (r *redisRepository) GetPreset(ctx context.Context, input *GetPresetInput) (*models.Preset, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.GuildID == "" || input.Name == "" {
+		return nil, errors.New("guild ID and preset name cannot be empty")
+	}
+
+	presetJSON, err := r.client.HGet(ctx, guildPresetsKeyPrefix+input.GuildID, presetField(input.Name)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrPresetNotFound
+		}
+		return nil, fmt.Errorf("failed to get preset: %w", err)
+	}
+
+	var preset models.Preset
+	if err := json.Unmarshal([]byte(presetJSON), &preset); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal preset: %w", err)
+	}
+
+	return &preset, nil
+}
+
+// GetPresetsForGuild retrieves all presets saved for a guild, sorted by name
+func (r *redisRepository) GetPresetsForGuild(ctx context.Context, input *GetPresetsForGuildInput) (*GetPresetsForGuildOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.GuildID == "" {
+		return nil, errors.New("guild ID cannot be empty")
+	}
+
+	presetsJSON, err := r.client.HGetAll(ctx, guildPresetsKeyPrefix+input.GuildID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild presets: %w", err)
+	}
+
+	presets := make([]*models.Preset, 0, len(presetsJSON))
+	for _, presetJSON := range presetsJSON {
+		var preset models.Preset
+		if err := json.Unmarshal([]byte(presetJSON), &preset); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal preset: %w", err)
+		}
+
+		presets = append(presets, &preset)
+	}
+
+	sort.Slice(presets, func(i, j int) bool {
+		return presetField(presets[i].Name) < presetField(presets[j].Name)
+	})
+
+	return &GetPresetsForGuildOutput{
+		Presets: presets,
+	}, nil
+}
+
+// DeletePreset removes a guild's preset
+func (r *redisRepository) DeletePreset(ctx context.Context, input *DeletePresetInput) error {
+	if input == nil {
+		return errors.New("input cannot be nil")
+	}
+
+	if input.GuildID == "" || input.Name == "" {
+		return errors.New("guild ID and preset name cannot be empty")
+	}
+
+	removed, err := r.client.HDel(ctx, guildPresetsKeyPrefix+input.GuildID, presetField(input.Name)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+
+	if removed == 0 {
+		return ErrPresetNotFound
+	}
+
+	return nil
+}
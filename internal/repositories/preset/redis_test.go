@@ -0,0 +1,139 @@
+package preset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	// Set up test time
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveAndGetPreset() {
+	ctx := context.Background()
+
+	preset := &models.Preset{
+		Name:      "Quickies",
+		GuildID:   "guild-1",
+		Rules:     models.GameRules{DiceSides: 4, MaxPlayers: 4, CriticalHitValues: []int{4}, CriticalFailValues: []int{1}},
+		CreatedBy: "admin-1",
+		CreatedAt: s.testNow,
+	}
+
+	err := s.repo.SavePreset(ctx, &SavePresetInput{Preset: preset})
+	s.Require().NoError(err)
+
+	// Names are looked up case insensitively
+	saved, err := s.repo.GetPreset(ctx, &GetPresetInput{GuildID: "guild-1", Name: "quickies"})
+	s.Require().NoError(err)
+	s.Equal("Quickies", saved.Name)
+	s.Equal(preset.Rules, saved.Rules)
+	s.True(s.testNow.Equal(saved.CreatedAt))
+
+	// Presets are scoped to their guild
+	_, err = s.repo.GetPreset(ctx, &GetPresetInput{GuildID: "guild-2", Name: "quickies"})
+	s.ErrorIs(err, ErrPresetNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetPreset_SingleCritValues() {
+	ctx := context.Background()
+
+	// Presets saved before critical values became lists still load
+	s.mr.HSet(guildPresetsKeyPrefix+"guild-1", presetField("old"),
+		`{"name":"old","guild_id":"guild-1","rules":{"dice_sides":8,"critical_hit_value":8,"critical_fail_value":1}}`)
+
+	saved, err := s.repo.GetPreset(ctx, &GetPresetInput{GuildID: "guild-1", Name: "old"})
+	s.Require().NoError(err)
+	s.Equal(models.GameRules{DiceSides: 8, CriticalHitValues: []int{8}, CriticalFailValues: []int{1}}, saved.Rules)
+}
+
+func (s *RedisRepositoryTestSuite) TestSavePreset_ReplacesSameName() {
+	ctx := context.Background()
+
+	for _, sides := range []int{4, 8} {
+		err := s.repo.SavePreset(ctx, &SavePresetInput{Preset: &models.Preset{
+			Name:    "party",
+			GuildID: "guild-1",
+			Rules:   models.GameRules{DiceSides: sides},
+		}})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.GetPresetsForGuild(ctx, &GetPresetsForGuildInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Presets, 1)
+	s.Equal(8, output.Presets[0].Rules.DiceSides)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetPresetsForGuild_Sorted() {
+	ctx := context.Background()
+
+	for _, name := range []string{"quickies", "Big Night", "d20"} {
+		err := s.repo.SavePreset(ctx, &SavePresetInput{Preset: &models.Preset{Name: name, GuildID: "guild-1"}})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.repo.GetPresetsForGuild(ctx, &GetPresetsForGuildInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Presets, 3)
+	s.Equal("Big Night", output.Presets[0].Name)
+	s.Equal("d20", output.Presets[1].Name)
+	s.Equal("quickies", output.Presets[2].Name)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeletePreset() {
+	ctx := context.Background()
+
+	err := s.repo.SavePreset(ctx, &SavePresetInput{Preset: &models.Preset{Name: "quickies", GuildID: "guild-1"}})
+	s.Require().NoError(err)
+
+	err = s.repo.DeletePreset(ctx, &DeletePresetInput{GuildID: "guild-1", Name: "QUICKIES"})
+	s.Require().NoError(err)
+
+	_, err = s.repo.GetPreset(ctx, &GetPresetInput{GuildID: "guild-1", Name: "quickies"})
+	s.ErrorIs(err, ErrPresetNotFound)
+
+	err = s.repo.DeletePreset(ctx, &DeletePresetInput{GuildID: "guild-1", Name: "quickies"})
+	s.ErrorIs(err, ErrPresetNotFound)
+}
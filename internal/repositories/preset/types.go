@@ -0,0 +1,30 @@
+package preset
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// SavePresetInput contains parameters for saving a preset
+type SavePresetInput struct {
+	Preset *models.Preset
+}
+
+// GetPresetInput contains parameters for retrieving a preset
+type GetPresetInput struct {
+	GuildID string
+	Name    string
+}
+
+// GetPresetsForGuildInput contains parameters for retrieving a guild's presets
+type GetPresetsForGuildInput struct {
+	GuildID string
+}
+
+// GetPresetsForGuildOutput contains the result of retrieving a guild's presets
+type GetPresetsForGuildOutput struct {
+	Presets []*models.Preset
+}
+
+// DeletePresetInput contains parameters for deleting a preset
+type DeletePresetInput struct {
+	GuildID string
+	Name    string
+}
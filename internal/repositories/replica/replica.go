@@ -0,0 +1,48 @@
+package replica
+
+import (
+	"context"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// replicaReadsKey keys whether the reads made with a context may be served from a replica
+type replicaReadsKey struct{}
+
+// WithReplicaReads marks the reads made with the context as fine to serve from a replica that may lag behind the
+// primary, unless the caller already asked for the primary
+func WithReplicaReads(ctx context.Context) context.Context {
+	if _, marked := ctx.Value(replicaReadsKey{}).(bool); marked {
+		return ctx
+	}
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// WithPrimaryReads marks the reads made with the context as needing the primary, even on paths that would otherwise
+// read from a replica
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, false)
+}
+
+// ReplicaReadsAllowed reports whether the reads made with the context may be served from a replica
+func ReplicaReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return allowed
+}
+
+// Read runs a read against the replica when there is one and the context allows it, falling back to the primary if
+// the replica fails or doesn't have what was asked for yet. Otherwise it reads from the primary.
+func Read(ctx context.Context, primary, replica redis.UniversalClient, read func(client redis.UniversalClient) error) error {
+	if replica == nil || !ReplicaReadsAllowed(ctx) {
+		return read(primary)
+	}
+
+	err := read(replica)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	log.Printf("Replica read failed, reading from the primary: %v", err)
+	return read(primary)
+}
@@ -0,0 +1,107 @@
+package replica
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplicaTestSuite struct {
+	suite.Suite
+	primaryServer *miniredis.Miniredis
+	replicaServer *miniredis.Miniredis
+	primary       *redis.Client
+	replica       *redis.Client
+	ctx           context.Context
+}
+
+func (s *ReplicaTestSuite) SetupTest() {
+	var err error
+	s.primaryServer, err = miniredis.Run()
+	s.Require().NoError(err)
+	s.replicaServer, err = miniredis.Run()
+	s.Require().NoError(err)
+
+	s.primary = redis.NewClient(&redis.Options{Addr: s.primaryServer.Addr()})
+	s.replica = redis.NewClient(&redis.Options{Addr: s.replicaServer.Addr()})
+
+	s.Require().NoError(s.primaryServer.Set("key", "primary"))
+	s.Require().NoError(s.replicaServer.Set("key", "replica"))
+
+	s.ctx = context.Background()
+}
+
+func (s *ReplicaTestSuite) TearDownTest() {
+	s.primary.Close()
+	s.replica.Close()
+	s.primaryServer.Close()
+	s.replicaServer.Close()
+}
+
+func TestReplicaTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplicaTestSuite))
+}
+
+// get reads the key the way a repository would
+func (s *ReplicaTestSuite) get(ctx context.Context, replica redis.UniversalClient) (string, error) {
+	var value string
+	err := Read(ctx, s.primary, replica, func(client redis.UniversalClient) error {
+		var err error
+		value, err = client.Get(ctx, "key").Result()
+		return err
+	})
+	return value, err
+}
+
+func (s *ReplicaTestSuite) TestReadsPrimaryByDefault() {
+	value, err := s.get(s.ctx, s.replica)
+	s.Require().NoError(err)
+	s.Equal("primary", value)
+
+	// Without a replica the reads the caller allows go to the primary too
+	value, err = s.get(WithReplicaReads(s.ctx), nil)
+	s.Require().NoError(err)
+	s.Equal("primary", value)
+}
+
+func (s *ReplicaTestSuite) TestReadsReplicaWhenAllowed() {
+	value, err := s.get(WithReplicaReads(s.ctx), s.replica)
+	s.Require().NoError(err)
+	s.Equal("replica", value)
+}
+
+func (s *ReplicaTestSuite) TestPrimaryReadsWin() {
+	ctx := WithReplicaReads(WithPrimaryReads(s.ctx))
+	s.False(ReplicaReadsAllowed(ctx))
+
+	value, err := s.get(ctx, s.replica)
+	s.Require().NoError(err)
+	s.Equal("primary", value)
+}
+
+func (s *ReplicaTestSuite) TestFallsBackToPrimary() {
+	ctx := WithReplicaReads(s.ctx)
+
+	// A replica that hasn't caught up yet
+	s.replicaServer.Del("key")
+	value, err := s.get(ctx, s.replica)
+	s.Require().NoError(err)
+	s.Equal("primary", value)
+
+	// A replica that's down
+	s.replicaServer.Close()
+	value, err = s.get(ctx, s.replica)
+	s.Require().NoError(err)
+	s.Equal("primary", value)
+}
+
+func (s *ReplicaTestSuite) TestPrimaryErrorIsReturned() {
+	s.primaryServer.Del("key")
+	s.replicaServer.Del("key")
+
+	_, err := s.get(WithReplicaReads(s.ctx), s.replica)
+	s.ErrorIs(err, redis.Nil)
+}
@@ -0,0 +1,19 @@
+package roll_log
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/roll_log Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for persisting every dice roll
+type Repository interface {
+	// AddRoll records a roll
+	AddRoll(ctx context.Context, input *AddRollInput) error
+
+	// GetRolls returns the rolls matching the filters, oldest first
+	GetRolls(ctx context.Context, input *GetRollsInput) (*GetRollsOutput, error)
+
+	// ReassignPlayerRolls moves all of a player's rolls to another player
+	ReassignPlayerRolls(ctx context.Context, input *ReassignPlayerRollsInput) (*ReassignPlayerRollsOutput, error)
+}
@@ -0,0 +1,273 @@
+package roll_log
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis
+	rollKeyPrefix        = "roll:"
+	scopeRollsKeyPrefix  = "roll_log:scope:"
+	playerRollsKeyPrefix = "roll_log:player:"
+	gameRollsKeyPrefix   = "roll_log:game:"
+)
+
+// Config holds configuration for the Redis roll log repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed roll log repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// AddRoll saves a roll and indexes it by scope, player and game
+func (r *redisRepository) AddRoll(ctx context.Context, input *AddRollInput) error {
+	if input == nil || input.Roll == nil {
+		return errors.New("roll cannot be nil")
+	}
+
+	roll := input.Roll
+	if roll.ID == "" {
+		return errors.New("roll ID cannot be empty")
+	}
+
+	rollJSON, err := json.Marshal(roll)
+	if err != nil {
+		return fmt.Errorf("failed to marshal roll: %w", err)
+	}
+
+	member := redis.Z{
+		Score:  float64(roll.Timestamp.UnixNano()),
+		Member: roll.ID,
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, rollKeyPrefix+roll.ID, rollJSON, 0)
+	pipe.ZAdd(ctx, scopeRollsKeyPrefix+rollScope(roll), member)
+	pipe.ZAdd(ctx, playerRollsKeyPrefix+roll.PlayerID, member)
+	pipe.ZAdd(ctx, gameRollsKeyPrefix+roll.GameID, member)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save roll: %w", err)
+	}
+
+	return nil
+}
+
+// GetRolls returns a scope's rolls matching the filters, oldest first
+func (r *redisRepository) GetRolls(ctx context.Context, input *GetRollsInput) (*GetRollsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.Scope == "" {
+		return nil, errors.New("scope cannot be empty")
+	}
+
+	// Read from the narrowest index, the rest of the filters are applied below
+	indexKey := scopeRollsKeyPrefix + input.Scope
+	switch {
+	case input.GameID != "":
+		indexKey = gameRollsKeyPrefix + input.GameID
+	case input.PlayerID != "":
+		indexKey = playerRollsKeyPrefix + input.PlayerID
+	}
+
+	rangeBy := &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "+inf",
+	}
+	if !input.Since.IsZero() {
+		rangeBy.Min = strconv.FormatInt(input.Since.UnixNano(), 10)
+	}
+	if !input.Until.IsZero() {
+		rangeBy.Max = strconv.FormatInt(input.Until.UnixNano(), 10)
+	}
+
+	rollIDs, err := r.client.ZRangeByScore(ctx, indexKey, rangeBy).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roll IDs: %w", err)
+	}
+
+	rolls, err := r.getRolls(ctx, rollIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*models.Roll, 0, len(rolls))
+	for _, roll := range rolls {
+		if rollScope(roll) != input.Scope {
+			continue
+		}
+		if input.PlayerID != "" && roll.PlayerID != input.PlayerID {
+			continue
+		}
+		if input.GameID != "" && roll.GameID != input.GameID {
+			continue
+		}
+		filtered = append(filtered, roll)
+	}
+
+	if input.Limit > 0 && len(filtered) > input.Limit {
+		filtered = filtered[len(filtered)-input.Limit:]
+	}
+
+	return &GetRollsOutput{
+		Rolls: filtered,
+	}, nil
+}
+
+// ReassignPlayerRolls moves all of a player's rolls to another player, or only those from one guild
+func (r *redisRepository) ReassignPlayerRolls(ctx context.Context, input *ReassignPlayerRollsInput) (*ReassignPlayerRollsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.FromPlayerID == "" || input.ToPlayerID == "" {
+		return nil, errors.New("player IDs cannot be empty")
+	}
+
+	fromKey := playerRollsKeyPrefix + input.FromPlayerID
+	members, err := r.client.ZRangeWithScores(ctx, fromKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player's roll IDs: %w", err)
+	}
+
+	if len(members) == 0 {
+		return &ReassignPlayerRollsOutput{}, nil
+	}
+
+	rollIDs := make([]string, 0, len(members))
+	scores := make(map[string]float64, len(members))
+	for _, member := range members {
+		rollID := member.Member.(string)
+		rollIDs = append(rollIDs, rollID)
+		scores[rollID] = member.Score
+	}
+
+	rolls, err := r.getRolls(ctx, rollIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.GuildID != "" {
+		rolls = slices.DeleteFunc(rolls, func(roll *models.Roll) bool {
+			return roll.GuildID != input.GuildID
+		})
+	}
+
+	pipe := r.client.TxPipeline()
+	for _, roll := range rolls {
+		roll.PlayerID = input.ToPlayerID
+		roll.PlayerName = input.ToPlayerName
+
+		rollJSON, err := json.Marshal(roll)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal roll: %w", err)
+		}
+		pipe.Set(ctx, rollKeyPrefix+roll.ID, rollJSON, 0)
+	}
+	if input.GuildID == "" {
+		pipe.ZUnionStore(ctx, playerRollsKeyPrefix+input.ToPlayerID, &redis.ZStore{
+			Keys: []string{playerRollsKeyPrefix + input.ToPlayerID, fromKey},
+		})
+		pipe.Del(ctx, fromKey)
+	} else if len(rolls) > 0 {
+		// The player keeps their rolls from other guilds
+		moved := make([]redis.Z, 0, len(rolls))
+		movedIDs := make([]interface{}, 0, len(rolls))
+		for _, roll := range rolls {
+			moved = append(moved, redis.Z{Score: scores[roll.ID], Member: roll.ID})
+			movedIDs = append(movedIDs, roll.ID)
+		}
+		pipe.ZAdd(ctx, playerRollsKeyPrefix+input.ToPlayerID, moved...)
+		pipe.ZRem(ctx, fromKey, movedIDs...)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reassign rolls: %w", err)
+	}
+
+	return &ReassignPlayerRollsOutput{
+		RollsMoved: len(rolls),
+	}, nil
+}
+
+// getRolls loads rolls by ID in one call, skipping any that have gone missing
+func (r *redisRepository) getRolls(ctx context.Context, rollIDs []string) ([]*models.Roll, error) {
+	if len(rollIDs) == 0 {
+		return []*models.Roll{}, nil
+	}
+
+	keys := make([]string, 0, len(rollIDs))
+	for _, rollID := range rollIDs {
+		keys = append(keys, rollKeyPrefix+rollID)
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolls: %w", err)
+	}
+
+	rolls := make([]*models.Roll, 0, len(values))
+	for _, value := range values {
+		rollJSON, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var roll models.Roll
+		if err := json.Unmarshal([]byte(rollJSON), &roll); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal roll: %w", err)
+		}
+		rolls = append(rolls, &roll)
+	}
+
+	// Rolls made in the same instant keep a stable order
+	sort.SliceStable(rolls, func(i, j int) bool {
+		return rolls[i].Timestamp.Before(rolls[j].Timestamp)
+	})
+
+	return rolls, nil
+}
+
+// rollScope returns the guild a roll is listed under, or its channel outside a guild
+func rollScope(roll *models.Roll) string {
+	if roll.GuildID != "" {
+		return roll.GuildID
+	}
+	return roll.ChannelID
+}
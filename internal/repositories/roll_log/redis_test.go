@@ -0,0 +1,192 @@
+package roll_log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) addRoll(id, guildID, playerID, gameID string, value int, at time.Time) {
+	err := s.repo.AddRoll(context.Background(), &AddRollInput{
+		Roll: &models.Roll{
+			ID:         id,
+			Value:      value,
+			PlayerID:   playerID,
+			PlayerName: "Player " + playerID,
+			GameID:     gameID,
+			GuildID:    guildID,
+			ChannelID:  "channel-1",
+			Timestamp:  at,
+			DiceSides:  6,
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func rollIDs(rolls []*models.Roll) []string {
+	ids := make([]string, 0, len(rolls))
+	for _, roll := range rolls {
+		ids = append(ids, roll.ID)
+	}
+	return ids
+}
+
+func (s *RedisRepositoryTestSuite) TestAddRoll_Validation() {
+	ctx := context.Background()
+
+	s.Error(s.repo.AddRoll(ctx, nil))
+	s.Error(s.repo.AddRoll(ctx, &AddRollInput{Roll: &models.Roll{}}))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetRolls_OldestFirst() {
+	ctx := context.Background()
+
+	s.addRoll("roll-2", "guild-1", "player-1", "game-1", 4, s.testNow.Add(time.Minute))
+	s.addRoll("roll-1", "guild-1", "player-2", "game-1", 6, s.testNow)
+
+	output, err := s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-1", "roll-2"}, rollIDs(output.Rolls))
+	s.Equal(6, output.Rolls[0].Value)
+	s.Equal("Player player-2", output.Rolls[0].PlayerName)
+	s.Equal(6, output.Rolls[0].DiceSides)
+	s.True(s.testNow.Equal(output.Rolls[0].Timestamp))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetRolls_RequiresScope() {
+	_, err := s.repo.GetRolls(context.Background(), &GetRollsInput{})
+	s.Error(err)
+}
+
+func (s *RedisRepositoryTestSuite) TestGetRolls_Filters() {
+	ctx := context.Background()
+
+	s.addRoll("roll-1", "guild-1", "player-1", "game-1", 1, s.testNow)
+	s.addRoll("roll-2", "guild-1", "player-2", "game-1", 2, s.testNow.Add(time.Minute))
+	s.addRoll("roll-3", "guild-1", "player-1", "game-2", 3, s.testNow.Add(2*time.Minute))
+	s.addRoll("roll-4", "guild-2", "player-1", "game-3", 4, s.testNow.Add(3*time.Minute))
+
+	output, err := s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", PlayerID: "player-1"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-1", "roll-3"}, rollIDs(output.Rolls))
+
+	output, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", GameID: "game-1"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-1", "roll-2"}, rollIDs(output.Rolls))
+
+	output, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", GameID: "game-1", PlayerID: "player-2"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-2"}, rollIDs(output.Rolls))
+
+	output, err = s.repo.GetRolls(ctx, &GetRollsInput{
+		Scope: "guild-1",
+		Since: s.testNow.Add(time.Minute),
+		Until: s.testNow.Add(2 * time.Minute),
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-2", "roll-3"}, rollIDs(output.Rolls))
+
+	output, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", Limit: 2})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-2", "roll-3"}, rollIDs(output.Rolls))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetRolls_KeptPerScope() {
+	ctx := context.Background()
+
+	s.addRoll("roll-1", "guild-1", "player-1", "game-1", 1, s.testNow)
+	s.addRoll("roll-2", "guild-2", "player-1", "game-2", 2, s.testNow)
+
+	// A game from another guild can't be read through this one
+	output, err := s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", GameID: "game-2"})
+	s.Require().NoError(err)
+	s.Empty(output.Rolls)
+
+	output, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-2"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-2"}, rollIDs(output.Rolls))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetRolls_OutsideGuild() {
+	ctx := context.Background()
+
+	s.addRoll("roll-1", "", "player-1", "game-1", 1, s.testNow)
+
+	output, err := s.repo.GetRolls(ctx, &GetRollsInput{Scope: "channel-1"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-1"}, rollIDs(output.Rolls))
+}
+
+func (s *RedisRepositoryTestSuite) TestReassignPlayerRolls() {
+	ctx := context.Background()
+
+	s.addRoll("roll-1", "guild-1", "player-1", "game-1", 1, s.testNow)
+	s.addRoll("roll-2", "guild-2", "player-1", "game-2", 2, s.testNow.Add(time.Minute))
+	s.addRoll("roll-3", "guild-1", "player-2", "game-1", 3, s.testNow)
+
+	output, err := s.repo.ReassignPlayerRolls(ctx, &ReassignPlayerRollsInput{
+		FromPlayerID: "player-1",
+		ToPlayerID:   "deleted-1",
+		ToPlayerName: models.DeletedPlayerName,
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.RollsMoved)
+
+	rolls, err := s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", PlayerID: "player-1"})
+	s.Require().NoError(err)
+	s.Empty(rolls.Rolls)
+
+	rolls, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-2", PlayerID: "deleted-1"})
+	s.Require().NoError(err)
+	s.Require().Len(rolls.Rolls, 1)
+	s.Equal("roll-2", rolls.Rolls[0].ID)
+	s.Equal(models.DeletedPlayerName, rolls.Rolls[0].PlayerName)
+
+	// Other players' rolls are left alone
+	rolls, err = s.repo.GetRolls(ctx, &GetRollsInput{Scope: "guild-1", PlayerID: "player-2"})
+	s.Require().NoError(err)
+	s.Equal([]string{"roll-3"}, rollIDs(rolls.Rolls))
+}
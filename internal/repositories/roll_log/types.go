@@ -0,0 +1,49 @@
+package roll_log
+
+import (
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// AddRollInput contains parameters for recording a roll
+type AddRollInput struct {
+	Roll *models.Roll
+}
+
+// GetRollsInput contains the filters for listing rolls
+type GetRollsInput struct {
+	// Scope is required and limits the rolls to one guild (or channel outside a guild)
+	Scope string
+
+	// PlayerID and GameID are optional filters
+	PlayerID string
+	GameID   string
+
+	// Since and Until bound the roll time, zero values leave that end open
+	Since time.Time
+	Until time.Time
+
+	// Limit keeps only the most recent rolls, 0 returns them all
+	Limit int
+}
+
+// GetRollsOutput contains the rolls that matched
+type GetRollsOutput struct {
+	Rolls []*models.Roll
+}
+
+// ReassignPlayerRollsInput contains parameters for moving a player's rolls
+type ReassignPlayerRollsInput struct {
+	FromPlayerID string
+	ToPlayerID   string
+	ToPlayerName string
+
+	// GuildID limits the reassignment to the rolls made in the server, all are moved when it's empty
+	GuildID string
+}
+
+// ReassignPlayerRollsOutput contains the result of moving a player's rolls
+type ReassignPlayerRollsOutput struct {
+	RollsMoved int
+}
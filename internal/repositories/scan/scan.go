@@ -0,0 +1,58 @@
+package scan
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scanCount is how many keys each SCAN call asks Redis to look at
+const scanCount = 100
+
+// Keys returns every key matching the pattern, each once. A Redis Cluster spreads its keys across its primaries,
+// so each of them is scanned when the client is a cluster client.
+func Keys(ctx context.Context, client redis.UniversalClient, match string) ([]string, error) {
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return nodeKeys(ctx, client, match)
+	}
+
+	var mu sync.Mutex
+	var keys []string
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		found, err := nodeKeys(ctx, node, match)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// nodeKeys scans a single Redis node for the keys matching the pattern, dropping the repeats SCAN can return
+func nodeKeys(ctx context.Context, client redis.Cmdable, match string) ([]string, error) {
+	var keys []string
+	seen := make(map[string]bool)
+	iter := client.Scan(ctx, 0, match, scanCount).Iterator()
+	for iter.Next(ctx) {
+		if seen[iter.Val()] {
+			continue
+		}
+		seen[iter.Val()] = true
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,67 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScanTestSuite struct {
+	suite.Suite
+	mr  *miniredis.Miniredis
+	ctx context.Context
+}
+
+func (s *ScanTestSuite) SetupTest() {
+	var err error
+	s.mr, err = miniredis.Run()
+	s.Require().NoError(err)
+
+	for i := 0; i < 250; i++ {
+		s.Require().NoError(s.mr.Set(fmt.Sprintf("game:%d", i), "game"))
+	}
+	s.Require().NoError(s.mr.Set("player:1", "player"))
+
+	s.ctx = context.Background()
+}
+
+func (s *ScanTestSuite) TearDownTest() {
+	s.mr.Close()
+}
+
+func TestScanTestSuite(t *testing.T) {
+	suite.Run(t, new(ScanTestSuite))
+}
+
+func (s *ScanTestSuite) TestKeys() {
+	client := redis.NewClient(&redis.Options{Addr: s.mr.Addr()})
+	defer client.Close()
+
+	keys, err := Keys(s.ctx, client, "game:*")
+	s.Require().NoError(err)
+	s.Len(keys, 250)
+	s.NotContains(keys, "player:1")
+}
+
+func (s *ScanTestSuite) TestClusterKeys() {
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{s.mr.Addr()}})
+	defer client.Close()
+
+	keys, err := Keys(s.ctx, client, "game:*")
+	s.Require().NoError(err)
+	s.Len(keys, 250)
+	s.NotContains(keys, "player:1")
+}
+
+func (s *ScanTestSuite) TestNoKeys() {
+	client := redis.NewClient(&redis.Options{Addr: s.mr.Addr()})
+	defer client.Close()
+
+	keys, err := Keys(s.ctx, client, "moment:*")
+	s.Require().NoError(err)
+	s.Empty(keys)
+}
@@ -0,0 +1,16 @@
+package seasonal
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/seasonal Repository
+
+import (
+	"context"
+)
+
+// Repository defines the interface for persisting which guilds have opted out of seasonal events
+type Repository interface {
+	// IsOptedOut returns true if the guild has opted out of seasonal events
+	IsOptedOut(ctx context.Context, input *IsOptedOutInput) (bool, error)
+
+	// SetOptOut opts a guild out of, or back into, seasonal events
+	SetOptOut(ctx context.Context, input *SetOptOutInput) error
+}
@@ -0,0 +1,79 @@
+package seasonal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// optedOutGuildsKey is the set of guilds that have opted out of seasonal events
+	optedOutGuildsKey = "seasonal_opted_out"
+)
+
+// Config holds configuration for the Redis seasonal repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed seasonal repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// IsOptedOut returns true if the guild has opted out of seasonal events
+func (r *redisRepository) IsOptedOut(ctx context.Context, input *IsOptedOutInput) (bool, error) {
+	if input == nil || input.GuildID == "" {
+		return false, errors.New("guild ID is required")
+	}
+
+	optedOut, err := r.client.SIsMember(ctx, optedOutGuildsKey, input.GuildID).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check opt-out: %w", err)
+	}
+
+	return optedOut, nil
+}
+
+// SetOptOut opts a guild out of, or back into, seasonal events
+func (r *redisRepository) SetOptOut(ctx context.Context, input *SetOptOutInput) error {
+	if input == nil || input.GuildID == "" {
+		return errors.New("guild ID is required")
+	}
+
+	var err error
+	if input.OptOut {
+		err = r.client.SAdd(ctx, optedOutGuildsKey, input.GuildID).Err()
+	} else {
+		err = r.client.SRem(ctx, optedOutGuildsKey, input.GuildID).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save opt-out: %w", err)
+	}
+
+	return nil
+}
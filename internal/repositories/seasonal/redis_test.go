@@ -0,0 +1,80 @@
+package seasonal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	repo   Repository
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestOptOutAndBackIn() {
+	ctx := context.Background()
+
+	optedOut, err := s.repo.IsOptedOut(ctx, &IsOptedOutInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.False(optedOut)
+
+	err = s.repo.SetOptOut(ctx, &SetOptOutInput{GuildID: "guild-1", OptOut: true})
+	s.Require().NoError(err)
+
+	optedOut, err = s.repo.IsOptedOut(ctx, &IsOptedOutInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.True(optedOut)
+
+	// Other guilds are unaffected
+	optedOut, err = s.repo.IsOptedOut(ctx, &IsOptedOutInput{GuildID: "guild-2"})
+	s.Require().NoError(err)
+	s.False(optedOut)
+
+	err = s.repo.SetOptOut(ctx, &SetOptOutInput{GuildID: "guild-1", OptOut: false})
+	s.Require().NoError(err)
+
+	optedOut, err = s.repo.IsOptedOut(ctx, &IsOptedOutInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.False(optedOut)
+}
+
+func (s *RedisRepositoryTestSuite) TestGuildIDRequired() {
+	_, err := s.repo.IsOptedOut(context.Background(), &IsOptedOutInput{})
+	s.Error(err)
+
+	err = s.repo.SetOptOut(context.Background(), &SetOptOutInput{OptOut: true})
+	s.Error(err)
+}
@@ -0,0 +1,12 @@
+package seasonal
+
+// IsOptedOutInput contains parameters for checking a guild's opt-out
+type IsOptedOutInput struct {
+	GuildID string
+}
+
+// SetOptOutInput contains parameters for changing a guild's opt-out
+type SetOptOutInput struct {
+	GuildID string
+	OptOut  bool
+}
@@ -0,0 +1,15 @@
+package transaction
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_runner.go github.com/KirkDiggler/ronnied/internal/repositories/transaction Runner
+
+import (
+	"context"
+)
+
+// Runner runs writes across the repositories as a single unit of work
+type Runner interface {
+	// Run calls fn with a context the repositories hold their writes back on, applying all of them once fn
+	// returns without an error and none of them when it returns one. Reads in fn don't see its own writes, and
+	// a Run inside another joins the outer unit of work.
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,123 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// unitKey is the context key a unit of work is carried under
+type unitKey struct{}
+
+// unit is a unit of work in progress, the writes queued on its pipeline and what to do once they're applied or
+// dropped
+type unit struct {
+	pipe        redis.Pipeliner
+	afterCommit []func(ctx context.Context)
+	onDiscard   []func(ctx context.Context)
+}
+
+// Config holds configuration for the Redis transaction runner
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRunner implements the Runner interface with Redis MULTI/EXEC transactions
+type redisRunner struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed transaction runner
+func NewRedis(cfg *Config) (*redisRunner, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	return &redisRunner{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// Run queues the writes fn makes on one MULTI/EXEC transaction, executing it once fn succeeds
+func (r *redisRunner) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if fn == nil {
+		return errors.New("fn cannot be nil")
+	}
+
+	// Join the unit of work already in progress
+	if _, ok := ctx.Value(unitKey{}).(*unit); ok {
+		return fn(ctx)
+	}
+
+	u := &unit{
+		pipe: r.client.TxPipeline(),
+	}
+	if err := fn(context.WithValue(ctx, unitKey{}, u)); err != nil {
+		u.pipe.Discard()
+		u.discarded(ctx)
+		return err
+	}
+
+	if _, err := u.pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		u.discarded(ctx)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, hook := range u.afterCommit {
+		hook(ctx)
+	}
+
+	return nil
+}
+
+// discarded undoes what was written straight away for a unit of work that was dropped
+func (u *unit) discarded(ctx context.Context) {
+	for _, hook := range u.onDiscard {
+		hook(ctx)
+	}
+}
+
+// Pipelined queues the writes fn makes on the unit of work the context carries, or applies them straight away in
+// a transaction of their own when it doesn't carry one. Repositories write through it so their writes can be
+// part of a unit of work.
+func Pipelined(ctx context.Context, client redis.Cmdable, fn func(pipe redis.Pipeliner) error) error {
+	if u, ok := ctx.Value(unitKey{}).(*unit); ok {
+		return fn(u.pipe)
+	}
+
+	_, err := client.TxPipelined(ctx, fn)
+	return err
+}
+
+// InProgress checks whether the context carries a unit of work, whose writes reads won't see until it's applied
+func InProgress(ctx context.Context) bool {
+	_, ok := ctx.Value(unitKey{}).(*unit)
+	return ok
+}
+
+// AfterCommit calls fn once the unit of work the context carries has been applied, dropping it if the unit fails,
+// or straight away when the context doesn't carry one. fn is given a context outside the unit of work.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if u, ok := ctx.Value(unitKey{}).(*unit); ok {
+		u.afterCommit = append(u.afterCommit, fn)
+		return
+	}
+
+	fn(ctx)
+}
+
+// OnDiscard calls fn if the unit of work the context carries is dropped rather than applied, and never when the
+// context doesn't carry one. Writes that can't wait for the unit, such as those whose result the flow needs
+// straight away, use it to undo themselves. fn is given a context outside the unit of work.
+func OnDiscard(ctx context.Context, fn func(ctx context.Context)) {
+	if u, ok := ctx.Value(unitKey{}).(*unit); ok {
+		u.onDiscard = append(u.onDiscard, fn)
+	}
+}
@@ -0,0 +1,143 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRunnerTestSuite struct {
+	suite.Suite
+	mr     *miniredis.Miniredis
+	client *redis.Client
+	runner Runner
+	ctx    context.Context
+}
+
+func (s *RedisRunnerTestSuite) SetupTest() {
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	s.runner, err = NewRedis(&Config{RedisClient: s.client})
+	s.Require().NoError(err)
+
+	s.ctx = context.Background()
+}
+
+func (s *RedisRunnerTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRunnerTestSuite))
+}
+
+// set writes a key the way a repository would
+func (s *RedisRunnerTestSuite) set(ctx context.Context, key, value string) error {
+	return Pipelined(ctx, s.client, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, value, 0)
+		return nil
+	})
+}
+
+func (s *RedisRunnerTestSuite) TestNewRedis_Validation() {
+	_, err := NewRedis(nil)
+	s.Error(err)
+
+	_, err = NewRedis(&Config{})
+	s.Error(err)
+}
+
+func (s *RedisRunnerTestSuite) TestPipelinedWithoutUnit() {
+	s.False(InProgress(s.ctx))
+	s.Require().NoError(s.set(s.ctx, "a", "1"))
+
+	s.mr.CheckGet(s.T(), "a", "1")
+}
+
+func (s *RedisRunnerTestSuite) TestRunCommits() {
+	var committed bool
+	err := s.runner.Run(s.ctx, func(ctx context.Context) error {
+		s.True(InProgress(ctx))
+		s.Require().NoError(s.set(ctx, "a", "1"))
+		s.Require().NoError(s.set(ctx, "b", "2"))
+		AfterCommit(ctx, func(ctx context.Context) {
+			s.False(InProgress(ctx), "hooks run outside the unit of work")
+			committed = true
+		})
+
+		// Nothing is written until the unit of work is done
+		s.False(s.mr.Exists("a"))
+		s.False(committed)
+		return nil
+	})
+	s.Require().NoError(err)
+
+	s.mr.CheckGet(s.T(), "a", "1")
+	s.mr.CheckGet(s.T(), "b", "2")
+	s.True(committed)
+}
+
+func (s *RedisRunnerTestSuite) TestRunRollsBack() {
+	expectedErr := errors.New("boom")
+
+	var committed, discarded bool
+	err := s.runner.Run(s.ctx, func(ctx context.Context) error {
+		s.Require().NoError(s.set(ctx, "a", "1"))
+		AfterCommit(ctx, func(context.Context) {
+			committed = true
+		})
+		OnDiscard(ctx, func(ctx context.Context) {
+			s.False(InProgress(ctx), "hooks run outside the unit of work")
+			discarded = true
+		})
+		return expectedErr
+	})
+	s.ErrorIs(err, expectedErr)
+
+	s.False(s.mr.Exists("a"))
+	s.False(committed)
+	s.True(discarded)
+}
+
+func (s *RedisRunnerTestSuite) TestOnDiscardSkippedOnCommit() {
+	var discarded bool
+	err := s.runner.Run(s.ctx, func(ctx context.Context) error {
+		OnDiscard(ctx, func(context.Context) {
+			discarded = true
+		})
+		return s.set(ctx, "a", "1")
+	})
+	s.Require().NoError(err)
+	s.False(discarded)
+
+	// There's nothing to drop without a unit of work
+	OnDiscard(s.ctx, func(context.Context) {
+		discarded = true
+	})
+	s.False(discarded)
+}
+
+func (s *RedisRunnerTestSuite) TestNestedRunJoinsOuterUnit() {
+	expectedErr := errors.New("boom")
+
+	err := s.runner.Run(s.ctx, func(ctx context.Context) error {
+		s.Require().NoError(s.runner.Run(ctx, func(ctx context.Context) error {
+			return s.set(ctx, "inner", "1")
+		}))
+		return expectedErr
+	})
+	s.ErrorIs(err, expectedErr)
+
+	s.False(s.mr.Exists("inner"), "the inner writes are rolled back with the outer unit")
+}
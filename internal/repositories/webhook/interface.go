@@ -0,0 +1,24 @@
+package webhook
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_repository.go github.com/KirkDiggler/ronnied/internal/repositories/webhook Repository
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// Repository defines the interface for webhook registration persistence
+type Repository interface {
+	// SaveWebhook persists a webhook registration
+	SaveWebhook(ctx context.Context, input *SaveWebhookInput) error
+
+	// GetWebhook retrieves a webhook by ID
+	GetWebhook(ctx context.Context, input *GetWebhookInput) (*models.Webhook, error)
+
+	// GetWebhooksForGuild retrieves all webhooks registered for a guild
+	GetWebhooksForGuild(ctx context.Context, input *GetWebhooksForGuildInput) (*GetWebhooksForGuildOutput, error)
+
+	// DeleteWebhook removes a webhook registration
+	DeleteWebhook(ctx context.Context, input *DeleteWebhookInput) error
+}
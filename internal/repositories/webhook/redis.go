@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key prefixes for Redis
+	webhookKeyPrefix       = "webhook:"
+	guildWebhooksKeyPrefix = "guild_webhooks:"
+)
+
+// ErrWebhookNotFound is returned when a webhook is not found
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Config holds configuration for the Redis webhook repository
+type Config struct {
+	// Redis client
+	RedisClient redis.UniversalClient
+}
+
+// redisRepository implements the Repository interface using Redis
+type redisRepository struct {
+	client redis.UniversalClient
+}
+
+// NewRedis creates a new Redis-backed webhook repository
+func NewRedis(cfg *Config) (*redisRepository, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	if cfg.RedisClient == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+
+	// Test connection
+	if err := cfg.RedisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisRepository{
+		client: cfg.RedisClient,
+	}, nil
+}
+
+// SaveWebhook persists a webhook to Redis
+func (r *redisRepository) SaveWebhook(ctx context.Context, input *SaveWebhookInput) error {
+	if input == nil || input.Webhook == nil {
+		return errors.New("input and webhook cannot be nil")
+	}
+
+	webhook := input.Webhook
+
+	if webhook.ID == "" {
+		return errors.New("webhook ID cannot be empty")
+	}
+
+	if webhook.GuildID == "" {
+		return errors.New("guild ID cannot be empty")
+	}
+
+	// Marshal the webhook to JSON
+	webhookJSON, err := json.Marshal(webhook)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook: %w", err)
+	}
+
+	// Save the webhook and index it under its guild
+	pipe := r.client.Pipeline()
+	pipe.Set(ctx, webhookKeyPrefix+webhook.ID, webhookJSON, 0)
+	pipe.SAdd(ctx, guildWebhooksKeyPrefix+webhook.GuildID, webhook.ID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (r *redisRepository) GetWebhook(ctx context.Context, input *GetWebhookInput) (*models.Webhook, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.WebhookID == "" {
+		return nil, errors.New("webhook ID cannot be empty")
+	}
+
+	webhookJSON, err := r.client.Get(ctx, webhookKeyPrefix+input.WebhookID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	var webhook models.Webhook
+	if err := json.Unmarshal([]byte(webhookJSON), &webhook); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooksForGuild retrieves all webhooks registered for a guild
+func (r *redisRepository) GetWebhooksForGuild(ctx context.Context, input *GetWebhooksForGuildInput) (*GetWebhooksForGuildOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if input.GuildID == "" {
+		return nil, errors.New("guild ID cannot be empty")
+	}
+
+	webhookIDs, err := r.client.SMembers(ctx, guildWebhooksKeyPrefix+input.GuildID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild webhooks: %w", err)
+	}
+
+	webhooks := make([]*models.Webhook, 0, len(webhookIDs))
+	for _, webhookID := range webhookIDs {
+		webhook, err := r.GetWebhook(ctx, &GetWebhookInput{
+			WebhookID: webhookID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrWebhookNotFound) {
+				// Stale index entry, skip it
+				continue
+			}
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return &GetWebhooksForGuildOutput{
+		Webhooks: webhooks,
+	}, nil
+}
+
+// DeleteWebhook removes a webhook registration
+func (r *redisRepository) DeleteWebhook(ctx context.Context, input *DeleteWebhookInput) error {
+	if input == nil {
+		return errors.New("input cannot be nil")
+	}
+
+	if input.GuildID == "" || input.WebhookID == "" {
+		return errors.New("guild ID and webhook ID cannot be empty")
+	}
+
+	// Make sure the webhook belongs to the guild before deleting it
+	isMember, err := r.client.SIsMember(ctx, guildWebhooksKeyPrefix+input.GuildID, input.WebhookID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check guild webhooks: %w", err)
+	}
+
+	if !isMember {
+		return ErrWebhookNotFound
+	}
+
+	pipe := r.client.Pipeline()
+	pipe.Del(ctx, webhookKeyPrefix+input.WebhookID)
+	pipe.SRem(ctx, guildWebhooksKeyPrefix+input.GuildID, input.WebhookID)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
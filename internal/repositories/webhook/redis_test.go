@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisRepositoryTestSuite struct {
+	suite.Suite
+	mr      *miniredis.Miniredis
+	client  *redis.Client
+	repo    Repository
+	testNow time.Time
+}
+
+func (s *RedisRepositoryTestSuite) SetupTest() {
+	// Create a new miniredis server for each test
+	mr, err := miniredis.Run()
+	s.Require().NoError(err)
+	s.mr = mr
+
+	// Create a Redis client connected to the miniredis server
+	s.client = redis.NewClient(&redis.Options{
+		Addr: s.mr.Addr(),
+	})
+
+	// Create the repository
+	repo, err := NewRedis(&Config{
+		RedisClient: s.client,
+	})
+	s.Require().NoError(err)
+	s.repo = repo
+
+	// Set up test time
+	s.testNow = time.Date(2025, 4, 5, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RedisRepositoryTestSuite) TearDownTest() {
+	s.client.Close()
+	s.mr.Close()
+}
+
+func TestRedisRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisRepositoryTestSuite))
+}
+
+func (s *RedisRepositoryTestSuite) TestSaveAndGetWebhooksForGuild() {
+	ctx := context.Background()
+
+	webhook := &models.Webhook{
+		ID:        "webhook-1",
+		GuildID:   "guild-1",
+		URL:       "https://example.com/hook",
+		Events:    []string{"game_completed"},
+		CreatedBy: "admin-1",
+		CreatedAt: s.testNow,
+	}
+
+	err := s.repo.SaveWebhook(ctx, &SaveWebhookInput{Webhook: webhook})
+	s.Require().NoError(err)
+
+	// Save a webhook for another guild to make sure guilds are isolated
+	err = s.repo.SaveWebhook(ctx, &SaveWebhookInput{Webhook: &models.Webhook{
+		ID:      "webhook-2",
+		GuildID: "guild-2",
+		URL:     "https://example.com/other",
+	}})
+	s.Require().NoError(err)
+
+	output, err := s.repo.GetWebhooksForGuild(ctx, &GetWebhooksForGuildInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Webhooks, 1)
+	s.Equal(webhook.URL, output.Webhooks[0].URL)
+	s.Equal(webhook.Events, output.Webhooks[0].Events)
+	s.True(webhook.CreatedAt.Equal(output.Webhooks[0].CreatedAt))
+}
+
+func (s *RedisRepositoryTestSuite) TestGetWebhookNotFound() {
+	_, err := s.repo.GetWebhook(context.Background(), &GetWebhookInput{WebhookID: "missing"})
+	s.ErrorIs(err, ErrWebhookNotFound)
+}
+
+func (s *RedisRepositoryTestSuite) TestDeleteWebhook() {
+	ctx := context.Background()
+
+	err := s.repo.SaveWebhook(ctx, &SaveWebhookInput{Webhook: &models.Webhook{
+		ID:      "webhook-1",
+		GuildID: "guild-1",
+		URL:     "https://example.com/hook",
+	}})
+	s.Require().NoError(err)
+
+	// Deleting from the wrong guild is rejected
+	err = s.repo.DeleteWebhook(ctx, &DeleteWebhookInput{GuildID: "guild-2", WebhookID: "webhook-1"})
+	s.ErrorIs(err, ErrWebhookNotFound)
+
+	err = s.repo.DeleteWebhook(ctx, &DeleteWebhookInput{GuildID: "guild-1", WebhookID: "webhook-1"})
+	s.Require().NoError(err)
+
+	output, err := s.repo.GetWebhooksForGuild(ctx, &GetWebhooksForGuildInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Empty(output.Webhooks)
+
+	_, err = s.repo.GetWebhook(ctx, &GetWebhookInput{WebhookID: "webhook-1"})
+	s.ErrorIs(err, ErrWebhookNotFound)
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import "github.com/KirkDiggler/ronnied/internal/models"
+
+// SaveWebhookInput contains parameters for saving a webhook
+type SaveWebhookInput struct {
+	Webhook *models.Webhook
+}
+
+// GetWebhookInput contains parameters for retrieving a webhook
+type GetWebhookInput struct {
+	WebhookID string
+}
+
+// GetWebhooksForGuildInput contains parameters for retrieving a guild's webhooks
+type GetWebhooksForGuildInput struct {
+	GuildID string
+}
+
+// GetWebhooksForGuildOutput contains the result of retrieving a guild's webhooks
+type GetWebhooksForGuildOutput struct {
+	Webhooks []*models.Webhook
+}
+
+// DeleteWebhookInput contains parameters for deleting a webhook
+type DeleteWebhookInput struct {
+	GuildID   string
+	WebhookID string
+}
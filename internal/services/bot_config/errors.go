@@ -0,0 +1,16 @@
+package bot_config
+
+// BotConfigError is a custom error type for bot config errors
+type BotConfigError string
+
+// Error implements the error interface
+func (e BotConfigError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig        BotConfigError = "config cannot be nil"
+	ErrNilBotConfigRepo BotConfigError = "bot config repository cannot be nil"
+	ErrInvalidInput     BotConfigError = "invalid input"
+)
@@ -0,0 +1,14 @@
+package bot_config
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_service.go github.com/KirkDiggler/ronnied/internal/services/bot_config Service
+
+import "context"
+
+// Service keeps track of the settings that can be changed while the bot is running
+type Service interface {
+	// Reload reads the runtime settings again and reports whether they changed since they were last read
+	Reload(ctx context.Context, input *ReloadInput) (*ReloadOutput, error)
+
+	// GetBotConfig returns the runtime settings as they were last read
+	GetBotConfig(ctx context.Context, input *GetBotConfigInput) (*GetBotConfigOutput, error)
+}
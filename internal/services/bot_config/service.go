@@ -0,0 +1,79 @@
+package bot_config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	botConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/bot_config"
+)
+
+// service implements the Service interface
+type service struct {
+	// Repository dependencies
+	botConfigRepo botConfigRepo.Repository
+
+	// The settings last read, nil until the first reload
+	mu     sync.RWMutex
+	config *models.BotConfig
+}
+
+// New creates a new bot config service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.BotConfigRepo == nil {
+		return nil, ErrNilBotConfigRepo
+	}
+
+	return &service{
+		botConfigRepo: cfg.BotConfigRepo,
+	}, nil
+}
+
+// Reload reads the runtime settings again and reports whether they changed since they were last read
+func (s *service) Reload(ctx context.Context, input *ReloadInput) (*ReloadOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	output, err := s.botConfigRepo.GetBotConfig(ctx, &botConfigRepo.GetBotConfigInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := s.config == nil || !reflect.DeepEqual(s.config, output.Config)
+	s.config = output.Config
+
+	return &ReloadOutput{
+		Config:  output.Config,
+		Changed: changed,
+	}, nil
+}
+
+// GetBotConfig returns the runtime settings as they were last read
+func (s *service) GetBotConfig(ctx context.Context, input *GetBotConfigInput) (*GetBotConfigOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	config := s.config
+	if config == nil {
+		config = &models.BotConfig{}
+	}
+
+	return &GetBotConfigOutput{
+		Config: config,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package bot_config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	botConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/bot_config"
+	botConfigMocks "github.com/KirkDiggler/ronnied/internal/repositories/bot_config/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type BotConfigServiceTestSuite struct {
+	suite.Suite
+	mockCtrl          *gomock.Controller
+	mockBotConfigRepo *botConfigMocks.MockRepository
+	botConfigService  Service
+	ctx               context.Context
+}
+
+func (s *BotConfigServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockBotConfigRepo = botConfigMocks.NewMockRepository(s.mockCtrl)
+	s.ctx = context.Background()
+
+	svc, err := New(&Config{
+		BotConfigRepo: s.mockBotConfigRepo,
+	})
+	s.Require().NoError(err)
+	s.botConfigService = svc
+}
+
+func (s *BotConfigServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestBotConfigServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BotConfigServiceTestSuite))
+}
+
+// expectConfig has the repository return the config on the next read
+func (s *BotConfigServiceTestSuite) expectConfig(config *models.BotConfig) {
+	s.mockBotConfigRepo.EXPECT().
+		GetBotConfig(s.ctx, &botConfigRepo.GetBotConfigInput{}).
+		Return(&botConfigRepo.GetBotConfigOutput{Config: config}, nil)
+}
+
+func (s *BotConfigServiceTestSuite) TestReload() {
+	// Nothing read yet
+	getOutput, err := s.botConfigService.GetBotConfig(s.ctx, &GetBotConfigInput{})
+	s.Require().NoError(err)
+	s.Equal(&models.BotConfig{}, getOutput.Config)
+
+	s.expectConfig(&models.BotConfig{DiceSides: 20})
+	output, err := s.botConfigService.Reload(s.ctx, &ReloadInput{})
+	s.Require().NoError(err)
+	s.True(output.Changed, "the first reload always counts as a change")
+
+	s.expectConfig(&models.BotConfig{DiceSides: 20})
+	output, err = s.botConfigService.Reload(s.ctx, &ReloadInput{})
+	s.Require().NoError(err)
+	s.False(output.Changed)
+
+	s.expectConfig(&models.BotConfig{DiceSides: 20, ContentRating: models.ContentRatingPG})
+	output, err = s.botConfigService.Reload(s.ctx, &ReloadInput{})
+	s.Require().NoError(err)
+	s.True(output.Changed)
+
+	getOutput, err = s.botConfigService.GetBotConfig(s.ctx, &GetBotConfigInput{})
+	s.Require().NoError(err)
+	s.Equal(models.ContentRatingPG, getOutput.Config.ContentRating)
+}
+
+func (s *BotConfigServiceTestSuite) TestReload_Error() {
+	s.expectConfig(&models.BotConfig{DiceSides: 20})
+	_, err := s.botConfigService.Reload(s.ctx, &ReloadInput{})
+	s.Require().NoError(err)
+
+	s.mockBotConfigRepo.EXPECT().
+		GetBotConfig(s.ctx, gomock.Any()).
+		Return(nil, errors.New("redis is down"))
+	_, err = s.botConfigService.Reload(s.ctx, &ReloadInput{})
+	s.Error(err)
+
+	// The last settings read are kept
+	getOutput, err := s.botConfigService.GetBotConfig(s.ctx, &GetBotConfigInput{})
+	s.Require().NoError(err)
+	s.Equal(20, getOutput.Config.DiceSides)
+}
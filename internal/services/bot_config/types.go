@@ -0,0 +1,34 @@
+package bot_config
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+	botConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/bot_config"
+)
+
+// Config holds configuration for the bot config service
+type Config struct {
+	// Repository dependencies
+	BotConfigRepo botConfigRepo.Repository
+}
+
+// ReloadInput defines the input for reloading the runtime settings
+type ReloadInput struct {
+}
+
+// ReloadOutput defines the output for reloading the runtime settings
+type ReloadOutput struct {
+	Config *models.BotConfig
+
+	// Changed is true if the settings are different from the last ones read, always true on the first reload
+	Changed bool
+}
+
+// GetBotConfigInput defines the input for getting the runtime settings
+type GetBotConfigInput struct {
+}
+
+// GetBotConfigOutput defines the output for getting the runtime settings
+type GetBotConfigOutput struct {
+	// Config is empty until the settings are first read
+	Config *models.BotConfig
+}
@@ -0,0 +1,17 @@
+package feature_flag
+
+// FeatureFlagError is a custom error type for feature flag errors
+type FeatureFlagError string
+
+// Error implements the error interface
+func (e FeatureFlagError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig          FeatureFlagError = "config cannot be nil"
+	ErrNilFeatureFlagRepo FeatureFlagError = "feature flag repository cannot be nil"
+	ErrInvalidInput       FeatureFlagError = "invalid input"
+	ErrUnknownFlag        FeatureFlagError = "unknown feature flag"
+)
@@ -0,0 +1,20 @@
+package feature_flag
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_service.go github.com/KirkDiggler/ronnied/internal/services/feature_flag Service
+
+import "context"
+
+// Service decides which parts of the bot are turned on, for the whole bot or per guild
+type Service interface {
+	// IsEnabled returns whether a flag is on for a guild, a guild's override wins over the bot's setting
+	IsEnabled(ctx context.Context, input *IsEnabledInput) (*IsEnabledOutput, error)
+
+	// GetFeatureFlags returns every known flag as it is for a guild
+	GetFeatureFlags(ctx context.Context, input *GetFeatureFlagsInput) (*GetFeatureFlagsOutput, error)
+
+	// SetFeatureFlag turns a flag on or off for a guild, or for the whole bot when no guild is given
+	SetFeatureFlag(ctx context.Context, input *SetFeatureFlagInput) (*SetFeatureFlagOutput, error)
+
+	// ClearFeatureFlag removes a guild's override of a flag, or the bot's setting when no guild is given
+	ClearFeatureFlag(ctx context.Context, input *ClearFeatureFlagInput) (*ClearFeatureFlagOutput, error)
+}
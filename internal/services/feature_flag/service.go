@@ -0,0 +1,159 @@
+package feature_flag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	featureFlagRepo "github.com/KirkDiggler/ronnied/internal/repositories/feature_flag"
+)
+
+// service implements the Service interface
+type service struct {
+	// Repository dependencies
+	featureFlagRepo featureFlagRepo.Repository
+}
+
+// New creates a new feature flag service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.FeatureFlagRepo == nil {
+		return nil, ErrNilFeatureFlagRepo
+	}
+
+	return &service{
+		featureFlagRepo: cfg.FeatureFlagRepo,
+	}, nil
+}
+
+// IsEnabled returns whether a flag is on for a guild, a guild's override wins over the bot's setting
+func (s *service) IsEnabled(ctx context.Context, input *IsEnabledInput) (*IsEnabledOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	state, err := s.getState(ctx, input.GuildID, input.Flag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IsEnabledOutput{
+		Enabled: state.Enabled,
+		Source:  state.Source,
+	}, nil
+}
+
+// GetFeatureFlags returns every known flag as it is for a guild
+func (s *service) GetFeatureFlags(ctx context.Context, input *GetFeatureFlagsInput) (*GetFeatureFlagsOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	output, err := s.featureFlagRepo.GetFeatureFlags(ctx, &featureFlagRepo.GetFeatureFlagsInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
+
+	flags := make([]*models.FeatureFlagState, 0, len(models.FeatureFlags))
+	for _, flag := range models.FeatureFlags {
+		flags = append(flags, resolve(flag, output))
+	}
+
+	return &GetFeatureFlagsOutput{
+		Flags: flags,
+	}, nil
+}
+
+// SetFeatureFlag turns a flag on or off for a guild, or for the whole bot when no guild is given
+func (s *service) SetFeatureFlag(ctx context.Context, input *SetFeatureFlagInput) (*SetFeatureFlagOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Flag.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, input.Flag)
+	}
+
+	if err := s.featureFlagRepo.SetFeatureFlag(ctx, &featureFlagRepo.SetFeatureFlagInput{
+		GuildID: input.GuildID,
+		Flag:    input.Flag,
+		Enabled: input.Enabled,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
+	source := models.FeatureFlagSourceGuild
+	if input.GuildID == "" {
+		source = models.FeatureFlagSourceBot
+	}
+
+	return &SetFeatureFlagOutput{
+		State: &models.FeatureFlagState{
+			Flag:    input.Flag,
+			Enabled: input.Enabled,
+			Source:  source,
+		},
+	}, nil
+}
+
+// ClearFeatureFlag removes a guild's override of a flag, or the bot's setting when no guild is given
+func (s *service) ClearFeatureFlag(ctx context.Context, input *ClearFeatureFlagInput) (*ClearFeatureFlagOutput, error) {
+	if input == nil {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Flag.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, input.Flag)
+	}
+
+	if err := s.featureFlagRepo.ClearFeatureFlag(ctx, &featureFlagRepo.ClearFeatureFlagInput{
+		GuildID: input.GuildID,
+		Flag:    input.Flag,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to clear feature flag: %w", err)
+	}
+
+	state, err := s.getState(ctx, input.GuildID, input.Flag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClearFeatureFlagOutput{
+		State: state,
+	}, nil
+}
+
+// getState reads a flag as it is for a guild
+func (s *service) getState(ctx context.Context, guildID string, flag models.FeatureFlag) (*models.FeatureFlagState, error) {
+	if !flag.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+
+	output, err := s.featureFlagRepo.GetFeatureFlags(ctx, &featureFlagRepo.GetFeatureFlagsInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
+
+	return resolve(flag, output), nil
+}
+
+// resolve picks a flag's value, the guild's override first, then the bot's setting, then the flag's default
+func resolve(flag models.FeatureFlag, flags *featureFlagRepo.GetFeatureFlagsOutput) *models.FeatureFlagState {
+	if enabled, ok := flags.GuildFlags[flag]; ok {
+		return &models.FeatureFlagState{Flag: flag, Enabled: enabled, Source: models.FeatureFlagSourceGuild}
+	}
+
+	if enabled, ok := flags.BotFlags[flag]; ok {
+		return &models.FeatureFlagState{Flag: flag, Enabled: enabled, Source: models.FeatureFlagSourceBot}
+	}
+
+	return &models.FeatureFlagState{Flag: flag, Enabled: flag.Default(), Source: models.FeatureFlagSourceDefault}
+}
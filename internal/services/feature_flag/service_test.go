@@ -0,0 +1,146 @@
+package feature_flag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	featureFlagRepo "github.com/KirkDiggler/ronnied/internal/repositories/feature_flag"
+	featureFlagMocks "github.com/KirkDiggler/ronnied/internal/repositories/feature_flag/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type FeatureFlagServiceTestSuite struct {
+	suite.Suite
+	mockCtrl            *gomock.Controller
+	mockFeatureFlagRepo *featureFlagMocks.MockRepository
+	featureFlagService  Service
+	ctx                 context.Context
+}
+
+func (s *FeatureFlagServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockFeatureFlagRepo = featureFlagMocks.NewMockRepository(s.mockCtrl)
+	s.ctx = context.Background()
+
+	svc, err := New(&Config{
+		FeatureFlagRepo: s.mockFeatureFlagRepo,
+	})
+	s.Require().NoError(err)
+	s.featureFlagService = svc
+}
+
+func (s *FeatureFlagServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestFeatureFlagServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagServiceTestSuite))
+}
+
+// expectFlags has the repository return the flags on the next read for the guild
+func (s *FeatureFlagServiceTestSuite) expectFlags(guildID string, botFlags, guildFlags map[models.FeatureFlag]bool) {
+	s.mockFeatureFlagRepo.EXPECT().
+		GetFeatureFlags(s.ctx, &featureFlagRepo.GetFeatureFlagsInput{GuildID: guildID}).
+		Return(&featureFlagRepo.GetFeatureFlagsOutput{BotFlags: botFlags, GuildFlags: guildFlags}, nil)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestIsEnabled() {
+	// Nobody has set it
+	s.expectFlags("guild1", nil, nil)
+	output, err := s.featureFlagService.IsEnabled(s.ctx, &IsEnabledInput{GuildID: "guild1", Flag: models.FeatureFlagRollOffs})
+	s.Require().NoError(err)
+	s.True(output.Enabled)
+	s.Equal(models.FeatureFlagSourceDefault, output.Source)
+
+	// Turned off for the whole bot
+	s.expectFlags("guild1", map[models.FeatureFlag]bool{models.FeatureFlagRollOffs: false}, nil)
+	output, err = s.featureFlagService.IsEnabled(s.ctx, &IsEnabledInput{GuildID: "guild1", Flag: models.FeatureFlagRollOffs})
+	s.Require().NoError(err)
+	s.False(output.Enabled)
+	s.Equal(models.FeatureFlagSourceBot, output.Source)
+
+	// The guild's override wins
+	s.expectFlags("guild1",
+		map[models.FeatureFlag]bool{models.FeatureFlagRollOffs: false},
+		map[models.FeatureFlag]bool{models.FeatureFlagRollOffs: true},
+	)
+	output, err = s.featureFlagService.IsEnabled(s.ctx, &IsEnabledInput{GuildID: "guild1", Flag: models.FeatureFlagRollOffs})
+	s.Require().NoError(err)
+	s.True(output.Enabled)
+	s.Equal(models.FeatureFlagSourceGuild, output.Source)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestIsEnabled_UnknownFlag() {
+	_, err := s.featureFlagService.IsEnabled(s.ctx, &IsEnabledInput{Flag: "confetti"})
+	s.ErrorIs(err, ErrUnknownFlag)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestIsEnabled_Error() {
+	s.mockFeatureFlagRepo.EXPECT().
+		GetFeatureFlags(s.ctx, gomock.Any()).
+		Return(nil, errors.New("redis is down"))
+
+	_, err := s.featureFlagService.IsEnabled(s.ctx, &IsEnabledInput{Flag: models.FeatureFlagLLMMessages})
+	s.Error(err)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestGetFeatureFlags() {
+	s.expectFlags("guild1",
+		map[models.FeatureFlag]bool{models.FeatureFlagLLMMessages: false},
+		map[models.FeatureFlag]bool{models.FeatureFlagAnimations: false},
+	)
+
+	output, err := s.featureFlagService.GetFeatureFlags(s.ctx, &GetFeatureFlagsInput{GuildID: "guild1"})
+	s.Require().NoError(err)
+	s.Equal([]*models.FeatureFlagState{
+		{Flag: models.FeatureFlagRollOffs, Enabled: true, Source: models.FeatureFlagSourceDefault},
+		{Flag: models.FeatureFlagAnimations, Enabled: false, Source: models.FeatureFlagSourceGuild},
+		{Flag: models.FeatureFlagLLMMessages, Enabled: false, Source: models.FeatureFlagSourceBot},
+	}, output.Flags)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestSetFeatureFlag() {
+	s.mockFeatureFlagRepo.EXPECT().
+		SetFeatureFlag(s.ctx, &featureFlagRepo.SetFeatureFlagInput{
+			GuildID: "guild1",
+			Flag:    models.FeatureFlagAnimations,
+			Enabled: false,
+		}).
+		Return(nil)
+
+	output, err := s.featureFlagService.SetFeatureFlag(s.ctx, &SetFeatureFlagInput{
+		GuildID: "guild1",
+		Flag:    models.FeatureFlagAnimations,
+		Enabled: false,
+	})
+	s.Require().NoError(err)
+	s.Equal(&models.FeatureFlagState{
+		Flag:    models.FeatureFlagAnimations,
+		Enabled: false,
+		Source:  models.FeatureFlagSourceGuild,
+	}, output.State)
+
+	_, err = s.featureFlagService.SetFeatureFlag(s.ctx, &SetFeatureFlagInput{Flag: "confetti"})
+	s.ErrorIs(err, ErrUnknownFlag)
+}
+
+func (s *FeatureFlagServiceTestSuite) TestClearFeatureFlag() {
+	s.mockFeatureFlagRepo.EXPECT().
+		ClearFeatureFlag(s.ctx, &featureFlagRepo.ClearFeatureFlagInput{
+			GuildID: "guild1",
+			Flag:    models.FeatureFlagAnimations,
+		}).
+		Return(nil)
+	s.expectFlags("guild1", map[models.FeatureFlag]bool{models.FeatureFlagAnimations: false}, nil)
+
+	output, err := s.featureFlagService.ClearFeatureFlag(s.ctx, &ClearFeatureFlagInput{
+		GuildID: "guild1",
+		Flag:    models.FeatureFlagAnimations,
+	})
+	s.Require().NoError(err)
+	s.False(output.State.Enabled, "the guild goes back to the bot's setting")
+	s.Equal(models.FeatureFlagSourceBot, output.State.Source)
+}
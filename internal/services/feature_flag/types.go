@@ -0,0 +1,63 @@
+package feature_flag
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+	featureFlagRepo "github.com/KirkDiggler/ronnied/internal/repositories/feature_flag"
+)
+
+// Config holds configuration for the feature flag service
+type Config struct {
+	// Repository dependencies
+	FeatureFlagRepo featureFlagRepo.Repository
+}
+
+// IsEnabledInput defines the input for checking a flag
+type IsEnabledInput struct {
+	// GuildID is optional, without it only the bot's setting counts
+	GuildID string
+	Flag    models.FeatureFlag
+}
+
+// IsEnabledOutput defines the output for checking a flag
+type IsEnabledOutput struct {
+	Enabled bool
+	Source  models.FeatureFlagSource
+}
+
+// GetFeatureFlagsInput defines the input for listing the flags
+type GetFeatureFlagsInput struct {
+	// GuildID is optional, without it the flags are listed as set for the whole bot
+	GuildID string
+}
+
+// GetFeatureFlagsOutput defines the output for listing the flags
+type GetFeatureFlagsOutput struct {
+	// Flags has every known flag, in the order of models.FeatureFlags
+	Flags []*models.FeatureFlagState
+}
+
+// SetFeatureFlagInput defines the input for turning a flag on or off
+type SetFeatureFlagInput struct {
+	// GuildID is empty to set the flag for the whole bot
+	GuildID string
+	Flag    models.FeatureFlag
+	Enabled bool
+}
+
+// SetFeatureFlagOutput defines the output for turning a flag on or off
+type SetFeatureFlagOutput struct {
+	State *models.FeatureFlagState
+}
+
+// ClearFeatureFlagInput defines the input for removing a flag's setting
+type ClearFeatureFlagInput struct {
+	// GuildID is empty to clear the bot's setting
+	GuildID string
+	Flag    models.FeatureFlag
+}
+
+// ClearFeatureFlagOutput defines the output for removing a flag's setting
+type ClearFeatureFlagOutput struct {
+	// State is the flag as it is once the setting is gone
+	State *models.FeatureFlagState
+}
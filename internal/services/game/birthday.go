@@ -0,0 +1,120 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// SetBirthday saves or clears the day a player celebrates their birthday, creating the player if they haven't played yet
+func (s *service) SetBirthday(ctx context.Context, input *SetBirthdayInput) (*SetBirthdayOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	if input.Birthday != nil && !input.Birthday.IsValid() {
+		return nil, ErrInvalidBirthday
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, fmt.Errorf("failed to get player: %w", err)
+		}
+		player = &models.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		}
+	}
+
+	player.Birthday = input.Birthday
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save player: %w", err)
+	}
+
+	return &SetBirthdayOutput{
+		Birthday: player.Birthday,
+	}, nil
+}
+
+// GetBirthday returns a player's birthday and whether it's today
+func (s *service) GetBirthday(ctx context.Context, input *GetBirthdayInput) (*GetBirthdayOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return &GetBirthdayOutput{}, nil
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	return &GetBirthdayOutput{
+		Birthday: player.Birthday,
+		IsToday:  s.isBirthday(player),
+	}, nil
+}
+
+// birthdayPlayers returns the game's players celebrating their birthday today, other than the one given
+func (s *service) birthdayPlayers(ctx context.Context, game *models.Game, exceptPlayerID string) []PlayerOption {
+	var players []PlayerOption
+	for _, p := range game.Participants {
+		if p.PlayerID == exceptPlayerID {
+			continue
+		}
+
+		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: p.PlayerID,
+		})
+		if err != nil {
+			if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+				// Play on without the birthday rule rather than holding up the game
+				log.Printf("Error getting player birthday: %v", err)
+			}
+			continue
+		}
+
+		if s.isBirthday(player) {
+			players = append(players, PlayerOption{
+				PlayerID:   p.PlayerID,
+				PlayerName: p.PlayerName,
+			})
+		}
+	}
+	return players
+}
+
+// hasPlayerOption returns true if the player is one of the options
+func hasPlayerOption(options []PlayerOption, playerID string) bool {
+	for _, option := range options {
+		if option.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// isBirthday returns true if the player is celebrating their birthday today
+func (s *service) isBirthday(player *models.Player) bool {
+	if player.Birthday == nil {
+		return false
+	}
+
+	now := s.clock.Now()
+	if s.birthdayLocation != nil {
+		now = now.In(s.birthdayLocation)
+	}
+	return player.Birthday.IsOn(now)
+}
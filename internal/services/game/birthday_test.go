@@ -0,0 +1,125 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// BirthdayTestSuite tests the birthday rule against the real Redis repositories
+type BirthdayTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+
+	now time.Time
+}
+
+func (s *BirthdayTestSuite) SetupTest() {
+	s.now = time.Date(2025, time.March, 14, 20, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+		cfg.BirthdayLocation = time.UTC
+	})
+	s.testChannelID = "birthday-channel"
+}
+
+func TestBirthdayTestSuite(t *testing.T) {
+	suite.Run(t, new(BirthdayTestSuite))
+}
+
+// setBirthday gives a player a birthday
+func (s *BirthdayTestSuite) setBirthday(playerID string, month time.Month, day int) {
+	_, err := s.gameService.SetBirthday(s.ctx, &SetBirthdayInput{
+		PlayerID:   playerID,
+		PlayerName: playerID,
+		Birthday:   &models.Birthday{Month: month, Day: day},
+	})
+	s.Require().NoError(err)
+}
+
+// critical rolls a critical hit for the player
+func (s *BirthdayTestSuite) critical(gameID, playerID string) *RollDiceOutput {
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	output, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+	s.Require().NoError(err)
+	s.Require().True(output.IsCriticalHit)
+	return output
+}
+
+func (s *BirthdayTestSuite) TestSetBirthday() {
+	s.setBirthday("bob", time.March, 14)
+
+	output, err := s.gameService.GetBirthday(s.ctx, &GetBirthdayInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(&models.Birthday{Month: time.March, Day: 14}, output.Birthday)
+	s.True(output.IsToday)
+
+	// Clearing it
+	_, err = s.gameService.SetBirthday(s.ctx, &SetBirthdayInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	output, err = s.gameService.GetBirthday(s.ctx, &GetBirthdayInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Nil(output.Birthday)
+	s.False(output.IsToday)
+}
+
+func (s *BirthdayTestSuite) TestSetBirthday_Invalid() {
+	for _, birthday := range []*models.Birthday{
+		{Month: time.February, Day: 30},
+		{Month: time.April, Day: 31},
+		{Month: 13, Day: 1},
+		{Month: time.May, Day: 0},
+	} {
+		_, err := s.gameService.SetBirthday(s.ctx, &SetBirthdayInput{PlayerID: "bob", Birthday: birthday})
+		s.ErrorIs(err, ErrInvalidBirthday, birthday.String())
+	}
+}
+
+func (s *BirthdayTestSuite) TestCriticalHitGoesToTheBirthdayPlayer() {
+	s.setBirthday("bob", time.March, 14)
+	s.setBirthday("carol", time.June, 1)
+	gameID := s.startGame("alice", "bob", "carol")
+
+	output := s.critical(gameID, "alice")
+	s.Equal([]PlayerOption{{PlayerID: "bob", PlayerName: "bob"}}, output.BirthdayPlayers)
+	s.Equal(output.BirthdayPlayers, output.EligiblePlayers)
+
+	_, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "alice", ToPlayerID: "carol", Reason: DrinkReasonCriticalHit})
+	s.ErrorIs(err, ErrMustTargetBirthday)
+
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "alice", ToPlayerID: "bob", Reason: DrinkReasonCriticalHit})
+	s.NoError(err)
+}
+
+func (s *BirthdayTestSuite) TestBirthdayPlayerPicksAnyone() {
+	s.setBirthday("bob", time.March, 14)
+	gameID := s.startGame("alice", "bob", "carol")
+
+	output := s.critical(gameID, "bob")
+	s.Empty(output.BirthdayPlayers)
+	s.Len(output.EligiblePlayers, 2)
+
+	_, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "bob", ToPlayerID: "carol", Reason: DrinkReasonCriticalHit})
+	s.NoError(err)
+}
+
+func (s *BirthdayTestSuite) TestLeapDayBirthday() {
+	s.setBirthday("bob", time.February, 29)
+
+	// Celebrated on the 28th outside leap years
+	s.now = time.Date(2025, time.February, 28, 20, 0, 0, 0, time.UTC)
+	output, err := s.gameService.GetBirthday(s.ctx, &GetBirthdayInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.True(output.IsToday)
+
+	s.now = time.Date(2028, time.February, 28, 20, 0, 0, 0, time.UTC)
+	output, err = s.gameService.GetBirthday(s.ctx, &GetBirthdayInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.False(output.IsToday)
+}
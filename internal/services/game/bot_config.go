@@ -0,0 +1,95 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// ApplyBotConfig swaps in settings changed while the bot is running over the ones it was started with.
+// Settings that don't make a playable game are rejected and the current settings kept.
+func (s *service) ApplyBotConfig(ctx context.Context, input *ApplyBotConfigInput) (*ApplyBotConfigOutput, error) {
+	if input == nil || input.Config == nil {
+		return nil, errors.New("config is required")
+	}
+
+	rules := s.mergeBotConfig(input.Config)
+	if err := ValidateRules(rules); err != nil {
+		return nil, err
+	}
+
+	drinkCap := s.drinkCap
+	if input.Config.DrinkCap != nil {
+		drinkCap = *input.Config.DrinkCap
+	}
+	if drinkCap < 0 {
+		return nil, fmt.Errorf("%w: the drink cap can't be negative", ErrInvalidGameRules)
+	}
+
+	s.botConfigMu.Lock()
+	s.botConfig = input.Config
+	s.botConfigMu.Unlock()
+
+	return &ApplyBotConfigOutput{
+		Rules:    rules,
+		DrinkCap: drinkCap,
+	}, nil
+}
+
+// defaultRules returns the rules of games that don't set their own
+func (s *service) defaultRules() models.GameRules {
+	s.botConfigMu.RLock()
+	defer s.botConfigMu.RUnlock()
+
+	return s.mergeBotConfig(s.botConfig)
+}
+
+// mergeBotConfig returns the rules the service was started with, overridden by the runtime settings that are set
+func (s *service) mergeBotConfig(config *models.BotConfig) models.GameRules {
+	rules := models.GameRules{
+		DiceSides:          s.diceSides,
+		MaxPlayers:         s.maxPlayers,
+		CriticalHitValues:  s.criticalHitValues,
+		CriticalFailValues: s.criticalFailValues,
+		SocialValues:       s.socialValues,
+		LeaderHandicap:     s.leaderHandicap,
+		GuestDrinks:        s.guestDrinks,
+		Karma:              s.karma,
+		Jackpot:            s.jackpot,
+	}
+
+	if config == nil {
+		return rules
+	}
+
+	if config.DiceSides > 0 {
+		rules.DiceSides = config.DiceSides
+	}
+	if config.MaxPlayers > 0 {
+		rules.MaxPlayers = config.MaxPlayers
+	}
+	if len(config.CriticalHitValues) > 0 {
+		rules.CriticalHitValues = config.CriticalHitValues
+	}
+	if len(config.CriticalFailValues) > 0 {
+		rules.CriticalFailValues = config.CriticalFailValues
+	}
+	if config.LeaderHandicap != nil {
+		rules.LeaderHandicap = *config.LeaderHandicap
+	}
+
+	return rules
+}
+
+// currentDrinkCap returns the mercy rule's drink cap, 0 when it's off
+func (s *service) currentDrinkCap() int {
+	s.botConfigMu.RLock()
+	defer s.botConfigMu.RUnlock()
+
+	if s.botConfig != nil && s.botConfig.DrinkCap != nil {
+		return *s.botConfig.DrinkCap
+	}
+	return s.drinkCap
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// BotConfigTestSuite tests applying runtime settings against the real Redis repositories
+type BotConfigTestSuite struct {
+	serviceHarness
+}
+
+func (s *BotConfigTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.DrinkCap = 5
+	})
+}
+
+func TestBotConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(BotConfigTestSuite))
+}
+
+// defaultRules returns the rules of games that don't set their own
+func (s *BotConfigTestSuite) defaultRules() models.GameRules {
+	output, err := s.gameService.GetGameRules(s.ctx, &GetGameRulesInput{})
+	s.Require().NoError(err)
+	return output.Rules
+}
+
+func (s *BotConfigTestSuite) TestApplyBotConfig() {
+	noCap := 0
+	output, err := s.gameService.ApplyBotConfig(s.ctx, &ApplyBotConfigInput{
+		Config: &models.BotConfig{
+			DiceSides:          20,
+			CriticalHitValues:  []int{19, 20},
+			CriticalFailValues: []int{1},
+			DrinkCap:           &noCap,
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal(0, output.DrinkCap)
+
+	rules := s.defaultRules()
+	s.Equal(20, rules.DiceSides)
+	s.Equal([]int{19, 20}, rules.CriticalHitValues)
+	s.Equal(10, rules.MaxPlayers, "settings left unset keep their startup value")
+	s.Equal(output.Rules, rules)
+
+	// Games with their own rules keep them
+	gameOutput, err := s.gameService.GetGameRules(s.ctx, &GetGameRulesInput{
+		Game: &models.Game{Rules: &models.GameRules{DiceSides: 6, CriticalHitValues: []int{6}}},
+	})
+	s.Require().NoError(err)
+	s.Equal(6, gameOutput.Rules.DiceSides)
+
+	// An empty config goes back to the startup settings
+	output, err = s.gameService.ApplyBotConfig(s.ctx, &ApplyBotConfigInput{Config: &models.BotConfig{}})
+	s.Require().NoError(err)
+	s.Equal(6, output.Rules.DiceSides)
+	s.Equal(5, output.DrinkCap)
+}
+
+func (s *BotConfigTestSuite) TestApplyBotConfig_Unplayable() {
+	negativeCap := -1
+	for _, config := range []*models.BotConfig{
+		{DiceSides: 4},
+		{CriticalHitValues: []int{1}},
+		{DrinkCap: &negativeCap},
+	} {
+		_, err := s.gameService.ApplyBotConfig(s.ctx, &ApplyBotConfigInput{Config: config})
+		s.ErrorIs(err, ErrInvalidGameRules)
+	}
+
+	// The startup settings are kept
+	s.Equal(6, s.defaultRules().DiceSides)
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// challengeTTL is how long the opponent has to accept a challenge
+const challengeTTL = 5 * time.Minute
+
+// CreateChallenge challenges another player to a quickfire game of odds and evens, no lobby needed.
+// The opponent has until the challenge expires to accept it.
+func (s *service) CreateChallenge(ctx context.Context, input *CreateChallengeInput) (*CreateChallengeOutput, error) {
+	if input == nil || input.ChannelID == "" || input.ChallengerID == "" || input.OpponentID == "" {
+		return nil, errors.New("channel, challenger and opponent IDs are required")
+	}
+
+	if input.ChallengerID == input.OpponentID {
+		return nil, ErrCannotChallengeSelf
+	}
+
+	call := input.Call
+	if call == "" {
+		call = models.ChallengeCallOdds
+	}
+	if call != models.ChallengeCallOdds && call != models.ChallengeCallEvens {
+		return nil, ErrInvalidChallengeCall
+	}
+
+	now := s.clock.Now()
+	challenge := &models.ChallengeGame{
+		ID:             s.uuid.NewUUID(),
+		ChannelID:      input.ChannelID,
+		GuildID:        input.GuildID,
+		ChallengerID:   input.ChallengerID,
+		ChallengerName: input.ChallengerName,
+		OpponentID:     input.OpponentID,
+		OpponentName:   input.OpponentName,
+		Call:           call,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(challengeTTL),
+	}
+
+	if err := s.gameRepo.CreateChallenge(ctx, &gameRepo.CreateChallengeInput{
+		Challenge: challenge,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save challenge: %w", err)
+	}
+
+	return &CreateChallengeOutput{
+		Challenge: challenge,
+	}, nil
+}
+
+// AcceptChallenge plays a challenge: both players roll at once and the loser's drink goes in the session ledger
+func (s *service) AcceptChallenge(ctx context.Context, input *AcceptChallengeInput) (*AcceptChallengeOutput, error) {
+	if input == nil || input.ChallengeID == "" || input.PlayerID == "" {
+		return nil, errors.New("challenge and player IDs are required")
+	}
+
+	challenge, err := s.getChallenge(ctx, input.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.OpponentID != input.PlayerID {
+		return nil, ErrNotChallengeTarget
+	}
+
+	// Claim the challenge so it can only be played once
+	if err := s.deleteChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
+
+	rules := s.rulesFor(nil)
+	challenge.ChallengerRoll = s.diceRoller.Roll(rules.DiceSides)
+	challenge.OpponentRoll = s.diceRoller.Roll(rules.DiceSides)
+
+	// The drink is recorded like a game's, with the challenge standing in for the game
+	challengeGame := &models.Game{
+		ID:        challenge.ID,
+		ChannelID: challenge.ChannelID,
+		GuildID:   challenge.GuildID,
+		Participants: []*models.Participant{
+			{PlayerID: challenge.ChallengerID, PlayerName: challenge.ChallengerName},
+			{PlayerID: challenge.OpponentID, PlayerName: challenge.OpponentName},
+		},
+	}
+
+	output, err := s.recordDrink(ctx, challengeGame, s.getSession(ctx, challenge.GuildID, challenge.ChannelID), &ledgerRepo.CreateDrinkRecordInput{
+		GameID:       challenge.ID,
+		FromPlayerID: challenge.WinnerID(),
+		ToPlayerID:   challenge.LoserID(),
+		Reason:       models.DrinkReasonChallenge,
+		Timestamp:    s.clock.Now(),
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record challenge drink: %w", err)
+	}
+
+	return &AcceptChallengeOutput{
+		Challenge:   challenge,
+		DrinkRecord: output.Record,
+	}, nil
+}
+
+// DeclineChallenge turns down a challenge, either player can call it off
+func (s *service) DeclineChallenge(ctx context.Context, input *DeclineChallengeInput) (*DeclineChallengeOutput, error) {
+	if input == nil || input.ChallengeID == "" || input.PlayerID == "" {
+		return nil, errors.New("challenge and player IDs are required")
+	}
+
+	challenge, err := s.getChallenge(ctx, input.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.ChallengerID != input.PlayerID && challenge.OpponentID != input.PlayerID {
+		return nil, ErrNotChallengeTarget
+	}
+
+	if err := s.deleteChallenge(ctx, challenge.ID); err != nil {
+		return nil, err
+	}
+
+	return &DeclineChallengeOutput{
+		Challenge: challenge,
+	}, nil
+}
+
+// getChallenge gets a pending challenge, mapping a missing or expired one to ErrChallengeNotFound
+func (s *service) getChallenge(ctx context.Context, challengeID string) (*models.ChallengeGame, error) {
+	output, err := s.gameRepo.GetChallenge(ctx, &gameRepo.GetChallengeInput{
+		ChallengeID: challengeID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrChallengeNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+	return output.Challenge, nil
+}
+
+// deleteChallenge removes a pending challenge, failing if someone else answered it first
+func (s *service) deleteChallenge(ctx context.Context, challengeID string) error {
+	err := s.gameRepo.DeleteChallenge(ctx, &gameRepo.DeleteChallengeInput{
+		ChallengeID: challengeID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrChallengeNotFound) {
+			return ErrChallengeNotFound
+		}
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+	return nil
+}
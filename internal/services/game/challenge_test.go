@@ -0,0 +1,115 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// ChallengeTestSuite tests quickfire challenges between players against the real Redis repositories
+type ChallengeTestSuite struct {
+	serviceHarness
+}
+
+func (s *ChallengeTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "challenge-channel"
+	s.testGuildID = "challenge-guild"
+}
+
+func TestChallengeTestSuite(t *testing.T) {
+	suite.Run(t, new(ChallengeTestSuite))
+}
+
+// challenge has alice challenge bob with the given call
+func (s *ChallengeTestSuite) challenge(call models.ChallengeCall) (*CreateChallengeOutput, error) {
+	return s.gameService.CreateChallenge(s.ctx, &CreateChallengeInput{
+		ChannelID:      s.testChannelID,
+		GuildID:        s.testGuildID,
+		ChallengerID:   "alice",
+		ChallengerName: "Alice",
+		OpponentID:     "bob",
+		OpponentName:   "Bob",
+		Call:           call,
+	})
+}
+
+// sessionDrinks returns the drinks each player owes in the test guild's session
+func (s *ChallengeTestSuite) sessionDrinks() map[string]int {
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	drinks := make(map[string]int)
+	for _, entry := range output.Entries {
+		drinks[entry.PlayerID] = entry.DrinkCount
+	}
+	return drinks
+}
+
+func (s *ChallengeTestSuite) TestChallengerWins() {
+	createOutput, err := s.challenge(models.ChallengeCallEvens)
+	s.Require().NoError(err)
+
+	// Only bob can accept
+	_, err = s.gameService.AcceptChallenge(s.ctx, &AcceptChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrNotChallengeTarget)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	s.mockDiceRoller.EXPECT().Roll(6).Return(5)
+	acceptOutput, err := s.gameService.AcceptChallenge(s.ctx, &AcceptChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(8, acceptOutput.Challenge.Total())
+	s.Equal("bob", acceptOutput.DrinkRecord.ToPlayerID)
+	s.Equal("alice", acceptOutput.DrinkRecord.FromPlayerID)
+	s.Equal(models.DrinkReasonChallenge, acceptOutput.DrinkRecord.Reason)
+
+	// The drink is on bob's session tab
+	s.Equal(map[string]int{"bob": 1}, s.sessionDrinks())
+
+	// It can't be played twice
+	_, err = s.gameService.AcceptChallenge(s.ctx, &AcceptChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrChallengeNotFound)
+}
+
+func (s *ChallengeTestSuite) TestChallengerLoses() {
+	createOutput, err := s.challenge("")
+	s.Require().NoError(err)
+	s.Equal(models.ChallengeCallOdds, createOutput.Challenge.Call)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(2)
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	acceptOutput, err := s.gameService.AcceptChallenge(s.ctx, &AcceptChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal("alice", acceptOutput.DrinkRecord.ToPlayerID)
+	s.Equal(map[string]int{"alice": 1}, s.sessionDrinks())
+}
+
+func (s *ChallengeTestSuite) TestDeclineChallenge() {
+	createOutput, err := s.challenge(models.ChallengeCallOdds)
+	s.Require().NoError(err)
+
+	_, err = s.gameService.DeclineChallenge(s.ctx, &DeclineChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrNotChallengeTarget)
+
+	_, err = s.gameService.DeclineChallenge(s.ctx, &DeclineChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.AcceptChallenge(s.ctx, &AcceptChallengeInput{ChallengeID: createOutput.Challenge.ID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrChallengeNotFound)
+}
+
+func (s *ChallengeTestSuite) TestCreateChallenge_Invalid() {
+	_, err := s.gameService.CreateChallenge(s.ctx, &CreateChallengeInput{
+		ChannelID:    s.testChannelID,
+		ChallengerID: "alice",
+		OpponentID:   "alice",
+	})
+	s.ErrorIs(err, ErrCannotChallengeSelf)
+
+	_, err = s.challenge("sevens")
+	s.ErrorIs(err, ErrInvalidChallengeCall)
+}
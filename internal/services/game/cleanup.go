@@ -0,0 +1,184 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// cleanupResult is what cleaning up a game did to it
+type cleanupResult int
+
+const (
+	// cleanupNothing means the game was already over, or a roll-off left to its game
+	cleanupNothing cleanupResult = iota
+
+	// cleanupRepaired means the game was moved along
+	cleanupRepaired
+
+	// cleanupAbandoned means the game was abandoned
+	cleanupAbandoned
+
+	// cleanupInPlay means the game was left to its players
+	cleanupInPlay
+)
+
+// CleanupGuildGames resolves a server's games that aren't over. Games stuck waiting on something that will never
+// happen are moved along, lobbies and games left idle are abandoned along with their roll-offs, and so are
+// roll-offs whose game is over. Games played in the last few minutes, or paused, are left to their players.
+func (s *service) CleanupGuildGames(ctx context.Context, input *CleanupGuildGamesInput) (*CleanupGuildGamesOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, errors.New("guild ID is required")
+	}
+
+	unfinished, err := s.gameRepo.GetUnfinishedGames(ctx, &gameRepo.GetUnfinishedGamesInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfinished games: %w", err)
+	}
+
+	active, err := s.gameRepo.GetActiveGames(ctx, &gameRepo.GetActiveGamesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active games: %w", err)
+	}
+
+	// Roll-offs, and games their channel lost track of, are only found among the active games
+	games := unfinished.Games
+	seen := make(map[string]bool)
+	for _, game := range games {
+		seen[game.ID] = true
+	}
+	for _, game := range active.Games {
+		if game.GuildID == input.GuildID && !seen[game.ID] {
+			seen[game.ID] = true
+			games = append(games, game)
+		}
+	}
+
+	// Top-level games go first, so roll-offs are only looked at once their game has been
+	sort.SliceStable(games, func(i, j int) bool {
+		if (games[i].ParentGameID == "") != (games[j].ParentGameID == "") {
+			return games[i].ParentGameID == ""
+		}
+		return games[i].CreatedAt.Before(games[j].CreatedAt)
+	})
+
+	output := &CleanupGuildGamesOutput{}
+	rootIDs := make(map[string]bool)
+	for _, game := range games {
+		// Roll-offs are shown on the message of the game they started from
+		rootIDs[s.rootGameID(ctx, game)] = true
+
+		result, err := s.cleanupGame(ctx, game.ID)
+		if err != nil {
+			log.Printf("Error cleaning up game %s: %v", game.ID, err)
+			continue
+		}
+
+		switch result {
+		case cleanupRepaired:
+			output.Repaired++
+		case cleanupAbandoned:
+			output.Abandoned++
+		case cleanupInPlay:
+			output.InPlay++
+		}
+	}
+
+	for gameID := range rootIDs {
+		game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			log.Printf("Error getting game %s to clean up: %v", gameID, err)
+			continue
+		}
+
+		if _, err := s.restoreChannelMapping(ctx, game); err != nil {
+			log.Printf("Error restoring channel mapping for game %s: %v", gameID, err)
+		}
+
+		output.Games = append(output.Games, game)
+	}
+
+	sort.Slice(output.Games, func(i, j int) bool {
+		return output.Games[i].CreatedAt.Before(output.Games[j].CreatedAt)
+	})
+
+	return output, nil
+}
+
+// cleanupGame repairs the game if it's stuck, or abandons it if it's been left idle or is a roll-off whose game is
+// over. The game is loaded again since cleaning up another game may have finished it.
+func (s *service) cleanupGame(ctx context.Context, gameID string) (cleanupResult, error) {
+	repaired, err := s.repairGame(ctx, gameID)
+	if err != nil {
+		return cleanupNothing, err
+	}
+	if repaired {
+		return cleanupRepaired, nil
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return cleanupNothing, err
+	}
+	if game.Status.IsFinished() {
+		return cleanupNothing, nil
+	}
+
+	if game.ParentGameID != "" {
+		// Roll-offs go with their game, unless it's over or gone
+		parentGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.ParentGameID,
+		})
+		if err != nil && !errors.Is(err, gameRepo.ErrGameNotFound) {
+			return cleanupNothing, err
+		}
+		if err == nil && !parentGame.Status.IsFinished() {
+			return cleanupNothing, nil
+		}
+	} else {
+		lastPlayed, err := s.lastPlayedAt(ctx, game)
+		if err != nil {
+			return cleanupNothing, err
+		}
+		if game.IsPaused() || s.clock.Now().Sub(lastPlayed) < idleGameAge {
+			return cleanupInPlay, nil
+		}
+	}
+
+	if _, err := s.AbandonGame(ctx, &AbandonGameInput{
+		GameID: game.ID,
+	}); err != nil {
+		return cleanupNothing, err
+	}
+
+	log.Printf("Abandoned game %s while cleaning up guild %s", game.ID, game.GuildID)
+	return cleanupAbandoned, nil
+}
+
+// lastPlayedAt returns when the game or any of its roll-offs was last changed
+func (s *service) lastPlayedAt(ctx context.Context, game *models.Game) (time.Time, error) {
+	rollOffs, err := s.rollOffTree(ctx, game)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	lastPlayed := game.UpdatedAt
+	for _, rollOff := range rollOffs {
+		if rollOff.UpdatedAt.After(lastPlayed) {
+			lastPlayed = rollOff.UpdatedAt
+		}
+	}
+	return lastPlayed, nil
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// CleanupGuildGamesTestSuite tests cleaning up a server's stuck games against the real Redis repositories
+type CleanupGuildGamesTestSuite struct {
+	serviceHarness
+	clock *clock.Fake
+}
+
+func (s *CleanupGuildGamesTestSuite) SetupTest() {
+	// Lobbies are stamped with the time they're created and joined by the repository rather than the service's clock
+	s.clock = clock.NewFake(time.Now())
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+	})
+	s.testGuildID = "guild"
+	s.testChannelID = "channel"
+}
+
+func TestCleanupGuildGamesTestSuite(t *testing.T) {
+	suite.Run(t, new(CleanupGuildGamesTestSuite))
+}
+
+func (s *CleanupGuildGamesTestSuite) TestIdleGamesAreAbandoned() {
+	lobbyID := s.createGameWith(&CreateGameInput{ChannelID: "lobby-channel"}, "alice")
+	idleID := s.startGameWith(&CreateGameInput{ChannelID: "idle-channel"}, "bob", "carol")
+	otherGuildID := s.createGameWith(&CreateGameInput{GuildID: "other-guild", ChannelID: "other-channel"}, "dan")
+
+	s.clock.Advance(idleGameAge + time.Second)
+	playingID := s.startGameWith(&CreateGameInput{ChannelID: "playing-channel"}, "erin", "frank")
+
+	output, err := s.gameService.CleanupGuildGames(s.ctx, &CleanupGuildGamesInput{GuildID: "guild"})
+	s.Require().NoError(err)
+	s.Equal(2, output.Abandoned)
+	s.Equal(1, output.InPlay)
+	s.Equal(0, output.Repaired)
+	s.Require().Len(output.Games, 3)
+
+	s.Equal(models.GameStatusAbandoned, s.gameStatus(lobbyID))
+	s.Equal(models.GameStatusAbandoned, s.gameStatus(idleID))
+	s.Equal(models.GameStatusActive, s.gameStatus(playingID))
+	s.Equal(models.GameStatusWaiting, s.gameStatus(otherGuildID))
+
+	// The lobby's players are free to play again
+	_, err = s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: "lobby-channel"})
+	s.Error(err)
+}
+
+func (s *CleanupGuildGamesTestSuite) TestRollOffsGoWithTheirGame() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffID := game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffID)
+
+	// A roll-off played recently keeps its game going
+	s.clock.Advance(idleGameAge + time.Second)
+	s.roll(rollOffID, "alice", 3)
+
+	output, err := s.gameService.CleanupGuildGames(s.ctx, &CleanupGuildGamesInput{GuildID: "guild"})
+	s.Require().NoError(err)
+	s.Equal(0, output.Abandoned)
+	s.Equal(1, output.InPlay)
+	s.Require().Len(output.Games, 1)
+	s.Equal(gameID, output.Games[0].ID)
+
+	// Once it's been left idle too, the game and its roll-off are abandoned together
+	s.clock.Advance(idleGameAge + time.Second)
+	output, err = s.gameService.CleanupGuildGames(s.ctx, &CleanupGuildGamesInput{GuildID: "guild"})
+	s.Require().NoError(err)
+	s.Equal(1, output.Abandoned)
+	s.Equal(models.GameStatusAbandoned, s.gameStatus(gameID))
+	s.Equal(models.GameStatusAbandoned, s.gameStatus(rollOffID))
+}
+
+func (s *CleanupGuildGamesTestSuite) TestOrphanedRollOff() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffID := game.LowestRollOffGameID
+
+	// The bot went down after the game was abandoned but before its roll-off was
+	game.Status = models.GameStatusAbandoned
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: game}))
+
+	output, err := s.gameService.CleanupGuildGames(s.ctx, &CleanupGuildGamesInput{GuildID: "guild"})
+	s.Require().NoError(err)
+	s.Equal(1, output.Abandoned)
+	s.Equal(0, output.InPlay)
+	s.Require().Len(output.Games, 1)
+	s.Equal(gameID, output.Games[0].ID)
+	s.Equal(models.GameStatusAbandoned, s.gameStatus(rollOffID))
+}
+
+func (s *CleanupGuildGamesTestSuite) TestStuckGamesAreRepaired() {
+	gameID := s.startGame("alice", "bob")
+
+	// Everyone's rolls were saved but the bot went down before the game was ended
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	now := s.clock.Now()
+	for value, participant := range map[int]*models.Participant{3: game.GetParticipant("alice"), 5: game.GetParticipant("bob")} {
+		participant.RollValue = value
+		participant.RollTime = &now
+		participant.Status = models.ParticipantStatusActive
+	}
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: game}))
+
+	output, err := s.gameService.CleanupGuildGames(s.ctx, &CleanupGuildGamesInput{GuildID: "guild"})
+	s.Require().NoError(err)
+	s.Equal(1, output.Repaired)
+	s.Equal(0, output.Abandoned)
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+}
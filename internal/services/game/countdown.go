@@ -0,0 +1,54 @@
+package game
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// countdownLength is how long the countdown runs before a countdown game's rolling window opens
+const countdownLength = 3 * time.Second
+
+// countdownRollWindow returns the rolling window for a countdown game started at the given time
+func (s *service) countdownRollWindow(startedAt time.Time) *models.RollWindow {
+	opensAt := startedAt.Add(countdownLength)
+	return &models.RollWindow{
+		OpensAt:  opensAt,
+		ClosesAt: opensAt.Add(s.rollWindow),
+	}
+}
+
+// checkRollTiming gives a countdown game's player a penalty drink when they roll outside the rolling window,
+// returning when they rolled if it was out of sync. Roll-offs are played at everyone's own pace.
+func (s *service) checkRollTiming(ctx context.Context, game *models.Game, participant *models.Participant, rolledAt time.Time) RollTiming {
+	if game.RollWindow == nil || game.Status == models.GameStatusRollOff {
+		return ""
+	}
+
+	var timing RollTiming
+	switch {
+	case game.RollWindow.IsEarly(rolledAt):
+		timing = RollTimingEarly
+	case game.RollWindow.IsLate(rolledAt):
+		timing = RollTimingLate
+	default:
+		return ""
+	}
+
+	_, err := s.createDrinkRecord(ctx, game, s.getSessionForGame(ctx, game), &ledgerRepo.CreateDrinkRecordInput{
+		GameID:       game.ID,
+		FromPlayerID: participant.PlayerID,
+		ToPlayerID:   participant.PlayerID,
+		Reason:       models.DrinkReasonOutOfSync,
+		Timestamp:    rolledAt,
+	})
+	if err != nil {
+		// Don't fail the roll over the penalty
+		log.Printf("Error saving out of sync drink record: %v", err)
+	}
+
+	return timing
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// CountdownTestSuite tests countdown games against the real Redis repositories
+type CountdownTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+
+	// now is the time the mocked clock reports
+	now time.Time
+}
+
+func (s *CountdownTestSuite) SetupTest() {
+	s.now = time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+		cfg.CountdownRollWindow = 5 * time.Second
+	})
+	s.testChannelID = "countdown-channel"
+}
+
+func TestCountdownTestSuite(t *testing.T) {
+	suite.Run(t, new(CountdownTestSuite))
+}
+
+// startGame starts a game for alice and bob, returning its ID and rolling window
+func (s *CountdownTestSuite) startGame(countdown bool) (string, *models.RollWindow) {
+	gameID := s.createGameWith(&CreateGameInput{Countdown: countdown}, "alice", "bob")
+
+	startOutput, err := s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	return gameID, startOutput.RollWindow
+}
+
+// rollAt rolls a 3 for the player at the given time
+func (s *CountdownTestSuite) rollAt(gameID, playerID string, at time.Time) *RollDiceOutput {
+	s.now = at
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	output, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+	s.Require().NoError(err)
+	return output
+}
+
+// outOfSyncDrinks returns the out of sync drinks given in the game
+func (s *CountdownTestSuite) outOfSyncDrinks(gameID string) []*models.DrinkLedger {
+	output, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	var drinks []*models.DrinkLedger
+	for _, record := range output.Records {
+		if record.Reason == models.DrinkReasonOutOfSync {
+			drinks = append(drinks, record)
+		}
+	}
+	return drinks
+}
+
+func (s *CountdownTestSuite) TestStartOpensTheWindowAfterTheCountdown() {
+	start := s.now
+	_, window := s.startGame(true)
+
+	s.Require().NotNil(window)
+	s.Equal(start.Add(3*time.Second), window.OpensAt)
+	s.Equal(start.Add(8*time.Second), window.ClosesAt)
+}
+
+func (s *CountdownTestSuite) TestRollsInTheWindowAreFree() {
+	gameID, window := s.startGame(true)
+
+	output := s.rollAt(gameID, "alice", window.OpensAt)
+	s.Empty(output.RollTiming)
+
+	output = s.rollAt(gameID, "bob", window.ClosesAt)
+	s.Empty(output.RollTiming)
+
+	s.Empty(s.outOfSyncDrinks(gameID))
+}
+
+func (s *CountdownTestSuite) TestRollsOutsideTheWindowCostADrink() {
+	gameID, window := s.startGame(true)
+
+	output := s.rollAt(gameID, "alice", window.OpensAt.Add(-time.Second))
+	s.Equal(RollTimingEarly, output.RollTiming)
+
+	output = s.rollAt(gameID, "bob", window.ClosesAt.Add(time.Second))
+	s.Equal(RollTimingLate, output.RollTiming)
+
+	drinks := s.outOfSyncDrinks(gameID)
+	s.Require().Len(drinks, 2)
+	s.ElementsMatch([]string{"alice", "bob"}, []string{drinks[0].ToPlayerID, drinks[1].ToPlayerID})
+}
+
+func (s *CountdownTestSuite) TestOtherGamesHaveNoWindow() {
+	gameID, window := s.startGame(false)
+	s.Nil(window)
+
+	output := s.rollAt(gameID, "alice", s.now.Add(time.Hour))
+	s.Empty(output.RollTiming)
+	s.Empty(s.outOfSyncDrinks(gameID))
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// applyCredit offsets an owed drink with a credit the recipient banked, recording it as already paid.
+// Social drinks and drinks owed by designated drivers are left alone, they aren't paid now anyway.
+func (s *service) applyCredit(ctx context.Context, input *ledgerRepo.CreateDrinkRecordInput) {
+	if input.SessionID == "" || input.Social || input.OwedLater {
+		return
+	}
+
+	output, err := s.drinkLedgerRepo.ApplyCredit(ctx, &ledgerRepo.ApplyCreditInput{
+		SessionID: input.SessionID,
+		PlayerID:  input.ToPlayerID,
+	})
+	if err != nil {
+		// The drink is owed as normal and the credit kept for the next one
+		log.Printf("Error applying drink credit: %v", err)
+		return
+	}
+
+	input.PaidWithCredit = output.Applied
+}
+
+// bankCredit banks a credit for a player who paid a drink they didn't owe, returning their balance
+func (s *service) bankCredit(ctx context.Context, session *models.Session, playerID string) (int, error) {
+	output, err := s.drinkLedgerRepo.AddCredit(ctx, &ledgerRepo.AddCreditInput{
+		SessionID: session.ID,
+		PlayerID:  playerID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to bank drink credit: %w", err)
+	}
+	return output.Balance, nil
+}
+
+// creditsFor returns the credits a player has banked in a session, zero when they can't be looked up
+func (s *service) creditsFor(ctx context.Context, session *models.Session, playerID string) int {
+	if session == nil {
+		return 0
+	}
+
+	output, err := s.drinkLedgerRepo.GetCredits(ctx, &ledgerRepo.GetCreditsInput{
+		SessionID: session.ID,
+	})
+	if err != nil {
+		log.Printf("Error getting drink credits: %v", err)
+		return 0
+	}
+	return output.Balances[playerID]
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// DrinkCreditTestSuite tests banking and spending drink credits against the real Redis repositories
+type DrinkCreditTestSuite struct {
+	serviceHarness
+}
+
+func (s *DrinkCreditTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "credit-channel"
+}
+
+func TestDrinkCreditTestSuite(t *testing.T) {
+	suite.Run(t, new(DrinkCreditTestSuite))
+}
+
+// playRound starts the game and has bob roll lowest, so he's given a drink
+func (s *DrinkCreditTestSuite) playRound(gameID string) {
+	s.start(gameID, "alice")
+	s.roll(gameID, "alice", 4)
+	s.roll(gameID, "bob", 2)
+}
+
+// tab returns bob's tab for the game
+func (s *DrinkCreditTestSuite) tab(gameID string) *PlayerTab {
+	output, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	return output.Tab
+}
+
+func (s *DrinkCreditTestSuite) TestPayDrink_BanksCredit() {
+	gameID := s.createGame("alice", "bob")
+
+	for expected := 1; expected <= 2; expected++ {
+		output, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+		s.Require().NoError(err)
+		s.True(output.Credited)
+		s.Equal(expected, output.CreditBalance)
+	}
+
+	s.Equal(2, s.tab(gameID).Credits)
+}
+
+func (s *DrinkCreditTestSuite) TestCreditOffsetsNextDrink() {
+	gameID := s.createGame("alice", "bob")
+
+	_, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	s.playRound(gameID)
+
+	// The drink bob was given is already covered and his credit is spent
+	tab := s.tab(gameID)
+	s.Require().Len(tab.DrinksOwed, 1)
+	s.True(tab.DrinksOwed[0].Paid)
+	s.True(tab.DrinksOwed[0].PaidWithCredit)
+	s.Zero(tab.Credits)
+
+	// So paying again banks a new credit rather than paying it twice
+	output, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.True(output.Credited)
+	s.Equal(1, output.CreditBalance)
+}
+
+func (s *DrinkCreditTestSuite) TestNoCreditMeansDrinkIsOwed() {
+	gameID := s.createGame("alice", "bob")
+
+	s.playRound(gameID)
+
+	tab := s.tab(gameID)
+	s.Require().Len(tab.DrinksOwed, 1)
+	s.False(tab.DrinksOwed[0].Paid)
+	s.False(tab.DrinksOwed[0].PaidWithCredit)
+
+	output, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.False(output.Credited)
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// departedForgiveReason is why the drinks of a player who left the server are forgiven
+const departedForgiveReason = "left the server"
+
+// RemoveDepartedPlayer takes a player who left a server out of the game they were playing there, so it isn't left
+// waiting on a roll or a drink to assign that's never coming. The game notes they left, the game moves to another
+// player when they created it and a game nobody is left in is abandoned. The drinks they still owe in the server's
+// session are forgiven, as nobody can collect them.
+func (s *service) RemoveDepartedPlayer(ctx context.Context, input *RemoveDepartedPlayerInput) (*RemoveDepartedPlayerOutput, error) {
+	if input == nil || input.GuildID == "" || input.PlayerID == "" {
+		return nil, errors.New("guild and player IDs are required")
+	}
+
+	output := &RemoveDepartedPlayerOutput{}
+
+	// A player who never played has nothing to clean up
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return output, nil
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	if player.CurrentGameID != "" {
+		game, err := s.getGame(ctx, player.CurrentGameID)
+		if err != nil && !errors.Is(err, ErrGameNotFound) {
+			return nil, err
+		}
+
+		// Only the games played in the server they left
+		if game != nil && game.GuildID == input.GuildID && !game.Status.IsFinished() {
+			output.GameID = game.ID
+			output.ChannelID = game.ChannelID
+			output.RootGameID = s.rootGameID(ctx, game)
+
+			output.Abandoned, err = s.removeDepartedParticipant(ctx, game, output.RootGameID, input.PlayerID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	forgiveOutput, err := s.ForgiveDrinks(ctx, &ForgiveDrinksInput{
+		ChannelID:  output.ChannelID,
+		GuildID:    input.GuildID,
+		PlayerID:   input.PlayerID,
+		WholeTab:   true,
+		ForgivenBy: "system",
+		Reason:     departedForgiveReason,
+	})
+	switch {
+	case err == nil:
+		output.ForgivenCount = len(forgiveOutput.Records)
+	case errors.Is(err, ErrNoDrinksToForgive), errors.Is(err, ErrSessionNotFound):
+	default:
+		log.Printf("Error forgiving the drinks of %s, who left guild %s: %v", input.PlayerID, input.GuildID, err)
+	}
+
+	return output, nil
+}
+
+// removeDepartedParticipant takes a player who left the server out of a game and notes it on the game whose message
+// shows it. The game carries on without them, completing if everyone left has rolled, and is abandoned when
+// nobody is left, which it reports.
+func (s *service) removeDepartedParticipant(ctx context.Context, game *models.Game, rootGameID, playerID string) (bool, error) {
+	participant := game.GetParticipant(playerID)
+	if participant == nil {
+		// They were only waiting for a spot
+		if game.WaitlistPosition(playerID) == 0 {
+			return false, nil
+		}
+		game.Waitlist = slices.DeleteFunc(game.Waitlist, func(entry *models.WaitlistEntry) bool {
+			return entry.PlayerID == playerID
+		})
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+			return false, fmt.Errorf("failed to save game: %w", err)
+		}
+		return false, nil
+	}
+
+	now := s.clock.Now()
+	departure := &models.Departure{
+		PlayerID:   playerID,
+		PlayerName: participant.PlayerName,
+		LeftAt:     now,
+	}
+
+	// Note it on the game with the message before a roll-off finishing can complete it
+	if rootGameID != game.ID {
+		rootGame, err := s.getGame(ctx, rootGameID)
+		if err != nil {
+			return false, err
+		}
+		rootGame.Departures = append(rootGame.Departures, departure)
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: rootGame}); err != nil {
+			return false, fmt.Errorf("failed to save game: %w", err)
+		}
+	} else {
+		game.Departures = append(game.Departures, departure)
+	}
+
+	// Whatever they still had to roll or assign goes with them
+	game.Participants = slices.DeleteFunc(game.Participants, func(participant *models.Participant) bool {
+		return participant.PlayerID == playerID
+	})
+	game.IndexParticipants()
+	game.CoHostIDs = slices.DeleteFunc(game.CoHostIDs, func(id string) bool {
+		return id == playerID
+	})
+	if game.CreatorID == playerID && len(game.Participants) > 0 {
+		game.CreatorID = game.Participants[0].PlayerID
+	}
+	game.UpdatedAt = now
+
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return false, fmt.Errorf("failed to save game: %w", err)
+	}
+	s.releasePlayers(ctx, game.ID, []string{playerID})
+
+	if len(game.Participants) == 0 {
+		return true, s.abandonTimedOutGame(ctx, game)
+	}
+
+	if game.Status == models.GameStatusWaiting {
+		if _, err := s.promoteFromWaitlist(ctx, game, playerID); err != nil {
+			log.Printf("Error promoting from the waitlist of game %s: %v", game.ID, err)
+		}
+		return false, nil
+	}
+
+	if _, err := s.completeIfReady(ctx, game); err != nil {
+		return false, fmt.Errorf("failed to complete game: %w", err)
+	}
+
+	return false, nil
+}
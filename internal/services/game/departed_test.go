@@ -0,0 +1,120 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// DepartedPlayerTestSuite tests handling players who leave a server against the real Redis repositories
+type DepartedPlayerTestSuite struct {
+	serviceHarness
+}
+
+func (s *DepartedPlayerTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testGuildID = "departed-guild"
+	s.testChannelID = "departed-channel"
+}
+
+func TestDepartedPlayerTestSuite(t *testing.T) {
+	suite.Run(t, new(DepartedPlayerTestSuite))
+}
+
+func (s *DepartedPlayerTestSuite) leave(playerID string) *RemoveDepartedPlayerOutput {
+	output, err := s.gameService.RemoveDepartedPlayer(s.ctx, &RemoveDepartedPlayerInput{
+		GuildID:  s.testGuildID,
+		PlayerID: playerID,
+	})
+	s.Require().NoError(err)
+	return output
+}
+
+func (s *DepartedPlayerTestSuite) TestGameCarriesOnWithoutThem() {
+	gameID := s.createGame("alice", "bob", "carol")
+	s.start(gameID, "alice")
+	s.roll(gameID, "alice", 4)
+	s.roll(gameID, "bob", 2)
+
+	output := s.leave("carol")
+	s.Equal(gameID, output.GameID)
+	s.Equal(gameID, output.RootGameID)
+	s.Equal(s.testChannelID, output.ChannelID)
+	s.False(output.Abandoned)
+
+	// Everyone left has rolled, so the game is over
+	game := s.getGame(gameID)
+	s.Equal(models.GameStatusCompleted, game.Status)
+	s.False(game.HasParticipant("carol"))
+	s.Require().Len(game.Departures, 1)
+	s.Equal("carol", game.Departures[0].PlayerName)
+
+	player, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Empty(player.CurrentGameID)
+}
+
+func (s *DepartedPlayerTestSuite) TestCreatorLeavingHandsOverTheGame() {
+	gameID := s.createGame("alice", "bob")
+
+	s.leave("alice")
+
+	game := s.getGame(gameID)
+	s.Equal(models.GameStatusWaiting, game.Status)
+	s.Equal("bob", game.CreatorID)
+	s.Len(game.Participants, 1)
+}
+
+func (s *DepartedPlayerTestSuite) TestLastPlayerLeavingAbandonsTheGame() {
+	gameID := s.createGame("alice")
+
+	output := s.leave("alice")
+	s.True(output.Abandoned)
+	s.Equal(models.GameStatusAbandoned, s.getGame(gameID).Status)
+}
+
+func (s *DepartedPlayerTestSuite) TestOutstandingDrinksAreForgiven() {
+	gameID := s.createGame("alice", "bob")
+	s.start(gameID, "alice")
+	s.roll(gameID, "alice", 4)
+	s.roll(gameID, "bob", 2)
+
+	output := s.leave("bob")
+	s.Empty(output.GameID, "the game was already over")
+	s.Equal(1, output.ForgivenCount)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal(1, leaderboard.Entries[0].ForgivenCount)
+}
+
+func (s *DepartedPlayerTestSuite) TestOtherServersGamesAreLeftAlone() {
+	gameID := s.createGame("alice", "bob")
+
+	output, err := s.gameService.RemoveDepartedPlayer(s.ctx, &RemoveDepartedPlayerInput{
+		GuildID:  "another-guild",
+		PlayerID: "bob",
+	})
+	s.Require().NoError(err)
+	s.Empty(output.GameID)
+	s.True(s.getGame(gameID).HasParticipant("bob"))
+}
+
+func (s *DepartedPlayerTestSuite) TestUnknownPlayer() {
+	output := s.leave("stranger")
+	s.Empty(output.GameID)
+	s.Zero(output.ForgivenCount)
+
+	_, err := s.gameService.RemoveDepartedPlayer(s.ctx, &RemoveDepartedPlayerInput{GuildID: s.testGuildID})
+	s.Error(err)
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// SetDesignatedDriver makes a player the designated driver for the channel's session
+func (s *service) SetDesignatedDriver(ctx context.Context, input *SetDesignatedDriverInput) (*SetDesignatedDriverOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	if input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, errors.New("no active session found for channel")
+	}
+
+	if session.GetDesignatedDriver(input.PlayerID) != nil {
+		return nil, ErrAlreadyDesignatedDriver
+	}
+
+	// A substitute can't stop drinking while someone is counting on them
+	for _, driver := range session.DesignatedDrivers {
+		if driver.SubstituteID == input.PlayerID {
+			return nil, ErrCoveringForDriver
+		}
+	}
+
+	session.DesignatedDrivers = append(session.DesignatedDrivers, &models.DesignatedDriver{
+		PlayerID:   input.PlayerID,
+		PlayerName: input.PlayerName,
+		Since:      s.clock.Now(),
+	})
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.publishLedgerChanged(ctx, input.GuildID, input.ChannelID, session.ID, "designated_driver")
+
+	return &SetDesignatedDriverOutput{
+		Session: session,
+	}, nil
+}
+
+// ClearDesignatedDriver lets a designated driver start drinking again, their drinks owed later become payable
+func (s *service) ClearDesignatedDriver(ctx context.Context, input *ClearDesignatedDriverInput) (*ClearDesignatedDriverOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	if input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, errors.New("no active session found for channel")
+	}
+
+	if session.GetDesignatedDriver(input.PlayerID) == nil {
+		return nil, ErrNotDesignatedDriver
+	}
+
+	drivers := make([]*models.DesignatedDriver, 0, len(session.DesignatedDrivers)-1)
+	for _, driver := range session.DesignatedDrivers {
+		if driver.PlayerID != input.PlayerID {
+			drivers = append(drivers, driver)
+		}
+	}
+	session.DesignatedDrivers = drivers
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.publishLedgerChanged(ctx, input.GuildID, input.ChannelID, session.ID, "designated_driver")
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session drink records: %w", err)
+	}
+
+	drinksOwed := 0
+	for _, record := range drinkRecords.Records {
+		if record.ToPlayerID == input.PlayerID && record.OwedLater && record.IsOutstanding() {
+			drinksOwed++
+		}
+	}
+
+	return &ClearDesignatedDriverOutput{
+		DrinksOwed: drinksOwed,
+	}, nil
+}
+
+// CoverDesignatedDriver has a player volunteer to take a designated driver's drinks
+func (s *service) CoverDesignatedDriver(ctx context.Context, input *CoverDesignatedDriverInput) (*CoverDesignatedDriverOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	if input.DriverID == "" || input.SubstituteID == "" {
+		return nil, errors.New("driver ID and substitute ID are required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, errors.New("no active session found for channel")
+	}
+
+	driver := session.GetDesignatedDriver(input.DriverID)
+	if driver == nil {
+		return nil, ErrNotDesignatedDriver
+	}
+
+	if session.GetDesignatedDriver(input.SubstituteID) != nil {
+		return nil, ErrInvalidSubstitute
+	}
+
+	driver.SubstituteID = input.SubstituteID
+	driver.SubstituteName = input.SubstituteName
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return &CoverDesignatedDriverOutput{
+		Driver: driver,
+	}, nil
+}
+
+// applyDesignatedDriver redirects a drink given to a designated driver to their substitute,
+// or marks it owed later when nobody is covering for them
+func applyDesignatedDriver(driver *models.DesignatedDriver, input *ledgerRepo.CreateDrinkRecordInput) {
+	if driver.SubstituteID != "" {
+		input.CoveredFor = driver.PlayerID
+		input.ToPlayerID = driver.SubstituteID
+		return
+	}
+
+	input.OwedLater = true
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// DesignatedDriverTestSuite tests the designated driver role against the real Redis repositories
+type DesignatedDriverTestSuite struct {
+	serviceHarness
+}
+
+func (s *DesignatedDriverTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "driver-channel"
+}
+
+func TestDesignatedDriverTestSuite(t *testing.T) {
+	suite.Run(t, new(DesignatedDriverTestSuite))
+}
+
+// sessionRecords returns the drink records of the test channel's current session
+func (s *DesignatedDriverTestSuite) sessionRecords() []*models.DrinkLedger {
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Require().NotNil(leaderboard.Session)
+
+	records, err := s.ledgerRepo.GetDrinkRecordsForSession(s.ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: leaderboard.Session.ID,
+		GuildID:   leaderboard.Session.GuildID,
+	})
+	s.Require().NoError(err)
+	return records.Records
+}
+
+func (s *DesignatedDriverTestSuite) TestDriverDrinksAreOwedLater() {
+	_, err := s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		PlayerID:   "alice",
+		PlayerName: "Alice",
+	})
+	s.Require().NoError(err)
+
+	gameID := s.playGame([]string{"alice", "bob"}, []int{2, 4})
+
+	records := s.sessionRecords()
+	s.Require().Len(records, 1)
+	s.Equal("alice", records[0].ToPlayerID)
+	s.True(records[0].OwedLater)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal(1, leaderboard.Entries[0].DrinkCount)
+	s.True(leaderboard.Entries[0].DesignatedDriver)
+
+	// The drink can't be paid while driving
+	_, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Error(err)
+
+	clearOutput, err := s.gameService.ClearDesignatedDriver(s.ctx, &ClearDesignatedDriverInput{
+		ChannelID: s.testChannelID,
+		PlayerID:  "alice",
+	})
+	s.Require().NoError(err)
+	s.Equal(1, clearOutput.DrinksOwed)
+
+	_, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.NoError(err)
+}
+
+func (s *DesignatedDriverTestSuite) TestDriverLosingARollOffOwesLater() {
+	_, err := s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		PlayerID:   "alice",
+		PlayerName: "Alice",
+	})
+	s.Require().NoError(err)
+
+	// alice and bob tie for lowest, so they roll off
+	gameID := s.playGame([]string{"alice", "bob", "carol"}, []int{2, 2, 4})
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	for playerID, value := range map[string]int{"alice": 1, "bob": 3} {
+		_, err := s.gameRepo.RecordRoll(s.ctx, &gameRepo.RecordRollInput{
+			GameID:    rollOffGameID,
+			PlayerID:  playerID,
+			RollValue: value,
+			RollTime:  time.Now(),
+			Status:    models.ParticipantStatusActive,
+		})
+		s.Require().NoError(err)
+	}
+
+	output, err := s.gameService.HandleRollOff(s.ctx, &HandleRollOffInput{
+		ParentGameID:  gameID,
+		RollOffGameID: rollOffGameID,
+		PlayerIDs:     []string{"alice", "bob"},
+		Type:          RollOffTypeLowest,
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"alice"}, output.WinnerPlayerIDs)
+
+	// alice's drink from the roll-off is in the session, and she's only owing it while she drives
+	records := s.sessionRecords()
+	s.Require().Len(records, 1)
+	s.Equal("alice", records[0].ToPlayerID)
+	s.Equal(models.DrinkReasonLowestRoll, records[0].Reason)
+	s.True(records[0].OwedLater)
+	s.False(records[0].Timestamp.IsZero())
+}
+
+func (s *DesignatedDriverTestSuite) TestSubstituteTakesDriverDrinks() {
+	_, err := s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		PlayerID:   "alice",
+		PlayerName: "Alice",
+	})
+	s.Require().NoError(err)
+
+	coverOutput, err := s.gameService.CoverDesignatedDriver(s.ctx, &CoverDesignatedDriverInput{
+		ChannelID:      s.testChannelID,
+		DriverID:       "alice",
+		SubstituteID:   "carol",
+		SubstituteName: "Carol",
+	})
+	s.Require().NoError(err)
+	s.Equal("Alice", coverOutput.Driver.PlayerName)
+
+	s.playGame([]string{"alice", "bob"}, []int{2, 4})
+
+	records := s.sessionRecords()
+	s.Require().Len(records, 1)
+	s.Equal("carol", records[0].ToPlayerID)
+	s.Equal("alice", records[0].CoveredFor)
+	s.False(records[0].OwedLater)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 2)
+	for _, entry := range leaderboard.Entries {
+		switch entry.PlayerID {
+		case "alice":
+			s.Equal(0, entry.DrinkCount)
+			s.True(entry.DesignatedDriver)
+		case "carol":
+			s.Equal(1, entry.DrinkCount)
+			s.False(entry.DesignatedDriver)
+		default:
+			s.Failf("unexpected leaderboard entry", "player %s", entry.PlayerID)
+		}
+	}
+}
+
+func (s *DesignatedDriverTestSuite) TestRoleErrors() {
+	_, err := s.gameService.ClearDesignatedDriver(s.ctx, &ClearDesignatedDriverInput{ChannelID: s.testChannelID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrNotDesignatedDriver)
+
+	_, err = s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{ChannelID: s.testChannelID, PlayerID: "alice", PlayerName: "Alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{ChannelID: s.testChannelID, PlayerID: "alice", PlayerName: "Alice"})
+	s.ErrorIs(err, ErrAlreadyDesignatedDriver)
+
+	_, err = s.gameService.CoverDesignatedDriver(s.ctx, &CoverDesignatedDriverInput{ChannelID: s.testChannelID, DriverID: "bob", SubstituteID: "carol"})
+	s.ErrorIs(err, ErrNotDesignatedDriver)
+
+	_, err = s.gameService.CoverDesignatedDriver(s.ctx, &CoverDesignatedDriverInput{ChannelID: s.testChannelID, DriverID: "alice", SubstituteID: "carol", SubstituteName: "Carol"})
+	s.Require().NoError(err)
+
+	// Carol is covering for Alice, so she has to keep drinking
+	_, err = s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{ChannelID: s.testChannelID, PlayerID: "carol", PlayerName: "Carol"})
+	s.ErrorIs(err, ErrCoveringForDriver)
+
+	_, err = s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{ChannelID: s.testChannelID, PlayerID: "bob", PlayerName: "Bob"})
+	s.Require().NoError(err)
+
+	// A designated driver can't drink for someone else
+	_, err = s.gameService.CoverDesignatedDriver(s.ctx, &CoverDesignatedDriverInput{ChannelID: s.testChannelID, DriverID: "alice", SubstituteID: "bob"})
+	s.ErrorIs(err, ErrInvalidSubstitute)
+}
@@ -0,0 +1,219 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// CheckDrinkReminders reminds the players who've owed drinks longer than the reminder delay, at most once per delay.
+// Drinks owed later by designated drivers and drinks in closed sessions can't be paid, so nobody is reminded of them.
+func (s *service) CheckDrinkReminders(ctx context.Context, input *CheckDrinkRemindersInput) (*CheckDrinkRemindersOutput, error) {
+	output := &CheckDrinkRemindersOutput{}
+	if s.drinkReminderAfter == 0 {
+		return output, nil
+	}
+
+	recordsOutput, err := s.drinkLedgerRepo.ListDrinkRecords(ctx, &ledgerRepo.ListDrinkRecordsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drink records: %w", err)
+	}
+
+	check := &ledgerCheck{
+		service:  s,
+		sessions: make(map[string]*models.Session),
+	}
+
+	now := s.clock.Now()
+	overdue := make(map[string][]*models.DrinkLedger)
+	for _, record := range recordsOutput.Records {
+		if !record.IsOutstanding() || record.OwedLater || record.Archived || record.ToPlayerID == "" {
+			continue
+		}
+		if now.Sub(record.Timestamp) < s.drinkReminderAfter {
+			continue
+		}
+
+		if record.SessionID != "" {
+			session, err := check.session(ctx, record.SessionID)
+			if err != nil {
+				return nil, err
+			}
+			if session == nil || !session.Active || session.IsPaused() {
+				continue
+			}
+		}
+
+		overdue[record.ToPlayerID] = append(overdue[record.ToPlayerID], record)
+	}
+
+	playerIDs := make([]string, 0, len(overdue))
+	for playerID := range overdue {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	for _, playerID := range playerIDs {
+		reminded, err := s.remindPlayer(ctx, playerID, overdue[playerID], now)
+		if err != nil {
+			log.Printf("Error reminding player %s of their drinks: %v", playerID, err)
+			continue
+		}
+		if reminded {
+			output.RemindedPlayerIDs = append(output.RemindedPlayerIDs, playerID)
+		}
+	}
+
+	return output, nil
+}
+
+// remindPlayer reminds a player of the drinks they've owed too long, unless they've muted reminders or were
+// reminded within the delay, reporting whether they were reminded. Reminders escalate with every delay the
+// oldest drink has gone unpaid.
+func (s *service) remindPlayer(ctx context.Context, playerID string, drinks []*models.DrinkLedger, now time.Time) (bool, error) {
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	reminders := player.DrinkReminders
+	if reminders == nil {
+		reminders = &models.DrinkReminders{}
+	}
+	if reminders.Muted {
+		return false, nil
+	}
+	if !reminders.LastRemindedAt.IsZero() && now.Sub(reminders.LastRemindedAt) < s.drinkReminderAfter {
+		return false, nil
+	}
+
+	sort.Slice(drinks, func(i, j int) bool {
+		return drinks[i].Timestamp.Before(drinks[j].Timestamp)
+	})
+
+	// The channel the latest drink was given in, skipping games that are gone
+	var game *models.Game
+	for i := len(drinks) - 1; i >= 0 && game == nil; i-- {
+		game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: drinks[i].GameID,
+		})
+		if err != nil && !errors.Is(err, gameRepo.ErrGameNotFound) {
+			return false, fmt.Errorf("failed to get game: %w", err)
+		}
+	}
+	if game == nil {
+		return false, nil
+	}
+
+	reminders.LastRemindedAt = now
+	player.DrinkReminders = reminders
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return false, fmt.Errorf("failed to save player: %w", err)
+	}
+
+	oldest := drinks[0].Timestamp
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeDrinkReminder,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: now,
+		Payload: &events.DrinkReminderPayload{
+			PlayerID:      player.ID,
+			PlayerName:    player.Name,
+			DrinkCount:    len(drinks),
+			OldestDrinkAt: oldest,
+			Reminder:      int(now.Sub(oldest) / s.drinkReminderAfter),
+			Delivery:      string(reminders.DeliveryOrDefault()),
+		},
+	})
+
+	return true, nil
+}
+
+// SetDrinkReminders changes how a player is reminded of drinks they owe, creating the player if they haven't played yet
+func (s *service) SetDrinkReminders(ctx context.Context, input *SetDrinkRemindersInput) (*SetDrinkRemindersOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	if input.Delivery != "" && !input.Delivery.IsValid() {
+		return nil, ErrInvalidReminderDelivery
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, fmt.Errorf("failed to get player: %w", err)
+		}
+		player = &models.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		}
+	}
+
+	if player.DrinkReminders == nil {
+		player.DrinkReminders = &models.DrinkReminders{}
+	}
+	if input.Delivery != "" {
+		player.DrinkReminders.Delivery = input.Delivery
+	}
+	if input.Muted != nil {
+		player.DrinkReminders.Muted = *input.Muted
+	}
+
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save player: %w", err)
+	}
+
+	return &SetDrinkRemindersOutput{
+		Reminders: player.DrinkReminders,
+	}, nil
+}
+
+// GetDrinkReminders returns how a player is reminded of drinks they owe
+func (s *service) GetDrinkReminders(ctx context.Context, input *GetDrinkRemindersInput) (*GetDrinkRemindersOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	output := &GetDrinkRemindersOutput{
+		Reminders: &models.DrinkReminders{},
+		After:     s.drinkReminderAfter,
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return output, nil
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	if player.DrinkReminders != nil {
+		output.Reminders = player.DrinkReminders
+	}
+	return output, nil
+}
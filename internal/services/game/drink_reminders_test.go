@@ -0,0 +1,138 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// DrinkReminderTestSuite tests drink reminders against the real Redis repositories
+type DrinkReminderTestSuite struct {
+	serviceHarness
+	clock     *clock.Fake
+	reminders []*events.DrinkReminderPayload
+
+	testGameID string
+}
+
+func (s *DrinkReminderTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC))
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+		cfg.DrinkReminderAfter = time.Hour
+	})
+
+	s.reminders = nil
+	s.eventBus.Subscribe(events.TypeDrinkReminder, func(_ context.Context, event *events.Event) {
+		s.reminders = append(s.reminders, event.Payload.(*events.DrinkReminderPayload))
+	})
+
+	s.testGameID = "reminder-game"
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{
+		Game: &models.Game{ID: s.testGameID, ChannelID: "reminder-channel", GuildID: "reminder-guild", Status: models.GameStatusCompleted},
+	}))
+	for _, playerID := range []string{"alice", "bob"} {
+		s.Require().NoError(s.playerRepo.SavePlayer(s.ctx, &playerRepo.SavePlayerInput{
+			Player: &models.Player{ID: playerID, Name: playerID},
+		}))
+	}
+}
+
+func TestDrinkReminderTestSuite(t *testing.T) {
+	suite.Run(t, new(DrinkReminderTestSuite))
+}
+
+// giveDrink records a drink for the player given the time ago, changed by edit
+func (s *DrinkReminderTestSuite) giveDrink(id, playerID string, ago time.Duration, edit func(*models.DrinkLedger)) {
+	record := &models.DrinkLedger{
+		ID:         id,
+		ToPlayerID: playerID,
+		GameID:     s.testGameID,
+		Timestamp:  s.clock.Now().Add(-ago),
+	}
+	if edit != nil {
+		edit(record)
+	}
+	s.Require().NoError(s.ledgerRepo.AddDrinkRecord(s.ctx, &ledgerRepo.AddDrinkRecordInput{Record: record}))
+}
+
+func (s *DrinkReminderTestSuite) check() []string {
+	output, err := s.gameService.CheckDrinkReminders(s.ctx, &CheckDrinkRemindersInput{})
+	s.Require().NoError(err)
+	return output.RemindedPlayerIDs
+}
+
+func (s *DrinkReminderTestSuite) TestCheckDrinkReminders() {
+	s.giveDrink("old", "alice", 90*time.Minute, nil)
+	s.giveDrink("older", "alice", 2*time.Hour+30*time.Minute, nil)
+	s.giveDrink("fresh", "bob", 10*time.Minute, nil)
+	s.giveDrink("paid", "bob", 3*time.Hour, func(d *models.DrinkLedger) { d.Paid = true })
+	s.giveDrink("driver", "bob", 3*time.Hour, func(d *models.DrinkLedger) { d.OwedLater = true })
+
+	s.Equal([]string{"alice"}, s.check())
+	s.Require().Len(s.reminders, 1)
+	s.Equal("alice", s.reminders[0].PlayerID)
+	s.Equal(2, s.reminders[0].DrinkCount)
+	s.Equal(2, s.reminders[0].Reminder, "the oldest drink has been owed for two reminder delays")
+	s.Equal(string(models.ReminderDeliveryChannel), s.reminders[0].Delivery)
+
+	// Nobody is reminded again until another delay has passed
+	s.Empty(s.check())
+
+	s.clock.Advance(time.Hour)
+	s.Equal([]string{"alice", "bob"}, s.check())
+	s.Equal(3, s.reminders[1].Reminder)
+	s.Equal(1, s.reminders[2].Reminder)
+}
+
+func (s *DrinkReminderTestSuite) TestCheckDrinkReminders_Muted() {
+	s.giveDrink("old", "alice", 2*time.Hour, nil)
+
+	muted := true
+	_, err := s.gameService.SetDrinkReminders(s.ctx, &SetDrinkRemindersInput{PlayerID: "alice", Muted: &muted})
+	s.Require().NoError(err)
+	s.Empty(s.check())
+
+	muted = false
+	_, err = s.gameService.SetDrinkReminders(s.ctx, &SetDrinkRemindersInput{PlayerID: "alice", Muted: &muted, Delivery: models.ReminderDeliveryDM})
+	s.Require().NoError(err)
+	s.Equal([]string{"alice"}, s.check())
+	s.Equal(string(models.ReminderDeliveryDM), s.reminders[0].Delivery)
+}
+
+func (s *DrinkReminderTestSuite) TestCheckDrinkReminders_ClosedSession() {
+	session, err := s.ledgerRepo.CreateSession(s.ctx, &ledgerRepo.CreateSessionInput{GuildID: "reminder-guild"})
+	s.Require().NoError(err)
+	session.Session.Active = false
+	s.Require().NoError(s.ledgerRepo.UpdateSession(s.ctx, &ledgerRepo.UpdateSessionInput{Session: session.Session}))
+
+	s.giveDrink("old", "alice", 2*time.Hour, func(d *models.DrinkLedger) { d.SessionID = session.Session.ID })
+	s.Empty(s.check())
+}
+
+func (s *DrinkReminderTestSuite) TestSetDrinkReminders() {
+	output, err := s.gameService.SetDrinkReminders(s.ctx, &SetDrinkRemindersInput{
+		PlayerID:   "carol",
+		PlayerName: "Carol",
+		Delivery:   models.ReminderDeliveryDM,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.ReminderDeliveryDM, output.Reminders.Delivery)
+	s.False(output.Reminders.Muted)
+
+	getOutput, err := s.gameService.GetDrinkReminders(s.ctx, &GetDrinkRemindersInput{PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Equal(models.ReminderDeliveryDM, getOutput.Reminders.DeliveryOrDefault())
+	s.Equal(time.Hour, getOutput.After)
+
+	_, err = s.gameService.SetDrinkReminders(s.ctx, &SetDrinkRemindersInput{PlayerID: "carol", Delivery: "pigeon"})
+	s.ErrorIs(err, ErrInvalidReminderDelivery)
+}
@@ -1,5 +1,7 @@
 package game
 
+import "github.com/KirkDiggler/ronnied/internal/models"
+
 // GameError is a custom error type for game-related errors
 type GameError string
 
@@ -8,6 +10,14 @@ func (e GameError) Error() string {
 	return string(e)
 }
 
+// Code returns the error code classifying this error
+func (e GameError) Code() models.ErrorCode {
+	if code, ok := errorCodes[e]; ok {
+		return code
+	}
+	return models.ErrorCodeUnknown
+}
+
 // Define errors
 const (
 	ErrGameNotFound        GameError = "game not found"
@@ -25,15 +35,171 @@ const (
 	ErrNilDiceRoller       GameError = "dice roller cannot be nil"
 	ErrNilClock            GameError = "clock cannot be nil"
 	ErrNilUUIDGenerator    GameError = "UUID generator cannot be nil"
-	
+
 	// More specific game state errors
-	ErrGameActive          GameError = "game is already active"
-	ErrGameRollOff         GameError = "game is in roll-off state"
-	ErrGameCompleted       GameError = "game is already completed"
-	ErrPlayerAlreadyRolled GameError = "player already rolled"
-	ErrNotEnoughPlayers    GameError = "not enough players"
-	ErrInvalidRollOffType  GameError = "invalid roll-off type"
-	ErrInvalidDrinkReason  GameError = "invalid drink reason"
-	ErrNotCreator          GameError = "not creator"
-	ErrPlayerInRollOff     GameError = "player should be rolling in a roll-off game"
+	ErrGameActive              GameError = "game is already active"
+	ErrGameRollOff             GameError = "game is in roll-off state"
+	ErrGameCompleted           GameError = "game is already completed"
+	ErrGameAbandoned           GameError = "game was abandoned"
+	ErrGameNotStarted          GameError = "game has not started yet"
+	ErrPlayerAlreadyRolled     GameError = "player already rolled"
+	ErrNotEnoughPlayers        GameError = "not enough players"
+	ErrInvalidRollOffType      GameError = "invalid roll-off type"
+	ErrInvalidDrinkReason      GameError = "invalid drink reason"
+	ErrNotCreator              GameError = "not creator"
+	ErrPlayerInRollOff         GameError = "player should be rolling in a roll-off game"
+	ErrNotEligibleToAssign     GameError = "player is not eligible to assign a drink"
+	ErrTargetNotInGame         GameError = "target player is not in the game"
+	ErrPlayersStillRolling     GameError = "not all players have rolled yet"
+	ErrPendingDrinkAssignments GameError = "some players still need to assign drinks"
+	ErrInvalidGameRules        GameError = "invalid game rules"
+	ErrAlreadyDesignatedDriver GameError = "player is already the designated driver"
+	ErrNotDesignatedDriver     GameError = "player is not a designated driver"
+	ErrCoveringForDriver       GameError = "player is covering for a designated driver"
+	ErrInvalidSubstitute       GameError = "substitute cannot be a designated driver"
+	ErrPacingCooldown          GameError = "player is cooling down after paying drinks too quickly"
+	ErrCannotMergeSelf         GameError = "cannot merge a player into themselves"
+	ErrPlayerAlreadyMerged     GameError = "player has already been merged into another player"
+	ErrMergePlayerInGame       GameError = "player is in a game that hasn't finished"
+	ErrNoRerollTokens          GameError = "player has no re-roll tokens"
+	ErrRerollNotAllowed        GameError = "roll cannot be re-rolled"
+	ErrInvalidRollModifier     GameError = "invalid roll modifier"
+	ErrSessionNotFound         GameError = "session not found"
+	ErrForgetPlayerInGame      GameError = "player can't be forgotten until their game is over"
+	ErrNoDrinksToForgive       GameError = "player has no outstanding drinks to forgive"
+	ErrNoDrinksToTransfer      GameError = "player has no drinks to transfer"
+	ErrCannotTransferToSelf    GameError = "cannot transfer a drink to yourself"
+	ErrDrinkTransferNotFound   GameError = "drink transfer not found or expired"
+	ErrNotTransferTarget       GameError = "player can't answer this drink transfer"
+	ErrLastCallAlreadyCalled   GameError = "last call has already been called this session"
+	ErrLastCallGameStarted     GameError = "it's last call and the final game has already started"
+	ErrRollLogDisabled         GameError = "rolls aren't being logged"
+	ErrCannotChallengeSelf     GameError = "cannot challenge yourself"
+	ErrInvalidChallengeCall    GameError = "challenge call must be odds or evens"
+	ErrChallengeNotFound       GameError = "challenge not found or expired"
+	ErrNotChallengeTarget      GameError = "player can't answer this challenge"
+	ErrCannotBetOnSelf         GameError = "cannot bet on your own roll"
+	ErrInvalidSideBet          GameError = "side bet must be on a critical hit, critical fail, over or under"
+	ErrSideBetAlreadyPlaced    GameError = "player already has a bet on this roll"
+	ErrNotInvited              GameError = "player isn't invited to this private game"
+	ErrGameNotPrivate          GameError = "game isn't private"
+	ErrMomentsDisabled         GameError = "moments aren't being kept"
+	ErrNothingToClip           GameError = "there's no roll to clip yet"
+	ErrAlreadyClipped          GameError = "that roll has already been clipped"
+	ErrInvalidBirthday         GameError = "that's not a day of the year"
+	ErrMustTargetBirthday      GameError = "critical hits go to the birthday player today"
+	ErrInvalidReminderDelivery GameError = "reminders are sent in the channel or by DM"
+	ErrGamePaused              GameError = "game is paused"
+	ErrGameNotPaused           GameError = "game isn't paused"
+	ErrInvalidVoteAction       GameError = "players vote to start or abandon a game"
+	ErrVoteInProgress          GameError = "a vote is already being held in this game"
+	ErrNoVote                  GameError = "there's no vote being held in this game"
+	ErrAlreadyVoted            GameError = "player already voted"
+	ErrGameNotFull             GameError = "game isn't full, join it instead"
+	ErrCreatorCannotLeave      GameError = "the creator can't leave their own game"
+	ErrSessionPaused           GameError = "session is paused for a break"
+	ErrSessionNotPaused        GameError = "session isn't paused"
+	ErrGuestDrinksOff          GameError = "drinks can't be given to guests in this game"
+	ErrGuestIsPlaying          GameError = "guest is playing in the game, assign them a drink instead"
+	ErrGuestDrinkNotFound      GameError = "guest drink not found or already answered"
+	ErrNotGuest                GameError = "player can't answer this guest drink"
+	ErrInvalidSessionGoal      GameError = "unknown session challenge"
+	ErrInvalidRounds           GameError = "games are played over 2 to 10 rounds"
+	ErrCountdownRounds         GameError = "countdown games can't be played over rounds"
+	ErrNotYourTurn             GameError = "it's another player's turn to roll"
+	ErrInvalidHotSeatName      GameError = "hot seat players need a name of up to 32 characters"
+	ErrNotHotSeatHost          GameError = "only the player running a hot seat can roll for its players"
+	ErrNoHotSeatRolls          GameError = "none of your hot seat players have a roll to make"
 )
+
+// errorCodes maps each game error to its code
+var errorCodes = map[GameError]models.ErrorCode{
+	ErrGameNotFound:            models.ErrorCodeGameNotFound,
+	ErrRollOffGameNotFound:     models.ErrorCodeGameNotFound,
+	ErrPlayerNotFound:          models.ErrorCodePlayerNotFound,
+	ErrPlayerAlreadyInGame:     models.ErrorCodeAlreadyJoined,
+	ErrGameAlreadyExists:       models.ErrorCodeGameExists,
+	ErrInvalidGameState:        models.ErrorCodeInvalidGameState,
+	ErrPlayerNotInGame:         models.ErrorCodeNotInGame,
+	ErrTargetNotInGame:         models.ErrorCodeNotInGame,
+	ErrGameFull:                models.ErrorCodeGameFull,
+	ErrNilConfig:               models.ErrorCodeConfig,
+	ErrNilGameRepo:             models.ErrorCodeConfig,
+	ErrNilPlayerRepo:           models.ErrorCodeConfig,
+	ErrNilDrinkLedgerRepo:      models.ErrorCodeConfig,
+	ErrNilDiceRoller:           models.ErrorCodeConfig,
+	ErrNilClock:                models.ErrorCodeConfig,
+	ErrNilUUIDGenerator:        models.ErrorCodeConfig,
+	ErrGameActive:              models.ErrorCodeGameActive,
+	ErrGameRollOff:             models.ErrorCodeGameRollOff,
+	ErrGameCompleted:           models.ErrorCodeGameCompleted,
+	ErrGameAbandoned:           models.ErrorCodeGameCompleted,
+	ErrGameNotStarted:          models.ErrorCodeGameNotStarted,
+	ErrPlayerAlreadyRolled:     models.ErrorCodeAlreadyRolled,
+	ErrNotEnoughPlayers:        models.ErrorCodeNotEnoughPlayers,
+	ErrInvalidRollOffType:      models.ErrorCodeInvalidInput,
+	ErrInvalidDrinkReason:      models.ErrorCodeInvalidInput,
+	ErrNotCreator:              models.ErrorCodeNotCreator,
+	ErrPlayerInRollOff:         models.ErrorCodeInRollOff,
+	ErrNotEligibleToAssign:     models.ErrorCodeNotEligible,
+	ErrPlayersStillRolling:     models.ErrorCodeRoundInProgress,
+	ErrPendingDrinkAssignments: models.ErrorCodeRoundInProgress,
+	ErrInvalidGameRules:        models.ErrorCodeInvalidInput,
+	ErrAlreadyDesignatedDriver: models.ErrorCodeInvalidInput,
+	ErrNotDesignatedDriver:     models.ErrorCodeInvalidInput,
+	ErrCoveringForDriver:       models.ErrorCodeInvalidInput,
+	ErrInvalidSubstitute:       models.ErrorCodeInvalidInput,
+	ErrPacingCooldown:          models.ErrorCodeCoolingDown,
+	ErrCannotMergeSelf:         models.ErrorCodeInvalidInput,
+	ErrPlayerAlreadyMerged:     models.ErrorCodeInvalidInput,
+	ErrMergePlayerInGame:       models.ErrorCodeInvalidGameState,
+	ErrNoRerollTokens:          models.ErrorCodeNoRerollTokens,
+	ErrRerollNotAllowed:        models.ErrorCodeRerollNotAllowed,
+	ErrInvalidRollModifier:     models.ErrorCodeInvalidInput,
+	ErrSessionNotFound:         models.ErrorCodeInvalidInput,
+	ErrForgetPlayerInGame:      models.ErrorCodeInvalidGameState,
+	ErrNoDrinksToForgive:       models.ErrorCodeInvalidInput,
+	ErrNoDrinksToTransfer:      models.ErrorCodeInvalidInput,
+	ErrCannotTransferToSelf:    models.ErrorCodeInvalidInput,
+	ErrDrinkTransferNotFound:   models.ErrorCodeInvalidInput,
+	ErrNotTransferTarget:       models.ErrorCodeInvalidInput,
+	ErrLastCallAlreadyCalled:   models.ErrorCodeInvalidInput,
+	ErrLastCallGameStarted:     models.ErrorCodeInvalidGameState,
+	ErrRollLogDisabled:         models.ErrorCodeConfig,
+	ErrCannotChallengeSelf:     models.ErrorCodeInvalidInput,
+	ErrInvalidChallengeCall:    models.ErrorCodeInvalidInput,
+	ErrChallengeNotFound:       models.ErrorCodeInvalidInput,
+	ErrNotChallengeTarget:      models.ErrorCodeInvalidInput,
+	ErrCannotBetOnSelf:         models.ErrorCodeInvalidInput,
+	ErrInvalidSideBet:          models.ErrorCodeInvalidInput,
+	ErrSideBetAlreadyPlaced:    models.ErrorCodeInvalidInput,
+	ErrNotInvited:              models.ErrorCodeNotInvited,
+	ErrGameNotPrivate:          models.ErrorCodeInvalidGameState,
+	ErrMomentsDisabled:         models.ErrorCodeConfig,
+	ErrNothingToClip:           models.ErrorCodeInvalidInput,
+	ErrAlreadyClipped:          models.ErrorCodeInvalidInput,
+	ErrInvalidBirthday:         models.ErrorCodeInvalidInput,
+	ErrMustTargetBirthday:      models.ErrorCodeNotEligible,
+	ErrInvalidReminderDelivery: models.ErrorCodeInvalidInput,
+	ErrGamePaused:              models.ErrorCodeGamePaused,
+	ErrGameNotPaused:           models.ErrorCodeInvalidGameState,
+	ErrInvalidVoteAction:       models.ErrorCodeInvalidInput,
+	ErrVoteInProgress:          models.ErrorCodeInvalidGameState,
+	ErrNoVote:                  models.ErrorCodeInvalidGameState,
+	ErrAlreadyVoted:            models.ErrorCodeInvalidInput,
+	ErrGameNotFull:             models.ErrorCodeInvalidInput,
+	ErrCreatorCannotLeave:      models.ErrorCodeInvalidInput,
+	ErrSessionPaused:           models.ErrorCodeSessionPaused,
+	ErrSessionNotPaused:        models.ErrorCodeInvalidInput,
+	ErrGuestDrinksOff:          models.ErrorCodeConfig,
+	ErrGuestIsPlaying:          models.ErrorCodeInvalidInput,
+	ErrGuestDrinkNotFound:      models.ErrorCodeInvalidInput,
+	ErrNotGuest:                models.ErrorCodeInvalidInput,
+	ErrInvalidSessionGoal:      models.ErrorCodeInvalidInput,
+	ErrInvalidRounds:           models.ErrorCodeInvalidInput,
+	ErrCountdownRounds:         models.ErrorCodeInvalidInput,
+	ErrNotYourTurn:             models.ErrorCodeNotYourTurn,
+	ErrInvalidHotSeatName:      models.ErrorCodeInvalidInput,
+	ErrNotHotSeatHost:          models.ErrorCodeNotEligible,
+	ErrNoHotSeatRolls:          models.ErrorCodeInvalidGameState,
+}
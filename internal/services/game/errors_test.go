@@ -0,0 +1,38 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s *ErrorsTestSuite) TestCodeOf_GameError() {
+	s.Equal(models.ErrorCodeAlreadyRolled, models.ErrorCodeOf(ErrPlayerAlreadyRolled))
+	s.Equal(models.ErrorCodeGameNotFound, models.ErrorCodeOf(ErrRollOffGameNotFound))
+	s.Equal(models.ErrorCodeConfig, models.ErrorCodeOf(ErrNilClock))
+	s.Equal(models.ErrorCodeGamePaused, models.ErrorCodeOf(ErrGamePaused))
+	s.Equal(models.ErrorCodeSessionPaused, models.ErrorCodeOf(ErrSessionPaused))
+}
+
+func (s *ErrorsTestSuite) TestCodeOf_WrappedError() {
+	err := fmt.Errorf("failed to roll: %w", ErrGameCompleted)
+
+	s.Equal(models.ErrorCodeGameCompleted, models.ErrorCodeOf(err))
+}
+
+func (s *ErrorsTestSuite) TestCodeOf_UnknownError() {
+	s.Equal(models.ErrorCodeUnknown, models.ErrorCodeOf(errors.New("redis is down")))
+	s.Equal(models.ErrorCodeUnknown, models.ErrorCodeOf(nil))
+	s.Equal(models.ErrorCodeUnknown, GameError("something new").Code())
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+)
+
+// fairnessMinDrinks is how many drinks each of two players has to have chosen who takes before the report reads
+// anything into who they give them to
+const fairnessMinDrinks = 3
+
+// GetFairnessReport works out who has been giving the drinks they chose to whom in the channel's current session,
+// so groups can see who gives drinks back and call out players who never give them to each other
+func (s *service) GetFairnessReport(ctx context.Context, input *GetFairnessReportInput) (*GetFairnessReportOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	// Like leaderboards, reports are read from a replica if there is one
+	ctx = replica.WithReplicaReads(ctx)
+
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil || sessionOutput.Session == nil {
+		return &GetFairnessReportOutput{}, nil
+	}
+	session := sessionOutput.Session
+
+	records, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drink records: %w", err)
+	}
+
+	players, pairs := buildFairnessReport(records.Records, s.playerNames(ctx, nil))
+
+	return &GetFairnessReportOutput{
+		Session: session,
+		Players: players,
+		Pairs:   pairs,
+	}, nil
+}
+
+// buildFairnessReport lists everyone who chose who takes a drink or was chosen, most drinks given first, and every
+// two of them who have given each other drinks or could be in an alliance
+func buildFairnessReport(records []*models.DrinkLedger, playerName func(playerID string) string) ([]*FairnessPlayer, []*FairnessPair) {
+	karmas := tallyKarma(records)
+
+	// Guests watching the game aren't saved as players, their drinks carry their names
+	guestNames := make(map[string]string)
+	for _, record := range records {
+		if record.IsGuest() {
+			guestNames[record.ToPlayerID] = record.GuestName
+		}
+	}
+
+	playerMap := make(map[string]*FairnessPlayer)
+	player := func(playerID string) *FairnessPlayer {
+		if p, ok := playerMap[playerID]; ok {
+			return p
+		}
+		name, ok := guestNames[playerID]
+		if !ok {
+			name = playerName(playerID)
+		}
+		p := &FairnessPlayer{PlayerID: playerID, PlayerName: name, Targets: make(map[string]int)}
+		playerMap[playerID] = p
+		return p
+	}
+
+	for fromPlayerID, given := range karmas {
+		from := player(fromPlayerID)
+		from.Karma = given.score()
+		for toPlayerID, count := range given {
+			from.Targets[toPlayerID] = count
+			from.Given += count
+			player(toPlayerID).Received += count
+		}
+	}
+
+	players := make([]*FairnessPlayer, 0, len(playerMap))
+	for _, p := range playerMap {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Given != players[j].Given {
+			return players[i].Given > players[j].Given
+		}
+		return players[i].PlayerName < players[j].PlayerName
+	})
+
+	var pairs []*FairnessPair
+	for i, first := range players {
+		for _, second := range players[i+1:] {
+			pair := &FairnessPair{
+				FirstPlayerID:  first.PlayerID,
+				SecondPlayerID: second.PlayerID,
+				FirstToSecond:  first.Targets[second.PlayerID],
+				SecondToFirst:  second.Targets[first.PlayerID],
+			}
+			pair.Pattern = fairnessPattern(first, second, pair)
+			if pair.FirstToSecond+pair.SecondToFirst > 0 || pair.Pattern != "" {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	return players, pairs
+}
+
+// Reciprocity is how evenly the two players have given each other drinks, 1 when it's drink for drink and 0 when
+// only one of them has given any
+func (p *FairnessPair) Reciprocity() float64 {
+	most := max(p.FirstToSecond, p.SecondToFirst)
+	if most == 0 {
+		return 0
+	}
+	return float64(min(p.FirstToSecond, p.SecondToFirst)) / float64(most)
+}
+
+// fairnessPattern reads what two players giving each other drinks says about them. Players who have both given
+// enough drinks but never to each other look like an alliance, and players who give each other at least half of
+// their drinks look like they're feuding.
+func fairnessPattern(first, second *FairnessPlayer, pair *FairnessPair) FairnessPattern {
+	if first.Given < fairnessMinDrinks || second.Given < fairnessMinDrinks {
+		return ""
+	}
+	if pair.FirstToSecond == 0 && pair.SecondToFirst == 0 {
+		return FairnessPatternAlliance
+	}
+	if 2*pair.FirstToSecond >= first.Given && 2*pair.SecondToFirst >= second.Given {
+		return FairnessPatternFeud
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chosenDrinks returns count critical hit drinks from one player to another
+func chosenDrinks(from, to string, count int) []*models.DrinkLedger {
+	records := make([]*models.DrinkLedger, count)
+	for i := range records {
+		records[i] = &models.DrinkLedger{FromPlayerID: from, ToPlayerID: to, Reason: models.DrinkReasonCriticalHit}
+	}
+	return records
+}
+
+func pairOf(pairs []*FairnessPair, a, b string) *FairnessPair {
+	for _, pair := range pairs {
+		if (pair.FirstPlayerID == a && pair.SecondPlayerID == b) || (pair.FirstPlayerID == b && pair.SecondPlayerID == a) {
+			return pair
+		}
+	}
+	return nil
+}
+
+func TestBuildFairnessReport(t *testing.T) {
+	var records []*models.DrinkLedger
+	// alice and bob only ever give their drinks to carol
+	records = append(records, chosenDrinks("alice", "carol", 3)...)
+	records = append(records, chosenDrinks("bob", "carol", 4)...)
+	// carol and dave keep giving theirs to each other
+	records = append(records, chosenDrinks("carol", "dave", 3)...)
+	records = append(records, chosenDrinks("dave", "carol", 2)...)
+	records = append(records, chosenDrinks("dave", "alice", 1)...)
+	// Drinks nobody chose don't count
+	records = append(records, &models.DrinkLedger{ToPlayerID: "alice", Reason: models.DrinkReasonLowestRoll})
+	records = append(records, &models.DrinkLedger{FromPlayerID: "erin", ToPlayerID: "watcher", GuestName: "Watcher", Reason: models.DrinkReasonCriticalHit})
+
+	players, pairs := buildFairnessReport(records, func(playerID string) string { return playerID })
+
+	require.Len(t, players, 6)
+	assert.Equal(t, "bob", players[0].PlayerID, "most drinks given first")
+	assert.Equal(t, 4, players[0].Given)
+
+	names := make(map[string]*FairnessPlayer)
+	for _, player := range players {
+		names[player.PlayerID] = player
+	}
+	assert.Equal(t, "Watcher", names["watcher"].PlayerName)
+	assert.Equal(t, 9, names["carol"].Received)
+	assert.Equal(t, 1, names["alice"].Received, "the lowest roll drink isn't counted")
+	assert.Equal(t, -3, names["bob"].Karma)
+
+	alliance := pairOf(pairs, "alice", "bob")
+	require.NotNil(t, alliance)
+	assert.Equal(t, FairnessPatternAlliance, alliance.Pattern)
+
+	feud := pairOf(pairs, "carol", "dave")
+	require.NotNil(t, feud)
+	assert.Equal(t, FairnessPatternFeud, feud.Pattern)
+	assert.InDelta(t, 2.0/3.0, feud.Reciprocity(), 0.001)
+
+	oneWay := pairOf(pairs, "alice", "carol")
+	require.NotNil(t, oneWay)
+	assert.Empty(t, oneWay.Pattern)
+	assert.Zero(t, oneWay.Reciprocity())
+
+	assert.Nil(t, pairOf(pairs, "erin", "bob"), "players who never gave each other drinks are left out")
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+)
+
+// featureEnabled returns whether a flag is on for the guild, its default when flags can't be read
+func (s *service) featureEnabled(ctx context.Context, guildID string, flag models.FeatureFlag) bool {
+	if s.featureFlagService == nil {
+		return flag.Default()
+	}
+
+	output, err := s.featureFlagService.IsEnabled(ctx, &feature_flag.IsEnabledInput{
+		GuildID: guildID,
+		Flag:    flag,
+	})
+	if err != nil {
+		// Play the game as usual rather than not at all
+		log.Printf("Error checking feature flag %s: %v", flag, err)
+		return flag.Default()
+	}
+	return output.Enabled
+}
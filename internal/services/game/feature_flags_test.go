@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	featureFlagRepo "github.com/KirkDiggler/ronnied/internal/repositories/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/stretchr/testify/suite"
+)
+
+// FeatureFlagsTestSuite tests games played with feature flags against the real Redis repositories
+type FeatureFlagsTestSuite struct {
+	serviceHarness
+	featureFlagService feature_flag.Service
+}
+
+func (s *FeatureFlagsTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		flags, err := featureFlagRepo.NewRedis(&featureFlagRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+
+		s.featureFlagService, err = feature_flag.New(&feature_flag.Config{FeatureFlagRepo: flags})
+		s.Require().NoError(err)
+		cfg.FeatureFlagService = s.featureFlagService
+	})
+	s.testGuildID = "flags-guild"
+	s.testChannelID = "flags-channel"
+}
+
+func TestFeatureFlagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagsTestSuite))
+}
+
+// drinksFor counts the drinks recorded for a player in a game
+func (s *FeatureFlagsTestSuite) drinksFor(gameID, playerID string) int {
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	count := 0
+	for _, record := range records.Records {
+		if record.ToPlayerID == playerID {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *FeatureFlagsTestSuite) TestRollOffsDisabled() {
+	_, err := s.featureFlagService.SetFeatureFlag(s.ctx, &feature_flag.SetFeatureFlagInput{
+		GuildID: s.testGuildID,
+		Flag:    models.FeatureFlagRollOffs,
+		Enabled: false,
+	})
+	s.Require().NoError(err)
+
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	// Both tied lowest rollers drink and the game is over
+	s.Equal(1, s.drinksFor(gameID, "alice"))
+	s.Equal(1, s.drinksFor(gameID, "bob"))
+
+	game, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID, GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, game.Game.Status)
+	s.Empty(game.Game.RollOffGameID)
+}
+
+func (s *FeatureFlagsTestSuite) TestRollOffsEnabledByDefault() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	game, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID, GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusRollOff, game.Game.Status)
+	s.NotEmpty(game.Game.LowestRollOffGameID)
+	s.Zero(s.drinksFor(gameID, "alice"))
+}
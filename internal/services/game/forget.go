@@ -0,0 +1,245 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// anonymousIDPrefix starts the IDs that replace forgotten players
+const anonymousIDPrefix = "deleted-"
+
+// ForgetPlayer deletes a player's profile and anonymizes everything else that refers to them.
+// Drink records are kept so other players' tallies still add up, but they're moved to an anonymous
+// "Deleted Player" who can't be traced back to the player. A server admin can only purge the player from
+// their own server, the profile and everything from other servers is left alone.
+func (s *service) ForgetPlayer(ctx context.Context, input *ForgetPlayerInput) (*ForgetPlayerOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	// Only the player can wipe themselves everywhere
+	var purgeGuildID string
+	if input.RequestedBy != "" && input.RequestedBy != input.PlayerID {
+		if input.GuildID == "" {
+			return nil, errors.New("guild ID is required to purge another player")
+		}
+		purgeGuildID = input.GuildID
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	// Rewriting records out from under a running game would confuse its tally
+	if s.inUnfinishedGame(ctx, player) {
+		return nil, ErrForgetPlayerInGame
+	}
+
+	anonymousID := anonymousIDPrefix + s.uuid.NewUUID()
+
+	reassignOutput, err := s.drinkLedgerRepo.ReassignPlayerRecords(ctx, &ledgerRepo.ReassignPlayerRecordsInput{
+		FromPlayerID: player.ID,
+		ToPlayerID:   anonymousID,
+		GuildID:      purgeGuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to anonymize drink records: %w", err)
+	}
+
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: &models.Player{
+			ID:   anonymousID,
+			Name: models.DeletedPlayerName,
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save anonymous player: %w", err)
+	}
+
+	if err := s.anonymizeGames(ctx, player.ID, anonymousID, purgeGuildID); err != nil {
+		return nil, err
+	}
+
+	// Side bets only stand for a few minutes, there's nothing worth keeping in them
+	if _, err := s.gameRepo.DeleteSideBetsByPlayer(ctx, &gameRepo.DeleteSideBetsByPlayerInput{
+		PlayerID: player.ID,
+		GuildID:  purgeGuildID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to delete side bets: %w", err)
+	}
+
+	if input.GuildID != "" {
+		if err := s.anonymizeSession(ctx, input.GuildID, player.ID, anonymousID); err != nil {
+			return nil, err
+		}
+	}
+
+	rollsAnonymized, err := s.anonymizeRolls(ctx, player.ID, anonymousID, purgeGuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	momentsAnonymized, err := s.anonymizeMoments(ctx, player.ID, anonymousID, purgeGuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if purgeGuildID == "" {
+		if err := s.playerRepo.DeletePlayer(ctx, &playerRepo.DeletePlayerInput{
+			PlayerID: player.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to delete player: %w", err)
+		}
+	}
+
+	s.auditForgetPlayer(ctx, input, anonymousID, reassignOutput.RecordsMoved)
+
+	return &ForgetPlayerOutput{
+		AnonymousID:       anonymousID,
+		RecordsAnonymized: reassignOutput.RecordsMoved,
+		RollsAnonymized:   rollsAnonymized,
+		MomentsAnonymized: momentsAnonymized,
+	}, nil
+}
+
+// anonymizeGames replaces the player in every game that refers to them, or only the guild's games when a guild is
+// given, which is also what their game views are rendered from
+func (s *service) anonymizeGames(ctx context.Context, playerID, anonymousID, guildID string) error {
+	gamesOutput, err := s.gameRepo.GetGamesByPlayer(ctx, &gameRepo.GetGamesByPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the player's games: %w", err)
+	}
+
+	for _, game := range gamesOutput.Games {
+		if guildID != "" && game.GuildID != guildID {
+			continue
+		}
+		if !game.ReplacePlayer(playerID, anonymousID, models.DeletedPlayerName) {
+			continue
+		}
+
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		}); err != nil {
+			return fmt.Errorf("failed to anonymize game: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// relatedGameIDs returns a game, the game it was a roll-off of and that game's roll-offs, none when the game is gone
+func (s *service) relatedGameIDs(ctx context.Context, gameID string) []string {
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return nil
+	}
+
+	gameIDs := []string{game.ID}
+	if game.ParentGameID != "" {
+		gameIDs = append(gameIDs, game.ParentGameID)
+		game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.ParentGameID,
+		})
+		if err != nil {
+			game = nil
+		}
+	}
+	if game != nil {
+		gameIDs = append(gameIDs, game.RollOffGameID, game.HighestRollOffGameID, game.LowestRollOffGameID)
+	}
+
+	seen := make(map[string]bool)
+	related := make([]string, 0, len(gameIDs))
+	for _, id := range gameIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		related = append(related, id)
+	}
+
+	return related
+}
+
+// anonymizeSession replaces the player among the guild's current session's designated drivers and substitutes
+func (s *service) anonymizeSession(ctx context.Context, guildID, playerID, anonymousID string) error {
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: guildID,
+	})
+	if err != nil || sessionOutput.Session == nil {
+		return nil
+	}
+
+	session := sessionOutput.Session
+	changed := false
+	for _, driver := range session.DesignatedDrivers {
+		if driver.PlayerID == playerID {
+			driver.PlayerID = anonymousID
+			driver.PlayerName = models.DeletedPlayerName
+			changed = true
+		}
+		if driver.SubstituteID == playerID {
+			driver.SubstituteID = anonymousID
+			driver.SubstituteName = models.DeletedPlayerName
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return fmt.Errorf("failed to anonymize session: %w", err)
+	}
+
+	return nil
+}
+
+// auditForgetPlayer records the deletion, under the anonymous ID so the entry doesn't identify the player
+func (s *service) auditForgetPlayer(ctx context.Context, input *ForgetPlayerInput, anonymousID string, recordsAnonymized int) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := &models.AuditEntry{
+		ID:        s.uuid.NewUUID(),
+		GuildID:   input.GuildID,
+		Action:    models.AuditActionForgetPlayer,
+		SubjectID: anonymousID,
+		Details:   fmt.Sprintf("Deleted at the player's request, %d drink records anonymized", recordsAnonymized),
+		CreatedAt: s.clock.Now(),
+	}
+
+	if input.RequestedBy != "" && input.RequestedBy != input.PlayerID {
+		entry.ActorID = input.RequestedBy
+		entry.Details = fmt.Sprintf("Purged by a server admin, %d drink records anonymized", recordsAnonymized)
+	}
+
+	// The data is already gone, a missing entry shouldn't make the deletion look like it failed
+	if err := s.auditRepo.AddEntry(ctx, &auditRepo.AddEntryInput{
+		Entry: entry,
+	}); err != nil {
+		log.Printf("Error adding audit entry for forgotten player %s: %v", anonymousID, err)
+	}
+}
@@ -0,0 +1,290 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// ForgetPlayerTestSuite tests deleting a player's data against the real Redis repositories
+type ForgetPlayerTestSuite struct {
+	serviceHarness
+	auditRepo auditRepo.Repository
+}
+
+func (s *ForgetPlayerTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		var err error
+		s.auditRepo, err = auditRepo.NewRedis(&auditRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		cfg.AuditRepo = s.auditRepo
+	})
+	s.testChannelID = "forget-channel"
+	s.testGuildID = "forget-guild"
+}
+
+func TestForgetPlayerTestSuite(t *testing.T) {
+	suite.Run(t, new(ForgetPlayerTestSuite))
+}
+
+func (s *ForgetPlayerTestSuite) TestForgetPlayerAnonymizesRecords() {
+	// alice rolls a critical fail and the lowest roll
+	gameID := s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "alice",
+		RequestedBy: "alice",
+		GuildID:     s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(output.AnonymousID, anonymousIDPrefix))
+	s.Equal(2, output.RecordsAnonymized)
+
+	// The profile is gone
+	_, err = s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.ErrorIs(err, playerRepo.ErrPlayerNotFound)
+
+	// The drinks still count, but against the deleted player
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal(output.AnonymousID, leaderboard.Entries[0].PlayerID)
+	s.Equal(models.DeletedPlayerName, leaderboard.Entries[0].PlayerName)
+	s.Equal(2, leaderboard.Entries[0].DrinkCount)
+
+	// The game no longer mentions alice
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID, GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Nil(gameOutput.Game.GetParticipant("alice"))
+	s.Equal(output.AnonymousID, gameOutput.Game.CreatorID)
+	s.Require().NotNil(gameOutput.Game.GetParticipant(output.AnonymousID))
+	s.Equal(models.DeletedPlayerName, gameOutput.Game.GetParticipant(output.AnonymousID).PlayerName)
+
+	// The deletion is audited without naming alice
+	entries, err := s.auditRepo.ListEntries(s.ctx, &auditRepo.ListEntriesInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Require().Len(entries.Entries, 1)
+	s.Equal(models.AuditActionForgetPlayer, entries.Entries[0].Action)
+	s.Equal(output.AnonymousID, entries.Entries[0].SubjectID)
+	s.Empty(entries.Entries[0].ActorID)
+}
+
+func (s *ForgetPlayerTestSuite) TestForgetPlayerLeavesNoTrace() {
+	// alice plays two games, and bets on carol's roll in a game she isn't playing
+	s.playGame([]string{"alice", "bob"}, []int{1, 4})
+	s.playGame([]string{"bob", "alice"}, []int{3, 5})
+
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   "other-channel",
+		GuildID:     s.testGuildID,
+		CreatorID:   "bob",
+		CreatorName: "bob",
+	})
+	s.Require().NoError(err)
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: createOutput.GameID, PlayerID: "carol", PlayerName: "carol"})
+	s.Require().NoError(err)
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: createOutput.GameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	_, err = s.gameService.PlaceSideBet(s.ctx, &PlaceSideBetInput{
+		GameID:         createOutput.GameID,
+		BettorID:       "alice",
+		BettorName:     "alice",
+		TargetPlayerID: "carol",
+		Outcome:        models.SideBetCriticalHit,
+	})
+	s.Require().NoError(err)
+
+	// And pays for more rounds than she owes, banking a credit
+	for credited := false; !credited; {
+		payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: createOutput.GameID, PlayerID: "alice"})
+		s.Require().NoError(err)
+		credited = payOutput.Credited
+	}
+
+	_, err = s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "alice",
+		RequestedBy: "alice",
+		GuildID:     s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	s.Empty(s.storedReferences("alice"))
+}
+
+// storedReferences returns every key in Redis whose name or value mentions the text
+func (s *ForgetPlayerTestSuite) storedReferences(text string) []string {
+	var found []string
+	for _, key := range s.mr.Keys() {
+		var values []string
+		switch s.mr.Type(key) {
+		case "string":
+			value, err := s.mr.Get(key)
+			s.Require().NoError(err)
+			values = append(values, value)
+		case "hash":
+			fields, err := s.mr.HKeys(key)
+			s.Require().NoError(err)
+			for _, field := range fields {
+				values = append(values, field, s.mr.HGet(key, field))
+			}
+		case "set":
+			members, err := s.mr.Members(key)
+			s.Require().NoError(err)
+			values = append(values, members...)
+		case "zset":
+			members, err := s.mr.ZMembers(key)
+			s.Require().NoError(err)
+			values = append(values, members...)
+		case "list":
+			items, err := s.mr.List(key)
+			s.Require().NoError(err)
+			values = append(values, items...)
+		}
+
+		if strings.Contains(key, text) || strings.Contains(strings.Join(values, "\n"), text) {
+			found = append(found, key)
+		}
+	}
+	return found
+}
+
+func (s *ForgetPlayerTestSuite) TestPurgeByAdminIsAudited() {
+	s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "alice",
+		RequestedBy: "admin",
+		GuildID:     s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	entries, err := s.auditRepo.ListEntries(s.ctx, &auditRepo.ListEntriesInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Require().Len(entries.Entries, 1)
+	s.Equal("admin", entries.Entries[0].ActorID)
+	s.Equal(output.AnonymousID, entries.Entries[0].SubjectID)
+}
+
+func (s *ForgetPlayerTestSuite) TestPurgeByAdminStaysInTheirServer() {
+	s.playGame([]string{"alice", "bob"}, []int{1, 4})
+	otherGameID := s.playInOtherServer([]string{"alice", "bob"}, []int{1, 4})
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "alice",
+		RequestedBy: "admin",
+		GuildID:     s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.RecordsAnonymized)
+
+	// The admin's server only has the deleted player
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal(output.AnonymousID, leaderboard.Entries[0].PlayerID)
+
+	// But alice keeps her profile and everything from the other server
+	_, err = s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	leaderboard, err = s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: "other-server-channel",
+		GuildID:   "other-server",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal("alice", leaderboard.Entries[0].PlayerID)
+	s.Equal(2, leaderboard.Entries[0].DrinkCount)
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: otherGameID, GuildID: "other-server"})
+	s.Require().NoError(err)
+	s.NotNil(gameOutput.Game.GetParticipant("alice"))
+}
+
+func (s *ForgetPlayerTestSuite) TestPurgeByAdminNeedsServer() {
+	s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	_, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "alice",
+		RequestedBy: "admin",
+	})
+	s.Error(err)
+
+	_, err = s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.NoError(err)
+}
+
+// playInOtherServer plays a game like playGame, in a channel of another server
+func (s *ForgetPlayerTestSuite) playInOtherServer(playerIDs []string, rolls []int) string {
+	channelID, guildID := s.testChannelID, s.testGuildID
+	defer func() {
+		s.testChannelID, s.testGuildID = channelID, guildID
+	}()
+
+	s.testChannelID, s.testGuildID = "other-server-channel", "other-server"
+	return s.playGame(playerIDs, rolls)
+}
+
+func (s *ForgetPlayerTestSuite) TestForgetDesignatedDriver() {
+	_, err := s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "alice",
+		PlayerName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.CoverDesignatedDriver(s.ctx, &CoverDesignatedDriverInput{
+		ChannelID:      s.testChannelID,
+		GuildID:        s.testGuildID,
+		DriverID:       "alice",
+		SubstituteID:   "bob",
+		SubstituteName: "bob",
+	})
+	s.Require().NoError(err)
+
+	s.playGame([]string{"alice", "bob"}, []int{2, 4})
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{
+		PlayerID:    "bob",
+		RequestedBy: "bob",
+		GuildID:     s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	driver := leaderboard.Session.GetDesignatedDriver("alice")
+	s.Require().NotNil(driver)
+	s.Equal(output.AnonymousID, driver.SubstituteID)
+	s.Equal(models.DeletedPlayerName, driver.SubstituteName)
+}
+
+func (s *ForgetPlayerTestSuite) TestForgetPlayerErrors() {
+	_, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{PlayerID: "nobody"})
+	s.ErrorIs(err, ErrPlayerNotFound)
+
+	// alice is waiting in a game that hasn't been played yet
+	_, err = s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{PlayerID: "alice", RequestedBy: "alice"})
+	s.ErrorIs(err, ErrForgetPlayerInGame)
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// ForgiveDrinks writes off a player's outstanding drinks in the current session, a single drink or their whole tab.
+// Forgiven drinks stay on the ledger so leaderboards can show them apart from the drinks that were paid.
+func (s *service) ForgiveDrinks(ctx context.Context, input *ForgiveDrinksInput) (*ForgiveDrinksOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	if input.Reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session drink records: %w", err)
+	}
+
+	var outstanding []*models.DrinkLedger
+	for _, record := range drinkRecords.Records {
+		if record.ToPlayerID != input.PlayerID || !record.IsOutstanding() {
+			continue
+		}
+		if !input.WholeTab && input.DrinkID != "" && record.ID != input.DrinkID {
+			continue
+		}
+		outstanding = append(outstanding, record)
+		if !input.WholeTab {
+			break
+		}
+	}
+
+	if len(outstanding) == 0 {
+		return nil, ErrNoDrinksToForgive
+	}
+
+	now := s.clock.Now()
+	forgiven := make([]*models.DrinkLedger, 0, len(outstanding))
+	for _, record := range outstanding {
+		if err := s.drinkLedgerRepo.ForgiveDrink(ctx, &ledgerRepo.ForgiveDrinkInput{
+			DrinkID:    record.ID,
+			ForgivenBy: input.ForgivenBy,
+			Reason:     input.Reason,
+			ForgivenAt: now,
+		}); err != nil {
+			// Drinks already forgiven stay that way, report what was done so far
+			if len(forgiven) > 0 {
+				s.auditForgiveDrinks(ctx, input, len(forgiven))
+			}
+			return nil, fmt.Errorf("failed to forgive drink %s: %w", record.ID, err)
+		}
+
+		record.Forgiven = true
+		record.ForgivenBy = input.ForgivenBy
+		record.ForgiveReason = input.Reason
+		record.ForgivenTimestamp = now
+		forgiven = append(forgiven, record)
+	}
+
+	s.auditForgiveDrinks(ctx, input, len(forgiven))
+	s.publishLedgerChanged(ctx, input.GuildID, input.ChannelID, session.ID, "drink_forgiven")
+
+	return &ForgiveDrinksOutput{
+		Records: forgiven,
+	}, nil
+}
+
+// auditForgiveDrinks records an admin writing off a player's drinks
+func (s *service) auditForgiveDrinks(ctx context.Context, input *ForgiveDrinksInput, count int) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := &models.AuditEntry{
+		ID:        s.uuid.NewUUID(),
+		GuildID:   input.GuildID,
+		ActorID:   input.ForgivenBy,
+		Action:    models.AuditActionForgiveDrinks,
+		SubjectID: input.PlayerID,
+		Details:   fmt.Sprintf("Forgave %d drinks: %s", count, input.Reason),
+		CreatedAt: s.clock.Now(),
+	}
+
+	// The drinks are already forgiven, a missing entry shouldn't make it look like it failed
+	if err := s.auditRepo.AddEntry(ctx, &auditRepo.AddEntryInput{
+		Entry: entry,
+	}); err != nil {
+		log.Printf("Error adding audit entry for forgiven drinks of %s: %v", input.PlayerID, err)
+	}
+}
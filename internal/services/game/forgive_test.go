@@ -0,0 +1,136 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
+	"github.com/stretchr/testify/suite"
+)
+
+// ForgiveDrinksTestSuite tests writing off drinks against the real Redis repositories
+type ForgiveDrinksTestSuite struct {
+	serviceHarness
+	auditRepo auditRepo.Repository
+}
+
+func (s *ForgiveDrinksTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		var err error
+		s.auditRepo, err = auditRepo.NewRedis(&auditRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		cfg.AuditRepo = s.auditRepo
+	})
+	s.testChannelID = "forgive-channel"
+	s.testGuildID = "forgive-guild"
+}
+
+func TestForgiveDrinksTestSuite(t *testing.T) {
+	suite.Run(t, new(ForgiveDrinksTestSuite))
+}
+
+// sessionEntry returns the player's entry on the session leaderboard
+func (s *ForgiveDrinksTestSuite) sessionEntry(playerID string) LeaderboardEntry {
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	for _, entry := range leaderboard.Entries {
+		if entry.PlayerID == playerID {
+			return entry
+		}
+	}
+	s.FailNow("player not on the session leaderboard", playerID)
+	return LeaderboardEntry{}
+}
+
+func (s *ForgiveDrinksTestSuite) TestForgiveOneDrink() {
+	// alice rolls a critical fail and the lowest roll, owing two drinks
+	gameID := s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	output, err := s.gameService.ForgiveDrinks(s.ctx, &ForgiveDrinksInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "alice",
+		ForgivenBy: "admin",
+		Reason:     "birthday",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(output.Records, 1)
+	s.True(output.Records[0].Forgiven)
+	s.Equal("admin", output.Records[0].ForgivenBy)
+	s.Equal("birthday", output.Records[0].ForgiveReason)
+
+	// The forgiven drink is shown apart from the one still owed
+	entry := s.sessionEntry("alice")
+	s.Equal(1, entry.DrinkCount)
+	s.Equal(0, entry.PaidCount)
+	s.Equal(1, entry.ForgivenCount)
+
+	// Only the drink still owed can be paid, paying another banks a credit
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.False(payOutput.Credited)
+	payOutput, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(payOutput.Credited)
+
+	entries, err := s.auditRepo.ListEntries(s.ctx, &auditRepo.ListEntriesInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Require().Len(entries.Entries, 1)
+	s.Equal(models.AuditActionForgiveDrinks, entries.Entries[0].Action)
+	s.Equal("admin", entries.Entries[0].ActorID)
+	s.Equal("alice", entries.Entries[0].SubjectID)
+}
+
+func (s *ForgiveDrinksTestSuite) TestForgiveWholeTab() {
+	gameID := s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	output, err := s.gameService.ForgiveDrinks(s.ctx, &ForgiveDrinksInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "alice",
+		WholeTab:   true,
+		ForgivenBy: "admin",
+		Reason:     "designated driver all along",
+	})
+	s.Require().NoError(err)
+	s.Len(output.Records, 2)
+
+	entry := s.sessionEntry("alice")
+	s.Equal(0, entry.DrinkCount)
+	s.Equal(2, entry.ForgivenCount)
+
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(payOutput.Credited)
+
+	// There's nothing left to forgive
+	_, err = s.gameService.ForgiveDrinks(s.ctx, &ForgiveDrinksInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "alice",
+		WholeTab:   true,
+		ForgivenBy: "admin",
+		Reason:     "again",
+	})
+	s.ErrorIs(err, ErrNoDrinksToForgive)
+}
+
+func (s *ForgiveDrinksTestSuite) TestForgiveRequiresReason() {
+	s.playGame([]string{"alice", "bob"}, []int{1, 4})
+
+	_, err := s.gameService.ForgiveDrinks(s.ctx, &ForgiveDrinksInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		PlayerID:  "alice",
+		WholeTab:  true,
+	})
+	s.Error(err)
+
+	entry := s.sessionEntry("alice")
+	s.Equal(2, entry.DrinkCount)
+	s.Zero(entry.ForgivenCount)
+}
@@ -0,0 +1,242 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+)
+
+// gameDurationWarningShare is how much of the max game duration passes before the slow players are warned
+const gameDurationWarningShare = 0.75
+
+// CheckGameDurations warns the players who haven't rolled once a game has used most of its time, and ends the
+// games out of time without them. Games waiting on roll-offs are left to their roll-offs, which get their own time,
+// and paused games are left until they're resumed.
+func (s *service) CheckGameDurations(ctx context.Context, input *CheckGameDurationsInput) (*CheckGameDurationsOutput, error) {
+	output := &CheckGameDurationsOutput{}
+	if s.maxGameDuration == 0 {
+		return output, nil
+	}
+
+	active, err := s.gameRepo.GetActiveGames(ctx, &gameRepo.GetActiveGamesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active games: %w", err)
+	}
+
+	now := s.clock.Now()
+	for _, game := range active.Games {
+		if len(rollOffGameIDs(game)) > 0 || game.IsPaused() {
+			continue
+		}
+
+		endsAt := gameStartedAt(game).Add(s.maxGameDuration)
+		warnAt := endsAt.Add(-time.Duration(float64(s.maxGameDuration) * (1 - gameDurationWarningShare)))
+
+		// A game that changes while it's checked, by a roll coming in, is refused and checked again next time
+		switch {
+		case !now.Before(endsAt):
+			if err := s.timeOutGame(ctx, game, ""); err != nil {
+				log.Printf("Error ending game %s that ran out of time: %v", game.ID, err)
+				continue
+			}
+			output.EndedGameIDs = append(output.EndedGameIDs, game.ID)
+		case !now.Before(warnAt) && !game.DurationWarned:
+			warned, err := s.warnSlowPlayers(ctx, game, endsAt)
+			if err != nil {
+				log.Printf("Error warning the players of game %s: %v", game.ID, err)
+				continue
+			}
+			if warned {
+				output.WarnedGameIDs = append(output.WarnedGameIDs, game.ID)
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// gameStartedAt returns when a game's time started, roll-offs start when they're created. Time spent paused
+// doesn't count, so it moves the start later.
+func gameStartedAt(game *models.Game) time.Time {
+	startedAt := game.CreatedAt
+	if game.StartedAt != nil {
+		startedAt = *game.StartedAt
+	}
+	return startedAt.Add(game.PausedFor)
+}
+
+// warnSlowPlayers lets the players who haven't rolled know the game ends soon, reporting whether anyone was warned.
+// The game is only ever warned once.
+func (s *service) warnSlowPlayers(ctx context.Context, game *models.Game, endsAt time.Time) (bool, error) {
+	playerIDs := notRolledPlayerIDs(game)
+
+	game.DurationWarned = true
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return false, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	if len(playerIDs) == 0 {
+		return false, nil
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeGameDurationWarning,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.GameDurationWarningPayload{
+			PlayerIDs:  playerIDs,
+			EndsAt:     endsAt,
+			RootGameID: s.rootGameID(ctx, game),
+		},
+	})
+
+	return true, nil
+}
+
+// timeOutGame ends a game that ran out of time, or that a host wrapped up. The players who haven't rolled are
+// skipped and drinks not yet assigned are forfeited, then the game ends as usual, or is abandoned when nobody
+// rolled at all.
+func (s *service) timeOutGame(ctx context.Context, game *models.Game, endedBy string) error {
+	skipped := notRolledPlayerIDs(game)
+	abandoned := len(skipped) == len(game.Participants)
+
+	if abandoned {
+		if err := s.abandonTimedOutGame(ctx, game); err != nil {
+			return err
+		}
+	} else {
+		rolled := make([]*models.Participant, 0, len(game.Participants)-len(skipped))
+		for _, participant := range game.Participants {
+			if participant.RollTime == nil {
+				continue
+			}
+			if participant.Status == models.ParticipantStatusNeedsToAssign {
+				participant.Status = models.ParticipantStatusActive
+			}
+			rolled = append(rolled, participant)
+		}
+		game.Participants = rolled
+		game.IndexParticipants()
+
+		// A rounds game that runs out of time ends with the round being played
+		if game.Rounds != nil {
+			game.Rounds.Total = game.Rounds.Current
+		}
+
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+
+		s.releasePlayers(ctx, game.ID, skipped)
+
+		if _, err := s.EndGame(ctx, &EndGameInput{Game: game}); err != nil {
+			return err
+		}
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeGameTimedOut,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.GameTimedOutPayload{
+			SkippedPlayerIDs: skipped,
+			Abandoned:        abandoned,
+			RootGameID:       s.rootGameID(ctx, game),
+			EndedBy:          endedBy,
+		},
+	})
+
+	return nil
+}
+
+// abandonTimedOutGame abandons a game nobody rolled in. A roll-off nobody rolled in settles nothing, so the
+// games above it are completed without it.
+func (s *service) abandonTimedOutGame(ctx context.Context, game *models.Game) error {
+	if game.ParentGameID == "" {
+		_, err := s.AbandonGame(ctx, &AbandonGameInput{GameID: game.ID})
+		return err
+	}
+
+	if err := s.archiveGame(ctx, game); err != nil {
+		return err
+	}
+
+	playerIDs := make([]string, 0, len(game.Participants))
+	for _, participant := range game.Participants {
+		playerIDs = append(playerIDs, participant.PlayerID)
+	}
+	s.releasePlayers(ctx, game.ID, playerIDs)
+
+	parentGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: game.ParentGameID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get parent game: %w", err)
+	}
+
+	if completedParentGame := s.completeRollOffParents(ctx, game, parentGame); completedParentGame != nil {
+		sessionID := s.getSessionIDForGame(ctx, completedParentGame)
+
+		var sessionLeaderboard []LeaderboardEntry
+		sessionLeaderboardOutput, err := s.GetSessionLeaderboard(replica.WithPrimaryReads(ctx), &GetSessionLeaderboardInput{
+			SessionID: sessionID,
+			GuildID:   completedParentGame.GuildID,
+			ChannelID: completedParentGame.ChannelID,
+		})
+		if err == nil && sessionLeaderboardOutput != nil {
+			sessionLeaderboard = sessionLeaderboardOutput.Entries
+		}
+
+		s.publishGameCompleted(ctx, completedParentGame, s.getPlayerResults(ctx, completedParentGame), sessionID, sessionLeaderboard)
+		s.countSurvivedGameGoals(ctx, completedParentGame)
+		s.closeLastCallSession(ctx, completedParentGame)
+	}
+
+	return nil
+}
+
+// releasePlayers clears the current game of players who are no longer playing it
+func (s *service) releasePlayers(ctx context.Context, gameID string, playerIDs []string) {
+	for _, playerID := range playerIDs {
+		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: playerID,
+		})
+		if err != nil {
+			log.Printf("Error getting player %s: %v", playerID, err)
+			continue
+		}
+
+		if player.CurrentGameID != gameID {
+			continue
+		}
+
+		player.CurrentGameID = ""
+		if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+			Player: player,
+		}); err != nil {
+			log.Printf("Error updating player %s: %v", playerID, err)
+		}
+	}
+}
+
+// notRolledPlayerIDs returns the players in a game who haven't rolled yet
+func notRolledPlayerIDs(game *models.Game) []string {
+	var playerIDs []string
+	for _, participant := range game.Participants {
+		if participant.RollTime == nil {
+			playerIDs = append(playerIDs, participant.PlayerID)
+		}
+	}
+	return playerIDs
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// GameDurationTestSuite tests the max game duration against the real Redis repositories
+type GameDurationTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+	warnings  []*events.GameDurationWarningPayload
+	timeouts  []*events.GameTimedOutPayload
+
+	// now is the time the mocked clock reports
+	now time.Time
+}
+
+func (s *GameDurationTestSuite) SetupTest() {
+	s.now = time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+		cfg.MaxGameDuration = 20 * time.Minute
+	})
+	s.testChannelID = "duration-channel"
+
+	s.warnings = nil
+	s.timeouts = nil
+	s.eventBus.Subscribe(events.TypeGameDurationWarning, func(_ context.Context, event *events.Event) {
+		s.warnings = append(s.warnings, event.Payload.(*events.GameDurationWarningPayload))
+	})
+	s.eventBus.Subscribe(events.TypeGameTimedOut, func(_ context.Context, event *events.Event) {
+		s.timeouts = append(s.timeouts, event.Payload.(*events.GameTimedOutPayload))
+	})
+}
+
+func TestGameDurationTestSuite(t *testing.T) {
+	suite.Run(t, new(GameDurationTestSuite))
+}
+
+// check runs the duration checks at the given offset from the start of the test
+func (s *GameDurationTestSuite) check(start time.Time, offset time.Duration) *CheckGameDurationsOutput {
+	s.now = start.Add(offset)
+	output, err := s.gameService.CheckGameDurations(s.ctx, &CheckGameDurationsInput{})
+	s.Require().NoError(err)
+	return output
+}
+
+func (s *GameDurationTestSuite) TestWarnsOnceBeforeTheEnd() {
+	start := s.now
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 3)
+
+	output := s.check(start, 10*time.Minute)
+	s.Empty(output.WarnedGameIDs)
+	s.Empty(s.warnings)
+
+	output = s.check(start, 15*time.Minute)
+	s.Equal([]string{gameID}, output.WarnedGameIDs)
+	s.Require().Len(s.warnings, 1)
+	s.ElementsMatch([]string{"bob", "carol"}, s.warnings[0].PlayerIDs)
+	s.Equal(start.Add(20*time.Minute), s.warnings[0].EndsAt)
+	s.Equal(gameID, s.warnings[0].RootGameID)
+
+	// The players are only warned once
+	output = s.check(start, 16*time.Minute)
+	s.Empty(output.WarnedGameIDs)
+	s.Len(s.warnings, 1)
+	s.Equal(models.GameStatusActive, s.getGame(gameID).Status)
+}
+
+func (s *GameDurationTestSuite) TestEndsWithoutThePlayersWhoHaventRolled() {
+	start := s.now
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 3)
+	s.roll(gameID, "bob", 6)
+
+	output := s.check(start, 20*time.Minute)
+	s.Equal([]string{gameID}, output.EndedGameIDs)
+	s.Require().Len(s.timeouts, 1)
+	s.Equal([]string{"carol"}, s.timeouts[0].SkippedPlayerIDs)
+	s.False(s.timeouts[0].Abandoned)
+
+	game := s.getGame(gameID)
+	s.Equal(models.GameStatusCompleted, game.Status)
+	s.False(game.HasParticipant("carol"))
+
+	// Bob's critical hit went unassigned and alice had the lowest roll
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Len(records.Records, 1)
+	s.Equal("alice", records.Records[0].ToPlayerID)
+	s.Equal(models.DrinkReasonLowestRoll, records.Records[0].Reason)
+
+	player, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Empty(player.CurrentGameID)
+}
+
+func (s *GameDurationTestSuite) TestAbandonsWhenNobodyRolled() {
+	start := s.now
+	gameID := s.startGame("alice", "bob")
+
+	output := s.check(start, 25*time.Minute)
+	s.Equal([]string{gameID}, output.EndedGameIDs)
+	s.Require().Len(s.timeouts, 1)
+	s.True(s.timeouts[0].Abandoned)
+	s.Equal(models.GameStatusAbandoned, s.getGame(gameID).Status)
+}
+
+func (s *GameDurationTestSuite) TestWaitingGamesHaveNoTimeLimit() {
+	start := s.now
+	_, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	output := s.check(start, time.Hour)
+	s.Empty(output.WarnedGameIDs)
+	s.Empty(output.EndedGameIDs)
+}
+
+func (s *GameDurationTestSuite) TestNegativeDurationIsRejected() {
+	_, err := New(&Config{
+		GameRepo:        s.gameRepo,
+		PlayerRepo:      s.playerRepo,
+		DrinkLedgerRepo: s.ledgerRepo,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           s.mockClock,
+		MaxGameDuration: -time.Minute,
+	})
+	s.ErrorContains(err, "max game duration")
+}
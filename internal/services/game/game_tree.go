@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// GetGameTree loads a game with every roll-off started from it, the roll-offs of its roll-offs included.
+// Roll-offs are listed level by level, so a roll-off always comes before the ones started from it.
+func (s *service) GetGameTree(ctx context.Context, input *GetGameTreeInput) (*GetGameTreeOutput, error) {
+	if input == nil || input.GameID == "" {
+		return nil, errors.New("game ID is required")
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	rollOffs, err := s.rollOffTree(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetGameTreeOutput{
+		Game:     game,
+		RollOffs: rollOffs,
+	}, nil
+}
+
+// rollOffTree loads every roll-off started from a game level by level, the roll-offs of its roll-offs included
+func (s *service) rollOffTree(ctx context.Context, game *models.Game) ([]*models.Game, error) {
+	var tree []*models.Game
+	seen := map[string]bool{game.ID: true}
+	parents := []*models.Game{game}
+	for len(parents) > 0 {
+		var next []*models.Game
+		for _, parent := range parents {
+			rollOffs, err := s.gameRepo.GetGamesByParent(ctx, &gameRepo.GetGamesByParentInput{
+				ParentGameID: parent.ID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get roll-offs of game %s: %w", parent.ID, err)
+			}
+
+			for _, rollOff := range rollOffs {
+				if seen[rollOff.ID] {
+					continue
+				}
+				seen[rollOff.ID] = true
+				next = append(next, rollOff)
+			}
+		}
+
+		sort.SliceStable(next, func(i, j int) bool {
+			return next[i].CreatedAt.Before(next[j].CreatedAt)
+		})
+		tree = append(tree, next...)
+		parents = next
+	}
+
+	return tree, nil
+}
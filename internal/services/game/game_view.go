@@ -0,0 +1,147 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	gameViewRepo "github.com/KirkDiggler/ronnied/internal/repositories/game_view"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+)
+
+// GetGameView loads a game with everything needed to render it: its related games, its drink records and,
+// once it's completed, the game and session leaderboards
+func (s *service) GetGameView(ctx context.Context, input *GetGameViewInput) (*GetGameViewOutput, error) {
+	if input == nil || input.GameID == "" {
+		return nil, errors.New("game ID is required")
+	}
+
+	// Games are rendered from a replica if there is one, unless the caller needs the primary
+	ctx = replica.WithReplicaReads(ctx)
+	view, err := s.loadGameView(ctx, input.GameID)
+
+	// Rotating a completed game's session below goes by the session in the view, which has to be the primary's
+	if err == nil && view.Game.Status.IsCompleted() && s.sessionRotation != nil && replica.ReplicaReadsAllowed(ctx) {
+		ctx = replica.WithPrimaryReads(ctx)
+		view, err = s.loadGameView(ctx, input.GameID)
+	}
+	if err != nil {
+		if errors.Is(err, gameViewRepo.ErrGameNotFound) || errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to load game view: %w", err)
+	}
+
+	game := view.Game
+	output := &GetGameViewOutput{
+		Game:         game,
+		Rules:        s.rulesFor(game),
+		RollOffGame:  view.RollOffGame,
+		DrinkRecords: view.DrinkRecords,
+	}
+
+	if game.Status.IsRollOff() {
+		output.ParentGame = view.ParentGame
+	}
+
+	// The game the roll-offs started from shows how each of them is going, it's rendered without them if they
+	// can't be loaded
+	if game.Status.IsRollOff() && game.ParentGameID == "" {
+		rollOffs, err := s.rollOffTree(ctx, game)
+		if err != nil {
+			log.Printf("Error getting roll-offs of game %s: %v", game.ID, err)
+		}
+		output.RollOffs = rollOffs
+	}
+
+	if !game.Status.IsCompleted() {
+		return output, nil
+	}
+
+	playerName := s.playerNames(ctx, view.PlayerNames)
+	output.Leaderboard = buildGameLeaderboard(game, view.DrinkRecords, playerName)
+
+	if session := view.Session; session != nil {
+		records := view.SessionRecords
+
+		// Nobody has drunk anything in a session that was only just started
+		if rotated := s.rotateSessionIfDue(ctx, game.GuildID, game.ChannelID, session); rotated.ID != session.ID {
+			session, records = rotated, nil
+		}
+
+		output.Session = session
+		output.SessionLeaderboard = buildSessionLeaderboard(session, records, playerName)
+	}
+
+	return output, nil
+}
+
+// loadGameView loads a game view in one go from the view repository, or piece by piece when there isn't one
+func (s *service) loadGameView(ctx context.Context, gameID string) (*gameViewRepo.LoadGameViewOutput, error) {
+	if s.gameViewRepo != nil {
+		return s.gameViewRepo.LoadGameView(ctx, &gameViewRepo.LoadGameViewInput{
+			GameID: gameID,
+		})
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	view := &gameViewRepo.LoadGameViewOutput{
+		Game: game,
+	}
+
+	if game.ParentGameID != "" {
+		if parentGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.ParentGameID,
+		}); err == nil {
+			view.ParentGame = parentGame
+		}
+	}
+
+	if game.RollOffGameID != "" {
+		if rollOffGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.RollOffGameID,
+		}); err == nil {
+			view.RollOffGame = rollOffGame
+		}
+	}
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForGame(ctx, &ledgerRepo.GetDrinkRecordsForGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drink records: %w", err)
+	}
+	view.DrinkRecords = drinkRecords.Records
+
+	if !game.Status.IsCompleted() {
+		return view, nil
+	}
+
+	currentSession, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(game.GuildID, game.ChannelID),
+	})
+	if err != nil || currentSession.Session == nil {
+		return view, nil
+	}
+
+	sessionRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: currentSession.Session.ID,
+		GuildID:   sessionScope(game.GuildID, game.ChannelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session drink records: %w", err)
+	}
+	view.Session = currentSession.Session
+	view.SessionRecords = sessionRecords.Records
+
+	return view, nil
+}
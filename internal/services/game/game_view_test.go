@@ -0,0 +1,116 @@
+package game
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameViewRepo "github.com/KirkDiggler/ronnied/internal/repositories/game_view"
+	"github.com/stretchr/testify/suite"
+)
+
+// GameViewTestSuite tests game views against the real Redis repositories, with and without the view repository
+type GameViewTestSuite struct {
+	serviceHarness
+	pieceService Service
+}
+
+func (s *GameViewTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		views, err := gameViewRepo.NewRedis(&gameViewRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		cfg.GameViewRepo = views
+	})
+	s.testChannelID = "channel-1"
+	s.testGuildID = "guild-1"
+
+	// The same service reading the repositories one at a time
+	s.pieceService = s.newService(nil)
+}
+
+func TestGameViewTestSuite(t *testing.T) {
+	suite.Run(t, new(GameViewTestSuite))
+}
+
+// sortedEntries orders leaderboard entries by player so they can be compared
+func sortedEntries(entries []LeaderboardEntry) []LeaderboardEntry {
+	sorted := append([]LeaderboardEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PlayerID < sorted[j].PlayerID
+	})
+	return sorted
+}
+
+func (s *GameViewTestSuite) TestGetGameView_GameNotFound() {
+	_, err := s.gameService.GetGameView(s.ctx, &GetGameViewInput{GameID: "missing"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.pieceService.GetGameView(s.ctx, &GetGameViewInput{GameID: "missing"})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *GameViewTestSuite) TestGetGameView_CompletedGame() {
+	// bob rolls lowest
+	gameID := s.playGame([]string{"alice", "bob"}, []int{4, 2})
+
+	view, err := s.gameService.GetGameView(s.ctx, &GetGameViewInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, view.Game.Status)
+	s.Require().Len(view.DrinkRecords, 1)
+	s.Equal("bob", view.DrinkRecords[0].ToPlayerID)
+
+	// The leaderboards match the ones loaded on their own
+	leaderboard, err := s.gameService.GetLeaderboard(s.ctx, &GetLeaderboardInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(sortedEntries(leaderboard.Entries), sortedEntries(view.Leaderboard))
+
+	sessionLeaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: "channel-1",
+		GuildID:   "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(view.SessionLeaderboard)
+	s.Equal(sessionLeaderboard.Entries, view.SessionLeaderboard)
+	s.Require().NotNil(view.Session)
+	s.Equal(sessionLeaderboard.Session.ID, view.Session.ID)
+	s.Equal(6, view.Rules.DiceSides)
+
+	// Reading the repositories one at a time gives the same view
+	pieceView, err := s.pieceService.GetGameView(s.ctx, &GetGameViewInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(view.Game, pieceView.Game)
+	s.Equal(view.DrinkRecords, pieceView.DrinkRecords)
+	s.Equal(sortedEntries(view.Leaderboard), sortedEntries(pieceView.Leaderboard))
+	s.Equal(view.SessionLeaderboard, pieceView.SessionLeaderboard)
+}
+
+func (s *GameViewTestSuite) TestGetGameView_ActiveGameHasNoLeaderboards() {
+	gameID := s.createGame("alice")
+
+	view, err := s.gameService.GetGameView(s.ctx, &GetGameViewInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusWaiting, view.Game.Status)
+	s.Empty(view.DrinkRecords)
+	s.Nil(view.Leaderboard)
+	s.Nil(view.SessionLeaderboard)
+}
+
+func (s *GameViewTestSuite) TestGetGameView_RollOffs() {
+	gameID := s.startGame("alice", "bob")
+
+	// Tying for the highest and lowest rolls sends both players to two roll-offs
+	s.roll(gameID, "alice", 3)
+	rollOutput := s.roll(gameID, "bob", 3)
+	s.Require().True(rollOutput.NeedsRollOff)
+
+	// The game the roll-off started from has its tree, the roll-off itself doesn't
+	view, err := s.gameService.GetGameView(s.ctx, &GetGameViewInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Len(view.RollOffs, 2)
+	s.Contains([]string{view.RollOffs[0].ID, view.RollOffs[1].ID}, rollOutput.RollOffGameID)
+
+	rollOffView, err := s.gameService.GetGameView(s.ctx, &GetGameViewInput{GameID: rollOutput.RollOffGameID})
+	s.Require().NoError(err)
+	s.Empty(rollOffView.RollOffs)
+	s.Equal(gameID, rollOffView.ParentGame.ID)
+}
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// OfferGuestDrink offers a critical hit's drink to someone in the channel who isn't playing, in games played with
+// guest drinks. The player still has a drink to assign until the guest takes it, and can give it to a player
+// instead in the meantime.
+func (s *service) OfferGuestDrink(ctx context.Context, input *OfferGuestDrinkInput) (*OfferGuestDrinkOutput, error) {
+	if input == nil || input.GameID == "" || input.FromPlayerID == "" || input.GuestID == "" {
+		return nil, errors.New("game, from player and guest IDs are required")
+	}
+
+	game, participant, err := s.getAssigningParticipant(ctx, input.GameID, input.FromPlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.rulesFor(game).GuestDrinks {
+		return nil, ErrGuestDrinksOff
+	}
+
+	if input.GuestID == input.FromPlayerID || game.HasParticipant(input.GuestID) {
+		return nil, ErrGuestIsPlaying
+	}
+
+	// On a player's birthday every critical hit's drink goes to them
+	if len(s.birthdayPlayers(ctx, game, input.FromPlayerID)) > 0 {
+		return nil, ErrMustTargetBirthday
+	}
+
+	guestName := input.GuestName
+	if guestName == "" {
+		guestName = input.GuestID
+	}
+
+	participant.GuestOffer = &models.GuestOffer{
+		GuestID:   input.GuestID,
+		GuestName: guestName,
+		OfferedAt: s.clock.Now(),
+	}
+	game.UpdatedAt = s.clock.Now()
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return &OfferGuestDrinkOutput{
+		Offer: participant.GuestOffer,
+	}, nil
+}
+
+// AcceptGuestDrink has the guest take the drink they were offered. It goes on their tab without them joining the
+// game, and uses up the player's drink to assign.
+func (s *service) AcceptGuestDrink(ctx context.Context, input *AcceptGuestDrinkInput) (*AcceptGuestDrinkOutput, error) {
+	if input == nil || input.GameID == "" || input.FromPlayerID == "" || input.GuestID == "" {
+		return nil, errors.New("game, from player and guest IDs are required")
+	}
+
+	game, participant, err := s.getAssigningParticipant(ctx, input.GameID, input.FromPlayerID)
+	if err != nil {
+		if errors.Is(err, ErrNotEligibleToAssign) {
+			// The drink went to a player while the guest was deciding
+			return nil, ErrGuestDrinkNotFound
+		}
+		return nil, err
+	}
+
+	offer := participant.GuestOffer
+	if offer == nil {
+		return nil, ErrGuestDrinkNotFound
+	}
+	if offer.GuestID != input.GuestID {
+		return nil, ErrNotGuest
+	}
+
+	drinkInput := &ledgerRepo.CreateDrinkRecordInput{
+		GameID:       game.ID,
+		FromPlayerID: input.FromPlayerID,
+		ToPlayerID:   offer.GuestID,
+		Reason:       models.DrinkReasonCriticalHit,
+		Timestamp:    s.clock.Now(),
+		GuestName:    offer.GuestName,
+	}
+
+	// Save the drink together with the assignment being used up, as with assigning it to a player
+	var drinkOutput *ledgerRepo.CreateDrinkRecordOutput
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		drinkOutput, err = s.createDrinkRecord(ctx, game, s.getSessionForGame(ctx, game), drinkInput)
+		if err != nil {
+			return err
+		}
+
+		participant.Status = models.ParticipantStatusActive
+		participant.GuestOffer = nil
+		game.UpdatedAt = s.clock.Now()
+		return s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endGameOutput, err := s.completeIfReady(ctx, game)
+	if err != nil {
+		log.Printf("Error ending game after guest drink: %v", err)
+	}
+
+	return &AcceptGuestDrinkOutput{
+		Offer:               offer,
+		Record:              drinkOutput.Record,
+		HappyHourMultiplier: drinkInput.HappyHourMultiplier,
+		GameEnded:           endGameOutput != nil && endGameOutput.Success,
+		EndGameOutput:       endGameOutput,
+	}, nil
+}
+
+// DeclineGuestDrink turns down a guest drink, either the guest or the player who offered it can call it off.
+// The player goes back to picking who gets their drink.
+func (s *service) DeclineGuestDrink(ctx context.Context, input *DeclineGuestDrinkInput) (*DeclineGuestDrinkOutput, error) {
+	if input == nil || input.GameID == "" || input.FromPlayerID == "" || input.PlayerID == "" {
+		return nil, errors.New("game, from player and player IDs are required")
+	}
+
+	game, participant, err := s.getAssigningParticipant(ctx, input.GameID, input.FromPlayerID)
+	if err != nil {
+		if errors.Is(err, ErrNotEligibleToAssign) {
+			return nil, ErrGuestDrinkNotFound
+		}
+		return nil, err
+	}
+
+	offer := participant.GuestOffer
+	if offer == nil {
+		return nil, ErrGuestDrinkNotFound
+	}
+	if offer.GuestID != input.PlayerID && input.FromPlayerID != input.PlayerID {
+		return nil, ErrNotGuest
+	}
+
+	participant.GuestOffer = nil
+	game.UpdatedAt = s.clock.Now()
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return &DeclineGuestDrinkOutput{
+		Offer: offer,
+	}, nil
+}
+
+// getAssigningParticipant gets a game being played and the participant in it who has a drink to assign
+func (s *service) getAssigningParticipant(ctx context.Context, gameID, playerID string) (*models.Game, *models.Participant, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if game.Status == models.GameStatusWaiting {
+		return nil, nil, ErrGameNotStarted
+	}
+	if !s.lifecycle.IsPlaying(game.Status) {
+		return nil, nil, ErrInvalidGameState
+	}
+
+	participant := game.GetParticipant(playerID)
+	if participant == nil {
+		return nil, nil, ErrPlayerNotInGame
+	}
+	if participant.Status != models.ParticipantStatusNeedsToAssign {
+		return nil, nil, ErrNotEligibleToAssign
+	}
+
+	return game, participant, nil
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// GuestDrinkTestSuite tests offering critical hits' drinks to people watching against the real Redis repositories
+type GuestDrinkTestSuite struct {
+	serviceHarness
+}
+
+func (s *GuestDrinkTestSuite) SetupTest() {
+	s.setUpService(guestDrinkConfig(true))
+	s.testGuildID = "guest-guild"
+	s.testChannelID = "guest-channel"
+}
+
+func TestGuestDrinkTestSuite(t *testing.T) {
+	suite.Run(t, new(GuestDrinkTestSuite))
+}
+
+// guestDrinkConfig gives the service a fixed clock and turns guest drinks on or off
+func guestDrinkConfig(guestDrinks bool) func(cfg *Config) {
+	return func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+		cfg.GuestDrinks = guestDrinks
+	}
+}
+
+// critGame starts a game between alice and bob where bob has rolled and alice rolled a critical hit
+func (s *GuestDrinkTestSuite) critGame() string {
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "bob", 4)
+	rollOutput := s.roll(gameID, "alice", 6)
+	s.Require().True(rollOutput.IsCriticalHit)
+
+	return gameID
+}
+
+func (s *GuestDrinkTestSuite) offer(gameID string) {
+	_, err := s.gameService.OfferGuestDrink(s.ctx, &OfferGuestDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		GuestID:      "watcher",
+		GuestName:    "Watcher",
+	})
+	s.Require().NoError(err)
+}
+
+func (s *GuestDrinkTestSuite) TestGuestTakesTheDrink() {
+	gameID := s.critGame()
+	s.offer(gameID)
+
+	_, err := s.gameService.AcceptGuestDrink(s.ctx, &AcceptGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "bob"})
+	s.ErrorIs(err, ErrNotGuest)
+
+	output, err := s.gameService.AcceptGuestDrink(s.ctx, &AcceptGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "watcher"})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Record)
+	s.Equal("watcher", output.Record.ToPlayerID)
+	s.Equal("Watcher", output.Record.GuestName)
+	s.True(output.GameEnded, "alice's drink was all the game was waiting on")
+
+	game := s.getGame(gameID)
+	s.False(game.HasParticipant("watcher"), "guests don't join the game")
+	s.Nil(game.GetParticipant("alice").GuestOffer)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	var names []string
+	for _, entry := range leaderboard.Entries {
+		names = append(names, entry.PlayerName)
+	}
+	s.Contains(names, "Watcher")
+}
+
+func (s *GuestDrinkTestSuite) TestGuestPasses() {
+	gameID := s.critGame()
+	s.offer(gameID)
+
+	_, err := s.gameService.DeclineGuestDrink(s.ctx, &DeclineGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", PlayerID: "bob"})
+	s.ErrorIs(err, ErrNotGuest)
+
+	_, err = s.gameService.DeclineGuestDrink(s.ctx, &DeclineGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", PlayerID: "watcher"})
+	s.Require().NoError(err)
+
+	// alice is back to picking who drinks
+	participant := s.getGame(gameID).GetParticipant("alice")
+	s.Nil(participant.GuestOffer)
+	s.Equal(models.ParticipantStatusNeedsToAssign, participant.Status)
+
+	_, err = s.gameService.AcceptGuestDrink(s.ctx, &AcceptGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "watcher"})
+	s.ErrorIs(err, ErrGuestDrinkNotFound)
+}
+
+func (s *GuestDrinkTestSuite) TestAssigningAPlayerWithdrawsTheOffer() {
+	gameID := s.critGame()
+	s.offer(gameID)
+
+	_, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   "bob",
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.AcceptGuestDrink(s.ctx, &AcceptGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "watcher"})
+	s.Error(err)
+}
+
+func (s *GuestDrinkTestSuite) TestPlayersArentGuests() {
+	gameID := s.critGame()
+
+	_, err := s.gameService.OfferGuestDrink(s.ctx, &OfferGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "bob"})
+	s.ErrorIs(err, ErrGuestIsPlaying)
+
+	_, err = s.gameService.OfferGuestDrink(s.ctx, &OfferGuestDrinkInput{GameID: gameID, FromPlayerID: "bob", GuestID: "watcher"})
+	s.ErrorIs(err, ErrNotEligibleToAssign, "bob didn't roll a critical hit")
+}
+
+func (s *GuestDrinkTestSuite) TestGuestDrinksOff() {
+	gameID := s.critGame()
+
+	s.gameService = s.newService(guestDrinkConfig(false))
+	_, err := s.gameService.OfferGuestDrink(s.ctx, &OfferGuestDrinkInput{GameID: gameID, FromPlayerID: "alice", GuestID: "watcher"})
+	s.ErrorIs(err, ErrGuestDrinksOff)
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// GetGuildOverview returns a guild's games being played and its current session with the session leaderboard
+func (s *service) GetGuildOverview(ctx context.Context, input *GetGuildOverviewInput) (*GetGuildOverviewOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, errors.New("guild ID is required")
+	}
+
+	active, err := s.gameRepo.GetActiveGames(ctx, &gameRepo.GetActiveGamesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active games: %w", err)
+	}
+
+	output := &GetGuildOverviewOutput{}
+	for _, game := range active.Games {
+		if game.GuildID == input.GuildID {
+			output.ActiveGames = append(output.ActiveGames, game)
+		}
+	}
+	sort.Slice(output.ActiveGames, func(i, j int) bool {
+		return output.ActiveGames[i].CreatedAt.Before(output.ActiveGames[j].CreatedAt)
+	})
+
+	session, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current session: %w", err)
+	}
+	if session.Session == nil {
+		return output, nil
+	}
+	output.Session = session.Session
+
+	leaderboard, err := s.GetSessionLeaderboard(ctx, &GetSessionLeaderboardInput{
+		SessionID: session.Session.ID,
+		GuildID:   input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session leaderboard: %w", err)
+	}
+	output.Leaderboard = leaderboard.Entries
+
+	return output, nil
+}
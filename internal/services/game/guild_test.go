@@ -0,0 +1,186 @@
+package game
+
+import (
+	"testing"
+
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// GuildScopingTestSuite tests that games, sessions and drinks stay within their guild against the real Redis repositories
+type GuildScopingTestSuite struct {
+	serviceHarness
+}
+
+func (s *GuildScopingTestSuite) SetupTest() {
+	s.setUpService(nil)
+}
+
+func TestGuildScopingTestSuite(t *testing.T) {
+	suite.Run(t, new(GuildScopingTestSuite))
+}
+
+// playCriticalFail plays a game where the player rolls a critical fail, returning the game ID
+func (s *GuildScopingTestSuite) playCriticalFail(guildID, channelID, playerID string) string {
+	gameID := s.startGameWith(&CreateGameInput{GuildID: guildID, ChannelID: channelID}, playerID)
+	s.roll(gameID, playerID, 1)
+	return gameID
+}
+
+// leaderboard returns the session leaderboard a channel in a guild sees
+func (s *GuildScopingTestSuite) leaderboard(guildID, channelID string) *GetSessionLeaderboardOutput {
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: channelID,
+		GuildID:   guildID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Session)
+	return output
+}
+
+func (s *GuildScopingTestSuite) TestChannelsInAGuildShareASession() {
+	gameID := s.playCriticalFail("guild-1", "channel-1", "player-1")
+	s.playCriticalFail("guild-1", "channel-2", "player-2")
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID, GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal("guild-1", gameOutput.Game.GuildID)
+
+	first := s.leaderboard("guild-1", "channel-1")
+	second := s.leaderboard("guild-1", "channel-2")
+	s.Equal(first.Session.ID, second.Session.ID)
+	s.Equal("guild-1", first.Session.GuildID)
+	s.Len(first.Entries, 2)
+
+	records, err := s.ledgerRepo.GetDrinkRecordsForSession(s.ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: first.Session.ID,
+		GuildID:   "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(records.Records)
+	for _, record := range records.Records {
+		s.Equal("guild-1", record.GuildID)
+	}
+}
+
+func (s *GuildScopingTestSuite) TestGuildsDontShareSessions() {
+	s.playCriticalFail("guild-1", "channel-1", "player-1")
+	s.playCriticalFail("guild-2", "channel-2", "player-2")
+
+	first := s.leaderboard("guild-1", "channel-1")
+	second := s.leaderboard("guild-2", "channel-2")
+	s.NotEqual(first.Session.ID, second.Session.ID)
+
+	s.Require().Len(first.Entries, 1)
+	s.Equal("player-1", first.Entries[0].PlayerID)
+	s.Require().Len(second.Entries, 1)
+	s.Equal("player-2", second.Entries[0].PlayerID)
+}
+
+func (s *GuildScopingTestSuite) TestLeaderboardRefusesAnotherGuildsSession() {
+	s.playCriticalFail("guild-1", "channel-1", "player-1")
+	session := s.leaderboard("guild-1", "channel-1").Session
+
+	_, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		SessionID: session.ID,
+		GuildID:   "guild-2",
+	})
+	s.ErrorIs(err, ErrSessionNotFound)
+}
+
+func (s *GuildScopingTestSuite) TestReadsFromAnotherGuild() {
+	gameID := s.playCriticalFail("guild-1", "channel-1", "player-1")
+	session := s.leaderboard("guild-1", "channel-1").Session
+
+	// Neither another guild nor a chat outside one can read the game
+	for _, guildID := range []string{"guild-2", ""} {
+		_, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID, GuildID: guildID})
+		s.ErrorIs(err, ErrGameNotFound)
+
+		_, err = s.gameService.GetGameByChannel(s.ctx, &GetGameByChannelInput{ChannelID: "channel-1", GuildID: guildID})
+		s.ErrorIs(err, ErrGameNotFound)
+	}
+
+	// Or the session's drinks
+	_, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		SessionID: session.ID,
+		ChannelID: "dm-channel",
+	})
+	s.ErrorIs(err, ErrSessionNotFound)
+
+	_, err = s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		SessionID: session.ID,
+	})
+	s.Error(err)
+
+	_, err = s.ledgerRepo.GetDrinkRecordsForSession(s.ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   "guild-2",
+	})
+	s.ErrorIs(err, ledgerRepo.ErrSessionNotInGuild)
+
+	// While the guild itself still reads all of it
+	gameOutput, err := s.gameService.GetGameByChannel(s.ctx, &GetGameByChannelInput{ChannelID: "channel-1", GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Equal(gameID, gameOutput.Game.ID)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		SessionID: session.ID,
+		GuildID:   "guild-1",
+	})
+	s.Require().NoError(err)
+	s.Len(leaderboard.Entries, 1)
+}
+
+func (s *GuildScopingTestSuite) TestGamesOutsideAGuildUseAChannelSession() {
+	s.playCriticalFail("", "dm-channel", "player-1")
+
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: "dm-channel",
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Session)
+	s.Equal("dm-channel", output.Session.GuildID)
+	s.Len(output.Entries, 1)
+}
+
+func (s *GuildScopingTestSuite) TestGetGuildOverview() {
+	// Nothing played yet
+	output, err := s.gameService.GetGuildOverview(s.ctx, &GetGuildOverviewInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Empty(output.ActiveGames)
+	s.Nil(output.Session)
+
+	s.playCriticalFail("guild-1", "channel-1", "player-1")
+
+	playing, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   "channel-1",
+		GuildID:     "guild-1",
+		CreatorID:   "player-2",
+		CreatorName: "player-2",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: playing.GameID, PlayerID: "player-2"})
+	s.Require().NoError(err)
+
+	other, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   "channel-2",
+		GuildID:     "guild-2",
+		CreatorID:   "player-3",
+		CreatorName: "player-3",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: other.GameID, PlayerID: "player-3"})
+	s.Require().NoError(err)
+
+	output, err = s.gameService.GetGuildOverview(s.ctx, &GetGuildOverviewInput{GuildID: "guild-1"})
+	s.Require().NoError(err)
+	s.Require().Len(output.ActiveGames, 1, "other guilds' games aren't shown")
+	s.Equal(playing.GameID, output.ActiveGames[0].ID)
+	s.Require().NotNil(output.Session)
+	s.Equal("guild-1", output.Session.GuildID)
+	s.Require().Len(output.Leaderboard, 1)
+	s.Equal("player-1", output.Leaderboard[0].PlayerID)
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+)
+
+// leaderHandicapSource labels the disadvantage the session leader rolls with
+const leaderHandicapSource = "session leader handicap"
+
+// leaderHandicapFor returns the disadvantage a player rolls with when the game is played with the leader handicap
+// and they lead the session, nil otherwise. Roll-offs are played without it.
+func (s *service) leaderHandicapFor(ctx context.Context, game *models.Game, rules models.GameRules, playerID string) *models.RollModifier {
+	if !rules.LeaderHandicap || game.Status == models.GameStatusRollOff {
+		return nil
+	}
+
+	// The leader is whoever leads now, not whoever a lagging replica thinks does
+	output, err := s.GetSessionLeaderboard(replica.WithPrimaryReads(ctx), &GetSessionLeaderboardInput{
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+	})
+	if err != nil {
+		// Roll without the handicap rather than holding up the game
+		log.Printf("Error getting session leaderboard for the leader handicap: %v", err)
+		return nil
+	}
+
+	if sessionLeader(output.Entries) != playerID {
+		return nil
+	}
+
+	return &models.RollModifier{
+		Type:   models.RollModifierDisadvantage,
+		Rolls:  1,
+		Source: leaderHandicapSource,
+	}
+}
+
+// sessionLeader returns the ID of the player with the most drinks on the session leaderboard, empty when nobody
+// has been given a drink or the lead is shared
+func sessionLeader(entries []LeaderboardEntry) string {
+	var leader string
+	most := 0
+	for _, entry := range entries {
+		switch {
+		case entry.DrinkCount > most:
+			leader, most = entry.PlayerID, entry.DrinkCount
+		case entry.DrinkCount == most:
+			leader = ""
+		}
+	}
+	return leader
+}
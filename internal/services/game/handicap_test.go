@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// HandicapTestSuite tests the session leader handicap against the real Redis repositories
+type HandicapTestSuite struct {
+	serviceHarness
+}
+
+func (s *HandicapTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "handicap-channel"
+}
+
+func TestHandicapTestSuite(t *testing.T) {
+	suite.Run(t, new(HandicapTestSuite))
+}
+
+// makeAliceTheLeader plays a game alice loses twice over, a critical fail and the lowest roll
+func (s *HandicapTestSuite) makeAliceTheLeader(rules *models.GameRules) {
+	gameID := s.startGameWith(&CreateGameInput{Rules: rules}, "alice", "bob")
+	s.roll(gameID, "alice", 1)
+	s.roll(gameID, "bob", 4)
+}
+
+func (s *HandicapTestSuite) TestLeaderRollsWithDisadvantage() {
+	rules := &models.GameRules{LeaderHandicap: true}
+	s.makeAliceTheLeader(rules)
+	gameID := s.startGameWith(&CreateGameInput{Rules: rules}, "alice", "bob")
+
+	// Alice rolls twice and keeps the worse roll
+	output := s.roll(gameID, "alice", 5, 2)
+	s.Equal(2, output.RollValue)
+	s.Require().Len(output.RollModifiers, 1)
+	s.Equal(models.RollModifierDisadvantage, output.RollModifiers[0].Type)
+	s.Equal(leaderHandicapSource, output.RollModifiers[0].Source)
+	s.True(output.LeaderHandicap)
+
+	// Bob rolls as normal
+	output = s.roll(gameID, "bob", 5)
+	s.Empty(output.RollModifiers)
+	s.False(output.LeaderHandicap)
+}
+
+func (s *HandicapTestSuite) TestNoHandicapWithoutTheRule() {
+	s.makeAliceTheLeader(nil)
+	gameID := s.startGame("alice", "bob")
+
+	output := s.roll(gameID, "alice", 5)
+	s.Equal(5, output.RollValue)
+	s.Empty(output.RollModifiers)
+}
+
+func (s *HandicapTestSuite) TestSessionLeader() {
+	s.Empty(sessionLeader(nil))
+	s.Empty(sessionLeader([]LeaderboardEntry{{PlayerID: "alice"}, {PlayerID: "bob"}}))
+	s.Empty(sessionLeader([]LeaderboardEntry{{PlayerID: "alice", DrinkCount: 2}, {PlayerID: "bob", DrinkCount: 2}}))
+	s.Equal("bob", sessionLeader([]LeaderboardEntry{
+		{PlayerID: "alice", DrinkCount: 2},
+		{PlayerID: "carol", DrinkCount: 2},
+		{PlayerID: "bob", DrinkCount: 3},
+	}))
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// validateHappyHour checks each happy hour window is within a day, open for some of it and has a multiplier
+func validateHappyHour(happyHour *HappyHourConfig) error {
+	if happyHour == nil {
+		return nil
+	}
+
+	for _, window := range happyHour.Windows {
+		if window.Start < 0 || window.Start >= 24*time.Hour || window.End < 0 || window.End >= 24*time.Hour {
+			return errors.New("happy hour times must be within a day")
+		}
+		if window.Start == window.End {
+			return errors.New("happy hour windows must open and close at different times")
+		}
+		if window.Multiplier <= 0 {
+			return errors.New("happy hour multiplier must be positive")
+		}
+	}
+
+	return nil
+}
+
+// happyHourMultiplier returns how many times drinks given now count for happy hour, 0 outside happy hour
+func (s *service) happyHourMultiplier() float64 {
+	if s.happyHour == nil {
+		return 0
+	}
+
+	at := s.clock.Now()
+	if s.happyHour.Location != nil {
+		at = at.In(s.happyHour.Location)
+	}
+	timeOfDay := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute + time.Duration(at.Second())*time.Second
+
+	for _, window := range s.happyHour.Windows {
+		if window.isOpen(timeOfDay) {
+			return window.Multiplier
+		}
+	}
+	return 0
+}
+
+// happyHourDrinkCount applies a happy hour multiplier to a number of drinks. Part of a drink left over, such as
+// one drink at half, is rolled for so drinks count the multiplier on average: a half drink is owed one time in two.
+func (s *service) happyHourDrinkCount(count int, multiplier float64) int {
+	exact := float64(count) * multiplier
+	whole := math.Floor(exact)
+	if chance := int(math.Round((exact - whole) * 100)); chance > 0 && s.diceRoller.Roll(100) <= chance {
+		whole++
+	}
+	return int(whole)
+}
+
+// isOpen returns true if the window is open at the time of day, including its start but not its end
+func (w HappyHourWindow) isOpen(timeOfDay time.Duration) bool {
+	if w.Start < w.End {
+		return timeOfDay >= w.Start && timeOfDay < w.End
+	}
+
+	// The window runs past midnight
+	return timeOfDay >= w.Start || timeOfDay < w.End
+}
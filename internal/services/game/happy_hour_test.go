@@ -0,0 +1,137 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// HappyHourTestSuite tests drinks counting more or less during happy hour against the real Redis repositories
+type HappyHourTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+
+	// now is the time the mocked clock reports
+	now time.Time
+}
+
+func (s *HappyHourTestSuite) SetupTest() {
+	s.now = time.Date(2025, 4, 19, 20, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+		cfg.HappyHour = &HappyHourConfig{
+			Windows: []HappyHourWindow{
+				{Start: 17 * time.Hour, End: 19 * time.Hour, Multiplier: 2},
+				{Start: 23 * time.Hour, End: time.Hour, Multiplier: 0.5},
+			},
+			Location: time.UTC,
+		}
+	})
+	s.testChannelID = "happy-hour-channel"
+}
+
+func TestHappyHourTestSuite(t *testing.T) {
+	suite.Run(t, new(HappyHourTestSuite))
+}
+
+// assignDrink has alice roll a critical hit and give bob a drink, returning the game and the assignment
+func (s *HappyHourTestSuite) assignDrink() (string, *AssignDrinkOutput) {
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "alice", 6)
+
+	output, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   "bob",
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+
+	return gameID, output
+}
+
+// drinks returns the drinks recorded in the game
+func (s *HappyHourTestSuite) drinks(gameID string) []*models.DrinkLedger {
+	output, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	return output.Records
+}
+
+func (s *HappyHourTestSuite) TestOutsideHappyHour() {
+	gameID, output := s.assignDrink()
+
+	s.Zero(output.HappyHourMultiplier)
+	s.False(output.OnTheHouse)
+
+	drinks := s.drinks(gameID)
+	s.Require().Len(drinks, 1)
+	s.Zero(drinks[0].HappyHourMultiplier)
+}
+
+func (s *HappyHourTestSuite) TestDoubleDuringHappyHour() {
+	s.now = time.Date(2025, 4, 19, 18, 30, 0, 0, time.UTC)
+
+	gameID, output := s.assignDrink()
+
+	s.Equal(2.0, output.HappyHourMultiplier)
+	s.False(output.OnTheHouse)
+
+	drinks := s.drinks(gameID)
+	s.Require().Len(drinks, 2)
+	for _, drink := range drinks {
+		s.Equal("bob", drink.ToPlayerID)
+		s.Equal(2.0, drink.HappyHourMultiplier)
+	}
+}
+
+func (s *HappyHourTestSuite) TestHalfPastMidnight_Owed() {
+	s.now = time.Date(2025, 4, 20, 0, 30, 0, 0, time.UTC)
+
+	// The half drink is rolled for
+	s.mockDiceRoller.EXPECT().Roll(100).Return(50)
+	gameID, output := s.assignDrink()
+
+	s.Equal(0.5, output.HappyHourMultiplier)
+	s.False(output.OnTheHouse)
+	s.Len(s.drinks(gameID), 1)
+}
+
+func (s *HappyHourTestSuite) TestHalfPastMidnight_OnTheHouse() {
+	s.now = time.Date(2025, 4, 19, 23, 0, 0, 0, time.UTC)
+
+	s.mockDiceRoller.EXPECT().Roll(100).Return(51)
+	gameID, output := s.assignDrink()
+
+	s.Equal(0.5, output.HappyHourMultiplier)
+	s.True(output.OnTheHouse)
+	s.Empty(s.drinks(gameID))
+}
+
+func (s *HappyHourTestSuite) TestNew_InvalidHappyHour() {
+	testCases := []HappyHourWindow{
+		{Start: -time.Hour, End: time.Hour, Multiplier: 2},
+		{Start: time.Hour, End: 24 * time.Hour, Multiplier: 2},
+		{Start: time.Hour, End: time.Hour, Multiplier: 2},
+		{Start: time.Hour, End: 2 * time.Hour},
+	}
+
+	for _, window := range testCases {
+		_, err := New(&Config{
+			GameRepo:        s.gameRepo,
+			PlayerRepo:      s.playerRepo,
+			DrinkLedgerRepo: s.ledgerRepo,
+			DiceRoller:      s.mockDiceRoller,
+			UUIDGenerator:   uuid.New(),
+			Clock:           s.mockClock,
+			HappyHour:       &HappyHourConfig{Windows: []HappyHourWindow{window}},
+		})
+		s.Error(err)
+	}
+}
@@ -0,0 +1,196 @@
+package game
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// serviceHarness runs the game service against the real Redis repositories on a miniredis of its own. Suites
+// embed it and set the service up in SetupTest, changing the config where they test something it turns on.
+type serviceHarness struct {
+	suite.Suite
+	mr             *miniredis.Miniredis
+	client         *redis.Client
+	mockCtrl       *gomock.Controller
+	mockDiceRoller *diceMocks.MockRoller
+	gameRepo       gameRepo.Repository
+	playerRepo     playerRepo.Repository
+	ledgerRepo     ledgerRepo.Repository
+	eventBus       events.Bus
+	gameService    Service
+	ctx            context.Context
+
+	// testChannelID and testGuildID are where the helpers create games
+	testChannelID string
+	testGuildID   string
+}
+
+// setUpService starts miniredis and the repositories on it, then creates the game service. configure, when not
+// nil, changes the config before the service is created.
+func (h *serviceHarness) setUpService(configure func(cfg *Config)) {
+	mr, err := miniredis.Run()
+	h.Require().NoError(err)
+	h.mr = mr
+
+	h.client = redis.NewClient(&redis.Options{
+		Addr: h.mr.Addr(),
+	})
+
+	h.gameRepo, err = gameRepo.NewRedis(&gameRepo.Config{RedisClient: h.client})
+	h.Require().NoError(err)
+
+	h.playerRepo, err = playerRepo.NewRedis(&playerRepo.Config{RedisClient: h.client})
+	h.Require().NoError(err)
+
+	h.ledgerRepo, err = ledgerRepo.NewRedis(&ledgerRepo.Config{RedisClient: h.client})
+	h.Require().NoError(err)
+
+	h.mockCtrl = gomock.NewController(h.T())
+	h.mockDiceRoller = diceMocks.NewMockRoller(h.mockCtrl)
+	h.eventBus = events.NewBus()
+	h.ctx = context.Background()
+
+	h.gameService = h.newService(configure)
+}
+
+// newService creates another game service on the harness's repositories, dice and event bus, with a real clock
+// unless configure changes it
+func (h *serviceHarness) newService(configure func(cfg *Config)) Service {
+	cfg := &Config{
+		GameRepo:        h.gameRepo,
+		PlayerRepo:      h.playerRepo,
+		DrinkLedgerRepo: h.ledgerRepo,
+		DiceRoller:      h.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+		EventBus:        h.eventBus,
+	}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	svc, err := New(cfg)
+	h.Require().NoError(err)
+	return svc
+}
+
+func (h *serviceHarness) TearDownTest() {
+	h.mockCtrl.Finish()
+	h.client.Close()
+	h.mr.Close()
+}
+
+// createGame creates a game with the given players, the first one being the creator
+func (h *serviceHarness) createGame(playerIDs ...string) string {
+	return h.createGameWith(&CreateGameInput{}, playerIDs...)
+}
+
+// createGameWith creates a game set up by input with the given players, the first one being the creator. The
+// game goes in the harness's channel and guild unless input names others.
+func (h *serviceHarness) createGameWith(input *CreateGameInput, playerIDs ...string) string {
+	createInput := *input
+	if createInput.ChannelID == "" {
+		createInput.ChannelID = h.testChannelID
+	}
+	if createInput.GuildID == "" {
+		createInput.GuildID = h.testGuildID
+	}
+	createInput.CreatorID = playerIDs[0]
+	createInput.CreatorName = playerIDs[0]
+
+	createOutput, err := h.gameService.CreateGame(h.ctx, &createInput)
+	h.Require().NoError(err)
+
+	for _, playerID := range playerIDs[1:] {
+		_, err := h.gameService.JoinGame(h.ctx, &JoinGameInput{
+			GameID:     createOutput.GameID,
+			PlayerID:   playerID,
+			PlayerName: playerID,
+		})
+		h.Require().NoError(err)
+	}
+
+	return createOutput.GameID
+}
+
+// start starts a game as the player
+func (h *serviceHarness) start(gameID, playerID string) {
+	startOutput, err := h.gameService.StartGame(h.ctx, &StartGameInput{
+		GameID:   gameID,
+		PlayerID: playerID,
+	})
+	h.Require().NoError(err)
+	h.Require().True(startOutput.Success)
+}
+
+// startGame creates and starts a game with the given players, the first one being the creator
+func (h *serviceHarness) startGame(playerIDs ...string) string {
+	return h.startGameWith(&CreateGameInput{}, playerIDs...)
+}
+
+// startGameWith creates a game set up by input with the given players and starts it
+func (h *serviceHarness) startGameWith(input *CreateGameInput, playerIDs ...string) string {
+	gameID := h.createGameWith(input, playerIDs...)
+	h.start(gameID, playerIDs[0])
+	return gameID
+}
+
+// expectRolls scripts the dice roller to return the given values in order
+func (h *serviceHarness) expectRolls(values ...int) {
+	calls := make([]any, 0, len(values))
+	for _, value := range values {
+		calls = append(calls, h.mockDiceRoller.EXPECT().Roll(6).Return(value))
+	}
+	gomock.InOrder(calls...)
+}
+
+// roll rolls for the player in the game, the dice landing on the values in turn
+func (h *serviceHarness) roll(gameID, playerID string, values ...int) *RollDiceOutput {
+	for _, value := range values {
+		h.mockDiceRoller.EXPECT().Roll(6).Return(value)
+	}
+	rollOutput, err := h.gameService.RollDice(h.ctx, &RollDiceInput{
+		GameID:   gameID,
+		PlayerID: playerID,
+	})
+	h.Require().NoError(err)
+	return rollOutput
+}
+
+// playGame starts a game with the given players and rolls the given values for them in order
+func (h *serviceHarness) playGame(playerIDs []string, rolls []int) string {
+	gameID := h.startGame(playerIDs...)
+	h.rollAll(gameID, playerIDs, rolls)
+	return gameID
+}
+
+// rollAll rolls the given values for the players of a game in order
+func (h *serviceHarness) rollAll(gameID string, playerIDs []string, rolls []int) {
+	for i, playerID := range playerIDs {
+		h.roll(gameID, playerID, rolls[i])
+	}
+}
+
+// getGame reads a game as it's stored
+func (h *serviceHarness) getGame(gameID string) *models.Game {
+	game, err := h.gameRepo.GetGame(h.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	h.Require().NoError(err)
+	return game
+}
+
+// gameStatus returns the game's current status
+func (h *serviceHarness) gameStatus(gameID string) models.GameStatus {
+	return h.getGame(gameID).Status
+}
@@ -0,0 +1,298 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// idleGameAge is how long a game has to be left untouched before players who don't host it can abandon it
+const idleGameAge = 5 * time.Minute
+
+// AddCoHost deputizes a player in the game to help run it, only the game's creator can
+func (s *service) AddCoHost(ctx context.Context, input *AddCoHostInput) (*AddCoHostOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" || input.CoHostID == "" {
+		return nil, errors.New("game, player and co-host IDs are required")
+	}
+
+	var game *models.Game
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.creatorsGame(ctx, input.GameID, input.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		if !game.HasParticipant(input.CoHostID) {
+			return ErrPlayerNotInGame
+		}
+
+		if !game.IsHost(input.CoHostID) {
+			game.CoHostIDs = append(game.CoHostIDs, input.CoHostID)
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+				return fmt.Errorf("failed to save game: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddCoHostOutput{
+		CoHostIDs: game.CoHostIDs,
+	}, nil
+}
+
+// RemoveCoHost takes a co-host's powers away, only the game's creator can
+func (s *service) RemoveCoHost(ctx context.Context, input *RemoveCoHostInput) (*RemoveCoHostOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" || input.CoHostID == "" {
+		return nil, errors.New("game, player and co-host IDs are required")
+	}
+
+	var game *models.Game
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.creatorsGame(ctx, input.GameID, input.PlayerID)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(game.CoHostIDs, input.CoHostID) {
+			game.CoHostIDs = slices.DeleteFunc(game.CoHostIDs, func(id string) bool {
+				return id == input.CoHostID
+			})
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+				return fmt.Errorf("failed to save game: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoveCoHostOutput{
+		CoHostIDs: game.CoHostIDs,
+	}, nil
+}
+
+// creatorsGame returns a game that isn't over yet, failing unless the player created it
+func (s *service) creatorsGame(ctx context.Context, gameID, playerID string) (*models.Game, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.CreatorID != playerID {
+		return nil, ErrNotCreator
+	}
+	if game.Status.IsFinished() {
+		return nil, ErrInvalidGameState
+	}
+
+	return game, nil
+}
+
+// PauseGame stops everyone rolling in a game and its roll-offs until a host resumes it, only the hosts can
+func (s *service) PauseGame(ctx context.Context, input *PauseGameInput) (*PauseGameOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	// The game and its roll-offs are paused together, and all again if a roll changes one of them first
+	pausedAt := s.clock.Now()
+	err := retryOnGameChange(func() error {
+		tree, err := s.hostedGameTree(ctx, input.GameID, input.PlayerID)
+		if err != nil {
+			return err
+		}
+		if !s.lifecycle.IsPlaying(tree.Game.Status) {
+			return ErrGameNotStarted
+		}
+		if tree.Game.IsPaused() {
+			return ErrGamePaused
+		}
+
+		return s.inTransaction(ctx, func(ctx context.Context) error {
+			for _, game := range gamesInPlay(s.lifecycle, tree) {
+				game.PausedAt = &pausedAt
+				game.PausedBy = input.PlayerID
+				if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+					return fmt.Errorf("failed to save game %s: %w", game.ID, err)
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PauseGameOutput{
+		PausedAt: pausedAt,
+	}, nil
+}
+
+// ResumeGame lets everyone roll again in a paused game, only the hosts can. The time spent paused doesn't count
+// against the game's max duration.
+func (s *service) ResumeGame(ctx context.Context, input *ResumeGameInput) (*ResumeGameOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	var tree *GetGameTreeOutput
+	var pausedFor time.Duration
+	err := retryOnGameChange(func() error {
+		var err error
+		tree, err = s.hostedGameTree(ctx, input.GameID, input.PlayerID)
+		if err != nil {
+			return err
+		}
+		if !tree.Game.IsPaused() {
+			return ErrGameNotPaused
+		}
+
+		pausedFor = s.clock.Now().Sub(*tree.Game.PausedAt)
+		return s.unpause(ctx, tree)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ronnie picks up where he left off
+	s.scheduleRonnie(tree.Game)
+
+	return &ResumeGameOutput{
+		PausedFor: pausedFor,
+	}, nil
+}
+
+// WrapUpGame ends a game stuck waiting on players, only the hosts can. Every game still being played, the
+// game itself or the roll-offs it's waiting on, ends with the rolls made so far, as if it had run out of time.
+func (s *service) WrapUpGame(ctx context.Context, input *WrapUpGameInput) (*WrapUpGameOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	var tree *GetGameTreeOutput
+	err := retryOnGameChange(func() error {
+		var err error
+		tree, err = s.hostedGameTree(ctx, input.GameID, input.PlayerID)
+		if err != nil {
+			return err
+		}
+		if !s.lifecycle.IsPlaying(tree.Game.Status) {
+			return ErrGameNotStarted
+		}
+
+		return s.unpause(ctx, tree)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &WrapUpGameOutput{}
+	for _, game := range gamesInPlay(s.lifecycle, tree) {
+		skipped, err := s.wrapUp(ctx, game, input.PlayerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap up game %s: %w", game.ID, err)
+		}
+		output.SkippedPlayerIDs = append(output.SkippedPlayerIDs, skipped...)
+	}
+
+	return output, nil
+}
+
+// wrapUp times out a game a host wrapped up, returning the players skipped. A game changed since it was read, by
+// a roll that came in, is read again so the roll counts; a game waiting on roll-offs is left to them.
+func (s *service) wrapUp(ctx context.Context, game *models.Game, endedBy string) ([]string, error) {
+	var skipped []string
+	err := retryOnGameChange(func() error {
+		skipped = nil
+		if len(rollOffGameIDs(game)) > 0 || !s.lifecycle.IsPlaying(game.Status) {
+			return nil
+		}
+
+		skipped = notRolledPlayerIDs(game)
+		err := s.timeOutGame(ctx, game, endedBy)
+		if errors.Is(err, gameRepo.ErrGameChanged) {
+			if latest, getErr := s.getGame(ctx, game.ID); getErr == nil {
+				game = latest
+			}
+		}
+		return err
+	})
+	return skipped, err
+}
+
+// hostedGameTree loads the game a game or roll-off was started from with its roll-offs, failing unless the
+// player hosts it
+func (s *service) hostedGameTree(ctx context.Context, gameID, playerID string) (*GetGameTreeOutput, error) {
+	game, err := s.getGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := s.GetGameTree(ctx, &GetGameTreeInput{
+		GameID: s.rootGameID(ctx, game),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !tree.Game.IsHost(playerID) {
+		return nil, ErrNotCreator
+	}
+	return tree, nil
+}
+
+// unpause clears the pause on a game and its roll-offs together, adding the time spent paused to each
+func (s *service) unpause(ctx context.Context, tree *GetGameTreeOutput) error {
+	now := s.clock.Now()
+	return s.inTransaction(ctx, func(ctx context.Context) error {
+		for _, game := range append([]*models.Game{tree.Game}, tree.RollOffs...) {
+			if !game.IsPaused() {
+				continue
+			}
+
+			game.PausedFor += now.Sub(*game.PausedAt)
+			game.PausedAt = nil
+			game.PausedBy = ""
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+				return fmt.Errorf("failed to save game %s: %w", game.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// gamesInPlay returns the game and its roll-offs that are still being played
+func gamesInPlay(lifecycle *StateMachine, tree *GetGameTreeOutput) []*models.Game {
+	var games []*models.Game
+	for _, game := range append([]*models.Game{tree.Game}, tree.RollOffs...) {
+		if lifecycle.IsPlaying(game.Status) {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// getGame loads a game, mapping a missing game to ErrGameNotFound
+func (s *service) getGame(ctx context.Context, gameID string) (*models.Game, error) {
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	return game, nil
+}
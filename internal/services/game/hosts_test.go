@@ -0,0 +1,169 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// HostTestSuite tests co-hosts and the host actions against the real Redis repositories
+type HostTestSuite struct {
+	serviceHarness
+	clock    *clock.Fake
+	timedOut []*events.GameTimedOutPayload
+}
+
+func (s *HostTestSuite) SetupTest() {
+	// The repository stamps new games with the real time, which idle games are measured from
+	s.clock = clock.NewFake(time.Now())
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+	})
+	s.testChannelID = "host-channel"
+
+	s.timedOut = nil
+	s.eventBus.Subscribe(events.TypeGameTimedOut, func(_ context.Context, event *events.Event) {
+		s.timedOut = append(s.timedOut, event.Payload.(*events.GameTimedOutPayload))
+	})
+}
+
+func TestHostTestSuite(t *testing.T) {
+	suite.Run(t, new(HostTestSuite))
+}
+
+func (s *HostTestSuite) addCoHost(gameID, coHostID string) {
+	_, err := s.gameService.AddCoHost(s.ctx, &AddCoHostInput{GameID: gameID, PlayerID: "alice", CoHostID: coHostID})
+	s.Require().NoError(err)
+}
+
+func (s *HostTestSuite) TestCoHostCanStartGame() {
+	gameID := s.createGame("alice", "bob", "carol")
+
+	_, err := s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrNotCreator)
+
+	s.addCoHost(gameID, "bob")
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, s.getGame(gameID).Status)
+}
+
+func (s *HostTestSuite) TestAddCoHost() {
+	gameID := s.createGame("alice", "bob", "carol")
+
+	_, err := s.gameService.AddCoHost(s.ctx, &AddCoHostInput{GameID: gameID, PlayerID: "alice", CoHostID: "dave"})
+	s.ErrorIs(err, ErrPlayerNotInGame, "co-hosts have to be in the game")
+
+	s.addCoHost(gameID, "bob")
+	s.addCoHost(gameID, "bob")
+	s.Equal([]string{"bob"}, s.getGame(gameID).CoHostIDs)
+
+	// Co-hosts can't deputize anyone themselves
+	_, err = s.gameService.AddCoHost(s.ctx, &AddCoHostInput{GameID: gameID, PlayerID: "bob", CoHostID: "carol"})
+	s.ErrorIs(err, ErrNotCreator)
+
+	output, err := s.gameService.RemoveCoHost(s.ctx, &RemoveCoHostInput{GameID: gameID, PlayerID: "alice", CoHostID: "bob"})
+	s.Require().NoError(err)
+	s.Empty(output.CoHostIDs)
+	s.False(s.getGame(gameID).IsHost("bob"))
+}
+
+func (s *HostTestSuite) TestPauseAndResume() {
+	gameID := s.createGame("alice", "bob", "carol")
+	s.addCoHost(gameID, "bob")
+
+	_, err := s.gameService.PauseGame(s.ctx, &PauseGameInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrGameNotStarted)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.PauseGame(s.ctx, &PauseGameInput{GameID: gameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrNotCreator)
+
+	pauseOutput, err := s.gameService.PauseGame(s.ctx, &PauseGameInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(s.clock.Now(), pauseOutput.PausedAt)
+
+	_, err = s.gameService.PauseGame(s.ctx, &PauseGameInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrGamePaused)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrGamePaused, "nobody rolls while the game is paused")
+
+	s.clock.Advance(10 * time.Minute)
+	resumeOutput, err := s.gameService.ResumeGame(s.ctx, &ResumeGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(10*time.Minute, resumeOutput.PausedFor)
+
+	game := s.getGame(gameID)
+	s.False(game.IsPaused())
+	s.Equal(10*time.Minute, game.PausedFor)
+
+	_, err = s.gameService.ResumeGame(s.ctx, &ResumeGameInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrGameNotPaused)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "carol"})
+	s.NoError(err)
+}
+
+func (s *HostTestSuite) TestAbandonGame() {
+	gameID := s.createGame("alice", "bob", "carol")
+	s.addCoHost(gameID, "bob")
+
+	_, err := s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrNotCreator)
+
+	// Anyone can clear out a game that's been left idle
+	s.clock.Advance(idleGameAge + time.Second)
+	_, err = s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID, PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusAbandoned, s.getGame(gameID).Status)
+}
+
+func (s *HostTestSuite) TestCoHostCanAbandonGame() {
+	gameID := s.createGame("alice", "bob", "carol")
+	s.addCoHost(gameID, "bob")
+
+	_, err := s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusAbandoned, s.getGame(gameID).Status)
+}
+
+func (s *HostTestSuite) TestWrapUpGame() {
+	gameID := s.createGame("alice", "bob", "carol")
+	s.addCoHost(gameID, "bob")
+	_, err := s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.PauseGame(s.ctx, &PauseGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.WrapUpGame(s.ctx, &WrapUpGameInput{GameID: gameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrNotCreator)
+
+	output, err := s.gameService.WrapUpGame(s.ctx, &WrapUpGameInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal([]string{"carol"}, output.SkippedPlayerIDs)
+
+	game := s.getGame(gameID)
+	s.True(game.Status.IsFinished())
+	s.False(game.IsPaused())
+
+	s.Require().Len(s.timedOut, 1)
+	s.Equal("bob", s.timedOut[0].EndedBy)
+	s.Equal([]string{"carol"}, s.timedOut[0].SkippedPlayerIDs)
+}
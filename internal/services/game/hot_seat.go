@@ -0,0 +1,159 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// AddHotSeatPlayers sits players without the bot down at a game waiting to start, with a Discord user in it rolling
+// for them from their device. Names already at the table are left out, and the players only sit down if there's
+// room for all of them.
+func (s *service) AddHotSeatPlayers(ctx context.Context, input *AddHotSeatPlayersInput) (*AddHotSeatPlayersOutput, error) {
+	if input == nil || input.GameID == "" || input.HostID == "" {
+		return nil, errors.New("game ID and host ID are required")
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	switch game.Status {
+	case models.GameStatusWaiting:
+	case models.GameStatusActive:
+		return nil, ErrGameActive
+	case models.GameStatusRollOff:
+		return nil, ErrGameRollOff
+	default:
+		return nil, ErrGameCompleted
+	}
+
+	// The players are rolled for by someone playing at the same table
+	if !game.HasParticipant(input.HostID) {
+		return nil, ErrPlayerNotInGame
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range input.PlayerNames {
+		name = strings.TrimSpace(name)
+		if name == "" || utf8.RuneCountInString(name) > models.MaxHotSeatNameLength {
+			return nil, ErrInvalidHotSeatName
+		}
+
+		playerID := models.HotSeatPlayerID(input.HostID, name)
+		if seen[playerID] || game.HasParticipant(playerID) {
+			continue
+		}
+		seen[playerID] = true
+		names = append(names, name)
+	}
+
+	if len(game.Participants)+len(names) > s.rulesFor(game).MaxPlayers {
+		return nil, ErrGameFull
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		for _, name := range names {
+			playerID := models.HotSeatPlayerID(input.HostID, name)
+
+			// Hot seat players keep their player between games, like anyone else
+			player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+				PlayerID: playerID,
+			})
+			if err != nil {
+				player = &models.Player{
+					ID:           playerID,
+					LastRollTime: s.clock.Now(),
+				}
+			}
+			player.Name = name
+			player.CurrentGameID = game.ID
+
+			if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+				Player: player,
+			}); err != nil {
+				return err
+			}
+
+			if _, err := s.gameRepo.CreateParticipant(ctx, &gameRepo.CreateParticipantInput{
+				GameID:     game.ID,
+				PlayerID:   playerID,
+				PlayerName: name,
+				Status:     models.ParticipantStatusWaitingToRoll,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	output := &AddHotSeatPlayersOutput{
+		Game: game,
+	}
+	for _, name := range names {
+		output.Added = append(output.Added, game.GetParticipant(models.HotSeatPlayerID(input.HostID, name)))
+	}
+	return output, nil
+}
+
+// RollHotSeat rolls for the first of a Discord user's hot seat players with a roll to make, in the game or whichever
+// of its roll-offs they're in
+func (s *service) RollHotSeat(ctx context.Context, input *RollHotSeatInput) (*RollDiceOutput, error) {
+	if input == nil || input.GameID == "" || input.HostID == "" {
+		return nil, errors.New("game ID and host ID are required")
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	rollOffs, err := s.rollOffTree(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range append([]*models.Game{game}, rollOffs...) {
+		if !s.lifecycle.IsPlaying(candidate.Status) {
+			continue
+		}
+
+		for _, participant := range candidate.HotSeatPlayers(input.HostID) {
+			if participant.RollTime != nil {
+				continue
+			}
+			if candidate.SuddenDeath != nil && candidate.NextRoller() != participant {
+				continue
+			}
+
+			return s.RollDice(ctx, &RollDiceInput{
+				GameID:   candidate.ID,
+				PlayerID: participant.PlayerID,
+				HostID:   input.HostID,
+			})
+		}
+	}
+
+	return nil, ErrNoHotSeatRolls
+}
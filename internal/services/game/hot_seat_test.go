@@ -0,0 +1,142 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// HotSeatTestSuite tests players rolled for from someone else's device against the real Redis repositories
+type HotSeatTestSuite struct {
+	serviceHarness
+
+	gameID string
+	dan    string
+	erin   string
+}
+
+func (s *HotSeatTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "hot-seat-channel"
+	s.testGuildID = "hot-seat-guild"
+
+	s.gameID = s.createGame("alice")
+	s.dan = models.HotSeatPlayerID("alice", "Dan")
+	s.erin = models.HotSeatPlayerID("alice", "Erin")
+}
+
+func TestHotSeatTestSuite(t *testing.T) {
+	suite.Run(t, new(HotSeatTestSuite))
+}
+
+func (s *HotSeatTestSuite) TestAddPlayers() {
+	output, err := s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "alice",
+		PlayerNames: []string{"Dan", " Erin ", "dan"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(output.Added, 2)
+	s.Equal(s.dan, output.Added[0].PlayerID)
+	s.Equal("Dan", output.Added[0].PlayerName)
+	s.Equal(s.erin, output.Added[1].PlayerID)
+	s.Equal("Erin", output.Added[1].PlayerName)
+	s.Len(output.Game.HotSeatPlayers("alice"), 2)
+
+	// Adding them again leaves them be
+	output, err = s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "alice",
+		PlayerNames: []string{"Dan"},
+	})
+	s.Require().NoError(err)
+	s.Empty(output.Added)
+	s.Len(output.Game.Participants, 3)
+}
+
+func (s *HotSeatTestSuite) TestAddPlayersErrors() {
+	_, err := s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "bob",
+		PlayerNames: []string{"Dan"},
+	})
+	s.ErrorIs(err, ErrPlayerNotInGame)
+
+	_, err = s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "alice",
+		PlayerNames: []string{"Dan", strings.Repeat("x", models.MaxHotSeatNameLength+1)},
+	})
+	s.ErrorIs(err, ErrInvalidHotSeatName)
+
+	_, err = s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "alice",
+		PlayerNames: []string{"Dan", "  "},
+	})
+	s.ErrorIs(err, ErrInvalidHotSeatName)
+}
+
+func (s *HotSeatTestSuite) TestHostRollsForTheTable() {
+	_, err := s.gameService.AddHotSeatPlayers(s.ctx, &AddHotSeatPlayersInput{
+		GameID:      s.gameID,
+		HostID:      "alice",
+		PlayerNames: []string{"Dan", "Erin"},
+	})
+	s.Require().NoError(err)
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: s.gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// Only the host rolls for the table
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: s.gameID, PlayerID: s.dan})
+	s.ErrorIs(err, ErrNotHotSeatHost)
+	_, err = s.gameService.RollHotSeat(s.ctx, &RollHotSeatInput{GameID: s.gameID, HostID: "bob"})
+	s.ErrorIs(err, ErrNoHotSeatRolls)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: s.gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// The hot seat players roll in the order they sat down
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	output, err := s.gameService.RollHotSeat(s.ctx, &RollHotSeatInput{GameID: s.gameID, HostID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(s.dan, output.PlayerID)
+	s.True(output.IsCriticalHit)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(2)
+	output, err = s.gameService.RollHotSeat(s.ctx, &RollHotSeatInput{GameID: s.gameID, HostID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(s.erin, output.PlayerID)
+
+	_, err = s.gameService.RollHotSeat(s.ctx, &RollHotSeatInput{GameID: s.gameID, HostID: "alice"})
+	s.ErrorIs(err, ErrNoHotSeatRolls)
+
+	// So does giving away their drinks
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: s.gameID, FromPlayerID: s.dan, ToPlayerID: s.erin, Reason: DrinkReasonCriticalHit})
+	s.ErrorIs(err, ErrNotHotSeatHost)
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: s.gameID, FromPlayerID: s.dan, ToPlayerID: s.erin, Reason: DrinkReasonCriticalHit, HostID: "alice"})
+	s.Require().NoError(err)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: s.gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, game.Status)
+
+	// Their drinks go on the ledger like anyone else's
+	records, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: s.gameID})
+	s.Require().NoError(err)
+	var erinsDrinks []models.DrinkReason
+	for _, record := range records.Records {
+		s.Equal(s.erin, record.ToPlayerID)
+		erinsDrinks = append(erinsDrinks, record.Reason)
+	}
+	s.ElementsMatch([]models.DrinkReason{models.DrinkReasonCriticalHit, models.DrinkReasonLowestRoll}, erinsDrinks)
+}
@@ -0,0 +1,611 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// GameIntegrationTestSuite runs the game service against the real Redis repositories
+type GameIntegrationTestSuite struct {
+	serviceHarness
+}
+
+func (s *GameIntegrationTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "integration-channel"
+}
+
+func TestGameIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(GameIntegrationTestSuite))
+}
+
+// drinksByReason returns the drink records of a game grouped by recipient and reason
+func (s *GameIntegrationTestSuite) drinksByReason(gameID string) map[string]models.DrinkReason {
+	output, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{
+		GameID: gameID,
+	})
+	s.Require().NoError(err)
+
+	drinks := make(map[string]models.DrinkReason)
+	for _, record := range output.Records {
+		drinks[record.ToPlayerID] = record.Reason
+	}
+	return drinks
+}
+
+func (s *GameIntegrationTestSuite) TestFullGame_CriticalHitAndLowestRoll() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.expectRolls(6, 2, 4)
+
+	completed := 0
+	s.eventBus.Subscribe(events.TypeGameCompleted, func(ctx context.Context, event *events.Event) {
+		completed++
+	})
+
+	rollOutput, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(rollOutput.IsCriticalHit)
+	s.Len(rollOutput.EligiblePlayers, 2)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "carol"})
+	s.Require().NoError(err)
+
+	// The game waits for alice to assign her drink
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, gameOutput.Game.Status)
+
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   "carol",
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+
+	gameOutput, err = s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, gameOutput.Game.Status)
+	s.Equal(1, completed)
+
+	drinks := s.drinksByReason(gameID)
+	s.Equal(models.DrinkReasonCriticalHit, drinks["carol"])
+	s.Equal(models.DrinkReasonLowestRoll, drinks["bob"])
+	s.NotContains(drinks, "alice")
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+	})
+	s.Require().NoError(err)
+	s.Len(leaderboard.Entries, 2)
+}
+
+func (s *GameIntegrationTestSuite) TestGameCreatedAndDrinkAssignedEvents() {
+	var created []*events.GameCreatedPayload
+	s.eventBus.Subscribe(events.TypeGameCreated, func(ctx context.Context, event *events.Event) {
+		created = append(created, event.Payload.(*events.GameCreatedPayload))
+	})
+
+	var drinks []*events.DrinkAssignedPayload
+	s.eventBus.Subscribe(events.TypeDrinkAssigned, func(ctx context.Context, event *events.Event) {
+		drinks = append(drinks, event.Payload.(*events.DrinkAssignedPayload))
+	})
+
+	gameID := s.startGame("alice", "bob")
+	s.expectRolls(6, 2)
+
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   "bob",
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(created, 1)
+	s.Equal("alice", created[0].CreatorID)
+
+	// The critical hit's drink and bob's lowest roll
+	s.Require().Len(drinks, 2)
+	s.Equal(&events.DrinkAssignedPayload{FromPlayerID: "alice", ToPlayerID: "bob", Reason: string(models.DrinkReasonCriticalHit)}, drinks[0])
+	s.Equal(&events.DrinkAssignedPayload{ToPlayerID: "bob", Reason: string(models.DrinkReasonLowestRoll)}, drinks[1])
+}
+
+func (s *GameIntegrationTestSuite) TestFullGame_LowestRollOff() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.expectRolls(3, 3, 5, 2, 4)
+
+	var rolls []*events.DiceRolledPayload
+	s.eventBus.Subscribe(events.TypeDiceRolled, func(ctx context.Context, event *events.Event) {
+		rolls = append(rolls, event.Payload.(*events.DiceRolledPayload))
+	})
+
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusRollOff, gameOutput.Game.Status)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	gameOutput, err = s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, gameOutput.Game.Status)
+
+	// alice lost the roll-off
+	drinks := s.drinksByReason(gameID)
+	for playerID, reason := range s.drinksByReason(rollOffGameID) {
+		drinks[playerID] = reason
+	}
+	s.Equal(models.DrinkReasonLowestRoll, drinks["alice"])
+	s.NotContains(drinks, "bob")
+	s.NotContains(drinks, "carol")
+
+	// Every roll was published, roll-off rolls point back at the game they came from
+	s.Require().Len(rolls, 5)
+	for i, roll := range rolls {
+		s.Equal(gameID, roll.RootGameID)
+		s.Equal(i >= 3, roll.RollOff)
+	}
+	s.Equal(2, rolls[3].RollValue)
+}
+
+func (s *GameIntegrationTestSuite) TestPayDrink() {
+	gameID := s.startGame("alice", "bob")
+	s.expectRolls(1, 4)
+
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(payOutput.Success)
+	s.True(payOutput.DrinkRecord.Paid)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(leaderboard.Entries)
+	s.Equal("alice", leaderboard.Entries[0].PlayerID)
+	s.Equal(1, leaderboard.Entries[0].PaidCount)
+}
+
+func (s *GameIntegrationTestSuite) TestLedgerChangedEvents() {
+	var changes []string
+	sessionIDs := map[string]bool{}
+	s.eventBus.Subscribe(events.TypeLedgerChanged, func(ctx context.Context, event *events.Event) {
+		s.Equal(s.testChannelID, event.ChannelID)
+		sessionIDs[event.SessionID] = true
+		changes = append(changes, event.Payload.(*events.LedgerChangedPayload).Change)
+	})
+
+	gameID := s.startGame("alice", "bob")
+	s.expectRolls(1, 4)
+
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	// alice's critical fail and lowest roll
+	s.Equal([]string{"drink_recorded", "drink_recorded"}, changes)
+
+	_, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal("drink_paid", changes[len(changes)-1])
+
+	_, err = s.gameService.StartNewSession(s.ctx, &StartNewSessionInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal("session_started", changes[len(changes)-1])
+	s.Len(sessionIDs, 2, "the drinks' session and the new one")
+}
+
+func (s *GameIntegrationTestSuite) TestNestedRollOff_CompletesMainGame() {
+	gameID := s.startGame("alice", "bob", "carol", "dave")
+	// alice, bob and carol tie for lowest, alice and bob tie again in the roll-off, the nested roll-off settles it
+	s.expectRolls(2, 2, 2, 5, 3, 3, 5, 4, 1)
+
+	for _, playerID := range []string{"alice", "bob", "carol", "dave"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	rollOffOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusRollOff, rollOffOutput.Game.Status)
+	nestedRollOffGameID := rollOffOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(nestedRollOffGameID)
+
+	for _, playerID := range []string{"alice", "bob"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: nestedRollOffGameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	// Every level of the game is over
+	for _, id := range []string{nestedRollOffGameID, rollOffGameID, gameID} {
+		output, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: id})
+		s.Require().NoError(err)
+		s.Equal(models.GameStatusCompleted, output.Game.Status, "game %s", id)
+	}
+
+	// bob lost the nested roll-off and drinks against the main game
+	drinks := s.drinksByReason(gameID)
+	s.Equal(models.DrinkReasonLowestRoll, drinks["bob"])
+	s.NotContains(drinks, "alice")
+	s.NotContains(drinks, "carol")
+}
+
+func (s *GameIntegrationTestSuite) TestChannelGames_ActiveAndCompleted() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.expectRolls(3, 3, 5, 2, 4)
+
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	// The channel's game is still the main game while its roll-off is played
+	activeOutput, err := s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal(gameID, activeOutput.Game.ID)
+	s.Equal(models.GameStatusRollOff, activeOutput.Game.Status)
+	rollOffGameID := activeOutput.Game.LowestRollOffGameID
+
+	_, err = s.gameService.GetLatestCompletedGameByChannel(s.ctx, &GetLatestCompletedGameByChannelInput{ChannelID: s.testChannelID})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	for _, playerID := range []string{"alice", "bob"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	// Once it's over the channel has no game being played
+	_, err = s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	completedOutput, err := s.gameService.GetLatestCompletedGameByChannel(s.ctx, &GetLatestCompletedGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal(gameID, completedOutput.Game.ID)
+
+	// A new game is the channel's game while the finished one is still found
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "bob",
+		CreatorName: "bob",
+	})
+	s.Require().NoError(err)
+
+	activeOutput, err = s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal(createOutput.GameID, activeOutput.Game.ID)
+
+	completedOutput, err = s.gameService.GetLatestCompletedGameByChannel(s.ctx, &GetLatestCompletedGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal(gameID, completedOutput.Game.ID)
+}
+
+func (s *GameIntegrationTestSuite) TestAbandonGame_ArchivesGameAndRollOffs() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.expectRolls(3, 3, 5)
+
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	_, err = s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	// The game and its roll-off are kept as history
+	for _, id := range []string{gameID, rollOffGameID} {
+		output, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: id})
+		s.Require().NoError(err)
+		s.Equal(models.GameStatusAbandoned, output.Game.Status)
+	}
+
+	_, err = s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: "dave", PlayerName: "dave"})
+	s.ErrorIs(err, ErrGameAbandoned)
+
+	// Abandoning it again changes nothing
+	_, err = s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	// Nothing is purged until it's past the retention period
+	purgeOutput, err := s.gameService.PurgeArchivedGames(s.ctx, &PurgeArchivedGamesInput{
+		ArchivedBefore: time.Now().Add(-time.Hour),
+	})
+	s.Require().NoError(err)
+	s.Zero(purgeOutput.DeletedCount)
+
+	purgeOutput, err = s.gameService.PurgeArchivedGames(s.ctx, &PurgeArchivedGamesInput{
+		ArchivedBefore: time.Now().Add(time.Minute),
+	})
+	s.Require().NoError(err)
+	s.Equal(2, purgeOutput.DeletedCount)
+
+	_, err = s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.ErrorIs(err, ErrGameNotFound)
+}
+
+func (s *GameIntegrationTestSuite) TestRollDice_BeforeStart() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: createOutput.GameID, PlayerID: "alice"})
+
+	s.ErrorIs(err, ErrGameNotStarted)
+}
+
+func (s *GameIntegrationTestSuite) TestRollDice_Concurrent() {
+	players := []string{"alice", "bob", "carol", "dave", "erin", "frank"}
+	gameID := s.startGame(players...)
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3).AnyTimes()
+
+	// Everyone but frank rolls at once, with a double click each
+	var wg sync.WaitGroup
+	rolled := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(playerID string) {
+			defer wg.Done()
+			_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+			if err == nil {
+				rolled <- playerID
+				return
+			}
+			s.ErrorIs(err, ErrPlayerAlreadyRolled)
+		}(players[i%5])
+	}
+	wg.Wait()
+	close(rolled)
+
+	rollers := []string{}
+	for playerID := range rolled {
+		rollers = append(rollers, playerID)
+	}
+	s.ElementsMatch(players[:5], rollers)
+
+	// Every roll was kept, so nobody gets to roll again
+	output, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	for _, participant := range output.Game.Participants {
+		if participant.PlayerID == "frank" {
+			s.Nil(participant.RollTime)
+			continue
+		}
+		s.NotNil(participant.RollTime, participant.PlayerID)
+
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: participant.PlayerID})
+		s.ErrorIs(err, ErrPlayerAlreadyRolled, participant.PlayerID)
+	}
+}
+
+func (s *GameIntegrationTestSuite) TestCustomRules() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{DiceSides: 4, MaxPlayers: 2, CriticalHitValues: []int{4}, CriticalFailValues: []int{1}},
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.GameID
+
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().NoError(err)
+
+	// The preset caps the game at 2 players
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: "carol", PlayerName: "carol"})
+	s.ErrorIs(err, ErrGameFull)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// Rolls use the game's d4, where a 4 is the critical hit
+	gomock.InOrder(
+		s.mockDiceRoller.EXPECT().Roll(4).Return(4),
+		s.mockDiceRoller.EXPECT().Roll(4).Return(2),
+	)
+
+	rollOutput, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(rollOutput.IsCriticalHit)
+
+	rollOutput, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.False(rollOutput.IsCriticalHit)
+}
+
+func (s *GameIntegrationTestSuite) TestCustomRules_RollOffInheritsRules() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{DiceSides: 20, CriticalHitValues: []int{20}, CriticalFailValues: []int{1}},
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.GameID
+
+	for _, playerID := range []string{"bob", "carol"} {
+		_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: playerID, PlayerName: playerID})
+		s.Require().NoError(err)
+	}
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	gomock.InOrder(
+		s.mockDiceRoller.EXPECT().Roll(20).Return(8),
+		s.mockDiceRoller.EXPECT().Roll(20).Return(8),
+		s.mockDiceRoller.EXPECT().Roll(20).Return(15),
+	)
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	rollOffOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	s.Equal(gameOutput.Game.Rules, rollOffOutput.Game.Rules)
+
+	s.mockDiceRoller.EXPECT().Roll(20).Return(12)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+}
+
+func (s *GameIntegrationTestSuite) TestCustomRules_Invalid() {
+	// A critical hit of 6 (the default) isn't on a d4
+	_, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{DiceSides: 4},
+	})
+	s.ErrorIs(err, ErrInvalidGameRules)
+	s.Equal(models.ErrorCodeInvalidInput, models.ErrorCodeOf(err))
+}
+
+func (s *GameIntegrationTestSuite) TestCustomRules_MultipleCrits() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{DiceSides: 10, CriticalHitValues: []int{9, 10}, CriticalFailValues: []int{1, 2}},
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.GameID
+
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.mockDiceRoller.EXPECT().Roll(10).Return(9)
+	rollOutput, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(rollOutput.IsCriticalHit)
+
+	s.mockDiceRoller.EXPECT().Roll(10).Return(2)
+	rollOutput, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.True(rollOutput.IsCriticalFail)
+}
+
+func (s *GameIntegrationTestSuite) TestCustomRules_OverlappingCrits() {
+	_, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{CriticalHitValues: []int{5, 6}, CriticalFailValues: []int{1, 5}},
+	})
+	s.ErrorIs(err, ErrInvalidGameRules)
+}
+
+func (s *GameIntegrationTestSuite) TestGetGameTree_NestedRollOffs() {
+	gameID := s.startGame("alice", "bob", "carol", "dave")
+	// alice, bob and carol tie for lowest, then alice and bob tie again in the roll-off
+	s.expectRolls(2, 2, 2, 5, 3, 3, 5, 4)
+
+	for _, playerID := range []string{"alice", "bob", "carol", "dave"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+
+	for _, playerID := range []string{"alice", "bob", "carol"} {
+		_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+
+	rollOffOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	nestedRollOffGameID := rollOffOutput.Game.LowestRollOffGameID
+
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: nestedRollOffGameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	tree, err := s.gameService.GetGameTree(s.ctx, &GetGameTreeInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(gameID, tree.Game.ID)
+	s.Require().Len(tree.RollOffs, 2)
+
+	// The roll-off comes before the one started from it
+	rollOff, nested := tree.RollOffs[0], tree.RollOffs[1]
+	s.Equal(rollOffGameID, rollOff.ID)
+	s.Equal(nestedRollOffGameID, nested.ID)
+	s.Len(rollOff.Participants, 3)
+
+	// alice has rolled in the nested roll-off, bob hasn't yet
+	s.NotNil(nested.GetParticipant("alice").RollTime)
+	s.Equal(4, nested.GetParticipant("alice").RollValue)
+	s.Nil(nested.GetParticipant("bob").RollTime)
+
+	// A roll-off's tree only has the roll-offs below it
+	subtree, err := s.gameService.GetGameTree(s.ctx, &GetGameTreeInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	s.Require().Len(subtree.RollOffs, 1)
+	s.Equal(nestedRollOffGameID, subtree.RollOffs[0].ID)
+
+	_, err = s.gameService.GetGameTree(s.ctx, &GetGameTreeInput{GameID: "missing-game"})
+	s.ErrorIs(err, ErrGameNotFound)
+}
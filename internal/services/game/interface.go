@@ -13,6 +13,30 @@ type Service interface {
 	// JoinGame adds a player to an existing game
 	JoinGame(ctx context.Context, input *JoinGameInput) (*JoinGameOutput, error)
 
+	// AddHotSeatPlayers sits players without the bot down at a game, with a Discord user in it rolling for them
+	AddHotSeatPlayers(ctx context.Context, input *AddHotSeatPlayersInput) (*AddHotSeatPlayersOutput, error)
+
+	// RollHotSeat rolls for the next of a Discord user's hot seat players who has a roll to make, in the game or
+	// its roll-offs
+	RollHotSeat(ctx context.Context, input *RollHotSeatInput) (*RollDiceOutput, error)
+
+	// AddRonnie sits Ronnie, the bot's own player, down at a game. He rolls on his own once it starts.
+	AddRonnie(ctx context.Context, input *AddRonnieInput) (*AddRonnieOutput, error)
+
+	// LeaveGame takes a player out of a game that hasn't started, or off its waitlist, giving their spot to
+	// the first waitlisted player
+	LeaveGame(ctx context.Context, input *LeaveGameInput) (*LeaveGameOutput, error)
+
+	// RemoveDepartedPlayer takes a player who left a server out of the game they were playing there and forgives
+	// the drinks they still owe in its session
+	RemoveDepartedPlayer(ctx context.Context, input *RemoveDepartedPlayerInput) (*RemoveDepartedPlayerOutput, error)
+
+	// RenamePlayer updates the name saved for a player who changed it, and the names kept on their game and session
+	RenamePlayer(ctx context.Context, input *RenamePlayerInput) (*RenamePlayerOutput, error)
+
+	// JoinWaitlist puts a player on the waitlist of a full game
+	JoinWaitlist(ctx context.Context, input *JoinWaitlistInput) (*JoinWaitlistOutput, error)
+
 	// StartGame transitions a game from waiting to active state
 	StartGame(ctx context.Context, input *StartGameInput) (*StartGameOutput, error)
 
@@ -34,21 +58,39 @@ type Service interface {
 	// GetGameByChannel retrieves a game by its Discord channel ID
 	GetGameByChannel(ctx context.Context, input *GetGameByChannelInput) (*GetGameByChannelOutput, error)
 
+	// GetActiveGameByChannel retrieves the game still being played in a channel, roll-offs included
+	GetActiveGameByChannel(ctx context.Context, input *GetActiveGameByChannelInput) (*GetActiveGameByChannelOutput, error)
+
+	// GetLatestCompletedGameByChannel retrieves the last game finished in a channel
+	GetLatestCompletedGameByChannel(ctx context.Context, input *GetLatestCompletedGameByChannelInput) (*GetLatestCompletedGameByChannelOutput, error)
+
 	// GetGame retrieves a game by its ID
 	GetGame(ctx context.Context, input *GetGameInput) (*GetGameOutput, error)
 
+	// GetGameRules returns the rules a game is played with, the service defaults filled in for anything it doesn't set
+	GetGameRules(ctx context.Context, input *GetGameRulesInput) (*GetGameRulesOutput, error)
+
 	// GetLeaderboard retrieves the leaderboard for a game
 	GetLeaderboard(ctx context.Context, input *GetLeaderboardInput) (*GetLeaderboardOutput, error)
 
-	// AbandonGame forcefully abandons a game regardless of its state
+	// AbandonGame archives a game that hasn't finished, along with its roll-offs
 	AbandonGame(ctx context.Context, input *AbandonGameInput) (*AbandonGameOutput, error)
 
+	// PurgeArchivedGames permanently deletes games abandoned before a time, for the retention policy
+	PurgeArchivedGames(ctx context.Context, input *PurgeArchivedGamesInput) (*PurgeArchivedGamesOutput, error)
+
 	// UpdateGameMessage updates the Discord message ID associated with a game
 	UpdateGameMessage(ctx context.Context, input *UpdateGameMessageInput) (*UpdateGameMessageOutput, error)
 
 	// GetDrinkRecords retrieves all drink records for a game
 	GetDrinkRecords(ctx context.Context, input *GetDrinkRecordsInput) (*GetDrinkRecordsOutput, error)
 
+	// GetGameView loads a game with everything needed to render it
+	GetGameView(ctx context.Context, input *GetGameViewInput) (*GetGameViewOutput, error)
+
+	// GetGameTree loads a game with all of its roll-offs, nested roll-offs included
+	GetGameTree(ctx context.Context, input *GetGameTreeInput) (*GetGameTreeOutput, error)
+
 	// GetPlayerTab retrieves a player's current tab (drinks owed and received)
 	GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*GetPlayerTabOutput, error)
 
@@ -64,6 +106,156 @@ type Service interface {
 	// GetSessionLeaderboard retrieves the leaderboard for the current session
 	GetSessionLeaderboard(ctx context.Context, input *GetSessionLeaderboardInput) (*GetSessionLeaderboardOutput, error)
 
-	// StartNewSession creates a new drinking session for a channel (alias for CreateSession with a clearer name)
+	// PauseSession pauses the channel's session for a break, no games are started and nobody is reminded of drinks
+	// until it's resumed
+	PauseSession(ctx context.Context, input *PauseSessionInput) (*PauseSessionOutput, error)
+
+	// ResumeSession ends the break the channel's session was paused for
+	ResumeSession(ctx context.Context, input *ResumeSessionInput) (*ResumeSessionOutput, error)
+
+	// StartNewSession creates a new drinking session for a channel (alias for CreateSession with a clearer name),
+	// played with any challenges picked for it
 	StartNewSession(ctx context.Context, input *StartNewSessionInput) (*StartNewSessionOutput, error)
+
+	// SetDesignatedDriver makes a player the designated driver for the channel's session
+	SetDesignatedDriver(ctx context.Context, input *SetDesignatedDriverInput) (*SetDesignatedDriverOutput, error)
+
+	// ClearDesignatedDriver lets a designated driver start drinking again, their drinks owed later become payable
+	ClearDesignatedDriver(ctx context.Context, input *ClearDesignatedDriverInput) (*ClearDesignatedDriverOutput, error)
+
+	// CoverDesignatedDriver has a player volunteer to take a designated driver's drinks
+	CoverDesignatedDriver(ctx context.Context, input *CoverDesignatedDriverInput) (*CoverDesignatedDriverOutput, error)
+
+	// MergePlayers moves an alt account's drink records and stats under the canonical player
+	MergePlayers(ctx context.Context, input *MergePlayersInput) (*MergePlayersOutput, error)
+
+	// RerollDice spends one of the player's re-roll tokens to void their roll and roll again
+	RerollDice(ctx context.Context, input *RerollDiceInput) (*RollDiceOutput, error)
+
+	// GrantRerollTokens gives a player re-roll tokens
+	GrantRerollTokens(ctx context.Context, input *GrantRerollTokensInput) (*GrantRerollTokensOutput, error)
+
+	// GetRerollTokens returns how many re-roll tokens a player holds
+	GetRerollTokens(ctx context.Context, input *GetRerollTokensInput) (*GetRerollTokensOutput, error)
+
+	// AddRollModifier attaches a temporary buff or debuff to a player's next rolls
+	AddRollModifier(ctx context.Context, input *AddRollModifierInput) (*AddRollModifierOutput, error)
+
+	// ForgetPlayer deletes a player's profile and anonymizes everything else that refers to them
+	ForgetPlayer(ctx context.Context, input *ForgetPlayerInput) (*ForgetPlayerOutput, error)
+
+	// ForgiveDrinks writes off a player's outstanding drinks, a single drink or their whole tab
+	ForgiveDrinks(ctx context.Context, input *ForgiveDrinksInput) (*ForgiveDrinksOutput, error)
+
+	// RequestDrinkTransfer offers one of a player's drinks to a volunteer
+	RequestDrinkTransfer(ctx context.Context, input *RequestDrinkTransferInput) (*RequestDrinkTransferOutput, error)
+
+	// AcceptDrinkTransfer has the volunteer take the offered drink
+	AcceptDrinkTransfer(ctx context.Context, input *AcceptDrinkTransferInput) (*AcceptDrinkTransferOutput, error)
+
+	// DeclineDrinkTransfer turns down or calls off a drink transfer
+	DeclineDrinkTransfer(ctx context.Context, input *DeclineDrinkTransferInput) (*DeclineDrinkTransferOutput, error)
+
+	// OfferGuestDrink offers a critical hit's drink to someone in the channel who isn't playing
+	OfferGuestDrink(ctx context.Context, input *OfferGuestDrinkInput) (*OfferGuestDrinkOutput, error)
+
+	// AcceptGuestDrink has a guest take the drink they were offered
+	AcceptGuestDrink(ctx context.Context, input *AcceptGuestDrinkInput) (*AcceptGuestDrinkOutput, error)
+
+	// DeclineGuestDrink turns down a guest drink, either the guest or the player who offered it can call it off
+	DeclineGuestDrink(ctx context.Context, input *DeclineGuestDrinkInput) (*DeclineGuestDrinkOutput, error)
+
+	// CallSocialRound gives everyone playing the channel's game a drink at once
+	CallSocialRound(ctx context.Context, input *CallSocialRoundInput) (*CallSocialRoundOutput, error)
+
+	// LastCall puts the channel's session into last call, closing it once a final game ends
+	LastCall(ctx context.Context, input *LastCallInput) (*LastCallOutput, error)
+
+	// GetRolls returns a server's logged rolls, optionally narrowed to a player, game or time range
+	GetRolls(ctx context.Context, input *GetRollsInput) (*GetRollsOutput, error)
+
+	// ClipMoment keeps the latest roll in the channel's game, or its last finished game, as a moment to look back on
+	ClipMoment(ctx context.Context, input *ClipMomentInput) (*ClipMomentOutput, error)
+
+	// GetMoments returns a page of a server's clipped moments, newest first
+	GetMoments(ctx context.Context, input *GetMomentsInput) (*GetMomentsOutput, error)
+
+	// SetBirthday saves or clears the day a player celebrates their birthday
+	SetBirthday(ctx context.Context, input *SetBirthdayInput) (*SetBirthdayOutput, error)
+
+	// GetBirthday returns a player's birthday and whether it's today
+	GetBirthday(ctx context.Context, input *GetBirthdayInput) (*GetBirthdayOutput, error)
+
+	// SetDrinkReminders changes how a player is reminded of drinks they owe, or mutes their reminders
+	SetDrinkReminders(ctx context.Context, input *SetDrinkRemindersInput) (*SetDrinkRemindersOutput, error)
+
+	// GetDrinkReminders returns how a player is reminded of drinks they owe
+	GetDrinkReminders(ctx context.Context, input *GetDrinkRemindersInput) (*GetDrinkRemindersOutput, error)
+
+	// ApplyBotConfig swaps in settings changed while the bot is running over the ones it was started with
+	ApplyBotConfig(ctx context.Context, input *ApplyBotConfigInput) (*ApplyBotConfigOutput, error)
+
+	// RecoverGames repairs games left stuck by a restart and returns the games whose messages need redrawing
+	RecoverGames(ctx context.Context, input *RecoverGamesInput) (*RecoverGamesOutput, error)
+
+	// CleanupGuildGames repairs or abandons a server's games left stuck, such as by an outage, leaving games being played
+	CleanupGuildGames(ctx context.Context, input *CleanupGuildGamesInput) (*CleanupGuildGamesOutput, error)
+
+	// VerifyLedger cross-checks drink records against their games, sessions and players, optionally repairing them
+	VerifyLedger(ctx context.Context, input *VerifyLedgerInput) (*VerifyLedgerOutput, error)
+
+	// CheckGameDurations warns the slow players of games running out of time and ends the games out of time
+	CheckGameDurations(ctx context.Context, input *CheckGameDurationsInput) (*CheckGameDurationsOutput, error)
+
+	// CheckDrinkReminders reminds players of drinks they've owed longer than the reminder delay
+	CheckDrinkReminders(ctx context.Context, input *CheckDrinkRemindersInput) (*CheckDrinkRemindersOutput, error)
+
+	// RotateDueSessions rotates every session the rotation config says has run its course, so quiet channels
+	// still get their closing leaderboard
+	RotateDueSessions(ctx context.Context, input *RotateDueSessionsInput) (*RotateDueSessionsOutput, error)
+
+	// ProcessNextJob runs the next job queued in the background, waiting for one up to the input's timeout
+	ProcessNextJob(ctx context.Context, input *ProcessNextJobInput) (*ProcessNextJobOutput, error)
+
+	// CreateChallenge challenges another player to a quickfire game of odds and evens
+	CreateChallenge(ctx context.Context, input *CreateChallengeInput) (*CreateChallengeOutput, error)
+
+	// AcceptChallenge plays a challenge, both players roll and the loser takes a drink
+	AcceptChallenge(ctx context.Context, input *AcceptChallengeInput) (*AcceptChallengeOutput, error)
+
+	// DeclineChallenge turns down or calls off a challenge
+	DeclineChallenge(ctx context.Context, input *DeclineChallengeInput) (*DeclineChallengeOutput, error)
+
+	// PlaceSideBet bets a drink on the outcome of another player's next roll
+	PlaceSideBet(ctx context.Context, input *PlaceSideBetInput) (*PlaceSideBetOutput, error)
+
+	// InvitePlayers invites players to a private game, only its creator can
+	InvitePlayers(ctx context.Context, input *InvitePlayersInput) (*InvitePlayersOutput, error)
+
+	// AddCoHost deputizes a player to help run a game, only its creator can
+	AddCoHost(ctx context.Context, input *AddCoHostInput) (*AddCoHostOutput, error)
+
+	// RemoveCoHost takes a co-host's powers away, only the game's creator can
+	RemoveCoHost(ctx context.Context, input *RemoveCoHostInput) (*RemoveCoHostOutput, error)
+
+	// PauseGame stops everyone rolling in a game until it's resumed, only its hosts can
+	PauseGame(ctx context.Context, input *PauseGameInput) (*PauseGameOutput, error)
+
+	// ResumeGame lets everyone roll again in a paused game, only its hosts can
+	ResumeGame(ctx context.Context, input *ResumeGameInput) (*ResumeGameOutput, error)
+
+	// WrapUpGame ends a game stuck waiting on players with the rolls made so far, only its hosts can
+	WrapUpGame(ctx context.Context, input *WrapUpGameInput) (*WrapUpGameOutput, error)
+
+	// CallVote has a player call a vote to start or abandon their game
+	CallVote(ctx context.Context, input *CallVoteInput) (*CallVoteOutput, error)
+
+	// CastVote records a player's vote on the vote being held in their game
+	CastVote(ctx context.Context, input *CastVoteInput) (*CastVoteOutput, error)
+
+	// GetGuildOverview returns a guild's games being played and its current session with the session leaderboard
+	GetGuildOverview(ctx context.Context, input *GetGuildOverviewInput) (*GetGuildOverviewOutput, error)
+
+	// GetFairnessReport works out who has been giving the drinks they chose to whom in a channel's current session
+	GetFairnessReport(ctx context.Context, input *GetFairnessReportInput) (*GetFairnessReportOutput, error)
 }
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// isDoubles checks whether a player rolled the same as the roll just before theirs in the game. Roll-offs don't count,
+// their players are tied already.
+func isDoubles(game *models.Game, participant *models.Participant) bool {
+	if game.Status == models.GameStatusRollOff {
+		return false
+	}
+
+	var previous *models.Participant
+	for _, other := range game.Participants {
+		if other == participant || other.RollTime == nil || other.RollTime.After(*participant.RollTime) {
+			continue
+		}
+		if previous == nil || other.RollTime.After(*previous.RollTime) {
+			previous = other
+		}
+	}
+	return previous != nil && previous.RollValue == participant.RollValue
+}
+
+// addToJackpot puts a drink in the session's jackpot, for a tie or for the player rolling doubles, when the game is
+// played for it. It returns the drinks in the jackpot now, 0 when nothing went in.
+func (s *service) addToJackpot(ctx context.Context, game *models.Game, participant *models.Participant) int {
+	if !s.rulesFor(game).Jackpot {
+		return 0
+	}
+
+	session := s.getSessionForGame(ctx, game)
+	if session == nil {
+		return 0
+	}
+
+	session.Jackpot++
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		log.Printf("Error adding to the jackpot: %v", err)
+		return 0
+	}
+
+	payload := &events.JackpotPayload{
+		Drinks: session.Jackpot,
+	}
+	if participant != nil {
+		payload.PlayerID = participant.PlayerID
+		payload.PlayerName = participant.PlayerName
+	}
+	s.publishJackpot(ctx, game, session, payload)
+
+	return session.Jackpot
+}
+
+// takeJackpot gives the player who rolled a critical fail in a game played for the session's jackpot every drink in
+// it, emptying it. It returns how many drinks they took.
+func (s *service) takeJackpot(ctx context.Context, game *models.Game, participant *models.Participant) int {
+	if !s.rulesFor(game).Jackpot {
+		return 0
+	}
+
+	session := s.getSessionForGame(ctx, game)
+	if session == nil || session.Jackpot == 0 {
+		return 0
+	}
+
+	// Empty the jackpot first, so a drink that fails to save can't be taken again
+	drinks := session.Jackpot
+	session.Jackpot = 0
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		log.Printf("Error emptying the jackpot: %v", err)
+		return 0
+	}
+
+	for range drinks {
+		_, err := s.createDrinkRecord(ctx, game, session, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:     game.ID,
+			ToPlayerID: participant.PlayerID,
+			Reason:     models.DrinkReasonJackpot,
+			Timestamp:  s.clock.Now(),
+		})
+		if err != nil {
+			log.Printf("Error saving jackpot drink record: %v", err)
+		}
+	}
+
+	s.publishJackpot(ctx, game, session, &events.JackpotPayload{
+		PlayerID:   participant.PlayerID,
+		PlayerName: participant.PlayerName,
+		Drinks:     drinks,
+		Won:        true,
+	})
+
+	return drinks
+}
+
+// publishJackpot announces a change to the session's jackpot in the game's channel
+func (s *service) publishJackpot(ctx context.Context, game *models.Game, session *models.Session, payload *events.JackpotPayload) {
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeJackpot,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		SessionID: session.ID,
+		Timestamp: s.clock.Now(),
+		Payload:   payload,
+	})
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// JackpotTestSuite tests the session's jackpot against the real Redis repositories
+type JackpotTestSuite struct {
+	serviceHarness
+	clock *clock.Fake
+
+	jackpots []*events.JackpotPayload
+}
+
+func (s *JackpotTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+	})
+	s.testChannelID = "jackpot-channel"
+	s.testGuildID = "jackpot-guild"
+
+	s.jackpots = nil
+	s.eventBus.Subscribe(events.TypeJackpot, func(_ context.Context, event *events.Event) {
+		s.jackpots = append(s.jackpots, event.Payload.(*events.JackpotPayload))
+	})
+}
+
+func TestJackpotTestSuite(t *testing.T) {
+	suite.Run(t, new(JackpotTestSuite))
+}
+
+// startGame starts a game between alice, bob and carol, played for the jackpot or not
+func (s *JackpotTestSuite) startGame(jackpot bool) string {
+	return s.startGameWith(&CreateGameInput{Rules: &models.GameRules{Jackpot: jackpot}}, "alice", "bob", "carol")
+}
+
+// roll rolls for a player a minute after the last roll
+func (s *JackpotTestSuite) roll(gameID, playerID string, value int) *RollDiceOutput {
+	s.clock.Advance(time.Minute)
+	return s.serviceHarness.roll(gameID, playerID, value)
+}
+
+// jackpot returns the drinks in the session's jackpot
+func (s *JackpotTestSuite) jackpot() int {
+	output, err := s.ledgerRepo.GetCurrentSession(s.ctx, &ledgerRepo.GetCurrentSessionInput{GuildID: "jackpot-guild"})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Session)
+	return output.Session.Jackpot
+}
+
+func (s *JackpotTestSuite) TestDoublesAndTiesFeedTheJackpot() {
+	gameID := s.startGame(true)
+
+	s.Zero(s.roll(gameID, "alice", 3).Jackpot)
+	s.Equal(1, s.roll(gameID, "bob", 3).Jackpot)
+
+	// alice and bob tied for the lowest roll
+	output := s.roll(gameID, "carol", 5)
+	s.Require().True(output.NeedsRollOff)
+	s.Zero(output.Jackpot)
+	s.Equal(2, s.jackpot())
+
+	// Matching rolls in a roll-off are ties already
+	s.roll(output.RollOffGameID, "alice", 4)
+	s.Zero(s.roll(output.RollOffGameID, "bob", 4).Jackpot)
+
+	s.Require().Len(s.jackpots, 3)
+	s.Equal(&events.JackpotPayload{PlayerID: "bob", PlayerName: "bob", Drinks: 1}, s.jackpots[0])
+	s.Equal(&events.JackpotPayload{Drinks: 2}, s.jackpots[1])
+	s.Equal(&events.JackpotPayload{Drinks: 3}, s.jackpots[2])
+}
+
+func (s *JackpotTestSuite) TestCriticalFailTakesTheJackpot() {
+	gameID := s.startGame(true)
+
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	output := s.roll(gameID, "carol", 1)
+	s.Equal(1, output.JackpotDrinks)
+	s.Contains(output.Details, "JACKPOT")
+
+	records, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	var carolsDrinks []models.DrinkReason
+	for _, record := range records.Records {
+		if record.ToPlayerID == "carol" {
+			carolsDrinks = append(carolsDrinks, record.Reason)
+		}
+	}
+	s.ElementsMatch([]models.DrinkReason{models.DrinkReasonCriticalFail, models.DrinkReasonJackpot}, carolsDrinks)
+
+	s.Require().Len(s.jackpots, 3)
+	s.Equal(&events.JackpotPayload{PlayerID: "carol", PlayerName: "carol", Drinks: 1, Won: true}, s.jackpots[1])
+
+	// alice and bob tied for the highest roll, starting the jackpot again
+	s.Equal(1, s.jackpot())
+}
+
+func (s *JackpotTestSuite) TestOnlyGamesPlayedForTheJackpot() {
+	gameID := s.startGame(false)
+
+	s.roll(gameID, "alice", 3)
+	s.Zero(s.roll(gameID, "bob", 3).Jackpot)
+	s.Zero(s.roll(gameID, "carol", 1).JackpotDrinks)
+
+	s.Zero(s.jackpot())
+	s.Empty(s.jackpots)
+}
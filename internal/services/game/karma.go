@@ -0,0 +1,94 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// karmaPenaltyScore is the karma at which picking on the same player again costs a drink, in games played with karma
+const karmaPenaltyScore = -2
+
+// karma counts the drinks a player chose to give, by who they gave them to
+type karma map[string]int
+
+// score is how evenly the drinks were spread. Every drink to someone other than the player's favourite target is +1
+// and every drink to their favourite target after the first is -1, so spreading drinks around is positive and
+// picking on one player is negative.
+func (k karma) score() int {
+	var given, most int
+	for _, count := range k {
+		given += count
+		if count > most {
+			most = count
+		}
+	}
+	if given == 0 {
+		return 0
+	}
+	return given - 2*most + 1
+}
+
+// isFavorite checks whether the player has been given at least as many of the drinks as anyone else
+func (k karma) isFavorite(playerID string) bool {
+	for _, count := range k {
+		if count > k[playerID] {
+			return false
+		}
+	}
+	return k[playerID] > 0
+}
+
+// tallyKarma counts who each player chose to give drinks to in the records. Only critical hit drinks are chosen,
+// the rest go where the dice send them.
+func tallyKarma(records []*models.DrinkLedger) map[string]karma {
+	karmas := make(map[string]karma)
+	for _, record := range records {
+		if record.Reason != models.DrinkReasonCriticalHit || record.FromPlayerID == "" {
+			continue
+		}
+		if karmas[record.FromPlayerID] == nil {
+			karmas[record.FromPlayerID] = make(karma)
+		}
+		karmas[record.FromPlayerID][record.ToPlayerID]++
+	}
+	return karmas
+}
+
+// karmaDrink returns the drink a player takes for giving one to the same player once too often this session, nil
+// when the game isn't played with karma or the drink keeps their karma above the penalty. With only one other
+// player to pick from there's no choice to punish, so two player games never pay it.
+func (s *service) karmaDrink(ctx context.Context, game *models.Game, session *models.Session, fromPlayerID, toPlayerID string) *ledgerRepo.CreateDrinkRecordInput {
+	if !s.rulesFor(game).Karma || session == nil || len(game.Participants) < 3 {
+		return nil
+	}
+
+	records, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   session.GuildID,
+	})
+	if err != nil {
+		log.Printf("Error getting drink records for karma: %v", err)
+		return nil
+	}
+
+	given := tallyKarma(records.Records)[fromPlayerID]
+	if given == nil {
+		given = make(karma)
+	}
+	given[toPlayerID]++
+
+	if given.score() > karmaPenaltyScore || !given.isFavorite(toPlayerID) {
+		return nil
+	}
+
+	return &ledgerRepo.CreateDrinkRecordInput{
+		GameID:       game.ID,
+		FromPlayerID: toPlayerID,
+		ToPlayerID:   fromPlayerID,
+		Reason:       models.DrinkReasonKarma,
+		Timestamp:    s.clock.Now(),
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestKarmaScore(t *testing.T) {
+	testCases := []struct {
+		name  string
+		karma karma
+		want  int
+	}{
+		{name: "no drinks given", karma: nil, want: 0},
+		{name: "one drink", karma: karma{"bob": 1}, want: 0},
+		{name: "spread around", karma: karma{"bob": 1, "carol": 1, "dave": 1, "erin": 1}, want: 3},
+		{name: "split evenly", karma: karma{"bob": 2, "carol": 2}, want: 1},
+		{name: "picking on one player", karma: karma{"bob": 4}, want: -3},
+		{name: "mostly one player", karma: karma{"bob": 4, "carol": 1}, want: -2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.karma.score())
+		})
+	}
+}
+
+func TestTallyKarma_OnlyCountsChosenDrinks(t *testing.T) {
+	records := []*models.DrinkLedger{
+		{FromPlayerID: "alice", ToPlayerID: "bob", Reason: models.DrinkReasonCriticalHit},
+		{FromPlayerID: "alice", ToPlayerID: "bob", Reason: models.DrinkReasonCriticalHit},
+		{FromPlayerID: "alice", ToPlayerID: "bob", Reason: models.DrinkReasonSideBet},
+		{FromPlayerID: "bob", ToPlayerID: "bob", Reason: models.DrinkReasonCriticalFail},
+		{ToPlayerID: "carol", Reason: models.DrinkReasonLowestRoll},
+	}
+
+	karmas := tallyKarma(records)
+	assert.Equal(t, karma{"bob": 2}, karmas["alice"])
+	assert.Nil(t, karmas["bob"])
+	assert.Len(t, karmas, 1)
+}
+
+// KarmaTestSuite tests karma drinks against the real Redis repositories
+type KarmaTestSuite struct {
+	serviceHarness
+}
+
+func (s *KarmaTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+		cfg.Karma = true
+	})
+	s.testGuildID = "karma-guild"
+	s.testChannelID = "karma-channel"
+}
+
+func TestKarmaTestSuite(t *testing.T) {
+	suite.Run(t, new(KarmaTestSuite))
+}
+
+// critAndAssign plays a game where alice rolls a critical hit and gives the drink to target, the other players
+// rolling a 4 and a 3
+func (s *KarmaTestSuite) critAndAssign(target string, playerIDs ...string) *AssignDrinkOutput {
+	gameID := s.startGame(append([]string{"alice"}, playerIDs...)...)
+	for i, playerID := range playerIDs {
+		s.roll(gameID, playerID, 4-i)
+	}
+	s.roll(gameID, "alice", 6)
+
+	output, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   target,
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+	s.Require().True(output.GameEnded)
+	return output
+}
+
+func (s *KarmaTestSuite) leaderboardEntry(playerID string) LeaderboardEntry {
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	for _, entry := range output.Entries {
+		if entry.PlayerID == playerID {
+			return entry
+		}
+	}
+	s.FailNow("no leaderboard entry for " + playerID)
+	return LeaderboardEntry{}
+}
+
+func (s *KarmaTestSuite) TestPickingOnOnePlayer() {
+	s.False(s.critAndAssign("bob", "bob", "carol").KarmaDrink)
+	s.False(s.critAndAssign("bob", "bob", "carol").KarmaDrink)
+
+	output := s.critAndAssign("bob", "bob", "carol")
+	s.True(output.KarmaDrink, "the third drink in a row to bob comes back around")
+
+	entry := s.leaderboardEntry("alice")
+	s.Equal(1, entry.DrinkCount, "alice drinks for picking on bob")
+	s.Equal(-2, entry.Karma)
+}
+
+func (s *KarmaTestSuite) TestSpreadingDrinksAround() {
+	for _, target := range []string{"bob", "carol", "bob", "carol"} {
+		s.False(s.critAndAssign(target, "bob", "carol").KarmaDrink)
+	}
+}
+
+func (s *KarmaTestSuite) TestTwoPlayerGamesAreLetOff() {
+	for i := 0; i < 4; i++ {
+		s.False(s.critAndAssign("bob", "bob").KarmaDrink)
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// LastCall puts the channel's session into last call: one final game is played with drinks counting extra,
+// and the session closes once it ends. A game already under way in the channel becomes the final one.
+func (s *service) LastCall(ctx context.Context, input *LastCallInput) (*LastCallOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if session.LastCall {
+		return nil, ErrLastCallAlreadyCalled
+	}
+
+	now := s.clock.Now()
+	session.LastCall = true
+	session.LastCallBy = input.PlayerID
+	session.LastCallAt = &now
+
+	err := retryOnGameChange(func() error {
+		game, err := s.gameRepo.GetActiveGameByChannel(ctx, &gameRepo.GetActiveGameByChannelInput{
+			ChannelID: input.ChannelID,
+		})
+		switch {
+		case err == nil:
+			game.LastCall = true
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+				return fmt.Errorf("failed to save game: %w", err)
+			}
+			session.LastCallGameID = game.ID
+		case !errors.Is(err, gameRepo.ErrGameNotFound):
+			return fmt.Errorf("failed to get active game: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return &LastCallOutput{
+		Session:    session,
+		GameID:     session.LastCallGameID,
+		Multiplier: s.lastCallMultiplier,
+	}, nil
+}
+
+// lastCallSession returns the channel's session if it's in last call and a new game would be its final one.
+// Once the final game is under way no more games can be started, unless it was abandoned.
+func (s *service) lastCallSession(ctx context.Context, guildID, channelID string) (*models.Session, error) {
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(guildID, channelID),
+	})
+	if err != nil || sessionOutput.Session == nil || !sessionOutput.Session.LastCall {
+		return nil, nil
+	}
+
+	session := sessionOutput.Session
+	if session.LastCallGameID != "" {
+		game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: session.LastCallGameID,
+		})
+		if err == nil && !game.Status.IsAbandoned() {
+			return nil, ErrLastCallGameStarted
+		}
+	}
+
+	return session, nil
+}
+
+// closeLastCallSession closes the session once its final game after last call is over
+func (s *service) closeLastCallSession(ctx context.Context, game *models.Game) {
+	if !game.LastCall {
+		return
+	}
+
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(game.GuildID, game.ChannelID),
+	})
+	if err != nil || sessionOutput.Session == nil {
+		log.Printf("Error getting session to close after last call game %s: %v", game.ID, err)
+		return
+	}
+
+	if sessionOutput.Session.LastCallGameID != game.ID {
+		return
+	}
+
+	s.closeSession(ctx, game.GuildID, game.ChannelID, sessionOutput.Session, SessionRotationReasonLastCall)
+}
+
+// drinkCount returns how many drink records each drink given in the game creates, counting the game's
+// seasonal events and the last call multiplier rounded up to whole drinks, then any happy hour multiplier
+func (s *service) drinkCount(game *models.Game, seasonalMultiplier int, happyHourMultiplier float64) int {
+	count := seasonalMultiplier
+	if game.LastCall {
+		count = int(math.Ceil(float64(seasonalMultiplier) * s.lastCallMultiplier))
+	}
+	if happyHourMultiplier > 0 {
+		count = s.happyHourDrinkCount(count, happyHourMultiplier)
+	}
+	return count
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/stretchr/testify/suite"
+)
+
+// LastCallTestSuite tests closing a session with a final game against the real Redis repositories
+type LastCallTestSuite struct {
+	serviceHarness
+	rotated []*events.Event
+}
+
+func (s *LastCallTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "last-call-channel"
+	s.testGuildID = "last-call-guild"
+
+	s.rotated = nil
+	s.eventBus.Subscribe(events.TypeSessionRotated, func(_ context.Context, event *events.Event) {
+		s.rotated = append(s.rotated, event)
+	})
+}
+
+func TestLastCallTestSuite(t *testing.T) {
+	suite.Run(t, new(LastCallTestSuite))
+}
+
+// createGame creates a game in a channel of the test guild
+func (s *LastCallTestSuite) createGame(channelID string) (string, error) {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   channelID,
+		GuildID:     s.testGuildID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	if err != nil {
+		return "", err
+	}
+	return createOutput.GameID, nil
+}
+
+// playGame plays a created game where bob rolls lowest and is given a drink
+func (s *LastCallTestSuite) playGame(gameID string) {
+	_, err := s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: gameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().NoError(err)
+
+	s.start(gameID, "alice")
+	s.roll(gameID, "alice", 4)
+	s.roll(gameID, "bob", 2)
+}
+
+// lastCall calls last call on the test channel's session
+func (s *LastCallTestSuite) lastCall() (*LastCallOutput, error) {
+	return s.gameService.LastCall(s.ctx, &LastCallInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		PlayerID:  "alice",
+	})
+}
+
+func (s *LastCallTestSuite) TestFinalGameClosesSession() {
+	output, err := s.lastCall()
+	s.Require().NoError(err)
+	s.Empty(output.GameID)
+	s.Equal(1.5, output.Multiplier)
+
+	_, err = s.lastCall()
+	s.ErrorIs(err, ErrLastCallAlreadyCalled)
+
+	gameID, err := s.createGame(s.testChannelID)
+	s.Require().NoError(err)
+	s.playGame(gameID)
+
+	// bob's drink counted 1.5 times, rounded up
+	s.Require().Len(s.rotated, 1)
+	payload := s.rotated[0].Payload.(*events.SessionRotatedPayload)
+	s.Equal(output.Session.ID, payload.PreviousSessionID)
+	s.Equal(string(SessionRotationReasonLastCall), payload.Reason)
+	s.Require().Len(payload.Standings, 1)
+	s.Equal("bob", payload.Standings[0].PlayerID)
+	s.Equal(2, payload.Standings[0].DrinkCount)
+
+	// The new session starts fresh and can play as many games as it likes
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.NotEqual(output.Session.ID, leaderboard.Session.ID)
+	s.False(leaderboard.Session.LastCall)
+	s.Empty(leaderboard.Entries)
+
+	_, err = s.createGame("other-channel")
+	s.Require().NoError(err)
+}
+
+func (s *LastCallTestSuite) TestOnlyOneFinalGame() {
+	_, err := s.lastCall()
+	s.Require().NoError(err)
+
+	gameID, err := s.createGame(s.testChannelID)
+	s.Require().NoError(err)
+
+	_, err = s.createGame("other-channel")
+	s.ErrorIs(err, ErrLastCallGameStarted)
+
+	// An abandoned final game can be replaced
+	_, err = s.gameService.AbandonGame(s.ctx, &AbandonGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	_, err = s.createGame("other-channel")
+	s.Require().NoError(err)
+	s.Empty(s.rotated)
+}
+
+func (s *LastCallTestSuite) TestGameInProgressBecomesFinalGame() {
+	gameID, err := s.createGame(s.testChannelID)
+	s.Require().NoError(err)
+
+	output, err := s.lastCall()
+	s.Require().NoError(err)
+	s.Equal(gameID, output.GameID)
+
+	s.playGame(gameID)
+
+	s.Require().Len(s.rotated, 1)
+	s.Equal(output.Session.ID, s.rotated[0].Payload.(*events.SessionRotatedPayload).PreviousSessionID)
+}
@@ -0,0 +1,173 @@
+package game
+
+import (
+	"context"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// unknownPlayerName is shown for players without a saved profile
+const unknownPlayerName = "Unknown Player"
+
+// drinkTally counts the drinks each player was given
+type drinkTally struct {
+	drinks   map[string]int // Total drinks owed
+	paid     map[string]int // Drinks paid
+	social   map[string]int // Drinks waived by the mercy rule
+	forgiven map[string]int // Drinks written off by an admin
+}
+
+// tallyDrinks counts the drinks in the records by the player who was given them
+func tallyDrinks(records []*models.DrinkLedger) *drinkTally {
+	tally := &drinkTally{
+		drinks:   make(map[string]int),
+		paid:     make(map[string]int),
+		social:   make(map[string]int),
+		forgiven: make(map[string]int),
+	}
+
+	for _, record := range records {
+		if record.Social {
+			tally.social[record.ToPlayerID]++
+			continue
+		}
+		if record.Forgiven {
+			tally.forgiven[record.ToPlayerID]++
+			continue
+		}
+		tally.drinks[record.ToPlayerID]++
+		if record.Paid {
+			tally.paid[record.ToPlayerID]++
+		}
+	}
+
+	return tally
+}
+
+// buildGameLeaderboard builds a game's leaderboard from its drink records.
+// Every participant gets an entry, as does anyone given a drink who has since left the game.
+func buildGameLeaderboard(game *models.Game, records []*models.DrinkLedger, playerName func(playerID string) string) []LeaderboardEntry {
+	tally := tallyDrinks(records)
+
+	playerMap := make(map[string]*LeaderboardEntry)
+	for _, participant := range game.Participants {
+		playerMap[participant.PlayerID] = &LeaderboardEntry{
+			PlayerID:      participant.PlayerID,
+			PlayerName:    participant.PlayerName,
+			DrinkCount:    tally.drinks[participant.PlayerID],
+			PaidCount:     tally.paid[participant.PlayerID],
+			SocialCount:   tally.social[participant.PlayerID],
+			ForgivenCount: tally.forgiven[participant.PlayerID],
+		}
+	}
+
+	for playerID := range tally.drinks {
+		if _, exists := playerMap[playerID]; !exists {
+			playerMap[playerID] = &LeaderboardEntry{
+				PlayerID:      playerID,
+				PlayerName:    playerName(playerID),
+				DrinkCount:    tally.drinks[playerID],
+				PaidCount:     tally.paid[playerID],
+				SocialCount:   tally.social[playerID],
+				ForgivenCount: tally.forgiven[playerID],
+			}
+		}
+	}
+
+	var entries []LeaderboardEntry
+	for _, entry := range playerMap {
+		entries = append(entries, *entry)
+	}
+
+	return entries
+}
+
+// buildSessionLeaderboard builds a session's leaderboard from its drink records, most drinks first.
+// Players who have only had social or forgiven drinks and designated drivers who haven't been given any still get an entry.
+// Each entry has the player's karma for the drinks they chose to give.
+func buildSessionLeaderboard(session *models.Session, records []*models.DrinkLedger, playerName func(playerID string) string) []LeaderboardEntry {
+	tally := tallyDrinks(records)
+
+	for _, waived := range []map[string]int{tally.social, tally.forgiven} {
+		for playerID := range waived {
+			if _, ok := tally.drinks[playerID]; !ok {
+				tally.drinks[playerID] = 0
+			}
+		}
+	}
+
+	driverNames := make(map[string]string)
+	if session != nil {
+		for _, driver := range session.DesignatedDrivers {
+			if _, ok := tally.drinks[driver.PlayerID]; !ok {
+				tally.drinks[driver.PlayerID] = 0
+			}
+			driverNames[driver.PlayerID] = driver.PlayerName
+		}
+	}
+
+	// Guests watching the game aren't saved as players, their drinks carry their names
+	guestNames := make(map[string]string)
+	for _, record := range records {
+		if record.IsGuest() {
+			guestNames[record.ToPlayerID] = record.GuestName
+		}
+	}
+
+	karmas := tallyKarma(records)
+
+	var entries []LeaderboardEntry
+	for playerID, drinkCount := range tally.drinks {
+		name, ok := driverNames[playerID]
+		if !ok {
+			name = playerName(playerID)
+		}
+		if guestName, ok := guestNames[playerID]; ok && name == unknownPlayerName {
+			name = guestName
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			PlayerID:         playerID,
+			PlayerName:       name,
+			DrinkCount:       drinkCount,
+			PaidCount:        tally.paid[playerID],
+			SocialCount:      tally.social[playerID],
+			ForgivenCount:    tally.forgiven[playerID],
+			DesignatedDriver: session != nil && session.GetDesignatedDriver(playerID) != nil,
+			Karma:            karmas[playerID].score(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DrinkCount > entries[j].DrinkCount
+	})
+
+	return entries
+}
+
+// playerNames returns a lookup of players' saved names, starting from the names already known
+func (s *service) playerNames(ctx context.Context, known map[string]string) func(playerID string) string {
+	names := make(map[string]string, len(known))
+	for playerID, name := range known {
+		names[playerID] = name
+	}
+
+	return func(playerID string) string {
+		if name, ok := names[playerID]; ok {
+			return name
+		}
+
+		name := unknownPlayerName
+		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: playerID,
+		})
+		if err == nil && player != nil {
+			name = player.Name
+		}
+		names[playerID] = name
+
+		return name
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// recordDrink records a drink given in the game during a session, applying the designated driver and mercy rules.
+// Once the recipient has been given the session's drink cap, further drinks are recorded as social instead of owed.
+// A drink the recipient still owes is offset by a credit if they've banked one. pending counts the drinks to the
+// recipient recorded earlier in the same unit of work, which the ledger doesn't show until it's saved.
+func (s *service) recordDrink(ctx context.Context, game *models.Game, session *models.Session, input *ledgerRepo.CreateDrinkRecordInput, pending int) (*ledgerRepo.CreateDrinkRecordOutput, error) {
+	playerName := input.ToPlayerID
+	if participant := game.GetParticipant(input.ToPlayerID); participant != nil {
+		playerName = participant.PlayerName
+	} else if input.GuestName != "" {
+		playerName = input.GuestName
+	}
+
+	input.GuildID = game.GuildID
+	if session != nil {
+		input.SessionID = session.ID
+		if driver := session.GetDesignatedDriver(input.ToPlayerID); driver != nil {
+			applyDesignatedDriver(driver, input)
+			if input.CoveredFor != "" {
+				playerName = driver.SubstituteName
+			}
+		}
+	}
+
+	drinkCap := s.currentDrinkCap()
+	if drinkCap > 0 && input.SessionID != "" && s.drinksGivenInSession(ctx, sessionScope(game.GuildID, game.ChannelID), input.SessionID, input.ToPlayerID)+pending >= drinkCap {
+		input.Social = true
+	}
+
+	s.applyCredit(ctx, input)
+
+	output, err := s.drinkLedgerRepo.CreateDrinkRecord(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishDrinkAssigned(ctx, game, input)
+	s.publishLedgerChanged(ctx, game.GuildID, game.ChannelID, input.SessionID, "drink_recorded")
+
+	if input.Social {
+		s.eventBus.Publish(ctx, &events.Event{
+			Type:      events.TypeMercyRule,
+			ChannelID: game.ChannelID,
+			GuildID:   game.GuildID,
+			GameID:    game.ID,
+			SessionID: input.SessionID,
+			Timestamp: s.clock.Now(),
+			Payload: &events.MercyRulePayload{
+				PlayerID:   input.ToPlayerID,
+				PlayerName: playerName,
+				Reason:     string(input.Reason),
+				DrinkCap:   drinkCap,
+			},
+		})
+	}
+
+	return output, nil
+}
+
+// drinksGivenInSession counts the drinks a player has been given in a session, social drinks don't count
+func (s *service) drinksGivenInSession(ctx context.Context, scope, sessionID, playerID string) int {
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: sessionID,
+		GuildID:   scope,
+	})
+	if err != nil {
+		// Without the tally we can't apply the cap, so the drink counts as normal
+		log.Printf("Error getting drink records for mercy rule: %v", err)
+		return 0
+	}
+
+	count := 0
+	for _, record := range drinkRecords.Records {
+		if record.ToPlayerID == playerID && !record.Social {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/stretchr/testify/suite"
+)
+
+// MercyRuleTestSuite tests the session drink cap against the real Redis repositories
+type MercyRuleTestSuite struct {
+	serviceHarness
+	mercyEvents []*events.MercyRulePayload
+}
+
+func (s *MercyRuleTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.DrinkCap = 1
+	})
+	s.testChannelID = "mercy-channel"
+
+	s.mercyEvents = nil
+	s.eventBus.Subscribe(events.TypeMercyRule, func(_ context.Context, event *events.Event) {
+		s.mercyEvents = append(s.mercyEvents, event.Payload.(*events.MercyRulePayload))
+	})
+}
+
+func TestMercyRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(MercyRuleTestSuite))
+}
+
+func (s *MercyRuleTestSuite) TestDrinksPastCapAreSocial() {
+	// alice's critical fail reaches the cap, so her lowest roll drink is social
+	s.playGame([]string{"alice", "bob"}, []int{1, 3})
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal("alice", leaderboard.Entries[0].PlayerID)
+	s.Equal(1, leaderboard.Entries[0].DrinkCount)
+	s.Equal(1, leaderboard.Entries[0].SocialCount)
+
+	s.Require().Len(s.mercyEvents, 1)
+	s.Equal("alice", s.mercyEvents[0].PlayerName)
+	s.Equal(string(DrinkReasonLowestRoll), s.mercyEvents[0].Reason)
+	s.Equal(1, s.mercyEvents[0].DrinkCap)
+}
+
+func (s *MercyRuleTestSuite) TestSocialDrinksCannotBePaid() {
+	s.playGame([]string{"alice", "bob"}, []int{1, 3})
+
+	gameOutput, err := s.gameService.GetGameByChannel(s.ctx, &GetGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameOutput.Game.ID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// The only drink left is the social one, which isn't owed, so paying banks a credit instead
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameOutput.Game.ID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(payOutput.Credited)
+}
+
+func (s *MercyRuleTestSuite) TestUnderCap() {
+	// bob's only drink is under the cap
+	s.playGame([]string{"alice", "bob"}, []int{4, 3})
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal(1, leaderboard.Entries[0].DrinkCount)
+	s.Equal(0, leaderboard.Entries[0].SocialCount)
+	s.Empty(s.mercyEvents)
+}
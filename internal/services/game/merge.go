@@ -0,0 +1,119 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// MergePlayers moves an alt account's drink records and stats from the server it's merged in under the canonical
+// player, along with the re-roll tokens and roll modifiers it was holding. The alt account is kept, linked to the
+// canonical player, so the merge can be traced later.
+func (s *service) MergePlayers(ctx context.Context, input *MergePlayersInput) (*MergePlayersOutput, error) {
+	if input == nil || input.CanonicalPlayerID == "" || input.AliasPlayerID == "" {
+		return nil, errors.New("canonical and alias player IDs are required")
+	}
+
+	if input.GuildID == "" {
+		return nil, errors.New("guild ID is required")
+	}
+
+	if input.CanonicalPlayerID == input.AliasPlayerID {
+		return nil, ErrCannotMergeSelf
+	}
+
+	canonical, err := s.getMergePlayer(ctx, input.CanonicalPlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := s.getMergePlayer(ctx, input.AliasPlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rewriting records out from under a running game would confuse its tally
+	if s.inUnfinishedGame(ctx, alias) {
+		return nil, ErrMergePlayerInGame
+	}
+
+	reassignOutput, err := s.drinkLedgerRepo.ReassignPlayerRecords(ctx, &ledgerRepo.ReassignPlayerRecordsInput{
+		FromPlayerID: alias.ID,
+		ToPlayerID:   canonical.ID,
+		GuildID:      input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to move drink records: %w", err)
+	}
+
+	// The tokens and modifiers the alt was holding go to the canonical player with the link
+	canonical.RerollTokens += alias.RerollTokens
+	canonical.RollModifiers = append(canonical.RollModifiers, alias.RollModifiers...)
+	alias.RerollTokens = 0
+	alias.RollModifiers = nil
+	alias.MergedInto = canonical.ID
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+			Player: canonical,
+		}); err != nil {
+			return fmt.Errorf("failed to save canonical player: %w", err)
+		}
+
+		if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+			Player: alias,
+		}); err != nil {
+			return fmt.Errorf("failed to link merged player: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergePlayersOutput{
+		Canonical:    canonical,
+		Alias:        alias,
+		RecordsMoved: reassignOutput.RecordsMoved,
+	}, nil
+}
+
+// getMergePlayer gets a player taking part in a merge, who must not have been merged already
+func (s *service) getMergePlayer(ctx context.Context, playerID string) (*models.Player, error) {
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	if player.MergedInto != "" {
+		return nil, ErrPlayerAlreadyMerged
+	}
+
+	return player, nil
+}
+
+// inUnfinishedGame returns true if the player's current game is still being played
+func (s *service) inUnfinishedGame(ctx context.Context, player *models.Player) bool {
+	if player.CurrentGameID == "" {
+		return false
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: player.CurrentGameID,
+	})
+	if err != nil {
+		// The game is gone, so there's nothing to confuse
+		return false
+	}
+
+	return !game.Status.IsFinished()
+}
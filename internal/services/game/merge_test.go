@@ -0,0 +1,164 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// MergePlayersTestSuite tests merging alt accounts against the real Redis repositories
+type MergePlayersTestSuite struct {
+	serviceHarness
+}
+
+func (s *MergePlayersTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "merge-channel"
+	s.testGuildID = "merge-guild"
+}
+
+func TestMergePlayersTestSuite(t *testing.T) {
+	suite.Run(t, new(MergePlayersTestSuite))
+}
+
+func (s *MergePlayersTestSuite) TestMergeMovesDrinks() {
+	// alt rolls a critical fail and the lowest roll, alice has the lowest roll in the second game
+	s.playGame([]string{"alt", "bob"}, []int{1, 4})
+	s.playGame([]string{"alice", "bob"}, []int{2, 4})
+
+	output, err := s.gameService.MergePlayers(s.ctx, &MergePlayersInput{
+		CanonicalPlayerID: "alice",
+		AliasPlayerID:     "alt",
+		GuildID:           s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.RecordsMoved)
+	s.Equal("alice", output.Canonical.ID)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal("alice", leaderboard.Entries[0].PlayerID)
+	s.Equal(3, leaderboard.Entries[0].DrinkCount)
+
+	// The alt account is linked to alice
+	alt, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Equal("alice", alt.MergedInto)
+
+	_, err = s.gameService.MergePlayers(s.ctx, &MergePlayersInput{CanonicalPlayerID: "bob", AliasPlayerID: "alt", GuildID: s.testGuildID})
+	s.ErrorIs(err, ErrPlayerAlreadyMerged)
+}
+
+func (s *MergePlayersTestSuite) TestMergeMovesCredits() {
+	// bob drinks, so the alt's round is banked as a credit
+	gameID := s.playGame([]string{"alt", "bob"}, []int{4, 2})
+
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Require().True(payOutput.Credited)
+
+	s.playGame([]string{"alice", "bob"}, []int{4, 2})
+
+	_, err = s.gameService.MergePlayers(s.ctx, &MergePlayersInput{
+		CanonicalPlayerID: "alice",
+		AliasPlayerID:     "alt",
+		GuildID:           s.testGuildID,
+	})
+	s.Require().NoError(err)
+
+	aliceTab, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(1, aliceTab.Tab.Credits)
+
+	altTab, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{GameID: gameID, PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Zero(altTab.Tab.Credits)
+}
+
+func (s *MergePlayersTestSuite) TestMergeErrors() {
+	_, err := s.gameService.MergePlayers(s.ctx, &MergePlayersInput{CanonicalPlayerID: "alice", AliasPlayerID: "alice", GuildID: s.testGuildID})
+	s.ErrorIs(err, ErrCannotMergeSelf)
+
+	_, err = s.gameService.MergePlayers(s.ctx, &MergePlayersInput{CanonicalPlayerID: "alice", AliasPlayerID: "nobody", GuildID: s.testGuildID})
+	s.ErrorIs(err, ErrPlayerNotFound)
+
+	// Merges are made in a server, and only move what the alt did there
+	_, err = s.gameService.MergePlayers(s.ctx, &MergePlayersInput{CanonicalPlayerID: "alice", AliasPlayerID: "alt"})
+	s.Error(err)
+
+	// alt is waiting in a game that hasn't been played yet
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		GuildID:     s.testGuildID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: createOutput.GameID, PlayerID: "alt", PlayerName: "alt"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.MergePlayers(s.ctx, &MergePlayersInput{CanonicalPlayerID: "alice", AliasPlayerID: "alt", GuildID: s.testGuildID})
+	s.ErrorIs(err, ErrMergePlayerInGame)
+}
+
+func (s *MergePlayersTestSuite) TestMergeStaysInServer() {
+	s.playGame([]string{"alt", "bob"}, []int{1, 4})
+	s.playGame([]string{"alice", "bob"}, []int{2, 4})
+
+	// The alt also drinks in another server, which isn't the admin's to merge
+	channelID, guildID := s.testChannelID, s.testGuildID
+	s.testChannelID, s.testGuildID = "other-server-channel", "other-server"
+	s.playGame([]string{"alt", "bob"}, []int{2, 4})
+	s.testChannelID, s.testGuildID = channelID, guildID
+
+	output, err := s.gameService.MergePlayers(s.ctx, &MergePlayersInput{
+		CanonicalPlayerID: "alice",
+		AliasPlayerID:     "alt",
+		GuildID:           s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Equal(2, output.RecordsMoved)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: "other-server-channel",
+		GuildID:   "other-server",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal("alt", leaderboard.Entries[0].PlayerID)
+	s.Equal(1, leaderboard.Entries[0].DrinkCount)
+}
+
+func (s *MergePlayersTestSuite) TestMergeMovesTokensAndModifiers() {
+	for _, player := range []*models.Player{
+		{ID: "alice", Name: "alice", RerollTokens: 1},
+		{ID: "alt", Name: "alt", RerollTokens: 2, RollModifiers: []*models.RollModifier{{Type: models.RollModifierBonus, Amount: 1, Rolls: 1}}},
+	} {
+		s.Require().NoError(s.playerRepo.SavePlayer(s.ctx, &playerRepo.SavePlayerInput{Player: player}))
+	}
+
+	output, err := s.gameService.MergePlayers(s.ctx, &MergePlayersInput{
+		CanonicalPlayerID: "alice",
+		AliasPlayerID:     "alt",
+		GuildID:           s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Equal(3, output.Canonical.RerollTokens)
+
+	alice, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(3, alice.RerollTokens)
+	s.Require().Len(alice.RollModifiers, 1)
+	s.Equal(1, alice.RollModifiers[0].Amount)
+
+	alt, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alt"})
+	s.Require().NoError(err)
+	s.Zero(alt.RerollTokens)
+	s.Empty(alt.RollModifiers)
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// AddRollModifier attaches a temporary modifier to a player's next rolls
+func (s *service) AddRollModifier(ctx context.Context, input *AddRollModifierInput) (*AddRollModifierOutput, error) {
+	if input == nil || input.PlayerID == "" || input.Modifier == nil {
+		return nil, errors.New("player ID and modifier are required")
+	}
+
+	modifier := *input.Modifier
+	if modifier.Rolls == 0 {
+		modifier.Rolls = 1
+	}
+
+	if err := validateRollModifier(&modifier); err != nil {
+		return nil, err
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, fmt.Errorf("failed to get player: %w", err)
+		}
+		player = &models.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		}
+	}
+
+	player.RollModifiers = append(player.RollModifiers, &modifier)
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save player: %w", err)
+	}
+
+	return &AddRollModifierOutput{
+		Modifiers: player.RollModifiers,
+	}, nil
+}
+
+// validateRollModifier checks that a modifier has an effect and applies to at least one roll
+func validateRollModifier(modifier *models.RollModifier) error {
+	if modifier.Rolls < 0 {
+		return ErrInvalidRollModifier
+	}
+
+	switch modifier.Type {
+	case models.RollModifierBonus:
+		if modifier.Amount == 0 {
+			return ErrInvalidRollModifier
+		}
+	case models.RollModifierAdvantage, models.RollModifierDisadvantage:
+	default:
+		return ErrInvalidRollModifier
+	}
+
+	return nil
+}
+
+// playerWithModifiers returns the player if they have modifiers waiting for their next roll, nil otherwise
+func (s *service) playerWithModifiers(ctx context.Context, playerID string) *models.Player {
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil {
+		if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			// Roll without modifiers rather than holding up the game
+			log.Printf("Error getting player modifiers: %v", err)
+		}
+		return nil
+	}
+
+	if len(player.RollModifiers) == 0 {
+		return nil
+	}
+
+	return player
+}
+
+// resolveRoll rolls the dice for a player and runs the roll through their modifiers.
+// Advantage and disadvantage pick between two rolls (cancelling each other out), then bonuses are added
+// and the result is kept on the dice.
+func (s *service) resolveRoll(rules models.GameRules, modifiers []*models.RollModifier) int {
+	advantage := 0
+	bonus := 0
+	for _, modifier := range modifiers {
+		switch modifier.Type {
+		case models.RollModifierAdvantage:
+			advantage++
+		case models.RollModifierDisadvantage:
+			advantage--
+		case models.RollModifierBonus:
+			bonus += modifier.Amount
+		}
+	}
+
+	rollValue := s.diceRoller.Roll(rules.DiceSides)
+	if advantage != 0 {
+		secondRoll := s.diceRoller.Roll(rules.DiceSides)
+		if (advantage > 0 && secondRoll > rollValue) || (advantage < 0 && secondRoll < rollValue) {
+			rollValue = secondRoll
+		}
+	}
+
+	rollValue += bonus
+	if rollValue < 1 {
+		rollValue = 1
+	}
+	if rollValue > rules.DiceSides {
+		rollValue = rules.DiceSides
+	}
+
+	return rollValue
+}
+
+// useRollModifiers counts a roll against the player's modifiers, dropping the ones that are used up
+func (s *service) useRollModifiers(ctx context.Context, player *models.Player) {
+	var remaining []*models.RollModifier
+	for _, modifier := range player.RollModifiers {
+		if modifier.Rolls > 1 {
+			used := *modifier
+			used.Rolls--
+			remaining = append(remaining, &used)
+		}
+	}
+	player.RollModifiers = remaining
+
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		log.Printf("Error saving used roll modifiers: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// RollModifierTestSuite tests roll modifiers against the real Redis repositories
+type RollModifierTestSuite struct {
+	serviceHarness
+}
+
+func (s *RollModifierTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "modifier-channel"
+}
+
+func TestRollModifierTestSuite(t *testing.T) {
+	suite.Run(t, new(RollModifierTestSuite))
+}
+
+func (s *RollModifierTestSuite) addModifier(playerID string, modifier *models.RollModifier) {
+	_, err := s.gameService.AddRollModifier(s.ctx, &AddRollModifierInput{PlayerID: playerID, PlayerName: playerID, Modifier: modifier})
+	s.Require().NoError(err)
+}
+
+func (s *RollModifierTestSuite) modifiers(playerID string) []*models.RollModifier {
+	player, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: playerID})
+	s.Require().NoError(err)
+	return player.RollModifiers
+}
+
+func (s *RollModifierTestSuite) TestBonusModifier() {
+	s.addModifier("alice", &models.RollModifier{Type: models.RollModifierBonus, Amount: 1, Source: "test"})
+	gameID := s.startGame("alice", "bob")
+
+	output := s.roll(gameID, "alice", 5)
+	s.Equal(6, output.RollValue)
+	s.True(output.IsCriticalHit)
+	s.Require().Len(output.RollModifiers, 1)
+	s.Equal("+1 to roll (test)", output.RollModifiers[0].Label())
+
+	// The modifier is used up, but the game remembers it was applied
+	s.Empty(s.modifiers("alice"))
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Len(game.GetParticipant("alice").RollModifiers, 1)
+}
+
+func (s *RollModifierTestSuite) TestBonusStaysOnTheDice() {
+	s.addModifier("alice", &models.RollModifier{Type: models.RollModifierBonus, Amount: -3})
+	s.addModifier("bob", &models.RollModifier{Type: models.RollModifierBonus, Amount: 3})
+	gameID := s.startGame("alice", "bob")
+
+	s.True(s.roll(gameID, "alice", 2).IsCriticalFail)
+	s.Equal(6, s.roll(gameID, "bob", 5).RollValue)
+}
+
+func (s *RollModifierTestSuite) TestAdvantageTakesBetter() {
+	s.addModifier("alice", &models.RollModifier{Type: models.RollModifierAdvantage})
+	gameID := s.startGame("alice", "bob")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(5)
+	s.Equal(5, s.roll(gameID, "alice", 2).RollValue)
+}
+
+func (s *RollModifierTestSuite) TestDisadvantageTakesWorse() {
+	s.addModifier("alice", &models.RollModifier{Type: models.RollModifierDisadvantage})
+	gameID := s.startGame("alice", "bob")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(2)
+	s.Equal(2, s.roll(gameID, "alice", 5).RollValue)
+}
+
+func (s *RollModifierTestSuite) TestRollModeFromRules() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{DiceSides: 20, CriticalHitValues: []int{20}, CriticalFailValues: []int{1}, RollMode: models.RollModifierAdvantage},
+	})
+	s.Require().NoError(err)
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: createOutput.GameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().NoError(err)
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: createOutput.GameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.mockDiceRoller.EXPECT().Roll(20).Return(20)
+	s.mockDiceRoller.EXPECT().Roll(20).Return(7)
+	output, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: createOutput.GameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(20, output.RollValue)
+	s.Equal(20, output.DiceSides)
+	s.True(output.IsCriticalHit)
+	s.Equal("Natural 20! Critical Hit!", output.Result)
+	s.Require().Len(output.RollModifiers, 1)
+	s.Equal(models.RollModifierAdvantage, output.RollModifiers[0].Type)
+
+	s.mockDiceRoller.EXPECT().Roll(20).Return(1)
+	s.mockDiceRoller.EXPECT().Roll(20).Return(1)
+	output, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: createOutput.GameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.True(output.IsCriticalFail)
+	s.Equal("Natural 1! Critical Fail!", output.Result)
+}
+
+func (s *RollModifierTestSuite) TestInvalidRollMode() {
+	_, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+		Rules:       &models.GameRules{RollMode: "lucky"},
+	})
+	s.ErrorIs(err, ErrInvalidGameRules)
+}
+
+func (s *RollModifierTestSuite) TestModifierLastsSeveralRolls() {
+	s.addModifier("alice", &models.RollModifier{Type: models.RollModifierBonus, Amount: 1, Rolls: 2})
+
+	gameID := s.startGame("alice", "bob")
+	s.Equal(4, s.roll(gameID, "alice", 3).RollValue)
+	s.roll(gameID, "bob", 3)
+
+	remaining := s.modifiers("alice")
+	s.Require().Len(remaining, 1)
+	s.Equal(1, remaining[0].Rolls)
+
+	gameID = s.startGame("alice", "bob")
+	s.Equal(4, s.roll(gameID, "alice", 3).RollValue)
+	s.Empty(s.modifiers("alice"))
+}
+
+func (s *RollModifierTestSuite) TestInvalidModifier() {
+	_, err := s.gameService.AddRollModifier(s.ctx, &AddRollModifierInput{
+		PlayerID: "alice",
+		Modifier: &models.RollModifier{Type: models.RollModifierBonus},
+	})
+	s.ErrorIs(err, ErrInvalidRollModifier)
+
+	_, err = s.gameService.AddRollModifier(s.ctx, &AddRollModifierInput{
+		PlayerID: "alice",
+		Modifier: &models.RollModifier{Type: "lucky"},
+	})
+	s.ErrorIs(err, ErrInvalidRollModifier)
+}
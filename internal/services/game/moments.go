@@ -0,0 +1,187 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	momentRepo "github.com/KirkDiggler/ronnied/internal/repositories/moment"
+)
+
+// ClipMoment keeps the latest roll in the channel's game, or its last finished game, as a moment to look back on
+func (s *service) ClipMoment(ctx context.Context, input *ClipMomentInput) (*ClipMomentOutput, error) {
+	if input == nil || input.ChannelID == "" || input.PlayerID == "" {
+		return nil, errors.New("channel and player IDs are required")
+	}
+
+	if s.momentRepo == nil {
+		return nil, ErrMomentsDisabled
+	}
+
+	game, err := s.gameToClip(ctx, input.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roll-offs are played alongside their game, so their rolls count as the game's latest too
+	games := s.gameWithRollOffs(ctx, game)
+
+	var latestGame *models.Game
+	var latest *models.Participant
+	for _, g := range games {
+		for _, p := range g.Participants {
+			if p.RollTime == nil {
+				continue
+			}
+			if latest == nil || p.RollTime.After(*latest.RollTime) {
+				latestGame, latest = g, p
+			}
+		}
+	}
+
+	if latest == nil {
+		return nil, ErrNothingToClip
+	}
+
+	drinkCount := 0
+	for _, g := range games {
+		output, err := s.drinkLedgerRepo.GetDrinkRecordsForGame(ctx, &ledgerRepo.GetDrinkRecordsForGameInput{
+			GameID: g.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get drink records: %w", err)
+		}
+		for _, record := range output.Records {
+			if record.ToPlayerID == latest.PlayerID {
+				drinkCount++
+			}
+		}
+	}
+
+	rules := s.rulesFor(latestGame)
+	moment := &models.Moment{
+		ID:             fmt.Sprintf("%s:%s:%d", latestGame.ID, latest.PlayerID, latest.RollTime.UnixNano()),
+		GuildID:        game.GuildID,
+		ChannelID:      game.ChannelID,
+		GameID:         latestGame.ID,
+		PlayerID:       latest.PlayerID,
+		PlayerName:     latest.PlayerName,
+		RollValue:      latest.RollValue,
+		IsCriticalHit:  rules.IsCriticalHit(latest.RollValue),
+		IsCriticalFail: rules.IsCriticalFail(latest.RollValue),
+		IsRollOff:      latestGame.ParentGameID != "",
+		DrinkCount:     drinkCount,
+		RolledAt:       *latest.RollTime,
+		ClippedByID:    input.PlayerID,
+		ClippedByName:  input.PlayerName,
+		ClippedAt:      s.clock.Now(),
+	}
+
+	if err := s.momentRepo.AddMoment(ctx, &momentRepo.AddMomentInput{Moment: moment}); err != nil {
+		if errors.Is(err, momentRepo.ErrMomentExists) {
+			return nil, ErrAlreadyClipped
+		}
+		return nil, fmt.Errorf("failed to save moment: %w", err)
+	}
+
+	return &ClipMomentOutput{
+		Moment: moment,
+	}, nil
+}
+
+// GetMoments returns a page of a server's clipped moments, newest first
+func (s *service) GetMoments(ctx context.Context, input *GetMomentsInput) (*GetMomentsOutput, error) {
+	if input == nil || (input.GuildID == "" && input.ChannelID == "") {
+		return nil, errors.New("guild or channel ID is required")
+	}
+
+	if s.momentRepo == nil {
+		return nil, ErrMomentsDisabled
+	}
+
+	output, err := s.momentRepo.GetMoments(ctx, &momentRepo.GetMomentsInput{
+		Scope:  sessionScope(input.GuildID, input.ChannelID),
+		Offset: input.Offset,
+		Limit:  input.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moments: %w", err)
+	}
+
+	return &GetMomentsOutput{
+		Moments: output.Moments,
+		Total:   output.Total,
+	}, nil
+}
+
+// gameToClip returns the channel's game, or the last one finished there when nothing is being played
+func (s *service) gameToClip(ctx context.Context, channelID string) (*models.Game, error) {
+	game, err := s.gameRepo.GetActiveGameByChannel(ctx, &gameRepo.GetActiveGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err == nil {
+		return game, nil
+	}
+	if !errors.Is(err, gameRepo.ErrGameNotFound) {
+		return nil, fmt.Errorf("failed to get active game by channel: %w", err)
+	}
+
+	game, err = s.gameRepo.GetLatestCompletedGameByChannel(ctx, &gameRepo.GetLatestCompletedGameByChannelInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrNothingToClip
+		}
+		return nil, fmt.Errorf("failed to get completed game by channel: %w", err)
+	}
+
+	return game, nil
+}
+
+// gameWithRollOffs returns a game along with its roll-offs and theirs, skipping any that have gone missing
+func (s *service) gameWithRollOffs(ctx context.Context, game *models.Game) []*models.Game {
+	games := []*models.Game{game}
+	seen := map[string]bool{game.ID: true}
+
+	for i := 0; i < len(games); i++ {
+		for _, rollOffID := range []string{games[i].RollOffGameID, games[i].HighestRollOffGameID, games[i].LowestRollOffGameID} {
+			if rollOffID == "" || seen[rollOffID] {
+				continue
+			}
+			seen[rollOffID] = true
+
+			rollOff, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+				GameID: rollOffID,
+			})
+			if err != nil {
+				continue
+			}
+			games = append(games, rollOff)
+		}
+	}
+
+	return games
+}
+
+// anonymizeMoments moves a forgotten player's moments to their anonymous ID, only the guild's when a guild is given
+func (s *service) anonymizeMoments(ctx context.Context, playerID, anonymousID, guildID string) (int, error) {
+	if s.momentRepo == nil {
+		return 0, nil
+	}
+
+	output, err := s.momentRepo.ReassignPlayerMoments(ctx, &momentRepo.ReassignPlayerMomentsInput{
+		FromPlayerID: playerID,
+		ToPlayerID:   anonymousID,
+		ToPlayerName: models.DeletedPlayerName,
+		GuildID:      guildID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize moments: %w", err)
+	}
+
+	return output.MomentsMoved, nil
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	momentRepo "github.com/KirkDiggler/ronnied/internal/repositories/moment"
+	"github.com/stretchr/testify/suite"
+)
+
+// MomentTestSuite tests clipping and browsing moments against the real Redis repositories
+type MomentTestSuite struct {
+	serviceHarness
+}
+
+func (s *MomentTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		moments, err := momentRepo.NewRedis(&momentRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		cfg.MomentRepo = moments
+	})
+	s.testChannelID = "moment-channel"
+	s.testGuildID = "moment-guild"
+}
+
+func TestMomentTestSuite(t *testing.T) {
+	suite.Run(t, new(MomentTestSuite))
+}
+
+// playRound starts the game and has bob roll lowest last, so he's given a drink
+func (s *MomentTestSuite) playRound(gameID string) {
+	s.start(gameID, "alice")
+	s.roll(gameID, "alice", 4)
+	s.roll(gameID, "bob", 2)
+}
+
+// clip has carol clip the latest roll in the channel
+func (s *MomentTestSuite) clip() (*ClipMomentOutput, error) {
+	return s.gameService.ClipMoment(s.ctx, &ClipMomentInput{
+		ChannelID:  s.testChannelID,
+		PlayerID:   "carol",
+		PlayerName: "Carol",
+	})
+}
+
+func (s *MomentTestSuite) TestClipMoment() {
+	gameID := s.createGame("alice", "bob")
+	s.playRound(gameID)
+
+	output, err := s.clip()
+	s.Require().NoError(err)
+
+	moment := output.Moment
+	s.Equal("moment-guild", moment.GuildID)
+	s.Equal(gameID, moment.GameID)
+	s.Equal("bob", moment.PlayerID)
+	s.Equal(2, moment.RollValue)
+	s.False(moment.IsCriticalHit)
+	s.False(moment.IsCriticalFail)
+	s.Equal(1, moment.DrinkCount)
+	s.Equal("Carol", moment.ClippedByName)
+
+	moments, err := s.gameService.GetMoments(s.ctx, &GetMomentsInput{GuildID: "moment-guild"})
+	s.Require().NoError(err)
+	s.Equal(1, moments.Total)
+	s.Require().Len(moments.Moments, 1)
+	s.Equal(moment.ID, moments.Moments[0].ID)
+}
+
+func (s *MomentTestSuite) TestClipMoment_AlreadyClipped() {
+	gameID := s.createGame("alice", "bob")
+	s.playRound(gameID)
+
+	_, err := s.clip()
+	s.Require().NoError(err)
+
+	_, err = s.clip()
+	s.ErrorIs(err, ErrAlreadyClipped)
+}
+
+func (s *MomentTestSuite) TestClipMoment_NothingToClip() {
+	_, err := s.clip()
+	s.ErrorIs(err, ErrNothingToClip)
+
+	// Nobody has rolled yet
+	s.createGame("alice", "bob")
+	_, err = s.clip()
+	s.ErrorIs(err, ErrNothingToClip)
+}
+
+func (s *MomentTestSuite) TestClipMoment_Disabled() {
+	svc := s.newService(nil)
+
+	_, err := svc.ClipMoment(s.ctx, &ClipMomentInput{ChannelID: s.testChannelID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrMomentsDisabled)
+}
+
+func (s *MomentTestSuite) TestForgetPlayer_AnonymizesMoments() {
+	gameID := s.createGame("alice", "bob")
+	s.playRound(gameID)
+
+	_, err := s.clip()
+	s.Require().NoError(err)
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{PlayerID: "bob", RequestedBy: "bob"})
+	s.Require().NoError(err)
+	s.Equal(1, output.MomentsAnonymized)
+
+	moments, err := s.gameService.GetMoments(s.ctx, &GetMomentsInput{GuildID: "moment-guild"})
+	s.Require().NoError(err)
+	s.Require().Len(moments.Moments, 1)
+	s.Equal(output.AnonymousID, moments.Moments[0].PlayerID)
+	s.Equal(models.DeletedPlayerName, moments.Moments[0].PlayerName)
+}
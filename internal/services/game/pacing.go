@@ -0,0 +1,81 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// checkPacing warns the channel when a player has paid more drinks within the pacing window than allowed,
+// returning true if they have
+func (s *service) checkPacing(ctx context.Context, game *models.Game, sessionID string, records []*models.DrinkLedger, playerID string, now time.Time) bool {
+	if s.pacing == nil {
+		return false
+	}
+
+	drinksPaid := paidBetween(records, playerID, now.Add(-s.pacing.Window), now)
+	if drinksPaid <= s.pacing.MaxDrinks {
+		return false
+	}
+
+	playerName := playerID
+	if participant := game.GetParticipant(playerID); participant != nil {
+		playerName = participant.PlayerName
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypePacingWarning,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		SessionID: sessionID,
+		Timestamp: now,
+		Payload: &events.PacingWarningPayload{
+			PlayerID:        playerID,
+			PlayerName:      playerName,
+			DrinksPaid:      drinksPaid,
+			WindowMinutes:   int(s.pacing.Window / time.Minute),
+			CooldownMinutes: int(s.pacing.Cooldown / time.Minute),
+		},
+	})
+
+	return true
+}
+
+// pacingCooldownUntil returns when a player can pay drinks again, the zero time if they aren't cooling down.
+// A cooldown starts with the payment that took the player over the pacing limit.
+func (s *service) pacingCooldownUntil(records []*models.DrinkLedger, playerID string) time.Time {
+	if s.pacing.Cooldown <= 0 {
+		return time.Time{}
+	}
+
+	var lastPaid time.Time
+	for _, record := range records {
+		if record.ToPlayerID == playerID && record.Paid && record.PaidTimestamp.After(lastPaid) {
+			lastPaid = record.PaidTimestamp
+		}
+	}
+
+	if lastPaid.IsZero() || paidBetween(records, playerID, lastPaid.Add(-s.pacing.Window), lastPaid) <= s.pacing.MaxDrinks {
+		return time.Time{}
+	}
+
+	return lastPaid.Add(s.pacing.Cooldown)
+}
+
+// paidBetween counts the drinks a player paid between from and to, inclusive
+func paidBetween(records []*models.DrinkLedger, playerID string, from, to time.Time) int {
+	count := 0
+	for _, record := range records {
+		if record.ToPlayerID != playerID || !record.Paid {
+			continue
+		}
+		if record.PaidTimestamp.Before(from) || record.PaidTimestamp.After(to) {
+			continue
+		}
+		count++
+	}
+	return count
+}
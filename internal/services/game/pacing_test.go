@@ -0,0 +1,164 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// PacingTestSuite tests the drink pacing checks against the real Redis repositories
+type PacingTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+	warnings  []*events.PacingWarningPayload
+
+	// now is the time the mocked clock reports
+	now time.Time
+}
+
+func (s *PacingTestSuite) SetupTest() {
+	s.now = time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+	})
+	s.testChannelID = "pacing-channel"
+
+	s.warnings = nil
+	s.eventBus.Subscribe(events.TypePacingWarning, func(_ context.Context, event *events.Event) {
+		s.warnings = append(s.warnings, event.Payload.(*events.PacingWarningPayload))
+	})
+}
+
+func TestPacingTestSuite(t *testing.T) {
+	suite.Run(t, new(PacingTestSuite))
+}
+
+// pacedService creates a game service with the pacing config
+func (s *PacingTestSuite) pacedService(pacing *PacingConfig) Service {
+	return s.newService(func(cfg *Config) {
+		cfg.Clock = s.mockClock
+		cfg.Pacing = pacing
+	})
+}
+
+// setupDrinks creates a game in the test channel and gives alice the number of drinks, returning the game ID
+func (s *PacingTestSuite) setupDrinks(svc Service, drinks int) string {
+	sessionOutput, err := svc.StartNewSession(s.ctx, &StartNewSessionInput{
+		ChannelID: s.testChannelID,
+		CreatorID: "alice",
+	})
+	s.Require().NoError(err)
+
+	gameOutput, err := svc.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "Alice",
+	})
+	s.Require().NoError(err)
+
+	for i := 0; i < drinks; i++ {
+		_, err := s.ledgerRepo.CreateDrinkRecord(s.ctx, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:     gameOutput.GameID,
+			ToPlayerID: "alice",
+			Reason:     models.DrinkReasonLowestRoll,
+			Timestamp:  s.now,
+			SessionID:  sessionOutput.SessionID,
+		})
+		s.Require().NoError(err)
+	}
+
+	return gameOutput.GameID
+}
+
+// payAt pays one of alice's drinks at the given offset from the start of the test
+func (s *PacingTestSuite) payAt(svc Service, gameID string, start time.Time, offset time.Duration) (*PayDrinkOutput, error) {
+	s.now = start.Add(offset)
+	return svc.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "alice"})
+}
+
+func (s *PacingTestSuite) TestWarnsAndCoolsDown() {
+	svc := s.pacedService(&PacingConfig{MaxDrinks: 2, Window: 30 * time.Minute, Cooldown: 10 * time.Minute})
+	gameID := s.setupDrinks(svc, 5)
+	start := s.now
+
+	output, err := s.payAt(svc, gameID, start, 0)
+	s.Require().NoError(err)
+	s.False(output.PacingWarning)
+	s.Equal(start, output.DrinkRecord.PaidTimestamp)
+
+	output, err = s.payAt(svc, gameID, start, time.Minute)
+	s.Require().NoError(err)
+	s.False(output.PacingWarning)
+	s.Empty(s.warnings)
+
+	// The third drink in the window is one too many
+	output, err = s.payAt(svc, gameID, start, 2*time.Minute)
+	s.Require().NoError(err)
+	s.True(output.PacingWarning)
+	s.Require().Len(s.warnings, 1)
+	s.Equal("Alice", s.warnings[0].PlayerName)
+	s.Equal(3, s.warnings[0].DrinksPaid)
+	s.Equal(30, s.warnings[0].WindowMinutes)
+	s.Equal(10, s.warnings[0].CooldownMinutes)
+
+	_, err = s.payAt(svc, gameID, start, 5*time.Minute)
+	s.ErrorIs(err, ErrPacingCooldown)
+	s.Equal(models.ErrorCodeCoolingDown, models.ErrorCodeOf(err))
+
+	// Once the cooldown is over they can pay again
+	_, err = s.payAt(svc, gameID, start, 12*time.Minute)
+	s.NoError(err)
+}
+
+func (s *PacingTestSuite) TestWarnsWithoutCooldown() {
+	svc := s.pacedService(&PacingConfig{MaxDrinks: 1, Window: 10 * time.Minute})
+	gameID := s.setupDrinks(svc, 3)
+	start := s.now
+
+	_, err := s.payAt(svc, gameID, start, 0)
+	s.Require().NoError(err)
+
+	output, err := s.payAt(svc, gameID, start, time.Minute)
+	s.Require().NoError(err)
+	s.True(output.PacingWarning)
+
+	output, err = s.payAt(svc, gameID, start, 2*time.Minute)
+	s.Require().NoError(err)
+	s.True(output.PacingWarning)
+	s.Len(s.warnings, 2)
+}
+
+func (s *PacingTestSuite) TestSlowDrinkingIsFine() {
+	svc := s.pacedService(&PacingConfig{MaxDrinks: 1, Window: 10 * time.Minute, Cooldown: 10 * time.Minute})
+	gameID := s.setupDrinks(svc, 3)
+	start := s.now
+
+	for i := 0; i < 3; i++ {
+		output, err := s.payAt(svc, gameID, start, time.Duration(i)*15*time.Minute)
+		s.Require().NoError(err)
+		s.False(output.PacingWarning)
+	}
+	s.Empty(s.warnings)
+}
+
+func (s *PacingTestSuite) TestInvalidConfig() {
+	_, err := New(&Config{
+		GameRepo:        s.gameRepo,
+		PlayerRepo:      s.playerRepo,
+		DrinkLedgerRepo: s.ledgerRepo,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           s.mockClock,
+		Pacing:          &PacingConfig{MaxDrinks: 3},
+	})
+	s.Error(err)
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	jobRepo "github.com/KirkDiggler/ronnied/internal/repositories/job"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+)
+
+// maxJobAttempts is how many times a background job is tried before it's given up on
+const maxJobAttempts = 3
+
+// jobTimeout is how long a job taken off the queue gets to run, and to be queued again if it fails
+const jobTimeout = time.Minute
+
+// queuePostGame queues the work left once a game completed to be done in the background, reporting whether it was.
+// Without a job queue, or when the job couldn't be queued, the work is left to the caller.
+func (s *service) queuePostGame(ctx context.Context, game *models.Game, sessionID string) bool {
+	if s.jobRepo == nil {
+		return false
+	}
+
+	err := s.jobRepo.Enqueue(ctx, &jobRepo.EnqueueInput{
+		Job: &models.Job{
+			ID:         s.uuid.NewUUID(),
+			Type:       models.JobTypePostGame,
+			GameID:     game.ID,
+			SessionID:  sessionID,
+			EnqueuedAt: s.clock.Now(),
+		},
+	})
+	if err != nil {
+		log.Printf("Error queueing post-game work for game %s, doing it now: %v", game.ID, err)
+		return false
+	}
+
+	return true
+}
+
+// finishGame does the work left once a game completed: completing the games above a roll-off, getting the session
+// leaderboard, letting subscribers (webhooks, etc.) know and closing a last call session. Roll-offs are reported
+// through their parent game once it completes. It returns the session leaderboard and the game reported, nil if
+// the game was a roll-off whose parent is still being played.
+func (s *service) finishGame(ctx context.Context, game, parentGame *models.Game, results []*events.PlayerResult, sessionID string) ([]LeaderboardEntry, *models.Game) {
+	var completedParentGame *models.Game
+	if game.ParentGameID != "" && parentGame != nil {
+		completedParentGame = s.completeRollOffParents(ctx, game, parentGame)
+	}
+
+	// Get the session leaderboard, from the primary so it has the game's last drinks
+	var sessionLeaderboard []LeaderboardEntry
+	sessionLeaderboardOutput, err := s.GetSessionLeaderboard(replica.WithPrimaryReads(ctx), &GetSessionLeaderboardInput{
+		SessionID: sessionID,
+		GuildID:   game.GuildID,
+		ChannelID: game.ChannelID,
+	})
+	if err == nil && sessionLeaderboardOutput != nil {
+		sessionLeaderboard = sessionLeaderboardOutput.Entries
+	}
+
+	if game.ParentGameID == "" {
+		s.publishGameCompleted(ctx, game, results, sessionID, sessionLeaderboard)
+		s.countSurvivedGameGoals(ctx, game)
+		s.closeLastCallSession(ctx, game)
+		return sessionLeaderboard, game
+	}
+
+	if completedParentGame != nil {
+		s.publishGameCompleted(ctx, completedParentGame, s.getPlayerResults(ctx, completedParentGame), sessionID, sessionLeaderboard)
+		s.countSurvivedGameGoals(ctx, completedParentGame)
+		s.closeLastCallSession(ctx, completedParentGame)
+	}
+	return sessionLeaderboard, completedParentGame
+}
+
+// ProcessNextJob takes the next job off the queue and runs it, completing it once it's done. A job that fails is
+// queued again until it has been tried maxJobAttempts times. Only waiting for a job stops when the context is
+// done: a job already taken is run, and queued again if it fails, even while the worker is shutting down, so it
+// isn't cut off or lost. A job left taken by a worker that stopped anyway is queued again when one next starts.
+func (s *service) ProcessNextJob(ctx context.Context, input *ProcessNextJobInput) (*ProcessNextJobOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	output := &ProcessNextJobOutput{}
+	if s.jobRepo == nil {
+		return output, nil
+	}
+
+	dequeueOutput, err := s.jobRepo.Dequeue(ctx, &jobRepo.DequeueInput{
+		Timeout: input.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take the next job: %w", err)
+	}
+
+	job := dequeueOutput.Job
+	if job == nil {
+		return output, nil
+	}
+	output.Job = job
+
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobTimeout)
+	defer cancel()
+
+	if err := s.runJob(jobCtx, job); err != nil {
+		job.Attempts++
+		if job.Attempts >= maxJobAttempts {
+			log.Printf("Giving up on job %s after %d attempts", job.ID, job.Attempts)
+		}
+
+		// The job is queued again and completed together, so it's neither lost nor run twice
+		requeueErr := s.inTransaction(jobCtx, func(ctx context.Context) error {
+			if job.Attempts < maxJobAttempts {
+				if err := s.jobRepo.Enqueue(ctx, &jobRepo.EnqueueInput{Job: job}); err != nil {
+					return err
+				}
+			}
+			return s.jobRepo.Complete(ctx, &jobRepo.CompleteInput{JobID: job.ID})
+		})
+		if requeueErr != nil {
+			log.Printf("Error queueing job %s again: %v", job.ID, requeueErr)
+		}
+		return nil, fmt.Errorf("failed to run job %s: %w", job.ID, err)
+	}
+
+	if err := s.jobRepo.Complete(jobCtx, &jobRepo.CompleteInput{JobID: job.ID}); err != nil {
+		return nil, fmt.Errorf("failed to complete job %s: %w", job.ID, err)
+	}
+
+	return output, nil
+}
+
+// runJob does the work of a job
+func (s *service) runJob(ctx context.Context, job *models.Job) error {
+	switch job.Type {
+	case models.JobTypePostGame:
+		return s.runPostGame(ctx, job)
+	}
+
+	return fmt.Errorf("unknown job type %q", job.Type)
+}
+
+// runPostGame finishes off a completed game in the background, then lets subscribers know its message can be
+// redrawn with the results
+func (s *service) runPostGame(ctx context.Context, job *models.Job) error {
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: job.GameID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get game: %w", err)
+	}
+
+	var parentGame *models.Game
+	var results []*events.PlayerResult
+	if game.ParentGameID != "" {
+		parentGame, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.ParentGameID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get parent game: %w", err)
+		}
+	} else {
+		results = s.getPlayerResults(ctx, game)
+	}
+
+	_, finishedGame := s.finishGame(ctx, game, parentGame, results, job.SessionID)
+	if finishedGame == nil {
+		return nil
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypePostGameFinished,
+		ChannelID: finishedGame.ChannelID,
+		GuildID:   finishedGame.GuildID,
+		GameID:    finishedGame.ID,
+		SessionID: job.SessionID,
+		Timestamp: s.clock.Now(),
+	})
+
+	return nil
+}
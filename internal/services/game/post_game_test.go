@@ -0,0 +1,189 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	jobRepo "github.com/KirkDiggler/ronnied/internal/repositories/job"
+	"github.com/stretchr/testify/suite"
+)
+
+// stoppingJobRepo stops the worker once it's taken a job, like a shutdown starting while the job is run
+type stoppingJobRepo struct {
+	jobRepo.Repository
+	stop context.CancelFunc
+}
+
+func (r *stoppingJobRepo) Dequeue(ctx context.Context, input *jobRepo.DequeueInput) (*jobRepo.DequeueOutput, error) {
+	output, err := r.Repository.Dequeue(ctx, input)
+	if r.stop != nil {
+		r.stop()
+	}
+	return output, err
+}
+
+// PostGameTestSuite tests finishing off completed games with a job queue
+type PostGameTestSuite struct {
+	serviceHarness
+	jobRepo   *stoppingJobRepo
+	published []*events.Event
+}
+
+func (s *PostGameTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		jobs, err := jobRepo.NewRedis(&jobRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		s.jobRepo = &stoppingJobRepo{Repository: jobs}
+
+		cfg.JobRepo = s.jobRepo
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "post-game-channel"
+
+	s.published = nil
+	for _, eventType := range []events.Type{events.TypeGameCompleted, events.TypePostGameFinished} {
+		s.eventBus.Subscribe(eventType, func(_ context.Context, event *events.Event) {
+			s.published = append(s.published, event)
+		})
+	}
+}
+
+func TestPostGameTestSuite(t *testing.T) {
+	suite.Run(t, new(PostGameTestSuite))
+}
+
+func (s *PostGameTestSuite) processNextJob() *models.Job {
+	output, err := s.gameService.ProcessNextJob(s.ctx, &ProcessNextJobInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	return output.Job
+}
+
+// takenJobs counts the jobs taken off the queue by any worker and not completed
+func (s *PostGameTestSuite) takenJobs() int {
+	taken := 0
+	for _, key := range s.mr.Keys() {
+		if strings.Contains(key, ":processing:") {
+			jobs, err := s.mr.List(key)
+			s.Require().NoError(err)
+			taken += len(jobs)
+		}
+	}
+	return taken
+}
+
+func (s *PostGameTestSuite) TestCompletedGameIsFinishedByJob() {
+	gameID := s.playGame([]string{"alice", "bob"}, []int{4, 3})
+
+	// The game is complete, but nobody's been told yet
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+	s.Empty(s.published)
+
+	job := s.processNextJob()
+	s.Require().NotNil(job)
+	s.Equal(models.JobTypePostGame, job.Type)
+	s.Equal(gameID, job.GameID)
+
+	s.Require().Len(s.published, 2)
+	s.Equal(events.TypeGameCompleted, s.published[0].Type)
+	s.Equal(gameID, s.published[0].GameID)
+	results := s.published[0].Payload.(*events.GameCompletedPayload).Results
+	s.Len(results, 2)
+
+	s.Equal(events.TypePostGameFinished, s.published[1].Type)
+	s.Equal(gameID, s.published[1].GameID)
+	s.Equal("post-game-channel", s.published[1].ChannelID)
+	s.Zero(s.takenJobs(), "the job is completed once it's run")
+}
+
+func (s *PostGameTestSuite) TestRollOffParentIsCompletedByJob() {
+	gameID := s.playGame([]string{"alice", "bob", "carol"}, []int{3, 3, 5})
+	s.Equal(models.GameStatusRollOff, s.gameStatus(gameID))
+
+	gameOutput, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	rollOffGameID := gameOutput.Game.LowestRollOffGameID
+	s.Require().NotEmpty(rollOffGameID)
+
+	s.rollAll(rollOffGameID, []string{"alice", "bob"}, []int{2, 4})
+	s.Equal(models.GameStatusCompleted, s.gameStatus(rollOffGameID))
+	s.Equal(models.GameStatusRollOff, s.gameStatus(gameID), "the main game is completed in the background")
+
+	job := s.processNextJob()
+	s.Require().NotNil(job)
+	s.Equal(rollOffGameID, job.GameID)
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+
+	// The main game is reported and redrawn, not the roll-off
+	s.Require().Len(s.published, 2)
+	s.Equal(gameID, s.published[0].GameID)
+	s.Equal(gameID, s.published[1].GameID)
+}
+
+func (s *PostGameTestSuite) TestFailedJobIsRetried() {
+	err := s.jobRepo.Enqueue(s.ctx, &jobRepo.EnqueueInput{
+		Job: &models.Job{ID: "job-1", Type: models.JobTypePostGame, GameID: "missing"},
+	})
+	s.Require().NoError(err)
+
+	for attempt := 1; attempt <= maxJobAttempts; attempt++ {
+		_, err := s.gameService.ProcessNextJob(s.ctx, &ProcessNextJobInput{Timeout: time.Second})
+		s.Error(err, "attempt %d", attempt)
+	}
+
+	// The job was given up on after its last attempt
+	output, err := s.jobRepo.Dequeue(s.ctx, &jobRepo.DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Nil(output.Job)
+	s.Zero(s.takenJobs())
+}
+
+func (s *PostGameTestSuite) TestJobFinishesWhenWorkerStops() {
+	gameID := s.playGame([]string{"alice", "bob"}, []int{4, 3})
+
+	ctx, stop := context.WithCancel(s.ctx)
+	defer stop()
+	s.jobRepo.stop = stop
+
+	output, err := s.gameService.ProcessNextJob(ctx, &ProcessNextJobInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+	s.Equal(gameID, output.Job.GameID)
+
+	// The players were still told about the game, though the worker stopped while it was being finished
+	s.Require().Len(s.published, 2)
+	s.Equal(events.TypePostGameFinished, s.published[1].Type)
+}
+
+func (s *PostGameTestSuite) TestFailedJobIsRequeuedWhenWorkerStops() {
+	err := s.jobRepo.Enqueue(s.ctx, &jobRepo.EnqueueInput{
+		Job: &models.Job{ID: "job-1", Type: models.JobTypePostGame, GameID: "missing"},
+	})
+	s.Require().NoError(err)
+
+	ctx, stop := context.WithCancel(s.ctx)
+	defer stop()
+	s.jobRepo.stop = stop
+
+	_, err = s.gameService.ProcessNextJob(ctx, &ProcessNextJobInput{Timeout: time.Second})
+	s.Error(err)
+	s.jobRepo.stop = nil
+
+	// The job is waiting to be tried again when the bot next starts
+	output, err := s.jobRepo.Dequeue(s.ctx, &jobRepo.DequeueInput{Timeout: time.Second})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Job)
+	s.Equal("job-1", output.Job.ID)
+	s.Equal(1, output.Job.Attempts)
+}
+
+func (s *PostGameTestSuite) TestProcessNextJob_Empty() {
+	s.Nil(s.processNextJob())
+
+	_, err := s.gameService.ProcessNextJob(s.ctx, nil)
+	s.Error(err)
+}
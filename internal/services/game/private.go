@@ -0,0 +1,55 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// InvitePlayers invites players to a private game while it's waiting for players, only its creator can.
+// Players already invited are skipped.
+func (s *service) InvitePlayers(ctx context.Context, input *InvitePlayersInput) (*InvitePlayersOutput, error) {
+	if input == nil || input.GameID == "" || input.InviterID == "" || len(input.PlayerIDs) == 0 {
+		return nil, errors.New("game, inviter and player IDs are required")
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if !game.Private {
+		return nil, ErrGameNotPrivate
+	}
+
+	if game.CreatorID != input.InviterID {
+		return nil, ErrNotCreator
+	}
+
+	// Invites only matter while players can still join
+	if !game.Status.IsWaiting() {
+		return nil, fmt.Errorf("%w: game status is %s", ErrInvalidGameState, game.Status)
+	}
+
+	game.Invite(input.PlayerIDs...)
+
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return &InvitePlayersOutput{
+		Game: game,
+	}, nil
+}
+
+// invitedPlayerIDs returns the players a private game is created inviting, without the creator or duplicates
+func invitedPlayerIDs(creatorID string, playerIDs []string) []string {
+	invites := &models.Game{CreatorID: creatorID}
+	invites.Invite(playerIDs...)
+	return invites.InvitedPlayerIDs
+}
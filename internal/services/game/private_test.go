@@ -0,0 +1,113 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// PrivateGameTestSuite tests invite-only games against the real Redis repositories
+type PrivateGameTestSuite struct {
+	serviceHarness
+}
+
+func (s *PrivateGameTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "private-channel"
+}
+
+func TestPrivateGameTestSuite(t *testing.T) {
+	suite.Run(t, new(PrivateGameTestSuite))
+}
+
+// createGame creates a game started by alice, inviting the given players
+func (s *PrivateGameTestSuite) createGame(private bool, invited ...string) string {
+	return s.createGameWith(&CreateGameInput{Private: private, InvitedPlayerIDs: invited}, "alice")
+}
+
+// join has a player try to join the game
+func (s *PrivateGameTestSuite) join(gameID, playerID string) error {
+	_, err := s.gameService.JoinGame(s.ctx, &JoinGameInput{
+		GameID:     gameID,
+		PlayerID:   playerID,
+		PlayerName: playerID,
+	})
+	return err
+}
+
+func (s *PrivateGameTestSuite) TestCreateGame_InvitesMakeTheGamePrivate() {
+	gameID := s.createGame(false, "bob", "alice", "bob", "")
+
+	output, err := s.gameService.GetGame(s.ctx, &GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.True(output.Game.Private)
+	s.Equal([]string{"bob"}, output.Game.InvitedPlayerIDs)
+}
+
+func (s *PrivateGameTestSuite) TestJoinGame_OnlyInvitedPlayers() {
+	gameID := s.createGame(true, "bob")
+
+	s.NoError(s.join(gameID, "bob"))
+	s.ErrorIs(s.join(gameID, "carol"), ErrNotInvited)
+
+	// The creator is already in and rejoining is fine
+	s.NoError(s.join(gameID, "alice"))
+}
+
+func (s *PrivateGameTestSuite) TestJoinGame_PublicGame() {
+	gameID := s.createGame(false)
+
+	s.NoError(s.join(gameID, "carol"))
+}
+
+func (s *PrivateGameTestSuite) TestInvitePlayers() {
+	gameID := s.createGame(true, "bob")
+
+	output, err := s.gameService.InvitePlayers(s.ctx, &InvitePlayersInput{
+		GameID:    gameID,
+		InviterID: "alice",
+		PlayerIDs: []string{"carol", "bob"},
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{"bob", "carol"}, output.Game.InvitedPlayerIDs)
+
+	s.NoError(s.join(gameID, "carol"))
+}
+
+func (s *PrivateGameTestSuite) TestInvitePlayers_OnlyTheCreator() {
+	gameID := s.createGame(true, "bob")
+
+	_, err := s.gameService.InvitePlayers(s.ctx, &InvitePlayersInput{
+		GameID:    gameID,
+		InviterID: "bob",
+		PlayerIDs: []string{"carol"},
+	})
+	s.ErrorIs(err, ErrNotCreator)
+	s.ErrorIs(s.join(gameID, "carol"), ErrNotInvited)
+}
+
+func (s *PrivateGameTestSuite) TestInvitePlayers_PublicGame() {
+	gameID := s.createGame(false)
+
+	_, err := s.gameService.InvitePlayers(s.ctx, &InvitePlayersInput{
+		GameID:    gameID,
+		InviterID: "alice",
+		PlayerIDs: []string{"carol"},
+	})
+	s.ErrorIs(err, ErrGameNotPrivate)
+}
+
+func (s *PrivateGameTestSuite) TestInvitePlayers_GameStarted() {
+	gameID := s.createGame(true, "bob")
+	s.Require().NoError(s.join(gameID, "bob"))
+
+	_, err := s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.InvitePlayers(s.ctx, &InvitePlayersInput{
+		GameID:    gameID,
+		InviterID: "alice",
+		PlayerIDs: []string{"carol"},
+	})
+	s.ErrorIs(err, ErrInvalidGameState)
+}
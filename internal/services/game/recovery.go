@@ -0,0 +1,160 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// RecoverGames repairs the games being played that were left in a stale state, such as by a restart part way
+// through a game, and returns the top-level games to redraw
+func (s *service) RecoverGames(ctx context.Context, input *RecoverGamesInput) (*RecoverGamesOutput, error) {
+	active, err := s.gameRepo.GetActiveGames(ctx, &gameRepo.GetActiveGamesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active games: %w", err)
+	}
+
+	output := &RecoverGamesOutput{}
+	rootIDs := make(map[string]bool)
+	for _, game := range active.Games {
+		// Roll-offs are shown on the message of the game they started from
+		rootIDs[s.rootGameID(ctx, game)] = true
+
+		repaired, err := s.repairGame(ctx, game.ID)
+		if err != nil {
+			log.Printf("Error repairing game %s: %v", game.ID, err)
+			continue
+		}
+		if repaired {
+			output.Repaired++
+		}
+	}
+
+	for gameID := range rootIDs {
+		game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			log.Printf("Error getting game %s to recover: %v", gameID, err)
+			continue
+		}
+
+		restored, err := s.restoreChannelMapping(ctx, game)
+		if err != nil {
+			log.Printf("Error restoring channel mapping for game %s: %v", gameID, err)
+		}
+		if restored {
+			output.Repaired++
+		}
+
+		output.Games = append(output.Games, game)
+	}
+
+	sort.Slice(output.Games, func(i, j int) bool {
+		return output.Games[i].CreatedAt.Before(output.Games[j].CreatedAt)
+	})
+
+	return output, nil
+}
+
+// repairGame moves a game along that's stuck waiting on something that will never happen, reporting whether
+// it did. The game is loaded again since repairing another game may have finished it.
+func (s *service) repairGame(ctx context.Context, gameID string) (bool, error) {
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if !s.lifecycle.IsPlaying(game.Status) {
+		return false, nil
+	}
+
+	// The game is waiting on roll-offs that are gone or already over
+	if game.Status.IsRollOff() && len(rollOffGameIDs(game)) > 0 {
+		if s.hasRollOffInPlay(ctx, game) {
+			return false, nil
+		}
+
+		if err := s.lifecycle.Transition(game, models.GameStatusCompleted, s.clock.Now()); err != nil {
+			return false, err
+		}
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+			return false, fmt.Errorf("failed to save game: %w", err)
+		}
+
+		if game.ParentGameID != "" {
+			parentGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+				GameID: game.ParentGameID,
+			})
+			if err == nil {
+				s.completeRollOffParents(ctx, game, parentGame)
+			}
+		}
+
+		log.Printf("Completed game %s, its roll-offs were no longer being played", game.ID)
+		return true, nil
+	}
+
+	// Everyone has rolled and assigned their drinks but the game was never ended
+	output, err := s.completeIfReady(ctx, game)
+	if err != nil {
+		return false, err
+	}
+	if output != nil {
+		log.Printf("Ended game %s, everyone had already rolled", game.ID)
+	}
+	return output != nil, nil
+}
+
+// hasRollOffInPlay checks whether any of the game's roll-offs are still being played
+func (s *service) hasRollOffInPlay(ctx context.Context, game *models.Game) bool {
+	for _, rollOffID := range rollOffGameIDs(game) {
+		rollOffGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: rollOffID,
+		})
+		if err == nil && !rollOffGame.Status.IsFinished() {
+			return true
+		}
+	}
+	return false
+}
+
+// rollOffGameIDs returns the IDs of the roll-offs a game started
+func rollOffGameIDs(game *models.Game) []string {
+	var ids []string
+	for _, id := range []string{game.RollOffGameID, game.HighestRollOffGameID, game.LowestRollOffGameID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// restoreChannelMapping saves an unfinished game again when its channel has lost track of it, so the channel's
+// commands find it, reporting whether it did
+func (s *service) restoreChannelMapping(ctx context.Context, game *models.Game) (bool, error) {
+	if game.ChannelID == "" || game.ParentGameID != "" || game.Status.IsFinished() {
+		return false, nil
+	}
+
+	_, err := s.gameRepo.GetActiveGameByChannel(ctx, &gameRepo.GetActiveGameByChannelInput{
+		ChannelID: game.ChannelID,
+	})
+	if !errors.Is(err, gameRepo.ErrGameNotFound) {
+		return false, err
+	}
+
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return false, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	log.Printf("Restored channel %s's mapping to game %s", game.ChannelID, game.ID)
+	return true, nil
+}
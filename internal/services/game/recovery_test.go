@@ -0,0 +1,122 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// RecoverGamesTestSuite tests repairing games left stuck by a restart against the real Redis repositories
+type RecoverGamesTestSuite struct {
+	serviceHarness
+}
+
+func (s *RecoverGamesTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "recover-channel"
+}
+
+func TestRecoverGamesTestSuite(t *testing.T) {
+	suite.Run(t, new(RecoverGamesTestSuite))
+}
+
+func (s *RecoverGamesTestSuite) TestHealthyGamesAreLeftAlone() {
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "alice", 3)
+
+	output, err := s.gameService.RecoverGames(s.ctx, &RecoverGamesInput{})
+	s.Require().NoError(err)
+	s.Equal(0, output.Repaired)
+	s.Require().Len(output.Games, 1)
+	s.Equal(gameID, output.Games[0].ID)
+	s.Equal(models.GameStatusActive, s.gameStatus(gameID))
+}
+
+func (s *RecoverGamesTestSuite) TestRollOffThatIsGone() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Equal(models.GameStatusRollOff, game.Status)
+	s.Require().NotEmpty(game.LowestRollOffGameID)
+
+	// The roll-off was lost while the bot was down
+	s.Require().NoError(s.gameRepo.DeleteGame(s.ctx, &gameRepo.DeleteGameInput{GameID: game.LowestRollOffGameID}))
+
+	output, err := s.gameService.RecoverGames(s.ctx, &RecoverGamesInput{})
+	s.Require().NoError(err)
+	s.Equal(1, output.Repaired)
+	s.Require().Len(output.Games, 1)
+	s.Equal(gameID, output.Games[0].ID)
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+}
+
+func (s *RecoverGamesTestSuite) TestRollOffOverButParentNot() {
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 2)
+	s.roll(gameID, "carol", 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	// The roll-off finished but the bot went down before the parent was completed
+	rollOff, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: game.LowestRollOffGameID})
+	s.Require().NoError(err)
+	rollOff.Status = models.GameStatusCompleted
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: rollOff}))
+
+	output, err := s.gameService.RecoverGames(s.ctx, &RecoverGamesInput{})
+	s.Require().NoError(err)
+	s.Equal(1, output.Repaired)
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+}
+
+func (s *RecoverGamesTestSuite) TestRolledGameThatNeverEnded() {
+	gameID := s.startGame("alice", "bob")
+
+	// Everyone's rolls were saved but the bot went down before the game was ended
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	now := time.Now()
+	for value, participant := range map[int]*models.Participant{3: game.GetParticipant("alice"), 5: game.GetParticipant("bob")} {
+		participant.RollValue = value
+		participant.RollTime = &now
+		participant.Status = models.ParticipantStatusActive
+	}
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: game}))
+
+	output, err := s.gameService.RecoverGames(s.ctx, &RecoverGamesInput{})
+	s.Require().NoError(err)
+	s.Equal(1, output.Repaired)
+	s.Equal(models.GameStatusCompleted, s.gameStatus(gameID))
+
+	// The lowest roller got their drink
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Len(records.Records, 1)
+	s.Equal("alice", records.Records[0].ToPlayerID)
+}
+
+func (s *RecoverGamesTestSuite) TestLostChannelMapping() {
+	gameID := s.startGame("alice", "bob")
+
+	s.mr.Del("channel_active:" + s.testChannelID)
+	s.mr.Del("channel:" + s.testChannelID)
+	_, err := s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().Error(err)
+
+	output, err := s.gameService.RecoverGames(s.ctx, &RecoverGamesInput{})
+	s.Require().NoError(err)
+	s.Equal(1, output.Repaired)
+
+	activeGame, err := s.gameService.GetActiveGameByChannel(s.ctx, &GetActiveGameByChannelInput{ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Equal(gameID, activeGame.Game.ID)
+}
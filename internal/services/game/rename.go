@@ -0,0 +1,149 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// RenamePlayer updates the name saved for a player who changed their name, along with the names kept on the game
+// they're playing and the session's designated drivers, so nothing shows the old one. Leaderboards and tabs look
+// names up from the player, so they follow on their own.
+func (s *service) RenamePlayer(ctx context.Context, input *RenamePlayerInput) (*RenamePlayerOutput, error) {
+	if input == nil || input.PlayerID == "" || input.PlayerName == "" {
+		return nil, errors.New("player ID and name are required")
+	}
+
+	output := &RenamePlayerOutput{}
+
+	// A player who never played has no name saved to update
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return output, nil
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	if player.Name == input.PlayerName {
+		return output, nil
+	}
+
+	player.Name = input.PlayerName
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save player: %w", err)
+	}
+	output.Renamed = true
+
+	if player.CurrentGameID != "" {
+		renamedInGame := false
+		for _, gameID := range s.relatedGameIDs(ctx, player.CurrentGameID) {
+			renamed, err := s.renameInGame(ctx, gameID, input.PlayerID, input.PlayerName)
+			if err != nil {
+				return nil, err
+			}
+			renamedInGame = renamedInGame || renamed
+		}
+
+		// The game's message needs redrawing with the new name
+		if renamedInGame {
+			if game, err := s.getGame(ctx, player.CurrentGameID); err == nil {
+				output.GameID = s.rootGameID(ctx, game)
+				output.ChannelID = game.ChannelID
+			}
+		}
+	}
+
+	if input.GuildID != "" {
+		if err := s.renameInSession(ctx, input.GuildID, input.PlayerID, input.PlayerName); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// renameInGame updates the player's name in a game they're playing or waiting for a spot in, reporting whether it
+// changed
+func (s *service) renameInGame(ctx context.Context, gameID, playerID, playerName string) (bool, error) {
+	changed := false
+	err := retryOnGameChange(func() error {
+		game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: gameID,
+		})
+		if err != nil {
+			return nil
+		}
+
+		changed = false
+		if participant := game.GetParticipant(playerID); participant != nil && participant.PlayerName != playerName {
+			participant.PlayerName = playerName
+			changed = true
+		}
+		for _, entry := range game.Waitlist {
+			if entry.PlayerID == playerID && entry.PlayerName != playerName {
+				entry.PlayerName = playerName
+				changed = true
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		}); err != nil {
+			return fmt.Errorf("failed to rename player in game: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+// renameInSession updates the player's name among the guild's current session's designated drivers and substitutes
+func (s *service) renameInSession(ctx context.Context, guildID, playerID, playerName string) error {
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: guildID,
+	})
+	if err != nil || sessionOutput.Session == nil {
+		return nil
+	}
+
+	session := sessionOutput.Session
+	changed := false
+	for _, driver := range session.DesignatedDrivers {
+		if driver.PlayerID == playerID && driver.PlayerName != playerName {
+			driver.PlayerName = playerName
+			changed = true
+		}
+		if driver.SubstituteID == playerID && driver.SubstituteName != playerName {
+			driver.SubstituteName = playerName
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return fmt.Errorf("failed to rename player in session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// RenamePlayerTestSuite tests keeping players' names up to date against the real Redis repositories
+type RenamePlayerTestSuite struct {
+	serviceHarness
+}
+
+func (s *RenamePlayerTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testGuildID = "rename-guild"
+	s.testChannelID = "rename-channel"
+}
+
+func TestRenamePlayerTestSuite(t *testing.T) {
+	suite.Run(t, new(RenamePlayerTestSuite))
+}
+
+func (s *RenamePlayerTestSuite) rename(playerID, name string) *RenamePlayerOutput {
+	output, err := s.gameService.RenamePlayer(s.ctx, &RenamePlayerInput{
+		GuildID:    s.testGuildID,
+		PlayerID:   playerID,
+		PlayerName: name,
+	})
+	s.Require().NoError(err)
+	return output
+}
+
+func (s *RenamePlayerTestSuite) TestRenamesPlayerAndGame() {
+	gameID := s.createGame("alice", "bob")
+
+	_, err := s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "bob",
+		PlayerName: "bob",
+	})
+	s.Require().NoError(err)
+
+	output := s.rename("bob", "Bobby Tables")
+	s.True(output.Renamed)
+	s.Equal(gameID, output.GameID)
+	s.Equal(s.testChannelID, output.ChannelID)
+
+	player, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal("Bobby Tables", player.Name)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal("Bobby Tables", game.GetParticipant("bob").PlayerName)
+	s.Equal("alice", game.GetParticipant("alice").PlayerName)
+
+	leaderboard, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(leaderboard.Entries, 1)
+	s.Equal("Bobby Tables", leaderboard.Entries[0].PlayerName)
+	s.Equal("Bobby Tables", leaderboard.Session.GetDesignatedDriver("bob").PlayerName)
+}
+
+func (s *RenamePlayerTestSuite) TestSameName() {
+	s.Require().NoError(s.playerRepo.SavePlayer(s.ctx, &playerRepo.SavePlayerInput{
+		Player: &models.Player{ID: "bob", Name: "bob"},
+	}))
+
+	s.False(s.rename("bob", "bob").Renamed)
+	s.Empty(s.rename("carol", "Carol").GameID, "a player who never played has nothing to rename")
+
+	_, err := s.gameService.RenamePlayer(s.ctx, &RenamePlayerInput{PlayerID: "bob"})
+	s.Error(err)
+}
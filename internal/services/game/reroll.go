@@ -0,0 +1,243 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// hardLuckCriticalFails is how many critical fails in a session earn the "Hard Luck" re-roll token
+const hardLuckCriticalFails = 3
+
+// RerollDice spends one of the player's re-roll tokens to void their roll and roll again.
+// The voided roll is kept on the participant, along with the critical fail drinks it voided, if any.
+func (s *service) RerollDice(ctx context.Context, input *RerollDiceInput) (*RollDiceOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game ID and player ID are required")
+	}
+
+	// The roll is voided, its drinks deleted and the token spent together, and all over again on the game as it is
+	// now if it changed since it was read
+	var game *models.Game
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: input.GameID,
+		})
+		if err != nil {
+			return ErrGameNotFound
+		}
+
+		if game.Status == models.GameStatusWaiting {
+			return ErrGameNotStarted
+		}
+
+		// Roll-offs are settled with the roll you get
+		if game.Status != models.GameStatusActive {
+			return ErrRerollNotAllowed
+		}
+
+		participant := game.GetParticipant(input.PlayerID)
+		if participant == nil {
+			return ErrPlayerNotInGame
+		}
+
+		// Only a finished, non-critical-hit roll can be re-rolled, and only once a game
+		rules := s.rulesFor(game)
+		if participant.RollTime == nil || rules.IsCriticalHit(participant.RollValue) || len(participant.VoidedRolls) > 0 {
+			return ErrRerollNotAllowed
+		}
+
+		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: input.PlayerID,
+		})
+		if err != nil {
+			if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+				return ErrNoRerollTokens
+			}
+			return fmt.Errorf("failed to get player: %w", err)
+		}
+
+		if player.RerollTokens <= 0 {
+			return ErrNoRerollTokens
+		}
+
+		return s.inTransaction(ctx, func(ctx context.Context) error {
+			now := s.clock.Now()
+			voided := &models.VoidedRoll{
+				RollValue: participant.RollValue,
+				RollTime:  *participant.RollTime,
+				VoidedAt:  now,
+			}
+
+			// A voided critical fail takes its drinks with it
+			if rules.IsCriticalFail(participant.RollValue) {
+				drinkIDs, err := s.criticalFailDrinkIDs(ctx, game.ID, input.PlayerID)
+				if err != nil {
+					return err
+				}
+
+				for _, drinkID := range drinkIDs {
+					if err := s.drinkLedgerRepo.DeleteDrinkRecord(ctx, &ledgerRepo.DeleteDrinkRecordInput{
+						DrinkID: drinkID,
+					}); err != nil {
+						return fmt.Errorf("failed to void critical fail drink: %w", err)
+					}
+					voided.DrinkIDs = append(voided.DrinkIDs, drinkID)
+				}
+
+				if len(voided.DrinkIDs) > 0 {
+					s.publishLedgerChanged(ctx, game.GuildID, game.ChannelID, "", "drink_voided")
+				}
+			}
+
+			player.RerollTokens--
+			if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+				Player: player,
+			}); err != nil {
+				return fmt.Errorf("failed to spend re-roll token: %w", err)
+			}
+
+			participant.VoidedRolls = append(participant.VoidedRolls, voided)
+			participant.RollValue = 0
+			participant.RollTime = nil
+			participant.Status = models.ParticipantStatusActive
+			game.UpdatedAt = now
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+				Game: game,
+			}); err != nil {
+				return fmt.Errorf("failed to save game: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.RollDice(ctx, &RollDiceInput{
+		GameID:   game.ID,
+		PlayerID: input.PlayerID,
+	})
+}
+
+// GrantRerollTokens gives a player re-roll tokens, creating the player if they haven't played yet
+func (s *service) GrantRerollTokens(ctx context.Context, input *GrantRerollTokensInput) (*GrantRerollTokensOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	if input.Count <= 0 {
+		return nil, errors.New("count must be positive")
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return nil, fmt.Errorf("failed to get player: %w", err)
+		}
+		player = &models.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		}
+	}
+
+	player.RerollTokens += input.Count
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save player: %w", err)
+	}
+
+	return &GrantRerollTokensOutput{
+		Tokens: player.RerollTokens,
+	}, nil
+}
+
+// GetRerollTokens returns how many re-roll tokens a player holds
+func (s *service) GetRerollTokens(ctx context.Context, input *GetRerollTokensInput) (*GetRerollTokensOutput, error) {
+	if input == nil || input.PlayerID == "" {
+		return nil, errors.New("player ID is required")
+	}
+
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: input.PlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, playerRepo.ErrPlayerNotFound) {
+			return &GetRerollTokensOutput{}, nil
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	return &GetRerollTokensOutput{
+		Tokens: player.RerollTokens,
+	}, nil
+}
+
+// criticalFailDrinkIDs finds the drinks a player took for a critical fail in a game
+func (s *service) criticalFailDrinkIDs(ctx context.Context, gameID, playerID string) ([]string, error) {
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForGame(ctx, &ledgerRepo.GetDrinkRecordsForGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drink records: %w", err)
+	}
+
+	var drinkIDs []string
+	for _, record := range drinkRecords.Records {
+		if record.Reason == models.DrinkReasonCriticalFail && record.FromPlayerID == playerID {
+			drinkIDs = append(drinkIDs, record.ID)
+		}
+	}
+
+	return drinkIDs, nil
+}
+
+// checkHardLuck awards the "Hard Luck" achievement, a re-roll token for every few critical fails in a session
+func (s *service) checkHardLuck(ctx context.Context, game *models.Game, record *models.DrinkLedger, playerName string) bool {
+	if record.SessionID == "" {
+		return false
+	}
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: record.SessionID,
+		GuildID:   sessionScope(game.GuildID, game.ChannelID),
+	})
+	if err != nil {
+		log.Printf("Error getting drink records for hard luck: %v", err)
+		return false
+	}
+
+	// Count games rather than records, a critical fail can be worth more than one drink
+	failedGames := make(map[string]bool)
+	for _, sessionRecord := range drinkRecords.Records {
+		if sessionRecord.Reason == models.DrinkReasonCriticalFail && sessionRecord.FromPlayerID == record.FromPlayerID {
+			failedGames[sessionRecord.GameID] = true
+		}
+	}
+	criticalFails := len(failedGames)
+
+	if criticalFails == 0 || criticalFails%hardLuckCriticalFails != 0 {
+		return false
+	}
+
+	if _, err := s.GrantRerollTokens(ctx, &GrantRerollTokensInput{
+		PlayerID:   record.FromPlayerID,
+		PlayerName: playerName,
+		Count:      1,
+	}); err != nil {
+		log.Printf("Error granting hard luck re-roll token: %v", err)
+		return false
+	}
+
+	return true
+}
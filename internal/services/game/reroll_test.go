@@ -0,0 +1,109 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// RerollTestSuite tests re-roll tokens against the real Redis repositories
+type RerollTestSuite struct {
+	serviceHarness
+}
+
+func (s *RerollTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "reroll-channel"
+}
+
+func TestRerollTestSuite(t *testing.T) {
+	suite.Run(t, new(RerollTestSuite))
+}
+
+func (s *RerollTestSuite) grant(playerID string, count int) {
+	_, err := s.gameService.GrantRerollTokens(s.ctx, &GrantRerollTokensInput{PlayerID: playerID, PlayerName: playerID, Count: count})
+	s.Require().NoError(err)
+}
+
+func (s *RerollTestSuite) tokens(playerID string) int {
+	output, err := s.gameService.GetRerollTokens(s.ctx, &GetRerollTokensInput{PlayerID: playerID})
+	s.Require().NoError(err)
+	return output.Tokens
+}
+
+func (s *RerollTestSuite) TestRerollVoidsCriticalFail() {
+	s.grant("alice", 1)
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 1)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	output, err := s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(4, output.RollValue)
+	s.False(output.IsCriticalFail)
+	s.Equal(0, s.tokens("alice"))
+
+	// The voided roll is kept on the participant for the record
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	participant := game.GetParticipant("alice")
+	s.Equal(4, participant.RollValue)
+	s.Require().Len(participant.VoidedRolls, 1)
+	s.Equal(1, participant.VoidedRolls[0].RollValue)
+	s.Len(participant.VoidedRolls[0].DrinkIDs, 1)
+
+	// The critical fail drink went with it
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+	for _, record := range records.Records {
+		s.NotEqual(models.DrinkReasonCriticalFail, record.Reason)
+	}
+}
+
+func (s *RerollTestSuite) TestRerollNeedsToken() {
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "alice", 2)
+
+	_, err := s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrNoRerollTokens)
+}
+
+func (s *RerollTestSuite) TestRerollOncePerGame() {
+	s.grant("alice", 2)
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 2)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3)
+	_, err := s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrRerollNotAllowed)
+	s.Equal(1, s.tokens("alice"))
+}
+
+func (s *RerollTestSuite) TestCannotRerollCriticalHitOrUnrolled() {
+	s.grant("alice", 1)
+	s.grant("bob", 1)
+	gameID := s.startGame("alice", "bob", "carol")
+	s.roll(gameID, "alice", 6)
+
+	_, err := s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrRerollNotAllowed)
+
+	_, err = s.gameService.RerollDice(s.ctx, &RerollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrRerollNotAllowed)
+}
+
+func (s *RerollTestSuite) TestHardLuckEarnsToken() {
+	for i := 1; i <= hardLuckCriticalFails; i++ {
+		gameID := s.startGame("alice", "bob")
+		output := s.roll(gameID, "alice", 1)
+		s.Equal(i == hardLuckCriticalFails, output.EarnedRerollToken)
+		s.roll(gameID, "bob", 4)
+	}
+
+	s.Equal(1, s.tokens("alice"))
+}
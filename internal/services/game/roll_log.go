@@ -0,0 +1,87 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	rollLogRepo "github.com/KirkDiggler/ronnied/internal/repositories/roll_log"
+)
+
+// GetRolls returns a server's logged rolls, optionally narrowed to a player, game or time range
+func (s *service) GetRolls(ctx context.Context, input *GetRollsInput) (*GetRollsOutput, error) {
+	if input == nil || (input.GuildID == "" && input.ChannelID == "") {
+		return nil, fmt.Errorf("guild or channel ID is required")
+	}
+
+	if s.rollLogRepo == nil {
+		return nil, ErrRollLogDisabled
+	}
+
+	output, err := s.rollLogRepo.GetRolls(ctx, &rollLogRepo.GetRollsInput{
+		Scope:    sessionScope(input.GuildID, input.ChannelID),
+		PlayerID: input.PlayerID,
+		GameID:   input.GameID,
+		Since:    input.Since,
+		Until:    input.Until,
+		Limit:    input.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolls: %w", err)
+	}
+
+	return &GetRollsOutput{
+		Rolls: output.Rolls,
+	}, nil
+}
+
+// logRoll records a participant's roll along with the dice it was made with
+func (s *service) logRoll(ctx context.Context, game *models.Game, participant *models.Participant, rules models.GameRules, isCriticalHit, isCriticalFail bool) {
+	if s.rollLogRepo == nil {
+		return
+	}
+
+	roll := &models.Roll{
+		ID:                 s.uuid.NewUUID(),
+		Value:              participant.RollValue,
+		PlayerID:           participant.PlayerID,
+		PlayerName:         participant.PlayerName,
+		GameID:             game.ID,
+		ParentGameID:       game.ParentGameID,
+		GuildID:            game.GuildID,
+		ChannelID:          game.ChannelID,
+		Timestamp:          *participant.RollTime,
+		IsCriticalHit:      isCriticalHit,
+		IsCriticalFail:     isCriticalFail,
+		IsReroll:           len(participant.VoidedRolls) > 0,
+		DiceSides:          rules.DiceSides,
+		CriticalHitValues:  rules.CriticalHitValues,
+		CriticalFailValues: rules.CriticalFailValues,
+		Modifiers:          participant.RollModifiers,
+	}
+
+	// The roll already counts, so a logging failure shouldn't undo it
+	if err := s.rollLogRepo.AddRoll(ctx, &rollLogRepo.AddRollInput{Roll: roll}); err != nil {
+		log.Printf("Error logging roll for player %s in game %s: %v", participant.PlayerID, game.ID, err)
+	}
+}
+
+// anonymizeRolls moves a forgotten player's logged rolls to their anonymous ID, only the guild's when a guild is given
+func (s *service) anonymizeRolls(ctx context.Context, playerID, anonymousID, guildID string) (int, error) {
+	if s.rollLogRepo == nil {
+		return 0, nil
+	}
+
+	output, err := s.rollLogRepo.ReassignPlayerRolls(ctx, &rollLogRepo.ReassignPlayerRollsInput{
+		FromPlayerID: playerID,
+		ToPlayerID:   anonymousID,
+		ToPlayerName: models.DeletedPlayerName,
+		GuildID:      guildID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize rolls: %w", err)
+	}
+
+	return output.RollsMoved, nil
+}
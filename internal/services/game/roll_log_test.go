@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	rollLogRepo "github.com/KirkDiggler/ronnied/internal/repositories/roll_log"
+	"github.com/stretchr/testify/suite"
+)
+
+// RollLogTestSuite tests logging and querying rolls against the real Redis repositories
+type RollLogTestSuite struct {
+	serviceHarness
+}
+
+func (s *RollLogTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		rolls, err := rollLogRepo.NewRedis(&rollLogRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+		cfg.RollLogRepo = rolls
+	})
+	s.testChannelID = "roll-log-channel"
+	s.testGuildID = "roll-log-guild"
+}
+
+func TestRollLogTestSuite(t *testing.T) {
+	suite.Run(t, new(RollLogTestSuite))
+}
+
+func (s *RollLogTestSuite) TestRollsAreLogged() {
+	// alice rolls a 4 and bob rolls a critical fail
+	gameID := s.playGame([]string{"alice", "bob"}, []int{4, 1})
+
+	output, err := s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID, ChannelID: s.testChannelID})
+	s.Require().NoError(err)
+	s.Require().Len(output.Rolls, 2)
+
+	roll := output.Rolls[0]
+	s.Equal("alice", roll.PlayerID)
+	s.Equal("alice", roll.PlayerName)
+	s.Equal(4, roll.Value)
+	s.Equal(gameID, roll.GameID)
+	s.Equal(s.testGuildID, roll.GuildID)
+	s.Equal(s.testChannelID, roll.ChannelID)
+	s.False(roll.IsCriticalHit)
+	s.False(roll.IsCriticalFail)
+	s.Equal(6, roll.DiceSides)
+	s.Equal([]int{6}, roll.CriticalHitValues)
+	s.Equal([]int{1}, roll.CriticalFailValues)
+	s.False(roll.Timestamp.IsZero())
+
+	s.Equal("bob", output.Rolls[1].PlayerID)
+	s.Equal(1, output.Rolls[1].Value)
+	s.True(output.Rolls[1].IsCriticalFail)
+}
+
+func (s *RollLogTestSuite) TestGetRollsFilters() {
+	gameID := s.playGame([]string{"alice", "bob"}, []int{4, 1})
+
+	output, err := s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().Len(output.Rolls, 1)
+	s.Equal("bob", output.Rolls[0].PlayerID)
+
+	output, err = s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID, GameID: gameID, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(output.Rolls, 1)
+	s.Equal("bob", output.Rolls[0].PlayerID)
+
+	// Another server can't see the rolls
+	output, err = s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: "other-guild", GameID: gameID})
+	s.Require().NoError(err)
+	s.Empty(output.Rolls)
+}
+
+func (s *RollLogTestSuite) TestForgetPlayerAnonymizesRolls() {
+	s.playGame([]string{"alice", "bob"}, []int{4, 1})
+
+	output, err := s.gameService.ForgetPlayer(s.ctx, &ForgetPlayerInput{PlayerID: "bob", RequestedBy: "bob"})
+	s.Require().NoError(err)
+	s.Equal(1, output.RollsAnonymized)
+
+	rolls, err := s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Empty(rolls.Rolls)
+
+	rolls, err = s.gameService.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID, PlayerID: output.AnonymousID})
+	s.Require().NoError(err)
+	s.Require().Len(rolls.Rolls, 1)
+	s.Equal(models.DeletedPlayerName, rolls.Rolls[0].PlayerName)
+}
+
+func (s *RollLogTestSuite) TestGetRollsDisabled() {
+	svc := s.newService(nil)
+
+	_, err := svc.GetRolls(s.ctx, &GetRollsInput{GuildID: s.testGuildID})
+	s.ErrorIs(err, ErrRollLogDisabled)
+}
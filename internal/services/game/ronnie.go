@@ -0,0 +1,189 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// defaultRonnieRollDelay is how long Ronnie waits before making a roll when the service isn't told otherwise
+const defaultRonnieRollDelay = 3 * time.Second
+
+// AddRonnie sits Ronnie down at a game waiting to start, for a player in it who's short of someone to play with
+func (s *service) AddRonnie(ctx context.Context, input *AddRonnieInput) (*AddRonnieOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game ID and player ID are required")
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	switch game.Status {
+	case models.GameStatusWaiting:
+	case models.GameStatusActive:
+		return nil, ErrGameActive
+	case models.GameStatusRollOff:
+		return nil, ErrGameRollOff
+	default:
+		return nil, ErrGameCompleted
+	}
+
+	if !game.HasParticipant(input.PlayerID) {
+		return nil, ErrPlayerNotInGame
+	}
+	if game.HasRonnie() {
+		return nil, ErrPlayerAlreadyInGame
+	}
+	if len(game.Participants) >= s.rulesFor(game).MaxPlayers {
+		return nil, ErrGameFull
+	}
+
+	// Ronnie plays in any number of games at once, so he doesn't keep a player of his own
+	if _, err := s.gameRepo.CreateParticipant(ctx, &gameRepo.CreateParticipantInput{
+		GameID:     game.ID,
+		PlayerID:   models.RonniePlayerID,
+		PlayerName: models.RonniePlayerName,
+		Status:     models.ParticipantStatusWaitingToRoll,
+	}); err != nil {
+		return nil, err
+	}
+
+	game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	return &AddRonnieOutput{
+		Game: game,
+	}, nil
+}
+
+// scheduleRonnie has Ronnie make the next roll he has to in the game or its roll-offs after a short delay, so his
+// rolls come in like everyone else's rather than the moment he can make them
+func (s *service) scheduleRonnie(game *models.Game) {
+	if game == nil || !game.HasRonnie() {
+		return
+	}
+
+	gameID := game.ID
+	s.clock.AfterFunc(s.ronnieRollDelay, func() {
+		s.rollForRonnie(context.Background(), gameID)
+	})
+}
+
+// rollForRonnie makes Ronnie's roll in the game or whichever of its roll-offs he has one to make in, handing a
+// critical hit's drink to a random player
+func (s *service) rollForRonnie(ctx context.Context, gameID string) {
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil {
+		log.Printf("Error getting game %s for Ronnie's roll: %v", gameID, err)
+		return
+	}
+
+	rollOffs, err := s.rollOffTree(ctx, game)
+	if err != nil {
+		log.Printf("Error getting roll-offs of game %s for Ronnie's roll: %v", gameID, err)
+		return
+	}
+
+	for _, candidate := range append([]*models.Game{game}, rollOffs...) {
+		if !s.lifecycle.IsPlaying(candidate.Status) || candidate.IsPaused() {
+			continue
+		}
+
+		participant := candidate.GetParticipant(models.RonniePlayerID)
+		if participant == nil || participant.RollTime != nil {
+			continue
+		}
+		if candidate.SuddenDeath != nil && candidate.NextRoller() != participant {
+			continue
+		}
+
+		output, err := s.RollDice(ctx, &RollDiceInput{
+			GameID:   candidate.ID,
+			PlayerID: models.RonniePlayerID,
+		})
+		if err != nil {
+			// Another timer got to the roll first
+			if !errors.Is(err, ErrPlayerAlreadyRolled) {
+				log.Printf("Error rolling for Ronnie in game %s: %v", candidate.ID, err)
+			}
+			return
+		}
+
+		s.publishRonnieRolled(ctx, candidate, output, s.assignRonnieDrink(ctx, candidate, output))
+		return
+	}
+}
+
+// assignRonnieDrink gives the drink for Ronnie's critical hit to a random player in the game, returning their name,
+// empty when he didn't roll one
+func (s *service) assignRonnieDrink(ctx context.Context, game *models.Game, roll *RollDiceOutput) string {
+	if !roll.IsCriticalHit || len(roll.EligiblePlayers) == 0 {
+		return ""
+	}
+
+	target := roll.EligiblePlayers[s.diceRoller.Roll(len(roll.EligiblePlayers))-1]
+	if _, err := s.AssignDrink(ctx, &AssignDrinkInput{
+		GameID:       game.ID,
+		FromPlayerID: models.RonniePlayerID,
+		ToPlayerID:   target.PlayerID,
+		Reason:       DrinkReasonCriticalHit,
+	}); err != nil {
+		log.Printf("Error assigning Ronnie's drink in game %s: %v", game.ID, err)
+		return ""
+	}
+
+	return target.PlayerName
+}
+
+// ronnieDrinkTo picks who drinks a drink given to Ronnie in the game by the rule it's played with: the player who
+// gave it to him, or a random player in the game. It returns empty when there's nobody to drink it.
+func (s *service) ronnieDrinkTo(game *models.Game, fromPlayerID string) string {
+	if s.rulesFor(game).RonnieDrinks != models.RonnieDrinksRandom && fromPlayerID != "" && !models.IsRonnie(fromPlayerID) {
+		return fromPlayerID
+	}
+
+	var playerIDs []string
+	for _, participant := range game.Participants {
+		if !models.IsRonnie(participant.PlayerID) {
+			playerIDs = append(playerIDs, participant.PlayerID)
+		}
+	}
+	if len(playerIDs) == 0 {
+		return ""
+	}
+
+	return playerIDs[s.diceRoller.Roll(len(playerIDs))-1]
+}
+
+// publishRonnieRolled shows Ronnie's roll in the game's channel
+func (s *service) publishRonnieRolled(ctx context.Context, game *models.Game, roll *RollDiceOutput, drinkToName string) {
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeRonnieRolled,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.RonnieRolledPayload{
+			RollValue:    roll.RollValue,
+			CriticalHit:  roll.IsCriticalHit,
+			CriticalFail: roll.IsCriticalFail,
+			DrinkToName:  drinkToName,
+			GameIDs:      roll.GameIDsToUpdate,
+		},
+	})
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// RonnieTestSuite tests Ronnie, the bot's own player, against the real Redis repositories
+type RonnieTestSuite struct {
+	serviceHarness
+	clock *clock.Fake
+
+	rolls []*events.RonnieRolledPayload
+}
+
+func (s *RonnieTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+	})
+	s.testChannelID = "ronnie-channel"
+	s.testGuildID = "ronnie-guild"
+
+	s.rolls = nil
+	s.eventBus.Subscribe(events.TypeRonnieRolled, func(_ context.Context, event *events.Event) {
+		s.rolls = append(s.rolls, event.Payload.(*events.RonnieRolledPayload))
+	})
+}
+
+func TestRonnieTestSuite(t *testing.T) {
+	suite.Run(t, new(RonnieTestSuite))
+}
+
+// startGame starts a game between alice, bob and Ronnie, with the rule for Ronnie's drinks
+func (s *RonnieTestSuite) startGame(rule models.RonnieDrinkRule) string {
+	gameID := s.createGameWith(&CreateGameInput{Rules: &models.GameRules{RonnieDrinks: rule}}, "alice", "bob")
+
+	_, err := s.gameService.AddRonnie(s.ctx, &AddRonnieInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.start(gameID, "alice")
+
+	return gameID
+}
+
+// ronnieRolls lets Ronnie make his roll
+func (s *RonnieTestSuite) ronnieRolls(value int) {
+	s.mockDiceRoller.EXPECT().Roll(6).Return(value)
+	s.clock.Advance(defaultRonnieRollDelay)
+}
+
+// drinksFor returns the reasons for the drinks the player was given in the game
+func (s *RonnieTestSuite) drinksFor(gameID, playerID string) []models.DrinkReason {
+	records, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	var reasons []models.DrinkReason
+	for _, record := range records.Records {
+		if record.ToPlayerID == playerID {
+			reasons = append(reasons, record.Reason)
+		}
+	}
+	return reasons
+}
+
+func (s *RonnieTestSuite) TestRonnieRollsOnHisOwn() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   "ronnie-channel",
+		GuildID:     "ronnie-guild",
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+	gameID := createOutput.GameID
+
+	// Only players in the game can ask Ronnie to play, and he only plays once
+	_, err = s.gameService.AddRonnie(s.ctx, &AddRonnieInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrPlayerNotInGame)
+	addOutput, err := s.gameService.AddRonnie(s.ctx, &AddRonnieInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(addOutput.Game.HasRonnie())
+	_, err = s.gameService.AddRonnie(s.ctx, &AddRonnieInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrPlayerAlreadyInGame)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// He takes a moment before rolling
+	s.clock.Advance(defaultRonnieRollDelay - time.Second)
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Nil(game.GetParticipant(models.RonniePlayerID).RollTime)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(4)
+	s.clock.Advance(time.Second)
+	game, err = s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(4, game.GetParticipant(models.RonniePlayerID).RollValue)
+
+	s.Require().Len(s.rolls, 1)
+	s.Equal(&events.RonnieRolledPayload{RollValue: 4, GameIDs: []string{gameID}}, s.rolls[0])
+
+	// alice's roll finishes the game
+	output := s.roll(gameID, "alice", 3)
+	s.True(output.AllPlayersRolled)
+	s.Equal([]models.DrinkReason{models.DrinkReasonLowestRoll}, s.drinksFor(gameID, "alice"))
+}
+
+func (s *RonnieTestSuite) TestDrinksGivenToRonnieBounceBack() {
+	gameID := s.startGame("")
+
+	s.ronnieRolls(2)
+	s.roll(gameID, "alice", 6)
+
+	assignOutput, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "alice",
+		ToPlayerID:   models.RonniePlayerID,
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+	s.Equal("alice", assignOutput.RonnieDrinkTo)
+
+	// Ronnie's own lowest roll drink goes to a random player
+	s.mockDiceRoller.EXPECT().Roll(2).Return(2)
+	s.roll(gameID, "bob", 4)
+
+	s.Equal([]models.DrinkReason{models.DrinkReasonCriticalHit}, s.drinksFor(gameID, "alice"))
+	s.Equal([]models.DrinkReason{models.DrinkReasonLowestRoll}, s.drinksFor(gameID, "bob"))
+	s.Empty(s.drinksFor(gameID, models.RonniePlayerID))
+}
+
+func (s *RonnieTestSuite) TestRonnieHandsOutHisCriticalHits() {
+	gameID := s.startGame(models.RonnieDrinksRandom)
+
+	s.mockDiceRoller.EXPECT().Roll(2).Return(1)
+	s.ronnieRolls(6)
+	s.Require().Len(s.rolls, 1)
+	s.True(s.rolls[0].CriticalHit)
+	s.Equal("alice", s.rolls[0].DrinkToName)
+
+	// With the random rule, drinks given to him don't go back to the player who gave them
+	s.roll(gameID, "bob", 6)
+	s.mockDiceRoller.EXPECT().Roll(2).Return(1)
+	assignOutput, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+		GameID:       gameID,
+		FromPlayerID: "bob",
+		ToPlayerID:   models.RonniePlayerID,
+		Reason:       DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+	s.Equal("alice", assignOutput.RonnieDrinkTo)
+
+	s.ElementsMatch([]models.DrinkReason{models.DrinkReasonCriticalHit, models.DrinkReasonCriticalHit}, s.drinksFor(gameID, "alice"))
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// newGameRounds creates the rounds for a game played over that many of them, nil for a single round game
+func newGameRounds(total int) *models.GameRounds {
+	if total == 0 {
+		return nil
+	}
+	return models.NewGameRounds(total)
+}
+
+// nextRound finishes a round that isn't a rounds game's last: the round's lowest rollers drink, every tied player
+// among them since rounds aren't settled with roll-offs, and everyone is set up to roll again
+func (s *service) nextRound(ctx context.Context, game *models.Game) (*EndGameOutput, error) {
+	session := s.getSessionForGame(ctx, game)
+	lowestRollers := game.LowestRollers()
+	round := game.Rounds.Current + 1
+
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		for _, participant := range lowestRollers {
+			_, err := s.createDrinkRecord(ctx, game, session, &ledgerRepo.CreateDrinkRecordInput{
+				GameID:     game.ID,
+				ToPlayerID: participant.PlayerID,
+				Reason:     models.DrinkReasonLowestRoll,
+				Timestamp:  s.clock.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		clearRolls(game)
+		game.Rounds.Current = round
+		game.UpdatedAt = s.clock.Now()
+
+		return s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start round %d: %w", round, err)
+	}
+
+	return &EndGameOutput{
+		SessionID: s.getSessionIDForGame(ctx, game),
+		NextRound: round,
+	}, nil
+}
+
+// giveRoundsLoserDrinks gives the bonus drink for the lowest total over every round of a rounds game, to everyone
+// tied on it
+func (s *service) giveRoundsLoserDrinks(ctx context.Context, game *models.Game) {
+	session := s.getSessionForGame(ctx, game)
+	for _, participant := range game.Rounds.LowestTotals(game.Participants) {
+		_, err := s.createDrinkRecord(ctx, game, session, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:     game.ID,
+			ToPlayerID: participant.PlayerID,
+			Reason:     models.DrinkReasonRoundsLoser,
+			Timestamp:  s.clock.Now(),
+		})
+		if err != nil {
+			log.Printf("Error saving rounds loser drink record: %v", err)
+		}
+	}
+}
+
+// clearRolls has every player in the game roll again
+func clearRolls(game *models.Game) {
+	for _, participant := range game.Participants {
+		participant.Status = models.ParticipantStatusWaitingToRoll
+		participant.RollValue = 0
+		participant.RollTime = nil
+		participant.RollModifiers = nil
+		participant.VoidedRolls = nil
+	}
+}
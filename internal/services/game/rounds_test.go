@@ -0,0 +1,131 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// RoundsTestSuite tests games played over several rounds against the real Redis repositories
+type RoundsTestSuite struct {
+	serviceHarness
+}
+
+func (s *RoundsTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "rounds-channel"
+	s.testGuildID = "rounds-guild"
+}
+
+func TestRoundsTestSuite(t *testing.T) {
+	suite.Run(t, new(RoundsTestSuite))
+}
+
+// startGame starts a game between alice and bob played over the given number of rounds
+func (s *RoundsTestSuite) startGame(rounds int) string {
+	return s.startGameWith(&CreateGameInput{Rounds: rounds}, "alice", "bob")
+}
+
+// playRound has alice then bob roll, returning the result of bob's roll
+func (s *RoundsTestSuite) playRound(gameID string, aliceRoll, bobRoll int) *RollDiceOutput {
+	s.roll(gameID, "alice", aliceRoll)
+	return s.roll(gameID, "bob", bobRoll)
+}
+
+// drinks counts the drinks recorded in the game for each player and reason
+func (s *RoundsTestSuite) drinks(gameID string) map[string]map[models.DrinkReason]int {
+	output, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	drinks := make(map[string]map[models.DrinkReason]int)
+	for _, record := range output.Records {
+		if drinks[record.ToPlayerID] == nil {
+			drinks[record.ToPlayerID] = make(map[models.DrinkReason]int)
+		}
+		drinks[record.ToPlayerID][record.Reason]++
+	}
+	return drinks
+}
+
+func (s *RoundsTestSuite) TestPlaysEveryRound() {
+	gameID := s.startGame(3)
+
+	// Each round's lowest roller drinks and everyone rolls again
+	output := s.playRound(gameID, 3, 4)
+	s.Equal(2, output.NextRound)
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, game.Status)
+	s.Equal(2, game.Rounds.Current)
+	s.Equal(map[string]int{"alice": 3, "bob": 4}, game.Rounds.Totals)
+	for _, participant := range game.Participants {
+		s.Nil(participant.RollTime)
+		s.Equal(models.ParticipantStatusWaitingToRoll, participant.Status)
+	}
+
+	output = s.playRound(gameID, 5, 2)
+	s.Equal(3, output.NextRound)
+
+	// bob wins the last round but has the lowest total
+	output = s.playRound(gameID, 2, 3)
+	s.Zero(output.NextRound)
+
+	game, err = s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, game.Status)
+	s.Equal(map[string]int{"alice": 10, "bob": 9}, game.Rounds.Totals)
+
+	drinks := s.drinks(gameID)
+	s.Equal(map[models.DrinkReason]int{models.DrinkReasonLowestRoll: 2}, drinks["alice"])
+	s.Equal(map[models.DrinkReason]int{models.DrinkReasonLowestRoll: 1, models.DrinkReasonRoundsLoser: 1}, drinks["bob"])
+}
+
+func (s *RoundsTestSuite) TestTiedRoundsAllDrink() {
+	gameID := s.startGame(3)
+
+	// Rounds aren't settled with roll-offs, and a tied total gives everyone the bonus drink
+	output := s.playRound(gameID, 4, 4)
+	s.False(output.NeedsRollOff)
+	s.Equal(2, output.NextRound)
+	s.playRound(gameID, 4, 2)
+	s.playRound(gameID, 2, 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, game.Status)
+
+	drinks := s.drinks(gameID)
+	s.Equal(map[models.DrinkReason]int{models.DrinkReasonLowestRoll: 2, models.DrinkReasonRoundsLoser: 1}, drinks["alice"])
+	s.Equal(map[models.DrinkReason]int{models.DrinkReasonLowestRoll: 2, models.DrinkReasonRoundsLoser: 1}, drinks["bob"])
+}
+
+func (s *RoundsTestSuite) TestInvalidRounds() {
+	_, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID: "rounds-channel",
+		CreatorID: "alice",
+		Rounds:    1,
+	})
+	s.ErrorIs(err, ErrInvalidRounds)
+
+	_, err = s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID: "rounds-channel",
+		CreatorID: "alice",
+		Rounds:    models.MaxGameRounds + 1,
+	})
+	s.ErrorIs(err, ErrInvalidRounds)
+
+	_, err = s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID: "rounds-channel",
+		CreatorID: "alice",
+		Rounds:    3,
+		Countdown: true,
+	})
+	s.ErrorIs(err, ErrCountdownRounds)
+}
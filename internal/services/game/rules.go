@@ -0,0 +1,164 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+)
+
+// rollModeSource labels the advantage or disadvantage a game's rules give every roll
+const rollModeSource = "game rules"
+
+// rulesFor returns the rules a game is played with, falling back to the service defaults for anything the game doesn't set
+func (s *service) rulesFor(game *models.Game) models.GameRules {
+	rules := s.defaultRules()
+
+	if game == nil || game.Rules == nil {
+		return rules
+	}
+
+	if game.Rules.DiceSides > 0 {
+		rules.DiceSides = game.Rules.DiceSides
+	}
+	if game.Rules.MaxPlayers > 0 {
+		rules.MaxPlayers = game.Rules.MaxPlayers
+	}
+	if len(game.Rules.CriticalHitValues) > 0 {
+		rules.CriticalHitValues = game.Rules.CriticalHitValues
+	}
+	if len(game.Rules.CriticalFailValues) > 0 {
+		rules.CriticalFailValues = game.Rules.CriticalFailValues
+	}
+	if len(game.Rules.SocialValues) > 0 {
+		rules.SocialValues = game.Rules.SocialValues
+	} else {
+		// The default social values give way to the game's own dice and critical values
+		rules.SocialValues = slices.DeleteFunc(slices.Clone(rules.SocialValues), func(value int) bool {
+			return value > rules.DiceSides || rules.IsCriticalHit(value) || rules.IsCriticalFail(value)
+		})
+	}
+	if game.Rules.LeaderHandicap {
+		rules.LeaderHandicap = true
+	}
+	if game.Rules.GuestDrinks {
+		rules.GuestDrinks = true
+	}
+	if game.Rules.Karma {
+		rules.Karma = true
+	}
+	if game.Rules.Jackpot {
+		rules.Jackpot = true
+	}
+	if game.Rules.RollMode != "" {
+		rules.RollMode = game.Rules.RollMode
+	}
+	if game.Rules.TieStrategy != "" {
+		rules.TieStrategy = game.Rules.TieStrategy
+	}
+	if game.Rules.RonnieDrinks != "" {
+		rules.RonnieDrinks = game.Rules.RonnieDrinks
+	}
+
+	return rules
+}
+
+// ValidateRules checks that a complete set of rules makes a playable game
+func ValidateRules(rules models.GameRules) error {
+	if rules.DiceSides < 2 {
+		return fmt.Errorf("%w: dice need at least 2 sides", ErrInvalidGameRules)
+	}
+
+	if rules.MaxPlayers < 1 {
+		return fmt.Errorf("%w: at least 1 player must be able to join", ErrInvalidGameRules)
+	}
+
+	if len(rules.CriticalHitValues) == 0 || len(rules.CriticalFailValues) == 0 {
+		return fmt.Errorf("%w: there must be at least one critical hit and one critical fail", ErrInvalidGameRules)
+	}
+
+	for _, value := range append(slices.Clone(rules.CriticalHitValues), rules.CriticalFailValues...) {
+		if value < 1 || value > rules.DiceSides {
+			return fmt.Errorf("%w: critical value %d is not on a %d-sided die", ErrInvalidGameRules, value, rules.DiceSides)
+		}
+	}
+
+	for _, value := range rules.CriticalHitValues {
+		if rules.IsCriticalFail(value) {
+			return fmt.Errorf("%w: %d can't be both a critical hit and a critical fail", ErrInvalidGameRules, value)
+		}
+	}
+
+	for _, value := range rules.SocialValues {
+		if value < 1 || value > rules.DiceSides {
+			return fmt.Errorf("%w: social value %d is not on a %d-sided die", ErrInvalidGameRules, value, rules.DiceSides)
+		}
+		if rules.IsCriticalHit(value) || rules.IsCriticalFail(value) {
+			return fmt.Errorf("%w: %d can't be both critical and a social", ErrInvalidGameRules, value)
+		}
+	}
+
+	if !models.ValidRollMode(rules.RollMode) {
+		return fmt.Errorf("%w: rolls are made normally, with advantage or with disadvantage", ErrInvalidGameRules)
+	}
+
+	if rules.TieStrategy != "" && !rules.TieStrategy.IsValid() {
+		return fmt.Errorf("%w: ties are settled with roll-offs or sudden death", ErrInvalidGameRules)
+	}
+
+	if rules.RonnieDrinks != "" && !rules.RonnieDrinks.IsValid() {
+		return fmt.Errorf("%w: Ronnie's drinks bounce back or go to a random player", ErrInvalidGameRules)
+	}
+
+	return nil
+}
+
+// rollModeFor returns the advantage or disadvantage every roll of a game is made with, nil for normal rolls
+func rollModeFor(rules models.GameRules) *models.RollModifier {
+	if rules.RollMode == "" {
+		return nil
+	}
+
+	return &models.RollModifier{
+		Type:   rules.RollMode,
+		Rolls:  1,
+		Source: rollModeSource,
+	}
+}
+
+// GetGameRules returns the rules a game is played with, the service defaults filled in for anything it doesn't set
+func (s *service) GetGameRules(ctx context.Context, input *GetGameRulesInput) (*GetGameRulesOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	game := input.Game
+	if game == nil && input.GuildID != "" {
+		game = &models.Game{Rules: s.guildRules(ctx, input.GuildID)}
+	}
+
+	return &GetGameRulesOutput{
+		Rules: s.rulesFor(game),
+	}, nil
+}
+
+// guildRules returns the rules a server's overrides start its games with, nil when it doesn't override any
+func (s *service) guildRules(ctx context.Context, guildID string) *models.GameRules {
+	if s.guildConfigService == nil {
+		return nil
+	}
+
+	output, err := s.guildConfigService.GetGuildConfig(ctx, &guild_config.GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		log.Printf("Error getting guild config for game rules: %v", err)
+		return nil
+	}
+
+	return output.Config.ApplyGameRules(nil)
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"context"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+)
+
+// activeEventIDs returns the IDs of the seasonal events a new game is played with
+func (s *service) activeEventIDs(ctx context.Context, input *CreateGameInput) []string {
+	if s.seasonalService == nil {
+		return nil
+	}
+
+	output, err := s.seasonalService.GetActiveEvents(ctx, &seasonal.GetActiveEventsInput{
+		GuildID: sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil {
+		// Play the game without events rather than not at all
+		log.Printf("Error getting seasonal events: %v", err)
+		return nil
+	}
+
+	var eventIDs []string
+	for _, event := range output.Events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+	return eventIDs
+}
+
+// createDrinkRecord records a drink given in the game, once for every time the game's seasonal events,
+// last call and happy hour make it count. It returns the first record created, none when happy hour
+// leaves the drink on the house or nobody's left to drink a drink given to Ronnie.
+func (s *service) createDrinkRecord(ctx context.Context, game *models.Game, session *models.Session, input *ledgerRepo.CreateDrinkRecordInput) (*ledgerRepo.CreateDrinkRecordOutput, error) {
+	// Ronnie can't drink, someone drinks his drinks for him
+	if models.IsRonnie(input.ToPlayerID) {
+		input.ToPlayerID = s.ronnieDrinkTo(game, input.FromPlayerID)
+		if input.ToPlayerID == "" {
+			return &ledgerRepo.CreateDrinkRecordOutput{}, nil
+		}
+	}
+
+	input.HappyHourMultiplier = s.happyHourMultiplier()
+
+	count := s.drinkCount(game, seasonal.DrinkMultiplier(game.Events), input.HappyHourMultiplier)
+
+	var output *ledgerRepo.CreateDrinkRecordOutput
+	for i := 0; i < count; i++ {
+		drinkInput := *input
+
+		// The ledger won't show the drinks recorded so far until a unit of work they're part of is saved
+		pending := 0
+		if transaction.InProgress(ctx) {
+			pending = i
+		}
+
+		drinkOutput, err := s.recordDrink(ctx, game, session, &drinkInput, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		if output == nil {
+			output = drinkOutput
+		}
+	}
+
+	if output == nil {
+		return &ledgerRepo.CreateDrinkRecordOutput{}, nil
+	}
+	return output, nil
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	seasonalRepo "github.com/KirkDiggler/ronnied/internal/repositories/seasonal"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	"github.com/stretchr/testify/suite"
+)
+
+// SeasonalEventsTestSuite tests games played during seasonal events against the real Redis repositories
+type SeasonalEventsTestSuite struct {
+	serviceHarness
+	seasonalService seasonal.Service
+
+	// now is the date the seasonal calendar is checked against
+	now time.Time
+}
+
+func (s *SeasonalEventsTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		seasons, err := seasonalRepo.NewRedis(&seasonalRepo.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+
+		mockClock := clockMocks.NewMockClock(s.mockCtrl)
+		mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+
+		s.seasonalService, err = seasonal.New(&seasonal.Config{
+			Location:     time.UTC,
+			SeasonalRepo: seasons,
+			Clock:        mockClock,
+		})
+		s.Require().NoError(err)
+		cfg.SeasonalService = s.seasonalService
+	})
+	s.testGuildID = "seasonal-guild"
+	s.testChannelID = "seasonal-channel"
+}
+
+func TestSeasonalEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(SeasonalEventsTestSuite))
+}
+
+// drinksFor counts the drinks recorded for a player in a game
+func (s *SeasonalEventsTestSuite) drinksFor(gameID, playerID string) int {
+	records, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	count := 0
+	for _, record := range records.Records {
+		if record.ToPlayerID == playerID {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *SeasonalEventsTestSuite) TestDoubleDrinkFriday() {
+	s.now = time.Date(2025, 4, 18, 21, 0, 0, 0, time.UTC)
+
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 4)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal([]string{seasonal.EventDoubleDrinkFriday}, game.Events)
+
+	// alice had the lowest roll, and it's Friday
+	s.Equal(2, s.drinksFor(gameID, "alice"))
+}
+
+func (s *SeasonalEventsTestSuite) TestOptedOutGuildPlaysNormally() {
+	s.now = time.Date(2025, 4, 18, 21, 0, 0, 0, time.UTC)
+	_, err := s.seasonalService.SetOptOut(s.ctx, &seasonal.SetOptOutInput{GuildID: s.testGuildID, OptOut: true})
+	s.Require().NoError(err)
+
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 4)
+
+	s.Equal(1, s.drinksFor(gameID, "alice"))
+}
+
+func (s *SeasonalEventsTestSuite) TestLuckOfTheIrish() {
+	s.now = time.Date(2025, 3, 17, 21, 0, 0, 0, time.UTC)
+
+	gameID := s.startGame("alice", "bob")
+
+	// Every roll is made twice and the better one kept
+	output := s.roll(gameID, "alice", 1, 5)
+	s.Equal(5, output.RollValue)
+	s.Require().Len(output.RollModifiers, 1)
+	s.Equal("Luck of the Irish", output.RollModifiers[0].Source)
+}
+
+func (s *SeasonalEventsTestSuite) TestEventLastsForTheGame() {
+	s.now = time.Date(2025, 4, 18, 23, 59, 0, 0, time.UTC)
+	gameID := s.startGame("alice", "bob")
+
+	// The game started on Friday, so it's played as one even after midnight
+	s.now = s.now.Add(time.Hour)
+	s.roll(gameID, "alice", 2)
+	s.roll(gameID, "bob", 4)
+
+	s.Equal(2, s.drinksFor(gameID, "alice"))
+}
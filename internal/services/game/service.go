@@ -5,15 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/KirkDiggler/ronnied/internal/common/clock"
 	"github.com/KirkDiggler/ronnied/internal/common/uuid"
 	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/events"
 	"github.com/KirkDiggler/ronnied/internal/models"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
 	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
 	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	gameViewRepo "github.com/KirkDiggler/ronnied/internal/repositories/game_view"
+	jobRepo "github.com/KirkDiggler/ronnied/internal/repositories/job"
+	momentRepo "github.com/KirkDiggler/ronnied/internal/repositories/moment"
 	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
+	rollLogRepo "github.com/KirkDiggler/ronnied/internal/repositories/roll_log"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
 )
 
 // service implements the Service interface
@@ -21,19 +33,88 @@ type service struct {
 	// Configuration parameters
 	maxPlayers         int
 	diceSides          int
-	criticalHitValue   int
-	criticalFailValue  int
+	criticalHitValues  []int
+	criticalFailValues []int
+	socialValues       []int
 	maxConcurrentGames int
 
 	// Repository dependencies
 	gameRepo        gameRepo.Repository
 	playerRepo      playerRepo.Repository
 	drinkLedgerRepo ledgerRepo.Repository
+	auditRepo       auditRepo.Repository    // nil when actions aren't audited
+	gameViewRepo    gameViewRepo.Repository // nil when game views are loaded piece by piece
+	rollLogRepo     rollLogRepo.Repository  // nil when rolls aren't logged
+	momentRepo      momentRepo.Repository   // nil when moments can't be clipped
+	transactions    transaction.Runner      // nil when writes are saved one at a time
+	jobRepo         jobRepo.Repository      // nil when post-game work is done in the interaction
 
 	// Service dependencies
 	diceRoller dice.Roller
 	clock      clock.Clock
 	uuid       uuid.UUID
+	eventBus   events.Bus
+
+	// Game lifecycle
+	lifecycle *StateMachine
+
+	// Session rotation, nil when sessions are never rotated automatically
+	sessionRotation *SessionRotationConfig
+
+	// Mercy rule drink cap per player per session, 0 when disabled
+	drinkCap int
+
+	// Sober-up checks on paying drinks, nil when disabled
+	pacing *PacingConfig
+
+	// Seasonal events for new games, nil when disabled
+	seasonalService seasonal.Service
+
+	// Feature flags per guild, nil when everything is on
+	featureFlagService feature_flag.Service
+
+	// Servers' own game rules, nil when every server plays with the defaults
+	guildConfigService guild_config.Service
+
+	// How many times each drink counts in a session's final game after last call
+	lastCallMultiplier float64
+
+	// How long games can be played before they're ended, 0 when there's no limit
+	maxGameDuration time.Duration
+
+	// How long countdown games' players have to roll once the countdown ends
+	rollWindow time.Duration
+
+	// Whether every game makes the session leader roll with disadvantage
+	leaderHandicap bool
+
+	// Whether every game lets critical hits give drinks to people watching
+	guestDrinks bool
+
+	// Whether every game makes players who keep picking on the same player drink too
+	karma bool
+
+	// Whether every game is played for the session's jackpot
+	jackpot bool
+
+	// How long Ronnie waits before making a roll
+	ronnieRollDelay time.Duration
+
+	// Times of day drinks count more or less, nil when there's no happy hour
+	happyHour *HappyHourConfig
+
+	// Time zone birthdays are celebrated in, nil for local time
+	birthdayLocation *time.Location
+
+	// How long drinks are owed before their player is reminded, 0 when there are no reminders
+	drinkReminderAfter time.Duration
+
+	// Share of a game's players who have to approve a vote
+	voteThreshold float64
+
+	// Runtime settings applied over the configuration parameters, nil until some are applied
+	botConfigMu sync.RWMutex
+	botConfig   *models.BotConfig
 }
 
 // New creates a new game service
@@ -78,14 +159,25 @@ func New(cfg *Config) (*service, error) {
 		diceSides = 6
 	}
 
-	criticalHitValue := cfg.CriticalHitValue
-	if criticalHitValue <= 0 {
-		criticalHitValue = 6
+	criticalHitValues := cfg.CriticalHitValues
+	if len(criticalHitValues) == 0 {
+		criticalHitValues = []int{diceSides}
 	}
 
-	criticalFailValue := cfg.CriticalFailValue
-	if criticalFailValue <= 0 {
-		criticalFailValue = 1
+	criticalFailValues := cfg.CriticalFailValues
+	if len(criticalFailValues) == 0 {
+		criticalFailValues = []int{1}
+	}
+
+	// The default critical values have to be on the default dice
+	if err := ValidateRules(models.GameRules{
+		DiceSides:          diceSides,
+		MaxPlayers:         maxPlayers,
+		CriticalHitValues:  criticalHitValues,
+		CriticalFailValues: criticalFailValues,
+		SocialValues:       cfg.SocialValues,
+	}); err != nil {
+		return nil, err
 	}
 
 	maxConcurrentGames := cfg.MaxConcurrentGames
@@ -93,48 +185,205 @@ func New(cfg *Config) (*service, error) {
 		maxConcurrentGames = 100
 	}
 
+	// Events are optional, fall back to a bus nobody listens to
+	eventBus := cfg.EventBus
+	if eventBus == nil {
+		eventBus = events.NewBus()
+	}
+	eventBus = &committedBus{Bus: eventBus}
+
+	lifecycle := cfg.Lifecycle
+	if lifecycle == nil {
+		lifecycle = NewStateMachine()
+	}
+
+	lastCallMultiplier := cfg.LastCallMultiplier
+	if lastCallMultiplier == 0 {
+		lastCallMultiplier = 1.5
+	}
+	if lastCallMultiplier < 1 {
+		return nil, errors.New("last call multiplier must be at least 1")
+	}
+
+	if cfg.SessionRotation != nil && cfg.SessionRotation.Daily &&
+		(cfg.SessionRotation.DailyAt < 0 || cfg.SessionRotation.DailyAt >= 24*time.Hour) {
+		return nil, errors.New("session rotation time must be within a day")
+	}
+
+	if cfg.Pacing != nil && (cfg.Pacing.MaxDrinks <= 0 || cfg.Pacing.Window <= 0 || cfg.Pacing.Cooldown < 0) {
+		return nil, errors.New("pacing needs a positive drink limit and window")
+	}
+
+	if cfg.MaxGameDuration < 0 {
+		return nil, errors.New("max game duration can't be negative")
+	}
+
+	if err := validateHappyHour(cfg.HappyHour); err != nil {
+		return nil, err
+	}
+
+	rollWindow := cfg.CountdownRollWindow
+	if rollWindow == 0 {
+		rollWindow = 10 * time.Second
+	}
+	if rollWindow < 0 {
+		return nil, errors.New("countdown roll window can't be negative")
+	}
+
+	ronnieRollDelay := cfg.RonnieRollDelay
+	if ronnieRollDelay == 0 {
+		ronnieRollDelay = defaultRonnieRollDelay
+	}
+	if ronnieRollDelay < 0 {
+		return nil, errors.New("Ronnie's roll delay can't be negative")
+	}
+
+	voteThreshold := cfg.VoteThreshold
+	if voteThreshold == 0 {
+		voteThreshold = defaultVoteThreshold
+	}
+	if voteThreshold < 0 || voteThreshold > 1 {
+		return nil, errors.New("vote threshold must be between 0 and 1")
+	}
+
 	return &service{
 		// Configuration parameters
 		maxPlayers:         maxPlayers,
 		diceSides:          diceSides,
-		criticalHitValue:   criticalHitValue,
-		criticalFailValue:  criticalFailValue,
+		criticalHitValues:  criticalHitValues,
+		criticalFailValues: criticalFailValues,
+		socialValues:       cfg.SocialValues,
 		maxConcurrentGames: maxConcurrentGames,
 
 		// Repository dependencies
 		gameRepo:        cfg.GameRepo,
 		playerRepo:      cfg.PlayerRepo,
 		drinkLedgerRepo: cfg.DrinkLedgerRepo,
+		auditRepo:       cfg.AuditRepo,
+		gameViewRepo:    cfg.GameViewRepo,
+		rollLogRepo:     cfg.RollLogRepo,
+		momentRepo:      cfg.MomentRepo,
+		transactions:    cfg.Transactions,
+		jobRepo:         cfg.JobRepo,
 
 		// Service dependencies
 		diceRoller: cfg.DiceRoller,
 		clock:      cfg.Clock,
 		uuid:       cfg.UUIDGenerator,
+		eventBus:   eventBus,
+
+		// Game lifecycle
+		lifecycle: lifecycle,
+
+		sessionRotation: cfg.SessionRotation,
+		drinkCap:        cfg.DrinkCap,
+		pacing:          cfg.Pacing,
+		seasonalService: cfg.SeasonalService,
+
+		featureFlagService: cfg.FeatureFlagService,
+		guildConfigService: cfg.GuildConfigService,
+
+		lastCallMultiplier: lastCallMultiplier,
+		maxGameDuration:    cfg.MaxGameDuration,
+		rollWindow:         rollWindow,
+		leaderHandicap:     cfg.LeaderHandicap,
+		guestDrinks:        cfg.GuestDrinks,
+		karma:              cfg.Karma,
+		jackpot:            cfg.Jackpot,
+		ronnieRollDelay:    ronnieRollDelay,
+		happyHour:          cfg.HappyHour,
+		birthdayLocation:   cfg.BirthdayLocation,
+		drinkReminderAfter: cfg.DrinkReminderAfter,
+		voteThreshold:      voteThreshold,
 	}, nil
 }
 
 func (s *service) CreateGame(ctx context.Context, input *CreateGameInput) (*CreateGameOutput, error) {
-	// Create a new game using the repository
-	createGameOutput, err := s.gameRepo.CreateGame(ctx, &gameRepo.CreateGameInput{
-		ChannelID: input.ChannelID,
-		CreatorID: input.CreatorID,
-		Status:    models.GameStatusWaiting,
-	})
+	// Make sure custom rules still make a playable game once merged with the defaults
+	if input.Rules != nil {
+		if err := ValidateRules(s.rulesFor(&models.Game{Rules: input.Rules})); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rounds games are played over a handful of rounds, and not with a countdown's single rolling window
+	if input.Rounds != 0 {
+		if input.Rounds < models.MinGameRounds || input.Rounds > models.MaxGameRounds {
+			return nil, ErrInvalidRounds
+		}
+		if input.Countdown {
+			return nil, ErrCountdownRounds
+		}
+	}
+
+	// No games are started while the session is paused for a break
+	if s.sessionPaused(ctx, input.GuildID, input.ChannelID) {
+		return nil, ErrSessionPaused
+	}
+
+	// After last call only the final game can be played
+	lastCallSession, err := s.lastCallSession(ctx, input.GuildID, input.ChannelID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create the creator as a participant
-	_, err = s.gameRepo.CreateParticipant(ctx, &gameRepo.CreateParticipantInput{
-		GameID:     createGameOutput.Game.ID,
-		PlayerID:   input.CreatorID,
-		PlayerName: input.CreatorName,
-		Status:     models.ParticipantStatusWaitingToRoll,
+	// Create the game, its creator's participant and player record together so a failure can't leave a game
+	// without its creator or a creator pointing at a game that was never saved
+	var createGameOutput *gameRepo.CreateGameOutput
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createGameOutput, err = s.gameRepo.CreateGame(ctx, &gameRepo.CreateGameInput{
+			ChannelID:        input.ChannelID,
+			GuildID:          input.GuildID,
+			CreatorID:        input.CreatorID,
+			Status:           models.GameStatusWaiting,
+			Rules:            input.Rules,
+			Events:           s.activeEventIDs(ctx, input),
+			LastCall:         lastCallSession != nil,
+			Countdown:        input.Countdown,
+			Rounds:           newGameRounds(input.Rounds),
+			Private:          input.Private || len(input.InvitedPlayerIDs) > 0,
+			InvitedPlayerIDs: invitedPlayerIDs(input.CreatorID, input.InvitedPlayerIDs),
+			JoinCreator:      true,
+			CreatorName:      input.CreatorName,
+		})
+		if err != nil {
+			return err
+		}
+
+		if lastCallSession != nil {
+			lastCallSession.LastCallGameID = createGameOutput.Game.ID
+			err = s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+				Session: lastCallSession,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update session: %w", err)
+			}
+		}
+
+		// Make sure the creator has a player record, roll-offs look players up by ID
+		creator, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: input.CreatorID,
+		})
+		if err != nil {
+			creator = &models.Player{
+				ID:           input.CreatorID,
+				Name:         input.CreatorName,
+				LastRollTime: s.clock.Now(),
+			}
+		}
+		creator.CurrentGameID = createGameOutput.Game.ID
+
+		return s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+			Player: creator,
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.publishGameCreated(ctx, createGameOutput.Game, input.CreatorName)
+
 	return &CreateGameOutput{
 		GameID: createGameOutput.Game.ID,
 	}, nil
@@ -150,8 +399,8 @@ func (s *service) StartGame(ctx context.Context, input *StartGameInput) (*StartG
 		return nil, ErrGameNotFound
 	}
 
-	// Ensure the game is in waiting status
-	if game.Status != models.GameStatusWaiting {
+	// Ensure the game can be started
+	if !s.lifecycle.CanTransition(game.Status, models.GameStatusActive) {
 		return nil, ErrInvalidGameState
 	}
 
@@ -169,12 +418,12 @@ func (s *service) StartGame(ctx context.Context, input *StartGameInput) (*StartG
 		}
 	}
 
-	// Check if the player is the game creator
-	isCreator := game.CreatorID == input.PlayerID
+	// Check if the player hosts the game, as its creator or a co-host
+	isHost := game.IsHost(input.PlayerID)
 	
-	// If not the creator, check if force start is allowed
+	// If not a host, check if force start is allowed
 	forceStarted := false
-	if !isCreator {
+	if !isHost {
 		// Only allow force start if explicitly requested and game is older than 5 minutes
 		if !input.ForceStart {
 			return nil, ErrNotCreator
@@ -194,13 +443,12 @@ func (s *service) StartGame(ctx context.Context, input *StartGameInput) (*StartG
 		forceStarted = true
 		
 		// Assign a drink to the creator for delaying
-		_, err = s.drinkLedgerRepo.CreateDrinkRecord(ctx, &ledgerRepo.CreateDrinkRecordInput{
+		_, err = s.createDrinkRecord(ctx, game, s.getSessionForGame(ctx, game), &ledgerRepo.CreateDrinkRecordInput{
 			GameID:       input.GameID,
 			FromPlayerID: input.PlayerID,
 			ToPlayerID:   game.CreatorID,
 			Reason:       models.DrinkReasonDelayedStart,
 			Timestamp:    s.clock.Now(),
-			SessionID:    s.getSessionIDForChannel(ctx, game.ChannelID),
 		})
 		
 		if err != nil {
@@ -210,8 +458,15 @@ func (s *service) StartGame(ctx context.Context, input *StartGameInput) (*StartG
 	}
 
 	// Update game status to active
-	game.Status = models.GameStatusActive
-	game.UpdatedAt = s.clock.Now()
+	startedAt := s.clock.Now()
+	err = s.lifecycle.Transition(game, models.GameStatusActive, startedAt)
+	if err != nil {
+		return nil, err
+	}
+	game.StartedAt = &startedAt
+	if game.Countdown {
+		game.RollWindow = s.countdownRollWindow(startedAt)
+	}
 
 	// Save the updated game
 	err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
@@ -221,34 +476,36 @@ func (s *service) StartGame(ctx context.Context, input *StartGameInput) (*StartG
 		return nil, err
 	}
 
-	// Check if the game is ready to complete (all players have rolled and assigned drinks)
-	if game.IsReadyToComplete() {
-		log.Printf("Game %s is ready to complete immediately after starting", game.ID)
-
-		// Try to end the game
-		endGameOutput, err := s.EndGame(ctx, &EndGameInput{
-			Game: game,
-		})
-
-		if err != nil {
-			// Log the error but don't fail the start game operation
-			log.Printf("Error ending game after start: %v", err)
-		} else if endGameOutput.NeedsRollOff {
-			// A roll-off is needed, log this information
-			log.Printf("Game %s needs a roll-off after immediate completion", game.ID)
-		}
-	}
+	// Ronnie rolls once the game starts, if he's playing
+	s.scheduleRonnie(game)
 
 	return &StartGameOutput{
 		Success:      true,
 		ForceStarted: forceStarted,
 		CreatorID:    game.CreatorID,
 		CreatorName:  creatorName,
+		RollWindow:   game.RollWindow,
 	}, nil
 }
 
 // JoinGame adds a player to an existing game
 func (s *service) JoinGame(ctx context.Context, input *JoinGameInput) (*JoinGameOutput, error) {
+	// Players tend to join all at once, a join that loses the race to another is made again on the game as it is now
+	var output *JoinGameOutput
+	err := retryOnGameChange(func() error {
+		var err error
+		output, err = s.joinGame(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// joinGame adds a player to the game as it is when it's read
+func (s *service) joinGame(ctx context.Context, input *JoinGameInput) (*JoinGameOutput, error) {
 	// Get the game
 	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
 		GameID: input.GameID,
@@ -258,13 +515,7 @@ func (s *service) JoinGame(ctx context.Context, input *JoinGameInput) (*JoinGame
 	}
 
 	// Check if player is already in the game
-	playerAlreadyInGame := false
-	for _, participant := range game.Participants {
-		if participant.PlayerID == input.PlayerID {
-			playerAlreadyInGame = true
-			break
-		}
-	}
+	playerAlreadyInGame := game.HasParticipant(input.PlayerID)
 
 	// If player is not already in the game, check if they can join based on game state
 	if !playerAlreadyInGame {
@@ -276,9 +527,15 @@ func (s *service) JoinGame(ctx context.Context, input *JoinGameInput) (*JoinGame
 			return nil, ErrGameRollOff
 		case models.GameStatusCompleted:
 			return nil, ErrGameCompleted
+		case models.GameStatusAbandoned:
+			return nil, ErrGameAbandoned
 		case models.GameStatusWaiting:
+			// Private games can only be joined by invitation
+			if !game.CanJoin(input.PlayerID) {
+				return nil, ErrNotInvited
+			}
 			// Check if the game is full
-			if len(game.Participants) >= s.maxPlayers {
+			if len(game.Participants) >= s.rulesFor(game).MaxPlayers {
 				return nil, ErrGameFull
 			}
 			// Game is waiting and not full, so player can join
@@ -296,57 +553,62 @@ func (s *service) JoinGame(ctx context.Context, input *JoinGameInput) (*JoinGame
 		}, nil
 	}
 
-	// Check if player already exists
-	existingPlayer, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-		PlayerID: input.PlayerID,
-	})
+	// Point the player at the game and add them to it together, so a failure can't leave a phantom participant
+	// or a player stuck in a game they never joined
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		// Check if player already exists
+		existingPlayer, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: input.PlayerID,
+		})
 
-	// If player exists, check if they're already in a game
-	if err == nil {
-		if existingPlayer.CurrentGameID != "" {
-			// They're in another game, update their game ID
-			err = s.playerRepo.UpdatePlayerGame(ctx, &playerRepo.UpdatePlayerGameInput{
-				PlayerID: input.PlayerID,
-				GameID:   input.GameID,
-			})
-			if err != nil {
-				return nil, err
+		// If player exists, check if they're already in a game
+		if err == nil {
+			if existingPlayer.CurrentGameID != "" {
+				// They're in another game, update their game ID
+				err = s.playerRepo.UpdatePlayerGame(ctx, &playerRepo.UpdatePlayerGameInput{
+					PlayerID: input.PlayerID,
+					GameID:   input.GameID,
+				})
+				if err != nil {
+					return err
+				}
+			} else {
+				// Update the player's current game
+				existingPlayer.CurrentGameID = input.GameID
+				err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+					Player: existingPlayer,
+				})
+				if err != nil {
+					return err
+				}
 			}
 		} else {
-			// Update the player's current game
-			existingPlayer.CurrentGameID = input.GameID
+			// Create a new player
+			now := s.clock.Now()
+			player := &models.Player{
+				ID:            input.PlayerID,
+				Name:          input.PlayerName,
+				CurrentGameID: input.GameID,
+				LastRoll:      0,
+				LastRollTime:  now,
+			}
+
 			err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
-				Player: existingPlayer,
+				Player: player,
 			})
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
-	} else {
-		// Create a new player
-		now := s.clock.Now()
-		player := &models.Player{
-			ID:            input.PlayerID,
-			Name:          input.PlayerName,
-			CurrentGameID: input.GameID,
-			LastRoll:      0,
-			LastRollTime:  now,
-		}
 
-		err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
-			Player: player,
+		// Use the repository to create a participant with a generated UUID
+		_, err = s.gameRepo.CreateParticipant(ctx, &gameRepo.CreateParticipantInput{
+			GameID:     input.GameID,
+			PlayerID:   input.PlayerID,
+			PlayerName: input.PlayerName,
+			Status:     models.ParticipantStatusWaitingToRoll,
 		})
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Use the repository to create a participant with a generated UUID
-	_, err = s.gameRepo.CreateParticipant(ctx, &gameRepo.CreateParticipantInput{
-		GameID:     input.GameID,
-		PlayerID:   input.PlayerID,
-		PlayerName: input.PlayerName,
-		Status:     models.ParticipantStatusWaitingToRoll,
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -381,6 +643,11 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
 
+	// Nobody rolls while a host has the game paused
+	if game.IsPaused() {
+		return nil, ErrGamePaused
+	}
+
 	// Check if this is a main game and if the player should be in a roll-off instead
 	if game.Status != models.GameStatusRollOff {
 		rollOffGame, err := s.FindActiveRollOffGame(ctx, input.PlayerID, input.GameID)
@@ -412,10 +679,21 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 	}
 
 	// Check if game is in a valid state for rolling
-	if !isValidGameStateForRolling(game.Status) {
+	if game.Status == models.GameStatusWaiting {
+		return nil, ErrGameNotStarted
+	}
+	if !s.lifecycle.IsPlaying(game.Status) {
 		return nil, fmt.Errorf("%w: game status is %s", ErrInvalidGameState, game.Status)
 	}
 
+	// Remember whether this is a roll-off roll before the game possibly ends below
+	isRollOffRoll := game.Status == models.GameStatusRollOff
+	gameIDsToUpdate := []string{input.GameID}
+	if isRollOffRoll && game.ParentGameID != "" {
+		// If this is a roll-off game, also update the parent game
+		gameIDsToUpdate = append(gameIDsToUpdate, game.ParentGameID)
+	}
+
 	// Find the participant in the game
 	participant := game.GetParticipant(input.PlayerID)
 	if participant == nil {
@@ -424,55 +702,134 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 
 	// Check if the participant has already rolled
 	if participant.RollTime != nil {
-		return nil, fmt.Errorf("player %s has already rolled in this game", participant.PlayerName)
+		return nil, fmt.Errorf("%w: player %s has already rolled in this game", ErrPlayerAlreadyRolled, participant.PlayerName)
 	}
 
-	// Roll the dice
-	rollValue := s.diceRoller.Roll(s.diceSides)
-	now := s.clock.Now()
+	// Hot seat players are rolled for by the player running their hot seat
+	if host := models.HotSeatHost(participant.PlayerID); host != "" && host != input.HostID {
+		return nil, ErrNotHotSeatHost
+	}
+
+	// Sudden death is rolled in turns
+	if game.SuddenDeath != nil && game.NextRoller() != participant {
+		return nil, ErrNotYourTurn
+	}
 
-	// Update the participant's roll
-	participant.RollValue = rollValue
-	participant.RollTime = &now
+	// Roll the dice, running the roll through any modifiers the player is carrying
+	rules := s.rulesFor(game)
+	rollModifiers := seasonal.RollModifiers(game.Events)
+	handicap := s.leaderHandicapFor(ctx, game, rules, input.PlayerID)
+	if handicap != nil {
+		rollModifiers = append(rollModifiers, handicap)
+	}
+	if rollMode := rollModeFor(rules); rollMode != nil {
+		rollModifiers = append(rollModifiers, rollMode)
+	}
+	modifiedPlayer := s.playerWithModifiers(ctx, input.PlayerID)
+	if modifiedPlayer != nil {
+		rollModifiers = append(rollModifiers, modifiedPlayer.RollModifiers...)
+	}
+	rollValue := s.resolveRoll(rules, rollModifiers)
+	now := s.clock.Now()
 
 	// Check if the roll is a critical hit or fail
-	isCriticalHit := rollValue == s.criticalHitValue
-	isCriticalFail := rollValue == s.criticalFailValue
+	isCriticalHit := rules.IsCriticalHit(rollValue)
+	isCriticalFail := rules.IsCriticalFail(rollValue)
+
+	// Everyone drinks on a social value, outside roll-offs where only the tied players are rolling
+	isSocialRound := !isCriticalHit && !isCriticalFail && !isRollOffRoll && rules.IsSocial(rollValue)
 
 	// Update participant status based on roll
+	status := models.ParticipantStatusActive
 	if isCriticalHit {
-		participant.Status = models.ParticipantStatusNeedsToAssign
-	} else {
-		participant.Status = models.ParticipantStatusActive
+		status = models.ParticipantStatusNeedsToAssign
+	}
+
+	// Record the roll before anything comes of it, so a second click that got past the check above can't roll again.
+	// The game isn't saved whole after this, as that would overwrite the rolls other players recorded meanwhile.
+	recordOutput, err := s.gameRepo.RecordRoll(ctx, &gameRepo.RecordRollInput{
+		GameID:        input.GameID,
+		PlayerID:      input.PlayerID,
+		RollValue:     rollValue,
+		RollTime:      now,
+		Status:        status,
+		RollModifiers: rollModifiers,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gameRepo.ErrRollAlreadyRecorded):
+			return nil, fmt.Errorf("%w: player %s has already rolled in this game", ErrPlayerAlreadyRolled, participant.PlayerName)
+		case errors.Is(err, gameRepo.ErrParticipantNotFound):
+			return nil, ErrPlayerNotInGame
+		case errors.Is(err, gameRepo.ErrGameNotFound):
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to record roll: %w", err)
+	}
+
+	if modifiedPlayer != nil {
+		s.useRollModifiers(ctx, modifiedPlayer)
+	}
 
+	// Carry on with the game as saved with the roll, which has the rolls recorded since it was read
+	game = recordOutput.Game
+	participant = game.GetParticipant(input.PlayerID)
+
+	// Save the drinks a critical fail or social round costs together
+	var criticalFailDrink *models.DrinkLedger
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
 		// If it's a critical fail, automatically assign a drink to self
-		if isCriticalFail {
+		if isCriticalFail && !isCriticalHit {
 			// Create a new drink record using the repository
-			_, err = s.drinkLedgerRepo.CreateDrinkRecord(ctx, &ledgerRepo.CreateDrinkRecordInput{
+			drinkOutput, err := s.createDrinkRecord(ctx, game, s.getSessionForGame(ctx, game), &ledgerRepo.CreateDrinkRecordInput{
 				GameID:       input.GameID,
 				FromPlayerID: input.PlayerID,
 				ToPlayerID:   input.PlayerID,
 				Reason:       models.DrinkReasonCriticalFail,
 				Timestamp:    now,
-				SessionID:    s.getSessionIDForChannel(ctx, game.ChannelID),
 			})
 
 			if err != nil {
-				log.Printf("Error saving critical fail drink record: %v", err)
-				// Don't return the error, continue with the roll
+				return fmt.Errorf("failed to save critical fail drink: %w", err)
 			}
+			criticalFailDrink = drinkOutput.Record
 		}
-	}
 
-	// Update the game
-	game.UpdatedAt = now
-	err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-		Game: game,
+		if isSocialRound {
+			if _, err := s.recordSocialRound(ctx, game, input.PlayerID, participant.PlayerName, rollValue, now); err != nil {
+				return fmt.Errorf("failed to save social round drinks: %w", err)
+			}
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to save game: %w", err)
+		return nil, err
 	}
 
+	// Hard luck counts the critical fails saved, this one included
+	earnedRerollToken := false
+	if criticalFailDrink != nil && !models.IsRonnie(participant.PlayerID) {
+		earnedRerollToken = s.checkHardLuck(ctx, game, criticalFailDrink, participant.PlayerName)
+	}
+
+	// Games played for the jackpot have a critical fail drink all of it, and doubles add to it
+	jackpotDrinks, jackpot := 0, 0
+	if criticalFailDrink != nil {
+		jackpotDrinks = s.takeJackpot(ctx, game, participant)
+	} else if isDoubles(game, participant) {
+		jackpot = s.addToJackpot(ctx, game, participant)
+	}
+
+	// Countdown games cost a drink for rolling outside the rolling window
+	rollTiming := s.checkRollTiming(ctx, game, participant, now)
+
+	s.logRoll(ctx, game, participant, rules, isCriticalHit, isCriticalFail)
+	s.publishDiceRolled(ctx, game, participant, isCriticalHit, isCriticalFail)
+	if isCriticalHit {
+		s.countCriticalHitGoals(ctx, game, participant)
+	}
+	s.settleSideBets(ctx, game, participant, rules, now)
+
 	// Check if all players have rolled
 	allPlayersRolled := true
 	for _, p := range game.Participants {
@@ -482,58 +839,44 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 		}
 	}
 
-	// If all players have rolled and no players need to assign drinks, try to end the game
-	var endGameOutput *EndGameOutput
+	// End the game if this roll was the last thing it was waiting on
 	needsRollOff := false
 	rollOffType := ""
 	rollOffGameID := ""
+	nextRound := 0
+	suddenDeathPot := 0
 
-	if allPlayersRolled {
-		// Check if any players need to assign drinks
-		allDrinksAssigned := true
-		for _, p := range game.Participants {
-			if p.Status == models.ParticipantStatusNeedsToAssign {
-				allDrinksAssigned = false
-				break
-			}
-		}
-
-		// Only try to end the game if all drinks are assigned
-		if allDrinksAssigned {
-			endGameOutput, err = s.EndGame(ctx, &EndGameInput{
-				Game: game,
-			})
-
-			if err == nil {
-				if endGameOutput.NeedsRollOff {
-					needsRollOff = true
-					rollOffType = string(endGameOutput.RollOffType)
-					rollOffGameID = endGameOutput.RollOffGameID
-				}
-			} else {
-				// Log the error but don't return it to the caller
-				log.Printf("Error ending game after all players rolled: %v", err)
-			}
-		}
+	endGameOutput, err := s.completeIfReady(ctx, game)
+	if err != nil {
+		// Log the error but don't return it to the caller
+		log.Printf("Error ending game after all players rolled: %v", err)
+	} else if endGameOutput != nil && endGameOutput.NeedsRollOff {
+		needsRollOff = true
+		rollOffType = string(endGameOutput.RollOffType)
+		rollOffGameID = endGameOutput.RollOffGameID
+	} else if endGameOutput != nil {
+		nextRound = endGameOutput.NextRound
+		suddenDeathPot = endGameOutput.SuddenDeathPot
 	}
 
 	// Prepare domain result information
 	result := ""
 	details := ""
 	var eligiblePlayers []PlayerOption
+	var birthdayPlayers []PlayerOption
 
 	// Get the player name
 	playerName := ""
-	for _, p := range game.Participants {
-		if p.PlayerID == input.PlayerID {
-			playerName = p.PlayerName
-			break
-		}
+	if p := game.GetParticipant(input.PlayerID); p != nil {
+		playerName = p.PlayerName
 	}
 
 	// Set result and details based on roll result
 	if isCriticalHit {
 		result = fmt.Sprintf("You Rolled a %d! Critical Hit!", rollValue)
+		if rules.DiceSides == 20 && rollValue == 20 {
+			result = "Natural 20! Critical Hit!"
+		}
 		details = "Select a player to assign a drink:"
 
 		// Get eligible players for drink assignment
@@ -553,36 +896,56 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 		// If there are no other players, include the current player
 		if len(eligiblePlayers) == 0 {
 			// Find the current player
-			for _, p := range game.Participants {
-				if p.PlayerID == input.PlayerID {
-					eligiblePlayers = append(eligiblePlayers, PlayerOption{
-						PlayerID:        p.PlayerID,
-						PlayerName:      p.PlayerName + " (You)",
-						IsCurrentPlayer: true,
-					})
-					break
-				}
+			if p := game.GetParticipant(input.PlayerID); p != nil {
+				eligiblePlayers = append(eligiblePlayers, PlayerOption{
+					PlayerID:        p.PlayerID,
+					PlayerName:      p.PlayerName + " (You)",
+					IsCurrentPlayer: true,
+				})
 			}
 			details += "\n\nYou're the only player, so you'll have to drink yourself!"
 		}
+
+		// On a player's birthday every critical hit's drink goes to them
+		birthdayPlayers = s.birthdayPlayers(ctx, game, input.PlayerID)
+		if len(birthdayPlayers) > 0 {
+			eligiblePlayers = birthdayPlayers
+			details = "It's a birthday! Your drink goes to the birthday player:"
+		}
 	} else if isCriticalFail {
-		result = "You Rolled a 1! Critical Fail!"
+		result = fmt.Sprintf("You Rolled a %d! Critical Fail!", rollValue)
+		if rules.DiceSides == 20 && rollValue == 1 {
+			result = "Natural 1! Critical Fail!"
+		}
 		details = "Drink up! 🍺"
+		if jackpotDrinks > 0 {
+			details = fmt.Sprintf("💰 JACKPOT! You drink the whole pot too, that's %d more! 🍺", jackpotDrinks)
+		}
+	} else if isSocialRound {
+		result = fmt.Sprintf("You Rolled a %d! Social!", rollValue)
+		details = "Everyone drinks! 🍻"
 	} else {
 		result = fmt.Sprintf("You Rolled a %d", rollValue)
 		details = "Your roll has been recorded."
 	}
 
-	// Determine which game IDs need to be updated
-	gameIDsToUpdate := []string{input.GameID}
-	
-	// If this is a roll-off game, also update the parent game
-	if game.Status == models.GameStatusRollOff && game.ParentGameID != "" {
-		gameIDsToUpdate = append(gameIDsToUpdate, game.ParentGameID)
+	// The roll matched the one before it and fed the jackpot
+	if jackpot > 0 {
+		details += fmt.Sprintf("\n\n💰 Doubles! A drink goes in the jackpot, %d in it for the next critical fail.", jackpot)
 	}
 
-	// Check if this is a roll-off roll
-	isRollOffRoll := game.Status == models.GameStatusRollOff
+	// The roll finished a round of a rounds game, everyone goes again
+	if nextRound > 0 {
+		details += fmt.Sprintf("\n\nThat's the round! Everyone rolls again for round %d of %d.", nextRound, game.Rounds.Total)
+	}
+
+	// The roll tied a sudden death up again, everyone goes again in turn
+	if suddenDeathPot > 0 {
+		details += fmt.Sprintf("\n\n☠️ Tied again! Another drink goes in the pot, the loser takes %d extra. Roll again in turn.", suddenDeathPot)
+	}
+
+	// Ronnie makes whatever roll comes to him next, a roll-off or round this roll started, or his turn
+	s.scheduleRonnie(game)
 
 	return &RollDiceOutput{
 		// Basic roll information
@@ -592,6 +955,7 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 		PlayerName:       playerName,
 		IsCriticalHit:    isCriticalHit,
 		IsCriticalFail:   isCriticalFail,
+		DiceSides:        rules.DiceSides,
 		AllPlayersRolled: allPlayersRolled,
 		NeedsRollOff:     needsRollOff,
 		RollOffType:      RollOffType(rollOffType),
@@ -609,14 +973,30 @@ func (s *service) RollDice(ctx context.Context, input *RollDiceInput) (*RollDice
 		ParentGameID:        game.ParentGameID,
 		NeedsToRollInRollOff: false, // We're already rolling in the right game
 		GameIDsToUpdate:     gameIDsToUpdate,
+		EarnedRerollToken:   earnedRerollToken,
+		RollModifiers:       rollModifiers,
+		RollTiming:          rollTiming,
+		LeaderHandicap:      handicap != nil,
+		BirthdayPlayers:     birthdayPlayers,
+		IsSocialRound:       isSocialRound,
+		GuestDrinks:         isCriticalHit && rules.GuestDrinks && len(birthdayPlayers) == 0,
+		NextRound:           nextRound,
+		SuddenDeathPot:      suddenDeathPot,
+		JackpotDrinks:       jackpotDrinks,
+		Jackpot:             jackpot,
 	}, nil
 }
 
-// isValidGameStateForRolling checks if a game state allows dice rolling
-func isValidGameStateForRolling(status models.GameStatus) bool {
-	return status == models.GameStatusActive ||
-		status == models.GameStatusRollOff ||
-		status == models.GameStatusWaiting
+// completeIfReady ends the game once every player has rolled and assigned their drinks.
+// It returns nil output when the game is still waiting on players.
+func (s *service) completeIfReady(ctx context.Context, game *models.Game) (*EndGameOutput, error) {
+	if !game.IsReadyToComplete() {
+		return nil, nil
+	}
+
+	return s.EndGame(ctx, &EndGameInput{
+		Game: game,
+	})
 }
 
 // AssignDrink records that one player has assigned a drink to another
@@ -638,93 +1018,123 @@ func (s *service) AssignDrink(ctx context.Context, input *AssignDrinkInput) (*As
 		return nil, errors.New("to player ID cannot be empty")
 	}
 
-	// Get the game
-	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-		GameID: input.GameID,
-	})
-	if err != nil {
-		return nil, ErrGameNotFound
-	}
+	// A roll recorded after the game is read has the assignment made again on the game as it is now
+	var game *models.Game
+	var drinkInput *ledgerRepo.CreateDrinkRecordInput
+	var drinkOutput *ledgerRepo.CreateDrinkRecordOutput
+	var karmaDrink *ledgerRepo.CreateDrinkRecordInput
+	err := retryOnGameChange(func() error {
+		// Get the game
+		var err error
+		game, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: input.GameID,
+		})
+		if err != nil {
+			return ErrGameNotFound
+		}
 
-	// Check if game is active or waiting
-	if game.Status != models.GameStatusActive && game.Status != models.GameStatusRollOff && game.Status != models.GameStatusWaiting {
-		return nil, ErrInvalidGameState
-	}
+		// Check if game is being played
+		if game.Status == models.GameStatusWaiting {
+			return ErrGameNotStarted
+		}
+		if !s.lifecycle.IsPlaying(game.Status) {
+			return ErrInvalidGameState
+		}
 
-	// Find the assigning participant in the game
-	assigningParticipant := game.GetParticipant(input.FromPlayerID)
-	if assigningParticipant == nil {
-		return nil, ErrPlayerNotInGame
-	}
+		// Find the assigning participant in the game
+		assigningParticipant := game.GetParticipant(input.FromPlayerID)
+		if assigningParticipant == nil {
+			return ErrPlayerNotInGame
+		}
 
-	// Check if the assigning participant is allowed to assign a drink
-	if assigningParticipant.Status != models.ParticipantStatusNeedsToAssign {
-		return nil, errors.New("player is not eligible to assign a drink")
-	}
+		// Hot seat players' drinks are given by the player running their hot seat
+		if host := models.HotSeatHost(assigningParticipant.PlayerID); host != "" && host != input.HostID {
+			return ErrNotHotSeatHost
+		}
 
-	// Find the target participant in the game
-	targetParticipant := game.GetParticipant(input.ToPlayerID)
-	if targetParticipant == nil {
-		return nil, errors.New("target player is not in the game")
-	}
+		// Check if the assigning participant is allowed to assign a drink
+		if assigningParticipant.Status != models.ParticipantStatusNeedsToAssign {
+			return ErrNotEligibleToAssign
+		}
 
-	// Create a drink record using the repository
-	_, err = s.drinkLedgerRepo.CreateDrinkRecord(ctx, &ledgerRepo.CreateDrinkRecordInput{
-		GameID:       input.GameID,
-		FromPlayerID: input.FromPlayerID,
-		ToPlayerID:   input.ToPlayerID,
-		Reason:       models.DrinkReason(input.Reason),
-		Timestamp:    s.clock.Now(),
-		SessionID:    s.getSessionIDForChannel(ctx, game.ChannelID),
-	})
-	if err != nil {
-		return nil, err
-	}
+		// Find the target participant in the game
+		targetParticipant := game.GetParticipant(input.ToPlayerID)
+		if targetParticipant == nil {
+			return ErrTargetNotInGame
+		}
 
-	// Update the assigning participant's status
-	assigningParticipant.Status = models.ParticipantStatusActive
+		// On a player's birthday every critical hit's drink goes to them
+		if birthdayPlayers := s.birthdayPlayers(ctx, game, input.FromPlayerID); len(birthdayPlayers) > 0 && !hasPlayerOption(birthdayPlayers, input.ToPlayerID) {
+			return ErrMustTargetBirthday
+		}
 
-	// Update the game
-	game.UpdatedAt = s.clock.Now()
-	err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-		Game: game,
+		// Create a drink record using the repository
+		drinkInput = &ledgerRepo.CreateDrinkRecordInput{
+			GameID:       input.GameID,
+			FromPlayerID: input.FromPlayerID,
+			ToPlayerID:   input.ToPlayerID,
+			Reason:       models.DrinkReason(input.Reason),
+			Timestamp:    s.clock.Now(),
+		}
+		session := s.getSessionForGame(ctx, game)
+		karmaDrink = s.karmaDrink(ctx, game, session, input.FromPlayerID, input.ToPlayerID)
+
+		// Save the drink together with the assignment being used up, so it can't be recorded twice or not at all
+		return s.inTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			drinkOutput, err = s.createDrinkRecord(ctx, game, session, drinkInput)
+			if err != nil {
+				return err
+			}
+
+			// Karma comes back around on players who keep picking on the same player
+			if karmaDrink != nil {
+				if _, err := s.recordDrink(ctx, game, session, karmaDrink, 0); err != nil {
+					return err
+				}
+			}
+
+			// Update the assigning participant's status, a drink offered to a guest goes to the player instead
+			assigningParticipant.Status = models.ParticipantStatusActive
+			assigningParticipant.GuestOffer = nil
+
+			// Update the game
+			game.UpdatedAt = s.clock.Now()
+			return s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+				Game: game,
+			})
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if all players have completed their actions and the game can be ended
-	allPlayersRolled := true
-	allDrinksAssigned := true
-	for _, participant := range game.Participants {
-		if participant.RollTime == nil {
-			allPlayersRolled = false
-			break
-		}
-
-		if participant.Status == models.ParticipantStatusNeedsToAssign {
-			allDrinksAssigned = false
-			break
-		}
+	// End the game if this assignment was the last thing it was waiting on
+	endGameOutput, err := s.completeIfReady(ctx, game)
+	if err != nil {
+		// Log the error but don't return it to the caller
+		log.Printf("Error ending game after drink assignment: %v", err)
 	}
 
-	// If all players have rolled and all drinks are assigned, attempt to end the game
-	var endGameOutput *EndGameOutput
-	if allPlayersRolled && allDrinksAssigned {
-		endGameOutput, err = s.EndGame(ctx, &EndGameInput{
-			Game: game,
-		})
-		if err == nil {
-		} else {
-			// Log the error but don't return it to the caller
-			log.Printf("Error ending game after drink assignment: %v", err)
+	// Ronnie plays in any roll-off the game ended in
+	s.scheduleRonnie(game)
+
+	// Ronnie can't drink, someone drinks his drink for him
+	ronnieDrinkTo := ""
+	if models.IsRonnie(input.ToPlayerID) && drinkInput.ToPlayerID != input.ToPlayerID {
+		if participant := game.GetParticipant(drinkInput.ToPlayerID); participant != nil {
+			ronnieDrinkTo = participant.PlayerName
 		}
 	}
 
 	return &AssignDrinkOutput{
-		Success:       true,
-		GameEnded:     allPlayersRolled && allDrinksAssigned,
-		EndGameOutput: endGameOutput,
+		Success:             true,
+		HappyHourMultiplier: drinkInput.HappyHourMultiplier,
+		OnTheHouse:          drinkOutput.Record == nil,
+		KarmaDrink:          karmaDrink != nil,
+		RonnieDrinkTo:       ronnieDrinkTo,
+		GameEnded:           endGameOutput != nil && endGameOutput.Success,
+		EndGameOutput:       endGameOutput,
 	}, nil
 }
 
@@ -748,27 +1158,36 @@ func (s *service) EndGame(ctx context.Context, input *EndGameInput) (*EndGameOut
 		}
 	}
 
-	// Check if game is active
-	if game.Status != models.GameStatusActive && game.Status != models.GameStatusRollOff {
+	// Check if game is being played
+	if !s.lifecycle.IsPlaying(game.Status) {
 		return nil, ErrInvalidGameState
 	}
 
-	// For roll-off games, we always mark them as completed when EndGame is called
-	if isRollOffGame {
-		game.Status = models.GameStatusCompleted
-	}
-
 	// Check if all participants have completed their actions
 	for _, participant := range game.Participants {
 		// Check if everyone has rolled
 		if participant.RollTime == nil {
-			return nil, errors.New("not all players have rolled yet")
+			return nil, ErrPlayersStillRolling
 		}
 
 		// Check if anyone still needs to assign a drink
 		if participant.Status == models.ParticipantStatusNeedsToAssign {
-			return nil, errors.New("some players still need to assign drinks")
+			return nil, ErrPendingDrinkAssignments
+		}
+	}
+
+	// Rounds games go on to the next round with the same players, the last one ends like any other game
+	if game.Rounds != nil {
+		game.Rounds.Record(game.Participants)
+		if !game.Rounds.IsLastRound() {
+			return s.nextRound(ctx, game)
 		}
+		s.giveRoundsLoserDrinks(ctx, game)
+	}
+
+	// Sudden death goes on while every player is tied, each tie adding a drink to the pot
+	if game.SuddenDeath != nil && game.IsDeadHeat() {
+		return s.suddenDeathTie(ctx, game)
 	}
 
 	// Get drink records for this game
@@ -809,7 +1228,7 @@ func (s *service) EndGame(ctx context.Context, input *EndGameInput) (*EndGameOut
 	}
 
 	// Find players with the lowest roll
-	lowestRoll := s.diceSides + 1 // Start with a value higher than possible
+	lowestRoll := s.rulesFor(game).DiceSides + 1 // Start with a value higher than possible
 	lowestRollPlayerIDs := []string{}
 
 	// Find players with the highest roll
@@ -850,148 +1269,99 @@ func (s *service) EndGame(ctx context.Context, input *EndGameInput) (*EndGameOut
 	var lowestRollOffGameID string
 	var lowestRollOffPlayerIDs []string
 
-	// Check for ties with the highest roll (critical hits)
-	if len(highestRollPlayerIDs) > 1 {
-		// Multiple players tied for highest roll, create a roll-off game
+	// Ties are settled with roll-offs unless the guild has them turned off
+	rollOffs := s.featureEnabled(ctx, game.GuildID, models.FeatureFlagRollOffs)
 
-		// Create a map of player IDs to names for the roll-off game
-		playerNames := make(map[string]string)
-		for _, participant := range game.Participants {
-			for _, playerID := range highestRollPlayerIDs {
-				if participant.PlayerID == playerID {
-					playerNames[playerID] = participant.PlayerName
-					break
-				}
-			}
-		}
+	// Check for ties with the highest roll (critical hits) and the lowest
+	needsHighestRollOff = len(highestRollPlayerIDs) > 1 && rollOffs
+	needsLowestRollOff = len(lowestRollPlayerIDs) > 1 && rollOffs
 
-		// Create the roll-off game with the repository
-		rollOffGameOutput, err := s.gameRepo.CreateRollOffGame(ctx, &gameRepo.CreateRollOffGameInput{
-			ChannelID:    game.ChannelID,
-			CreatorID:    game.CreatorID,
-			ParentGameID: game.ID,
-			PlayerIDs:    highestRollPlayerIDs,
-			PlayerNames:  playerNames,
-		})
+	if !needsHighestRollOff && !needsLowestRollOff {
+		// If there's only one player with the lowest roll, or roll-offs are off, we can complete the game and
+		// assign a drink. Without roll-offs every tied player drinks.
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to create roll-off game for highest rollers: %w", err)
+		// Determine which game ID to use for the drink record
+		targetGameID := game.ID
+		if isRollOffGame {
+			// If this is a roll-off game, assign the drink to the game the roll-offs started from
+			targetGameID = s.rootGameID(ctx, parentGame)
 		}
 
-		// Update the parent game with the roll-off game ID
-		game.HighestRollOffGameID = rollOffGameOutput.Game.ID
-		game.RollOffGameID = rollOffGameOutput.Game.ID // For backward compatibility
-		game.Status = models.GameStatusRollOff
-		game.UpdatedAt = s.clock.Now()
-
-		// Update the players' current game ID
-		for _, playerID := range highestRollPlayerIDs {
-			player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-				PlayerID: playerID,
+		for _, lowestPlayerID := range lowestRollPlayerIDs {
+			// Create a drink record for the player with the lowest roll using the repository
+			_, err = s.createDrinkRecord(ctx, game, s.getSessionForGame(ctx, game), &ledgerRepo.CreateDrinkRecordInput{
+				GameID:     targetGameID,
+				ToPlayerID: lowestPlayerID,
+				Reason:     models.DrinkReasonLowestRoll,
+				Timestamp:  s.clock.Now(),
 			})
+
 			if err != nil {
-				return nil, err
+				log.Printf("Error saving lowest roll drink record: %v", err)
+				// Don't return the error, continue with ending the game
 			}
 
-			player.CurrentGameID = rollOffGameOutput.Game.ID
-
-			err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
-				Player: player,
-			})
-			if err != nil {
-				return nil, err
+			// The loser of a sudden death takes its pot too
+			if game.SuddenDeath != nil {
+				s.drinkSuddenDeathPot(ctx, game, targetGameID, lowestPlayerID)
 			}
 		}
-
-		// Store the highest roll-off information
-		needsHighestRollOff = true
-		highestRollOffGameID = rollOffGameOutput.Game.ID
-		highestRollOffPlayerIDs = highestRollPlayerIDs
 	}
 
-	// Check for lowest roll ties or single lowest roller
-	if len(lowestRollPlayerIDs) == 1 && !needsHighestRollOff {
-		// If there's only one player with the lowest roll and we don't need a highest roll-off,
-		// we can complete the game and assign a drink
-		lowestPlayerID := lowestRollPlayerIDs[0]
-
-		// Determine which game ID to use for the drink record
-		targetGameID := game.ID
-		if isRollOffGame {
-			// If this is a roll-off game, assign the drink to the parent game
-			targetGameID = game.ParentGameID
-		}
-
-		// Create a drink record for the player with the lowest roll using the repository
-		_, err = s.drinkLedgerRepo.CreateDrinkRecord(ctx, &ledgerRepo.CreateDrinkRecordInput{
-			GameID:     targetGameID,
-			ToPlayerID: lowestPlayerID,
-			Reason:     models.DrinkReasonLowestRoll,
-			Timestamp:  s.clock.Now(),
-			SessionID:  s.getSessionIDForChannel(ctx, game.ChannelID),
-		})
-
-		if err != nil {
-			log.Printf("Error saving lowest roll drink record: %v", err)
-			// Don't return the error, continue with ending the game
-		}
-	} else if len(lowestRollPlayerIDs) > 1 {
-		// Multiple players tied for lowest roll, create a roll-off game
-		// Only create a lowest roll-off if we don't already have a highest roll-off
-		// This matches the current test expectations
-
-		// Create a map of player IDs to names for the roll-off game
-		playerNames := make(map[string]string)
-		for _, participant := range game.Participants {
-			for _, playerID := range lowestRollPlayerIDs {
-				if participant.PlayerID == playerID {
-					playerNames[playerID] = participant.PlayerName
-					break
-				}
+	// Create the roll-offs, move their players to them and save the game together, so a failure can't leave
+	// players in a roll-off the game doesn't know about
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if needsHighestRollOff {
+			rollOffGame, err := s.startRollOff(ctx, game, highestRollPlayerIDs)
+			if err != nil {
+				return fmt.Errorf("failed to create roll-off game for highest rollers: %w", err)
 			}
-		}
 
-		// Create the roll-off game with the repository
-		rollOffGameOutput, err := s.gameRepo.CreateRollOffGame(ctx, &gameRepo.CreateRollOffGameInput{
-			ChannelID:    game.ChannelID,
-			CreatorID:    game.CreatorID,
-			ParentGameID: game.ID,
-			PlayerIDs:    lowestRollPlayerIDs,
-			PlayerNames:  playerNames,
-		})
+			// Update the parent game with the roll-off game ID
+			game.HighestRollOffGameID = rollOffGame.ID
+			game.RollOffGameID = rollOffGame.ID // For backward compatibility
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to create roll-off game for lowest rollers: %w", err)
+			// Store the highest roll-off information
+			highestRollOffGameID = rollOffGame.ID
+			highestRollOffPlayerIDs = highestRollPlayerIDs
 		}
 
-		// Update the parent game with the roll-off game ID
-		game.LowestRollOffGameID = rollOffGameOutput.Game.ID
-		game.RollOffGameID = rollOffGameOutput.Game.ID // For backward compatibility
-		game.Status = models.GameStatusRollOff
-		game.UpdatedAt = s.clock.Now()
-		// Update the players' current game ID
-		for _, playerID := range lowestRollPlayerIDs {
-			player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-				PlayerID: playerID,
-			})
+		if needsLowestRollOff {
+			rollOffGame, err := s.startRollOff(ctx, game, lowestRollPlayerIDs)
 			if err != nil {
-				return nil, err
+				return fmt.Errorf("failed to create roll-off game for lowest rollers: %w", err)
 			}
 
-			player.CurrentGameID = rollOffGameOutput.Game.ID
+			// Update the parent game with the roll-off game ID
+			game.LowestRollOffGameID = rollOffGame.ID
+			game.RollOffGameID = rollOffGame.ID // For backward compatibility
 
-			err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
-				Player: player,
-			})
-			if err != nil {
-				return nil, err
-			}
+			// Store the lowest roll-off information
+			lowestRollOffGameID = rollOffGame.ID
+			lowestRollOffPlayerIDs = lowestRollPlayerIDs
+		}
+
+		// The game is completed if no roll-offs are needed, otherwise it waits on them
+		status := models.GameStatusCompleted
+		if needsHighestRollOff || needsLowestRollOff {
+			status = models.GameStatusRollOff
 		}
+		if err := s.lifecycle.Transition(game, status, s.clock.Now()); err != nil {
+			return err
+		}
+
+		// Save the updated game
+		return s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		// Store the lowest roll-off information
-		needsLowestRollOff = true
-		lowestRollOffGameID = rollOffGameOutput.Game.ID
-		lowestRollOffPlayerIDs = lowestRollPlayerIDs
+	// A tie puts a drink in the jackpot, in games played for it
+	if needsHighestRollOff || needsLowestRollOff {
+		s.addToJackpot(ctx, game, nil)
 	}
 
 	// Convert map to slice for output
@@ -1000,116 +1370,319 @@ func (s *service) EndGame(ctx context.Context, input *EndGameInput) (*EndGameOut
 		playerStats = append(playerStats, stats)
 	}
 
-	// Update game status to completed if no roll-offs are needed
+	// Prepare the output
+	output := &EndGameOutput{
+		Success:                 !needsHighestRollOff && !needsLowestRollOff,
+		FinalLeaderboard:        playerStats,
+		NeedsHighestRollOff:     needsHighestRollOff,
+		HighestRollOffGameID:    highestRollOffGameID,
+		HighestRollOffPlayerIDs: highestRollOffPlayerIDs,
+		NeedsLowestRollOff:      needsLowestRollOff,
+		LowestRollOffGameID:     lowestRollOffGameID,
+		LowestRollOffPlayerIDs:  lowestRollOffPlayerIDs,
+	}
+
+	// Set backward compatibility fields
+	if needsHighestRollOff {
+		output.NeedsRollOff = true
+		output.RollOffType = RollOffTypeHighest
+		output.RollOffGameID = highestRollOffGameID
+		output.RollOffPlayerIDs = highestRollOffPlayerIDs
+	} else if needsLowestRollOff {
+		output.NeedsRollOff = true
+		output.RollOffType = RollOffTypeLowest
+		output.RollOffGameID = lowestRollOffGameID
+		output.RollOffPlayerIDs = lowestRollOffPlayerIDs
+	}
+
+	// Get the session ID for the channel
+	sessionID := s.getSessionIDForGame(ctx, game)
+	output.SessionID = sessionID
+
+	// Only finish off the game if it's actually ending (no roll-offs needed), in the background when there's a
+	// job queue so the roll that ended it returns quickly
 	if !needsHighestRollOff && !needsLowestRollOff {
-		game.Status = models.GameStatusCompleted
-		game.UpdatedAt = s.clock.Now()
+		if s.queuePostGame(ctx, game, sessionID) {
+			output.PostGameQueued = true
+		} else {
+			output.SessionLeaderboard, _ = s.finishGame(ctx, game, parentGame, playerResultsFromStats(playerStats), sessionID)
+		}
+	}
 
-		// Save the updated game
-		err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-			Game: game,
+	return output, nil
+}
+
+// startRollOff creates a roll-off of a game for the tied players and moves them to it
+func (s *service) startRollOff(ctx context.Context, game *models.Game, playerIDs []string) (*models.Game, error) {
+	rollOffGameOutput, err := s.gameRepo.CreateRollOffGame(ctx, &gameRepo.CreateRollOffGameInput{
+		ChannelID:    game.ChannelID,
+		GuildID:      game.GuildID,
+		CreatorID:    game.CreatorID,
+		ParentGameID: game.ID,
+		PlayerIDs:    playerIDs,
+		PlayerNames:  getPlayerNames(game.Participants, playerIDs),
+		Rules:        game.Rules,
+		Events:       game.Events,
+		LastCall:     game.LastCall,
+		SuddenDeath:  s.suddenDeathFor(game),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the players' current game ID
+	for _, playerID := range playerIDs {
+		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+			PlayerID: playerID,
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		// If this is a roll-off game, update the parent game as well
-		if isRollOffGame && parentGame != nil {
-			// Check if the parent game has any other active roll-offs
-			hasOtherActiveRollOffs := false
+		player.CurrentGameID = rollOffGameOutput.Game.ID
 
-			// If the parent game has a highest roll-off that's not this game
-			if parentGame.HighestRollOffGameID != "" && parentGame.HighestRollOffGameID != game.ID {
-				// Check if that roll-off is still active
-				highestRollOffGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-					GameID: parentGame.HighestRollOffGameID,
-				})
-				if err == nil && highestRollOffGame.Status != models.GameStatusCompleted {
-					hasOtherActiveRollOffs = true
-				}
-			}
+		err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+			Player: player,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			// If the parent game has a lowest roll-off that's not this game
-			if parentGame.LowestRollOffGameID != "" && parentGame.LowestRollOffGameID != game.ID {
-				// Check if that roll-off is still active
-				lowestRollOffGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-					GameID: parentGame.LowestRollOffGameID,
-				})
-				if err == nil && lowestRollOffGame.Status != models.GameStatusCompleted {
-					hasOtherActiveRollOffs = true
-				}
-			}
+	return rollOffGameOutput.Game, nil
+}
+
+// completeRollOffParents completes the games above a finished roll-off once none of their roll-offs are still
+// being played, walking up through nested roll-offs. It returns the top-level game if it was completed.
+func (s *service) completeRollOffParents(ctx context.Context, rollOffGame *models.Game, parentGame *models.Game) *models.Game {
+	for parentGame != nil {
+		if s.hasOtherActiveRollOffs(ctx, parentGame, rollOffGame.ID) {
+			return nil
+		}
+
+		err := s.lifecycle.Transition(parentGame, models.GameStatusCompleted, s.clock.Now())
+		if err != nil {
+			log.Printf("Error completing parent game %s: %v", parentGame.ID, err)
+			return nil
+		}
+
+		// Save the updated parent game
+		err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: parentGame,
+		})
+		if err != nil {
+			log.Printf("Error updating parent game status: %v", err)
+			// Don't return the error, continue with ending the game
+			return nil
+		}
+
+		if parentGame.ParentGameID == "" {
+			return parentGame
+		}
+
+		// The parent was itself a roll-off, move up a level
+		rollOffGame = parentGame
+		parentGame, err = s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: parentGame.ParentGameID,
+		})
+		if err != nil {
+			log.Printf("Error getting parent game %s: %v", rollOffGame.ParentGameID, err)
+			return nil
+		}
+	}
 
-			// If there are no other active roll-offs, mark the parent game as completed
-			if !hasOtherActiveRollOffs {
-				parentGame.Status = models.GameStatusCompleted
-				parentGame.UpdatedAt = s.clock.Now()
+	return nil
+}
 
-				// Save the updated parent game
-				err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-					Game: parentGame,
-				})
-				if err != nil {
-					log.Printf("Error updating parent game status: %v", err)
-					// Don't return the error, continue with ending the game
-				}
-			}
+// hasOtherActiveRollOffs checks whether the game has a roll-off other than the given one still being played
+func (s *service) hasOtherActiveRollOffs(ctx context.Context, game *models.Game, rollOffGameID string) bool {
+	for _, otherID := range []string{game.HighestRollOffGameID, game.LowestRollOffGameID} {
+		if otherID == "" || otherID == rollOffGameID {
+			continue
 		}
-	} else {
-		// If there are roll-offs, mark the game as roll-off
-		game.Status = models.GameStatusRollOff
-		game.UpdatedAt = s.clock.Now()
 
-		// Save the updated game
-		err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-			Game: game,
+		otherGame, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: otherID,
+		})
+		if err == nil && !otherGame.Status.IsFinished() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rootGameID returns the ID of the top-level game a roll-off parent belongs to
+func (s *service) rootGameID(ctx context.Context, parentGame *models.Game) string {
+	game := parentGame
+	for game.ParentGameID != "" {
+		next, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: game.ParentGameID,
 		})
 		if err != nil {
-			return nil, err
+			log.Printf("Error getting parent game %s: %v", game.ParentGameID, err)
+			return game.ParentGameID
 		}
+		game = next
 	}
 
-	// Prepare the output
-	output := &EndGameOutput{
-		Success:                 !needsHighestRollOff && !needsLowestRollOff,
-		FinalLeaderboard:        playerStats,
-		NeedsHighestRollOff:     needsHighestRollOff,
-		HighestRollOffGameID:    highestRollOffGameID,
-		HighestRollOffPlayerIDs: highestRollOffPlayerIDs,
-		NeedsLowestRollOff:      needsLowestRollOff,
-		LowestRollOffGameID:     lowestRollOffGameID,
-		LowestRollOffPlayerIDs:  lowestRollOffPlayerIDs,
+	return game.ID
+}
+
+// playerResultsFromStats converts end of game stats into event results
+func playerResultsFromStats(playerStats []*PlayerStats) []*events.PlayerResult {
+	results := make([]*events.PlayerResult, 0, len(playerStats))
+	for _, stats := range playerStats {
+		results = append(results, &events.PlayerResult{
+			PlayerID:       stats.PlayerID,
+			PlayerName:     stats.PlayerName,
+			RollValue:      stats.LastRoll,
+			DrinksAssigned: stats.DrinksAssigned,
+			DrinksReceived: stats.DrinksReceived,
+		})
 	}
 
-	// Set backward compatibility fields
-	if needsHighestRollOff {
-		output.NeedsRollOff = true
-		output.RollOffType = RollOffTypeHighest
-		output.RollOffGameID = highestRollOffGameID
-		output.RollOffPlayerIDs = highestRollOffPlayerIDs
-	} else if needsLowestRollOff {
-		output.NeedsRollOff = true
-		output.RollOffType = RollOffTypeLowest
-		output.RollOffGameID = lowestRollOffGameID
-		output.RollOffPlayerIDs = lowestRollOffPlayerIDs
+	return results
+}
+
+// getPlayerResults builds event results for a game from its participants and drink records
+func (s *service) getPlayerResults(ctx context.Context, game *models.Game) []*events.PlayerResult {
+	resultsByPlayer := make(map[string]*events.PlayerResult)
+	results := make([]*events.PlayerResult, 0, len(game.Participants))
+	for _, participant := range game.Participants {
+		result := &events.PlayerResult{
+			PlayerID:   participant.PlayerID,
+			PlayerName: participant.PlayerName,
+			RollValue:  participant.RollValue,
+		}
+		resultsByPlayer[participant.PlayerID] = result
+		results = append(results, result)
 	}
 
-	// Get the session ID for the channel
-	sessionID := s.getSessionIDForChannel(ctx, game.ChannelID)
-	output.SessionID = sessionID
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForGame(ctx, &ledgerRepo.GetDrinkRecordsForGameInput{
+		GameID: game.ID,
+	})
+	if err != nil {
+		log.Printf("Error getting drink records for game results: %v", err)
+		return results
+	}
 
-	// Only fetch the session leaderboard if the game is actually ending (no roll-offs needed)
-	if !needsHighestRollOff && !needsLowestRollOff {
-		// Get the session leaderboard
-		sessionLeaderboardOutput, err := s.GetSessionLeaderboard(ctx, &GetSessionLeaderboardInput{
-			SessionID: sessionID,
-		})
-		if err == nil && sessionLeaderboardOutput != nil {
-			output.SessionLeaderboard = sessionLeaderboardOutput.Entries
+	for _, record := range drinkRecords.Records {
+		if result, ok := resultsByPlayer[record.FromPlayerID]; ok {
+			result.DrinksAssigned++
+		}
+		if result, ok := resultsByPlayer[record.ToPlayerID]; ok {
+			result.DrinksReceived++
 		}
 	}
 
-	return output, nil
+	return results
+}
+
+// publishDiceRolled lets subscribers (the public roll feed, etc.) know a player rolled
+func (s *service) publishDiceRolled(ctx context.Context, game *models.Game, participant *models.Participant, isCriticalHit, isCriticalFail bool) {
+	rootGameID := game.ID
+	if game.ParentGameID != "" {
+		rootGameID = game.ParentGameID
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeDiceRolled,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.DiceRolledPayload{
+			PlayerID:     participant.PlayerID,
+			PlayerName:   participant.PlayerName,
+			RollValue:    participant.RollValue,
+			CriticalHit:  isCriticalHit,
+			CriticalFail: isCriticalFail,
+			RollOff:      game.ParentGameID != "",
+			Reroll:       len(participant.VoidedRolls) > 0,
+			RootGameID:   rootGameID,
+		},
+	})
+}
+
+// publishGameCreated lets subscribers (analytics, etc.) know a game was created
+func (s *service) publishGameCreated(ctx context.Context, game *models.Game, creatorName string) {
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeGameCreated,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.GameCreatedPayload{
+			CreatorID:   game.CreatorID,
+			CreatorName: creatorName,
+			Countdown:   game.Countdown,
+			Private:     game.Private,
+			LastCall:    game.LastCall,
+			Events:      game.Events,
+		},
+	})
+}
+
+// publishDrinkAssigned lets subscribers (analytics, etc.) know a drink was recorded in the game
+func (s *service) publishDrinkAssigned(ctx context.Context, game *models.Game, input *ledgerRepo.CreateDrinkRecordInput) {
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeDrinkAssigned,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		SessionID: input.SessionID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.DrinkAssignedPayload{
+			FromPlayerID: input.FromPlayerID,
+			ToPlayerID:   input.ToPlayerID,
+			Reason:       string(input.Reason),
+			Social:       input.Social,
+		},
+	})
+}
+
+// publishLedgerChanged lets subscribers (pinned leaderboards, etc.) know the drinks in a session changed
+func (s *service) publishLedgerChanged(ctx context.Context, guildID, channelID, sessionID, change string) {
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeLedgerChanged,
+		ChannelID: channelID,
+		GuildID:   guildID,
+		SessionID: sessionID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.LedgerChangedPayload{
+			Change: change,
+		},
+	})
+}
+
+// publishGameCompleted publishes the game completed and session leaderboard events
+func (s *service) publishGameCompleted(ctx context.Context, game *models.Game, results []*events.PlayerResult, sessionID string, sessionLeaderboard []LeaderboardEntry) {
+	now := s.clock.Now()
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeGameCompleted,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		SessionID: sessionID,
+		Timestamp: now,
+		Payload: &events.GameCompletedPayload{
+			Results: results,
+		},
+	})
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeSessionLeaderboard,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		SessionID: sessionID,
+		Timestamp: now,
+		Payload: &events.SessionLeaderboardPayload{
+			Standings: sessionStandings(sessionLeaderboard),
+		},
+	})
 }
 
 // HandleRollOff manages roll-offs for tied players
@@ -1132,7 +1705,7 @@ func (s *service) HandleRollOff(ctx context.Context, input *HandleRollOffInput)
 	}
 
 	if input.Type != RollOffTypeHighest && input.Type != RollOffTypeLowest {
-		return nil, errors.New("invalid roll-off type")
+		return nil, ErrInvalidRollOffType
 	}
 
 	// Get the roll-off game
@@ -1156,7 +1729,7 @@ func (s *service) HandleRollOff(ctx context.Context, input *HandleRollOffInput)
 	// Check if all players in the roll-off have rolled
 	allRolled := true
 	var highestValue int
-	var lowestValue int = s.diceSides + 1 // Initialize to a value higher than possible
+	var lowestValue int = s.rulesFor(rollOffGame).DiceSides + 1 // Initialize to a value higher than possible
 
 	// Track players with highest/lowest rolls
 	highestPlayers := []string{}
@@ -1253,10 +1826,15 @@ func (s *service) HandleRollOff(ctx context.Context, input *HandleRollOffInput)
 		// Create the roll-off game with the repository
 		rollOffGameOutput, err := s.gameRepo.CreateRollOffGame(ctx, &gameRepo.CreateRollOffGameInput{
 			ChannelID:    rollOffGame.ChannelID,
+			GuildID:      rollOffGame.GuildID,
 			CreatorID:    rollOffGame.CreatorID,
 			ParentGameID: input.ParentGameID, // Keep the original parent
 			PlayerIDs:    winners,
 			PlayerNames:  getPlayerNames(rollOffGame.Participants, winners),
+			Rules:        rollOffGame.Rules,
+			Events:       rollOffGame.Events,
+			LastCall:     rollOffGame.LastCall,
+			SuddenDeath:  s.suddenDeathFor(rollOffGame),
 		})
 
 		if err != nil {
@@ -1271,10 +1849,11 @@ func (s *service) HandleRollOff(ctx context.Context, input *HandleRollOffInput)
 			// Assign drinks to the losers
 			for _, loserID := range winners {
 				// Create a new drink record using the repository
-				_, drinkErr := s.drinkLedgerRepo.CreateDrinkRecord(ctx, &ledgerRepo.CreateDrinkRecordInput{
+				_, drinkErr := s.createDrinkRecord(ctx, rollOffGame, s.getSessionForGame(ctx, rollOffGame), &ledgerRepo.CreateDrinkRecordInput{
 					GameID:     input.ParentGameID,
 					ToPlayerID: loserID,
 					Reason:     models.DrinkReasonLowestRoll,
+					Timestamp:  s.clock.Now(),
 				})
 
 				if drinkErr != nil {
@@ -1284,8 +1863,10 @@ func (s *service) HandleRollOff(ctx context.Context, input *HandleRollOffInput)
 		}
 
 		// Update the roll-off game status to completed
-		rollOffGame.Status = models.GameStatusCompleted
-		rollOffGame.UpdatedAt = s.clock.Now()
+		err = s.lifecycle.Transition(rollOffGame, models.GameStatusCompleted, s.clock.Now())
+		if err != nil {
+			return nil, err
+		}
 
 		// Save the updated roll-off game
 		err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
@@ -1372,6 +1953,7 @@ func (s *service) GetGameByChannel(ctx context.Context, input *GetGameByChannelI
 	// Get the game from the repository
 	game, err := s.gameRepo.GetGameByChannel(ctx, &gameRepo.GetGameByChannelInput{
 		ChannelID: input.ChannelID,
+		GuildID:   input.GuildID,
 	})
 	if err != nil {
 		// If it's a "game not found" error, return our service-level error
@@ -1387,12 +1969,58 @@ func (s *service) GetGameByChannel(ctx context.Context, input *GetGameByChannelI
 	}, nil
 }
 
+// GetActiveGameByChannel retrieves the waiting, active or roll-off game in a channel.
+// A channel's roll-offs are played through the game they came from, so that's the game returned.
+func (s *service) GetActiveGameByChannel(ctx context.Context, input *GetActiveGameByChannelInput) (*GetActiveGameByChannelOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	game, err := s.gameRepo.GetActiveGameByChannel(ctx, &gameRepo.GetActiveGameByChannelInput{
+		ChannelID: input.ChannelID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get active game by channel: %w", err)
+	}
+
+	return &GetActiveGameByChannelOutput{
+		Game: game,
+	}, nil
+}
+
+// GetLatestCompletedGameByChannel retrieves the last game finished in a channel
+func (s *service) GetLatestCompletedGameByChannel(ctx context.Context, input *GetLatestCompletedGameByChannelInput) (*GetLatestCompletedGameByChannelOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	game, err := s.gameRepo.GetLatestCompletedGameByChannel(ctx, &gameRepo.GetLatestCompletedGameByChannelInput{
+		ChannelID: input.ChannelID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get completed game by channel: %w", err)
+	}
+
+	return &GetLatestCompletedGameByChannelOutput{
+		Game: game,
+	}, nil
+}
+
 // GetLeaderboard retrieves the leaderboard for a game
 func (s *service) GetLeaderboard(ctx context.Context, input *GetLeaderboardInput) (*GetLeaderboardOutput, error) {
 	if input == nil || input.GameID == "" {
 		return nil, errors.New("game ID is required")
 	}
 
+	// Leaderboards are read from a replica if there is one
+	ctx = replica.WithReplicaReads(ctx)
+
 	// Get the game to access participant information
 	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
 		GameID: input.GameID,
@@ -1409,159 +2037,173 @@ func (s *service) GetLeaderboard(ctx context.Context, input *GetLeaderboardInput
 		return nil, fmt.Errorf("failed to get drink records: %w", err)
 	}
 
-	// Build maps to track drinks and payment status
-	drinkCounts := make(map[string]int) // Total drinks owed
-	paidCounts := make(map[string]int)  // Drinks paid
+	return &GetLeaderboardOutput{
+		GameID:  input.GameID,
+		Entries: buildGameLeaderboard(game, drinkRecords.Records, s.playerNames(ctx, nil)),
+	}, nil
+}
 
-	// Process all drink records
-	for _, record := range drinkRecords.Records {
-		drinkCounts[record.ToPlayerID]++
-		if record.Paid {
-			paidCounts[record.ToPlayerID]++
-		}
+// AbandonGame forcefully abandons a game regardless of its state. Players who don't host the game can only
+// abandon it once it's been left idle.
+func (s *service) AbandonGame(ctx context.Context, input *AbandonGameInput) (*AbandonGameOutput, error) {
+	// Get the game
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
 	}
 
-	// Create a map of player IDs to their information
-	playerMap := make(map[string]*LeaderboardEntry)
+	// Abandoning a game twice changes nothing, and a completed game is already over
+	if game.Status.IsAbandoned() {
+		return &AbandonGameOutput{
+			Success: true,
+		}, nil
+	}
+	if game.Status.IsCompleted() {
+		return nil, ErrGameCompleted
+	}
 
-	// First, add all participants from the game
-	for _, participant := range game.Participants {
-		totalDrinks := drinkCounts[participant.PlayerID]
-		paidDrinks := paidCounts[participant.PlayerID]
+	// Only the hosts can abandon a game, unless it's been left untouched
+	if input.PlayerID != "" && !game.IsHost(input.PlayerID) && s.clock.Now().Sub(game.UpdatedAt) < idleGameAge {
+		return nil, fmt.Errorf("%w: only the hosts can abandon a game played in the last %v", ErrNotCreator, idleGameAge)
+	}
 
-		playerMap[participant.PlayerID] = &LeaderboardEntry{
-			PlayerID:   participant.PlayerID,
-			PlayerName: participant.PlayerName,
-			DrinkCount: totalDrinks,
-			PaidCount:  paidDrinks,
+	// Archive the game and its roll-offs and free its players together, so a failure can't leave players stuck
+	// in a game that's over or a game abandoned with its players still in it
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		// Archive the game and any roll-offs still being played, keeping them and their drinks as history
+		if err := s.archiveGame(ctx, game); err != nil {
+			return err
 		}
-	}
 
-	// Then add any players who have drinks but aren't in the game anymore
-	for playerID := range drinkCounts {
-		if _, exists := playerMap[playerID]; !exists {
-			// Get player name
+		// Clear the CurrentGameID for all players in this game
+		for _, participant := range game.Participants {
+			// Get the player
 			player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-				PlayerID: playerID,
+				PlayerID: participant.PlayerID,
 			})
-
-			totalDrinks := drinkCounts[playerID]
-			paidDrinks := paidCounts[playerID]
-
-			playerName := "Unknown Player"
-			if err == nil {
-				playerName = player.Name
+			if err != nil {
+				// Log the error but continue with other players
+				log.Printf("Error getting player %s: %v", participant.PlayerID, err)
+				continue
 			}
 
-			playerMap[playerID] = &LeaderboardEntry{
-				PlayerID:   playerID,
-				PlayerName: playerName,
-				DrinkCount: totalDrinks,
-				PaidCount:  paidDrinks,
+			// Only update if this is the player's current game
+			if player.CurrentGameID == input.GameID {
+				// Clear the current game ID
+				player.CurrentGameID = ""
+
+				// Save the updated player
+				err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+					Player: player,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to update player %s: %w", participant.PlayerID, err)
+				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert the map to a slice
-	var entries []LeaderboardEntry
-	for _, entry := range playerMap {
-		entries = append(entries, *entry)
-	}
-
-	return &GetLeaderboardOutput{
-		GameID:  input.GameID,
-		Entries: entries,
+	return &AbandonGameOutput{
+		Success: true,
 	}, nil
 }
 
-// AbandonGame forcefully abandons a game regardless of its state
-func (s *service) AbandonGame(ctx context.Context, input *AbandonGameInput) (*AbandonGameOutput, error) {
-	// Get the game
-	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-		GameID: input.GameID,
-	})
-	if err != nil {
-		return nil, ErrGameNotFound
+// archiveGame marks a game and its unfinished roll-offs as abandoned
+func (s *service) archiveGame(ctx context.Context, game *models.Game) error {
+	if err := s.lifecycle.Transition(game, models.GameStatusAbandoned, s.clock.Now()); err != nil {
+		return err
 	}
 
-	// Update game status to completed regardless of current state
-	game.Status = models.GameStatusCompleted
-	game.UpdatedAt = s.clock.Now()
-
-	// Save the updated game
-	err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
 		Game: game,
+	}); err != nil {
+		return err
+	}
+
+	rollOffGames, err := s.gameRepo.GetGamesByParent(ctx, &gameRepo.GetGamesByParentInput{
+		ParentGameID: game.ID,
 	})
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to get roll-off games: %w", err)
 	}
 
-	// Clear the CurrentGameID for all players in this game
-	for _, participant := range game.Participants {
-		// Get the player
-		player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-			PlayerID: participant.PlayerID,
-		})
-		if err != nil {
-			// Log the error but continue with other players
-			log.Printf("Error getting player %s: %v", participant.PlayerID, err)
+	for _, rollOffGame := range rollOffGames {
+		if rollOffGame.Status.IsFinished() {
 			continue
 		}
+		if err := s.archiveGame(ctx, rollOffGame); err != nil {
+			return err
+		}
+	}
 
-		// Only update if this is the player's current game
-		if player.CurrentGameID == input.GameID {
-			// Clear the current game ID
-			player.CurrentGameID = ""
+	return nil
+}
 
-			// Save the updated player
-			err = s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
-				Player: player,
-			})
-			if err != nil {
-				// Log the error but continue with other players
-				log.Printf("Error updating player %s: %v", participant.PlayerID, err)
-			}
-		}
+// PurgeArchivedGames permanently deletes the games abandoned before a time, leaving their drink records in place
+func (s *service) PurgeArchivedGames(ctx context.Context, input *PurgeArchivedGamesInput) (*PurgeArchivedGamesOutput, error) {
+	if input == nil || input.ArchivedBefore.IsZero() {
+		return nil, errors.New("archived before time is required")
 	}
 
-	// Delete the game to clean up all Redis keys including channel mapping
-	// This is more reliable than just updating the status
-	err = s.gameRepo.DeleteGame(ctx, &gameRepo.DeleteGameInput{
-		GameID: input.GameID,
+	archived, err := s.gameRepo.GetArchivedGames(ctx, &gameRepo.GetArchivedGamesInput{
+		ArchivedBefore: input.ArchivedBefore,
 	})
 	if err != nil {
-		log.Printf("Warning: Failed to delete game %s: %v", input.GameID, err)
-		// Continue anyway since we've already marked the game as completed
+		return nil, fmt.Errorf("failed to get archived games: %w", err)
 	}
 
-	return &AbandonGameOutput{
-		Success: true,
-	}, nil
+	output := &PurgeArchivedGamesOutput{}
+	for _, gameID := range archived.GameIDs {
+		err := s.gameRepo.DeleteGame(ctx, &gameRepo.DeleteGameInput{
+			GameID: gameID,
+		})
+		if err != nil && !errors.Is(err, gameRepo.ErrGameNotFound) {
+			// Log the error but carry on with the other games
+			log.Printf("Error purging archived game %s: %v", gameID, err)
+			continue
+		}
+		output.DeletedCount++
+	}
+
+	return output, nil
 }
 
 // UpdateGameMessage updates the Discord message ID associated with a game
 func (s *service) UpdateGameMessage(ctx context.Context, input *UpdateGameMessageInput) (*UpdateGameMessageOutput, error) {
-	// Get the game
-	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-		GameID: input.GameID,
-	})
-	if err != nil {
-		if errors.Is(err, gameRepo.ErrGameNotFound) {
-			return nil, ErrGameNotFound
+	// Players join as soon as the game is posted, so the message ID is set on the game as it is by then
+	err := retryOnGameChange(func() error {
+		// Get the game
+		game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+			GameID: input.GameID,
+		})
+		if err != nil {
+			if errors.Is(err, gameRepo.ErrGameNotFound) {
+				return ErrGameNotFound
+			}
+			return fmt.Errorf("failed to get game: %w", err)
 		}
-		return nil, fmt.Errorf("failed to get game: %w", err)
-	}
 
-	// Update the message ID
-	game.MessageID = input.MessageID
-	game.UpdatedAt = s.clock.Now()
+		// Update the message ID
+		game.MessageID = input.MessageID
+		game.UpdatedAt = s.clock.Now()
 
-	// Save the updated game
-	err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
-		Game: game,
+		// Save the updated game
+		err = s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+			Game: game,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update game: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update game: %w", err)
+		return nil, err
 	}
 
 	return &UpdateGameMessageOutput{
@@ -1573,7 +2215,9 @@ func (s *service) UpdateGameMessage(ctx context.Context, input *UpdateGameMessag
 func (s *service) GetGame(ctx context.Context, input *GetGameInput) (*GetGameOutput, error) {
 	// Get the game from the repository
 	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
-		GameID: input.GameID,
+		GameID:     input.GameID,
+		GuildID:    input.GuildID,
+		CheckGuild: true,
 	})
 	if err != nil {
 		if errors.Is(err, gameRepo.ErrGameNotFound) {
@@ -1612,6 +2256,13 @@ func (s *service) GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*
 		return nil, errors.New("player ID is required")
 	}
 
+	if input.Page < 0 || input.PageSize < 0 {
+		return nil, errors.New("page and page size can't be negative")
+	}
+
+	// Tabs are read from a replica if there is one
+	ctx = replica.WithReplicaReads(ctx)
+
 	// Get the game
 	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
 		GameID: input.GameID,
@@ -1652,11 +2303,8 @@ func (s *service) GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*
 			fromPlayerName = player.Name
 		} else {
 			// Find the player in the game participants
-			for _, participant := range game.Participants {
-				if participant.PlayerID == record.FromPlayerID {
-					fromPlayerName = participant.PlayerName
-					break
-				}
+			if participant := game.GetParticipant(record.FromPlayerID); participant != nil {
+				fromPlayerName = participant.PlayerName
 			}
 
 			// If not found in participants, try to get from repository
@@ -1678,11 +2326,8 @@ func (s *service) GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*
 			toPlayerName = player.Name
 		} else {
 			// Find the player in the game participants
-			for _, participant := range game.Participants {
-				if participant.PlayerID == record.ToPlayerID {
-					toPlayerName = participant.PlayerName
-					break
-				}
+			if participant := game.GetParticipant(record.ToPlayerID); participant != nil {
+				toPlayerName = participant.PlayerName
 			}
 
 			// If not found in participants, try to get from repository
@@ -1700,26 +2345,38 @@ func (s *service) GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*
 
 		// Create a tab entry for this drink record
 		entry := &PlayerTabEntry{
-			FromPlayerID:   record.FromPlayerID,
-			FromPlayerName: fromPlayerName,
-			ToPlayerID:     record.ToPlayerID,
-			ToPlayerName:   toPlayerName,
-			Reason:         record.Reason,
-			Timestamp:      record.Timestamp,
-			Paid:           record.Paid,
-		}
-
-		// Add to the appropriate list
+			FromPlayerID:    record.FromPlayerID,
+			FromPlayerName:  fromPlayerName,
+			ToPlayerID:      record.ToPlayerID,
+			ToPlayerName:    toPlayerName,
+			Reason:          record.Reason,
+			Timestamp:       record.Timestamp,
+			Paid:            record.Paid,
+			Social:          record.Social,
+			Forgiven:        record.Forgiven,
+			TransferredFrom: record.TransferredFrom,
+			OwedLater:       record.OwedLater,
+			CoveredFor:      record.CoveredFor,
+			PaidWithCredit:  record.PaidWithCredit,
+		}
+
+		// Add to the appropriate list, social and forgiven drinks are listed but not owed.
+		// Drinks the filters leave out still count towards the totals.
+		listed := matchesTabFilters(input, record)
 		if record.ToPlayerID == player.ID {
-			tab.DrinksOwed = append(tab.DrinksOwed, entry)
-			if !record.Paid {
+			if listed {
+				tab.DrinksOwed = append(tab.DrinksOwed, entry)
+			}
+			if record.IsOutstanding() {
 				tab.TotalOwed++
 			}
 		}
 
 		if record.FromPlayerID == player.ID {
-			tab.DrinksAssigned = append(tab.DrinksAssigned, entry)
-			if !record.Paid {
+			if listed {
+				tab.DrinksAssigned = append(tab.DrinksAssigned, entry)
+			}
+			if record.IsOutstanding() {
 				tab.TotalAssigned++
 			}
 		}
@@ -1727,10 +2384,15 @@ func (s *service) GetPlayerTab(ctx context.Context, input *GetPlayerTabInput) (*
 
 	// Calculate net drinks
 	tab.NetDrinks = tab.TotalOwed - tab.TotalAssigned
+	tab.Credits = s.creditsFor(ctx, s.getSessionForGame(ctx, game), player.ID)
+
+	page, pageCount := pageTab(tab, input.Page, input.PageSize)
 
 	return &GetPlayerTabOutput{
-		Tab:  tab,
-		Game: game,
+		Tab:       tab,
+		Game:      game,
+		Page:      page,
+		PageCount: pageCount,
 	}, nil
 }
 
@@ -1760,8 +2422,8 @@ func (s *service) ResetGameTab(ctx context.Context, input *ResetGameTabInput) (*
 		return nil, fmt.Errorf("failed to get resetter: %w", err)
 	}
 
-	// Get the current leaderboard before resetting
-	leaderboardOutput, err := s.GetLeaderboard(ctx, &GetLeaderboardInput{
+	// Get the current leaderboard before resetting, from the primary so nothing reset is missed
+	leaderboardOutput, err := s.GetLeaderboard(replica.WithPrimaryReads(ctx), &GetLeaderboardInput{
 		GameID: input.GameID,
 	})
 	if err != nil {
@@ -1833,37 +2495,66 @@ func (s *service) PayDrink(ctx context.Context, input *PayDrinkInput) (*PayDrink
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
 
-	// Get the session ID from the game's channel
-	sessionID := s.getSessionIDForChannel(ctx, game.ChannelID)
-	if sessionID == "" {
+	// Get the session from the game's channel
+	session := s.getSessionForGame(ctx, game)
+	if session == nil {
 		return nil, fmt.Errorf("no active session found for channel")
 	}
 
 	// Get all drink records for this session
 	sessionDrinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
-		SessionID: sessionID,
+		SessionID: session.ID,
+		GuildID:   sessionScope(game.GuildID, game.ChannelID),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session drink records: %w", err)
 	}
 
+	now := s.clock.Now()
+
+	// Players who have been paying too fast wait out the cooldown
+	if s.pacing != nil && now.Before(s.pacingCooldownUntil(sessionDrinkRecords.Records, input.PlayerID)) {
+		return nil, ErrPacingCooldown
+	}
+
+	// Drinks given to a designated driver wait until they stop driving
+	driving := session.GetDesignatedDriver(input.PlayerID) != nil
+
 	// Find the first unpaid drink for this player
 	var drinkRecord *models.DrinkLedger
 	for _, record := range sessionDrinkRecords.Records {
-		if record.ToPlayerID == input.PlayerID && !record.Paid {
+		if record.OwedLater && driving {
+			continue
+		}
+		if record.ToPlayerID == input.PlayerID && record.IsOutstanding() {
 			drinkRecord = record
 			break
 		}
 	}
 
-	// If no unpaid drink found, return an error
+	// Paying a drink that isn't owed banks a credit for the next one, designated drivers can't bank any
 	if drinkRecord == nil {
-		return nil, fmt.Errorf("no unpaid drinks found for player %s", input.PlayerID)
+		if driving {
+			return nil, fmt.Errorf("no unpaid drinks found for player %s", input.PlayerID)
+		}
+
+		balance, err := s.bankCredit(ctx, session, input.PlayerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PayDrinkOutput{
+			Success:       true,
+			Game:          game,
+			Credited:      true,
+			CreditBalance: balance,
+		}, nil
 	}
 
 	// Mark the drink as paid
 	err = s.drinkLedgerRepo.MarkDrinkPaid(ctx, &ledgerRepo.MarkDrinkPaidInput{
 		DrinkID: drinkRecord.ID,
+		PaidAt:  now,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to mark drink as paid: %w", err)
@@ -1871,11 +2562,14 @@ func (s *service) PayDrink(ctx context.Context, input *PayDrinkInput) (*PayDrink
 
 	// Update the drink record with the paid status
 	drinkRecord.Paid = true
-	drinkRecord.PaidTimestamp = s.clock.Now()
+	drinkRecord.PaidTimestamp = now
+
+	s.publishLedgerChanged(ctx, game.GuildID, game.ChannelID, session.ID, "drink_paid")
 
 	return &PayDrinkOutput{
-		Success:     true,
-		Game:        game,
-		DrinkRecord: drinkRecord,
+		Success:       true,
+		Game:          game,
+		DrinkRecord:   drinkRecord,
+		PacingWarning: s.checkPacing(ctx, game, session.ID, sessionDrinkRecords.Records, input.PlayerID, now),
 	}, nil
 }
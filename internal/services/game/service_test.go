@@ -10,6 +10,7 @@ import (
 	"github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
 	uuidMocks "github.com/KirkDiggler/ronnied/internal/common/uuid/mocks"
 	diceMocks "github.com/KirkDiggler/ronnied/internal/dice/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
 	"github.com/KirkDiggler/ronnied/internal/models"
 	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
 	ledgerMocks "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger/mocks"
@@ -30,6 +31,7 @@ type GameServiceTestSuite struct {
 	mockDiceRoller *diceMocks.MockRoller
 	mockClock      *mocks.MockClock
 	mockUUID       *uuidMocks.MockUUID
+	eventBus       events.Bus
 	gameService    Service
 	ctx            context.Context
 
@@ -67,6 +69,7 @@ func (s *GameServiceTestSuite) SetupTest() {
 	s.mockDiceRoller = diceMocks.NewMockRoller(s.mockCtrl)
 	s.mockClock = mocks.NewMockClock(s.mockCtrl)
 	s.mockUUID = uuidMocks.NewMockUUID(s.mockCtrl)
+	s.eventBus = events.NewBus()
 
 	s.ctx = context.Background()
 
@@ -175,10 +178,11 @@ func (s *GameServiceTestSuite) SetupTest() {
 		DiceRoller:        s.mockDiceRoller,
 		Clock:             s.mockClock,
 		UUIDGenerator:     s.mockUUID,
+		EventBus:          s.eventBus,
 		MaxPlayers:        10, // Set a max players value for testing
 		DiceSides:         6,  // Standard dice
-		CriticalHitValue:  6,  // Critical hit on 6
-		CriticalFailValue: 1,  // Critical fail on 1
+		CriticalHitValues:  []int{6}, // Critical hit on 6
+		CriticalFailValues: []int{1}, // Critical fail on 1
 	}
 
 	var err error
@@ -191,6 +195,22 @@ func (s *GameServiceTestSuite) TearDownTest() {
 	s.mockCtrl.Finish()
 }
 
+// recordRollIn returns a RecordRoll stub that records the roll in the game and returns it, as the repository does
+func (s *GameServiceTestSuite) recordRollIn(game *models.Game) func(context.Context, *gameRepo.RecordRollInput) (*gameRepo.RecordRollOutput, error) {
+	return func(_ context.Context, input *gameRepo.RecordRollInput) (*gameRepo.RecordRollOutput, error) {
+		participant := game.GetParticipant(input.PlayerID)
+		s.Require().NotNil(participant)
+
+		rollTime := input.RollTime
+		participant.RollValue = input.RollValue
+		participant.RollTime = &rollTime
+		participant.RollModifiers = input.RollModifiers
+		participant.Status = input.Status
+		game.UpdatedAt = rollTime
+		return &gameRepo.RecordRollOutput{Game: game}, nil
+	}
+}
+
 // setupSessionExpectations sets up the expectations for session-related calls
 func (s *GameServiceTestSuite) setupSessionExpectations() {
 	// Expect GetCurrentSession to be called for the channel
@@ -205,24 +225,37 @@ func (s *GameServiceTestSuite) setupSessionExpectations() {
 }
 
 func (s *GameServiceTestSuite) TestCreateGame_HappyPath() {
+	// The session is checked for last call before the game is created
+	s.setupSessionExpectations()
+
 	// Expect CreateGame to be called on the game repository
 	s.mockGameRepo.EXPECT().
 		CreateGame(gomock.Any(), &gameRepo.CreateGameInput{
-			ChannelID: s.testChannelID,
-			CreatorID: s.testCreatorID,
-			Status:    models.GameStatusWaiting,
+			ChannelID:   s.testChannelID,
+			CreatorID:   s.testCreatorID,
+			Status:      models.GameStatusWaiting,
+			JoinCreator: true,
+			CreatorName: s.testCreatorName,
 		}).
 		Return(&gameRepo.CreateGameOutput{Game: s.expectedGame}, nil)
 
-	// Expect CreateParticipant to be called on the game repository
-	s.mockGameRepo.EXPECT().
-		CreateParticipant(gomock.Any(), &gameRepo.CreateParticipantInput{
-			GameID:     s.testGameID,
-			PlayerID:   s.testCreatorID,
-			PlayerName: s.testCreatorName,
-			Status:     models.ParticipantStatusWaitingToRoll,
+	// Expect the creator's player record to be created
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.testCreatorID,
+		}).
+		Return(nil, errors.New("player not found"))
+
+	s.mockPlayerRepo.EXPECT().
+		SavePlayer(gomock.Any(), &playerRepo.SavePlayerInput{
+			Player: &models.Player{
+				ID:            s.testCreatorID,
+				Name:          s.testCreatorName,
+				CurrentGameID: s.testGameID,
+				LastRollTime:  s.testTime,
+			},
 		}).
-		Return(&gameRepo.CreateParticipantOutput{Participant: s.expectedParticipant}, nil)
+		Return(nil)
 
 	// Act
 	output, err := s.gameService.CreateGame(s.ctx, s.createGameInput)
@@ -234,14 +267,17 @@ func (s *GameServiceTestSuite) TestCreateGame_HappyPath() {
 }
 
 func (s *GameServiceTestSuite) TestCreateGame_CreateGameError() {
+	s.setupSessionExpectations()
 	expectedError := errors.New("failed to create game")
 
 	// Expect CreateGame to be called on the game repository and return an error
 	s.mockGameRepo.EXPECT().
 		CreateGame(gomock.Any(), &gameRepo.CreateGameInput{
-			ChannelID: s.testChannelID,
-			CreatorID: s.testCreatorID,
-			Status:    models.GameStatusWaiting,
+			ChannelID:   s.testChannelID,
+			CreatorID:   s.testCreatorID,
+			Status:      models.GameStatusWaiting,
+			JoinCreator: true,
+			CreatorName: s.testCreatorName,
 		}).
 		Return(nil, expectedError)
 
@@ -254,27 +290,31 @@ func (s *GameServiceTestSuite) TestCreateGame_CreateGameError() {
 	s.Nil(output)
 }
 
-func (s *GameServiceTestSuite) TestCreateGame_CreateParticipantError() {
-	expectedError := errors.New("failed to create participant")
+func (s *GameServiceTestSuite) TestCreateGame_SavePlayerError() {
+	s.setupSessionExpectations()
+	expectedError := errors.New("failed to save player")
 
 	// Expect CreateGame to be called on the game repository
 	s.mockGameRepo.EXPECT().
 		CreateGame(gomock.Any(), &gameRepo.CreateGameInput{
-			ChannelID: s.testChannelID,
-			CreatorID: s.testCreatorID,
-			Status:    models.GameStatusWaiting,
+			ChannelID:   s.testChannelID,
+			CreatorID:   s.testCreatorID,
+			Status:      models.GameStatusWaiting,
+			JoinCreator: true,
+			CreatorName: s.testCreatorName,
 		}).
 		Return(&gameRepo.CreateGameOutput{Game: s.expectedGame}, nil)
 
-	// Expect CreateParticipant to be called on the game repository and return an error
-	s.mockGameRepo.EXPECT().
-		CreateParticipant(gomock.Any(), &gameRepo.CreateParticipantInput{
-			GameID:     s.testGameID,
-			PlayerID:   s.testCreatorID,
-			PlayerName: s.testCreatorName,
-			Status:     models.ParticipantStatusWaitingToRoll,
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.testCreatorID,
 		}).
-		Return(nil, expectedError)
+		Return(nil, errors.New("player not found"))
+
+	// Expect saving the creator's player record to return an error
+	s.mockPlayerRepo.EXPECT().
+		SavePlayer(gomock.Any(), gomock.Any()).
+		Return(expectedError)
 
 	// Act
 	output, err := s.gameService.CreateGame(s.ctx, s.createGameInput)
@@ -305,6 +345,7 @@ func (s *GameServiceTestSuite) TestStartGame_HappyPath() {
 				Status:       models.GameStatusActive,
 				CreatedAt:    s.testTime,
 				UpdatedAt:    s.testTime,
+				StartedAt:    &s.testTime,
 				Participants: []*models.Participant{s.expectedParticipant},
 			},
 		}).
@@ -435,6 +476,7 @@ func (s *GameServiceTestSuite) TestStartGame_SaveGameError() {
 				Status:       models.GameStatusActive,
 				CreatedAt:    s.testTime,
 				UpdatedAt:    s.testTime,
+				StartedAt:    &s.testTime,
 				Participants: []*models.Participant{s.expectedParticipant},
 			},
 		}).
@@ -852,49 +894,40 @@ func (s *GameServiceTestSuite) TestRollDice_RegularRoll() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: s.rollDiceInput.PlayerID}, nil)
+
 	// Expect Roll to be called on the dice roller
 	s.mockDiceRoller.EXPECT().
 		Roll(6). // 6-sided dice
 		Return(3)
 
-	// Expect SaveGame to be called with the updated game
+	// Expect the roll to be recorded before anything comes of it
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:        s.testGameID,
-				ChannelID: s.testChannelID,
-				CreatorID: s.testCreatorID,
-				Status:    models.GameStatusActive,
-				CreatedAt: s.testTime,
-				UpdatedAt: s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         s.testParticipantID,
-						GameID:     s.testGameID,
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusActive,
-						RollValue:  3,
-						RollTime:   &s.testTime,
-					},
-					{
-						ID:         "another-participant-id",
-						GameID:     s.testGameID,
-						PlayerID:   s.testPlayerID,
-						PlayerName: s.testPlayerName,
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-				},
-			},
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    s.testGameID,
+			PlayerID:  s.testCreatorID,
+			RollValue: 3,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusActive,
 		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the game is marked as completed
-			s.Equal(models.GameStatusActive, input.Game.Status)
-			return nil
-		}).
-		Return(nil)
+		DoAndReturn(s.recordRollIn(activeGame))
+
+	// No one has bet on the roll
+	s.mockGameRepo.EXPECT().
+		TakeSideBets(gomock.Any(), &gameRepo.TakeSideBetsInput{GameID: s.testGameID, TargetPlayerID: s.testCreatorID}).
+		Return(&gameRepo.TakeSideBetsOutput{}, nil)
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
@@ -914,6 +947,9 @@ func (s *GameServiceTestSuite) TestRollDice_RegularRoll() {
 }
 
 func (s *GameServiceTestSuite) TestRollDice_CriticalHit() {
+	// The session's challenges are checked for the critical hit
+	s.setupSessionExpectations()
+
 	// Create an active game with multiple participants, one who hasn't rolled yet
 	activeGame := &models.Game{
 		ID:        s.testGameID,
@@ -960,58 +996,49 @@ func (s *GameServiceTestSuite) TestRollDice_CriticalHit() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: s.rollDiceInput.PlayerID}, nil)
+
+	// Expect the other players to be checked for birthdays, neither is celebrating
+	for _, playerID := range []string{s.testPlayerID, "third-player-id"} {
+		s.mockPlayerRepo.EXPECT().
+			GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+				PlayerID: playerID,
+			}).
+			Return(&models.Player{ID: playerID}, nil)
+	}
+
 	// Expect Roll to be called on the dice roller and return a critical hit
 	s.mockDiceRoller.EXPECT().
 		Roll(6). // 6-sided dice
 		Return(6)
 
-	// Expect SaveGame to be called with the updated game
+	// Expect the roll to be recorded before anything comes of it
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:        s.testGameID,
-				ChannelID: s.testChannelID,
-				CreatorID: s.testCreatorID,
-				Status:    models.GameStatusActive,
-				CreatedAt: s.testTime,
-				UpdatedAt: s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         s.testParticipantID,
-						GameID:     s.testGameID,
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusNeedsToAssign,
-						RollValue:  6,
-						RollTime:   &s.testTime,
-					},
-					{
-						ID:         "another-participant-id",
-						GameID:     s.testGameID,
-						PlayerID:   s.testPlayerID,
-						PlayerName: s.testPlayerName,
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-					{
-						ID:         "third-participant-id",
-						GameID:     s.testGameID,
-						PlayerID:   "third-player-id",
-						PlayerName: "Third Player",
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-				},
-			},
-		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the game is marked as completed
-			s.Equal(models.GameStatusActive, input.Game.Status)
-			return nil
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    s.testGameID,
+			PlayerID:  s.testCreatorID,
+			RollValue: 6,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusNeedsToAssign,
 		}).
-		Return(nil)
+		DoAndReturn(s.recordRollIn(activeGame))
+
+	// No one has bet on the roll
+	s.mockGameRepo.EXPECT().
+		TakeSideBets(gomock.Any(), &gameRepo.TakeSideBetsInput{GameID: s.testGameID, TargetPlayerID: s.testCreatorID}).
+		Return(&gameRepo.TakeSideBetsOutput{}, nil)
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
@@ -1084,11 +1111,44 @@ func (s *GameServiceTestSuite) TestRollDice_CriticalFail() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: s.rollDiceInput.PlayerID}, nil)
+
 	// Expect Roll to be called on the dice roller and return a critical fail
 	s.mockDiceRoller.EXPECT().
 		Roll(6). // 6-sided dice
 		Return(1)
 
+	// Expect the roll to be recorded before anything comes of it
+	s.mockGameRepo.EXPECT().
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    s.testGameID,
+			PlayerID:  s.testCreatorID,
+			RollValue: 1,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusActive,
+		}).
+		DoAndReturn(s.recordRollIn(activeGame))
+
+	// No credits have been banked to offset the drink
+	s.mockDrinkRepo.EXPECT().
+		ApplyCredit(gomock.Any(), &ledgerRepo.ApplyCreditInput{
+			SessionID: s.testSessionID,
+			PlayerID:  s.testCreatorID,
+		}).
+		Return(&ledgerRepo.ApplyCreditOutput{}, nil)
+
 	// Expect CreateDrinkRecord to be called for the critical fail
 	s.mockDrinkRepo.EXPECT().
 		CreateDrinkRecord(gomock.Any(), &ledgerRepo.CreateDrinkRecordInput{
@@ -1101,44 +1161,10 @@ func (s *GameServiceTestSuite) TestRollDice_CriticalFail() {
 		}).
 		Return(&ledgerRepo.CreateDrinkRecordOutput{}, nil)
 
-	// Expect SaveGame to be called with the updated game
+	// No one has bet on the roll
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:        s.testGameID,
-				ChannelID: s.testChannelID,
-				CreatorID: s.testCreatorID,
-				Status:    models.GameStatusActive,
-				CreatedAt: s.testTime,
-				UpdatedAt: s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         s.testParticipantID,
-						GameID:     s.testGameID,
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusActive,
-						RollValue:  1,
-						RollTime:   &s.testTime,
-					},
-					{
-						ID:         "another-participant-id",
-						GameID:     s.testGameID,
-						PlayerID:   s.testPlayerID,
-						PlayerName: s.testPlayerName,
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-				},
-			},
-		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the game is marked as completed
-			s.Equal(models.GameStatusActive, input.Game.Status)
-			return nil
-		}).
-		Return(nil)
+		TakeSideBets(gomock.Any(), &gameRepo.TakeSideBetsInput{GameID: s.testGameID, TargetPlayerID: s.testCreatorID}).
+		Return(&gameRepo.TakeSideBetsOutput{}, nil)
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
@@ -1193,6 +1219,13 @@ func (s *GameServiceTestSuite) TestRollDice_InvalidGameState() {
 		}).
 		Return(completedGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
 
@@ -1229,6 +1262,13 @@ func (s *GameServiceTestSuite) TestRollDice_PlayerNotInGame() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
 
@@ -1268,6 +1308,13 @@ func (s *GameServiceTestSuite) TestRollDice_PlayerAlreadyRolled() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
 
@@ -1277,7 +1324,62 @@ func (s *GameServiceTestSuite) TestRollDice_PlayerAlreadyRolled() {
 	s.Nil(output)
 }
 
-func (s *GameServiceTestSuite) TestRollDice_SaveGameError() {
+func (s *GameServiceTestSuite) TestRollDice_RollAlreadyRecorded() {
+	// Create an active game the player hadn't rolled in when it was loaded
+	activeGame := &models.Game{
+		ID:        s.testGameID,
+		ChannelID: s.testChannelID,
+		CreatorID: s.testCreatorID,
+		Status:    models.GameStatusActive,
+		CreatedAt: s.testTime,
+		UpdatedAt: s.testTime,
+		Participants: []*models.Participant{
+			{
+				ID:         s.testParticipantID,
+				GameID:     s.testGameID,
+				PlayerID:   s.testCreatorID,
+				PlayerName: s.testCreatorName,
+				Status:     models.ParticipantStatusWaitingToRoll,
+			},
+		},
+	}
+
+	s.mockGameRepo.EXPECT().
+		GetGame(gomock.Any(), &gameRepo.GetGameInput{
+			GameID: s.testGameID,
+		}).
+		Return(activeGame, nil)
+
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: s.rollDiceInput.PlayerID}, nil)
+
+	s.mockDiceRoller.EXPECT().
+		Roll(6).
+		Return(1)
+
+	// Another click recorded a roll since the game was loaded, so nothing else happens
+	s.mockGameRepo.EXPECT().
+		RecordRoll(gomock.Any(), gomock.Any()).
+		Return(nil, gameRepo.ErrRollAlreadyRecorded)
+
+	// Act
+	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
+
+	// Assert
+	s.ErrorIs(err, ErrPlayerAlreadyRolled)
+	s.Nil(output)
+}
+
+func (s *GameServiceTestSuite) TestRollDice_RecordRollError() {
 	// Create an active game with a participant who hasn't rolled yet
 	activeGame := &models.Game{
 		ID:        s.testGameID,
@@ -1306,41 +1408,36 @@ func (s *GameServiceTestSuite) TestRollDice_SaveGameError() {
 		}).
 		Return(activeGame, nil)
 
+	// Expect a lookup for active roll-offs the player should be rolling in instead
+	s.mockGameRepo.EXPECT().
+		GetGamesByParent(gomock.Any(), &gameRepo.GetGamesByParentInput{
+			ParentGameID: s.testGameID,
+		}).
+		Return([]*models.Game{}, nil)
+
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: s.rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: s.rollDiceInput.PlayerID}, nil)
+
 	// Expect Roll to be called on the dice roller
 	s.mockDiceRoller.EXPECT().
 		Roll(6). // 6-sided dice
 		Return(3)
 
-	// Expect SaveGame to be called and return an error
-	expectedError := errors.New("failed to save game")
+	// Expect the roll to fail to be recorded
+	expectedError := errors.New("failed to record roll")
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:        s.testGameID,
-				ChannelID: s.testChannelID,
-				CreatorID: s.testCreatorID,
-				Status:    models.GameStatusActive,
-				CreatedAt: s.testTime,
-				UpdatedAt: s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         s.testParticipantID,
-						GameID:     s.testGameID,
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusActive,
-						RollValue:  3,
-						RollTime:   &s.testTime,
-					},
-				},
-			},
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    s.testGameID,
+			PlayerID:  s.testCreatorID,
+			RollValue: 3,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusActive,
 		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the game is marked as completed
-			s.Equal(models.GameStatusActive, input.Game.Status)
-			return expectedError
-		}).
-		Return(expectedError)
+		Return(nil, expectedError)
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, s.rollDiceInput)
@@ -1443,67 +1540,28 @@ func (s *GameServiceTestSuite) TestRollDice_RollOffGame() {
 		}).
 		Return([]*models.Game{nestedRollOffGame}, nil)
 
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: rollDiceInput.PlayerID}, nil)
+
 	// Expect the dice to be rolled (use 6 as the default sides for testing)
 	s.mockDiceRoller.EXPECT().
 		Roll(6).
 		Return(5) // Regular roll, not critical
 
-	// Expect SaveGame to be called with updated participant roll in the NESTED game
+	// Expect the roll to be recorded before anything comes of it
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:           "nested-roll-off-id",
-				ChannelID:    s.testChannelID,
-				CreatorID:    s.testCreatorID,
-				ParentGameID: "parent-roll-off-id",
-				Status:       models.GameStatusRollOff,
-				CreatedAt:    s.testTime,
-				UpdatedAt:    s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         "nested-participant-1",
-						GameID:     "nested-roll-off-id",
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusActive,
-						RollValue:  5,
-						RollTime:   &s.testTime,
-					},
-					{
-						ID:         "nested-participant-2",
-						GameID:     "nested-roll-off-id",
-						PlayerID:   s.testPlayerID,
-						PlayerName: s.testPlayerName,
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-				},
-			},
-		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the NESTED game has been updated with the participant's roll
-			s.Equal("nested-roll-off-id", input.Game.ID)
-			s.Equal(models.GameStatusRollOff, input.Game.Status)
-
-			// Find the participant who rolled
-			var rolledParticipant *models.Participant
-			for _, p := range input.Game.Participants {
-				if p.PlayerID == s.testCreatorID {
-					rolledParticipant = p
-					break
-				}
-			}
-
-			// Verify participant roll was updated in the nested game
-			s.NotNil(rolledParticipant)
-			s.Equal(5, rolledParticipant.RollValue)
-			s.NotNil(rolledParticipant.RollTime)
-			s.Equal(models.ParticipantStatusActive, rolledParticipant.Status)
-
-			return nil
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    "nested-roll-off-id",
+			PlayerID:  s.testCreatorID,
+			RollValue: 5,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusActive,
 		}).
-		Return(nil)
+		DoAndReturn(s.recordRollIn(nestedRollOffGame))
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, rollDiceInput)
@@ -2141,67 +2199,28 @@ func (s *GameServiceTestSuite) TestRollDice_NestedRollOffGame() {
 		}).
 		Return([]*models.Game{nestedRollOffGame}, nil)
 
+	// Expect the player to be looked up for roll modifiers
+	s.mockPlayerRepo.EXPECT().
+		GetPlayer(gomock.Any(), &playerRepo.GetPlayerInput{
+			PlayerID: rollDiceInput.PlayerID,
+		}).
+		Return(&models.Player{ID: rollDiceInput.PlayerID}, nil)
+
 	// Expect the dice to be rolled (use 6 as the default sides for testing)
 	s.mockDiceRoller.EXPECT().
 		Roll(6).
 		Return(5) // Regular roll, not critical
 
-	// Expect SaveGame to be called with updated participant roll in the NESTED game
+	// Expect the roll to be recorded before anything comes of it
 	s.mockGameRepo.EXPECT().
-		SaveGame(gomock.Any(), &gameRepo.SaveGameInput{
-			Game: &models.Game{
-				ID:           "nested-roll-off-id",
-				ChannelID:    s.testChannelID,
-				CreatorID:    s.testCreatorID,
-				ParentGameID: "parent-roll-off-id",
-				Status:       models.GameStatusRollOff,
-				CreatedAt:    s.testTime,
-				UpdatedAt:    s.testTime,
-				Participants: []*models.Participant{
-					{
-						ID:         "nested-participant-1",
-						GameID:     "nested-roll-off-id",
-						PlayerID:   s.testCreatorID,
-						PlayerName: s.testCreatorName,
-						Status:     models.ParticipantStatusActive,
-						RollValue:  5,
-						RollTime:   &s.testTime,
-					},
-					{
-						ID:         "nested-participant-2",
-						GameID:     "nested-roll-off-id",
-						PlayerID:   s.testPlayerID,
-						PlayerName: s.testPlayerName,
-						Status:     models.ParticipantStatusWaitingToRoll,
-						RollValue:  0,
-						RollTime:   nil,
-					},
-				},
-			},
+		RecordRoll(gomock.Any(), &gameRepo.RecordRollInput{
+			GameID:    "nested-roll-off-id",
+			PlayerID:  s.testCreatorID,
+			RollValue: 5,
+			RollTime:  s.testTime,
+			Status:    models.ParticipantStatusActive,
 		}).
-		DoAndReturn(func(_ context.Context, input *gameRepo.SaveGameInput) error {
-			// Verify the NESTED game has been updated with the participant's roll
-			s.Equal("nested-roll-off-id", input.Game.ID)
-			s.Equal(models.GameStatusRollOff, input.Game.Status)
-
-			// Find the participant who rolled
-			var rolledParticipant *models.Participant
-			for _, p := range input.Game.Participants {
-				if p.PlayerID == s.testCreatorID {
-					rolledParticipant = p
-					break
-				}
-			}
-
-			// Verify participant roll was updated in the nested game
-			s.NotNil(rolledParticipant)
-			s.Equal(5, rolledParticipant.RollValue)
-			s.NotNil(rolledParticipant.RollTime)
-			s.Equal(models.ParticipantStatusActive, rolledParticipant.Status)
-
-			return nil
-		}).
-		Return(nil)
+		DoAndReturn(s.recordRollIn(nestedRollOffGame))
 
 	// Act
 	output, err := s.gameService.RollDice(s.ctx, rollDiceInput)
@@ -2302,6 +2321,7 @@ func (s *GameServiceTestSuite) TestEndGame_CompletedLowestRollOff() {
 	s.mockDrinkRepo.EXPECT().
 		GetDrinkRecordsForSession(gomock.Any(), &ledgerRepo.GetDrinkRecordsForSessionInput{
 			SessionID: "test-session-id",
+			GuildID:   "test-channel-id",
 		}).
 		Return(&ledgerRepo.GetDrinkRecordsForSessionOutput{
 			Records: []*models.DrinkLedger{
@@ -2334,6 +2354,37 @@ func (s *GameServiceTestSuite) TestEndGame_CompletedLowestRollOff() {
 			Records: []*models.DrinkLedger{},
 		}, nil)
 
+	// Expect GetDrinkRecordsForGame to be called for the parent game results
+	s.mockDrinkRepo.EXPECT().
+		GetDrinkRecordsForGame(gomock.Any(), &ledgerRepo.GetDrinkRecordsForGameInput{
+			GameID: parentGame.ID,
+		}).
+		Return(&ledgerRepo.GetDrinkRecordsForGameOutput{
+			Records: []*models.DrinkLedger{
+				{
+					ID:         "roll-off-drink",
+					GameID:     parentGame.ID,
+					ToPlayerID: "third-player-id",
+					Reason:     models.DrinkReasonLowestRoll,
+					Timestamp:  s.testTime,
+				},
+			},
+		}, nil)
+
+	// Capture the completed event for the parent game
+	var completedEvents []*events.Event
+	s.eventBus.Subscribe(events.TypeGameCompleted, func(ctx context.Context, event *events.Event) {
+		completedEvents = append(completedEvents, event)
+	})
+
+	// No credits have been banked to offset the drink
+	s.mockDrinkRepo.EXPECT().
+		ApplyCredit(gomock.Any(), &ledgerRepo.ApplyCreditInput{
+			SessionID: s.testSessionID,
+			PlayerID:  "third-player-id",
+		}).
+		Return(&ledgerRepo.ApplyCreditOutput{}, nil)
+
 	// Expect CreateDrinkRecord to be called for the lowest roller in the roll-off
 	s.mockDrinkRepo.EXPECT().
 		CreateDrinkRecord(gomock.Any(), &ledgerRepo.CreateDrinkRecordInput{
@@ -2420,6 +2471,18 @@ func (s *GameServiceTestSuite) TestEndGame_CompletedLowestRollOff() {
 	s.False(output.NeedsLowestRollOff)
 	s.Equal("", output.LowestRollOffGameID)
 	s.Equal(0, len(output.LowestRollOffPlayerIDs))
+
+	// The parent game is reported as completed, not the roll-off
+	s.Require().Len(completedEvents, 1)
+	s.Equal(parentGame.ID, completedEvents[0].GameID)
+	payload, ok := completedEvents[0].Payload.(*events.GameCompletedPayload)
+	s.Require().True(ok)
+	s.Len(payload.Results, 4)
+	for _, result := range payload.Results {
+		if result.PlayerID == "third-player-id" {
+			s.Equal(1, result.DrinksReceived)
+		}
+	}
 }
 
 func (s *GameServiceTestSuite) TestEndGame_IncludesSessionLeaderboard() {
@@ -2474,6 +2537,14 @@ func (s *GameServiceTestSuite) TestEndGame_IncludesSessionLeaderboard() {
 			Records: []*models.DrinkLedger{},
 		}, nil)
 
+	// No credits have been banked to offset the drink
+	s.mockDrinkRepo.EXPECT().
+		ApplyCredit(gomock.Any(), &ledgerRepo.ApplyCreditInput{
+			SessionID: s.testSessionID,
+			PlayerID:  "third-player-id",
+		}).
+		Return(&ledgerRepo.ApplyCreditOutput{}, nil)
+
 	// Expect CreateDrinkRecord to be called for the lowest roller
 	s.mockDrinkRepo.EXPECT().
 		CreateDrinkRecord(gomock.Any(), &ledgerRepo.CreateDrinkRecordInput{
@@ -2504,6 +2575,7 @@ func (s *GameServiceTestSuite) TestEndGame_IncludesSessionLeaderboard() {
 	s.mockDrinkRepo.EXPECT().
 		GetDrinkRecordsForSession(gomock.Any(), &ledgerRepo.GetDrinkRecordsForSessionInput{
 			SessionID: "test-session-id",
+			GuildID:   "test-channel-id",
 		}).
 		Return(&ledgerRepo.GetDrinkRecordsForSessionOutput{
 			Records: []*models.DrinkLedger{
@@ -2576,6 +2648,14 @@ func (s *GameServiceTestSuite) TestEndGame_IncludesSessionLeaderboard() {
 			CurrentGameID: s.testGameID,
 		}, nil).AnyTimes()
 
+	// Capture published events
+	var publishedEvents []*events.Event
+	captureEvent := func(ctx context.Context, event *events.Event) {
+		publishedEvents = append(publishedEvents, event)
+	}
+	s.eventBus.Subscribe(events.TypeGameCompleted, captureEvent)
+	s.eventBus.Subscribe(events.TypeSessionLeaderboard, captureEvent)
+
 	// Act
 	output, err := s.gameService.EndGame(s.ctx, &EndGameInput{
 		Game: game,
@@ -2607,6 +2687,16 @@ func (s *GameServiceTestSuite) TestEndGame_IncludesSessionLeaderboard() {
 	s.Equal("third-player-id", output.SessionLeaderboard[2].PlayerID)
 	s.Equal(1, output.SessionLeaderboard[2].DrinkCount)
 	s.Equal(0, output.SessionLeaderboard[2].PaidCount)
+
+	// Verify the completion events were published
+	s.Require().Len(publishedEvents, 2)
+	s.Equal(events.TypeGameCompleted, publishedEvents[0].Type)
+	s.Equal(s.testGameID, publishedEvents[0].GameID)
+	s.Equal(s.testChannelID, publishedEvents[0].ChannelID)
+	s.Equal(events.TypeSessionLeaderboard, publishedEvents[1].Type)
+	standings, ok := publishedEvents[1].Payload.(*events.SessionLeaderboardPayload)
+	s.Require().True(ok)
+	s.Len(standings.Standings, 3)
 }
 
 func TestGameServiceSuite(t *testing.T) {
@@ -2644,6 +2734,7 @@ func (s *GameServiceTestSuite) TestPayDrink_HappyPath() {
 	// Get drink records for the session
 	s.mockDrinkRepo.EXPECT().GetDrinkRecordsForSession(s.ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
 		SessionID: s.testSessionID,
+		GuildID:   s.testChannelID,
 	}).Return(&ledgerRepo.GetDrinkRecordsForSessionOutput{
 		Records: []*models.DrinkLedger{testDrink},
 	}, nil)
@@ -2651,6 +2742,7 @@ func (s *GameServiceTestSuite) TestPayDrink_HappyPath() {
 	// Mark the drink as paid
 	s.mockDrinkRepo.EXPECT().MarkDrinkPaid(s.ctx, &ledgerRepo.MarkDrinkPaidInput{
 		DrinkID: testDrinkID,
+		PaidAt:  s.testTime,
 	}).Return(nil)
 	
 	// Execute the method
@@ -2669,7 +2761,7 @@ func (s *GameServiceTestSuite) TestPayDrink_HappyPath() {
 	s.True(result.DrinkRecord.Paid)
 }
 
-func (s *GameServiceTestSuite) TestPayDrink_NoUnpaidDrinks() {
+func (s *GameServiceTestSuite) TestPayDrink_NoUnpaidDrinksBanksCredit() {
 	// Set up test data
 	testDrinkID := "test-drink-id"
 	testDrink := &models.DrinkLedger{
@@ -2700,10 +2792,17 @@ func (s *GameServiceTestSuite) TestPayDrink_NoUnpaidDrinks() {
 	// Get drink records for the session
 	s.mockDrinkRepo.EXPECT().GetDrinkRecordsForSession(s.ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
 		SessionID: s.testSessionID,
+		GuildID:   s.testChannelID,
 	}).Return(&ledgerRepo.GetDrinkRecordsForSessionOutput{
 		Records: []*models.DrinkLedger{testDrink},
 	}, nil)
 	
+	// The drink paid is banked as a credit
+	s.mockDrinkRepo.EXPECT().AddCredit(s.ctx, &ledgerRepo.AddCreditInput{
+		SessionID: s.testSessionID,
+		PlayerID:  s.testPlayerID,
+	}).Return(&ledgerRepo.AddCreditOutput{Balance: 1}, nil)
+	
 	// Execute the method
 	result, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{
 		GameID:   s.testGameID,
@@ -2711,7 +2810,8 @@ func (s *GameServiceTestSuite) TestPayDrink_NoUnpaidDrinks() {
 	})
 	
 	// Verify the result
-	s.Error(err)
-	s.Nil(result)
-	s.Contains(err.Error(), "no unpaid drinks found")
+	s.NoError(err)
+	s.True(result.Credited)
+	s.Equal(1, result.CreditBalance)
+	s.Nil(result.DrinkRecord)
 }
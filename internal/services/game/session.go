@@ -5,61 +5,305 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sort"
+	"time"
 
+	"github.com/KirkDiggler/ronnied/internal/events"
 	"github.com/KirkDiggler/ronnied/internal/models"
 	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
-	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/replica"
 )
 
-// extractGuildIDFromChannel extracts the guild ID from a Discord channel ID
-// In Discord, channel IDs are unique, but we can use a simple mapping for now
-// In a real implementation, this would use the Discord API to get the guild ID for a channel
-func (s *service) extractGuildIDFromChannel(ctx context.Context, channelID string) string {
-	// For now, we'll use a simple approach - in a real implementation,
-	// this would query Discord API or use a cached mapping
-	
-	// If we have a mapping service or Discord client, we'd use it here
-	// For now, we'll just use the channel ID as the guild ID
-	// This is a placeholder until proper Discord API integration
+// sessionScope returns the key sessions are kept under: the guild, or the channel itself for
+// channels outside a Discord server (DMs, Telegram and Slack chats, the CLI)
+func sessionScope(guildID, channelID string) string {
+	if guildID != "" {
+		return guildID
+	}
 	return channelID
 }
 
-// getSessionIDForChannel gets the current session ID for a channel
+// getSessionIDForGame gets the current session ID for a game's guild
 // If no session exists, it creates a new one
-func (s *service) getSessionIDForChannel(ctx context.Context, channelID string) string {
-	if channelID == "" {
+func (s *service) getSessionIDForGame(ctx context.Context, game *models.Game) string {
+	session := s.getSessionForGame(ctx, game)
+	if session == nil {
 		return ""
 	}
+	return session.ID
+}
 
-	// Extract the guild ID from the channel ID
-	guildID := s.extractGuildIDFromChannel(ctx, channelID)
-	if guildID == "" {
-		return ""
+// getSessionForGame gets the current session for a game's guild
+// If no session exists, it creates a new one
+func (s *service) getSessionForGame(ctx context.Context, game *models.Game) *models.Session {
+	return s.getSession(ctx, game.GuildID, game.ChannelID)
+}
+
+// getSession gets the current session for a guild, or for the channel when it isn't in a guild
+// If no session exists, it creates a new one
+func (s *service) getSession(ctx context.Context, guildID, channelID string) *models.Session {
+	scope := sessionScope(guildID, channelID)
+	if scope == "" {
+		return nil
 	}
 
 	// Try to get the current session for the guild
 	currentSessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
-		GuildID: guildID,
+		GuildID: scope,
 	})
 	
 	// If there's an error or no session exists, create a new one
 	if err != nil || currentSessionOutput.Session == nil {
 		// Create a new session
 		sessionOutput, err := s.drinkLedgerRepo.CreateSession(ctx, &ledgerRepo.CreateSessionInput{
-			GuildID:   guildID,
+			GuildID:   scope,
 			CreatedBy: "system", // Default to system since we don't have a user ID here
 		})
 		
 		if err != nil {
-			// If we can't create a session, just return empty string
-			return ""
+			// If we can't create a session, there's nothing to return
+			return nil
 		}
 		
-		return sessionOutput.Session.ID
+		return sessionOutput.Session
 	}
 	
-	return currentSessionOutput.Session.ID
+	return s.rotateSessionIfDue(ctx, guildID, channelID, currentSessionOutput.Session)
+}
+
+// rotateSessionIfDue replaces the session with a fresh one once the rotation config says it has run its course,
+// publishing the closing leaderboard to the channel
+func (s *service) rotateSessionIfDue(ctx context.Context, guildID, channelID string, session *models.Session) *models.Session {
+	if s.sessionRotation == nil {
+		return session
+	}
+
+	reason, err := s.sessionRotationReason(ctx, session)
+	if err != nil {
+		log.Printf("Error checking rotation for session %s: %v", session.ID, err)
+		return session
+	}
+	if reason == "" {
+		return session
+	}
+
+	return s.closeSession(ctx, guildID, channelID, session, reason)
+}
+
+// closeSession replaces the session with a fresh one, publishing its closing leaderboard to the channel.
+// It returns the new session, or the old one if it couldn't be replaced.
+func (s *service) closeSession(ctx context.Context, guildID, channelID string, session *models.Session, reason SessionRotationReason) *models.Session {
+	// A session that went quiet ended with its last activity rather than when it was noticed
+	endedAt := s.clock.Now()
+	if reason == SessionRotationReasonInactivity {
+		if lastActivity, err := s.lastSessionActivity(ctx, session); err == nil {
+			endedAt = lastActivity
+		}
+	}
+	closing := *session
+	closing.EndedAt = &endedAt
+
+	// Capture the closing standings before the new session takes over, from the primary so none are missed
+	leaderboard, err := s.sessionLeaderboard(replica.WithPrimaryReads(ctx), session.ID, &closing, session.GuildID)
+	if err != nil {
+		log.Printf("Error getting closing leaderboard for session %s: %v", session.ID, err)
+		return session
+	}
+
+	// Only the caller that actually replaces the session announces it
+	sessionOutput, err := s.drinkLedgerRepo.CreateSession(ctx, &ledgerRepo.CreateSessionInput{
+		GuildID:           session.GuildID,
+		CreatedBy:         "system",
+		PreviousSessionID: session.ID,
+	})
+	if errors.Is(err, ledgerRepo.ErrSessionReplaced) {
+		currentSessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+			GuildID: session.GuildID,
+		})
+		if err != nil || currentSessionOutput.Session == nil {
+			return session
+		}
+		return currentSessionOutput.Session
+	}
+	if err != nil {
+		log.Printf("Error rotating session %s: %v", session.ID, err)
+		return session
+	}
+
+	log.Printf("Rotated session %s to %s (%s)", session.ID, sessionOutput.Session.ID, reason)
+	s.publishLedgerChanged(ctx, guildID, channelID, sessionOutput.Session.ID, "session_started")
+
+	// A session nobody drank in has nothing worth announcing
+	if len(leaderboard.Entries) > 0 {
+		s.eventBus.Publish(ctx, &events.Event{
+			Type:      events.TypeSessionRotated,
+			ChannelID: channelID,
+			GuildID:   guildID,
+			SessionID: sessionOutput.Session.ID,
+			Timestamp: s.clock.Now(),
+			Payload: &events.SessionRotatedPayload{
+				PreviousSessionID: session.ID,
+				Reason:            string(reason),
+				Standings:         sessionStandings(leaderboard.Entries),
+				StartedAt:         session.CreatedAt,
+				EndedAt:           endedAt,
+			},
+		})
+	}
+
+	return sessionOutput.Session
+}
+
+// RotateDueSessions rotates every session the rotation config says has run its course, announcing each in the
+// channel its last drink was in
+func (s *service) RotateDueSessions(ctx context.Context, input *RotateDueSessionsInput) (*RotateDueSessionsOutput, error) {
+	output := &RotateDueSessionsOutput{}
+	if s.sessionRotation == nil {
+		return output, nil
+	}
+
+	sessionsOutput, err := s.drinkLedgerRepo.GetCurrentSessions(ctx, &ledgerRepo.GetCurrentSessionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current sessions: %w", err)
+	}
+
+	for _, session := range sessionsOutput.Sessions {
+		reason, err := s.sessionRotationReason(ctx, session)
+		if err != nil {
+			log.Printf("Error checking rotation for session %s: %v", session.ID, err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+
+		guildID, channelID := s.sessionChannel(ctx, session)
+		if rotated := s.closeSession(ctx, guildID, channelID, session, reason); rotated.ID != session.ID {
+			output.RotatedSessionIDs = append(output.RotatedSessionIDs, session.ID)
+		}
+	}
+
+	return output, nil
+}
+
+// sessionChannel returns the guild and channel the session was last played in, from the game of its latest drink.
+// A session nobody drank in has nothing to announce, so it's only kept under its scope.
+func (s *service) sessionChannel(ctx context.Context, session *models.Session) (string, string) {
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   session.GuildID,
+	})
+	if err != nil {
+		return session.GuildID, ""
+	}
+
+	var latest *models.DrinkLedger
+	for _, record := range drinkRecords.Records {
+		if record.GameID != "" && (latest == nil || record.Timestamp.After(latest.Timestamp)) {
+			latest = record
+		}
+	}
+	if latest == nil {
+		return session.GuildID, ""
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: latest.GameID,
+	})
+	if err != nil {
+		return session.GuildID, ""
+	}
+
+	return game.GuildID, game.ChannelID
+}
+
+// sessionRotationReason returns why the session should be rotated, or an empty reason if it should be kept
+func (s *service) sessionRotationReason(ctx context.Context, session *models.Session) (SessionRotationReason, error) {
+	now := s.clock.Now()
+
+	if s.sessionRotation.Daily && session.CreatedAt.Before(s.lastDailyRotation(now)) {
+		return SessionRotationReasonDaily, nil
+	}
+
+	// A session paused for a break isn't idle, and is active again from when it was resumed
+	if s.sessionRotation.Inactivity > 0 && !session.IsPaused() {
+		lastActivity, err := s.lastSessionActivity(ctx, session)
+		if err != nil {
+			return "", err
+		}
+
+		if now.Sub(lastActivity) >= s.sessionRotation.Inactivity {
+			return SessionRotationReasonInactivity, nil
+		}
+	}
+
+	return "", nil
+}
+
+// lastSessionActivity returns when the session was last active: its creation, the end of a break or its most
+// recent drink, whichever was latest
+func (s *service) lastSessionActivity(ctx context.Context, session *models.Session) (time.Time, error) {
+	lastActivity := session.CreatedAt
+	for _, pause := range session.Pauses {
+		if pause.End != nil && pause.End.After(lastActivity) {
+			lastActivity = *pause.End
+		}
+	}
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   session.GuildID,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get drink records: %w", err)
+	}
+
+	for _, record := range drinkRecords.Records {
+		if record.Timestamp.After(lastActivity) {
+			lastActivity = record.Timestamp
+		}
+	}
+
+	return lastActivity, nil
+}
+
+// lastDailyRotation returns the most recent daily rotation time at or before now
+func (s *service) lastDailyRotation(now time.Time) time.Time {
+	location := s.sessionRotation.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	local := now.In(location)
+	hour := int(s.sessionRotation.DailyAt / time.Hour)
+	minute := int(s.sessionRotation.DailyAt % time.Hour / time.Minute)
+
+	rotation := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, location)
+	if local.Before(rotation) {
+		// Today's rotation hasn't happened yet, so the last one was yesterday
+		rotation = time.Date(local.Year(), local.Month(), local.Day()-1, hour, minute, 0, 0, location)
+	}
+
+	return rotation
+}
+
+// sessionStandings converts leaderboard entries into event standings
+func sessionStandings(entries []LeaderboardEntry) []*events.SessionStanding {
+	standings := make([]*events.SessionStanding, 0, len(entries))
+	for _, entry := range entries {
+		standings = append(standings, &events.SessionStanding{
+			PlayerID:         entry.PlayerID,
+			PlayerName:       entry.PlayerName,
+			DrinkCount:       entry.DrinkCount,
+			PaidCount:        entry.PaidCount,
+			SocialCount:      entry.SocialCount,
+			ForgivenCount:    entry.ForgivenCount,
+			DesignatedDriver: entry.DesignatedDriver,
+			DrinksPerHour:    entry.DrinksPerHour,
+			LongestDryStreak: entry.LongestDryStreak,
+		})
+	}
+	return standings
 }
 
 // CreateSession creates a new drinking session for a channel
@@ -72,21 +316,17 @@ func (s *service) CreateSession(ctx context.Context, input *CreateSessionInput)
 		return nil, errors.New("channel ID is required")
 	}
 
-	// Extract the guild ID from the channel ID
-	guildID := s.extractGuildIDFromChannel(ctx, input.ChannelID)
-	if guildID == "" {
-		return nil, errors.New("failed to extract guild ID from channel")
-	}
-
 	// Create a new session using the repository
 	sessionOutput, err := s.drinkLedgerRepo.CreateSession(ctx, &ledgerRepo.CreateSessionInput{
-		GuildID:   guildID,
+		GuildID:   sessionScope(input.GuildID, input.ChannelID),
 		CreatedBy: input.CreatedBy,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
+	s.publishLedgerChanged(ctx, input.GuildID, input.ChannelID, sessionOutput.Session.ID, "session_started")
+
 	return &CreateSessionOutput{
 		Success: true,
 		Session: sessionOutput.Session,
@@ -99,6 +339,9 @@ func (s *service) GetSessionLeaderboard(ctx context.Context, input *GetSessionLe
 		return nil, errors.New("input cannot be nil")
 	}
 
+	// Leaderboards are read from a replica if there is one, unless the caller needs the primary
+	ctx = replica.WithReplicaReads(ctx)
+
 	var sessionID string
 	var session *models.Session
 
@@ -108,15 +351,9 @@ func (s *service) GetSessionLeaderboard(ctx context.Context, input *GetSessionLe
 		// We don't have a direct method to get a session by ID, so we'll need to use what we have
 		// This is a limitation in the current API
 	} else if input.ChannelID != "" {
-		// Extract the guild ID from the channel ID
-		guildID := s.extractGuildIDFromChannel(ctx, input.ChannelID)
-		if guildID == "" {
-			return nil, errors.New("failed to extract guild ID from channel")
-		}
-
 		// Get the current session for the guild
 		currentSessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
-			GuildID: guildID,
+			GuildID: sessionScope(input.GuildID, input.ChannelID),
 		})
 		
 		if err != nil || currentSessionOutput.Session == nil {
@@ -128,8 +365,8 @@ func (s *service) GetSessionLeaderboard(ctx context.Context, input *GetSessionLe
 			}, nil
 		}
 		
-		sessionID = currentSessionOutput.Session.ID
-		session = currentSessionOutput.Session
+		session = s.rotateSessionIfDue(ctx, input.GuildID, input.ChannelID, currentSessionOutput.Session)
+		sessionID = session.ID
 		
 		// Log the session details for debugging
 		log.Printf("GetSessionLeaderboard: Found session %s with CreatedAt %v", 
@@ -138,67 +375,47 @@ func (s *service) GetSessionLeaderboard(ctx context.Context, input *GetSessionLe
 		return nil, errors.New("either channel ID or session ID must be provided")
 	}
 
+	scope := sessionScope(input.GuildID, input.ChannelID)
+	if scope == "" {
+		return nil, errors.New("guild or channel ID is required")
+	}
+
+	return s.sessionLeaderboard(ctx, sessionID, session, scope)
+}
+
+// sessionLeaderboard builds the leaderboard of a session from its drink records. Pacing stats are only worked out
+// when the session itself is known, not just its ID. scope is the guild, or channel, the session has to belong to.
+func (s *service) sessionLeaderboard(ctx context.Context, sessionID string, session *models.Session, scope string) (*GetSessionLeaderboardOutput, error) {
 	// Get all drink records for this session
 	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
 		SessionID: sessionID,
+		GuildID:   scope,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get drink records: %w", err)
-	}
-
-	// Build maps to track drinks and payment status
-	drinkCounts := make(map[string]int)    // Total drinks owed
-	paidCounts := make(map[string]int)     // Drinks paid
-	playerNames := make(map[string]string) // Player names cache
-
-	// Process all drink records
-	for _, record := range drinkRecords.Records {
-		drinkCounts[record.ToPlayerID]++
-		if record.Paid {
-			paidCounts[record.ToPlayerID]++
+		if errors.Is(err, ledgerRepo.ErrSessionNotInGuild) {
+			return nil, ErrSessionNotFound
 		}
+		return nil, fmt.Errorf("failed to get drink records: %w", err)
 	}
 
-	// Create leaderboard entries
-	var entries []LeaderboardEntry
-	for playerID, drinkCount := range drinkCounts {
-		// Try to get player name from cache
-		playerName, ok := playerNames[playerID]
-		if !ok {
-			// If not in cache, try to get from repository
-			player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
-				PlayerID: playerID,
-			})
-			if err == nil && player != nil {
-				playerName = player.Name
-				playerNames[playerID] = playerName
-			} else {
-				playerName = "Unknown Player"
-			}
-		}
+	entries := buildSessionLeaderboard(session, drinkRecords.Records, s.playerNames(ctx, nil))
 
-		entries = append(entries, LeaderboardEntry{
-			PlayerID:   playerID,
-			PlayerName: playerName,
-			DrinkCount: drinkCount,
-			PaidCount:  paidCounts[playerID],
-		})
+	// Paces are over the time the session has been running, leaving out its breaks
+	var activeDuration time.Duration
+	if session != nil {
+		activeDuration = setPacingStats(entries, session, drinkRecords.Records, s.clock.Now())
 	}
 
-	// Sort entries by drink count (most drinks first)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].DrinkCount > entries[j].DrinkCount
-	})
-
 	// If we have a session ID but no session object (from direct ID lookup), create a minimal session
 	if session == nil && sessionID != "" {
 		session = &models.Session{ID: sessionID}
 	}
 
 	return &GetSessionLeaderboardOutput{
-		Success: true,
-		Session: session,
-		Entries: entries,
+		Success:        true,
+		Session:        session,
+		Entries:        entries,
+		ActiveDuration: activeDuration,
 	}, nil
 }
 
@@ -212,21 +429,31 @@ func (s *service) StartNewSession(ctx context.Context, input *StartNewSessionInp
 		return nil, errors.New("channel ID cannot be empty")
 	}
 
-	// Extract the guild ID from the channel ID
-	guildID := s.extractGuildIDFromChannel(ctx, input.ChannelID)
-	if guildID == "" {
-		return nil, errors.New("failed to extract guild ID from channel")
+	goals, err := sessionGoals(input.Goals)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the session using CreateSession
 	sessionOutput, err := s.CreateSession(ctx, &CreateSessionInput{
 		ChannelID: input.ChannelID,
+		GuildID:   input.GuildID,
 		CreatedBy: input.CreatorID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Play it with the challenges picked
+	if len(goals) > 0 {
+		sessionOutput.Session.Goals = goals
+		if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+			Session: sessionOutput.Session,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to save session challenges: %w", err)
+		}
+	}
+
 	return &StartNewSessionOutput{
 		Success:   true,
 		Session:   sessionOutput.Session,
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// defaultSessionGoalTargets are the targets of challenges picked without one
+var defaultSessionGoalTargets = map[models.SessionGoalType]int{
+	models.SessionGoalFirstToCrits: 3,
+	models.SessionGoalSurviveGames: 5,
+}
+
+// sessionGoals checks the challenges a session is to be played with, giving those without a target the default one
+func sessionGoals(goals []*models.SessionGoal) ([]*models.SessionGoal, error) {
+	checked := make([]*models.SessionGoal, 0, len(goals))
+	for _, goal := range goals {
+		target, ok := defaultSessionGoalTargets[goal.Type]
+		if !ok {
+			return nil, ErrInvalidSessionGoal
+		}
+		if goal.Target > 0 {
+			target = goal.Target
+		}
+		checked = append(checked, &models.SessionGoal{Type: goal.Type, Target: target})
+	}
+	return checked, nil
+}
+
+// sessionGoalCompletion is a player completing one of the session's challenges
+type sessionGoalCompletion struct {
+	goal     *models.SessionGoal
+	playerID string
+}
+
+// countCriticalHitGoals counts a player's critical hit towards the session's races to roll them
+func (s *service) countCriticalHitGoals(ctx context.Context, game *models.Game, participant *models.Participant) {
+	s.advanceSessionGoals(ctx, game, models.SessionGoalFirstToCrits, func(progress map[string]int) {
+		progress[participant.PlayerID]++
+	})
+}
+
+// countSurvivedGameGoals counts a finished game towards the session's challenges to go without a critical fail,
+// everyone who failed in it starts again
+func (s *service) countSurvivedGameGoals(ctx context.Context, game *models.Game) {
+	rules := s.rulesFor(game)
+	s.advanceSessionGoals(ctx, game, models.SessionGoalSurviveGames, func(progress map[string]int) {
+		for _, participant := range game.Participants {
+			if participant.RollTime == nil {
+				continue
+			}
+			if rules.IsCriticalFail(participant.RollValue) && !rules.IsCriticalHit(participant.RollValue) {
+				progress[participant.PlayerID] = 0
+				continue
+			}
+			progress[participant.PlayerID]++
+		}
+	})
+}
+
+// advanceSessionGoals moves the session's challenges of the type given along with step, which counts what happened
+// towards each player's progress. Every player who reached a challenge's target is announced and earns it as an
+// achievement.
+func (s *service) advanceSessionGoals(ctx context.Context, game *models.Game, goalType models.SessionGoalType, step func(progress map[string]int)) {
+	// Only a session already running can have challenges, there's no need to start one
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(game.GuildID, game.ChannelID),
+	})
+	if err != nil || sessionOutput.Session == nil || len(sessionOutput.Session.Goals) == 0 {
+		return
+	}
+	session := sessionOutput.Session
+
+	var advanced bool
+	var completions []sessionGoalCompletion
+	for _, goal := range session.Goals {
+		if goal.Type != goalType || goal.IsOver() {
+			continue
+		}
+		if goal.Progress == nil {
+			goal.Progress = make(map[string]int)
+		}
+		step(goal.Progress)
+		advanced = true
+
+		playerIDs := make([]string, 0, len(goal.Progress))
+		for playerID := range goal.Progress {
+			playerIDs = append(playerIDs, playerID)
+		}
+		sort.Strings(playerIDs)
+
+		for _, playerID := range playerIDs {
+			if goal.Progress[playerID] < goal.Target || goal.HasCompleted(playerID) || goal.IsOver() {
+				continue
+			}
+			goal.CompletedBy = append(goal.CompletedBy, playerID)
+			completions = append(completions, sessionGoalCompletion{goal: goal, playerID: playerID})
+		}
+	}
+	if !advanced {
+		return
+	}
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		log.Printf("Error saving session challenges: %v", err)
+		return
+	}
+
+	for _, completion := range completions {
+		playerName := completion.playerID
+		if participant := game.GetParticipant(completion.playerID); participant != nil {
+			playerName = participant.PlayerName
+		}
+
+		s.awardAchievement(ctx, completion.playerID, &models.Achievement{
+			Name:      completion.goal.Name(),
+			SessionID: session.ID,
+			EarnedAt:  s.clock.Now(),
+		})
+
+		s.eventBus.Publish(ctx, &events.Event{
+			Type:      events.TypeSessionGoalCompleted,
+			ChannelID: game.ChannelID,
+			GuildID:   game.GuildID,
+			GameID:    game.ID,
+			SessionID: session.ID,
+			Timestamp: s.clock.Now(),
+			Payload: &events.SessionGoalCompletedPayload{
+				PlayerID:   completion.playerID,
+				PlayerName: playerName,
+				Goal:       completion.goal.Name(),
+			},
+		})
+	}
+}
+
+// awardAchievement records an achievement the player earned
+func (s *service) awardAchievement(ctx context.Context, playerID string, achievement *models.Achievement) {
+	player, err := s.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil {
+		log.Printf("Error getting player %s for their achievement: %v", playerID, err)
+		return
+	}
+
+	player.Achievements = append(player.Achievements, achievement)
+	if err := s.playerRepo.SavePlayer(ctx, &playerRepo.SavePlayerInput{
+		Player: player,
+	}); err != nil {
+		log.Printf("Error saving achievement for player %s: %v", playerID, err)
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// SessionGoalsTestSuite tests session challenges against the real Redis repositories
+type SessionGoalsTestSuite struct {
+	serviceHarness
+	completed []*events.SessionGoalCompletedPayload
+}
+
+func (s *SessionGoalsTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testGuildID = "goals-guild"
+	s.testChannelID = "goals-channel"
+
+	s.completed = nil
+	s.eventBus.Subscribe(events.TypeSessionGoalCompleted, func(_ context.Context, event *events.Event) {
+		s.completed = append(s.completed, event.Payload.(*events.SessionGoalCompletedPayload))
+	})
+}
+
+func TestSessionGoalsTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionGoalsTestSuite))
+}
+
+func (s *SessionGoalsTestSuite) startSession(goals ...*models.SessionGoal) *models.Session {
+	output, err := s.gameService.StartNewSession(s.ctx, &StartNewSessionInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		CreatorID: "admin",
+		Goals:     goals,
+	})
+	s.Require().NoError(err)
+	return output.Session
+}
+
+// playGame plays a game between alice and bob, alice giving any critical hit's drink to bob
+func (s *SessionGoalsTestSuite) playGame(aliceRoll, bobRoll int) {
+	gameID := s.startGame("alice", "bob")
+	s.roll(gameID, "bob", bobRoll)
+
+	if s.roll(gameID, "alice", aliceRoll).IsCriticalHit {
+		_, err := s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{
+			GameID:       gameID,
+			FromPlayerID: "alice",
+			ToPlayerID:   "bob",
+			Reason:       DrinkReasonCriticalHit,
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *SessionGoalsTestSuite) TestFirstToCrits() {
+	s.startSession(&models.SessionGoal{Type: models.SessionGoalFirstToCrits, Target: 2})
+
+	s.playGame(6, 3)
+	s.Empty(s.completed)
+
+	s.playGame(6, 4)
+	s.Require().Len(s.completed, 1)
+	s.Equal("alice", s.completed[0].PlayerID)
+	s.Equal("First to 2 crits", s.completed[0].Goal)
+
+	// The race is won, alice's next critical hit doesn't win it again
+	s.playGame(6, 2)
+	s.Len(s.completed, 1)
+
+	player, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Require().Len(player.Achievements, 1)
+	s.Equal("First to 2 crits", player.Achievements[0].Name)
+}
+
+func (s *SessionGoalsTestSuite) TestSurviveGames() {
+	s.startSession(&models.SessionGoal{Type: models.SessionGoalSurviveGames, Target: 2})
+
+	// bob fails the first game and starts again, alice survives both
+	s.playGame(3, 1)
+	s.playGame(4, 5)
+	s.Require().Len(s.completed, 1)
+	s.Equal("alice", s.completed[0].PlayerID)
+
+	s.playGame(4, 5)
+	s.Require().Len(s.completed, 2)
+	s.Equal("bob", s.completed[1].PlayerID)
+}
+
+func (s *SessionGoalsTestSuite) TestDefaultTargetAndUnknownGoals() {
+	session := s.startSession(&models.SessionGoal{Type: models.SessionGoalSurviveGames})
+	s.Require().Len(session.Goals, 1)
+	s.Equal(5, session.Goals[0].Target)
+
+	_, err := s.gameService.StartNewSession(s.ctx, &StartNewSessionInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		Goals:     []*models.SessionGoal{{Type: "drink_the_most"}},
+	})
+	s.ErrorIs(err, ErrInvalidSessionGoal)
+}
+
+func (s *SessionGoalsTestSuite) TestSessionsWithoutGoals() {
+	s.startSession()
+	s.playGame(6, 1)
+	s.Empty(s.completed)
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"sort"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// minDrinkRateDuration is how long a session has to have been running before drink rates mean anything
+const minDrinkRateDuration = 15 * time.Minute
+
+// setPacingStats sets each player's drinks per hour and longest dry streak from when they were given their drinks,
+// over the time the session has been running up to now or until it ended, leaving out its breaks. Nothing is set
+// until the session has been running long enough. It returns how long the session has been running.
+func setPacingStats(entries []LeaderboardEntry, session *models.Session, records []*models.DrinkLedger, now time.Time) time.Duration {
+	active := session.ActiveDuration(now)
+	if active < minDrinkRateDuration {
+		return active
+	}
+
+	given := make(map[string][]time.Time)
+	for _, record := range records {
+		if record.Social || record.Forgiven {
+			continue
+		}
+		given[record.ToPlayerID] = append(given[record.ToPlayerID], record.Timestamp)
+	}
+
+	end := session.EndTime(now)
+	for i := range entries {
+		entries[i].DrinksPerHour = float64(entries[i].DrinkCount) / active.Hours()
+		entries[i].LongestDryStreak = longestDryStreak(session, given[entries[i].PlayerID], end)
+	}
+
+	return active
+}
+
+// longestDryStreak returns the longest the session ran without a drink being given, from its start through each
+// of the drinks until its end
+func longestDryStreak(session *models.Session, given []time.Time, end time.Time) time.Duration {
+	sort.Slice(given, func(i, j int) bool {
+		return given[i].Before(given[j])
+	})
+
+	var longest time.Duration
+	last := session.CreatedAt
+	for _, at := range append(given, end) {
+		if streak := session.ActiveBetween(last, at); streak > longest {
+			longest = streak
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+
+	return longest
+}
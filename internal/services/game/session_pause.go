@@ -0,0 +1,88 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// PauseSession pauses the channel's session for a break. No games are started and nobody is reminded of drinks until
+// it's resumed, and the break is left out of the session's time-based stats. Games already under way carry on.
+func (s *service) PauseSession(ctx context.Context, input *PauseSessionInput) (*PauseSessionOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	if session.IsPaused() {
+		return nil, ErrSessionPaused
+	}
+
+	session.Pauses = append(session.Pauses, &models.SessionPause{
+		PausedBy: input.PlayerID,
+		Reason:   input.Reason,
+		Start:    s.clock.Now(),
+	})
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return &PauseSessionOutput{
+		Session: session,
+	}, nil
+}
+
+// ResumeSession ends the break the channel's session was paused for
+func (s *service) ResumeSession(ctx context.Context, input *ResumeSessionInput) (*ResumeSessionOutput, error) {
+	if input == nil || input.ChannelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil || sessionOutput.Session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	session := sessionOutput.Session
+	pause := session.CurrentPause()
+	if pause == nil {
+		return nil, ErrSessionNotPaused
+	}
+
+	now := s.clock.Now()
+	pause.End = &now
+
+	if err := s.drinkLedgerRepo.UpdateSession(ctx, &ledgerRepo.UpdateSessionInput{
+		Session: session,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return &ResumeSessionOutput{
+		Session:   session,
+		PausedFor: now.Sub(pause.Start),
+	}, nil
+}
+
+// sessionPaused reports whether the channel's current session is paused for a break
+func (s *service) sessionPaused(ctx context.Context, guildID, channelID string) bool {
+	sessionOutput, err := s.drinkLedgerRepo.GetCurrentSession(ctx, &ledgerRepo.GetCurrentSessionInput{
+		GuildID: sessionScope(guildID, channelID),
+	})
+	if err != nil || sessionOutput.Session == nil {
+		return false
+	}
+	return sessionOutput.Session.IsPaused()
+}
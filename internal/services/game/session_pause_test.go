@@ -0,0 +1,150 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+// SessionPauseTestSuite tests pausing sessions for a break against the real Redis repositories
+type SessionPauseTestSuite struct {
+	serviceHarness
+	clock *clock.Fake
+}
+
+func (s *SessionPauseTestSuite) SetupTest() {
+	// Sessions are stamped with the real time when they're created
+	s.clock = clock.NewFake(time.Now())
+
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+		cfg.DrinkReminderAfter = 30 * time.Minute
+	})
+	s.testChannelID = "pause-channel"
+	s.testGuildID = "pause-guild"
+}
+
+func TestSessionPauseTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionPauseTestSuite))
+}
+
+func (s *SessionPauseTestSuite) createGame() (string, error) {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		GuildID:     s.testGuildID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	if err != nil {
+		return "", err
+	}
+	return createOutput.GameID, nil
+}
+
+// playGame plays a game where bob rolls lowest and is given a drink
+func (s *SessionPauseTestSuite) playGame() {
+	s.serviceHarness.playGame([]string{"alice", "bob"}, []int{4, 2})
+}
+
+func (s *SessionPauseTestSuite) pause() (*PauseSessionOutput, error) {
+	return s.gameService.PauseSession(s.ctx, &PauseSessionInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		PlayerID:  "alice",
+		Reason:    "food break",
+	})
+}
+
+func (s *SessionPauseTestSuite) resume() (*ResumeSessionOutput, error) {
+	return s.gameService.ResumeSession(s.ctx, &ResumeSessionInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+		PlayerID:  "alice",
+	})
+}
+
+func (s *SessionPauseTestSuite) TestPauseStopsNewGames() {
+	pauseOutput, err := s.pause()
+	s.Require().NoError(err)
+	s.True(pauseOutput.Session.IsPaused())
+	s.Equal("food break", pauseOutput.Session.CurrentPause().Reason)
+
+	_, err = s.pause()
+	s.ErrorIs(err, ErrSessionPaused)
+
+	_, err = s.createGame()
+	s.ErrorIs(err, ErrSessionPaused)
+
+	s.clock.Advance(45 * time.Minute)
+	resumeOutput, err := s.resume()
+	s.Require().NoError(err)
+	s.Equal(45*time.Minute, resumeOutput.PausedFor)
+	s.False(resumeOutput.Session.IsPaused())
+
+	_, err = s.resume()
+	s.ErrorIs(err, ErrSessionNotPaused)
+
+	_, err = s.createGame()
+	s.NoError(err)
+}
+
+func (s *SessionPauseTestSuite) TestResumeWithoutSession() {
+	_, err := s.resume()
+	s.ErrorIs(err, ErrSessionNotFound)
+}
+
+func (s *SessionPauseTestSuite) TestDrinksPerHourLeavesOutBreaks() {
+	s.playGame()
+
+	s.clock.Advance(30 * time.Minute)
+	_, err := s.pause()
+	s.Require().NoError(err)
+
+	s.clock.Advance(time.Hour)
+	_, err = s.resume()
+	s.Require().NoError(err)
+	s.clock.Advance(30 * time.Minute)
+
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.InDelta(time.Hour, output.ActiveDuration, float64(time.Second))
+	s.Require().Len(output.Entries, 1)
+	s.Equal("bob", output.Entries[0].PlayerID)
+	s.InDelta(1.0, output.Entries[0].DrinksPerHour, 0.01)
+	s.InDelta(time.Hour, output.Entries[0].LongestDryStreak, float64(time.Second), "the break isn't a dry streak")
+}
+
+func (s *SessionPauseTestSuite) TestDrinksPerHourNeedsTime() {
+	s.playGame()
+
+	output, err := s.gameService.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+		GuildID:   s.testGuildID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(output.Entries, 1)
+	s.Zero(output.Entries[0].DrinksPerHour, "a session just started has no meaningful rate")
+}
+
+func (s *SessionPauseTestSuite) TestNoDrinkRemindersWhilePaused() {
+	s.playGame()
+	_, err := s.pause()
+	s.Require().NoError(err)
+
+	s.clock.Advance(time.Hour)
+	output, err := s.gameService.CheckDrinkReminders(s.ctx, &CheckDrinkRemindersInput{})
+	s.Require().NoError(err)
+	s.Empty(output.RemindedPlayerIDs)
+
+	_, err = s.resume()
+	s.Require().NoError(err)
+
+	output, err = s.gameService.CheckDrinkReminders(s.ctx, &CheckDrinkRemindersInput{})
+	s.Require().NoError(err)
+	s.Equal([]string{"bob"}, output.RemindedPlayerIDs)
+}
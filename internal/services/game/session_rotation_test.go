@@ -0,0 +1,330 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// SessionRotationTestSuite tests automatic session rotation against the real Redis repositories
+type SessionRotationTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+	mu        sync.Mutex
+	rotated   []*events.Event
+
+	// now is the time the mocked clock reports
+	now time.Time
+}
+
+func (s *SessionRotationTestSuite) SetupTest() {
+	s.now = time.Now()
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+	})
+	s.testChannelID = "rotation-channel"
+
+	s.rotated = nil
+	s.eventBus.Subscribe(events.TypeSessionRotated, func(_ context.Context, event *events.Event) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.rotated = append(s.rotated, event)
+	})
+}
+
+func TestSessionRotationTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionRotationTestSuite))
+}
+
+// rotatingService creates a game service with the rotation config
+func (s *SessionRotationTestSuite) rotatingService(rotation *SessionRotationConfig) Service {
+	return s.newService(func(cfg *Config) {
+		cfg.Clock = s.mockClock
+		cfg.SessionRotation = rotation
+	})
+}
+
+// startSession starts a session in the test channel
+func (s *SessionRotationTestSuite) startSession(svc Service) string {
+	output, err := svc.StartNewSession(s.ctx, &StartNewSessionInput{
+		ChannelID: s.testChannelID,
+		CreatorID: "alice",
+	})
+	s.Require().NoError(err)
+	return output.SessionID
+}
+
+// addDrink records a drink for the player in the session at the given time
+func (s *SessionRotationTestSuite) addDrink(sessionID, playerID string, at time.Time) {
+	_, err := s.ledgerRepo.CreateDrinkRecord(s.ctx, &ledgerRepo.CreateDrinkRecordInput{
+		GameID:     "game-id",
+		ToPlayerID: playerID,
+		Reason:     models.DrinkReasonLowestRoll,
+		Timestamp:  at,
+		SessionID:  sessionID,
+	})
+	s.Require().NoError(err)
+}
+
+// currentSessionID returns the session the channel is using now, rotating it if due
+func (s *SessionRotationTestSuite) currentSessionID(svc Service) string {
+	output, err := svc.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+		ChannelID: s.testChannelID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(output.Session)
+	return output.Session.ID
+}
+
+func (s *SessionRotationTestSuite) TestNotConfigured() {
+	svc := s.rotatingService(nil)
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", s.now)
+
+	s.now = s.now.Add(72 * time.Hour)
+
+	s.Equal(sessionID, s.currentSessionID(svc))
+	s.Empty(s.rotated)
+}
+
+func (s *SessionRotationTestSuite) TestDaily_BeforeRotationTime() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Daily:    true,
+		DailyAt:  4 * time.Hour,
+		Location: time.UTC,
+	})
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", s.now)
+
+	s.Equal(sessionID, s.currentSessionID(svc))
+	s.Empty(s.rotated)
+}
+
+func (s *SessionRotationTestSuite) TestDaily_Rotates() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Daily:    true,
+		DailyAt:  4 * time.Hour,
+		Location: time.UTC,
+	})
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", s.now)
+	s.addDrink(sessionID, "bob", s.now)
+
+	// A day later the rotation time has certainly passed
+	s.now = s.now.Add(25 * time.Hour)
+
+	newSessionID := s.currentSessionID(svc)
+	s.NotEqual(sessionID, newSessionID)
+
+	s.Require().Len(s.rotated, 1)
+	event := s.rotated[0]
+	s.Equal(s.testChannelID, event.ChannelID)
+	s.Equal(newSessionID, event.SessionID)
+
+	payload, ok := event.Payload.(*events.SessionRotatedPayload)
+	s.Require().True(ok)
+	s.Equal(sessionID, payload.PreviousSessionID)
+	s.Equal(string(SessionRotationReasonDaily), payload.Reason)
+	s.Require().Len(payload.Standings, 1)
+	s.Equal("bob", payload.Standings[0].PlayerID)
+	s.Equal(2, payload.Standings[0].DrinkCount)
+}
+
+func (s *SessionRotationTestSuite) TestDaily_EmptySessionNotAnnounced() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Daily:    true,
+		DailyAt:  4 * time.Hour,
+		Location: time.UTC,
+	})
+	sessionID := s.startSession(svc)
+
+	s.now = s.now.Add(25 * time.Hour)
+
+	s.NotEqual(sessionID, s.currentSessionID(svc))
+	s.Empty(s.rotated)
+}
+
+func (s *SessionRotationTestSuite) TestInactivity() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Inactivity: 8 * time.Hour,
+	})
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", s.now.Add(7*time.Hour))
+
+	// Still within 8 hours of the last drink
+	s.now = s.now.Add(10 * time.Hour)
+	s.Equal(sessionID, s.currentSessionID(svc))
+
+	s.now = s.now.Add(6 * time.Hour)
+	s.NotEqual(sessionID, s.currentSessionID(svc))
+
+	s.Require().Len(s.rotated, 1)
+	payload, ok := s.rotated[0].Payload.(*events.SessionRotatedPayload)
+	s.Require().True(ok)
+	s.Equal(string(SessionRotationReasonInactivity), payload.Reason)
+}
+
+func (s *SessionRotationTestSuite) TestInactivity_ClosingPace() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Inactivity: 8 * time.Hour,
+	})
+	started := s.now
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", started.Add(time.Hour))
+	s.addDrink(sessionID, "bob", started.Add(3*time.Hour))
+
+	s.now = s.now.Add(12 * time.Hour)
+	s.NotEqual(sessionID, s.currentSessionID(svc))
+
+	// The session ended with its last drink, not when it was rotated
+	s.Require().Len(s.rotated, 1)
+	payload, ok := s.rotated[0].Payload.(*events.SessionRotatedPayload)
+	s.Require().True(ok)
+	s.WithinDuration(started, payload.StartedAt, time.Second)
+	s.True(started.Add(3 * time.Hour).Equal(payload.EndedAt))
+
+	s.Require().Len(payload.Standings, 1)
+	s.InDelta(2.0/3, payload.Standings[0].DrinksPerHour, 0.01)
+	s.Equal(2*time.Hour, payload.Standings[0].LongestDryStreak)
+}
+
+func (s *SessionRotationTestSuite) TestConcurrentReadersRotateOnce() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Daily:    true,
+		DailyAt:  4 * time.Hour,
+		Location: time.UTC,
+	})
+	sessionID := s.startSession(svc)
+	s.addDrink(sessionID, "bob", s.now)
+
+	s.now = s.now.Add(25 * time.Hour)
+
+	// Everyone reading the due session at once ends up in the same new session
+	sessionIDs := make([]string, 5)
+	var wg sync.WaitGroup
+	for i := range sessionIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			output, err := svc.GetSessionLeaderboard(s.ctx, &GetSessionLeaderboardInput{
+				ChannelID: s.testChannelID,
+			})
+			if err == nil && output.Session != nil {
+				sessionIDs[i] = output.Session.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	current, err := s.ledgerRepo.GetCurrentSession(s.ctx, &ledgerRepo.GetCurrentSessionInput{GuildID: s.testChannelID})
+	s.Require().NoError(err)
+	s.NotEqual(sessionID, current.Session.ID)
+	for _, id := range sessionIDs {
+		s.Equal(current.Session.ID, id)
+	}
+
+	// And the rotation is only announced once
+	s.Require().Len(s.rotated, 1)
+	s.Equal(current.Session.ID, s.rotated[0].SessionID)
+}
+
+func (s *SessionRotationTestSuite) TestRotateDueSessions() {
+	svc := s.rotatingService(&SessionRotationConfig{
+		Inactivity: 8 * time.Hour,
+	})
+	sessionID := s.startSession(svc)
+
+	s.Require().NoError(s.gameRepo.SaveGame(s.ctx, &gameRepo.SaveGameInput{Game: &models.Game{
+		ID:        "game-id",
+		ChannelID: s.testChannelID,
+		Status:    models.GameStatusCompleted,
+	}}))
+	s.addDrink(sessionID, "bob", s.now.Add(time.Hour))
+
+	// Not quiet long enough yet
+	output, err := svc.RotateDueSessions(s.ctx, &RotateDueSessionsInput{})
+	s.Require().NoError(err)
+	s.Empty(output.RotatedSessionIDs)
+
+	// Nobody reads the channel's session again, but its closing leaderboard is still posted there
+	s.now = s.now.Add(12 * time.Hour)
+	output, err = svc.RotateDueSessions(s.ctx, &RotateDueSessionsInput{})
+	s.Require().NoError(err)
+	s.Equal([]string{sessionID}, output.RotatedSessionIDs)
+
+	s.Require().Len(s.rotated, 1)
+	s.Equal(s.testChannelID, s.rotated[0].ChannelID)
+	payload, ok := s.rotated[0].Payload.(*events.SessionRotatedPayload)
+	s.Require().True(ok)
+	s.Equal(sessionID, payload.PreviousSessionID)
+	s.Equal(string(SessionRotationReasonInactivity), payload.Reason)
+}
+
+func (s *SessionRotationTestSuite) TestInvalidRotationTime() {
+	_, err := New(&Config{
+		GameRepo:        s.gameRepo,
+		PlayerRepo:      s.playerRepo,
+		DrinkLedgerRepo: s.ledgerRepo,
+		DiceRoller:      s.mockDiceRoller,
+		SessionRotation: &SessionRotationConfig{Daily: true, DailyAt: 25 * time.Hour},
+	})
+	s.Error(err)
+}
+
+func TestLastDailyRotation(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "after rotation time",
+			location: time.UTC,
+			now:      time.Date(2025, 4, 19, 5, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 4, 19, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before rotation time",
+			location: time.UTC,
+			now:      time.Date(2025, 4, 19, 3, 59, 0, 0, time.UTC),
+			expected: time.Date(2025, 4, 18, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "in another time zone",
+			location: chicago,
+			now:      time.Date(2025, 4, 19, 8, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 4, 18, 4, 0, 0, 0, chicago),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &service{sessionRotation: &SessionRotationConfig{
+				Daily:    true,
+				DailyAt:  4 * time.Hour,
+				Location: tt.location,
+			}}
+
+			actual := svc.lastDailyRotation(tt.now)
+			if !actual.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// sideBetTTL is how long a side bet stands waiting for the player to roll
+const sideBetTTL = 10 * time.Minute
+
+// PlaceSideBet bets a drink on the outcome of another player's next roll in the main round of a game.
+// Over and under are measured against the middle of the die.
+func (s *service) PlaceSideBet(ctx context.Context, input *PlaceSideBetInput) (*PlaceSideBetOutput, error) {
+	if input == nil || input.GameID == "" || input.BettorID == "" || input.TargetPlayerID == "" {
+		return nil, errors.New("game, bettor and target player IDs are required")
+	}
+
+	if input.BettorID == input.TargetPlayerID {
+		return nil, ErrCannotBetOnSelf
+	}
+
+	switch input.Outcome {
+	case models.SideBetCriticalHit, models.SideBetCriticalFail, models.SideBetOver, models.SideBetUnder:
+	default:
+		return nil, ErrInvalidSideBet
+	}
+
+	game, err := s.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: input.GameID,
+	})
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	// Bets are only taken on the main round, roll-offs are settled too quickly to bet on
+	if game.Status == models.GameStatusWaiting {
+		return nil, ErrGameNotStarted
+	}
+	if game.Status != models.GameStatusActive {
+		return nil, fmt.Errorf("%w: game status is %s", ErrInvalidGameState, game.Status)
+	}
+
+	target := game.GetParticipant(input.TargetPlayerID)
+	if target == nil {
+		return nil, ErrTargetNotInGame
+	}
+	if target.RollTime != nil {
+		return nil, ErrPlayerAlreadyRolled
+	}
+
+	now := s.clock.Now()
+	bet := &models.SideBet{
+		ID:               s.uuid.NewUUID(),
+		GameID:           game.ID,
+		ChannelID:        game.ChannelID,
+		BettorID:         input.BettorID,
+		BettorName:       input.BettorName,
+		TargetPlayerID:   target.PlayerID,
+		TargetPlayerName: target.PlayerName,
+		Outcome:          input.Outcome,
+		Line:             s.rulesFor(game).DiceSides / 2,
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(sideBetTTL),
+	}
+
+	err = s.gameRepo.CreateSideBet(ctx, &gameRepo.CreateSideBetInput{
+		Bet: bet,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrSideBetAlreadyPlaced) {
+			return nil, ErrSideBetAlreadyPlaced
+		}
+		return nil, fmt.Errorf("failed to save side bet: %w", err)
+	}
+
+	return &PlaceSideBetOutput{
+		Bet: bet,
+	}, nil
+}
+
+// settleSideBets settles the bets on a player's roll: the player drinks for each bet that won and the bettor
+// drinks for each bet that lost. Bets that expired before the roll are dropped.
+func (s *service) settleSideBets(ctx context.Context, game *models.Game, participant *models.Participant, rules models.GameRules, rolledAt time.Time) {
+	if game.Status != models.GameStatusActive {
+		return
+	}
+
+	output, err := s.gameRepo.TakeSideBets(ctx, &gameRepo.TakeSideBetsInput{
+		GameID:         game.ID,
+		TargetPlayerID: participant.PlayerID,
+	})
+	if err != nil {
+		log.Printf("Error taking side bets on %s's roll: %v", participant.PlayerID, err)
+		return
+	}
+	if len(output.Bets) == 0 {
+		return
+	}
+
+	session := s.getSessionForGame(ctx, game)
+	var settled []*events.SettledSideBet
+	for _, bet := range output.Bets {
+		if rolledAt.After(bet.ExpiresAt) {
+			continue
+		}
+
+		won := bet.Wins(participant.RollValue, rules)
+		drink := &ledgerRepo.CreateDrinkRecordInput{
+			GameID:       game.ID,
+			FromPlayerID: participant.PlayerID,
+			ToPlayerID:   bet.BettorID,
+			Reason:       models.DrinkReasonSideBet,
+			Timestamp:    rolledAt,
+		}
+		if won {
+			drink.FromPlayerID, drink.ToPlayerID = bet.BettorID, participant.PlayerID
+		}
+
+		if _, err := s.recordDrink(ctx, game, session, drink, 0); err != nil {
+			log.Printf("Error saving side bet drink record: %v", err)
+			continue
+		}
+
+		settled = append(settled, &events.SettledSideBet{
+			BettorID:   bet.BettorID,
+			BettorName: bet.BettorName,
+			Label:      bet.Label(),
+			Won:        won,
+		})
+	}
+
+	if len(settled) == 0 {
+		return
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeSideBetsSettled,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: rolledAt,
+		Payload: &events.SideBetsSettledPayload{
+			PlayerID:   participant.PlayerID,
+			PlayerName: participant.PlayerName,
+			RollValue:  participant.RollValue,
+			Bets:       settled,
+		},
+	})
+}
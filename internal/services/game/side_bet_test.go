@@ -0,0 +1,136 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// SideBetTestSuite tests side bets against the real Redis repositories
+type SideBetTestSuite struct {
+	serviceHarness
+	mockClock *clockMocks.MockClock
+	settled   []*events.SideBetsSettledPayload
+
+	// now is the time the mocked clock reports
+	now time.Time
+
+	testGameID string
+}
+
+func (s *SideBetTestSuite) SetupTest() {
+	s.now = time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC)
+	s.setUpService(func(cfg *Config) {
+		s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+		s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+		cfg.Clock = s.mockClock
+	})
+	s.testChannelID = "side-bet-channel"
+
+	s.settled = nil
+	s.eventBus.Subscribe(events.TypeSideBetsSettled, func(_ context.Context, event *events.Event) {
+		s.settled = append(s.settled, event.Payload.(*events.SideBetsSettledPayload))
+	})
+
+	// Alice and bob are playing, carol is watching
+	s.testGameID = s.startGame("alice", "bob")
+}
+
+func TestSideBetTestSuite(t *testing.T) {
+	suite.Run(t, new(SideBetTestSuite))
+}
+
+// bet places a bet on the target's roll
+func (s *SideBetTestSuite) bet(bettorID, targetID string, outcome models.SideBetOutcome) (*PlaceSideBetOutput, error) {
+	return s.gameService.PlaceSideBet(s.ctx, &PlaceSideBetInput{
+		GameID:         s.testGameID,
+		BettorID:       bettorID,
+		BettorName:     bettorID,
+		TargetPlayerID: targetID,
+		Outcome:        outcome,
+	})
+}
+
+// sideBetDrinks returns the side bet drinks given in the game
+func (s *SideBetTestSuite) sideBetDrinks() []*models.DrinkLedger {
+	output, err := s.gameService.GetDrinkRecords(s.ctx, &GetDrinkRecordsInput{GameID: s.testGameID})
+	s.Require().NoError(err)
+
+	var drinks []*models.DrinkLedger
+	for _, record := range output.Records {
+		if record.Reason == models.DrinkReasonSideBet {
+			drinks = append(drinks, record)
+		}
+	}
+	return drinks
+}
+
+func (s *SideBetTestSuite) TestWinningBetMakesThePlayerDrink() {
+	output, err := s.bet("carol", "bob", models.SideBetOver)
+	s.Require().NoError(err)
+	s.Equal(3, output.Bet.Line)
+	s.Equal("bob", output.Bet.TargetPlayerName)
+
+	s.roll(s.testGameID, "bob", 5)
+
+	drinks := s.sideBetDrinks()
+	s.Require().Len(drinks, 1)
+	s.Equal("carol", drinks[0].FromPlayerID)
+	s.Equal("bob", drinks[0].ToPlayerID)
+
+	s.Require().Len(s.settled, 1)
+	s.Equal("bob", s.settled[0].PlayerID)
+	s.Require().Len(s.settled[0].Bets, 1)
+	s.True(s.settled[0].Bets[0].Won)
+	s.Equal("over 3", s.settled[0].Bets[0].Label)
+}
+
+func (s *SideBetTestSuite) TestLosingBetMakesTheBettorDrink() {
+	_, err := s.bet("alice", "bob", models.SideBetCriticalHit)
+	s.Require().NoError(err)
+
+	s.roll(s.testGameID, "bob", 3)
+
+	drinks := s.sideBetDrinks()
+	s.Require().Len(drinks, 1)
+	s.Equal("bob", drinks[0].FromPlayerID)
+	s.Equal("alice", drinks[0].ToPlayerID)
+	s.False(s.settled[0].Bets[0].Won)
+}
+
+func (s *SideBetTestSuite) TestExpiredBetsAreDropped() {
+	_, err := s.bet("carol", "bob", models.SideBetUnder)
+	s.Require().NoError(err)
+
+	s.now = s.now.Add(sideBetTTL + time.Second)
+	s.roll(s.testGameID, "bob", 2)
+
+	s.Empty(s.sideBetDrinks())
+	s.Empty(s.settled)
+}
+
+func (s *SideBetTestSuite) TestInvalidBets() {
+	_, err := s.bet("bob", "bob", models.SideBetOver)
+	s.ErrorIs(err, ErrCannotBetOnSelf)
+
+	_, err = s.bet("carol", "bob", models.SideBetOutcome("jackpot"))
+	s.ErrorIs(err, ErrInvalidSideBet)
+
+	_, err = s.bet("carol", "dave", models.SideBetOver)
+	s.ErrorIs(err, ErrTargetNotInGame)
+
+	_, err = s.bet("carol", "bob", models.SideBetOver)
+	s.Require().NoError(err)
+	_, err = s.bet("carol", "bob", models.SideBetUnder)
+	s.ErrorIs(err, ErrSideBetAlreadyPlaced)
+
+	// Once the player has rolled it's too late
+	s.roll(s.testGameID, "alice", 4)
+	_, err = s.bet("carol", "alice", models.SideBetOver)
+	s.ErrorIs(err, ErrPlayerAlreadyRolled)
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// CallSocialRound gives everyone playing the channel's game a drink at once. The drinks are saved together and
+// announced with a single event rather than one for each of them.
+func (s *service) CallSocialRound(ctx context.Context, input *CallSocialRoundInput) (*CallSocialRoundOutput, error) {
+	if input == nil || input.ChannelID == "" || input.PlayerID == "" {
+		return nil, errors.New("channel and player IDs are required")
+	}
+
+	game, err := s.gameRepo.GetActiveGameByChannel(ctx, &gameRepo.GetActiveGameByChannelInput{
+		ChannelID: input.ChannelID,
+	})
+	if err != nil {
+		if errors.Is(err, gameRepo.ErrGameNotFound) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("failed to get active game by channel: %w", err)
+	}
+
+	// Everyone in the game drinks, not just those left in a roll-off
+	rootGameID := s.rootGameID(ctx, game)
+	if rootGameID != game.ID {
+		if game, err = s.getGame(ctx, rootGameID); err != nil {
+			return nil, err
+		}
+	}
+
+	if game.Status == models.GameStatusWaiting {
+		return nil, ErrGameNotStarted
+	}
+
+	var records []*models.DrinkLedger
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		records, err = s.recordSocialRound(ctx, game, input.PlayerID, input.PlayerName, 0, s.clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CallSocialRoundOutput{
+		Records:    records,
+		RootGameID: rootGameID,
+		ChannelID:  game.ChannelID,
+	}, nil
+}
+
+// recordSocialRound gives every participant in the game a drink from the player who set off the social round,
+// by rolling one of the game's social values or calling it, and announces it once
+func (s *service) recordSocialRound(ctx context.Context, game *models.Game, fromPlayerID, fromPlayerName string, rollValue int, now time.Time) ([]*models.DrinkLedger, error) {
+	session := s.getSessionForGame(ctx, game)
+
+	var records []*models.DrinkLedger
+	for _, participant := range game.Participants {
+		drinkOutput, err := s.createDrinkRecord(ctx, game, session, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:       game.ID,
+			FromPlayerID: fromPlayerID,
+			ToPlayerID:   participant.PlayerID,
+			Reason:       models.DrinkReasonSocialRound,
+			Timestamp:    now,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record social round drink: %w", err)
+		}
+		if drinkOutput.Record != nil {
+			records = append(records, drinkOutput.Record)
+		}
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeSocialRound,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: now,
+		Payload: &events.SocialRoundPayload{
+			PlayerID:   fromPlayerID,
+			PlayerName: fromPlayerName,
+			RollValue:  rollValue,
+			DrinkCount: len(game.Participants),
+			RootGameID: s.rootGameID(ctx, game),
+		},
+	})
+
+	return records, nil
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// SocialRoundTestSuite tests social rounds against the real Redis repositories
+type SocialRoundTestSuite struct {
+	serviceHarness
+	rounds []*events.SocialRoundPayload
+}
+
+func (s *SocialRoundTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+		cfg.SocialValues = []int{3}
+	})
+	s.testChannelID = "social-channel"
+
+	s.rounds = nil
+	s.eventBus.Subscribe(events.TypeSocialRound, func(_ context.Context, event *events.Event) {
+		s.rounds = append(s.rounds, event.Payload.(*events.SocialRoundPayload))
+	})
+}
+
+func TestSocialRoundTestSuite(t *testing.T) {
+	suite.Run(t, new(SocialRoundTestSuite))
+}
+
+// socialDrinks returns who was given a social round drink in the game
+func (s *SocialRoundTestSuite) socialDrinks(gameID string) []string {
+	output, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+
+	var drinkers []string
+	for _, record := range output.Records {
+		if record.Reason == models.DrinkReasonSocialRound {
+			drinkers = append(drinkers, record.ToPlayerID)
+		}
+	}
+	return drinkers
+}
+
+func (s *SocialRoundTestSuite) TestRollingASocialValue() {
+	gameID := s.startGame("alice", "bob", "carol")
+
+	output := s.roll(gameID, "bob", 3)
+	s.True(output.IsSocialRound)
+	s.False(output.IsCriticalHit)
+	s.Equal("You Rolled a 3! Social!", output.Result)
+
+	s.ElementsMatch([]string{"alice", "bob", "carol"}, s.socialDrinks(gameID))
+
+	s.Require().Len(s.rounds, 1, "one announcement for the whole round")
+	s.Equal("bob", s.rounds[0].PlayerID)
+	s.Equal(3, s.rounds[0].RollValue)
+	s.Equal(3, s.rounds[0].DrinkCount)
+	s.Equal(gameID, s.rounds[0].RootGameID)
+}
+
+func (s *SocialRoundTestSuite) TestOtherRollsAreNotSocial() {
+	gameID := s.startGame("alice", "bob")
+
+	output := s.roll(gameID, "alice", 4)
+	s.False(output.IsSocialRound)
+	s.Empty(s.socialDrinks(gameID))
+	s.Empty(s.rounds)
+}
+
+func (s *SocialRoundTestSuite) TestCallingASocialRound() {
+	gameID := s.startGame("alice", "bob")
+
+	output, err := s.gameService.CallSocialRound(s.ctx, &CallSocialRoundInput{
+		ChannelID:  s.testChannelID,
+		PlayerID:   "admin",
+		PlayerName: "Admin",
+	})
+	s.Require().NoError(err)
+	s.Len(output.Records, 2)
+	s.Equal(gameID, output.RootGameID)
+	s.Equal(s.testChannelID, output.ChannelID)
+
+	s.ElementsMatch([]string{"alice", "bob"}, s.socialDrinks(gameID))
+	s.Require().Len(s.rounds, 1)
+	s.Equal("Admin", s.rounds[0].PlayerName)
+	s.Zero(s.rounds[0].RollValue)
+}
+
+func (s *SocialRoundTestSuite) TestCallingASocialRoundNeedsAStartedGame() {
+	_, err := s.gameService.CallSocialRound(s.ctx, &CallSocialRoundInput{ChannelID: s.testChannelID, PlayerID: "admin"})
+	s.ErrorIs(err, ErrGameNotFound)
+
+	_, err = s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.CallSocialRound(s.ctx, &CallSocialRoundInput{ChannelID: s.testChannelID, PlayerID: "admin"})
+	s.ErrorIs(err, ErrGameNotStarted)
+	s.Empty(s.rounds)
+}
+
+func (s *SocialRoundTestSuite) TestSocialValuesCantBeCritical() {
+	_, err := New(&Config{
+		GameRepo:        s.gameRepo,
+		PlayerRepo:      s.playerRepo,
+		DrinkLedgerRepo: s.ledgerRepo,
+		DiceRoller:      s.mockDiceRoller,
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.NewFake(time.Now()),
+		SocialValues:    []int{6},
+	})
+	s.ErrorIs(err, ErrInvalidGameRules)
+}
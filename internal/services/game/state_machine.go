@@ -0,0 +1,56 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// StateMachine describes the game lifecycle: the status transitions a game may make
+type StateMachine struct {
+	transitions map[models.GameStatus][]models.GameStatus
+}
+
+// NewStateMachine creates the standard game lifecycle
+func NewStateMachine() *StateMachine {
+	return &StateMachine{
+		transitions: map[models.GameStatus][]models.GameStatus{
+			// A waiting game is started, or abandoned before it starts
+			models.GameStatusWaiting: {models.GameStatusActive, models.GameStatusAbandoned},
+			// An active game ends once everyone has rolled, unless a tie needs a roll-off, or is abandoned
+			models.GameStatusActive: {models.GameStatusRollOff, models.GameStatusCompleted, models.GameStatusAbandoned},
+			// A roll-off that ties again stays in roll-off while the next one is played
+			models.GameStatusRollOff: {models.GameStatusRollOff, models.GameStatusCompleted, models.GameStatusAbandoned},
+		},
+	}
+}
+
+// CanTransition reports whether a game may move from one status to another
+func (m *StateMachine) CanTransition(from, to models.GameStatus) bool {
+	for _, allowed := range m.transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPlaying reports whether players may roll and assign drinks in a game with the status
+func (m *StateMachine) IsPlaying(status models.GameStatus) bool {
+	return status == models.GameStatusActive || status == models.GameStatusRollOff
+}
+
+// Transition moves the game to the status.
+// The game is only changed in memory, saving it is up to the caller.
+func (m *StateMachine) Transition(game *models.Game, to models.GameStatus, at time.Time) error {
+	from := game.Status
+	if !m.CanTransition(from, to) {
+		return fmt.Errorf("%w: cannot move game %s from %s to %s", ErrInvalidGameState, game.ID, from, to)
+	}
+
+	game.Status = to
+	game.UpdatedAt = at
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type StateMachineTestSuite struct {
+	suite.Suite
+	machine  *StateMachine
+	testTime time.Time
+}
+
+func (s *StateMachineTestSuite) SetupTest() {
+	s.machine = NewStateMachine()
+	s.testTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestStateMachineTestSuite(t *testing.T) {
+	suite.Run(t, new(StateMachineTestSuite))
+}
+
+func (s *StateMachineTestSuite) TestCanTransition() {
+	s.True(s.machine.CanTransition(models.GameStatusWaiting, models.GameStatusActive))
+	s.True(s.machine.CanTransition(models.GameStatusWaiting, models.GameStatusAbandoned))
+	s.True(s.machine.CanTransition(models.GameStatusActive, models.GameStatusRollOff))
+	s.True(s.machine.CanTransition(models.GameStatusActive, models.GameStatusCompleted))
+	s.True(s.machine.CanTransition(models.GameStatusActive, models.GameStatusAbandoned))
+	s.True(s.machine.CanTransition(models.GameStatusRollOff, models.GameStatusRollOff))
+	s.True(s.machine.CanTransition(models.GameStatusRollOff, models.GameStatusCompleted))
+	s.True(s.machine.CanTransition(models.GameStatusRollOff, models.GameStatusAbandoned))
+
+	s.False(s.machine.CanTransition(models.GameStatusWaiting, models.GameStatusRollOff))
+	s.False(s.machine.CanTransition(models.GameStatusWaiting, models.GameStatusCompleted))
+	s.False(s.machine.CanTransition(models.GameStatusActive, models.GameStatusWaiting))
+	s.False(s.machine.CanTransition(models.GameStatusCompleted, models.GameStatusActive))
+	s.False(s.machine.CanTransition(models.GameStatusCompleted, models.GameStatusCompleted))
+	s.False(s.machine.CanTransition(models.GameStatusCompleted, models.GameStatusAbandoned))
+	s.False(s.machine.CanTransition(models.GameStatusAbandoned, models.GameStatusActive))
+}
+
+func (s *StateMachineTestSuite) TestTransition() {
+	game := &models.Game{ID: "game-id", Status: models.GameStatusWaiting}
+
+	err := s.machine.Transition(game, models.GameStatusActive, s.testTime)
+
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusActive, game.Status)
+	s.Equal(s.testTime, game.UpdatedAt)
+}
+
+func (s *StateMachineTestSuite) TestTransition_NotAllowed() {
+	game := &models.Game{ID: "game-id", Status: models.GameStatusCompleted}
+
+	err := s.machine.Transition(game, models.GameStatusActive, s.testTime)
+
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidGameState))
+	s.Equal(models.GameStatusCompleted, game.Status)
+	s.True(game.UpdatedAt.IsZero())
+}
+
+func (s *StateMachineTestSuite) TestIsPlaying() {
+	s.False(s.machine.IsPlaying(models.GameStatusWaiting))
+	s.True(s.machine.IsPlaying(models.GameStatusActive))
+	s.True(s.machine.IsPlaying(models.GameStatusRollOff))
+	s.False(s.machine.IsPlaying(models.GameStatusCompleted))
+}
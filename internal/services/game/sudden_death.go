@@ -0,0 +1,61 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// suddenDeathFor returns the sudden death a roll-off from the game is played as, carrying on the pot of a sudden
+// death that's still tied, nil when the game settles its ties with normal roll-offs
+func (s *service) suddenDeathFor(game *models.Game) *models.SuddenDeath {
+	if game.SuddenDeath != nil {
+		return &models.SuddenDeath{Pot: game.SuddenDeath.Pot}
+	}
+	if s.rulesFor(game).TieStrategy != models.TieSuddenDeath {
+		return nil
+	}
+	return &models.SuddenDeath{}
+}
+
+// suddenDeathTie adds a drink to the pot of a sudden death its players all tied in, and has them roll again in turn
+func (s *service) suddenDeathTie(ctx context.Context, game *models.Game) (*EndGameOutput, error) {
+	clearRolls(game)
+	game.SuddenDeath.Pot++
+	game.UpdatedAt = s.clock.Now()
+
+	err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{
+		Game: game,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sudden death: %w", err)
+	}
+
+	// The tie feeds the jackpot too, in games played for it
+	s.addToJackpot(ctx, game, nil)
+
+	return &EndGameOutput{
+		SessionID:      s.getSessionIDForGame(ctx, game),
+		SuddenDeathPot: game.SuddenDeath.Pot,
+	}, nil
+}
+
+// drinkSuddenDeathPot gives the loser of a sudden death a drink for every time it was tied
+func (s *service) drinkSuddenDeathPot(ctx context.Context, game *models.Game, gameID, playerID string) {
+	session := s.getSessionForGame(ctx, game)
+	for range game.SuddenDeath.Pot {
+		_, err := s.createDrinkRecord(ctx, game, session, &ledgerRepo.CreateDrinkRecordInput{
+			GameID:     gameID,
+			ToPlayerID: playerID,
+			Reason:     models.DrinkReasonSuddenDeath,
+			Timestamp:  s.clock.Now(),
+		})
+		if err != nil {
+			log.Printf("Error saving sudden death drink record: %v", err)
+		}
+	}
+}
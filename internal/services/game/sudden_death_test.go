@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/stretchr/testify/suite"
+)
+
+// SuddenDeathTestSuite tests ties settled with sudden death against the real Redis repositories
+type SuddenDeathTestSuite struct {
+	serviceHarness
+}
+
+func (s *SuddenDeathTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "sudden-death-channel"
+	s.testGuildID = "sudden-death-guild"
+}
+
+func TestSuddenDeathTestSuite(t *testing.T) {
+	suite.Run(t, new(SuddenDeathTestSuite))
+}
+
+// startGame starts a game between alice, bob and carol that settles ties the given way
+func (s *SuddenDeathTestSuite) startGame(strategy models.TieStrategy) string {
+	return s.startGameWith(&CreateGameInput{Rules: &models.GameRules{TieStrategy: strategy}}, "alice", "bob", "carol")
+}
+
+// roll rolls for a player in the game, or the roll-off they're in
+func (s *SuddenDeathTestSuite) roll(gameID, playerID string, value int) *RollDiceOutput {
+	output := s.serviceHarness.roll(gameID, playerID, value)
+	if output.NeedsToRollInRollOff {
+		var err error
+		output, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: output.RollOffGameID, PlayerID: playerID})
+		s.Require().NoError(err)
+	}
+	return output
+}
+
+func (s *SuddenDeathTestSuite) TestTiesGoInThePot() {
+	gameID := s.startGame(models.TieSuddenDeath)
+
+	s.roll(gameID, "alice", 3)
+	s.roll(gameID, "bob", 3)
+	output := s.roll(gameID, "carol", 5)
+	s.Require().True(output.NeedsRollOff)
+	rollOffGameID := output.RollOffGameID
+
+	rollOff, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	s.Require().NotNil(rollOff.SuddenDeath)
+
+	// Sudden death is rolled in turns
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: rollOffGameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrNotYourTurn)
+
+	// Tying again puts a drink in the pot and they go again in the same game
+	s.roll(rollOffGameID, "alice", 4)
+	output = s.roll(rollOffGameID, "bob", 4)
+	s.Equal(1, output.SuddenDeathPot)
+	s.False(output.NeedsRollOff)
+
+	rollOff, err = s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: rollOffGameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusRollOff, rollOff.Status)
+	s.Equal(1, rollOff.SuddenDeath.Pot)
+	s.Equal("alice", rollOff.NextRoller().PlayerID)
+
+	// alice goes lower and takes her drink and the pot
+	s.roll(rollOffGameID, "alice", 2)
+	s.roll(rollOffGameID, "bob", 5)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(models.GameStatusCompleted, game.Status)
+
+	records, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	var reasons []models.DrinkReason
+	for _, record := range records.Records {
+		s.Equal("alice", record.ToPlayerID)
+		reasons = append(reasons, record.Reason)
+	}
+	s.ElementsMatch([]models.DrinkReason{models.DrinkReasonLowestRoll, models.DrinkReasonSuddenDeath}, reasons)
+}
+
+func (s *SuddenDeathTestSuite) TestRollOffsByDefault() {
+	gameID := s.startGame("")
+
+	s.roll(gameID, "alice", 3)
+	s.roll(gameID, "bob", 3)
+	output := s.roll(gameID, "carol", 5)
+	s.Require().True(output.NeedsRollOff)
+
+	rollOff, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: output.RollOffGameID})
+	s.Require().NoError(err)
+	s.Nil(rollOff.SuddenDeath)
+
+	// Anyone can roll first in a roll-off
+	s.roll(output.RollOffGameID, "bob", 4)
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// matchesTabFilters reports whether a drink is listed on a tab with the input's filters
+func matchesTabFilters(input *GetPlayerTabInput, record *models.DrinkLedger) bool {
+	if input.UnpaidOnly && !record.IsOutstanding() {
+		return false
+	}
+	if input.Reason != "" && record.Reason != input.Reason {
+		return false
+	}
+	if input.AssignedBy != "" && record.FromPlayerID != input.AssignedBy {
+		return false
+	}
+	return true
+}
+
+// pageTab sorts the tab's lists newest first and cuts them down to one page, returning the page listed and
+// how many pages there are. The page is moved back to the last one when it's past the end.
+func pageTab(tab *PlayerTab, page, pageSize int) (int, int) {
+	for _, entries := range [][]*PlayerTabEntry{tab.DrinksOwed, tab.DrinksAssigned} {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Timestamp.After(entries[j].Timestamp)
+		})
+	}
+
+	if pageSize == 0 {
+		return 0, 1
+	}
+
+	pageCount := max(1, (max(len(tab.DrinksOwed), len(tab.DrinksAssigned))+pageSize-1)/pageSize)
+	page = min(page, pageCount-1)
+
+	tab.DrinksOwed = pageEntries(tab.DrinksOwed, page, pageSize)
+	tab.DrinksAssigned = pageEntries(tab.DrinksAssigned, page, pageSize)
+
+	return page, pageCount
+}
+
+// pageEntries returns one page of tab entries, empty when the list doesn't reach that page
+func pageEntries(entries []*PlayerTabEntry, page, pageSize int) []*PlayerTabEntry {
+	start := min(page*pageSize, len(entries))
+	end := min(start+pageSize, len(entries))
+	return entries[start:end]
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// PlayerTabTestSuite tests filtering and paging a player's tab against the real Redis repositories
+type PlayerTabTestSuite struct {
+	serviceHarness
+
+	gameID string
+	start  time.Time
+}
+
+func (s *PlayerTabTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "tab-channel"
+	s.start = time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	s.gameID = s.createGame("alice", "bob", "carol")
+
+	// alice owes five drinks, the odd ones paid, and handed one out
+	drinks := []struct {
+		from   string
+		to     string
+		reason models.DrinkReason
+	}{
+		{"bob", "alice", models.DrinkReasonCriticalHit},
+		{"carol", "alice", models.DrinkReasonCriticalHit},
+		{"alice", "alice", models.DrinkReasonCriticalFail},
+		{"bob", "alice", models.DrinkReasonCriticalHit},
+		{"alice", "alice", models.DrinkReasonLowestRoll},
+		{"alice", "bob", models.DrinkReasonCriticalHit},
+	}
+	for n, drink := range drinks {
+		err := s.ledgerRepo.AddDrinkRecord(s.ctx, &ledgerRepo.AddDrinkRecordInput{
+			Record: &models.DrinkLedger{
+				ID:           fmt.Sprintf("drink-%d", n),
+				GameID:       s.gameID,
+				FromPlayerID: drink.from,
+				ToPlayerID:   drink.to,
+				Reason:       drink.reason,
+				Timestamp:    s.start.Add(time.Duration(n) * time.Minute),
+				Paid:         n%2 == 1,
+			},
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func TestPlayerTabTestSuite(t *testing.T) {
+	suite.Run(t, new(PlayerTabTestSuite))
+}
+
+// owedMinutes returns the timestamps of the drinks a tab lists as owed, in minutes after the first drink
+func (s *PlayerTabTestSuite) owedMinutes(tab *PlayerTab) []int {
+	minutes := make([]int, 0, len(tab.DrinksOwed))
+	for _, entry := range tab.DrinksOwed {
+		minutes = append(minutes, int(entry.Timestamp.Sub(s.start)/time.Minute))
+	}
+	return minutes
+}
+
+func (s *PlayerTabTestSuite) TestWholeTabNewestFirst() {
+	output, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{GameID: s.gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.Equal([]int{4, 3, 2, 1, 0}, s.owedMinutes(output.Tab))
+	s.Len(output.Tab.DrinksAssigned, 3)
+	s.Equal(0, output.Page)
+	s.Equal(1, output.PageCount)
+}
+
+func (s *PlayerTabTestSuite) TestFilters() {
+	output, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:     s.gameID,
+		PlayerID:   "alice",
+		UnpaidOnly: true,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{4, 2, 0}, s.owedMinutes(output.Tab))
+
+	// The totals count the whole tab, whatever is listed
+	s.Equal(3, output.Tab.TotalOwed)
+	s.Equal(2, output.Tab.TotalAssigned)
+
+	output, err = s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:   s.gameID,
+		PlayerID: "alice",
+		Reason:   models.DrinkReasonCriticalHit,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{3, 1, 0}, s.owedMinutes(output.Tab))
+
+	output, err = s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:     s.gameID,
+		PlayerID:   "alice",
+		AssignedBy: "bob",
+		UnpaidOnly: true,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{0}, s.owedMinutes(output.Tab))
+	s.Empty(output.Tab.DrinksAssigned)
+}
+
+func (s *PlayerTabTestSuite) TestPages() {
+	output, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:   s.gameID,
+		PlayerID: "alice",
+		Page:     1,
+		PageSize: 2,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{2, 1}, s.owedMinutes(output.Tab))
+	s.Len(output.Tab.DrinksAssigned, 1)
+	s.Equal(1, output.Page)
+	s.Equal(3, output.PageCount)
+
+	// A page past the end lists the last one
+	output, err = s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:   s.gameID,
+		PlayerID: "alice",
+		Page:     7,
+		PageSize: 2,
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{0}, s.owedMinutes(output.Tab))
+	s.Empty(output.Tab.DrinksAssigned)
+	s.Equal(2, output.Page)
+
+	_, err = s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{
+		GameID:   s.gameID,
+		PlayerID: "alice",
+		Page:     -1,
+	})
+	s.Error(err)
+}
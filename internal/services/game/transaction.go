@@ -0,0 +1,48 @@
+package game
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+)
+
+// gameChangeAttempts is how many times a change to a game is tried before giving up on a game that keeps changing
+const gameChangeAttempts = 5
+
+// committedBus holds back the events published in a unit of work until its writes are saved, so subscribers
+// never read state that could still be rolled back
+type committedBus struct {
+	events.Bus
+}
+
+// Publish delivers the event once the unit of work the context carries is saved, or straight away outside one
+func (b *committedBus) Publish(ctx context.Context, event *events.Event) {
+	transaction.AfterCommit(ctx, func(ctx context.Context) {
+		b.Bus.Publish(ctx, event)
+	})
+}
+
+// inTransaction runs fn as a single unit of work, so the writes it makes across the repositories are saved all
+// or nothing. Without a transaction runner each write is saved as it's made.
+func (s *service) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactions == nil {
+		return fn(ctx)
+	}
+	return s.transactions.Run(ctx, fn)
+}
+
+// retryOnGameChange runs fn again while the game it saves changed after it read it, like a roll recorded in
+// between, so the change is made to the game as it is now rather than refused. fn has to read the game itself
+// and save it before making any other write outside a unit of work, so running it again repeats nothing.
+func retryOnGameChange(fn func() error) error {
+	var err error
+	for attempt := 0; attempt < gameChangeAttempts; attempt++ {
+		if err = fn(); !errors.Is(err, gameRepo.ErrGameChanged) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,224 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
+	"github.com/stretchr/testify/suite"
+)
+
+// failingGameRepo fails the game writes it's told to, after the real repository has queued them
+type failingGameRepo struct {
+	gameRepo.Repository
+	failSaveGame          bool
+	failCreateParticipant bool
+
+	// beforeSaveGame runs once before the next game save, like a write racing it
+	beforeSaveGame func()
+}
+
+func (r *failingGameRepo) SaveGame(ctx context.Context, input *gameRepo.SaveGameInput) error {
+	if hook := r.beforeSaveGame; hook != nil {
+		r.beforeSaveGame = nil
+		hook()
+	}
+	if err := r.Repository.SaveGame(ctx, input); err != nil || !r.failSaveGame {
+		return err
+	}
+	return errors.New("save game failed")
+}
+
+func (r *failingGameRepo) CreateParticipant(ctx context.Context, input *gameRepo.CreateParticipantInput) (*gameRepo.CreateParticipantOutput, error) {
+	if r.failCreateParticipant {
+		return nil, errors.New("create participant failed")
+	}
+	return r.Repository.CreateParticipant(ctx, input)
+}
+
+// TransactionTestSuite tests that flows writing to several repositories save all or nothing
+type TransactionTestSuite struct {
+	serviceHarness
+	failingRepo *failingGameRepo
+}
+
+func (s *TransactionTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		transactions, err := transaction.NewRedis(&transaction.Config{RedisClient: s.client})
+		s.Require().NoError(err)
+
+		s.failingRepo = &failingGameRepo{Repository: s.gameRepo}
+		cfg.GameRepo = s.failingRepo
+		cfg.Transactions = transactions
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "transaction-channel"
+}
+
+func TestTransactionTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionTestSuite))
+}
+
+func (s *TransactionTestSuite) drinkCount(gameID string) int {
+	output, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	return len(output.Records)
+}
+
+func (s *TransactionTestSuite) TestCreateGameSavesCreator() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: createOutput.GameID})
+	s.Require().NoError(err)
+	s.True(game.HasParticipant("alice"))
+
+	alice, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.Equal(createOutput.GameID, alice.CurrentGameID)
+}
+
+func (s *TransactionTestSuite) TestJoinGameRollsBackPlayer() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   s.testChannelID,
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	s.failingRepo.failCreateParticipant = true
+	_, err = s.gameService.JoinGame(s.ctx, &JoinGameInput{GameID: createOutput.GameID, PlayerID: "bob", PlayerName: "bob"})
+	s.Require().Error(err)
+
+	// Bob isn't left pointing at a game he never joined
+	_, err = s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "bob"})
+	s.Error(err)
+}
+
+func (s *TransactionTestSuite) TestAssignDrinkRollsBackDrink() {
+	gameID := s.startGame("alice", "bob")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.failingRepo.failSaveGame = true
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "alice", ToPlayerID: "bob", Reason: DrinkReasonCriticalHit})
+	s.Require().Error(err)
+
+	// No drink was recorded for an assignment alice still has to make
+	s.Zero(s.drinkCount(gameID))
+}
+
+func (s *TransactionTestSuite) TestAssignDrinkKeepsRollRecordedMeanwhile() {
+	gameID := s.startGame("alice", "bob")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// Bob's roll is recorded after alice's assignment read the game
+	s.failingRepo.beforeSaveGame = func() {
+		_, err := s.gameRepo.RecordRoll(s.ctx, &gameRepo.RecordRollInput{
+			GameID:    gameID,
+			PlayerID:  "bob",
+			RollValue: 3,
+			RollTime:  time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC),
+			Status:    models.ParticipantStatusActive,
+		})
+		s.Require().NoError(err)
+	}
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "alice", ToPlayerID: "bob", Reason: DrinkReasonCriticalHit})
+	s.Require().NoError(err)
+
+	game, err := s.gameRepo.GetGame(s.ctx, &gameRepo.GetGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Equal(3, game.GetParticipant("bob").RollValue)
+	s.Equal(models.ParticipantStatusActive, game.GetParticipant("alice").Status)
+
+	// The assignment was made again on the game with bob's roll, not twice
+	records, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	criticalHits := 0
+	for _, record := range records.Records {
+		if record.Reason == models.DrinkReasonCriticalHit {
+			criticalHits++
+		}
+	}
+	s.Equal(1, criticalHits)
+}
+
+func (s *TransactionTestSuite) TestAssignDrinkRollsBackCredit() {
+	gameID := s.startGame("alice", "bob")
+
+	// Bob pays a drink ahead, banking a credit
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().True(payOutput.Credited)
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(6)
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	s.failingRepo.failSaveGame = true
+	_, err = s.gameService.AssignDrink(s.ctx, &AssignDrinkInput{GameID: gameID, FromPlayerID: "alice", ToPlayerID: "bob", Reason: DrinkReasonCriticalHit})
+	s.Require().Error(err)
+
+	// The credit wasn't spent on a drink that was never saved
+	tabOutput, err := s.gameService.GetPlayerTab(s.ctx, &GetPlayerTabInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(1, tabOutput.Tab.Credits)
+	s.Zero(s.drinkCount(gameID))
+}
+
+func (s *TransactionTestSuite) TestCriticalFailEventsSeeSavedDrink() {
+	gameID := s.startGame("alice", "bob")
+
+	// Subscribers hear about the drink once it's saved, not while it's still queued
+	drinksSeen := -1
+	s.eventBus.Subscribe(events.TypeDrinkAssigned, func(context.Context, *events.Event) {
+		drinksSeen = s.drinkCount(gameID)
+	})
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(1)
+	output, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	s.True(output.IsCriticalFail)
+
+	s.Equal(1, s.drinkCount(gameID))
+	s.Equal(1, drinksSeen)
+}
+
+func (s *TransactionTestSuite) TestRollOffRollsBackWithGame() {
+	gameID := s.startGame("alice", "bob")
+
+	s.mockDiceRoller.EXPECT().Roll(6).Return(3).Times(2)
+	_, err := s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+
+	// The tie needs a roll-off, but the game waiting on it can't be saved
+	s.failingRepo.failSaveGame = true
+	_, err = s.gameService.RollDice(s.ctx, &RollDiceInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+
+	rollOffs, err := s.gameRepo.GetGamesByParent(s.ctx, &gameRepo.GetGamesByParentInput{ParentGameID: gameID})
+	s.Require().NoError(err)
+	s.Empty(rollOffs)
+
+	// Nobody was moved to a roll-off the game doesn't know about
+	bob, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal(gameID, bob.CurrentGameID)
+}
@@ -0,0 +1,175 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+)
+
+// drinkTransferTTL is how long a volunteer has to accept a drink transfer
+const drinkTransferTTL = 10 * time.Minute
+
+// RequestDrinkTransfer offers one of the player's drinks to a volunteer, who has until the offer expires to accept it.
+// The drink offered is the first one the player owes this session, as with paying drinks.
+func (s *service) RequestDrinkTransfer(ctx context.Context, input *RequestDrinkTransferInput) (*RequestDrinkTransferOutput, error) {
+	if input == nil || input.FromPlayerID == "" || input.ToPlayerID == "" {
+		return nil, errors.New("from and to player IDs are required")
+	}
+
+	if input.FromPlayerID == input.ToPlayerID {
+		return nil, ErrCannotTransferToSelf
+	}
+
+	session := s.getSession(ctx, input.GuildID, input.ChannelID)
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	// Designated drivers can't be handed drinks
+	if session.GetDesignatedDriver(input.ToPlayerID) != nil {
+		return nil, ErrInvalidSubstitute
+	}
+
+	drinkRecords, err := s.drinkLedgerRepo.GetDrinkRecordsForSession(ctx, &ledgerRepo.GetDrinkRecordsForSessionInput{
+		SessionID: session.ID,
+		GuildID:   sessionScope(input.GuildID, input.ChannelID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session drink records: %w", err)
+	}
+
+	// Drinks a designated driver owes later aren't theirs to hand on until they stop driving
+	driving := session.GetDesignatedDriver(input.FromPlayerID) != nil
+
+	now := s.clock.Now()
+	for _, record := range drinkRecords.Records {
+		if record.ToPlayerID != input.FromPlayerID || !record.IsOutstanding() || (record.OwedLater && driving) {
+			continue
+		}
+
+		transfer := &models.DrinkTransfer{
+			ID:           s.uuid.NewUUID(),
+			DrinkID:      record.ID,
+			FromPlayerID: input.FromPlayerID,
+			ToPlayerID:   input.ToPlayerID,
+			ChannelID:    input.ChannelID,
+			GuildID:      input.GuildID,
+			CreatedAt:    now,
+			ExpiresAt:    now.Add(drinkTransferTTL),
+		}
+
+		err := s.drinkLedgerRepo.CreateDrinkTransfer(ctx, &ledgerRepo.CreateDrinkTransferInput{
+			Transfer: transfer,
+		})
+		if errors.Is(err, ledgerRepo.ErrDrinkTransferPending) {
+			// Already on offer, try the next drink
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to save drink transfer: %w", err)
+		}
+
+		return &RequestDrinkTransferOutput{
+			Transfer: transfer,
+		}, nil
+	}
+
+	return nil, ErrNoDrinksToTransfer
+}
+
+// AcceptDrinkTransfer has the volunteer take the offered drink, which moves to their tab
+func (s *service) AcceptDrinkTransfer(ctx context.Context, input *AcceptDrinkTransferInput) (*AcceptDrinkTransferOutput, error) {
+	if input == nil || input.TransferID == "" || input.PlayerID == "" {
+		return nil, errors.New("transfer and player IDs are required")
+	}
+
+	transfer, err := s.getDrinkTransfer(ctx, input.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ToPlayerID != input.PlayerID {
+		return nil, ErrNotTransferTarget
+	}
+
+	// Claim the transfer so it can only be answered once
+	if err := s.deleteDrinkTransfer(ctx, transfer.ID); err != nil {
+		return nil, err
+	}
+
+	output, err := s.drinkLedgerRepo.TransferDrink(ctx, &ledgerRepo.TransferDrinkInput{
+		DrinkID:      transfer.DrinkID,
+		FromPlayerID: transfer.FromPlayerID,
+		ToPlayerID:   transfer.ToPlayerID,
+	})
+	if err != nil {
+		if errors.Is(err, ledgerRepo.ErrDrinkNotTransferable) || errors.Is(err, ledgerRepo.ErrDrinkNotFound) {
+			// Paid, forgiven or deleted since it was offered
+			return nil, ErrDrinkTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to transfer drink: %w", err)
+	}
+
+	s.publishLedgerChanged(ctx, transfer.GuildID, transfer.ChannelID, output.Record.SessionID, "drink_transferred")
+
+	return &AcceptDrinkTransferOutput{
+		Transfer: transfer,
+		Record:   output.Record,
+	}, nil
+}
+
+// DeclineDrinkTransfer turns down a drink transfer, either player can call it off
+func (s *service) DeclineDrinkTransfer(ctx context.Context, input *DeclineDrinkTransferInput) (*DeclineDrinkTransferOutput, error) {
+	if input == nil || input.TransferID == "" || input.PlayerID == "" {
+		return nil, errors.New("transfer and player IDs are required")
+	}
+
+	transfer, err := s.getDrinkTransfer(ctx, input.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.FromPlayerID != input.PlayerID && transfer.ToPlayerID != input.PlayerID {
+		return nil, ErrNotTransferTarget
+	}
+
+	if err := s.deleteDrinkTransfer(ctx, transfer.ID); err != nil {
+		return nil, err
+	}
+
+	return &DeclineDrinkTransferOutput{
+		Transfer: transfer,
+	}, nil
+}
+
+// getDrinkTransfer gets a pending drink transfer, mapping a missing or expired one to ErrDrinkTransferNotFound
+func (s *service) getDrinkTransfer(ctx context.Context, transferID string) (*models.DrinkTransfer, error) {
+	output, err := s.drinkLedgerRepo.GetDrinkTransfer(ctx, &ledgerRepo.GetDrinkTransferInput{
+		TransferID: transferID,
+	})
+	if err != nil {
+		if errors.Is(err, ledgerRepo.ErrDrinkTransferNotFound) {
+			return nil, ErrDrinkTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get drink transfer: %w", err)
+	}
+	return output.Transfer, nil
+}
+
+// deleteDrinkTransfer removes a pending drink transfer, failing if someone else answered it first
+func (s *service) deleteDrinkTransfer(ctx context.Context, transferID string) error {
+	err := s.drinkLedgerRepo.DeleteDrinkTransfer(ctx, &ledgerRepo.DeleteDrinkTransferInput{
+		TransferID: transferID,
+	})
+	if err != nil {
+		if errors.Is(err, ledgerRepo.ErrDrinkTransferNotFound) {
+			return ErrDrinkTransferNotFound
+		}
+		return fmt.Errorf("failed to delete drink transfer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// DrinkTransferTestSuite tests handing drinks between players against the real Redis repositories
+type DrinkTransferTestSuite struct {
+	serviceHarness
+}
+
+func (s *DrinkTransferTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "transfer-channel"
+	s.testGuildID = "transfer-guild"
+}
+
+func TestDrinkTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(DrinkTransferTestSuite))
+}
+
+// playGame plays a game in the test channel where bob rolls lowest and owes a drink
+func (s *DrinkTransferTestSuite) playGame() string {
+	return s.serviceHarness.playGame([]string{"alice", "bob", "carol"}, []int{4, 2, 5})
+}
+
+// requestTransfer has bob offer his drink to the given player
+func (s *DrinkTransferTestSuite) requestTransfer(toPlayerID string) (*RequestDrinkTransferOutput, error) {
+	return s.gameService.RequestDrinkTransfer(s.ctx, &RequestDrinkTransferInput{
+		ChannelID:    s.testChannelID,
+		GuildID:      s.testGuildID,
+		FromPlayerID: "bob",
+		ToPlayerID:   toPlayerID,
+	})
+}
+
+func (s *DrinkTransferTestSuite) TestAcceptDrinkTransfer() {
+	gameID := s.playGame()
+
+	requestOutput, err := s.requestTransfer("carol")
+	s.Require().NoError(err)
+
+	// Only carol can take it
+	_, err = s.gameService.AcceptDrinkTransfer(s.ctx, &AcceptDrinkTransferInput{TransferID: requestOutput.Transfer.ID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrNotTransferTarget)
+
+	acceptOutput, err := s.gameService.AcceptDrinkTransfer(s.ctx, &AcceptDrinkTransferInput{TransferID: requestOutput.Transfer.ID, PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.Equal("carol", acceptOutput.Record.ToPlayerID)
+	s.Equal([]string{"bob"}, acceptOutput.Record.TransferredFrom)
+
+	// It can't be accepted twice
+	_, err = s.gameService.AcceptDrinkTransfer(s.ctx, &AcceptDrinkTransferInput{TransferID: requestOutput.Transfer.ID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrDrinkTransferNotFound)
+
+	// carol now owes the drink and bob doesn't
+	payOutput, err := s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.True(payOutput.Credited)
+	payOutput, err = s.gameService.PayDrink(s.ctx, &PayDrinkInput{GameID: gameID, PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.False(payOutput.Credited)
+}
+
+func (s *DrinkTransferTestSuite) TestDeclineDrinkTransfer() {
+	s.playGame()
+
+	requestOutput, err := s.requestTransfer("carol")
+	s.Require().NoError(err)
+
+	// The drink is already on offer
+	_, err = s.requestTransfer("alice")
+	s.ErrorIs(err, ErrNoDrinksToTransfer)
+
+	_, err = s.gameService.DeclineDrinkTransfer(s.ctx, &DeclineDrinkTransferInput{TransferID: requestOutput.Transfer.ID, PlayerID: "carol"})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.AcceptDrinkTransfer(s.ctx, &AcceptDrinkTransferInput{TransferID: requestOutput.Transfer.ID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrDrinkTransferNotFound)
+
+	// Once declined bob can offer it to someone else
+	_, err = s.requestTransfer("alice")
+	s.Require().NoError(err)
+}
+
+func (s *DrinkTransferTestSuite) TestRequestDrinkTransfer_Invalid() {
+	s.playGame()
+
+	_, err := s.requestTransfer("bob")
+	s.ErrorIs(err, ErrCannotTransferToSelf)
+
+	// carol doesn't owe anything to hand over
+	_, err = s.gameService.RequestDrinkTransfer(s.ctx, &RequestDrinkTransferInput{
+		ChannelID:    s.testChannelID,
+		GuildID:      s.testGuildID,
+		FromPlayerID: "carol",
+		ToPlayerID:   "bob",
+	})
+	s.ErrorIs(err, ErrNoDrinksToTransfer)
+
+	// Designated drivers can't be handed drinks
+	_, err = s.gameService.SetDesignatedDriver(s.ctx, &SetDesignatedDriverInput{
+		ChannelID:  s.testChannelID,
+		GuildID:    s.testGuildID,
+		PlayerID:   "alice",
+		PlayerName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.requestTransfer("alice")
+	s.ErrorIs(err, ErrInvalidSubstitute)
+}
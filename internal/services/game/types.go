@@ -6,10 +6,20 @@ import (
 	"github.com/KirkDiggler/ronnied/internal/common/clock"
 	"github.com/KirkDiggler/ronnied/internal/common/uuid"
 	"github.com/KirkDiggler/ronnied/internal/dice"
+	"github.com/KirkDiggler/ronnied/internal/events"
 	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/feature_flag"
+	"github.com/KirkDiggler/ronnied/internal/services/guild_config"
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+	auditRepo "github.com/KirkDiggler/ronnied/internal/repositories/audit"
 	drinkLedgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
 	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	gameViewRepo "github.com/KirkDiggler/ronnied/internal/repositories/game_view"
+	jobRepo "github.com/KirkDiggler/ronnied/internal/repositories/job"
+	momentRepo "github.com/KirkDiggler/ronnied/internal/repositories/moment"
 	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	rollLogRepo "github.com/KirkDiggler/ronnied/internal/repositories/roll_log"
+	"github.com/KirkDiggler/ronnied/internal/repositories/transaction"
 )
 
 // GameStatus represents the current state of a game
@@ -27,6 +37,9 @@ const (
 
 	// GameStatusCompleted indicates a game has been completed
 	GameStatusCompleted GameStatus = "completed"
+
+	// GameStatusAbandoned indicates a game was abandoned before it finished
+	GameStatusAbandoned GameStatus = "abandoned"
 )
 
 // DrinkReason represents why a drink was assigned
@@ -62,11 +75,14 @@ type Config struct {
 	// Number of sides on the dice
 	DiceSides int
 
-	// Value that counts as a critical hit
-	CriticalHitValue int
+	// Values that count as a critical hit, defaults to the highest side
+	CriticalHitValues []int
+
+	// Values that count as a critical fail, defaults to 1
+	CriticalFailValues []int
 
-	// Value that counts as a critical fail
-	CriticalFailValue int
+	// Values that call a social round, giving every player a drink (optional, no social rounds when empty)
+	SocialValues []int
 
 	// Maximum number of concurrent games
 	MaxConcurrentGames int
@@ -76,12 +92,167 @@ type Config struct {
 	PlayerRepo      playerRepo.Repository
 	DrinkLedgerRepo drinkLedgerRepo.Repository
 
+	// AuditRepo records data deletions (optional, nothing is audited when nil)
+	AuditRepo auditRepo.Repository
+
+	// GameViewRepo loads everything needed to render a game in one pipelined call
+	// (optional, the other repositories are read one at a time when nil)
+	GameViewRepo gameViewRepo.Repository
+
+	// RollLogRepo keeps every roll for statistics and audits (optional, rolls aren't logged when nil)
+	RollLogRepo rollLogRepo.Repository
+
+	// MomentRepo keeps the moments players clip (optional, clipping is off when nil)
+	MomentRepo momentRepo.Repository
+
+	// Transactions saves the writes of flows touching several repositories all or nothing
+	// (optional, each write is saved on its own when nil)
+	Transactions transaction.Runner
+
+	// JobRepo queues the work left once a game completes to be done in the background by ProcessNextJob
+	// (optional, the work is done before the roll that completed the game returns when nil)
+	JobRepo jobRepo.Repository
+
 	// Service dependencies
 	DiceRoller    dice.Roller
 	Clock         clock.Clock
 	UUIDGenerator uuid.UUID
+
+	// EventBus receives domain events such as completed games (optional)
+	EventBus events.Bus
+
+	// Lifecycle is the game state machine (optional, defaults to NewStateMachine)
+	Lifecycle *StateMachine
+
+	// SessionRotation closes sessions automatically (optional, sessions last until replaced by hand)
+	SessionRotation *SessionRotationConfig
+
+	// DrinkCap is the most drinks a player can be given in a session before the mercy rule
+	// turns further drinks into social ones (optional, 0 means no cap)
+	DrinkCap int
+
+	// Pacing warns players who pay drinks too quickly (optional, no pacing checks when nil)
+	Pacing *PacingConfig
+
+	// SeasonalService decides which seasonal events new games are played with (optional, no events when nil)
+	SeasonalService seasonal.Service
+
+	// FeatureFlagService decides which parts of the game are turned on per guild (optional, everything is on when nil)
+	FeatureFlagService feature_flag.Service
+
+	// GuildConfigService looks up the rules servers override for their games (optional, the defaults are
+	// reported for every server when nil)
+	GuildConfigService guild_config.Service
+
+	// LastCallMultiplier is how many times each drink counts in a session's final game after last call,
+	// rounded up to whole drinks (optional, defaults to 1.5)
+	LastCallMultiplier float64
+
+	// MaxGameDuration ends games still being played this long after they started, skipping the players who
+	// haven't rolled, and warns those players once most of the time has gone (optional, 0 means no limit)
+	MaxGameDuration time.Duration
+
+	// CountdownRollWindow is how long countdown games' players have to roll once the countdown ends
+	// (optional, defaults to 10 seconds)
+	CountdownRollWindow time.Duration
+
+	// LeaderHandicap makes the session leader roll with disadvantage in every game, games can also turn it on
+	// through their rules (optional)
+	LeaderHandicap bool
+
+	// GuestDrinks lets critical hits give drinks to people in the channel who aren't playing in every game, games
+	// can also turn it on through their rules (optional)
+	GuestDrinks bool
+
+	// Karma makes players who keep giving their drinks to the same player drink one too in every game, games can
+	// also turn it on through their rules (optional)
+	Karma bool
+
+	// Jackpot plays every game for the session's jackpot, which ties and doubles add to and the next critical fail
+	// drinks, games can also turn it on through their rules (optional)
+	Jackpot bool
+
+	// RonnieRollDelay is how long Ronnie waits before making a roll in a game he's playing
+	// (optional, defaults to 3 seconds)
+	RonnieRollDelay time.Duration
+
+	// HappyHour makes drinks given during its windows count more or less (optional, no happy hours when nil)
+	HappyHour *HappyHourConfig
+
+	// BirthdayLocation is the time zone birthdays are celebrated in (optional, defaults to local time)
+	BirthdayLocation *time.Location
+
+	// DrinkReminderAfter reminds players of drinks they've owed this long, and again each time as long passes
+	// (optional, 0 means no reminders)
+	DrinkReminderAfter time.Duration
+
+	// VoteThreshold is the share of a game's players a vote to start or abandon it needs more than of approvals
+	// to pass, 1 needs everyone (optional, defaults to half so a majority has to approve)
+	VoteThreshold float64
+}
+
+// SessionRotationConfig controls when a channel's session is closed and a fresh one started
+type SessionRotationConfig struct {
+	// Daily rotates sessions once a day at DailyAt
+	Daily bool
+
+	// DailyAt is the time of day to rotate at as an offset from midnight, e.g. 4*time.Hour for 4am
+	DailyAt time.Duration
+
+	// Location is the time zone DailyAt is in (optional, defaults to local time)
+	Location *time.Location
+
+	// Inactivity rotates a session once no drinks have been recorded for this long (optional)
+	Inactivity time.Duration
+}
+
+// HappyHourConfig sets the times of day drinks count more or less
+type HappyHourConfig struct {
+	// Windows are the happy hours, checked in order with the first one open applying
+	Windows []HappyHourWindow
+
+	// Location is the time zone the windows are in (optional, defaults to local time)
+	Location *time.Location
+}
+
+// HappyHourWindow is a time of day drinks count more or less
+type HappyHourWindow struct {
+	// Start is when the window opens as an offset from midnight, e.g. 17*time.Hour for 5pm
+	Start time.Duration
+
+	// End is when the window closes as an offset from midnight, before Start for windows running past midnight
+	End time.Duration
+
+	// Multiplier is how many times each drink given counts, e.g. 2 for double or 0.5 for half
+	Multiplier float64
 }
 
+// PacingConfig controls the sober-up checks on paying drinks
+type PacingConfig struct {
+	// MaxDrinks is the most drinks a player can pay within Window before being warned
+	MaxDrinks int
+
+	// Window is how far back paid drinks are counted
+	Window time.Duration
+
+	// Cooldown blocks paying drinks for this long after a warning (optional, 0 only warns)
+	Cooldown time.Duration
+}
+
+// SessionRotationReason describes why a session was rotated
+type SessionRotationReason string
+
+const (
+	// SessionRotationReasonDaily indicates the daily rotation time passed
+	SessionRotationReasonDaily SessionRotationReason = "daily"
+
+	// SessionRotationReasonInactivity indicates the session went quiet for too long
+	SessionRotationReasonInactivity SessionRotationReason = "inactivity"
+
+	// SessionRotationReasonLastCall indicates the final game after last call ended
+	SessionRotationReasonLastCall SessionRotationReason = "last_call"
+)
+
 // CreateGameInput contains parameters for creating a new game
 type CreateGameInput struct {
 	// ChannelID is the Discord channel ID where the game is being played
@@ -92,6 +263,26 @@ type CreateGameInput struct {
 
 	// CreatorName is the display name of the player creating the game
 	CreatorName string
+
+	// Rules override the default game rules, e.g. from a preset (optional)
+	Rules *models.GameRules
+
+	// GuildID is the Discord server the game is played in, it scopes the game's session and seasonal events
+	// (optional, games outside a server are scoped to their channel)
+	GuildID string
+
+	// Countdown makes everyone roll at once after a countdown, rolls outside the rolling window cost a drink
+	Countdown bool
+
+	// Rounds plays the game over that many rounds with the same players, the lowest total taking a bonus drink
+	// (optional, 0 plays a single round)
+	Rounds int
+
+	// Private makes the game invite-only, only the creator and the invited players can join
+	Private bool
+
+	// InvitedPlayerIDs are the players invited to a private game (optional, more can be invited later)
+	InvitedPlayerIDs []string
 }
 
 // CreateGameOutput contains the result of creating a new game
@@ -119,6 +310,49 @@ type JoinGameOutput struct {
 	AlreadyJoined bool // Indicates if the player was already in the game
 }
 
+// AddHotSeatPlayersInput contains parameters for adding players a Discord user rolls for to a game
+type AddHotSeatPlayersInput struct {
+	// GameID is the game the players sit down at, it has to be waiting to start
+	GameID string
+
+	// HostID is the Discord user rolling for the players from their device, who has to be in the game
+	HostID string
+
+	// PlayerNames are the names of the players at the table
+	PlayerNames []string
+}
+
+// AddHotSeatPlayersOutput contains the result of adding hot seat players to a game
+type AddHotSeatPlayersOutput struct {
+	Game *models.Game
+
+	// Added are the players that sat down, leaving out names already in the game
+	Added []*models.Participant
+}
+
+// RollHotSeatInput contains parameters for rolling for the next of a Discord user's hot seat players
+type RollHotSeatInput struct {
+	// GameID is the game being played, or any of its roll-offs
+	GameID string
+
+	// HostID is the Discord user rolling for their hot seat players
+	HostID string
+}
+
+// AddRonnieInput contains parameters for sitting Ronnie down at a game
+type AddRonnieInput struct {
+	// GameID is the game Ronnie sits down at, it has to be waiting to start
+	GameID string
+
+	// PlayerID is the player asking Ronnie to play, who has to be in the game
+	PlayerID string
+}
+
+// AddRonnieOutput contains the result of sitting Ronnie down at a game
+type AddRonnieOutput struct {
+	Game *models.Game
+}
+
 // LeaveGameInput contains parameters for leaving a game
 type LeaveGameInput struct {
 	// GameID is the unique identifier for the game
@@ -132,6 +366,100 @@ type LeaveGameInput struct {
 type LeaveGameOutput struct {
 	// Success indicates if the player successfully left the game
 	Success bool
+
+	// Waitlisted is true when the player only left the waitlist
+	Waitlisted bool
+
+	// PromotedPlayerID is the waitlisted player given the spot that opened up, empty when nobody was waiting
+	PromotedPlayerID string
+}
+
+// RenamePlayerInput contains parameters for updating the name of a player who changed it
+type RenamePlayerInput struct {
+	// GuildID is the Discord server the player changed their name in (optional)
+	GuildID string
+
+	// PlayerID is the Discord user ID of the player
+	PlayerID string
+
+	// PlayerName is the player's new display name
+	PlayerName string
+}
+
+// RenamePlayerOutput contains what was renamed
+type RenamePlayerOutput struct {
+	// Renamed is true when the player's saved name changed
+	Renamed bool
+
+	// GameID is the game whose message shows the old name, empty when none does
+	GameID string
+
+	// ChannelID is the channel the game is played in
+	ChannelID string
+}
+
+// CallSocialRoundInput contains parameters for giving everyone in a channel's game a drink
+type CallSocialRoundInput struct {
+	// ChannelID is the channel whose game drinks
+	ChannelID string
+
+	// PlayerID is the ID of the player calling the social round
+	PlayerID string
+
+	// PlayerName is the display name of the player calling the social round
+	PlayerName string
+}
+
+// CallSocialRoundOutput contains the drinks a social round gave
+type CallSocialRoundOutput struct {
+	// Records are the drinks given, one for each player in the game
+	Records []*models.DrinkLedger
+
+	// RootGameID is the game whose message shows the round
+	RootGameID string
+
+	// ChannelID is the channel the game is played in
+	ChannelID string
+}
+
+// RemoveDepartedPlayerInput contains parameters for handling a player who left a server
+type RemoveDepartedPlayerInput struct {
+	// GuildID is the Discord server the player left
+	GuildID string
+
+	// PlayerID is the Discord user ID of the player who left
+	PlayerID string
+}
+
+// RemoveDepartedPlayerOutput contains what was done about a player who left a server
+type RemoveDepartedPlayerOutput struct {
+	// GameID is the game the player was taken out of, empty when they weren't playing one in the server
+	GameID string
+
+	// RootGameID is the game whose message shows GameID, the game itself unless it's a roll-off
+	RootGameID string
+
+	// ChannelID is the channel the game is played in
+	ChannelID string
+
+	// Abandoned is true when nobody was left to play the game, so it was abandoned
+	Abandoned bool
+
+	// ForgivenCount is how many of the player's outstanding drinks in the server's session were forgiven
+	ForgivenCount int
+}
+
+// JoinWaitlistInput contains parameters for waiting for a spot in a full game
+type JoinWaitlistInput struct {
+	GameID     string
+	PlayerID   string
+	PlayerName string
+}
+
+// JoinWaitlistOutput contains the player's place on the waitlist
+type JoinWaitlistOutput struct {
+	// Position is where the player is on the waitlist counting from 1
+	Position int
 }
 
 // RollDiceInput contains parameters for rolling dice
@@ -141,6 +469,9 @@ type RollDiceInput struct {
 
 	// PlayerID is the Discord user ID of the player
 	PlayerID string
+
+	// HostID is the Discord user rolling for the player when they're a hot seat player
+	HostID string
 }
 
 // PlayerOption represents a player who can be selected for a drink assignment
@@ -175,6 +506,9 @@ type RollDiceOutput struct {
 	// IsCriticalFail indicates if the roll was a critical fail
 	IsCriticalFail bool
 
+	// DiceSides is the number of sides on the dice the game is played with
+	DiceSides int
+
 	// IsLowestRoll indicates if the roll was the lowest in the game
 	// This will be false initially and may be updated after all players roll
 	IsLowestRoll bool
@@ -222,8 +556,52 @@ type RollDiceOutput struct {
 	
 	// GameIDsToUpdate is a list of game IDs that should be updated after this roll
 	GameIDsToUpdate []string
+
+	// EarnedRerollToken indicates the roll earned the player a re-roll token
+	EarnedRerollToken bool
+
+	// RollModifiers are the modifiers that were applied to the roll
+	RollModifiers []*models.RollModifier
+
+	// RollTiming is set when a countdown game's roll landed outside the rolling window and cost a drink
+	RollTiming RollTiming
+
+	// LeaderHandicap indicates the player rolled with disadvantage for leading the session
+	LeaderHandicap bool
+
+	// BirthdayPlayers are the other players celebrating their birthday today, a critical hit's drink must go to one of them
+	BirthdayPlayers []PlayerOption
+
+	// IsSocialRound indicates the roll was one of the game's social values, so everyone in the game drinks
+	IsSocialRound bool
+
+	// GuestDrinks indicates a critical hit's drink can also be offered to someone in the channel who isn't playing
+	GuestDrinks bool
+
+	// NextRound is the round everyone rolls in next when the roll finished a round of a rounds game, 0 otherwise
+	NextRound int
+
+	// SuddenDeathPot is the sudden death's pot when the roll left its players all tied again, 0 otherwise
+	SuddenDeathPot int
+
+	// JackpotDrinks is how many drinks the player took from the session's jackpot with their critical fail
+	JackpotDrinks int
+
+	// Jackpot is the drinks in the session's jackpot after the player's doubles put one in, 0 when they didn't
+	Jackpot int
 }
 
+// RollTiming describes when a countdown game's roll landed outside the rolling window
+type RollTiming string
+
+const (
+	// RollTimingEarly indicates the roll came before the countdown ended
+	RollTimingEarly RollTiming = "early"
+
+	// RollTimingLate indicates the roll came after the rolling window closed
+	RollTimingLate RollTiming = "late"
+)
+
 // AssignDrinkInput contains parameters for assigning a drink
 type AssignDrinkInput struct {
 	// GameID is the unique identifier for the game
@@ -237,6 +615,9 @@ type AssignDrinkInput struct {
 
 	// Reason is why the drink is being assigned
 	Reason DrinkReason
+
+	// HostID is the Discord user assigning the drink for the player when they're a hot seat player
+	HostID string
 }
 
 // AssignDrinkOutput contains the result of assigning a drink
@@ -244,6 +625,20 @@ type AssignDrinkOutput struct {
 	// Success indicates if the drink was successfully assigned
 	Success bool
 
+	// HappyHourMultiplier is how many times the drink counted for being given during happy hour,
+	// 0 outside happy hour
+	HappyHourMultiplier float64
+
+	// OnTheHouse indicates happy hour left the drink unowed
+	OnTheHouse bool
+
+	// KarmaDrink indicates the assigning player drinks too, for picking on the same player once too often
+	KarmaDrink bool
+
+	// RonnieDrinkTo is the name of the player who drinks a drink given to Ronnie in his place, empty for drinks
+	// given to anyone else
+	RonnieDrinkTo string
+
 	// GameEnded indicates if the game ended as a result of this drink assignment
 	GameEnded bool
 
@@ -320,8 +715,17 @@ type EndGameOutput struct {
 	// SessionID is the ID of the session this game belongs to
 	SessionID string
 
-	// SessionLeaderboard contains the current session leaderboard
+	// SessionLeaderboard contains the current session leaderboard, empty when the post-game work was queued
 	SessionLeaderboard []LeaderboardEntry
+
+	// PostGameQueued is set when the work left once the game completed was queued to be done in the background
+	PostGameQueued bool
+
+	// NextRound is the round everyone rolls in next when a rounds game moved on instead of ending, 0 otherwise
+	NextRound int
+
+	// SuddenDeathPot is the sudden death's pot when its players all tied again and roll on, 0 otherwise
+	SuddenDeathPot int
 }
 
 // StartGameInput defines the input for starting a game
@@ -338,6 +742,9 @@ type StartGameOutput struct {
 	ForceStarted  bool   // Whether the game was force-started by a non-creator
 	CreatorID     string // The ID of the original creator who delayed starting
 	CreatorName   string // The name of the original creator
+
+	// RollWindow is when rolls count for countdown games, nil for other games
+	RollWindow *models.RollWindow
 }
 
 // HandleRollOffInput contains parameters for handling a roll-off
@@ -373,6 +780,10 @@ type HandleRollOffOutput struct {
 // GetGameByChannelInput defines the input for retrieving a game by channel ID
 type GetGameByChannelInput struct {
 	ChannelID string
+
+	// GuildID is the Discord server the channel is in, empty outside a server
+	// Another server's game is reported as ErrGameNotFound
+	GuildID string
 }
 
 // GetGameByChannelOutput defines the output for retrieving a game by channel ID
@@ -380,6 +791,26 @@ type GetGameByChannelOutput struct {
 	Game *models.Game
 }
 
+// GetActiveGameByChannelInput defines the input for retrieving the game being played in a channel
+type GetActiveGameByChannelInput struct {
+	ChannelID string
+}
+
+// GetActiveGameByChannelOutput defines the output for retrieving the game being played in a channel
+type GetActiveGameByChannelOutput struct {
+	Game *models.Game
+}
+
+// GetLatestCompletedGameByChannelInput defines the input for retrieving the last game finished in a channel
+type GetLatestCompletedGameByChannelInput struct {
+	ChannelID string
+}
+
+// GetLatestCompletedGameByChannelOutput defines the output for retrieving the last game finished in a channel
+type GetLatestCompletedGameByChannelOutput struct {
+	Game *models.Game
+}
+
 // GetLeaderboardInput defines the input for retrieving a game's leaderboard
 type GetLeaderboardInput struct {
 	GameID string
@@ -387,10 +818,28 @@ type GetLeaderboardInput struct {
 
 // LeaderboardEntry represents a single entry in the leaderboard
 type LeaderboardEntry struct {
-	PlayerID   string
-	PlayerName string
-	DrinkCount int // Total drinks this player owes
-	PaidCount  int // Number of drinks this player has paid
+	PlayerID      string
+	PlayerName    string
+	DrinkCount    int // Total drinks this player owes
+	PaidCount     int // Number of drinks this player has paid
+	SocialCount   int // Drinks waived by the mercy rule
+	ForgivenCount int // Drinks written off by an admin
+
+	// DesignatedDriver marks a player sitting out drinks this session
+	DesignatedDriver bool
+
+	// DrinksPerHour is how many drinks the player has been given per hour the session has been running, leaving out
+	// its breaks. It's only set on session leaderboards looked up by channel and closing ones, once the session has
+	// run a while.
+	DrinksPerHour float64
+
+	// LongestDryStreak is the longest the session ran without the player being given a drink, leaving out its
+	// breaks. It's set alongside DrinksPerHour.
+	LongestDryStreak time.Duration
+
+	// Karma is how evenly the player has spread the drinks they chose to give this session, positive for spreading
+	// them around and negative for picking on one player. It's only set on session leaderboards.
+	Karma int
 }
 
 // GetLeaderboardOutput defines the output for retrieving a game's leaderboard
@@ -403,6 +852,10 @@ type GetLeaderboardOutput struct {
 type AbandonGameInput struct {
 	// GameID is the unique identifier for the game
 	GameID string
+
+	// PlayerID is the player abandoning the game, who must host it unless it's idle
+	// (optional, empty for the bot itself)
+	PlayerID string
 }
 
 // AbandonGameOutput contains the result of abandoning a game
@@ -411,6 +864,18 @@ type AbandonGameOutput struct {
 	Success bool
 }
 
+// PurgeArchivedGamesInput contains parameters for deleting old abandoned games
+type PurgeArchivedGamesInput struct {
+	// ArchivedBefore is the time games must have been abandoned before to be deleted
+	ArchivedBefore time.Time
+}
+
+// PurgeArchivedGamesOutput contains the result of deleting old abandoned games
+type PurgeArchivedGamesOutput struct {
+	// DeletedCount is the number of games deleted
+	DeletedCount int
+}
+
 // UpdateGameMessageInput contains parameters for updating a game's message ID
 type UpdateGameMessageInput struct {
 	// GameID is the unique identifier for the game
@@ -430,6 +895,10 @@ type UpdateGameMessageOutput struct {
 type GetGameInput struct {
 	// GameID is the unique identifier for the game
 	GameID string
+
+	// GuildID is the Discord server asking for the game, empty outside a server
+	// Another server's game is reported as ErrGameNotFound
+	GuildID string
 }
 
 // GetGameOutput contains the result of retrieving a game by ID
@@ -438,11 +907,70 @@ type GetGameOutput struct {
 	Game *models.Game
 }
 
+// GetGameRulesInput defines the input for getting the rules a game is played with
+type GetGameRulesInput struct {
+	// Game is the game to get the rules for, nil for the service defaults
+	Game *models.Game
+
+	// GuildID is the server whose overrides a new game is started with, used when Game is nil
+	GuildID string
+}
+
+// GetGameRulesOutput defines the output for getting the rules a game is played with
+type GetGameRulesOutput struct {
+	// Rules are the complete rules the game is played with
+	Rules models.GameRules
+}
+
 // GetDrinkRecordsInput contains parameters for retrieving drink records for a game
 type GetDrinkRecordsInput struct {
 	GameID string
 }
 
+// GetGameViewInput contains parameters for loading everything needed to render a game
+type GetGameViewInput struct {
+	GameID string
+}
+
+// GetGameViewOutput contains a game along with everything needed to render it
+type GetGameViewOutput struct {
+	Game *models.Game
+
+	// Rules are the complete rules the game is played with
+	Rules models.GameRules
+
+	// ParentGame is the game a roll-off was started from
+	ParentGame *models.Game
+
+	// RollOffGame is the game's roll-off, if it has one
+	RollOffGame *models.Game
+
+	// RollOffs are every roll-off started from a game in roll-off that isn't one itself, level by level
+	RollOffs []*models.Game
+
+	DrinkRecords []*models.DrinkLedger
+
+	// Leaderboard and SessionLeaderboard are only filled in once the game is completed
+	Leaderboard        []LeaderboardEntry
+	SessionLeaderboard []LeaderboardEntry
+
+	// Session is the guild's current session, only filled in once the game is completed
+	Session *models.Session
+}
+
+// GetGameTreeInput contains parameters for loading a game with all of its roll-offs
+type GetGameTreeInput struct {
+	GameID string
+}
+
+// GetGameTreeOutput contains a game and every roll-off started from it
+type GetGameTreeOutput struct {
+	Game *models.Game
+
+	// RollOffs are the game's roll-offs and theirs, level by level
+	RollOffs []*models.Game
+}
+
 // GetDrinkRecordsOutput contains the result of retrieving drink records for a game
 type GetDrinkRecordsOutput struct {
 	Records []*models.DrinkLedger
@@ -466,6 +994,21 @@ type GetPlayerTabInput struct {
 
 	// PlayerID is the ID of the player to get the tab for
 	PlayerID string
+
+	// UnpaidOnly lists only the drinks still owed
+	UnpaidOnly bool
+
+	// Reason lists only the drinks assigned for this reason, all of them when empty
+	Reason models.DrinkReason
+
+	// AssignedBy lists only the drinks assigned by this player, anyone's when empty
+	AssignedBy string
+
+	// Page is the page of drinks to list, starting at 0
+	Page int
+
+	// PageSize is how many drinks each list has per page, all of them when 0
+	PageSize int
 }
 
 // PlayerTabEntry represents a single drink in a player's tab
@@ -490,6 +1033,24 @@ type PlayerTabEntry struct {
 
 	// Paid indicates whether the drink has been paid (taken)
 	Paid bool
+
+	// Social indicates the drink was waived by the mercy rule and isn't owed
+	Social bool
+
+	// Forgiven indicates the drink was written off by an admin and isn't owed
+	Forgiven bool
+
+	// TransferredFrom lists the players who handed the drink on, oldest first
+	TransferredFrom []string
+
+	// OwedLater indicates the drink was given to a designated driver, who pays it once they stop driving
+	OwedLater bool
+
+	// CoveredFor is the ID of the designated driver a substitute took the drink for
+	CoveredFor string
+
+	// PaidWithCredit indicates the drink was offset by a credit the player banked
+	PaidWithCredit bool
 }
 
 // PlayerTab contains information about a player's drinks
@@ -514,6 +1075,9 @@ type PlayerTab struct {
 
 	// NetDrinks is the net number of drinks (owed - assigned)
 	NetDrinks int
+
+	// Credits is how many drink credits the player has banked this session
+	Credits int
 }
 
 // GetPlayerTabOutput contains the result of retrieving a player's tab
@@ -523,6 +1087,12 @@ type GetPlayerTabOutput struct {
 
 	// Game is the game the tab is for
 	Game *models.Game
+
+	// Page is the page listed, the last one when the requested page is past it
+	Page int
+
+	// PageCount is how many pages the longer of the tab's lists takes
+	PageCount int
 }
 
 // ResetGameTabInput contains parameters for resetting a game's drink ledger
@@ -589,8 +1159,17 @@ type PayDrinkOutput struct {
 	// Game is the game the drink was paid in
 	Game *models.Game
 
-	// DrinkRecord is the drink record that was marked as paid
+	// DrinkRecord is the drink record that was marked as paid, nil when a credit was banked instead
 	DrinkRecord *models.DrinkLedger
+
+	// Credited is true when the player didn't owe a drink, so paying it banked a credit for the next one
+	Credited bool
+
+	// CreditBalance is how many credits the player has banked after paying
+	CreditBalance int
+
+	// PacingWarning indicates the player has paid drinks faster than the pacing config allows
+	PacingWarning bool
 }
 
 // CreateSessionInput represents the input for the CreateSession method
@@ -598,6 +1177,9 @@ type CreateSessionInput struct {
 	// ChannelID is the Discord channel ID for this session
 	ChannelID string
 
+	// GuildID is the Discord server the session is for (optional, defaults to a session for the channel)
+	GuildID string
+
 	// CreatedBy is the user ID who created the session
 	CreatedBy string
 }
@@ -620,6 +1202,10 @@ type GetSessionLeaderboardInput struct {
 	// SessionID is the specific session ID to get the leaderboard for
 	// If specified, will override ChannelID
 	SessionID string
+
+	// GuildID is the Discord server asking for the leaderboard, empty outside a server
+	// A session belonging to another server, or outside a server to another channel, is refused with ErrSessionNotFound
+	GuildID string
 }
 
 // GetSessionLeaderboardOutput represents the output of the GetSessionLeaderboard method
@@ -632,12 +1218,60 @@ type GetSessionLeaderboardOutput struct {
 
 	// Entries is the list of leaderboard entries
 	Entries []LeaderboardEntry
+
+	// ActiveDuration is how long the session has been running, leaving out the breaks it was paused for. It's
+	// zero when the leaderboard was looked up by session ID.
+	ActiveDuration time.Duration
+}
+
+// PauseSessionInput contains parameters for pausing a session for a break
+type PauseSessionInput struct {
+	// ChannelID is the channel whose session is paused
+	ChannelID string
+
+	// GuildID is the guild the channel belongs to, empty outside a guild
+	GuildID string
+
+	// PlayerID is the ID of the player pausing the session
+	PlayerID string
+
+	// Reason is why the session is paused, e.g. "food break" (optional)
+	Reason string
+}
+
+// PauseSessionOutput contains the paused session
+type PauseSessionOutput struct {
+	Session *models.Session
+}
+
+// ResumeSessionInput contains parameters for resuming a paused session
+type ResumeSessionInput struct {
+	// ChannelID is the channel whose session is resumed
+	ChannelID string
+
+	// GuildID is the guild the channel belongs to, empty outside a guild
+	GuildID string
+
+	// PlayerID is the ID of the player resuming the session
+	PlayerID string
+}
+
+// ResumeSessionOutput contains the resumed session
+type ResumeSessionOutput struct {
+	Session *models.Session
+
+	// PausedFor is how long the break lasted
+	PausedFor time.Duration
 }
 
 // StartNewSessionInput is the input for StartNewSession
 type StartNewSessionInput struct {
 	ChannelID string
+	GuildID   string // Optional, defaults to a session for the channel
 	CreatorID string
+
+	// Goals are the challenges to play the session with (optional)
+	Goals []*models.SessionGoal
 }
 
 // StartNewSessionOutput is the output for StartNewSession
@@ -646,3 +1280,908 @@ type StartNewSessionOutput struct {
 	Session   *models.Session
 	SessionID string
 }
+
+// SetDesignatedDriverInput contains parameters for making a player the designated driver for the session
+type SetDesignatedDriverInput struct {
+	// ChannelID is the channel whose session the player is driving for
+	ChannelID string
+
+	// GuildID is the Discord server the session is for (optional, defaults to a session for the channel)
+	GuildID string
+
+	// PlayerID is the ID of the player sitting out drinks
+	PlayerID string
+
+	// PlayerName is the display name of the player
+	PlayerName string
+}
+
+// SetDesignatedDriverOutput contains the result of making a player the designated driver
+type SetDesignatedDriverOutput struct {
+	// Session is the session the player is driving for
+	Session *models.Session
+}
+
+// ClearDesignatedDriverInput contains parameters for a designated driver to start drinking again
+type ClearDesignatedDriverInput struct {
+	// ChannelID is the channel whose session the player is driving for
+	ChannelID string
+
+	// GuildID is the Discord server the session is for (optional, defaults to a session for the channel)
+	GuildID string
+
+	// PlayerID is the ID of the designated driver
+	PlayerID string
+}
+
+// ClearDesignatedDriverOutput contains the result of a designated driver stepping down
+type ClearDesignatedDriverOutput struct {
+	// DrinksOwed is the number of drinks given to the player while driving that they now owe
+	DrinksOwed int
+}
+
+// CoverDesignatedDriverInput contains parameters for a player volunteering to drink for a designated driver
+type CoverDesignatedDriverInput struct {
+	// ChannelID is the channel whose session the driver is driving for
+	ChannelID string
+
+	// GuildID is the Discord server the session is for (optional, defaults to a session for the channel)
+	GuildID string
+
+	// DriverID is the ID of the designated driver being covered
+	DriverID string
+
+	// SubstituteID is the ID of the player volunteering to take the driver's drinks
+	SubstituteID string
+
+	// SubstituteName is the display name of the substitute
+	SubstituteName string
+}
+
+// CoverDesignatedDriverOutput contains the result of volunteering to cover a designated driver
+type CoverDesignatedDriverOutput struct {
+	// Driver is the designated driver now being covered
+	Driver *models.DesignatedDriver
+}
+
+// MergePlayersInput contains parameters for merging an alt account into a canonical player
+type MergePlayersInput struct {
+	// CanonicalPlayerID is the ID of the player that keeps the records
+	CanonicalPlayerID string
+
+	// AliasPlayerID is the ID of the alt account being merged
+	AliasPlayerID string
+
+	// GuildID is the Discord server the merge is made in, only the alt's drinks from it are moved
+	GuildID string
+}
+
+// MergePlayersOutput contains the result of merging players
+type MergePlayersOutput struct {
+	// Canonical is the player the alt account was merged into
+	Canonical *models.Player
+
+	// Alias is the merged alt account
+	Alias *models.Player
+
+	// RecordsMoved is the number of drink records moved to the canonical player
+	RecordsMoved int
+}
+
+// RerollDiceInput contains parameters for spending a re-roll token
+type RerollDiceInput struct {
+	// GameID is the ID of the game the roll was made in
+	GameID string
+
+	// PlayerID is the ID of the player re-rolling
+	PlayerID string
+}
+
+// GrantRerollTokensInput contains parameters for granting re-roll tokens to a player
+type GrantRerollTokensInput struct {
+	// PlayerID is the ID of the player receiving the tokens
+	PlayerID string
+
+	// PlayerName is the display name of the player, used if they haven't played yet
+	PlayerName string
+
+	// Count is the number of tokens to grant
+	Count int
+}
+
+// GrantRerollTokensOutput contains the result of granting re-roll tokens
+type GrantRerollTokensOutput struct {
+	// Tokens is the number of tokens the player now holds
+	Tokens int
+}
+
+// GetRerollTokensInput contains parameters for looking up a player's re-roll tokens
+type GetRerollTokensInput struct {
+	// PlayerID is the ID of the player
+	PlayerID string
+}
+
+// GetRerollTokensOutput contains a player's re-roll tokens
+type GetRerollTokensOutput struct {
+	// Tokens is the number of tokens the player holds
+	Tokens int
+}
+
+// AddRollModifierInput contains parameters for attaching a modifier to a player
+type AddRollModifierInput struct {
+	// PlayerID is the ID of the player receiving the modifier
+	PlayerID string
+
+	// PlayerName is the display name of the player, used if they haven't played yet
+	PlayerName string
+
+	// Modifier is the modifier to attach, it applies to one roll if Rolls isn't set
+	Modifier *models.RollModifier
+}
+
+// AddRollModifierOutput contains the result of attaching a modifier
+type AddRollModifierOutput struct {
+	// Modifiers are all the modifiers now waiting for the player's next rolls
+	Modifiers []*models.RollModifier
+}
+
+// ForgetPlayerInput contains parameters for deleting a player's data
+type ForgetPlayerInput struct {
+	// PlayerID is the ID of the player to forget
+	PlayerID string
+
+	// RequestedBy is the ID of whoever asked, the player themselves or a server admin
+	RequestedBy string
+
+	// GuildID is the Discord server the request came from, the player is also removed from its
+	// current session's designated drivers. Optional when players forget themselves; a server
+	// admin's purge requires it and only reaches the player's data from this server.
+	GuildID string
+}
+
+// ForgetPlayerOutput contains the result of deleting a player's data
+type ForgetPlayerOutput struct {
+	// AnonymousID is the ID that replaced the player in the records that were kept
+	AnonymousID string
+
+	// RecordsAnonymized is the number of drink records now attributed to the anonymous ID
+	RecordsAnonymized int
+
+	// RollsAnonymized is the number of logged rolls now attributed to the anonymous ID
+	RollsAnonymized int
+
+	// MomentsAnonymized is the number of moments now attributed to the anonymous ID
+	MomentsAnonymized int
+}
+
+// ForgiveDrinksInput contains parameters for writing off a player's drinks
+type ForgiveDrinksInput struct {
+	// ChannelID is the channel the drinks are forgiven from
+	ChannelID string
+
+	// GuildID is the Discord server whose session the drinks are in (optional)
+	GuildID string
+
+	// PlayerID is the ID of the player whose drinks are forgiven
+	PlayerID string
+
+	// WholeTab forgives every drink the player owes, otherwise only one drink is forgiven
+	WholeTab bool
+
+	// DrinkID is the single drink to forgive (optional), defaults to the first drink the player owes
+	DrinkID string
+
+	// ForgivenBy is the ID of the admin forgiving the drinks
+	ForgivenBy string
+
+	// Reason is why the drinks are forgiven
+	Reason string
+}
+
+// ForgiveDrinksOutput contains the result of writing off a player's drinks
+type ForgiveDrinksOutput struct {
+	// Records are the drink records that were forgiven
+	Records []*models.DrinkLedger
+}
+
+// RequestDrinkTransferInput contains parameters for offering one of a player's drinks to a volunteer
+type RequestDrinkTransferInput struct {
+	// ChannelID is the channel the transfer is offered in
+	ChannelID string
+
+	// GuildID is the Discord server whose session the drink is in (optional)
+	GuildID string
+
+	// FromPlayerID is the ID of the player giving a drink away
+	FromPlayerID string
+
+	// ToPlayerID is the ID of the volunteer asked to take it
+	ToPlayerID string
+}
+
+// RequestDrinkTransferOutput contains the pending transfer
+type RequestDrinkTransferOutput struct {
+	// Transfer is the offer waiting for the volunteer to accept
+	Transfer *models.DrinkTransfer
+}
+
+// AcceptDrinkTransferInput contains parameters for taking an offered drink
+type AcceptDrinkTransferInput struct {
+	// TransferID is the ID of the transfer
+	TransferID string
+
+	// PlayerID is the ID of the player accepting, who must be the volunteer
+	PlayerID string
+}
+
+// AcceptDrinkTransferOutput contains the result of taking an offered drink
+type AcceptDrinkTransferOutput struct {
+	// Transfer is the transfer that was accepted
+	Transfer *models.DrinkTransfer
+
+	// Record is the drink record, now on the volunteer's tab
+	Record *models.DrinkLedger
+}
+
+// DeclineDrinkTransferInput contains parameters for turning down a drink transfer
+type DeclineDrinkTransferInput struct {
+	// TransferID is the ID of the transfer
+	TransferID string
+
+	// PlayerID is the ID of the player declining, either the volunteer or the player who offered the drink
+	PlayerID string
+}
+
+// DeclineDrinkTransferOutput contains the transfer that was turned down
+type DeclineDrinkTransferOutput struct {
+	// Transfer is the transfer that was turned down
+	Transfer *models.DrinkTransfer
+}
+
+// OfferGuestDrinkInput contains parameters for offering a critical hit's drink to someone watching the game
+type OfferGuestDrinkInput struct {
+	// GameID is the ID of the game the drink was won in
+	GameID string
+
+	// FromPlayerID is the ID of the player with a drink to assign
+	FromPlayerID string
+
+	// GuestID is the ID of the channel member offered the drink
+	GuestID string
+
+	// GuestName is the display name of the channel member offered the drink
+	GuestName string
+}
+
+// OfferGuestDrinkOutput contains the drink offered to a guest
+type OfferGuestDrinkOutput struct {
+	// Offer is the drink waiting on the guest
+	Offer *models.GuestOffer
+}
+
+// AcceptGuestDrinkInput contains parameters for a guest taking the drink they were offered
+type AcceptGuestDrinkInput struct {
+	// GameID is the ID of the game the drink was won in
+	GameID string
+
+	// FromPlayerID is the ID of the player who offered the drink
+	FromPlayerID string
+
+	// GuestID is the ID of the channel member taking the drink
+	GuestID string
+}
+
+// AcceptGuestDrinkOutput contains the drink a guest took
+type AcceptGuestDrinkOutput struct {
+	// Offer is the offer that was taken
+	Offer *models.GuestOffer
+
+	// Record is the drink recorded for the guest, nil when happy hour left it on the house
+	Record *models.DrinkLedger
+
+	// HappyHourMultiplier is how many times the drink counted for being given during happy hour,
+	// 0 outside happy hour
+	HappyHourMultiplier float64
+
+	// GameEnded indicates if the game ended as a result of the drink being taken
+	GameEnded bool
+
+	// EndGameOutput contains the result of ending the game (if applicable)
+	EndGameOutput *EndGameOutput
+}
+
+// DeclineGuestDrinkInput contains parameters for turning down a guest drink
+type DeclineGuestDrinkInput struct {
+	// GameID is the ID of the game the drink was won in
+	GameID string
+
+	// FromPlayerID is the ID of the player who offered the drink
+	FromPlayerID string
+
+	// PlayerID is the ID of the guest or player calling it off
+	PlayerID string
+}
+
+// DeclineGuestDrinkOutput contains the guest drink that was turned down
+type DeclineGuestDrinkOutput struct {
+	// Offer is the offer that was turned down
+	Offer *models.GuestOffer
+}
+
+// LastCallInput contains parameters for calling last call on a channel's session
+type LastCallInput struct {
+	// ChannelID is the channel whose session is closing
+	ChannelID string
+
+	// GuildID is the guild the channel belongs to, empty outside a guild
+	GuildID string
+
+	// PlayerID is the ID of the player calling last call
+	PlayerID string
+}
+
+// LastCallOutput contains the session in last call
+type LastCallOutput struct {
+	// Session is the session, now in last call
+	Session *models.Session
+
+	// GameID is the game already under way that became the final one, empty if the next game will be
+	GameID string
+
+	// Multiplier is how many times each drink counts in the final game
+	Multiplier float64
+}
+
+// GetRollsInput contains the filters for listing logged rolls
+type GetRollsInput struct {
+	// GuildID and ChannelID pick the server whose rolls are listed
+	GuildID   string
+	ChannelID string
+
+	// PlayerID limits the rolls to one player (optional)
+	PlayerID string
+
+	// GameID limits the rolls to one game (optional)
+	GameID string
+
+	// Since and Until bound when the rolls were made (optional)
+	Since time.Time
+	Until time.Time
+
+	// Limit keeps only the most recent rolls, 0 returns them all
+	Limit int
+}
+
+// GetRollsOutput contains the logged rolls, oldest first
+type GetRollsOutput struct {
+	Rolls []*models.Roll
+}
+
+// ClipMomentInput contains parameters for clipping the latest roll in a channel
+type ClipMomentInput struct {
+	ChannelID string
+
+	// PlayerID and PlayerName are who's clipping the moment
+	PlayerID   string
+	PlayerName string
+}
+
+// ClipMomentOutput contains the clipped moment
+type ClipMomentOutput struct {
+	Moment *models.Moment
+}
+
+// GetMomentsInput contains parameters for listing a server's moments
+type GetMomentsInput struct {
+	// GuildID and ChannelID pick the server whose moments are listed
+	GuildID   string
+	ChannelID string
+
+	// Offset skips the newest moments and Limit caps how many are returned, 0 returns them all
+	Offset int
+	Limit  int
+}
+
+// GetMomentsOutput contains a page of moments, newest first
+type GetMomentsOutput struct {
+	Moments []*models.Moment
+
+	// Total is how many moments the server has
+	Total int
+}
+
+// SetBirthdayInput contains parameters for saving a player's birthday
+type SetBirthdayInput struct {
+	PlayerID   string
+	PlayerName string
+
+	// Birthday is the player's birthday, nil clears it
+	Birthday *models.Birthday
+}
+
+// SetBirthdayOutput contains the player's saved birthday
+type SetBirthdayOutput struct {
+	// Birthday is nil if it was cleared
+	Birthday *models.Birthday
+}
+
+// GetBirthdayInput contains parameters for looking up a player's birthday
+type GetBirthdayInput struct {
+	PlayerID string
+}
+
+// GetBirthdayOutput contains a player's birthday
+type GetBirthdayOutput struct {
+	// Birthday is nil if the player hasn't shared it
+	Birthday *models.Birthday
+
+	// IsToday is true if the player is celebrating today
+	IsToday bool
+}
+
+// ApplyBotConfigInput contains the runtime settings to apply
+type ApplyBotConfigInput struct {
+	Config *models.BotConfig
+}
+
+// ApplyBotConfigOutput contains the settings games are played with once the runtime settings are applied
+type ApplyBotConfigOutput struct {
+	// Rules are the rules of games without their own
+	Rules models.GameRules
+
+	// DrinkCap is the mercy rule's drink cap, 0 when it's off
+	DrinkCap int
+}
+
+// RecoverGamesInput contains parameters for recovering games after a restart
+type RecoverGamesInput struct {
+}
+
+// RecoverGamesOutput contains the games recovered after a restart
+type RecoverGamesOutput struct {
+	// Games are the unfinished top-level games, and any completed by the repairs, oldest first
+	Games []*models.Game
+
+	// Repaired is how many repairs were made
+	Repaired int
+}
+
+// CleanupGuildGamesInput contains parameters for resolving a server's stuck games
+type CleanupGuildGamesInput struct {
+	GuildID string
+}
+
+// CleanupGuildGamesOutput contains what cleaning up a server's games fixed
+type CleanupGuildGamesOutput struct {
+	// Games are the top-level games cleaned up or left being played, oldest first
+	Games []*models.Game
+
+	// Repaired is how many games were moved along, such as by ending games everyone had rolled in
+	Repaired int
+
+	// Abandoned is how many lobbies and games left idle, and roll-offs whose game was over, were abandoned
+	Abandoned int
+
+	// InPlay is how many games were played too recently, or paused, to be cleaned up
+	InPlay int
+}
+
+// CheckGameDurationsInput contains parameters for checking games against the max game duration
+type CheckGameDurationsInput struct {
+}
+
+// CheckGameDurationsOutput contains the games warned and ended for running too long
+type CheckGameDurationsOutput struct {
+	// WarnedGameIDs are the games whose slow players were just warned
+	WarnedGameIDs []string
+
+	// EndedGameIDs are the games that ran out of time and were ended
+	EndedGameIDs []string
+}
+
+// CreateChallengeInput contains parameters for challenging another player
+type CreateChallengeInput struct {
+	// ChannelID is the channel the challenge is made in
+	ChannelID string
+
+	// GuildID is the Discord server the challenge is made in, empty outside a server
+	GuildID string
+
+	// ChallengerID and ChallengerName are the player making the challenge
+	ChallengerID   string
+	ChallengerName string
+
+	// OpponentID and OpponentName are the player being challenged
+	OpponentID   string
+	OpponentName string
+
+	// Call is what the challenger bets the dice add up to, odds when it's empty
+	Call models.ChallengeCall
+}
+
+// CreateChallengeOutput contains the pending challenge
+type CreateChallengeOutput struct {
+	// Challenge is the challenge waiting for the opponent to accept
+	Challenge *models.ChallengeGame
+}
+
+// AcceptChallengeInput contains parameters for playing a challenge
+type AcceptChallengeInput struct {
+	// ChallengeID is the ID of the challenge
+	ChallengeID string
+
+	// PlayerID is the ID of the player accepting, who must be the opponent
+	PlayerID string
+}
+
+// AcceptChallengeOutput contains the result of a challenge
+type AcceptChallengeOutput struct {
+	// Challenge is the challenge with both players' rolls
+	Challenge *models.ChallengeGame
+
+	// DrinkRecord is the drink the loser took
+	DrinkRecord *models.DrinkLedger
+}
+
+// DeclineChallengeInput contains parameters for turning down a challenge
+type DeclineChallengeInput struct {
+	// ChallengeID is the ID of the challenge
+	ChallengeID string
+
+	// PlayerID is the ID of the player turning it down, either player can
+	PlayerID string
+}
+
+// DeclineChallengeOutput contains the challenge that was turned down
+type DeclineChallengeOutput struct {
+	// Challenge is the challenge that was turned down
+	Challenge *models.ChallengeGame
+}
+
+// PlaceSideBetInput contains parameters for betting on another player's roll
+type PlaceSideBetInput struct {
+	// GameID is the game the player is rolling in
+	GameID string
+
+	// BettorID is the ID of the player making the bet, they don't have to be playing
+	BettorID string
+
+	// BettorName is the display name of the player making the bet
+	BettorName string
+
+	// TargetPlayerID is the ID of the player whose roll is bet on, they mustn't have rolled yet
+	TargetPlayerID string
+
+	// Outcome is what the bet backs the roll to do
+	Outcome models.SideBetOutcome
+}
+
+// PlaceSideBetOutput contains the result of betting on another player's roll
+type PlaceSideBetOutput struct {
+	// Bet is the bet placed
+	Bet *models.SideBet
+}
+
+// InvitePlayersInput contains parameters for inviting players to a private game
+type InvitePlayersInput struct {
+	// GameID is the private game to invite the players to
+	GameID string
+
+	// InviterID is the ID of the player sending the invites, only the game's creator can
+	InviterID string
+
+	// PlayerIDs are the players to invite
+	PlayerIDs []string
+}
+
+// InvitePlayersOutput contains the result of inviting players to a private game
+type InvitePlayersOutput struct {
+	// Game is the private game with the players invited
+	Game *models.Game
+}
+
+// LedgerIssueType describes what's inconsistent about a drink record
+type LedgerIssueType string
+
+const (
+	// LedgerIssueMissingGame indicates the drink's game no longer exists
+	LedgerIssueMissingGame LedgerIssueType = "missing_game"
+
+	// LedgerIssueMissingSession indicates the drink's session no longer exists
+	LedgerIssueMissingSession LedgerIssueType = "missing_session"
+
+	// LedgerIssueUnknownPlayer indicates a player the drink was given to or by has no profile
+	LedgerIssueUnknownPlayer LedgerIssueType = "unknown_player"
+
+	// LedgerIssueUnpaidInClosedSession indicates the drink is still owed though its session has closed
+	LedgerIssueUnpaidInClosedSession LedgerIssueType = "unpaid_in_closed_session"
+)
+
+// LedgerIssue is an inconsistency found in a drink record
+type LedgerIssue struct {
+	// DrinkID is the ID of the drink record
+	DrinkID string
+
+	// Type is what's inconsistent about it
+	Type LedgerIssueType
+
+	// Detail names the game, session or player the issue is about
+	Detail string
+
+	// Repaired indicates the issue was fixed
+	Repaired bool
+}
+
+// VerifyLedgerInput contains parameters for checking the drink ledger
+type VerifyLedgerInput struct {
+	// Repair forgives drinks that can no longer be paid, those still owed in closed sessions or missing games.
+	// Other issues are only reported.
+	Repair bool
+}
+
+// VerifyLedgerOutput contains the result of checking the drink ledger
+type VerifyLedgerOutput struct {
+	// Checked is how many drink records were checked
+	Checked int
+
+	// Issues are the inconsistencies found, in drink ID order
+	Issues []*LedgerIssue
+
+	// Repaired is how many drinks were forgiven to repair their issues
+	Repaired int
+}
+
+// GetGuildOverviewInput contains parameters for looking over a guild
+type GetGuildOverviewInput struct {
+	GuildID string
+}
+
+// GetGuildOverviewOutput contains what's going on in a guild
+type GetGuildOverviewOutput struct {
+	// ActiveGames are the guild's games being played, roll-offs included, oldest first
+	ActiveGames []*models.Game
+
+	// Session is the guild's current session, nil when it has none
+	Session *models.Session
+
+	// Leaderboard is the current session's leaderboard
+	Leaderboard []LeaderboardEntry
+}
+
+// RotateDueSessionsInput contains parameters for rotating every session that's run its course
+type RotateDueSessionsInput struct{}
+
+// RotateDueSessionsOutput contains the sessions rotated
+type RotateDueSessionsOutput struct {
+	// RotatedSessionIDs are the sessions that were replaced
+	RotatedSessionIDs []string
+}
+
+// CheckDrinkRemindersInput contains parameters for reminding players of drinks they've owed a long time
+type CheckDrinkRemindersInput struct{}
+
+// CheckDrinkRemindersOutput contains the players reminded
+type CheckDrinkRemindersOutput struct {
+	// RemindedPlayerIDs are the players reminded of their drinks
+	RemindedPlayerIDs []string
+}
+
+// ProcessNextJobInput contains parameters for running the next background job
+type ProcessNextJobInput struct {
+	// Timeout is how long to wait for a job to be queued, 0 waits until one is or the context is done
+	Timeout time.Duration
+}
+
+// ProcessNextJobOutput contains the job that was run
+type ProcessNextJobOutput struct {
+	// Job is the job run, nil when none was queued in time or there's no job queue
+	Job *models.Job
+}
+
+// SetDrinkRemindersInput contains parameters for changing how a player is reminded of drinks they owe
+type SetDrinkRemindersInput struct {
+	PlayerID   string
+	PlayerName string
+
+	// Delivery is how the player is reminded (optional, empty keeps how they're reminded now)
+	Delivery models.ReminderDelivery
+
+	// Muted stops or restarts the player's reminders (optional, nil leaves them as they are)
+	Muted *bool
+}
+
+// SetDrinkRemindersOutput contains the player's reminder settings
+type SetDrinkRemindersOutput struct {
+	Reminders *models.DrinkReminders
+}
+
+// GetDrinkRemindersInput contains parameters for looking up how a player is reminded of drinks they owe
+type GetDrinkRemindersInput struct {
+	PlayerID string
+}
+
+// GetDrinkRemindersOutput contains the player's reminder settings
+type GetDrinkRemindersOutput struct {
+	// Reminders is never nil, players who haven't changed anything get the defaults
+	Reminders *models.DrinkReminders
+
+	// After is how long drinks are owed before the player is reminded, 0 when reminders are off for the bot
+	After time.Duration
+}
+
+// AddCoHostInput contains parameters for deputizing a co-host
+type AddCoHostInput struct {
+	GameID string
+
+	// PlayerID is the player deputizing, who must have created the game
+	PlayerID string
+
+	// CoHostID is the player being deputized, who must be in the game
+	CoHostID string
+}
+
+// AddCoHostOutput contains the game's co-hosts
+type AddCoHostOutput struct {
+	CoHostIDs []string
+}
+
+// RemoveCoHostInput contains parameters for taking a co-host's powers away
+type RemoveCoHostInput struct {
+	GameID string
+
+	// PlayerID is the player removing the co-host, who must have created the game
+	PlayerID string
+
+	CoHostID string
+}
+
+// RemoveCoHostOutput contains the game's remaining co-hosts
+type RemoveCoHostOutput struct {
+	CoHostIDs []string
+}
+
+// PauseGameInput contains parameters for pausing a game
+type PauseGameInput struct {
+	GameID string
+
+	// PlayerID is the host pausing the game
+	PlayerID string
+}
+
+// PauseGameOutput contains the result of pausing a game
+type PauseGameOutput struct {
+	PausedAt time.Time
+}
+
+// ResumeGameInput contains parameters for resuming a paused game
+type ResumeGameInput struct {
+	GameID string
+
+	// PlayerID is the host resuming the game
+	PlayerID string
+}
+
+// ResumeGameOutput contains the result of resuming a game
+type ResumeGameOutput struct {
+	// PausedFor is how long the game was paused
+	PausedFor time.Duration
+}
+
+// WrapUpGameInput contains parameters for ending a game stuck waiting on players
+type WrapUpGameInput struct {
+	GameID string
+
+	// PlayerID is the host wrapping the game up
+	PlayerID string
+}
+
+// WrapUpGameOutput contains the result of wrapping up a game
+type WrapUpGameOutput struct {
+	// SkippedPlayerIDs are the players dropped for not rolling
+	SkippedPlayerIDs []string
+}
+
+// CallVoteInput contains parameters for calling a vote on a game
+type CallVoteInput struct {
+	GameID string
+
+	// PlayerID is the player calling the vote, who must be in the game
+	PlayerID string
+
+	Action models.VoteAction
+}
+
+// CallVoteOutput contains the vote called
+type CallVoteOutput struct {
+	Vote *models.GameVote
+
+	// Closed is true once the vote has passed or can no longer pass
+	Closed bool
+
+	// Passed is true when the vote passed and its action was applied to the game
+	Passed bool
+}
+
+// CastVoteInput contains parameters for voting on the vote being held in a game
+type CastVoteInput struct {
+	GameID   string
+	PlayerID string
+
+	// Approve votes for the vote's action, false votes against it
+	Approve bool
+}
+
+// CastVoteOutput contains the vote after the player voted
+type CastVoteOutput struct {
+	Vote *models.GameVote
+
+	// Closed is true once the vote has passed or can no longer pass
+	Closed bool
+
+	// Passed is true when the vote passed and its action was applied to the game
+	Passed bool
+}
+
+// FairnessPattern is what two players giving each other drinks looks like
+type FairnessPattern string
+
+const (
+	// FairnessPatternAlliance is two players who give out plenty of drinks but never to each other
+	FairnessPatternAlliance FairnessPattern = "alliance"
+
+	// FairnessPatternFeud is two players who give each other at least half of their drinks
+	FairnessPatternFeud FairnessPattern = "feud"
+)
+
+// GetFairnessReportInput contains parameters for the fairness report of a channel's current session
+type GetFairnessReportInput struct {
+	ChannelID string
+
+	// GuildID is the Discord server asking for the report (optional)
+	GuildID string
+}
+
+// GetFairnessReportOutput contains who has been giving drinks to whom in a session
+type GetFairnessReportOutput struct {
+	// Session is the session the report is for, nil when the channel has no session
+	Session *models.Session
+
+	// Players is everyone who chose who takes a drink or was chosen, most drinks given first
+	Players []*FairnessPlayer
+
+	// Pairs is every two players who have given each other drinks or look like an alliance
+	Pairs []*FairnessPair
+}
+
+// FairnessPlayer is who a player has given the drinks they chose to
+type FairnessPlayer struct {
+	PlayerID   string
+	PlayerName string
+
+	// Given is how many drinks the player chose who takes
+	Given int
+
+	// Received is how many drinks other players chose the player to take
+	Received int
+
+	// Targets counts the drinks the player gave by who they gave them to
+	Targets map[string]int
+
+	// Karma is how evenly the player has spread the drinks they gave, see LeaderboardEntry
+	Karma int
+}
+
+// FairnessPair is the drinks two players have given each other
+type FairnessPair struct {
+	FirstPlayerID  string
+	SecondPlayerID string
+
+	// FirstToSecond is how many drinks the first player gave the second
+	FirstToSecond int
+
+	// SecondToFirst is how many drinks the second player gave the first
+	SecondToFirst int
+
+	// Pattern is what the drinks between them look like, empty when it's nothing out of the ordinary
+	Pattern FairnessPattern
+}
@@ -0,0 +1,199 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+)
+
+// ledgerRepairer is who drinks forgiven by a ledger repair are recorded as forgiven by
+const ledgerRepairer = "ledger-repair"
+
+// VerifyLedger cross-checks every drink record against the game, session and players it refers to. With Repair set,
+// drinks still owed that nobody can pay any more, because their game is gone or their session has closed, are
+// forgiven; the other issues need a closer look and are only reported.
+func (s *service) VerifyLedger(ctx context.Context, input *VerifyLedgerInput) (*VerifyLedgerOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	recordsOutput, err := s.drinkLedgerRepo.ListDrinkRecords(ctx, &ledgerRepo.ListDrinkRecordsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drink records: %w", err)
+	}
+
+	records := recordsOutput.Records
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ID < records[j].ID
+	})
+
+	check := &ledgerCheck{
+		service:  s,
+		games:    make(map[string]bool),
+		sessions: make(map[string]*models.Session),
+		players:  make(map[string]bool),
+	}
+
+	output := &VerifyLedgerOutput{
+		Checked: len(records),
+	}
+	for _, record := range records {
+		issues, err := check.record(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		if input.Repair && record.IsOutstanding() && unpayable(issues) {
+			if err := s.drinkLedgerRepo.ForgiveDrink(ctx, &ledgerRepo.ForgiveDrinkInput{
+				DrinkID:    record.ID,
+				ForgivenBy: ledgerRepairer,
+				Reason:     "Could no longer be paid",
+				ForgivenAt: s.clock.Now(),
+			}); err != nil {
+				return nil, fmt.Errorf("failed to forgive drink %s: %w", record.ID, err)
+			}
+
+			for _, issue := range issues {
+				issue.Repaired = issue.Type == LedgerIssueMissingGame || issue.Type == LedgerIssueUnpaidInClosedSession
+			}
+			output.Repaired++
+		}
+
+		output.Issues = append(output.Issues, issues...)
+	}
+
+	return output, nil
+}
+
+// unpayable returns true if the issues mean nobody can pay the drink any more
+func unpayable(issues []*LedgerIssue) bool {
+	for _, issue := range issues {
+		if issue.Type == LedgerIssueMissingGame || issue.Type == LedgerIssueUnpaidInClosedSession {
+			return true
+		}
+	}
+	return false
+}
+
+// ledgerCheck remembers the games, sessions and players already looked up while checking the ledger
+type ledgerCheck struct {
+	service  *service
+	games    map[string]bool
+	sessions map[string]*models.Session // nil for sessions that don't exist
+	players  map[string]bool
+}
+
+// record returns the issues with a drink record
+func (c *ledgerCheck) record(ctx context.Context, record *models.DrinkLedger) ([]*LedgerIssue, error) {
+	var issues []*LedgerIssue
+	issue := func(issueType LedgerIssueType, detail string) {
+		issues = append(issues, &LedgerIssue{
+			DrinkID: record.ID,
+			Type:    issueType,
+			Detail:  detail,
+		})
+	}
+
+	gameExists, err := c.gameExists(ctx, record.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if !gameExists {
+		issue(LedgerIssueMissingGame, record.GameID)
+	}
+
+	if record.SessionID != "" {
+		session, err := c.session(ctx, record.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case session == nil:
+			issue(LedgerIssueMissingSession, record.SessionID)
+		case !session.Active && record.IsOutstanding():
+			issue(LedgerIssueUnpaidInClosedSession, record.SessionID)
+		}
+	}
+
+	for _, playerID := range []string{record.ToPlayerID, record.FromPlayerID} {
+		if playerID == "" {
+			continue
+		}
+		playerExists, err := c.playerExists(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+		if !playerExists {
+			issue(LedgerIssueUnknownPlayer, playerID)
+		}
+	}
+
+	return issues, nil
+}
+
+// gameExists checks whether a game is still stored
+func (c *ledgerCheck) gameExists(ctx context.Context, gameID string) (bool, error) {
+	if gameID == "" {
+		return false, nil
+	}
+	if exists, ok := c.games[gameID]; ok {
+		return exists, nil
+	}
+
+	_, err := c.service.gameRepo.GetGame(ctx, &gameRepo.GetGameInput{
+		GameID: gameID,
+	})
+	if err != nil && !errors.Is(err, gameRepo.ErrGameNotFound) {
+		return false, fmt.Errorf("failed to get game %s: %w", gameID, err)
+	}
+
+	c.games[gameID] = err == nil
+	return c.games[gameID], nil
+}
+
+// session returns a stored session, nil when it doesn't exist
+func (c *ledgerCheck) session(ctx context.Context, sessionID string) (*models.Session, error) {
+	if session, ok := c.sessions[sessionID]; ok {
+		return session, nil
+	}
+
+	output, err := c.service.drinkLedgerRepo.GetSession(ctx, &ledgerRepo.GetSessionInput{
+		SessionID: sessionID,
+	})
+	if err != nil && !errors.Is(err, ledgerRepo.ErrSessionNotFound) {
+		return nil, fmt.Errorf("failed to get session %s: %w", sessionID, err)
+	}
+
+	var session *models.Session
+	if err == nil {
+		session = output.Session
+	}
+	c.sessions[sessionID] = session
+	return session, nil
+}
+
+// playerExists checks whether a player has a profile
+func (c *ledgerCheck) playerExists(ctx context.Context, playerID string) (bool, error) {
+	if exists, ok := c.players[playerID]; ok {
+		return exists, nil
+	}
+
+	_, err := c.service.playerRepo.GetPlayer(ctx, &playerRepo.GetPlayerInput{
+		PlayerID: playerID,
+	})
+	if err != nil && !errors.Is(err, playerRepo.ErrPlayerNotFound) {
+		return false, fmt.Errorf("failed to get player %s: %w", playerID, err)
+	}
+
+	c.players[playerID] = err == nil
+	return c.players[playerID], nil
+}
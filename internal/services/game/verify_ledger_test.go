@@ -0,0 +1,126 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	ledgerRepo "github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
+	"github.com/stretchr/testify/suite"
+)
+
+// VerifyLedgerTestSuite tests checking and repairing the drink ledger against the real Redis repositories
+type VerifyLedgerTestSuite struct {
+	serviceHarness
+}
+
+func (s *VerifyLedgerTestSuite) SetupTest() {
+	s.setUpService(nil)
+	s.testChannelID = "ledger-channel"
+}
+
+func TestVerifyLedgerTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyLedgerTestSuite))
+}
+
+// playGame plays a game in a new session where bob rolls lowest, returning the game and the drink he owes
+func (s *VerifyLedgerTestSuite) playGame() (string, *models.DrinkLedger) {
+	_, err := s.gameService.CreateSession(s.ctx, &CreateSessionInput{ChannelID: s.testChannelID, CreatedBy: "alice"})
+	s.Require().NoError(err)
+
+	gameID := s.serviceHarness.playGame([]string{"alice", "bob"}, []int{4, 2})
+
+	drinks, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	s.Require().Len(drinks.Records, 1)
+	s.Require().NotEmpty(drinks.Records[0].SessionID)
+
+	return gameID, drinks.Records[0]
+}
+
+// addDrink adds a drink record straight to the ledger
+func (s *VerifyLedgerTestSuite) addDrink(record *models.DrinkLedger) {
+	record.Reason = models.DrinkReasonLowestRoll
+	record.Timestamp = time.Now()
+	s.Require().NoError(s.ledgerRepo.AddDrinkRecord(s.ctx, &ledgerRepo.AddDrinkRecordInput{Record: record}))
+}
+
+// verify checks the ledger, returning the issues found by drink
+func (s *VerifyLedgerTestSuite) verify(repair bool) (*VerifyLedgerOutput, map[string][]LedgerIssueType) {
+	output, err := s.gameService.VerifyLedger(s.ctx, &VerifyLedgerInput{Repair: repair})
+	s.Require().NoError(err)
+
+	issues := make(map[string][]LedgerIssueType)
+	for _, issue := range output.Issues {
+		issues[issue.DrinkID] = append(issues[issue.DrinkID], issue.Type)
+	}
+	return output, issues
+}
+
+func (s *VerifyLedgerTestSuite) TestVerifyLedger_Consistent() {
+	s.playGame()
+
+	output, issues := s.verify(false)
+	s.Equal(1, output.Checked)
+	s.Empty(issues)
+}
+
+func (s *VerifyLedgerTestSuite) TestVerifyLedger_ReportsIssues() {
+	gameID, owed := s.playGame()
+
+	// Closing the session leaves bob's drink unpaid in it
+	_, err := s.gameService.CreateSession(s.ctx, &CreateSessionInput{ChannelID: s.testChannelID, CreatedBy: "alice"})
+	s.Require().NoError(err)
+
+	s.addDrink(&models.DrinkLedger{ID: "ghost-drink", GameID: "gone-game", ToPlayerID: "ghost"})
+	s.addDrink(&models.DrinkLedger{ID: "lost-session-drink", GameID: gameID, ToPlayerID: "bob", SessionID: "gone-session", Paid: true})
+
+	output, issues := s.verify(false)
+	s.Equal(3, output.Checked)
+	s.Zero(output.Repaired)
+	s.Equal(map[string][]LedgerIssueType{
+		owed.ID:              {LedgerIssueUnpaidInClosedSession},
+		"ghost-drink":        {LedgerIssueMissingGame, LedgerIssueUnknownPlayer},
+		"lost-session-drink": {LedgerIssueMissingSession},
+	}, issues)
+
+	// Nothing was changed
+	for _, issue := range output.Issues {
+		s.False(issue.Repaired)
+	}
+	bobDrinks, err := s.ledgerRepo.GetDrinkRecordsForGame(s.ctx, &ledgerRepo.GetDrinkRecordsForGameInput{GameID: gameID})
+	s.Require().NoError(err)
+	for _, drink := range bobDrinks.Records {
+		s.False(drink.Forgiven)
+	}
+}
+
+func (s *VerifyLedgerTestSuite) TestVerifyLedger_Repair() {
+	_, owed := s.playGame()
+
+	_, err := s.gameService.CreateSession(s.ctx, &CreateSessionInput{ChannelID: s.testChannelID, CreatedBy: "alice"})
+	s.Require().NoError(err)
+
+	s.addDrink(&models.DrinkLedger{ID: "ghost-drink", GameID: "gone-game", ToPlayerID: "ghost"})
+
+	output, _ := s.verify(true)
+	s.Equal(2, output.Repaired)
+	for _, issue := range output.Issues {
+		s.Equal(issue.Type != LedgerIssueUnknownPlayer, issue.Repaired, issue.Type)
+	}
+
+	// Both drinks were forgiven
+	bobDrinks, err := s.ledgerRepo.GetDrinkRecordsForPlayer(s.ctx, &ledgerRepo.GetDrinkRecordsForPlayerInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Require().Len(bobDrinks.Records, 1)
+	s.Equal(owed.ID, bobDrinks.Records[0].ID)
+	s.True(bobDrinks.Records[0].Forgiven)
+	s.Equal(ledgerRepairer, bobDrinks.Records[0].ForgivenBy)
+
+	// The closed session no longer has anything owed, the missing game and player are still reported
+	output, issues := s.verify(true)
+	s.Zero(output.Repaired)
+	s.Equal(map[string][]LedgerIssueType{
+		"ghost-drink": {LedgerIssueMissingGame, LedgerIssueUnknownPlayer},
+	}, issues)
+}
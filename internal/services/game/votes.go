@@ -0,0 +1,203 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// voteTTL is how long players have to vote before a vote fails
+const voteTTL = 2 * time.Minute
+
+// defaultVoteThreshold is the share of a game's players a vote's approvals have to be more than when it isn't
+// configured, so a majority has to approve
+const defaultVoteThreshold = 0.5
+
+// CallVote has a player in the game call a vote to start or abandon it, approving it themselves. The vote passes
+// as soon as enough of the players approve, which may be straight away.
+func (s *service) CallVote(ctx context.Context, input *CallVoteInput) (*CallVoteOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+	if !input.Action.IsValid() {
+		return nil, ErrInvalidVoteAction
+	}
+
+	var game *models.Game
+	var result *CastVoteOutput
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.getGame(ctx, input.GameID)
+		if err != nil {
+			return err
+		}
+
+		if !game.HasParticipant(input.PlayerID) {
+			return ErrPlayerNotInGame
+		}
+		if !s.canVoteOn(game, input.Action) {
+			return ErrInvalidGameState
+		}
+
+		now := s.clock.Now()
+		if s.openVote(game, now) != nil {
+			return ErrVoteInProgress
+		}
+
+		game.Vote = &models.GameVote{
+			Action:    input.Action,
+			CalledBy:  input.PlayerID,
+			Required:  s.votesRequired(len(game.Participants)),
+			Approvals: []string{input.PlayerID},
+			CalledAt:  now,
+			ExpiresAt: now.Add(voteTTL),
+		}
+
+		result, err = s.countVotes(ctx, game)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.closeVote(ctx, game, result); err != nil {
+		return nil, err
+	}
+
+	return &CallVoteOutput{
+		Vote:   result.Vote,
+		Passed: result.Passed,
+		Closed: result.Closed,
+	}, nil
+}
+
+// CastVote records a player's vote on the vote being held in their game, passing or failing it once the outcome
+// is settled
+func (s *service) CastVote(ctx context.Context, input *CastVoteInput) (*CastVoteOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	var game *models.Game
+	var output *CastVoteOutput
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.getGame(ctx, input.GameID)
+		if err != nil {
+			return err
+		}
+
+		vote := s.openVote(game, s.clock.Now())
+		if vote == nil {
+			return ErrNoVote
+		}
+		if !game.HasParticipant(input.PlayerID) {
+			return ErrPlayerNotInGame
+		}
+		if vote.HasVoted(input.PlayerID) {
+			return ErrAlreadyVoted
+		}
+
+		if input.Approve {
+			vote.Approvals = append(vote.Approvals, input.PlayerID)
+		} else {
+			vote.Rejections = append(vote.Rejections, input.PlayerID)
+		}
+
+		output, err = s.countVotes(ctx, game)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.closeVote(ctx, game, output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// countVotes saves the game's vote, closing it once it has passed or can no longer pass
+func (s *service) countVotes(ctx context.Context, game *models.Game) (*CastVoteOutput, error) {
+	vote := game.Vote
+	output := &CastVoteOutput{
+		Vote:   vote,
+		Passed: vote.Passed(),
+		Closed: vote.Passed() || !vote.CanPass(len(game.Participants)),
+	}
+
+	if output.Closed {
+		game.Vote = nil
+	}
+	if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return output, nil
+}
+
+// closeVote applies a vote that passed to the game and lets the channel know the vote is over, once it's closed
+func (s *service) closeVote(ctx context.Context, game *models.Game, output *CastVoteOutput) error {
+	if !output.Closed {
+		return nil
+	}
+
+	vote := output.Vote
+	if output.Passed {
+		var err error
+		switch vote.Action {
+		case models.VoteActionStart:
+			_, err = s.StartGame(ctx, &StartGameInput{GameID: game.ID, PlayerID: game.CreatorID})
+		case models.VoteActionAbandon:
+			_, err = s.AbandonGame(ctx, &AbandonGameInput{GameID: game.ID})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s game: %w", vote.Action, err)
+		}
+	}
+
+	s.eventBus.Publish(ctx, &events.Event{
+		Type:      events.TypeVoteClosed,
+		ChannelID: game.ChannelID,
+		GuildID:   game.GuildID,
+		GameID:    game.ID,
+		Timestamp: s.clock.Now(),
+		Payload: &events.VoteClosedPayload{
+			Action:    string(vote.Action),
+			CalledBy:  vote.CalledBy,
+			Passed:    output.Passed,
+			Approvals: vote.Approvals,
+		},
+	})
+
+	return nil
+}
+
+// openVote returns the vote being held in the game, nil when there isn't one or it's run out of time or been
+// overtaken by the game, like a vote to start a game its host already started
+func (s *service) openVote(game *models.Game, now time.Time) *models.GameVote {
+	if game.Vote == nil || game.Vote.IsExpired(now) || !s.canVoteOn(game, game.Vote.Action) {
+		return nil
+	}
+	return game.Vote
+}
+
+// canVoteOn checks whether the action can be applied to the game: only games waiting for players can be
+// started, and only games that aren't over can be abandoned
+func (s *service) canVoteOn(game *models.Game, action models.VoteAction) bool {
+	if action == models.VoteActionStart {
+		return s.lifecycle.CanTransition(game.Status, models.GameStatusActive)
+	}
+	return !game.Status.IsFinished()
+}
+
+// votesRequired returns how many of a game's players have to approve a vote for it to pass: more than the
+// threshold's share of them, or all of them when the threshold is everyone
+func (s *service) votesRequired(players int) int {
+	return max(1, min(players, int(math.Floor(s.voteThreshold*float64(players)))+1))
+}
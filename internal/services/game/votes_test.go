@@ -0,0 +1,146 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// VoteTestSuite tests votes to start and abandon games against the real Redis repositories
+type VoteTestSuite struct {
+	serviceHarness
+	clock  *clock.Fake
+	closed []*events.VoteClosedPayload
+}
+
+func (s *VoteTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2025, 4, 19, 21, 0, 0, 0, time.UTC))
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = s.clock
+	})
+	s.testChannelID = "vote-channel"
+
+	s.closed = nil
+	s.eventBus.Subscribe(events.TypeVoteClosed, func(_ context.Context, event *events.Event) {
+		s.closed = append(s.closed, event.Payload.(*events.VoteClosedPayload))
+	})
+}
+
+func TestVoteTestSuite(t *testing.T) {
+	suite.Run(t, new(VoteTestSuite))
+}
+
+func (s *VoteTestSuite) vote(gameID, playerID string, approve bool) *CastVoteOutput {
+	output, err := s.gameService.CastVote(s.ctx, &CastVoteInput{GameID: gameID, PlayerID: playerID, Approve: approve})
+	s.Require().NoError(err)
+	return output
+}
+
+func (s *VoteTestSuite) TestVoteToStart() {
+	gameID := s.createGame("alice", "bob", "carol", "dave")
+
+	callOutput, err := s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: models.VoteActionStart})
+	s.Require().NoError(err)
+	s.False(callOutput.Closed)
+	s.Equal(3, callOutput.Vote.Required, "more than half of the four players have to approve")
+	s.Equal([]string{"bob"}, callOutput.Vote.Approvals)
+
+	_, err = s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "carol", Action: models.VoteActionAbandon})
+	s.ErrorIs(err, ErrVoteInProgress)
+
+	_, err = s.gameService.CastVote(s.ctx, &CastVoteInput{GameID: gameID, PlayerID: "bob", Approve: true})
+	s.ErrorIs(err, ErrAlreadyVoted)
+	_, err = s.gameService.CastVote(s.ctx, &CastVoteInput{GameID: gameID, PlayerID: "eve", Approve: true})
+	s.ErrorIs(err, ErrPlayerNotInGame)
+
+	s.False(s.vote(gameID, "carol", true).Closed)
+	s.Equal(models.GameStatusWaiting, s.getGame(gameID).Status)
+
+	output := s.vote(gameID, "dave", true)
+	s.True(output.Closed)
+	s.True(output.Passed)
+
+	game := s.getGame(gameID)
+	s.Equal(models.GameStatusActive, game.Status)
+	s.Nil(game.Vote)
+
+	s.Require().Len(s.closed, 1)
+	s.True(s.closed[0].Passed)
+	s.Equal("start", s.closed[0].Action)
+
+	_, err = s.gameService.CastVote(s.ctx, &CastVoteInput{GameID: gameID, PlayerID: "alice", Approve: true})
+	s.ErrorIs(err, ErrNoVote)
+}
+
+func (s *VoteTestSuite) TestVoteToAbandon_Fails() {
+	gameID := s.createGame("alice", "bob", "carol")
+
+	_, err := s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: models.VoteActionAbandon})
+	s.Require().NoError(err)
+
+	// Two of three players turning it down leaves too few to pass it
+	s.False(s.vote(gameID, "alice", false).Closed)
+	output := s.vote(gameID, "carol", false)
+	s.True(output.Closed)
+	s.False(output.Passed)
+
+	s.Equal(models.GameStatusWaiting, s.getGame(gameID).Status)
+	s.Require().Len(s.closed, 1)
+	s.False(s.closed[0].Passed)
+}
+
+func (s *VoteTestSuite) TestVoteToAbandon_Passes() {
+	gameID := s.createGame("alice", "bob", "carol")
+
+	_, err := s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: models.VoteActionAbandon})
+	s.Require().NoError(err)
+	s.True(s.vote(gameID, "carol", true).Passed)
+
+	s.Equal(models.GameStatusAbandoned, s.getGame(gameID).Status)
+}
+
+func (s *VoteTestSuite) TestVoteExpires() {
+	gameID := s.createGame("alice", "bob", "carol")
+
+	_, err := s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: models.VoteActionStart})
+	s.Require().NoError(err)
+
+	s.clock.Advance(voteTTL)
+	_, err = s.gameService.CastVote(s.ctx, &CastVoteInput{GameID: gameID, PlayerID: "carol", Approve: true})
+	s.ErrorIs(err, ErrNoVote)
+
+	// A new vote can be called once the old one has lapsed
+	_, err = s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "carol", Action: models.VoteActionStart})
+	s.NoError(err)
+}
+
+func (s *VoteTestSuite) TestCallVote_Invalid() {
+	gameID := s.createGame("alice", "bob")
+
+	_, err := s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: "mutiny"})
+	s.ErrorIs(err, ErrInvalidVoteAction)
+
+	_, err = s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "eve", Action: models.VoteActionStart})
+	s.ErrorIs(err, ErrPlayerNotInGame)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	_, err = s.gameService.CallVote(s.ctx, &CallVoteInput{GameID: gameID, PlayerID: "bob", Action: models.VoteActionStart})
+	s.ErrorIs(err, ErrInvalidGameState, "a game that's started can't be voted to start")
+}
+
+func (s *VoteTestSuite) TestVotesRequired() {
+	svc := s.gameService.(*service)
+	s.Equal(1, svc.votesRequired(1))
+	s.Equal(2, svc.votesRequired(2))
+	s.Equal(2, svc.votesRequired(3))
+	s.Equal(3, svc.votesRequired(4))
+
+	svc.voteThreshold = 1
+	s.Equal(4, svc.votesRequired(4), "a threshold of everyone needs every player")
+}
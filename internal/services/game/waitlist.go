@@ -0,0 +1,203 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+)
+
+// JoinWaitlist puts a player on the waitlist of a full game that hasn't started, or tells them where they are
+// if they're already on it
+func (s *service) JoinWaitlist(ctx context.Context, input *JoinWaitlistInput) (*JoinWaitlistOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	output := &JoinWaitlistOutput{}
+	err := retryOnGameChange(func() error {
+		game, err := s.getGame(ctx, input.GameID)
+		if err != nil {
+			return err
+		}
+
+		if game.Status != models.GameStatusWaiting {
+			return ErrInvalidGameState
+		}
+		if game.HasParticipant(input.PlayerID) {
+			return ErrPlayerAlreadyInGame
+		}
+		if !game.CanJoin(input.PlayerID) {
+			return ErrNotInvited
+		}
+
+		if output.Position = game.WaitlistPosition(input.PlayerID); output.Position > 0 {
+			return nil
+		}
+		if len(game.Participants) < s.rulesFor(game).MaxPlayers {
+			return ErrGameNotFull
+		}
+
+		game.Waitlist = append(game.Waitlist, &models.WaitlistEntry{
+			PlayerID:   input.PlayerID,
+			PlayerName: input.PlayerName,
+			AddedAt:    s.clock.Now(),
+		})
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+		output.Position = len(game.Waitlist)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// LeaveGame takes a player out of a game that hasn't started, or off its waitlist. A spot left in the game goes
+// to the first player on the waitlist, who is told about it.
+func (s *service) LeaveGame(ctx context.Context, input *LeaveGameInput) (*LeaveGameOutput, error) {
+	if input == nil || input.GameID == "" || input.PlayerID == "" {
+		return nil, errors.New("game and player IDs are required")
+	}
+
+	var game *models.Game
+	waitlisted := false
+	err := retryOnGameChange(func() error {
+		var err error
+		game, err = s.getGame(ctx, input.GameID)
+		if err != nil {
+			return err
+		}
+
+		if game.Status != models.GameStatusWaiting {
+			return ErrInvalidGameState
+		}
+
+		waitlisted = game.WaitlistPosition(input.PlayerID) > 0
+		if waitlisted {
+			game.Waitlist = slices.DeleteFunc(game.Waitlist, func(entry *models.WaitlistEntry) bool {
+				return entry.PlayerID == input.PlayerID
+			})
+			if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+				return fmt.Errorf("failed to save game: %w", err)
+			}
+			return nil
+		}
+
+		if !game.HasParticipant(input.PlayerID) {
+			return ErrPlayerNotInGame
+		}
+		if input.PlayerID == game.CreatorID {
+			return ErrCreatorCannotLeave
+		}
+
+		game.Participants = slices.DeleteFunc(game.Participants, func(participant *models.Participant) bool {
+			return participant.PlayerID == input.PlayerID
+		})
+		game.IndexParticipants()
+		game.CoHostIDs = slices.DeleteFunc(game.CoHostIDs, func(id string) bool {
+			return id == input.PlayerID
+		})
+		game.UpdatedAt = s.clock.Now()
+
+		if err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game}); err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if waitlisted {
+		return &LeaveGameOutput{
+			Success:    true,
+			Waitlisted: true,
+		}, nil
+	}
+	s.releasePlayers(ctx, game.ID, []string{input.PlayerID})
+
+	promoted, err := s.promoteFromWaitlist(ctx, game, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LeaveGameOutput{
+		Success:          true,
+		PromotedPlayerID: promoted,
+	}, nil
+}
+
+// promoteFromWaitlist gives the spot a player left to the first player on the waitlist who can still join,
+// returning who got it
+func (s *service) promoteFromWaitlist(ctx context.Context, game *models.Game, leftPlayerID string) (string, error) {
+	for len(game.Waitlist) > 0 {
+		entry, err := s.popWaitlist(ctx, game)
+		if err != nil {
+			return "", err
+		}
+		if entry == nil {
+			break
+		}
+
+		if _, err := s.JoinGame(ctx, &JoinGameInput{
+			GameID:     game.ID,
+			PlayerID:   entry.PlayerID,
+			PlayerName: entry.PlayerName,
+		}); err != nil {
+			log.Printf("Error promoting %s from the waitlist of game %s: %v", entry.PlayerID, game.ID, err)
+			continue
+		}
+
+		s.eventBus.Publish(ctx, &events.Event{
+			Type:      events.TypeWaitlistPromoted,
+			ChannelID: game.ChannelID,
+			GuildID:   game.GuildID,
+			GameID:    game.ID,
+			Timestamp: s.clock.Now(),
+			Payload: &events.WaitlistPromotedPayload{
+				PlayerID:     entry.PlayerID,
+				PlayerName:   entry.PlayerName,
+				LeftPlayerID: leftPlayerID,
+			},
+		})
+		return entry.PlayerID, nil
+	}
+
+	return "", nil
+}
+
+// popWaitlist takes the first player off the game's waitlist, reading the game again if it changed since it was
+// read. It returns nil once the waitlist is empty.
+func (s *service) popWaitlist(ctx context.Context, game *models.Game) (*models.WaitlistEntry, error) {
+	var entry *models.WaitlistEntry
+	err := retryOnGameChange(func() error {
+		if len(game.Waitlist) == 0 {
+			return nil
+		}
+
+		entry = game.Waitlist[0]
+		game.Waitlist = game.Waitlist[1:]
+		err := s.gameRepo.SaveGame(ctx, &gameRepo.SaveGameInput{Game: game})
+		if errors.Is(err, gameRepo.ErrGameChanged) {
+			entry = nil
+			latest, getErr := s.getGame(ctx, game.ID)
+			if getErr != nil {
+				return getErr
+			}
+			*game = *latest
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save game: %w", err)
+		}
+		return nil
+	})
+	return entry, err
+}
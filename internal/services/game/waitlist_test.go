@@ -0,0 +1,119 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	playerRepo "github.com/KirkDiggler/ronnied/internal/repositories/player"
+	"github.com/stretchr/testify/suite"
+)
+
+// WaitlistTestSuite tests waitlists for full games against the real Redis repositories
+type WaitlistTestSuite struct {
+	serviceHarness
+	promoted []*events.WaitlistPromotedPayload
+}
+
+func (s *WaitlistTestSuite) SetupTest() {
+	s.setUpService(func(cfg *Config) {
+		cfg.Clock = clock.NewFake(time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC))
+	})
+	s.testChannelID = "waitlist-channel"
+
+	s.promoted = nil
+	s.eventBus.Subscribe(events.TypeWaitlistPromoted, func(_ context.Context, event *events.Event) {
+		s.promoted = append(s.promoted, event.Payload.(*events.WaitlistPromotedPayload))
+	})
+}
+
+func TestWaitlistTestSuite(t *testing.T) {
+	suite.Run(t, new(WaitlistTestSuite))
+}
+
+// createFullGame creates a two player game alice created and bob joined, returning the game ID
+func (s *WaitlistTestSuite) createFullGame() string {
+	return s.createGameWith(&CreateGameInput{Rules: &models.GameRules{MaxPlayers: 2}}, "alice", "bob")
+}
+
+func (s *WaitlistTestSuite) joinWaitlist(gameID, playerID string) int {
+	output, err := s.gameService.JoinWaitlist(s.ctx, &JoinWaitlistInput{GameID: gameID, PlayerID: playerID, PlayerName: playerID})
+	s.Require().NoError(err)
+	return output.Position
+}
+
+func (s *WaitlistTestSuite) TestJoinWaitlist() {
+	gameID := s.createFullGame()
+
+	s.Equal(1, s.joinWaitlist(gameID, "carol"))
+	s.Equal(2, s.joinWaitlist(gameID, "dave"))
+	s.Equal(1, s.joinWaitlist(gameID, "carol"), "joining again keeps their spot")
+
+	_, err := s.gameService.JoinWaitlist(s.ctx, &JoinWaitlistInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrPlayerAlreadyInGame)
+}
+
+func (s *WaitlistTestSuite) TestJoinWaitlistNotFull() {
+	createOutput, err := s.gameService.CreateGame(s.ctx, &CreateGameInput{
+		ChannelID:   "waitlist-channel",
+		CreatorID:   "alice",
+		CreatorName: "alice",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.gameService.JoinWaitlist(s.ctx, &JoinWaitlistInput{GameID: createOutput.GameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrGameNotFull)
+}
+
+func (s *WaitlistTestSuite) TestLeaveGamePromotesWaitlist() {
+	gameID := s.createFullGame()
+	s.joinWaitlist(gameID, "carol")
+	s.joinWaitlist(gameID, "dave")
+
+	output, err := s.gameService.LeaveGame(s.ctx, &LeaveGameInput{GameID: gameID, PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Equal("carol", output.PromotedPlayerID)
+
+	game := s.getGame(gameID)
+	s.False(game.HasParticipant("bob"))
+	s.True(game.HasParticipant("carol"))
+	s.Equal(1, game.WaitlistPosition("dave"))
+
+	bob, err := s.playerRepo.GetPlayer(s.ctx, &playerRepo.GetPlayerInput{PlayerID: "bob"})
+	s.Require().NoError(err)
+	s.Empty(bob.CurrentGameID)
+
+	s.Require().Len(s.promoted, 1)
+	s.Equal("carol", s.promoted[0].PlayerID)
+	s.Equal("bob", s.promoted[0].LeftPlayerID)
+}
+
+func (s *WaitlistTestSuite) TestLeaveWaitlist() {
+	gameID := s.createFullGame()
+	s.joinWaitlist(gameID, "carol")
+	s.joinWaitlist(gameID, "dave")
+
+	output, err := s.gameService.LeaveGame(s.ctx, &LeaveGameInput{GameID: gameID, PlayerID: "carol"})
+	s.Require().NoError(err)
+	s.True(output.Waitlisted)
+	s.Equal(1, s.getGame(gameID).WaitlistPosition("dave"))
+	s.Empty(s.promoted)
+}
+
+func (s *WaitlistTestSuite) TestLeaveGameErrors() {
+	gameID := s.createFullGame()
+
+	_, err := s.gameService.LeaveGame(s.ctx, &LeaveGameInput{GameID: gameID, PlayerID: "alice"})
+	s.ErrorIs(err, ErrCreatorCannotLeave)
+
+	_, err = s.gameService.LeaveGame(s.ctx, &LeaveGameInput{GameID: gameID, PlayerID: "carol"})
+	s.ErrorIs(err, ErrPlayerNotInGame)
+
+	_, err = s.gameService.StartGame(s.ctx, &StartGameInput{GameID: gameID, PlayerID: "alice"})
+	s.Require().NoError(err)
+	_, err = s.gameService.LeaveGame(s.ctx, &LeaveGameInput{GameID: gameID, PlayerID: "bob"})
+	s.ErrorIs(err, ErrInvalidGameState, "nobody leaves once the game has started")
+}
@@ -0,0 +1,30 @@
+package guild_config
+
+// GuildConfigError is a custom error type for guild config errors
+type GuildConfigError string
+
+// Error implements the error interface
+func (e GuildConfigError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig          GuildConfigError = "config cannot be nil"
+	ErrNilGuildConfigRepo GuildConfigError = "guild config repository cannot be nil"
+	ErrInvalidInput       GuildConfigError = "invalid input"
+	ErrInvalidRollFeed    GuildConfigError = "unknown roll feed mode"
+	ErrInvalidDisplay     GuildConfigError = "unknown display density"
+	ErrInvalidCritValues  GuildConfigError = "critical values must be die faces and can't be both a hit and a fail"
+	ErrInvalidRating      GuildConfigError = "unknown content rating"
+	ErrInvalidTone        GuildConfigError = "unknown message tone"
+	ErrInvalidDice        GuildConfigError = "dice must have between 2 and 100 sides"
+	ErrInvalidScoring     GuildConfigError = "unknown scoring mode"
+	ErrInvalidEmojiSlot   GuildConfigError = "unknown emoji slot"
+	ErrInvalidEmoji       GuildConfigError = "emoji must be one of the server's custom emoji"
+	ErrInvalidPersona     GuildConfigError = "persona needs a name Discord allows and an http(s) avatar link"
+	ErrInvalidChannelMode GuildConfigError = "unknown game channel mode"
+	ErrNoAllowedChannels  GuildConfigError = "games need at least one channel they're allowed in"
+	ErrInvalidTieStrategy GuildConfigError = "unknown tie strategy"
+	ErrInvalidRonnieRule  GuildConfigError = "unknown rule for Ronnie's drinks"
+)
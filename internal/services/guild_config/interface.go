@@ -0,0 +1,56 @@
+package guild_config
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_service.go github.com/KirkDiggler/ronnied/internal/services/guild_config Service
+
+import "context"
+
+// Service manages each guild's settings
+type Service interface {
+	// GetGuildConfig returns a guild's settings
+	GetGuildConfig(ctx context.Context, input *GetGuildConfigInput) (*GetGuildConfigOutput, error)
+
+	// SetRollFeed changes how a guild's rolls are posted in the channel
+	SetRollFeed(ctx context.Context, input *SetRollFeedInput) (*SetRollFeedOutput, error)
+
+	// SetDisplayDensity changes how much detail a guild's game messages show
+	SetDisplayDensity(ctx context.Context, input *SetDisplayDensityInput) (*SetDisplayDensityOutput, error)
+
+	// SetCriticalValues changes the rolls that count as critical hits and fails in a guild's games
+	SetCriticalValues(ctx context.Context, input *SetCriticalValuesInput) (*SetCriticalValuesOutput, error)
+
+	// SetContentRating changes how tame a guild's messages are kept
+	SetContentRating(ctx context.Context, input *SetContentRatingInput) (*SetContentRatingOutput, error)
+
+	// SetTone changes the voice a guild's messages are written in
+	SetTone(ctx context.Context, input *SetToneInput) (*SetToneOutput, error)
+
+	// SetDefaultDice changes the dice a guild's games are played with
+	SetDefaultDice(ctx context.Context, input *SetDefaultDiceInput) (*SetDefaultDiceOutput, error)
+
+	// SetScoring changes whether a guild's games are played for drinks or points
+	SetScoring(ctx context.Context, input *SetScoringInput) (*SetScoringOutput, error)
+
+	// SetBanter turns freshly written roll comments on or off for a guild
+	SetBanter(ctx context.Context, input *SetBanterInput) (*SetBanterOutput, error)
+
+	// SetEmoji shows one of a guild's own emoji in place of one of the bot's, or goes back to the bot's
+	SetEmoji(ctx context.Context, input *SetEmojiInput) (*SetEmojiOutput, error)
+
+	// SetSoundboard changes the voice channel a guild's sound effects play in, or turns them off
+	SetSoundboard(ctx context.Context, input *SetSoundboardInput) (*SetSoundboardOutput, error)
+
+	// SetPersona changes the name and avatar a guild's game messages are posted under, or goes back to the bot's
+	SetPersona(ctx context.Context, input *SetPersonaInput) (*SetPersonaOutput, error)
+
+	// SetGameChannels changes which channels a guild's games can be created in
+	SetGameChannels(ctx context.Context, input *SetGameChannelsInput) (*SetGameChannelsOutput, error)
+
+	// SetTieStrategy changes how a guild's games settle ties
+	SetTieStrategy(ctx context.Context, input *SetTieStrategyInput) (*SetTieStrategyOutput, error)
+
+	// SetRonnieDrinks changes who drinks the drinks given to Ronnie in a guild's games
+	SetRonnieDrinks(ctx context.Context, input *SetRonnieDrinksInput) (*SetRonnieDrinksOutput, error)
+
+	// SetPinnedLeaderboard changes the pinned session leaderboard the bot keeps up to date for a guild
+	SetPinnedLeaderboard(ctx context.Context, input *SetPinnedLeaderboardInput) (*SetPinnedLeaderboardOutput, error)
+}
@@ -0,0 +1,459 @@
+package guild_config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	guildConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/guild_config"
+)
+
+// maxCriticalValue is the highest critical value a guild can set, the biggest die presets allow
+const maxCriticalValue = 100
+
+// maxDiceSides is the biggest die a guild's games can be played with, the biggest die presets allow
+const maxDiceSides = 100
+
+// customEmojiPattern matches a server's custom emoji the way Discord writes them in messages, e.g.
+// <:crit:123456789012345678>, or <a:crit:123456789012345678> when it's animated
+var customEmojiPattern = regexp.MustCompile(`^<a?:\w{2,32}:\d{17,20}>$`)
+
+// maxPersonaNameLength is the longest name Discord lets a webhook post under
+const maxPersonaNameLength = 80
+
+// service implements the Service interface
+type service struct {
+	// Repository dependencies
+	guildConfigRepo guildConfigRepo.Repository
+}
+
+// New creates a new guild config service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.GuildConfigRepo == nil {
+		return nil, ErrNilGuildConfigRepo
+	}
+
+	return &service{
+		guildConfigRepo: cfg.GuildConfigRepo,
+	}, nil
+}
+
+// GetGuildConfig returns a guild's settings
+func (s *service) GetGuildConfig(ctx context.Context, input *GetGuildConfigInput) (*GetGuildConfigOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	output, err := s.guildConfigRepo.GetGuildConfig(ctx, &guildConfigRepo.GetGuildConfigInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild config: %w", err)
+	}
+
+	return &GetGuildConfigOutput{
+		Config: output.Config,
+	}, nil
+}
+
+// SetRollFeed changes how a guild's rolls are posted in the channel
+func (s *service) SetRollFeed(ctx context.Context, input *SetRollFeedInput) (*SetRollFeedOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Mode.IsValid() {
+		return nil, ErrInvalidRollFeed
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.RollFeed = input.Mode
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetRollFeedOutput{
+		Config: config,
+	}, nil
+}
+
+// SetDisplayDensity changes how much detail a guild's game messages show
+func (s *service) SetDisplayDensity(ctx context.Context, input *SetDisplayDensityInput) (*SetDisplayDensityOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Density.IsValid() {
+		return nil, ErrInvalidDisplay
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Display = input.Density
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetDisplayDensityOutput{
+		Config: config,
+	}, nil
+}
+
+// SetCriticalValues changes the rolls that count as critical hits and fails in a guild's games.
+// Whether they fit on the dice is checked when a game is created, since presets can change the dice.
+func (s *service) SetCriticalValues(ctx context.Context, input *SetCriticalValuesInput) (*SetCriticalValuesOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	for _, value := range append(slices.Clone(input.HitValues), input.FailValues...) {
+		if value < 1 || value > maxCriticalValue {
+			return nil, ErrInvalidCritValues
+		}
+	}
+
+	for _, value := range input.HitValues {
+		if slices.Contains(input.FailValues, value) {
+			return nil, ErrInvalidCritValues
+		}
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.CriticalHitValues = input.HitValues
+		config.CriticalFailValues = input.FailValues
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetCriticalValuesOutput{
+		Config: config,
+	}, nil
+}
+
+// SetContentRating changes how tame a guild's messages are kept
+func (s *service) SetContentRating(ctx context.Context, input *SetContentRatingInput) (*SetContentRatingOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Rating.IsValid() {
+		return nil, ErrInvalidRating
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Rating = input.Rating
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetContentRatingOutput{
+		Config: config,
+	}, nil
+}
+
+// SetTone changes the voice a guild's messages are written in
+func (s *service) SetTone(ctx context.Context, input *SetToneInput) (*SetToneOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Tone.IsValid() {
+		return nil, ErrInvalidTone
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Tone = input.Tone
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetToneOutput{
+		Config: config,
+	}, nil
+}
+
+// SetDefaultDice changes the dice a guild's games are played with.
+// Whether the guild's critical values fit on them is checked when a game is created, as with presets.
+func (s *service) SetDefaultDice(ctx context.Context, input *SetDefaultDiceInput) (*SetDefaultDiceOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if input.Sides != 0 && (input.Sides < 2 || input.Sides > maxDiceSides) {
+		return nil, ErrInvalidDice
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.DiceSides = input.Sides
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetDefaultDiceOutput{
+		Config: config,
+	}, nil
+}
+
+// SetScoring changes whether a guild's games are played for drinks or points
+func (s *service) SetScoring(ctx context.Context, input *SetScoringInput) (*SetScoringOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Mode.IsValid() {
+		return nil, ErrInvalidScoring
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Scoring = input.Mode
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetScoringOutput{
+		Config: config,
+	}, nil
+}
+
+// SetBanter turns freshly written roll comments on or off for a guild
+func (s *service) SetBanter(ctx context.Context, input *SetBanterInput) (*SetBanterOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Banter = input.Enabled
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetBanterOutput{
+		Config: config,
+	}, nil
+}
+
+// SetEmoji shows one of a guild's own emoji in place of one of the bot's, or goes back to the bot's
+func (s *service) SetEmoji(ctx context.Context, input *SetEmojiInput) (*SetEmojiOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Slot.IsValid() {
+		return nil, ErrInvalidEmojiSlot
+	}
+
+	if input.Emoji != "" && !customEmojiPattern.MatchString(input.Emoji) {
+		return nil, ErrInvalidEmoji
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		if input.Emoji == "" {
+			delete(config.Emojis, input.Slot)
+			return
+		}
+		if config.Emojis == nil {
+			config.Emojis = make(map[models.EmojiSlot]string)
+		}
+		config.Emojis[input.Slot] = input.Emoji
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetEmojiOutput{
+		Config: config,
+	}, nil
+}
+
+// SetSoundboard changes the voice channel a guild's sound effects play in, or turns them off
+func (s *service) SetSoundboard(ctx context.Context, input *SetSoundboardInput) (*SetSoundboardOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.SoundboardChannelID = input.ChannelID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetSoundboardOutput{
+		Config: config,
+	}, nil
+}
+
+// SetPersona changes the name and avatar a guild's game messages are posted under, or goes back to the bot's
+func (s *service) SetPersona(ctx context.Context, input *SetPersonaInput) (*SetPersonaOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if input.Persona != nil && !validPersona(input.Persona) {
+		return nil, ErrInvalidPersona
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.Persona = input.Persona
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetPersonaOutput{
+		Config: config,
+	}, nil
+}
+
+// validPersona reports whether Discord will post under the persona. Webhook names can't be empty or run past 80
+// characters, and Discord turns down any naming it or its old Clyde bot.
+func validPersona(persona *models.GamePersona) bool {
+	name := strings.ToLower(strings.TrimSpace(persona.Name))
+	if name == "" || utf8.RuneCountInString(name) > maxPersonaNameLength ||
+		strings.Contains(name, "discord") || strings.Contains(name, "clyde") {
+		return false
+	}
+
+	if persona.AvatarURL == "" {
+		return true
+	}
+	avatar, err := url.Parse(persona.AvatarURL)
+	return err == nil && (avatar.Scheme == "http" || avatar.Scheme == "https") && avatar.Host != ""
+}
+
+// SetGameChannels changes which channels a guild's games can be created in
+func (s *service) SetGameChannels(ctx context.Context, input *SetGameChannelsInput) (*SetGameChannelsOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if input.Mode != "" && !input.Mode.IsValid() {
+		return nil, ErrInvalidChannelMode
+	}
+
+	// A guild can't allow games in no channels at all
+	channelIDs := slices.Clone(input.ChannelIDs)
+	slices.Sort(channelIDs)
+	channelIDs = slices.Compact(channelIDs)
+	if input.Mode == models.GameChannelsAllow && len(channelIDs) == 0 {
+		return nil, ErrNoAllowedChannels
+	}
+	if input.Mode == "" {
+		channelIDs = nil
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.GameChannelMode = input.Mode
+		config.GameChannelIDs = channelIDs
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetGameChannelsOutput{
+		Config: config,
+	}, nil
+}
+
+// SetTieStrategy changes how a guild's games settle ties
+func (s *service) SetTieStrategy(ctx context.Context, input *SetTieStrategyInput) (*SetTieStrategyOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Strategy.IsValid() {
+		return nil, ErrInvalidTieStrategy
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.TieStrategy = input.Strategy
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetTieStrategyOutput{
+		Config: config,
+	}, nil
+}
+
+// SetRonnieDrinks changes who drinks the drinks given to Ronnie in a guild's games
+func (s *service) SetRonnieDrinks(ctx context.Context, input *SetRonnieDrinksInput) (*SetRonnieDrinksOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !input.Rule.IsValid() {
+		return nil, ErrInvalidRonnieRule
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.RonnieDrinks = input.Rule
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetRonnieDrinksOutput{
+		Config: config,
+	}, nil
+}
+
+// SetPinnedLeaderboard changes the pinned session leaderboard the bot keeps up to date for a guild
+func (s *service) SetPinnedLeaderboard(ctx context.Context, input *SetPinnedLeaderboardInput) (*SetPinnedLeaderboardOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if input.Pin != nil && (input.Pin.ChannelID == "" || input.Pin.MessageID == "") {
+		return nil, ErrInvalidInput
+	}
+
+	config, err := s.updateGuildConfig(ctx, input.GuildID, func(config *models.GuildConfig) {
+		config.PinnedLeaderboard = input.Pin
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetPinnedLeaderboardOutput{
+		Config: config,
+	}, nil
+}
+
+// updateGuildConfig loads a guild's settings, applies a change and saves them
+func (s *service) updateGuildConfig(ctx context.Context, guildID string, update func(config *models.GuildConfig)) (*models.GuildConfig, error) {
+	output, err := s.GetGuildConfig(ctx, &GetGuildConfigInput{
+		GuildID: guildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	config := output.Config
+	update(config)
+	if err := s.guildConfigRepo.SaveGuildConfig(ctx, &guildConfigRepo.SaveGuildConfigInput{
+		Config: config,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save guild config: %w", err)
+	}
+
+	return config, nil
+}
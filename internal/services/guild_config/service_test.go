@@ -0,0 +1,510 @@
+package guild_config
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	guildConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/guild_config"
+	guildConfigMocks "github.com/KirkDiggler/ronnied/internal/repositories/guild_config/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type GuildConfigServiceTestSuite struct {
+	suite.Suite
+	mockCtrl            *gomock.Controller
+	mockGuildConfigRepo *guildConfigMocks.MockRepository
+	guildConfigService  Service
+	ctx                 context.Context
+
+	// Test data
+	testGuildID string
+}
+
+func (s *GuildConfigServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockGuildConfigRepo = guildConfigMocks.NewMockRepository(s.mockCtrl)
+	s.ctx = context.Background()
+
+	s.testGuildID = "test-guild-id"
+
+	svc, err := New(&Config{
+		GuildConfigRepo: s.mockGuildConfigRepo,
+	})
+	s.Require().NoError(err)
+	s.guildConfigService = svc
+}
+
+func (s *GuildConfigServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestGuildConfigServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GuildConfigServiceTestSuite))
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetRollFeed() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, RollFeed: models.RollFeedMessages},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetRollFeed(s.ctx, &SetRollFeedInput{
+		GuildID: s.testGuildID,
+		Mode:    models.RollFeedMessages,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.RollFeedMessages, output.Config.RollFeedMode())
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetRollFeed_UnknownMode() {
+	_, err := s.guildConfigService.SetRollFeed(s.ctx, &SetRollFeedInput{
+		GuildID: s.testGuildID,
+		Mode:    "loud",
+	})
+	s.ErrorIs(err, ErrInvalidRollFeed)
+}
+
+func (s *GuildConfigServiceTestSuite) TestGetGuildConfig_RequiresGuild() {
+	_, err := s.guildConfigService.GetGuildConfig(s.ctx, &GetGuildConfigInput{})
+	s.ErrorIs(err, ErrInvalidInput)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetDisplayDensity_KeepsOtherSettings() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, RollFeed: models.RollFeedLog},
+		}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, RollFeed: models.RollFeedLog, Display: models.DisplayCompact},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetDisplayDensity(s.ctx, &SetDisplayDensityInput{
+		GuildID: s.testGuildID,
+		Density: models.DisplayCompact,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.DisplayCompact, output.Config.DisplayDensity())
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetDisplayDensity_UnknownDensity() {
+	_, err := s.guildConfigService.SetDisplayDensity(s.ctx, &SetDisplayDensityInput{
+		GuildID: s.testGuildID,
+		Density: "dark",
+	})
+	s.ErrorIs(err, ErrInvalidDisplay)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetCriticalValues() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, CriticalHitValues: []int{5, 6}, CriticalFailValues: []int{1}},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetCriticalValues(s.ctx, &SetCriticalValuesInput{
+		GuildID:    s.testGuildID,
+		HitValues:  []int{5, 6},
+		FailValues: []int{1},
+	})
+	s.Require().NoError(err)
+	s.Equal([]int{5, 6}, output.Config.CriticalHitValues)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetCriticalValues_Invalid() {
+	tests := []struct {
+		name       string
+		hitValues  []int
+		failValues []int
+	}{
+		{name: "zero", hitValues: []int{0}},
+		{name: "too big", hitValues: []int{101}},
+		{name: "hit and fail", hitValues: []int{5, 6}, failValues: []int{1, 5}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.guildConfigService.SetCriticalValues(s.ctx, &SetCriticalValuesInput{
+				GuildID:    s.testGuildID,
+				HitValues:  tt.hitValues,
+				FailValues: tt.failValues,
+			})
+			s.ErrorIs(err, ErrInvalidCritValues)
+		})
+	}
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetContentRating() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Display: models.DisplayCompact},
+		}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Display: models.DisplayCompact, Rating: models.ContentRatingPG13},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetContentRating(s.ctx, &SetContentRatingInput{
+		GuildID: s.testGuildID,
+		Rating:  models.ContentRatingPG13,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.ContentRatingPG13, output.Config.ContentRating())
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetContentRating_UnknownRating() {
+	_, err := s.guildConfigService.SetContentRating(s.ctx, &SetContentRatingInput{
+		GuildID: s.testGuildID,
+		Rating:  "nc-17",
+	})
+	s.ErrorIs(err, ErrInvalidRating)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetTone() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Tone: models.ToneEncouraging},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetTone(s.ctx, &SetToneInput{
+		GuildID: s.testGuildID,
+		Tone:    models.ToneEncouraging,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.ToneEncouraging, output.Config.MessageTone())
+
+	_, err = s.guildConfigService.SetTone(s.ctx, &SetToneInput{
+		GuildID: s.testGuildID,
+		Tone:    "grumpy",
+	})
+	s.ErrorIs(err, ErrInvalidTone)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetDefaultDice() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, DiceSides: 20},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetDefaultDice(s.ctx, &SetDefaultDiceInput{
+		GuildID: s.testGuildID,
+		Sides:   20,
+	})
+	s.Require().NoError(err)
+	s.Equal(20, output.Config.DiceSides)
+
+	for _, sides := range []int{-6, 1, 101} {
+		_, err := s.guildConfigService.SetDefaultDice(s.ctx, &SetDefaultDiceInput{
+			GuildID: s.testGuildID,
+			Sides:   sides,
+		})
+		s.ErrorIs(err, ErrInvalidDice, "sides %d", sides)
+	}
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetScoring() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Scoring: models.ScoringPoints},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetScoring(s.ctx, &SetScoringInput{
+		GuildID: s.testGuildID,
+		Mode:    models.ScoringPoints,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.ScoringPoints, output.Config.ScoringMode())
+
+	_, err = s.guildConfigService.SetScoring(s.ctx, &SetScoringInput{
+		GuildID: s.testGuildID,
+		Mode:    "shots",
+	})
+	s.ErrorIs(err, ErrInvalidScoring)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetBanter() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Rating: models.ContentRatingPG},
+		}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Rating: models.ContentRatingPG, Banter: true},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetBanter(s.ctx, &SetBanterInput{
+		GuildID: s.testGuildID,
+		Enabled: true,
+	})
+	s.Require().NoError(err)
+	s.True(output.Config.Banter)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetPinnedLeaderboard() {
+	pin := &models.PinnedLeaderboard{ChannelID: "channel-1", MessageID: "message-1"}
+
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Banter: true},
+		}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Banter: true, PinnedLeaderboard: pin},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetPinnedLeaderboard(s.ctx, &SetPinnedLeaderboardInput{
+		GuildID: s.testGuildID,
+		Pin:     pin,
+	})
+	s.Require().NoError(err)
+	s.Equal(pin, output.Config.PinnedLeaderboard)
+
+	_, err = s.guildConfigService.SetPinnedLeaderboard(s.ctx, &SetPinnedLeaderboardInput{
+		GuildID: s.testGuildID,
+		Pin:     &models.PinnedLeaderboard{ChannelID: "channel-1"},
+	})
+	s.ErrorIs(err, ErrInvalidInput, "a pin needs its message")
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetEmoji() {
+	crit := "<:crit:123456789012345678>"
+
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Emojis: map[models.EmojiSlot]string{models.EmojiSlotCriticalHit: crit}},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetEmoji(s.ctx, &SetEmojiInput{
+		GuildID: s.testGuildID,
+		Slot:    models.EmojiSlotCriticalHit,
+		Emoji:   crit,
+	})
+	s.Require().NoError(err)
+	s.Equal(crit, output.Config.Emoji(models.EmojiSlotCriticalHit, "🔥"))
+	s.Equal("💀", output.Config.Emoji(models.EmojiSlotCriticalFail, "💀"))
+
+	// Going back to the bot's emoji
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: output.Config}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, gomock.Any()).
+		Return(nil)
+
+	output, err = s.guildConfigService.SetEmoji(s.ctx, &SetEmojiInput{
+		GuildID: s.testGuildID,
+		Slot:    models.EmojiSlotCriticalHit,
+	})
+	s.Require().NoError(err)
+	s.Equal("🔥", output.Config.Emoji(models.EmojiSlotCriticalHit, "🔥"))
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetEmoji_Invalid() {
+	_, err := s.guildConfigService.SetEmoji(s.ctx, &SetEmojiInput{
+		GuildID: s.testGuildID,
+		Slot:    "trophy",
+		Emoji:   "<:cup:123456789012345678>",
+	})
+	s.ErrorIs(err, ErrInvalidEmojiSlot)
+
+	_, err = s.guildConfigService.SetEmoji(s.ctx, &SetEmojiInput{
+		GuildID: s.testGuildID,
+		Slot:    models.EmojiSlotDice,
+		Emoji:   "🎯",
+	})
+	s.ErrorIs(err, ErrInvalidEmoji, "the bot's emoji are already unicode, only server emoji are mapped")
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetSoundboard() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, SoundboardChannelID: "voice-channel"},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetSoundboard(s.ctx, &SetSoundboardInput{
+		GuildID:   s.testGuildID,
+		ChannelID: "voice-channel",
+	})
+	s.Require().NoError(err)
+	s.Equal("voice-channel", output.Config.SoundboardChannelID)
+
+	_, err = s.guildConfigService.SetSoundboard(s.ctx, &SetSoundboardInput{ChannelID: "voice-channel"})
+	s.ErrorIs(err, ErrInvalidInput)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetPersona() {
+	persona := &models.GamePersona{Name: "Ronnie the Bartender", AvatarURL: "https://example.com/ronnie.png"}
+
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, Persona: persona},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetPersona(s.ctx, &SetPersonaInput{
+		GuildID: s.testGuildID,
+		Persona: persona,
+	})
+	s.Require().NoError(err)
+	s.Equal(persona, output.Config.Persona)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetPersona_Invalid() {
+	for _, persona := range []*models.GamePersona{
+		{Name: "  "},
+		{Name: strings.Repeat("r", 81)},
+		{Name: "Discord Bartender"},
+		{Name: "Ronnie", AvatarURL: "ftp://example.com/ronnie.png"},
+		{Name: "Ronnie", AvatarURL: "ronnie.png"},
+	} {
+		_, err := s.guildConfigService.SetPersona(s.ctx, &SetPersonaInput{
+			GuildID: s.testGuildID,
+			Persona: persona,
+		})
+		s.ErrorIs(err, ErrInvalidPersona, "%q %q", persona.Name, persona.AvatarURL)
+	}
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetGameChannels() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{
+				GuildID:         s.testGuildID,
+				GameChannelMode: models.GameChannelsAllow,
+				GameChannelIDs:  []string{"bar", "games"},
+			},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetGameChannels(s.ctx, &SetGameChannelsInput{
+		GuildID:    s.testGuildID,
+		Mode:       models.GameChannelsAllow,
+		ChannelIDs: []string{"games", "bar", "games"},
+	})
+	s.Require().NoError(err)
+	s.True(output.Config.AllowsGamesIn("games", ""))
+	s.True(output.Config.AllowsGamesIn("thread", "bar"), "threads follow their channel")
+	s.False(output.Config.AllowsGamesIn("general", ""))
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetGameChannels_Invalid() {
+	_, err := s.guildConfigService.SetGameChannels(s.ctx, &SetGameChannelsInput{
+		GuildID: s.testGuildID,
+		Mode:    "only",
+	})
+	s.ErrorIs(err, ErrInvalidChannelMode)
+
+	_, err = s.guildConfigService.SetGameChannels(s.ctx, &SetGameChannelsInput{
+		GuildID: s.testGuildID,
+		Mode:    models.GameChannelsAllow,
+	})
+	s.ErrorIs(err, ErrNoAllowedChannels)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetTieStrategy() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, TieStrategy: models.TieSuddenDeath},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetTieStrategy(s.ctx, &SetTieStrategyInput{
+		GuildID:  s.testGuildID,
+		Strategy: models.TieSuddenDeath,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.TieSuddenDeath, output.Config.TieStrategy)
+
+	_, err = s.guildConfigService.SetTieStrategy(s.ctx, &SetTieStrategyInput{
+		GuildID:  s.testGuildID,
+		Strategy: "coin_flip",
+	})
+	s.ErrorIs(err, ErrInvalidTieStrategy)
+}
+
+func (s *GuildConfigServiceTestSuite) TestSetRonnieDrinks() {
+	s.mockGuildConfigRepo.EXPECT().
+		GetGuildConfig(s.ctx, &guildConfigRepo.GetGuildConfigInput{GuildID: s.testGuildID}).
+		Return(&guildConfigRepo.GetGuildConfigOutput{Config: &models.GuildConfig{GuildID: s.testGuildID}}, nil)
+
+	s.mockGuildConfigRepo.EXPECT().
+		SaveGuildConfig(s.ctx, &guildConfigRepo.SaveGuildConfigInput{
+			Config: &models.GuildConfig{GuildID: s.testGuildID, RonnieDrinks: models.RonnieDrinksRandom},
+		}).
+		Return(nil)
+
+	output, err := s.guildConfigService.SetRonnieDrinks(s.ctx, &SetRonnieDrinksInput{
+		GuildID: s.testGuildID,
+		Rule:    models.RonnieDrinksRandom,
+	})
+	s.Require().NoError(err)
+	s.Equal(models.RonnieDrinksRandom, output.Config.RonnieDrinks)
+
+	_, err = s.guildConfigService.SetRonnieDrinks(s.ctx, &SetRonnieDrinksInput{
+		GuildID: s.testGuildID,
+		Rule:    "pour_it_out",
+	})
+	s.ErrorIs(err, ErrInvalidRonnieRule)
+}
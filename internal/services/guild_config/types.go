@@ -0,0 +1,206 @@
+package guild_config
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+	guildConfigRepo "github.com/KirkDiggler/ronnied/internal/repositories/guild_config"
+)
+
+// Config holds configuration for the guild config service
+type Config struct {
+	// Repository dependencies
+	GuildConfigRepo guildConfigRepo.Repository
+}
+
+// GetGuildConfigInput defines the input for getting a guild's settings
+type GetGuildConfigInput struct {
+	GuildID string
+}
+
+// GetGuildConfigOutput defines the output for getting a guild's settings
+type GetGuildConfigOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetRollFeedInput defines the input for changing a guild's roll feed
+type SetRollFeedInput struct {
+	GuildID string
+	Mode    models.RollFeedMode
+}
+
+// SetRollFeedOutput defines the output for changing a guild's roll feed
+type SetRollFeedOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetDisplayDensityInput defines the input for changing a guild's display density
+type SetDisplayDensityInput struct {
+	GuildID string
+	Density models.DisplayDensity
+}
+
+// SetDisplayDensityOutput defines the output for changing a guild's display density
+type SetDisplayDensityOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetCriticalValuesInput defines the input for changing a guild's critical values
+type SetCriticalValuesInput struct {
+	GuildID string
+
+	// HitValues and FailValues replace the guild's critical values, empty to go back to the bot's defaults
+	HitValues  []int
+	FailValues []int
+}
+
+// SetCriticalValuesOutput defines the output for changing a guild's critical values
+type SetCriticalValuesOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetContentRatingInput defines the input for changing a guild's content rating
+type SetContentRatingInput struct {
+	GuildID string
+	Rating  models.ContentRating
+}
+
+// SetContentRatingOutput defines the output for changing a guild's content rating
+type SetContentRatingOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetToneInput defines the input for changing a guild's message tone
+type SetToneInput struct {
+	GuildID string
+	Tone    models.MessageTone
+}
+
+// SetToneOutput defines the output for changing a guild's message tone
+type SetToneOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetDefaultDiceInput defines the input for changing the dice a guild's games are played with
+type SetDefaultDiceInput struct {
+	GuildID string
+
+	// Sides is the number of sides on the dice, zero to go back to the bot's default
+	Sides int
+}
+
+// SetDefaultDiceOutput defines the output for changing the dice a guild's games are played with
+type SetDefaultDiceOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetScoringInput defines the input for changing what a guild's games are played for
+type SetScoringInput struct {
+	GuildID string
+	Mode    models.ScoringMode
+}
+
+// SetScoringOutput defines the output for changing what a guild's games are played for
+type SetScoringOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetBanterInput defines the input for turning a guild's fresh roll comments on or off
+type SetBanterInput struct {
+	GuildID string
+	Enabled bool
+}
+
+// SetBanterOutput defines the output for turning a guild's fresh roll comments on or off
+type SetBanterOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetEmojiInput defines the input for changing one of a guild's emoji
+type SetEmojiInput struct {
+	GuildID string
+	Slot    models.EmojiSlot
+
+	// Emoji is the server emoji to show, e.g. <:crit:123456789012345678>, empty goes back to the bot's
+	Emoji string
+}
+
+// SetEmojiOutput defines the output for changing one of a guild's emoji
+type SetEmojiOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetSoundboardInput defines the input for changing the voice channel a guild's sound effects play in
+type SetSoundboardInput struct {
+	GuildID string
+
+	// ChannelID is the voice channel to play sound effects in, empty turns the soundboard off
+	ChannelID string
+}
+
+// SetSoundboardOutput defines the output for changing the voice channel a guild's sound effects play in
+type SetSoundboardOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetPersonaInput defines the input for changing the name and avatar a guild's game messages are posted under
+type SetPersonaInput struct {
+	GuildID string
+
+	// Persona is the name and avatar to post under, nil goes back to posting as the bot
+	Persona *models.GamePersona
+}
+
+// SetPersonaOutput defines the output for changing the name and avatar a guild's game messages are posted under
+type SetPersonaOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetGameChannelsInput defines the input for changing where a guild's games can be created
+type SetGameChannelsInput struct {
+	GuildID string
+
+	// Mode is whether games are only allowed in the channels or anywhere but them, empty allows games anywhere
+	Mode models.GameChannelMode
+
+	// ChannelIDs are the channels games are allowed or denied in
+	ChannelIDs []string
+}
+
+// SetGameChannelsOutput defines the output for changing where a guild's games can be created
+type SetGameChannelsOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetTieStrategyInput defines the input for changing how a guild's games settle ties
+type SetTieStrategyInput struct {
+	GuildID  string
+	Strategy models.TieStrategy
+}
+
+// SetTieStrategyOutput defines the output for changing how a guild's games settle ties
+type SetTieStrategyOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetRonnieDrinksInput defines the input for changing who drinks the drinks given to Ronnie in a guild's games
+type SetRonnieDrinksInput struct {
+	GuildID string
+	Rule    models.RonnieDrinkRule
+}
+
+// SetRonnieDrinksOutput defines the output for changing who drinks the drinks given to Ronnie in a guild's games
+type SetRonnieDrinksOutput struct {
+	Config *models.GuildConfig
+}
+
+// SetPinnedLeaderboardInput defines the input for changing a guild's pinned session leaderboard
+type SetPinnedLeaderboardInput struct {
+	GuildID string
+
+	// Pin is the pinned leaderboard message, nil stops keeping one
+	Pin *models.PinnedLeaderboard
+}
+
+// SetPinnedLeaderboardOutput defines the output for changing a guild's pinned session leaderboard
+type SetPinnedLeaderboardOutput struct {
+	Config *models.GuildConfig
+}
@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// birthdayMessages celebrate a critical hit's drink going to the birthday player, %[1]s is the player who rolled
+// and %[2]s the birthday player
+var birthdayMessages = []string{
+	"🎂 It's **%[2]s**'s birthday, **%[1]s**! That critical hit has their name on it.",
+	"🎉 Birthday rules, **%[1]s**: every critical hit today is a gift for **%[2]s**.",
+	"🥳 Happy birthday, **%[2]s**! **%[1]s** got you a drink.",
+	"🎁 **%[1]s** rolled the perfect present for **%[2]s**. Happy birthday!",
+	"🎂 Make a wish, **%[2]s**, and then make it a double. Courtesy of **%[1]s**.",
+}
+
+// GetBirthdayMessage returns a celebratory line for a critical hit whose drink goes to the birthday player
+func (s *service) GetBirthdayMessage(ctx context.Context, input *GetBirthdayMessageInput) (*GetBirthdayMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	if len(input.BirthdayPlayerNames) == 0 {
+		return &GetBirthdayMessageOutput{}, nil
+	}
+
+	return &GetBirthdayMessageOutput{
+		Message: fmt.Sprintf(s.pick(ctx, birthdayMessages), input.PlayerName, joinNames(input.BirthdayPlayerNames)),
+	}, nil
+}
+
+// joinNames lists names as they'd be said, e.g. "Alice, Bob and Carol"
+func joinNames(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
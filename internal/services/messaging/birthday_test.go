@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BirthdayMessageTestSuite struct {
+	suite.Suite
+	messagingService Service
+}
+
+func (s *BirthdayMessageTestSuite) SetupTest() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+	s.messagingService = svc
+}
+
+func TestBirthdayMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(BirthdayMessageTestSuite))
+}
+
+func (s *BirthdayMessageTestSuite) TestGetBirthdayMessage() {
+	output, err := s.messagingService.GetBirthdayMessage(context.Background(), &GetBirthdayMessageInput{
+		PlayerName:          "Alice",
+		BirthdayPlayerNames: []string{"Bob", "Carol"},
+	})
+	s.Require().NoError(err)
+	s.Contains(output.Message, "**Alice**")
+	s.Contains(output.Message, "**Bob and Carol**")
+}
+
+func (s *BirthdayMessageTestSuite) TestGetBirthdayMessage_NobodyCelebrating() {
+	output, err := s.messagingService.GetBirthdayMessage(context.Background(), &GetBirthdayMessageInput{
+		PlayerName: "Alice",
+	})
+	s.Require().NoError(err)
+	s.Empty(output.Message)
+}
+
+func (s *BirthdayMessageTestSuite) TestJoinNames() {
+	s.Equal("Bob", joinNames([]string{"Bob"}))
+	s.Equal("Bob and Carol", joinNames([]string{"Bob", "Carol"}))
+	s.Equal("Alice, Bob and Carol", joinNames([]string{"Alice", "Bob", "Carol"}))
+}
@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// contentRatingKey is the context key for the content rating messages are kept to
+type contentRatingKey struct{}
+
+// ratedPhrases are the phrases that make a message too much for the tamer ratings
+var ratedPhrases = map[string]models.ContentRating{
+	"shitsnacks":   models.ContentRatingR,
+	"whore":        models.ContentRatingR,
+	"just the tip": models.ContentRatingR,
+	"handjob":      models.ContentRatingR,
+	"sploosh":      models.ContentRatingPG13,
+	"phrasing":     models.ContentRatingPG13,
+	"wasted":       models.ContentRatingPG13,
+	"drunk":        models.ContentRatingPG13,
+}
+
+// WithContentRating returns a context whose messages are kept to the given rating
+func WithContentRating(ctx context.Context, rating models.ContentRating) context.Context {
+	return context.WithValue(ctx, contentRatingKey{}, rating)
+}
+
+// SetDefaultContentRating changes the rating of messages whose context doesn't set one, empty goes back to R
+func (s *service) SetDefaultContentRating(ctx context.Context, input *SetDefaultContentRatingInput) (*SetDefaultContentRatingOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	rating := input.Rating
+	if rating == "" {
+		rating = models.ContentRatingR
+	}
+	if !rating.IsValid() {
+		return nil, fmt.Errorf("unknown content rating %q", input.Rating)
+	}
+
+	s.ratingMu.Lock()
+	s.defaultRating = rating
+	s.ratingMu.Unlock()
+
+	return &SetDefaultContentRatingOutput{
+		Rating: rating,
+	}, nil
+}
+
+// contentRating returns the rating messages are kept to, the default rating when the context doesn't set one
+func (s *service) contentRating(ctx context.Context) models.ContentRating {
+	rating, ok := ctx.Value(contentRatingKey{}).(models.ContentRating)
+	if ok && rating != "" {
+		return rating
+	}
+
+	s.ratingMu.RLock()
+	defer s.ratingMu.RUnlock()
+	if s.defaultRating == "" {
+		return models.ContentRatingR
+	}
+	return s.defaultRating
+}
+
+// rateMessage returns the rating a message needs to be shown
+func rateMessage(message string) models.ContentRating {
+	message = strings.ToLower(message)
+
+	rating := models.ContentRatingPG
+	for phrase, phraseRating := range ratedPhrases {
+		if strings.Contains(message, phrase) && !rating.Allows(phraseRating) {
+			rating = phraseRating
+		}
+	}
+	return rating
+}
+
+// pick returns a random message the context's rating allows.
+// If none are allowed it picks from the tamest messages instead, so there's always something to say.
+func (s *service) pick(ctx context.Context, messages []string) string {
+	rating := s.contentRating(ctx)
+
+	var eligible []string
+	for _, message := range messages {
+		if rating.Allows(rateMessage(message)) {
+			eligible = append(eligible, message)
+		}
+	}
+
+	if len(eligible) == 0 {
+		tamest := models.ContentRatingR
+		for _, message := range messages {
+			if messageRating := rateMessage(message); !messageRating.Allows(tamest) {
+				tamest = messageRating
+			}
+		}
+		for _, message := range messages {
+			if tamest.Allows(rateMessage(message)) {
+				eligible = append(eligible, message)
+			}
+		}
+	}
+
+	return eligible[s.rand.Intn(len(eligible))]
+}
@@ -0,0 +1,98 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentRatingTestSuite struct {
+	suite.Suite
+	messagingService *service
+}
+
+func (s *ContentRatingTestSuite) SetupTest() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+	s.messagingService = svc.(*service)
+}
+
+func TestContentRatingTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentRatingTestSuite))
+}
+
+func (s *ContentRatingTestSuite) TestRateMessage() {
+	s.Equal(models.ContentRatingPG, rateMessage("A new challenger appears!"))
+	s.Equal(models.ContentRatingPG13, rateMessage("Phrasing! But seriously, welcome to the game."))
+	s.Equal(models.ContentRatingR, rateMessage("HOLY SHITSNACKS! Sploosh!"))
+	s.Equal(models.ContentRatingR, rateMessage("Not great, not terrible. Like a sad handjob."))
+}
+
+func (s *ContentRatingTestSuite) TestPick() {
+	messages := []string{"Sploosh!", "Holy shitsnacks!", "Nice roll!"}
+
+	for _, rating := range []models.ContentRating{models.ContentRatingPG, models.ContentRatingPG13, models.ContentRatingR} {
+		ctx := WithContentRating(context.Background(), rating)
+		for range 50 {
+			s.True(rating.Allows(rateMessage(s.messagingService.pick(ctx, messages))))
+		}
+	}
+}
+
+func (s *ContentRatingTestSuite) TestPick_FallsBackToTheTamest() {
+	ctx := WithContentRating(context.Background(), models.ContentRatingPG)
+
+	for range 50 {
+		s.Equal("Sploosh!", s.messagingService.pick(ctx, []string{"Sploosh!", "Holy shitsnacks!"}))
+	}
+}
+
+func (s *ContentRatingTestSuite) TestPick_DefaultsToR() {
+	seen := make(map[string]bool)
+	for range 200 {
+		seen[s.messagingService.pick(context.Background(), []string{"Holy shitsnacks!", "Nice roll!"})] = true
+	}
+	s.True(seen["Holy shitsnacks!"])
+}
+
+func (s *ContentRatingTestSuite) TestRollResultMessage_PG() {
+	ctx := WithContentRating(context.Background(), models.ContentRatingPG)
+
+	for range 100 {
+		for _, input := range []*GetRollResultMessageInput{
+			{PlayerName: "Alice", RollValue: 6, IsCriticalHit: true},
+			{PlayerName: "Alice", RollValue: 1, IsCriticalFail: true},
+			{PlayerName: "Alice", RollValue: 4, IsPersonalMessage: true},
+		} {
+			output, err := s.messagingService.GetRollResultMessage(ctx, input)
+			s.Require().NoError(err)
+			s.Equal(models.ContentRatingPG, rateMessage(output.Title))
+			s.Equal(models.ContentRatingPG, rateMessage(output.Message))
+		}
+
+		output, err := s.messagingService.GetJoinGameMessage(ctx, &GetJoinGameMessageInput{PlayerName: "Alice"})
+		s.Require().NoError(err)
+		s.Equal(models.ContentRatingPG, rateMessage(output.Message))
+	}
+}
+
+func (s *ContentRatingTestSuite) TestSetDefaultContentRating() {
+	ctx := context.Background()
+	s.Equal(models.ContentRatingR, s.messagingService.contentRating(ctx))
+
+	_, err := s.messagingService.SetDefaultContentRating(ctx, &SetDefaultContentRatingInput{Rating: models.ContentRatingPG})
+	s.Require().NoError(err)
+	s.Equal(models.ContentRatingPG, s.messagingService.contentRating(ctx))
+
+	// A context with its own rating keeps it
+	s.Equal(models.ContentRatingPG13, s.messagingService.contentRating(WithContentRating(ctx, models.ContentRatingPG13)))
+
+	_, err = s.messagingService.SetDefaultContentRating(ctx, &SetDefaultContentRatingInput{Rating: "nc-17"})
+	s.Error(err)
+
+	_, err = s.messagingService.SetDefaultContentRating(ctx, &SetDefaultContentRatingInput{})
+	s.Require().NoError(err)
+	s.Equal(models.ContentRatingR, s.messagingService.contentRating(ctx))
+}
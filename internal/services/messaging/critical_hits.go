@@ -0,0 +1,27 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// criticalHitsKey is the context key for the roll values that are critical hits
+type criticalHitsKey struct{}
+
+// defaultCriticalHitValues are the critical hits of a game with the default rules
+var defaultCriticalHitValues = []int{6}
+
+// WithCriticalHitValues returns a context whose messages name the given values as the critical hits
+func WithCriticalHitValues(ctx context.Context, values []int) context.Context {
+	return context.WithValue(ctx, criticalHitsKey{}, values)
+}
+
+// criticalHits describes the roll values that are critical hits for the context, the service's when it doesn't set them
+func (s *service) criticalHits(ctx context.Context) string {
+	values, ok := ctx.Value(criticalHitsKey{}).([]int)
+	if !ok || len(values) == 0 {
+		values = s.criticalHitValues
+	}
+	return models.FormatRollValues(values)
+}
@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CriticalHitsTestSuite struct {
+	suite.Suite
+}
+
+func TestCriticalHitsTestSuite(t *testing.T) {
+	suite.Run(t, new(CriticalHitsTestSuite))
+}
+
+// notEligibleMessages returns every message a player who can't assign drinks may be told
+func (s *CriticalHitsTestSuite) notEligibleMessages(ctx context.Context, svc Service) map[string]bool {
+	messages := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		output, err := svc.GetErrorMessage(ctx, &GetErrorMessageInput{ErrorType: ErrorTypeNotEligible})
+		s.Require().NoError(err)
+		messages[output.Message] = true
+	}
+	return messages
+}
+
+func (s *CriticalHitsTestSuite) TestDefaultCriticalHitIsASix() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+
+	s.Contains(s.notEligibleMessages(context.Background(), svc), "No drink for you to give! Roll a 6 first.")
+}
+
+func (s *CriticalHitsTestSuite) TestConfiguredCriticalHits() {
+	svc, err := NewService(&ServiceConfig{CriticalHitValues: []int{5, 6}})
+	s.Require().NoError(err)
+
+	s.Contains(s.notEligibleMessages(context.Background(), svc), "No drink for you to give! Roll a 5 or 6 first.")
+
+	// A server's own critical hits win
+	ctx := WithCriticalHitValues(context.Background(), []int{20})
+	s.Contains(s.notEligibleMessages(ctx, svc), "No drink for you to give! Roll a 20 first.")
+}
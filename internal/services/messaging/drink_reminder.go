@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// drinkReminderTitles are the titles of drink reminders, getting snarkier with each reminder
+var drinkReminderTitles = [][]string{
+	{"🍺 Friendly Reminder", "🍺 Just Checking In", "🍺 Psst..."},
+	{"🍺 Still Waiting...", "🍺 Reminder, Again", "🧾 Your Tab Is Showing"},
+	{"🚨 Overdue Drinks", "🧾 Final Notice", "🚨 The Tab Remembers"},
+}
+
+// drinkReminderMessages remind a player of the drinks they owe, getting snarkier with each reminder.
+// %[1]s is the player and %[2]s the drinks they owe, e.g. "2 drinks".
+var drinkReminderMessages = [][]string{
+	{
+		"Hey **%[1]s**, you've still got %[2]s on the tab. No rush... well, a little rush.",
+		"**%[1]s**, just a gentle nudge: %[2]s waiting for you. Cheers!",
+		"Don't forget, **%[1]s**, you owe %[2]s. Hit that pay button when you've had them.",
+	},
+	{
+		"**%[1]s**, those %[2]s aren't going to drink themselves.",
+		"Still %[2]s on your tab, **%[1]s**. The dice remember, even if you don't.",
+		"**%[1]s**, we've noticed %[2]s gathering dust. Are you saving them for a special occasion?",
+	},
+	{
+		"**%[1]s**! %[2]s and counting. At this point the ice has melted and so has our patience.",
+		"This is your final notice, **%[1]s**: %[2]s owed. Then again, it was your final notice last time too.",
+		"**%[1]s**, historians will study how long you've owed %[2]s. Drink up!",
+		"HOLY SHITSNACKS, **%[1]s**, %[2]s still?! Phrasing aside, pay up.",
+	},
+}
+
+// GetDrinkReminderMessage returns a reminder for a player who's owed drinks a long time, snarkier with each reminder
+func (s *service) GetDrinkReminderMessage(ctx context.Context, input *GetDrinkReminderMessageInput) (*GetDrinkReminderMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	level := input.Reminder - 1
+	if level < 0 {
+		level = 0
+	}
+	if level >= len(drinkReminderMessages) {
+		level = len(drinkReminderMessages) - 1
+	}
+
+	drinks := "1 drink"
+	if input.DrinkCount != 1 {
+		drinks = fmt.Sprintf("%d drinks", input.DrinkCount)
+	}
+
+	return &GetDrinkReminderMessageOutput{
+		Title:   s.pick(ctx, drinkReminderTitles[level]),
+		Message: fmt.Sprintf(s.pick(ctx, drinkReminderMessages[level]), input.PlayerName, drinks),
+	}, nil
+}
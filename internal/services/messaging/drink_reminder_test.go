@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DrinkReminderMessageTestSuite struct {
+	suite.Suite
+	messagingService Service
+}
+
+func (s *DrinkReminderMessageTestSuite) SetupTest() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+	s.messagingService = svc
+}
+
+func TestDrinkReminderMessageTestSuite(t *testing.T) {
+	suite.Run(t, new(DrinkReminderMessageTestSuite))
+}
+
+func (s *DrinkReminderMessageTestSuite) TestGetDrinkReminderMessage() {
+	output, err := s.messagingService.GetDrinkReminderMessage(context.Background(), &GetDrinkReminderMessageInput{
+		PlayerName: "Alice",
+		DrinkCount: 1,
+		Reminder:   1,
+	})
+	s.Require().NoError(err)
+	s.Contains(drinkReminderTitles[0], output.Title)
+	s.Contains(output.Message, "**Alice**")
+	s.Contains(output.Message, "1 drink")
+}
+
+func (s *DrinkReminderMessageTestSuite) TestGetDrinkReminderMessage_Escalates() {
+	for reminder, titles := range map[int][]string{
+		0:  drinkReminderTitles[0],
+		2:  drinkReminderTitles[1],
+		3:  drinkReminderTitles[2],
+		12: drinkReminderTitles[2],
+	} {
+		output, err := s.messagingService.GetDrinkReminderMessage(context.Background(), &GetDrinkReminderMessageInput{
+			PlayerName: "Alice",
+			DrinkCount: 3,
+			Reminder:   reminder,
+		})
+		s.Require().NoError(err)
+		s.Contains(titles, output.Title)
+		s.Contains(output.Message, "3 drinks")
+	}
+}
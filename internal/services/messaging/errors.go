@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// ErrorType identifies the kind of error a user-facing error message is written for
+type ErrorType string
+
+const (
+	// ErrorTypeUnknown is used for errors without a friendly message
+	ErrorTypeUnknown ErrorType = "unknown"
+
+	ErrorTypeGameNotFound     ErrorType = "game_not_found"
+	ErrorTypeGameExists       ErrorType = "game_exists"
+	ErrorTypeAlreadyJoined    ErrorType = "already_joined"
+	ErrorTypeNotInGame        ErrorType = "not_in_game"
+	ErrorTypeGameFull         ErrorType = "game_full"
+	ErrorTypeInvalidGameState ErrorType = "invalid_game_state"
+	ErrorTypeGameActive       ErrorType = "game_active"
+	ErrorTypeGameRollOff      ErrorType = "game_roll_off"
+	ErrorTypeGameCompleted    ErrorType = "game_completed"
+	ErrorTypeGameNotStarted   ErrorType = "game_not_started"
+	ErrorTypeAlreadyRolled    ErrorType = "already_rolled"
+	ErrorTypeNotEnoughPlayers ErrorType = "not_enough_players"
+	ErrorTypeNotCreator       ErrorType = "not_creator"
+	ErrorTypeInRollOff        ErrorType = "in_roll_off"
+	ErrorTypeNotEligible      ErrorType = "not_eligible"
+	ErrorTypeRoundInProgress  ErrorType = "round_in_progress"
+	ErrorTypeNotYourTurn      ErrorType = "not_your_turn"
+	ErrorTypeCoolingDown      ErrorType = "cooling_down"
+	ErrorTypeNoRerollTokens   ErrorType = "no_reroll_tokens"
+	ErrorTypeRerollNotAllowed ErrorType = "reroll_not_allowed"
+	ErrorTypeNotInvited       ErrorType = "not_invited"
+	ErrorTypeGamePaused       ErrorType = "game_paused"
+)
+
+// errorTypes maps service error codes to the error type of their user-facing message
+var errorTypes = map[models.ErrorCode]ErrorType{
+	models.ErrorCodeGameNotFound:     ErrorTypeGameNotFound,
+	models.ErrorCodeGameExists:       ErrorTypeGameExists,
+	models.ErrorCodeAlreadyJoined:    ErrorTypeAlreadyJoined,
+	models.ErrorCodeNotInGame:        ErrorTypeNotInGame,
+	models.ErrorCodeGameFull:         ErrorTypeGameFull,
+	models.ErrorCodeInvalidGameState: ErrorTypeInvalidGameState,
+	models.ErrorCodeGameActive:       ErrorTypeGameActive,
+	models.ErrorCodeGameRollOff:      ErrorTypeGameRollOff,
+	models.ErrorCodeGameCompleted:    ErrorTypeGameCompleted,
+	models.ErrorCodeGameNotStarted:   ErrorTypeGameNotStarted,
+	models.ErrorCodeAlreadyRolled:    ErrorTypeAlreadyRolled,
+	models.ErrorCodeNotEnoughPlayers: ErrorTypeNotEnoughPlayers,
+	models.ErrorCodeNotCreator:       ErrorTypeNotCreator,
+	models.ErrorCodeInRollOff:        ErrorTypeInRollOff,
+	models.ErrorCodeNotEligible:      ErrorTypeNotEligible,
+	models.ErrorCodeRoundInProgress:  ErrorTypeRoundInProgress,
+	models.ErrorCodeCoolingDown:      ErrorTypeCoolingDown,
+	models.ErrorCodeNoRerollTokens:   ErrorTypeNoRerollTokens,
+	models.ErrorCodeRerollNotAllowed: ErrorTypeRerollNotAllowed,
+	models.ErrorCodeNotInvited:       ErrorTypeNotInvited,
+	models.ErrorCodeGamePaused:       ErrorTypeGamePaused,
+	models.ErrorCodeNotYourTurn:      ErrorTypeNotYourTurn,
+}
+
+// ErrorTypeFor maps a service error to the error type of its user-facing message.
+// Errors that users can't act on (configuration, invalid input, unexpected failures) map to ErrorTypeUnknown.
+func ErrorTypeFor(err error) ErrorType {
+	if errorType, ok := errorTypes[models.ErrorCodeOf(err)]; ok {
+		return errorType
+	}
+	return ErrorTypeUnknown
+}
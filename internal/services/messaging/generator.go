@@ -0,0 +1,95 @@
+package messaging
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_generator.go github.com/KirkDiggler/ronnied/internal/services/messaging Generator
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+)
+
+// maxGeneratedLength is the longest generated comment that's used, anything longer falls back to the usual lines
+const maxGeneratedLength = 300
+
+// defaultGenerationsPerMinute is how many comments a guild can have generated a minute when it isn't configured
+const defaultGenerationsPerMinute = 10
+
+// defaultGenerationTimeout is how long to wait for a comment before falling back to the usual lines
+const defaultGenerationTimeout = 3 * time.Second
+
+// Generator writes fresh roll commentary, such as by asking an LLM
+type Generator interface {
+	// GenerateRollComment writes a comment for a roll
+	GenerateRollComment(ctx context.Context, input *GenerateRollCommentInput) (*GenerateRollCommentOutput, error)
+}
+
+// generationKey is the context key for the guild whose roll commentary may be generated
+type generationKey struct{}
+
+// generationWindow counts a guild's generated comments in the current minute
+type generationWindow struct {
+	start time.Time
+	count int
+}
+
+// WithGeneration returns a context whose roll commentary may be generated, counted against the guild's rate limit
+func WithGeneration(ctx context.Context, guildID string) context.Context {
+	return context.WithValue(ctx, generationKey{}, guildID)
+}
+
+// generateRollMessage asks the generator for a roll comment.
+// It returns false when generation is off, over the guild's limit, fails or writes something unusable.
+func (s *service) generateRollMessage(ctx context.Context, input *GetRollResultMessageInput) (string, bool) {
+	guildID, ok := ctx.Value(generationKey{}).(string)
+	if s.generator == nil || !ok || guildID == "" || !s.allowGeneration(guildID) {
+		return "", false
+	}
+
+	rating := s.contentRating(ctx)
+
+	generateCtx, cancel := context.WithTimeout(ctx, s.generationTimeout)
+	defer cancel()
+
+	output, err := s.generator.GenerateRollComment(generateCtx, &GenerateRollCommentInput{
+		PlayerName:        input.PlayerName,
+		RollValue:         input.RollValue,
+		IsCriticalHit:     input.IsCriticalHit,
+		IsCriticalFail:    input.IsCriticalFail,
+		IsPersonalMessage: input.IsPersonalMessage,
+		DiceSides:         input.DiceSides,
+		Rating:            rating,
+		Tone:              contextTone(ctx, ""),
+	})
+	if err != nil {
+		log.Printf("Error generating roll comment, using the usual lines: %v", err)
+		return "", false
+	}
+
+	comment := strings.TrimSpace(output.Comment)
+	if comment == "" || len(comment) > maxGeneratedLength || !rating.Allows(rateMessage(comment)) {
+		return "", false
+	}
+
+	return comment, true
+}
+
+// allowGeneration counts a generated comment against the guild's limit, false when the guild is over it
+func (s *service) allowGeneration(guildID string) bool {
+	s.generationMu.Lock()
+	defer s.generationMu.Unlock()
+
+	now := s.clock.Now()
+	window, ok := s.generations[guildID]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		window = &generationWindow{start: now}
+		s.generations[guildID] = window
+	}
+
+	if window.count >= s.generationsPerMinute {
+		return false
+	}
+
+	window.count++
+	return true
+}
@@ -0,0 +1,136 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	clockMocks "github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging"
+	"github.com/KirkDiggler/ronnied/internal/services/messaging/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type GeneratorTestSuite struct {
+	suite.Suite
+	mockCtrl         *gomock.Controller
+	mockGenerator    *mocks.MockGenerator
+	mockClock        *clockMocks.MockClock
+	messagingService messaging.Service
+	now              time.Time
+	input            *messaging.GetRollResultMessageInput
+}
+
+func (s *GeneratorTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockGenerator = mocks.NewMockGenerator(s.mockCtrl)
+	s.mockClock = clockMocks.NewMockClock(s.mockCtrl)
+
+	s.now = time.Date(2025, 3, 14, 20, 0, 0, 0, time.UTC)
+	s.mockClock.EXPECT().Now().DoAndReturn(func() time.Time { return s.now }).AnyTimes()
+
+	svc, err := messaging.NewService(&messaging.ServiceConfig{
+		Generator:            s.mockGenerator,
+		GenerationsPerMinute: 2,
+		Clock:                s.mockClock,
+	})
+	s.Require().NoError(err)
+	s.messagingService = svc
+
+	s.input = &messaging.GetRollResultMessageInput{
+		PlayerName:    "Alice",
+		RollValue:     6,
+		IsCriticalHit: true,
+	}
+}
+
+func (s *GeneratorTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(GeneratorTestSuite))
+}
+
+func (s *GeneratorTestSuite) TestGeneratedComment() {
+	ctx := messaging.WithContentRating(messaging.WithGeneration(context.Background(), "guild-1"), models.ContentRatingPG)
+
+	s.mockGenerator.EXPECT().
+		GenerateRollComment(gomock.Any(), &messaging.GenerateRollCommentInput{
+			PlayerName:    "Alice",
+			RollValue:     6,
+			IsCriticalHit: true,
+			Rating:        models.ContentRatingPG,
+		}).
+		Return(&messaging.GenerateRollCommentOutput{Comment: "  Alice found the good dice.  "}, nil)
+
+	output, err := s.messagingService.GetRollResultMessage(ctx, s.input)
+	s.Require().NoError(err)
+	s.Equal("Alice found the good dice.", output.Message)
+}
+
+func (s *GeneratorTestSuite) TestGuildWithoutBanter() {
+	// The generator isn't called without a guild that has banter on
+	output, err := s.messagingService.GetRollResultMessage(context.Background(), s.input)
+	s.Require().NoError(err)
+	s.NotEmpty(output.Message)
+}
+
+func (s *GeneratorTestSuite) TestFallsBackToTheUsualLines() {
+	ctx := messaging.WithContentRating(messaging.WithGeneration(context.Background(), "guild-1"), models.ContentRatingPG)
+
+	testCases := []struct {
+		name    string
+		comment string
+		err     error
+	}{
+		{name: "error", err: errors.New("endpoint down")},
+		{name: "empty", comment: "   "},
+		{name: "too spicy for the rating", comment: "Holy shitsnacks, Alice!"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.now = s.now.Add(time.Minute)
+
+			s.mockGenerator.EXPECT().
+				GenerateRollComment(gomock.Any(), gomock.Any()).
+				Return(&messaging.GenerateRollCommentOutput{Comment: tc.comment}, tc.err)
+
+			output, err := s.messagingService.GetRollResultMessage(ctx, s.input)
+			s.Require().NoError(err)
+			s.NotEmpty(output.Message)
+			s.NotEqual(tc.comment, output.Message)
+		})
+	}
+}
+
+func (s *GeneratorTestSuite) TestRateLimitedPerGuild() {
+	guild1 := messaging.WithGeneration(context.Background(), "guild-1")
+	guild2 := messaging.WithGeneration(context.Background(), "guild-2")
+
+	s.mockGenerator.EXPECT().
+		GenerateRollComment(gomock.Any(), gomock.Any()).
+		Return(&messaging.GenerateRollCommentOutput{Comment: "Fresh!"}, nil).
+		Times(5)
+
+	generated := func(ctx context.Context) bool {
+		output, err := s.messagingService.GetRollResultMessage(ctx, s.input)
+		s.Require().NoError(err)
+		return output.Message == "Fresh!"
+	}
+
+	// Two a minute for each guild
+	s.True(generated(guild1))
+	s.True(generated(guild1))
+	s.False(generated(guild1))
+	s.True(generated(guild2))
+
+	// The limit resets after a minute
+	s.now = s.now.Add(time.Minute)
+	s.True(generated(guild1))
+	s.True(generated(guild2))
+}
@@ -0,0 +1,21 @@
+package messaging
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// HappyHourNote returns a note on how a drink given during happy hour counted, empty outside happy hour
+func HappyHourNote(multiplier float64, onTheHouse bool) string {
+	switch {
+	case multiplier <= 0 || multiplier == 1:
+		return ""
+	case onTheHouse:
+		return "🍹 Happy hour, that one's on the house!"
+	case multiplier == 2:
+		return "🍹 Happy hour, that one counts double!"
+	case multiplier == 0.5:
+		return "🍹 Happy hour, that one only counts half!"
+	}
+	return fmt.Sprintf("🍹 Happy hour, that one counts x%s!", strconv.FormatFloat(multiplier, 'f', -1, 64))
+}
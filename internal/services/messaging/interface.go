@@ -36,4 +36,25 @@ type Service interface {
 	
 	// GetDrinkAssignmentMessage returns a message for a drink assignment in the shared game message
 	GetDrinkAssignmentMessage(ctx context.Context, input *GetDrinkAssignmentMessageInput) (*GetDrinkAssignmentMessageOutput, error)
+
+	// GetMercyRuleMessage returns a message for when the mercy rule turns a player's drink social
+	GetMercyRuleMessage(ctx context.Context, input *GetMercyRuleMessageInput) (*GetMercyRuleMessageOutput, error)
+
+	// GetSocialRoundMessage returns a message for a social round, when everyone in the game drinks at once
+	GetSocialRoundMessage(ctx context.Context, input *GetSocialRoundMessageInput) (*GetSocialRoundMessageOutput, error)
+
+	// GetPacingMessage returns a gentle message for a player paying drinks too quickly
+	GetPacingMessage(ctx context.Context, input *GetPacingMessageInput) (*GetPacingMessageOutput, error)
+
+	// GetSeasonalMessage returns an extra line for a roll made during a seasonal event
+	GetSeasonalMessage(ctx context.Context, input *GetSeasonalMessageInput) (*GetSeasonalMessageOutput, error)
+
+	// GetBirthdayMessage returns a celebratory line for a critical hit whose drink goes to the birthday player
+	GetBirthdayMessage(ctx context.Context, input *GetBirthdayMessageInput) (*GetBirthdayMessageOutput, error)
+
+	// GetDrinkReminderMessage returns a reminder for a player who's owed drinks a long time, snarkier with each reminder
+	GetDrinkReminderMessage(ctx context.Context, input *GetDrinkReminderMessageInput) (*GetDrinkReminderMessageOutput, error)
+
+	// SetDefaultContentRating changes the rating of messages whose context doesn't set one
+	SetDefaultContentRating(ctx context.Context, input *SetDefaultContentRatingInput) (*SetDefaultContentRatingOutput, error)
 }
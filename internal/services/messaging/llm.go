@@ -0,0 +1,175 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// llmSystemPrompt sets the voice of the generated roll commentary
+const llmSystemPrompt = "You are Ronnie, the host of a dice drinking game in a chat server. " +
+	"Write one short, punchy comment (under 200 characters) reacting to a roll, in the style of the TV show Archer. " +
+	"Reply with the comment only, no quotes."
+
+// llmRatingGuidance tells the LLM how tame to keep the comment for each content rating
+var llmRatingGuidance = map[models.ContentRating]string{
+	models.ContentRatingPG:   "Keep it family friendly: no innuendo and no swearing.",
+	models.ContentRatingPG13: "Light innuendo is fine, but no swearing.",
+	models.ContentRatingR:    "Adult humor and mild swearing are fine.",
+}
+
+// llmToneGuidance tells the LLM the voice to write the comment in for each tone that changes it
+var llmToneGuidance = map[MessageTone]string{
+	ToneSarcastic:   "Be dry and sarcastic.",
+	ToneEncouraging: "Be warm and encouraging, cheering the player on whatever they rolled.",
+	ToneNeutral:     "Keep it plain and matter-of-fact.",
+}
+
+// llmGenerator generates roll commentary with an OpenAI-compatible chat completions endpoint
+type llmGenerator struct {
+	endpoint   string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// chatCompletionRequest is the body sent to the chat completions endpoint
+type chatCompletionRequest struct {
+	Model     string        `json:"model,omitempty"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens"`
+}
+
+// chatMessage is one message in a chat completion
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatCompletionResponse is the part of the chat completions response that's used
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// NewLLMGenerator creates a generator that asks an OpenAI-compatible chat completions endpoint for roll commentary
+func NewLLMGenerator(cfg *LLMGeneratorConfig) (Generator, error) {
+	if cfg == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	parsed, err := url.Parse(cfg.Endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.New("endpoint must be an http(s) URL")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultGenerationTimeout}
+	}
+
+	return &llmGenerator{
+		endpoint:   cfg.Endpoint,
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+		httpClient: httpClient,
+	}, nil
+}
+
+// GenerateRollComment asks the endpoint for a comment on a roll
+func (g *llmGenerator) GenerateRollComment(ctx context.Context, input *GenerateRollCommentInput) (*GenerateRollCommentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	body, err := json.Marshal(&chatCompletionRequest{
+		Model: g.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt(input)},
+			{Role: "user", Content: rollPrompt(input)},
+		},
+		MaxTokens: 80,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.apiKey)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("endpoint returned no choices")
+	}
+
+	return &GenerateRollCommentOutput{
+		Comment: strings.Trim(strings.TrimSpace(completion.Choices[0].Message.Content), `"`),
+	}, nil
+}
+
+// systemPrompt sets the voice of the comment, kept to its rating and written in its tone
+func systemPrompt(input *GenerateRollCommentInput) string {
+	prompt := llmSystemPrompt + " " + llmRatingGuidance[input.Rating]
+	if guidance, ok := llmToneGuidance[input.Tone]; ok {
+		prompt += " " + guidance
+	}
+	return prompt
+}
+
+// rollPrompt describes a roll for the LLM to comment on
+func rollPrompt(input *GenerateRollCommentInput) string {
+	var outcome string
+	switch {
+	case input.IsCriticalHit:
+		outcome = "a critical hit, so they get to make someone else drink"
+	case input.IsCriticalFail:
+		outcome = "a critical fail, so they have to drink"
+	default:
+		outcome = "an ordinary roll, nothing happens unless it ends up the lowest"
+	}
+
+	switch {
+	case isNatural20(input.DiceSides, input.RollValue):
+		outcome = "a natural 20, " + outcome
+	case isNatural1(input.DiceSides, input.RollValue):
+		outcome = "a natural 1, " + outcome
+	}
+
+	roll := fmt.Sprintf("%d", input.RollValue)
+	if input.DiceSides > 0 {
+		roll = fmt.Sprintf("%d on a d%d", input.RollValue, input.DiceSides)
+	}
+
+	if input.IsPersonalMessage {
+		return fmt.Sprintf("Speaking directly to %s, react to them rolling a %s: %s.", input.PlayerName, roll, outcome)
+	}
+	return fmt.Sprintf("React to %s rolling a %s: %s.", input.PlayerName, roll, outcome)
+}
@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type LLMGeneratorTestSuite struct {
+	suite.Suite
+	server   *httptest.Server
+	requests []*chatCompletionRequest
+	headers  []http.Header
+	status   int
+	reply    string
+}
+
+func (s *LLMGeneratorTestSuite) SetupTest() {
+	s.requests = nil
+	s.headers = nil
+	s.status = http.StatusOK
+	s.reply = `{"choices":[{"message":{"role":"assistant","content":"\"Alice rolls like a legend.\""}}]}`
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req chatCompletionRequest
+		s.Require().NoError(json.NewDecoder(r.Body).Decode(&req))
+		s.requests = append(s.requests, &req)
+		s.headers = append(s.headers, r.Header)
+
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.reply))
+	}))
+}
+
+func (s *LLMGeneratorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func TestLLMGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(LLMGeneratorTestSuite))
+}
+
+func (s *LLMGeneratorTestSuite) generator() Generator {
+	generator, err := NewLLMGenerator(&LLMGeneratorConfig{
+		Endpoint: s.server.URL,
+		APIKey:   "secret",
+		Model:    "banter-1",
+	})
+	s.Require().NoError(err)
+	return generator
+}
+
+func (s *LLMGeneratorTestSuite) TestGenerateRollComment() {
+	output, err := s.generator().GenerateRollComment(context.Background(), &GenerateRollCommentInput{
+		PlayerName:    "Alice",
+		RollValue:     6,
+		IsCriticalHit: true,
+		Rating:        models.ContentRatingPG,
+	})
+	s.Require().NoError(err)
+	s.Equal("Alice rolls like a legend.", output.Comment)
+
+	s.Require().Len(s.requests, 1)
+	s.Equal("Bearer secret", s.headers[0].Get("Authorization"))
+	s.Equal("banter-1", s.requests[0].Model)
+	s.Require().Len(s.requests[0].Messages, 2)
+	s.Contains(s.requests[0].Messages[0].Content, llmRatingGuidance[models.ContentRatingPG])
+	s.True(strings.Contains(s.requests[0].Messages[1].Content, "Alice rolling a 6: a critical hit"))
+}
+
+func (s *LLMGeneratorTestSuite) TestGenerateRollComment_Tone() {
+	_, err := s.generator().GenerateRollComment(context.Background(), &GenerateRollCommentInput{
+		PlayerName: "Alice",
+		RollValue:  1,
+		Rating:     models.ContentRatingR,
+		Tone:       ToneEncouraging,
+	})
+	s.Require().NoError(err)
+
+	s.Require().Len(s.requests, 1)
+	s.Contains(s.requests[0].Messages[0].Content, llmToneGuidance[ToneEncouraging])
+}
+
+func (s *LLMGeneratorTestSuite) TestGenerateRollComment_Errors() {
+	s.Run("status", func() {
+		s.status = http.StatusTooManyRequests
+		_, err := s.generator().GenerateRollComment(context.Background(), &GenerateRollCommentInput{PlayerName: "Alice", RollValue: 3})
+		s.Error(err)
+	})
+
+	s.Run("no choices", func() {
+		s.status = http.StatusOK
+		s.reply = `{"choices":[]}`
+		_, err := s.generator().GenerateRollComment(context.Background(), &GenerateRollCommentInput{PlayerName: "Alice", RollValue: 3})
+		s.Error(err)
+	})
+}
+
+func (s *LLMGeneratorTestSuite) TestNewLLMGenerator_InvalidEndpoint() {
+	for _, endpoint := range []string{"", "ftp://example.com", "not a url"} {
+		_, err := NewLLMGenerator(&LLMGeneratorConfig{Endpoint: endpoint})
+		s.Error(err, endpoint)
+	}
+}
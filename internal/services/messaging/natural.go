@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+)
+
+// naturalDiceSides is the die whose highest and lowest faces are called a natural 20 and a natural 1
+const naturalDiceSides = 20
+
+// defaultDiceSides is the die rolled when a message doesn't say
+const defaultDiceSides = 6
+
+// diceSides returns the sides of the dice a message is about, 6 when it isn't set
+func diceSides(sides int) int {
+	if sides <= 0 {
+		return defaultDiceSides
+	}
+	return sides
+}
+
+// isNatural20 checks whether a roll is a natural 20 on a d20
+func isNatural20(sides, rollValue int) bool {
+	return sides == naturalDiceSides && rollValue == naturalDiceSides
+}
+
+// isNatural1 checks whether a roll is a natural 1 on a d20
+func isNatural1(sides, rollValue int) bool {
+	return sides == naturalDiceSides && rollValue == 1
+}
+
+// naturalRollResult returns the title and message for a natural 20 or natural 1 that's also a critical,
+// false for any other roll
+func (s *service) naturalRollResult(ctx context.Context, input *GetRollResultMessageInput) (string, string, bool) {
+	var titles, messages []string
+
+	switch {
+	case input.IsCriticalHit && isNatural20(input.DiceSides, input.RollValue):
+		titles = []string{
+			"NAT 20!",
+			"✨ Natural 20! ✨",
+			"The dice gods have spoken!",
+			"CRITICAL SUCCESS!",
+		}
+		if input.IsPersonalMessage {
+			messages = []string{
+				"A natural 20! Roll for damage... by which we mean pick someone to drink!",
+				"Nat 20! The bard in you is weeping with joy. Choose who drinks!",
+				"You rolled a natural 20! Legends will be sung about this. Someone's drinking!",
+				"NAT 20! Your DM would never let you live this down. Go make someone drink!",
+			}
+		} else {
+			messages = []string{
+				fmt.Sprintf("A natural 20 for %s! Roll for damage... by which we mean pick someone to drink!", input.PlayerName),
+				fmt.Sprintf("Nat 20! %s's bard is weeping with joy. Someone's drinking!", input.PlayerName),
+				fmt.Sprintf("%s rolled a natural 20! Legends will be sung about this one!", input.PlayerName),
+				fmt.Sprintf("NAT 20! %s is never letting anyone forget this. Choose your victim!", input.PlayerName),
+			}
+		}
+
+	case input.IsCriticalFail && isNatural1(input.DiceSides, input.RollValue):
+		titles = []string{
+			"NAT 1!",
+			"💀 Natural 1 💀",
+			"CRITICAL FUMBLE!",
+			"You trip over your own sword!",
+		}
+		if input.IsPersonalMessage {
+			messages = []string{
+				"A natural 1! You swing, you miss, and you hit yourself. Drink up!",
+				"Nat 1! Your character falls down the stairs. Take a drink for the healing potion.",
+				"You rolled a natural 1. The DM is trying very hard not to laugh. Drink!",
+				"NAT 1! Roll a new character, but first, drink.",
+			}
+		} else {
+			messages = []string{
+				fmt.Sprintf("A natural 1 for %s! They swing, they miss, they hit themselves. Drink up!", input.PlayerName),
+				fmt.Sprintf("Nat 1! %s falls down the stairs. Time for a healing potion, in drink form.", input.PlayerName),
+				fmt.Sprintf("%s rolled a natural 1. The DM is trying very hard not to laugh. Drink!", input.PlayerName),
+				fmt.Sprintf("NAT 1! %s should roll a new character, but first, drink.", input.PlayerName),
+			}
+		}
+
+	default:
+		return "", "", false
+	}
+
+	return s.pick(ctx, titles), s.pick(ctx, messages), true
+}
+
+// naturalRollWhisper returns a whisper for a natural 20 or natural 1 that's also a critical, false for any other roll
+func (s *service) naturalRollWhisper(ctx context.Context, input *GetRollWhisperMessageInput) (string, bool) {
+	var messages []string
+
+	switch {
+	case input.IsCriticalHit && isNatural20(input.DiceSides, input.RollValue):
+		messages = []string{
+			fmt.Sprintf("*whispers* %s, I'd frame that natural 20 if I could.", input.PlayerName),
+			fmt.Sprintf("Between us, %s, one in twenty and it picked you. Make it count!", input.PlayerName),
+			fmt.Sprintf("*quietly* A nat 20, %s? Inspiration for everyone... except whoever drinks.", input.PlayerName),
+		}
+
+	case input.IsCriticalFail && isNatural1(input.DiceSides, input.RollValue):
+		messages = []string{
+			fmt.Sprintf("*whispers* %s, even the best adventurers roll a nat 1. Mostly the worst ones, though.", input.PlayerName),
+			fmt.Sprintf("Between us, %s, one in twenty and it picked you. Drink to the dice gods.", input.PlayerName),
+			fmt.Sprintf("*quietly* %s, let's call that a tactical fumble. Bottoms up.", input.PlayerName),
+		}
+
+	default:
+		return "", false
+	}
+
+	return s.pick(ctx, messages), true
+}
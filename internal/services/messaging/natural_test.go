@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NaturalRollTestSuite struct {
+	suite.Suite
+	messagingService Service
+	ctx              context.Context
+}
+
+func (s *NaturalRollTestSuite) SetupTest() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+	s.messagingService = svc
+	s.ctx = context.Background()
+}
+
+func TestNaturalRollTestSuite(t *testing.T) {
+	suite.Run(t, new(NaturalRollTestSuite))
+}
+
+// isNaturalMessage checks whether a message calls out a natural roll
+func isNaturalMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "natural") || strings.Contains(message, "nat ")
+}
+
+func (s *NaturalRollTestSuite) TestNatural20() {
+	output, err := s.messagingService.GetRollResultMessage(s.ctx, &GetRollResultMessageInput{
+		PlayerName:    "Alice",
+		RollValue:     20,
+		DiceSides:     20,
+		IsCriticalHit: true,
+	})
+	s.Require().NoError(err)
+	s.True(isNaturalMessage(output.Message), output.Message)
+	s.Contains(output.Message, "Alice")
+
+	whisper, err := s.messagingService.GetRollWhisperMessage(s.ctx, &GetRollWhisperMessageInput{
+		PlayerName:    "Alice",
+		RollValue:     20,
+		DiceSides:     20,
+		IsCriticalHit: true,
+	})
+	s.Require().NoError(err)
+	s.Contains(whisper.Message, "Alice")
+}
+
+func (s *NaturalRollTestSuite) TestNatural1() {
+	output, err := s.messagingService.GetRollResultMessage(s.ctx, &GetRollResultMessageInput{
+		PlayerName:        "Alice",
+		RollValue:         1,
+		DiceSides:         20,
+		IsCriticalFail:    true,
+		IsPersonalMessage: true,
+	})
+	s.Require().NoError(err)
+	s.True(isNaturalMessage(output.Message), output.Message)
+}
+
+func (s *NaturalRollTestSuite) TestSixSidedCritsAreNotNatural() {
+	for range 20 {
+		output, err := s.messagingService.GetRollResultMessage(s.ctx, &GetRollResultMessageInput{
+			PlayerName:    "Alice",
+			RollValue:     6,
+			IsCriticalHit: true,
+		})
+		s.Require().NoError(err)
+		s.False(isNaturalMessage(output.Message), output.Message)
+	}
+}
+
+func (s *NaturalRollTestSuite) TestWhisperSaysTheDice() {
+	whisper, err := s.messagingService.GetRollWhisperMessage(s.ctx, &GetRollWhisperMessageInput{
+		PlayerName: "Alice",
+		RollValue:  12,
+		DiceSides:  20,
+	})
+	s.Require().NoError(err)
+	s.NotContains(whisper.Message, "Not a 6")
+}
@@ -0,0 +1,83 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/ronnied/internal/services/seasonal"
+)
+
+// seasonalPack holds an event's extra lines for each kind of roll, with %s standing in for the player's name
+type seasonalPack struct {
+	criticalHit  []string
+	criticalFail []string
+	normal       []string
+}
+
+// seasonalPacks are the message packs of the seasonal events that have one
+var seasonalPacks = map[string]*seasonalPack{
+	seasonal.EventHalloween: {
+		criticalHit: []string{
+			"🎃 Treat! **%s** gets to pick who's cursed with a drink.",
+			"👻 **%s** summons a spirit... and it's headed for someone's glass.",
+		},
+		criticalFail: []string{
+			"💀 Trick! The ghouls make **%s** drink.",
+			"🦇 Something wicked this way comes, and it's a drink for **%s**.",
+		},
+		normal: []string{
+			"🕸️ **%s**'s dice rattle like old bones.",
+			"🕯️ A spooky roll from **%s**. The candles flicker.",
+		},
+	},
+	seasonal.EventStPatricks: {
+		criticalHit: []string{
+			"🍀 **%s** found the pot of gold! Pick someone to buy a round.",
+			"🌈 The luck of the Irish is with **%s** today.",
+		},
+		criticalFail: []string{
+			"☘️ Even the luck of the Irish couldn't save **%s**. Sláinte!",
+			"🍺 A leprechaun swiped **%s**'s luck. Drink up!",
+		},
+		normal: []string{
+			"☘️ **%s** rolls with a little green luck.",
+			"🍀 Top o' the roll to you, **%s**.",
+		},
+	},
+	seasonal.EventDoubleDrinkFriday: {
+		criticalHit: []string{
+			"🍻 It's Friday, **%s**! Whoever you pick drinks double.",
+		},
+		criticalFail: []string{
+			"🍻 Double-Drink Friday, **%s**. That's two!",
+		},
+	},
+}
+
+// GetSeasonalMessage returns an extra line for a roll made during a seasonal event, empty if the event has nothing to say
+func (s *service) GetSeasonalMessage(ctx context.Context, input *GetSeasonalMessageInput) (*GetSeasonalMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	pack, ok := seasonalPacks[input.EventID]
+	if !ok {
+		return &GetSeasonalMessageOutput{}, nil
+	}
+
+	lines := pack.normal
+	if input.IsCriticalHit {
+		lines = pack.criticalHit
+	} else if input.IsCriticalFail {
+		lines = pack.criticalFail
+	}
+
+	if len(lines) == 0 {
+		return &GetSeasonalMessageOutput{}, nil
+	}
+
+	return &GetSeasonalMessageOutput{
+		Message: fmt.Sprintf(s.pick(ctx, lines), input.PlayerName),
+	}, nil
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
 	"github.com/KirkDiggler/ronnied/internal/models"
 )
 
@@ -25,6 +27,21 @@ type service struct {
 
 	// Random number generator for selecting random messages
 	rand *rand.Rand
+
+	// Generator writes fresh roll commentary, rate limited per guild
+	generator            Generator
+	generationsPerMinute int
+	generationTimeout    time.Duration
+	clock                clock.Clock
+	generationMu         sync.Mutex
+	generations          map[string]*generationWindow
+
+	// defaultRating is the rating of messages whose context doesn't set one, R when empty
+	ratingMu      sync.RWMutex
+	defaultRating models.ContentRating
+
+	// criticalHitValues are the roll values named as critical hits when the context doesn't set them
+	criticalHitValues []int
 }
 
 // NewService creates a new messaging service
@@ -32,9 +49,39 @@ func NewService(config *ServiceConfig) (Service, error) {
 	// Create a new random source with the current time as seed
 	source := rand.NewSource(time.Now().UnixNano())
 
+	if config == nil {
+		config = &ServiceConfig{}
+	}
+
+	generationsPerMinute := config.GenerationsPerMinute
+	if generationsPerMinute <= 0 {
+		generationsPerMinute = defaultGenerationsPerMinute
+	}
+
+	generationTimeout := config.GenerationTimeout
+	if generationTimeout <= 0 {
+		generationTimeout = defaultGenerationTimeout
+	}
+
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.New()
+	}
+
+	criticalHitValues := config.CriticalHitValues
+	if len(criticalHitValues) == 0 {
+		criticalHitValues = defaultCriticalHitValues
+	}
+
 	return &service{
 		// repository: config.Repository,
-		rand: rand.New(source),
+		rand:                 rand.New(source),
+		generator:            config.Generator,
+		generationsPerMinute: generationsPerMinute,
+		generationTimeout:    generationTimeout,
+		clock:                clk,
+		generations:          make(map[string]*generationWindow),
+		criticalHitValues:    criticalHitValues,
 	}, nil
 }
 
@@ -46,7 +93,7 @@ func (s *service) GetJoinGameMessage(ctx context.Context, input *GetJoinGameMess
 
 	// Set default tone if not specified
 	if input.PreferredTone == "" {
-		tone = ToneFunny
+		tone = contextTone(ctx, ToneFunny)
 	} else {
 		tone = input.PreferredTone
 	}
@@ -76,7 +123,7 @@ func (s *service) GetJoinGameMessage(ctx context.Context, input *GetJoinGameMess
 				"Roll-off in progress! This is where legends (and hangovers) are made.",
 				"The tension builds during this roll-off! Stay tuned for your turn.",
 			}
-		} else if input.GameStatus.IsCompleted() {
+		} else if input.GameStatus.IsFinished() {
 			messages = []string{
 				"You're still here? it's over, go home, you're drunk",
 				"I know, I miss that game too, maybe start another one?",
@@ -105,7 +152,7 @@ func (s *service) GetJoinGameMessage(ctx context.Context, input *GetJoinGameMess
 	}
 
 	// Select a random message
-	selectedMessage := messages[s.rand.Intn(len(messages))]
+	selectedMessage := s.pick(ctx, messages)
 
 	return &GetJoinGameMessageOutput{
 		Message: selectedMessage,
@@ -118,32 +165,32 @@ func (s *service) GetJoinGameErrorMessage(ctx context.Context, input *GetJoinGam
 	// Set default tone if not specified
 	tone := input.Tone
 	if tone == "" {
-		tone = MessageToneFunny // Default to funny tone
+		tone = contextTone(ctx, MessageToneFunny) // Default to funny tone
 	}
 
 	var messages []string
 
 	// Select messages based on error type
 	switch input.ErrorType {
-	case "game_active":
+	case ErrorTypeGameActive:
 		messages = []string{
 			fmt.Sprintf("Sorry, %s! The game is already in progress. Wait for the next round to show off your dice skills.", input.PlayerName),
 			fmt.Sprintf("Whoa there, %s! This train has already left the station. Catch the next game!", input.PlayerName),
 			fmt.Sprintf("Too late, %s! The dice are already rolling. Next time, be quicker on the draw!", input.PlayerName),
 		}
-	case "game_completed":
+	case ErrorTypeGameCompleted:
 		messages = []string{
 			fmt.Sprintf("%s, this game is over! But don't worry, there's always another chance to lose... I mean play!", input.PlayerName),
 			fmt.Sprintf("Game's done, %s! You missed all the fun. Start a new one?", input.PlayerName),
 			fmt.Sprintf("Sorry %s, you can't join a finished game. That's like trying to board a plane that's already landed!", input.PlayerName),
 		}
-	case "game_roll_off":
+	case ErrorTypeGameRollOff:
 		messages = []string{
 			fmt.Sprintf("%s, there's a roll-off in progress! Only the tied players get to participate in this showdown.", input.PlayerName),
 			fmt.Sprintf("Hold your horses, %s! This is a special tie-breaker round. Wait for the next full game.", input.PlayerName),
 			"Roll-off in progress! This is where legends (and hangovers) are made.",
 		}
-	case "already_joined":
+	case ErrorTypeAlreadyJoined:
 		messages = []string{
 			fmt.Sprintf("%s, you're already in this game! One player, one set of dice - those are the rules.", input.PlayerName),
 			fmt.Sprintf("Easy there, %s! You're already part of this game. No need to join twice!", input.PlayerName),
@@ -158,7 +205,7 @@ func (s *service) GetJoinGameErrorMessage(ctx context.Context, input *GetJoinGam
 	}
 
 	// Select a random message
-	selectedMessage := messages[s.rand.Intn(len(messages))]
+	selectedMessage := s.pick(ctx, messages)
 
 	return &GetJoinGameErrorMessageOutput{
 		Title:   "Error Joining Game",
@@ -171,7 +218,7 @@ func (s *service) GetGameStatusMessage(ctx context.Context, input *GetGameStatus
 	// Set default tone if not specified
 	tone := input.Tone
 	if tone == "" {
-		tone = MessageToneFunny // Default to funny tone
+		tone = contextTone(ctx, MessageToneFunny) // Default to funny tone
 	}
 
 	var messages []string
@@ -189,7 +236,7 @@ func (s *service) GetGameStatusMessage(ctx context.Context, input *GetGameStatus
 		messages = []string{
 			"The game is afoot! Roll those dice and pray to the drinking gods.",
 			"May the odds be ever in your favor! Roll your dice!",
-			"It's rolling time! Remember: a 6 means you're lucky, a 1 means you're thirsty.",
+			"It's rolling time! Remember: a critical hit means you're lucky, a critical fail means you're thirsty.",
 			"Game in progress! Roll well or prepare to drink well.",
 			"The dice are hot, and soon your throat will be too! Roll wisely.",
 		}
@@ -208,6 +255,11 @@ func (s *service) GetGameStatusMessage(ctx context.Context, input *GetGameStatus
 			"Game complete! Remember: it's not about winning, it's about making friends drink.",
 			"The final tally is in. Bottoms up to the unlucky ones!",
 		}
+	case models.GameStatusAbandoned:
+		messages = []string{
+			"This game was abandoned. The dice will remember.",
+			"Game abandoned! Nobody wins, everybody still drinks what they owe.",
+		}
 	default:
 		// Fallback message
 		return &GetGameStatusMessageOutput{
@@ -217,9 +269,8 @@ func (s *service) GetGameStatusMessage(ctx context.Context, input *GetGameStatus
 
 	// Select a random message from the appropriate list
 	if len(messages) > 0 {
-		randomIndex := rand.Intn(len(messages))
 		return &GetGameStatusMessageOutput{
-			Message: messages[randomIndex],
+			Message: s.pick(ctx, messages),
 		}, nil
 	}
 
@@ -241,12 +292,12 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 	// Generate dynamic messages based on roll value
 	switch {
 	case input.IsCriticalHit:
-		// Critical hit (6)
+		// Critical hit
 		if isPersonal {
 			titles := []string{
 				"CRIT!",
 				"BOOM! Critical Hit!",
-				"Nat 6!",
+				fmt.Sprintf("Nat %d!", input.RollValue),
 				"Perfect Roll!",
 				"MAXIMUM DAMAGE!",
 				"DANGER ZONE!",
@@ -254,25 +305,25 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 			}
 
 			messages := []string{
-				"You rolled a 6! Time to make someone drink!",
-				"Incredible! You just rolled a 6 and get to assign a drink!",
+				fmt.Sprintf("You rolled a %d! Time to make someone drink!", input.RollValue),
+				fmt.Sprintf("Incredible! You just rolled a %d and get to assign a drink!", input.RollValue),
 				"You're on fire! This means someone's about to get thirsty!",
-				"The dice gods favor you today! That's a 6! Choose your victim!",
+				fmt.Sprintf("The dice gods favor you today! That's a %d! Choose your victim!", input.RollValue),
 				"CRIT! You have the power to make someone drink!",
 				"WOOOO! That's how you get ants! And by ants, I mean drinks for someone else!",
-				"Holy shitsnacks! You rolled a 6! Time to make someone drink!",
-				"Sploosh! That's a 6! You get to choose who drinks!",
+				fmt.Sprintf("Holy shitsnacks! You rolled a %d! Time to make someone drink!", input.RollValue),
+				fmt.Sprintf("Sploosh! That's a %d! You get to choose who drinks!", input.RollValue),
 				"Do you want drunk people? Because that's how you get drunk people!",
-				"Lana. Lana. LANA! LANAAAA! You rolled a 6!",
+				fmt.Sprintf("Lana. Lana. LANA! LANAAAA! You rolled a %d!", input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		} else {
 			titles := []string{
 				"CRIT!",
 				"BOOM! Critical Hit!",
-				"Nat 6!",
+				fmt.Sprintf("Nat %d!", input.RollValue),
 				"Perfect Roll!",
 				"MAXIMUM DAMAGE!",
 				"DANGER ZONE!",
@@ -280,37 +331,37 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 			}
 
 			messages := []string{
-				fmt.Sprintf("%s rolled a 6! Time to make someone drink!", input.PlayerName),
-				fmt.Sprintf("Incredible! %s just rolled a 6 and gets to assign a drink!", input.PlayerName),
-				fmt.Sprintf("%s is on fire! A perfect 6 means someone's about to get thirsty!", input.PlayerName),
-				fmt.Sprintf("The dice gods favor %s today! That's a 6! Choose your victim!", input.PlayerName),
+				fmt.Sprintf("%s rolled a %d! Time to make someone drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Incredible! %s just rolled a %d and gets to assign a drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s is on fire! A perfect %d means someone's about to get thirsty!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("The dice gods favor %s today! That's a %d! Choose your victim!", input.PlayerName, input.RollValue),
 				fmt.Sprintf("CRIT! %s has the power to make someone drink!", input.PlayerName),
-				fmt.Sprintf("%s rolled a 6! Someone's getting a drink whether they like it or not!", input.PlayerName),
-				fmt.Sprintf("Look at %s showing off with that 6! Now they get to choose who drinks!", input.PlayerName),
-				fmt.Sprintf("A wild 6 appears for %s! Time to inflict some liquid damage!", input.PlayerName),
-				fmt.Sprintf("%s just rolled a 6! Finally achieving something in life!", input.PlayerName),
+				fmt.Sprintf("%s rolled a %d! Someone's getting a drink whether they like it or not!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Look at %s showing off with that %d! Now they get to choose who drinks!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("A wild %d appears for %s! Time to inflict some liquid damage!", input.RollValue, input.PlayerName),
+				fmt.Sprintf("%s just rolled a %d! Finally achieving something in life!", input.PlayerName, input.RollValue),
 				fmt.Sprintf("The chosen one! %s now wields the power of drink assignment!", input.PlayerName),
-				fmt.Sprintf("Against all odds, %s somehow managed to roll a 6! Must be their birthday!", input.PlayerName),
-				fmt.Sprintf("%s's 6 is the universe's way of saying someone needs to drink more!", input.PlayerName),
-				fmt.Sprintf("%s just entered the DANGER ZONE with that 6! Time to assign a drink!", input.PlayerName),
-				fmt.Sprintf("Holy shitsnacks! %s rolled a 6! Someone's about to get wasted!", input.PlayerName),
-				fmt.Sprintf("Do you want drunk people? Because that's how %s gets drunk people! With a 6!", input.PlayerName),
-				fmt.Sprintf("%s rolled a 6! Just the tip... of greatness!", input.PlayerName),
-				fmt.Sprintf("Sploosh! %s rolled a 6 and gets to make someone drink!", input.PlayerName),
-				fmt.Sprintf("%s: 'I swear I had something for this...' *rolls a 6*", input.PlayerName),
+				fmt.Sprintf("Against all odds, %s somehow managed to roll a %d! Must be their birthday!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s's %d is the universe's way of saying someone needs to drink more!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s just entered the DANGER ZONE with that %d! Time to assign a drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Holy shitsnacks! %s rolled a %d! Someone's about to get wasted!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Do you want drunk people? Because that's how %s gets drunk people! With a %d!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s rolled a %d! Just the tip... of greatness!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Sploosh! %s rolled a %d and gets to make someone drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s: 'I swear I had something for this...' *rolls a %d*", input.PlayerName, input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		}
 
 	case input.IsCriticalFail:
-		// Critical fail (1)
+		// Critical fail
 		if isPersonal {
 			titles := []string{
 				"CRITICAL FAIL!",
 				"Ouch! Snake Eye!",
-				"Nat 1!",
+				fmt.Sprintf("Nat %d!", input.RollValue),
 				"MINIMUM DAMAGE!",
 				"Better luck next time!",
 				"I didn't see it, was it a good roll?",
@@ -319,25 +370,25 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 			}
 
 			messages := []string{
-				"You rolled a 1! Drink up!",
-				"Oof! You just rolled a 1. Bottoms up!",
-				"You angered the dice gods with that 1! Drink up, friend!",
-				"Ronnie has spoken! You rolled a 1 and must take a drink!",
-				"CRITICAL FAIL! You rolled a 1 and have to drink!",
-				"Wow, that's... just... wow. You rolled a 1. Drink until you forget that happened.",
-				"That's what I call a 'Pam-level' roll. A 1! Drink up!",
-				"You rolled a 1! Do you want to get ants? Because that's how you get ants.",
-				"That's a 1! I had something for this... something about drinking?",
-				"Nooope! You rolled a 1. Time to drink away the shame.",
+				fmt.Sprintf("You rolled a %d! Drink up!", input.RollValue),
+				fmt.Sprintf("Oof! You just rolled a %d. Bottoms up!", input.RollValue),
+				fmt.Sprintf("You angered the dice gods with that %d! Drink up, friend!", input.RollValue),
+				fmt.Sprintf("Ronnie has spoken! You rolled a %d and must take a drink!", input.RollValue),
+				fmt.Sprintf("CRITICAL FAIL! You rolled a %d and have to drink!", input.RollValue),
+				fmt.Sprintf("Wow, that's... just... wow. You rolled a %d. Drink until you forget that happened.", input.RollValue),
+				fmt.Sprintf("That's what I call a 'Pam-level' roll. A %d! Drink up!", input.RollValue),
+				fmt.Sprintf("You rolled a %d! Do you want to get ants? Because that's how you get ants.", input.RollValue),
+				fmt.Sprintf("That's a %d! I had something for this... something about drinking?", input.RollValue),
+				fmt.Sprintf("Nooope! You rolled a %d. Time to drink away the shame.", input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		} else {
 			titles := []string{
 				"CRITICAL FAIL!",
 				"Ouch! Snake Eye!",
-				"Nat 1!",
+				fmt.Sprintf("Nat %d!", input.RollValue),
 				"MINIMUM DAMAGE!",
 				"Better luck next time!",
 				":trumpet: Sad Trumpet :(",
@@ -346,28 +397,28 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 			}
 
 			messages := []string{
-				fmt.Sprintf("%s rolled a 1! Time to drink up!", input.PlayerName),
-				fmt.Sprintf("Oof! %s just rolled a 1. Bottoms up!", input.PlayerName),
-				fmt.Sprintf("%s angered the dice gods with that 1! Drink up, friend!", input.PlayerName),
-				fmt.Sprintf("Ronnie has spoken! %s rolled a 1 and must take a drink!", input.PlayerName),
-				fmt.Sprintf("CRITICAL FAIL! %s rolled a 1 and has to drink!", input.PlayerName),
-				fmt.Sprintf("%s rolled a 1! Exactly what we all expected from them!", input.PlayerName),
-				fmt.Sprintf("The dice gods have forsaken %s with that 1! Drink to drown your sorrows!", input.PlayerName),
-				fmt.Sprintf("A spectacular fail from %s with that 1! At least they're consistent!", input.PlayerName),
-				fmt.Sprintf("%s's 1 is a perfect representation of their life choices so far!", input.PlayerName),
-				fmt.Sprintf("The universe has spoken: %s rolled a 1 and needs alcohol to cope with it!", input.PlayerName),
-				fmt.Sprintf("Even the dice are laughing at %s's pathetic 1! Drink up, buddy!", input.PlayerName),
-				fmt.Sprintf("%s just proved they can't even roll dice properly with that 1! Drink to forget!", input.PlayerName),
-				fmt.Sprintf("Wow, that's... just... wow. %s rolled a 1. Drink until you forget that happened.", input.PlayerName),
-				fmt.Sprintf("%s rolled a 1! Guess they'll be entering the DANGER ZONE of drunkenness soon!", input.PlayerName),
-				fmt.Sprintf("Holy shitsnacks! %s rolled a 1! That's like, the worst possible outcome!", input.PlayerName),
-				fmt.Sprintf("%s: 'Wait, I had something for this...' *rolls a 1* 'Dammit!'", input.PlayerName),
-				fmt.Sprintf("That's what I call a 'Pam-level' roll from %s. A 1! Drink up!", input.PlayerName),
-				fmt.Sprintf("%s rolled a 1! Do you want to get drunk? Because that's how you get drunk.", input.PlayerName),
+				fmt.Sprintf("%s rolled a %d! Time to drink up!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Oof! %s just rolled a %d. Bottoms up!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s angered the dice gods with that %d! Drink up, friend!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Ronnie has spoken! %s rolled a %d and must take a drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("CRITICAL FAIL! %s rolled a %d and has to drink!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s rolled a %d! Exactly what we all expected from them!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("The dice gods have forsaken %s with that %d! Drink to drown your sorrows!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("A spectacular fail from %s with that %d! At least they're consistent!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s's %d is a perfect representation of their life choices so far!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("The universe has spoken: %s rolled a %d and needs alcohol to cope with it!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Even the dice are laughing at %s's pathetic %d! Drink up, buddy!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s just proved they can't even roll dice properly with that %d! Drink to forget!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Wow, that's... just... wow. %s rolled a %d. Drink until you forget that happened.", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s rolled a %d! Guess they'll be entering the DANGER ZONE of drunkenness soon!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Holy shitsnacks! %s rolled a %d! That's like, the worst possible outcome!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s: 'Wait, I had something for this...' *rolls a %d* 'Dammit!'", input.PlayerName, input.RollValue),
+				fmt.Sprintf("That's what I call a 'Pam-level' roll from %s. A %d! Drink up!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("%s rolled a %d! Do you want to get drunk? Because that's how you get drunk.", input.PlayerName, input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		}
 
 	default:
@@ -396,8 +447,8 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 				fmt.Sprintf("You rolled a %d. Meh, I've seen better. I've seen worse, but I've definitely seen better.", input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		} else {
 			titles := []string{
 				fmt.Sprintf("%d!", input.RollValue),
@@ -432,11 +483,21 @@ func (s *service) GetRollResultMessage(ctx context.Context, input *GetRollResult
 				fmt.Sprintf("%s got a %d. Meh, I've seen better. I've seen worse, but I've definitely seen better.", input.PlayerName, input.RollValue),
 			}
 
-			title = titles[rand.Intn(len(titles))]
-			message = messages[rand.Intn(len(messages))]
+			title = s.pick(ctx, titles)
+			message = s.pick(ctx, messages)
 		}
 	}
 
+	// Natural 20s and natural 1s on a d20 get called out as such
+	if naturalTitle, naturalMessage, ok := s.naturalRollResult(ctx, input); ok {
+		title, message = naturalTitle, naturalMessage
+	}
+
+	// Use a freshly written comment when the guild has them on
+	if generated, ok := s.generateRollMessage(ctx, input); ok {
+		message = generated
+	}
+
 	return &GetRollResultMessageOutput{
 		Title:   title,
 		Message: message,
@@ -462,7 +523,7 @@ func (s *service) GetGameStartedMessage(ctx context.Context, input *GetGameStart
 	}
 
 	// Select a random message
-	selectedMessage := messages[s.rand.Intn(len(messages))]
+	selectedMessage := s.pick(ctx, messages)
 
 	return &GetGameStartedMessageOutput{
 		Message: selectedMessage,
@@ -476,21 +537,21 @@ func (s *service) GetErrorMessage(ctx context.Context, input *GetErrorMessageInp
 
 	// Set default tone if not specified
 	if input.PreferredTone == "" {
-		tone = MessageToneFunny
+		tone = contextTone(ctx, MessageToneFunny)
 	} else {
 		tone = input.PreferredTone
 	}
 
 	// Select messages based on error type
 	switch input.ErrorType {
-	case "game_active":
+	case ErrorTypeGameActive:
 		messages = []string{
 			"This game is already rolling! Catch the next one.",
 			"Too late, hotshot! The dice are already in motion.",
 			"The game has started without you. Next time be quicker!",
 			"Sorry, this train has left the station. Wait for the next game.",
 		}
-	case "game_roll_off":
+	case ErrorTypeGameRollOff:
 		messages = []string{
 			"There's an epic roll-off happening! Wait for the next round.",
 			"Roll-off in progress! No new players allowed in this tense moment.",
@@ -498,41 +559,133 @@ func (s *service) GetErrorMessage(ctx context.Context, input *GetErrorMessageInp
 			"Can't join during a roll-off! The tension is too high for newcomers.",
 			"Roll-off in progress! This is where legends (and hangovers) are made.",
 		}
-	case "game_completed":
+	case ErrorTypeGameCompleted:
 		messages = []string{
 			"This game is already over! Check out who's buying drinks.",
 			"You missed this one completely. The game is already finished!",
 			"Too late! The tab has already been settled for this game.",
 			"Game over! But you can start a new one if you're thirsty.",
 		}
-	case "invalid_game_state":
+	case ErrorTypeInvalidGameState:
 		messages = []string{
 			"The game is in a weird state. Try again later or start a new one.",
 			"Something's off with this game. Maybe it's had too many drinks?",
 			"Can't join right now. The game is... confused.",
 			"This game has gone rogue! Best to start a fresh one.",
 		}
-	case "game_full":
+	case ErrorTypeGameFull:
 		messages = []string{
 			"This game is packed! Try again when someone leaves.",
 			"No room at the inn! This game is full.",
 			"Too many players already! Wait for the next game.",
 			"This party's at capacity! Try again later.",
 		}
-	case "already_rolled":
+	case ErrorTypeAlreadyRolled:
 		messages = []string{
 			"You've already rolled! Give someone else a turn.",
 			"Eager, aren't we? You've already had your turn!",
 			"One roll per turn! You'll have to wait.",
 			"The dice need a break from you! You've already rolled.",
 		}
-	case "not_your_turn":
+	case ErrorTypeNotYourTurn:
 		messages = []string{
 			"Patience! It's not your turn yet.",
 			"Hold your horses! Someone else is rolling now.",
 			"Wait your turn! The dice will come to you soon.",
 			"The dice aren't ready for you yet! Wait your turn.",
 		}
+	case ErrorTypeGameNotFound:
+		messages = []string{
+			"There's no game here! Start one and get the dice rolling.",
+			"No game found. The dice are gathering dust.",
+			"Nothing to see here... yet. Start a new game!",
+		}
+	case ErrorTypeGameNotStarted:
+		messages = []string{
+			"Not so fast! The game hasn't started yet.",
+			"Hold your dice! Wait for the game to begin.",
+			"The game hasn't kicked off yet. Patience, thirsty one!",
+		}
+	case ErrorTypeGameExists:
+		messages = []string{
+			"There's already a game going in this channel! Join that one.",
+			"One game at a time! Finish the current one first.",
+			"A game is already brewing here. Jump in!",
+		}
+	case ErrorTypeAlreadyJoined:
+		messages = []string{
+			"You're already in this game! One set of dice per player.",
+			"Easy there! You've already joined.",
+			"You can't join twice, no matter how thirsty you are.",
+		}
+	case ErrorTypeNotInGame:
+		messages = []string{
+			"You're not part of this game! Join the next one.",
+			"Nice try, but you're not in this game.",
+			"Spectators don't get dice! Wait for the next game.",
+		}
+	case ErrorTypeNotEnoughPlayers:
+		messages = []string{
+			"Drinking alone? Get more players to join first!",
+			"Not enough players yet! Round up some friends.",
+			"You need company for this! Wait for more players.",
+		}
+	case ErrorTypeNotCreator:
+		messages = []string{
+			"Only the game creator or a co-host can do that!",
+			"That's the host's call, not yours.",
+			"Nice try! Only whoever started the game, or their co-hosts, can do that.",
+		}
+	case ErrorTypeInRollOff:
+		messages = []string{
+			"You're in a roll-off! Roll there to settle the tie.",
+			"Tie-breaker time! Your roll belongs in the roll-off.",
+			"The roll-off awaits you! Roll to break the tie.",
+		}
+	case ErrorTypeNotEligible:
+		criticalHits := s.criticalHits(ctx)
+		messages = []string{
+			"You haven't earned the right to hand out drinks!",
+			fmt.Sprintf("Only a %s gets to assign drinks. Keep rolling!", criticalHits),
+			fmt.Sprintf("No drink for you to give! Roll a %s first.", criticalHits),
+		}
+	case ErrorTypeRoundInProgress:
+		messages = []string{
+			"Not so fast! This round isn't over yet.",
+			"Some players still have business to finish. Hang tight!",
+			"The round is still going! Wait for everyone to finish.",
+		}
+	case ErrorTypeCoolingDown:
+		messages = []string{
+			"Easy there! Have some water before your next one.",
+			"Pace yourself! That drink will still be here in a few minutes.",
+			"Cooling down! Take a breather before paying another drink.",
+		}
+	case ErrorTypeNoRerollTokens:
+		messages = []string{
+			"You're out of re-roll tokens. That roll stands!",
+			"No tokens, no do-overs. Live with it!",
+			"Your re-roll pouch is empty. Earn some more first!",
+		}
+	case ErrorTypeRerollNotAllowed:
+		messages = []string{
+			"That roll can't be re-rolled. The dice have spoken!",
+			"One re-roll per game, and only before the round wraps up!",
+			"No take-backs on that one!",
+		}
+	case ErrorTypeNotInvited:
+		messages = []string{
+			"This is a private table and your name's not on the list. Nice shoes though.",
+			"Invite only! The bouncer has never heard of you.",
+			"Sorry, this table's reserved. Try the bar, they're less picky.",
+			"No invite, no dice. Literally.",
+		}
+	case ErrorTypeGamePaused:
+		messages = []string{
+			"Hold your horses, the game is paused!",
+			"The host hit pause. Put the dice down and top up your drink.",
+			"Paused! Use the break wisely: bathroom, snacks, hydration.",
+		}
 	default:
 		messages = []string{
 			"Something went wrong! Try again later.",
@@ -543,7 +696,7 @@ func (s *service) GetErrorMessage(ctx context.Context, input *GetErrorMessageInp
 	}
 
 	// Select a random message
-	selectedMessage := messages[s.rand.Intn(len(messages))]
+	selectedMessage := s.pick(ctx, messages)
 
 	return &GetErrorMessageOutput{
 		Message: selectedMessage,
@@ -562,46 +715,47 @@ func (s *service) GetRollWhisperMessage(ctx context.Context, input *GetRollWhisp
 
 	// Set default tone if not specified
 	if input.PreferredTone == "" {
-		tone = MessageToneEncouraging // Default to encouraging tone for whispers
+		tone = contextTone(ctx, MessageToneEncouraging) // Default to encouraging tone for whispers
 	} else {
 		tone = input.PreferredTone
 	}
+	sides := diceSides(input.DiceSides)
 
 	// Select messages based on roll type
 	switch {
 	case input.IsCriticalHit:
-		// Critical hit (6)
+		// Critical hit
 		messages = []string{
 			fmt.Sprintf("Hey %s, between you and me, that was a killer roll! Make 'em drink good!", input.PlayerName),
 			fmt.Sprintf("*whispers* %s, I knew you had it in you! Now make someone suffer!", input.PlayerName),
 			fmt.Sprintf("Don't tell anyone, but that's the best roll I've seen all day, %s!", input.PlayerName),
-			fmt.Sprintf("*quietly* %s, you're my favorite player. That 6 was destiny!", input.PlayerName),
+			fmt.Sprintf("*quietly* %s, you're my favorite player. That %d was destiny!", input.PlayerName, input.RollValue),
 			fmt.Sprintf("Just between us, %s, I might have nudged that dice a little for you. Our secret!", input.PlayerName),
-			fmt.Sprintf("*whispers* Welcome to the DANGER ZONE, %s! That 6 is your ticket to power!", input.PlayerName),
-			fmt.Sprintf("Hey %s, do you want drunk people? Because that's how you get drunk people! Nice 6!", input.PlayerName),
-			fmt.Sprintf("*quietly* Sploosh! That 6 was amazing, %s. Now make someone pay!", input.PlayerName),
+			fmt.Sprintf("*whispers* Welcome to the DANGER ZONE, %s! That %d is your ticket to power!", input.PlayerName, input.RollValue),
+			fmt.Sprintf("Hey %s, do you want drunk people? Because that's how you get drunk people! Nice %d!", input.PlayerName, input.RollValue),
+			fmt.Sprintf("*quietly* Sploosh! That %d was amazing, %s. Now make someone pay!", input.RollValue, input.PlayerName),
 			fmt.Sprintf("Between us, %s, I'm pretty sure that roll just got you a seat on the highway to the DANGER ZONE!", input.PlayerName),
 			fmt.Sprintf("*whispers* That's how you roll dice, %s! Other Barry would be proud.", input.PlayerName),
 		}
 
 	case input.IsCriticalFail:
-		// Critical fail (1)
+		// Critical fail
 		messages = []string{
-			fmt.Sprintf("*whispers* Hey %s, don't worry about that 1. We all have bad days!", input.PlayerName),
+			fmt.Sprintf("*whispers* Hey %s, don't worry about that %d. We all have bad days!", input.PlayerName, input.RollValue),
 			fmt.Sprintf("Between you and me, %s, I've seen worse rolls... well, actually I haven't, but chin up!", input.PlayerName),
 			fmt.Sprintf("*quietly* %s, that drink might help you forget that terrible roll!", input.PlayerName),
-			fmt.Sprintf("Don't let the others see you sweat, %s. Act like you meant to roll that 1!", input.PlayerName),
+			fmt.Sprintf("Don't let the others see you sweat, %s. Act like you meant to roll that %d!", input.PlayerName, input.RollValue),
 			fmt.Sprintf("Hey %s, at least you're consistent! Consistently unlucky, but still...", input.PlayerName),
 			fmt.Sprintf("*whispers* Holy shitsnacks, %s! That was... not great. Drink to forget!", input.PlayerName),
 			fmt.Sprintf("Between us, %s, that roll was what I call a classic Cyril. Total failure.", input.PlayerName),
-			fmt.Sprintf("*quietly* %s, you just entered a whole new DANGER ZONE of failure with that 1!", input.PlayerName),
-			fmt.Sprintf("Phrasing! But seriously %s, that 1 was pretty bad. Have a drink, you need it.", input.PlayerName),
+			fmt.Sprintf("*quietly* %s, you just entered a whole new DANGER ZONE of failure with that %d!", input.PlayerName, input.RollValue),
+			fmt.Sprintf("Phrasing! But seriously %s, that %d was pretty bad. Have a drink, you need it.", input.PlayerName, input.RollValue),
 			fmt.Sprintf("*whispers* %s, I'd say that roll was disappointing, but that would imply I expected more from you.", input.PlayerName),
 		}
 
 	default:
-		// Normal roll (2-5)
-		if input.RollValue <= 3 {
+		// Normal roll, between the critical fails and hits
+		if input.RollValue <= sides/2 {
 			// Lower normal rolls
 			messages = []string{
 				fmt.Sprintf("*whispers* Not great, not terrible, %s. You'll get 'em next time!", input.PlayerName),
@@ -619,7 +773,7 @@ func (s *service) GetRollWhisperMessage(ctx context.Context, input *GetRollWhisp
 			// Higher normal rolls
 			messages = []string{
 				fmt.Sprintf("*whispers* Nice roll, %s! Just one away from greatness!", input.PlayerName),
-				fmt.Sprintf("Between us, %s, that %d is pretty solid. Not a 6, but respectable!", input.PlayerName, input.RollValue),
+				fmt.Sprintf("Between us, %s, that %d is pretty solid. Not a %d, but respectable!", input.PlayerName, input.RollValue, sides),
 				fmt.Sprintf("*quietly* %s, you're getting better at this! Keep it up!", input.PlayerName),
 				fmt.Sprintf("Hey %s, with rolls like that, you might just survive this game!", input.PlayerName),
 				fmt.Sprintf("Don't tell the others, but I'm rooting for you, %s! That was a good one!", input.PlayerName),
@@ -633,7 +787,10 @@ func (s *service) GetRollWhisperMessage(ctx context.Context, input *GetRollWhisp
 	}
 
 	// Select a random message
-	selectedMessage := messages[s.rand.Intn(len(messages))]
+	selectedMessage := s.pick(ctx, messages)
+	if natural, ok := s.naturalRollWhisper(ctx, input); ok {
+		selectedMessage = natural
+	}
 
 	return &GetRollWhisperMessageOutput{
 		Message: selectedMessage,
@@ -659,7 +816,7 @@ func (s *service) GetLeaderboardMessage(ctx context.Context, input *GetLeaderboa
 			fmt.Sprintf("The crown goes to %s with %d drinks! Remember this moment, it's all downhill from here.", input.PlayerName, input.DrinkCount),
 			fmt.Sprintf("%s dominated with %d drinks assigned! Exactly what we'd expect from someone in their income bracket.", input.PlayerName, input.DrinkCount),
 		}
-		message = messages[s.rand.Intn(len(messages))]
+		message = s.pick(ctx, messages)
 	} else if input.Rank == 1 { // Second place
 		messages := []string{
 			fmt.Sprintf("Silver medalist %s assigned %d drinks. So close to greatness, yet so far.", input.PlayerName, input.DrinkCount),
@@ -668,7 +825,7 @@ func (s *service) GetLeaderboardMessage(ctx context.Context, input *GetLeaderboa
 			fmt.Sprintf("%s got %d drinks assigned - the silver medal of making others suffer!", input.PlayerName, input.DrinkCount),
 			fmt.Sprintf("Second place: %s with %d drinks. Nobody remembers second place, but we'll try.", input.PlayerName, input.DrinkCount),
 		}
-		message = messages[s.rand.Intn(len(messages))]
+		message = s.pick(ctx, messages)
 	} else if input.Rank == 2 { // Third place
 		messages := []string{
 			fmt.Sprintf("Bronze tier: %s with %d drinks assigned. At least you made the podium!", input.PlayerName, input.DrinkCount),
@@ -678,7 +835,7 @@ func (s *service) GetLeaderboardMessage(ctx context.Context, input *GetLeaderboa
 			fmt.Sprintf("The bronze medal of drink delegation goes to %s with %d drinks. You're technically on the podium!", input.PlayerName, input.DrinkCount),
 			fmt.Sprintf("%s takes the bronze with %d drinks assigned. Better luck next time, maybe?", input.PlayerName, input.DrinkCount),
 		}
-		message = messages[s.rand.Intn(len(messages))]
+		message = s.pick(ctx, messages)
 	} else if input.Rank == input.TotalPlayers-1 { // Last place
 		messages := []string{
 			fmt.Sprintf("Dead last: %s with %d drinks. Even the dice feel sorry for you.", input.PlayerName, input.DrinkCount),
@@ -687,7 +844,7 @@ func (s *service) GetLeaderboardMessage(ctx context.Context, input *GetLeaderboa
 			fmt.Sprintf("%s assigned %d drinks. If 'participation trophy' was a person.", input.PlayerName, input.DrinkCount),
 			fmt.Sprintf("And then there's %s with %d drinks. Thanks for showing up, I guess?", input.PlayerName, input.DrinkCount),
 		}
-		message = messages[s.rand.Intn(len(messages))]
+		message = s.pick(ctx, messages)
 	} else { // Middle of the pack
 		messages := []string{
 			fmt.Sprintf("%s: %d drinks assigned. Perfectly average, just like their choice of clothing.", input.PlayerName, input.DrinkCount),
@@ -696,7 +853,7 @@ func (s *service) GetLeaderboardMessage(ctx context.Context, input *GetLeaderboa
 			fmt.Sprintf("%s: %d drinks. Aggressively mediocre performance as usual.", input.PlayerName, input.DrinkCount),
 			fmt.Sprintf("With %d drinks assigned, %s continues their lifelong streak of being unremarkable.", input.DrinkCount, input.PlayerName),
 		}
-		message = messages[s.rand.Intn(len(messages))]
+		message = s.pick(ctx, messages)
 	}
 
 	return &GetLeaderboardMessageOutput{
@@ -735,8 +892,8 @@ func (s *service) GetPayDrinkMessage(ctx context.Context, input *GetPayDrinkMess
 	}
 
 	// Select random title and message
-	title = titles[s.rand.Intn(len(titles))]
-	message = messages[s.rand.Intn(len(messages))]
+	title = s.pick(ctx, titles)
+	message = s.pick(ctx, messages)
 
 	if input.DrinkCount > 1 {
 		message += fmt.Sprintf(" (%d/%d drinks paid)", input.DrinkCount, input.DrinkCount)
@@ -756,9 +913,14 @@ func (s *service) GetRollComment(ctx context.Context, input *GetRollCommentInput
 
 	var comment string
 
+	// A neutral tone sticks to the rolls
+	if contextTone(ctx, MessageToneFunny) == ToneNeutral {
+		return &GetRollCommentOutput{}, nil
+	}
+
 	// Select comment based on roll value
 	switch {
-	case input.RollValue == 6:
+	case input.IsCriticalHit:
 		// Archer-inspired comments for critical hits
 		archerComments := []string{
 			"\n    *\"Feeling powerful today!\"*",
@@ -782,8 +944,8 @@ func (s *service) GetRollComment(ctx context.Context, input *GetRollCommentInput
 			"\n    *\"Yuuup! That's how it's done!\"*",
 			"\n    *\"Burt Reynolds would be proud of that roll!\"*",
 		}
-		comment = archerComments[s.rand.Intn(len(archerComments))]
-	case input.RollValue == 1:
+		comment = s.pick(ctx, archerComments)
+	case input.IsCriticalFail:
 		// Archer-inspired comments for critical fails
 		archerComments := []string{
 			"\n    *\"Ouch, better luck next time!\"*",
@@ -807,7 +969,7 @@ func (s *service) GetRollComment(ctx context.Context, input *GetRollCommentInput
 			"\n    *\"That roll was so bad, even Woodhouse wouldn't pick it up.\"*",
 			"\n    *\"Nooope! That's not how it's done!\"*",
 		}
-		comment = archerComments[s.rand.Intn(len(archerComments))]
+		comment = s.pick(ctx, archerComments)
 	case input.RollValue >= 4:
 		// Comments for high rolls (4-5)
 		archerComments := []string{
@@ -827,7 +989,7 @@ func (s *service) GetRollComment(ctx context.Context, input *GetRollCommentInput
 			"\n    *\"Rolls dice like they're on a modest amount of Groovy Bears.\"*",
 			"\n    *\"That's a 'meh plus' on the Archer scale of excellence.\"*",
 		}
-		comment = archerComments[s.rand.Intn(len(archerComments))]
+		comment = s.pick(ctx, archerComments)
 	default:
 		// Comments for low rolls (2-3)
 		archerComments := []string{
@@ -847,7 +1009,7 @@ func (s *service) GetRollComment(ctx context.Context, input *GetRollCommentInput
 			"\n    *\"That roll was like Lana's patience - stretched pretty thin.\"*",
 			"\n    *\"That roll was like Brett's ability to not get shot - not great.\"*",
 		}
-		comment = archerComments[s.rand.Intn(len(archerComments))]
+		comment = s.pick(ctx, archerComments)
 	}
 
 	return &GetRollCommentOutput{
@@ -885,26 +1047,26 @@ func (s *service) GetDrinkAssignmentMessage(ctx context.Context, input *GetDrink
 			"🔥 **%s** chose **%s** for a drink! *\"Burt Reynolds would be proud of that drink assignment!\"*",
 			"🔥 **%s** → **%s** *\"That drink assignment was like my fifth of scotch - perfect!\"*",
 		}
-		message = fmt.Sprintf(archerAssignments[s.rand.Intn(len(archerAssignments))], input.FromPlayerName, input.ToPlayerName)
+		message = fmt.Sprintf(s.pick(ctx, archerAssignments), input.FromPlayerName, input.ToPlayerName)
 	case models.DrinkReasonCriticalFail:
 		archerFailMessages := []string{
-			"💀 **%s** rolled a 1 and had to drink! *\"That's how you get ants!\"*",
-			"💀 **%s** rolled a 1! *\"Classic Cyril move right there!\"*",
+			"💀 **%s** rolled a critical fail and had to drink! *\"That's how you get ants!\"*",
+			"💀 **%s** rolled a critical fail! *\"Classic Cyril move right there!\"*",
 			"💀 **%s** critically failed! *\"Entered a whole new DANGER ZONE of failure!\"*",
-			"💀 **%s** rolled a 1 and drinks! *\"Holy shitsnacks! That was... not great.\"*",
-			"💀 **%s** rolled a 1! *\"Raises their glass in a toast to bad luck and good friends! 🥂\"*",
-			"💀 **%s** rolled a 1! *\"I swear I had something for this...\"*",
-			"💀 **%s** rolled a 1! *\"Womp womp!\"*",
-			"💀 **%s** rolled a 1 and drinks! *\"Looks like someone's eating a big bowl of spider webs tonight!\"*",
+			"💀 **%s** rolled a critical fail and drinks! *\"Holy shitsnacks! That was... not great.\"*",
+			"💀 **%s** rolled a critical fail! *\"Raises their glass in a toast to bad luck and good friends! 🥂\"*",
+			"💀 **%s** rolled a critical fail! *\"I swear I had something for this...\"*",
+			"💀 **%s** rolled a critical fail! *\"Womp womp!\"*",
+			"💀 **%s** rolled a critical fail and drinks! *\"Looks like someone's eating a big bowl of spider webs tonight!\"*",
 			"💀 **%s** critically failed! *\"That roll was worse than Brett getting shot... again.\"*",
-			"💀 **%s** rolled a 1! *\"Lana. Lana. LANAAA! This roll is terrible!\"*",
-			"💀 **%s** rolled a 1 and drinks! *\"That's what I call a Cyril-grade performance.\"*",
+			"💀 **%s** rolled a critical fail! *\"Lana. Lana. LANAAA! This roll is terrible!\"*",
+			"💀 **%s** rolled a critical fail and drinks! *\"That's what I call a Cyril-grade performance.\"*",
 			"💀 **%s** critically failed! *\"Looks like someone's been drinking Glengoolie... Brown.\"*",
-			"💀 **%s** rolled a 1! *\"Do you want to lose? Because that's how you lose!\"*",
-			"💀 **%s** rolled a 1 and drinks! *\"That roll was like Milton's toast - burnt to a crisp.\"*",
+			"💀 **%s** rolled a critical fail! *\"Do you want to lose? Because that's how you lose!\"*",
+			"💀 **%s** rolled a critical fail and drinks! *\"That roll was like Milton's toast - burnt to a crisp.\"*",
 			"💀 **%s** critically failed! *\"That roll was so bad, even Woodhouse wouldn't pick it up.\"*",
 		}
-		message = fmt.Sprintf(archerFailMessages[s.rand.Intn(len(archerFailMessages))], input.FromPlayerName)
+		message = fmt.Sprintf(s.pick(ctx, archerFailMessages), input.FromPlayerName)
 	case models.DrinkReasonLowestRoll:
 		archerLowestMessages := []string{
 			"👇 **%s** had the lowest roll and had to drink! *\"Womp womp!\"*",
@@ -920,12 +1082,112 @@ func (s *service) GetDrinkAssignmentMessage(ctx context.Context, input *GetDrink
 			"👇 **%s** rolled lowest and drinks! *\"That roll was like Archer's emotional growth - minimal but technically exists.\"*",
 			"👇 **%s** got the lowest roll! *\"That roll was like Lana's patience - stretched pretty thin.\"*",
 		}
-		message = fmt.Sprintf(archerLowestMessages[s.rand.Intn(len(archerLowestMessages))], input.FromPlayerName)
+		message = fmt.Sprintf(s.pick(ctx, archerLowestMessages), input.FromPlayerName)
+	case models.DrinkReasonSocialRound:
+		message = "🍻 **Social!** Everyone drinks!"
+		if input.FromPlayerName != "" {
+			message = fmt.Sprintf("🍻 **Social!** Everyone drinks, thanks to **%s**!", input.FromPlayerName)
+		}
+	case models.DrinkReasonKarma:
+		message = fmt.Sprintf("😈 **%s** picked on **%s** once too often, karma says they drink too!", input.ToPlayerName, input.FromPlayerName)
+	case models.DrinkReasonRoundsLoser:
+		message = fmt.Sprintf("🔁 **%s** had the lowest total over the rounds and takes a bonus drink!", input.ToPlayerName)
+	case models.DrinkReasonSuddenDeath:
+		message = fmt.Sprintf("☠️ **%s** lost the sudden death and drinks from the pot!", input.ToPlayerName)
+	case models.DrinkReasonJackpot:
+		message = fmt.Sprintf("💰 **%s** drinks from the jackpot!", input.ToPlayerName)
 	default:
 		message = fmt.Sprintf("🍺 **%s** → **%s**", input.FromPlayerName, input.ToPlayerName)
 	}
 
+	if note := HappyHourNote(input.HappyHourMultiplier, false); note != "" {
+		message += " " + note
+	}
+
 	return &GetDrinkAssignmentMessageOutput{
 		Message: message,
 	}, nil
 }
+
+// GetMercyRuleMessage returns a message for when the mercy rule turns a player's drink social
+func (s *service) GetMercyRuleMessage(ctx context.Context, input *GetMercyRuleMessageInput) (*GetMercyRuleMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	titles := []string{
+		"Mercy Rule! 🏳️",
+		"Cut Off! 🛑",
+		"Tab Capped! 🧢",
+	}
+
+	messages := []string{
+		fmt.Sprintf("**%s** has hit %d drinks this session. That one's a social - everybody else drinks with them instead!", input.PlayerName, input.DrinkCap),
+		fmt.Sprintf("The mercy rule spares **%s** at %d drinks. Call it a social and raise a glass together.", input.PlayerName, input.DrinkCap),
+		fmt.Sprintf("%d drinks is plenty for **%s**. The dice show mercy - this one's on the table, not on their tab.", input.DrinkCap, input.PlayerName),
+		fmt.Sprintf("**%s** is capped at %d drinks. Somebody get them a water, the rest of you can have a social.", input.PlayerName, input.DrinkCap),
+	}
+
+	return &GetMercyRuleMessageOutput{
+		Title:   s.pick(ctx, titles),
+		Message: s.pick(ctx, messages),
+	}, nil
+}
+
+// GetSocialRoundMessage returns a message for a social round, when everyone in the game drinks at once
+func (s *service) GetSocialRoundMessage(ctx context.Context, input *GetSocialRoundMessageInput) (*GetSocialRoundMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	titles := []string{
+		"Social! 🍻",
+		"Everybody Drinks! 🍻",
+		"Cheers! 🥂",
+	}
+
+	cause := fmt.Sprintf("**%s** called a social", input.PlayerName)
+	if input.RollValue > 0 {
+		cause = fmt.Sprintf("**%s** rolled a %d", input.PlayerName, input.RollValue)
+	}
+
+	messages := []string{
+		fmt.Sprintf("%s! All %d of you, glasses up - everybody drinks!", cause, input.DrinkCount),
+		fmt.Sprintf("%s. Nobody's safe this time: that's a drink each for all %d players.", cause, input.DrinkCount),
+		fmt.Sprintf("%s, so it's a social! Raise a glass together, all %d of you.", cause, input.DrinkCount),
+	}
+
+	return &GetSocialRoundMessageOutput{
+		Title:   s.pick(ctx, titles),
+		Message: s.pick(ctx, messages),
+	}, nil
+}
+
+// GetPacingMessage returns a gentle message for a player paying drinks too quickly
+func (s *service) GetPacingMessage(ctx context.Context, input *GetPacingMessageInput) (*GetPacingMessageOutput, error) {
+	if input == nil {
+		return nil, errors.New("input cannot be nil")
+	}
+
+	titles := []string{
+		"Pace Yourself! 🐢",
+		"Hydration Check! 💧",
+		"Slow Your Roll! 🛑",
+	}
+
+	messages := []string{
+		fmt.Sprintf("**%s** has put away %d drinks in %d minutes. Maybe grab a glass of water?", input.PlayerName, input.DrinksPaid, input.WindowMinutes),
+		fmt.Sprintf("%d drinks in %d minutes, **%s**! It's a marathon, not a sprint.", input.DrinksPaid, input.WindowMinutes, input.PlayerName),
+		fmt.Sprintf("Whoa, **%s**! %d drinks in %d minutes is a lot. The dice will wait for you.", input.PlayerName, input.DrinksPaid, input.WindowMinutes),
+	}
+
+	message := s.pick(ctx, messages)
+	if input.CooldownMinutes > 0 {
+		message += fmt.Sprintf(" No more paying drinks for %d minutes.", input.CooldownMinutes)
+	}
+
+	return &GetPacingMessageOutput{
+		Title:   s.pick(ctx, titles),
+		Message: message,
+	}, nil
+}
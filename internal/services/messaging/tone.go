@@ -0,0 +1,24 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// toneKey is the context key for the tone messages are written in
+type toneKey struct{}
+
+// WithTone returns a context whose messages are written in the given tone
+func WithTone(ctx context.Context, tone models.MessageTone) context.Context {
+	return context.WithValue(ctx, toneKey{}, tone)
+}
+
+// contextTone returns the tone the context's messages are written in, the fallback when it doesn't set one
+func contextTone(ctx context.Context, fallback MessageTone) MessageTone {
+	tone, ok := ctx.Value(toneKey{}).(models.MessageTone)
+	if !ok || tone == "" {
+		return fallback
+	}
+	return MessageTone(tone)
+}
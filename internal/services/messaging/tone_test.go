@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ToneTestSuite struct {
+	suite.Suite
+	messagingService Service
+}
+
+func (s *ToneTestSuite) SetupTest() {
+	svc, err := NewService(&ServiceConfig{})
+	s.Require().NoError(err)
+	s.messagingService = svc
+}
+
+func TestToneTestSuite(t *testing.T) {
+	suite.Run(t, new(ToneTestSuite))
+}
+
+func (s *ToneTestSuite) TestContextToneIsTheDefault() {
+	ctx := WithTone(context.Background(), models.ToneSarcastic)
+
+	output, err := s.messagingService.GetRollWhisperMessage(ctx, &GetRollWhisperMessageInput{PlayerName: "Alice", RollValue: 3})
+	s.Require().NoError(err)
+	s.Equal(ToneSarcastic, output.Tone)
+
+	// A tone asked for still wins
+	output, err = s.messagingService.GetRollWhisperMessage(ctx, &GetRollWhisperMessageInput{
+		PlayerName:    "Alice",
+		RollValue:     3,
+		PreferredTone: ToneFunny,
+	})
+	s.Require().NoError(err)
+	s.Equal(ToneFunny, output.Tone)
+}
+
+func (s *ToneTestSuite) TestNeutralToneLeavesOutRollComments() {
+	input := &GetRollCommentInput{PlayerName: "Alice", RollValue: 6, IsCriticalHit: true}
+
+	output, err := s.messagingService.GetRollComment(WithTone(context.Background(), models.ToneNeutral), input)
+	s.Require().NoError(err)
+	s.Empty(output.Comment)
+
+	output, err = s.messagingService.GetRollComment(context.Background(), input)
+	s.Require().NoError(err)
+	s.NotEmpty(output.Comment)
+}
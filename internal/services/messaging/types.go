@@ -1,6 +1,10 @@
 package messaging
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
 	"github.com/KirkDiggler/ronnied/internal/models"
 )
 
@@ -68,7 +72,7 @@ type GetJoinGameMessageOutput struct {
 // GetJoinGameErrorMessageInput is the input for GetJoinGameErrorMessage
 type GetJoinGameErrorMessageInput struct {
 	PlayerName string
-	ErrorType  string
+	ErrorType  ErrorType
 	Tone       MessageTone
 }
 
@@ -97,6 +101,7 @@ type GetRollResultMessageInput struct {
 	IsCriticalHit    bool
 	IsCriticalFail   bool
 	IsPersonalMessage bool // Indicates if this is a personal/ephemeral message to the player
+	DiceSides        int  // Sides on the dice rolled, 6 when not set
 }
 
 // GetRollResultMessageOutput contains the output for GetRollResultMessage
@@ -119,7 +124,7 @@ type GetGameStartedMessageOutput struct {
 // GetErrorMessageInput contains parameters for getting an error message
 type GetErrorMessageInput struct {
 	// ErrorType is the type of error
-	ErrorType string
+	ErrorType ErrorType
 	
 	// PreferredTone is the preferred tone for the message (optional)
 	PreferredTone MessageTone
@@ -148,6 +153,9 @@ type GetRollWhisperMessageInput struct {
 	// IsCriticalFail indicates if the roll was a critical fail (e.g., 1)
 	IsCriticalFail bool
 	
+	// DiceSides is the number of sides on the dice rolled, 6 when not set
+	DiceSides int
+	
 	// PreferredTone is the preferred tone for the message (optional)
 	PreferredTone MessageTone
 }
@@ -174,6 +182,134 @@ type GetLeaderboardMessageOutput struct {
 	Message string
 }
 
+// GetMercyRuleMessageInput contains parameters for getting a mercy rule message
+type GetMercyRuleMessageInput struct {
+	// PlayerName is the name of the player spared by the mercy rule
+	PlayerName string
+
+	// DrinkCap is the session drink cap the player reached
+	DrinkCap int
+}
+
+// GetMercyRuleMessageOutput contains the output for a mercy rule message
+type GetMercyRuleMessageOutput struct {
+	// Title is the title of the message
+	Title string
+
+	// Message is the body of the message
+	Message string
+}
+
+// GetSocialRoundMessageInput contains parameters for getting a social round message
+type GetSocialRoundMessageInput struct {
+	// PlayerName is the name of the player who rolled or called the social round
+	PlayerName string
+
+	// RollValue is the roll that set off the social round, zero when it was called
+	RollValue int
+
+	// DrinkCount is how many players drink
+	DrinkCount int
+}
+
+// GetSocialRoundMessageOutput contains the output for a social round message
+type GetSocialRoundMessageOutput struct {
+	// Title is the title of the message
+	Title string
+
+	// Message is the body of the message
+	Message string
+}
+
+// GetPacingMessageInput contains parameters for getting a pacing warning message
+type GetPacingMessageInput struct {
+	// PlayerName is the name of the player drinking too fast
+	PlayerName string
+
+	// DrinksPaid is the number of drinks the player paid within the pacing window
+	DrinksPaid int
+
+	// WindowMinutes is the length of the pacing window
+	WindowMinutes int
+
+	// CooldownMinutes is how long the player has to wait before paying another drink, 0 if they don't
+	CooldownMinutes int
+}
+
+// GetPacingMessageOutput contains the output for a pacing warning message
+type GetPacingMessageOutput struct {
+	// Title is the title of the message
+	Title string
+
+	// Message is the body of the message
+	Message string
+}
+
+// GetSeasonalMessageInput contains parameters for getting a seasonal event message
+type GetSeasonalMessageInput struct {
+	// EventID is the ID of the seasonal event
+	EventID string
+
+	// PlayerName is the name of the player who rolled
+	PlayerName string
+
+	// IsCriticalHit indicates if the roll was a critical hit
+	IsCriticalHit bool
+
+	// IsCriticalFail indicates if the roll was a critical fail
+	IsCriticalFail bool
+}
+
+// GetSeasonalMessageOutput contains the output for a seasonal event message
+type GetSeasonalMessageOutput struct {
+	// Message is the extra line for the roll, empty if the event has none
+	Message string
+}
+
+// GetBirthdayMessageInput contains parameters for getting a birthday message
+type GetBirthdayMessageInput struct {
+	// PlayerName is the name of the player who rolled the critical hit
+	PlayerName string
+
+	// BirthdayPlayerNames are the names of the players celebrating their birthday
+	BirthdayPlayerNames []string
+}
+
+// GetBirthdayMessageOutput contains the output for a birthday message
+type GetBirthdayMessageOutput struct {
+	// Message is the celebratory line, empty if nobody is celebrating
+	Message string
+}
+
+// GetDrinkReminderMessageInput contains parameters for getting a drink reminder
+type GetDrinkReminderMessageInput struct {
+	// PlayerName is the name of the player being reminded
+	PlayerName string
+
+	// DrinkCount is how many drinks the player owes
+	DrinkCount int
+
+	// Reminder is how many times the player has been reminded about these drinks, counting this one
+	Reminder int
+}
+
+// GetDrinkReminderMessageOutput contains the output for a drink reminder
+type GetDrinkReminderMessageOutput struct {
+	Title   string
+	Message string
+}
+
+// SetDefaultContentRatingInput contains parameters for changing the default content rating
+type SetDefaultContentRatingInput struct {
+	// Rating is the new default, empty goes back to R
+	Rating models.ContentRating
+}
+
+// SetDefaultContentRatingOutput contains the new default content rating
+type SetDefaultContentRatingOutput struct {
+	Rating models.ContentRating
+}
+
 // GetPayDrinkMessageInput contains parameters for getting a pay drink message
 type GetPayDrinkMessageInput struct {
 	// PlayerName is the name of the player paying the drink
@@ -200,10 +336,10 @@ type GetRollCommentInput struct {
 	// RollValue is the value of the roll
 	RollValue int
 	
-	// IsCriticalHit indicates if the roll was a critical hit (6)
+	// IsCriticalHit indicates if the roll was a critical hit
 	IsCriticalHit bool
 	
-	// IsCriticalFail indicates if the roll was a critical fail (1)
+	// IsCriticalFail indicates if the roll was a critical fail
 	IsCriticalFail bool
 }
 
@@ -223,6 +359,9 @@ type GetDrinkAssignmentMessageInput struct {
 	
 	// Reason is why the drink was assigned
 	Reason models.DrinkReason
+
+	// HappyHourMultiplier is how many times the drink counted for being given during happy hour, 0 outside it
+	HappyHourMultiplier float64
 }
 
 // GetDrinkAssignmentMessageOutput contains the result of getting a drink assignment message
@@ -236,4 +375,57 @@ type ServiceConfig struct {
 	// Repository is the repository for storing and retrieving messages
 	// This is commented out for now, but can be uncommented when we add a repository
 	// Repository Repository
+
+	// Generator writes fresh roll commentary for guilds that turn it on (optional)
+	Generator Generator
+
+	// GenerationsPerMinute is how many comments a guild can have generated a minute, defaults to 10
+	GenerationsPerMinute int
+
+	// GenerationTimeout is how long to wait for a comment before using the usual lines, defaults to 3 seconds
+	GenerationTimeout time.Duration
+
+	// Clock times the generation rate limit (optional, defaults to the system clock)
+	Clock clock.Clock
+
+	// CriticalHitValues are the roll values messages name as critical hits, defaults to 6
+	CriticalHitValues []int
+}
+
+// GenerateRollCommentInput contains the roll a generator comments on
+type GenerateRollCommentInput struct {
+	PlayerName        string
+	RollValue         int
+	IsCriticalHit     bool
+	IsCriticalFail    bool
+	IsPersonalMessage bool
+
+	// DiceSides is the number of sides on the dice rolled, left out of the prompt when not set
+	DiceSides int
+
+	// Rating is how tame the comment has to be
+	Rating models.ContentRating
+
+	// Tone is the voice the comment is written in, the generator's own when empty
+	Tone MessageTone
+}
+
+// GenerateRollCommentOutput contains a generated roll comment
+type GenerateRollCommentOutput struct {
+	Comment string
+}
+
+// LLMGeneratorConfig contains configuration for the LLM roll comment generator
+type LLMGeneratorConfig struct {
+	// Endpoint is the URL of an OpenAI-compatible chat completions endpoint
+	Endpoint string
+
+	// APIKey is sent as a bearer token when set
+	APIKey string
+
+	// Model is the model to ask, if the endpoint needs one
+	Model string
+
+	// HTTPClient calls the endpoint (optional, defaults to a client with a 3 second timeout)
+	HTTPClient *http.Client
 }
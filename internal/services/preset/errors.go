@@ -0,0 +1,21 @@
+package preset
+
+// PresetError is a custom error type for preset-related errors
+type PresetError string
+
+// Error implements the error interface
+func (e PresetError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig         PresetError = "config cannot be nil"
+	ErrNilPresetRepo     PresetError = "preset repository cannot be nil"
+	ErrNilClock          PresetError = "clock cannot be nil"
+	ErrInvalidInput      PresetError = "invalid input"
+	ErrInvalidPresetName PresetError = "preset names are 1-32 letters, numbers, dashes or underscores"
+	ErrInvalidRules      PresetError = "invalid preset rules"
+	ErrTooManyPresets    PresetError = "too many presets saved for this guild"
+	ErrPresetNotFound    PresetError = "preset not found"
+)
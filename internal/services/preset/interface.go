@@ -0,0 +1,20 @@
+package preset
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_service.go github.com/KirkDiggler/ronnied/internal/services/preset Service
+
+import "context"
+
+// Service manages the named game presets each guild can start games with
+type Service interface {
+	// SavePreset saves a preset for a guild, replacing any preset with the same name
+	SavePreset(ctx context.Context, input *SavePresetInput) (*SavePresetOutput, error)
+
+	// GetPreset returns a guild's preset by name
+	GetPreset(ctx context.Context, input *GetPresetInput) (*GetPresetOutput, error)
+
+	// ListPresets returns all presets saved for a guild
+	ListPresets(ctx context.Context, input *ListPresetsInput) (*ListPresetsOutput, error)
+
+	// RemovePreset removes a preset from a guild
+	RemovePreset(ctx context.Context, input *RemovePresetInput) (*RemovePresetOutput, error)
+}
@@ -0,0 +1,275 @@
+package preset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	presetRepo "github.com/KirkDiggler/ronnied/internal/repositories/preset"
+)
+
+const (
+	// maxDiceSides is the largest die a preset may use
+	maxDiceSides = 100
+
+	// maxPlayers is the largest game a preset may allow
+	maxPlayers = 25
+)
+
+// presetNamePattern matches valid preset names, short single words so they are easy to type
+var presetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// service implements the Service interface
+type service struct {
+	// Configuration parameters
+	maxPresetsPerGuild int
+
+	// Repository dependencies
+	presetRepo presetRepo.Repository
+
+	// Service dependencies
+	clock clock.Clock
+}
+
+// New creates a new preset service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.PresetRepo == nil {
+		return nil, ErrNilPresetRepo
+	}
+
+	if cfg.Clock == nil {
+		return nil, ErrNilClock
+	}
+
+	// Set default values for configuration parameters if not provided
+	maxPresetsPerGuild := cfg.MaxPresetsPerGuild
+	if maxPresetsPerGuild <= 0 {
+		maxPresetsPerGuild = 10
+	}
+
+	return &service{
+		maxPresetsPerGuild: maxPresetsPerGuild,
+		presetRepo:         cfg.PresetRepo,
+		clock:              cfg.Clock,
+	}, nil
+}
+
+// SavePreset saves a preset for a guild, replacing any preset with the same name
+func (s *service) SavePreset(ctx context.Context, input *SavePresetInput) (*SavePresetOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if !presetNamePattern.MatchString(input.Name) {
+		return nil, ErrInvalidPresetName
+	}
+
+	rules, err := normalizeRules(input.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.presetRepo.GetPresetsForGuild(ctx, &presetRepo.GetPresetsForGuildInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Replacing a preset doesn't count against the per-guild limit, names are case insensitive
+	replaced := false
+	for _, preset := range existing.Presets {
+		if strings.EqualFold(preset.Name, input.Name) {
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced && len(existing.Presets) >= s.maxPresetsPerGuild {
+		return nil, ErrTooManyPresets
+	}
+
+	preset := &models.Preset{
+		Name:      input.Name,
+		GuildID:   input.GuildID,
+		Rules:     rules,
+		CreatedBy: input.CreatedBy,
+		CreatedAt: s.clock.Now(),
+	}
+
+	err = s.presetRepo.SavePreset(ctx, &presetRepo.SavePresetInput{
+		Preset: preset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SavePresetOutput{
+		Preset:   preset,
+		Replaced: replaced,
+	}, nil
+}
+
+// GetPreset returns a guild's preset by name, or the built-in preset with the name if the guild hasn't saved one
+func (s *service) GetPreset(ctx context.Context, input *GetPresetInput) (*GetPresetOutput, error) {
+	if input == nil || input.GuildID == "" || input.Name == "" {
+		return nil, ErrInvalidInput
+	}
+
+	preset, err := s.presetRepo.GetPreset(ctx, &presetRepo.GetPresetInput{
+		GuildID: input.GuildID,
+		Name:    input.Name,
+	})
+	if err != nil {
+		if errors.Is(err, presetRepo.ErrPresetNotFound) {
+			if builtin := models.BuiltinPreset(input.Name); builtin != nil {
+				return &GetPresetOutput{
+					Preset: builtinFor(builtin, input.GuildID),
+				}, nil
+			}
+			return nil, ErrPresetNotFound
+		}
+		return nil, err
+	}
+
+	return &GetPresetOutput{
+		Preset: preset,
+	}, nil
+}
+
+// ListPresets returns all presets saved for a guild, followed by the built-in presets it hasn't replaced
+func (s *service) ListPresets(ctx context.Context, input *ListPresetsInput) (*ListPresetsOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	output, err := s.presetRepo.GetPresetsForGuild(ctx, &presetRepo.GetPresetsForGuildInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	presets := output.Presets
+	for _, builtin := range models.BuiltinPresets {
+		replaced := slices.ContainsFunc(presets, func(preset *models.Preset) bool {
+			return strings.EqualFold(preset.Name, builtin.Name)
+		})
+		if !replaced {
+			presets = append(presets, builtinFor(builtin, input.GuildID))
+		}
+	}
+
+	return &ListPresetsOutput{
+		Presets: presets,
+	}, nil
+}
+
+// builtinFor returns a copy of a built-in preset for a guild
+func builtinFor(builtin *models.Preset, guildID string) *models.Preset {
+	preset := *builtin
+	preset.GuildID = guildID
+	preset.Rules.CriticalHitValues = slices.Clone(builtin.Rules.CriticalHitValues)
+	preset.Rules.CriticalFailValues = slices.Clone(builtin.Rules.CriticalFailValues)
+	return &preset
+}
+
+// RemovePreset removes a preset from a guild
+func (s *service) RemovePreset(ctx context.Context, input *RemovePresetInput) (*RemovePresetOutput, error) {
+	if input == nil || input.GuildID == "" || input.Name == "" {
+		return nil, ErrInvalidInput
+	}
+
+	err := s.presetRepo.DeletePreset(ctx, &presetRepo.DeletePresetInput{
+		GuildID: input.GuildID,
+		Name:    input.Name,
+	})
+	if err != nil {
+		if errors.Is(err, presetRepo.ErrPresetNotFound) {
+			return nil, ErrPresetNotFound
+		}
+		return nil, err
+	}
+
+	return &RemovePresetOutput{
+		Success: true,
+	}, nil
+}
+
+// normalizeRules validates preset rules and fills in the critical values for custom dice.
+// A preset with custom dice but no critical values uses the highest side as the hit and 1 as the fail.
+func normalizeRules(rules models.GameRules) (models.GameRules, error) {
+	if rules.DiceSides < 0 || rules.MaxPlayers < 0 {
+		return rules, fmt.Errorf("%w: values cannot be negative", ErrInvalidRules)
+	}
+
+	for _, value := range append(slices.Clone(rules.CriticalHitValues), rules.CriticalFailValues...) {
+		if value < 1 {
+			return rules, fmt.Errorf("%w: critical values must be at least 1", ErrInvalidRules)
+		}
+	}
+
+	for _, value := range rules.SocialValues {
+		if value < 1 {
+			return rules, fmt.Errorf("%w: social values must be at least 1", ErrInvalidRules)
+		}
+		if rules.DiceSides != 0 && value > rules.DiceSides {
+			return rules, fmt.Errorf("%w: social values must be on a %d-sided die", ErrInvalidRules, rules.DiceSides)
+		}
+	}
+
+	if rules.IsZero() {
+		return rules, fmt.Errorf("%w: a preset must change at least one rule", ErrInvalidRules)
+	}
+
+	if rules.DiceSides != 0 && (rules.DiceSides < 2 || rules.DiceSides > maxDiceSides) {
+		return rules, fmt.Errorf("%w: dice must have between 2 and %d sides", ErrInvalidRules, maxDiceSides)
+	}
+
+	if !models.ValidRollMode(rules.RollMode) {
+		return rules, fmt.Errorf("%w: rolls are made normally, with advantage or with disadvantage", ErrInvalidRules)
+	}
+
+	if rules.MaxPlayers > maxPlayers {
+		return rules, fmt.Errorf("%w: at most %d players", ErrInvalidRules, maxPlayers)
+	}
+
+	if rules.DiceSides != 0 {
+		if len(rules.CriticalHitValues) == 0 {
+			rules.CriticalHitValues = []int{rules.DiceSides}
+		}
+		if len(rules.CriticalFailValues) == 0 {
+			rules.CriticalFailValues = []int{1}
+		}
+
+		for _, value := range append(slices.Clone(rules.CriticalHitValues), rules.CriticalFailValues...) {
+			if value > rules.DiceSides {
+				return rules, fmt.Errorf("%w: critical values must be on a %d-sided die", ErrInvalidRules, rules.DiceSides)
+			}
+		}
+	}
+
+	for _, value := range rules.CriticalHitValues {
+		if rules.IsCriticalFail(value) {
+			return rules, fmt.Errorf("%w: %d can't be both a critical hit and a critical fail", ErrInvalidRules, value)
+		}
+	}
+
+	for _, value := range rules.SocialValues {
+		if rules.IsCriticalHit(value) || rules.IsCriticalFail(value) {
+			return rules, fmt.Errorf("%w: %d can't be both critical and a social", ErrInvalidRules, value)
+		}
+	}
+
+	return rules, nil
+}
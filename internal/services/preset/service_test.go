@@ -0,0 +1,228 @@
+package preset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	presetRepo "github.com/KirkDiggler/ronnied/internal/repositories/preset"
+	presetMocks "github.com/KirkDiggler/ronnied/internal/repositories/preset/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type PresetServiceTestSuite struct {
+	suite.Suite
+	mockCtrl       *gomock.Controller
+	mockPresetRepo *presetMocks.MockRepository
+	mockClock      *mocks.MockClock
+	presetService  Service
+	ctx            context.Context
+
+	// Test data
+	testTime    time.Time
+	testGuildID string
+}
+
+func (s *PresetServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockPresetRepo = presetMocks.NewMockRepository(s.mockCtrl)
+	s.mockClock = mocks.NewMockClock(s.mockCtrl)
+	s.ctx = context.Background()
+
+	s.testTime = time.Date(2025, 4, 19, 12, 0, 0, 0, time.UTC)
+	s.testGuildID = "test-guild-id"
+
+	s.mockClock.EXPECT().Now().Return(s.testTime).AnyTimes()
+
+	svc, err := New(&Config{
+		PresetRepo: s.mockPresetRepo,
+		Clock:      s.mockClock,
+	})
+	s.Require().NoError(err)
+	s.presetService = svc
+}
+
+func (s *PresetServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestPresetServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PresetServiceTestSuite))
+}
+
+func (s *PresetServiceTestSuite) TestSavePreset() {
+	s.mockPresetRepo.EXPECT().
+		GetPresetsForGuild(s.ctx, &presetRepo.GetPresetsForGuildInput{GuildID: s.testGuildID}).
+		Return(&presetRepo.GetPresetsForGuildOutput{}, nil)
+	s.mockPresetRepo.EXPECT().
+		SavePreset(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input *presetRepo.SavePresetInput) error {
+			s.Equal("quickies", input.Preset.Name)
+			s.Equal(s.testGuildID, input.Preset.GuildID)
+			s.Equal(s.testTime, input.Preset.CreatedAt)
+			return nil
+		})
+
+	output, err := s.presetService.SavePreset(s.ctx, &SavePresetInput{
+		GuildID:   s.testGuildID,
+		Name:      "quickies",
+		Rules:     models.GameRules{DiceSides: 4, MaxPlayers: 4},
+		CreatedBy: "admin",
+	})
+	s.Require().NoError(err)
+	s.False(output.Replaced)
+
+	// Custom dice get critical values on the new die
+	s.Equal(models.GameRules{DiceSides: 4, MaxPlayers: 4, CriticalHitValues: []int{4}, CriticalFailValues: []int{1}}, output.Preset.Rules)
+}
+
+func (s *PresetServiceTestSuite) TestSavePreset_ReplaceAtLimit() {
+	existing := []*models.Preset{{Name: "Quickies"}}
+	for len(existing) < 10 {
+		existing = append(existing, &models.Preset{Name: "other"})
+	}
+
+	s.mockPresetRepo.EXPECT().
+		GetPresetsForGuild(s.ctx, gomock.Any()).
+		Return(&presetRepo.GetPresetsForGuildOutput{Presets: existing}, nil)
+	s.mockPresetRepo.EXPECT().
+		SavePreset(s.ctx, gomock.Any()).
+		Return(nil)
+
+	output, err := s.presetService.SavePreset(s.ctx, &SavePresetInput{
+		GuildID: s.testGuildID,
+		Name:    "quickies",
+		Rules:   models.GameRules{MaxPlayers: 4},
+	})
+	s.Require().NoError(err)
+	s.True(output.Replaced)
+}
+
+func (s *PresetServiceTestSuite) TestSavePreset_LimitReached() {
+	existing := make([]*models.Preset, 10)
+	for i := range existing {
+		existing[i] = &models.Preset{Name: "other"}
+	}
+
+	s.mockPresetRepo.EXPECT().
+		GetPresetsForGuild(s.ctx, gomock.Any()).
+		Return(&presetRepo.GetPresetsForGuildOutput{Presets: existing}, nil)
+
+	_, err := s.presetService.SavePreset(s.ctx, &SavePresetInput{
+		GuildID: s.testGuildID,
+		Name:    "quickies",
+		Rules:   models.GameRules{MaxPlayers: 4},
+	})
+	s.ErrorIs(err, ErrTooManyPresets)
+}
+
+func (s *PresetServiceTestSuite) TestSavePreset_InvalidName() {
+	_, err := s.presetService.SavePreset(s.ctx, &SavePresetInput{
+		GuildID: s.testGuildID,
+		Name:    "two words",
+		Rules:   models.GameRules{MaxPlayers: 4},
+	})
+	s.ErrorIs(err, ErrInvalidPresetName)
+}
+
+func (s *PresetServiceTestSuite) TestSavePreset_InvalidRules() {
+	tests := []struct {
+		name  string
+		rules models.GameRules
+	}{
+		{name: "no rules", rules: models.GameRules{}},
+		{name: "negative", rules: models.GameRules{MaxPlayers: -1}},
+		{name: "one sided die", rules: models.GameRules{DiceSides: 1}},
+		{name: "huge die", rules: models.GameRules{DiceSides: 1000}},
+		{name: "too many players", rules: models.GameRules{MaxPlayers: 100}},
+		{name: "crit off the die", rules: models.GameRules{DiceSides: 4, CriticalHitValues: []int{3, 6}}},
+		{name: "same crits", rules: models.GameRules{CriticalHitValues: []int{3}, CriticalFailValues: []int{3}}},
+		{name: "overlapping crits", rules: models.GameRules{CriticalHitValues: []int{5, 6}, CriticalFailValues: []int{1, 5}}},
+		{name: "zero crit", rules: models.GameRules{CriticalFailValues: []int{0}}},
+		{name: "unknown roll mode", rules: models.GameRules{RollMode: models.RollModifierBonus}},
+		{name: "social off the die", rules: models.GameRules{DiceSides: 4, SocialValues: []int{5}}},
+		{name: "critical social", rules: models.GameRules{DiceSides: 6, SocialValues: []int{6}}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.presetService.SavePreset(s.ctx, &SavePresetInput{
+				GuildID: s.testGuildID,
+				Name:    "broken",
+				Rules:   tt.rules,
+			})
+			s.ErrorIs(err, ErrInvalidRules)
+		})
+	}
+}
+
+func (s *PresetServiceTestSuite) TestGetPreset_NotFound() {
+	s.mockPresetRepo.EXPECT().
+		GetPreset(s.ctx, &presetRepo.GetPresetInput{GuildID: s.testGuildID, Name: "missing"}).
+		Return(nil, presetRepo.ErrPresetNotFound)
+
+	_, err := s.presetService.GetPreset(s.ctx, &GetPresetInput{
+		GuildID: s.testGuildID,
+		Name:    "missing",
+	})
+	s.ErrorIs(err, ErrPresetNotFound)
+}
+
+func (s *PresetServiceTestSuite) TestGetPreset_Builtin() {
+	s.mockPresetRepo.EXPECT().
+		GetPreset(s.ctx, &presetRepo.GetPresetInput{GuildID: s.testGuildID, Name: "D20"}).
+		Return(nil, presetRepo.ErrPresetNotFound)
+
+	output, err := s.presetService.GetPreset(s.ctx, &GetPresetInput{
+		GuildID: s.testGuildID,
+		Name:    "D20",
+	})
+	s.Require().NoError(err)
+	s.Equal("d20", output.Preset.Name)
+	s.Equal(s.testGuildID, output.Preset.GuildID)
+	s.Equal(20, output.Preset.Rules.DiceSides)
+	s.Equal([]int{20}, output.Preset.Rules.CriticalHitValues)
+	s.Equal([]int{1}, output.Preset.Rules.CriticalFailValues)
+
+	// Changing the copy leaves the built-in alone
+	output.Preset.Rules.CriticalHitValues[0] = 19
+	s.Equal([]int{20}, models.BuiltinPreset("d20").Rules.CriticalHitValues)
+}
+
+func (s *PresetServiceTestSuite) TestListPresets_Builtins() {
+	saved := &models.Preset{GuildID: s.testGuildID, Name: "quick", Rules: models.GameRules{MaxPlayers: 4}}
+	s.mockPresetRepo.EXPECT().
+		GetPresetsForGuild(s.ctx, &presetRepo.GetPresetsForGuildInput{GuildID: s.testGuildID}).
+		Return(&presetRepo.GetPresetsForGuildOutput{Presets: []*models.Preset{saved}}, nil)
+
+	output, err := s.presetService.ListPresets(s.ctx, &ListPresetsInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Require().Len(output.Presets, 2)
+	s.Equal(saved, output.Presets[0])
+	s.Equal("d20", output.Presets[1].Name)
+
+	// A guild's own d20 preset replaces the built-in one
+	own := &models.Preset{GuildID: s.testGuildID, Name: "d20", Rules: models.GameRules{DiceSides: 20, MaxPlayers: 4}}
+	s.mockPresetRepo.EXPECT().
+		GetPresetsForGuild(s.ctx, &presetRepo.GetPresetsForGuildInput{GuildID: s.testGuildID}).
+		Return(&presetRepo.GetPresetsForGuildOutput{Presets: []*models.Preset{own}}, nil)
+
+	output, err = s.presetService.ListPresets(s.ctx, &ListPresetsInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.Equal([]*models.Preset{own}, output.Presets)
+}
+
+func (s *PresetServiceTestSuite) TestRemovePreset_NotFound() {
+	s.mockPresetRepo.EXPECT().
+		DeletePreset(s.ctx, &presetRepo.DeletePresetInput{GuildID: s.testGuildID, Name: "missing"}).
+		Return(presetRepo.ErrPresetNotFound)
+
+	_, err := s.presetService.RemovePreset(s.ctx, &RemovePresetInput{
+		GuildID: s.testGuildID,
+		Name:    "missing",
+	})
+	s.ErrorIs(err, ErrPresetNotFound)
+}
@@ -0,0 +1,67 @@
+package preset
+
+import (
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	presetRepo "github.com/KirkDiggler/ronnied/internal/repositories/preset"
+)
+
+// Config holds configuration for the preset service
+type Config struct {
+	// Maximum number of presets a single guild may save
+	MaxPresetsPerGuild int
+
+	// Repository dependencies
+	PresetRepo presetRepo.Repository
+
+	// Service dependencies
+	Clock clock.Clock
+}
+
+// SavePresetInput defines the input for saving a preset
+type SavePresetInput struct {
+	GuildID   string
+	Name      string
+	Rules     models.GameRules
+	CreatedBy string
+}
+
+// SavePresetOutput defines the output for saving a preset
+type SavePresetOutput struct {
+	Preset *models.Preset
+
+	// Replaced is true if an existing preset with the same name was overwritten
+	Replaced bool
+}
+
+// GetPresetInput defines the input for getting a preset
+type GetPresetInput struct {
+	GuildID string
+	Name    string
+}
+
+// GetPresetOutput defines the output for getting a preset
+type GetPresetOutput struct {
+	Preset *models.Preset
+}
+
+// ListPresetsInput defines the input for listing a guild's presets
+type ListPresetsInput struct {
+	GuildID string
+}
+
+// ListPresetsOutput defines the output for listing a guild's presets
+type ListPresetsOutput struct {
+	Presets []*models.Preset
+}
+
+// RemovePresetInput defines the input for removing a preset
+type RemovePresetInput struct {
+	GuildID string
+	Name    string
+}
+
+// RemovePresetOutput defines the output for removing a preset
+type RemovePresetOutput struct {
+	Success bool
+}
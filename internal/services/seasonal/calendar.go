@@ -0,0 +1,93 @@
+package seasonal
+
+import (
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/models"
+)
+
+// Event IDs
+const (
+	EventHalloween         = "halloween"
+	EventStPatricks        = "st_patricks"
+	EventDoubleDrinkFriday = "double_drink_friday"
+)
+
+// calendar is every seasonal event the bot knows about
+var calendar = []*Event{
+	{
+		ID:        EventHalloween,
+		Name:      "Halloween",
+		Emoji:     "🎃",
+		DiceEmoji: "🎃",
+		activeOn: func(t time.Time) bool {
+			return t.Month() == time.October && t.Day() >= 24
+		},
+	},
+	{
+		ID:        EventStPatricks,
+		Name:      "St. Patrick's Day",
+		Emoji:     "☘️",
+		DiceEmoji: "🍀",
+		RollModifier: &models.RollModifier{
+			Type:   models.RollModifierAdvantage,
+			Rolls:  1,
+			Source: "Luck of the Irish",
+		},
+		activeOn: func(t time.Time) bool {
+			return t.Month() == time.March && t.Day() == 17
+		},
+	},
+	{
+		ID:              EventDoubleDrinkFriday,
+		Name:            "Double-Drink Friday",
+		Emoji:           "🍻",
+		DrinkMultiplier: 2,
+		activeOn: func(t time.Time) bool {
+			return t.Weekday() == time.Friday
+		},
+	},
+}
+
+// Lookup returns the event with the given ID, or nil if there isn't one
+func Lookup(id string) *Event {
+	for _, event := range calendar {
+		if event.ID == id {
+			return event
+		}
+	}
+	return nil
+}
+
+// eventsActiveOn returns the events running at the given time
+func eventsActiveOn(t time.Time) []*Event {
+	var active []*Event
+	for _, event := range calendar {
+		if event.activeOn(t) {
+			active = append(active, event)
+		}
+	}
+	return active
+}
+
+// DrinkMultiplier returns how many times each drink counts in a game running the given events
+func DrinkMultiplier(eventIDs []string) int {
+	multiplier := 1
+	for _, id := range eventIDs {
+		if event := Lookup(id); event != nil && event.DrinkMultiplier > multiplier {
+			multiplier = event.DrinkMultiplier
+		}
+	}
+	return multiplier
+}
+
+// RollModifiers returns the modifiers applied to every roll in a game running the given events
+func RollModifiers(eventIDs []string) []*models.RollModifier {
+	var modifiers []*models.RollModifier
+	for _, id := range eventIDs {
+		if event := Lookup(id); event != nil && event.RollModifier != nil {
+			modifiers = append(modifiers, event.RollModifier)
+		}
+	}
+	return modifiers
+}
@@ -0,0 +1,17 @@
+package seasonal
+
+// SeasonalError is a custom error type for seasonal event errors
+type SeasonalError string
+
+// Error implements the error interface
+func (e SeasonalError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig       SeasonalError = "config cannot be nil"
+	ErrNilSeasonalRepo SeasonalError = "seasonal repository cannot be nil"
+	ErrNilClock        SeasonalError = "clock cannot be nil"
+	ErrInvalidInput    SeasonalError = "invalid input"
+)
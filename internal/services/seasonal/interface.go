@@ -0,0 +1,14 @@
+package seasonal
+
+//go:generate mockgen -package=mocks -destination=mocks/mock_service.go github.com/KirkDiggler/ronnied/internal/services/seasonal Service
+
+import "context"
+
+// Service decides which seasonal events are running for each guild
+type Service interface {
+	// GetActiveEvents returns the seasonal events running for a guild right now
+	GetActiveEvents(ctx context.Context, input *GetActiveEventsInput) (*GetActiveEventsOutput, error)
+
+	// SetOptOut opts a guild out of, or back into, seasonal events
+	SetOptOut(ctx context.Context, input *SetOptOutInput) (*SetOptOutOutput, error)
+}
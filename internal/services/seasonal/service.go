@@ -0,0 +1,91 @@
+package seasonal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	seasonalRepo "github.com/KirkDiggler/ronnied/internal/repositories/seasonal"
+)
+
+// service implements the Service interface
+type service struct {
+	// Configuration parameters
+	location *time.Location
+
+	// Repository dependencies
+	seasonalRepo seasonalRepo.Repository
+
+	// Service dependencies
+	clock clock.Clock
+}
+
+// New creates a new seasonal service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.SeasonalRepo == nil {
+		return nil, ErrNilSeasonalRepo
+	}
+
+	if cfg.Clock == nil {
+		return nil, ErrNilClock
+	}
+
+	location := cfg.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	return &service{
+		location:     location,
+		seasonalRepo: cfg.SeasonalRepo,
+		clock:        cfg.Clock,
+	}, nil
+}
+
+// GetActiveEvents returns the seasonal events running for a guild right now
+func (s *service) GetActiveEvents(ctx context.Context, input *GetActiveEventsInput) (*GetActiveEventsOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	optedOut, err := s.seasonalRepo.IsOptedOut(ctx, &seasonalRepo.IsOptedOutInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check opt-out: %w", err)
+	}
+
+	if optedOut {
+		return &GetActiveEventsOutput{
+			OptedOut: true,
+		}, nil
+	}
+
+	return &GetActiveEventsOutput{
+		Events: eventsActiveOn(s.clock.Now().In(s.location)),
+	}, nil
+}
+
+// SetOptOut opts a guild out of, or back into, seasonal events
+func (s *service) SetOptOut(ctx context.Context, input *SetOptOutInput) (*SetOptOutOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	if err := s.seasonalRepo.SetOptOut(ctx, &seasonalRepo.SetOptOutInput{
+		GuildID: input.GuildID,
+		OptOut:  input.OptOut,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to save opt-out: %w", err)
+	}
+
+	return &SetOptOutOutput{
+		Success: true,
+	}, nil
+}
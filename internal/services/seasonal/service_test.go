@@ -0,0 +1,125 @@
+package seasonal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	seasonalRepo "github.com/KirkDiggler/ronnied/internal/repositories/seasonal"
+	seasonalMocks "github.com/KirkDiggler/ronnied/internal/repositories/seasonal/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type SeasonalServiceTestSuite struct {
+	suite.Suite
+	mockCtrl         *gomock.Controller
+	mockSeasonalRepo *seasonalMocks.MockRepository
+	mockClock        *mocks.MockClock
+	seasonalService  Service
+	ctx              context.Context
+
+	// Test data
+	testGuildID string
+}
+
+func (s *SeasonalServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockSeasonalRepo = seasonalMocks.NewMockRepository(s.mockCtrl)
+	s.mockClock = mocks.NewMockClock(s.mockCtrl)
+	s.ctx = context.Background()
+
+	s.testGuildID = "test-guild-id"
+
+	svc, err := New(&Config{
+		Location:     time.UTC,
+		SeasonalRepo: s.mockSeasonalRepo,
+		Clock:        s.mockClock,
+	})
+	s.Require().NoError(err)
+	s.seasonalService = svc
+}
+
+func (s *SeasonalServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestSeasonalServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SeasonalServiceTestSuite))
+}
+
+// activeEventIDs returns the IDs of the events running for the test guild at the given time
+func (s *SeasonalServiceTestSuite) activeEventIDs(now time.Time) []string {
+	s.mockSeasonalRepo.EXPECT().
+		IsOptedOut(s.ctx, &seasonalRepo.IsOptedOutInput{GuildID: s.testGuildID}).
+		Return(false, nil)
+	s.mockClock.EXPECT().Now().Return(now)
+
+	output, err := s.seasonalService.GetActiveEvents(s.ctx, &GetActiveEventsInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+
+	var ids []string
+	for _, event := range output.Events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func (s *SeasonalServiceTestSuite) TestCalendar() {
+	// A Thursday in spring has nothing going on
+	s.Empty(s.activeEventIDs(time.Date(2025, 4, 17, 20, 0, 0, 0, time.UTC)))
+
+	// Halloween 2025 falls on a Friday
+	s.Equal([]string{EventHalloween, EventDoubleDrinkFriday}, s.activeEventIDs(time.Date(2025, 10, 31, 20, 0, 0, 0, time.UTC)))
+
+	// St. Patrick's Day 2025 is a Monday
+	s.Equal([]string{EventStPatricks}, s.activeEventIDs(time.Date(2025, 3, 17, 20, 0, 0, 0, time.UTC)))
+}
+
+func (s *SeasonalServiceTestSuite) TestDatesUseConfiguredLocation() {
+	chicago, err := time.LoadLocation("America/Chicago")
+	s.Require().NoError(err)
+
+	svc, err := New(&Config{
+		Location:     chicago,
+		SeasonalRepo: s.mockSeasonalRepo,
+		Clock:        s.mockClock,
+	})
+	s.Require().NoError(err)
+	s.seasonalService = svc
+
+	// Early Saturday morning in UTC is still Friday night in Chicago
+	s.Equal([]string{EventDoubleDrinkFriday}, s.activeEventIDs(time.Date(2025, 4, 19, 3, 0, 0, 0, time.UTC)))
+}
+
+func (s *SeasonalServiceTestSuite) TestOptedOutGuildHasNoEvents() {
+	s.mockSeasonalRepo.EXPECT().
+		IsOptedOut(s.ctx, &seasonalRepo.IsOptedOutInput{GuildID: s.testGuildID}).
+		Return(true, nil)
+
+	output, err := s.seasonalService.GetActiveEvents(s.ctx, &GetActiveEventsInput{GuildID: s.testGuildID})
+	s.Require().NoError(err)
+	s.True(output.OptedOut)
+	s.Empty(output.Events)
+}
+
+func (s *SeasonalServiceTestSuite) TestSetOptOut() {
+	s.mockSeasonalRepo.EXPECT().
+		SetOptOut(s.ctx, &seasonalRepo.SetOptOutInput{GuildID: s.testGuildID, OptOut: true}).
+		Return(nil)
+
+	output, err := s.seasonalService.SetOptOut(s.ctx, &SetOptOutInput{GuildID: s.testGuildID, OptOut: true})
+	s.Require().NoError(err)
+	s.True(output.Success)
+}
+
+func (s *SeasonalServiceTestSuite) TestEventRules() {
+	s.Equal(2, DrinkMultiplier([]string{EventHalloween, EventDoubleDrinkFriday}))
+	s.Equal(1, DrinkMultiplier(nil))
+	s.Equal(1, DrinkMultiplier([]string{"retired-event"}))
+
+	modifiers := RollModifiers([]string{EventStPatricks, EventHalloween})
+	s.Require().Len(modifiers, 1)
+	s.Equal("Luck of the Irish", modifiers[0].Source)
+}
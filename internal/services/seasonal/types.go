@@ -0,0 +1,69 @@
+package seasonal
+
+import (
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	seasonalRepo "github.com/KirkDiggler/ronnied/internal/repositories/seasonal"
+)
+
+// Config holds configuration for the seasonal service
+type Config struct {
+	// Location is the time zone event dates are checked in (defaults to the server's zone)
+	Location *time.Location
+
+	// Repository dependencies
+	SeasonalRepo seasonalRepo.Repository
+
+	// Service dependencies
+	Clock clock.Clock
+}
+
+// Event is a date-driven theme that changes how games look and play while it runs
+type Event struct {
+	// ID identifies the event, games keep the IDs of the events they were started in
+	ID string
+
+	// Name is the display name of the event
+	Name string
+
+	// Emoji is shown alongside the event's name
+	Emoji string
+
+	// DiceEmoji replaces the dice shown for ordinary rolls, empty keeps the default
+	DiceEmoji string
+
+	// DrinkMultiplier is how many times each drink counts during the event, 0 leaves drinks alone
+	DrinkMultiplier int
+
+	// RollModifier is applied to every roll in the event's games (optional)
+	RollModifier *models.RollModifier
+
+	// activeOn returns true if the event is running at the given time
+	activeOn func(t time.Time) bool
+}
+
+// GetActiveEventsInput defines the input for getting a guild's active events
+type GetActiveEventsInput struct {
+	GuildID string
+}
+
+// GetActiveEventsOutput defines the output for getting a guild's active events
+type GetActiveEventsOutput struct {
+	Events []*Event
+
+	// OptedOut is true if the guild has opted out, in which case no events are returned
+	OptedOut bool
+}
+
+// SetOptOutInput defines the input for changing a guild's opt-out
+type SetOptOutInput struct {
+	GuildID string
+	OptOut  bool
+}
+
+// SetOptOutOutput defines the output for changing a guild's opt-out
+type SetOptOutOutput struct {
+	Success bool
+}
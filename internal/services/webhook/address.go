@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Resolver looks up the addresses of a webhook's host
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// blockedHosts are names that reach cloud metadata servers without resolving to an address checked below
+var blockedHosts = map[string]bool{
+	"localhost":                true,
+	"metadata":                 true,
+	"metadata.google.internal": true,
+}
+
+// blockedNetworks are the special purpose ranges not covered by the checks in isPublicAddress
+var blockedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT, which some clouds serve metadata from
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, which can reach any IPv4 address
+}
+
+// isPublicAddress reports whether webhooks may be sent to an address. Loopback, private, link-local (where cloud
+// metadata servers live) and other special purpose addresses are refused, so webhooks can't reach the bot's own
+// network.
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkURL checks a webhook URL is an absolute http(s) URL whose host only resolves to public addresses
+func (s *service) checkURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if blockedHosts[host] || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !isPublicAddress(addr) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: couldn't look up %s", ErrInvalidURL, host)
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+
+	return nil
+}
+
+// newHTTPClient creates the client webhooks are delivered with. The address is checked again as each connection
+// is made, so a host that resolved to a public address at registration can't be pointed at a private one later.
+// Proxies are skipped, as the check would see the proxy's address rather than the webhook's.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !isPublicAddress(addr) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package webhook
+
+// WebhookError is a custom error type for webhook-related errors
+type WebhookError string
+
+// Error implements the error interface
+func (e WebhookError) Error() string {
+	return string(e)
+}
+
+// Define errors
+const (
+	ErrNilConfig        WebhookError = "config cannot be nil"
+	ErrNilWebhookRepo   WebhookError = "webhook repository cannot be nil"
+	ErrNilClock         WebhookError = "clock cannot be nil"
+	ErrNilUUIDGenerator WebhookError = "UUID generator cannot be nil"
+	ErrInvalidInput     WebhookError = "invalid input"
+	ErrInvalidURL       WebhookError = "webhook URL must be an absolute http(s) URL"
+	ErrForbiddenAddress WebhookError = "webhook URL must not point at a private, loopback or link-local address"
+	ErrUnknownEvent     WebhookError = "unknown event type"
+	ErrTooManyWebhooks  WebhookError = "too many webhooks registered for this guild"
+	ErrWebhookNotFound  WebhookError = "webhook not found"
+)
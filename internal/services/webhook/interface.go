@@ -0,0 +1,18 @@
+package webhook
+
+import "context"
+
+// Service manages outbound webhooks and delivers events to them
+type Service interface {
+	// RegisterWebhook registers a new webhook URL for a guild
+	RegisterWebhook(ctx context.Context, input *RegisterWebhookInput) (*RegisterWebhookOutput, error)
+
+	// ListWebhooks returns all webhooks registered for a guild
+	ListWebhooks(ctx context.Context, input *ListWebhooksInput) (*ListWebhooksOutput, error)
+
+	// RemoveWebhook removes a webhook registration from a guild
+	RemoveWebhook(ctx context.Context, input *RemoveWebhookInput) (*RemoveWebhookOutput, error)
+
+	// Dispatch delivers an event to every webhook in the guild subscribed to it
+	Dispatch(ctx context.Context, input *DispatchInput) (*DispatchOutput, error)
+}
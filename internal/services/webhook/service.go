@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	webhookRepo "github.com/KirkDiggler/ronnied/internal/repositories/webhook"
+)
+
+// supportedEvents lists the event types that can be delivered to webhooks
+var supportedEvents = map[events.Type]bool{
+	events.TypeGameCompleted:      true,
+	events.TypeSessionLeaderboard: true,
+	events.TypeSessionRotated:     true,
+}
+
+// service implements the Service interface
+type service struct {
+	// Configuration parameters
+	maxWebhooksPerGuild int
+
+	// Repository dependencies
+	webhookRepo webhookRepo.Repository
+
+	// Service dependencies
+	httpClient *http.Client
+	resolver   Resolver
+	clock      clock.Clock
+	uuid       uuid.UUID
+}
+
+// New creates a new webhook service
+func New(cfg *Config) (*service, error) {
+	// Validate config
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if cfg.WebhookRepo == nil {
+		return nil, ErrNilWebhookRepo
+	}
+
+	if cfg.Clock == nil {
+		return nil, ErrNilClock
+	}
+
+	if cfg.UUIDGenerator == nil {
+		return nil, ErrNilUUIDGenerator
+	}
+
+	// Set default values for configuration parameters if not provided
+	maxWebhooksPerGuild := cfg.MaxWebhooksPerGuild
+	if maxWebhooksPerGuild <= 0 {
+		maxWebhooksPerGuild = 5
+	}
+
+	deliveryTimeout := cfg.DeliveryTimeout
+	if deliveryTimeout <= 0 {
+		deliveryTimeout = 5 * time.Second
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = newHTTPClient(deliveryTimeout)
+	}
+
+	var resolver Resolver = net.DefaultResolver
+	if cfg.Resolver != nil {
+		resolver = cfg.Resolver
+	}
+
+	return &service{
+		maxWebhooksPerGuild: maxWebhooksPerGuild,
+		webhookRepo:         cfg.WebhookRepo,
+		httpClient:          httpClient,
+		resolver:            resolver,
+		clock:               cfg.Clock,
+		uuid:                cfg.UUIDGenerator,
+	}, nil
+}
+
+// RegisterWebhook registers a new webhook URL for a guild
+func (s *service) RegisterWebhook(ctx context.Context, input *RegisterWebhookInput) (*RegisterWebhookOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	// Only accept absolute http(s) URLs on the public internet
+	if err := s.checkURL(ctx, input.URL); err != nil {
+		return nil, err
+	}
+
+	eventNames := make([]string, 0, len(input.Events))
+	for _, eventType := range input.Events {
+		if !supportedEvents[eventType] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, eventType)
+		}
+		eventNames = append(eventNames, string(eventType))
+	}
+
+	// Enforce the per-guild limit
+	existing, err := s.webhookRepo.GetWebhooksForGuild(ctx, &webhookRepo.GetWebhooksForGuildInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing.Webhooks) >= s.maxWebhooksPerGuild {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &models.Webhook{
+		ID:        s.uuid.NewUUID(),
+		GuildID:   input.GuildID,
+		URL:       input.URL,
+		Events:    eventNames,
+		Secret:    secret,
+		CreatedBy: input.CreatedBy,
+		CreatedAt: s.clock.Now(),
+	}
+
+	err = s.webhookRepo.SaveWebhook(ctx, &webhookRepo.SaveWebhookInput{
+		Webhook: webhook,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegisterWebhookOutput{
+		Webhook: webhook,
+	}, nil
+}
+
+// ListWebhooks returns all webhooks registered for a guild
+func (s *service) ListWebhooks(ctx context.Context, input *ListWebhooksInput) (*ListWebhooksOutput, error) {
+	if input == nil || input.GuildID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	output, err := s.webhookRepo.GetWebhooksForGuild(ctx, &webhookRepo.GetWebhooksForGuildInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListWebhooksOutput{
+		Webhooks: output.Webhooks,
+	}, nil
+}
+
+// RemoveWebhook removes a webhook registration from a guild
+func (s *service) RemoveWebhook(ctx context.Context, input *RemoveWebhookInput) (*RemoveWebhookOutput, error) {
+	if input == nil || input.GuildID == "" || input.WebhookID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	err := s.webhookRepo.DeleteWebhook(ctx, &webhookRepo.DeleteWebhookInput{
+		GuildID:   input.GuildID,
+		WebhookID: input.WebhookID,
+	})
+	if err != nil {
+		if errors.Is(err, webhookRepo.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &RemoveWebhookOutput{
+		Success: true,
+	}, nil
+}
+
+// Dispatch delivers an event to every webhook in the guild subscribed to it
+func (s *service) Dispatch(ctx context.Context, input *DispatchInput) (*DispatchOutput, error) {
+	if input == nil || input.GuildID == "" || input.Event == nil {
+		return nil, ErrInvalidInput
+	}
+
+	webhooks, err := s.webhookRepo.GetWebhooksForGuild(ctx, &webhookRepo.GetWebhooksForGuildInput{
+		GuildID: input.GuildID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &DispatchOutput{}
+	if len(webhooks.Webhooks) == 0 {
+		return output, nil
+	}
+
+	body, err := json.Marshal(&Payload{
+		Event:     input.Event.Type,
+		GuildID:   input.GuildID,
+		ChannelID: input.Event.ChannelID,
+		GameID:    input.Event.GameID,
+		SessionID: input.Event.SessionID,
+		Timestamp: input.Event.Timestamp,
+		Data:      input.Event.Payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	for _, webhook := range webhooks.Webhooks {
+		if !webhook.WantsEvent(string(input.Event.Type)) {
+			continue
+		}
+
+		if err := s.deliver(ctx, webhook, body); err != nil {
+			log.Printf("Error delivering %s to webhook %s: %v", input.Event.Type, webhook.ID, err)
+			output.Failed++
+			continue
+		}
+
+		output.Delivered++
+	}
+
+	return output, nil
+}
+
+// deliver POSTs a payload to a single webhook
+func (s *service) deliver(ctx context.Context, webhook *models.Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ronnied-webhooks")
+
+	// Webhooks registered before they were signed have no secret
+	if webhook.Secret != "" {
+		now := s.clock.Now()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, now, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock/mocks"
+	uuidMocks "github.com/KirkDiggler/ronnied/internal/common/uuid/mocks"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	webhookRepo "github.com/KirkDiggler/ronnied/internal/repositories/webhook"
+	webhookMocks "github.com/KirkDiggler/ronnied/internal/repositories/webhook/mocks"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeResolver resolves the hosts it has addresses for
+type fakeResolver map[string][]netip.Addr
+
+// LookupNetIP returns the host's addresses, failing for hosts it doesn't know
+func (r fakeResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockWebhookRepo *webhookMocks.MockRepository
+	mockClock       *mocks.MockClock
+	mockUUID        *uuidMocks.MockUUID
+	webhookService  Service
+	ctx             context.Context
+
+	// Test data
+	testTime    time.Time
+	testGuildID string
+}
+
+func (s *WebhookServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockWebhookRepo = webhookMocks.NewMockRepository(s.mockCtrl)
+	s.mockClock = mocks.NewMockClock(s.mockCtrl)
+	s.mockUUID = uuidMocks.NewMockUUID(s.mockCtrl)
+	s.ctx = context.Background()
+
+	s.testTime = time.Date(2025, 4, 19, 12, 0, 0, 0, time.UTC)
+	s.testGuildID = "test-guild-id"
+
+	s.mockClock.EXPECT().Now().Return(s.testTime).AnyTimes()
+
+	// The test servers listen on loopback, which the default client refuses to deliver to
+	svc, err := New(&Config{
+		WebhookRepo:   s.mockWebhookRepo,
+		HTTPClient:    &http.Client{Timeout: time.Second},
+		Resolver:      s.resolver(),
+		Clock:         s.mockClock,
+		UUIDGenerator: s.mockUUID,
+	})
+	s.Require().NoError(err)
+	s.webhookService = svc
+}
+
+func (s *WebhookServiceTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+// resolver resolves example.com to a public address and internal.example.com to a private one
+func (s *WebhookServiceTestSuite) resolver() Resolver {
+	return fakeResolver{
+		"example.com":          {netip.MustParseAddr("93.184.216.34")},
+		"internal.example.com": {netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("192.168.1.10")},
+	}
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
+
+func (s *WebhookServiceTestSuite) TestRegisterWebhook() {
+	s.mockWebhookRepo.EXPECT().
+		GetWebhooksForGuild(s.ctx, &webhookRepo.GetWebhooksForGuildInput{GuildID: s.testGuildID}).
+		Return(&webhookRepo.GetWebhooksForGuildOutput{}, nil)
+	s.mockUUID.EXPECT().NewUUID().Return("webhook-1")
+	s.mockWebhookRepo.EXPECT().
+		SaveWebhook(s.ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, input *webhookRepo.SaveWebhookInput) error {
+			s.Equal("webhook-1", input.Webhook.ID)
+			s.Equal(s.testGuildID, input.Webhook.GuildID)
+			s.Equal([]string{string(events.TypeGameCompleted)}, input.Webhook.Events)
+			s.Equal(s.testTime, input.Webhook.CreatedAt)
+			s.Len(input.Webhook.Secret, 64)
+			return nil
+		})
+
+	output, err := s.webhookService.RegisterWebhook(s.ctx, &RegisterWebhookInput{
+		GuildID:   s.testGuildID,
+		URL:       "https://example.com/hook",
+		Events:    []events.Type{events.TypeGameCompleted},
+		CreatedBy: "admin",
+	})
+	s.Require().NoError(err)
+	s.Equal("webhook-1", output.Webhook.ID)
+	s.NotEmpty(output.Webhook.Secret)
+}
+
+func (s *WebhookServiceTestSuite) TestRegisterWebhookInvalidURL() {
+	_, err := s.webhookService.RegisterWebhook(s.ctx, &RegisterWebhookInput{
+		GuildID: s.testGuildID,
+		URL:     "ftp://example.com",
+	})
+	s.ErrorIs(err, ErrInvalidURL)
+
+	// Hosts that don't resolve can't be checked
+	_, err = s.webhookService.RegisterWebhook(s.ctx, &RegisterWebhookInput{
+		GuildID: s.testGuildID,
+		URL:     "https://missing.example.com/hook",
+	})
+	s.ErrorIs(err, ErrInvalidURL)
+}
+
+func (s *WebhookServiceTestSuite) TestRegisterWebhookForbiddenAddress() {
+	for _, url := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://api.localhost/hook",
+		"http://[::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"http://10.0.0.5/hook",
+		"http://172.16.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[fd00:ec2::254]/latest/meta-data/",
+		"http://100.100.100.200/latest/meta-data/",
+		"http://metadata.google.internal/computeMetadata/v1/",
+		"http://METADATA.GOOGLE.INTERNAL./computeMetadata/v1/",
+		"https://internal.example.com/hook",
+	} {
+		_, err := s.webhookService.RegisterWebhook(s.ctx, &RegisterWebhookInput{
+			GuildID: s.testGuildID,
+			URL:     url,
+		})
+		s.ErrorIs(err, ErrForbiddenAddress, url)
+	}
+}
+
+func (s *WebhookServiceTestSuite) TestRegisterWebhookLimitReached() {
+	existing := make([]*models.Webhook, 5)
+	s.mockWebhookRepo.EXPECT().
+		GetWebhooksForGuild(s.ctx, gomock.Any()).
+		Return(&webhookRepo.GetWebhooksForGuildOutput{Webhooks: existing}, nil)
+
+	_, err := s.webhookService.RegisterWebhook(s.ctx, &RegisterWebhookInput{
+		GuildID: s.testGuildID,
+		URL:     "https://example.com/hook",
+	})
+	s.ErrorIs(err, ErrTooManyWebhooks)
+}
+
+func (s *WebhookServiceTestSuite) TestDispatch() {
+	var received []*Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		s.Require().NoError(err)
+
+		// Deliveries are signed with the webhook's secret
+		s.Equal(strconv.FormatInt(s.testTime.Unix(), 10), r.Header.Get(TimestampHeader))
+		s.Equal(Sign("secret", s.testTime, body), r.Header.Get(SignatureHeader))
+
+		var payload Payload
+		s.Require().NoError(json.Unmarshal(body, &payload))
+		received = append(received, &payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	s.mockWebhookRepo.EXPECT().
+		GetWebhooksForGuild(s.ctx, &webhookRepo.GetWebhooksForGuildInput{GuildID: s.testGuildID}).
+		Return(&webhookRepo.GetWebhooksForGuildOutput{Webhooks: []*models.Webhook{
+			{ID: "all", GuildID: s.testGuildID, URL: server.URL, Secret: "secret"},
+			{ID: "sessions-only", GuildID: s.testGuildID, URL: server.URL, Events: []string{string(events.TypeSessionLeaderboard)}},
+			{ID: "broken", GuildID: s.testGuildID, URL: failing.URL},
+		}}, nil)
+
+	output, err := s.webhookService.Dispatch(s.ctx, &DispatchInput{
+		GuildID: s.testGuildID,
+		Event: &events.Event{
+			Type:      events.TypeGameCompleted,
+			ChannelID: "channel-1",
+			GameID:    "game-1",
+			Timestamp: s.testTime,
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal(1, output.Delivered)
+	s.Equal(1, output.Failed)
+
+	s.Require().Len(received, 1)
+	s.Equal(events.TypeGameCompleted, received[0].Event)
+	s.Equal("game-1", received[0].GameID)
+	s.Equal(s.testGuildID, received[0].GuildID)
+}
+
+func (s *WebhookServiceTestSuite) TestDispatchRefusesPrivateAddresses() {
+	// A host that resolved to a public address at registration could resolve to the bot's own network later
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	svc, err := New(&Config{
+		WebhookRepo:   s.mockWebhookRepo,
+		Clock:         s.mockClock,
+		UUIDGenerator: s.mockUUID,
+	})
+	s.Require().NoError(err)
+
+	s.mockWebhookRepo.EXPECT().
+		GetWebhooksForGuild(s.ctx, gomock.Any()).
+		Return(&webhookRepo.GetWebhooksForGuildOutput{Webhooks: []*models.Webhook{
+			{ID: "rebound", GuildID: s.testGuildID, URL: server.URL},
+		}}, nil)
+
+	output, err := svc.Dispatch(s.ctx, &DispatchInput{
+		GuildID: s.testGuildID,
+		Event:   &events.Event{Type: events.TypeGameCompleted, Timestamp: s.testTime},
+	})
+	s.Require().NoError(err)
+	s.Equal(1, output.Failed)
+	s.False(hit)
+}
+
+func (s *WebhookServiceTestSuite) TestSign() {
+	// echo -n '1745064000.{}' | openssl dgst -sha256 -hmac secret
+	s.Equal("sha256=dbad59c982b35c70b171c8c69de819e1e7581cddf8dd877253bbd4a21162a1ed", Sign("secret", s.testTime, []byte("{}")))
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the delivery's timestamp and body, keyed with the webhook's secret
+	SignatureHeader = "X-Ronnied-Signature"
+
+	// TimestampHeader carries when the delivery was signed, in Unix seconds, so receivers can refuse old ones
+	TimestampHeader = "X-Ronnied-Timestamp"
+)
+
+// newSecret generates a webhook's signing secret
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns the signature header value for a delivery: "sha256=" followed by the hex HMAC-SHA256 of the
+// timestamp in Unix seconds, a dot and the body
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
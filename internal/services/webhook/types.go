@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/KirkDiggler/ronnied/internal/common/clock"
+	"github.com/KirkDiggler/ronnied/internal/common/uuid"
+	"github.com/KirkDiggler/ronnied/internal/events"
+	"github.com/KirkDiggler/ronnied/internal/models"
+	webhookRepo "github.com/KirkDiggler/ronnied/internal/repositories/webhook"
+)
+
+// Config holds configuration for the webhook service
+type Config struct {
+	// Maximum number of webhooks a single guild may register
+	MaxWebhooksPerGuild int
+
+	// Timeout for a single webhook delivery
+	DeliveryTimeout time.Duration
+
+	// Repository dependencies
+	WebhookRepo webhookRepo.Repository
+
+	// HTTPClient is used to deliver payloads (optional, defaults to a client with DeliveryTimeout that refuses to
+	// connect to private addresses). A client given here is used as is.
+	HTTPClient *http.Client
+
+	// Resolver looks up webhook hosts when they're registered (optional, defaults to the system resolver)
+	Resolver Resolver
+
+	// Service dependencies
+	Clock         clock.Clock
+	UUIDGenerator uuid.UUID
+}
+
+// RegisterWebhookInput defines the input for registering a webhook
+type RegisterWebhookInput struct {
+	GuildID   string
+	URL       string
+	Events    []events.Type
+	CreatedBy string
+}
+
+// RegisterWebhookOutput defines the output for registering a webhook
+type RegisterWebhookOutput struct {
+	Webhook *models.Webhook
+}
+
+// ListWebhooksInput defines the input for listing a guild's webhooks
+type ListWebhooksInput struct {
+	GuildID string
+}
+
+// ListWebhooksOutput defines the output for listing a guild's webhooks
+type ListWebhooksOutput struct {
+	Webhooks []*models.Webhook
+}
+
+// RemoveWebhookInput defines the input for removing a webhook
+type RemoveWebhookInput struct {
+	GuildID   string
+	WebhookID string
+}
+
+// RemoveWebhookOutput defines the output for removing a webhook
+type RemoveWebhookOutput struct {
+	Success bool
+}
+
+// DispatchInput defines the input for dispatching an event to a guild's webhooks
+type DispatchInput struct {
+	GuildID string
+	Event   *events.Event
+}
+
+// DispatchOutput defines the output for dispatching an event
+type DispatchOutput struct {
+	// Delivered is the number of webhooks that accepted the payload
+	Delivered int
+
+	// Failed is the number of webhooks that could not be reached or returned an error status
+	Failed int
+}
+
+// Payload is the JSON body POSTed to webhook URLs
+type Payload struct {
+	Event     events.Type `json:"event"`
+	GuildID   string      `json:"guild_id"`
+	ChannelID string      `json:"channel_id"`
+	GameID    string      `json:"game_id,omitempty"`
+	SessionID string      `json:"session_id,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
@@ -2,192 +2,345 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/KirkDiggler/ronnied/internal/bootstrap"
 	"github.com/KirkDiggler/ronnied/internal/common/clock"
 	"github.com/KirkDiggler/ronnied/internal/common/uuid"
 	"github.com/KirkDiggler/ronnied/internal/dice"
-	"github.com/KirkDiggler/ronnied/internal/handlers/discord"
+	"github.com/KirkDiggler/ronnied/internal/repositories/backup"
 	"github.com/KirkDiggler/ronnied/internal/repositories/drink_ledger"
-	"github.com/KirkDiggler/ronnied/internal/repositories/game"
+	gameRepo "github.com/KirkDiggler/ronnied/internal/repositories/game"
+	"github.com/KirkDiggler/ronnied/internal/repositories/migration"
 	"github.com/KirkDiggler/ronnied/internal/repositories/player"
-	gameService "github.com/KirkDiggler/ronnied/internal/services/game"
-	messagingService "github.com/KirkDiggler/ronnied/internal/services/messaging"
-	"github.com/joho/godotenv"
+	"github.com/KirkDiggler/ronnied/internal/services/game"
 	"github.com/redis/go-redis/v9"
 )
 
+// usage describes the commands the bot runs
+const usage = `Usage: ronnied [command] [flags]
+
+Commands:
+  serve           run the bot (the default)
+  check           check the configuration, Redis and the chat platform's setup
+  migrate         bring the data saved by older versions of the bot up to date
+  backup          write all of the bot's data in Redis to a file
+  restore         load the data in a backup file back into Redis
+  verify-ledger   check the drink ledger against its games, sessions and players
+
+Run "ronnied <command> -h" for the command's flags.
+`
+
 func main() {
-	fmt.Println("Starting Ronnied - Discord Dice Drinking Game Bot")
-	
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: No .env file found. Using environment variables.")
-	}
-	
-	// Get Discord token from environment
-	discordToken := getEnv("DISCORD_TOKEN", "")
-	if discordToken == "" {
-		log.Fatal("DISCORD_TOKEN environment variable is required")
-	}
-	
-	// Get application ID for the bot
-	applicationID := getEnv("APPLICATION_ID", "")
-	
-	// Get optional guild ID for development
-	guildID := getEnv("GUILD_ID", "")
-	
-	// Initialize Redis client
-	redisAddr := getEnv("REDIS_ADDR", "localhost:6379")
-	redisPassword := getEnv("REDIS_PASSWORD", "")
-	
-	fmt.Printf("Connecting to Redis at %s...\n", redisAddr)
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisAddr,
-		Password: redisPassword,
-		DB:       0,
-	})
+	check := flag.Bool("check", false, "same as the check command")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+	}
+	flag.Parse()
 
-	// Test Redis connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
-	}
-	fmt.Println("Connected to Redis successfully")
-	
-	// Initialize common dependencies
-	uuidGen := uuid.New()
-	clockSvc := clock.New()
-	
-	// Initialize repositories
-	fmt.Println("Initializing repositories...")
-	gameRepo, err := game.NewRedis(&game.Config{
-		RedisClient: redisClient,
-	})
-	if err != nil {
-		log.Fatalf("Failed to create game repository: %v", err)
+	command := "serve"
+	args := flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
 	}
-	
-	playerRepo, err := player.NewRedis(&player.Config{
-		RedisClient: redisClient,
-	})
-	if err != nil {
-		log.Fatalf("Failed to create player repository: %v", err)
+	if *check {
+		command = "check"
+	}
+
+	var err error
+	switch command {
+	case "serve":
+		err = serve()
+	case "check":
+		// Check the setup instead of running the bot
+		bootstrap.LoadEnv()
+		if !bootstrap.RunChecks() {
+			os.Exit(1)
+		}
+	case "migrate":
+		err = migrate(args)
+	case "backup":
+		err = backupData(args)
+	case "restore":
+		err = restoreData(args)
+	case "verify-ledger":
+		err = verifyLedger(args)
+	case "help":
+		flag.Usage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", command)
+		flag.Usage()
+		os.Exit(2)
 	}
-	
-	drinkLedgerRepo, err := drink_ledger.NewRedis(&drink_ledger.Config{
-		RedisClient: redisClient,
-	})
-	if err != nil {
-		log.Fatalf("Failed to create drink ledger repository: %v", err)
-	}
-	
-	// Initialize dice roller
-	diceRoller := dice.New(&dice.Config{})
-	
-	// Get game configuration from environment
-	maxPlayers := getEnvAsInt("MAX_PLAYERS", 10)
-	diceSides := getEnvAsInt("DICE_SIDES", 6)
-	criticalHitValue := getEnvAsInt("CRITICAL_HIT_VALUE", 6)
-	criticalFailValue := getEnvAsInt("CRITICAL_FAIL_VALUE", 1)
-	
-	// Initialize game service
-	fmt.Println("Initializing game service...")
-	gameSvc, err := gameService.New(&gameService.Config{
-		GameRepo:       gameRepo,
-		PlayerRepo:     playerRepo,
-		DrinkLedgerRepo: drinkLedgerRepo,
-		DiceRoller:     diceRoller,
-		UUIDGenerator:  uuidGen,
-		Clock:          clockSvc,
-		MaxPlayers:     maxPlayers,
-		DiceSides:      diceSides,
-		CriticalHitValue: criticalHitValue,
-		CriticalFailValue: criticalFailValue,
-	})
 	if err != nil {
-		log.Fatalf("Failed to create game service: %v", err)
+		log.Fatal(err)
 	}
-	
-	// Initialize messaging service
-	fmt.Println("Initializing messaging service...")
-	msgSvc, err := messagingService.NewService(&messagingService.ServiceConfig{
-		// We'll add repository configuration here later when we implement message storage
-	})
+}
+
+// serve runs the bot until it's interrupted
+func serve() error {
+	fmt.Println("Starting Ronnied - Dice Drinking Game Bot")
+
+	redisConn, err := connect()
 	if err != nil {
-		log.Fatalf("Failed to create messaging service: %v", err)
-	}
-	
-	// Initialize Discord bot
-	fmt.Println("Initializing Discord bot...")
-	bot, err := discord.New(&discord.Config{
-		Token:         discordToken,
-		ApplicationID: applicationID,
-		GuildID:       guildID,
-		GameService:   gameSvc,
-		MessagingService: msgSvc,
+		return err
+	}
+
+	app, err := bootstrap.New(&bootstrap.Config{
+		Redis: redisConn,
 	})
 	if err != nil {
-		log.Fatalf("Failed to create Discord bot: %v", err)
+		redisConn.Close()
+		return err
 	}
-	
-	// Start the bot
-	fmt.Println("Starting Discord bot...")
-	if err := bot.Start(); err != nil {
-		log.Fatalf("Failed to start Discord bot: %v", err)
+
+	if err := app.Start(); err != nil {
+		redisConn.Close()
+		return err
 	}
-	
+
 	// Keep the bot running until interrupted
 	fmt.Println("Bot is now running. Press CTRL-C to exit.")
 	sc := make(chan os.Signal, 1)
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	<-sc
-	
+
 	// Cleanup before exit
 	fmt.Println("Shutting down...")
-	
-	// Stop the Discord bot
-	if err := bot.Stop(); err != nil {
-		log.Printf("Error stopping bot: %v", err)
-	}
-	
+	app.Stop()
+
 	// Close Redis connection
-	if err := redisClient.Close(); err != nil {
-		log.Printf("Error closing Redis connection: %v", err)
+	if err := redisConn.Close(); err != nil {
+		log.Printf("Error closing Redis: %v", err)
 	}
-	
+
 	fmt.Println("Shutdown complete. Goodbye!")
+	return nil
+}
+
+// migrate applies the migrations the saved data is missing, or with -status lists them
+func migrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	status := flags.Bool("status", false, "list the migrations the saved data is missing without applying them")
+	flags.Parse(args)
+
+	redisConn, err := connect()
+	if err != nil {
+		return err
+	}
+	defer redisConn.Close()
+
+	migrationRepo, err := migration.NewRedis(&migration.Config{
+		RedisClient: redisConn.Client,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create migration repository: %w", err)
+	}
+
+	ctx := context.Background()
+	if *status {
+		output, err := migrationRepo.GetStatus(ctx, &migration.GetStatusInput{})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Saved data is at version %d\n", output.Version)
+		for _, m := range output.Pending {
+			fmt.Printf("Pending migration %d: %s\n", m.Version, m.Name)
+		}
+		return nil
+	}
+
+	output, err := migrationRepo.Migrate(ctx, &migration.MigrateInput{})
+	if output != nil {
+		for _, applied := range output.Applied {
+			fmt.Printf("Applied migration %d (%s), %d records changed\n", applied.Migration.Version, applied.Migration.Name, applied.Changed)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved data is at version %d\n", output.Version)
+	return nil
+}
+
+// backupData writes every key in Redis to the file named by -out
+func backupData(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	out := flags.String("out", "ronnied-"+time.Now().Format("20060102-150405")+".jsonl", "file to write the backup to")
+	flags.Parse(args)
+
+	redisConn, err := connect()
+	if err != nil {
+		return err
+	}
+	defer redisConn.Close()
+
+	backupRepo, err := backup.NewRedis(&backup.Config{
+		RedisClient: redisConn.Client,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create backup repository: %w", err)
+	}
+
+	// Backups hold player names and webhook secrets, so only the owner can read them
+	file, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	output, err := backupRepo.Backup(context.Background(), &backup.BackupInput{
+		Writer: file,
+	})
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write backup file: %w", closeErr)
+	}
+	if err != nil {
+		// Don't leave a partial backup that looks like a whole one
+		os.Remove(*out)
+		return err
+	}
+
+	fmt.Printf("Backed up %d keys to %s\n", output.Keys, *out)
+	return nil
 }
 
-// getEnv gets an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// restoreData loads the backup in the file named by -in into Redis
+func restoreData(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := flags.String("in", "", "backup file to restore (required)")
+	flags.Parse(args)
+
+	if *in == "" {
+		flags.Usage()
+		os.Exit(2)
 	}
-	return value
+
+	file, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	redisConn, err := connect()
+	if err != nil {
+		return err
+	}
+	defer redisConn.Close()
+
+	backupRepo, err := backup.NewRedis(&backup.Config{
+		RedisClient: redisConn.Client,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create backup repository: %w", err)
+	}
+
+	output, err := backupRepo.Restore(context.Background(), &backup.RestoreInput{
+		Reader: file,
+	})
+	if output != nil {
+		fmt.Printf("Restored %d keys from %s\n", output.Keys, *in)
+	}
+	return err
 }
 
-// getEnvAsInt gets an environment variable as an integer or returns a default value
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := getEnv(key, "")
-	if valueStr == "" {
-		return defaultValue
+// verifyLedger reports the drinks in the ledger that don't add up, and with -repair forgives the drinks still
+// owed that can no longer be paid
+func verifyLedger(args []string) error {
+	flags := flag.NewFlagSet("verify-ledger", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "forgive drinks still owed that can no longer be paid")
+	flags.Parse(args)
+
+	redisConn, err := connect()
+	if err != nil {
+		return err
 	}
-	
-	value, err := strconv.Atoi(valueStr)
+	defer redisConn.Close()
+
+	gameSvc, err := newLedgerGameService(redisConn.Client)
 	if err != nil {
-		log.Printf("Warning: Could not parse %s as integer, using default: %d", key, defaultValue)
-		return defaultValue
+		return fmt.Errorf("failed to create game service: %w", err)
 	}
-	
-	return value
+
+	output, err := gameSvc.VerifyLedger(context.Background(), &game.VerifyLedgerInput{
+		Repair: *repair,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify ledger: %w", err)
+	}
+
+	printLedgerReport(os.Stdout, output, *repair)
+	if len(output.Issues) > 0 && !*repair {
+		return fmt.Errorf("found %d ledger issues", len(output.Issues))
+	}
+	return nil
+}
+
+// newLedgerGameService wires the repositories and game service the ledger is checked with on top of Redis
+func newLedgerGameService(redisClient redis.UniversalClient) (game.Service, error) {
+	games, err := gameRepo.NewRedis(&gameRepo.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := player.NewRedis(&player.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := drink_ledger.NewRedis(&drink_ledger.Config{RedisClient: redisClient})
+	if err != nil {
+		return nil, err
+	}
+
+	return game.New(&game.Config{
+		GameRepo:        games,
+		PlayerRepo:      players,
+		DrinkLedgerRepo: ledger,
+		DiceRoller:      dice.New(&dice.Config{}),
+		UUIDGenerator:   uuid.New(),
+		Clock:           clock.New(),
+	})
+}
+
+// printLedgerReport lists the ledger issues found, one per line, followed by a summary
+func printLedgerReport(out io.Writer, output *game.VerifyLedgerOutput, repair bool) {
+	if len(output.Issues) > 0 {
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DRINK\tISSUE\tDETAIL\tREPAIRED")
+		for _, issue := range output.Issues {
+			repaired := "no"
+			if issue.Repaired {
+				repaired = "yes"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.DrinkID, issue.Type, issue.Detail, repaired)
+		}
+		w.Flush()
+		fmt.Fprintln(out)
+	}
+
+	fmt.Fprintf(out, "Checked %d drinks, found %d issues.\n", output.Checked, len(output.Issues))
+	if repair {
+		fmt.Fprintf(out, "Forgave %d drinks that could no longer be paid.\n", output.Repaired)
+	}
+}
+
+// connect loads the .env file, reads the secrets kept in files or secret managers and connects to Redis
+func connect() (*bootstrap.Redis, error) {
+	bootstrap.LoadEnv()
+
+	if _, err := bootstrap.LoadSecrets(); err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+
+	return bootstrap.ConnectRedis()
 }